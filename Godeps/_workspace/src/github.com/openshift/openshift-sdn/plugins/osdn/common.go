@@ -291,22 +291,25 @@ func (oc *OvsController) watchAndGetResource(resourceName string, watcher watchW
 }
 
 type FirewallRule struct {
-	table string
-	chain string
-	args  []string
+	Table string
+	Chain string
+	Args  []string
 }
 
-func SetupIptables(ipt iptables.Interface, clusterNetworkCIDR string) error {
-	rules := []FirewallRule{
+// IptablesRules returns the iptables rules the node sets up for clusterNetworkCIDR
+func IptablesRules(clusterNetworkCIDR string) []FirewallRule {
+	return []FirewallRule{
 		{"nat", "POSTROUTING", []string{"-s", clusterNetworkCIDR, "!", "-d", clusterNetworkCIDR, "-j", "MASQUERADE"}},
 		{"filter", "INPUT", []string{"-p", "udp", "-m", "multiport", "--dports", "4789", "-m", "comment", "--comment", "001 vxlan incoming", "-j", "ACCEPT"}},
 		{"filter", "INPUT", []string{"-i", "tun0", "-m", "comment", "--comment", "traffic from docker for internet", "-j", "ACCEPT"}},
 		{"filter", "FORWARD", []string{"-d", clusterNetworkCIDR, "-j", "ACCEPT"}},
 		{"filter", "FORWARD", []string{"-s", clusterNetworkCIDR, "-j", "ACCEPT"}},
 	}
+}
 
-	for _, rule := range rules {
-		_, err := ipt.EnsureRule(iptables.Prepend, iptables.Table(rule.table), iptables.Chain(rule.chain), rule.args...)
+func SetupIptables(ipt iptables.Interface, clusterNetworkCIDR string) error {
+	for _, rule := range IptablesRules(clusterNetworkCIDR) {
+		_, err := ipt.EnsureRule(iptables.Prepend, iptables.Table(rule.Table), iptables.Chain(rule.Chain), rule.Args...)
 		if err != nil {
 			return err
 		}
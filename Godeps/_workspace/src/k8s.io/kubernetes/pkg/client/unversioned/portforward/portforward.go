@@ -37,8 +37,9 @@ import (
 // PortForwarder knows how to listen for local connections and forward them to
 // a remote pod via an upgraded HTTP request.
 type PortForwarder struct {
-	ports    []ForwardedPort
-	stopChan <-chan struct{}
+	addresses []string
+	ports     []ForwardedPort
+	stopChan  <-chan struct{}
 
 	dialer        httpstream.Dialer
 	streamConn    httpstream.Connection
@@ -106,8 +107,18 @@ func parsePorts(ports []string) ([]ForwardedPort, error) {
 	return forwards, nil
 }
 
-// New creates a new PortForwarder.
+// New creates a new PortForwarder that listens on localhost.
 func New(dialer httpstream.Dialer, ports []string, stopChan <-chan struct{}) (*PortForwarder, error) {
+	return NewOnAddresses(dialer, []string{"localhost"}, ports, stopChan)
+}
+
+// NewOnAddresses creates a new PortForwarder that listens on each of the given
+// addresses. The address "localhost" listens on the IPv4 and IPv6 loopback
+// interfaces.
+func NewOnAddresses(dialer httpstream.Dialer, addresses []string, ports []string, stopChan <-chan struct{}) (*PortForwarder, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("You must specify at least 1 address")
+	}
 	if len(ports) == 0 {
 		return nil, errors.New("You must specify at least 1 port")
 	}
@@ -116,10 +127,11 @@ func New(dialer httpstream.Dialer, ports []string, stopChan <-chan struct{}) (*P
 		return nil, err
 	}
 	return &PortForwarder{
-		dialer:   dialer,
-		ports:    parsedPorts,
-		stopChan: stopChan,
-		Ready:    make(chan struct{}),
+		dialer:    dialer,
+		addresses: addresses,
+		ports:     parsedPorts,
+		stopChan:  stopChan,
+		Ready:     make(chan struct{}),
 	}, nil
 }
 
@@ -171,13 +183,25 @@ func (pf *PortForwarder) forward() error {
 	return nil
 }
 
-// listenOnPort delegates tcp4 and tcp6 listener creation and waits for connections on both of these addresses.
-// If both listener creation fail, an error is raised.
+// listenOnPort creates a listener on each address of the forwarder and waits for connections on them.
+// If the listeners of an address fail to create, an error is raised.
 func (pf *PortForwarder) listenOnPort(port *ForwardedPort) error {
-	errTcp4 := pf.listenOnPortAndAddress(port, "tcp4", "127.0.0.1")
-	errTcp6 := pf.listenOnPortAndAddress(port, "tcp6", "[::1]")
-	if errTcp4 != nil && errTcp6 != nil {
-		return fmt.Errorf("All listeners failed to create with the following errors: %s, %s", errTcp4, errTcp6)
+	for _, address := range pf.addresses {
+		if address != "localhost" {
+			hostname := address
+			if strings.Contains(address, ":") {
+				hostname = "[" + address + "]"
+			}
+			if err := pf.listenOnPortAndAddress(port, "tcp", hostname); err != nil {
+				return err
+			}
+			continue
+		}
+		errTcp4 := pf.listenOnPortAndAddress(port, "tcp4", "127.0.0.1")
+		errTcp6 := pf.listenOnPortAndAddress(port, "tcp6", "[::1]")
+		if errTcp4 != nil && errTcp6 != nil {
+			return fmt.Errorf("All listeners failed to create with the following errors: %s, %s", errTcp4, errTcp6)
+		}
 	}
 	return nil
 }
@@ -191,7 +191,7 @@ func (s *CustomColumnsPrinter) printOneObject(obj runtime.Object, parsers []*jso
 	switch u := obj.(type) {
 	case *runtime.Unknown:
 		var err error
-		if obj, err = api.Codec.Decode(u.RawJSON); err != nil {
+		if obj, err = decodeToSerializedVersion(u.RawJSON); err != nil {
 			return err
 		}
 	}
@@ -216,6 +216,16 @@ func (s *CustomColumnsPrinter) printOneObject(obj runtime.Object, parsers []*jso
 	return nil
 }
 
+// decodeToSerializedVersion decodes data into the versioned type it was serialized
+// as, whose fields match the names used by JSONPath expressions.
+func decodeToSerializedVersion(data []byte) (runtime.Object, error) {
+	version, _, err := api.Scheme.DataVersionAndKind(data)
+	if err != nil {
+		return nil, err
+	}
+	return api.Scheme.DecodeToVersion(data, version)
+}
+
 func (s *CustomColumnsPrinter) HandledResources() []string {
 	return []string{}
 }
@@ -354,7 +354,8 @@ func (r *RollingUpdater) scaleAndWaitWithScaler(rc *api.ReplicationController, r
 
 // pollForReadyPods polls oldRc and newRc each interval and returns the old
 // and new ready counts for their pods. If a pod is observed as being ready,
-// it's considered ready even if it later becomes notReady.
+// it's considered ready even if it later becomes notReady. Pods being deleted,
+// like the pods of a node being drained, are not counted as ready.
 func (r *RollingUpdater) pollForReadyPods(interval, timeout time.Duration, oldRc, newRc *api.ReplicationController) (int, int, error) {
 	controllers := []*api.ReplicationController{oldRc, newRc}
 	oldReady := 0
@@ -368,7 +369,7 @@ func (r *RollingUpdater) pollForReadyPods(interval, timeout time.Duration, oldRc
 				return false, err
 			}
 			for _, pod := range pods.Items {
-				if api.IsPodReady(&pod) {
+				if pod.DeletionTimestamp == nil && api.IsPodReady(&pod) {
 					switch controller.Name {
 					case oldRc.Name:
 						oldReady++
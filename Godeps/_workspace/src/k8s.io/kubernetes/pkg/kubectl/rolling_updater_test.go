@@ -29,6 +29,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/testapi"
 	apitesting "k8s.io/kubernetes/pkg/api/testing"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/client/unversioned/fake"
 	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
@@ -1191,6 +1192,7 @@ func TestAddDeploymentHash(t *testing.T) {
 }
 
 func TestRollingUpdater_pollForReadyPods(t *testing.T) {
+	deleted := unversioned.Now()
 	mkpod := func(owner *api.ReplicationController, ready bool) *api.Pod {
 		labels := map[string]string{}
 		for k, v := range owner.Spec.Selector {
@@ -1225,6 +1227,8 @@ func TestRollingUpdater_pollForReadyPods(t *testing.T) {
 		// pods owned by the rcs; indicate whether they're ready
 		oldPods []bool
 		newPods []bool
+		// ready old pods which are being deleted
+		deletedOldPods int
 	}{
 		{
 			oldRc:    oldRc(4, 4),
@@ -1268,6 +1272,19 @@ func TestRollingUpdater_pollForReadyPods(t *testing.T) {
 				false,
 			},
 		},
+		{
+			oldRc:    oldRc(4, 4),
+			newRc:    newRc(4, 4),
+			oldReady: 1,
+			newReady: 1,
+			oldPods: []bool{
+				true,
+			},
+			newPods: []bool{
+				true,
+			},
+			deletedOldPods: 2,
+		},
 	}
 
 	for i, test := range tests {
@@ -1280,6 +1297,11 @@ func TestRollingUpdater_pollForReadyPods(t *testing.T) {
 		for _, ready := range test.newPods {
 			pods = append(pods, mkpod(test.newRc, ready))
 		}
+		for i := 0; i < test.deletedOldPods; i++ {
+			pod := mkpod(test.oldRc, true)
+			pod.DeletionTimestamp = &deleted
+			pods = append(pods, pod)
+		}
 		client := testclient.NewSimpleFake(pods...)
 
 		updater := &RollingUpdater{
@@ -23,6 +23,8 @@ import (
 	"sort"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/jsonpath"
 
@@ -68,9 +70,17 @@ func (s *SortingPrinter) sortObj(obj runtime.Object) error {
 		switch u := item.(type) {
 		case *runtime.Unknown:
 			var err error
-			if objs[ix], err = api.Codec.Decode(u.RawJSON); err != nil {
+			if objs[ix], err = decodeToSerializedVersion(u.RawJSON); err != nil {
 				return err
 			}
+		default:
+			// internal objects are compared in their versioned form, whose fields
+			// match the names used by JSONPath expressions
+			if version, _, err := api.Scheme.ObjectVersionAndKind(item); err == nil && len(version) == 0 {
+				if versioned, err := api.Scheme.ConvertToVersion(item, latest.GroupOrDie("").Version); err == nil {
+					objs[ix] = versioned
+				}
+			}
 		}
 	}
 	values, err := parser.FindResults(reflect.ValueOf(objs[0]).Elem().Interface())
@@ -81,19 +91,36 @@ func (s *SortingPrinter) sortObj(obj runtime.Object) error {
 		return fmt.Errorf("couldn't find any field with path: %s", s.SortField)
 	}
 	sorter := &RuntimeSort{
-		field: s.SortField,
-		objs:  objs,
+		field:        s.SortField,
+		objs:         objs,
+		origPosition: make([]int, len(objs)),
+	}
+	for ix := range sorter.origPosition {
+		sorter.origPosition[ix] = ix
 	}
 	sort.Sort(sorter)
-	runtime.SetList(obj, sorter.objs)
+
+	// reorder the original items, which may be raw encoded objects that cannot
+	// be replaced by the decoded ones
+	itemsPtr, err := runtime.GetItemsPtr(obj)
+	if err != nil {
+		return err
+	}
+	items := reflect.ValueOf(itemsPtr).Elem()
+	sorted := reflect.MakeSlice(items.Type(), items.Len(), items.Len())
+	for ix, origIx := range sorter.origPosition {
+		sorted.Index(ix).Set(items.Index(origIx))
+	}
+	items.Set(sorted)
 	return nil
 }
 
 // RuntimeSort is an implementation of the golang sort interface that knows how to sort
 // lists of runtime.Object
 type RuntimeSort struct {
-	field string
-	objs  []runtime.Object
+	field        string
+	objs         []runtime.Object
+	origPosition []int
 }
 
 func (r *RuntimeSort) Len() int {
@@ -102,8 +129,11 @@ func (r *RuntimeSort) Len() int {
 
 func (r *RuntimeSort) Swap(i, j int) {
 	r.objs[i], r.objs[j] = r.objs[j], r.objs[i]
+	r.origPosition[i], r.origPosition[j] = r.origPosition[j], r.origPosition[i]
 }
 
+var timeType = reflect.TypeOf(unversioned.Time{})
+
 func isLess(i, j reflect.Value) (bool, error) {
 	switch i.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -115,7 +145,16 @@ func isLess(i, j reflect.Value) (bool, error) {
 	case reflect.String:
 		return i.String() < j.String(), nil
 	case reflect.Ptr:
+		// unset fields sort first
+		if i.IsNil() || j.IsNil() {
+			return i.IsNil() && !j.IsNil(), nil
+		}
 		return isLess(i.Elem(), j.Elem())
+	case reflect.Struct:
+		if i.Type() == timeType {
+			return i.Interface().(unversioned.Time).Before(j.Interface().(unversioned.Time)), nil
+		}
+		return false, fmt.Errorf("unsortable type: %v", i.Type())
 	default:
 		return false, fmt.Errorf("unsortable type: %v", i.Kind())
 	}
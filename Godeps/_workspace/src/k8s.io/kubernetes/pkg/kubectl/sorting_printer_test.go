@@ -19,13 +19,17 @@ package kubectl
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	api "k8s.io/kubernetes/pkg/api/v1"
 	"k8s.io/kubernetes/pkg/runtime"
 )
 
 func TestSortingPrinter(t *testing.T) {
 	intPtr := func(val int) *int { return &val }
+	now := unversioned.Now()
+	earlier := unversioned.NewTime(now.Add(-time.Hour))
 
 	tests := []struct {
 		obj   runtime.Object
@@ -159,6 +163,54 @@ func TestSortingPrinter(t *testing.T) {
 			},
 			field: "{.spec.replicas}",
 		},
+		{
+			name: "timestamps",
+			obj: &api.PodList{
+				Items: []api.Pod{
+					{
+						ObjectMeta: api.ObjectMeta{Name: "b", CreationTimestamp: now},
+					},
+					{
+						ObjectMeta: api.ObjectMeta{Name: "a", CreationTimestamp: earlier},
+					},
+				},
+			},
+			sort: &api.PodList{
+				Items: []api.Pod{
+					{
+						ObjectMeta: api.ObjectMeta{Name: "a", CreationTimestamp: earlier},
+					},
+					{
+						ObjectMeta: api.ObjectMeta{Name: "b", CreationTimestamp: now},
+					},
+				},
+			},
+			field: "{.metadata.creationTimestamp}",
+		},
+		{
+			name: "unset-pointers-first",
+			obj: &api.ReplicationControllerList{
+				Items: []api.ReplicationController{
+					{
+						Spec: api.ReplicationControllerSpec{
+							Replicas: intPtr(5),
+						},
+					},
+					{},
+				},
+			},
+			sort: &api.ReplicationControllerList{
+				Items: []api.ReplicationController{
+					{},
+					{
+						Spec: api.ReplicationControllerSpec{
+							Replicas: intPtr(5),
+						},
+					},
+				},
+			},
+			field: "{.spec.replicas}",
+		},
 	}
 	for _, test := range tests {
 		sort := &SortingPrinter{SortField: test.field}
@@ -12,6 +12,7 @@ import (
 
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
+	prune := flag.String("prune", "", "Remove the content of the registry storage that no image references instead of serving the registry: 'check' lists what would be removed, 'delete' removes it. The registry must not accept pushes meanwhile.")
 	flag.Parse()
 
 	// TODO convert to flags instead of a config file?
@@ -35,5 +36,15 @@ func main() {
 		log.Fatalf("Unable to open configuration file: %s", err)
 	}
 
-	dockerregistry.Execute(configFile)
+	switch *prune {
+	case "":
+		dockerregistry.Execute(configFile)
+	case "check":
+		dockerregistry.ExecutePruner(configFile, true)
+	case "delete":
+		dockerregistry.ExecutePruner(configFile, false)
+	default:
+		fmt.Printf("invalid value %q for -prune, must be check or delete\n", *prune)
+		os.Exit(1)
+	}
 }
@@ -124,6 +124,19 @@ os::build::host_platform() {
   echo "$(go env GOHOSTOS)/$(go env GOHOSTARCH)"
 }
 
+# os::build::gotags returns the build tags for the platform ($1), from OS_GOFLAGS_TAGS.
+# The gssapi tag, which lets the client authenticate with Kerberos, is added for builds
+# that target the host platform on Linux, unless OS_BUILD_GSSAPI is "false". It links
+# against the GSSAPI library with cgo, so those builds need its headers (the krb5-devel
+# package on Fedora and RHEL, libkrb5-dev on Debian and Ubuntu).
+os::build::gotags() {
+  local -a tags=(${OS_GOFLAGS_TAGS:-})
+  if [[ "${1}" == "$(os::build::host_platform)" && "${1%/*}" == "linux" && "${CGO_ENABLED:-1}" != "0" && "${OS_BUILD_GSSAPI:-}" != "false" ]]; then
+    tags+=("gssapi")
+  fi
+  echo "${tags[*]-}"
+}
+
 # Build binaries targets specified
 #
 # Input:
@@ -160,6 +173,7 @@ os::build::build_binaries() {
       os::build::set_platform_envs "${platform}"
       echo "++ Building go targets for ${platform}:" "${targets[@]}"
       go install "${goflags[@]:+${goflags[@]}}" \
+          -tags "$(os::build::gotags "${platform}")" \
           -ldflags "${version_ldflags}" \
           "${binaries[@]}"
       os::build::unset_platform_envs "${platform}"
@@ -0,0 +1,17 @@
+#!/bin/bash
+
+# This script embeds the default image streams and templates installed by the dev profile of
+# "openshift start" into pkg/bootstrap.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+OS_ROOT=$(dirname "${BASH_SOURCE}")/..
+source "${OS_ROOT}/hack/common.sh"
+
+pushd "${OS_ROOT}" > /dev/null
+  Godeps/_workspace/bin/go-bindata -nocompress -nometadata -pkg "bootstrap" -o "pkg/bootstrap/bindata.go" -ignore "\\.md$" \
+    examples/image-streams/image-streams-centos7.json \
+    examples/db-templates/...
+popd > /dev/null
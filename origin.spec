@@ -55,6 +55,7 @@ ExclusiveArch:  x86_64
 Source0:        https://%{import_path}/archive/%{commit}/%{name}-%{version}.tar.gz
 BuildRequires:  systemd
 BuildRequires:  golang >= 1.4
+BuildRequires:  krb5-devel
 Requires:       %{name}-clients = %{version}-%{release}
 Requires:       iptables
 Obsoletes:      openshift < %{package_refector_version}
@@ -192,7 +193,7 @@ export GOPATH=$(pwd)/_build:$(pwd)/_thirdpartyhacks:%{buildroot}%{gopath}:%{gopa
 # Build all linux components we care about
 for cmd in oc openshift dockerregistry recycle
 do
-        go install -ldflags "%{ldflags}" %{import_path}/cmd/${cmd}
+        go install -tags gssapi -ldflags "%{ldflags}" %{import_path}/cmd/${cmd}
 done
 
 %if 0%{?make_redistributable}
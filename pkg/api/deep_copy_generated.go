@@ -4,6 +4,7 @@ package api
 import (
 	api "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	certificatesapi "github.com/openshift/origin/pkg/certificates/api"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
@@ -30,6 +31,8 @@ func deepCopy_api_AuthorizationAttributes(in api.AuthorizationAttributes, out *a
 	} else {
 		out.Content = newVal.(runtime.EmbeddedObject)
 	}
+	out.IsNonResourceURL = in.IsNonResourceURL
+	out.Path = in.Path
 	return nil
 }
 
@@ -874,6 +877,16 @@ func deepCopy_api_BuildOutput(in buildapi.BuildOutput, out *buildapi.BuildOutput
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]buildapi.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := deepCopy_api_ImageLabel(in.ImageLabels[i], &out.ImageLabels[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -940,6 +953,15 @@ func deepCopy_api_BuildRequest(in buildapi.BuildRequest, out *buildapi.BuildRequ
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if newVal, err := c.DeepCopy(in.ScheduleTime); err != nil {
+			return err
+		} else {
+			out.ScheduleTime = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -1064,6 +1086,14 @@ func deepCopy_api_BuildStatus(in buildapi.BuildStatus, out *buildapi.BuildStatus
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapi.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = in.Finalizers[i]
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -1121,6 +1151,14 @@ func deepCopy_api_BuildTriggerPolicy(in buildapi.BuildTriggerPolicy, out *builda
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(buildapi.ScheduleTrigger)
+		if err := deepCopy_api_ScheduleTrigger(*in.Schedule, out.Schedule, c); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -1200,6 +1238,7 @@ func deepCopy_api_DockerBuildStrategy(in buildapi.DockerBuildStrategy, out *buil
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -1237,6 +1276,12 @@ func deepCopy_api_ImageChangeTrigger(in buildapi.ImageChangeTrigger, out *builda
 	return nil
 }
 
+func deepCopy_api_ImageLabel(in buildapi.ImageLabel, out *buildapi.ImageLabel, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
 func deepCopy_api_ImageSource(in buildapi.ImageSource, out *buildapi.ImageSource, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.From); err != nil {
 		return err
@@ -1271,6 +1316,22 @@ func deepCopy_api_ImageSourcePath(in buildapi.ImageSourcePath, out *buildapi.Ima
 	return nil
 }
 
+func deepCopy_api_ScheduleTrigger(in buildapi.ScheduleTrigger, out *buildapi.ScheduleTrigger, c *conversion.Cloner) error {
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = in.ConcurrencyPolicy
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if newVal, err := c.DeepCopy(in.LastScheduleTime); err != nil {
+			return err
+		} else {
+			out.LastScheduleTime = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
 func deepCopy_api_SecretBuildSource(in buildapi.SecretBuildSource, out *buildapi.SecretBuildSource, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.Secret); err != nil {
 		return err
@@ -1344,6 +1405,114 @@ func deepCopy_api_SourceRevision(in buildapi.SourceRevision, out *buildapi.Sourc
 
 func deepCopy_api_WebHookTrigger(in buildapi.WebHookTrigger, out *buildapi.WebHookTrigger, c *conversion.Cloner) error {
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
+	return nil
+}
+
+func deepCopy_api_CertificateSigningRequest(in certificatesapi.CertificateSigningRequest, out *certificatesapi.CertificateSigningRequest, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapi.ObjectMeta)
+	}
+	if err := deepCopy_api_CertificateSigningRequestSpec(in.Spec, &out.Spec, c); err != nil {
+		return err
+	}
+	if err := deepCopy_api_CertificateSigningRequestStatus(in.Status, &out.Status, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func deepCopy_api_CertificateSigningRequestCondition(in certificatesapi.CertificateSigningRequestCondition, out *certificatesapi.CertificateSigningRequestCondition, c *conversion.Cloner) error {
+	out.Type = in.Type
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if newVal, err := c.DeepCopy(in.LastUpdateTime); err != nil {
+		return err
+	} else {
+		out.LastUpdateTime = newVal.(unversioned.Time)
+	}
+	return nil
+}
+
+func deepCopy_api_CertificateSigningRequestList(in certificatesapi.CertificateSigningRequestList, out *certificatesapi.CertificateSigningRequestList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapi.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_api_CertificateSigningRequest(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_api_CertificateSigningRequestSpec(in certificatesapi.CertificateSigningRequestSpec, out *certificatesapi.CertificateSigningRequestSpec, c *conversion.Cloner) error {
+	if in.Request != nil {
+		out.Request = make([]uint8, len(in.Request))
+		for i := range in.Request {
+			out.Request[i] = in.Request[i]
+		}
+	} else {
+		out.Request = nil
+	}
+	out.Usage = in.Usage
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func deepCopy_api_CertificateSigningRequestStatus(in certificatesapi.CertificateSigningRequestStatus, out *certificatesapi.CertificateSigningRequestStatus, c *conversion.Cloner) error {
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapi.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := deepCopy_api_CertificateSigningRequestCondition(in.Conditions[i], &out.Conditions[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Certificate != nil {
+		out.Certificate = make([]uint8, len(in.Certificate))
+		for i := range in.Certificate {
+			out.Certificate[i] = in.Certificate[i]
+		}
+	} else {
+		out.Certificate = nil
+	}
 	return nil
 }
 
@@ -1369,6 +1538,7 @@ func deepCopy_api_CustomDeploymentStrategyParams(in deployapi.CustomDeploymentSt
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -2099,6 +2269,14 @@ func deepCopy_api_ImageStreamTag(in imageapi.ImageStreamTag, out *imageapi.Image
 	} else {
 		out.ObjectMeta = newVal.(pkgapi.ObjectMeta)
 	}
+	if in.Tag != nil {
+		out.Tag = new(imageapi.TagReference)
+		if err := deepCopy_api_TagReference(*in.Tag, out.Tag, c); err != nil {
+			return err
+		}
+	} else {
+		out.Tag = nil
+	}
 	if err := deepCopy_api_Image(in.Image, &out.Image, c); err != nil {
 		return err
 	}
@@ -2302,6 +2480,8 @@ func deepCopy_api_OAuthClient(in oauthapi.OAuthClient, out *oauthapi.OAuthClient
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = in.GrantMethod
 	return nil
 }
 
@@ -2979,14 +3159,21 @@ func init() {
 		deepCopy_api_GitBuildSource,
 		deepCopy_api_GitSourceRevision,
 		deepCopy_api_ImageChangeTrigger,
+		deepCopy_api_ImageLabel,
 		deepCopy_api_ImageSource,
 		deepCopy_api_ImageSourcePath,
+		deepCopy_api_ScheduleTrigger,
 		deepCopy_api_SecretBuildSource,
 		deepCopy_api_SecretSpec,
 		deepCopy_api_SourceBuildStrategy,
 		deepCopy_api_SourceControlUser,
 		deepCopy_api_SourceRevision,
 		deepCopy_api_WebHookTrigger,
+		deepCopy_api_CertificateSigningRequest,
+		deepCopy_api_CertificateSigningRequestCondition,
+		deepCopy_api_CertificateSigningRequestList,
+		deepCopy_api_CertificateSigningRequestSpec,
+		deepCopy_api_CertificateSigningRequestStatus,
 		deepCopy_api_CustomDeploymentStrategyParams,
 		deepCopy_api_DeploymentCause,
 		deepCopy_api_DeploymentCauseImageTrigger,
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -21,3 +22,27 @@ func TestMultipleEdgeKindsBetweenTheSameNodes(t *testing.T) {
 		t.Errorf("expected second, got %v", edge)
 	}
 }
+
+func TestNewJSONGraph(t *testing.T) {
+	g := New()
+
+	fooNode := makeTestNode(g, "foo")
+	barNode := makeTestNode(g, "bar")
+	g.AddEdge(fooNode, barNode, "second")
+	g.AddEdge(fooNode, barNode, "first")
+
+	out := NewJSONGraph(g, "test")
+	if out.Name != "test" {
+		t.Errorf("expected name test, got %q", out.Name)
+	}
+	if len(out.Nodes) != 2 || out.Nodes[0].ID != fooNode.ID() || out.Nodes[1].ID != barNode.ID() {
+		t.Fatalf("unexpected nodes: %#v", out.Nodes)
+	}
+	if out.Nodes[0].Kind != UnknownNodeKind {
+		t.Errorf("expected kind %s, got %s", UnknownNodeKind, out.Nodes[0].Kind)
+	}
+	expected := []JSONEdge{{From: fooNode.ID(), To: barNode.ID(), Kinds: []string{"first", "second"}}}
+	if !reflect.DeepEqual(out.Edges, expected) {
+		t.Errorf("expected edges %#v, got %#v", expected, out.Edges)
+	}
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// JSONGraph is a serializable representation of a graph, suitable for consumption by
+// documentation and visualization tooling.
+type JSONGraph struct {
+	Name  string     `json:"name,omitempty"`
+	Nodes []JSONNode `json:"nodes"`
+	Edges []JSONEdge `json:"edges"`
+}
+
+// JSONNode describes a single node of a JSONGraph.
+type JSONNode struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// JSONEdge describes a directed edge between two nodes of a JSONGraph.
+type JSONEdge struct {
+	From  int      `json:"from"`
+	To    int      `json:"to"`
+	Kinds []string `json:"kinds"`
+}
+
+// NewJSONGraph converts g into a JSONGraph. Nodes and edges are sorted by ID so the
+// output is stable.
+func NewJSONGraph(g Graph, name string) JSONGraph {
+	out := JSONGraph{Name: name, Nodes: []JSONNode{}, Edges: []JSONEdge{}}
+
+	nodes := g.Nodes()
+	sort.Sort(ByID(nodes))
+	for _, node := range nodes {
+		out.Nodes = append(out.Nodes, JSONNode{ID: node.ID(), Kind: g.Kind(node), Name: g.Name(node)})
+
+		successors := g.From(node)
+		sort.Sort(ByID(successors))
+		for _, successor := range successors {
+			edge := g.Edge(node, successor)
+			out.Edges = append(out.Edges, JSONEdge{From: node.ID(), To: successor.ID(), Kinds: g.EdgeKinds(edge).List()})
+		}
+	}
+
+	return out
+}
+
+// MarshalJSON returns the indented JSON encoding of g.
+func MarshalJSON(g Graph, name, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(NewJSONGraph(g, name), prefix, indent)
+}
@@ -140,6 +140,8 @@ func init() {
 		"ClusterNetwork": true,
 		"HostSubnet":     true,
 		"NetNamespace":   true,
+
+		"CertificateSigningRequest": true,
 	}
 
 	// enumerate all supported versions, get the kinds, and register with the mapper how to address our resources
@@ -6,6 +6,7 @@ import (
 
 	_ "github.com/openshift/origin/pkg/authorization/api"
 	_ "github.com/openshift/origin/pkg/build/api"
+	_ "github.com/openshift/origin/pkg/certificates/api"
 	_ "github.com/openshift/origin/pkg/deploy/api"
 	_ "github.com/openshift/origin/pkg/image/api"
 	_ "github.com/openshift/origin/pkg/oauth/api"
@@ -202,6 +202,11 @@ func fuzzInternalObject(t *testing.T, forVersion string, item runtime.Object, se
 			j.From.ResourceVersion = ""
 			j.From.FieldPath = ""
 		},
+		func(j *build.ScheduleTrigger, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			policies := []build.ScheduleConcurrencyPolicy{build.ScheduleConcurrencyForbid, build.ScheduleConcurrencyReplace}
+			j.ConcurrencyPolicy = policies[c.Intn(len(policies))]
+		},
 		func(j *build.BuildOutput, c fuzz.Continue) {
 			c.FuzzNoCustom(j)
 			if j.To != nil && (len(j.To.Kind) == 0 || j.To.Kind == "ImageStream") {
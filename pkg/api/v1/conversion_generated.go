@@ -6,6 +6,8 @@ import (
 	v1 "github.com/openshift/origin/pkg/authorization/api/v1"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	apiv1 "github.com/openshift/origin/pkg/build/api/v1"
+	certificatesapi "github.com/openshift/origin/pkg/certificates/api"
+	certificatesapiv1 "github.com/openshift/origin/pkg/certificates/api/v1"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployapiv1 "github.com/openshift/origin/pkg/deploy/api/v1"
 	imageapi "github.com/openshift/origin/pkg/image/api"
@@ -1325,6 +1327,16 @@ func autoconvert_api_BuildOutput_To_v1_BuildOutput(in *buildapi.BuildOutput, out
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]apiv1.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := convert_api_ImageLabel_To_v1_ImageLabel(&in.ImageLabels[i], &out.ImageLabels[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -1385,6 +1397,13 @@ func autoconvert_api_BuildRequest_To_v1_BuildRequest(in *buildapi.BuildRequest,
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if err := s.Convert(&in.ScheduleTime, &out.ScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -1517,6 +1536,14 @@ func autoconvert_api_BuildStatus_To_v1_BuildStatus(in *buildapi.BuildStatus, out
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapiv1.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = pkgapiv1.FinalizerName(in.Finalizers[i])
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -1581,6 +1608,14 @@ func autoconvert_api_BuildTriggerPolicy_To_v1_BuildTriggerPolicy(in *buildapi.Bu
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(apiv1.ScheduleTrigger)
+		if err := convert_api_ScheduleTrigger_To_v1_ScheduleTrigger(in.Schedule, out.Schedule, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -1657,6 +1692,7 @@ func autoconvert_api_DockerBuildStrategy_To_v1_DockerBuildStrategy(in *buildapi.
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -1714,6 +1750,19 @@ func convert_api_ImageChangeTrigger_To_v1_ImageChangeTrigger(in *buildapi.ImageC
 	return autoconvert_api_ImageChangeTrigger_To_v1_ImageChangeTrigger(in, out, s)
 }
 
+func autoconvert_api_ImageLabel_To_v1_ImageLabel(in *buildapi.ImageLabel, out *apiv1.ImageLabel, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*buildapi.ImageLabel))(in)
+	}
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+func convert_api_ImageLabel_To_v1_ImageLabel(in *buildapi.ImageLabel, out *apiv1.ImageLabel, s conversion.Scope) error {
+	return autoconvert_api_ImageLabel_To_v1_ImageLabel(in, out, s)
+}
+
 func autoconvert_api_ImageSource_To_v1_ImageSource(in *buildapi.ImageSource, out *apiv1.ImageSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*buildapi.ImageSource))(in)
@@ -1759,6 +1808,27 @@ func convert_api_ImageSourcePath_To_v1_ImageSourcePath(in *buildapi.ImageSourceP
 	return autoconvert_api_ImageSourcePath_To_v1_ImageSourcePath(in, out, s)
 }
 
+func autoconvert_api_ScheduleTrigger_To_v1_ScheduleTrigger(in *buildapi.ScheduleTrigger, out *apiv1.ScheduleTrigger, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*buildapi.ScheduleTrigger))(in)
+	}
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = apiv1.ScheduleConcurrencyPolicy(in.ConcurrencyPolicy)
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if err := s.Convert(&in.LastScheduleTime, &out.LastScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
+func convert_api_ScheduleTrigger_To_v1_ScheduleTrigger(in *buildapi.ScheduleTrigger, out *apiv1.ScheduleTrigger, s conversion.Scope) error {
+	return autoconvert_api_ScheduleTrigger_To_v1_ScheduleTrigger(in, out, s)
+}
+
 func autoconvert_api_SecretBuildSource_To_v1_SecretBuildSource(in *buildapi.SecretBuildSource, out *apiv1.SecretBuildSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*buildapi.SecretBuildSource))(in)
@@ -1853,6 +1923,14 @@ func autoconvert_api_WebHookTrigger_To_v1_WebHookTrigger(in *buildapi.WebHookTri
 		defaulting.(func(*buildapi.WebHookTrigger))(in)
 	}
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
 	return nil
 }
 
@@ -2116,6 +2194,16 @@ func autoconvert_v1_BuildOutput_To_api_BuildOutput(in *apiv1.BuildOutput, out *b
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]buildapi.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := convert_v1_ImageLabel_To_api_ImageLabel(&in.ImageLabels[i], &out.ImageLabels[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -2176,6 +2264,13 @@ func autoconvert_v1_BuildRequest_To_api_BuildRequest(in *apiv1.BuildRequest, out
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if err := s.Convert(&in.ScheduleTime, &out.ScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -2309,6 +2404,14 @@ func autoconvert_v1_BuildStatus_To_api_BuildStatus(in *apiv1.BuildStatus, out *b
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapi.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = pkgapi.FinalizerName(in.Finalizers[i])
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -2374,6 +2477,14 @@ func autoconvert_v1_BuildTriggerPolicy_To_api_BuildTriggerPolicy(in *apiv1.Build
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(buildapi.ScheduleTrigger)
+		if err := convert_v1_ScheduleTrigger_To_api_ScheduleTrigger(in.Schedule, out.Schedule, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -2450,6 +2561,7 @@ func autoconvert_v1_DockerBuildStrategy_To_api_DockerBuildStrategy(in *apiv1.Doc
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -2507,6 +2619,19 @@ func convert_v1_ImageChangeTrigger_To_api_ImageChangeTrigger(in *apiv1.ImageChan
 	return autoconvert_v1_ImageChangeTrigger_To_api_ImageChangeTrigger(in, out, s)
 }
 
+func autoconvert_v1_ImageLabel_To_api_ImageLabel(in *apiv1.ImageLabel, out *buildapi.ImageLabel, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*apiv1.ImageLabel))(in)
+	}
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+func convert_v1_ImageLabel_To_api_ImageLabel(in *apiv1.ImageLabel, out *buildapi.ImageLabel, s conversion.Scope) error {
+	return autoconvert_v1_ImageLabel_To_api_ImageLabel(in, out, s)
+}
+
 func autoconvert_v1_ImageSource_To_api_ImageSource(in *apiv1.ImageSource, out *buildapi.ImageSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*apiv1.ImageSource))(in)
@@ -2552,6 +2677,27 @@ func convert_v1_ImageSourcePath_To_api_ImageSourcePath(in *apiv1.ImageSourcePath
 	return autoconvert_v1_ImageSourcePath_To_api_ImageSourcePath(in, out, s)
 }
 
+func autoconvert_v1_ScheduleTrigger_To_api_ScheduleTrigger(in *apiv1.ScheduleTrigger, out *buildapi.ScheduleTrigger, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*apiv1.ScheduleTrigger))(in)
+	}
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = buildapi.ScheduleConcurrencyPolicy(in.ConcurrencyPolicy)
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if err := s.Convert(&in.LastScheduleTime, &out.LastScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
+func convert_v1_ScheduleTrigger_To_api_ScheduleTrigger(in *apiv1.ScheduleTrigger, out *buildapi.ScheduleTrigger, s conversion.Scope) error {
+	return autoconvert_v1_ScheduleTrigger_To_api_ScheduleTrigger(in, out, s)
+}
+
 func autoconvert_v1_SecretBuildSource_To_api_SecretBuildSource(in *apiv1.SecretBuildSource, out *buildapi.SecretBuildSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*apiv1.SecretBuildSource))(in)
@@ -2647,6 +2793,14 @@ func autoconvert_v1_WebHookTrigger_To_api_WebHookTrigger(in *apiv1.WebHookTrigge
 		defaulting.(func(*apiv1.WebHookTrigger))(in)
 	}
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
 	return nil
 }
 
@@ -2654,6 +2808,236 @@ func convert_v1_WebHookTrigger_To_api_WebHookTrigger(in *apiv1.WebHookTrigger, o
 	return autoconvert_v1_WebHookTrigger_To_api_WebHookTrigger(in, out, s)
 }
 
+func autoconvert_api_CertificateSigningRequest_To_v1_CertificateSigningRequest(in *certificatesapi.CertificateSigningRequest, out *certificatesapiv1.CertificateSigningRequest, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequest))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := convert_api_CertificateSigningRequestSpec_To_v1_CertificateSigningRequestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := convert_api_CertificateSigningRequestStatus_To_v1_CertificateSigningRequestStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequest_To_v1_CertificateSigningRequest(in *certificatesapi.CertificateSigningRequest, out *certificatesapiv1.CertificateSigningRequest, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequest_To_v1_CertificateSigningRequest(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestCondition_To_v1_CertificateSigningRequestCondition(in *certificatesapi.CertificateSigningRequestCondition, out *certificatesapiv1.CertificateSigningRequestCondition, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestCondition))(in)
+	}
+	out.Type = certificatesapiv1.CertificateSigningRequestConditionType(in.Type)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if err := s.Convert(&in.LastUpdateTime, &out.LastUpdateTime, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestCondition_To_v1_CertificateSigningRequestCondition(in *certificatesapi.CertificateSigningRequestCondition, out *certificatesapiv1.CertificateSigningRequestCondition, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestCondition_To_v1_CertificateSigningRequestCondition(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestList_To_v1_CertificateSigningRequestList(in *certificatesapi.CertificateSigningRequestList, out *certificatesapiv1.CertificateSigningRequestList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestList))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.ListMeta, &out.ListMeta, 0); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapiv1.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := convert_api_CertificateSigningRequest_To_v1_CertificateSigningRequest(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestList_To_v1_CertificateSigningRequestList(in *certificatesapi.CertificateSigningRequestList, out *certificatesapiv1.CertificateSigningRequestList, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestList_To_v1_CertificateSigningRequestList(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestSpec_To_v1_CertificateSigningRequestSpec(in *certificatesapi.CertificateSigningRequestSpec, out *certificatesapiv1.CertificateSigningRequestSpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestSpec))(in)
+	}
+	if err := s.Convert(&in.Request, &out.Request, 0); err != nil {
+		return err
+	}
+	out.Usage = certificatesapiv1.CertificateUsage(in.Usage)
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestSpec_To_v1_CertificateSigningRequestSpec(in *certificatesapi.CertificateSigningRequestSpec, out *certificatesapiv1.CertificateSigningRequestSpec, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestSpec_To_v1_CertificateSigningRequestSpec(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestStatus_To_v1_CertificateSigningRequestStatus(in *certificatesapi.CertificateSigningRequestStatus, out *certificatesapiv1.CertificateSigningRequestStatus, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestStatus))(in)
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapiv1.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := convert_api_CertificateSigningRequestCondition_To_v1_CertificateSigningRequestCondition(&in.Conditions[i], &out.Conditions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if err := s.Convert(&in.Certificate, &out.Certificate, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestStatus_To_v1_CertificateSigningRequestStatus(in *certificatesapi.CertificateSigningRequestStatus, out *certificatesapiv1.CertificateSigningRequestStatus, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestStatus_To_v1_CertificateSigningRequestStatus(in, out, s)
+}
+
+func autoconvert_v1_CertificateSigningRequest_To_api_CertificateSigningRequest(in *certificatesapiv1.CertificateSigningRequest, out *certificatesapi.CertificateSigningRequest, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1.CertificateSigningRequest))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := convert_v1_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := convert_v1_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1_CertificateSigningRequest_To_api_CertificateSigningRequest(in *certificatesapiv1.CertificateSigningRequest, out *certificatesapi.CertificateSigningRequest, s conversion.Scope) error {
+	return autoconvert_v1_CertificateSigningRequest_To_api_CertificateSigningRequest(in, out, s)
+}
+
+func autoconvert_v1_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(in *certificatesapiv1.CertificateSigningRequestCondition, out *certificatesapi.CertificateSigningRequestCondition, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1.CertificateSigningRequestCondition))(in)
+	}
+	out.Type = certificatesapi.CertificateSigningRequestConditionType(in.Type)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if err := s.Convert(&in.LastUpdateTime, &out.LastUpdateTime, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(in *certificatesapiv1.CertificateSigningRequestCondition, out *certificatesapi.CertificateSigningRequestCondition, s conversion.Scope) error {
+	return autoconvert_v1_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(in, out, s)
+}
+
+func autoconvert_v1_CertificateSigningRequestList_To_api_CertificateSigningRequestList(in *certificatesapiv1.CertificateSigningRequestList, out *certificatesapi.CertificateSigningRequestList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1.CertificateSigningRequestList))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.ListMeta, &out.ListMeta, 0); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapi.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := convert_v1_CertificateSigningRequest_To_api_CertificateSigningRequest(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func convert_v1_CertificateSigningRequestList_To_api_CertificateSigningRequestList(in *certificatesapiv1.CertificateSigningRequestList, out *certificatesapi.CertificateSigningRequestList, s conversion.Scope) error {
+	return autoconvert_v1_CertificateSigningRequestList_To_api_CertificateSigningRequestList(in, out, s)
+}
+
+func autoconvert_v1_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(in *certificatesapiv1.CertificateSigningRequestSpec, out *certificatesapi.CertificateSigningRequestSpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1.CertificateSigningRequestSpec))(in)
+	}
+	if err := s.Convert(&in.Request, &out.Request, 0); err != nil {
+		return err
+	}
+	out.Usage = certificatesapi.CertificateUsage(in.Usage)
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func convert_v1_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(in *certificatesapiv1.CertificateSigningRequestSpec, out *certificatesapi.CertificateSigningRequestSpec, s conversion.Scope) error {
+	return autoconvert_v1_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(in, out, s)
+}
+
+func autoconvert_v1_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(in *certificatesapiv1.CertificateSigningRequestStatus, out *certificatesapi.CertificateSigningRequestStatus, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1.CertificateSigningRequestStatus))(in)
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapi.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := convert_v1_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(&in.Conditions[i], &out.Conditions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if err := s.Convert(&in.Certificate, &out.Certificate, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(in *certificatesapiv1.CertificateSigningRequestStatus, out *certificatesapi.CertificateSigningRequestStatus, s conversion.Scope) error {
+	return autoconvert_v1_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(in, out, s)
+}
+
 func autoconvert_api_CustomDeploymentStrategyParams_To_v1_CustomDeploymentStrategyParams(in *deployapi.CustomDeploymentStrategyParams, out *deployapiv1.CustomDeploymentStrategyParams, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapi.CustomDeploymentStrategyParams))(in)
@@ -2677,6 +3061,7 @@ func autoconvert_api_CustomDeploymentStrategyParams_To_v1_CustomDeploymentStrate
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -3204,6 +3589,7 @@ func autoconvert_v1_CustomDeploymentStrategyParams_To_api_CustomDeploymentStrate
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -3870,16 +4256,19 @@ func autoconvert_api_ImageStreamTag_To_v1_ImageStreamTag(in *imageapi.ImageStrea
 	if err := convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
+	if in.Tag != nil {
+		if err := s.Convert(&in.Tag, &out.Tag, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Tag = nil
+	}
 	if err := s.Convert(&in.Image, &out.Image, 0); err != nil {
 		return err
 	}
 	return nil
 }
 
-func convert_api_ImageStreamTag_To_v1_ImageStreamTag(in *imageapi.ImageStreamTag, out *imageapiv1.ImageStreamTag, s conversion.Scope) error {
-	return autoconvert_api_ImageStreamTag_To_v1_ImageStreamTag(in, out, s)
-}
-
 func autoconvert_api_ImageStreamTagList_To_v1_ImageStreamTagList(in *imageapi.ImageStreamTagList, out *imageapiv1.ImageStreamTagList, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapi.ImageStreamTagList))(in)
@@ -3893,7 +4282,7 @@ func autoconvert_api_ImageStreamTagList_To_v1_ImageStreamTagList(in *imageapi.Im
 	if in.Items != nil {
 		out.Items = make([]imageapiv1.ImageStreamTag, len(in.Items))
 		for i := range in.Items {
-			if err := convert_api_ImageStreamTag_To_v1_ImageStreamTag(&in.Items[i], &out.Items[i], s); err != nil {
+			if err := s.Convert(&in.Items[i], &out.Items[i], 0); err != nil {
 				return err
 			}
 		}
@@ -4072,16 +4461,19 @@ func autoconvert_v1_ImageStreamTag_To_api_ImageStreamTag(in *imageapiv1.ImageStr
 	if err := convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
+	if in.Tag != nil {
+		if err := s.Convert(&in.Tag, &out.Tag, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Tag = nil
+	}
 	if err := s.Convert(&in.Image, &out.Image, 0); err != nil {
 		return err
 	}
 	return nil
 }
 
-func convert_v1_ImageStreamTag_To_api_ImageStreamTag(in *imageapiv1.ImageStreamTag, out *imageapi.ImageStreamTag, s conversion.Scope) error {
-	return autoconvert_v1_ImageStreamTag_To_api_ImageStreamTag(in, out, s)
-}
-
 func autoconvert_v1_ImageStreamTagList_To_api_ImageStreamTagList(in *imageapiv1.ImageStreamTagList, out *imageapi.ImageStreamTagList, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapiv1.ImageStreamTagList))(in)
@@ -4095,7 +4487,7 @@ func autoconvert_v1_ImageStreamTagList_To_api_ImageStreamTagList(in *imageapiv1.
 	if in.Items != nil {
 		out.Items = make([]imageapi.ImageStreamTag, len(in.Items))
 		for i := range in.Items {
-			if err := convert_v1_ImageStreamTag_To_api_ImageStreamTag(&in.Items[i], &out.Items[i], s); err != nil {
+			if err := s.Convert(&in.Items[i], &out.Items[i], 0); err != nil {
 				return err
 			}
 		}
@@ -4246,6 +4638,8 @@ func autoconvert_api_OAuthClient_To_v1_OAuthClient(in *oauthapi.OAuthClient, out
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = oauthapiv1.GrantHandlerType(in.GrantMethod)
 	return nil
 }
 
@@ -4472,6 +4866,8 @@ func autoconvert_v1_OAuthClient_To_api_OAuthClient(in *oauthapiv1.OAuthClient, o
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = oauthapi.GrantHandlerType(in.GrantMethod)
 	return nil
 }
 
@@ -7926,6 +8322,11 @@ func init() {
 		autoconvert_api_Build_To_v1_Build,
 		autoconvert_api_Capabilities_To_v1_Capabilities,
 		autoconvert_api_CephFSVolumeSource_To_v1_CephFSVolumeSource,
+		autoconvert_api_CertificateSigningRequestCondition_To_v1_CertificateSigningRequestCondition,
+		autoconvert_api_CertificateSigningRequestList_To_v1_CertificateSigningRequestList,
+		autoconvert_api_CertificateSigningRequestSpec_To_v1_CertificateSigningRequestSpec,
+		autoconvert_api_CertificateSigningRequestStatus_To_v1_CertificateSigningRequestStatus,
+		autoconvert_api_CertificateSigningRequest_To_v1_CertificateSigningRequest,
 		autoconvert_api_CinderVolumeSource_To_v1_CinderVolumeSource,
 		autoconvert_api_ClusterNetworkList_To_v1_ClusterNetworkList,
 		autoconvert_api_ClusterNetwork_To_v1_ClusterNetwork,
@@ -7981,6 +8382,7 @@ func init() {
 		autoconvert_api_IdentityList_To_v1_IdentityList,
 		autoconvert_api_Identity_To_v1_Identity,
 		autoconvert_api_ImageChangeTrigger_To_v1_ImageChangeTrigger,
+		autoconvert_api_ImageLabel_To_v1_ImageLabel,
 		autoconvert_api_ImageList_To_v1_ImageList,
 		autoconvert_api_ImageSourcePath_To_v1_ImageSourcePath,
 		autoconvert_api_ImageSource_To_v1_ImageSource,
@@ -8044,6 +8446,7 @@ func init() {
 		autoconvert_api_RouteStatus_To_v1_RouteStatus,
 		autoconvert_api_Route_To_v1_Route,
 		autoconvert_api_SELinuxOptions_To_v1_SELinuxOptions,
+		autoconvert_api_ScheduleTrigger_To_v1_ScheduleTrigger,
 		autoconvert_api_SecretBuildSource_To_v1_SecretBuildSource,
 		autoconvert_api_SecretSpec_To_v1_SecretSpec,
 		autoconvert_api_SecretVolumeSource_To_v1_SecretVolumeSource,
@@ -8084,6 +8487,11 @@ func init() {
 		autoconvert_v1_Build_To_api_Build,
 		autoconvert_v1_Capabilities_To_api_Capabilities,
 		autoconvert_v1_CephFSVolumeSource_To_api_CephFSVolumeSource,
+		autoconvert_v1_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition,
+		autoconvert_v1_CertificateSigningRequestList_To_api_CertificateSigningRequestList,
+		autoconvert_v1_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec,
+		autoconvert_v1_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus,
+		autoconvert_v1_CertificateSigningRequest_To_api_CertificateSigningRequest,
 		autoconvert_v1_CinderVolumeSource_To_api_CinderVolumeSource,
 		autoconvert_v1_ClusterNetworkList_To_api_ClusterNetworkList,
 		autoconvert_v1_ClusterNetwork_To_api_ClusterNetwork,
@@ -8139,6 +8547,7 @@ func init() {
 		autoconvert_v1_IdentityList_To_api_IdentityList,
 		autoconvert_v1_Identity_To_api_Identity,
 		autoconvert_v1_ImageChangeTrigger_To_api_ImageChangeTrigger,
+		autoconvert_v1_ImageLabel_To_api_ImageLabel,
 		autoconvert_v1_ImageList_To_api_ImageList,
 		autoconvert_v1_ImageSourcePath_To_api_ImageSourcePath,
 		autoconvert_v1_ImageSource_To_api_ImageSource,
@@ -8202,6 +8611,7 @@ func init() {
 		autoconvert_v1_RouteStatus_To_api_RouteStatus,
 		autoconvert_v1_Route_To_api_Route,
 		autoconvert_v1_SELinuxOptions_To_api_SELinuxOptions,
+		autoconvert_v1_ScheduleTrigger_To_api_ScheduleTrigger,
 		autoconvert_v1_SecretBuildSource_To_api_SecretBuildSource,
 		autoconvert_v1_SecretSpec_To_api_SecretSpec,
 		autoconvert_v1_SecretVolumeSource_To_api_SecretVolumeSource,
@@ -4,6 +4,7 @@ package v1
 import (
 	v1 "github.com/openshift/origin/pkg/authorization/api/v1"
 	apiv1 "github.com/openshift/origin/pkg/build/api/v1"
+	certificatesapiv1 "github.com/openshift/origin/pkg/certificates/api/v1"
 	deployapiv1 "github.com/openshift/origin/pkg/deploy/api/v1"
 	imageapiv1 "github.com/openshift/origin/pkg/image/api/v1"
 	oauthapiv1 "github.com/openshift/origin/pkg/oauth/api/v1"
@@ -30,6 +31,8 @@ func deepCopy_v1_AuthorizationAttributes(in v1.AuthorizationAttributes, out *v1.
 	} else {
 		out.Content = newVal.(runtime.RawExtension)
 	}
+	out.IsNonResourceURL = in.IsNonResourceURL
+	out.Path = in.Path
 	return nil
 }
 
@@ -898,6 +901,16 @@ func deepCopy_v1_BuildOutput(in apiv1.BuildOutput, out *apiv1.BuildOutput, c *co
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]apiv1.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := deepCopy_v1_ImageLabel(in.ImageLabels[i], &out.ImageLabels[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -964,6 +977,15 @@ func deepCopy_v1_BuildRequest(in apiv1.BuildRequest, out *apiv1.BuildRequest, c
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if newVal, err := c.DeepCopy(in.ScheduleTime); err != nil {
+			return err
+		} else {
+			out.ScheduleTime = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -1089,6 +1111,14 @@ func deepCopy_v1_BuildStatus(in apiv1.BuildStatus, out *apiv1.BuildStatus, c *co
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapiv1.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = in.Finalizers[i]
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -1147,6 +1177,14 @@ func deepCopy_v1_BuildTriggerPolicy(in apiv1.BuildTriggerPolicy, out *apiv1.Buil
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(apiv1.ScheduleTrigger)
+		if err := deepCopy_v1_ScheduleTrigger(*in.Schedule, out.Schedule, c); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -1226,6 +1264,7 @@ func deepCopy_v1_DockerBuildStrategy(in apiv1.DockerBuildStrategy, out *apiv1.Do
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -1263,6 +1302,12 @@ func deepCopy_v1_ImageChangeTrigger(in apiv1.ImageChangeTrigger, out *apiv1.Imag
 	return nil
 }
 
+func deepCopy_v1_ImageLabel(in apiv1.ImageLabel, out *apiv1.ImageLabel, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
 func deepCopy_v1_ImageSource(in apiv1.ImageSource, out *apiv1.ImageSource, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.From); err != nil {
 		return err
@@ -1297,6 +1342,22 @@ func deepCopy_v1_ImageSourcePath(in apiv1.ImageSourcePath, out *apiv1.ImageSourc
 	return nil
 }
 
+func deepCopy_v1_ScheduleTrigger(in apiv1.ScheduleTrigger, out *apiv1.ScheduleTrigger, c *conversion.Cloner) error {
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = in.ConcurrencyPolicy
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if newVal, err := c.DeepCopy(in.LastScheduleTime); err != nil {
+			return err
+		} else {
+			out.LastScheduleTime = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
 func deepCopy_v1_SecretBuildSource(in apiv1.SecretBuildSource, out *apiv1.SecretBuildSource, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.Secret); err != nil {
 		return err
@@ -1371,6 +1432,114 @@ func deepCopy_v1_SourceRevision(in apiv1.SourceRevision, out *apiv1.SourceRevisi
 
 func deepCopy_v1_WebHookTrigger(in apiv1.WebHookTrigger, out *apiv1.WebHookTrigger, c *conversion.Cloner) error {
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_CertificateSigningRequest(in certificatesapiv1.CertificateSigningRequest, out *certificatesapiv1.CertificateSigningRequest, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapiv1.ObjectMeta)
+	}
+	if err := deepCopy_v1_CertificateSigningRequestSpec(in.Spec, &out.Spec, c); err != nil {
+		return err
+	}
+	if err := deepCopy_v1_CertificateSigningRequestStatus(in.Status, &out.Status, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func deepCopy_v1_CertificateSigningRequestCondition(in certificatesapiv1.CertificateSigningRequestCondition, out *certificatesapiv1.CertificateSigningRequestCondition, c *conversion.Cloner) error {
+	out.Type = in.Type
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if newVal, err := c.DeepCopy(in.LastUpdateTime); err != nil {
+		return err
+	} else {
+		out.LastUpdateTime = newVal.(unversioned.Time)
+	}
+	return nil
+}
+
+func deepCopy_v1_CertificateSigningRequestList(in certificatesapiv1.CertificateSigningRequestList, out *certificatesapiv1.CertificateSigningRequestList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapiv1.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_v1_CertificateSigningRequest(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_CertificateSigningRequestSpec(in certificatesapiv1.CertificateSigningRequestSpec, out *certificatesapiv1.CertificateSigningRequestSpec, c *conversion.Cloner) error {
+	if in.Request != nil {
+		out.Request = make([]uint8, len(in.Request))
+		for i := range in.Request {
+			out.Request[i] = in.Request[i]
+		}
+	} else {
+		out.Request = nil
+	}
+	out.Usage = in.Usage
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_CertificateSigningRequestStatus(in certificatesapiv1.CertificateSigningRequestStatus, out *certificatesapiv1.CertificateSigningRequestStatus, c *conversion.Cloner) error {
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapiv1.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := deepCopy_v1_CertificateSigningRequestCondition(in.Conditions[i], &out.Conditions[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Certificate != nil {
+		out.Certificate = make([]uint8, len(in.Certificate))
+		for i := range in.Certificate {
+			out.Certificate[i] = in.Certificate[i]
+		}
+	} else {
+		out.Certificate = nil
+	}
 	return nil
 }
 
@@ -1396,6 +1565,7 @@ func deepCopy_v1_CustomDeploymentStrategyParams(in deployapiv1.CustomDeploymentS
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -1989,6 +2159,14 @@ func deepCopy_v1_ImageStreamTag(in imageapiv1.ImageStreamTag, out *imageapiv1.Im
 	} else {
 		out.ObjectMeta = newVal.(pkgapiv1.ObjectMeta)
 	}
+	if in.Tag != nil {
+		out.Tag = new(imageapiv1.NamedTagReference)
+		if err := deepCopy_v1_NamedTagReference(*in.Tag, out.Tag, c); err != nil {
+			return err
+		}
+	} else {
+		out.Tag = nil
+	}
 	if err := deepCopy_v1_Image(in.Image, &out.Image, c); err != nil {
 		return err
 	}
@@ -2194,6 +2372,8 @@ func deepCopy_v1_OAuthClient(in oauthapiv1.OAuthClient, out *oauthapiv1.OAuthCli
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = in.GrantMethod
 	return nil
 }
 
@@ -2873,14 +3053,21 @@ func init() {
 		deepCopy_v1_GitBuildSource,
 		deepCopy_v1_GitSourceRevision,
 		deepCopy_v1_ImageChangeTrigger,
+		deepCopy_v1_ImageLabel,
 		deepCopy_v1_ImageSource,
 		deepCopy_v1_ImageSourcePath,
+		deepCopy_v1_ScheduleTrigger,
 		deepCopy_v1_SecretBuildSource,
 		deepCopy_v1_SecretSpec,
 		deepCopy_v1_SourceBuildStrategy,
 		deepCopy_v1_SourceControlUser,
 		deepCopy_v1_SourceRevision,
 		deepCopy_v1_WebHookTrigger,
+		deepCopy_v1_CertificateSigningRequest,
+		deepCopy_v1_CertificateSigningRequestCondition,
+		deepCopy_v1_CertificateSigningRequestList,
+		deepCopy_v1_CertificateSigningRequestSpec,
+		deepCopy_v1_CertificateSigningRequestStatus,
 		deepCopy_v1_CustomDeploymentStrategyParams,
 		deepCopy_v1_DeploymentCause,
 		deepCopy_v1_DeploymentCauseImageTrigger,
@@ -6,6 +6,8 @@ import (
 	v1beta3 "github.com/openshift/origin/pkg/authorization/api/v1beta3"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	apiv1beta3 "github.com/openshift/origin/pkg/build/api/v1beta3"
+	certificatesapi "github.com/openshift/origin/pkg/certificates/api"
+	certificatesapiv1beta3 "github.com/openshift/origin/pkg/certificates/api/v1beta3"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployapiv1beta3 "github.com/openshift/origin/pkg/deploy/api/v1beta3"
 	imageapi "github.com/openshift/origin/pkg/image/api"
@@ -1334,6 +1336,16 @@ func autoconvert_api_BuildOutput_To_v1beta3_BuildOutput(in *buildapi.BuildOutput
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]apiv1beta3.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := convert_api_ImageLabel_To_v1beta3_ImageLabel(&in.ImageLabels[i], &out.ImageLabels[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -1394,6 +1406,13 @@ func autoconvert_api_BuildRequest_To_v1beta3_BuildRequest(in *buildapi.BuildRequ
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if err := s.Convert(&in.ScheduleTime, &out.ScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -1526,6 +1545,14 @@ func autoconvert_api_BuildStatus_To_v1beta3_BuildStatus(in *buildapi.BuildStatus
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapiv1beta3.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = pkgapiv1beta3.FinalizerName(in.Finalizers[i])
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -1590,6 +1617,14 @@ func autoconvert_api_BuildTriggerPolicy_To_v1beta3_BuildTriggerPolicy(in *builda
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(apiv1beta3.ScheduleTrigger)
+		if err := convert_api_ScheduleTrigger_To_v1beta3_ScheduleTrigger(in.Schedule, out.Schedule, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -1666,6 +1701,7 @@ func autoconvert_api_DockerBuildStrategy_To_v1beta3_DockerBuildStrategy(in *buil
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -1723,6 +1759,19 @@ func convert_api_ImageChangeTrigger_To_v1beta3_ImageChangeTrigger(in *buildapi.I
 	return autoconvert_api_ImageChangeTrigger_To_v1beta3_ImageChangeTrigger(in, out, s)
 }
 
+func autoconvert_api_ImageLabel_To_v1beta3_ImageLabel(in *buildapi.ImageLabel, out *apiv1beta3.ImageLabel, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*buildapi.ImageLabel))(in)
+	}
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+func convert_api_ImageLabel_To_v1beta3_ImageLabel(in *buildapi.ImageLabel, out *apiv1beta3.ImageLabel, s conversion.Scope) error {
+	return autoconvert_api_ImageLabel_To_v1beta3_ImageLabel(in, out, s)
+}
+
 func autoconvert_api_ImageSource_To_v1beta3_ImageSource(in *buildapi.ImageSource, out *apiv1beta3.ImageSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*buildapi.ImageSource))(in)
@@ -1768,6 +1817,27 @@ func convert_api_ImageSourcePath_To_v1beta3_ImageSourcePath(in *buildapi.ImageSo
 	return autoconvert_api_ImageSourcePath_To_v1beta3_ImageSourcePath(in, out, s)
 }
 
+func autoconvert_api_ScheduleTrigger_To_v1beta3_ScheduleTrigger(in *buildapi.ScheduleTrigger, out *apiv1beta3.ScheduleTrigger, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*buildapi.ScheduleTrigger))(in)
+	}
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = apiv1beta3.ScheduleConcurrencyPolicy(in.ConcurrencyPolicy)
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if err := s.Convert(&in.LastScheduleTime, &out.LastScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
+func convert_api_ScheduleTrigger_To_v1beta3_ScheduleTrigger(in *buildapi.ScheduleTrigger, out *apiv1beta3.ScheduleTrigger, s conversion.Scope) error {
+	return autoconvert_api_ScheduleTrigger_To_v1beta3_ScheduleTrigger(in, out, s)
+}
+
 func autoconvert_api_SecretBuildSource_To_v1beta3_SecretBuildSource(in *buildapi.SecretBuildSource, out *apiv1beta3.SecretBuildSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*buildapi.SecretBuildSource))(in)
@@ -1862,6 +1932,14 @@ func autoconvert_api_WebHookTrigger_To_v1beta3_WebHookTrigger(in *buildapi.WebHo
 		defaulting.(func(*buildapi.WebHookTrigger))(in)
 	}
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
 	return nil
 }
 
@@ -2125,6 +2203,16 @@ func autoconvert_v1beta3_BuildOutput_To_api_BuildOutput(in *apiv1beta3.BuildOutp
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]buildapi.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := convert_v1beta3_ImageLabel_To_api_ImageLabel(&in.ImageLabels[i], &out.ImageLabels[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -2185,6 +2273,13 @@ func autoconvert_v1beta3_BuildRequest_To_api_BuildRequest(in *apiv1beta3.BuildRe
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if err := s.Convert(&in.ScheduleTime, &out.ScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -2318,6 +2413,14 @@ func autoconvert_v1beta3_BuildStatus_To_api_BuildStatus(in *apiv1beta3.BuildStat
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapi.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = pkgapi.FinalizerName(in.Finalizers[i])
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -2383,6 +2486,14 @@ func autoconvert_v1beta3_BuildTriggerPolicy_To_api_BuildTriggerPolicy(in *apiv1b
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(buildapi.ScheduleTrigger)
+		if err := convert_v1beta3_ScheduleTrigger_To_api_ScheduleTrigger(in.Schedule, out.Schedule, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -2459,6 +2570,7 @@ func autoconvert_v1beta3_DockerBuildStrategy_To_api_DockerBuildStrategy(in *apiv
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -2516,6 +2628,19 @@ func convert_v1beta3_ImageChangeTrigger_To_api_ImageChangeTrigger(in *apiv1beta3
 	return autoconvert_v1beta3_ImageChangeTrigger_To_api_ImageChangeTrigger(in, out, s)
 }
 
+func autoconvert_v1beta3_ImageLabel_To_api_ImageLabel(in *apiv1beta3.ImageLabel, out *buildapi.ImageLabel, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*apiv1beta3.ImageLabel))(in)
+	}
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+func convert_v1beta3_ImageLabel_To_api_ImageLabel(in *apiv1beta3.ImageLabel, out *buildapi.ImageLabel, s conversion.Scope) error {
+	return autoconvert_v1beta3_ImageLabel_To_api_ImageLabel(in, out, s)
+}
+
 func autoconvert_v1beta3_ImageSource_To_api_ImageSource(in *apiv1beta3.ImageSource, out *buildapi.ImageSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*apiv1beta3.ImageSource))(in)
@@ -2561,6 +2686,27 @@ func convert_v1beta3_ImageSourcePath_To_api_ImageSourcePath(in *apiv1beta3.Image
 	return autoconvert_v1beta3_ImageSourcePath_To_api_ImageSourcePath(in, out, s)
 }
 
+func autoconvert_v1beta3_ScheduleTrigger_To_api_ScheduleTrigger(in *apiv1beta3.ScheduleTrigger, out *buildapi.ScheduleTrigger, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*apiv1beta3.ScheduleTrigger))(in)
+	}
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = buildapi.ScheduleConcurrencyPolicy(in.ConcurrencyPolicy)
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if err := s.Convert(&in.LastScheduleTime, &out.LastScheduleTime, 0); err != nil {
+			return err
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
+func convert_v1beta3_ScheduleTrigger_To_api_ScheduleTrigger(in *apiv1beta3.ScheduleTrigger, out *buildapi.ScheduleTrigger, s conversion.Scope) error {
+	return autoconvert_v1beta3_ScheduleTrigger_To_api_ScheduleTrigger(in, out, s)
+}
+
 func autoconvert_v1beta3_SecretBuildSource_To_api_SecretBuildSource(in *apiv1beta3.SecretBuildSource, out *buildapi.SecretBuildSource, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*apiv1beta3.SecretBuildSource))(in)
@@ -2656,6 +2802,14 @@ func autoconvert_v1beta3_WebHookTrigger_To_api_WebHookTrigger(in *apiv1beta3.Web
 		defaulting.(func(*apiv1beta3.WebHookTrigger))(in)
 	}
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
 	return nil
 }
 
@@ -2663,6 +2817,236 @@ func convert_v1beta3_WebHookTrigger_To_api_WebHookTrigger(in *apiv1beta3.WebHook
 	return autoconvert_v1beta3_WebHookTrigger_To_api_WebHookTrigger(in, out, s)
 }
 
+func autoconvert_api_CertificateSigningRequest_To_v1beta3_CertificateSigningRequest(in *certificatesapi.CertificateSigningRequest, out *certificatesapiv1beta3.CertificateSigningRequest, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequest))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := convert_api_ObjectMeta_To_v1beta3_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := convert_api_CertificateSigningRequestSpec_To_v1beta3_CertificateSigningRequestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := convert_api_CertificateSigningRequestStatus_To_v1beta3_CertificateSigningRequestStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequest_To_v1beta3_CertificateSigningRequest(in *certificatesapi.CertificateSigningRequest, out *certificatesapiv1beta3.CertificateSigningRequest, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequest_To_v1beta3_CertificateSigningRequest(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestCondition_To_v1beta3_CertificateSigningRequestCondition(in *certificatesapi.CertificateSigningRequestCondition, out *certificatesapiv1beta3.CertificateSigningRequestCondition, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestCondition))(in)
+	}
+	out.Type = certificatesapiv1beta3.CertificateSigningRequestConditionType(in.Type)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if err := s.Convert(&in.LastUpdateTime, &out.LastUpdateTime, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestCondition_To_v1beta3_CertificateSigningRequestCondition(in *certificatesapi.CertificateSigningRequestCondition, out *certificatesapiv1beta3.CertificateSigningRequestCondition, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestCondition_To_v1beta3_CertificateSigningRequestCondition(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestList_To_v1beta3_CertificateSigningRequestList(in *certificatesapi.CertificateSigningRequestList, out *certificatesapiv1beta3.CertificateSigningRequestList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestList))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.ListMeta, &out.ListMeta, 0); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapiv1beta3.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := convert_api_CertificateSigningRequest_To_v1beta3_CertificateSigningRequest(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestList_To_v1beta3_CertificateSigningRequestList(in *certificatesapi.CertificateSigningRequestList, out *certificatesapiv1beta3.CertificateSigningRequestList, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestList_To_v1beta3_CertificateSigningRequestList(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestSpec_To_v1beta3_CertificateSigningRequestSpec(in *certificatesapi.CertificateSigningRequestSpec, out *certificatesapiv1beta3.CertificateSigningRequestSpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestSpec))(in)
+	}
+	if err := s.Convert(&in.Request, &out.Request, 0); err != nil {
+		return err
+	}
+	out.Usage = certificatesapiv1beta3.CertificateUsage(in.Usage)
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestSpec_To_v1beta3_CertificateSigningRequestSpec(in *certificatesapi.CertificateSigningRequestSpec, out *certificatesapiv1beta3.CertificateSigningRequestSpec, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestSpec_To_v1beta3_CertificateSigningRequestSpec(in, out, s)
+}
+
+func autoconvert_api_CertificateSigningRequestStatus_To_v1beta3_CertificateSigningRequestStatus(in *certificatesapi.CertificateSigningRequestStatus, out *certificatesapiv1beta3.CertificateSigningRequestStatus, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapi.CertificateSigningRequestStatus))(in)
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapiv1beta3.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := convert_api_CertificateSigningRequestCondition_To_v1beta3_CertificateSigningRequestCondition(&in.Conditions[i], &out.Conditions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if err := s.Convert(&in.Certificate, &out.Certificate, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_api_CertificateSigningRequestStatus_To_v1beta3_CertificateSigningRequestStatus(in *certificatesapi.CertificateSigningRequestStatus, out *certificatesapiv1beta3.CertificateSigningRequestStatus, s conversion.Scope) error {
+	return autoconvert_api_CertificateSigningRequestStatus_To_v1beta3_CertificateSigningRequestStatus(in, out, s)
+}
+
+func autoconvert_v1beta3_CertificateSigningRequest_To_api_CertificateSigningRequest(in *certificatesapiv1beta3.CertificateSigningRequest, out *certificatesapi.CertificateSigningRequest, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1beta3.CertificateSigningRequest))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := convert_v1beta3_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := convert_v1beta3_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := convert_v1beta3_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1beta3_CertificateSigningRequest_To_api_CertificateSigningRequest(in *certificatesapiv1beta3.CertificateSigningRequest, out *certificatesapi.CertificateSigningRequest, s conversion.Scope) error {
+	return autoconvert_v1beta3_CertificateSigningRequest_To_api_CertificateSigningRequest(in, out, s)
+}
+
+func autoconvert_v1beta3_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(in *certificatesapiv1beta3.CertificateSigningRequestCondition, out *certificatesapi.CertificateSigningRequestCondition, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1beta3.CertificateSigningRequestCondition))(in)
+	}
+	out.Type = certificatesapi.CertificateSigningRequestConditionType(in.Type)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if err := s.Convert(&in.LastUpdateTime, &out.LastUpdateTime, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1beta3_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(in *certificatesapiv1beta3.CertificateSigningRequestCondition, out *certificatesapi.CertificateSigningRequestCondition, s conversion.Scope) error {
+	return autoconvert_v1beta3_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(in, out, s)
+}
+
+func autoconvert_v1beta3_CertificateSigningRequestList_To_api_CertificateSigningRequestList(in *certificatesapiv1beta3.CertificateSigningRequestList, out *certificatesapi.CertificateSigningRequestList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1beta3.CertificateSigningRequestList))(in)
+	}
+	if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.ListMeta, &out.ListMeta, 0); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapi.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := convert_v1beta3_CertificateSigningRequest_To_api_CertificateSigningRequest(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func convert_v1beta3_CertificateSigningRequestList_To_api_CertificateSigningRequestList(in *certificatesapiv1beta3.CertificateSigningRequestList, out *certificatesapi.CertificateSigningRequestList, s conversion.Scope) error {
+	return autoconvert_v1beta3_CertificateSigningRequestList_To_api_CertificateSigningRequestList(in, out, s)
+}
+
+func autoconvert_v1beta3_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(in *certificatesapiv1beta3.CertificateSigningRequestSpec, out *certificatesapi.CertificateSigningRequestSpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1beta3.CertificateSigningRequestSpec))(in)
+	}
+	if err := s.Convert(&in.Request, &out.Request, 0); err != nil {
+		return err
+	}
+	out.Usage = certificatesapi.CertificateUsage(in.Usage)
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func convert_v1beta3_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(in *certificatesapiv1beta3.CertificateSigningRequestSpec, out *certificatesapi.CertificateSigningRequestSpec, s conversion.Scope) error {
+	return autoconvert_v1beta3_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec(in, out, s)
+}
+
+func autoconvert_v1beta3_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(in *certificatesapiv1beta3.CertificateSigningRequestStatus, out *certificatesapi.CertificateSigningRequestStatus, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*certificatesapiv1beta3.CertificateSigningRequestStatus))(in)
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapi.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := convert_v1beta3_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition(&in.Conditions[i], &out.Conditions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if err := s.Convert(&in.Certificate, &out.Certificate, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1beta3_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(in *certificatesapiv1beta3.CertificateSigningRequestStatus, out *certificatesapi.CertificateSigningRequestStatus, s conversion.Scope) error {
+	return autoconvert_v1beta3_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus(in, out, s)
+}
+
 func autoconvert_api_CustomDeploymentStrategyParams_To_v1beta3_CustomDeploymentStrategyParams(in *deployapi.CustomDeploymentStrategyParams, out *deployapiv1beta3.CustomDeploymentStrategyParams, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapi.CustomDeploymentStrategyParams))(in)
@@ -2686,6 +3070,7 @@ func autoconvert_api_CustomDeploymentStrategyParams_To_v1beta3_CustomDeploymentS
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -3213,6 +3598,7 @@ func autoconvert_v1beta3_CustomDeploymentStrategyParams_To_api_CustomDeploymentS
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -3871,6 +4257,7 @@ func autoconvert_api_ImageStreamTag_To_v1beta3_ImageStreamTag(in *imageapi.Image
 	if err := convert_api_ObjectMeta_To_v1beta3_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
+	// in.Tag has no peer in out
 	if err := s.Convert(&in.Image, &out.Image, 0); err != nil {
 		return err
 	}
@@ -4221,6 +4608,8 @@ func autoconvert_api_OAuthClient_To_v1beta3_OAuthClient(in *oauthapi.OAuthClient
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = oauthapiv1beta3.GrantHandlerType(in.GrantMethod)
 	return nil
 }
 
@@ -4447,6 +4836,8 @@ func autoconvert_v1beta3_OAuthClient_To_api_OAuthClient(in *oauthapiv1beta3.OAut
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = oauthapi.GrantHandlerType(in.GrantMethod)
 	return nil
 }
 
@@ -7893,6 +8284,11 @@ func init() {
 		autoconvert_api_Build_To_v1beta3_Build,
 		autoconvert_api_Capabilities_To_v1beta3_Capabilities,
 		autoconvert_api_CephFSVolumeSource_To_v1beta3_CephFSVolumeSource,
+		autoconvert_api_CertificateSigningRequestCondition_To_v1beta3_CertificateSigningRequestCondition,
+		autoconvert_api_CertificateSigningRequestList_To_v1beta3_CertificateSigningRequestList,
+		autoconvert_api_CertificateSigningRequestSpec_To_v1beta3_CertificateSigningRequestSpec,
+		autoconvert_api_CertificateSigningRequestStatus_To_v1beta3_CertificateSigningRequestStatus,
+		autoconvert_api_CertificateSigningRequest_To_v1beta3_CertificateSigningRequest,
 		autoconvert_api_CinderVolumeSource_To_v1beta3_CinderVolumeSource,
 		autoconvert_api_ClusterNetworkList_To_v1beta3_ClusterNetworkList,
 		autoconvert_api_ClusterNetwork_To_v1beta3_ClusterNetwork,
@@ -7948,6 +8344,7 @@ func init() {
 		autoconvert_api_IdentityList_To_v1beta3_IdentityList,
 		autoconvert_api_Identity_To_v1beta3_Identity,
 		autoconvert_api_ImageChangeTrigger_To_v1beta3_ImageChangeTrigger,
+		autoconvert_api_ImageLabel_To_v1beta3_ImageLabel,
 		autoconvert_api_ImageList_To_v1beta3_ImageList,
 		autoconvert_api_ImageSourcePath_To_v1beta3_ImageSourcePath,
 		autoconvert_api_ImageSource_To_v1beta3_ImageSource,
@@ -8011,6 +8408,7 @@ func init() {
 		autoconvert_api_RouteStatus_To_v1beta3_RouteStatus,
 		autoconvert_api_Route_To_v1beta3_Route,
 		autoconvert_api_SELinuxOptions_To_v1beta3_SELinuxOptions,
+		autoconvert_api_ScheduleTrigger_To_v1beta3_ScheduleTrigger,
 		autoconvert_api_SecretBuildSource_To_v1beta3_SecretBuildSource,
 		autoconvert_api_SecretSpec_To_v1beta3_SecretSpec,
 		autoconvert_api_SecretVolumeSource_To_v1beta3_SecretVolumeSource,
@@ -8051,6 +8449,11 @@ func init() {
 		autoconvert_v1beta3_Build_To_api_Build,
 		autoconvert_v1beta3_Capabilities_To_api_Capabilities,
 		autoconvert_v1beta3_CephFSVolumeSource_To_api_CephFSVolumeSource,
+		autoconvert_v1beta3_CertificateSigningRequestCondition_To_api_CertificateSigningRequestCondition,
+		autoconvert_v1beta3_CertificateSigningRequestList_To_api_CertificateSigningRequestList,
+		autoconvert_v1beta3_CertificateSigningRequestSpec_To_api_CertificateSigningRequestSpec,
+		autoconvert_v1beta3_CertificateSigningRequestStatus_To_api_CertificateSigningRequestStatus,
+		autoconvert_v1beta3_CertificateSigningRequest_To_api_CertificateSigningRequest,
 		autoconvert_v1beta3_CinderVolumeSource_To_api_CinderVolumeSource,
 		autoconvert_v1beta3_ClusterNetworkList_To_api_ClusterNetworkList,
 		autoconvert_v1beta3_ClusterNetwork_To_api_ClusterNetwork,
@@ -8106,6 +8509,7 @@ func init() {
 		autoconvert_v1beta3_IdentityList_To_api_IdentityList,
 		autoconvert_v1beta3_Identity_To_api_Identity,
 		autoconvert_v1beta3_ImageChangeTrigger_To_api_ImageChangeTrigger,
+		autoconvert_v1beta3_ImageLabel_To_api_ImageLabel,
 		autoconvert_v1beta3_ImageList_To_api_ImageList,
 		autoconvert_v1beta3_ImageSourcePath_To_api_ImageSourcePath,
 		autoconvert_v1beta3_ImageSource_To_api_ImageSource,
@@ -8169,6 +8573,7 @@ func init() {
 		autoconvert_v1beta3_RouteStatus_To_api_RouteStatus,
 		autoconvert_v1beta3_Route_To_api_Route,
 		autoconvert_v1beta3_SELinuxOptions_To_api_SELinuxOptions,
+		autoconvert_v1beta3_ScheduleTrigger_To_api_ScheduleTrigger,
 		autoconvert_v1beta3_SecretBuildSource_To_api_SecretBuildSource,
 		autoconvert_v1beta3_SecretSpec_To_api_SecretSpec,
 		autoconvert_v1beta3_SecretVolumeSource_To_api_SecretVolumeSource,
@@ -4,6 +4,7 @@ package v1beta3
 import (
 	v1beta3 "github.com/openshift/origin/pkg/authorization/api/v1beta3"
 	apiv1beta3 "github.com/openshift/origin/pkg/build/api/v1beta3"
+	certificatesapiv1beta3 "github.com/openshift/origin/pkg/certificates/api/v1beta3"
 	deployapiv1beta3 "github.com/openshift/origin/pkg/deploy/api/v1beta3"
 	imageapiv1beta3 "github.com/openshift/origin/pkg/image/api/v1beta3"
 	oauthapiv1beta3 "github.com/openshift/origin/pkg/oauth/api/v1beta3"
@@ -30,6 +31,8 @@ func deepCopy_v1beta3_AuthorizationAttributes(in v1beta3.AuthorizationAttributes
 	} else {
 		out.Content = newVal.(runtime.RawExtension)
 	}
+	out.IsNonResourceURL = in.IsNonResourceURL
+	out.Path = in.Path
 	return nil
 }
 
@@ -906,6 +909,16 @@ func deepCopy_v1beta3_BuildOutput(in apiv1beta3.BuildOutput, out *apiv1beta3.Bui
 	} else {
 		out.PushSecret = nil
 	}
+	if in.ImageLabels != nil {
+		out.ImageLabels = make([]apiv1beta3.ImageLabel, len(in.ImageLabels))
+		for i := range in.ImageLabels {
+			if err := deepCopy_v1beta3_ImageLabel(in.ImageLabels[i], &out.ImageLabels[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ImageLabels = nil
+	}
 	return nil
 }
 
@@ -972,6 +985,15 @@ func deepCopy_v1beta3_BuildRequest(in apiv1beta3.BuildRequest, out *apiv1beta3.B
 	} else {
 		out.Env = nil
 	}
+	if in.ScheduleTime != nil {
+		if newVal, err := c.DeepCopy(in.ScheduleTime); err != nil {
+			return err
+		} else {
+			out.ScheduleTime = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.ScheduleTime = nil
+	}
 	return nil
 }
 
@@ -1097,6 +1119,14 @@ func deepCopy_v1beta3_BuildStatus(in apiv1beta3.BuildStatus, out *apiv1beta3.Bui
 	} else {
 		out.Config = nil
 	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]pkgapiv1beta3.FinalizerName, len(in.Finalizers))
+		for i := range in.Finalizers {
+			out.Finalizers[i] = in.Finalizers[i]
+		}
+	} else {
+		out.Finalizers = nil
+	}
 	return nil
 }
 
@@ -1155,6 +1185,14 @@ func deepCopy_v1beta3_BuildTriggerPolicy(in apiv1beta3.BuildTriggerPolicy, out *
 	} else {
 		out.ImageChange = nil
 	}
+	if in.Schedule != nil {
+		out.Schedule = new(apiv1beta3.ScheduleTrigger)
+		if err := deepCopy_v1beta3_ScheduleTrigger(*in.Schedule, out.Schedule, c); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
 	return nil
 }
 
@@ -1234,6 +1272,7 @@ func deepCopy_v1beta3_DockerBuildStrategy(in apiv1beta3.DockerBuildStrategy, out
 	}
 	out.ForcePull = in.ForcePull
 	out.DockerfilePath = in.DockerfilePath
+	out.Target = in.Target
 	return nil
 }
 
@@ -1271,6 +1310,12 @@ func deepCopy_v1beta3_ImageChangeTrigger(in apiv1beta3.ImageChangeTrigger, out *
 	return nil
 }
 
+func deepCopy_v1beta3_ImageLabel(in apiv1beta3.ImageLabel, out *apiv1beta3.ImageLabel, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
 func deepCopy_v1beta3_ImageSource(in apiv1beta3.ImageSource, out *apiv1beta3.ImageSource, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.From); err != nil {
 		return err
@@ -1305,6 +1350,22 @@ func deepCopy_v1beta3_ImageSourcePath(in apiv1beta3.ImageSourcePath, out *apiv1b
 	return nil
 }
 
+func deepCopy_v1beta3_ScheduleTrigger(in apiv1beta3.ScheduleTrigger, out *apiv1beta3.ScheduleTrigger, c *conversion.Cloner) error {
+	out.Cron = in.Cron
+	out.ConcurrencyPolicy = in.ConcurrencyPolicy
+	out.HistoryLimit = in.HistoryLimit
+	if in.LastScheduleTime != nil {
+		if newVal, err := c.DeepCopy(in.LastScheduleTime); err != nil {
+			return err
+		} else {
+			out.LastScheduleTime = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastScheduleTime = nil
+	}
+	return nil
+}
+
 func deepCopy_v1beta3_SecretBuildSource(in apiv1beta3.SecretBuildSource, out *apiv1beta3.SecretBuildSource, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.Secret); err != nil {
 		return err
@@ -1379,6 +1440,114 @@ func deepCopy_v1beta3_SourceRevision(in apiv1beta3.SourceRevision, out *apiv1bet
 
 func deepCopy_v1beta3_WebHookTrigger(in apiv1beta3.WebHookTrigger, out *apiv1beta3.WebHookTrigger, c *conversion.Cloner) error {
 	out.Secret = in.Secret
+	if in.AllowedRefs != nil {
+		out.AllowedRefs = make([]string, len(in.AllowedRefs))
+		for i := range in.AllowedRefs {
+			out.AllowedRefs[i] = in.AllowedRefs[i]
+		}
+	} else {
+		out.AllowedRefs = nil
+	}
+	return nil
+}
+
+func deepCopy_v1beta3_CertificateSigningRequest(in certificatesapiv1beta3.CertificateSigningRequest, out *certificatesapiv1beta3.CertificateSigningRequest, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapiv1beta3.ObjectMeta)
+	}
+	if err := deepCopy_v1beta3_CertificateSigningRequestSpec(in.Spec, &out.Spec, c); err != nil {
+		return err
+	}
+	if err := deepCopy_v1beta3_CertificateSigningRequestStatus(in.Status, &out.Status, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func deepCopy_v1beta3_CertificateSigningRequestCondition(in certificatesapiv1beta3.CertificateSigningRequestCondition, out *certificatesapiv1beta3.CertificateSigningRequestCondition, c *conversion.Cloner) error {
+	out.Type = in.Type
+	out.Reason = in.Reason
+	out.Message = in.Message
+	if newVal, err := c.DeepCopy(in.LastUpdateTime); err != nil {
+		return err
+	} else {
+		out.LastUpdateTime = newVal.(unversioned.Time)
+	}
+	return nil
+}
+
+func deepCopy_v1beta3_CertificateSigningRequestList(in certificatesapiv1beta3.CertificateSigningRequestList, out *certificatesapiv1beta3.CertificateSigningRequestList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]certificatesapiv1beta3.CertificateSigningRequest, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_v1beta3_CertificateSigningRequest(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_v1beta3_CertificateSigningRequestSpec(in certificatesapiv1beta3.CertificateSigningRequestSpec, out *certificatesapiv1beta3.CertificateSigningRequestSpec, c *conversion.Cloner) error {
+	if in.Request != nil {
+		out.Request = make([]uint8, len(in.Request))
+		for i := range in.Request {
+			out.Request[i] = in.Request[i]
+		}
+	} else {
+		out.Request = nil
+	}
+	out.Usage = in.Usage
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	return nil
+}
+
+func deepCopy_v1beta3_CertificateSigningRequestStatus(in certificatesapiv1beta3.CertificateSigningRequestStatus, out *certificatesapiv1beta3.CertificateSigningRequestStatus, c *conversion.Cloner) error {
+	if in.Conditions != nil {
+		out.Conditions = make([]certificatesapiv1beta3.CertificateSigningRequestCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := deepCopy_v1beta3_CertificateSigningRequestCondition(in.Conditions[i], &out.Conditions[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Certificate != nil {
+		out.Certificate = make([]uint8, len(in.Certificate))
+		for i := range in.Certificate {
+			out.Certificate[i] = in.Certificate[i]
+		}
+	} else {
+		out.Certificate = nil
+	}
 	return nil
 }
 
@@ -1404,6 +1573,7 @@ func deepCopy_v1beta3_CustomDeploymentStrategyParams(in deployapiv1beta3.CustomD
 	} else {
 		out.Command = nil
 	}
+	out.ServiceAccountName = in.ServiceAccountName
 	return nil
 }
 
@@ -2184,6 +2354,8 @@ func deepCopy_v1beta3_OAuthClient(in oauthapiv1beta3.OAuthClient, out *oauthapiv
 	} else {
 		out.RedirectURIs = nil
 	}
+	out.AccessTokenMaxAgeSeconds = in.AccessTokenMaxAgeSeconds
+	out.GrantMethod = in.GrantMethod
 	return nil
 }
 
@@ -2863,14 +3035,21 @@ func init() {
 		deepCopy_v1beta3_GitBuildSource,
 		deepCopy_v1beta3_GitSourceRevision,
 		deepCopy_v1beta3_ImageChangeTrigger,
+		deepCopy_v1beta3_ImageLabel,
 		deepCopy_v1beta3_ImageSource,
 		deepCopy_v1beta3_ImageSourcePath,
+		deepCopy_v1beta3_ScheduleTrigger,
 		deepCopy_v1beta3_SecretBuildSource,
 		deepCopy_v1beta3_SecretSpec,
 		deepCopy_v1beta3_SourceBuildStrategy,
 		deepCopy_v1beta3_SourceControlUser,
 		deepCopy_v1beta3_SourceRevision,
 		deepCopy_v1beta3_WebHookTrigger,
+		deepCopy_v1beta3_CertificateSigningRequest,
+		deepCopy_v1beta3_CertificateSigningRequestCondition,
+		deepCopy_v1beta3_CertificateSigningRequestList,
+		deepCopy_v1beta3_CertificateSigningRequestSpec,
+		deepCopy_v1beta3_CertificateSigningRequestStatus,
 		deepCopy_v1beta3_CustomDeploymentStrategyParams,
 		deepCopy_v1beta3_DeploymentCause,
 		deepCopy_v1beta3_DeploymentCauseImageTrigger,
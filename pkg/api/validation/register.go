@@ -3,6 +3,7 @@ package validation
 import (
 	authorizationvalidation "github.com/openshift/origin/pkg/authorization/api/validation"
 	buildvalidation "github.com/openshift/origin/pkg/build/api/validation"
+	certificatesvalidation "github.com/openshift/origin/pkg/certificates/api/validation"
 	deployvalidation "github.com/openshift/origin/pkg/deploy/api/validation"
 	imagevalidation "github.com/openshift/origin/pkg/image/api/validation"
 	oauthvalidation "github.com/openshift/origin/pkg/oauth/api/validation"
@@ -15,6 +16,7 @@ import (
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	certificatesapi "github.com/openshift/origin/pkg/certificates/api"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
@@ -47,6 +49,8 @@ func init() {
 	Validator.Register(&buildapi.BuildRequest{}, buildvalidation.ValidateBuildRequest, nil)
 	Validator.Register(&buildapi.BuildLogOptions{}, buildvalidation.ValidateBuildLogOptions, nil)
 
+	Validator.Register(&certificatesapi.CertificateSigningRequest{}, certificatesvalidation.ValidateCertificateSigningRequest, certificatesvalidation.ValidateCertificateSigningRequestUpdate)
+
 	Validator.Register(&deployapi.DeploymentConfig{}, deployvalidation.ValidateDeploymentConfig, deployvalidation.ValidateDeploymentConfigUpdate)
 	Validator.Register(&deployapi.DeploymentConfigRollback{}, deployvalidation.ValidateDeploymentConfigRollback, nil)
 	Validator.Register(&deployapi.DeploymentLogOptions{}, deployvalidation.ValidateDeploymentLogOptions, nil)
@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+)
+
+// Sink records the events of the audit log of the master: impersonations, exec, attach and port-forward sessions,
+// failed logins and authentications, lockouts and anonymous requests. Components that audit events take a Sink, and
+// decide from their own configuration which events they record.
+type Sink interface {
+	// Record writes an event to the audit log
+	Record(format string, args ...interface{})
+}
+
+// LogSink writes the events of the audit log to the log of the process, prefixed with AUDIT:
+type LogSink struct{}
+
+// Record implements Sink
+func (LogSink) Record(format string, args ...interface{}) {
+	glog.InfoDepth(1, fmt.Sprintf("AUDIT: "+format, args...))
+}
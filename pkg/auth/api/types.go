@@ -13,6 +13,12 @@ const (
 	// This is useful when the immutable providerUserName is different than the login used to authenticate
 	// If present, this extra value is used as the preferred username
 	IdentityPreferredUsernameKey = "preferred_username"
+
+	// ImpersonateUserHeader is the header a request sets to be authorized as another user. The authenticated user
+	// must be allowed to impersonate the requested user.
+	ImpersonateUserHeader = "Impersonate-User"
+	// ImpersonateGroupHeader is repeated for each group the impersonated user is given instead of its own groups
+	ImpersonateGroupHeader = "Impersonate-Group"
 )
 
 // UserIdentityInfo contains information about an identity.  Identities are distinct from users.  An authentication server of
@@ -2,10 +2,12 @@ package anonymous
 
 import (
 	"net/http"
+	"strings"
 
 	"k8s.io/kubernetes/pkg/auth/authenticator"
 	"k8s.io/kubernetes/pkg/auth/user"
 
+	"github.com/openshift/origin/pkg/audit"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 )
 
@@ -14,3 +16,33 @@ func NewAuthenticator() authenticator.Request {
 		return &user.DefaultInfo{Name: bootstrappolicy.UnauthenticatedUsername, Groups: []string{bootstrappolicy.UnauthenticatedGroup}}, true, nil
 	})
 }
+
+// NewPathAuthenticator authenticates the requests for paths as the anonymous user and leaves the others
+// unauthenticated. A path ending with * matches any path starting with the rest of it.
+func NewPathAuthenticator(paths []string) authenticator.Request {
+	anonymous := NewAuthenticator()
+	return authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+		for _, path := range paths {
+			if req.URL.Path == path || (strings.HasSuffix(path, "*") && strings.HasPrefix(req.URL.Path, strings.TrimSuffix(path, "*"))) {
+				return anonymous.AuthenticateRequest(req)
+			}
+		}
+		return nil, false, nil
+	})
+}
+
+// NewAuditingAuthenticator records the requests made without credentials in sink, whether delegate authenticates
+// them as the anonymous user or leaves them unauthenticated
+func NewAuditingAuthenticator(delegate authenticator.Request, sink audit.Sink) authenticator.Request {
+	return authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+		u, ok, err := delegate.AuthenticateRequest(req)
+		switch {
+		case err != nil:
+		case ok:
+			sink.Record("request %s %s from %s handled as %s", req.Method, req.URL.Path, req.RemoteAddr, u.GetName())
+		default:
+			sink.Record("unauthenticated request %s %s from %s rejected", req.Method, req.URL.Path, req.RemoteAddr)
+		}
+		return u, ok, err
+	})
+}
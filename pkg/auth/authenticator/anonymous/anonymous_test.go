@@ -1,6 +1,7 @@
 package anonymous
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
@@ -25,3 +26,26 @@ func TestAnonymous(t *testing.T) {
 		t.Fatalf("Expected group %s, got %v", bootstrappolicy.UnauthenticatedGroup, u.GetGroups())
 	}
 }
+
+func TestPathAuthenticator(t *testing.T) {
+	a := NewPathAuthenticator([]string{"/version", "/healthz/*"})
+	for path, expected := range map[string]bool{
+		"/version":        true,
+		"/healthz/ready":  true,
+		"/healthz":        false,
+		"/version/extra":  false,
+		"/api/v1/secrets": false,
+	} {
+		req, _ := http.NewRequest("GET", "https://master.example.com"+path, nil)
+		u, ok, err := a.AuthenticateRequest(req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", path, err)
+		}
+		if ok != expected {
+			t.Errorf("%s: expected authenticated=%v, got %v", path, expected, ok)
+		}
+		if ok && u.GetName() != bootstrappolicy.UnauthenticatedUsername {
+			t.Errorf("%s: expected username %s, got %s", path, bootstrappolicy.UnauthenticatedUsername, u.GetName())
+		}
+	}
+}
@@ -13,10 +13,17 @@ import (
 
 	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/authenticator"
+	authgroup "github.com/openshift/origin/pkg/auth/group"
 	"github.com/openshift/origin/pkg/auth/ldaputil"
 	"github.com/openshift/origin/pkg/auth/ldaputil/ldapclient"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	userregistry "github.com/openshift/origin/pkg/user/registry/user"
 )
 
+// LDAPGroupsAnnotationPrefix prefixes the annotation of users recording the groups added to them by the LDAP
+// identity provider named by the rest of the annotation, so that they are removed when the user leaves them
+const LDAPGroupsAnnotationPrefix = "openshift.io/ldap-groups."
+
 // Options contains configuration for an Authenticator instance
 type Options struct {
 	// URL is a parsed RFC 2255 URL
@@ -29,6 +36,11 @@ type Options struct {
 	// attribute with a non-empty value is used for all but the latter identity field. If no LDAP attributes
 	// are given for the ID address, login fails.
 	UserAttributeDefiner ldaputil.LDAPUserAttributeDefiner
+
+	// Groups optionally looks up the LDAP groups of users when they log in
+	Groups *configapi.LDAPGroupMembershipLookup
+	// Users records the LDAP groups of users in their groups. Required with Groups.
+	Users userregistry.Registry
 }
 
 // Authenticator validates username/passwords against an LDAP v3 server
@@ -37,6 +49,8 @@ type Authenticator struct {
 	options         Options
 	mapper          authapi.UserIdentityMapper
 	identityFactory ldaputil.LDAPUserIdentityFactory
+	// groups records the LDAP groups of users, if they are looked up
+	groups *authgroup.Recorder
 }
 
 // New returns an authenticator which will validate usernames/passwords using LDAP.
@@ -50,12 +64,16 @@ func New(providerName string, options Options, mapper authapi.UserIdentityMapper
 			Definer:      options.UserAttributeDefiner,
 		},
 	}
+	if options.Groups != nil {
+		filter := authgroup.Filter{Prefix: options.Groups.GroupPrefix, Allowed: sets.NewString(options.Groups.AllowedGroups...)}
+		auth.groups = authgroup.NewRecorder(LDAPGroupsAnnotationPrefix+providerName, filter, options.Users)
+	}
 	return auth, nil
 }
 
 // AuthenticatePassword validates the given username and password against an LDAP server
 func (a *Authenticator) AuthenticatePassword(username, password string) (user.Info, bool, error) {
-	identity, ok, err := a.getIdentity(username, password)
+	identity, groups, ok, err := a.getIdentity(username, password)
 	if err != nil {
 		return nil, false, err
 	}
@@ -69,12 +87,19 @@ func (a *Authenticator) AuthenticatePassword(username, password string) (user.In
 	}
 	glog.V(4).Infof("Got userIdentityMapping: %#v", user)
 
+	if a.groups != nil {
+		if user, err = a.groups.Record(user, groups); err != nil {
+			return nil, false, fmt.Errorf("Error recording the LDAP groups of %s: %v", user.GetName(), err)
+		}
+	}
+
 	return user, true, nil
 
 }
 
-// getIdentity looks up a username in an LDAP server, and attempts to bind to the user's DN using the provided password
-func (a *Authenticator) getIdentity(username, password string) (authapi.UserIdentityInfo, bool, error) {
+// getIdentity looks up a username in an LDAP server, and attempts to bind to the user's DN using the provided password.
+// It also returns the names of the LDAP groups of the user if the authenticator looks them up.
+func (a *Authenticator) getIdentity(username, password string) (authapi.UserIdentityInfo, []string, bool, error) {
 	defer func() {
 		if e := recover(); e != nil {
 			util.HandleError(fmt.Errorf("Recovered panic: %v, %s", e, debug.Stack()))
@@ -82,19 +107,19 @@ func (a *Authenticator) getIdentity(username, password string) (authapi.UserIden
 	}()
 
 	if len(username) == 0 || len(password) == 0 {
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
 
 	// Make the connection and bind to it if a bind DN and password were given
 	l, err := a.options.ClientConfig.Connect()
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 	defer l.Close()
 
 	if bindDN, bindPassword := a.options.ClientConfig.GetBindCredentials(); len(bindDN) > 0 {
 		if err := l.Bind(bindDN, bindPassword); err != nil {
-			return nil, false, err
+			return nil, nil, false, err
 		}
 	}
 
@@ -125,17 +150,17 @@ func (a *Authenticator) getIdentity(username, password string) (authapi.UserIden
 	glog.V(4).Infof("searching for %s", filter)
 	results, err := l.Search(searchRequest)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	if len(results.Entries) == 0 {
 		// 0 results means a missing username, not an error
 		glog.V(4).Infof("no entries matching %s", filter)
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
 	if len(results.Entries) > 1 {
 		// More than 1 result means a misconfigured server filter or query parameter
-		return nil, false, fmt.Errorf("multiple entries found matching %q", username)
+		return nil, nil, false, fmt.Errorf("multiple entries found matching %q", username)
 	}
 
 	entry := results.Entries[0]
@@ -158,16 +183,75 @@ func (a *Authenticator) getIdentity(username, password string) (authapi.UserIden
 				//    and password) are invalid.
 
 				// Authentication failed, return false, but no error
-				return nil, false, nil
+				return nil, nil, false, nil
 			}
 		}
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	// Build the identity
 	identity, err := a.identityFactory.IdentityFor(entry)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
+	}
+
+	if a.options.Groups == nil {
+		return identity, nil, true, nil
+	}
+	// Search for groups with the bind DN again, users may not be allowed to
+	if bindDN, bindPassword := a.options.ClientConfig.GetBindCredentials(); len(bindDN) > 0 {
+		if err := l.Bind(bindDN, bindPassword); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	groups, err := groupsFor(l, entry.DN, a.options.Groups)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return identity, groups, true, nil
+}
+
+// groupsFor returns the names of the groups of lookup that dn is a member of, directly or through nested groups if
+// the lookup includes them
+func groupsFor(l ldap.Client, dn string, lookup *configapi.LDAPGroupMembershipLookup) ([]string, error) {
+	filter := lookup.Filter
+	if len(filter) == 0 {
+		filter = "(objectClass=*)"
+	}
+
+	names := sets.NewString()
+	visited := sets.NewString(dn)
+	members := []string{dn}
+	for len(members) > 0 {
+		member := members[0]
+		members = members[1:]
+
+		searchRequest := ldap.NewSearchRequest(
+			lookup.BaseDN,
+			ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases,
+			0,
+			0,
+			false,
+			fmt.Sprintf("(&%s(%s=%s))", filter, ldap.EscapeFilter(lookup.MemberAttribute), ldap.EscapeFilter(member)),
+			[]string{lookup.NameAttribute},
+			nil,
+		)
+		results, err := l.Search(searchRequest)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range results.Entries {
+			if name := entry.GetAttributeValue(lookup.NameAttribute); len(name) > 0 {
+				names.Insert(name)
+			}
+			// the visited groups stop membership cycles
+			if lookup.Nested && !visited.Has(entry.DN) {
+				visited.Insert(entry.DN)
+				members = append(members, entry.DN)
+			}
+		}
 	}
-	return identity, true, nil
+	glog.V(4).Infof("found groups %v for dn=%q", names.List(), dn)
+	return names.List(), nil
 }
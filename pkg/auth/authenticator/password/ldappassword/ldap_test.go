@@ -0,0 +1,91 @@
+package ldappassword
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+
+	"gopkg.in/ldap.v2"
+
+	"github.com/openshift/origin/pkg/auth/ldaputil/testclient"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+	"github.com/openshift/origin/pkg/user/registry/test"
+)
+
+// groupClient returns the groups whose member attribute is the DN in the filter of searches
+type groupClient struct {
+	*testclient.Fake
+	members map[string][]*ldap.Entry
+}
+
+func (c *groupClient) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	for member, entries := range c.members {
+		if searchRequest.Filter == fmt.Sprintf("(&(objectClass=groupOfNames)(member=%s))", ldap.EscapeFilter(member)) {
+			return &ldap.SearchResult{Entries: entries}, nil
+		}
+	}
+	return &ldap.SearchResult{}, nil
+}
+
+func group(name string) *ldap.Entry {
+	return ldap.NewEntry("cn="+name+",ou=groups,dc=example,dc=com", map[string][]string{"cn": {name}})
+}
+
+func TestGroupsFor(t *testing.T) {
+	client := &groupClient{
+		Fake: testclient.New(),
+		members: map[string][]*ldap.Entry{
+			"uid=jane,ou=users,dc=example,dc=com":        {group("developers")},
+			"cn=developers,ou=groups,dc=example,dc=com":  {group("engineering")},
+			"cn=engineering,ou=groups,dc=example,dc=com": {group("developers"), group("staff")},
+		},
+	}
+	lookup := &configapi.LDAPGroupMembershipLookup{
+		BaseDN:          "ou=groups,dc=example,dc=com",
+		Filter:          "(objectClass=groupOfNames)",
+		MemberAttribute: "member",
+		NameAttribute:   "cn",
+	}
+
+	groups, err := groupsFor(client, "uid=jane,ou=users,dc=example,dc=com", lookup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"developers"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected direct groups %v, got %v", expected, groups)
+	}
+
+	lookup.Nested = true
+	groups, err = groupsFor(client, "uid=jane,ou=users,dc=example,dc=com", lookup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"developers", "engineering", "staff"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected nested groups %v, got %v", expected, groups)
+	}
+}
+
+func TestRecordFilteredGroups(t *testing.T) {
+	users := test.NewUserRegistry()
+	users.Get["jane"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "jane"}}
+
+	lookup := &configapi.LDAPGroupMembershipLookup{GroupPrefix: "ldap:", AllowedGroups: []string{"developers", "admins"}}
+	a, err := New("ldap", Options{Groups: lookup, Users: users}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info, err := a.(*Authenticator).groups.Record(&user.DefaultInfo{Name: "jane", UID: "1"}, []string{"developers", "testers"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"ldap:developers"}; !reflect.DeepEqual(info.GetGroups(), expected) {
+		t.Errorf("expected groups %v, got %v", expected, info.GetGroups())
+	}
+	if annotation := users.Get["jane"].Annotations[LDAPGroupsAnnotationPrefix+"ldap"]; annotation != "ldap:developers" {
+		t.Errorf("expected the LDAP groups to be recorded, got %q", annotation)
+	}
+}
@@ -3,21 +3,26 @@ package basicauthrequest
 import (
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"k8s.io/kubernetes/pkg/auth/user"
 
 	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 )
 
 type basicAuthRequestHandler struct {
 	passwordAuthenticator authenticator.Password
 	removeHeader          bool
+	throttle              lockout.Throttle
 }
 
-func NewBasicAuthAuthentication(passwordAuthenticator authenticator.Password, removeHeader bool) authenticator.Request {
-	return &basicAuthRequestHandler{passwordAuthenticator, removeHeader}
+// NewBasicAuthAuthentication returns a request authenticator for basic auth credentials. If throttle is not nil,
+// logins it refuses fail without checking the password.
+func NewBasicAuthAuthentication(passwordAuthenticator authenticator.Password, removeHeader bool, throttle lockout.Throttle) authenticator.Request {
+	return &basicAuthRequestHandler{passwordAuthenticator, removeHeader, throttle}
 }
 
 func (authHandler *basicAuthRequestHandler) AuthenticateRequest(req *http.Request) (user.Info, bool, error) {
@@ -29,10 +34,23 @@ func (authHandler *basicAuthRequestHandler) AuthenticateRequest(req *http.Reques
 		return nil, false, nil
 	}
 
+	if authHandler.throttle != nil {
+		if wait := authHandler.throttle.LockedOut(username, req); wait > 0 {
+			return nil, false, fmt.Errorf("too many failed login attempts, try again in %v", wait)
+		}
+	}
+
 	user, ok, err := authHandler.passwordAuthenticator.AuthenticatePassword(username, password)
 	if ok && authHandler.removeHeader {
 		req.Header.Del("Authorization")
 	}
+	if authHandler.throttle != nil && err == nil {
+		if ok {
+			authHandler.throttle.LoginSucceeded(username, req)
+		} else {
+			authHandler.throttle.LoginFailed(username, req)
+		}
+	}
 	return user, ok, err
 }
 
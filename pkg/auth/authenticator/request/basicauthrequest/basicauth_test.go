@@ -3,8 +3,11 @@ package basicauthrequest
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"k8s.io/kubernetes/pkg/auth/user"
+
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 )
 
 const (
@@ -30,7 +33,7 @@ func (mock *mockPasswordAuthenticator) AuthenticatePassword(username, password s
 
 func TestAuthenticateRequestValid(t *testing.T) {
 	passwordAuthenticator := &mockPasswordAuthenticator{}
-	authRequestHandler := NewBasicAuthAuthentication(passwordAuthenticator, true)
+	authRequestHandler := NewBasicAuthAuthentication(passwordAuthenticator, true, nil)
 	req, _ := http.NewRequest("GET", "http://example.org", nil)
 	req.SetBasicAuth(Username, Password)
 
@@ -48,7 +51,7 @@ func TestAuthenticateRequestInvalid(t *testing.T) {
 		ExpectedError = "No valid base64 data in basic auth scheme found"
 	)
 	passwordAuthenticator := &mockPasswordAuthenticator{isAuthenticated: true}
-	authRequestHandler := NewBasicAuthAuthentication(passwordAuthenticator, true)
+	authRequestHandler := NewBasicAuthAuthentication(passwordAuthenticator, true, nil)
 	req, _ := http.NewRequest("GET", "http://example.org", nil)
 	req.Header.Add("Authorization", "Basic invalid:string")
 
@@ -67,6 +70,31 @@ func TestAuthenticateRequestInvalid(t *testing.T) {
 	}
 }
 
+func TestAuthenticateRequestLockedOut(t *testing.T) {
+	passwordAuthenticator := &mockPasswordAuthenticator{}
+	throttle := lockout.New(lockout.Config{MaxFailedAttempts: 2, Lockout: time.Minute, MaxLockout: time.Hour}, nil).ForProvider("test")
+	authRequestHandler := NewBasicAuthAuthentication(passwordAuthenticator, true, throttle)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "http://example.org", nil)
+		req.SetBasicAuth(Username, Password)
+		if _, authenticated, err := authRequestHandler.AuthenticateRequest(req); authenticated || err != nil {
+			t.Fatalf("Expected the login to be denied, got %v: %v", authenticated, err)
+		}
+	}
+
+	passwordAuthenticator.isAuthenticated = true
+	passwordAuthenticator.passedUser = ""
+	req, _ := http.NewRequest("GET", "http://example.org", nil)
+	req.SetBasicAuth(Username, Password)
+	if _, authenticated, err := authRequestHandler.AuthenticateRequest(req); authenticated || err == nil {
+		t.Errorf("Expected an error once locked out, got %v: %v", authenticated, err)
+	}
+	if len(passwordAuthenticator.passedUser) > 0 {
+		t.Errorf("Expected the password not to be checked once locked out")
+	}
+}
+
 func TestGetBasicAuthInfo(t *testing.T) {
 	req, _ := http.NewRequest("GET", "http://example.org", nil)
 	req.SetBasicAuth(Username, Password)
@@ -7,12 +7,22 @@ import (
 	"github.com/golang/glog"
 
 	authapi "github.com/openshift/origin/pkg/auth/api"
+	authgroup "github.com/openshift/origin/pkg/auth/group"
 	"k8s.io/kubernetes/pkg/auth/user"
 )
 
+// GroupsAnnotationPrefix prefixes the annotation of users recording the groups added to them from the group headers
+// of the request header identity provider named by the rest of the annotation
+const GroupsAnnotationPrefix = "openshift.io/requestheader-groups."
+
 type Config struct {
 	// UserNameHeaders lists the headers to check (in order, case-insensitively) for a username. The first header with a value wins.
 	UserNameHeaders []string
+	// GroupHeaders lists the headers to read the groups of the user from. Every value of every header is a comma
+	// separated list of groups.
+	GroupHeaders []string
+	// Groups records the groups read from GroupHeaders in the user. Required with GroupHeaders.
+	Groups *authgroup.Recorder
 }
 
 func NewDefaultConfig() *Config {
@@ -54,5 +64,30 @@ func (a *Authenticator) AuthenticateRequest(req *http.Request) (user.Info, bool,
 	}
 	glog.V(4).Infof("Got userIdentityMapping: %#v", user)
 
+	if len(a.config.GroupHeaders) > 0 && a.config.Groups != nil {
+		if user, err = a.config.Groups.Record(user, a.groups(req)); err != nil {
+			return nil, false, err
+		}
+	}
+
 	return user, true, nil
 }
+
+// groups returns the groups listed in the group headers of req
+func (a *Authenticator) groups(req *http.Request) []string {
+	groups := []string{}
+	for _, header := range a.config.GroupHeaders {
+		header = strings.TrimSpace(header)
+		if len(header) == 0 {
+			continue
+		}
+		for _, value := range req.Header[http.CanonicalHeaderKey(header)] {
+			for _, group := range strings.Split(value, ",") {
+				if group = strings.TrimSpace(group); len(group) > 0 {
+					groups = append(groups, group)
+				}
+			}
+		}
+	}
+	return groups
+}
@@ -2,10 +2,17 @@ package headerrequest
 
 import (
 	"net/http"
+	"reflect"
 	"testing"
 
-	"github.com/openshift/origin/pkg/auth/api"
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/auth/api"
+	authgroup "github.com/openshift/origin/pkg/auth/group"
+	userapi "github.com/openshift/origin/pkg/user/api"
+	"github.com/openshift/origin/pkg/user/registry/test"
 )
 
 type TestUserIdentityMapper struct{}
@@ -61,7 +68,7 @@ func TestRequestHeader(t *testing.T) {
 
 	for k, testcase := range testcases {
 		mapper := &TestUserIdentityMapper{}
-		auth := NewAuthenticator("testprovider", &Config{testcase.ConfiguredHeaders}, mapper)
+		auth := NewAuthenticator("testprovider", &Config{UserNameHeaders: testcase.ConfiguredHeaders}, mapper)
 		req := &http.Request{Header: testcase.RequestHeaders}
 
 		user, ok, err := auth.AuthenticateRequest(req)
@@ -87,3 +94,30 @@ func TestRequestHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestHeaderGroups(t *testing.T) {
+	users := test.NewUserRegistry()
+	users.Get["Bob"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "Bob"}}
+
+	config := &Config{
+		UserNameHeaders: []string{"X-Remote-User"},
+		GroupHeaders:    []string{"x-remote-group"},
+		Groups:          authgroup.NewRecorder(GroupsAnnotationPrefix+"testprovider", authgroup.Filter{Allowed: sets.NewString("developers", "admins")}, users),
+	}
+	auth := NewAuthenticator("testprovider", config, &TestUserIdentityMapper{})
+	req := &http.Request{Header: http.Header{
+		"X-Remote-User":  {"Bob"},
+		"X-Remote-Group": {"developers, testers", "admins"},
+	}}
+
+	user, ok, err := auth.AuthenticateRequest(req)
+	if err != nil || !ok {
+		t.Fatalf("expected user, got %v, %v", ok, err)
+	}
+	if expected := []string{"developers", "admins"}; !reflect.DeepEqual(user.GetGroups(), expected) {
+		t.Errorf("expected groups %v, got %v", expected, user.GetGroups())
+	}
+	if annotation := users.Get["Bob"].Annotations[GroupsAnnotationPrefix+"testprovider"]; annotation != "developers,admins" {
+		t.Errorf("expected the groups to be recorded, got %q", annotation)
+	}
+}
@@ -0,0 +1,83 @@
+package lockoutrequest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
+)
+
+// Authenticator audits the requests its delegate rejects, like requests with an unknown bearer token or an
+// untrusted client certificate, and throttles the source IPs that keep sending rejected credentials. Requests with
+// credentials from a source IP that is locked out are refused without being checked. Requests without credentials
+// are not affected.
+type Authenticator struct {
+	delegate authenticator.Request
+	provider string
+	throttle lockout.Throttle
+	auditor  lockout.Auditor
+	sourceIP func(*http.Request) string
+}
+
+// New returns an Authenticator that records the rejections of delegate under provider. throttle, which should be
+// returned by lockout.Lockout.ForRequests, and auditor may be nil. sourceIP returns the address of the client of
+// a request, like lockout.Lockout.SourceIP.
+func New(delegate authenticator.Request, provider string, throttle lockout.Throttle, auditor lockout.Auditor, sourceIP func(*http.Request) string) *Authenticator {
+	return &Authenticator{delegate: delegate, provider: provider, throttle: throttle, auditor: auditor, sourceIP: sourceIP}
+}
+
+func (a *Authenticator) AuthenticateRequest(req *http.Request) (user.Info, bool, error) {
+	credentials := hasCredentials(req)
+	if a.throttle != nil && credentials {
+		if wait := a.throttle.LockedOut("", req); wait > 0 {
+			return nil, false, fmt.Errorf("the credentials of the request are refused for %v after repeated authentication failures", wait)
+		}
+	}
+	u, ok, err := a.delegate.AuthenticateRequest(req)
+	// requests without credentials are not failures, they are handled as the anonymous user, and errors of the
+	// server, like an unavailable token store, say nothing of the credentials
+	if err != nil && rejected(err) {
+		if a.auditor != nil {
+			a.auditor.LoginFailed(a.provider, "", a.sourceIP(req))
+		}
+		if a.throttle != nil && credentials {
+			a.throttle.LoginFailed("", req)
+		}
+	}
+	return u, ok, err
+}
+
+// hasCredentials returns true if req has an Authorization header, an access_token parameter or a client
+// certificate
+func hasCredentials(req *http.Request) bool {
+	return len(req.Header.Get("Authorization")) > 0 ||
+		len(req.URL.Query().Get("access_token")) > 0 ||
+		(req.TLS != nil && len(req.TLS.PeerCertificates) > 0)
+}
+
+// rejected returns true if err rejects the credentials of a request, rather than reporting that they could not
+// be checked. Server errors of the API and network errors are not rejections. Every error of an aggregate must be
+// a rejection.
+func rejected(err error) bool {
+	if aggregate, ok := err.(utilerrors.Aggregate); ok {
+		for _, err := range aggregate.Errors() {
+			if !rejected(err) {
+				return false
+			}
+		}
+		return true
+	}
+	if status, ok := err.(*kapierrors.StatusError); ok {
+		return status.Status().Code < http.StatusInternalServerError
+	}
+	if _, ok := err.(net.Error); ok {
+		return false
+	}
+	return true
+}
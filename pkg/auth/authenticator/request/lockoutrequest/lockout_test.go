@@ -0,0 +1,125 @@
+package lockoutrequest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
+)
+
+type fakeAuditor struct {
+	failed []string
+}
+
+func (a *fakeAuditor) LoginFailed(provider, username, sourceIP string) {
+	a.failed = append(a.failed, provider+"/"+username+"@"+sourceIP)
+}
+
+func (a *fakeAuditor) LockedOut(provider, subject string, until time.Time) {}
+
+func newRequest(remoteAddr string, authorization string) *http.Request {
+	req, _ := http.NewRequest("GET", "https://master.example.com/api", nil)
+	req.RemoteAddr = remoteAddr
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req
+}
+
+func TestAuthenticator(t *testing.T) {
+	delegate := authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+		switch req.Header.Get("Authorization") {
+		case "":
+			return nil, false, nil
+		case "Bearer valid":
+			return &user.DefaultInfo{Name: "alice"}, true, nil
+		case "Bearer unavailable":
+			return nil, false, kapierrors.NewInternalError(errors.New("etcd is unavailable"))
+		default:
+			return nil, false, kapierrors.NewNotFound("OAuthAccessToken", "invalid")
+		}
+	})
+	l := lockout.New(lockout.Config{MaxFailedAttempts: 1, MaxFailedAttemptsPerSourceIP: 2, Lockout: time.Minute, MaxLockout: time.Hour}, nil)
+	auditor := &fakeAuditor{}
+	a := New(delegate, "api", l.ForRequests("api"), auditor, l.SourceIP)
+
+	// requests without credentials are not failures, nor are errors of the server
+	for i := 0; i < 3; i++ {
+		if _, ok, err := a.AuthenticateRequest(newRequest("10.0.0.1:1234", "")); ok || err != nil {
+			t.Fatalf("unexpected result without credentials: %v %v", ok, err)
+		}
+		if _, _, err := a.AuthenticateRequest(newRequest("10.0.0.1:1234", "Bearer unavailable")); err == nil || !strings.Contains(err.Error(), "etcd is unavailable") {
+			t.Fatalf("%d: expected the error of the delegate, got %v", i, err)
+		}
+	}
+	if len(auditor.failed) != 0 {
+		t.Errorf("unexpected audited failures: %v", auditor.failed)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := a.AuthenticateRequest(newRequest("10.0.0.1:1234", fmt.Sprintf("Bearer invalid%d", i))); !kapierrors.IsNotFound(err) {
+			t.Fatalf("%d: expected the error of the delegate, got %v", i, err)
+		}
+	}
+	if len(auditor.failed) != 2 || auditor.failed[0] != "api/@10.0.0.1" {
+		t.Errorf("unexpected audited failures: %v", auditor.failed)
+	}
+
+	// any credentials from the source IP are refused, requests from other addresses and without credentials are not
+	if _, ok, err := a.AuthenticateRequest(newRequest("10.0.0.1:1234", "Bearer valid")); ok || err == nil || kapierrors.IsNotFound(err) {
+		t.Errorf("expected the credentials to be refused without being checked, got %v %v", ok, err)
+	}
+	if _, ok, err := a.AuthenticateRequest(newRequest("10.0.0.1:1234", "")); ok || err != nil {
+		t.Errorf("unexpected result without credentials: %v %v", ok, err)
+	}
+	if u, ok, err := a.AuthenticateRequest(newRequest("10.0.0.2:1234", "Bearer valid")); !ok || err != nil || u.GetName() != "alice" {
+		t.Errorf("expected the request with valid credentials to be authenticated, got %v %v %v", u, ok, err)
+	}
+}
+
+func TestAuthenticatorAuditOnly(t *testing.T) {
+	delegate := authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+		return nil, false, errors.New("token not found")
+	})
+	auditor := &fakeAuditor{}
+	a := New(delegate, "api", nil, auditor, lockout.SourceIP)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := a.AuthenticateRequest(newRequest("10.0.0.1:1234", "Bearer invalid")); err == nil || err.Error() != "token not found" {
+			t.Fatalf("%d: expected the error of the delegate, got %v", i, err)
+		}
+	}
+	if len(auditor.failed) != 3 {
+		t.Errorf("expected every failure to be audited, got %v", auditor.failed)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "unknown token", err: kapierrors.NewNotFound("OAuthAccessToken", "token"), expected: true},
+		{name: "expired token", err: errors.New("token is expired"), expected: true},
+		{name: "unavailable store", err: kapierrors.NewInternalError(errors.New("etcd is unavailable"))},
+		{name: "unreachable server", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+		{name: "rejections of several authenticators", err: utilerrors.NewAggregate([]error{errors.New("token is expired"), errors.New("unknown authority")}), expected: true},
+		{name: "rejection and server error", err: utilerrors.NewAggregate([]error{errors.New("unknown authority"), kapierrors.NewInternalError(errors.New("etcd is unavailable"))})},
+	}
+	for _, tc := range testCases {
+		if rejected(tc.err) != tc.expected {
+			t.Errorf("%s: expected %t", tc.name, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package unionrequest
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	authenticatorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_auth_request_authenticator_count",
+			Help: "Counter of the requests tried by each request authenticator, broken out by result: true if the authenticator authenticated the request, false if it did not, error if it failed.",
+		},
+		[]string{"authenticator", "result"},
+	)
+	authenticatorLatencies = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "openshift_auth_request_authenticator_latencies",
+			Help: "Latency distribution in microseconds of each request authenticator.",
+			// Use buckets ranging from 1 ms to 4 seconds.
+			Buckets: prometheus.ExponentialBuckets(1000, 2.0, 13),
+		},
+		[]string{"authenticator"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(authenticatorCounter)
+	prometheus.MustRegister(authenticatorLatencies)
+}
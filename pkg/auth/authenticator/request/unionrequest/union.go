@@ -2,7 +2,10 @@ package unionrequest
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/golang/glog"
 	kerrors "k8s.io/kubernetes/pkg/util/errors"
 
 	"github.com/openshift/origin/pkg/auth/authenticator"
@@ -14,6 +17,9 @@ import (
 type Authenticator struct {
 	Handlers    []authenticator.Request
 	FailOnError bool
+	// Names are the names the outcome and latency of the handlers are recorded under, in the same order as the
+	// handlers. Handlers without a name are not recorded.
+	Names []string
 }
 
 // NewUnionAuthentication returns a request authenticator that validates credentials using a chain of authenticator.Request objects
@@ -25,8 +31,15 @@ func NewUnionAuthentication(authRequestHandlers ...authenticator.Request) authen
 // success returns that identity.  Errors are only returned if no matches are found.
 func (authHandler *Authenticator) AuthenticateRequest(req *http.Request) (user.Info, bool, error) {
 	errors := []error{}
-	for _, currAuthRequestHandler := range authHandler.Handlers {
+	for i, currAuthRequestHandler := range authHandler.Handlers {
+		start := time.Now()
 		info, ok, err := currAuthRequestHandler.AuthenticateRequest(req)
+		if i < len(authHandler.Names) && len(authHandler.Names[i]) > 0 {
+			record(authHandler.Names[i], ok, err, start)
+			if err == nil && ok {
+				glog.V(5).Infof("Request %s %s authenticated by %s as %s", req.Method, req.URL.Path, authHandler.Names[i], info.GetName())
+			}
+		}
 		if err == nil && ok {
 			return info, ok, err
 		}
@@ -37,10 +50,19 @@ func (authHandler *Authenticator) AuthenticateRequest(req *http.Request) (user.I
 			errors = append(errors, err)
 		}
 	}
-
 	if len(errors) == 1 {
 		// Avoid wrapping an error if possible
 		return nil, false, errors[0]
 	}
 	return nil, false, kerrors.NewAggregate(errors)
 }
+
+// record observes the outcome and latency of the handler named name
+func record(name string, ok bool, err error, start time.Time) {
+	result := strconv.FormatBool(ok)
+	if err != nil {
+		result = "error"
+	}
+	authenticatorCounter.WithLabelValues(name, result).Inc()
+	authenticatorLatencies.WithLabelValues(name).Observe(float64(time.Since(start) / time.Microsecond))
+}
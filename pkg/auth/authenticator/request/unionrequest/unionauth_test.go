@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	dto "github.com/prometheus/client_model/go"
 	"k8s.io/kubernetes/pkg/auth/user"
 
 	"github.com/openshift/origin/pkg/auth/authenticator"
@@ -107,3 +108,32 @@ func TestAuthenticateRequestFailEarly(t *testing.T) {
 		t.Errorf("Unexpectedly authenticated: %v", isAuthenticated)
 	}
 }
+
+func TestAuthenticateRequestRecordsNamedHandlers(t *testing.T) {
+	handler1 := &mockAuthRequestHandler{err: errors.New("first")}
+	handler2 := &mockAuthRequestHandler{isAuthenticated: true, returnUser: &user.DefaultInfo{Name: "bob"}}
+	handler3 := &mockAuthRequestHandler{}
+	authRequestHandler := Authenticator{Handlers: []authenticator.Request{handler1, handler2, handler3}, Names: []string{"test-first", "test-second", "test-third"}}
+	req, _ := http.NewRequest("GET", "http://example.org", nil)
+
+	if _, isAuthenticated, err := authRequestHandler.AuthenticateRequest(req); !isAuthenticated || err != nil {
+		t.Fatalf("Expected to be authenticated, got %v, %v", isAuthenticated, err)
+	}
+	for _, expected := range []struct {
+		name   string
+		result string
+		count  float64
+	}{
+		{"test-first", "error", 1},
+		{"test-second", "true", 1},
+		{"test-third", "false", 0},
+	} {
+		metric := &dto.Metric{}
+		if err := authenticatorCounter.WithLabelValues(expected.name, expected.result).Write(metric); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if count := metric.GetCounter().GetValue(); count != expected.count {
+			t.Errorf("Expected %s to be recorded %v times with result %s, got %v", expected.name, expected.count, expected.result, count)
+		}
+	}
+}
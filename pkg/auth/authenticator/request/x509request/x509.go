@@ -3,11 +3,14 @@ package x509request
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"k8s.io/kubernetes/pkg/auth/user"
 	kerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
 )
 
 // UserConversion defines an interface for extracting user info from a client certificate chain
@@ -64,14 +67,46 @@ func (a *Authenticator) AuthenticateRequest(req *http.Request) (user.Info, bool,
 	return nil, false, kerrors.NewAggregate(errlist)
 }
 
-// Verifier implements request.Authenticator by verifying a client cert on the request, then delegating to the wrapped auth
+// IgnoreUnknownAuthority returns a request.Authenticator which does not handle the requests whose client certificates
+// were all rejected by auth for being signed by an unknown authority, so that an authenticator trusting another CA may
+// still handle them when the authenticators are combined in a union failing on errors
+func IgnoreUnknownAuthority(auth authenticator.Request) authenticator.Request {
+	return authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+		u, ok, err := auth.AuthenticateRequest(req)
+		if err != nil && isUnknownAuthority(err) {
+			return nil, false, nil
+		}
+		return u, ok, err
+	})
+}
+
+// isUnknownAuthority returns true if err, or every error it aggregates, is an x509.UnknownAuthorityError
+func isUnknownAuthority(err error) bool {
+	if agg, ok := err.(kerrors.Aggregate); ok {
+		for _, err := range agg.Errors() {
+			if !isUnknownAuthority(err) {
+				return false
+			}
+		}
+		return len(agg.Errors()) > 0
+	}
+	_, ok := err.(x509.UnknownAuthorityError)
+	return ok
+}
+
+// Verifier implements request.Authenticator by verifying a client cert on the request, then delegating to the wrapped auth.
+// Wrapping the request header authenticator, it ensures that only verified proxies can set the user and group headers.
 type Verifier struct {
 	opts x509.VerifyOptions
 	auth authenticator.Request
+
+	// allowedCommonNames contains the common names which a verified certificate is allowed to have.
+	// If empty, all verified certificates are allowed.
+	allowedCommonNames sets.String
 }
 
-func NewVerifier(opts x509.VerifyOptions, auth authenticator.Request) authenticator.Request {
-	return &Verifier{opts, auth}
+func NewVerifier(opts x509.VerifyOptions, auth authenticator.Request, allowedCommonNames sets.String) authenticator.Request {
+	return &Verifier{opts, auth, allowedCommonNames}
 }
 
 // AuthenticateRequest verifies the presented client certificates, then delegates to the wrapped auth
@@ -87,6 +122,10 @@ func (a *Verifier) AuthenticateRequest(req *http.Request) (user.Info, bool, erro
 			errlist = append(errlist, err)
 			continue
 		}
+		if len(a.allowedCommonNames) > 0 && !a.allowedCommonNames.Has(cert.Subject.CommonName) {
+			errlist = append(errlist, fmt.Errorf("x509: subject with cn=%s is not in the allowed list: %v", cert.Subject.CommonName, a.allowedCommonNames.List()))
+			continue
+		}
 		return a.auth.AuthenticateRequest(req)
 	}
 	return nil, false, kerrors.NewAggregate(errlist)
@@ -134,6 +173,47 @@ var EmailAddressUserConversion = UserConversionFunc(func(chain []*x509.Certifica
 	return &user.DefaultInfo{Name: chain[0].EmailAddresses[0]}, true, nil
 })
 
+// UserRestriction limits the users a client certificate may authenticate
+type UserRestriction struct {
+	// UserPrefix is the prefix the name of the user must start with
+	UserPrefix string
+	// GroupPrefix is the prefix every group of the user must start with
+	GroupPrefix string
+	// AllowedGroups, if not nil, are the only groups the user may be a member of
+	AllowedGroups sets.String
+}
+
+// Check returns an error if u is not allowed by the restriction
+func (r UserRestriction) Check(u user.Info) error {
+	if !strings.HasPrefix(u.GetName(), r.UserPrefix) {
+		return fmt.Errorf("client certificate user %q does not start with %q", u.GetName(), r.UserPrefix)
+	}
+	for _, group := range u.GetGroups() {
+		if !strings.HasPrefix(group, r.GroupPrefix) {
+			return fmt.Errorf("client certificate group %q does not start with %q", group, r.GroupPrefix)
+		}
+		if r.AllowedGroups != nil && !r.AllowedGroups.Has(group) {
+			return fmt.Errorf("client certificate group %q is not allowed", group)
+		}
+	}
+	return nil
+}
+
+// NewRestrictedUserConversion returns a UserConversion which converts certificate chains with conversion, and
+// rejects the users not allowed by restriction
+func NewRestrictedUserConversion(conversion UserConversion, restriction UserRestriction) UserConversion {
+	return UserConversionFunc(func(chain []*x509.Certificate) (user.Info, bool, error) {
+		u, ok, err := conversion.User(chain)
+		if err != nil || !ok {
+			return u, ok, err
+		}
+		if err := restriction.Check(u); err != nil {
+			return nil, false, err
+		}
+		return u, true, nil
+	})
+}
+
 func UserToSubject(u user.Info) pkix.Name {
 	return pkix.Name{
 		CommonName:   u.GetName(),
@@ -3,6 +3,7 @@ package x509request
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/util/sets"
 )
 
 const (
@@ -536,6 +538,8 @@ func TestX509Verifier(t *testing.T) {
 
 		Opts x509.VerifyOptions
 
+		AllowedCNs sets.String
+
 		ExpectOK  bool
 		ExpectErr bool
 	}{
@@ -580,6 +584,23 @@ func TestX509Verifier(t *testing.T) {
 			ExpectErr: false,
 		},
 
+		"valid client cert with allowed CN": {
+			Opts:       getDefaultVerifyOptions(t),
+			AllowedCNs: sets.NewString("foo", "client_cn", "bar"),
+			Certs:      getCerts(t, clientCNCert),
+
+			ExpectOK:  true,
+			ExpectErr: false,
+		},
+		"valid client cert with disallowed CN": {
+			Opts:       getDefaultVerifyOptions(t),
+			AllowedCNs: sets.NewString("foo", "bar"),
+			Certs:      getCerts(t, clientCNCert),
+
+			ExpectOK:  false,
+			ExpectErr: true,
+		},
+
 		"future cert": {
 			Opts: x509.VerifyOptions{
 				CurrentTime: time.Now().Add(-100 * time.Hour * 24 * 365),
@@ -614,7 +635,7 @@ func TestX509Verifier(t *testing.T) {
 			return &user.DefaultInfo{Name: "innerauth"}, true, nil
 		})
 
-		a := NewVerifier(testCase.Opts, auth)
+		a := NewVerifier(testCase.Opts, auth, testCase.AllowedCNs)
 
 		user, ok, err := a.AuthenticateRequest(req)
 
@@ -650,6 +671,129 @@ func TestX509Verifier(t *testing.T) {
 	}
 }
 
+func TestRestrictedUserConversion(t *testing.T) {
+	testCases := map[string]struct {
+		Subject     pkix.Name
+		Restriction UserRestriction
+
+		ExpectOK  bool
+		ExpectErr bool
+	}{
+		"no restriction": {
+			Subject:  pkix.Name{CommonName: "bob", Organization: []string{"developers"}},
+			ExpectOK: true,
+		},
+		"system prefix": {
+			Subject:     pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:nodes"}},
+			Restriction: UserRestriction{UserPrefix: "system:", GroupPrefix: "system:"},
+			ExpectOK:    true,
+		},
+		"user without system prefix": {
+			Subject:     pkix.Name{CommonName: "bob"},
+			Restriction: UserRestriction{UserPrefix: "system:", GroupPrefix: "system:"},
+			ExpectErr:   true,
+		},
+		"group without system prefix": {
+			Subject:     pkix.Name{CommonName: "system:admin", Organization: []string{"developers"}},
+			Restriction: UserRestriction{UserPrefix: "system:", GroupPrefix: "system:"},
+			ExpectErr:   true,
+		},
+		"allowed group": {
+			Subject:     pkix.Name{CommonName: "partner:bob", Organization: []string{"partners"}},
+			Restriction: UserRestriction{UserPrefix: "partner:", AllowedGroups: sets.NewString("partners")},
+			ExpectOK:    true,
+		},
+		"group not allowed": {
+			Subject:     pkix.Name{CommonName: "partner:bob", Organization: []string{"system:cluster-admins"}},
+			Restriction: UserRestriction{UserPrefix: "partner:", AllowedGroups: sets.NewString("partners")},
+			ExpectErr:   true,
+		},
+		"no group allowed": {
+			Subject:     pkix.Name{CommonName: "partner:bob", Organization: []string{"partners"}},
+			Restriction: UserRestriction{UserPrefix: "partner:", AllowedGroups: sets.NewString()},
+			ExpectErr:   true,
+		},
+		"no user": {
+			Subject:     pkix.Name{Organization: []string{"partners"}},
+			Restriction: UserRestriction{AllowedGroups: sets.NewString("partners")},
+		},
+	}
+
+	for k, testCase := range testCases {
+		conversion := NewRestrictedUserConversion(SubjectToUserConversion, testCase.Restriction)
+		user, ok, err := conversion.User([]*x509.Certificate{{Subject: testCase.Subject}})
+		if testCase.ExpectErr != (err != nil) {
+			t.Errorf("%s: expected error=%v, got %v", k, testCase.ExpectErr, err)
+			continue
+		}
+		if testCase.ExpectOK != ok {
+			t.Errorf("%s: expected ok=%v, got %v", k, testCase.ExpectOK, ok)
+			continue
+		}
+		if ok && user.GetName() != testCase.Subject.CommonName {
+			t.Errorf("%s: expected user %s, got %s", k, testCase.Subject.CommonName, user.GetName())
+		}
+	}
+}
+
+func TestIgnoreUnknownAuthority(t *testing.T) {
+	// the test certificates are only valid until the end of 2024
+	now := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(getCert(t, selfSignedCert))
+
+	testCases := map[string]struct {
+		Roots *x509.CertPool
+		Certs []*x509.Certificate
+
+		ExpectOK  bool
+		ExpectErr bool
+	}{
+		"known authority": {
+			Roots:    getRootCertPool(t),
+			Certs:    getCerts(t, clientCNCert),
+			ExpectOK: true,
+		},
+		"unknown authority": {
+			Roots: otherRoots,
+			Certs: getCerts(t, clientCNCert),
+		},
+		"self signed": {
+			Roots: getRootCertPool(t),
+			Certs: getCerts(t, selfSignedCert),
+		},
+		"known authority with disallowed usage": {
+			Roots:     getRootCertPool(t),
+			Certs:     getCerts(t, serverCert),
+			ExpectErr: true,
+		},
+		"unknown authority with disallowed usage": {
+			Roots:     getRootCertPool(t),
+			Certs:     getCerts(t, selfSignedCert, serverCert),
+			ExpectErr: true,
+		},
+	}
+
+	for k, testCase := range testCases {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.TLS = &tls.ConnectionState{PeerCertificates: testCase.Certs}
+
+		opts := DefaultVerifyOptions()
+		opts.Roots = testCase.Roots
+		opts.CurrentTime = now
+		a := IgnoreUnknownAuthority(New(opts, CommonNameUserConversion))
+
+		_, ok, err := a.AuthenticateRequest(req)
+		if testCase.ExpectErr != (err != nil) {
+			t.Errorf("%s: expected error=%v, got %v", k, testCase.ExpectErr, err)
+			continue
+		}
+		if testCase.ExpectOK != ok {
+			t.Errorf("%s: expected ok=%v, got %v", k, testCase.ExpectOK, ok)
+		}
+	}
+}
+
 func getDefaultVerifyOptions(t *testing.T) x509.VerifyOptions {
 	options := DefaultVerifyOptions()
 	options.Roots = getRootCertPool(t)
@@ -70,3 +70,13 @@ func (c *CacheAuthenticator) AuthenticateToken(token string) (user.Info, bool, e
 
 	return u, ok, err
 }
+
+// Invalidate removes the cached result for token, so it is authenticated again the next time it is presented
+func (c *CacheAuthenticator) Invalidate(token string) {
+	c.cache.Remove(token)
+}
+
+// InvalidateAll removes every cached result
+func (c *CacheAuthenticator) InvalidateAll() {
+	c.cache.Purge()
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+)
+
+// TokenReview is posted to the token review service to check a token, and returned by the service with its status
+// filled. It follows the TokenReview objects of the authentication.k8s.io/v1beta1 API group.
+type TokenReview struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Spec       TokenReviewSpec   `json:"spec"`
+	Status     TokenReviewStatus `json:"status"`
+}
+
+// TokenReviewSpec holds the token to review
+type TokenReviewSpec struct {
+	Token string `json:"token"`
+}
+
+// TokenReviewStatus holds the result of the review
+type TokenReviewStatus struct {
+	// Authenticated is true if the token is valid
+	Authenticated bool `json:"authenticated"`
+	// User is the user the token belongs to
+	User UserInfo `json:"user"`
+	// Error describes why the token could not be reviewed
+	Error string `json:"error,omitempty"`
+}
+
+// UserInfo describes the user a token belongs to
+type UserInfo struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid"`
+	Groups   []string `json:"groups"`
+}
+
+// requestTimeout bounds the time a request waits for the token review service
+const requestTimeout = 30 * time.Second
+
+type Authenticator struct {
+	client *http.Client
+	url    string
+}
+
+// NewFromConfigFile returns an authenticator posting TokenReview objects to the server of the current context of the
+// given kubeconfig file, with the credentials of its current user
+func NewFromConfigFile(configFile string) (*Authenticator, error) {
+	loadingRules := &clientcmd.ClientConfigLoadingRules{}
+	loadingRules.ExplicitPath = configFile
+	loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	config, err := loader.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return New(config)
+}
+
+// New returns an authenticator posting TokenReview objects to the host of config
+func New(config *kclient.Config) (*Authenticator, error) {
+	if len(config.Host) == 0 {
+		return nil, errors.New("the token review service must have a server")
+	}
+	transport, err := kclient.TransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Authenticator{
+		client: &http.Client{Transport: transport, Timeout: requestTimeout},
+		url:    config.Host,
+	}, nil
+}
+
+func (a *Authenticator) AuthenticateToken(value string) (user.Info, bool, error) {
+	if len(value) == 0 {
+		return nil, false, nil
+	}
+
+	review := &TokenReview{
+		APIVersion: "authentication.k8s.io/v1beta1",
+		Kind:       "TokenReview",
+		Spec:       TokenReviewSpec{Token: value},
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := http.NewRequest("POST", a.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, false, fmt.Errorf("the token review service returned %s", resp.Status)
+	}
+
+	result := &TokenReview{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, false, fmt.Errorf("unable to read the response of the token review service: %v", err)
+	}
+	if !result.Status.Authenticated {
+		if len(result.Status.Error) > 0 {
+			return nil, false, fmt.Errorf("the token review service failed: %s", result.Status.Error)
+		}
+		return nil, false, nil
+	}
+	if len(result.Status.User.Username) == 0 {
+		return nil, false, errors.New("the token review service authenticated a token without a user name")
+	}
+
+	return &user.DefaultInfo{
+		Name:   result.Status.User.Username,
+		UID:    result.Status.User.UID,
+		Groups: result.Status.User.Groups,
+	}, true, nil
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+func TestAuthenticateToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" {
+			t.Errorf("expected a POST, got %s", req.Method)
+		}
+		if auth := req.Header.Get("Authorization"); auth != "Bearer service-token" {
+			t.Errorf("expected the credentials of the config, got %q", auth)
+		}
+		review := &TokenReview{}
+		if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if review.Kind != "TokenReview" {
+			t.Errorf("expected a TokenReview, got %s", review.Kind)
+		}
+
+		switch review.Spec.Token {
+		case "valid":
+			review.Status = TokenReviewStatus{Authenticated: true, User: UserInfo{Username: "alice", UID: "1", Groups: []string{"admins"}}}
+		case "anonymous":
+			review.Status = TokenReviewStatus{Authenticated: true}
+		case "failing":
+			review.Status = TokenReviewStatus{Error: "directory unavailable"}
+		case "broken":
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(review)
+	}))
+	defer server.Close()
+
+	a, err := New(&kclient.Config{Host: server.URL, BearerToken: "service-token"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := map[string]struct {
+		Token        string
+		ExpectedUser user.Info
+		ExpectedOK   bool
+		ExpectedErr  bool
+	}{
+		"valid": {
+			Token:        "valid",
+			ExpectedUser: &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"admins"}},
+			ExpectedOK:   true,
+		},
+		"invalid": {
+			Token: "invalid",
+		},
+		"empty": {
+			Token: "",
+		},
+		"without user name": {
+			Token:       "anonymous",
+			ExpectedErr: true,
+		},
+		"review error": {
+			Token:       "failing",
+			ExpectedErr: true,
+		},
+		"server error": {
+			Token:       "broken",
+			ExpectedErr: true,
+		},
+	}
+
+	for k, tc := range testCases {
+		u, ok, err := a.AuthenticateToken(tc.Token)
+		if (err != nil) != tc.ExpectedErr {
+			t.Errorf("%s: unexpected error: %v", k, err)
+		}
+		if ok != tc.ExpectedOK {
+			t.Errorf("%s: expected ok=%v, got %v", k, tc.ExpectedOK, ok)
+		}
+		if !reflect.DeepEqual(tc.ExpectedUser, u) {
+			t.Errorf("%s: expected user %#v, got %#v", k, tc.ExpectedUser, u)
+		}
+	}
+}
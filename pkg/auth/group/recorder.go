@@ -0,0 +1,114 @@
+package group
+
+import (
+	"strings"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	userregistry "github.com/openshift/origin/pkg/user/registry/user"
+)
+
+// reservedPrefix starts the names of the groups of the system, which identity providers may never add users to
+const reservedPrefix = "system:"
+
+// Filter selects the groups reported by an identity provider that users are added to
+type Filter struct {
+	// Prefix is prepended to the names of the groups
+	Prefix string
+	// Allowed, if not empty, are the only groups, before Prefix is prepended, that users may be added to
+	Allowed sets.String
+}
+
+// Apply returns the names of the groups of groups that pass the filter, with the prefix of the filter. Groups in
+// the system: namespace are always dropped.
+func (f Filter) Apply(groups []string) []string {
+	filtered := []string{}
+	for _, group := range groups {
+		if len(group) == 0 || (len(f.Allowed) > 0 && !f.Allowed.Has(group)) {
+			continue
+		}
+		name := f.Prefix + group
+		if strings.HasPrefix(name, reservedPrefix) {
+			glog.V(2).Infof("Ignoring the group %q, identity providers cannot add users to %s groups", name, reservedPrefix)
+			continue
+		}
+		filtered = append(filtered, name)
+	}
+	return filtered
+}
+
+// Recorder records the groups an identity provider reports for a user in the groups of the User. The groups are
+// listed in an annotation of the User so that they are removed when the provider stops reporting them, while
+// the groups the user was added to otherwise are kept.
+type Recorder struct {
+	annotation string
+	filter     Filter
+	users      userregistry.Registry
+}
+
+// NewRecorder returns a Recorder of the groups that pass filter, which keeps track of them in the annotation of
+// users
+func NewRecorder(annotation string, filter Filter, users userregistry.Registry) *Recorder {
+	return &Recorder{annotation: annotation, filter: filter, users: users}
+}
+
+// Record replaces the groups previously recorded for the user of info with groups, and returns info with the
+// groups of the user
+func (r *Recorder) Record(info user.Info, groups []string) (user.Info, error) {
+	groups = r.filter.Apply(groups)
+	recorded := strings.Join(groups, ",")
+
+	ctx := kapi.NewContext()
+	var current []string
+	err := kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		u, err := r.users.GetUser(ctx, info.GetName())
+		if err != nil {
+			return err
+		}
+
+		previous := sets.NewString()
+		if value := u.Annotations[r.annotation]; len(value) > 0 {
+			previous.Insert(strings.Split(value, ",")...)
+		}
+		updated := []string{}
+		for _, group := range u.Groups {
+			if !previous.Has(group) {
+				updated = append(updated, group)
+			}
+		}
+		for _, group := range groups {
+			if !sets.NewString(updated...).Has(group) {
+				updated = append(updated, group)
+			}
+		}
+
+		if sets.NewString(updated...).Equal(sets.NewString(u.Groups...)) && u.Annotations[r.annotation] == recorded {
+			current = u.Groups
+			return nil
+		}
+		u.Groups = updated
+		if u.Annotations == nil {
+			u.Annotations = map[string]string{}
+		}
+		u.Annotations[r.annotation] = recorded
+		if u, err = r.users.UpdateUser(ctx, u); err != nil {
+			return err
+		}
+		current = u.Groups
+		return nil
+	})
+	if err != nil {
+		return info, err
+	}
+
+	return &user.DefaultInfo{
+		Name:   info.GetName(),
+		UID:    info.GetUID(),
+		Groups: current,
+	}, nil
+}
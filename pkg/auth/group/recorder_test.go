@@ -0,0 +1,129 @@
+package group
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	userapi "github.com/openshift/origin/pkg/user/api"
+	"github.com/openshift/origin/pkg/user/registry/test"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		filter   Filter
+		groups   []string
+		expected []string
+	}{
+		"no filter": {
+			groups:   []string{"developers", ""},
+			expected: []string{"developers"},
+		},
+		"prefix": {
+			filter:   Filter{Prefix: "ldap:"},
+			groups:   []string{"developers", "system:masters"},
+			expected: []string{"ldap:developers", "ldap:system:masters"},
+		},
+		"allowed": {
+			filter:   Filter{Allowed: sets.NewString("developers")},
+			groups:   []string{"developers", "admins"},
+			expected: []string{"developers"},
+		},
+		"system groups": {
+			groups:   []string{"system:masters", "system:cluster-admins", "developers"},
+			expected: []string{"developers"},
+		},
+		"allowed system groups": {
+			filter:   Filter{Allowed: sets.NewString("system:masters")},
+			groups:   []string{"system:masters"},
+			expected: []string{},
+		},
+	}
+	for name, tc := range testCases {
+		if groups := tc.filter.Apply(tc.groups); !reflect.DeepEqual(groups, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, groups)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	users := test.NewUserRegistry()
+	users.Get["jane"] = &userapi.User{
+		ObjectMeta: kapi.ObjectMeta{Name: "jane", Annotations: map[string]string{"groups": "old"}},
+		Groups:     []string{"admins", "old"},
+	}
+
+	r := NewRecorder("groups", Filter{}, users)
+	info, err := r.Record(&user.DefaultInfo{Name: "jane", UID: "1"}, []string{"developers", "system:masters"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"admins", "developers"}; !reflect.DeepEqual(info.GetGroups(), expected) {
+		t.Errorf("expected groups %v, got %v", expected, info.GetGroups())
+	}
+	if len(*users.Actions) != 2 || (*users.Actions)[1].Name != "UpdateUser" {
+		t.Fatalf("expected the user to be updated, got %#v", *users.Actions)
+	}
+	updated := (*users.Actions)[1].Object.(*userapi.User)
+	if annotation := updated.Annotations["groups"]; annotation != "developers" {
+		t.Errorf("expected the groups to be recorded, got %q", annotation)
+	}
+
+	// recording the same groups again leaves the user alone
+	*users.Actions = nil
+	if _, err := r.Record(&user.DefaultInfo{Name: "jane", UID: "1"}, []string{"developers"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*users.Actions) != 1 {
+		t.Errorf("expected the user not to be updated, got %#v", *users.Actions)
+	}
+}
+
+// conflictingUserRegistry fails the first update of a user with a conflict, and returns copies of the users like
+// the real registry
+type conflictingUserRegistry struct {
+	*test.UserRegistry
+	conflicted bool
+}
+
+func (r *conflictingUserRegistry) GetUser(ctx kapi.Context, name string) (*userapi.User, error) {
+	u, err := r.UserRegistry.GetUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (r *conflictingUserRegistry) UpdateUser(ctx kapi.Context, u *userapi.User) (*userapi.User, error) {
+	if !r.conflicted {
+		r.conflicted = true
+		*r.Actions = append(*r.Actions, test.Action{Name: "UpdateUser", Object: u})
+		return nil, kerrs.NewConflict("User", u.Name, nil)
+	}
+	return r.UserRegistry.UpdateUser(ctx, u)
+}
+
+func TestRecordRetriesConflicts(t *testing.T) {
+	users := &conflictingUserRegistry{UserRegistry: test.NewUserRegistry()}
+	users.Get["jane"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "jane"}}
+
+	info, err := NewRecorder("groups", Filter{}, users).Record(&user.DefaultInfo{Name: "jane", UID: "1"}, []string{"developers"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"developers"}; !reflect.DeepEqual(info.GetGroups(), expected) {
+		t.Errorf("expected groups %v, got %v", expected, info.GetGroups())
+	}
+	actions := []string{}
+	for _, action := range *users.Actions {
+		actions = append(actions, action.Name)
+	}
+	if expected := []string{"GetUser", "UpdateUser", "GetUser", "UpdateUser"}; !reflect.DeepEqual(actions, expected) {
+		t.Errorf("expected actions %v, got %v", expected, actions)
+	}
+}
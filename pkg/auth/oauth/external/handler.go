@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/RangelReale/osincli"
 	"github.com/golang/glog"
@@ -21,48 +22,71 @@ import (
 type Handler struct {
 	provider     Provider
 	state        State
-	clientConfig *osincli.ClientConfig
-	client       *osincli.Client
+	redirectURL  string
 	success      handlers.AuthenticationSuccessHandler
 	errorHandler handlers.AuthenticationErrorHandler
 	mapper       authapi.UserIdentityMapper
+
+	// clientLock guards client, which is created on first use
+	clientLock sync.Mutex
+	client     *osincli.Client
 }
 
+// NewExternalOAuthRedirector returns a handler for the flow of provider. The OAuth client of the provider is created
+// on first use, so that providers which discover their endpoints do not block the start of the master.
 func NewExternalOAuthRedirector(provider Provider, state State, redirectURL string, success handlers.AuthenticationSuccessHandler, errorHandler handlers.AuthenticationErrorHandler, mapper authapi.UserIdentityMapper) (*Handler, error) {
-	clientConfig, err := provider.NewConfig()
+	return &Handler{
+		provider:     provider,
+		state:        state,
+		redirectURL:  redirectURL,
+		success:      success,
+		errorHandler: errorHandler,
+		mapper:       mapper,
+	}, nil
+}
+
+// getClient returns the OAuth client of the provider, creating it the first time. It is created again by later
+// calls when that fails.
+func (h *Handler) getClient() (*osincli.Client, error) {
+	h.clientLock.Lock()
+	defer h.clientLock.Unlock()
+	if h.client != nil {
+		return h.client, nil
+	}
+
+	clientConfig, err := h.provider.NewConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	clientConfig.RedirectUrl = redirectURL
+	clientConfig.RedirectUrl = h.redirectURL
 
 	client, err := osincli.NewClient(clientConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	transport, err := provider.GetTransport()
+	transport, err := h.provider.GetTransport()
 	if err != nil {
 		return nil, err
 	}
 	client.Transport = transport
 
-	return &Handler{
-		provider:     provider,
-		state:        state,
-		clientConfig: clientConfig,
-		client:       client,
-		success:      success,
-		errorHandler: errorHandler,
-		mapper:       mapper,
-	}, nil
+	h.client = client
+	return client, nil
 }
 
 // AuthenticationRedirect implements oauth.handlers.RedirectAuthHandler
 func (h *Handler) AuthenticationRedirect(w http.ResponseWriter, req *http.Request) error {
 	glog.V(4).Infof("Authentication needed for %v", h)
 
-	authReq := h.client.NewAuthorizeRequest(osincli.CODE)
+	client, err := h.getClient()
+	if err != nil {
+		glog.V(4).Infof("Error creating the client of the provider: %v", err)
+		return err
+	}
+
+	authReq := client.NewAuthorizeRequest(osincli.CODE)
 	h.provider.AddCustomParameters(authReq)
 
 	state, err := h.state.Generate(w, req)
@@ -80,9 +104,15 @@ func (h *Handler) AuthenticationRedirect(w http.ResponseWriter, req *http.Reques
 
 // ServeHTTP handles the callback request in response to an external oauth flow
 func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	client, err := h.getClient()
+	if err != nil {
+		glog.V(4).Infof("Error creating the client of the provider: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
 
 	// Extract auth code
-	authReq := h.client.NewAuthorizeRequest(osincli.CODE)
+	authReq := client.NewAuthorizeRequest(osincli.CODE)
 	authData, err := authReq.HandleRequest(req)
 	if err != nil {
 		glog.V(4).Infof("Error handling request: %v", err)
@@ -107,7 +137,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 
 	// Exchange code for a token
-	accessReq := h.client.NewAccessRequest(osincli.AUTHORIZATION_CODE, authData)
+	accessReq := client.NewAccessRequest(osincli.AUTHORIZATION_CODE, authData)
 	accessData, err := accessReq.GetToken()
 	if err != nil {
 		glog.V(4).Infof("Error getting access token: %v", err)
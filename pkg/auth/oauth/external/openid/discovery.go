@@ -0,0 +1,104 @@
+package openid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/go-oidc/jose"
+	"github.com/coreos/go-oidc/key"
+)
+
+// DiscoveryPath is the path of the discovery document of an OpenID provider, relative to its issuer URL
+// http://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfig
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// Discovery is the subset of the discovery document of an OpenID provider used to authenticate with it
+// http://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the discovery document of the OpenID provider identified by issuer
+func Discover(issuer string, transport http.RoundTripper) (*Discovery, error) {
+	data, err := fetch(strings.TrimSuffix(issuer, "/")+DiscoveryPath, transport)
+	if err != nil {
+		return nil, err
+	}
+	discovery := &Discovery{}
+	if err := json.Unmarshal(data, discovery); err != nil {
+		return nil, fmt.Errorf("Error parsing discovery document: %v", err)
+	}
+
+	// The issuer value returned MUST be identical to the Issuer URL that was directly used to retrieve the configuration information
+	// http://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("Discovery document issuer (%s) did not match %s", discovery.Issuer, issuer)
+	}
+	if len(discovery.AuthorizationEndpoint) == 0 || len(discovery.TokenEndpoint) == 0 || len(discovery.JWKSURI) == 0 {
+		return nil, errors.New("Discovery document must contain the authorization_endpoint, token_endpoint and jwks_uri")
+	}
+
+	// The endpoints are used like the configured URLs, so they must use TLS too
+	if err := validateSecureURL(discovery.AuthorizationEndpoint, "Discovery document authorization_endpoint", true); err != nil {
+		return nil, err
+	}
+	if err := validateSecureURL(discovery.TokenEndpoint, "Discovery document token_endpoint", true); err != nil {
+		return nil, err
+	}
+	if err := validateSecureURL(discovery.UserInfoEndpoint, "Discovery document userinfo_endpoint", false); err != nil {
+		return nil, err
+	}
+	if err := validateSecureURL(discovery.JWKSURI, "Discovery document jwks_uri", true); err != nil {
+		return nil, err
+	}
+	return discovery, nil
+}
+
+// fetchKeys fetches the keys an OpenID provider signs ID tokens with from its JWK set URL
+// http://openid.net/specs/openid-connect-core-1_0.html#RotateSigKeys
+func fetchKeys(url string, transport http.RoundTripper) ([]key.PublicKey, error) {
+	data, err := fetch(url, transport)
+	if err != nil {
+		return nil, err
+	}
+	var keySet struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("Error parsing JWK set: %v", err)
+	}
+
+	keys := []key.PublicKey{}
+	for _, data := range keySet.Keys {
+		// only RSA signing keys are supported, skip the others
+		jwk := jose.JWK{}
+		if err := json.Unmarshal(data, &jwk); err != nil || jwk.Type != "RSA" || (len(jwk.Use) != 0 && jwk.Use != "sig") {
+			continue
+		}
+		keys = append(keys, *key.NewPublicKey(jwk))
+	}
+	return keys, nil
+}
+
+// fetch returns the body of a successful GET request of url
+func fetch(url string, transport http.RoundTripper) ([]byte, error) {
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Non-200 response from %s: %d", url, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
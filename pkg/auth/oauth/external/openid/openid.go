@@ -9,8 +9,13 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/RangelReale/osincli"
+	"github.com/coreos/go-oidc/jose"
+	"github.com/coreos/go-oidc/key"
+	"github.com/coreos/go-oidc/oidc"
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/util/sets"
 
@@ -24,6 +29,11 @@ const (
 	PreferredUsernameClaim = "preferred_username"
 	EmailClaim             = "email"
 	NameClaim              = "name"
+
+	// keysTTL is how long the keys signing ID tokens are cached before they are fetched again
+	keysTTL = time.Hour
+	// keysRefreshInterval is how often the cached keys are fetched again at most for ID tokens signed by none of them
+	keysRefreshInterval = time.Minute
 )
 
 type TokenValidator func(map[string]interface{}) error
@@ -40,6 +50,13 @@ type Config struct {
 	TokenURL     string
 	UserInfoURL  string
 
+	// Issuer is the expected iss claim of ID tokens, or empty to accept any issuer. If set, the URLs which are not
+	// set are read from its discovery document on first use.
+	Issuer string
+	// KeysURL is the URL of the JWK set whose keys must sign ID tokens, or empty to skip signature verification
+	// unless it is discovered from the issuer
+	KeysURL string
+
 	IDClaims                []string
 	PreferredUsernameClaims []string
 	EmailClaims             []string
@@ -52,17 +69,22 @@ type provider struct {
 	providerName string
 	transport    http.RoundTripper
 	Config
+
+	// lock guards the discovery document and the cached keys
+	lock sync.Mutex
+	// discovery is the discovery document of the issuer, once fetched
+	discovery *Discovery
+	// keys are the cached keys signing ID tokens, fetched at keysFetched
+	keys        []key.PublicKey
+	keysFetched time.Time
 }
 
 // NewProvider returns an implementation of an OpenID Connect Authorization Code Flow
 // See http://openid.net/specs/openid-connect-core-1_0.html#CodeFlowAuth
 // ID Token decryption is not supported
 // UserInfo decryption is not supported
+// The URLs which are not set are read from the discovery document of the issuer, if set
 func NewProvider(providerName string, transport http.RoundTripper, config Config) (external.Provider, error) {
-	// TODO: Add support for discovery documents
-	// see http://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfig
-	// e.g. https://accounts.google.com/.well-known/openid-configuration
-
 	// Validate client id/secret
 	if len(config.ClientID) == 0 {
 		return nil, errors.New("ClientID is required")
@@ -71,29 +93,18 @@ func NewProvider(providerName string, transport http.RoundTripper, config Config
 		return nil, errors.New("ClientSecret is required")
 	}
 
-	// Validate url presence
-	if len(config.AuthorizeURL) == 0 {
-		return nil, errors.New("Authorize URL is required")
-	} else if u, err := url.Parse(config.AuthorizeURL); err != nil {
-		return nil, errors.New("Authorize URL is invalid")
-	} else if u.Scheme != "https" {
-		return nil, errors.New("Authorize URL must use https scheme")
+	// Validate url presence, the authorize and token URLs may be discovered from the issuer instead
+	if err := validateSecureURL(config.AuthorizeURL, "Authorize URL", len(config.Issuer) == 0); err != nil {
+		return nil, err
 	}
-
-	if len(config.TokenURL) == 0 {
-		return nil, errors.New("Token URL is required")
-	} else if u, err := url.Parse(config.TokenURL); err != nil {
-		return nil, errors.New("Token URL is invalid")
-	} else if u.Scheme != "https" {
-		return nil, errors.New("Token URL must use https scheme")
+	if err := validateSecureURL(config.TokenURL, "Token URL", len(config.Issuer) == 0); err != nil {
+		return nil, err
 	}
-
-	if len(config.UserInfoURL) > 0 {
-		if u, err := url.Parse(config.UserInfoURL); err != nil {
-			return nil, errors.New("UserInfo URL is invalid")
-		} else if u.Scheme != "https" {
-			return nil, errors.New("UserInfo URL must use https scheme")
-		}
+	if err := validateSecureURL(config.UserInfoURL, "UserInfo URL", false); err != nil {
+		return nil, err
+	}
+	if err := validateSecureURL(config.KeysURL, "Keys URL", false); err != nil {
+		return nil, err
 	}
 
 	if !sets.NewString(config.Scopes...).Has("openid") {
@@ -104,36 +115,96 @@ func NewProvider(providerName string, transport http.RoundTripper, config Config
 		return nil, errors.New("IDClaims must specify at least one claim")
 	}
 
-	return provider{providerName, transport, config}, nil
+	return &provider{providerName: providerName, transport: transport, Config: config}, nil
+}
+
+// validateSecureURL checks that value is a URL using the https scheme, if it is set or required. name describes the
+// URL in the errors.
+func validateSecureURL(value, name string, required bool) error {
+	if len(value) == 0 {
+		if required {
+			return fmt.Errorf("%s is required", name)
+		}
+		return nil
+	}
+	if u, err := url.Parse(value); err != nil {
+		return fmt.Errorf("%s is invalid", name)
+	} else if u.Scheme != "https" {
+		return fmt.Errorf("%s must use https scheme", name)
+	}
+	return nil
+}
+
+// getConfig returns the config of the provider with the URLs which are not set read from the discovery document of
+// the issuer. The document is fetched on first use rather than when the provider is created, so that an issuer which
+// is down does not block the start of the master, and fetched again by later calls while that fails.
+func (p *provider) getConfig() (Config, error) {
+	config := p.Config
+	if len(config.Issuer) == 0 {
+		return config, nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.discovery == nil {
+		discovery, err := Discover(config.Issuer, p.transport)
+		if err != nil {
+			return config, fmt.Errorf("Error fetching the discovery document of %s: %v", config.Issuer, err)
+		}
+		p.discovery = discovery
+	}
+
+	if len(config.AuthorizeURL) == 0 {
+		config.AuthorizeURL = p.discovery.AuthorizationEndpoint
+	}
+	if len(config.TokenURL) == 0 {
+		config.TokenURL = p.discovery.TokenEndpoint
+	}
+	if len(config.UserInfoURL) == 0 {
+		config.UserInfoURL = p.discovery.UserInfoEndpoint
+	}
+	if len(config.KeysURL) == 0 {
+		config.KeysURL = p.discovery.JWKSURI
+	}
+	return config, nil
 }
 
 // NewConfig implements external/interfaces/Provider.NewConfig
-func (p provider) NewConfig() (*osincli.ClientConfig, error) {
+func (p *provider) NewConfig() (*osincli.ClientConfig, error) {
+	providerConfig, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
 	config := &osincli.ClientConfig{
 		ClientId:                 p.ClientID,
 		ClientSecret:             p.ClientSecret,
 		ErrorsInStatusCode:       true,
 		SendClientSecretInParams: true,
-		AuthorizeUrl:             p.AuthorizeURL,
-		TokenUrl:                 p.TokenURL,
+		AuthorizeUrl:             providerConfig.AuthorizeURL,
+		TokenUrl:                 providerConfig.TokenURL,
 		Scope:                    strings.Join(p.Scopes, " "),
 	}
 	return config, nil
 }
 
-func (p provider) GetTransport() (http.RoundTripper, error) {
+func (p *provider) GetTransport() (http.RoundTripper, error) {
 	return p.transport, nil
 }
 
 // AddCustomParameters implements external/interfaces/Provider.AddCustomParameters
-func (p provider) AddCustomParameters(req *osincli.AuthorizeRequest) {
+func (p *provider) AddCustomParameters(req *osincli.AuthorizeRequest) {
 	for k, v := range p.ExtraAuthorizeParameters {
 		req.CustomParameters[k] = v
 	}
 }
 
 // GetUserIdentity implements external/interfaces/Provider.GetUserIdentity
-func (p provider) GetUserIdentity(data *osincli.AccessData) (authapi.UserIdentityInfo, bool, error) {
+func (p *provider) GetUserIdentity(data *osincli.AccessData) (authapi.UserIdentityInfo, bool, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Token response MUST include id_token
 	// http://openid.net/specs/openid-connect-core-1_0.html#TokenResponse
 	idToken, ok := data.ResponseData["id_token"].(string)
@@ -147,15 +218,23 @@ func (p provider) GetUserIdentity(data *osincli.AccessData) (authapi.UserIdentit
 		return nil, false, err
 	}
 
+	// http://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+	now := time.Now()
+	if err := validateIDTokenClaims(idTokenClaims, p.Issuer, p.ClientID, now); err != nil {
+		return nil, false, err
+	}
+	if len(config.KeysURL) > 0 {
+		if err := p.verifyIDTokenSignature(idToken, config.KeysURL, now); err != nil {
+			return nil, false, err
+		}
+	}
+
 	if p.IDTokenValidator != nil {
 		if err := p.IDTokenValidator(idTokenClaims); err != nil {
 			return nil, false, err
 		}
 	}
 
-	// TODO: validate JWT
-	// http://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
-
 	// id_token MUST contain a sub claim as the subject identifier
 	// http://openid.net/specs/openid-connect-core-1_0.html#IDToken
 	idTokenSubject, ok := idTokenClaims[SubjectClaim].(string)
@@ -167,8 +246,8 @@ func (p provider) GetUserIdentity(data *osincli.AccessData) (authapi.UserIdentit
 	claims := idTokenClaims
 
 	// If we have a userinfo URL, use it to get more detailed claims
-	if len(p.UserInfoURL) != 0 {
-		userInfoClaims, err := fetchUserInfo(p.UserInfoURL, data.AccessToken, p.transport)
+	if len(config.UserInfoURL) != 0 {
+		userInfoClaims, err := fetchUserInfo(config.UserInfoURL, data.AccessToken, p.transport)
 		if err != nil {
 			return nil, false, err
 		}
@@ -216,6 +295,78 @@ func (p provider) GetUserIdentity(data *osincli.AccessData) (authapi.UserIdentit
 	return identity, true, nil
 }
 
+// validateIDTokenClaims checks that the id_token was issued by issuer, if set, to clientID and has not expired
+func validateIDTokenClaims(claims map[string]interface{}, issuer, clientID string, now time.Time) error {
+	// The Issuer Identifier for the OpenID Provider MUST exactly match the value of the iss (issuer) Claim.
+	if len(issuer) > 0 {
+		if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != strings.TrimSuffix(issuer, "/") {
+			return fmt.Errorf("id_token 'iss' claim (%s) did not match the issuer (%s)", iss, issuer)
+		}
+	}
+
+	// The Client MUST validate that the aud (audience) Claim contains its client_id value registered at the Issuer
+	// identified by the iss (issuer) Claim as an audience.
+	audiences := []string{}
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = append(audiences, aud)
+	case []interface{}:
+		for _, value := range aud {
+			if audience, ok := value.(string); ok {
+				audiences = append(audiences, audience)
+			}
+		}
+	}
+	if !sets.NewString(audiences...).Has(clientID) {
+		return fmt.Errorf("id_token 'aud' claim (%v) did not contain the client ID (%s)", claims["aud"], clientID)
+	}
+
+	// The current time MUST be before the time represented by the exp Claim.
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("id_token did not contain an 'exp' claim")
+	}
+	if !now.Before(time.Unix(int64(exp), 0)) {
+		return errors.New("id_token has expired")
+	}
+	return nil
+}
+
+// verifyIDTokenSignature checks that idToken is signed by one of the keys at keysURL. The keys are cached until they
+// expire. Providers rotate their keys, so they are also fetched again, at most every keysRefreshInterval, for an
+// idToken which is signed by none of the cached keys.
+func (p *provider) verifyIDTokenSignature(idToken, keysURL string, now time.Time) error {
+	jwt, err := jose.ParseJWT(idToken)
+	if err != nil {
+		return fmt.Errorf("Error parsing id_token: %v", err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	refresh := p.keys == nil || !now.Before(p.keysFetched.Add(keysTTL))
+	for {
+		if refresh {
+			keys, err := fetchKeys(keysURL, p.transport)
+			if err != nil {
+				return fmt.Errorf("Error fetching the keys of the provider: %v", err)
+			}
+			p.keys, p.keysFetched = keys, now
+		}
+
+		if ok, err := oidc.VerifySignature(jwt, p.keys); err != nil {
+			return fmt.Errorf("Error verifying the id_token signature: %v", err)
+		} else if ok {
+			return nil
+		}
+
+		if refresh || now.Before(p.keysFetched.Add(keysRefreshInterval)) {
+			return errors.New("id_token was not signed by the provider")
+		}
+		refresh = true
+	}
+}
+
 func getClaimValue(data map[string]interface{}, claims []string) (string, error) {
 	for _, claim := range claims {
 		value, ok := data[claim]
@@ -277,8 +428,8 @@ func decodeJWT(jwt string) (map[string]interface{}, error) {
 		encodedPayload += strings.Repeat("=", 4-l)
 	}
 
-	// Decode base-64
-	decodedPayload, err := base64.StdEncoding.DecodeString(encodedPayload)
+	// Decode base-64, JWTs use the URL-safe alphabet
+	decodedPayload, err := base64.URLEncoding.DecodeString(encodedPayload)
 	if err != nil {
 		return nil, fmt.Errorf("Error decoding payload: %v", err)
 	}
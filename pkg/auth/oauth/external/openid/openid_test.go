@@ -1,7 +1,17 @@
 package openid
 
 import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/jose"
+	"github.com/coreos/go-oidc/key"
 
 	"github.com/openshift/origin/pkg/auth/oauth/external"
 )
@@ -21,3 +31,194 @@ func TestOpenID(t *testing.T) {
 	_ = external.Provider(p)
 
 }
+
+func TestDiscover(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != DiscoveryPath {
+			http.NotFound(w, req)
+			return
+		}
+		fmt.Fprintf(w, `{"issuer": %q, "authorization_endpoint": "%[1]s/auth", "token_endpoint": "%[1]s/token", "userinfo_endpoint": "%[1]s/userinfo", "jwks_uri": "%[1]s/keys"}`, server.URL)
+	}))
+	defer server.Close()
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+	discovery, err := Discover(server.URL+"/", transport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Discovery{
+		Issuer:                server.URL,
+		AuthorizationEndpoint: server.URL + "/auth",
+		TokenEndpoint:         server.URL + "/token",
+		UserInfoEndpoint:      server.URL + "/userinfo",
+		JWKSURI:               server.URL + "/keys",
+	}
+	if !reflect.DeepEqual(discovery, expected) {
+		t.Errorf("expected %#v, got %#v", expected, discovery)
+	}
+
+	if _, err := Discover(server.URL+"/other", transport); err == nil {
+		t.Errorf("expected an error for a missing discovery document")
+	}
+}
+
+func TestDiscoverInsecureEndpoint(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"issuer": %q, "authorization_endpoint": "%[1]s/auth", "token_endpoint": "http://issuer/token", "jwks_uri": "%[1]s/keys"}`, server.URL)
+	}))
+	defer server.Close()
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+	if _, err := Discover(server.URL, transport); err == nil {
+		t.Errorf("expected an error for an endpoint not using https")
+	}
+}
+
+func TestProviderDiscoversOnFirstUse(t *testing.T) {
+	requests := 0
+	up := false
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if !up {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"issuer": %q, "authorization_endpoint": "%[1]s/auth", "token_endpoint": "%[1]s/token", "jwks_uri": "%[1]s/keys"}`, server.URL)
+	}))
+	defer server.Close()
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+	p, err := NewProvider("openid", transport, Config{
+		ClientID:     "foo",
+		ClientSecret: "secret",
+		Issuer:       server.URL,
+		Scopes:       []string{"openid"},
+		IDClaims:     []string{"sub"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request before the provider is used, got %d", requests)
+	}
+
+	if _, err := p.NewConfig(); err == nil {
+		t.Errorf("expected an error while the issuer is down")
+	}
+
+	up = true
+	for i := 0; i < 2; i++ {
+		config, err := p.NewConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.AuthorizeUrl != server.URL+"/auth" || config.TokenUrl != server.URL+"/token" {
+			t.Errorf("unexpected URLs: %s, %s", config.AuthorizeUrl, config.TokenUrl)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected the discovery document to be fetched again only after a failure, got %d requests", requests)
+	}
+}
+
+func TestValidateIDTokenClaims(t *testing.T) {
+	now := time.Unix(1000, 0)
+	testCases := map[string]struct {
+		claims map[string]interface{}
+		issuer string
+		valid  bool
+	}{
+		"valid": {
+			claims: map[string]interface{}{"iss": "https://issuer", "aud": "client", "exp": float64(2000)},
+			issuer: "https://issuer",
+			valid:  true,
+		},
+		"audiences": {
+			claims: map[string]interface{}{"aud": []interface{}{"other", "client"}, "exp": float64(2000)},
+			valid:  true,
+		},
+		"wrong issuer": {
+			claims: map[string]interface{}{"iss": "https://other", "aud": "client", "exp": float64(2000)},
+			issuer: "https://issuer",
+		},
+		"wrong audience": {
+			claims: map[string]interface{}{"aud": []interface{}{"other"}, "exp": float64(2000)},
+		},
+		"expired": {
+			claims: map[string]interface{}{"aud": "client", "exp": float64(500)},
+		},
+		"no expiry": {
+			claims: map[string]interface{}{"aud": "client"},
+		},
+	}
+	for name, tc := range testCases {
+		err := validateIDTokenClaims(tc.claims, tc.issuer, "client", now)
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestVerifyIDTokenSignature(t *testing.T) {
+	signingKey, err := key.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherKey, err := key.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string][]jose.JWK{"keys": {signingKey.JWK()}})
+	}))
+	defer server.Close()
+
+	p := &provider{transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	claims := map[string]interface{}{"sub": "user", "aud": "client"}
+	now := time.Unix(1000, 0)
+
+	signed, err := jose.NewSignedJWT(claims, signingKey.Signer())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forged, err := jose.NewSignedJWT(claims, otherKey.Signer())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		token    string
+		now      time.Time
+		valid    bool
+		requests int
+	}{
+		{name: "first token", token: signed.Encode(), now: now, valid: true, requests: 1},
+		{name: "cached keys", token: signed.Encode(), now: now.Add(time.Second), valid: true, requests: 1},
+		{name: "forged token, recently fetched keys", token: forged.Encode(), now: now.Add(time.Second), requests: 1},
+		{name: "forged token, keys fetched again", token: forged.Encode(), now: now.Add(keysRefreshInterval), requests: 2},
+		{name: "forged token, keys fetched again recently", token: forged.Encode(), now: now.Add(keysRefreshInterval + time.Second), requests: 2},
+		{name: "expired keys", token: signed.Encode(), now: now.Add(keysRefreshInterval + keysTTL), valid: true, requests: 3},
+	}
+	for _, tc := range testCases {
+		err := p.verifyIDTokenSignature(tc.token, server.URL, tc.now)
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+		if requests != tc.requests {
+			t.Errorf("%s: expected %d requests for the keys, got %d", tc.name, tc.requests, requests)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/RangelReale/osin"
 
 	"github.com/openshift/origin/pkg/auth/api"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 	"k8s.io/kubernetes/pkg/auth/user"
 )
 
@@ -122,3 +123,33 @@ func (g *redirectGrant) GrantNeeded(user user.Info, grant *api.Grant, w http.Res
 	http.Redirect(w, req, redirectURL.String(), http.StatusFound)
 	return false, true, nil
 }
+
+type perClientGrant struct {
+	defaultMethod oauthapi.GrantHandlerType
+	handlers      map[oauthapi.GrantHandlerType]GrantHandler
+}
+
+// NewPerClientGrant returns a grant handler that delegates to the handler of the grant method of the requesting
+// client, or of defaultMethod if the client does not set one. Grants of clients whose method has no handler are
+// denied.
+func NewPerClientGrant(defaultMethod oauthapi.GrantHandlerType, handlers map[oauthapi.GrantHandlerType]GrantHandler) GrantHandler {
+	return &perClientGrant{defaultMethod: defaultMethod, handlers: handlers}
+}
+
+// GrantNeeded implements the GrantHandler interface
+func (g *perClientGrant) GrantNeeded(user user.Info, grant *api.Grant, w http.ResponseWriter, req *http.Request) (bool, bool, error) {
+	client, _ := grant.Client.GetUserData().(*oauthapi.OAuthClient)
+	handler, ok := g.handlers[GrantMethod(client, g.defaultMethod)]
+	if !ok {
+		return false, false, nil
+	}
+	return handler.GrantNeeded(user, grant, w, req)
+}
+
+// GrantMethod returns the grant method of client, or defaultMethod if client is nil or does not set one
+func GrantMethod(client *oauthapi.OAuthClient, defaultMethod oauthapi.GrantHandlerType) oauthapi.GrantHandlerType {
+	if client != nil && len(client.GrantMethod) > 0 {
+		return client.GrantMethod
+	}
+	return defaultMethod
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/RangelReale/osin"
+
+	"github.com/openshift/origin/pkg/auth/api"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver"
 )
 
@@ -21,3 +26,39 @@ func TestAutoGrant(t *testing.T) {
 func TestRedirectGrant(t *testing.T) {
 	_ = NewRedirectGrant("/")
 }
+
+func TestPerClientGrant(t *testing.T) {
+	handler := NewPerClientGrant(oauthapi.GrantHandlerAuto, map[oauthapi.GrantHandlerType]GrantHandler{
+		oauthapi.GrantHandlerAuto: NewAutoGrant(),
+		oauthapi.GrantHandlerDeny: NewEmptyGrant(),
+	})
+
+	testCases := map[string]struct {
+		Client          osin.Client
+		ExpectAuthorize bool
+	}{
+		"client without grant method uses the default": {
+			Client:          &osin.DefaultClient{UserData: &oauthapi.OAuthClient{}},
+			ExpectAuthorize: true,
+		},
+		"client with grant method": {
+			Client:          &osin.DefaultClient{UserData: &oauthapi.OAuthClient{GrantMethod: oauthapi.GrantHandlerDeny}},
+			ExpectAuthorize: false,
+		},
+		"client with grant method without handler": {
+			Client:          &osin.DefaultClient{UserData: &oauthapi.OAuthClient{GrantMethod: oauthapi.GrantHandlerPrompt}},
+			ExpectAuthorize: false,
+		},
+	}
+
+	for k, testCase := range testCases {
+		req, _ := http.NewRequest("GET", "/", nil)
+		authorized, _, err := handler.GrantNeeded(nil, &api.Grant{Client: testCase.Client}, nil, req)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+		}
+		if authorized != testCase.ExpectAuthorize {
+			t.Errorf("%s: expected authorized=%v, got %v", k, testCase.ExpectAuthorize, authorized)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/storage"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	accesstokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken/etcd"
+)
+
+// TokenInvalidator forgets what it knows about access tokens
+type TokenInvalidator interface {
+	// Invalidate forgets the given access token
+	Invalidate(token string)
+	// InvalidateAll forgets every access token
+	InvalidateAll()
+}
+
+// InvalidateDeletedTokens watches the access tokens stored in etcd, and invalidates the ones that are deleted, by
+// any master, or expire. Deletions can be missed while the watch is restarted from scratch, so every token is
+// invalidated when it is. It runs until stopCh is closed.
+func InvalidateDeletedTokens(helper storage.Interface, invalidator TokenInvalidator, stopCh <-chan struct{}) {
+	resourceVersion := uint64(0)
+	util.Until(func() {
+		if resourceVersion == 0 {
+			invalidator.InvalidateAll()
+		}
+		resourceVersion = watchDeletedTokens(helper, invalidator, resourceVersion, stopCh)
+	}, time.Second, stopCh)
+}
+
+// watchDeletedTokens invalidates deleted tokens until the watch started at resourceVersion ends, and returns the
+// resource version to resume watching from, or 0 if the watch has to be restarted from scratch
+func watchDeletedTokens(helper storage.Interface, invalidator TokenInvalidator, resourceVersion uint64, stopCh <-chan struct{}) uint64 {
+	w, err := helper.WatchList(kapi.NewContext(), accesstokenetcd.EtcdPrefix, resourceVersion, storage.Everything)
+	if err != nil {
+		util.HandleError(fmt.Errorf("unable to watch access tokens: %v", err))
+		return 0
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return resourceVersion
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+			token, ok := event.Object.(*api.OAuthAccessToken)
+			if !ok {
+				glog.V(4).Infof("Restarting the watch of access tokens after %#v", event.Object)
+				return 0
+			}
+			if event.Type == watch.Deleted {
+				glog.V(5).Infof("Invalidating deleted access token for %s", token.UserName)
+				invalidator.Invalidate(token.Name)
+			}
+			if version, err := strconv.ParseUint(token.ResourceVersion, 10, 64); err == nil {
+				resourceVersion = version
+			}
+		}
+	}
+}
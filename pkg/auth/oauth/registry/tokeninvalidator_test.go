@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/storage"
+	"k8s.io/kubernetes/pkg/watch"
+
+	oapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+type watchOnlyStorage struct {
+	storage.Interface
+	watcher         *watch.FakeWatcher
+	resourceVersion uint64
+}
+
+func (s *watchOnlyStorage) WatchList(ctx context.Context, key string, resourceVersion uint64, filter storage.FilterFunc) (watch.Interface, error) {
+	s.resourceVersion = resourceVersion
+	return s.watcher, nil
+}
+
+type recordingInvalidator struct {
+	invalidated []string
+}
+
+func (i *recordingInvalidator) Invalidate(token string) {
+	i.invalidated = append(i.invalidated, token)
+}
+
+func (i *recordingInvalidator) InvalidateAll() {
+	i.invalidated = append(i.invalidated, "*")
+}
+
+func accessToken(name, resourceVersion string) *oapi.OAuthAccessToken {
+	return &oapi.OAuthAccessToken{ObjectMeta: kapi.ObjectMeta{Name: name, ResourceVersion: resourceVersion}, UserName: "user"}
+}
+
+func TestWatchDeletedTokens(t *testing.T) {
+	testCases := map[string]struct {
+		End                     func(w *watch.FakeWatcher)
+		ExpectedResourceVersion uint64
+	}{
+		"closed watch resumes": {
+			End:                     func(w *watch.FakeWatcher) { w.Stop() },
+			ExpectedResourceVersion: 3,
+		},
+		"watch error restarts": {
+			End:                     func(w *watch.FakeWatcher) { w.Error(&unversioned.Status{Message: "too old"}) },
+			ExpectedResourceVersion: 0,
+		},
+	}
+
+	for k, tc := range testCases {
+		helper := &watchOnlyStorage{watcher: watch.NewFake()}
+		invalidator := &recordingInvalidator{}
+		result := make(chan uint64)
+		go func() {
+			result <- watchDeletedTokens(helper, invalidator, 1, make(chan struct{}))
+		}()
+
+		helper.watcher.Add(accessToken("created", "2"))
+		helper.watcher.Delete(accessToken("deleted", "3"))
+		tc.End(helper.watcher)
+
+		if resourceVersion := <-result; resourceVersion != tc.ExpectedResourceVersion {
+			t.Errorf("%s: expected to resume from %d, got %d", k, tc.ExpectedResourceVersion, resourceVersion)
+		}
+		if helper.resourceVersion != 1 {
+			t.Errorf("%s: expected to watch from 1, got %d", k, helper.resourceVersion)
+		}
+		if !reflect.DeepEqual(invalidator.invalidated, []string{"deleted"}) {
+			t.Errorf("%s: expected the deleted token to be invalidated, got %v", k, invalidator.invalidated)
+		}
+	}
+}
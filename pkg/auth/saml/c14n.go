@@ -0,0 +1,268 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xmlNamespace is the namespace bound to the xml prefix, which is never declared
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// node is an element of a parsed XML document. Unlike encoding/xml, it keeps the prefixes and namespace
+// declarations of the document, which are needed to canonicalize the signed parts of it.
+type node struct {
+	prefix string
+	local  string
+	// attrs are the attributes of the element, except the namespace declarations
+	attrs []xml.Attr
+	// namespaces are the namespaces declared on the element, by prefix. The default namespace has an empty prefix.
+	namespaces map[string]string
+	// children are the *node and xml.CharData contents of the element
+	children []interface{}
+	parent   *node
+}
+
+// parseXML parses the document in data, rejecting document type declarations
+func parseXML(data []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root, current *node
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{prefix: t.Name.Space, local: t.Name.Local, namespaces: map[string]string{}, parent: current}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					n.namespaces[attr.Name.Local] = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					n.namespaces[""] = attr.Value
+				default:
+					n.attrs = append(n.attrs, attr)
+				}
+			}
+			if current != nil {
+				current.children = append(current.children, n)
+			} else if root == nil {
+				root = n
+			} else {
+				return nil, errors.New("document has more than one root element")
+			}
+			current = n
+
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.prefix || t.Name.Local != current.local {
+				return nil, fmt.Errorf("unexpected end element %s", qualifiedName(t.Name.Space, t.Name.Local))
+			}
+			current = current.parent
+
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, t.Copy())
+			}
+
+		case xml.Directive:
+			return nil, errors.New("document type declarations are not allowed")
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("document has no root element")
+	}
+	if current != nil {
+		return nil, fmt.Errorf("element %s is not closed", qualifiedName(current.prefix, current.local))
+	}
+	return root, nil
+}
+
+// lookupNamespace returns the namespace bound to prefix in the scope of n
+func (n *node) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for e := n; e != nil; e = e.parent {
+		if namespace, ok := e.namespaces[prefix]; ok {
+			return namespace, true
+		}
+	}
+	// elements without a prefix are in no namespace unless a default namespace is declared
+	return "", len(prefix) == 0
+}
+
+// is returns true if n is the element local of namespace
+func (n *node) is(namespace, local string) bool {
+	ns, _ := n.lookupNamespace(n.prefix)
+	return n.local == local && ns == namespace
+}
+
+// attr returns the value of the attribute of n without a namespace named name
+func (n *node) attr(name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// elements returns the child elements of n named local of namespace
+func (n *node) elements(namespace, local string) []*node {
+	elements := []*node{}
+	for _, child := range n.children {
+		if e, ok := child.(*node); ok && e.is(namespace, local) {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+// element returns the only child element of n named local of namespace
+func (n *node) element(namespace, local string) (*node, error) {
+	elements := n.elements(namespace, local)
+	if len(elements) != 1 {
+		return nil, fmt.Errorf("expected one %s element in %s, found %d", local, n.local, len(elements))
+	}
+	return elements[0], nil
+}
+
+// text returns the character data of n, ignoring its child elements
+func (n *node) text() string {
+	text := ""
+	for _, child := range n.children {
+		if data, ok := child.(xml.CharData); ok {
+			text += string(data)
+		}
+	}
+	return text
+}
+
+// canonicalize returns the exclusive canonical form without comments of n, leaving out the elements skip returns
+// true for. The namespaces of inclusivePrefixes are rendered as in inclusive canonicalization, "#default" being
+// the default namespace.
+// http://www.w3.org/TR/xml-exc-c14n/
+func canonicalize(n *node, inclusivePrefixes []string, skip func(*node) bool) []byte {
+	inclusive := map[string]bool{}
+	for _, prefix := range inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		inclusive[prefix] = true
+	}
+	buf := &bytes.Buffer{}
+	writeCanonical(buf, n, map[string]string{"": ""}, inclusive, skip)
+	return buf.Bytes()
+}
+
+// writeCanonical writes the canonical form of n, given the namespaces already rendered by its output ancestors
+func writeCanonical(buf *bytes.Buffer, n *node, rendered map[string]string, inclusive map[string]bool, skip func(*node) bool) {
+	// only the namespaces visibly utilized by the element and its attributes are rendered, if they were not by
+	// an output ancestor
+	utilized := map[string]bool{n.prefix: true}
+	for _, attr := range n.attrs {
+		if len(attr.Name.Space) > 0 {
+			utilized[attr.Name.Space] = true
+		}
+	}
+	for prefix := range inclusive {
+		if _, ok := n.lookupNamespace(prefix); ok {
+			utilized[prefix] = true
+		}
+	}
+
+	prefixes := []string{}
+	scope := map[string]string{}
+	for prefix, namespace := range rendered {
+		scope[prefix] = namespace
+	}
+	for prefix := range utilized {
+		namespace, ok := n.lookupNamespace(prefix)
+		if !ok || prefix == "xml" {
+			continue
+		}
+		if current, isRendered := rendered[prefix]; isRendered && current == namespace {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+		scope[prefix] = namespace
+	}
+	sort.Strings(prefixes)
+
+	attrs := make([]xml.Attr, len(n.attrs))
+	copy(attrs, n.attrs)
+	sort.Sort(byNamespace{attrs, n})
+
+	name := qualifiedName(n.prefix, n.local)
+	buf.WriteString("<" + name)
+	for _, prefix := range prefixes {
+		if len(prefix) == 0 {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		buf.WriteString(attributeEscaper.Replace(scope[prefix]) + `"`)
+	}
+	for _, attr := range attrs {
+		buf.WriteString(" " + qualifiedName(attr.Name.Space, attr.Name.Local) + `="` + attributeEscaper.Replace(attr.Value) + `"`)
+	}
+	buf.WriteString(">")
+
+	for _, child := range n.children {
+		switch c := child.(type) {
+		case *node:
+			if skip == nil || !skip(c) {
+				writeCanonical(buf, c, scope, inclusive, skip)
+			}
+		case xml.CharData:
+			buf.WriteString(textEscaper.Replace(string(c)))
+		}
+	}
+	buf.WriteString("</" + name + ">")
+}
+
+var (
+	textEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+// byNamespace sorts the attributes of an element by namespace, then by local name. Attributes without a
+// namespace come first.
+type byNamespace struct {
+	attrs   []xml.Attr
+	element *node
+}
+
+func (s byNamespace) Len() int      { return len(s.attrs) }
+func (s byNamespace) Swap(i, j int) { s.attrs[i], s.attrs[j] = s.attrs[j], s.attrs[i] }
+func (s byNamespace) Less(i, j int) bool {
+	ni, nj := "", ""
+	if len(s.attrs[i].Name.Space) > 0 {
+		ni, _ = s.element.lookupNamespace(s.attrs[i].Name.Space)
+	}
+	if len(s.attrs[j].Name.Space) > 0 {
+		nj, _ = s.element.lookupNamespace(s.attrs[j].Name.Space)
+	}
+	if ni != nj {
+		return ni < nj
+	}
+	return s.attrs[i].Name.Local < s.attrs[j].Name.Local
+}
+
+func qualifiedName(prefix, local string) string {
+	if len(prefix) == 0 {
+		return local
+	}
+	return prefix + ":" + local
+}
@@ -0,0 +1,66 @@
+package saml
+
+import (
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testCases := map[string]struct {
+		Document  string
+		Path      []int
+		Inclusive []string
+		Expected  string
+	}{
+		// http://www.w3.org/TR/xml-exc-c14n/#sec-Enveloping
+		"spec example": {
+			Document: `<n0:local xmlns:n0="foo:bar" xmlns:n3="ftp://example.org"><n1:elem2 xmlns:n1="http://example.net" xml:lang="en"><n3:stuff xmlns:n3="ftp://example.org"/></n1:elem2></n0:local>`,
+			Path:     []int{0},
+			Expected: `<n1:elem2 xmlns:n1="http://example.net" xml:lang="en"><n3:stuff xmlns:n3="ftp://example.org"></n3:stuff></n1:elem2>`,
+		},
+		"sorted and escaped attributes": {
+			Document: `<a xmlns="urn:a" xmlns:b="urn:b" xmlns:unused="urn:unused" z="1" b:y="2" a="&lt;&quot;&#10;"><c xmlns="">t &amp; &gt;</c><b:d/></a>`,
+			Expected: `<a xmlns="urn:a" xmlns:b="urn:b" a="&lt;&quot;&#xA;" z="1" b:y="2"><c xmlns="">t &amp; &gt;</c><b:d></b:d></a>`,
+		},
+		"inherited namespaces": {
+			Document: `<p:a xmlns:p="urn:p" xmlns:q="urn:q" xmlns="urn:default"><p:b><q:c/><d/></p:b></p:a>`,
+			Path:     []int{0},
+			Expected: `<p:b xmlns:p="urn:p"><q:c xmlns:q="urn:q"></q:c><d xmlns="urn:default"></d></p:b>`,
+		},
+		"inclusive namespaces": {
+			Document:  `<p:a xmlns:p="urn:p" xmlns:q="urn:q"><p:b/></p:a>`,
+			Path:      []int{0},
+			Inclusive: []string{"q"},
+			Expected:  `<p:b xmlns:p="urn:p" xmlns:q="urn:q"></p:b>`,
+		},
+		"comments and whitespace": {
+			Document: "<a>\n  <!-- comment --><b>x\r\ny</b>\n</a>",
+			Expected: "<a>\n  <b>x\ny</b>\n</a>",
+		},
+	}
+
+	for k, tc := range testCases {
+		n, err := parseXML([]byte(tc.Document))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		for _, i := range tc.Path {
+			elements := []*node{}
+			for _, child := range n.children {
+				if e, ok := child.(*node); ok {
+					elements = append(elements, e)
+				}
+			}
+			n = elements[i]
+		}
+		if actual := string(canonicalize(n, tc.Inclusive, nil)); actual != tc.Expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", k, tc.Expected, actual)
+		}
+	}
+}
+
+func TestParseXMLRejectsDocumentTypes(t *testing.T) {
+	if _, err := parseXML([]byte(`<!DOCTYPE a [<!ENTITY e "x">]><a>&e;</a>`)); err == nil {
+		t.Errorf("expected an error")
+	}
+}
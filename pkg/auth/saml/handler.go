@@ -0,0 +1,293 @@
+// Package saml delegates browser logins to a SAML 2.0 identity provider, using the Web Browser SSO profile
+// http://docs.oasis-open.org/security/saml/v2.0/saml-profiles-2.0-os.pdf section 4.1
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/auth/oauth/external"
+	"github.com/openshift/origin/pkg/auth/oauth/handlers"
+)
+
+const (
+	// bindingPOST is the binding the identity provider must send responses with
+	bindingPOST = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	// requestLifetime is how long the identity provider has to answer an authentication request
+	requestLifetime = 10 * time.Minute
+
+	// requestCookieName is the cookie holding the outstanding authentication request of a browser
+	requestCookieName = "saml_request"
+)
+
+// Config describes a SAML identity provider
+type Config struct {
+	// EntityID identifies the master to the identity provider
+	EntityID string
+	// Issuer is the entity ID of the identity provider
+	Issuer string
+	// SingleSignOnURL is the URL authentication requests are redirected to
+	SingleSignOnURL string
+	// Certificates are the certificates the identity provider signs responses or assertions with
+	Certificates []*x509.Certificate
+
+	IDAttributes                []string
+	PreferredUsernameAttributes []string
+	EmailAttributes             []string
+	NameAttributes              []string
+}
+
+// Handler exposes the login flow of a SAML identity provider as an oauth.handlers.AuthenticationRedirector, and
+// serves the assertion consumer service the identity provider posts its responses to
+type Handler struct {
+	providerName    string
+	config          Config
+	singleSignOnURL *url.URL
+	state           external.State
+	acsURL          string
+	acsPath         string
+	secureCookie    bool
+	success         handlers.AuthenticationSuccessHandler
+	errorHandler    handlers.AuthenticationErrorHandler
+	mapper          authapi.UserIdentityMapper
+
+	// assertions are the IDs of the assertions that were used, until they expire, so that they are not replayed
+	assertions *idSet
+}
+
+func NewSAMLRedirector(providerName string, config Config, state external.State, acsURL string, success handlers.AuthenticationSuccessHandler, errorHandler handlers.AuthenticationErrorHandler, mapper authapi.UserIdentityMapper) (*Handler, error) {
+	if len(config.EntityID) == 0 || len(config.Issuer) == 0 {
+		return nil, errors.New("SAML identity providers require an entity ID and an issuer")
+	}
+	if len(config.Certificates) == 0 {
+		return nil, errors.New("SAML identity providers require signing certificates")
+	}
+	singleSignOnURL, err := url.Parse(config.SingleSignOnURL)
+	if err != nil {
+		return nil, err
+	}
+	parsedACSURL, err := url.Parse(acsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		providerName:    providerName,
+		config:          config,
+		singleSignOnURL: singleSignOnURL,
+		state:           state,
+		acsURL:          acsURL,
+		acsPath:         parsedACSURL.Path,
+		secureCookie:    parsedACSURL.Scheme == "https",
+		success:         success,
+		errorHandler:    errorHandler,
+		mapper:          mapper,
+		assertions:      newIDSet(),
+	}, nil
+}
+
+// authnRequest is a SAML authentication request
+type authnRequest struct {
+	XMLName                     xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string   `xml:",attr"`
+	Version                     string   `xml:",attr"`
+	IssueInstant                string   `xml:",attr"`
+	Destination                 string   `xml:",attr"`
+	AssertionConsumerServiceURL string   `xml:",attr"`
+	ProtocolBinding             string   `xml:",attr"`
+	Issuer                      struct {
+		XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+		Value   string   `xml:",chardata"`
+	}
+}
+
+// AuthenticationRedirect implements oauth.handlers.RedirectAuthHandler by sending an authentication request to the
+// identity provider with the HTTP-Redirect binding. The state is round-tripped as the relay state.
+// http://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf section 3.4
+func (h *Handler) AuthenticationRedirect(w http.ResponseWriter, req *http.Request) error {
+	glog.V(4).Infof("Authentication needed for %v", h)
+
+	state, err := h.state.Generate(w, req)
+	if err != nil {
+		glog.V(4).Infof("Error generating state: %v", err)
+		return err
+	}
+
+	now := time.Now()
+	samlRequest, id, err := h.newAuthnRequest(now)
+	if err != nil {
+		return err
+	}
+	h.setRequestCookie(w, id, now.Add(requestLifetime))
+
+	redirectURL := *h.singleSignOnURL
+	query := redirectURL.Query()
+	query.Set("SAMLRequest", samlRequest)
+	query.Set("RelayState", state)
+	redirectURL.RawQuery = query.Encode()
+	glog.V(4).Infof("redirect to %v", redirectURL.String())
+
+	http.Redirect(w, req, redirectURL.String(), http.StatusFound)
+	return nil
+}
+
+// newAuthnRequest returns a deflated and base64 encoded authentication request, asking the identity provider to
+// post its response to the assertion consumer service, and the ID of the request
+func (h *Handler) newAuthnRequest(now time.Time) (string, string, error) {
+	id := make([]byte, 20)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+
+	request := authnRequest{
+		// IDs must not start with a digit
+		ID:                          "_" + hex.EncodeToString(id),
+		Version:                     "2.0",
+		IssueInstant:                now.UTC().Format(time.RFC3339),
+		Destination:                 h.config.SingleSignOnURL,
+		AssertionConsumerServiceURL: h.acsURL,
+		ProtocolBinding:             bindingPOST,
+	}
+	request.Issuer.Value = h.config.EntityID
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := flate.NewWriter(buf, flate.DefaultCompression)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return "", "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), request.ID, nil
+}
+
+// setRequestCookie makes the authentication request id outstanding in the browser until expires. The cookie is
+// only sent to the assertion consumer service, so that the identity provider may post its response to any master
+// and the masters keep no state per login.
+func (h *Handler) setRequestCookie(w http.ResponseWriter, id string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     requestCookieName,
+		Value:    id + "." + strconv.FormatInt(expires.Unix(), 10),
+		Path:     h.acsPath,
+		Expires:  expires,
+		Secure:   h.secureCookie,
+		HttpOnly: true,
+	})
+}
+
+// outstandingRequest returns the ID of the authentication request outstanding in the browser at time now, or
+// an empty string if there is none. The request is answered, so its cookie is cleared.
+func (h *Handler) outstandingRequest(w http.ResponseWriter, req *http.Request, now time.Time) string {
+	cookie, err := req.Cookie(requestCookieName)
+	if err != nil {
+		return ""
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     requestCookieName,
+		Path:     h.acsPath,
+		MaxAge:   -1,
+		Secure:   h.secureCookie,
+		HttpOnly: true,
+	})
+
+	parts := strings.Split(cookie.Value, ".")
+	if len(parts) != 2 {
+		return ""
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !now.Before(time.Unix(expires, 0)) {
+		return ""
+	}
+	return parts[0]
+}
+
+// ServeHTTP handles the responses posted by the identity provider to the assertion consumer service
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	samlResponse := req.PostFormValue("SAMLResponse")
+	state := req.PostFormValue("RelayState")
+	if len(samlResponse) == 0 {
+		err := errors.New("No SAML response")
+		h.handleError(err, w, req)
+		return
+	}
+
+	// Validate state before reading the response
+	ok, err := h.state.Check(state, req)
+	if !ok {
+		glog.V(4).Infof("State is invalid")
+		err := errors.New("State is invalid")
+		h.handleError(err, w, req)
+		return
+	}
+	if err != nil {
+		glog.V(4).Infof("Error verifying state: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(samlResponse), ""))
+	if err != nil {
+		glog.V(4).Infof("Error decoding SAML response: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	now := time.Now()
+	identity, err := h.identityFor(data, h.acsURL, h.outstandingRequest(w, req, now), now)
+	if err != nil {
+		glog.V(4).Infof("Error getting userIdentityInfo info: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	user, err := h.mapper.UserFor(identity)
+	glog.V(4).Infof("Got userIdentityMapping: %#v", user)
+	if err != nil {
+		glog.V(4).Infof("Error creating or updating mapping for: %#v due to %v", identity, err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	_, err = h.success.AuthenticationSucceeded(user, state, w, req)
+	if err != nil {
+		glog.V(4).Infof("Error calling success handler: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+}
+
+func (h *Handler) handleError(err error, w http.ResponseWriter, req *http.Request) {
+	handled, err := h.errorHandler.AuthenticationError(err, w, req)
+	if handled {
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte(`An error occurred`))
+}
@@ -0,0 +1,38 @@
+package saml
+
+import (
+	"sync"
+	"time"
+)
+
+// idSet holds IDs until they expire. It is kept in memory, so with several masters an ID is only known to the master
+// that added it.
+type idSet struct {
+	lock sync.Mutex
+	ids  map[string]time.Time
+}
+
+func newIDSet() *idSet {
+	return &idSet{ids: map[string]time.Time{}}
+}
+
+// add holds id until expires. It returns false if id is already held at time now.
+func (s *idSet) add(id string, expires, now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	if _, ok := s.ids[id]; ok {
+		return false
+	}
+	s.ids[id] = expires
+	return true
+}
+
+// expire drops the IDs that expired at time now. The caller must hold the lock.
+func (s *idSet) expire(now time.Time) {
+	for id, expires := range s.ids {
+		if !now.Before(expires) {
+			delete(s.ids, id)
+		}
+	}
+}
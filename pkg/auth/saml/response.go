@@ -0,0 +1,221 @@
+package saml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+)
+
+const (
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	statusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	bearerConfirmation = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// clockSkew is the difference tolerated between the clocks of the identity provider and the master
+	clockSkew = time.Minute
+)
+
+// assertion is the part of a SAML assertion used to authenticate a user
+type assertion struct {
+	ID      string `xml:"ID,attr"`
+	Issuer  string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject struct {
+		NameID               string                `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		SubjectConfirmations []subjectConfirmation `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions *struct {
+		NotBefore            time.Time `xml:"NotBefore,attr"`
+		NotOnOrAfter         time.Time `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	Attributes []struct {
+		Name   string   `xml:"Name,attr"`
+		Values []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement>Attribute"`
+
+	// responseInResponseTo is the InResponseTo attribute of the response holding the assertion
+	responseInResponseTo string
+}
+
+type subjectConfirmation struct {
+	Method string `xml:"Method,attr"`
+	Data   struct {
+		Recipient    string    `xml:"Recipient,attr"`
+		NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+		InResponseTo string    `xml:"InResponseTo,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+}
+
+// identityFor returns the identity of the user authenticated by the SAML response in data, received by the
+// assertion consumer service at acsURL at time now. The response must answer requestID, the authentication
+// request outstanding in the browser, and its assertion is only accepted once.
+func (h *Handler) identityFor(data []byte, acsURL, requestID string, now time.Time) (authapi.UserIdentityInfo, error) {
+	a, err := h.verifiedAssertion(data, acsURL)
+	if err != nil {
+		return nil, err
+	}
+	confirmation, err := h.validateAssertion(a, acsURL, now)
+	if err != nil {
+		return nil, err
+	}
+	// unsolicited responses are rejected, they could have been captured from another login
+	inResponseTo := confirmation.Data.InResponseTo
+	if len(a.responseInResponseTo) != 0 && a.responseInResponseTo != inResponseTo {
+		return nil, errors.New("SAML response and its assertion answer different authentication requests")
+	}
+	if len(requestID) == 0 || requestID != inResponseTo {
+		return nil, errors.New("SAML response does not answer an outstanding authentication request")
+	}
+	if !h.assertions.add(a.ID, confirmation.Data.NotOnOrAfter.Add(clockSkew), now) {
+		return nil, fmt.Errorf("SAML assertion %s was already used", a.ID)
+	}
+
+	id := a.Subject.NameID
+	if len(h.config.IDAttributes) > 0 {
+		id = a.attributeValue(h.config.IDAttributes)
+	}
+	if len(id) == 0 {
+		return nil, fmt.Errorf("Could not retrieve the user id from the assertion")
+	}
+	identity := authapi.NewDefaultUserIdentityInfo(h.providerName, id)
+
+	if preferredUsername := a.attributeValue(h.config.PreferredUsernameAttributes); len(preferredUsername) != 0 {
+		identity.Extra[authapi.IdentityPreferredUsernameKey] = preferredUsername
+	}
+
+	if email := a.attributeValue(h.config.EmailAttributes); len(email) != 0 {
+		identity.Extra[authapi.IdentityEmailKey] = email
+	}
+
+	if name := a.attributeValue(h.config.NameAttributes); len(name) != 0 {
+		identity.Extra[authapi.IdentityDisplayNameKey] = name
+	}
+
+	glog.V(4).Infof("identity=%v", identity)
+
+	return identity, nil
+}
+
+// verifiedAssertion returns the assertion of a successful SAML response, if either the response or the assertion
+// is signed by the identity provider. Only the signed elements are read, so that unsigned ones cannot be passed
+// off as part of them.
+func (h *Handler) verifiedAssertion(data []byte, acsURL string) (*assertion, error) {
+	response, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing SAML response: %v", err)
+	}
+	if !response.is(protocolNamespace, "Response") {
+		return nil, fmt.Errorf("Expected a SAML response, got %s", response.local)
+	}
+
+	responseErr := verifySignature(response, h.config.Certificates)
+	if responseErr != nil && responseErr != errNotSigned {
+		return nil, responseErr
+	}
+
+	if destination := response.attr("Destination"); len(destination) != 0 && destination != acsURL {
+		return nil, fmt.Errorf("SAML response was sent to %s", destination)
+	}
+	status, err := response.element(protocolNamespace, "Status")
+	if err != nil {
+		return nil, err
+	}
+	statusCode, err := status.element(protocolNamespace, "StatusCode")
+	if err != nil {
+		return nil, err
+	}
+	if code := statusCode.attr("Value"); code != statusSuccess {
+		return nil, fmt.Errorf("SAML identity provider returned status %s", code)
+	}
+
+	if len(response.elements(assertionNamespace, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("Encrypted SAML assertions are not supported")
+	}
+	assertionElement, err := response.element(assertionNamespace, "Assertion")
+	if err != nil {
+		return nil, err
+	}
+	if responseErr == errNotSigned {
+		if err := verifySignature(assertionElement, h.config.Certificates); err != nil {
+			return nil, fmt.Errorf("Neither the SAML response nor its assertion is signed: %v", err)
+		}
+	}
+
+	a := &assertion{}
+	if err := xml.Unmarshal(canonicalize(assertionElement, nil, nil), a); err != nil {
+		return nil, fmt.Errorf("Error parsing SAML assertion: %v", err)
+	}
+	a.responseInResponseTo = response.attr("InResponseTo")
+	return a, nil
+}
+
+// validateAssertion checks the assertion was issued by the identity provider for the master, and is valid at
+// time now. It returns the bearer subject confirmation the assertion is valid with.
+// http://docs.oasis-open.org/security/saml/v2.0/saml-profiles-2.0-os.pdf section 4.1.4.3
+func (h *Handler) validateAssertion(a *assertion, acsURL string, now time.Time) (*subjectConfirmation, error) {
+	if len(a.ID) == 0 {
+		return nil, errors.New("SAML assertion has no ID")
+	}
+	if a.Issuer != h.config.Issuer {
+		return nil, fmt.Errorf("SAML assertion was issued by %q, expected %q", a.Issuer, h.config.Issuer)
+	}
+
+	if a.Conditions == nil {
+		return nil, errors.New("SAML assertion has no conditions")
+	}
+	if !a.Conditions.NotBefore.IsZero() && now.Add(clockSkew).Before(a.Conditions.NotBefore) {
+		return nil, errors.New("SAML assertion is not valid yet")
+	}
+	if !a.Conditions.NotOnOrAfter.IsZero() && !now.Add(-clockSkew).Before(a.Conditions.NotOnOrAfter) {
+		return nil, errors.New("SAML assertion has expired")
+	}
+	if len(a.Conditions.AudienceRestrictions) == 0 {
+		return nil, errors.New("SAML assertion is not restricted to an audience")
+	}
+	for _, restriction := range a.Conditions.AudienceRestrictions {
+		if !contains(restriction.Audiences, h.config.EntityID) {
+			return nil, fmt.Errorf("SAML assertion is not intended for %s", h.config.EntityID)
+		}
+	}
+
+	for i := range a.Subject.SubjectConfirmations {
+		confirmation := &a.Subject.SubjectConfirmations[i]
+		if confirmation.Method == bearerConfirmation &&
+			confirmation.Data.Recipient == acsURL &&
+			len(confirmation.Data.InResponseTo) != 0 &&
+			now.Add(-clockSkew).Before(confirmation.Data.NotOnOrAfter) {
+			return confirmation, nil
+		}
+	}
+	return nil, errors.New("SAML assertion has no valid bearer subject confirmation")
+}
+
+// attributeValue returns the first value of the first of names the assertion has an attribute for
+func (a *assertion) attributeValue(names []string) string {
+	for _, name := range names {
+		for _, attribute := range a.Attributes {
+			if attribute.Name == name && len(attribute.Values) > 0 && len(attribute.Values[0]) > 0 {
+				return attribute.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
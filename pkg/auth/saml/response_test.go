@@ -0,0 +1,356 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+)
+
+const (
+	testACSURL   = "https://master.example.com/oauth2callback/saml"
+	testEntityID = "https://master.example.com"
+	testIssuer   = "https://idp.example.com"
+)
+
+type testState struct{}
+
+func (testState) Generate(w http.ResponseWriter, req *http.Request) (string, error) {
+	return "state", nil
+}
+
+func (testState) Check(state string, req *http.Request) (bool, error) {
+	return state == "state", nil
+}
+
+func newTestKey(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key, cert
+}
+
+// sign returns element, which must declare the namespaces it uses, with an enveloped signature by key inserted
+// after its first child element
+func sign(t *testing.T, element string, key *rsa.PrivateKey) string {
+	n, err := parseXML([]byte(element))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	digest := sha256.Sum256(canonicalize(n, nil, nil))
+
+	signedInfo := fmt.Sprintf(`<ds:SignedInfo><ds:CanonicalizationMethod Algorithm="%s"/><ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>`+
+		`<ds:Reference URI="#%s"><ds:Transforms><ds:Transform Algorithm="%s"/><ds:Transform Algorithm="%s"/></ds:Transforms>`+
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/><ds:DigestValue>%s</ds:DigestValue></ds:Reference></ds:SignedInfo>`,
+		exclusiveC14NTransform, n.attr("ID"), envelopedSignatureTransform, exclusiveC14NTransform, base64.StdEncoding.EncodeToString(digest[:]))
+	signedInfoNode, err := parseXML([]byte(`<ds:Signature xmlns:ds="` + xmldsigNamespace + `">` + signedInfo + `</ds:Signature>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hashed := sha256.Sum256(canonicalize(signedInfoNode.children[0].(*node), nil, nil))
+	value, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signature := `<ds:Signature xmlns:ds="` + xmldsigNamespace + `">` + signedInfo + `<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</ds:SignatureValue></ds:Signature>`
+
+	// the signature goes after the issuer, which ends the first child element
+	end := strings.Index(element, "</saml:Issuer>") + len("</saml:Issuer>")
+	return element[:end] + signature + element[end:]
+}
+
+// testAssertion returns an assertion with id, answering the authentication request inResponseTo
+func testAssertion(id, inResponseTo string, now time.Time, audience, recipient string) string {
+	return fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="%[6]s" Version="2.0" IssueInstant="%[1]s">
+  <saml:Issuer>%[2]s</saml:Issuer>
+  <saml:Subject>
+    <saml:NameID>jane@example.com</saml:NameID>
+    <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+      <saml:SubjectConfirmationData Recipient="%[5]s" NotOnOrAfter="%[3]s" InResponseTo="%[7]s"/>
+    </saml:SubjectConfirmation>
+  </saml:Subject>
+  <saml:Conditions NotBefore="%[1]s" NotOnOrAfter="%[3]s">
+    <saml:AudienceRestriction><saml:Audience>%[4]s</saml:Audience></saml:AudienceRestriction>
+  </saml:Conditions>
+  <saml:AttributeStatement>
+    <saml:Attribute Name="uid"><saml:AttributeValue>jane</saml:AttributeValue></saml:Attribute>
+    <saml:Attribute Name="displayName"><saml:AttributeValue>Jane Doe</saml:AttributeValue></saml:Attribute>
+  </saml:AttributeStatement>
+</saml:Assertion>`, now.UTC().Format(time.RFC3339), testIssuer, now.Add(5*time.Minute).UTC().Format(time.RFC3339), audience, recipient, id, inResponseTo)
+}
+
+func testResponse(assertion string) string {
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_response" Version="2.0" Destination="` + testACSURL + `">` +
+		`<saml:Issuer>` + testIssuer + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		assertion +
+		`</samlp:Response>`
+}
+
+func TestIdentityFor(t *testing.T) {
+	key, cert := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+	now := time.Now()
+
+	h, err := NewSAMLRedirector("saml", Config{
+		EntityID:        testEntityID,
+		Issuer:          testIssuer,
+		SingleSignOnURL: "https://idp.example.com/sso",
+		Certificates:    []*x509.Certificate{cert},
+
+		PreferredUsernameAttributes: []string{"uid"},
+		NameAttributes:              []string{"displayName"},
+	}, testState{}, testACSURL, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// every case answers the outstanding request with its own assertion, so that the cases do not depend on each
+	// other
+	n := 0
+	assertionFor := func(now time.Time, audience, recipient string) string {
+		n++
+		return testAssertion(fmt.Sprintf("_assertion%d", n), "_request", now, audience, recipient)
+	}
+	assertion := assertionFor(now, testEntityID, testACSURL)
+	testCases := map[string]struct {
+		Response      string
+		NoRequest     bool
+		ExpectedError string
+	}{
+		"signed assertion": {
+			Response: testResponse(sign(t, assertionFor(now, testEntityID, testACSURL), key)),
+		},
+		"signed response": {
+			Response: sign(t, testResponse(assertionFor(now, testEntityID, testACSURL)), key),
+		},
+		"unsigned": {
+			Response:      testResponse(assertion),
+			ExpectedError: "is signed",
+		},
+		"untrusted signature": {
+			Response:      testResponse(sign(t, assertion, otherKey)),
+			ExpectedError: "not made by a trusted certificate",
+		},
+		"modified assertion": {
+			Response:      testResponse(strings.Replace(sign(t, assertion, key), "jane@example.com", "admin@example.com", 1)),
+			ExpectedError: "digest",
+		},
+		"sha1 signature": {
+			Response:      testResponse(strings.Replace(sign(t, assertion, key), "xmldsig-more#rsa-sha256", "xmldsig#rsa-sha1", 1)),
+			ExpectedError: "unsupported signature method",
+		},
+		"wrapped assertion": {
+			Response:      testResponse(sign(t, assertion, key) + strings.Replace(assertion, `ID="_assertion1"`, `ID="_evil"`, 1)),
+			ExpectedError: "expected one Assertion",
+		},
+		"other audience": {
+			Response:      testResponse(sign(t, assertionFor(now, "https://other.example.com", testACSURL), key)),
+			ExpectedError: "not intended for",
+		},
+		"other recipient": {
+			Response:      testResponse(sign(t, assertionFor(now, testEntityID, "https://other.example.com/acs"), key)),
+			ExpectedError: "no valid bearer subject confirmation",
+		},
+		"expired": {
+			Response:      testResponse(sign(t, assertionFor(now.Add(-time.Hour), testEntityID, testACSURL), key)),
+			ExpectedError: "expired",
+		},
+		"unsolicited": {
+			Response:      testResponse(sign(t, testAssertion("_unsolicited", "", now, testEntityID, testACSURL), key)),
+			ExpectedError: "no valid bearer subject confirmation",
+		},
+		"unknown request": {
+			Response:      testResponse(sign(t, testAssertion("_unknown", "_other", now, testEntityID, testACSURL), key)),
+			ExpectedError: "does not answer an outstanding authentication request",
+		},
+		"no outstanding request": {
+			Response:      testResponse(sign(t, assertionFor(now, testEntityID, testACSURL), key)),
+			NoRequest:     true,
+			ExpectedError: "does not answer an outstanding authentication request",
+		},
+	}
+
+	for k, tc := range testCases {
+		requestID := "_request"
+		if tc.NoRequest {
+			requestID = ""
+		}
+		identity, err := h.identityFor([]byte(tc.Response), testACSURL, requestID, now)
+		if len(tc.ExpectedError) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Errorf("%s: expected error containing %q, got %v", k, tc.ExpectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if identity.GetProviderUserName() != "jane@example.com" {
+			t.Errorf("%s: expected the name ID as user name, got %q", k, identity.GetProviderUserName())
+		}
+		if extra := identity.GetExtra(); extra[authapi.IdentityPreferredUsernameKey] != "jane" || extra[authapi.IdentityDisplayNameKey] != "Jane Doe" {
+			t.Errorf("%s: unexpected extra %#v", k, extra)
+		}
+	}
+}
+
+func TestIdentityForReplay(t *testing.T) {
+	key, cert := newTestKey(t)
+	now := time.Now()
+	h, err := NewSAMLRedirector("saml", Config{
+		EntityID:        testEntityID,
+		Issuer:          testIssuer,
+		SingleSignOnURL: "https://idp.example.com/sso",
+		Certificates:    []*x509.Certificate{cert},
+	}, testState{}, testACSURL, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	response := []byte(testResponse(sign(t, testAssertion("_assertion", "_request", now, testEntityID, testACSURL), key)))
+	if _, err := h.identityFor(response, testACSURL, "_request", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the request was answered, so the browser no longer holds it
+	if _, err := h.identityFor(response, testACSURL, "", now); err == nil || !strings.Contains(err.Error(), "outstanding") {
+		t.Errorf("expected the answered request to be rejected, got %v", err)
+	}
+	// even if the request is outstanding again, the assertion was used
+	if _, err := h.identityFor(response, testACSURL, "_request", now); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("expected the replayed assertion to be rejected, got %v", err)
+	}
+}
+
+func TestOutstandingRequest(t *testing.T) {
+	_, cert := newTestKey(t)
+	now := time.Now()
+	h, err := NewSAMLRedirector("saml", Config{
+		EntityID:        testEntityID,
+		Issuer:          testIssuer,
+		SingleSignOnURL: "https://idp.example.com/sso",
+		Certificates:    []*x509.Certificate{cert},
+	}, testState{}, testACSURL, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := map[string]struct {
+		Cookie   string
+		Expected string
+	}{
+		"outstanding": {
+			Cookie:   fmt.Sprintf("_request.%d", now.Add(requestLifetime).Unix()),
+			Expected: "_request",
+		},
+		"expired": {
+			Cookie: fmt.Sprintf("_request.%d", now.Unix()),
+		},
+		"invalid": {
+			Cookie: "_request",
+		},
+		"none": {},
+	}
+	for k, tc := range testCases {
+		req, _ := http.NewRequest("POST", testACSURL, nil)
+		if len(tc.Cookie) > 0 {
+			req.AddCookie(&http.Cookie{Name: requestCookieName, Value: tc.Cookie})
+		}
+		w := httptest.NewRecorder()
+		if id := h.outstandingRequest(w, req, now); id != tc.Expected {
+			t.Errorf("%s: expected request %q, got %q", k, tc.Expected, id)
+		}
+		// the request is answered
+		cleared := w.Header().Get("Set-Cookie")
+		if len(tc.Cookie) > 0 && (!strings.HasPrefix(cleared, requestCookieName+"=;") || !strings.Contains(cleared, "Max-Age=0")) {
+			t.Errorf("%s: expected the request cookie to be cleared, got %q", k, cleared)
+		}
+	}
+}
+
+func TestAuthenticationRedirect(t *testing.T) {
+	_, cert := newTestKey(t)
+	h, err := NewSAMLRedirector("saml", Config{
+		EntityID:        testEntityID,
+		Issuer:          testIssuer,
+		SingleSignOnURL: "https://idp.example.com/sso?tenant=1",
+		Certificates:    []*x509.Certificate{cert},
+	}, testState{}, testACSURL, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "/oauth/authorize", nil)
+	w := httptest.NewRecorder()
+	if err := h.AuthenticationRedirect(w, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := location.Query()
+	if location.Host != "idp.example.com" || query.Get("tenant") != "1" || query.Get("RelayState") != "state" {
+		t.Errorf("unexpected redirect %s", location)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	request, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{`AssertionConsumerServiceURL="` + testACSURL + `"`, `ProtocolBinding="` + bindingPOST + `"`, `>` + testEntityID + `</Issuer>`} {
+		if !strings.Contains(string(request), expected) {
+			t.Errorf("expected %s in the authentication request, got %s", expected, request)
+		}
+	}
+	// the request is outstanding in the browser, which only sends it to the assertion consumer service
+	cookies := w.HeaderMap["Set-Cookie"]
+	if len(cookies) != 1 || !strings.Contains(cookies[0], "Path=/oauth2callback/saml") || !strings.Contains(cookies[0], "Secure") || !strings.Contains(cookies[0], "HttpOnly") {
+		t.Errorf("unexpected request cookie %v", cookies)
+	}
+	req, _ = http.NewRequest("POST", testACSURL, nil)
+	for _, cookie := range (&http.Response{Header: w.HeaderMap}).Cookies() {
+		req.AddCookie(cookie)
+	}
+	id := h.outstandingRequest(httptest.NewRecorder(), req, time.Now())
+	if !strings.Contains(string(request), `ID="`+id+`"`) || len(id) == 0 {
+		t.Errorf("expected the cookie to hold the ID of %s, got %q", request, id)
+	}
+}
@@ -0,0 +1,168 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	// register the hashes of the supported digest and signature methods
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+const (
+	xmldsigNamespace = "http://www.w3.org/2000/09/xmldsig#"
+
+	envelopedSignatureTransform = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	exclusiveC14NTransform      = "http://www.w3.org/2001/10/xml-exc-c14n#"
+)
+
+// signatureMethods are the supported signature algorithms, all of which use RSA keys. SHA-1 is not supported, its
+// collisions would let the signature of a document be reused for another one.
+// http://www.w3.org/TR/xmldsig-core/#sec-AlgID
+var signatureMethods = map[string]crypto.Hash{
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha256": crypto.SHA256,
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha512": crypto.SHA512,
+}
+
+// digestMethods are the supported digest algorithms
+var digestMethods = map[string]crypto.Hash{
+	"http://www.w3.org/2001/04/xmlenc#sha256": crypto.SHA256,
+	"http://www.w3.org/2001/04/xmlenc#sha512": crypto.SHA512,
+}
+
+// errNotSigned is returned when verifying the signature of an element which has none
+var errNotSigned = errors.New("element is not signed")
+
+// verifySignature verifies the enveloped signature of element n, as used by SAML: the signature is a child of n
+// and references n by its ID. It must be made by the key of one of certs.
+// http://docs.oasis-open.org/security/saml/v2.0/saml-core-2.0-os.pdf section 5.4
+func verifySignature(n *node, certs []*x509.Certificate) error {
+	signatures := n.elements(xmldsigNamespace, "Signature")
+	if len(signatures) == 0 {
+		return errNotSigned
+	}
+	if len(signatures) > 1 {
+		return fmt.Errorf("%s has more than one signature", n.local)
+	}
+	signature := signatures[0]
+
+	signedInfo, err := signature.element(xmldsigNamespace, "SignedInfo")
+	if err != nil {
+		return err
+	}
+	signatureValue, err := signature.element(xmldsigNamespace, "SignatureValue")
+	if err != nil {
+		return err
+	}
+
+	// the signed info is canonicalized with exclusive canonicalization, like the referenced element
+	canonicalizationMethod, err := signedInfo.element(xmldsigNamespace, "CanonicalizationMethod")
+	if err != nil {
+		return err
+	}
+	if algorithm := canonicalizationMethod.attr("Algorithm"); algorithm != exclusiveC14NTransform {
+		return fmt.Errorf("unsupported canonicalization method %q", algorithm)
+	}
+	signatureMethod, err := signedInfo.element(xmldsigNamespace, "SignatureMethod")
+	if err != nil {
+		return err
+	}
+	hash, ok := signatureMethods[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("unsupported signature method %q", signatureMethod.attr("Algorithm"))
+	}
+
+	if err := verifyReference(n, signature, signedInfo); err != nil {
+		return err
+	}
+
+	value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(signatureValue.text()), ""))
+	if err != nil {
+		return fmt.Errorf("invalid signature value: %v", err)
+	}
+	h := hash.New()
+	h.Write(canonicalize(signedInfo, inclusivePrefixes(canonicalizationMethod), nil))
+	digest := h.Sum(nil)
+	for _, cert := range certs {
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if err := rsa.VerifyPKCS1v15(key, hash, digest, value); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature of %s was not made by a trusted certificate", n.local)
+}
+
+// verifyReference checks the signed info references n and holds the digest of its canonical form
+func verifyReference(n, signature, signedInfo *node) error {
+	reference, err := signedInfo.element(xmldsigNamespace, "Reference")
+	if err != nil {
+		return err
+	}
+	// referencing the whole document would let elements be wrapped in signed ones
+	id := n.attr("ID")
+	if len(id) == 0 || reference.attr("URI") != "#"+id {
+		return fmt.Errorf("signature of %s does not reference it", n.local)
+	}
+
+	enveloped := false
+	var c14n *node
+	if transforms := reference.elements(xmldsigNamespace, "Transforms"); len(transforms) > 0 {
+		for _, transform := range transforms[0].elements(xmldsigNamespace, "Transform") {
+			switch algorithm := transform.attr("Algorithm"); algorithm {
+			case envelopedSignatureTransform:
+				enveloped = true
+			case exclusiveC14NTransform:
+				c14n = transform
+			default:
+				return fmt.Errorf("unsupported transform %q", algorithm)
+			}
+		}
+	}
+	// the default canonicalization of references is the inclusive one
+	if c14n == nil {
+		return errors.New("references must be canonicalized with exclusive canonicalization")
+	}
+
+	digestMethod, err := reference.element(xmldsigNamespace, "DigestMethod")
+	if err != nil {
+		return err
+	}
+	hash, ok := digestMethods[digestMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("unsupported digest method %q", digestMethod.attr("Algorithm"))
+	}
+	digestValue, err := reference.element(xmldsigNamespace, "DigestValue")
+	if err != nil {
+		return err
+	}
+	expected, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(digestValue.text()), ""))
+	if err != nil {
+		return fmt.Errorf("invalid digest value: %v", err)
+	}
+
+	skip := func(e *node) bool { return enveloped && e == signature }
+	h := hash.New()
+	h.Write(canonicalize(n, inclusivePrefixes(c14n), skip))
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return fmt.Errorf("digest of %s does not match its signature", n.local)
+	}
+	return nil
+}
+
+// inclusivePrefixes returns the prefix list of the InclusiveNamespaces parameter of an exclusive canonicalization
+// http://www.w3.org/TR/xml-exc-c14n/#def-InclusiveNamespaces-PrefixList
+func inclusivePrefixes(c14n *node) []string {
+	for _, inclusiveNamespaces := range c14n.elements(exclusiveC14NTransform, "InclusiveNamespaces") {
+		return strings.Fields(inclusiveNamespaces.attr("PrefixList"))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package errorpage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/golang/glog"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
+)
+
+const (
+	errorCodeClaim          = "mapping_claim_error"
+	errorCodeLookup         = "mapping_lookup_error"
+	errorCodeAuthentication = "authentication_error"
+	errorCodeGrant          = "grant_error"
+)
+
+// errorMessages are displayed instead of the errors themselves, which may reveal details of the server
+var errorMessages = map[string]string{
+	errorCodeClaim:          "Could not create user.",
+	errorCodeLookup:         "Could not find user.",
+	errorCodeAuthentication: "An authentication error occurred.",
+	errorCodeGrant:          "A grant error occurred.",
+}
+
+// ErrorPageRenderer is responsible for rendering an error page with the given status code
+type ErrorPageRenderer interface {
+	Render(data ErrorData, status int, w http.ResponseWriter, req *http.Request)
+}
+
+// ErrorData holds the parameters of the error page
+type ErrorData struct {
+	// Error is a message which can be displayed to the user
+	Error string
+	// ErrorCode identifies the kind of error, for templates which display their own messages
+	ErrorCode string
+}
+
+// ErrorPage renders an error page for authentication and grant errors. It handles every error, so it
+// must be the last handler of a chain.
+type ErrorPage struct {
+	render ErrorPageRenderer
+}
+
+// NewErrorPageHandler returns an error handler rendering its errors with renderer
+func NewErrorPageHandler(renderer ErrorPageRenderer) *ErrorPage {
+	return &ErrorPage{render: renderer}
+}
+
+// AuthenticationError implements handlers.AuthenticationErrorHandler
+func (p *ErrorPage) AuthenticationError(err error, w http.ResponseWriter, req *http.Request) (bool, error) {
+	glog.Errorf("AuthenticationError: %v", err)
+
+	errorCode, status := errorCodeAuthentication, http.StatusInternalServerError
+	switch {
+	case identitymapper.IsClaimError(err):
+		errorCode, status = errorCodeClaim, http.StatusForbidden
+	case kerrors.IsNotFound(err):
+		errorCode, status = errorCodeLookup, http.StatusForbidden
+	}
+	p.render.Render(ErrorData{Error: errorMessages[errorCode], ErrorCode: errorCode}, status, w, req)
+	return true, nil
+}
+
+// GrantError implements handlers.GrantErrorHandler
+func (p *ErrorPage) GrantError(err error, w http.ResponseWriter, req *http.Request) (bool, error) {
+	glog.Errorf("GrantError: %v", err)
+
+	p.render.Render(ErrorData{Error: errorMessages[errorCodeGrant], ErrorCode: errorCodeGrant}, http.StatusInternalServerError, w, req)
+	return true, nil
+}
+
+// NewErrorPageTemplateRenderer creates an error page renderer that takes in an optional custom template to
+// allow branding of the error page. Uses the default if customErrorTemplateFile is not set.
+func NewErrorPageTemplateRenderer(customErrorTemplateFile string) (*errorPageTemplateRenderer, error) {
+	r := &errorPageTemplateRenderer{}
+	if len(customErrorTemplateFile) > 0 {
+		customTemplate, err := template.ParseFiles(customErrorTemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		r.errorTemplate = customTemplate
+	} else {
+		r.errorTemplate = defaultErrorTemplate
+	}
+
+	return r, nil
+}
+
+func ValidateErrorPageTemplate(templateContent []byte) []error {
+	var allErrs []error
+
+	template, err := template.New("errorTemplateTest").Parse(string(templateContent))
+	if err != nil {
+		return append(allErrs, err)
+	}
+
+	// Execute the template with dummy values and check if they're there.
+	data := ErrorData{
+		Error:     "MyErrorMessage",
+		ErrorCode: "MyCode",
+	}
+
+	var buffer bytes.Buffer
+	if err := template.Execute(&buffer, data); err != nil {
+		return append(allErrs, err)
+	}
+
+	// The error code is optional, the message is not
+	if !bytes.Contains(buffer.Bytes(), []byte(data.Error)) {
+		allErrs = append(allErrs, errors.New("template is missing parameter {{ .Error }}"))
+	}
+
+	return allErrs
+}
+
+type errorPageTemplateRenderer struct {
+	errorTemplate *template.Template
+}
+
+func (r errorPageTemplateRenderer) Render(data ErrorData, status int, w http.ResponseWriter, req *http.Request) {
+	w.Header().Add("Content-Type", "text/html")
+	w.WriteHeader(status)
+	if err := r.errorTemplate.Execute(w, data); err != nil {
+		util.HandleError(fmt.Errorf("unable to render error page template: %v", err))
+	}
+}
+
+// ErrorPageTemplateExample is a basic template for customizing the error page.
+const ErrorPageTemplateExample = `<!DOCTYPE html>
+<!--
+
+This template can be modified and used to customize the error page. To replace
+the error page, set master configuration option oauthConfig.templates.error to
+the path of the template file. Don't remove parameters in curly braces below.
+
+The error code is one of mapping_claim_error, mapping_lookup_error,
+authentication_error or grant_error, and can be used to display custom messages.
+
+oauthConfig:
+  templates:
+    error: templates/error-template.html
+
+-->
+<html>
+  <head>
+    <title>Error</title>
+    <style type="text/css">
+      body {
+        font-family: "Open Sans", Helvetica, Arial, sans-serif;
+        font-size: 14px;
+        margin: 15px;
+      }
+    </style>
+  </head>
+  <body>
+
+    <div>{{ .Error }}</div>
+
+  </body>
+</html>
+`
+
+var defaultErrorTemplate = template.Must(template.New("defaultErrorPage").Parse(defaultErrorTemplateString))
+
+const defaultErrorTemplateString = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Error</title>
+    <style>
+      body { font-family: sans-serif; font-size: 12pt; margin: 2em 5%; background-color: #F9F9F9; }
+    </style>
+  </head>
+  <body>
+    <div class="message">{{ .Error }}</div>
+  </body>
+</html>
+`
@@ -0,0 +1,98 @@
+package errorpage
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+
+	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+func TestAuthenticationError(t *testing.T) {
+	testCases := map[string]struct {
+		Err           error
+		ExpectMessage string
+		ExpectStatus  int
+	}{
+		"generic error": {
+			Err:           errors.New("connection refused to ldap.example.com"),
+			ExpectMessage: "An authentication error occurred.",
+			ExpectStatus:  http.StatusInternalServerError,
+		},
+		"claim error": {
+			Err:           identitymapper.NewClaimError(&userapi.User{}, &userapi.Identity{}),
+			ExpectMessage: "Could not create user.",
+			ExpectStatus:  http.StatusForbidden,
+		},
+		"lookup error": {
+			Err:           kerrors.NewNotFound("UserIdentityMapping", "github:bob"),
+			ExpectMessage: "Could not find user.",
+			ExpectStatus:  http.StatusForbidden,
+		},
+	}
+
+	renderer, err := NewErrorPageTemplateRenderer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewErrorPageHandler(renderer)
+
+	for k, testCase := range testCases {
+		w := httptest.NewRecorder()
+		handled, err := handler.AuthenticationError(testCase.Err, w, &http.Request{})
+		if !handled || err != nil {
+			t.Errorf("%s: expected the error to be handled, got %v, %v", k, handled, err)
+			continue
+		}
+		if w.Code != testCase.ExpectStatus {
+			t.Errorf("%s: expected status %d, got %d", k, testCase.ExpectStatus, w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, testCase.ExpectMessage) {
+			t.Errorf("%s: expected %q in the page, got %s", k, testCase.ExpectMessage, body)
+		}
+		if strings.Contains(body, testCase.Err.Error()) {
+			t.Errorf("%s: expected the error not to be displayed, got %s", k, body)
+		}
+	}
+}
+
+func TestValidateErrorPageTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		Template      string
+		TemplateValid bool
+	}{
+		"default error template": {
+			Template:      defaultErrorTemplateString,
+			TemplateValid: true,
+		},
+		"error template example": {
+			Template:      ErrorPageTemplateExample,
+			TemplateValid: true,
+		},
+		"template with missing parameter": {
+			Template:      strings.Replace(ErrorPageTemplateExample, "{{ .Error }}", "{{ .ErrorCode }}", -1),
+			TemplateValid: false,
+		},
+		"template with unknown parameter": {
+			Template:      "{{ .Error }} {{ .Missing }}",
+			TemplateValid: false,
+		},
+	}
+
+	for k, testCase := range testCases {
+		allErrs := ValidateErrorPageTemplate([]byte(testCase.Template))
+		if testCase.TemplateValid {
+			for _, err := range allErrs {
+				t.Errorf("%s: template validation failed when it should have succeeded: %v", k, err)
+			}
+		} else if len(allErrs) == 0 {
+			t.Errorf("%s: template validation succeeded when it should have failed", k)
+		}
+	}
+}
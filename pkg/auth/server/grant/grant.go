@@ -1,6 +1,7 @@
 package grant
 
 import (
+	"bytes"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	"github.com/openshift/origin/pkg/auth/server/csrf"
 	oapi "github.com/openshift/origin/pkg/oauth/api"
 	"github.com/openshift/origin/pkg/oauth/registry/oauthclient"
@@ -67,15 +69,19 @@ type Grant struct {
 	render         FormRenderer
 	clientregistry oauthclient.Registry
 	authregistry   oauthclientauthorization.Registry
+	defaultMethod  oapi.GrantHandlerType
 }
 
-func NewGrant(csrf csrf.CSRF, auth authenticator.Request, render FormRenderer, clientregistry oauthclient.Registry, authregistry oauthclientauthorization.Registry) *Grant {
+// NewGrant returns a handler that prompts users to approve grants. Only the grants of clients whose grant method,
+// or defaultMethod if they do not set one, is prompt can be approved.
+func NewGrant(csrf csrf.CSRF, auth authenticator.Request, render FormRenderer, clientregistry oauthclient.Registry, authregistry oauthclientauthorization.Registry, defaultMethod oapi.GrantHandlerType) *Grant {
 	return &Grant{
 		auth:           auth,
 		csrf:           csrf,
 		render:         render,
 		clientregistry: clientregistry,
 		authregistry:   authregistry,
+		defaultMethod:  defaultMethod,
 	}
 }
 
@@ -117,6 +123,10 @@ func (l *Grant) handleForm(user user.Info, w http.ResponseWriter, req *http.Requ
 		l.failed("Could not find client for client_id", w, req)
 		return
 	}
+	if handlers.GrantMethod(client, l.defaultMethod) != oapi.GrantHandlerPrompt {
+		l.failed("Grants for this client cannot be approved", w, req)
+		return
+	}
 
 	uri, err := getBaseURL(req)
 	if err != nil {
@@ -185,6 +195,10 @@ func (l *Grant) handleGrant(user user.Info, w http.ResponseWriter, req *http.Req
 		l.failed("Could not find client for client_id", w, req)
 		return
 	}
+	if handlers.GrantMethod(client, l.defaultMethod) != oapi.GrantHandlerPrompt {
+		l.failed("Grants for this client cannot be approved", w, req)
+		return
+	}
 
 	clientAuthID := l.authregistry.ClientAuthorizationName(user.GetName(), client.Name)
 
@@ -251,20 +265,171 @@ func getBaseURL(req *http.Request) (*url.URL, error) {
 
 // DefaultFormRenderer displays a page prompting the user to approve an OAuth grant.
 // The requesting client id, requested scopes, and redirect URI are displayed to the user.
-var DefaultFormRenderer = grantTemplateRenderer{}
+var DefaultFormRenderer = grantTemplateRenderer{grantTemplate: defaultGrantTemplate}
+
+// NewFormRenderer creates a grant form renderer that takes in an optional custom template to
+// allow branding of the grant page. Uses the default if customGrantTemplateFile is not set.
+func NewFormRenderer(customGrantTemplateFile string) (*grantTemplateRenderer, error) {
+	r := &grantTemplateRenderer{}
+	if len(customGrantTemplateFile) > 0 {
+		customTemplate, err := template.ParseFiles(customGrantTemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		r.grantTemplate = customTemplate
+	} else {
+		r.grantTemplate = defaultGrantTemplate
+	}
+
+	return r, nil
+}
+
+func ValidateGrantTemplate(templateContent []byte) []error {
+	var allErrs []error
+
+	template, err := template.New("grantTemplateTest").Parse(string(templateContent))
+	if err != nil {
+		return append(allErrs, err)
+	}
+
+	// Execute the template with dummy values and check if they're there. The error is rendered instead of the form,
+	// so it is checked separately.
+	form := Form{
+		Action: "MyAction",
+		Values: FormValues{
+			Then:             "MyThenValue",
+			ThenParam:        "MyThenName",
+			CSRF:             "MyCSRFValue",
+			CSRFParam:        "MyCSRFName",
+			ClientID:         "MyClientIDValue",
+			ClientIDParam:    "MyClientIDName",
+			UserName:         "MyUserNameValue",
+			UserNameParam:    "MyUserNameName",
+			Scopes:           "MyScopesValue",
+			ScopesParam:      "MyScopesName",
+			RedirectURI:      "MyRedirectURIValue",
+			RedirectURIParam: "MyRedirectURIName",
+			ApproveParam:     "MyApproveName",
+			DenyParam:        "MyDenyName",
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := template.Execute(&buffer, form); err != nil {
+		return append(allErrs, err)
+	}
+	output := buffer.Bytes()
+
+	var testFields = map[string]string{
+		"Action":                  form.Action,
+		"Values.Then":             form.Values.Then,
+		"Values.ThenParam":        form.Values.ThenParam,
+		"Values.CSRF":             form.Values.CSRF,
+		"Values.CSRFParam":        form.Values.CSRFParam,
+		"Values.ClientID":         form.Values.ClientID,
+		"Values.ClientIDParam":    form.Values.ClientIDParam,
+		"Values.UserName":         form.Values.UserName,
+		"Values.UserNameParam":    form.Values.UserNameParam,
+		"Values.Scopes":           form.Values.Scopes,
+		"Values.ScopesParam":      form.Values.ScopesParam,
+		"Values.RedirectURI":      form.Values.RedirectURI,
+		"Values.RedirectURIParam": form.Values.RedirectURIParam,
+		"Values.ApproveParam":     form.Values.ApproveParam,
+		"Values.DenyParam":        form.Values.DenyParam,
+	}
 
-type grantTemplateRenderer struct{}
+	for field, value := range testFields {
+		if !bytes.Contains(output, []byte(value)) {
+			allErrs = append(allErrs, fmt.Errorf("template is missing parameter {{ .%s }}", field))
+		}
+	}
+
+	buffer.Reset()
+	if err := template.Execute(&buffer, Form{Error: "MyError"}); err != nil {
+		return append(allErrs, err)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("MyError")) {
+		allErrs = append(allErrs, fmt.Errorf("template is missing parameter {{ .Error }}"))
+	}
+
+	return allErrs
+}
+
+type grantTemplateRenderer struct {
+	grantTemplate *template.Template
+}
 
 func (r grantTemplateRenderer) Render(form Form, w http.ResponseWriter, req *http.Request) {
 	w.Header().Add("Content-Type", "text/html")
 	w.WriteHeader(http.StatusOK)
-	if err := grantTemplate.Execute(w, form); err != nil {
+	if err := r.grantTemplate.Execute(w, form); err != nil {
 		util.HandleError(fmt.Errorf("unable to render grant template: %v", err))
 	}
 }
 
-// TODO: allow template to be read from an external file
-var grantTemplate = template.Must(template.New("grantForm").Parse(`
+// GrantTemplateExample is a basic template for customizing the grant approval page.
+const GrantTemplateExample = `<!DOCTYPE html>
+<!--
+
+This template can be modified and used to customize the page prompting users
+to approve the grants requested by OAuth clients. To replace the page, set
+master configuration option oauthConfig.templates.grant to the path of the
+template file. Don't remove parameters in curly braces below.
+
+oauthConfig:
+  templates:
+    grant: templates/grant-template.html
+
+-->
+<html>
+  <head>
+    <title>Approve Client</title>
+    <style type="text/css">
+      body {
+        font-family: "Open Sans", Helvetica, Arial, sans-serif;
+        font-size: 14px;
+        margin: 15px;
+      }
+
+      .error {
+        color: red;
+        margin-bottom: 10px;
+      }
+    </style>
+  </head>
+  <body>
+
+    {{ if .Error }}
+      <div class="error">{{ .Error }}</div>
+    {{ else }}
+      <form action="{{ .Action }}" method="POST">
+        <input type="hidden" name="{{ .Values.ThenParam }}" value="{{ .Values.Then }}">
+        <input type="hidden" name="{{ .Values.CSRFParam }}" value="{{ .Values.CSRF }}">
+        <input type="hidden" name="{{ .Values.ClientIDParam }}" value="{{ .Values.ClientID }}">
+        <input type="hidden" name="{{ .Values.UserNameParam }}" value="{{ .Values.UserName }}">
+        <input type="hidden" name="{{ .Values.ScopesParam }}" value="{{ .Values.Scopes }}">
+        <input type="hidden" name="{{ .Values.RedirectURIParam }}" value="{{ .Values.RedirectURI }}">
+
+        <h3>Approve Client?</h3>
+        <p>Do you approve granting an access token to the following OAuth client?</p>
+        <dl>
+          <dt>Client</dt><dd>{{ .Values.ClientID }}</dd>
+          <dt>Scope</dt><dd>{{ .Values.Scopes }}</dd>
+          <dt>URI</dt><dd>{{ .Values.RedirectURI }}</dd>
+        </dl>
+
+        <button type="submit" name="{{ .Values.ApproveParam }}" value="Approve">Approve</button>
+        <button type="submit" name="{{ .Values.DenyParam }}" value="Reject">Reject</button>
+      </form>
+    {{ end }}
+
+  </body>
+</html>
+`
+
+var defaultGrantTemplate = template.Must(template.New("grantForm").Parse(defaultGrantTemplateString))
+
+const defaultGrantTemplateString = `
 <style>
 	body    { font-family: sans-serif; font-size: 12pt; margin: 2em 5%; background-color: #F9F9F9; }
 	pre     { padding-left: 1em; border-left: .25em solid #eee; }
@@ -294,4 +459,4 @@ URI:    {{ .Values.RedirectURI }}
   <input type="submit" name="{{ .Values.DenyParam }}" value="Reject">
 </form>
 {{ end }}
-`))
+`
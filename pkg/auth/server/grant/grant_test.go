@@ -40,6 +40,11 @@ func goodClientRegistry(clientID string, redirectURIs []string) *test.ClientRegi
 	client.Name = clientID
 	return &test.ClientRegistry{Client: client}
 }
+func denyClientRegistry(clientID string) *test.ClientRegistry {
+	registry := goodClientRegistry(clientID, []string{"myredirect"})
+	registry.Client.GrantMethod = oapi.GrantHandlerDeny
+	return registry
+}
 func badClientRegistry(err error) *test.ClientRegistry {
 	return &test.ClientRegistry{Err: err}
 }
@@ -177,6 +182,25 @@ func TestGrant(t *testing.T) {
 			ExpectContains:   []string{"find client"},
 		},
 
+		"error submitting form for client that denies grants": {
+			CSRF:           &csrf.FakeCSRF{Token: "test"},
+			Auth:           goodAuth("username"),
+			ClientRegistry: denyClientRegistry("myclient"),
+			AuthRegistry:   emptyAuthRegistry(),
+			Path:           "/grant",
+			PostValues: url.Values{
+				"approve":      {"true"},
+				"client_id":    {"myclient"},
+				"scopes":       {"myscope1 myscope2"},
+				"redirect_uri": {"/myredirect"},
+				"then":         {"/authorize"},
+				"csrf":         {"test"},
+			},
+
+			ExpectStatusCode: 200,
+			ExpectContains:   []string{"cannot be approved"},
+		},
+
 		"successful create grant with redirect": {
 			CSRF:           &csrf.FakeCSRF{Token: "test"},
 			Auth:           goodAuth("username"),
@@ -280,7 +304,7 @@ func TestGrant(t *testing.T) {
 	}
 
 	for k, testCase := range testCases {
-		server := httptest.NewServer(NewGrant(testCase.CSRF, testCase.Auth, DefaultFormRenderer, testCase.ClientRegistry, testCase.AuthRegistry))
+		server := httptest.NewServer(NewGrant(testCase.CSRF, testCase.Auth, DefaultFormRenderer, testCase.ClientRegistry, testCase.AuthRegistry, oapi.GrantHandlerPrompt))
 
 		var resp *http.Response
 		if testCase.PostValues != nil {
@@ -369,3 +393,38 @@ func getURL(url string) (resp *http.Response, err error) {
 	}
 	return tr.RoundTrip(req)
 }
+
+func TestValidateGrantTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		Template      string
+		TemplateValid bool
+	}{
+		"default grant template": {
+			Template:      defaultGrantTemplateString,
+			TemplateValid: true,
+		},
+		"grant template example": {
+			Template:      GrantTemplateExample,
+			TemplateValid: true,
+		},
+		"template with missing parameter": {
+			Template:      strings.Replace(GrantTemplateExample, "{{ .Values.CSRF }}", "", -1),
+			TemplateValid: false,
+		},
+		"template without error": {
+			Template:      strings.Replace(GrantTemplateExample, "{{ .Error }}", "", -1),
+			TemplateValid: false,
+		},
+	}
+
+	for k, testCase := range testCases {
+		allErrs := ValidateGrantTemplate([]byte(testCase.Template))
+		if testCase.TemplateValid {
+			for _, err := range allErrs {
+				t.Errorf("%s: template validation failed when it should have succeeded: %v", k, err)
+			}
+		} else if len(allErrs) == 0 {
+			t.Errorf("%s: template validation succeeded when it should have failed", k)
+		}
+	}
+}
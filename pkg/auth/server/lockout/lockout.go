@@ -0,0 +1,351 @@
+package lockout
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/audit"
+)
+
+// Throttle refuses password logins after repeated failures
+type Throttle interface {
+	// LockedOut returns how long logins as username by req are refused for, or 0 if the login may be attempted
+	LockedOut(username string, req *http.Request) time.Duration
+	// LoginFailed records a failed login as username by req
+	LoginFailed(username string, req *http.Request)
+	// LoginSucceeded records a successful login as username by req
+	LoginSucceeded(username string, req *http.Request)
+}
+
+// Auditor records failed logins and lockouts
+type Auditor interface {
+	// LoginFailed is called for every failed login. username is empty if the credentials did not name a user.
+	LoginFailed(provider, username, sourceIP string)
+	// LockedOut is called when logins as a user, from a source IP or with a credential are refused until the
+	// given time
+	LockedOut(provider, subject string, until time.Time)
+}
+
+// Config limits failed logins
+type Config struct {
+	// MaxFailedAttempts is the number of failed logins as a user after which logins as that user are refused. 0
+	// does not limit the logins of users.
+	MaxFailedAttempts int
+	// MaxFailedAttemptsPerSourceIP is the number of failed logins from a source IP after which logins from that
+	// address are refused. 0 does not limit the logins from source IPs.
+	MaxFailedAttemptsPerSourceIP int
+	// Lockout is how long logins are refused for the first time the limit is reached. Every further lockout that
+	// follows without a successful login doubles it, up to MaxLockout.
+	Lockout time.Duration
+	// MaxLockout is the longest time logins are refused for. Failures are forgotten after MaxLockout without
+	// another failure.
+	MaxLockout time.Duration
+	// TrustedProxies are the networks of the proxies allowed to report the source IP of clients in the
+	// X-Forwarded-For header. The header of requests received from other addresses is ignored.
+	TrustedProxies []*net.IPNet
+	// MaxSubjects is the largest number of users and source IPs whose failures are recorded at once. Once it is
+	// reached, the failures of the subject that failed least recently are forgotten. 0 uses DefaultMaxSubjects.
+	MaxSubjects int
+}
+
+// DefaultMaxSubjects is the default limit of users and source IPs whose failures are recorded at once
+const DefaultMaxSubjects = 10000
+
+// Lockout is a Throttle that counts failed logins per identity provider, both per user and per source IP
+type Lockout struct {
+	config  Config
+	auditor Auditor
+	now     func() time.Time
+
+	lock      sync.Mutex
+	attempts  map[string]*attempts
+	lastSweep time.Time
+}
+
+// attempts are the failed logins of a user or from a source IP
+type attempts struct {
+	failures    int
+	lockouts    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// New returns a Lockout that limits failed logins according to config and reports them to auditor
+func New(config Config, auditor Auditor) *Lockout {
+	if config.MaxSubjects <= 0 {
+		config.MaxSubjects = DefaultMaxSubjects
+	}
+	return &Lockout{
+		config:   config,
+		auditor:  auditor,
+		now:      time.Now,
+		attempts: map[string]*attempts{},
+	}
+}
+
+// ForProvider returns the Throttle of the logins of the named identity provider
+func (l *Lockout) ForProvider(provider string) Throttle {
+	return &providerThrottle{lockout: l, provider: provider}
+}
+
+// ForRequests returns the Throttle of the credentials of API requests checked by the named authenticator. The
+// requests are counted per source IP, and the username passed to it is ignored: a client guessing credentials
+// sends different ones every time. After MaxFailedAttemptsPerSourceIP failures, the credentials sent from the
+// source IP are refused. Failures are not audited, only lockouts are.
+func (l *Lockout) ForRequests(provider string) Throttle {
+	return &requestThrottle{lockout: l, provider: provider}
+}
+
+// SourceIP returns the address of the client of req, as reported by the trusted proxies the request went through
+func (l *Lockout) SourceIP(req *http.Request) string {
+	return ForwardedSourceIP(req, l.config.TrustedProxies)
+}
+
+// providerThrottle is the Throttle of the logins of one identity provider
+type providerThrottle struct {
+	lockout  *Lockout
+	provider string
+}
+
+func (t *providerThrottle) LockedOut(username string, req *http.Request) time.Duration {
+	return t.lockout.lockedOut(userKey(t.provider, username), sourceIPKey(t.provider, t.lockout.SourceIP(req)))
+}
+
+func (t *providerThrottle) LoginFailed(username string, req *http.Request) {
+	t.lockout.loginFailed(t.provider, username, t.lockout.SourceIP(req))
+}
+
+func (t *providerThrottle) LoginSucceeded(username string, req *http.Request) {
+	// failures from the source IP are kept, otherwise an attacker that knows the password of one user could keep
+	// guessing the passwords of others
+	t.lockout.forget(userKey(t.provider, username))
+}
+
+// requestThrottle is the Throttle of the API requests checked by one authenticator
+type requestThrottle struct {
+	lockout  *Lockout
+	provider string
+}
+
+func (t *requestThrottle) LockedOut(_ string, req *http.Request) time.Duration {
+	return t.lockout.lockedOut(sourceIPKey(t.provider, t.lockout.SourceIP(req)))
+}
+
+func (t *requestThrottle) LoginFailed(_ string, req *http.Request) {
+	t.lockout.requestFailed(t.provider, t.lockout.SourceIP(req))
+}
+
+func (t *requestThrottle) LoginSucceeded(_ string, req *http.Request) {
+	// as for password logins, failures from the source IP are kept when other credentials succeed
+}
+
+// SourceIP returns the address req was received from. Headers set by proxies are not trusted, as clients could
+// set them to avoid being locked out.
+func SourceIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// ForwardedSourceIP returns the address of the client of req. If req was received from one of trustedProxies,
+// the X-Forwarded-For header is read from the last address, which was added by the proxy, to the first one. The
+// first address not in trustedProxies is the client, as the addresses before it could have been set by the
+// client.
+func ForwardedSourceIP(req *http.Request, trustedProxies []*net.IPNet) string {
+	sourceIP := SourceIP(req)
+	if !contains(trustedProxies, sourceIP) {
+		return sourceIP
+	}
+	var forwarded []string
+	for _, header := range req.Header[http.CanonicalHeaderKey("X-Forwarded-For")] {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(address) == nil {
+			// the proxies do not add invalid addresses, so the rest of the header cannot be trusted
+			break
+		}
+		sourceIP = address
+		if !contains(trustedProxies, address) {
+			break
+		}
+	}
+	return sourceIP
+}
+
+// ParseTrustedProxies parses the IP addresses and CIDRs of trusted proxies
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, proxy := range proxies {
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or a CIDR", proxy)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// contains returns true if address is in one of networks
+func contains(networks []*net.IPNet, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func userKey(provider, username string) string {
+	return "user:" + provider + "/" + username
+}
+
+func sourceIPKey(provider, sourceIP string) string {
+	return "ip:" + provider + "/" + sourceIP
+}
+
+// lockedOut returns how long the longest lockout of keys lasts
+func (l *Lockout) lockedOut(keys ...string) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	remaining := time.Duration(0)
+	for _, key := range keys {
+		if a, ok := l.attempts[key]; ok && a.lockedUntil.After(now) && a.lockedUntil.Sub(now) > remaining {
+			remaining = a.lockedUntil.Sub(now)
+		}
+	}
+	return remaining
+}
+
+func (l *Lockout) loginFailed(provider, username, sourceIP string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if l.auditor != nil {
+		l.auditor.LoginFailed(provider, username, sourceIP)
+	}
+	l.fail(now, provider, userKey(provider, username), fmt.Sprintf("user %s", username), l.config.MaxFailedAttempts)
+	l.fail(now, provider, sourceIPKey(provider, sourceIP), fmt.Sprintf("source IP %s", sourceIP), l.config.MaxFailedAttemptsPerSourceIP)
+	l.sweep(now)
+}
+
+func (l *Lockout) requestFailed(provider, sourceIP string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	l.fail(now, provider, sourceIPKey(provider, sourceIP), fmt.Sprintf("source IP %s", sourceIP), l.config.MaxFailedAttemptsPerSourceIP)
+	l.sweep(now)
+}
+
+// fail records a failure for key, locking it out once max failures were recorded
+func (l *Lockout) fail(now time.Time, provider, key, subject string, max int) {
+	if max <= 0 {
+		return
+	}
+	a, ok := l.attempts[key]
+	if !ok || l.expired(now, a) {
+		if !ok && len(l.attempts) >= l.config.MaxSubjects {
+			l.evict()
+		}
+		a = &attempts{}
+		l.attempts[key] = a
+	}
+	a.failures++
+	a.lastFailure = now
+	if a.failures < max {
+		return
+	}
+
+	lockout := l.config.Lockout
+	for i := 0; i < a.lockouts && lockout < l.config.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > l.config.MaxLockout {
+		lockout = l.config.MaxLockout
+	}
+	a.failures = 0
+	a.lockouts++
+	a.lockedUntil = now.Add(lockout)
+	if l.auditor != nil {
+		l.auditor.LockedOut(provider, subject, a.lockedUntil)
+	}
+}
+
+// forget clears the failures recorded for key
+func (l *Lockout) forget(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.attempts, key)
+}
+
+// evict forgets the failures of the subject that failed least recently
+func (l *Lockout) evict() {
+	oldestKey, oldest := "", time.Time{}
+	for key, a := range l.attempts {
+		if len(oldestKey) == 0 || a.lastFailure.Before(oldest) {
+			oldestKey, oldest = key, a.lastFailure
+		}
+	}
+	delete(l.attempts, oldestKey)
+}
+
+// expired returns true if the failures of a are forgotten
+func (l *Lockout) expired(now time.Time, a *attempts) bool {
+	return now.After(a.lockedUntil) && now.Sub(a.lastFailure) > l.config.MaxLockout
+}
+
+// sweep forgets expired failures, at most once every MaxLockout
+func (l *Lockout) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.config.MaxLockout {
+		return
+	}
+	l.lastSweep = now
+	for key, a := range l.attempts {
+		if l.expired(now, a) {
+			delete(l.attempts, key)
+		}
+	}
+}
+
+// SinkAuditor writes failed logins and lockouts to an audit sink
+type SinkAuditor struct {
+	Sink audit.Sink
+}
+
+// LoginFailed implements Auditor
+func (a SinkAuditor) LoginFailed(provider, username, sourceIP string) {
+	if len(username) == 0 {
+		a.Sink.Record("failed authentication with %q from %s", provider, sourceIP)
+		return
+	}
+	a.Sink.Record("failed login as %q with identity provider %q from %s", username, provider, sourceIP)
+}
+
+// LockedOut implements Auditor
+func (a SinkAuditor) LockedOut(provider, subject string, until time.Time) {
+	a.Sink.Record("logins of %s with identity provider %q are refused until %s after repeated failures", subject, provider, until.Format(time.RFC3339))
+}
@@ -0,0 +1,225 @@
+package lockout
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeAuditor struct {
+	failed    []string
+	lockedOut []string
+}
+
+func (a *fakeAuditor) LoginFailed(provider, username, sourceIP string) {
+	a.failed = append(a.failed, provider+"/"+username+"@"+sourceIP)
+}
+
+func (a *fakeAuditor) LockedOut(provider, subject string, until time.Time) {
+	a.lockedOut = append(a.lockedOut, provider+"/"+subject)
+}
+
+func newTestLockout(config Config) (*Lockout, *fakeAuditor, *time.Time) {
+	auditor := &fakeAuditor{}
+	now := time.Unix(0, 0)
+	l := New(config, auditor)
+	l.now = func() time.Time { return now }
+	return l, auditor, &now
+}
+
+func request(remoteAddr string) *http.Request {
+	return &http.Request{RemoteAddr: remoteAddr}
+}
+
+func TestUserLockout(t *testing.T) {
+	l, auditor, _ := newTestLockout(Config{MaxFailedAttempts: 3, Lockout: time.Minute, MaxLockout: 3 * time.Minute})
+	throttle := l.ForProvider("htpasswd")
+
+	for i := 0; i < 3; i++ {
+		if wait := throttle.LockedOut("alice", request("10.0.0.1:1234")); wait != 0 {
+			t.Fatalf("%d: unexpected lockout for %v", i, wait)
+		}
+		throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+	}
+	if len(auditor.failed) != 3 || auditor.failed[0] != "htpasswd/alice@10.0.0.1" {
+		t.Errorf("unexpected failed logins: %v", auditor.failed)
+	}
+	if len(auditor.lockedOut) != 1 || auditor.lockedOut[0] != "htpasswd/user alice" {
+		t.Errorf("unexpected lockouts: %v", auditor.lockedOut)
+	}
+
+	// the user is locked out from any address, other users are not
+	if wait := throttle.LockedOut("alice", request("10.0.0.2:1234")); wait != time.Minute {
+		t.Errorf("expected a lockout of a minute, got %v", wait)
+	}
+	if wait := throttle.LockedOut("bob", request("10.0.0.1:1234")); wait != 0 {
+		t.Errorf("unexpected lockout of another user for %v", wait)
+	}
+	if wait := l.ForProvider("ldap").LockedOut("alice", request("10.0.0.1:1234")); wait != 0 {
+		t.Errorf("unexpected lockout with another identity provider for %v", wait)
+	}
+
+}
+
+func TestBackoff(t *testing.T) {
+	l, _, now := newTestLockout(Config{MaxFailedAttempts: 1, Lockout: time.Minute, MaxLockout: 10 * time.Minute})
+	throttle := l.ForProvider("htpasswd")
+
+	for _, expected := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute} {
+		throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+		wait := throttle.LockedOut("alice", request("10.0.0.1:1234"))
+		if wait != expected {
+			t.Errorf("expected a lockout of %v, got %v", expected, wait)
+		}
+		*now = now.Add(wait)
+	}
+
+	// failures are forgotten after the maximum lockout without another failure
+	*now = now.Add(11 * time.Minute)
+	throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+	if wait := throttle.LockedOut("alice", request("10.0.0.1:1234")); wait != time.Minute {
+		t.Errorf("expected the lockout to start over, got %v", wait)
+	}
+}
+
+func TestSourceIPLockout(t *testing.T) {
+	l, auditor, _ := newTestLockout(Config{MaxFailedAttemptsPerSourceIP: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	throttle := l.ForProvider("htpasswd")
+
+	throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+	throttle.LoginFailed("bob", request("10.0.0.1:5678"))
+	if len(auditor.lockedOut) != 1 || auditor.lockedOut[0] != "htpasswd/source IP 10.0.0.1" {
+		t.Errorf("unexpected lockouts: %v", auditor.lockedOut)
+	}
+	if wait := throttle.LockedOut("carol", request("10.0.0.1:1111")); wait != time.Minute {
+		t.Errorf("expected any user to be locked out from the address, got %v", wait)
+	}
+	if wait := throttle.LockedOut("alice", request("10.0.0.2:1234")); wait != 0 {
+		t.Errorf("unexpected lockout from another address for %v", wait)
+	}
+
+	// a successful login does not clear the failures of the address
+	throttle.LoginSucceeded("alice", request("10.0.0.1:1234"))
+	if wait := throttle.LockedOut("carol", request("10.0.0.1:1111")); wait != time.Minute {
+		t.Errorf("expected the address to stay locked out, got %v", wait)
+	}
+}
+
+func TestRequestLockout(t *testing.T) {
+	l, auditor, _ := newTestLockout(Config{MaxFailedAttempts: 1, MaxFailedAttemptsPerSourceIP: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	throttle := l.ForRequests("api")
+
+	throttle.LoginFailed("", request("10.0.0.1:1234"))
+	throttle.LoginFailed("", request("10.0.0.1:4321"))
+	if len(auditor.failed) != 0 {
+		t.Errorf("expected the failures not to be audited, got %v", auditor.failed)
+	}
+	if len(auditor.lockedOut) != 1 || auditor.lockedOut[0] != "api/source IP 10.0.0.1" {
+		t.Errorf("unexpected lockouts: %v", auditor.lockedOut)
+	}
+
+	// the source IP is locked out, other addresses and the logins of the users are not
+	if wait := throttle.LockedOut("", request("10.0.0.1:1111")); wait != time.Minute {
+		t.Errorf("expected the address to be locked out, got %v", wait)
+	}
+	if wait := throttle.LockedOut("", request("10.0.0.2:1234")); wait != 0 {
+		t.Errorf("unexpected lockout of another address for %v", wait)
+	}
+	if wait := l.ForProvider("htpasswd").LockedOut("alice", request("10.0.0.1:1234")); wait != 0 {
+		t.Errorf("unexpected lockout of a login with another provider for %v", wait)
+	}
+}
+
+func TestMaxSubjects(t *testing.T) {
+	l, _, now := newTestLockout(Config{MaxFailedAttemptsPerSourceIP: 2, Lockout: time.Minute, MaxLockout: time.Hour, MaxSubjects: 2})
+	throttle := l.ForRequests("api")
+
+	for _, address := range []string{"10.0.0.1:1234", "10.0.0.2:1234", "10.0.0.1:1234", "10.0.0.3:1234"} {
+		throttle.LoginFailed("", request(address))
+		*now = now.Add(time.Second)
+	}
+	if len(l.attempts) != 2 {
+		t.Errorf("expected the failures of 2 addresses to be kept, got %v", l.attempts)
+	}
+	// 10.0.0.2 failed least recently
+	if _, ok := l.attempts[sourceIPKey("api", "10.0.0.2")]; ok {
+		t.Errorf("expected the failures of 10.0.0.2 to be forgotten, got %v", l.attempts)
+	}
+	if wait := throttle.LockedOut("", request("10.0.0.1:1234")); wait == 0 {
+		t.Errorf("expected 10.0.0.1 to stay locked out")
+	}
+}
+
+func TestLoginSucceeded(t *testing.T) {
+	l, _, _ := newTestLockout(Config{MaxFailedAttempts: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	throttle := l.ForProvider("htpasswd")
+
+	throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+	throttle.LoginSucceeded("alice", request("10.0.0.1:1234"))
+	throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+	if wait := throttle.LockedOut("alice", request("10.0.0.1:1234")); wait != 0 {
+		t.Errorf("expected a successful login to clear the failures of the user, got a lockout for %v", wait)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	l, _, now := newTestLockout(Config{MaxFailedAttempts: 5, MaxFailedAttemptsPerSourceIP: 5, Lockout: time.Minute, MaxLockout: time.Hour})
+	throttle := l.ForProvider("htpasswd")
+
+	throttle.LoginFailed("alice", request("10.0.0.1:1234"))
+	*now = now.Add(2 * time.Hour)
+	throttle.LoginFailed("bob", request("10.0.0.2:1234"))
+	if len(l.attempts) != 2 {
+		t.Errorf("expected only the failures of bob to be kept, got %v", l.attempts)
+	}
+}
+
+func TestSourceIP(t *testing.T) {
+	for remoteAddr, expected := range map[string]string{
+		"10.0.0.1:1234":  "10.0.0.1",
+		"[fe80::1]:1234": "fe80::1",
+		"not-an-address": "not-an-address",
+	} {
+		if ip := SourceIP(request(remoteAddr)); ip != expected {
+			t.Errorf("%s: expected %s, got %s", remoteAddr, expected, ip)
+		}
+	}
+}
+
+func TestForwardedSourceIP(t *testing.T) {
+	trustedProxies, err := ParseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expected   string
+	}{
+		{name: "direct request", remoteAddr: "172.16.0.1:1234", expected: "172.16.0.1"},
+		{name: "header of an untrusted client", remoteAddr: "172.16.0.1:1234", forwarded: []string{"1.2.3.4"}, expected: "172.16.0.1"},
+		{name: "request through a proxy", remoteAddr: "10.0.0.1:1234", forwarded: []string{"1.2.3.4"}, expected: "1.2.3.4"},
+		{name: "request through several proxies", remoteAddr: "10.0.0.1:1234", forwarded: []string{"1.2.3.4, 192.168.1.1"}, expected: "1.2.3.4"},
+		{name: "header set by the client", remoteAddr: "10.0.0.1:1234", forwarded: []string{"5.6.7.8, 1.2.3.4"}, expected: "1.2.3.4"},
+		{name: "headers of several proxies", remoteAddr: "10.0.0.1:1234", forwarded: []string{"1.2.3.4", "192.168.1.1"}, expected: "1.2.3.4"},
+		{name: "invalid address", remoteAddr: "10.0.0.1:1234", forwarded: []string{"1.2.3.4, unknown"}, expected: "10.0.0.1"},
+		{name: "proxy without a header", remoteAddr: "10.0.0.1:1234", expected: "10.0.0.1"},
+	}
+	for _, tc := range testCases {
+		req := request(tc.remoteAddr)
+		req.Header = http.Header{"X-Forwarded-For": tc.forwarded}
+		if ip := ForwardedSourceIP(req, trustedProxies); ip != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, ip)
+		}
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	if _, err := ParseTrustedProxies([]string{"10.0.0.1", "fe80::1", "10.0.0.0/8"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ParseTrustedProxies([]string{"proxy.example.com"}); err == nil {
+		t.Errorf("expected an error for a host name")
+	}
+}
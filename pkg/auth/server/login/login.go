@@ -15,6 +15,7 @@ import (
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	"github.com/openshift/origin/pkg/auth/server/csrf"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 )
 
 const (
@@ -48,16 +49,20 @@ type LoginFormFields struct {
 }
 
 type Login struct {
-	csrf   csrf.CSRF
-	auth   PasswordAuthenticator
-	render LoginFormRenderer
+	csrf     csrf.CSRF
+	auth     PasswordAuthenticator
+	render   LoginFormRenderer
+	throttle lockout.Throttle
 }
 
-func NewLogin(csrf csrf.CSRF, auth PasswordAuthenticator, render LoginFormRenderer) *Login {
+// NewLogin returns a login handler. If throttle is not nil, logins it refuses are rejected without checking the
+// password.
+func NewLogin(csrf csrf.CSRF, auth PasswordAuthenticator, render LoginFormRenderer, throttle lockout.Throttle) *Login {
 	return &Login{
-		csrf:   csrf,
-		auth:   auth,
-		render: render,
+		csrf:     csrf,
+		auth:     auth,
+		render:   render,
+		throttle: throttle,
 	}
 }
 
@@ -111,6 +116,8 @@ func (l *Login) handleLoginForm(w http.ResponseWriter, req *http.Request) {
 		form.Error = "Could not check CSRF token. Please try again."
 	case "access denied":
 		form.Error = "Invalid login or password. Please try again."
+	case "locked out":
+		form.Error = "Too many failed login attempts. Please try again later."
 	default:
 		form.Error = "An unknown error has occurred. Please try again."
 	}
@@ -136,6 +143,10 @@ func (l *Login) handleLogin(w http.ResponseWriter, req *http.Request) {
 		failed("user required", w, req)
 		return
 	}
+	if l.throttle != nil && l.throttle.LockedOut(user, req) > 0 {
+		failed("locked out", w, req)
+		return
+	}
 	context, ok, err := l.auth.AuthenticatePassword(user, password)
 	if err != nil {
 		glog.Errorf("Unable to authenticate password: %v", err)
@@ -143,9 +154,15 @@ func (l *Login) handleLogin(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	if !ok {
+		if l.throttle != nil {
+			l.throttle.LoginFailed(user, req)
+		}
 		failed("access denied", w, req)
 		return
 	}
+	if l.throttle != nil {
+		l.throttle.LoginSucceeded(user, req)
+	}
 	l.auth.AuthenticationSucceeded(context, then, w, req)
 }
 
@@ -6,12 +6,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/kubernetes/pkg/auth/user"
 
 	"github.com/openshift/origin/pkg/auth/server/csrf"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 )
 
 type testAuth struct {
@@ -37,10 +40,32 @@ func (t *testAuth) AuthenticationSucceeded(user user.Info, then string, w http.R
 	return false, nil
 }
 
+type testThrottle struct {
+	Locked    bool
+	Failed    []string
+	Succeeded []string
+}
+
+func (t *testThrottle) LockedOut(username string, req *http.Request) time.Duration {
+	if t.Locked {
+		return time.Minute
+	}
+	return 0
+}
+
+func (t *testThrottle) LoginFailed(username string, req *http.Request) {
+	t.Failed = append(t.Failed, username)
+}
+
+func (t *testThrottle) LoginSucceeded(username string, req *http.Request) {
+	t.Succeeded = append(t.Succeeded, username)
+}
+
 func TestLogin(t *testing.T) {
 	testCases := map[string]struct {
 		CSRF       csrf.CSRF
 		Auth       *testAuth
+		Throttle   *testThrottle
 		Path       string
 		PostValues url.Values
 
@@ -48,6 +73,8 @@ func TestLogin(t *testing.T) {
 		ExpectRedirect   string
 		ExpectContains   []string
 		ExpectThen       string
+		ExpectFailed     []string
+		ExpectSucceeded  []string
 	}{
 		"display form": {
 			CSRF: &csrf.FakeCSRF{Token: "test"},
@@ -106,6 +133,39 @@ func TestLogin(t *testing.T) {
 			},
 			ExpectRedirect: "/login?reason=access+denied",
 		},
+		"display form when locked out": {
+			CSRF: &csrf.FakeCSRF{Token: "test"},
+			Auth: &testAuth{},
+			Path: "?reason=locked+out&username=user",
+
+			ExpectStatusCode: 200,
+			ExpectContains: []string{
+				`Too many failed login attempts`,
+			},
+		},
+		"redirect when locked out": {
+			CSRF:     &csrf.FakeCSRF{Token: "test"},
+			Auth:     &testAuth{Success: true, User: &user.DefaultInfo{Name: "user"}},
+			Throttle: &testThrottle{Locked: true},
+			Path:     "/login",
+			PostValues: url.Values{
+				"csrf":     []string{"test"},
+				"username": []string{"user"},
+			},
+			ExpectRedirect: "/login?reason=locked+out",
+		},
+		"failed login is recorded": {
+			CSRF:     &csrf.FakeCSRF{Token: "test"},
+			Auth:     &testAuth{Success: false},
+			Throttle: &testThrottle{},
+			Path:     "/login",
+			PostValues: url.Values{
+				"csrf":     []string{"test"},
+				"username": []string{"user"},
+			},
+			ExpectRedirect: "/login?reason=access+denied",
+			ExpectFailed:   []string{"user"},
+		},
 		"redirect on auth error": {
 			CSRF: &csrf.FakeCSRF{Token: "test"},
 			Auth: &testAuth{Err: errors.New("failed")},
@@ -137,6 +197,18 @@ func TestLogin(t *testing.T) {
 			},
 			ExpectThen: "done",
 		},
+		"successful login is recorded": {
+			CSRF:     &csrf.FakeCSRF{Token: "test"},
+			Auth:     &testAuth{Success: true, User: &user.DefaultInfo{Name: "user"}},
+			Throttle: &testThrottle{},
+			Path:     "/login?then=done",
+			PostValues: url.Values{
+				"csrf":     []string{"test"},
+				"username": []string{"user"},
+			},
+			ExpectThen:      "done",
+			ExpectSucceeded: []string{"user"},
+		},
 	}
 
 	for k, testCase := range testCases {
@@ -145,7 +217,11 @@ func TestLogin(t *testing.T) {
 			t.Errorf("%s: unexpected error: %v", k, err)
 			continue
 		}
-		server := httptest.NewServer(NewLogin(testCase.CSRF, testCase.Auth, loginFormRenderer))
+		var throttle lockout.Throttle
+		if testCase.Throttle != nil {
+			throttle = testCase.Throttle
+		}
+		server := httptest.NewServer(NewLogin(testCase.CSRF, testCase.Auth, loginFormRenderer, throttle))
 
 		var resp *http.Response
 		if testCase.PostValues != nil {
@@ -185,6 +261,18 @@ func TestLogin(t *testing.T) {
 			t.Errorf("%s: did not find expected 'then' value: %#v", k, testCase.Auth)
 		}
 
+		if testCase.Throttle != nil {
+			if testCase.Throttle.Locked && len(testCase.Auth.Username) > 0 {
+				t.Errorf("%s: expected the password not to be checked when locked out", k)
+			}
+			if !reflect.DeepEqual(testCase.ExpectFailed, testCase.Throttle.Failed) {
+				t.Errorf("%s: expected failed logins %v, got %v", k, testCase.ExpectFailed, testCase.Throttle.Failed)
+			}
+			if !reflect.DeepEqual(testCase.ExpectSucceeded, testCase.Throttle.Succeeded) {
+				t.Errorf("%s: expected successful logins %v, got %v", k, testCase.ExpectSucceeded, testCase.Throttle.Succeeded)
+			}
+		}
+
 		if len(testCase.ExpectContains) > 0 {
 			data, _ := ioutil.ReadAll(resp.Body)
 			body := string(data)
@@ -11,6 +11,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/util"
 
+	"github.com/openshift/origin/pkg/auth/server/csrf"
 	"github.com/openshift/origin/pkg/auth/server/login"
 )
 
@@ -22,6 +23,7 @@ const (
 
 type endpointDetails struct {
 	publicMasterURL   string
+	csrf              csrf.CSRF
 	originOAuthClient *osincli.Client
 }
 
@@ -29,8 +31,11 @@ type Endpoints interface {
 	Install(mux login.Mux, paths ...string)
 }
 
-func NewEndpoints(publicMasterURL string, originOAuthClient *osincli.Client) Endpoints {
-	return &endpointDetails{publicMasterURL, originOAuthClient}
+// NewEndpoints returns the endpoints to request a token in a browser. The authorization code the browser is
+// redirected back with is only exchanged for a token when the user submits the displayed form, which is protected
+// by csrf.
+func NewEndpoints(publicMasterURL string, csrf csrf.CSRF, originOAuthClient *osincli.Client) Endpoints {
+	return &endpointDetails{publicMasterURL, csrf, originOAuthClient}
 }
 
 // Install registers the request token endpoints into a mux. It is expected that the
@@ -64,6 +69,33 @@ func (endpoints *endpointDetails) displayToken(w http.ResponseWriter, req *http.
 		return
 	}
 
+	// Following the redirect with the code only displays a form. Otherwise any page could make a browser exchange
+	// a code it obtained for the user, and the token would be granted without the user asking for it.
+	if req.Method != "POST" {
+		csrfToken, err := endpoints.csrf.Generate(w, req)
+		if err != nil {
+			util.HandleError(fmt.Errorf("unable to generate CSRF token: %v", err))
+			data.Error = "Error generating CSRF token"
+			w.WriteHeader(http.StatusInternalServerError)
+			renderToken(w, data)
+			return
+		}
+		data.Code = authorizeData.Code
+		data.CSRF = csrfToken
+		renderToken(w, data)
+		return
+	}
+
+	if ok, err := endpoints.csrf.Check(req, req.FormValue("csrf")); !ok || err != nil {
+		if err != nil {
+			util.HandleError(fmt.Errorf("unable to check CSRF token: %v", err))
+		}
+		data.Error = "Could not check CSRF token. Please request another token."
+		w.WriteHeader(http.StatusBadRequest)
+		renderToken(w, data)
+		return
+	}
+
 	accessReq := endpoints.originOAuthClient.NewAccessRequest(osincli.AUTHORIZATION_CODE, authorizeData)
 	accessData, err := accessReq.GetToken()
 	if err != nil {
@@ -86,6 +118,8 @@ func renderToken(w io.Writer, data tokenData) {
 type tokenData struct {
 	Error           string
 	AccessToken     string
+	Code            string
+	CSRF            string
 	RequestURL      string
 	PublicMasterURL string
 }
@@ -108,6 +142,12 @@ var tokenTemplate = template.Must(template.New("tokenTemplate").Parse(`
 
 {{ if .Error }}
   {{ .Error }}
+{{ else if .Code }}
+  <form method="post" action="display">
+    <input type="hidden" name="code" value="{{.Code}}">
+    <input type="hidden" name="csrf" value="{{.CSRF}}">
+    <button type="submit">Display Token</button>
+  </form>
 {{ else }}
   <h2>Your API token is</h2>
   <code>{{.AccessToken}}</code>
@@ -0,0 +1,110 @@
+package tokenrequest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/RangelReale/osincli"
+
+	"github.com/openshift/origin/pkg/auth/server/csrf"
+)
+
+func TestDisplayToken(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		if req.Form.Get("code") != "mycode" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"mytoken","token_type":"bearer"}`)
+	}))
+	defer tokenServer.Close()
+
+	client, err := osincli.NewClient(&osincli.ClientConfig{
+		ClientId:     "openshift-browser-client",
+		ClientSecret: "secret",
+		AuthorizeUrl: tokenServer.URL + "/authorize",
+		TokenUrl:     tokenServer.URL + "/token",
+		RedirectUrl:  "https://example.com/oauth/token/display",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewEndpoints("https://example.com", &csrf.FakeCSRF{Token: "csrf"}, client).Install(mux, "/oauth")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testCases := map[string]struct {
+		Method string
+		Values url.Values
+
+		ExpectStatusCode  int
+		ExpectContains    []string
+		ExpectNotContains []string
+	}{
+		"following the redirect displays a form": {
+			Method:            "GET",
+			Values:            url.Values{"code": {"mycode"}},
+			ExpectStatusCode:  http.StatusOK,
+			ExpectContains:    []string{`name="code" value="mycode"`, `name="csrf" value="csrf"`},
+			ExpectNotContains: []string{"mytoken"},
+		},
+		"posting the form without the CSRF token is rejected": {
+			Method:            "POST",
+			Values:            url.Values{"code": {"mycode"}, "csrf": {"wrong"}},
+			ExpectStatusCode:  http.StatusBadRequest,
+			ExpectContains:    []string{"Could not check CSRF token"},
+			ExpectNotContains: []string{"mytoken"},
+		},
+		"posting the form exchanges the code": {
+			Method:           "POST",
+			Values:           url.Values{"code": {"mycode"}, "csrf": {"csrf"}},
+			ExpectStatusCode: http.StatusOK,
+			ExpectContains:   []string{"<code>mytoken</code>"},
+		},
+		"authorize errors are displayed": {
+			Method:           "GET",
+			Values:           url.Values{"error": {"access_denied"}},
+			ExpectStatusCode: http.StatusInternalServerError,
+			ExpectContains:   []string{"Error handling auth request"},
+		},
+	}
+
+	for k, testCase := range testCases {
+		var resp *http.Response
+		var err error
+		if testCase.Method == "POST" {
+			resp, err = http.PostForm(server.URL+"/oauth/token/display", testCase.Values)
+		} else {
+			resp, err = http.Get(server.URL + "/oauth/token/display?" + testCase.Values.Encode())
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		data, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		body := string(data)
+
+		if resp.StatusCode != testCase.ExpectStatusCode {
+			t.Errorf("%s: expected status %d, got %d: %s", k, testCase.ExpectStatusCode, resp.StatusCode, body)
+		}
+		for _, s := range testCase.ExpectContains {
+			if !strings.Contains(body, s) {
+				t.Errorf("%s: did not find expected value %s: %s", k, s, body)
+			}
+		}
+		for _, s := range testCase.ExpectNotContains {
+			if strings.Contains(body, s) {
+				t.Errorf("%s: found unexpected value %s: %s", k, s, body)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package noderestriction
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	kubelettypes "k8s.io/kubernetes/pkg/kubelet/types"
+
+	nodeauthorizer "github.com/openshift/origin/pkg/authorization/authorizer/node"
+)
+
+// PluginName is the name the plugin is registered under
+const PluginName = "OriginNodeRestriction"
+
+func init() {
+	admission.RegisterPlugin(PluginName, func(client client.Interface, config io.Reader) (admission.Interface, error) {
+		return NewNodeRestriction(), nil
+	})
+}
+
+// nodeRestriction is an implementation of admission.Interface which limits the pods and nodes a node
+// may create or update to its own. It complements the node authorizer, which cannot see the objects
+// being created.
+type nodeRestriction struct {
+	*admission.Handler
+}
+
+// NewNodeRestriction creates a new admission controller that keeps nodes from creating or updating the
+// Node objects of other nodes, and from creating pods other than mirror pods bound to themselves.
+func NewNodeRestriction() admission.Interface {
+	return &nodeRestriction{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}
+}
+
+func (r *nodeRestriction) Admit(a admission.Attributes) error {
+	nodeName, isNode := nodeauthorizer.NodeNameFromUser(a.GetUserInfo())
+	if !isNode {
+		return nil
+	}
+
+	switch a.GetResource() {
+	case "nodes":
+		node, ok := a.GetObject().(*kapi.Node)
+		if !ok {
+			return nil
+		}
+		if node.Name != nodeName {
+			return apierrors.NewForbidden(a.GetResource(), node.Name, fmt.Errorf("node %q may only create or update its own node object", nodeName))
+		}
+
+	case "pods":
+		if a.GetOperation() != admission.Create || len(a.GetSubresource()) > 0 {
+			return nil
+		}
+		pod, ok := a.GetObject().(*kapi.Pod)
+		if !ok {
+			return nil
+		}
+		if _, isMirror := pod.Annotations[kubelettypes.ConfigMirrorAnnotationKey]; !isMirror {
+			return apierrors.NewForbidden(a.GetResource(), pod.Name, fmt.Errorf("node %q may only create mirror pods", nodeName))
+		}
+		if pod.Spec.NodeName != nodeName {
+			return apierrors.NewForbidden(a.GetResource(), pod.Name, fmt.Errorf("node %q may only create mirror pods bound to itself", nodeName))
+		}
+		if secrets := nodeauthorizer.PodSecretNames(pod); len(secrets) > 0 {
+			return apierrors.NewForbidden(a.GetResource(), pod.Name, fmt.Errorf("mirror pods may not reference secrets: %v", secrets.List()))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package noderestriction
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kubelettypes "k8s.io/kubernetes/pkg/kubelet/types"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+func TestNodeRestriction(t *testing.T) {
+	node1 := &user.DefaultInfo{Name: bootstrappolicy.NodeUsernamePrefix + "node1", Groups: []string{bootstrappolicy.NodesGroup}}
+	mirrorPod := func(nodeName string, secretNames ...string) *kapi.Pod {
+		pod := &kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Name: "static", Annotations: map[string]string{kubelettypes.ConfigMirrorAnnotationKey: "hash"}},
+			Spec:       kapi.PodSpec{NodeName: nodeName},
+		}
+		for _, name := range secretNames {
+			pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, kapi.LocalObjectReference{Name: name})
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name        string
+		user        user.Info
+		object      runtime.Object
+		resource    string
+		subresource string
+		operation   admission.Operation
+		allowed     bool
+	}{
+		{
+			name:      "create own node",
+			user:      node1,
+			object:    &kapi.Node{ObjectMeta: kapi.ObjectMeta{Name: "node1"}},
+			resource:  "nodes",
+			operation: admission.Create,
+			allowed:   true,
+		},
+		{
+			name:      "create other node",
+			user:      node1,
+			object:    &kapi.Node{ObjectMeta: kapi.ObjectMeta{Name: "node2"}},
+			resource:  "nodes",
+			operation: admission.Create,
+		},
+		{
+			name:        "update status of other node",
+			user:        node1,
+			object:      &kapi.Node{ObjectMeta: kapi.ObjectMeta{Name: "node2"}},
+			resource:    "nodes",
+			subresource: "status",
+			operation:   admission.Update,
+		},
+		{
+			name:      "create mirror pod",
+			user:      node1,
+			object:    mirrorPod("node1"),
+			resource:  "pods",
+			operation: admission.Create,
+			allowed:   true,
+		},
+		{
+			name:      "create mirror pod on other node",
+			user:      node1,
+			object:    mirrorPod("node2"),
+			resource:  "pods",
+			operation: admission.Create,
+		},
+		{
+			name:      "create mirror pod referencing a secret",
+			user:      node1,
+			object:    mirrorPod("node1", "pull-secret"),
+			resource:  "pods",
+			operation: admission.Create,
+		},
+		{
+			name:      "create regular pod",
+			user:      node1,
+			object:    &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "regular"}, Spec: kapi.PodSpec{NodeName: "node1"}},
+			resource:  "pods",
+			operation: admission.Create,
+		},
+		{
+			name:      "other users are not restricted",
+			user:      &user.DefaultInfo{Name: "system:admin"},
+			object:    &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "regular"}, Spec: kapi.PodSpec{NodeName: "node2"}},
+			resource:  "pods",
+			operation: admission.Create,
+			allowed:   true,
+		},
+	}
+
+	plugin := NewNodeRestriction()
+	for _, test := range tests {
+		attributes := admission.NewAttributesRecord(test.object, "", "ns", "", test.resource, test.subresource, test.operation, test.user)
+		err := plugin.Admit(attributes)
+		if test.allowed && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.allowed && err == nil {
+			t.Errorf("%s: expected the request to be rejected", test.name)
+		}
+	}
+}
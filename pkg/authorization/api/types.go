@@ -80,7 +80,7 @@ var (
 		SDNGroupName:         {"clusternetworks", "hostsubnets", "netnamespaces"},
 		TemplateGroupName:    {"templates", "templateconfigs", "processedtemplates"},
 		UserGroupName:        {"identities", "users", "useridentitymappings", "groups"},
-		OAuthGroupName:       {"oauthauthorizetokens", "oauthaccesstokens", "oauthclients", "oauthclientauthorizations"},
+		OAuthGroupName:       {"oauthauthorizetokens", "oauthaccesstokens", "oauthclients", "oauthclientauthorizations", "useroauthclientauthorizations"},
 		PolicyOwnerGroupName: {"policies", "policybindings"},
 
 		// RAR and SAR are in this list to support backwards compatibility with clients that expect access to those resource in a namespace scope and a cluster scope.
@@ -88,8 +88,8 @@ var (
 		PermissionGrantingGroupName: {"roles", "rolebindings", "resourceaccessreviews" /* cluster scoped*/, "subjectaccessreviews" /* cluster scoped*/, "localresourceaccessreviews", "localsubjectaccessreviews"},
 		OpenshiftExposedGroupName:   {BuildGroupName, ImageGroupName, DeploymentGroupName, TemplateGroupName, "routes"},
 		OpenshiftAllGroupName: {OpenshiftExposedGroupName, UserGroupName, OAuthGroupName, PolicyOwnerGroupName, SDNGroupName, PermissionGrantingGroupName, OpenshiftStatusGroupName, "projects",
-			"clusterroles", "clusterrolebindings", "clusterpolicies", "clusterpolicybindings", "images" /* cluster scoped*/, "projectrequests", "builds/details"},
-		OpenshiftStatusGroupName: {"imagestreams/status", "routes/status"},
+			"clusterroles", "clusterrolebindings", "clusterpolicies", "clusterpolicybindings", "images" /* cluster scoped*/, "projectrequests", "builds/details", "builds/finalize", "certificatesigningrequests"},
+		OpenshiftStatusGroupName: {"imagestreams/status", "routes/status", "certificatesigningrequests/approval", "certificatesigningrequests/status"},
 
 		QuotaGroupName:         {"limitranges", "resourcequotas", "resourcequotausages"},
 		KubeExposedGroupName:   {"pods", "replicationcontrollers", "serviceaccounts", "services", "endpoints", "persistentvolumeclaims", "pods/log"},
@@ -269,6 +269,11 @@ type AuthorizationAttributes struct {
 	ResourceName string
 	// Content is the actual content of the request for create and update
 	Content kruntime.EmbeddedObject
+	// IsNonResourceURL is true if this is a request for a non-resource URL, such as /healthz or /metrics. Resource and
+	// ResourceName must be empty and Path is checked against the NonResourceURLs of the policy rules instead.
+	IsNonResourceURL bool
+	// Path is the path of the non-resource URL
+	Path string
 }
 
 // PolicyList is a collection of Policies
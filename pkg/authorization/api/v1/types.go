@@ -182,6 +182,10 @@ type AuthorizationAttributes struct {
 	ResourceName string `json:"resourceName" description:"name of the resource being requested for a get or delete"`
 	// Content is the actual content of the request for create and update
 	Content kruntime.RawExtension `json:"content,omitempty" description:"actual content of the request for create and update"`
+	// IsNonResourceURL is true if this is a request for a non-resource URL, such as /healthz or /metrics
+	IsNonResourceURL bool `json:"isNonResourceURL,omitempty" description:"true if this is a request for a non-resource URL, which is checked against the nonResourceURLs of the policy rules"`
+	// Path is the path of the non-resource URL
+	Path string `json:"path,omitempty" description:"path of the non-resource URL"`
 }
 
 // PolicyList is a collection of Policies
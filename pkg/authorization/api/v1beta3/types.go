@@ -185,6 +185,10 @@ type AuthorizationAttributes struct {
 	ResourceName string `json:"resourceName"`
 	// Content is the actual content of the request for create and update
 	Content kruntime.RawExtension `json:"content,omitempty"`
+	// IsNonResourceURL is true if this is a request for a non-resource URL, such as /healthz or /metrics
+	IsNonResourceURL bool `json:"isNonResourceURL,omitempty"`
+	// Path is the path of the non-resource URL
+	Path string `json:"path,omitempty"`
 }
 
 // PolicyList is a collection of Policies
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
@@ -14,53 +15,57 @@ import (
 )
 
 func ValidateSubjectAccessReview(review *authorizationapi.SubjectAccessReview) fielderrors.ValidationErrorList {
-	allErrs := fielderrors.ValidationErrorList{}
-
-	if len(review.Action.Verb) == 0 {
-		allErrs = append(allErrs, fielderrors.NewFieldRequired("verb"))
-	}
-	if len(review.Action.Resource) == 0 {
-		allErrs = append(allErrs, fielderrors.NewFieldRequired("resource"))
-	}
-
-	return allErrs
+	return validateAction(review.Action)
 }
 
 func ValidateResourceAccessReview(review *authorizationapi.ResourceAccessReview) fielderrors.ValidationErrorList {
-	allErrs := fielderrors.ValidationErrorList{}
-
-	if len(review.Action.Verb) == 0 {
-		allErrs = append(allErrs, fielderrors.NewFieldRequired("verb"))
-	}
-	if len(review.Action.Resource) == 0 {
-		allErrs = append(allErrs, fielderrors.NewFieldRequired("resource"))
-	}
-
-	return allErrs
+	return validateAction(review.Action)
 }
 
 func ValidateLocalSubjectAccessReview(review *authorizationapi.LocalSubjectAccessReview) fielderrors.ValidationErrorList {
-	allErrs := fielderrors.ValidationErrorList{}
+	return validateLocalAction(review.Action)
+}
 
-	if len(review.Action.Verb) == 0 {
-		allErrs = append(allErrs, fielderrors.NewFieldRequired("verb"))
-	}
-	if len(review.Action.Resource) == 0 {
-		allErrs = append(allErrs, fielderrors.NewFieldRequired("resource"))
-	}
+func ValidateLocalResourceAccessReview(review *authorizationapi.LocalResourceAccessReview) fielderrors.ValidationErrorList {
+	return validateLocalAction(review.Action)
+}
 
-	return allErrs
+// validateLocalAction checks that action names a resource. Non-resource URLs are only authorized by cluster
+// policy, so reviewing them in a namespace would report the rules of the namespace that are never applied to them.
+func validateLocalAction(action authorizationapi.AuthorizationAttributes) fielderrors.ValidationErrorList {
+	if action.IsNonResourceURL {
+		return fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("isNonResourceURL", true, "non-resource URLs can only be reviewed at the cluster scope")}
+	}
+	return validateAction(action)
 }
 
-func ValidateLocalResourceAccessReview(review *authorizationapi.LocalResourceAccessReview) fielderrors.ValidationErrorList {
+// validateAction checks that action names either a resource or the path of a non-resource URL
+func validateAction(action authorizationapi.AuthorizationAttributes) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
-	if len(review.Action.Verb) == 0 {
+	if len(action.Verb) == 0 {
 		allErrs = append(allErrs, fielderrors.NewFieldRequired("verb"))
 	}
-	if len(review.Action.Resource) == 0 {
+	if action.IsNonResourceURL {
+		if len(action.Path) == 0 {
+			allErrs = append(allErrs, fielderrors.NewFieldRequired("path"))
+		} else if !strings.HasPrefix(action.Path, "/") {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("path", action.Path, "must begin with /"))
+		}
+		if len(action.Resource) > 0 {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("resource", action.Resource, "must be empty for a non-resource URL"))
+		}
+		if len(action.ResourceName) > 0 {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("resourceName", action.ResourceName, "must be empty for a non-resource URL"))
+		}
+		return allErrs
+	}
+	if len(action.Resource) == 0 {
 		allErrs = append(allErrs, fielderrors.NewFieldRequired("resource"))
 	}
+	if len(action.Path) > 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("path", action.Path, "may only be set for a non-resource URL"))
+	}
 
 	return allErrs
 }
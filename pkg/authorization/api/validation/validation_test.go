@@ -419,3 +419,82 @@ func TestValidateClusterPolicyBinding(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateLocalAccessReviews(t *testing.T) {
+	resource := authorizationapi.AuthorizationAttributes{Verb: "get", Resource: "pods"}
+	if errs := ValidateLocalSubjectAccessReview(&authorizationapi.LocalSubjectAccessReview{Action: resource}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := ValidateLocalResourceAccessReview(&authorizationapi.LocalResourceAccessReview{Action: resource}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	nonResource := authorizationapi.AuthorizationAttributes{Verb: "get", IsNonResourceURL: true, Path: "/healthz"}
+	for _, errs := range []fielderrors.ValidationErrorList{
+		ValidateLocalSubjectAccessReview(&authorizationapi.LocalSubjectAccessReview{Action: nonResource}),
+		ValidateLocalResourceAccessReview(&authorizationapi.LocalResourceAccessReview{Action: nonResource}),
+	} {
+		if len(errs) != 1 || errs[0].(*fielderrors.ValidationError).Field != "isNonResourceURL" {
+			t.Errorf("expected non-resource URLs to be rejected in a namespace, got %v", errs)
+		}
+	}
+}
+
+func TestValidateSubjectAccessReview(t *testing.T) {
+	successCases := []authorizationapi.AuthorizationAttributes{
+		{Verb: "get", Resource: "pods"},
+		{Verb: "get", IsNonResourceURL: true, Path: "/healthz"},
+	}
+	for _, action := range successCases {
+		if errs := ValidateSubjectAccessReview(&authorizationapi.SubjectAccessReview{Action: action}); len(errs) != 0 {
+			t.Errorf("expected success for %#v: %v", action, errs)
+		}
+	}
+
+	errorCases := map[string]struct {
+		A authorizationapi.AuthorizationAttributes
+		T fielderrors.ValidationErrorType
+		F string
+	}{
+		"missing resource": {
+			A: authorizationapi.AuthorizationAttributes{Verb: "get"},
+			T: fielderrors.ValidationErrorTypeRequired,
+			F: "resource",
+		},
+		"path of resource": {
+			A: authorizationapi.AuthorizationAttributes{Verb: "get", Resource: "pods", Path: "/healthz"},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "path",
+		},
+		"missing path": {
+			A: authorizationapi.AuthorizationAttributes{Verb: "get", IsNonResourceURL: true},
+			T: fielderrors.ValidationErrorTypeRequired,
+			F: "path",
+		},
+		"relative path": {
+			A: authorizationapi.AuthorizationAttributes{Verb: "get", IsNonResourceURL: true, Path: "healthz"},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "path",
+		},
+		"resource of non-resource URL": {
+			A: authorizationapi.AuthorizationAttributes{Verb: "get", IsNonResourceURL: true, Path: "/healthz", Resource: "pods"},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "resource",
+		},
+	}
+	for k, v := range errorCases {
+		errs := ValidateSubjectAccessReview(&authorizationapi.SubjectAccessReview{Action: v.A})
+		if len(errs) == 0 {
+			t.Errorf("expected failure %s for %v", k, v.A)
+			continue
+		}
+		for i := range errs {
+			if errs[i].(*fielderrors.ValidationError).Type != v.T {
+				t.Errorf("%s: expected errors to have type %s: %v", k, v.T, errs[i])
+			}
+			if errs[i].(*fielderrors.ValidationError).Field != v.F {
+				t.Errorf("%s: expected errors to have field %s: %v", k, v.F, errs[i])
+			}
+		}
+	}
+}
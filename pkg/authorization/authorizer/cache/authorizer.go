@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
@@ -25,6 +27,18 @@ type CacheAuthorizer struct {
 
 	ttl time.Duration
 	now func() time.Time
+
+	// versioner reports the version of the policies and bindings the results depend on. All results are
+	// dropped when it changes.
+	versioner   LastSyncResourceVersioner
+	versionLock sync.Mutex
+	version     string
+}
+
+// LastSyncResourceVersioner is any object that can divulge a LastSyncResourceVersion, such as the cache of
+// policies and bindings
+type LastSyncResourceVersioner interface {
+	LastSyncResourceVersion() string
 }
 
 type authorizeCacheRecord struct {
@@ -59,6 +73,35 @@ func NewAuthorizer(a authorizer.Authorizer, ttl time.Duration, cacheSize int) (a
 	}, nil
 }
 
+// NewInvalidatingAuthorizer returns an authorizer that caches the results of the given authorizer until they
+// expire or the policies and bindings reported by versioner change
+func NewInvalidatingAuthorizer(a authorizer.Authorizer, versioner LastSyncResourceVersioner, ttl time.Duration, cacheSize int) (authorizer.Authorizer, error) {
+	cacheAuthorizer, err := NewAuthorizer(a, ttl, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	cacheAuthorizer.(*CacheAuthorizer).versioner = versioner
+	return cacheAuthorizer, nil
+}
+
+// invalidateIfChanged drops every cached result if the policies and bindings changed since they were cached
+func (c *CacheAuthorizer) invalidateIfChanged() {
+	if c.versioner == nil {
+		return
+	}
+	version := c.versioner.LastSyncResourceVersion()
+
+	c.versionLock.Lock()
+	defer c.versionLock.Unlock()
+	if version == c.version {
+		return
+	}
+	glog.V(5).Infof("policy changed from version %q to %q, dropping cached authorization results", c.version, version)
+	c.authorizeCache.Purge()
+	c.allowedSubjectsCache.Purge()
+	c.version = version
+}
+
 func (c *CacheAuthorizer) Authorize(ctx kapi.Context, a authorizer.AuthorizationAttributes) (allowed bool, reason string, err error) {
 	key, err := cacheKey(ctx, a)
 	if err != nil {
@@ -66,6 +109,7 @@ func (c *CacheAuthorizer) Authorize(ctx kapi.Context, a authorizer.Authorization
 		return c.authorizer.Authorize(ctx, a)
 	}
 
+	c.invalidateIfChanged()
 	if value, hit := c.authorizeCache.Get(key); hit {
 		switch record := value.(type) {
 		case *authorizeCacheRecord:
@@ -98,6 +142,7 @@ func (c *CacheAuthorizer) GetAllowedSubjects(ctx kapi.Context, attributes author
 		return c.authorizer.GetAllowedSubjects(ctx, attributes)
 	}
 
+	c.invalidateIfChanged()
 	if value, hit := c.allowedSubjectsCache.Get(key); hit {
 		switch record := value.(type) {
 		case *allowedSubjectsCacheRecord:
@@ -123,7 +168,14 @@ func (c *CacheAuthorizer) GetAllowedSubjects(ctx kapi.Context, attributes author
 }
 
 func cacheKey(ctx kapi.Context, a authorizer.AuthorizationAttributes) (string, error) {
-	if a.GetRequestAttributes() != nil {
+	switch requestAttributes := a.GetRequestAttributes().(type) {
+	case nil:
+	case *http.Request:
+		// only the content of a request is inspected by policy, and reads have none
+		if requestAttributes.Method != "GET" && requestAttributes.Method != "HEAD" {
+			return "", errors.New("cannot cache request attributes")
+		}
+	default:
 		// TODO: see if we can serialize this?
 		return "", errors.New("cannot cache request attributes")
 	}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"encoding/json"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
@@ -31,6 +32,16 @@ func TestCacheKey(t *testing.T) {
 			Attrs:       &authorizer.DefaultAuthorizationAttributes{RequestAttributes: true},
 			ExpectedErr: true,
 		},
+		"uncacheable request content": {
+			Context:     kapi.NewContext(),
+			Attrs:       &authorizer.DefaultAuthorizationAttributes{RequestAttributes: &http.Request{Method: "POST"}},
+			ExpectedErr: true,
+		},
+		"read request": {
+			Context:     kapi.NewContext(),
+			Attrs:       &authorizer.DefaultAuthorizationAttributes{Verb: "list", RequestAttributes: &http.Request{Method: "GET"}},
+			ExpectedKey: `{"apiGroup":"","apiVersion":"","nonResourceURL":false,"resource":"","resourceName":"","url":"","verb":"list"}`,
+		},
 		"empty": {
 			Context:     kapi.NewContext(),
 			Attrs:       &authorizer.DefaultAuthorizationAttributes{},
@@ -91,3 +102,77 @@ func TestCacheKeyFields(t *testing.T) {
 		}
 	}
 }
+
+type countingAuthorizer struct {
+	allowed bool
+	calls   int
+}
+
+func (a *countingAuthorizer) Authorize(ctx kapi.Context, attributes authorizer.AuthorizationAttributes) (bool, string, error) {
+	a.calls++
+	return a.allowed, "", nil
+}
+
+func (a *countingAuthorizer) GetAllowedSubjects(ctx kapi.Context, attributes authorizer.AuthorizationAttributes) (sets.String, sets.String, error) {
+	a.calls++
+	return sets.NewString(), sets.NewString(), nil
+}
+
+type fakeVersioner struct {
+	version string
+}
+
+func (v *fakeVersioner) LastSyncResourceVersion() string {
+	return v.version
+}
+
+func TestInvalidatingAuthorizer(t *testing.T) {
+	delegate := &countingAuthorizer{allowed: true}
+	versioner := &fakeVersioner{version: "1"}
+	a, err := NewInvalidatingAuthorizer(delegate, versioner, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Now()
+	a.(*CacheAuthorizer).now = func() time.Time { return now }
+
+	ctx := kapi.WithUser(kapi.NewContext(), &user.DefaultInfo{Name: "me"})
+	attributes := &authorizer.DefaultAuthorizationAttributes{Verb: "list", Resource: "projects"}
+	authorize := func(expected bool, expectedCalls int) {
+		allowed, _, err := a.Authorize(ctx, attributes)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if allowed != expected {
+			t.Errorf("expected allowed=%v, got %v", expected, allowed)
+		}
+		if delegate.calls != expectedCalls {
+			t.Errorf("expected %d calls to the authorizer, got %d", expectedCalls, delegate.calls)
+		}
+	}
+
+	authorize(true, 1)
+	authorize(true, 1)
+
+	// a change of policy drops the cached results
+	delegate.allowed = false
+	versioner.version = "2"
+	authorize(false, 2)
+	authorize(false, 2)
+
+	// results expire without a change of policy
+	delegate.allowed = true
+	now = now.Add(2 * time.Minute)
+	authorize(true, 3)
+
+	if _, _, err := a.GetAllowedSubjects(ctx, attributes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	versioner.version = "3"
+	if _, _, err := a.GetAllowedSubjects(ctx, attributes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delegate.calls != 5 {
+		t.Errorf("expected the allowed subjects to be reviewed again after a change of policy, got %d calls", delegate.calls)
+	}
+}
@@ -0,0 +1,146 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/authorization/authorizer"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+// readOnlyVerbs are the verbs a node may use on any node or pod
+var readOnlyVerbs = sets.NewString("get", "list", "watch")
+
+// nodeAuthorizer limits the requests made with the client credentials of a node to the resources of
+// that node: its own Node object, the pods bound to it, and the secrets those pods reference. Requests
+// that pass these limits, and the requests of all other users, are authorized by the delegate.
+type nodeAuthorizer struct {
+	delegate authorizer.Authorizer
+	pods     kclient.PodsNamespacer
+}
+
+// NewAuthorizer returns an authorizer that restricts nodes to their own resources before asking delegate.
+// pods is used to find the node a pod is bound to and must not itself be a node client.
+func NewAuthorizer(delegate authorizer.Authorizer, pods kclient.PodsNamespacer) authorizer.Authorizer {
+	return &nodeAuthorizer{delegate: delegate, pods: pods}
+}
+
+func (a *nodeAuthorizer) Authorize(ctx kapi.Context, attributes authorizer.AuthorizationAttributes) (bool, string, error) {
+	userInfo, _ := kapi.UserFrom(ctx)
+	nodeName, isNode := NodeNameFromUser(userInfo)
+	if !isNode || attributes.IsNonResourceURL() {
+		return a.delegate.Authorize(ctx, attributes)
+	}
+
+	namespace, _ := kapi.NamespaceFrom(ctx)
+	allowed, reason, err := a.authorizeNode(nodeName, namespace, attributes)
+	if !allowed || err != nil {
+		return false, reason, err
+	}
+	return a.delegate.Authorize(ctx, attributes)
+}
+
+// GetAllowedSubjects is answered by the delegate. The node restrictions depend on the object being
+// accessed, so every node remains a candidate subject.
+func (a *nodeAuthorizer) GetAllowedSubjects(ctx kapi.Context, attributes authorizer.AuthorizationAttributes) (sets.String, sets.String, error) {
+	return a.delegate.GetAllowedSubjects(ctx, attributes)
+}
+
+// authorizeNode returns false with a reason if the request is outside of the resources of the node
+func (a *nodeAuthorizer) authorizeNode(nodeName, namespace string, attributes authorizer.AuthorizationAttributes) (bool, string, error) {
+	verb := attributes.GetVerb()
+	name := attributes.GetResourceName()
+
+	switch attributes.GetResource() {
+	case "nodes", "nodes/status":
+		if readOnlyVerbs.Has(verb) {
+			return true, "", nil
+		}
+		// nodes are created without a name in the URL, the node restriction admission plugin checks the object
+		if verb == "create" || (len(name) > 0 && name == nodeName) {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("node %q may only modify its own node object", nodeName), nil
+
+	case "pods", "pods/status":
+		if readOnlyVerbs.Has(verb) {
+			return true, "", nil
+		}
+		// mirror pods are checked by the node restriction admission plugin
+		if verb == "create" {
+			return true, "", nil
+		}
+		if len(name) == 0 {
+			return false, fmt.Sprintf("node %q may only modify pods bound to it", nodeName), nil
+		}
+		pod, err := a.pods.Pods(namespace).Get(name)
+		if kerrors.IsNotFound(err) {
+			// let the request fail with the not found error
+			return true, "", nil
+		}
+		if err != nil {
+			return false, "", err
+		}
+		if pod.Spec.NodeName != nodeName {
+			return false, fmt.Sprintf("node %q may only modify pods bound to it, pod %s/%s is bound to %q", nodeName, namespace, name, pod.Spec.NodeName), nil
+		}
+		return true, "", nil
+
+	case "secrets":
+		if verb != "get" || len(name) == 0 {
+			return false, fmt.Sprintf("node %q may only get individual secrets referenced by pods bound to it", nodeName), nil
+		}
+		pods, err := a.pods.Pods(namespace).List(labels.Everything(), fields.OneTermEqualSelector(kclient.PodHost, nodeName))
+		if err != nil {
+			return false, "", err
+		}
+		for i := range pods.Items {
+			if pods.Items[i].Spec.NodeName == nodeName && PodSecretNames(&pods.Items[i]).Has(name) {
+				return true, "", nil
+			}
+		}
+		return false, fmt.Sprintf("node %q may only get secrets referenced by pods bound to it, no pod on the node references secret %s/%s", nodeName, namespace, name), nil
+	}
+
+	return true, "", nil
+}
+
+// NodeNameFromUser returns the name of the node whose client credentials identify the user, and false
+// if the user is not a node.
+func NodeNameFromUser(userInfo user.Info) (string, bool) {
+	if userInfo == nil || !strings.HasPrefix(userInfo.GetName(), bootstrappolicy.NodeUsernamePrefix) {
+		return "", false
+	}
+	if !sets.NewString(userInfo.GetGroups()...).Has(bootstrappolicy.NodesGroup) {
+		return "", false
+	}
+	nodeName := strings.TrimPrefix(userInfo.GetName(), bootstrappolicy.NodeUsernamePrefix)
+	return nodeName, len(nodeName) > 0
+}
+
+// PodSecretNames returns the names of the secrets the kubelet reads to run the pod
+func PodSecretNames(pod *kapi.Pod) sets.String {
+	names := sets.NewString()
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		names.Insert(secret.Name)
+	}
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.Secret != nil:
+			names.Insert(volume.Secret.SecretName)
+		case volume.RBD != nil && volume.RBD.SecretRef != nil:
+			names.Insert(volume.RBD.SecretRef.Name)
+		case volume.CephFS != nil && volume.CephFS.SecretRef != nil:
+			names.Insert(volume.CephFS.SecretRef.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,146 @@
+package node
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/authorization/authorizer"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+type allowAllAuthorizer struct{}
+
+func (allowAllAuthorizer) Authorize(ctx kapi.Context, a authorizer.AuthorizationAttributes) (bool, string, error) {
+	return true, "", nil
+}
+
+func (allowAllAuthorizer) GetAllowedSubjects(ctx kapi.Context, a authorizer.AuthorizationAttributes) (sets.String, sets.String, error) {
+	return sets.NewString(), sets.NewString(), nil
+}
+
+func nodeUser(name string) user.Info {
+	return &user.DefaultInfo{Name: bootstrappolicy.NodeUsernamePrefix + name, Groups: []string{bootstrappolicy.NodesGroup}}
+}
+
+func TestNodeAuthorizer(t *testing.T) {
+	pods := testclient.NewSimpleFake(
+		&kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "mine"},
+			Spec: kapi.PodSpec{
+				NodeName:         "node1",
+				ImagePullSecrets: []kapi.LocalObjectReference{{Name: "pull-secret"}},
+				Volumes:          []kapi.Volume{{Name: "v", VolumeSource: kapi.VolumeSource{Secret: &kapi.SecretVolumeSource{SecretName: "token-secret"}}}},
+			},
+		},
+		&kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "theirs"},
+			Spec: kapi.PodSpec{
+				NodeName: "node2",
+				Volumes:  []kapi.Volume{{Name: "v", VolumeSource: kapi.VolumeSource{Secret: &kapi.SecretVolumeSource{SecretName: "other-secret"}}}},
+			},
+		},
+	)
+	a := NewAuthorizer(allowAllAuthorizer{}, pods)
+
+	tests := []struct {
+		name       string
+		user       user.Info
+		attributes authorizer.DefaultAuthorizationAttributes
+		allowed    bool
+	}{
+		{
+			name:       "other users are not restricted",
+			user:       &user.DefaultInfo{Name: "system:admin"},
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "get", Resource: "secrets", ResourceName: "other-secret"},
+			allowed:    true,
+		},
+		{
+			name:       "node without the nodes group is not a node",
+			user:       &user.DefaultInfo{Name: "system:node:node1"},
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "update", Resource: "nodes", ResourceName: "node2"},
+			allowed:    true,
+		},
+		{
+			name:       "update own node status",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "update", Resource: "nodes/status", ResourceName: "node1"},
+			allowed:    true,
+		},
+		{
+			name:       "update other node",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "update", Resource: "nodes", ResourceName: "node2"},
+			allowed:    false,
+		},
+		{
+			name:       "list nodes",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "list", Resource: "nodes"},
+			allowed:    true,
+		},
+		{
+			name:       "update status of own pod",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "update", Resource: "pods/status", ResourceName: "mine"},
+			allowed:    true,
+		},
+		{
+			name:       "delete pod on other node",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "delete", Resource: "pods", ResourceName: "theirs"},
+			allowed:    false,
+		},
+		{
+			name:       "delete collection of pods",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "deletecollection", Resource: "pods"},
+			allowed:    false,
+		},
+		{
+			name:       "get pull secret of own pod",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "get", Resource: "secrets", ResourceName: "pull-secret"},
+			allowed:    true,
+		},
+		{
+			name:       "get volume secret of own pod",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "get", Resource: "secrets", ResourceName: "token-secret"},
+			allowed:    true,
+		},
+		{
+			name:       "get secret of pod on other node",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "get", Resource: "secrets", ResourceName: "other-secret"},
+			allowed:    false,
+		},
+		{
+			name:       "list secrets",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "list", Resource: "secrets"},
+			allowed:    false,
+		},
+		{
+			name:       "other resources are not restricted",
+			user:       nodeUser("node1"),
+			attributes: authorizer.DefaultAuthorizationAttributes{Verb: "create", Resource: "events"},
+			allowed:    true,
+		},
+	}
+
+	for _, test := range tests {
+		ctx := kapi.WithUser(kapi.WithNamespace(kapi.NewContext(), "ns"), test.user)
+		allowed, reason, err := a.Authorize(ctx, test.attributes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if allowed != test.allowed {
+			t.Errorf("%s: expected allowed=%t, got %t: %s", test.name, test.allowed, allowed, reason)
+		}
+	}
+}
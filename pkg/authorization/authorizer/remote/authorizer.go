@@ -98,7 +98,7 @@ func (r *RemoteAuthorizer) GetAllowedSubjects(ctx kapi.Context, attributes autho
 }
 
 func getAction(namespace string, attributes authorizer.AuthorizationAttributes) authzapi.AuthorizationAttributes {
-	return authzapi.AuthorizationAttributes{
+	action := authzapi.AuthorizationAttributes{
 		Namespace:    namespace,
 		Verb:         attributes.GetVerb(),
 		Resource:     attributes.GetResource(),
@@ -111,7 +111,11 @@ func getAction(namespace string, attributes authorizer.AuthorizationAttributes)
 		// APIVersion
 		// APIGroup
 		// RequestAttributes (unserializable?)
-		// IsNonResourceURL
-		// URL (doesn't make sense for remote authz?)
 	}
+	// the URL of a resource request is not checked by policy
+	if attributes.IsNonResourceURL() {
+		action.IsNonResourceURL = true
+		action.Path = attributes.GetURL()
+	}
+	return action
 }
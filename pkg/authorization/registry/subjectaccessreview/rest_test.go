@@ -111,6 +111,29 @@ func TestNoErrors(t *testing.T) {
 	test.runTest(t)
 }
 
+func TestNonResourceURL(t *testing.T) {
+	test := &subjectAccessTest{
+		authorizer: &testAuthorizer{
+			allowed: true,
+			reason:  "allowed by cluster rule",
+		},
+		reviewRequest: &authorizationapi.SubjectAccessReview{
+			Action: authorizationapi.AuthorizationAttributes{
+				Verb:             "get",
+				IsNonResourceURL: true,
+				Path:             "/healthz",
+			},
+			User:   "foo",
+			Groups: sets.NewString(),
+		},
+	}
+
+	test.runTest(t)
+	if !test.authorizer.actualAttributes.IsNonResourceURL() || test.authorizer.actualAttributes.GetURL() != "/healthz" {
+		t.Errorf("expected the path to be authorized as a non-resource URL, got %#v", test.authorizer.actualAttributes)
+	}
+}
+
 func TestErrors(t *testing.T) {
 	test := &subjectAccessTest{
 		authorizer: &testAuthorizer{
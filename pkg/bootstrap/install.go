@@ -0,0 +1,153 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/runtime"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/client"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	templateapi "github.com/openshift/origin/pkg/template/api"
+)
+
+// VersionAnnotation records the version of the server which installed or last updated an image stream or a template
+// of the bootstrap content. The objects without it are never updated, remove it to keep an object from being
+// updated by newer versions.
+const VersionAnnotation = "openshift.io/bootstrap-content-version"
+
+// Objects returns the image streams and templates embedded in the package
+func Objects() ([]runtime.Object, error) {
+	objects := []runtime.Object{}
+	for _, name := range AssetNames() {
+		obj, err := latest.Codec.Decode(MustAsset(name))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %v", name, err)
+		}
+		if !runtime.IsListType(obj) {
+			objects = append(objects, obj)
+			continue
+		}
+		items, err := runtime.ExtractList(obj)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", name, err)
+		}
+		if errs := runtime.DecodeList(items, kapi.Scheme); len(errs) > 0 {
+			return nil, fmt.Errorf("unable to decode %s: %v", name, errs)
+		}
+		objects = append(objects, items...)
+	}
+	return objects, nil
+}
+
+// InstallResult counts the image streams and templates changed by Install
+type InstallResult struct {
+	Created int
+	Updated int
+}
+
+// Install creates the image streams and templates embedded in the package in namespace, marked with version. The
+// objects marked with another version are updated, so that the content is refreshed when the server is upgraded.
+func Install(c client.Interface, namespace, version string) (InstallResult, error) {
+	result := InstallResult{}
+	objects, err := Objects()
+	if err != nil {
+		return result, err
+	}
+
+	errs := []error{}
+	for _, obj := range objects {
+		var err error
+		switch t := obj.(type) {
+		case *imageapi.ImageStream:
+			err = installImageStream(c.ImageStreams(namespace), t, version, &result)
+		case *templateapi.Template:
+			err = installTemplate(c.Templates(namespace), t, version, &result)
+		default:
+			err = fmt.Errorf("unsupported bootstrap content %T", obj)
+		}
+		// masters install the content concurrently when they start
+		if err != nil && !kerrors.IsAlreadyExists(err) && !kerrors.IsConflict(err) {
+			errs = append(errs, err)
+		}
+	}
+	return result, utilerrors.NewAggregate(errs)
+}
+
+func installImageStream(streams client.ImageStreamInterface, stream *imageapi.ImageStream, version string, result *InstallResult) error {
+	setVersion(&stream.ObjectMeta, version)
+
+	existing, err := streams.Get(stream.Name)
+	if kerrors.IsNotFound(err) {
+		if _, err := streams.Create(stream); err != nil {
+			return err
+		}
+		result.Created++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !needsUpdate(existing.ObjectMeta, version) {
+		return nil
+	}
+
+	glog.V(2).Infof("Updating the image stream %s/%s installed by %s", existing.Namespace, existing.Name, existing.Annotations[VersionAnnotation])
+	existing.Labels = stream.Labels
+	existing.Annotations = stream.Annotations
+	existing.Spec = stream.Spec
+	if _, err := streams.Update(existing); err != nil {
+		return err
+	}
+	result.Updated++
+	return nil
+}
+
+func installTemplate(templates client.TemplateInterface, template *templateapi.Template, version string, result *InstallResult) error {
+	setVersion(&template.ObjectMeta, version)
+
+	existing, err := templates.Get(template.Name)
+	if kerrors.IsNotFound(err) {
+		if _, err := templates.Create(template); err != nil {
+			return err
+		}
+		result.Created++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !needsUpdate(existing.ObjectMeta, version) {
+		return nil
+	}
+
+	glog.V(2).Infof("Updating the template %s/%s installed by %s", existing.Namespace, existing.Name, existing.Annotations[VersionAnnotation])
+	existing.Labels = template.Labels
+	existing.Annotations = template.Annotations
+	existing.Parameters = template.Parameters
+	existing.Objects = template.Objects
+	existing.ObjectLabels = template.ObjectLabels
+	if _, err := templates.Update(existing); err != nil {
+		return err
+	}
+	result.Updated++
+	return nil
+}
+
+// setVersion marks an object of the bootstrap content with version
+func setVersion(meta *kapi.ObjectMeta, version string) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[VersionAnnotation] = version
+}
+
+// needsUpdate returns true if an existing object was installed by another version
+func needsUpdate(meta kapi.ObjectMeta, version string) bool {
+	installed, ok := meta.Annotations[VersionAnnotation]
+	return ok && installed != version
+}
@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestInstall(t *testing.T) {
+	objects, err := Objects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// image streams installed by an older version, by this version, and created without the bootstrap content
+	existing := map[string]*imageapi.ImageStream{
+		"ruby":   {ObjectMeta: kapi.ObjectMeta{Name: "ruby", Annotations: map[string]string{VersionAnnotation: "v1.0.0"}}},
+		"nodejs": {ObjectMeta: kapi.ObjectMeta{Name: "nodejs", Annotations: map[string]string{VersionAnnotation: "v1.1.0"}}},
+		"perl":   {ObjectMeta: kapi.ObjectMeta{Name: "perl"}},
+	}
+	client := testclient.NewSimpleFake()
+	client.PrependReactor("get", "*", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if stream, ok := existing[name]; ok && action.GetResource() == "imagestreams" {
+			return true, stream, nil
+		}
+		return true, nil, kerrors.NewNotFound(action.GetResource(), name)
+	})
+	client.PrependReactor("create", "*", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, action.(ktestclient.CreateAction).GetObject(), nil
+	})
+	client.PrependReactor("update", "*", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, action.(ktestclient.UpdateAction).GetObject(), nil
+	})
+
+	result, err := Install(client, "openshift", "v1.1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (InstallResult{Created: len(objects) - len(existing), Updated: 1}); result != expected {
+		t.Errorf("expected %#v, got %#v", expected, result)
+	}
+
+	for _, action := range client.Actions() {
+		switch a := action.(type) {
+		case ktestclient.CreateAction:
+			meta, err := kapi.ObjectMetaFor(a.GetObject())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if meta.Annotations[VersionAnnotation] != "v1.1.0" {
+				t.Errorf("expected %s to be marked with the version, got %v", meta.Name, meta.Annotations)
+			}
+		case ktestclient.UpdateAction:
+			stream := a.GetObject().(*imageapi.ImageStream)
+			if stream.Name != "ruby" {
+				t.Errorf("unexpected update of %s", stream.Name)
+			}
+			if stream.Annotations[VersionAnnotation] != "v1.1.0" || len(stream.Spec.Tags) == 0 {
+				t.Errorf("expected the image stream to be refreshed, got %#v", stream)
+			}
+		}
+	}
+}
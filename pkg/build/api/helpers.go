@@ -5,8 +5,8 @@ import (
 )
 
 // BuildToPodLogOptions builds a PodLogOptions object out of a BuildLogOptions.
-// Currently BuildLogOptions.Container and BuildLogOptions.Previous aren't used
-// so they won't be copied to PodLogOptions.
+// BuildLogOptions.Container isn't used and BuildLogOptions.Previous selects a build
+// rather than a container instance, so neither is copied to PodLogOptions.
 func BuildToPodLogOptions(opts *BuildLogOptions) *kapi.PodLogOptions {
 	return &kapi.PodLogOptions{
 		Follow:       opts.Follow,
@@ -15,12 +15,20 @@ const (
 	BuildNumberAnnotation = "openshift.io/build.number"
 	// BuildCloneAnnotation is an annotation whose value is the name of the build this build was cloned from
 	BuildCloneAnnotation = "openshift.io/build.clone-of"
+	// BuildCloneChainAnnotation is an annotation whose value is the comma separated names of the last builds this
+	// build was cloned from, oldest first. At most 10 builds are recorded.
+	BuildCloneChainAnnotation = "openshift.io/build.clone-chain"
 	// BuildPodNameAnnotation is an annotation whose value is the name of the pod running this build
 	BuildPodNameAnnotation = "openshift.io/build.pod-name"
+	// BuildScheduleTimeAnnotation is an annotation whose value is the time a Schedule trigger started this build at
+	BuildScheduleTimeAnnotation = "openshift.io/build.schedule-time"
 	// BuildLabel is the key of a Pod label whose value is the Name of a Build which is run.
 	BuildLabel = "openshift.io/build.name"
 	// DefaultDockerLabelNamespace is the key of a Build label, whose values are build metadata.
 	DefaultDockerLabelNamespace = "io.openshift."
+
+	// BuildPodFinalizer is the finalizer that keeps a deleted Build until its pod is removed
+	BuildPodFinalizer kapi.FinalizerName = "openshift.io/build-pod"
 )
 
 // Build encapsulates the inputs needed to produce a new deployable image, as well as
@@ -101,6 +109,10 @@ type BuildStatus struct {
 
 	// Config is an ObjectReference to the BuildConfig this Build is based on.
 	Config *kapi.ObjectReference
+
+	// Finalizers is an opaque list of values that must be empty to permanently remove the build
+	// from storage. Until then, deleting the build only marks it for deletion.
+	Finalizers []kapi.FinalizerName
 }
 
 // BuildPhase represents the status of a build at a point in time.
@@ -385,6 +397,10 @@ type DockerBuildStrategy struct {
 	// DockerfilePath is the path of the Dockerfile that will be used to build the Docker image,
 	// relative to the root of the context (contextDir).
 	DockerfilePath string
+
+	// Target is the name of the build stage of a multi-stage Dockerfile that is built and
+	// pushed. The stages that follow it are ignored. Defaults to the last stage.
+	Target string
 }
 
 // SourceBuildStrategy defines input parameters specific to an Source build.
@@ -425,6 +441,20 @@ type BuildOutput struct {
 	// up the authentication for executing the Docker push to authentication
 	// enabled Docker Registry (or Docker Hub).
 	PushSecret *kapi.LocalObjectReference
+
+	// ImageLabels define a list of labels that are applied to the resulting image. A label
+	// with the same name as one generated from the source of the build overrides it. Image
+	// labels are only supported by the Docker strategy.
+	ImageLabels []ImageLabel
+}
+
+// ImageLabel represents a label applied to the resulting image.
+type ImageLabel struct {
+	// Name defines the name of the label. It must have non-zero length.
+	Name string
+
+	// Value defines the literal value of the label.
+	Value string
 }
 
 const (
@@ -437,6 +467,9 @@ const (
 	// BuildConfigPausedAnnotation is an annotation that marks a BuildConfig as paused.
 	// New Builds cannot be instantiated from a paused BuildConfig.
 	BuildConfigPausedAnnotation = "openshift.io/build-config.paused"
+	// BuildConfigImageChangePendingAnnotation marks a BuildConfig whose image change triggers recorded a new
+	// image while it was paused. A Build is instantiated for that image once the BuildConfig is resumed.
+	BuildConfigImageChangePendingAnnotation = "openshift.io/build-config.image-change-pending"
 )
 
 // BuildConfig is a template which can be used to create new builds.
@@ -471,6 +504,11 @@ type BuildConfigStatus struct {
 type WebHookTrigger struct {
 	// Secret used to validate requests.
 	Secret string
+
+	// AllowedRefs lists the git references, branches or tags, whose pushes trigger a build. A branch
+	// can be given by its name, and * matches any sequence of characters other than /. If empty, only
+	// pushes to the ref of the build source trigger a build.
+	AllowedRefs []string
 }
 
 // ImageChangeTrigger allows builds to be triggered when an ImageStream changes
@@ -486,6 +524,35 @@ type ImageChangeTrigger struct {
 	From *kapi.ObjectReference
 }
 
+// ScheduleTrigger allows builds to be triggered periodically
+type ScheduleTrigger struct {
+	// Cron is the schedule of the builds in the cron format, like "0 2 * * *" for a build every night at 2am
+	Cron string
+
+	// ConcurrencyPolicy specifies what happens when a build is due while a build of the config is
+	// still running. Defaults to Forbid.
+	ConcurrencyPolicy ScheduleConcurrencyPolicy
+
+	// HistoryLimit is the number of completed scheduled builds to keep. Older completed scheduled
+	// builds are deleted. All builds are kept if 0.
+	HistoryLimit int
+
+	// LastScheduleTime is used internally by the BuildScheduleController to save the time of the last
+	// scheduled build
+	LastScheduleTime *unversioned.Time
+}
+
+// ScheduleConcurrencyPolicy describes how a Schedule trigger handles the builds already running.
+type ScheduleConcurrencyPolicy string
+
+const (
+	// ScheduleConcurrencyForbid skips a scheduled build if a build of the config is running
+	ScheduleConcurrencyForbid ScheduleConcurrencyPolicy = "Forbid"
+
+	// ScheduleConcurrencyReplace cancels the running builds of the config before starting a scheduled build
+	ScheduleConcurrencyReplace ScheduleConcurrencyPolicy = "Replace"
+)
+
 // BuildTriggerPolicy describes a policy for a single trigger that results in a new Build.
 type BuildTriggerPolicy struct {
 	// Type is the type of build trigger
@@ -499,6 +566,9 @@ type BuildTriggerPolicy struct {
 
 	// ImageChange contains parameters for an ImageChange type of trigger
 	ImageChange *ImageChangeTrigger
+
+	// Schedule contains parameters for a Schedule type of trigger
+	Schedule *ScheduleTrigger
 }
 
 // BuildTriggerType refers to a specific BuildTriggerPolicy implementation.
@@ -510,6 +580,7 @@ var KnownTriggerTypes = sets.NewString(
 	string(GenericWebHookBuildTriggerType),
 	string(ImageChangeBuildTriggerType),
 	string(ConfigChangeBuildTriggerType),
+	string(ScheduleBuildTriggerType),
 )
 
 const (
@@ -531,6 +602,9 @@ const (
 	// ConfigChangeBuildTriggerType will trigger a build on an initial build config creation
 	// WARNING: In the future the behavior will change to trigger a build on any config change
 	ConfigChangeBuildTriggerType BuildTriggerType = "ConfigChange"
+
+	// ScheduleBuildTriggerType represents a trigger that launches builds periodically
+	ScheduleBuildTriggerType BuildTriggerType = "Schedule"
 )
 
 // BuildList is a collection of Builds.
@@ -605,6 +679,11 @@ type BuildRequest struct {
 
 	// Env contains additional environment variables you want to pass into a builder container
 	Env []kapi.EnvVar
+
+	// ScheduleTime is the time a Schedule trigger started this build at. If it is not after the last
+	// schedule time of the trigger, a build will not be generated. It is ignored unless the request
+	// comes from the master.
+	ScheduleTime *unversioned.Time
 }
 
 type BinaryBuildRequestOptions struct {
@@ -643,7 +722,8 @@ type BuildLogOptions struct {
 	// Follow if true indicates that the build log should be streamed until
 	// the build terminates.
 	Follow bool
-	// If true, return previous terminated container logs
+	// Previous if true returns the logs of the build of the same build config prior
+	// to the requested one.
 	Previous bool
 	// A relative time in seconds before the current time from which to show logs. If this value
 	// precedes the time a pod was started, only logs since the pod start will be returned.
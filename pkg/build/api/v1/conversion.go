@@ -206,6 +206,11 @@ func init() {
 				obj.ImageChange = &ImageChangeTrigger{}
 			}
 		},
+		func(obj *ScheduleTrigger) {
+			if len(obj.ConcurrencyPolicy) == 0 {
+				obj.ConcurrencyPolicy = ScheduleConcurrencyForbid
+			}
+		},
 	)
 	if err != nil {
 		panic(err)
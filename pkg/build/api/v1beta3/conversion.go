@@ -213,6 +213,11 @@ func init() {
 				obj.ImageChange = &ImageChangeTrigger{}
 			}
 		},
+		func(obj *ScheduleTrigger) {
+			if len(obj.ConcurrencyPolicy) == 0 {
+				obj.ConcurrencyPolicy = ScheduleConcurrencyForbid
+			}
+		},
 	)
 	if err != nil {
 		panic(err)
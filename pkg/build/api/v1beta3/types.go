@@ -85,6 +85,10 @@ type BuildStatus struct {
 
 	// Config is an ObjectReference to the BuildConfig this Build is based on.
 	Config *kapi.ObjectReference `json:"config,omitempty"`
+
+	// Finalizers is an opaque list of values that must be empty to permanently remove the build
+	// from storage. Until then, deleting the build only marks it for deletion.
+	Finalizers []kapi.FinalizerName `json:"finalizers,omitempty"`
 }
 
 // BuildPhase represents the status of a build at a point in time.
@@ -358,6 +362,10 @@ type DockerBuildStrategy struct {
 	// DockerfilePath is the path of the Dockerfile that will be used to build the Docker image,
 	// relative to the root of the context (contextDir).
 	DockerfilePath string `json:"dockerfilePath,omitempty" description:"path of the Dockerfile to use for building the Docker image, relative to the contextDir, if set"`
+
+	// Target is the name of the build stage of a multi-stage Dockerfile that is built and
+	// pushed. The stages that follow it are ignored. Defaults to the last stage.
+	Target string `json:"target,omitempty"`
 }
 
 // SourceBuildStrategy defines input parameters specific to an Source build.
@@ -397,6 +405,20 @@ type BuildOutput struct {
 	// up the authentication for executing the Docker push to authentication
 	// enabled Docker Registry (or Docker Hub).
 	PushSecret *kapi.LocalObjectReference `json:"pushSecret,omitempty" description:"supported type: dockercfg"`
+
+	// ImageLabels define a list of labels that are applied to the resulting image. A label
+	// with the same name as one generated from the source of the build overrides it. Image
+	// labels are only supported by the Docker strategy.
+	ImageLabels []ImageLabel `json:"imageLabels,omitempty"`
+}
+
+// ImageLabel represents a label applied to the resulting image.
+type ImageLabel struct {
+	// Name defines the name of the label. It must have non-zero length.
+	Name string `json:"name"`
+
+	// Value defines the literal value of the label.
+	Value string `json:"value,omitempty"`
 }
 
 // BuildConfig is a template which can be used to create new builds.
@@ -430,6 +452,11 @@ type BuildConfigStatus struct {
 type WebHookTrigger struct {
 	// Secret used to validate requests.
 	Secret string `json:"secret,omitempty"`
+
+	// AllowedRefs lists the git references, branches or tags, whose pushes trigger a build. A branch
+	// can be given by its name, and * matches any sequence of characters other than /. If empty, only
+	// pushes to the ref of the build source trigger a build.
+	AllowedRefs []string `json:"allowedRefs,omitempty"`
 }
 
 // ImageChangeTrigger allows builds to be triggered when an ImageStream changes
@@ -445,6 +472,35 @@ type ImageChangeTrigger struct {
 	From *kapi.ObjectReference `json:"from,omitempty" description:"reference to an ImageStreamTag that will trigger the build"`
 }
 
+// ScheduleTrigger allows builds to be triggered periodically
+type ScheduleTrigger struct {
+	// Cron is the schedule of the builds in the cron format, like "0 2 * * *" for a build every night at 2am
+	Cron string `json:"cron" description:"schedule of the builds in the cron format"`
+
+	// ConcurrencyPolicy specifies what happens when a build is due while a build of the config is
+	// still running. Defaults to Forbid.
+	ConcurrencyPolicy ScheduleConcurrencyPolicy `json:"concurrencyPolicy,omitempty" description:"what happens when a build is due while a build of the config is running, Forbid or Replace"`
+
+	// HistoryLimit is the number of completed scheduled builds to keep. Older completed scheduled
+	// builds are deleted. All builds are kept if 0.
+	HistoryLimit int `json:"historyLimit,omitempty" description:"number of completed scheduled builds to keep, all are kept if 0"`
+
+	// LastScheduleTime is used internally by the BuildScheduleController to save the time of the last
+	// scheduled build
+	LastScheduleTime *unversioned.Time `json:"lastScheduleTime,omitempty" description:"used internally to save the time of the last scheduled build"`
+}
+
+// ScheduleConcurrencyPolicy describes how a Schedule trigger handles the builds already running.
+type ScheduleConcurrencyPolicy string
+
+const (
+	// ScheduleConcurrencyForbid skips a scheduled build if a build of the config is running
+	ScheduleConcurrencyForbid ScheduleConcurrencyPolicy = "Forbid"
+
+	// ScheduleConcurrencyReplace cancels the running builds of the config before starting a scheduled build
+	ScheduleConcurrencyReplace ScheduleConcurrencyPolicy = "Replace"
+)
+
 // BuildTriggerPolicy describes a policy for a single trigger that results in a new Build.
 type BuildTriggerPolicy struct {
 	// Type is the type of build trigger
@@ -458,6 +514,9 @@ type BuildTriggerPolicy struct {
 
 	// ImageChange contains parameters for an ImageChange type of trigger
 	ImageChange *ImageChangeTrigger `json:"imageChange,omitempty"`
+
+	// Schedule contains parameters for a Schedule type of trigger
+	Schedule *ScheduleTrigger `json:"schedule,omitempty"`
 }
 
 // BuildTriggerType refers to a specific BuildTriggerPolicy implementation.
@@ -479,6 +538,9 @@ const (
 	// ConfigChangeBuildTriggerType will trigger a build on an initial build config creation
 	// WARNING: In the future the behavior will change to trigger a build on any config change
 	ConfigChangeBuildTriggerType BuildTriggerType = "ConfigChange"
+
+	// ScheduleBuildTriggerType represents a trigger that launches builds periodically
+	ScheduleBuildTriggerType BuildTriggerType = "Schedule"
 )
 
 // BuildList is a collection of Builds.
@@ -539,6 +601,11 @@ type BuildRequest struct {
 
 	// Env contains additional environment variables you want to pass into a builder container
 	Env []kapi.EnvVar `json:"env,omitempty" description:"additional environment variables you want to pass into a builder container"`
+
+	// ScheduleTime is the time a Schedule trigger started this build at. If it is not after the last
+	// schedule time of the trigger, a build will not be generated. It is ignored unless the request
+	// comes from the master.
+	ScheduleTime *unversioned.Time `json:"scheduleTime,omitempty" description:"time a Schedule trigger started this build at, ignored unless the request comes from the master"`
 }
 
 type BinaryBuildRequestOptions struct {
@@ -576,7 +643,7 @@ type BuildLogOptions struct {
 	// the build terminates.
 	Follow bool `json:"follow,omitempty" description:"if true indicates that the log should be streamed; defaults to false"`
 	// Return previous terminated container logs. Defaults to false.
-	Previous bool `json:"previous,omitempty" description:"return previous terminated container logs; defaults to false."`
+	Previous bool `json:"previous,omitempty" description:"return previous build logs; defaults to false."`
 	// A relative time in seconds before the current time from which to show logs. If this value
 	// precedes the time a pod was started, only logs since the pod start will be returned.
 	// If this value is in the future, no logs will be returned.
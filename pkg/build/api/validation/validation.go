@@ -10,12 +10,14 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/util/fielderrors"
+	"k8s.io/kubernetes/pkg/util/sets"
 	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 
 	oapi "github.com/openshift/origin/pkg/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildutil "github.com/openshift/origin/pkg/build/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/util/cron"
 )
 
 // ValidateBuild tests required fields for a Build.
@@ -34,6 +36,8 @@ func ValidateBuildUpdate(build *buildapi.Build, older *buildapi.Build) fielderro
 
 	if buildutil.IsBuildComplete(older) && older.Status.Phase != build.Status.Phase {
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("status.Phase", build.Status.Phase, "phase cannot be updated from a terminal state"))
+	} else if !buildutil.IsPhaseTransitionAllowed(older.Status.Phase, build.Status.Phase) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("status.Phase", build.Status.Phase, fmt.Sprintf("phase cannot be updated from %s to %s", older.Status.Phase, build.Status.Phase)))
 	}
 	if !kapi.Semantic.DeepEqual(build.Spec, older.Spec) {
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("spec", "content of spec is not printed out, please refer to the \"details\"", "spec is immutable"))
@@ -62,8 +66,15 @@ func ValidateBuildConfig(config *buildapi.BuildConfig) fielderrors.ValidationErr
 
 	// image change triggers that refer
 	fromRefs := map[string]struct{}{}
+	scheduleTriggers := 0
 	for i, trg := range config.Spec.Triggers {
 		allErrs = append(allErrs, validateTrigger(&trg).PrefixIndex(i).Prefix("triggers")...)
+		if trg.Type == buildapi.ScheduleBuildTriggerType {
+			scheduleTriggers++
+			if scheduleTriggers == 2 {
+				allErrs = append(allErrs, fielderrors.NewFieldInvalid("triggers", config.Spec.Triggers, "only one Schedule trigger is allowed"))
+			}
+		}
 		if trg.Type != buildapi.ImageChangeBuildTriggerType || trg.ImageChange == nil {
 			continue
 		}
@@ -126,7 +137,7 @@ func validateBuildSpec(spec *buildapi.BuildSpec) fielderrors.ValidationErrorList
 		}
 	}
 
-	allErrs = append(allErrs, validateOutput(&spec.Output).Prefix("output")...)
+	allErrs = append(allErrs, validateOutput(&spec.Output, s.DockerStrategy != nil).Prefix("output")...)
 	allErrs = append(allErrs, validateStrategy(&spec.Strategy).Prefix("strategy")...)
 
 	// TODO: validate resource requirements (prereq: https://github.com/kubernetes/kubernetes/pull/7059)
@@ -339,7 +350,7 @@ func validateFromImageReference(reference *kapi.ObjectReference) fielderrors.Val
 	return allErrs
 }
 
-func validateOutput(output *buildapi.BuildOutput) fielderrors.ValidationErrorList {
+func validateOutput(output *buildapi.BuildOutput, isDockerStrategy bool) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
 	// TODO: make part of a generic ValidateObjectReference method upstream.
@@ -348,6 +359,28 @@ func validateOutput(output *buildapi.BuildOutput) fielderrors.ValidationErrorLis
 	}
 
 	allErrs = append(allErrs, validateSecretRef(output.PushSecret).Prefix("pushSecret")...)
+	allErrs = append(allErrs, validateImageLabels(output.ImageLabels).Prefix("imageLabels")...)
+	// only the Docker strategy controls the labels of the resulting image
+	if len(output.ImageLabels) > 0 && !isDockerStrategy {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("imageLabels", output.ImageLabels, "image labels may only be set for builds using the Docker strategy"))
+	}
+
+	return allErrs
+}
+
+func validateImageLabels(labels []buildapi.ImageLabel) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	names := sets.NewString()
+	for i, label := range labels {
+		switch {
+		case len(label.Name) == 0:
+			allErrs = append(allErrs, fielderrors.NewFieldRequired(fmt.Sprintf("[%d].name", i)))
+		case names.Has(label.Name):
+			allErrs = append(allErrs, fielderrors.NewFieldDuplicate(fmt.Sprintf("[%d].name", i), label.Name))
+		}
+		names.Insert(label.Name)
+	}
 
 	return allErrs
 }
@@ -413,9 +446,17 @@ func validateSourceStrategy(strategy *buildapi.SourceBuildStrategy) fielderrors.
 	allErrs := fielderrors.ValidationErrorList{}
 	allErrs = append(allErrs, validateFromImageReference(&strategy.From).Prefix("from")...)
 	allErrs = append(allErrs, validateSecretRef(strategy.PullSecret).Prefix("pullSecret")...)
+	if len(strategy.Scripts) > 0 {
+		if u, err := url.Parse(strategy.Scripts); err != nil || !scriptsURLSchemes.Has(u.Scheme) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("scripts", strategy.Scripts, "must be a URL with the image, file, http or https scheme"))
+		}
+	}
 	return allErrs
 }
 
+// scriptsURLSchemes are the schemes of the locations S2I scripts can be downloaded from
+var scriptsURLSchemes = sets.NewString("image", "file", "http", "https")
+
 func validateCustomStrategy(strategy *buildapi.CustomBuildStrategy) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 	allErrs = append(allErrs, validateFromImageReference(&strategy.From).Prefix("from")...)
@@ -463,17 +504,50 @@ func validateTrigger(trigger *buildapi.BuildTriggerPolicy) fielderrors.Validatio
 		allErrs = append(allErrs, validateFromImageReference(trigger.ImageChange.From).Prefix("from")...)
 	case buildapi.ConfigChangeBuildTriggerType:
 		// doesn't require additional validation
+	case buildapi.ScheduleBuildTriggerType:
+		if trigger.Schedule == nil {
+			allErrs = append(allErrs, fielderrors.NewFieldRequired("schedule"))
+		} else {
+			allErrs = append(allErrs, validateSchedule(trigger.Schedule).Prefix("schedule")...)
+		}
 	default:
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("type", trigger.Type, "invalid trigger type"))
 	}
 	return allErrs
 }
 
+func validateSchedule(schedule *buildapi.ScheduleTrigger) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	if len(schedule.Cron) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("cron"))
+	} else if _, err := cron.Parse(schedule.Cron); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("cron", schedule.Cron, err.Error()))
+	}
+	switch schedule.ConcurrencyPolicy {
+	case buildapi.ScheduleConcurrencyForbid, buildapi.ScheduleConcurrencyReplace:
+	case "":
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("concurrencyPolicy"))
+	default:
+		allErrs = append(allErrs, fielderrors.NewFieldValueNotSupported("concurrencyPolicy", schedule.ConcurrencyPolicy, []string{string(buildapi.ScheduleConcurrencyForbid), string(buildapi.ScheduleConcurrencyReplace)}))
+	}
+	if schedule.HistoryLimit < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("historyLimit", schedule.HistoryLimit, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
 func validateWebHook(webHook *buildapi.WebHookTrigger) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 	if len(webHook.Secret) == 0 {
 		allErrs = append(allErrs, fielderrors.NewFieldRequired("secret"))
 	}
+	for i, ref := range webHook.AllowedRefs {
+		if len(ref) == 0 {
+			allErrs = append(allErrs, fielderrors.NewFieldRequired(fmt.Sprintf("allowedRefs[%d]", i)))
+		} else if _, err := path.Match(ref, ""); err != nil {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid(fmt.Sprintf("allowedRefs[%d]", i), ref, err.Error()))
+		}
+	}
 	return allErrs
 }
 
@@ -494,6 +568,9 @@ func ValidateBuildLogOptions(opts *buildapi.BuildLogOptions) fielderrors.Validat
 	if opts.Version != nil && *opts.Version <= 0 {
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("version", *opts.Version, "build version must be greater than 0"))
 	}
+	if opts.Version != nil && opts.Previous {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("previous", opts.Previous, "cannot use previous when a version is specified"))
+	}
 
 	return allErrs
 }
@@ -173,6 +173,20 @@ func TestValidateBuildUpdate(t *testing.T) {
 			T: fielderrors.ValidationErrorTypeInvalid,
 			F: "status.Phase",
 		},
+		"update backwards": {
+			Old: &buildapi.Build{
+				ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "my-build", ResourceVersion: "1"},
+				Spec:       newDefaultParameters(),
+				Status:     buildapi.BuildStatus{Phase: buildapi.BuildPhaseRunning},
+			},
+			Update: &buildapi.Build{
+				ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "my-build", ResourceVersion: "1"},
+				Spec:       newDefaultParameters(),
+				Status:     buildapi.BuildStatus{Phase: buildapi.BuildPhasePending},
+			},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "status.Phase",
+		},
 		"update from terminal4": {
 			Old: &buildapi.Build{
 				ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "my-build", ResourceVersion: "1"},
@@ -292,6 +306,50 @@ func TestBuildConfigDockerStrategyImageChangeTrigger(t *testing.T) {
 	}
 }
 
+func TestBuildConfigMultipleScheduleTriggers(t *testing.T) {
+	schedule := buildapi.BuildTriggerPolicy{
+		Type: buildapi.ScheduleBuildTriggerType,
+		Schedule: &buildapi.ScheduleTrigger{
+			Cron:              "0 2 * * *",
+			ConcurrencyPolicy: buildapi.ScheduleConcurrencyForbid,
+		},
+	}
+	buildConfig := &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "config-id", Namespace: "namespace"},
+		Spec: buildapi.BuildConfigSpec{
+			BuildSpec: buildapi.BuildSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{
+						URI: "http://github.com/my/repository",
+					},
+				},
+				Strategy: buildapi.BuildStrategy{
+					DockerStrategy: &buildapi.DockerBuildStrategy{},
+				},
+				Output: buildapi.BuildOutput{
+					To: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "repository/data",
+					},
+				},
+			},
+			Triggers: []buildapi.BuildTriggerPolicy{schedule},
+		},
+	}
+	if errors := ValidateBuildConfig(buildConfig); len(errors) != 0 {
+		t.Fatalf("Unexpected validation errors: %v", errors)
+	}
+
+	buildConfig.Spec.Triggers = append(buildConfig.Spec.Triggers, schedule)
+	errors := ValidateBuildConfig(buildConfig)
+	if len(errors) != 1 {
+		t.Fatalf("Expected a single validation error, got %v", errors)
+	}
+	if err := errors[0].(*fielderrors.ValidationError); err.Type != fielderrors.ValidationErrorTypeInvalid || err.Field != "triggers" {
+		t.Errorf("Expected an invalid triggers error, got %v", err)
+	}
+}
+
 func TestBuildConfigValidationFailureRequiredName(t *testing.T) {
 	buildConfig := &buildapi.BuildConfig{
 		ObjectMeta: kapi.ObjectMeta{Name: "", Namespace: "foo"},
@@ -1299,7 +1357,111 @@ func TestValidateBuildSpec(t *testing.T) {
 					},
 				},
 			},
-		}}
+		},
+		// 17
+		// invalid because the scripts location is not a URL S2I can download from
+		{
+			string(fielderrors.ValidationErrorTypeInvalid) + "strategy.sourceStrategy.scripts",
+			&buildapi.BuildSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{
+						URI: "http://github.com/my/repository",
+					},
+				},
+				Strategy: buildapi.BuildStrategy{
+					SourceStrategy: &buildapi.SourceBuildStrategy{
+						From:    kapi.ObjectReference{Kind: "DockerImage", Name: "reponame"},
+						Scripts: "/usr/libexec/s2i",
+					},
+				},
+				Output: buildapi.BuildOutput{
+					To: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "repository/data",
+					},
+				},
+			},
+		},
+		// 18
+		// invalid because an image label has no name
+		{
+			string(fielderrors.ValidationErrorTypeRequired) + "output.imageLabels[1].name",
+			&buildapi.BuildSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{
+						URI: "http://github.com/my/repository",
+					},
+				},
+				Strategy: buildapi.BuildStrategy{
+					DockerStrategy: &buildapi.DockerBuildStrategy{},
+				},
+				Output: buildapi.BuildOutput{
+					To: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "repository/data",
+					},
+					ImageLabels: []buildapi.ImageLabel{
+						{Name: "version", Value: "1.0"},
+						{Name: "", Value: "1.1"},
+					},
+				},
+			},
+		},
+		// 19
+		// invalid because an image label is listed twice
+		{
+			string(fielderrors.ValidationErrorTypeDuplicate) + "output.imageLabels[1].name",
+			&buildapi.BuildSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{
+						URI: "http://github.com/my/repository",
+					},
+				},
+				Strategy: buildapi.BuildStrategy{
+					DockerStrategy: &buildapi.DockerBuildStrategy{},
+				},
+				Output: buildapi.BuildOutput{
+					To: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "repository/data",
+					},
+					ImageLabels: []buildapi.ImageLabel{
+						{Name: "version", Value: "1.0"},
+						{Name: "version", Value: "1.1"},
+					},
+				},
+			},
+		},
+		// 20
+		// invalid because image labels are only supported by the Docker strategy
+		{
+			string(fielderrors.ValidationErrorTypeInvalid) + "output.imageLabels",
+			&buildapi.BuildSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{
+						URI: "http://github.com/my/repository",
+					},
+				},
+				Strategy: buildapi.BuildStrategy{
+					SourceStrategy: &buildapi.SourceBuildStrategy{
+						From: kapi.ObjectReference{
+							Kind: "DockerImage",
+							Name: "reponame",
+						},
+					},
+				},
+				Output: buildapi.BuildOutput{
+					To: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "repository/data",
+					},
+					ImageLabels: []buildapi.ImageLabel{
+						{Name: "version", Value: "1.0"},
+					},
+				},
+			},
+		},
+	}
 
 	for count, config := range errorCases {
 		errors := validateBuildSpec(config.BuildSpec)
@@ -1334,6 +1496,7 @@ func TestValidateBuildSpecSuccess(t *testing.T) {
 							Kind: "DockerImage",
 							Name: "reponame",
 						},
+						Scripts: "image:///usr/libexec/s2i",
 					},
 				},
 				Output: buildapi.BuildOutput{
@@ -1549,6 +1712,26 @@ func TestValidateTrigger(t *testing.T) {
 			},
 			expected: []*fielderrors.ValidationError{fielderrors.NewFieldRequired("github")},
 		},
+		"GitHub trigger with empty allowed ref": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:      "secret101",
+					AllowedRefs: []string{"master", ""},
+				},
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldRequired("github.allowedRefs[1]")},
+		},
+		"GitHub trigger with malformed allowed ref": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:      "secret101",
+					AllowedRefs: []string{"release-["},
+				},
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldInvalid("github.allowedRefs[0]", "", "")},
+		},
 		"Generic trigger with no generic webhook": {
 			trigger:  buildapi.BuildTriggerPolicy{Type: buildapi.GenericWebHookBuildTriggerType},
 			expected: []*fielderrors.ValidationError{fielderrors.NewFieldRequired("generic")},
@@ -1575,6 +1758,40 @@ func TestValidateTrigger(t *testing.T) {
 			},
 			expected: []*fielderrors.ValidationError{fielderrors.NewFieldRequired("imageChange")},
 		},
+		"Schedule trigger without params": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.ScheduleBuildTriggerType,
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldRequired("schedule")},
+		},
+		"Schedule trigger without cron": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type:     buildapi.ScheduleBuildTriggerType,
+				Schedule: &buildapi.ScheduleTrigger{ConcurrencyPolicy: buildapi.ScheduleConcurrencyForbid},
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldRequired("schedule.cron")},
+		},
+		"Schedule trigger with malformed cron": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type:     buildapi.ScheduleBuildTriggerType,
+				Schedule: &buildapi.ScheduleTrigger{Cron: "0 2 * *", ConcurrencyPolicy: buildapi.ScheduleConcurrencyForbid},
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldInvalid("schedule.cron", "", "")},
+		},
+		"Schedule trigger with unknown concurrency policy": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type:     buildapi.ScheduleBuildTriggerType,
+				Schedule: &buildapi.ScheduleTrigger{Cron: "0 2 * * *", ConcurrencyPolicy: "Allow"},
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldValueNotSupported("schedule.concurrencyPolicy", "", nil)},
+		},
+		"Schedule trigger with negative history limit": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type:     buildapi.ScheduleBuildTriggerType,
+				Schedule: &buildapi.ScheduleTrigger{Cron: "0 2 * * *", ConcurrencyPolicy: buildapi.ScheduleConcurrencyForbid, HistoryLimit: -1},
+			},
+			expected: []*fielderrors.ValidationError{fielderrors.NewFieldInvalid("schedule.historyLimit", "", "")},
+		},
 		"valid GitHub trigger": {
 			trigger: buildapi.BuildTriggerPolicy{
 				Type: buildapi.GitHubWebHookBuildTriggerType,
@@ -1583,6 +1800,15 @@ func TestValidateTrigger(t *testing.T) {
 				},
 			},
 		},
+		"valid GitHub trigger with allowed refs": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:      "secret101",
+					AllowedRefs: []string{"master", "release-*", "refs/tags/v*"},
+				},
+			},
+		},
 		"valid Generic trigger": {
 			trigger: buildapi.BuildTriggerPolicy{
 				Type: buildapi.GenericWebHookBuildTriggerType,
@@ -1605,6 +1831,16 @@ func TestValidateTrigger(t *testing.T) {
 				ImageChange: &buildapi.ImageChangeTrigger{},
 			},
 		},
+		"valid Schedule trigger": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.ScheduleBuildTriggerType,
+				Schedule: &buildapi.ScheduleTrigger{
+					Cron:              "@daily",
+					ConcurrencyPolicy: buildapi.ScheduleConcurrencyReplace,
+					HistoryLimit:      3,
+				},
+			},
+		},
 	}
 	for desc, test := range tests {
 		errors := validateTrigger(&test.trigger)
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -155,6 +156,14 @@ func (d *DockerBuilder) addBuildParameters(dir string) error {
 		return err
 	}
 
+	// Ignore the build stages that follow the target stage, so the target
+	// stage is the one that is built, updated and pushed.
+	if target := d.build.Spec.Strategy.DockerStrategy.Target; len(target) > 0 {
+		if err := truncateAfterStage(node, target); err != nil {
+			return err
+		}
+	}
+
 	// Update base image if build strategy specifies the From field.
 	if d.build.Spec.Strategy.DockerStrategy.From != nil && d.build.Spec.Strategy.DockerStrategy.From.Kind == "DockerImage" {
 		// Reduce the name to a minimal canonical form for the daemon
@@ -226,9 +235,31 @@ func (d *DockerBuilder) buildLabels(dir string) []dockerfile.KeyValue {
 		sourceInfo.ContextDir = d.build.Spec.Source.ContextDir
 	}
 	labels = util.GenerateLabelsFromSourceInfo(labels, &sourceInfo.SourceInfo, api.DefaultDockerLabelNamespace)
-	kv := make([]dockerfile.KeyValue, 0, len(labels))
-	for k, v := range labels {
-		kv = append(kv, dockerfile.KeyValue{Key: k, Value: v})
+	return mergeImageLabels(labels, d.build.Spec.Output.ImageLabels)
+}
+
+// mergeImageLabels returns the generated labels sorted by name followed by the
+// labels of the build output in the order they are listed. A label of the build
+// output overrides the generated label with the same name.
+func mergeImageLabels(generated map[string]string, imageLabels []api.ImageLabel) []dockerfile.KeyValue {
+	overridden := map[string]bool{}
+	for _, label := range imageLabels {
+		overridden[label.Name] = true
+	}
+	names := make([]string, 0, len(generated))
+	for name := range generated {
+		if !overridden[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	kv := make([]dockerfile.KeyValue, 0, len(names)+len(imageLabels))
+	for _, name := range names {
+		kv = append(kv, dockerfile.KeyValue{Key: name, Value: generated[name]})
+	}
+	for _, label := range imageLabels {
+		kv = append(kv, dockerfile.KeyValue{Key: label.Name, Value: label.Value})
 	}
 	return kv
 }
@@ -280,7 +311,11 @@ func replaceLastFrom(node *parser.Node, image string) error {
 	for i := len(node.Children) - 1; i >= 0; i-- {
 		child := node.Children[i]
 		if child != nil && child.Value == dockercmd.From {
+			// keep the name of the build stage
 			from, err := dockerfile.From(image)
+			if _, stage := dockerfile.BaseImageAndStage(child); len(stage) > 0 {
+				from, err = dockerfile.FromStage(image, stage)
+			}
 			if err != nil {
 				return err
 			}
@@ -325,6 +360,22 @@ func appendKeyValueInstruction(f func([]dockerfile.KeyValue) (string, error), no
 	return dockerfile.InsertInstructions(node, len(node.Children), instruction)
 }
 
+// truncateAfterStage removes the build stages of a multi-stage Dockerfile that
+// follow the stage named stage.
+func truncateAfterStage(node *parser.Node, stage string) error {
+	froms := dockerfile.FindAll(node, dockercmd.From)
+	for i, pos := range froms {
+		if _, name := dockerfile.BaseImageAndStage(node.Children[pos]); !strings.EqualFold(name, stage) {
+			continue
+		}
+		if i+1 < len(froms) {
+			node.Children = node.Children[:froms[i+1]]
+		}
+		return nil
+	}
+	return fmt.Errorf("the Dockerfile has no build stage named %q", stage)
+}
+
 // insertEnvAfterFrom inserts an ENV instruction with the environment variables
 // from env after every FROM instruction in node.
 func insertEnvAfterFrom(node *parser.Node, env []kapi.EnvVar) error {
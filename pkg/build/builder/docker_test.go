@@ -115,6 +115,19 @@ RUN echo "hello world"
 			want: `FROM scratch
 FROM centos
 RUN echo "hello world"
+`,
+		},
+		{
+			original: `FROM golang AS builder
+RUN go build
+FROM busybox AS runtime
+COPY --from=builder /go/bin/app /app
+`,
+			image: "centos",
+			want: `FROM golang AS builder
+RUN go build
+FROM centos AS runtime
+COPY --from=builder /go/bin/app /app
 `,
 		},
 	}
@@ -137,6 +150,82 @@ RUN echo "hello world"
 	}
 }
 
+func TestTruncateAfterStage(t *testing.T) {
+	original := `FROM golang AS builder
+RUN go build
+FROM busybox AS test
+RUN /app --test
+FROM centos
+COPY --from=builder /go/bin/app /app
+`
+	tests := []struct {
+		stage string
+		want  string
+		err   bool
+	}{
+		{
+			stage: "builder",
+			want: `FROM golang AS builder
+RUN go build
+`,
+		},
+		{
+			stage: "test",
+			want: `FROM golang AS builder
+RUN go build
+FROM busybox AS test
+RUN /app --test
+`,
+		},
+		{
+			stage: "missing",
+			err:   true,
+		},
+	}
+	for i, test := range tests {
+		got, err := parser.Parse(strings.NewReader(original))
+		if err != nil {
+			t.Errorf("test[%d]: %v", i, err)
+			continue
+		}
+		err = truncateAfterStage(got, test.stage)
+		if test.err {
+			if err == nil {
+				t.Errorf("test[%d]: expected an error for a missing stage", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test[%d]: unexpected error: %v", i, err)
+			continue
+		}
+		if got := string(dockerfile.ParseTreeToDockerfile(got)); got != test.want {
+			t.Errorf("test[%d]: truncateAfterStage(node, %q) = %q; want %q", i, test.stage, got, test.want)
+		}
+	}
+}
+
+func TestMergeImageLabels(t *testing.T) {
+	generated := map[string]string{
+		"io.openshift.build.commit.id":       "abc",
+		"io.openshift.build.commit.author":   "John Doe",
+		"io.openshift.build.source-location": "http://github.com/my/repository",
+	}
+	imageLabels := []api.ImageLabel{
+		{Name: "version", Value: "1.0"},
+		{Name: "io.openshift.build.commit.author", Value: "Jane Doe"},
+	}
+	want := []dockerfile.KeyValue{
+		{Key: "io.openshift.build.commit.id", Value: "abc"},
+		{Key: "io.openshift.build.source-location", Value: "http://github.com/my/repository"},
+		{Key: "version", Value: "1.0"},
+		{Key: "io.openshift.build.commit.author", Value: "Jane Doe"},
+	}
+	if got := mergeImageLabels(generated, imageLabels); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeImageLabels() = %v; want %v", got, want)
+	}
+}
+
 // TestDockerfilePath validates that we can use a Dockefile with a custom name, and in a sub-directory
 func TestDockerfilePath(t *testing.T) {
 	tests := []struct {
@@ -76,17 +76,27 @@ func fetchSource(dockerClient DockerClient, dir string, build *api.Build, urlTim
 
 	// a Dockerfile has been specified, create or overwrite into the destination
 	if dockerfileSource := build.Spec.Source.Dockerfile; dockerfileSource != nil {
-		baseDir := dir
-		// if a context dir has been defined and we cloned source, overwrite the destination
-		if hasGitSource && len(build.Spec.Source.ContextDir) != 0 {
-			baseDir = filepath.Join(baseDir, build.Spec.Source.ContextDir)
+		path := dockerfileSourcePath(dir, build)
+		if err := os.MkdirAll(filepath.Dir(path), 0770); err != nil {
+			return nil, err
 		}
-		return sourceInfo, ioutil.WriteFile(filepath.Join(baseDir, "Dockerfile"), []byte(*dockerfileSource), 0660)
+		return sourceInfo, ioutil.WriteFile(path, []byte(*dockerfileSource), 0660)
 	}
 
 	return sourceInfo, nil
 }
 
+// dockerfileSourcePath returns where the Dockerfile of the build source is written
+// in dir, which is where the Docker build reads it from: in the context dir, at the
+// Dockerfile path of the strategy.
+func dockerfileSourcePath(dir string, build *api.Build) string {
+	name := defaultDockerfilePath
+	if strategy := build.Spec.Strategy.DockerStrategy; strategy != nil && len(strategy.DockerfilePath) > 0 {
+		name = strategy.DockerfilePath
+	}
+	return filepath.Join(dir, build.Spec.Source.ContextDir, name)
+}
+
 // checkRemoteGit validates the specified Git URL. It returns GitNotFoundError
 // when the remote repository not found and GitAuthenticationError when the
 // remote repository failed to authenticate.
@@ -2,12 +2,16 @@ package builder
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/generate/git"
 )
 
@@ -41,3 +45,51 @@ func TestCheckRemoteGit(t *testing.T) {
 		t.Errorf("unexpected error %q", err)
 	}
 }
+
+func TestFetchDockerfileSource(t *testing.T) {
+	dockerfile := "FROM centos\nLABEL custom=true\n"
+	tests := map[string]struct {
+		contextDir     string
+		dockerfilePath string
+		expected       string
+	}{
+		"default": {
+			expected: "Dockerfile",
+		},
+		"context dir": {
+			contextDir: "app",
+			expected:   "app/Dockerfile",
+		},
+		"dockerfile path": {
+			contextDir:     "app",
+			dockerfilePath: "docker/Dockerfile.custom",
+			expected:       "app/docker/Dockerfile.custom",
+		},
+	}
+	for name, test := range tests {
+		dir, err := ioutil.TempDir("", "dockerfile-source")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		build := &api.Build{Spec: api.BuildSpec{
+			Source: api.BuildSource{Dockerfile: &dockerfile, ContextDir: test.contextDir},
+			Strategy: api.BuildStrategy{
+				DockerStrategy: &api.DockerBuildStrategy{DockerfilePath: test.dockerfilePath},
+			},
+		}}
+		if _, err := fetchSource(nil, dir, build, time.Second, nil, nil); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, test.expected))
+		if err != nil {
+			t.Errorf("%s: expected the Dockerfile to be written to %s: %v", name, test.expected, err)
+			continue
+		}
+		if string(content) != dockerfile {
+			t.Errorf("%s: unexpected Dockerfile %q", name, string(content))
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/openshift/source-to-image/pkg/api/validation"
 	s2ibuild "github.com/openshift/source-to-image/pkg/build"
 	s2i "github.com/openshift/source-to-image/pkg/build/strategies"
+	s2iutil "github.com/openshift/source-to-image/pkg/util"
 
 	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/builder/cmd/dockercfg"
@@ -25,6 +26,9 @@ import (
 	"github.com/openshift/origin/pkg/client"
 )
 
+// sourceEnvironmentFile is the file of the sources that sets environment variables of the build
+const sourceEnvironmentFile = ".s2i/environment"
+
 // builderFactory is the internal interface to decouple S2I-specific code from Origin builder code
 type builderFactory interface {
 	// Create S2I Builder based on S2I configuration
@@ -290,12 +294,37 @@ func (d *downloader) Download(config *s2iapi.Config) (*s2iapi.SourceInfo, error)
 			return nil, err
 		}
 	}
+
+	if err := addSourceEnvironment(d.dir, config.Environment); err != nil {
+		return nil, err
+	}
 	if sourceInfo != nil {
 		return &sourceInfo.SourceInfo, nil
 	}
 	return nil, nil
 }
 
+// addSourceEnvironment adds the variables set by the .s2i/environment file of the
+// sources in dir to env. Variables that env already sets, from the build, are kept.
+func addSourceEnvironment(dir string, env map[string]string) error {
+	path := filepath.Join(dir, sourceEnvironmentFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	sourceEnv, err := s2iutil.ReadEnvironmentFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %v", sourceEnvironmentFile, err)
+	}
+	for name, value := range sourceEnv {
+		if _, exists := env[name]; exists {
+			continue
+		}
+		glog.V(4).Infof("Setting %s from %s", name, sourceEnvironmentFile)
+		env[name] = value
+	}
+	return nil
+}
+
 // buildEnvVars returns a map with build metadata to be inserted into Docker
 // images produced by build. It transforms the output from buildInfo into the
 // input format expected by s2iapi.Config.Environment.
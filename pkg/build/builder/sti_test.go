@@ -2,6 +2,10 @@ package builder
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -170,3 +174,31 @@ func TestGetStrategyError(t *testing.T) {
 		}
 	}
 }
+
+func TestAddSourceEnvironment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "s2i-env")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	env := map[string]string{"OPENSHIFT_BUILD_NAME": "build", "FOO": "from-build"}
+	if err := addSourceEnvironment(dir, env); err != nil {
+		t.Fatalf("expected sources without an environment file to be ignored, got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".s2i"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := "# comment\nFOO=from-source\nBAR=bar\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, sourceEnvironmentFile), []byte(content), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := addSourceEnvironment(dir, env); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"OPENSHIFT_BUILD_NAME": "build", "FOO": "from-build", "BAR": "bar"}
+	if !reflect.DeepEqual(expected, env) {
+		t.Errorf("expected %v, got %v", expected, env)
+	}
+}
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	osclient "github.com/openshift/origin/pkg/client"
 )
@@ -41,6 +44,26 @@ type BuildUpdater interface {
 	Update(namespace string, build *buildapi.Build) error
 }
 
+// BuildGetter provides methods for getting existing Builds.
+type BuildGetter interface {
+	Get(namespace, name string) (*buildapi.Build, error)
+}
+
+// BuildDeleter provides methods for deleting existing Builds.
+type BuildDeleter interface {
+	Delete(namespace, name string) error
+}
+
+// BuildFinalizer provides methods for updating the finalizers of existing Builds.
+type BuildFinalizer interface {
+	Finalize(namespace string, build *buildapi.Build) error
+}
+
+// BuildLister provides methods for listing the Builds.
+type BuildLister interface {
+	List(namespace string, label labels.Selector, field fields.Selector) (*buildapi.BuildList, error)
+}
+
 // OSClientBuildClient deletes build create and update operations to the OpenShift client interface
 type OSClientBuildClient struct {
 	Client osclient.Interface
@@ -51,12 +74,55 @@ func NewOSClientBuildClient(client osclient.Interface) *OSClientBuildClient {
 	return &OSClientBuildClient{Client: client}
 }
 
+// Get returns a Build using the OpenShift client.
+func (c OSClientBuildClient) Get(namespace, name string) (*buildapi.Build, error) {
+	return c.Client.Builds(namespace).Get(name)
+}
+
 // Update updates builds using the OpenShift client.
 func (c OSClientBuildClient) Update(namespace string, build *buildapi.Build) error {
 	_, e := c.Client.Builds(namespace).Update(build)
 	return e
 }
 
+// Delete deletes builds using the OpenShift client.
+func (c OSClientBuildClient) Delete(namespace, name string) error {
+	return c.Client.Builds(namespace).Delete(name)
+}
+
+// Finalize updates the finalizers of builds using the OpenShift client.
+func (c OSClientBuildClient) Finalize(namespace string, build *buildapi.Build) error {
+	_, e := c.Client.Builds(namespace).Finalize(build)
+	return e
+}
+
+// List lists builds using the OpenShift client.
+func (c OSClientBuildClient) List(namespace string, label labels.Selector, field fields.Selector) (*buildapi.BuildList, error) {
+	return c.Client.Builds(namespace).List(label, field)
+}
+
+// UpdateBuildWithRetries applies mutateFn to build and updates it with updater. If
+// the update is rejected because build is stale, the latest version of the build is
+// retrieved with getter and mutateFn is applied to it before retrying. On success
+// build is replaced with the version that was updated.
+func UpdateBuildWithRetries(getter BuildGetter, updater BuildUpdater, build *buildapi.Build, mutateFn func(*buildapi.Build)) error {
+	current := build
+	err := osclient.UpdateWithRetries(
+		func() (err error) {
+			current, err = getter.Get(build.Namespace, build.Name)
+			return
+		},
+		func() error {
+			mutateFn(current)
+			return updater.Update(current.Namespace, current)
+		},
+	)
+	if err == nil && current != build {
+		*build = *current
+	}
+	return err
+}
+
 // BuildCloner provides methods for cloning builds
 type BuildCloner interface {
 	Clone(namespace string, request *buildapi.BuildRequest) (*buildapi.Build, error)
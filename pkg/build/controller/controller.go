@@ -10,6 +10,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/cache"
 	"k8s.io/kubernetes/pkg/client/record"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildclient "github.com/openshift/origin/pkg/build/client"
@@ -20,10 +21,14 @@ import (
 // BuildController watches build resources and manages their state
 type BuildController struct {
 	BuildUpdater      buildclient.BuildUpdater
+	BuildGetter       buildclient.BuildGetter
 	PodManager        podManager
 	BuildStrategy     BuildStrategy
 	ImageStreamClient imageStreamClient
 	Recorder          record.EventRecorder
+	// LoggingAnnotationPrefix, if set, is prepended to the names of the annotations added to build pods for
+	// aggregated logging
+	LoggingAnnotationPrefix string
 }
 
 // BuildStrategy knows how to create a pod spec for a pod which can execute a build.
@@ -62,12 +67,18 @@ func (bc *BuildController) CancelBuild(build *buildapi.Build) error {
 		}
 	}
 
-	build.Status.Phase = buildapi.BuildPhaseCancelled
-	build.Status.Reason = ""
-	build.Status.Message = ""
 	now := unversioned.Now()
-	build.Status.CompletionTimestamp = &now
-	if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+	err = buildclient.UpdateBuildWithRetries(bc.BuildGetter, bc.BuildUpdater, build, func(build *buildapi.Build) {
+		// The build may have completed in the meantime.
+		if buildutil.IsBuildComplete(build) {
+			return
+		}
+		build.Status.Phase = buildapi.BuildPhaseCancelled
+		build.Status.Reason = ""
+		build.Status.Message = ""
+		build.Status.CompletionTimestamp = &now
+	})
+	if err != nil {
 		return fmt.Errorf("Failed to update build %s/%s: %v", build.Namespace, build.Name, err)
 	}
 
@@ -88,8 +99,8 @@ func (bc *BuildController) HandleBuild(build *buildapi.Build) error {
 		}
 	}
 
-	// Handle new builds
-	if build.Status.Phase != buildapi.BuildPhaseNew {
+	// Handle new builds, unless they are being deleted
+	if build.Status.Phase != buildapi.BuildPhaseNew || build.DeletionTimestamp != nil {
 		return nil
 	}
 
@@ -97,7 +108,25 @@ func (bc *BuildController) HandleBuild(build *buildapi.Build) error {
 		return err
 	}
 
-	if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+	// Carry the changes made by nextBuildPhase over to the latest version of the
+	// build if the update conflicts, unless the build was already handled.
+	status := build.Status
+	podName, hasPodName := build.Annotations[buildapi.BuildPodNameAnnotation]
+	err := buildclient.UpdateBuildWithRetries(bc.BuildGetter, bc.BuildUpdater, build, func(latest *buildapi.Build) {
+		if latest == build || latest.Status.Phase != buildapi.BuildPhaseNew {
+			return
+		}
+		cancelled := latest.Status.Cancelled
+		latest.Status = status
+		latest.Status.Cancelled = latest.Status.Cancelled || cancelled
+		if hasPodName {
+			if latest.Annotations == nil {
+				latest.Annotations = make(map[string]string)
+			}
+			latest.Annotations[buildapi.BuildPodNameAnnotation] = podName
+		}
+	})
+	if err != nil {
 		// This is not a retryable error because the build has been created.  The worst case
 		// outcome of not updating the buildconfig is that we might rerun a build for the
 		// same "new" imageid change in the future, which is better than guaranteeing we
@@ -155,6 +184,7 @@ func (bc *BuildController) nextBuildPhase(build *buildapi.Build) error {
 		build.Status.Reason = buildapi.StatusReasonCannotCreateBuildPodSpec
 		return fmt.Errorf("failed to create a build pod spec for build %s/%s: %v", build.Namespace, build.Name, err)
 	}
+	bc.setLoggingAnnotations(build, podSpec)
 	glog.V(4).Infof("Pod %s for build %s/%s is about to be created", podSpec.Name, build.Namespace, build.Name)
 
 	if _, err := bc.PodManager.CreatePod(build.Namespace, podSpec); err != nil {
@@ -230,6 +260,7 @@ func (bc *BuildController) resolveOutputDockerImageReference(build *buildapi.Bui
 type BuildPodController struct {
 	BuildStore   cache.Store
 	BuildUpdater buildclient.BuildUpdater
+	BuildGetter  buildclient.BuildGetter
 	PodManager   podManager
 }
 
@@ -272,23 +303,37 @@ func (bc *BuildPodController) HandlePod(pod *kapi.Pod) error {
 		nextStatus = buildapi.BuildPhaseFailed
 	}
 
-	if build.Status.Phase != nextStatus && !buildutil.IsBuildComplete(build) {
-		glog.V(4).Infof("Updating build %s/%s status %s -> %s", build.Namespace, build.Name, build.Status.Phase, nextStatus)
-		build.Status.Phase = nextStatus
-		build.Status.Reason = ""
-		build.Status.Message = ""
-		if buildutil.IsBuildComplete(build) {
-			now := unversioned.Now()
-			build.Status.CompletionTimestamp = &now
-		}
-		if build.Status.Phase == buildapi.BuildPhaseRunning {
+	if build.Status.Phase != nextStatus && buildutil.IsPhaseTransitionAllowed(build.Status.Phase, nextStatus) {
+		currentStatus := build.Status.Phase
+		glog.V(4).Infof("Updating build %s/%s status %s -> %s", build.Namespace, build.Name, currentStatus, nextStatus)
+		err := buildclient.UpdateBuildWithRetries(bc.BuildGetter, bc.BuildUpdater, build, func(build *buildapi.Build) {
+			// The latest version of the build may already reflect the transition, or a later one. The pod
+			// may have been seen in a phase that is older than the one of the build if the cache is stale.
+			if build.Status.Phase == nextStatus || !buildutil.IsPhaseTransitionAllowed(build.Status.Phase, nextStatus) {
+				return
+			}
+			previousPhase := build.Status.Phase
+			build.Status.Phase = nextStatus
+			build.Status.Reason = ""
+			build.Status.Message = ""
 			now := unversioned.Now()
-			build.Status.StartTimestamp = &now
-		}
-		if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+			if build.Status.Phase == buildapi.BuildPhaseRunning && build.Status.StartTimestamp == nil {
+				build.Status.StartTimestamp = &now
+			}
+			if buildutil.IsBuildComplete(build) {
+				if build.Status.CompletionTimestamp == nil {
+					build.Status.CompletionTimestamp = &now
+				}
+				// the pod completed before it was seen running
+				if previousPhase != buildapi.BuildPhaseRunning && build.Status.StartTimestamp == nil {
+					build.Status.StartTimestamp = build.Status.CompletionTimestamp
+				}
+			}
+		})
+		if err != nil {
 			return fmt.Errorf("failed to update build %s/%s: %v", build.Namespace, build.Name, err)
 		}
-		glog.V(4).Infof("Build %s/%s status was updated %s -> %s", build.Namespace, build.Name, build.Status.Phase, nextStatus)
+		glog.V(4).Infof("Build %s/%s status was updated %s -> %s", build.Namespace, build.Name, currentStatus, nextStatus)
 	}
 	return nil
 }
@@ -302,6 +347,7 @@ func isBuildCancellable(build *buildapi.Build) bool {
 type BuildPodDeleteController struct {
 	BuildStore   cache.Store
 	BuildUpdater buildclient.BuildUpdater
+	BuildGetter  buildclient.BuildGetter
 }
 
 // HandleBuildPodDeletion sets the status of a build to error if the build pod has been deleted
@@ -332,12 +378,18 @@ func (bc *BuildPodDeleteController) HandleBuildPodDeletion(pod *kapi.Pod) error
 	nextStatus := buildapi.BuildPhaseError
 	if build.Status.Phase != nextStatus {
 		glog.V(4).Infof("Updating build %s/%s status %s -> %s", build.Namespace, build.Name, build.Status.Phase, nextStatus)
-		build.Status.Phase = nextStatus
-		build.Status.Reason = buildapi.StatusReasonBuildPodDeleted
-		build.Status.Message = "The pod for this build was deleted before the build completed."
 		now := unversioned.Now()
-		build.Status.CompletionTimestamp = &now
-		if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+		err := buildclient.UpdateBuildWithRetries(bc.BuildGetter, bc.BuildUpdater, build, func(build *buildapi.Build) {
+			// The build may have completed or been cancelled in the meantime.
+			if build.Status.Cancelled || buildutil.IsBuildComplete(build) {
+				return
+			}
+			build.Status.Phase = nextStatus
+			build.Status.Reason = buildapi.StatusReasonBuildPodDeleted
+			build.Status.Message = "The pod for this build was deleted before the build completed."
+			build.Status.CompletionTimestamp = &now
+		})
+		if err != nil {
 			return fmt.Errorf("Failed to update build %s/%s: %v", build.Namespace, build.Name, err)
 		}
 	}
@@ -346,12 +398,93 @@ func (bc *BuildPodDeleteController) HandleBuildPodDeletion(pod *kapi.Pod) error
 
 // BuildDeleteController watches for builds being deleted and cleans up associated pods
 type BuildDeleteController struct {
-	PodManager podManager
+	PodManager     podManager
+	BuildGetter    buildclient.BuildGetter
+	BuildFinalizer buildclient.BuildFinalizer
+	BuildDeleter   buildclient.BuildDeleter
+}
+
+// Migrate adds the BuildPodFinalizer to the builds that were created before builds had finalizers,
+// then deletes the pods whose builds were deleted before that. It is run once, when the controller starts.
+func (bc *BuildDeleteController) Migrate(builds []buildapi.Build, pods []kapi.Pod) error {
+	for i := range builds {
+		build := &builds[i]
+		if build.DeletionTimestamp != nil || hasFinalizer(build, buildapi.BuildPodFinalizer) {
+			continue
+		}
+		err := kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+			latest, err := bc.BuildGetter.Get(build.Namespace, build.Name)
+			if err != nil {
+				return err
+			}
+			if hasFinalizer(latest, buildapi.BuildPodFinalizer) {
+				return nil
+			}
+			latest.Status.Finalizers = append(latest.Status.Finalizers, buildapi.BuildPodFinalizer)
+			return bc.BuildFinalizer.Finalize(latest.Namespace, latest)
+		})
+		// a build deleted in the meantime leaves its pod to be deleted below
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to add the finalizer to build %s/%s: %v", build.Namespace, build.Name, err)
+		}
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		buildName := pod.Labels[buildapi.BuildLabel]
+		if len(buildName) == 0 {
+			continue
+		}
+		_, err := bc.BuildGetter.Get(pod.Namespace, buildName)
+		if err == nil {
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to get build %s/%s of pod %s: %v", pod.Namespace, buildName, pod.Name, err)
+		}
+		glog.V(4).Infof("No build found for build pod %s/%s, deleting pod", pod.Namespace, pod.Name)
+		if err := bc.PodManager.DeletePod(pod.Namespace, pod); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to delete pod %s/%s of deleted build %s: %v", pod.Namespace, pod.Name, buildName, err)
+		}
+	}
+	return nil
 }
 
-// HandleBuildDeletion deletes a build pod if the corresponding build has been deleted
+// HandleBuildDeletion deletes the pod of a build that is marked for deletion, then removes
+// the BuildPodFinalizer of the build so that the build itself is deleted
 func (bc *BuildDeleteController) HandleBuildDeletion(build *buildapi.Build) error {
-	glog.V(4).Infof("Handling deletion of build %s", build.Name)
+	if build.DeletionTimestamp == nil || !hasFinalizer(build, buildapi.BuildPodFinalizer) {
+		return nil
+	}
+	glog.V(4).Infof("Handling deletion of build %s/%s", build.Namespace, build.Name)
+	if err := bc.deleteBuildPod(build); err != nil {
+		return err
+	}
+
+	finalizers := []kapi.FinalizerName{}
+	for _, finalizer := range build.Status.Finalizers {
+		if finalizer != buildapi.BuildPodFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	build.Status.Finalizers = finalizers
+	if err := bc.BuildFinalizer.Finalize(build.Namespace, build); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Failed to remove the finalizer of build %s/%s: %v", build.Namespace, build.Name, err)
+	}
+	if len(finalizers) > 0 {
+		return nil
+	}
+	if err := bc.BuildDeleter.Delete(build.Namespace, build.Name); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to delete build %s/%s: %v", build.Namespace, build.Name, err)
+	}
+	return nil
+}
+
+// deleteBuildPod deletes the pod of build, if there is one
+func (bc *BuildDeleteController) deleteBuildPod(build *buildapi.Build) error {
 	podName := buildutil.GetBuildPodName(build)
 	pod, err := bc.PodManager.GetPod(build.Namespace, podName)
 	if err != nil && !errors.IsNotFound(err) {
@@ -374,6 +507,16 @@ func (bc *BuildDeleteController) HandleBuildDeletion(build *buildapi.Build) erro
 	return nil
 }
 
+// hasFinalizer returns true if build has finalizer
+func hasFinalizer(build *buildapi.Build, finalizer kapi.FinalizerName) bool {
+	for _, f := range build.Status.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
 // buildKey returns a build object that can be used to lookup a build
 // in the cache store, given a pod for the build
 func buildKey(pod *kapi.Pod) *buildapi.Build {
@@ -384,3 +527,33 @@ func buildKey(pod *kapi.Pod) *buildapi.Build {
 		},
 	}
 }
+
+// setLoggingAnnotations annotates the pod of build with its application, build config and commit, so that
+// aggregated logging can index the logs of builds. The annotations set by the build strategy are kept.
+func (bc *BuildController) setLoggingAnnotations(build *buildapi.Build, pod *kapi.Pod) {
+	if len(bc.LoggingAnnotationPrefix) == 0 {
+		return
+	}
+
+	values := map[string]string{
+		"app":         build.Labels["app"],
+		"buildconfig": build.Labels[buildapi.BuildConfigLabel],
+	}
+	if len(values["buildconfig"]) == 0 {
+		values["buildconfig"] = build.Labels[buildapi.BuildConfigLabelDeprecated]
+	}
+	if build.Spec.Revision != nil && build.Spec.Revision.Git != nil {
+		values["commit"] = build.Spec.Revision.Git.Commit
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	for name, value := range values {
+		key := bc.LoggingAnnotationPrefix + name
+		if _, exists := pod.Annotations[key]; exists || len(value) == 0 {
+			continue
+		}
+		pod.Annotations[key] = value
+	}
+}
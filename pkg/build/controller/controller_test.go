@@ -13,6 +13,7 @@ import (
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildclient "github.com/openshift/origin/pkg/build/client"
 	buildtest "github.com/openshift/origin/pkg/build/controller/test"
+	"github.com/openshift/origin/pkg/client/testclient"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
@@ -496,6 +497,24 @@ func TestHandlePod(t *testing.T) {
 			startTimestamp:      nil,
 			completionTimestamp: nil,
 		},
+		{ // 7
+			matchID:             true,
+			inStatus:            buildapi.BuildPhaseComplete,
+			outStatus:           buildapi.BuildPhaseComplete,
+			podStatus:           kapi.PodRunning,
+			exitCode:            0,
+			startTimestamp:      nil,
+			completionTimestamp: nil,
+		},
+		{ // 8
+			matchID:             true,
+			inStatus:            buildapi.BuildPhasePending,
+			outStatus:           buildapi.BuildPhaseComplete,
+			podStatus:           kapi.PodSucceeded,
+			exitCode:            0,
+			startTimestamp:      curtime,
+			completionTimestamp: curtime,
+		},
 	}
 
 	for i, tc := range tests {
@@ -696,10 +715,55 @@ func (c *customPodManager) GetPod(namespace, name string) (*kapi.Pod, error) {
 	return c.GetPodFunc(namespace, name)
 }
 
+type customBuildDeleter struct {
+	DeleteFunc func(namespace, name string) error
+}
+
+func (c *customBuildDeleter) Delete(namespace, name string) error {
+	return c.DeleteFunc(namespace, name)
+}
+
+type customBuildFinalizer struct {
+	FinalizeFunc func(namespace string, build *buildapi.Build) error
+}
+
+func (c *customBuildFinalizer) Finalize(namespace string, build *buildapi.Build) error {
+	return c.FinalizeFunc(namespace, build)
+}
+
+type customBuildGetter struct {
+	GetFunc func(namespace, name string) (*buildapi.Build, error)
+}
+
+func (c *customBuildGetter) Get(namespace, name string) (*buildapi.Build, error) {
+	return c.GetFunc(namespace, name)
+}
+
+func mockDeletedBuild() *buildapi.Build {
+	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
+	now := unversioned.Now()
+	build.DeletionTimestamp = &now
+	build.Status.Finalizers = []kapi.FinalizerName{buildapi.BuildPodFinalizer}
+	return build
+}
+
+func mockBuildDeleteController(podManager podManager, buildDeleter buildclient.BuildDeleter) *BuildDeleteController {
+	if buildDeleter == nil {
+		buildDeleter = &customBuildDeleter{DeleteFunc: func(namespace, name string) error { return nil }}
+	}
+	return &BuildDeleteController{
+		PodManager: podManager,
+		BuildFinalizer: &customBuildFinalizer{
+			FinalizeFunc: func(namespace string, build *buildapi.Build) error { return nil },
+		},
+		BuildDeleter: buildDeleter,
+	}
+}
+
 func TestHandleHandleBuildDeletionOK(t *testing.T) {
 	deleteWasCalled := false
-	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	build := mockDeletedBuild()
+	ctrl := mockBuildDeleteController(&customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Labels: map[string]string{buildapi.BuildLabel: build.Name}}}, nil
 		},
@@ -707,7 +771,7 @@ func TestHandleHandleBuildDeletionOK(t *testing.T) {
 			deleteWasCalled = true
 			return nil
 		},
-	}}
+	}, nil)
 
 	err := ctrl.HandleBuildDeletion(build)
 	if err != nil {
@@ -720,8 +784,8 @@ func TestHandleHandleBuildDeletionOK(t *testing.T) {
 
 func TestHandleHandleBuildDeletionOKDeprecatedLabel(t *testing.T) {
 	deleteWasCalled := false
-	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	build := mockDeletedBuild()
+	ctrl := mockBuildDeleteController(&customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Labels: map[string]string{buildapi.BuildLabel: build.Name}}}, nil
 		},
@@ -729,7 +793,7 @@ func TestHandleHandleBuildDeletionOKDeprecatedLabel(t *testing.T) {
 			deleteWasCalled = true
 			return nil
 		},
-	}}
+	}, nil)
 
 	err := ctrl.HandleBuildDeletion(build)
 	if err != nil {
@@ -741,12 +805,12 @@ func TestHandleHandleBuildDeletionOKDeprecatedLabel(t *testing.T) {
 }
 
 func TestHandleHandleBuildDeletionFailGetPod(t *testing.T) {
-	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	build := mockDeletedBuild()
+	ctrl := mockBuildDeleteController(&customPodManager{
 		GetPodFunc: func(namespace, name string) (*kapi.Pod, error) {
 			return nil, errors.New("random")
 		},
-	}}
+	}, nil)
 
 	err := ctrl.HandleBuildDeletion(build)
 	if err == nil {
@@ -756,8 +820,8 @@ func TestHandleHandleBuildDeletionFailGetPod(t *testing.T) {
 
 func TestHandleHandleBuildDeletionGetPodNotFound(t *testing.T) {
 	deleteWasCalled := false
-	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	build := mockDeletedBuild()
+	ctrl := mockBuildDeleteController(&customPodManager{
 		GetPodFunc: func(namespace, name string) (*kapi.Pod, error) {
 			return nil, kerrors.NewNotFound("Pod", name)
 		},
@@ -765,7 +829,7 @@ func TestHandleHandleBuildDeletionGetPodNotFound(t *testing.T) {
 			deleteWasCalled = true
 			return nil
 		},
-	}}
+	}, nil)
 
 	err := ctrl.HandleBuildDeletion(build)
 	if err != nil {
@@ -778,8 +842,8 @@ func TestHandleHandleBuildDeletionGetPodNotFound(t *testing.T) {
 
 func TestHandleHandleBuildDeletionMismatchedLabels(t *testing.T) {
 	deleteWasCalled := false
-	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	build := mockDeletedBuild()
+	ctrl := mockBuildDeleteController(&customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{}, nil
 		},
@@ -787,7 +851,7 @@ func TestHandleHandleBuildDeletionMismatchedLabels(t *testing.T) {
 			deleteWasCalled = true
 			return nil
 		},
-	}}
+	}, nil)
 
 	err := ctrl.HandleBuildDeletion(build)
 	if err != nil {
@@ -799,15 +863,15 @@ func TestHandleHandleBuildDeletionMismatchedLabels(t *testing.T) {
 }
 
 func TestHandleHandleBuildDeletionDeletePodError(t *testing.T) {
-	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	build := mockDeletedBuild()
+	ctrl := mockBuildDeleteController(&customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Labels: map[string]string{buildapi.BuildLabel: build.Name}}}, nil
 		},
 		DeletePodFunc: func(namespace string, pod *kapi.Pod) error {
 			return errors.New("random")
 		},
-	}}
+	}, nil)
 
 	err := ctrl.HandleBuildDeletion(build)
 	if err == nil {
@@ -815,6 +879,135 @@ func TestHandleHandleBuildDeletionDeletePodError(t *testing.T) {
 	}
 }
 
+func TestHandleBuildDeletionRemovesFinalizer(t *testing.T) {
+	tests := map[string]struct {
+		finalizers     []kapi.FinalizerName
+		deletion       bool
+		expectUpdate   bool
+		expectDeletion bool
+	}{
+		"not marked for deletion": {
+			finalizers: []kapi.FinalizerName{buildapi.BuildPodFinalizer},
+		},
+		"without finalizer": {
+			deletion: true,
+		},
+		"last finalizer": {
+			finalizers:     []kapi.FinalizerName{buildapi.BuildPodFinalizer},
+			deletion:       true,
+			expectUpdate:   true,
+			expectDeletion: true,
+		},
+		"other finalizers": {
+			finalizers:   []kapi.FinalizerName{"other", buildapi.BuildPodFinalizer},
+			deletion:     true,
+			expectUpdate: true,
+		},
+	}
+
+	for name, test := range tests {
+		build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
+		build.Status.Finalizers = test.finalizers
+		if test.deletion {
+			now := unversioned.Now()
+			build.DeletionTimestamp = &now
+		}
+		var updated *buildapi.Build
+		deleted := false
+		ctrl := &BuildDeleteController{
+			PodManager: &customPodManager{
+				GetPodFunc: func(namespace, name string) (*kapi.Pod, error) {
+					return nil, kerrors.NewNotFound("Pod", name)
+				},
+			},
+			BuildFinalizer: &customBuildFinalizer{
+				FinalizeFunc: func(namespace string, build *buildapi.Build) error {
+					updated = build
+					return nil
+				},
+			},
+			BuildDeleter: &customBuildDeleter{
+				DeleteFunc: func(namespace, name string) error {
+					deleted = true
+					return nil
+				},
+			},
+		}
+
+		if err := ctrl.HandleBuildDeletion(build); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if (updated != nil) != test.expectUpdate {
+			t.Errorf("%s: expected update %t, got %#v", name, test.expectUpdate, updated)
+		}
+		if updated != nil && hasFinalizer(updated, buildapi.BuildPodFinalizer) {
+			t.Errorf("%s: expected the finalizer to be removed, got %v", name, updated.Status.Finalizers)
+		}
+		if deleted != test.expectDeletion {
+			t.Errorf("%s: expected deletion %t, got %t", name, test.expectDeletion, deleted)
+		}
+	}
+}
+
+func TestBuildDeleteControllerMigrate(t *testing.T) {
+	withFinalizer := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
+	withFinalizer.Name = "with-finalizer"
+	withFinalizer.Status.Finalizers = []kapi.FinalizerName{buildapi.BuildPodFinalizer}
+	withoutFinalizer := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
+	withoutFinalizer.Name = "without-finalizer"
+	deletedMeanwhile := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
+	deletedMeanwhile.Name = "deleted-meanwhile"
+	current := map[string]*buildapi.Build{
+		withFinalizer.Name:    withFinalizer,
+		withoutFinalizer.Name: withoutFinalizer,
+	}
+
+	podFor := func(buildName string) kapi.Pod {
+		return kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: buildName + "-build", Namespace: "namespace", Labels: map[string]string{buildapi.BuildLabel: buildName}}}
+	}
+	pods := []kapi.Pod{podFor(withFinalizer.Name), podFor(withoutFinalizer.Name), podFor(deletedMeanwhile.Name), podFor("deleted-before")}
+
+	finalized := map[string]bool{}
+	deletedPods := []string{}
+	ctrl := &BuildDeleteController{
+		PodManager: &customPodManager{
+			DeletePodFunc: func(namespace string, pod *kapi.Pod) error {
+				deletedPods = append(deletedPods, pod.Name)
+				return nil
+			},
+		},
+		BuildGetter: &customBuildGetter{
+			GetFunc: func(namespace, name string) (*buildapi.Build, error) {
+				if build, ok := current[name]; ok {
+					copied := *build
+					return &copied, nil
+				}
+				return nil, kerrors.NewNotFound("Build", name)
+			},
+		},
+		BuildFinalizer: &customBuildFinalizer{
+			FinalizeFunc: func(namespace string, build *buildapi.Build) error {
+				if !hasFinalizer(build, buildapi.BuildPodFinalizer) {
+					t.Errorf("expected build %s to get the finalizer, got %v", build.Name, build.Status.Finalizers)
+				}
+				finalized[build.Name] = true
+				return nil
+			},
+		},
+	}
+
+	if err := ctrl.Migrate([]buildapi.Build{*withFinalizer, *withoutFinalizer, *deletedMeanwhile}, pods); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(finalized) != 1 || !finalized[withoutFinalizer.Name] {
+		t.Errorf("expected only %s to get the finalizer, got %v", withoutFinalizer.Name, finalized)
+	}
+	if e, a := []string{"deleted-meanwhile-build", "deleted-before-build"}, deletedPods; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the pods of deleted builds %v to be deleted, got %v", e, a)
+	}
+}
+
 type customBuildUpdater struct {
 	UpdateFunc func(namespace string, build *buildapi.Build) error
 }
@@ -936,3 +1129,163 @@ func TestHandleBuildPodDeletionBuildUpdateError(t *testing.T) {
 		t.Error("Expected random error, but got none!")
 	}
 }
+
+func TestSetLoggingAnnotations(t *testing.T) {
+	build := mockBuild(buildapi.BuildPhaseNew, buildapi.BuildOutput{})
+	build.Labels = map[string]string{"app": "frontend", buildapi.BuildConfigLabel: "frontend-build"}
+	build.Spec.Revision = &buildapi.SourceRevision{Git: &buildapi.GitSourceRevision{Commit: "abcdef"}}
+	pod := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Annotations: map[string]string{"logging.example.com/app": "strategy"}}}
+
+	ctrl := &BuildController{}
+	ctrl.setLoggingAnnotations(build, pod)
+	if len(pod.Annotations) != 1 {
+		t.Errorf("expected no annotations to be added without a prefix, got %v", pod.Annotations)
+	}
+
+	ctrl.LoggingAnnotationPrefix = "logging.example.com/"
+	ctrl.setLoggingAnnotations(build, pod)
+	expected := map[string]string{
+		"logging.example.com/app":         "strategy",
+		"logging.example.com/buildconfig": "frontend-build",
+		"logging.example.com/commit":      "abcdef",
+	}
+	if !reflect.DeepEqual(pod.Annotations, expected) {
+		t.Errorf("expected annotations %v, got %v", expected, pod.Annotations)
+	}
+}
+
+// conflictBuildClient rejects the first update of a build with a conflict and returns latest as the current
+// version of the build.
+type conflictBuildClient struct {
+	latest    *buildapi.Build
+	updates   []buildapi.Build
+	conflicts func(kind, name string) error
+}
+
+func (c *conflictBuildClient) Get(namespace, name string) (*buildapi.Build, error) {
+	return c.latest, nil
+}
+
+func (c *conflictBuildClient) Update(namespace string, build *buildapi.Build) error {
+	c.updates = append(c.updates, *build)
+	return c.conflicts("builds", build.Name)
+}
+
+func TestBuildControllersRetryConflicts(t *testing.T) {
+	// latest returns the version of the build stored after the build in hand was read
+	latest := func(phase buildapi.BuildPhase, cancelled bool) *buildapi.Build {
+		build := mockBuild(phase, buildapi.BuildOutput{})
+		build.Annotations = map[string]string{"updated": "meanwhile"}
+		build.Status.Cancelled = cancelled
+		return build
+	}
+
+	tests := []struct {
+		name              string
+		latest            *buildapi.Build
+		handle            func(client *conflictBuildClient) error
+		expectedPhase     buildapi.BuildPhase
+		expectedCancelled bool
+	}{
+		{
+			name:   "new build",
+			latest: latest(buildapi.BuildPhaseNew, true),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := mockBuildController()
+				ctrl.BuildGetter, ctrl.BuildUpdater = client, client
+				return ctrl.HandleBuild(mockBuild(buildapi.BuildPhaseNew, buildapi.BuildOutput{}))
+			},
+			expectedPhase:     buildapi.BuildPhasePending,
+			expectedCancelled: true,
+		},
+		{
+			name:   "cancelled build",
+			latest: latest(buildapi.BuildPhaseRunning, true),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := mockBuildController()
+				ctrl.BuildGetter, ctrl.BuildUpdater = client, client
+				return ctrl.CancelBuild(mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{}))
+			},
+			expectedPhase:     buildapi.BuildPhaseCancelled,
+			expectedCancelled: true,
+		},
+		{
+			name:   "cancelled build completed meanwhile",
+			latest: latest(buildapi.BuildPhaseComplete, false),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := mockBuildController()
+				ctrl.BuildGetter, ctrl.BuildUpdater = client, client
+				return ctrl.CancelBuild(mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{}))
+			},
+			expectedPhase: buildapi.BuildPhaseComplete,
+		},
+		{
+			name:   "running pod",
+			latest: latest(buildapi.BuildPhasePending, false),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := mockBuildPodController(mockBuild(buildapi.BuildPhasePending, buildapi.BuildOutput{}))
+				ctrl.BuildGetter, ctrl.BuildUpdater = client, client
+				return ctrl.HandlePod(mockPod(kapi.PodRunning, 0))
+			},
+			expectedPhase: buildapi.BuildPhaseRunning,
+		},
+		{
+			name:   "running pod of a build failed meanwhile",
+			latest: latest(buildapi.BuildPhaseFailed, false),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := mockBuildPodController(mockBuild(buildapi.BuildPhasePending, buildapi.BuildOutput{}))
+				ctrl.BuildGetter, ctrl.BuildUpdater = client, client
+				return ctrl.HandlePod(mockPod(kapi.PodRunning, 0))
+			},
+			expectedPhase: buildapi.BuildPhaseFailed,
+		},
+		{
+			name:   "deleted pod",
+			latest: latest(buildapi.BuildPhaseRunning, false),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := &BuildPodDeleteController{
+					BuildStore:   buildtest.FakeBuildStore{Build: mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{})},
+					BuildGetter:  client,
+					BuildUpdater: client,
+				}
+				return ctrl.HandleBuildPodDeletion(mockPod(kapi.PodSucceeded, 0))
+			},
+			expectedPhase: buildapi.BuildPhaseError,
+		},
+		{
+			name:   "deleted pod of a build completed meanwhile",
+			latest: latest(buildapi.BuildPhaseComplete, false),
+			handle: func(client *conflictBuildClient) error {
+				ctrl := &BuildPodDeleteController{
+					BuildStore:   buildtest.FakeBuildStore{Build: mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{})},
+					BuildGetter:  client,
+					BuildUpdater: client,
+				}
+				return ctrl.HandleBuildPodDeletion(mockPod(kapi.PodSucceeded, 0))
+			},
+			expectedPhase: buildapi.BuildPhaseComplete,
+		},
+	}
+
+	for _, test := range tests {
+		client := &conflictBuildClient{latest: test.latest, conflicts: testclient.NewConflicts(1)}
+		if err := test.handle(client); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(client.updates) != 2 {
+			t.Errorf("%s: expected the build to be updated again after the conflict, got %d updates", test.name, len(client.updates))
+			continue
+		}
+		updated := client.updates[1]
+		if updated.Annotations["updated"] != "meanwhile" {
+			t.Errorf("%s: expected the latest version of the build to be updated, got %#v", test.name, updated)
+		}
+		if updated.Status.Phase != test.expectedPhase {
+			t.Errorf("%s: expected phase %s, got %s", test.name, test.expectedPhase, updated.Status.Phase)
+		}
+		if updated.Status.Cancelled != test.expectedCancelled {
+			t.Errorf("%s: expected cancelled to be %v, got %v", test.name, test.expectedCancelled, updated.Status.Cancelled)
+		}
+	}
+}
@@ -23,29 +23,42 @@ import (
 	strategy "github.com/openshift/origin/pkg/build/controller/strategy"
 	buildutil "github.com/openshift/origin/pkg/build/util"
 	osclient "github.com/openshift/origin/pkg/client"
+	oscache "github.com/openshift/origin/pkg/client/cache"
 	controller "github.com/openshift/origin/pkg/controller"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	errors "github.com/openshift/origin/pkg/util/errors"
 )
 
-const maxRetries = 60
+const (
+	maxRetries = 60
+
+	// maxListAge is how long the build controllers resume watching before listing from the server again
+	maxListAge = 30 * time.Minute
+)
 
 // limitedLogAndRetry stops retrying after maxTimeout, failing the build.
-func limitedLogAndRetry(buildupdater buildclient.BuildUpdater, maxTimeout time.Duration) controller.RetryFunc {
+func limitedLogAndRetry(buildgetter buildclient.BuildGetter, buildupdater buildclient.BuildUpdater, maxTimeout time.Duration) controller.RetryFunc {
 	return func(obj interface{}, err error, retries controller.Retry) bool {
 		build := obj.(*buildapi.Build)
 		if time.Since(retries.StartTimestamp.Time) < maxTimeout {
 			glog.V(4).Infof("Retrying Build %s/%s with error: %v", build.Namespace, build.Name, err)
 			return true
 		}
-		build.Status.Phase = buildapi.BuildPhaseFailed
-		build.Status.Reason = buildapi.StatusReasonExceededRetryTimeout
-		build.Status.Message = errors.ErrorToSentence(err)
-		now := unversioned.Now()
-		build.Status.CompletionTimestamp = &now
 		glog.V(3).Infof("Giving up retrying Build %s/%s: %v", build.Namespace, build.Name, err)
 		kutil.HandleError(err)
-		if err := buildupdater.Update(build.Namespace, build); err != nil {
+		message := errors.ErrorToSentence(err)
+		now := unversioned.Now()
+		err = buildclient.UpdateBuildWithRetries(buildgetter, buildupdater, build, func(build *buildapi.Build) {
+			// The build may have completed in the meantime.
+			if buildutil.IsBuildComplete(build) {
+				return
+			}
+			build.Status.Phase = buildapi.BuildPhaseFailed
+			build.Status.Reason = buildapi.StatusReasonExceededRetryTimeout
+			build.Status.Message = message
+			build.Status.CompletionTimestamp = &now
+		})
+		if err != nil {
 			// retry update, but only on error other than NotFound
 			return !kerrors.IsNotFound(err)
 		}
@@ -58,9 +71,15 @@ type BuildControllerFactory struct {
 	OSClient            osclient.Interface
 	KubeClient          kclient.Interface
 	BuildUpdater        buildclient.BuildUpdater
+	BuildGetter         buildclient.BuildGetter
+	BuildDeleter        buildclient.BuildDeleter
+	BuildFinalizer      buildclient.BuildFinalizer
 	DockerBuildStrategy *strategy.DockerBuildStrategy
 	SourceBuildStrategy *strategy.SourceBuildStrategy
 	CustomBuildStrategy *strategy.CustomBuildStrategy
+	// LoggingAnnotationPrefix, if set, is prepended to the names of the annotations added to build pods for
+	// aggregated logging
+	LoggingAnnotationPrefix string
 	// Stop may be set to allow controllers created by this factory to be terminated.
 	Stop <-chan struct{}
 }
@@ -68,7 +87,7 @@ type BuildControllerFactory struct {
 // Create constructs a BuildController
 func (factory *BuildControllerFactory) Create() controller.RunnableController {
 	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
-	cache.NewReflector(&buildLW{client: factory.OSClient}, &buildapi.Build{}, queue, 2*time.Minute).RunUntil(factory.Stop)
+	cache.NewReflector(oscache.NewResumableListWatch(&buildLW{client: factory.OSClient}, maxListAge), &buildapi.Build{}, queue, 2*time.Minute).RunUntil(factory.Stop)
 
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartRecordingToSink(factory.KubeClient.Events(""))
@@ -76,6 +95,7 @@ func (factory *BuildControllerFactory) Create() controller.RunnableController {
 	client := ControllerClient{factory.KubeClient, factory.OSClient}
 	buildController := &buildcontroller.BuildController{
 		BuildUpdater:      factory.BuildUpdater,
+		BuildGetter:       factory.BuildGetter,
 		ImageStreamClient: client,
 		PodManager:        client,
 		BuildStrategy: &typeBasedFactoryStrategy{
@@ -83,28 +103,34 @@ func (factory *BuildControllerFactory) Create() controller.RunnableController {
 			SourceBuildStrategy: factory.SourceBuildStrategy,
 			CustomBuildStrategy: factory.CustomBuildStrategy,
 		},
-		Recorder: eventBroadcaster.NewRecorder(kapi.EventSource{Component: "build-controller"}),
+		Recorder:                eventBroadcaster.NewRecorder(kapi.EventSource{Component: "build-controller"}),
+		LoggingAnnotationPrefix: factory.LoggingAnnotationPrefix,
 	}
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
-			limitedLogAndRetry(factory.BuildUpdater, 30*time.Minute),
-			kutil.NewTokenBucketRateLimiter(1, 10)),
+			limitedLogAndRetry(factory.BuildGetter, factory.BuildUpdater, 30*time.Minute),
+			controller.DefaultBackoff),
 		Handle: func(obj interface{}) error {
 			build := obj.(*buildapi.Build)
 			err := buildController.HandleBuild(build)
 			if err != nil {
 				// Update the build status message only if it changed.
 				if msg := errors.ErrorToSentence(err); build.Status.Message != msg {
-					// Set default Reason.
-					if len(build.Status.Reason) == 0 {
-						build.Status.Reason = buildapi.StatusReasonError
-					}
-					build.Status.Message = msg
-					if err := buildController.BuildUpdater.Update(build.Namespace, build); err != nil {
+					err := buildclient.UpdateBuildWithRetries(buildController.BuildGetter, buildController.BuildUpdater, build, func(build *buildapi.Build) {
+						if buildutil.IsBuildComplete(build) {
+							return
+						}
+						// Set default Reason.
+						if len(build.Status.Reason) == 0 {
+							build.Status.Reason = buildapi.StatusReasonError
+						}
+						build.Status.Message = msg
+					})
+					if err != nil {
 						glog.V(2).Infof("Failed to update status message of Build %s/%s: %v", build.Namespace, build.Name, err)
 					}
 					buildController.Recorder.Eventf(build, "HandleBuildError", "Build has error: %v", err)
@@ -117,38 +143,65 @@ func (factory *BuildControllerFactory) Create() controller.RunnableController {
 
 // CreateDeleteController constructs a BuildDeleteController
 func (factory *BuildControllerFactory) CreateDeleteController() controller.RunnableController {
-	client := ControllerClient{factory.KubeClient, factory.OSClient}
-	queue := cache.NewDeltaFIFO(cache.MetaNamespaceKeyFunc, nil, keyListerGetter{})
-	cache.NewReflector(&buildDeleteLW{client, queue}, &buildapi.Build{}, queue, 5*time.Minute).RunUntil(factory.Stop)
+	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(oscache.NewResumableListWatch(&buildLW{client: factory.OSClient}, maxListAge), &buildapi.Build{}, queue, 2*time.Minute).RunUntil(factory.Stop)
 
+	client := ControllerClient{factory.KubeClient, factory.OSClient}
 	buildDeleteController := &buildcontroller.BuildDeleteController{
-		PodManager: client,
+		PodManager:     client,
+		BuildGetter:    factory.BuildGetter,
+		BuildFinalizer: factory.BuildFinalizer,
+		BuildDeleter:   factory.BuildDeleter,
 	}
+	go factory.migrateBuilds(buildDeleteController)
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
-			controller.RetryNever,
-			kutil.NewTokenBucketRateLimiter(1, 10)),
+			// a conflict means a newer version of the build is queued by the watch
+			retryFunc("BuildDelete", kerrors.IsConflict),
+			controller.DefaultBackoff),
 		Handle: func(obj interface{}) error {
-			deltas := obj.(cache.Deltas)
-			for _, delta := range deltas {
-				if delta.Type == cache.Deleted {
-					return buildDeleteController.HandleBuildDeletion(delta.Object.(*buildapi.Build))
-				}
-			}
-			return nil
+			return buildDeleteController.HandleBuildDeletion(obj.(*buildapi.Build))
 		},
 	}
 }
 
+// migrateBuilds runs the migration of buildDeleteController until it succeeds, so that the builds created
+// before builds had finalizers get one and the pods of the builds deleted before that are removed.
+func (factory *BuildControllerFactory) migrateBuilds(buildDeleteController *buildcontroller.BuildDeleteController) {
+	for {
+		err := func() error {
+			builds, err := factory.OSClient.Builds(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+			if err != nil {
+				return err
+			}
+			pods, err := listPods(factory.KubeClient)
+			if err != nil {
+				return err
+			}
+			return buildDeleteController.Migrate(builds.Items, pods.Items)
+		}()
+		if err == nil {
+			return
+		}
+		kutil.HandleError(fmt.Errorf("unable to migrate builds to the build pod finalizer: %v", err))
+		select {
+		case <-factory.Stop:
+			return
+		case <-time.After(30 * time.Second):
+		}
+	}
+}
+
 // BuildPodControllerFactory construct BuildPodController objects
 type BuildPodControllerFactory struct {
 	OSClient     osclient.Interface
 	KubeClient   kclient.Interface
 	BuildUpdater buildclient.BuildUpdater
+	BuildGetter  buildclient.BuildGetter
 	// Stop may be set to allow controllers created by this factory to be terminated.
 	Stop <-chan struct{}
 
@@ -180,25 +233,26 @@ func retryFunc(kind string, isFatal func(err error) bool) controller.RetryFunc {
 // Create constructs a BuildPodController
 func (factory *BuildPodControllerFactory) Create() controller.RunnableController {
 	factory.buildStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
-	cache.NewReflector(&buildLW{client: factory.OSClient}, &buildapi.Build{}, factory.buildStore, 2*time.Minute).RunUntil(factory.Stop)
+	cache.NewReflector(oscache.NewResumableListWatch(&buildLW{client: factory.OSClient}, maxListAge), &buildapi.Build{}, factory.buildStore, 2*time.Minute).RunUntil(factory.Stop)
 
 	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
-	cache.NewReflector(&podLW{client: factory.KubeClient}, &kapi.Pod{}, queue, 2*time.Minute).RunUntil(factory.Stop)
+	cache.NewReflector(oscache.NewResumableListWatch(&podLW{client: factory.KubeClient}, maxListAge), &kapi.Pod{}, queue, 2*time.Minute).RunUntil(factory.Stop)
 
 	client := ControllerClient{factory.KubeClient, factory.OSClient}
 	buildPodController := &buildcontroller.BuildPodController{
 		BuildStore:   factory.buildStore,
 		BuildUpdater: factory.BuildUpdater,
+		BuildGetter:  factory.BuildGetter,
 		PodManager:   client,
 	}
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			retryFunc("BuildPod", nil),
-			kutil.NewTokenBucketRateLimiter(1, 10)),
+			controller.DefaultBackoff),
 		Handle: func(obj interface{}) error {
 			pod := obj.(*kapi.Pod)
 			return buildPodController.HandlePod(pod)
@@ -239,15 +293,16 @@ func (factory *BuildPodControllerFactory) CreateDeleteController() controller.Ru
 	buildPodDeleteController := &buildcontroller.BuildPodDeleteController{
 		BuildStore:   factory.buildStore,
 		BuildUpdater: factory.BuildUpdater,
+		BuildGetter:  factory.BuildGetter,
 	}
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			controller.RetryNever,
-			kutil.NewTokenBucketRateLimiter(1, 10)),
+			controller.DefaultBackoff),
 		Handle: func(obj interface{}) error {
 			deltas := obj.(cache.Deltas)
 			for _, delta := range deltas {
@@ -273,26 +328,27 @@ type ImageChangeControllerFactory struct {
 // image is available
 func (factory *ImageChangeControllerFactory) Create() controller.RunnableController {
 	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
-	cache.NewReflector(&imageStreamLW{factory.Client}, &imageapi.ImageStream{}, queue, 2*time.Minute).RunUntil(factory.Stop)
+	cache.NewReflector(oscache.NewResumableListWatch(&imageStreamLW{factory.Client}, maxListAge), &imageapi.ImageStream{}, queue, 2*time.Minute).RunUntil(factory.Stop)
 
 	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
-	cache.NewReflector(&buildConfigLW{client: factory.Client}, &buildapi.BuildConfig{}, store, 2*time.Minute).RunUntil(factory.Stop)
+	cache.NewReflector(oscache.NewResumableListWatch(&buildConfigLW{client: factory.Client}, maxListAge), &buildapi.BuildConfig{}, store, 2*time.Minute).RunUntil(factory.Stop)
 
 	imageChangeController := &buildcontroller.ImageChangeController{
 		BuildConfigStore:        store,
 		BuildConfigInstantiator: factory.BuildConfigInstantiator,
+		BuildConfigUpdater:      buildclient.NewOSClientBuildConfigClient(factory.Client),
 	}
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			retryFunc("ImageStream update", func(err error) bool {
 				_, isFatal := err.(buildcontroller.ImageChangeControllerFatalError)
 				return isFatal
 			}),
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			imageRepo := obj.(*imageapi.ImageStream)
@@ -311,7 +367,7 @@ type BuildConfigControllerFactory struct {
 // Create creates a new ConfigChangeController which is used to trigger builds on creation
 func (factory *BuildConfigControllerFactory) Create() controller.RunnableController {
 	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
-	cache.NewReflector(&buildConfigLW{client: factory.Client}, &buildapi.BuildConfig{}, queue, 2*time.Minute).RunUntil(factory.Stop)
+	cache.NewReflector(oscache.NewResumableListWatch(&buildConfigLW{client: factory.Client}, maxListAge), &buildapi.BuildConfig{}, queue, 2*time.Minute).RunUntil(factory.Stop)
 
 	bcController := &buildcontroller.BuildConfigController{
 		BuildConfigInstantiator: factory.BuildConfigInstantiator,
@@ -319,11 +375,11 @@ func (factory *BuildConfigControllerFactory) Create() controller.RunnableControl
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			retryFunc("BuildConfig", buildcontroller.IsFatal),
-			kutil.NewTokenBucketRateLimiter(1, 10)),
+			controller.DefaultBackoff),
 		Handle: func(obj interface{}) error {
 			bc := obj.(*buildapi.BuildConfig)
 			return bcController.HandleBuildConfig(bc)
@@ -331,6 +387,59 @@ func (factory *BuildConfigControllerFactory) Create() controller.RunnableControl
 	}
 }
 
+// ScheduleControllerFactory can create a ScheduleController which periodically starts the builds of
+// the BuildConfigs with a Schedule trigger.
+type ScheduleControllerFactory struct {
+	Client                  osclient.Interface
+	BuildConfigInstantiator buildclient.BuildConfigInstantiator
+	// Stop may be set to allow controllers created by this factory to be terminated.
+	Stop <-chan struct{}
+}
+
+// Create creates a new ScheduleController which is used to trigger builds on a schedule
+func (factory *ScheduleControllerFactory) Create() controller.RunnableController {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(oscache.NewResumableListWatch(&buildConfigLW{client: factory.Client}, maxListAge), &buildapi.BuildConfig{}, store, 2*time.Minute).RunUntil(factory.Stop)
+
+	buildClient := buildclient.NewOSClientBuildClient(factory.Client)
+	bcClient := buildclient.NewOSClientBuildConfigClient(factory.Client)
+	scheduleController := &buildcontroller.ScheduleController{
+		BuildConfigStore:        store,
+		BuildConfigInstantiator: factory.BuildConfigInstantiator,
+		BuildConfigGetter:       bcClient,
+		BuildConfigUpdater:      bcClient,
+		BuildLister:             buildClient,
+		BuildGetter:             buildClient,
+		BuildUpdater:            buildClient,
+		BuildDeleter:            buildClient,
+		Now:                     time.Now,
+	}
+
+	return &periodicController{
+		handle: scheduleController.HandleBuildConfigs,
+		// schedules have a resolution of one minute
+		period: time.Minute,
+		stop:   factory.Stop,
+	}
+}
+
+// periodicController is a RunnableController which calls handle every period until stop is closed
+type periodicController struct {
+	handle func()
+	period time.Duration
+	stop   <-chan struct{}
+}
+
+// Run begins calling handle asynchronously.
+func (c *periodicController) Run() {
+	go kutil.Until(c.handle, c.period, c.stop)
+}
+
+// RunUntil begins calling handle asynchronously until stopCh is closed.
+func (c *periodicController) RunUntil(stopCh <-chan struct{}) {
+	go kutil.Until(c.handle, c.period, stopCh)
+}
+
 // podEnumerator allows a cache.Poller to enumerate items in an api.PodList
 type podEnumerator struct {
 	*kapi.PodList
@@ -449,61 +558,6 @@ func (lw *buildLW) Watch(resourceVersion string) (watch.Interface, error) {
 	return lw.client.Builds(kapi.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
 }
 
-// buildDeleteLW is a ListWatcher implementation that watches for builds being deleted
-type buildDeleteLW struct {
-	ControllerClient
-	store cache.Store
-}
-
-// List returns an empty list but adds delete events to the store for all Builds that have been deleted but still have pods.
-func (lw *buildDeleteLW) List() (runtime.Object, error) {
-	glog.V(5).Info("Checking for deleted builds")
-	podList, err := listPods(lw.KubeClient)
-	if err != nil {
-		glog.V(4).Infof("Failed to find any pods due to error %v", err)
-		return nil, err
-	}
-
-	for _, pod := range podList.Items {
-		buildName := pod.Labels[buildapi.BuildLabel]
-		if len(buildName) == 0 {
-			continue
-		}
-		glog.V(5).Infof("Found build pod %s/%s", pod.Namespace, pod.Name)
-
-		build, err := lw.Client.Builds(pod.Namespace).Get(buildName)
-		if err != nil && !kerrors.IsNotFound(err) {
-			glog.V(4).Infof("Error getting build for pod %s/%s: %v", pod.Namespace, pod.Name, err)
-			return nil, err
-		}
-		if err != nil && kerrors.IsNotFound(err) {
-			build = nil
-
-		}
-		if build == nil {
-			deletedBuild := &buildapi.Build{
-				ObjectMeta: kapi.ObjectMeta{
-					Name:      buildName,
-					Namespace: pod.Namespace,
-				},
-			}
-			glog.V(4).Infof("No build found for build pod %s/%s, deleting pod", pod.Namespace, pod.Name)
-			err := lw.store.Delete(deletedBuild)
-			if err != nil {
-				glog.V(4).Infof("Error queuing delete event: %v", err)
-			}
-		} else {
-			glog.V(5).Infof("Found build %s/%s for pod %s", build.Namespace, build.Name, pod.Name)
-		}
-	}
-	return &buildapi.BuildList{}, nil
-}
-
-// Watch watches all Builds.
-func (lw *buildDeleteLW) Watch(resourceVersion string) (watch.Interface, error) {
-	return lw.Client.Builds(kapi.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
-}
-
 // buildConfigLW is a ListWatcher implementation for BuildConfigs.
 type buildConfigLW struct {
 	client osclient.Interface
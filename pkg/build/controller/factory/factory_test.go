@@ -8,6 +8,7 @@ import (
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
@@ -16,9 +17,20 @@ import (
 
 type buildUpdater struct {
 	Build *buildapi.Build
+	// Latest is returned by Get, and Update fails with a conflict until it is retrieved
+	Latest *buildapi.Build
+}
+
+func (b *buildUpdater) Get(namespace, name string) (*buildapi.Build, error) {
+	latest := b.Latest
+	b.Latest = nil
+	return latest, nil
 }
 
 func (b *buildUpdater) Update(namespace string, build *buildapi.Build) error {
+	if b.Latest != nil {
+		return kerrors.NewConflict("build", build.Name, errors.New("stale"))
+	}
 	b.Build = build
 	return nil
 }
@@ -32,7 +44,7 @@ func TestLimitedLogAndRetryFinish(t *testing.T) {
 		Count:          0,
 		StartTimestamp: unversioned.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute()-31, now.Second(), now.Nanosecond(), now.Location()),
 	}
-	if limitedLogAndRetry(updater, 30*time.Minute)(&buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseNew}}, err, retry) {
+	if limitedLogAndRetry(updater, updater, 30*time.Minute)(&buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseNew}}, err, retry) {
 		t.Error("Expected no more retries after reaching timeout!")
 	}
 	if updater.Build == nil {
@@ -49,6 +61,42 @@ func TestLimitedLogAndRetryFinish(t *testing.T) {
 	}
 }
 
+func TestLimitedLogAndRetryFinishConflict(t *testing.T) {
+	tests := []struct {
+		name          string
+		latest        buildapi.BuildStatus
+		expectedPhase buildapi.BuildPhase
+	}{
+		{
+			name:          "still running",
+			latest:        buildapi.BuildStatus{Phase: buildapi.BuildPhaseRunning},
+			expectedPhase: buildapi.BuildPhaseFailed,
+		},
+		{
+			name:          "completed meanwhile",
+			latest:        buildapi.BuildStatus{Phase: buildapi.BuildPhaseComplete},
+			expectedPhase: buildapi.BuildPhaseComplete,
+		},
+	}
+
+	now := unversioned.Now()
+	retry := controller.Retry{
+		StartTimestamp: unversioned.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute()-31, now.Second(), now.Nanosecond(), now.Location()),
+	}
+	for _, test := range tests {
+		updater := &buildUpdater{Latest: &buildapi.Build{Status: test.latest}}
+		if limitedLogAndRetry(updater, updater, 30*time.Minute)(&buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseNew}}, errors.New("funky error"), retry) {
+			t.Errorf("%s: expected no more retries after reaching timeout", test.name)
+		}
+		if updater.Build == nil {
+			t.Fatalf("%s: expected the latest build to be updated", test.name)
+		}
+		if updater.Build.Status.Phase != test.expectedPhase {
+			t.Errorf("%s: expected status %s, got %s", test.name, test.expectedPhase, updater.Build.Status.Phase)
+		}
+	}
+}
+
 func TestLimitedLogAndRetryProcessing(t *testing.T) {
 	updater := &buildUpdater{}
 	err := errors.New("funky error")
@@ -58,7 +106,7 @@ func TestLimitedLogAndRetryProcessing(t *testing.T) {
 		Count:          0,
 		StartTimestamp: unversioned.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute()-10, now.Second(), now.Nanosecond(), now.Location()),
 	}
-	if !limitedLogAndRetry(updater, 30*time.Minute)(&buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseNew}}, err, retry) {
+	if !limitedLogAndRetry(updater, updater, 30*time.Minute)(&buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseNew}}, err, retry) {
 		t.Error("Expected more retries!")
 	}
 	if updater.Build != nil {
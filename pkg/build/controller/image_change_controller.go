@@ -29,10 +29,12 @@ func (e ImageChangeControllerFatalError) Error() string {
 
 // ImageChangeController watches for changes to ImageRepositories and triggers
 // builds when a new version of a tag referenced by a BuildConfig
-// is available.
+// is available. The new image is only recorded on the triggers of paused BuildConfigs, and built
+// once they are resumed.
 type ImageChangeController struct {
 	BuildConfigStore        cache.Store
 	BuildConfigInstantiator buildclient.BuildConfigInstantiator
+	BuildConfigUpdater      buildclient.BuildConfigUpdater
 }
 
 // getImageStreamNameFromReference strips off the :tag or @id suffix
@@ -61,11 +63,14 @@ func (c *ImageChangeController) HandleImageRepo(repo *imageapi.ImageStream) erro
 			from           *kapi.ObjectReference
 			shouldBuild    = false
 			triggeredImage = ""
+			triggerIndex   = 0
 		)
 		// For every ImageChange trigger find the latest tagged image from the image repository and
 		// invoke a build using that image id. A new build is triggered only if the latest tagged image id or pull spec
-		// differs from the last triggered build recorded on the build config for that trigger
-		for _, trigger := range config.Spec.Triggers {
+		// differs from the last triggered build recorded on the build config for that trigger, or if it was
+		// recorded while the build config was paused and not built yet
+		pending := buildutil.IsImageChangePending(config) && !buildutil.IsPaused(config)
+		for i, trigger := range config.Spec.Triggers {
 			if trigger.Type != buildapi.ImageChangeBuildTriggerType {
 				continue
 			}
@@ -109,8 +114,9 @@ func (c *ImageChangeController) HandleImageRepo(repo *imageapi.ImageStream) erro
 			last := trigger.ImageChange.LastTriggeredImageID
 			next := latest.DockerImageReference
 
-			if len(last) == 0 || (len(next) > 0 && next != last) {
+			if len(last) == 0 || (len(next) > 0 && next != last) || (pending && len(next) > 0) {
 				triggeredImage = next
+				triggerIndex = i
 				shouldBuild = true
 				// it doesn't really make sense to have multiple image change triggers any more,
 				// so just exit the loop now
@@ -118,6 +124,16 @@ func (c *ImageChangeController) HandleImageRepo(repo *imageapi.ImageStream) erro
 			}
 		}
 
+		if shouldBuild && buildutil.IsPaused(config) {
+			// keep the trigger up to date so that the latest image is built once the config is resumed
+			glog.V(4).Infof("Recording image %s for paused BuildConfig %s/%s", triggeredImage, config.Namespace, config.Name)
+			if err := c.recordTriggeredImage(config, triggerIndex, triggeredImage); err != nil {
+				util.HandleError(fmt.Errorf("error recording the image triggering paused BuildConfig %s/%s: %v", config.Namespace, config.Name, err))
+				hasError = true
+			}
+			continue
+		}
+
 		if shouldBuild {
 			glog.V(4).Infof("Running build for BuildConfig %s/%s", config.Namespace, config.Name)
 			// instantiate new build
@@ -148,3 +164,19 @@ func (c *ImageChangeController) HandleImageRepo(repo *imageapi.ImageStream) erro
 	}
 	return nil
 }
+
+// recordTriggeredImage sets the last triggered image of the index-th trigger of a copy of config, marks the image as
+// pending until the config is resumed and updates it
+func (c *ImageChangeController) recordTriggeredImage(config *buildapi.BuildConfig, index int, image string) error {
+	obj, err := kapi.Scheme.Copy(config)
+	if err != nil {
+		return err
+	}
+	configCopy := obj.(*buildapi.BuildConfig)
+	configCopy.Spec.Triggers[index].ImageChange.LastTriggeredImageID = image
+	if configCopy.Annotations == nil {
+		configCopy.Annotations = map[string]string{}
+	}
+	configCopy.Annotations[buildapi.BuildConfigImageChangePendingAnnotation] = "true"
+	return c.BuildConfigUpdater.Update(configCopy)
+}
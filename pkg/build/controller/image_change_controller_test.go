@@ -13,6 +13,7 @@ import (
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildtest "github.com/openshift/origin/pkg/build/controller/test"
 	buildgenerator "github.com/openshift/origin/pkg/build/generator"
+	buildutil "github.com/openshift/origin/pkg/build/util"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
@@ -45,6 +46,94 @@ func TestNewImageID(t *testing.T) {
 	}
 }
 
+func TestNewImageIDPausedConfig(t *testing.T) {
+	// paused configuration, the new image should be recorded without triggering a build.
+	buildcfg := mockBuildConfig("registry.com/namespace/imagename", "registry.com/namespace/imagename", "testImageStream", "testTag")
+	buildcfg.Annotations = map[string]string{buildapi.BuildConfigPausedAnnotation: "true"}
+	imageStream := mockImageStream("testImageStream", "registry.com/namespace/imagename", map[string]string{"testTag": "newImageID123"})
+	image := mockImage("testImage@id", "registry.com/namespace/imagename:newImageID123")
+	controller := mockImageChangeController(buildcfg, imageStream, image)
+	bcInstantiator := controller.BuildConfigInstantiator.(*buildConfigInstantiator)
+	bcUpdater := bcInstantiator.buildConfigUpdater
+
+	err := controller.HandleImageRepo(imageStream)
+	if err != nil {
+		t.Fatalf("Unexpected error %v from HandleImageRepo", err)
+	}
+
+	if len(bcInstantiator.name) != 0 {
+		t.Error("Expected no build generation for a paused build config")
+	}
+	if bcUpdater.buildcfg == nil {
+		t.Fatalf("Expected the new image to be recorded on the paused build config")
+	}
+	if actual, expected := bcUpdater.buildcfg.Spec.Triggers[0].ImageChange.LastTriggeredImageID, "registry.com/namespace/imagename:newImageID123"; actual != expected {
+		t.Errorf("Expected last triggered image %q, got %q", expected, actual)
+	}
+	if !buildutil.IsImageChangePending(bcUpdater.buildcfg) {
+		t.Errorf("Expected the recorded image to be pending, got annotations %v", bcUpdater.buildcfg.Annotations)
+	}
+	if len(buildcfg.Spec.Triggers[0].ImageChange.LastTriggeredImageID) != 0 {
+		t.Errorf("Expected the cached build config to be left untouched")
+	}
+}
+
+func TestPendingImageIDResumedConfig(t *testing.T) {
+	// resumed configuration, the image recorded while it was paused should trigger a build.
+	buildcfg := mockBuildConfig("registry.com/namespace/imagename", "registry.com/namespace/imagename", "testImageStream", "testTag")
+	buildcfg.Annotations = map[string]string{buildapi.BuildConfigImageChangePendingAnnotation: "true"}
+	buildcfg.Spec.Triggers[0].ImageChange.LastTriggeredImageID = "registry.com/namespace/imagename:newImageID123"
+	imageStream := mockImageStream("testImageStream", "registry.com/namespace/imagename", map[string]string{"testTag": "newImageID123"})
+	image := mockImage("testImage@id", "registry.com/namespace/imagename:newImageID123")
+	controller := mockImageChangeController(buildcfg, imageStream, image)
+	bcInstantiator := controller.BuildConfigInstantiator.(*buildConfigInstantiator)
+	bcUpdater := bcInstantiator.buildConfigUpdater
+
+	err := controller.HandleImageRepo(imageStream)
+	if err != nil {
+		t.Fatalf("Unexpected error %v from HandleImageRepo", err)
+	}
+
+	if len(bcInstantiator.name) == 0 || bcInstantiator.newBuild == nil {
+		t.Fatalf("Expected build generation for the pending image")
+	}
+	if actual, expected := bcInstantiator.newBuild.Spec.Strategy.DockerStrategy.From.Name, "registry.com/namespace/imagename:newImageID123"; actual != expected {
+		t.Errorf("Expected the pending image %s to be built, got %s", expected, actual)
+	}
+	if bcUpdater.buildcfg == nil {
+		t.Fatalf("Expected buildConfig update when the pending image was built")
+	}
+	if buildutil.IsImageChangePending(bcUpdater.buildcfg) {
+		t.Errorf("Expected the built image not to be pending anymore")
+	}
+}
+
+func TestPendingImageIDPausedConfig(t *testing.T) {
+	// still paused configuration, the recorded image should neither be built nor recorded again.
+	buildcfg := mockBuildConfig("registry.com/namespace/imagename", "registry.com/namespace/imagename", "testImageStream", "testTag")
+	buildcfg.Annotations = map[string]string{
+		buildapi.BuildConfigPausedAnnotation:             "true",
+		buildapi.BuildConfigImageChangePendingAnnotation: "true",
+	}
+	buildcfg.Spec.Triggers[0].ImageChange.LastTriggeredImageID = "registry.com/namespace/imagename:newImageID123"
+	imageStream := mockImageStream("testImageStream", "registry.com/namespace/imagename", map[string]string{"testTag": "newImageID123"})
+	image := mockImage("testImage@id", "registry.com/namespace/imagename:newImageID123")
+	controller := mockImageChangeController(buildcfg, imageStream, image)
+	bcInstantiator := controller.BuildConfigInstantiator.(*buildConfigInstantiator)
+
+	err := controller.HandleImageRepo(imageStream)
+	if err != nil {
+		t.Fatalf("Unexpected error %v from HandleImageRepo", err)
+	}
+
+	if len(bcInstantiator.name) != 0 {
+		t.Error("Expected no build generation for a paused build config")
+	}
+	if bcInstantiator.buildConfigUpdater.buildcfg != nil {
+		t.Errorf("Expected no update of the paused build config, got %#v", bcInstantiator.buildConfigUpdater.buildcfg)
+	}
+}
+
 func TestNewImageIDDefaultTag(t *testing.T) {
 	// valid configuration using default tag, new build should be triggered.
 	buildcfg := mockBuildConfig("registry.com/namespace/imagename", "registry.com/namespace/imagename", "testImageStream", "")
@@ -412,8 +501,10 @@ func mockBuildConfigInstantiator(buildcfg *buildapi.BuildConfig, imageStream *im
 }
 
 func mockImageChangeController(buildcfg *buildapi.BuildConfig, imageStream *imageapi.ImageStream, image *imageapi.Image) *ImageChangeController {
+	instantiator := mockBuildConfigInstantiator(buildcfg, imageStream, image)
 	return &ImageChangeController{
 		BuildConfigStore:        buildtest.NewFakeBuildConfigStore(buildcfg),
-		BuildConfigInstantiator: mockBuildConfigInstantiator(buildcfg, imageStream, image),
+		BuildConfigInstantiator: instantiator,
+		BuildConfigUpdater:      instantiator.buildConfigUpdater,
 	}
 }
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/util"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildclient "github.com/openshift/origin/pkg/build/client"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/util/cron"
+)
+
+// ScheduleController starts the builds of the BuildConfigs with a Schedule trigger when they are due,
+// and deletes their oldest completed scheduled builds. The schedule time is only recorded on the triggers
+// of paused BuildConfigs.
+type ScheduleController struct {
+	BuildConfigStore        cache.Store
+	BuildConfigInstantiator buildclient.BuildConfigInstantiator
+	BuildConfigGetter       buildclient.BuildConfigGetter
+	BuildConfigUpdater      buildclient.BuildConfigUpdater
+	BuildLister             buildclient.BuildLister
+	BuildGetter             buildclient.BuildGetter
+	BuildUpdater            buildclient.BuildUpdater
+	BuildDeleter            buildclient.BuildDeleter
+	// Now returns the current time
+	Now func() time.Time
+}
+
+// HandleBuildConfigs processes all the BuildConfigs with a Schedule trigger.
+func (c *ScheduleController) HandleBuildConfigs() {
+	for _, obj := range c.BuildConfigStore.List() {
+		bc := obj.(*buildapi.BuildConfig)
+		if err := c.HandleBuildConfig(bc); err != nil {
+			util.HandleError(err)
+		}
+	}
+}
+
+// HandleBuildConfig starts a build of bc if its Schedule trigger is due, and prunes its completed scheduled builds.
+func (c *ScheduleController) HandleBuildConfig(bc *buildapi.BuildConfig) error {
+	trigger := buildutil.GetScheduleTrigger(bc)
+	if trigger == nil {
+		return nil
+	}
+	schedule, err := cron.Parse(trigger.Cron)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q of BuildConfig %s/%s: %v", trigger.Cron, bc.Namespace, bc.Name, err)
+	}
+
+	builds, err := c.BuildLister.List(bc.Namespace, buildutil.BuildConfigSelector(bc.Name), fields.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing the builds of BuildConfig %s/%s: %v", bc.Namespace, bc.Name, err)
+	}
+	if err := c.pruneBuilds(bc, trigger.HistoryLimit, builds.Items); err != nil {
+		return err
+	}
+
+	since := bc.CreationTimestamp.Time
+	if trigger.LastScheduleTime != nil {
+		since = trigger.LastScheduleTime.Time
+	}
+	scheduleTime := schedule.Last(since, c.Now())
+	if scheduleTime.IsZero() {
+		return nil
+	}
+
+	if buildutil.IsPaused(bc) {
+		// keep the trigger up to date so that the missed builds are not started once the config is resumed
+		glog.V(4).Infof("Recording schedule time %v for paused BuildConfig %s/%s", scheduleTime, bc.Namespace, bc.Name)
+		return c.recordScheduleTime(bc, scheduleTime)
+	}
+
+	active := []*buildapi.Build{}
+	for i := range builds.Items {
+		if !buildutil.IsBuildComplete(&builds.Items[i]) {
+			active = append(active, &builds.Items[i])
+		}
+	}
+	if len(active) > 0 {
+		switch trigger.ConcurrencyPolicy {
+		case buildapi.ScheduleConcurrencyReplace:
+			for _, build := range active {
+				glog.V(4).Infof("Cancelling build %s/%s to replace it with a scheduled build", build.Namespace, build.Name)
+				if err := buildclient.UpdateBuildWithRetries(c.BuildGetter, c.BuildUpdater, build, func(b *buildapi.Build) { b.Status.Cancelled = true }); err != nil {
+					return fmt.Errorf("error cancelling build %s/%s: %v", build.Namespace, build.Name, err)
+				}
+			}
+		default:
+			glog.V(4).Infof("Skipping the build scheduled at %v for BuildConfig %s/%s because build %s is running", scheduleTime, bc.Namespace, bc.Name, active[0].Name)
+			return c.recordScheduleTime(bc, scheduleTime)
+		}
+	}
+
+	glog.V(4).Infof("Running build scheduled at %v for BuildConfig %s/%s", scheduleTime, bc.Namespace, bc.Name)
+	request := &buildapi.BuildRequest{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:      bc.Name,
+			Namespace: bc.Namespace,
+		},
+		ScheduleTime: &unversioned.Time{Time: scheduleTime},
+	}
+	if _, err := c.BuildConfigInstantiator.Instantiate(bc.Namespace, request); err != nil {
+		if kerrors.IsConflict(err) {
+			return fmt.Errorf("unable to instantiate Build for BuildConfig %s/%s due to a conflicting update: %v", bc.Namespace, bc.Name, err)
+		}
+		return fmt.Errorf("error instantiating Build from BuildConfig %s/%s: %v", bc.Namespace, bc.Name, err)
+	}
+	return nil
+}
+
+// recordScheduleTime sets the last schedule time of the Schedule trigger of a copy of bc and updates it, retrying
+// with the latest version of bc on conflicts
+func (c *ScheduleController) recordScheduleTime(bc *buildapi.BuildConfig, scheduleTime time.Time) error {
+	obj, err := kapi.Scheme.Copy(bc)
+	if err != nil {
+		return err
+	}
+	current := obj.(*buildapi.BuildConfig)
+	err = osclient.UpdateWithRetries(
+		func() (err error) {
+			current, err = c.BuildConfigGetter.Get(bc.Namespace, bc.Name)
+			return
+		},
+		func() error {
+			trigger := buildutil.GetScheduleTrigger(current)
+			// the trigger may have been removed, or a later schedule time recorded, since bc was cached
+			if trigger == nil || (trigger.LastScheduleTime != nil && !scheduleTime.After(trigger.LastScheduleTime.Time)) {
+				return nil
+			}
+			trigger.LastScheduleTime = &unversioned.Time{Time: scheduleTime}
+			return c.BuildConfigUpdater.Update(current)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error recording the schedule time of BuildConfig %s/%s: %v", bc.Namespace, bc.Name, err)
+	}
+	return nil
+}
+
+// pruneBuilds deletes the completed scheduled builds of bc beyond the most recent historyLimit ones
+func (c *ScheduleController) pruneBuilds(bc *buildapi.BuildConfig, historyLimit int, builds []buildapi.Build) error {
+	if historyLimit == 0 {
+		return nil
+	}
+	completed := []*buildapi.Build{}
+	for i := range builds {
+		build := &builds[i]
+		if _, scheduled := build.Annotations[buildapi.BuildScheduleTimeAnnotation]; scheduled && buildutil.IsBuildComplete(build) {
+			completed = append(completed, build)
+		}
+	}
+	if len(completed) <= historyLimit {
+		return nil
+	}
+	sort.Sort(sort.Reverse(buildapi.BuildPtrSliceByCreationTimestamp(completed)))
+	for _, build := range completed[historyLimit:] {
+		glog.V(4).Infof("Deleting scheduled build %s/%s of BuildConfig %s/%s", build.Namespace, build.Name, bc.Namespace, bc.Name)
+		if err := c.BuildDeleter.Delete(build.Namespace, build.Name); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting build %s/%s: %v", build.Namespace, build.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildtest "github.com/openshift/origin/pkg/build/controller/test"
+	"github.com/openshift/origin/pkg/client/testclient"
+)
+
+type scheduleBuildConfigClient struct {
+	// latest is returned by Get, and conflicts, if set, fails the updates with a conflict
+	latest    *buildapi.BuildConfig
+	conflicts func(kind, name string) error
+	updated   *buildapi.BuildConfig
+	request   *buildapi.BuildRequest
+}
+
+func (c *scheduleBuildConfigClient) Get(namespace, name string) (*buildapi.BuildConfig, error) {
+	obj, err := kapi.Scheme.Copy(c.latest)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*buildapi.BuildConfig), nil
+}
+
+func (c *scheduleBuildConfigClient) Update(bc *buildapi.BuildConfig) error {
+	if c.conflicts != nil {
+		if err := c.conflicts("BuildConfig", bc.Name); err != nil {
+			return err
+		}
+	}
+	c.updated = bc
+	return nil
+}
+
+func (c *scheduleBuildConfigClient) Instantiate(namespace string, request *buildapi.BuildRequest) (*buildapi.Build, error) {
+	c.request = request
+	return &buildapi.Build{}, nil
+}
+
+type scheduleBuildClient struct {
+	builds    []buildapi.Build
+	cancelled []string
+	deleted   []string
+}
+
+func (c *scheduleBuildClient) List(namespace string, label labels.Selector, field fields.Selector) (*buildapi.BuildList, error) {
+	list := &buildapi.BuildList{}
+	for _, build := range c.builds {
+		if label.Matches(labels.Set(build.Labels)) {
+			list.Items = append(list.Items, build)
+		}
+	}
+	return list, nil
+}
+
+func (c *scheduleBuildClient) Get(namespace, name string) (*buildapi.Build, error) {
+	for i := range c.builds {
+		if c.builds[i].Name == name {
+			build := c.builds[i]
+			return &build, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *scheduleBuildClient) Update(namespace string, build *buildapi.Build) error {
+	if build.Status.Cancelled {
+		c.cancelled = append(c.cancelled, build.Name)
+	}
+	return nil
+}
+
+func (c *scheduleBuildClient) Delete(namespace, name string) error {
+	c.deleted = append(c.deleted, name)
+	return nil
+}
+
+var scheduleNow = time.Date(2016, time.January, 2, 2, 30, 0, 0, time.UTC)
+
+func scheduledBuildConfig(policy buildapi.ScheduleConcurrencyPolicy, historyLimit int) *buildapi.BuildConfig {
+	lastScheduleTime := unversioned.NewTime(time.Date(2016, time.January, 1, 2, 0, 0, 0, time.UTC))
+	bc := baseBuildConfig()
+	bc.CreationTimestamp = unversioned.NewTime(time.Date(2015, time.December, 1, 0, 0, 0, 0, time.UTC))
+	bc.Spec.Triggers = []buildapi.BuildTriggerPolicy{
+		{
+			Type: buildapi.ScheduleBuildTriggerType,
+			Schedule: &buildapi.ScheduleTrigger{
+				Cron:              "0 2 * * *",
+				ConcurrencyPolicy: policy,
+				HistoryLimit:      historyLimit,
+				LastScheduleTime:  &lastScheduleTime,
+			},
+		},
+	}
+	return bc
+}
+
+func scheduledBuild(name string, phase buildapi.BuildPhase, created time.Time) buildapi.Build {
+	return buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:              name,
+			Namespace:         kapi.NamespaceDefault,
+			Labels:            map[string]string{buildapi.BuildConfigLabel: "testBuildConfig"},
+			Annotations:       map[string]string{buildapi.BuildScheduleTimeAnnotation: created.Format(time.RFC3339)},
+			CreationTimestamp: unversioned.NewTime(created),
+		},
+		Status: buildapi.BuildStatus{Phase: phase},
+	}
+}
+
+func mockScheduleController(bc *buildapi.BuildConfig, builds ...buildapi.Build) (*ScheduleController, *scheduleBuildConfigClient, *scheduleBuildClient) {
+	bcClient := &scheduleBuildConfigClient{latest: bc}
+	buildClient := &scheduleBuildClient{builds: builds}
+	return &ScheduleController{
+		BuildConfigStore:        buildtest.NewFakeBuildConfigStore(bc),
+		BuildConfigInstantiator: bcClient,
+		BuildConfigGetter:       bcClient,
+		BuildConfigUpdater:      bcClient,
+		BuildLister:             buildClient,
+		BuildGetter:             buildClient,
+		BuildUpdater:            buildClient,
+		BuildDeleter:            buildClient,
+		Now:                     func() time.Time { return scheduleNow },
+	}, bcClient, buildClient
+}
+
+func TestScheduleDue(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 0)
+	controller, bcClient, _ := mockScheduleController(bc)
+
+	controller.HandleBuildConfigs()
+
+	if bcClient.request == nil {
+		t.Fatalf("Expected a scheduled build to be instantiated")
+	}
+	if expected := time.Date(2016, time.January, 2, 2, 0, 0, 0, time.UTC); bcClient.request.ScheduleTime == nil || !bcClient.request.ScheduleTime.Equal(unversioned.NewTime(expected)) {
+		t.Errorf("Expected the schedule time %v, got %v", expected, bcClient.request.ScheduleTime)
+	}
+	if bcClient.updated != nil {
+		t.Errorf("Expected the generator to record the schedule time, got an update of the build config")
+	}
+}
+
+func TestScheduleNotDue(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 0)
+	lastScheduleTime := unversioned.NewTime(time.Date(2016, time.January, 2, 2, 0, 0, 0, time.UTC))
+	bc.Spec.Triggers[0].Schedule.LastScheduleTime = &lastScheduleTime
+	controller, bcClient, _ := mockScheduleController(bc)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if bcClient.request != nil || bcClient.updated != nil {
+		t.Errorf("Expected no build before the next schedule time, got %v", bcClient.request)
+	}
+}
+
+func TestScheduleNoTrigger(t *testing.T) {
+	bc := baseBuildConfig()
+	controller, bcClient, _ := mockScheduleController(bc)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if bcClient.request != nil {
+		t.Errorf("Expected no build for a build config without a Schedule trigger")
+	}
+}
+
+func TestSchedulePausedConfig(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 0)
+	bc.Annotations = map[string]string{buildapi.BuildConfigPausedAnnotation: "true"}
+	controller, bcClient, _ := mockScheduleController(bc)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if bcClient.request != nil {
+		t.Errorf("Expected no build for a paused build config")
+	}
+	if bcClient.updated == nil {
+		t.Fatalf("Expected the schedule time to be recorded on the paused build config")
+	}
+	if actual := bcClient.updated.Spec.Triggers[0].Schedule.LastScheduleTime; !actual.Equal(unversioned.NewTime(time.Date(2016, time.January, 2, 2, 0, 0, 0, time.UTC))) {
+		t.Errorf("Unexpected last schedule time %v", actual)
+	}
+	if bc.Spec.Triggers[0].Schedule.LastScheduleTime.Equal(*bcClient.updated.Spec.Triggers[0].Schedule.LastScheduleTime) {
+		t.Errorf("Expected the cached build config to be left untouched")
+	}
+}
+
+func TestScheduleRecordConflict(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 0)
+	bc.Annotations = map[string]string{buildapi.BuildConfigPausedAnnotation: "true"}
+	controller, bcClient, _ := mockScheduleController(bc)
+	obj, err := kapi.Scheme.Copy(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := obj.(*buildapi.BuildConfig)
+	latest.ResourceVersion = "2"
+	bcClient.latest = latest
+	bcClient.conflicts = testclient.NewConflicts(1)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if bcClient.updated == nil || bcClient.updated.ResourceVersion != "2" {
+		t.Fatalf("Expected the schedule time to be recorded on the latest build config, got %v", bcClient.updated)
+	}
+	if actual := bcClient.updated.Spec.Triggers[0].Schedule.LastScheduleTime; !actual.Equal(unversioned.NewTime(time.Date(2016, time.January, 2, 2, 0, 0, 0, time.UTC))) {
+		t.Errorf("Unexpected last schedule time %v", actual)
+	}
+}
+
+func TestScheduleRecordConflictLaterTime(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 0)
+	bc.Annotations = map[string]string{buildapi.BuildConfigPausedAnnotation: "true"}
+	controller, bcClient, _ := mockScheduleController(bc)
+	obj, err := kapi.Scheme.Copy(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := obj.(*buildapi.BuildConfig)
+	lastScheduleTime := unversioned.NewTime(scheduleNow)
+	latest.Spec.Triggers[0].Schedule.LastScheduleTime = &lastScheduleTime
+	bcClient.latest = latest
+	bcClient.conflicts = testclient.NewConflicts(1)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if bcClient.updated != nil {
+		t.Errorf("Expected no update when a later schedule time was recorded, got %v", bcClient.updated)
+	}
+}
+
+func TestScheduleForbidRunningBuild(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 0)
+	controller, bcClient, buildClient := mockScheduleController(bc, scheduledBuild("testBuildConfig-1", buildapi.BuildPhaseRunning, scheduleNow.Add(-24*time.Hour)))
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if bcClient.request != nil {
+		t.Errorf("Expected the scheduled build to be skipped while a build is running")
+	}
+	if len(buildClient.cancelled) != 0 {
+		t.Errorf("Expected no build to be cancelled, got %v", buildClient.cancelled)
+	}
+	if bcClient.updated == nil {
+		t.Fatalf("Expected the skipped schedule time to be recorded")
+	}
+}
+
+func TestScheduleReplaceRunningBuild(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyReplace, 0)
+	controller, bcClient, buildClient := mockScheduleController(bc,
+		scheduledBuild("testBuildConfig-1", buildapi.BuildPhaseComplete, scheduleNow.Add(-48*time.Hour)),
+		scheduledBuild("testBuildConfig-2", buildapi.BuildPhaseRunning, scheduleNow.Add(-24*time.Hour)),
+	)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if expected := []string{"testBuildConfig-2"}; !reflect.DeepEqual(buildClient.cancelled, expected) {
+		t.Errorf("Expected the running builds %v to be cancelled, got %v", expected, buildClient.cancelled)
+	}
+	if bcClient.request == nil {
+		t.Errorf("Expected a scheduled build to replace the running build")
+	}
+}
+
+func TestSchedulePruneBuilds(t *testing.T) {
+	bc := scheduledBuildConfig(buildapi.ScheduleConcurrencyForbid, 2)
+	unscheduled := scheduledBuild("testBuildConfig-1", buildapi.BuildPhaseComplete, scheduleNow.Add(-96*time.Hour))
+	delete(unscheduled.Annotations, buildapi.BuildScheduleTimeAnnotation)
+	controller, _, buildClient := mockScheduleController(bc,
+		unscheduled,
+		scheduledBuild("testBuildConfig-2", buildapi.BuildPhaseFailed, scheduleNow.Add(-72*time.Hour)),
+		scheduledBuild("testBuildConfig-3", buildapi.BuildPhaseComplete, scheduleNow.Add(-48*time.Hour)),
+		scheduledBuild("testBuildConfig-4", buildapi.BuildPhaseComplete, scheduleNow.Add(-24*time.Hour)),
+		scheduledBuild("testBuildConfig-5", buildapi.BuildPhaseComplete, scheduleNow.Add(-1*time.Hour)),
+	)
+
+	if err := controller.HandleBuildConfig(bc); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if expected := []string{"testBuildConfig-3", "testBuildConfig-2"}; !reflect.DeepEqual(buildClient.deleted, expected) {
+		t.Errorf("Expected the oldest scheduled builds %v to be deleted, got %v", expected, buildClient.deleted)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -22,6 +23,9 @@ import (
 	"github.com/openshift/origin/pkg/util/namer"
 )
 
+// maxBuildCloneChainLength is the number of builds a cloned build records in its clone chain
+const maxBuildCloneChainLength = 10
+
 // GeneratorFatalError represents a fatal error while generating a build.
 // An operation that fails because of a fatal error should not be retried.
 type GeneratorFatalError struct {
@@ -175,6 +179,9 @@ func describeBuildRequest(request *buildapi.BuildRequest) string {
 	if request.LastVersion != nil {
 		desc += fmt.Sprintf(", LastVersion: %d", *request.LastVersion)
 	}
+	if request.ScheduleTime != nil {
+		desc += fmt.Sprintf(", ScheduleTime: %v", request.ScheduleTime)
+	}
 	return desc
 }
 
@@ -229,6 +236,10 @@ func (g *BuildGenerator) Instantiate(ctx kapi.Context, request *buildapi.BuildRe
 		return nil, err
 	}
 
+	if err := g.updateScheduleTrigger(bc, request.ScheduleTime); err != nil {
+		return nil, err
+	}
+
 	newBuild, err := g.generateBuildFromConfig(ctx, bc, request.Revision, request.Binary)
 	if err != nil {
 		return nil, err
@@ -237,9 +248,12 @@ func (g *BuildGenerator) Instantiate(ctx kapi.Context, request *buildapi.BuildRe
 	if len(request.Env) > 0 {
 		updateBuildEnv(&newBuild.Spec.Strategy, request.Env)
 	}
+	if request.ScheduleTime != nil {
+		newBuild.Annotations[buildapi.BuildScheduleTimeAnnotation] = request.ScheduleTime.UTC().Format(time.RFC3339)
+	}
 	glog.V(4).Infof("Build %s/%s has been generated from %s/%s BuildConfig", newBuild.Namespace, newBuild.ObjectMeta.Name, bc.Namespace, bc.ObjectMeta.Name)
 
-	// need to update the BuildConfig because LastVersion and possibly LastTriggeredImageID changed
+	// need to update the BuildConfig because LastVersion and possibly LastTriggeredImageID or LastScheduleTime changed
 	if err := g.Client.UpdateBuildConfig(ctx, bc); err != nil {
 		glog.V(4).Infof("Failed to update BuildConfig %s/%s so no Build will be created", bc.Namespace, bc.Name)
 		return nil, err
@@ -264,13 +278,16 @@ func (g *BuildGenerator) checkLastVersion(bc *buildapi.BuildConfig, lastVersion
 }
 
 // updateImageTriggers sets the LastTriggeredImageID on all the ImageChangeTriggers on the BuildConfig and
-// updates the From reference of the strategy if the strategy uses an ImageStream or ImageStreamTag reference
+// updates the From reference of the strategy if the strategy uses an ImageStream or ImageStreamTag reference.
+// The images recorded while the BuildConfig was paused are built, so they are no longer pending.
 func (g *BuildGenerator) updateImageTriggers(ctx kapi.Context, bc *buildapi.BuildConfig, from, triggeredBy *kapi.ObjectReference) error {
 	var requestTrigger *buildapi.ImageChangeTrigger
 	if from != nil {
 		requestTrigger = findImageChangeTrigger(bc, from)
 	}
-	if requestTrigger != nil && triggeredBy != nil && requestTrigger.LastTriggeredImageID == triggeredBy.Name {
+	pending := buildutil.IsImageChangePending(bc)
+	delete(bc.Annotations, buildapi.BuildConfigImageChangePendingAnnotation)
+	if requestTrigger != nil && triggeredBy != nil && requestTrigger.LastTriggeredImageID == triggeredBy.Name && !pending {
 		glog.V(2).Infof("Aborting imageid triggered build for BuildConfig %s/%s with imageid %s because the BuildConfig already matches this imageid", bc.Namespace, bc.Name, triggeredBy.Name)
 		return fmt.Errorf("build config %s/%s has already instantiated a build for imageid %s", bc.Namespace, bc.Name, triggeredBy.Name)
 	}
@@ -307,6 +324,24 @@ func (g *BuildGenerator) updateImageTriggers(ctx kapi.Context, bc *buildapi.Buil
 	return nil
 }
 
+// updateScheduleTrigger sets the LastScheduleTime of the Schedule trigger of the BuildConfig to the time of a
+// scheduled build, and returns an error if a build was already instantiated for that time
+func (g *BuildGenerator) updateScheduleTrigger(bc *buildapi.BuildConfig, scheduleTime *unversioned.Time) error {
+	if scheduleTime == nil {
+		return nil
+	}
+	trigger := buildutil.GetScheduleTrigger(bc)
+	if trigger == nil {
+		return &GeneratorFatalError{fmt.Sprintf("can't instantiate a scheduled build from BuildConfig %s/%s: BuildConfig has no Schedule trigger", bc.Namespace, bc.Name)}
+	}
+	if trigger.LastScheduleTime != nil && !scheduleTime.After(trigger.LastScheduleTime.Time) {
+		glog.V(2).Infof("Aborting scheduled build for BuildConfig %s/%s at %v because the BuildConfig was last scheduled at %v", bc.Namespace, bc.Name, scheduleTime, trigger.LastScheduleTime)
+		return fmt.Errorf("build config %s/%s has already instantiated a build for the schedule time %v", bc.Namespace, bc.Name, scheduleTime)
+	}
+	trigger.LastScheduleTime = scheduleTime
+	return nil
+}
+
 // Clone returns clone of a Build
 func (g *BuildGenerator) Clone(ctx kapi.Context, request *buildapi.BuildRequest) (*buildapi.Build, error) {
 	glog.V(4).Infof("Generating build from build %s/%s", request.Namespace, request.Name)
@@ -328,6 +363,13 @@ func (g *BuildGenerator) Clone(ctx kapi.Context, request *buildapi.BuildRequest)
 	}
 
 	newBuild := generateBuildFromBuild(build, buildConfig)
+	// only git sources have revisions to build
+	if request.Revision != nil && newBuild.Spec.Source.Git != nil {
+		newBuild.Spec.Revision = request.Revision
+	}
+	if len(request.Env) > 0 {
+		updateBuildEnv(&newBuild.Spec.Strategy, request.Env)
+	}
 	glog.V(4).Infof("Build %s/%s has been generated from Build %s/%s", newBuild.Namespace, newBuild.ObjectMeta.Name, build.Namespace, build.ObjectMeta.Name)
 
 	// need to update the BuildConfig because LastVersion changed
@@ -659,6 +701,14 @@ func generateBuildFromBuild(build *buildapi.Build, buildConfig *buildapi.BuildCo
 		newBuild.Annotations = make(map[string]string)
 	}
 	newBuild.Annotations[buildapi.BuildCloneAnnotation] = build.Name
+	chain := []string{build.Name}
+	if previous := build.Annotations[buildapi.BuildCloneChainAnnotation]; len(previous) > 0 {
+		chain = append(strings.Split(previous, ","), build.Name)
+	}
+	if len(chain) > maxBuildCloneChainLength {
+		chain = chain[len(chain)-maxBuildCloneChainLength:]
+	}
+	newBuild.Annotations[buildapi.BuildCloneChainAnnotation] = strings.Join(chain, ",")
 	if buildConfig != nil {
 		newBuild.Annotations[buildapi.BuildNumberAnnotation] = strconv.Itoa(buildConfig.Status.LastVersion)
 	} else {
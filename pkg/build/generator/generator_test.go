@@ -6,9 +6,11 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 
 	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -340,6 +342,62 @@ func TestInstantiateWithLastVersion(t *testing.T) {
 	}
 }
 
+func TestInstantiateWithScheduleTime(t *testing.T) {
+	lastScheduleTime := unversioned.NewTime(time.Date(2016, time.January, 1, 2, 0, 0, 0, time.UTC))
+	g := mockBuildGenerator()
+	c := g.Client.(Client)
+	c.GetBuildConfigFunc = func(ctx kapi.Context, name string) (*buildapi.BuildConfig, error) {
+		bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+		bc.Spec.Triggers = []buildapi.BuildTriggerPolicy{
+			{
+				Type: buildapi.ScheduleBuildTriggerType,
+				Schedule: &buildapi.ScheduleTrigger{
+					Cron:             "0 2 * * *",
+					LastScheduleTime: &lastScheduleTime,
+				},
+			},
+		}
+		return bc, nil
+	}
+	var updatedConfig *buildapi.BuildConfig
+	c.UpdateBuildConfigFunc = func(ctx kapi.Context, buildConfig *buildapi.BuildConfig) error {
+		updatedConfig = buildConfig
+		return nil
+	}
+	var createdBuild *buildapi.Build
+	c.CreateBuildFunc = func(ctx kapi.Context, build *buildapi.Build) error {
+		createdBuild = build
+		return nil
+	}
+	g.Client = c
+
+	// Schedule time already instantiated
+	if _, err := g.Instantiate(kapi.NewDefaultContext(), &buildapi.BuildRequest{ScheduleTime: &lastScheduleTime}); err == nil {
+		t.Errorf("Expected an error and did not get one")
+	}
+
+	scheduleTime := unversioned.NewTime(lastScheduleTime.Add(24 * time.Hour))
+	if _, err := g.Instantiate(kapi.NewDefaultContext(), &buildapi.BuildRequest{ScheduleTime: &scheduleTime}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if trigger := buildutil.GetScheduleTrigger(updatedConfig); trigger.LastScheduleTime == nil || !trigger.LastScheduleTime.Equal(scheduleTime) {
+		t.Errorf("Expected the last schedule time to be %v, got %v", scheduleTime, trigger.LastScheduleTime)
+	}
+	if value := createdBuild.Annotations[buildapi.BuildScheduleTimeAnnotation]; value != "2016-01-02T02:00:00Z" {
+		t.Errorf("Expected the build to be annotated with the schedule time, got %q", value)
+	}
+
+	// BuildConfig without a Schedule trigger
+	c.GetBuildConfigFunc = func(ctx kapi.Context, name string) (*buildapi.BuildConfig, error) {
+		return mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput()), nil
+	}
+	g.Client = c
+	_, err := g.Instantiate(kapi.NewDefaultContext(), &buildapi.BuildRequest{ScheduleTime: &scheduleTime})
+	if _, ok := err.(*GeneratorFatalError); !ok {
+		t.Errorf("Expected a fatal error, got %v", err)
+	}
+}
+
 func TestFindImageTrigger(t *testing.T) {
 	defaultTrigger := &buildapi.ImageChangeTrigger{}
 	image1Trigger := &buildapi.ImageChangeTrigger{
@@ -495,6 +553,111 @@ func TestClone(t *testing.T) {
 	}
 }
 
+func TestCloneWithOverrides(t *testing.T) {
+	var created *buildapi.Build
+	generator := BuildGenerator{Client: Client{
+		CreateBuildFunc: func(ctx kapi.Context, build *buildapi.Build) error {
+			created = build
+			return nil
+		},
+		GetBuildFunc: func(ctx kapi.Context, name string) (*buildapi.Build, error) {
+			if created != nil {
+				return created, nil
+			}
+			build := mockBuild(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+			build.Namespace = kapi.NamespaceDefault
+			build.Annotations = map[string]string{buildapi.BuildCloneChainAnnotation: "test-build-0"}
+			build.Spec.Revision = &buildapi.SourceRevision{Git: &buildapi.GitSourceRevision{Commit: "1234", Message: "old"}}
+			build.Spec.Strategy.SourceStrategy.Env = []kapi.EnvVar{{Name: "DEBUG", Value: "false"}, {Name: "KEEP", Value: "me"}}
+			return build, nil
+		},
+	}}
+
+	revision := &buildapi.SourceRevision{Git: &buildapi.GitSourceRevision{Commit: "v2"}}
+	build, err := generator.Clone(kapi.NewDefaultContext(), &buildapi.BuildRequest{
+		ObjectMeta: kapi.ObjectMeta{Name: "test-build"},
+		Revision:   revision,
+		Env:        []kapi.EnvVar{{Name: "DEBUG", Value: "true"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(build.Spec.Revision, revision) {
+		t.Errorf("Expected the requested revision, got %#v", build.Spec.Revision)
+	}
+	expectedEnv := []kapi.EnvVar{{Name: "KEEP", Value: "me"}, {Name: "DEBUG", Value: "true"}}
+	if env := build.Spec.Strategy.SourceStrategy.Env; !reflect.DeepEqual(env, expectedEnv) {
+		t.Errorf("Expected env %v, got %v", expectedEnv, env)
+	}
+	if chain := build.Annotations[buildapi.BuildCloneChainAnnotation]; chain != "test-build-0,test-build" {
+		t.Errorf("Unexpected clone chain %q", chain)
+	}
+}
+
+func TestCloneRevisionOfBinaryBuild(t *testing.T) {
+	var created *buildapi.Build
+	generator := BuildGenerator{Client: Client{
+		CreateBuildFunc: func(ctx kapi.Context, build *buildapi.Build) error {
+			created = build
+			return nil
+		},
+		GetBuildFunc: func(ctx kapi.Context, name string) (*buildapi.Build, error) {
+			if created != nil {
+				return created, nil
+			}
+			build := mockBuild(buildapi.BuildSource{Binary: &buildapi.BinaryBuildSource{}}, mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+			build.Namespace = kapi.NamespaceDefault
+			build.Name = name
+			build.Spec.Revision = nil
+			return build, nil
+		},
+	}}
+
+	build, err := generator.Clone(kapi.NewDefaultContext(), &buildapi.BuildRequest{
+		ObjectMeta: kapi.ObjectMeta{Name: "test-build"},
+		Revision:   &buildapi.SourceRevision{Git: &buildapi.GitSourceRevision{Commit: "v2"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if build.Spec.Revision != nil {
+		t.Errorf("Expected no revision for a binary build, got %#v", build.Spec.Revision)
+	}
+}
+
+func TestCloneChainLength(t *testing.T) {
+	previous := []string{}
+	for i := 0; i < maxBuildCloneChainLength; i++ {
+		previous = append(previous, fmt.Sprintf("test-build-%d", i))
+	}
+	var created *buildapi.Build
+	generator := BuildGenerator{Client: Client{
+		CreateBuildFunc: func(ctx kapi.Context, build *buildapi.Build) error {
+			created = build
+			return nil
+		},
+		GetBuildFunc: func(ctx kapi.Context, name string) (*buildapi.Build, error) {
+			if created != nil {
+				return created, nil
+			}
+			build := mockBuild(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+			build.Namespace = kapi.NamespaceDefault
+			build.Name = name
+			build.Annotations = map[string]string{buildapi.BuildCloneChainAnnotation: strings.Join(previous, ",")}
+			return build, nil
+		},
+	}}
+
+	build, err := generator.Clone(kapi.NewDefaultContext(), &buildapi.BuildRequest{ObjectMeta: kapi.ObjectMeta{Name: "test-build"}})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := strings.Join(append(previous[1:], "test-build"), ",")
+	if chain := build.Annotations[buildapi.BuildCloneChainAnnotation]; chain != expected {
+		t.Errorf("Expected clone chain %q, got %q", expected, chain)
+	}
+}
+
 func TestCloneError(t *testing.T) {
 	generator := BuildGenerator{Client: Client{
 		GetBuildFunc: func(ctx kapi.Context, name string) (*buildapi.Build, error) {
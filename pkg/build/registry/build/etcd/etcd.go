@@ -2,6 +2,7 @@ package etcd
 
 import (
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -17,6 +18,7 @@ const BuildPath = "/builds"
 
 type REST struct {
 	*etcdgeneric.Etcd
+	deletion *etcdgeneric.Etcd
 }
 
 type DetailsREST struct {
@@ -33,8 +35,44 @@ func (r *DetailsREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Obje
 	return r.store.Update(ctx, obj)
 }
 
+type FinalizeREST struct {
+	store *etcdgeneric.Etcd
+}
+
+// New returns an empty object that can be used with Update after request data has been put into it.
+func (r *FinalizeREST) New() runtime.Object {
+	return r.store.New()
+}
+
+// Update updates the finalizers of a build.
+func (r *FinalizeREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
+	return r.store.Update(ctx, obj)
+}
+
+// Delete removes a build that has no finalizers. A build with finalizers is only marked for deletion,
+// it is removed once its finalizers are.
+func (r *REST) Delete(ctx kapi.Context, name string, options *kapi.DeleteOptions) (runtime.Object, error) {
+	obj, err := r.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	build := obj.(*api.Build)
+	if len(build.Status.Finalizers) == 0 {
+		return r.Etcd.Delete(ctx, name, options)
+	}
+
+	if build.DeletionTimestamp.IsZero() {
+		now := unversioned.Now()
+		build.DeletionTimestamp = &now
+		if _, _, err := r.deletion.Update(ctx, build); err != nil {
+			return nil, err
+		}
+	}
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
+
 // NewStorage returns a RESTStorage object that will work against Build objects.
-func NewStorage(s storage.Interface) (buildStorage *REST, detailsStorage *DetailsREST) {
+func NewStorage(s storage.Interface) (buildStorage *REST, detailsStorage *DetailsREST, finalizeStorage *FinalizeREST) {
 	store := &etcdgeneric.Etcd{
 		NewFunc:      func() runtime.Object { return &api.Build{} },
 		NewListFunc:  func() runtime.Object { return &api.BuildList{} },
@@ -59,10 +97,17 @@ func NewStorage(s storage.Interface) (buildStorage *REST, detailsStorage *Detail
 		Storage:             s,
 	}
 
-	buildStorage = &REST{store}
+	deletionStore := *store
+	deletionStore.UpdateStrategy = build.DeletionStrategy
+	buildStorage = &REST{Etcd: store, deletion: &deletionStore}
+
 	detailsStore := *store
 	detailsStore.UpdateStrategy = build.DetailsStrategy
 	detailsStorage = &DetailsREST{&detailsStore}
 
+	finalizeStore := *store
+	finalizeStore.UpdateStrategy = build.FinalizeStrategy
+	finalizeStorage = &FinalizeREST{&finalizeStore}
+
 	return
 }
@@ -63,11 +63,17 @@ func (strategy) PrepareForCreate(obj runtime.Object) {
 	if len(build.Status.Phase) == 0 {
 		build.Status.Phase = api.BuildPhaseNew
 	}
+	// the pod of the build is removed by the build controller before the build is
+	build.Status.Finalizers = []kapi.FinalizerName{api.BuildPodFinalizer}
 }
 
 // PrepareForUpdate clears fields that are not allowed to be set by end users on update.
 func (strategy) PrepareForUpdate(obj, old runtime.Object) {
-	_ = obj.(*api.Build)
+	newBuild := obj.(*api.Build)
+	oldBuild := old.(*api.Build)
+	// a build is only marked for deletion by deleting it, and its finalizers are only removed through builds/finalize
+	newBuild.DeletionTimestamp = oldBuild.DeletionTimestamp
+	newBuild.Status.Finalizers = oldBuild.Status.Finalizers
 }
 
 // Validate validates a new policy.
@@ -136,3 +142,35 @@ func (detailsStrategy) AllowUnconditionalUpdate() bool {
 
 // DetailsStrategy is the strategy used to manage updates to a Build revision
 var DetailsStrategy = detailsStrategy{Strategy}
+
+type deletionStrategy struct {
+	strategy
+}
+
+// Prepares a build for update by only allowing the build to be marked for deletion
+func (deletionStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	newBuild := obj.(*api.Build)
+	oldBuild := old.(*api.Build)
+	deletionTimestamp := newBuild.DeletionTimestamp
+	*newBuild = *oldBuild
+	newBuild.DeletionTimestamp = deletionTimestamp
+}
+
+// DeletionStrategy is the strategy used to mark a Build that still has finalizers for deletion
+var DeletionStrategy = deletionStrategy{Strategy}
+
+type finalizeStrategy struct {
+	strategy
+}
+
+// Prepares a build for update by only allowing its finalizers to be updated
+func (finalizeStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	newBuild := obj.(*api.Build)
+	oldBuild := old.(*api.Build)
+	finalizers := newBuild.Status.Finalizers
+	*newBuild = *oldBuild
+	newBuild.Status.Finalizers = finalizers
+}
+
+// FinalizeStrategy is the strategy used to update the finalizers of a Build
+var FinalizeStrategy = finalizeStrategy{Strategy}
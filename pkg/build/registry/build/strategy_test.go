@@ -42,6 +42,9 @@ func TestBuildStrategy(t *testing.T) {
 	if len(build.Status.Phase) == 0 || build.Status.Phase != buildapi.BuildPhaseNew {
 		t.Errorf("Build phase is not New")
 	}
+	if len(build.Status.Finalizers) != 1 || build.Status.Finalizers[0] != buildapi.BuildPodFinalizer {
+		t.Errorf("Build should have the build pod finalizer, got %v", build.Status.Finalizers)
+	}
 	errs := Strategy.Validate(ctx, build)
 	if len(errs) != 0 {
 		t.Errorf("Unexpected error validating %v", errs)
@@ -52,6 +55,30 @@ func TestBuildStrategy(t *testing.T) {
 	if len(errs) != 0 {
 		t.Errorf("Unexpected error validating %v", errs)
 	}
+	updated := *build
+	now := unversioned.Now()
+	updated.DeletionTimestamp = &now
+	Strategy.PrepareForUpdate(&updated, build)
+	if updated.DeletionTimestamp != nil {
+		t.Errorf("Build should only be marked for deletion by deleting it")
+	}
+	updated.DeletionTimestamp = &now
+	DeletionStrategy.PrepareForUpdate(&updated, build)
+	if updated.DeletionTimestamp == nil {
+		t.Errorf("Build should be marked for deletion")
+	}
+	updated.Status.Finalizers = nil
+	Strategy.PrepareForUpdate(&updated, build)
+	if len(updated.Status.Finalizers) != 1 {
+		t.Errorf("Build finalizers should only be updated through builds/finalize, got %v", updated.Status.Finalizers)
+	}
+	updated.Status.Finalizers = nil
+	updated.Spec.Revision = &buildapi.SourceRevision{}
+	FinalizeStrategy.PrepareForUpdate(&updated, build)
+	if len(updated.Status.Finalizers) != 0 || updated.Spec.Revision != nil {
+		t.Errorf("Build finalize should only update the finalizers, got %#v", updated)
+	}
+
 	invalidBuild := &buildapi.Build{}
 	errs = Strategy.Validate(ctx, invalidBuild)
 	if len(errs) == 0 {
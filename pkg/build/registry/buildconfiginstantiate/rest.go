@@ -17,11 +17,13 @@ import (
 	"k8s.io/kubernetes/pkg/registry/pod"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/httpstream/spdy"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/generator"
 	"github.com/openshift/origin/pkg/build/registry"
 	buildutil "github.com/openshift/origin/pkg/build/util"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 )
 
 // NewStorage creates a new storage object for build generation
@@ -46,7 +48,13 @@ func (s *InstantiateREST) Create(ctx kapi.Context, obj runtime.Object) (runtime.
 		return nil, err
 	}
 
-	return s.generator.Instantiate(ctx, obj.(*buildapi.BuildRequest))
+	request := obj.(*buildapi.BuildRequest)
+	// Only the schedule controller of the master starts scheduled builds, the schedule time set by users is ignored
+	if user, ok := kapi.UserFrom(ctx); !ok || !sets.NewString(user.GetGroups()...).Has(bootstrappolicy.MastersGroup) {
+		request.ScheduleTime = nil
+	}
+
+	return s.generator.Instantiate(ctx, request)
 }
 
 func NewBinaryStorage(generator *generator.BuildGenerator, watcher rest.Watcher, podClient kclient.PodsNamespacer, info kclient.ConnectionInfoGetter) *BinaryInstantiateREST {
@@ -2,25 +2,29 @@ package buildconfiginstantiate
 
 import (
 	"testing"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/auth/user"
 	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/runtime"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/generator"
 	mocks "github.com/openshift/origin/pkg/build/generator/test"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
-func TestCreateInstantiate(t *testing.T) {
+func newInstantiateREST() InstantiateREST {
 	imageStream := mocks.MockImageStream("testImageStream", "registry.com/namespace/imagename", map[string]string{"test": "newImageID123"})
 	image := mocks.MockImage("testImage@id", "registry.com/namespace/imagename@id")
 	fakeSecrets := []runtime.Object{}
 	for _, s := range mocks.MockBuilderSecrets() {
 		fakeSecrets = append(fakeSecrets, s)
 	}
-	rest := InstantiateREST{&generator.BuildGenerator{
+	return InstantiateREST{&generator.BuildGenerator{
 		Secrets:         testclient.NewSimpleFake(fakeSecrets...),
 		ServiceAccounts: mocks.MockBuilderServiceAccount(mocks.MockBuilderSecrets()),
 		Client: generator.Client{
@@ -46,13 +50,46 @@ func TestCreateInstantiate(t *testing.T) {
 				return &imageapi.ImageStreamImage{Image: *image}, nil
 			},
 		}}}
+}
 
+func TestCreateInstantiate(t *testing.T) {
+	rest := newInstantiateREST()
 	_, err := rest.Create(kapi.NewDefaultContext(), &buildapi.BuildRequest{ObjectMeta: kapi.ObjectMeta{Name: "name"}})
 	if err != nil {
 		t.Errorf("Unexpected error %v", err)
 	}
 }
 
+func TestCreateInstantiateScheduleTime(t *testing.T) {
+	testCases := map[string]struct {
+		user user.Info
+		// the mock BuildConfig has no Schedule trigger, so requests whose schedule time is honored fail
+		expectErr bool
+	}{
+		"no user": {},
+		"user":    {user: &user.DefaultInfo{Name: "user", Groups: []string{"group"}}},
+		"master":  {user: &user.DefaultInfo{Name: "system:openshift-master", Groups: []string{bootstrappolicy.MastersGroup}}, expectErr: true},
+	}
+	for name, tc := range testCases {
+		rest := newInstantiateREST()
+		ctx := kapi.NewDefaultContext()
+		if tc.user != nil {
+			ctx = kapi.WithUser(ctx, tc.user)
+		}
+		request := &buildapi.BuildRequest{
+			ObjectMeta:   kapi.ObjectMeta{Name: "name"},
+			ScheduleTime: &unversioned.Time{Time: time.Now()},
+		}
+		_, err := rest.Create(ctx, request)
+		if tc.expectErr && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if !tc.expectErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+}
+
 func TestCreateInstantiateValidationError(t *testing.T) {
 	rest := InstantiateREST{&generator.BuildGenerator{}}
 	_, err := rest.Create(kapi.NewDefaultContext(), &buildapi.BuildRequest{})
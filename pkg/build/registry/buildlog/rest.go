@@ -2,6 +2,7 @@ package buildlog
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang/glog"
@@ -68,6 +69,12 @@ func (r *REST) Get(ctx kapi.Context, name string, opts runtime.Object) (runtime.
 		return nil, err
 	}
 	build := obj.(*api.Build)
+	if buildLogOpts.Previous {
+		if build, err = r.previousBuild(ctx, build); err != nil {
+			return nil, err
+		}
+		name = build.Name
+	}
 	switch build.Status.Phase {
 	// Build has not launched, wait til it runs
 	case api.BuildPhaseNew, api.BuildPhasePending:
@@ -118,6 +125,22 @@ func (r *REST) Get(ctx kapi.Context, name string, opts runtime.Object) (runtime.
 	}, nil
 }
 
+// previousBuild returns the build of the build config of build numbered one less than it
+func (r *REST) previousBuild(ctx kapi.Context, build *api.Build) (*api.Build, error) {
+	number, err := strconv.Atoi(build.Annotations[api.BuildNumberAnnotation])
+	if err != nil || number <= 1 || build.Status.Config == nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("no previous build exists for build %q", build.Name))
+	}
+	obj, err := r.Getter.Get(ctx, buildutil.BuildNameForConfigVersion(build.Status.Config.Name, number-1))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewBadRequest(fmt.Sprintf("no previous build exists for build %q", build.Name))
+		}
+		return nil, err
+	}
+	return obj.(*api.Build), nil
+}
+
 // NewGetOptions returns a new options object for build logs
 func (r *REST) NewGetOptions() (runtime.Object, bool, string) {
 	return &api.BuildLogOptions{}, false, ""
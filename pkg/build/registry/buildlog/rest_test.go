@@ -8,6 +8,7 @@ import (
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/rest"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/fields"
@@ -35,6 +36,8 @@ func (p *testPodGetter) Get(ctx kapi.Context, name string) (runtime.Object, erro
 		pod = mockPod(kapi.PodFailed, name)
 	case "unknown-build":
 		pod = mockPod(kapi.PodUnknown, name)
+	case "config-1-build":
+		pod = mockPod(kapi.PodSucceeded, name)
 	}
 	return pod, nil
 }
@@ -176,6 +179,53 @@ func TestWaitForBuildTimeout(t *testing.T) {
 	}
 }
 
+func TestPreviousBuild(t *testing.T) {
+	numberedBuild := func(name string, number string) *api.Build {
+		build := mockBuild(api.BuildPhaseComplete, name)
+		build.Annotations = map[string]string{api.BuildNumberAnnotation: number}
+		build.Status.Config = &kapi.ObjectReference{Name: "config"}
+		return build
+	}
+	getter := buildGetter{
+		"config-1": numberedBuild("config-1", "1"),
+		"config-2": numberedBuild("config-2", "2"),
+		"config-4": numberedBuild("config-4", "4"),
+	}
+	storage := &REST{
+		Getter:         getter,
+		PodGetter:      &testPodGetter{},
+		ConnectionInfo: &kclient.HTTPKubeletClient{Config: &kclient.KubeletConfig{EnableHttps: true, Port: 12345}, Client: &http.Client{}},
+		Timeout:        defaultTimeout,
+	}
+	ctx := kapi.NewDefaultContext()
+
+	obj, err := storage.Get(ctx, "config-2", &api.BuildLogOptions{Previous: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := fmt.Sprintf("https://foo-host:12345/containerLogs/%s/config-1-build/foo-container", kapi.NamespaceDefault)
+	if location := obj.(*genericrest.LocationStreamer).Location.String(); location != expected {
+		t.Errorf("Expected the logs of the previous build at %s, got %s", expected, location)
+	}
+
+	for _, name := range []string{"config-1", "config-4"} {
+		if _, err := storage.Get(ctx, name, &api.BuildLogOptions{Previous: true}); err == nil {
+			t.Errorf("%s: expected an error for a build without a previous build", name)
+		}
+	}
+}
+
+// buildGetter gets the builds it holds by name
+type buildGetter map[string]*api.Build
+
+func (g buildGetter) Get(ctx kapi.Context, name string) (runtime.Object, error) {
+	build, ok := g[name]
+	if !ok {
+		return nil, errors.NewNotFound("build", name)
+	}
+	return build, nil
+}
+
 type buildWatcher struct {
 	Build   *api.Build
 	Watcher watch.Interface
@@ -66,11 +66,59 @@ func IsBuildComplete(build *buildapi.Build) bool {
 	return build.Status.Phase != buildapi.BuildPhaseRunning && build.Status.Phase != buildapi.BuildPhasePending && build.Status.Phase != buildapi.BuildPhaseNew
 }
 
+// phaseOrder is the order of the build phases. A build only moves to a later phase, and a terminal phase is final.
+var phaseOrder = map[buildapi.BuildPhase]int{
+	buildapi.BuildPhaseNew:       0,
+	buildapi.BuildPhasePending:   1,
+	buildapi.BuildPhaseRunning:   2,
+	buildapi.BuildPhaseComplete:  3,
+	buildapi.BuildPhaseFailed:    3,
+	buildapi.BuildPhaseError:     3,
+	buildapi.BuildPhaseCancelled: 3,
+}
+
+// IsPhaseTransitionAllowed returns whether a build may move from phase from to phase to. Phases only move
+// forward, from New to Pending to Running to one of the terminal phases, so an update based on a stale copy of
+// a build cannot undo a transition that was already recorded.
+func IsPhaseTransitionAllowed(from, to buildapi.BuildPhase) bool {
+	// builds created before the phase was defaulted have none
+	if len(from) == 0 {
+		from = buildapi.BuildPhaseNew
+	}
+	if from == to || len(to) == 0 && from == buildapi.BuildPhaseNew {
+		return true
+	}
+	fromOrder, ok := phaseOrder[from]
+	if !ok {
+		return false
+	}
+	toOrder, ok := phaseOrder[to]
+	if !ok {
+		return false
+	}
+	return toOrder > fromOrder
+}
+
 // IsPaused returns true if the provided BuildConfig is paused and cannot be used to create a new Build
 func IsPaused(bc *buildapi.BuildConfig) bool {
 	return strings.ToLower(bc.Annotations[buildapi.BuildConfigPausedAnnotation]) == "true"
 }
 
+// IsImageChangePending returns true if an image recorded while the provided BuildConfig was paused was not built yet
+func IsImageChangePending(bc *buildapi.BuildConfig) bool {
+	return strings.ToLower(bc.Annotations[buildapi.BuildConfigImageChangePendingAnnotation]) == "true"
+}
+
+// GetScheduleTrigger returns the Schedule trigger of the provided BuildConfig, or nil if it has none
+func GetScheduleTrigger(bc *buildapi.BuildConfig) *buildapi.ScheduleTrigger {
+	for _, trigger := range bc.Spec.Triggers {
+		if trigger.Type == buildapi.ScheduleBuildTriggerType && trigger.Schedule != nil {
+			return trigger.Schedule
+		}
+	}
+	return nil
+}
+
 // BuildNameForConfigVersion returns the name of the version-th build
 // for the config that has the provided name
 func BuildNameForConfigVersion(name string, version int) string {
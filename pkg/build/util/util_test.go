@@ -12,3 +12,28 @@ func TestGetBuildPodName(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, actual)
 	}
 }
+
+func TestIsPhaseTransitionAllowed(t *testing.T) {
+	tests := []struct {
+		from, to buildapi.BuildPhase
+		allowed  bool
+	}{
+		{"", buildapi.BuildPhaseNew, true},
+		{buildapi.BuildPhaseNew, buildapi.BuildPhasePending, true},
+		{buildapi.BuildPhaseNew, buildapi.BuildPhaseCancelled, true},
+		{buildapi.BuildPhasePending, buildapi.BuildPhaseRunning, true},
+		{buildapi.BuildPhasePending, buildapi.BuildPhaseComplete, true},
+		{buildapi.BuildPhaseRunning, buildapi.BuildPhaseRunning, true},
+		{buildapi.BuildPhaseRunning, buildapi.BuildPhaseFailed, true},
+		{buildapi.BuildPhaseRunning, buildapi.BuildPhasePending, false},
+		{buildapi.BuildPhasePending, buildapi.BuildPhaseNew, false},
+		{buildapi.BuildPhaseComplete, buildapi.BuildPhaseRunning, false},
+		{buildapi.BuildPhaseFailed, buildapi.BuildPhaseError, false},
+		{buildapi.BuildPhaseRunning, "Unknown", false},
+	}
+	for _, test := range tests {
+		if allowed := IsPhaseTransitionAllowed(test.from, test.to); allowed != test.allowed {
+			t.Errorf("expected the transition from %q to %q to be allowed=%v, got %v", test.from, test.to, test.allowed, allowed)
+		}
+	}
+}
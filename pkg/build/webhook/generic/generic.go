@@ -63,7 +63,7 @@ func (p *WebHookPlugin) Extract(buildCfg *api.BuildConfig, secret, path string,
 
 		if data.Git.Refs != nil {
 			for _, ref := range data.Git.Refs {
-				if webhook.GitRefMatches(ref.Ref, git.Ref) {
+				if webhook.TriggerRefMatches(ref.Ref, trigger.GenericWebHook, git.Ref) {
 					revision = &api.SourceRevision{
 						Git: &ref.GitSourceRevision,
 					}
@@ -73,7 +73,7 @@ func (p *WebHookPlugin) Extract(buildCfg *api.BuildConfig, secret, path string,
 			glog.V(2).Infof("Skipping build for BuildConfig %s/%s. None of the supplied refs matched %q", buildCfg.Namespace, buildCfg, git.Ref)
 			return nil, false, nil
 		}
-		if !webhook.GitRefMatches(data.Git.Ref, git.Ref) {
+		if !webhook.TriggerRefMatches(data.Git.Ref, trigger.GenericWebHook, git.Ref) {
 			glog.V(2).Infof("Skipping build for BuildConfig %s/%s. Branch reference from %q does not match configuration", buildCfg.Namespace, buildCfg.Name, data.Git.Ref)
 			return nil, false, nil
 		}
@@ -315,3 +315,35 @@ func TestExtractSkipsBuildForUnmatchedBranches(t *testing.T) {
 		t.Errorf("Expecting to not continue from this event because the branch is not for this buildConfig '%s'", context.buildCfg.Spec.Source.Git.Ref)
 	}
 }
+
+func TestExtractUsesAllowedRefs(t *testing.T) {
+	//setup
+	context := setup(t, "pushevent-not-master-branch.json", "push")
+	context.buildCfg.Spec.Triggers[0].GitHubWebHook.AllowedRefs = []string{"my_*"}
+
+	//execute
+	revision, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
+
+	//validation
+	if err != nil {
+		t.Errorf("Error while extracting build info: %s", err)
+	}
+	if !proceed {
+		t.Errorf("The 'proceed' return value should equal 'true' %t", proceed)
+	}
+	if revision == nil {
+		t.Error("Expecting the revision to not be nil")
+	}
+}
+
+func TestExtractSkipsBuildForBranchesNotAllowed(t *testing.T) {
+	//setup
+	context := setup(t, "pushevent.json", "push")
+	context.buildCfg.Spec.Triggers[0].GitHubWebHook.AllowedRefs = []string{"refs/tags/*"}
+
+	//execute
+	_, proceed, _ := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
+	if proceed {
+		t.Errorf("Expecting to not continue from this event because the branch is not allowed by %v", context.buildCfg.Spec.Triggers[0].GitHubWebHook.AllowedRefs)
+	}
+}
@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/openshift/origin/pkg/build/api"
@@ -23,6 +24,29 @@ func GitRefMatches(eventRef, configRef string) bool {
 	return configRef == eventRef
 }
 
+// TriggerRefMatches determines if the ref from a webhook event triggers a build: it must match one of the allowed
+// refs of the trigger when there are some, and the ref of the build configuration otherwise
+func TriggerRefMatches(eventRef string, trigger *api.WebHookTrigger, configRef string) bool {
+	if len(trigger.AllowedRefs) == 0 {
+		return GitRefMatches(eventRef, configRef)
+	}
+	eventRef = qualifyRef(eventRef)
+	for _, allowedRef := range trigger.AllowedRefs {
+		if matches, _ := path.Match(qualifyRef(allowedRef), eventRef); matches {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyRef returns the full name of a git reference, a bare name being a branch
+func qualifyRef(ref string) string {
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
 // FindTriggerPolicy retrieves the BuildTrigger of a given type from a build configuration
 func FindTriggerPolicy(triggerType api.BuildTriggerType, config *api.BuildConfig) (*api.BuildTriggerPolicy, bool) {
 	for _, p := range config.Spec.Triggers {
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/openshift/origin/pkg/build/api"
+)
+
+func TestTriggerRefMatches(t *testing.T) {
+	testCases := map[string]struct {
+		EventRef    string
+		AllowedRefs []string
+		ConfigRef   string
+		Matches     bool
+	}{
+		"default ref":                 {EventRef: "refs/heads/master", Matches: true},
+		"other than the config ref":   {EventRef: "refs/heads/dev", ConfigRef: "master"},
+		"allowed branch":              {EventRef: "refs/heads/dev", AllowedRefs: []string{"master", "dev"}, Matches: true},
+		"allowed refs replace config": {EventRef: "refs/heads/master", AllowedRefs: []string{"dev"}, ConfigRef: "master"},
+		"allowed branch pattern":      {EventRef: "refs/heads/release-1.1", AllowedRefs: []string{"release-*"}, Matches: true},
+		"pattern does not match /":    {EventRef: "refs/heads/release-1/fix", AllowedRefs: []string{"release-*"}},
+		"allowed tag":                 {EventRef: "refs/tags/v1.1", AllowedRefs: []string{"refs/tags/v*"}, Matches: true},
+		"tag is not a branch":         {EventRef: "refs/tags/v1.1", AllowedRefs: []string{"v1.1"}},
+		"unqualified event ref":       {EventRef: "dev", AllowedRefs: []string{"refs/heads/dev"}, Matches: true},
+	}
+
+	for k, tc := range testCases {
+		trigger := &api.WebHookTrigger{AllowedRefs: tc.AllowedRefs}
+		if matches := TriggerRefMatches(tc.EventRef, trigger, tc.ConfigRef); matches != tc.Matches {
+			t.Errorf("%s: expected %t, got %t", k, tc.Matches, matches)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package api
+
+import "k8s.io/kubernetes/pkg/fields"
+
+// CertificateSigningRequestToSelectableFields returns a label set that represents the object
+func CertificateSigningRequestToSelectableFields(obj *CertificateSigningRequest) fields.Set {
+	return fields.Set{
+		"metadata.name": obj.Name,
+		"spec.username": obj.Spec.Username,
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+// ParseCertificateRequest decodes the PEM encoded certificate request of csr and checks its signature
+func ParseCertificateRequest(csr *CertificateSigningRequest) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("request does not contain a PEM encoded CERTIFICATE REQUEST block")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.CheckSignature(); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetCondition returns the approval decision of csr, or nil if no decision has been made
+func GetCondition(csr *CertificateSigningRequest) *CertificateSigningRequestCondition {
+	for i := range csr.Status.Conditions {
+		switch csr.Status.Conditions[i].Type {
+		case CertificateApproved, CertificateDenied:
+			return &csr.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsApproved returns true if csr has been approved and not denied
+func IsApproved(csr *CertificateSigningRequest) bool {
+	condition := GetCondition(csr)
+	return condition != nil && condition.Type == CertificateApproved
+}
+
+// IsDenied returns true if csr has been denied
+func IsDenied(csr *CertificateSigningRequest) bool {
+	condition := GetCondition(csr)
+	return condition != nil && condition.Type == CertificateDenied
+}
@@ -0,0 +1,15 @@
+package api
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func init() {
+	api.Scheme.AddKnownTypes("",
+		&CertificateSigningRequest{},
+		&CertificateSigningRequestList{},
+	)
+}
+
+func (*CertificateSigningRequest) IsAnAPIObject()     {}
+func (*CertificateSigningRequestList) IsAnAPIObject() {}
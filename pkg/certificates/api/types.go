@@ -0,0 +1,81 @@
+package api
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// CertificateSigningRequest asks the master to issue a certificate for a key held by a node. A cluster
+// administrator approves or denies the request, after which the master signs approved requests.
+type CertificateSigningRequest struct {
+	unversioned.TypeMeta
+	kapi.ObjectMeta
+
+	// Spec is the certificate being requested
+	Spec CertificateSigningRequestSpec
+
+	// Status holds the approval decision and the issued certificate
+	Status CertificateSigningRequestStatus
+}
+
+// CertificateSigningRequestSpec describes the certificate being requested
+type CertificateSigningRequestSpec struct {
+	// Request is a PEM encoded PKCS#10 certificate request
+	Request []byte
+
+	// Usage is what the certificate will be used for
+	Usage CertificateUsage
+
+	// Username is the user that created the request. It is set by the server.
+	Username string
+	// Groups are the groups of the user that created the request. They are set by the server.
+	Groups []string
+}
+
+// CertificateUsage is what a requested certificate will be used for
+type CertificateUsage string
+
+const (
+	// CertificateUsageClient certificates authenticate a node to the master
+	CertificateUsageClient CertificateUsage = "client"
+	// CertificateUsageServing certificates are presented by the kubelet's server
+	CertificateUsageServing CertificateUsage = "serving"
+)
+
+// CertificateSigningRequestStatus holds the approval decision and the issued certificate
+type CertificateSigningRequestStatus struct {
+	// Conditions record whether the request was approved or denied
+	Conditions []CertificateSigningRequestCondition
+
+	// Certificate is the PEM encoded certificate issued for an approved request
+	Certificate []byte
+}
+
+// CertificateSigningRequestConditionType is the type of an approval decision
+type CertificateSigningRequestConditionType string
+
+const (
+	// CertificateApproved means the certificate may be issued
+	CertificateApproved CertificateSigningRequestConditionType = "Approved"
+	// CertificateDenied means the certificate will not be issued
+	CertificateDenied CertificateSigningRequestConditionType = "Denied"
+)
+
+// CertificateSigningRequestCondition records an approval decision
+type CertificateSigningRequestCondition struct {
+	// Type is Approved or Denied
+	Type CertificateSigningRequestConditionType
+	// Reason is a brief machine readable reason for the decision
+	Reason string
+	// Message is a human readable explanation of the decision
+	Message string
+	// LastUpdateTime is when the decision was made
+	LastUpdateTime unversioned.Time
+}
+
+// CertificateSigningRequestList is a collection of CertificateSigningRequests
+type CertificateSigningRequestList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []CertificateSigningRequest
+}
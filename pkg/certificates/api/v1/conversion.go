@@ -0,0 +1,16 @@
+package v1
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	oapi "github.com/openshift/origin/pkg/api"
+	"github.com/openshift/origin/pkg/certificates/api"
+)
+
+func init() {
+	if err := kapi.Scheme.AddFieldLabelConversionFunc("v1", "CertificateSigningRequest",
+		oapi.GetFieldLabelConversionFunc(api.CertificateSigningRequestToSelectableFields(&api.CertificateSigningRequest{}), nil),
+	); err != nil {
+		panic(err)
+	}
+}
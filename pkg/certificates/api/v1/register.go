@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func init() {
+	api.Scheme.AddKnownTypes("v1",
+		&CertificateSigningRequest{},
+		&CertificateSigningRequestList{},
+	)
+}
+
+func (*CertificateSigningRequest) IsAnAPIObject()     {}
+func (*CertificateSigningRequestList) IsAnAPIObject() {}
@@ -0,0 +1,65 @@
+package v1beta3
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kapi "k8s.io/kubernetes/pkg/api/v1beta3"
+)
+
+// CertificateSigningRequest asks the master to issue a certificate for a key held by a node. A cluster
+// administrator approves or denies the request, after which the master signs approved requests.
+type CertificateSigningRequest struct {
+	unversioned.TypeMeta `json:",inline"`
+	kapi.ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec   CertificateSigningRequestSpec   `json:"spec" description:"the certificate being requested"`
+	Status CertificateSigningRequestStatus `json:"status,omitempty" description:"the approval decision and the issued certificate"`
+}
+
+// CertificateSigningRequestSpec describes the certificate being requested
+type CertificateSigningRequestSpec struct {
+	Request  []byte           `json:"request" description:"PEM encoded PKCS#10 certificate request"`
+	Usage    CertificateUsage `json:"usage" description:"what the certificate will be used for: client or serving"`
+	Username string           `json:"username,omitempty" description:"user that created the request; set by the server"`
+	Groups   []string         `json:"groups,omitempty" description:"groups of the user that created the request; set by the server"`
+}
+
+// CertificateUsage is what a requested certificate will be used for
+type CertificateUsage string
+
+const (
+	// CertificateUsageClient certificates authenticate a node to the master
+	CertificateUsageClient CertificateUsage = "client"
+	// CertificateUsageServing certificates are presented by the kubelet's server
+	CertificateUsageServing CertificateUsage = "serving"
+)
+
+// CertificateSigningRequestStatus holds the approval decision and the issued certificate
+type CertificateSigningRequestStatus struct {
+	Conditions  []CertificateSigningRequestCondition `json:"conditions,omitempty" description:"whether the request was approved or denied"`
+	Certificate []byte                               `json:"certificate,omitempty" description:"PEM encoded certificate issued for an approved request"`
+}
+
+// CertificateSigningRequestConditionType is the type of an approval decision
+type CertificateSigningRequestConditionType string
+
+const (
+	// CertificateApproved means the certificate may be issued
+	CertificateApproved CertificateSigningRequestConditionType = "Approved"
+	// CertificateDenied means the certificate will not be issued
+	CertificateDenied CertificateSigningRequestConditionType = "Denied"
+)
+
+// CertificateSigningRequestCondition records an approval decision
+type CertificateSigningRequestCondition struct {
+	Type           CertificateSigningRequestConditionType `json:"type" description:"Approved or Denied"`
+	Reason         string                                 `json:"reason,omitempty" description:"brief machine readable reason for the decision"`
+	Message        string                                 `json:"message,omitempty" description:"human readable explanation of the decision"`
+	LastUpdateTime unversioned.Time                       `json:"lastUpdateTime,omitempty" description:"when the decision was made"`
+}
+
+// CertificateSigningRequestList is a collection of CertificateSigningRequests
+type CertificateSigningRequestList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+	Items                []CertificateSigningRequest `json:"items" description:"list of certificate signing requests"`
+}
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/util/fielderrors"
+
+	oapi "github.com/openshift/origin/pkg/api"
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+// ValidateCertificateSigningRequest tests that the request is a signed certificate request for a node identity
+func ValidateCertificateSigningRequest(csr *certapi.CertificateSigningRequest) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	allErrs = append(allErrs, validation.ValidateObjectMeta(&csr.ObjectMeta, false, oapi.MinimalNameRequirements).Prefix("metadata")...)
+	allErrs = append(allErrs, validateCertificateSigningRequestSpec(&csr.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+func validateCertificateSigningRequestSpec(spec *certapi.CertificateSigningRequestSpec) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	switch spec.Usage {
+	case certapi.CertificateUsageClient, certapi.CertificateUsageServing:
+	case "":
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("usage"))
+	default:
+		allErrs = append(allErrs, fielderrors.NewFieldValueNotSupported("usage", spec.Usage, []string{string(certapi.CertificateUsageClient), string(certapi.CertificateUsageServing)}))
+	}
+
+	if len(spec.Request) == 0 {
+		return append(allErrs, fielderrors.NewFieldRequired("request"))
+	}
+	request, err := certapi.ParseCertificateRequest(&certapi.CertificateSigningRequest{Spec: *spec})
+	if err != nil {
+		return append(allErrs, fielderrors.NewFieldInvalid("request", "", err.Error()))
+	}
+
+	subject := request.Subject
+	if !strings.HasPrefix(subject.CommonName, bootstrappolicy.NodeUsernamePrefix) || len(subject.CommonName) == len(bootstrappolicy.NodeUsernamePrefix) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("request", subject.CommonName, fmt.Sprintf("the common name must be a node username of the form %s<node name>", bootstrappolicy.NodeUsernamePrefix)))
+	}
+	switch spec.Usage {
+	case certapi.CertificateUsageClient:
+		if !reflect.DeepEqual(subject.Organization, []string{bootstrappolicy.NodesGroup}) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("request", subject.Organization, fmt.Sprintf("the organization of a client certificate must be %s", bootstrappolicy.NodesGroup)))
+		}
+		if len(request.DNSNames) > 0 || len(request.IPAddresses) > 0 {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("request", "", "client certificates may not include DNS names or IP addresses"))
+		}
+	case certapi.CertificateUsageServing:
+		if len(request.DNSNames) == 0 && len(request.IPAddresses) == 0 {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("request", "", "serving certificates must include at least one DNS name or IP address"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCertificateSigningRequestUpdate tests that only the metadata of the request changes
+func ValidateCertificateSigningRequestUpdate(csr *certapi.CertificateSigningRequest, old *certapi.CertificateSigningRequest) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&csr.ObjectMeta, &old.ObjectMeta).Prefix("metadata")...)
+	if !reflect.DeepEqual(csr.Spec, old.Spec) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("spec", "", "the spec of a certificate signing request may not be changed"))
+	}
+	return allErrs
+}
+
+// ValidateCertificateSigningRequestApprovalUpdate tests that the request carries a single approval
+// decision, and that the decision is not changed after a certificate has been issued
+func ValidateCertificateSigningRequestApprovalUpdate(csr *certapi.CertificateSigningRequest, old *certapi.CertificateSigningRequest) fielderrors.ValidationErrorList {
+	allErrs := ValidateCertificateSigningRequestUpdate(csr, old)
+
+	decisions := 0
+	for i, condition := range csr.Status.Conditions {
+		switch condition.Type {
+		case certapi.CertificateApproved, certapi.CertificateDenied:
+			decisions++
+		default:
+			allErrs = append(allErrs, fielderrors.NewFieldValueNotSupported(fmt.Sprintf("status.conditions[%d].type", i), condition.Type, []string{string(certapi.CertificateApproved), string(certapi.CertificateDenied)}))
+		}
+	}
+	if decisions > 1 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("status.conditions", "", "a request may only be approved or denied once"))
+	}
+	if len(old.Status.Certificate) > 0 && !reflect.DeepEqual(csr.Status.Conditions, old.Status.Conditions) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("status.conditions", "", "the decision may not be changed after the certificate has been issued"))
+	}
+	return allErrs
+}
+
+// ValidateCertificateSigningRequestStatusUpdate tests that a certificate is only issued for an approved request
+func ValidateCertificateSigningRequestStatusUpdate(csr *certapi.CertificateSigningRequest, old *certapi.CertificateSigningRequest) fielderrors.ValidationErrorList {
+	allErrs := ValidateCertificateSigningRequestUpdate(csr, old)
+	if len(csr.Status.Certificate) > 0 && !certapi.IsApproved(csr) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("status.certificate", "", "a certificate may only be issued for an approved request"))
+	}
+	return allErrs
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"crypto/x509/pkix"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
+)
+
+func newRequest(t *testing.T, usage certapi.CertificateUsage, subject pkix.Name, hosts []string) *certapi.CertificateSigningRequest {
+	request, _, err := crypto.NewCertificateRequest(subject, hosts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &certapi.CertificateSigningRequest{
+		ObjectMeta: kapi.ObjectMeta{Name: "node-node1-client-abcde"},
+		Spec: certapi.CertificateSigningRequestSpec{
+			Request: request,
+			Usage:   usage,
+		},
+	}
+}
+
+func TestValidateCertificateSigningRequest(t *testing.T) {
+	clientSubject := pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:nodes"}}
+	servingSubject := pkix.Name{CommonName: "system:node:node1"}
+
+	testCases := map[string]struct {
+		csr         *certapi.CertificateSigningRequest
+		expectedErr bool
+	}{
+		"client": {
+			csr: newRequest(t, certapi.CertificateUsageClient, clientSubject, nil),
+		},
+		"serving": {
+			csr: newRequest(t, certapi.CertificateUsageServing, servingSubject, []string{"node1", "10.0.0.1"}),
+		},
+		"missing usage": {
+			csr:         newRequest(t, "", clientSubject, nil),
+			expectedErr: true,
+		},
+		"missing request": {
+			csr:         &certapi.CertificateSigningRequest{ObjectMeta: kapi.ObjectMeta{Name: "csr"}, Spec: certapi.CertificateSigningRequestSpec{Usage: certapi.CertificateUsageClient}},
+			expectedErr: true,
+		},
+		"not a node": {
+			csr:         newRequest(t, certapi.CertificateUsageClient, pkix.Name{CommonName: "system:admin", Organization: []string{"system:nodes"}}, nil),
+			expectedErr: true,
+		},
+		"client in another group": {
+			csr:         newRequest(t, certapi.CertificateUsageClient, pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:cluster-admins"}}, nil),
+			expectedErr: true,
+		},
+		"client with hostnames": {
+			csr:         newRequest(t, certapi.CertificateUsageClient, clientSubject, []string{"node1"}),
+			expectedErr: true,
+		},
+		"serving without hostnames": {
+			csr:         newRequest(t, certapi.CertificateUsageServing, servingSubject, nil),
+			expectedErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		errs := ValidateCertificateSigningRequest(tc.csr)
+		if tc.expectedErr && len(errs) == 0 {
+			t.Errorf("%s: expected an error", name)
+		}
+		if !tc.expectedErr && len(errs) > 0 {
+			t.Errorf("%s: unexpected errors: %v", name, errs)
+		}
+	}
+}
+
+func TestValidateCertificateSigningRequestApprovalUpdate(t *testing.T) {
+	old := newRequest(t, certapi.CertificateUsageClient, pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:nodes"}}, nil)
+	old.ResourceVersion = "1"
+
+	approved := *old
+	approved.Status.Conditions = []certapi.CertificateSigningRequestCondition{{Type: certapi.CertificateApproved}}
+	if errs := ValidateCertificateSigningRequestApprovalUpdate(&approved, old); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	twice := approved
+	twice.Status.Conditions = []certapi.CertificateSigningRequestCondition{{Type: certapi.CertificateApproved}, {Type: certapi.CertificateDenied}}
+	if errs := ValidateCertificateSigningRequestApprovalUpdate(&twice, &approved); len(errs) == 0 {
+		t.Errorf("expected an error for two decisions")
+	}
+
+	issued := approved
+	issued.Status.Certificate = []byte("cert")
+	denied := issued
+	denied.Status.Conditions = []certapi.CertificateSigningRequestCondition{{Type: certapi.CertificateDenied}}
+	if errs := ValidateCertificateSigningRequestApprovalUpdate(&denied, &issued); len(errs) == 0 {
+		t.Errorf("expected an error for changing the decision of an issued request")
+	}
+
+	if errs := ValidateCertificateSigningRequestStatusUpdate(&issued, &approved); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	unapproved := *old
+	unapproved.Status.Certificate = []byte("cert")
+	if errs := ValidateCertificateSigningRequestStatusUpdate(&unapproved, old); len(errs) == 0 {
+		t.Errorf("expected an error for issuing a certificate for a request that was not approved")
+	}
+}
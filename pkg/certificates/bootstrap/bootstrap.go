@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	osclient "github.com/openshift/origin/pkg/client"
+)
+
+const (
+	// pollInterval is how often a pending certificate signing request is checked
+	pollInterval = 5 * time.Second
+
+	// renewalFraction is the part of the lifetime of a certificate after which it is renewed
+	renewalFraction = 0.8
+)
+
+// SubmitCertificateRequest creates a certificate signing request for the PEM-encoded request of the node
+func SubmitCertificateRequest(client osclient.CertificateSigningRequestInterface, nodeName string, usage certapi.CertificateUsage, request []byte) (*certapi.CertificateSigningRequest, error) {
+	return client.Create(&certapi.CertificateSigningRequest{
+		ObjectMeta: kapi.ObjectMeta{GenerateName: fmt.Sprintf("node-%s-%s-", nodeName, usage)},
+		Spec: certapi.CertificateSigningRequestSpec{
+			Request: request,
+			Usage:   usage,
+		},
+	})
+}
+
+// WaitForCertificate waits until the master issues the certificate of the named certificate signing request and
+// returns it. An error is returned if the request is denied.
+func WaitForCertificate(client osclient.CertificateSigningRequestInterface, name string) ([]byte, error) {
+	var certificate []byte
+	logged := false
+	err := wait.PollInfinite(pollInterval, func() (bool, error) {
+		csr, err := client.Get(name)
+		if err != nil {
+			glog.V(4).Infof("Unable to check certificate signing request %s: %v", name, err)
+			return false, nil
+		}
+		if certapi.IsDenied(csr) {
+			condition := certapi.GetCondition(csr)
+			return false, fmt.Errorf("certificate signing request %s was denied: %s %s", name, condition.Reason, condition.Message)
+		}
+		if len(csr.Status.Certificate) > 0 {
+			certificate = csr.Status.Certificate
+			return true, nil
+		}
+		if !logged && !certapi.IsApproved(csr) {
+			glog.Infof("Waiting for certificate signing request %s to be approved", name)
+			logged = true
+		}
+		return false, nil
+	})
+	return certificate, err
+}
+
+// RenewalTime returns the time after which cert should be replaced
+func RenewalTime(cert *x509.Certificate) time.Time {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotBefore.Add(time.Duration(float64(lifetime) * renewalFraction))
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/watch"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+// AutoApprovedReason is the reason recorded on requests that the controller approves
+const AutoApprovedReason = "AutoApproved"
+
+// Signer issues certificates for approved certificate requests
+type Signer interface {
+	SignClientCertificateRequest(request *x509.CertificateRequest) ([]byte, error)
+	SignServerCertificateRequest(request *x509.CertificateRequest) ([]byte, error)
+}
+
+// CertificateSigningControllerOptions contains options for the CertificateSigningController
+type CertificateSigningControllerOptions struct {
+	// Resync is the time.Duration at which to fully re-list certificate signing requests.
+	// If zero, re-list will be delayed as long as possible
+	Resync time.Duration
+}
+
+// NewCertificateSigningController returns a new *CertificateSigningController.
+func NewCertificateSigningController(client osclient.CertificateSigningRequestsInterface, signer Signer, options CertificateSigningControllerOptions) *CertificateSigningController {
+	e := &CertificateSigningController{
+		client: client,
+		signer: signer,
+	}
+
+	_, e.csrController = framework.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return e.client.CertificateSigningRequests().List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(rv string) (watch.Interface, error) {
+				return e.client.CertificateSigningRequests().Watch(labels.Everything(), fields.Everything(), rv)
+			},
+		},
+		&certapi.CertificateSigningRequest{},
+		options.Resync,
+		framework.ResourceEventHandlerFuncs{
+			AddFunc:    e.csrAdded,
+			UpdateFunc: e.csrUpdated,
+		},
+	)
+
+	return e
+}
+
+// CertificateSigningController approves the client certificate renewals of nodes and signs approved certificate requests
+type CertificateSigningController struct {
+	stopChan chan struct{}
+
+	client osclient.CertificateSigningRequestsInterface
+	signer Signer
+
+	csrController *framework.Controller
+}
+
+// Runs controller loops and returns immediately
+func (e *CertificateSigningController) Run() {
+	if e.stopChan == nil {
+		e.stopChan = make(chan struct{})
+		go e.csrController.Run(e.stopChan)
+	}
+}
+
+// Stop gracefully shuts down this controller
+func (e *CertificateSigningController) Stop() {
+	if e.stopChan != nil {
+		close(e.stopChan)
+		e.stopChan = nil
+	}
+}
+
+// csrAdded reacts to the creation of a certificate signing request
+func (e *CertificateSigningController) csrAdded(obj interface{}) {
+	csr := obj.(*certapi.CertificateSigningRequest)
+	if err := e.handleCertificateSigningRequest(csr); err != nil {
+		util.HandleError(err)
+	}
+}
+
+// csrUpdated reacts to the approval of a certificate signing request
+func (e *CertificateSigningController) csrUpdated(oldObj interface{}, newObj interface{}) {
+	csr := newObj.(*certapi.CertificateSigningRequest)
+	if err := e.handleCertificateSigningRequest(csr); err != nil {
+		util.HandleError(err)
+	}
+}
+
+// handleCertificateSigningRequest approves csr if it renews the client certificate of the requesting node, and issues the
+// certificate of approved requests
+func (e *CertificateSigningController) handleCertificateSigningRequest(obj *certapi.CertificateSigningRequest) error {
+	if len(obj.Status.Certificate) > 0 || certapi.IsDenied(obj) {
+		return nil
+	}
+
+	// the informer's copy must not be modified
+	copied, err := kapi.Scheme.Copy(obj)
+	if err != nil {
+		return err
+	}
+	csr := copied.(*certapi.CertificateSigningRequest)
+
+	request, err := certapi.ParseCertificateRequest(csr)
+	if err != nil {
+		return fmt.Errorf("unable to parse certificate signing request %s: %v", csr.Name, err)
+	}
+
+	if !certapi.IsApproved(csr) {
+		if !e.isNodeRequest(csr, request) {
+			return nil
+		}
+		csr.Status.Conditions = append(csr.Status.Conditions, certapi.CertificateSigningRequestCondition{
+			Type:           certapi.CertificateApproved,
+			Reason:         AutoApprovedReason,
+			Message:        fmt.Sprintf("%s requested a %s certificate for itself", csr.Spec.Username, csr.Spec.Usage),
+			LastUpdateTime: unversioned.Now(),
+		})
+		if csr, err = e.client.CertificateSigningRequests().UpdateApproval(csr); err != nil {
+			return err
+		}
+		glog.V(2).Infof("Approved certificate signing request %s from %s", csr.Name, csr.Spec.Username)
+	}
+
+	var certificate []byte
+	switch csr.Spec.Usage {
+	case certapi.CertificateUsageClient:
+		certificate, err = e.signer.SignClientCertificateRequest(request)
+	case certapi.CertificateUsageServing:
+		certificate, err = e.signer.SignServerCertificateRequest(request)
+	default:
+		return fmt.Errorf("certificate signing request %s has unknown usage %q", csr.Name, csr.Spec.Usage)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to sign certificate signing request %s: %v", csr.Name, err)
+	}
+
+	csr.Status.Certificate = certificate
+	if _, err := e.client.CertificateSigningRequests().UpdateStatus(csr); err != nil {
+		return err
+	}
+	glog.V(2).Infof("Issued a %s certificate for %s", csr.Spec.Usage, request.Subject.CommonName)
+	return nil
+}
+
+// isNodeRequest returns true if csr renews the client certificate of the node that made it. Serving certificates
+// are never approved automatically: the addresses a node reports in its status are set by the node itself, so they
+// cannot vouch for the names of a serving certificate, and cluster administrators approve those requests.
+func (e *CertificateSigningController) isNodeRequest(csr *certapi.CertificateSigningRequest, request *x509.CertificateRequest) bool {
+	return csr.Spec.Usage == certapi.CertificateUsageClient &&
+		csr.Spec.Username == request.Subject.CommonName &&
+		sets.NewString(csr.Spec.Groups...).Has(bootstrappolicy.NodesGroup)
+}
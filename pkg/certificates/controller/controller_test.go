@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
+)
+
+type fakeSigner struct{}
+
+func (fakeSigner) SignClientCertificateRequest(request *x509.CertificateRequest) ([]byte, error) {
+	return []byte("client:" + request.Subject.CommonName), nil
+}
+
+func (fakeSigner) SignServerCertificateRequest(request *x509.CertificateRequest) ([]byte, error) {
+	return []byte("serving:" + request.Subject.CommonName), nil
+}
+
+func newCertificateSigningRequest(t *testing.T, username string, groups []string, usage certapi.CertificateUsage, subject pkix.Name, hosts []string) *certapi.CertificateSigningRequest {
+	request, _, err := crypto.NewCertificateRequest(subject, hosts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &certapi.CertificateSigningRequest{
+		ObjectMeta: kapi.ObjectMeta{Name: "csr"},
+		Spec: certapi.CertificateSigningRequestSpec{
+			Request:  request,
+			Usage:    usage,
+			Username: username,
+			Groups:   groups,
+		},
+	}
+}
+
+func TestHandleCertificateSigningRequest(t *testing.T) {
+	nodeGroups := []string{"system:nodes", "system:authenticated"}
+	clientSubject := pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:nodes"}}
+	servingSubject := pkix.Name{CommonName: "system:node:node1"}
+
+	testCases := map[string]struct {
+		csr             *certapi.CertificateSigningRequest
+		decision        certapi.CertificateSigningRequestConditionType
+		expectedApprove bool
+		expectedCert    string
+	}{
+		"bootstrap request waits for approval": {
+			csr: newCertificateSigningRequest(t, "system:serviceaccount:openshift-infra:node-bootstrapper", []string{"system:serviceaccounts"}, certapi.CertificateUsageClient, clientSubject, nil),
+		},
+		"client renewal is approved": {
+			csr:             newCertificateSigningRequest(t, "system:node:node1", nodeGroups, certapi.CertificateUsageClient, clientSubject, nil),
+			expectedApprove: true,
+			expectedCert:    "client:system:node:node1",
+		},
+		"client request for another node waits for approval": {
+			csr: newCertificateSigningRequest(t, "system:node:node2", nodeGroups, certapi.CertificateUsageClient, clientSubject, nil),
+		},
+		"serving renewal waits for approval": {
+			csr: newCertificateSigningRequest(t, "system:node:node1", nodeGroups, certapi.CertificateUsageServing, servingSubject, []string{"node1", "10.0.0.1"}),
+		},
+		"approved serving request is signed": {
+			csr:          newCertificateSigningRequest(t, "system:node:node1", nodeGroups, certapi.CertificateUsageServing, servingSubject, []string{"node1", "10.0.0.1"}),
+			decision:     certapi.CertificateApproved,
+			expectedCert: "serving:system:node:node1",
+		},
+		"approved request is signed": {
+			csr:          newCertificateSigningRequest(t, "system:serviceaccount:openshift-infra:node-bootstrapper", []string{"system:serviceaccounts"}, certapi.CertificateUsageClient, clientSubject, nil),
+			decision:     certapi.CertificateApproved,
+			expectedCert: "client:system:node:node1",
+		},
+		"denied request is not signed": {
+			csr:      newCertificateSigningRequest(t, "system:node:node1", nodeGroups, certapi.CertificateUsageClient, clientSubject, nil),
+			decision: certapi.CertificateDenied,
+		},
+	}
+
+	for name, tc := range testCases {
+		if len(tc.decision) > 0 {
+			tc.csr.Status.Conditions = []certapi.CertificateSigningRequestCondition{{Type: tc.decision}}
+		}
+		client := &testclient.Fake{}
+		controller := NewCertificateSigningController(client, fakeSigner{}, CertificateSigningControllerOptions{})
+
+		if err := controller.handleCertificateSigningRequest(tc.csr); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+
+		approved, issued := false, ""
+		for _, action := range client.Actions() {
+			update, ok := action.(ktestclient.UpdateAction)
+			if !ok {
+				t.Errorf("%s: unexpected action: %#v", name, action)
+				continue
+			}
+			csr := update.GetObject().(*certapi.CertificateSigningRequest)
+			switch update.GetSubresource() {
+			case "approval":
+				approved = certapi.IsApproved(csr)
+			case "status":
+				issued = string(csr.Status.Certificate)
+			}
+		}
+		if approved != tc.expectedApprove {
+			t.Errorf("%s: expected approval %v, got %v", name, tc.expectedApprove, approved)
+		}
+		if issued != tc.expectedCert {
+			t.Errorf("%s: expected certificate %q, got %q", name, tc.expectedCert, issued)
+		}
+		if len(tc.csr.Status.Certificate) > 0 || len(tc.csr.Status.Conditions) > 1 {
+			t.Errorf("%s: the request passed to the controller was modified", name)
+		}
+	}
+}
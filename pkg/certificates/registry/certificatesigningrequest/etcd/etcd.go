@@ -0,0 +1,104 @@
+package etcd
+
+import (
+	"errors"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+
+	"github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/certificates/registry/certificatesigningrequest"
+)
+
+const etcdPrefix = "/registry/certificatesigningrequests"
+
+// REST implements a RESTStorage for certificate signing requests against etcd
+type REST struct {
+	*etcdgeneric.Etcd
+}
+
+// NewREST returns the RESTStorage objects for certificate signing requests, their approval
+// decisions, and their issued certificates
+func NewREST(s storage.Interface) (*REST, *ApprovalREST, *StatusREST) {
+	store := &etcdgeneric.Etcd{
+		NewFunc:     func() runtime.Object { return &api.CertificateSigningRequest{} },
+		NewListFunc: func() runtime.Object { return &api.CertificateSigningRequestList{} },
+		KeyRootFunc: func(ctx kapi.Context) string {
+			return etcdPrefix
+		},
+		KeyFunc: func(ctx kapi.Context, name string) (string, error) {
+			return etcdgeneric.NoNamespaceKeyFunc(ctx, etcdPrefix, name)
+		},
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return obj.(*api.CertificateSigningRequest).Name, nil
+		},
+		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+			return certificatesigningrequest.Matcher(label, field)
+		},
+		EndpointName: "certificatesigningrequest",
+
+		CreateStrategy: certificatesigningrequest.Strategy,
+		UpdateStrategy: certificatesigningrequest.Strategy,
+
+		Storage: s,
+	}
+
+	approvalStore := *store
+	approvalStore.UpdateStrategy = certificatesigningrequest.ApprovalStrategy
+
+	statusStore := *store
+	statusStore.UpdateStrategy = certificatesigningrequest.StatusStrategy
+
+	return &REST{store}, &ApprovalREST{&approvalStore}, &StatusREST{&statusStore}
+}
+
+// Create records the user making the request before storing it
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	csr, ok := obj.(*api.CertificateSigningRequest)
+	if !ok {
+		return nil, kerrors.NewBadRequest("not a CertificateSigningRequest")
+	}
+	user, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return nil, kerrors.NewForbidden("certificatesigningrequests", csr.Name, errors.New("the requesting user is unknown"))
+	}
+	csr.Spec.Username = user.GetName()
+	csr.Spec.Groups = user.GetGroups()
+	return r.Etcd.Create(ctx, csr)
+}
+
+// ApprovalREST implements the REST endpoint for approving or denying a certificate signing request
+type ApprovalREST struct {
+	store *etcdgeneric.Etcd
+}
+
+// New creates a new certificate signing request
+func (r *ApprovalREST) New() runtime.Object {
+	return &api.CertificateSigningRequest{}
+}
+
+// Update alters the approval decision of a request
+func (r *ApprovalREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
+	return r.store.Update(ctx, obj)
+}
+
+// StatusREST implements the REST endpoint for issuing the certificate of a certificate signing request
+type StatusREST struct {
+	store *etcdgeneric.Etcd
+}
+
+// New creates a new certificate signing request
+func (r *StatusREST) New() runtime.Object {
+	return &api.CertificateSigningRequest{}
+}
+
+// Update alters the issued certificate of a request
+func (r *StatusREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
+	return r.store.Update(ctx, obj)
+}
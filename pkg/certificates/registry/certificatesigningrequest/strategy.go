@@ -0,0 +1,110 @@
+package certificatesigningrequest
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/fielderrors"
+
+	"github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/certificates/api/validation"
+)
+
+// csrStrategy implements behavior for CertificateSigningRequests
+type csrStrategy struct {
+	runtime.ObjectTyper
+	kapi.NameGenerator
+}
+
+// Strategy is the default logic that applies when creating and updating CertificateSigningRequest
+// objects via the REST API. Updates may only change the metadata of a request.
+var Strategy = csrStrategy{kapi.Scheme, kapi.SimpleNameGenerator}
+
+// NamespaceScoped is false for certificate signing requests
+func (csrStrategy) NamespaceScoped() bool {
+	return false
+}
+
+// PrepareForCreate clears the status of a new request
+func (csrStrategy) PrepareForCreate(obj runtime.Object) {
+	csr := obj.(*api.CertificateSigningRequest)
+	csr.Status = api.CertificateSigningRequestStatus{}
+}
+
+// PrepareForUpdate keeps the spec and status of the request
+func (csrStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	csr := obj.(*api.CertificateSigningRequest)
+	oldCSR := old.(*api.CertificateSigningRequest)
+	csr.Spec = oldCSR.Spec
+	csr.Status = oldCSR.Status
+}
+
+// Validate validates a new request
+func (csrStrategy) Validate(ctx kapi.Context, obj runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateCertificateSigningRequest(obj.(*api.CertificateSigningRequest))
+}
+
+// AllowCreateOnUpdate is false for certificate signing requests
+func (csrStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (csrStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+// ValidateUpdate is the default update validation for a CertificateSigningRequest
+func (csrStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateCertificateSigningRequestUpdate(obj.(*api.CertificateSigningRequest), old.(*api.CertificateSigningRequest))
+}
+
+type csrApprovalStrategy struct {
+	csrStrategy
+}
+
+// ApprovalStrategy only allows the approval decision of a request to be updated
+var ApprovalStrategy = csrApprovalStrategy{Strategy}
+
+func (csrApprovalStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	csr := obj.(*api.CertificateSigningRequest)
+	oldCSR := old.(*api.CertificateSigningRequest)
+	csr.Spec = oldCSR.Spec
+	csr.Status.Certificate = oldCSR.Status.Certificate
+}
+
+func (csrApprovalStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateCertificateSigningRequestApprovalUpdate(obj.(*api.CertificateSigningRequest), old.(*api.CertificateSigningRequest))
+}
+
+type csrStatusStrategy struct {
+	csrStrategy
+}
+
+// StatusStrategy only allows the issued certificate of a request to be updated
+var StatusStrategy = csrStatusStrategy{Strategy}
+
+func (csrStatusStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	csr := obj.(*api.CertificateSigningRequest)
+	oldCSR := old.(*api.CertificateSigningRequest)
+	csr.Spec = oldCSR.Spec
+	csr.Status.Conditions = oldCSR.Status.Conditions
+}
+
+func (csrStatusStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateCertificateSigningRequestStatusUpdate(obj.(*api.CertificateSigningRequest), old.(*api.CertificateSigningRequest))
+}
+
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
+		csr, ok := obj.(*api.CertificateSigningRequest)
+		if !ok {
+			return false, fmt.Errorf("not a CertificateSigningRequest")
+		}
+		return label.Matches(labels.Set(csr.Labels)) && field.Matches(api.CertificateSigningRequestToSelectableFields(csr)), nil
+	})
+}
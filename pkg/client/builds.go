@@ -23,6 +23,7 @@ type BuildInterface interface {
 	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
 	Clone(request *buildapi.BuildRequest) (*buildapi.Build, error)
 	UpdateDetails(build *buildapi.Build) (*buildapi.Build, error)
+	Finalize(build *buildapi.Build) (*buildapi.Build, error)
 }
 
 // builds implements BuildsNamespacer interface
@@ -106,3 +107,11 @@ func (c *builds) UpdateDetails(build *buildapi.Build) (result *buildapi.Build, e
 	err = c.r.Put().Namespace(c.ns).Resource("builds").Name(build.Name).SubResource("details").Body(build).Do().Into(result)
 	return
 }
+
+// Finalize updates the finalizers of a given build.
+// Returns the server's representation of the build and error if one occurs.
+func (c *builds) Finalize(build *buildapi.Build) (result *buildapi.Build, err error) {
+	result = &buildapi.Build{}
+	err = c.r.Put().Namespace(c.ns).Resource("builds").Name(build.Name).SubResource("finalize").Body(build).Do().Into(result)
+	return
+}
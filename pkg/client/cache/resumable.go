@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	kcache "k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// ResumableListWatch is a ListerWatcher that lets a reflector resume watching from where its last watch
+// stopped instead of listing the whole collection from the server again. A reflector lists again every time
+// its watch ends - when the server times the watch out, when the connection drops and on every resync - which
+// for large collections of builds or image streams is far more expensive than the watch itself.
+//
+// The objects returned by the last real List are kept up to date with the events of the watches that follow
+// it, and the resource version of every event is checkpointed. A List is answered from that copy, at the
+// checkpointed resource version, so the next watch resumes right after the last event that was seen. The
+// collection is listed from the server again when a watch fails, for instance because the checkpointed
+// resource version is too old to resume from, or when the last real List is older than maxAge. The objects
+// are copied when they are recorded and when they are listed, since the handlers of the reflector's store may
+// modify the objects they are given.
+type ResumableListWatch struct {
+	lw     kcache.ListerWatcher
+	maxAge time.Duration
+	now    func() time.Time
+
+	lock sync.Mutex
+	// list is the object returned by the last real List, which is reused to return the current items
+	list  runtime.Object
+	items kcache.Store
+	// resourceVersion is the resource version of the last event seen
+	resourceVersion string
+	// listed is when the collection was last listed from the server
+	listed time.Time
+	// valid is false when the next List must go to the server
+	valid bool
+	// generation is incremented on every real List so that events of watches started before it are ignored
+	generation int
+}
+
+// ResumableListWatch implements kcache.ListerWatcher
+var _ kcache.ListerWatcher = &ResumableListWatch{}
+
+// NewResumableListWatch returns a ResumableListWatch that wraps lw and lists from the server at least every
+// maxAge.
+func NewResumableListWatch(lw kcache.ListerWatcher, maxAge time.Duration) *ResumableListWatch {
+	return &ResumableListWatch{
+		lw:     lw,
+		maxAge: maxAge,
+		now:    time.Now,
+		items:  kcache.NewStore(kcache.MetaNamespaceKeyFunc),
+	}
+}
+
+// List returns the objects seen through the last watch at the resource version of the last event, or lists
+// the collection from the server if watching cannot be resumed.
+func (r *ResumableListWatch) List() (runtime.Object, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.valid && r.now().Sub(r.listed) < r.maxAge {
+		list, err := kapi.Scheme.Copy(r.list)
+		if err != nil {
+			return nil, err
+		}
+		items := []runtime.Object{}
+		for _, item := range r.items.List() {
+			copied, err := kapi.Scheme.Copy(item.(runtime.Object))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, copied)
+		}
+		if err := runtime.SetList(list, items); err != nil {
+			return nil, err
+		}
+		listMeta, err := meta.Accessor(list)
+		if err != nil {
+			return nil, err
+		}
+		listMeta.SetResourceVersion(r.resourceVersion)
+		glog.V(5).Infof("Resuming watch of %T at resource version %s", list, r.resourceVersion)
+		return list, nil
+	}
+
+	r.valid = false
+	r.generation++
+	list, err := r.lw.List()
+	if err != nil {
+		return nil, err
+	}
+	listMeta, err := meta.Accessor(list)
+	if err != nil {
+		return nil, err
+	}
+	items, err := runtime.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		copied, err := kapi.Scheme.Copy(item)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, copied)
+	}
+	if err := r.items.Replace(objects, listMeta.ResourceVersion()); err != nil {
+		return nil, err
+	}
+	if r.list, err = kapi.Scheme.Copy(list); err != nil {
+		return nil, err
+	}
+	r.resourceVersion = listMeta.ResourceVersion()
+	r.listed = r.now()
+	r.valid = true
+	return list, nil
+}
+
+// Watch watches the collection from resourceVersion, recording the events it delivers.
+func (r *ResumableListWatch) Watch(resourceVersion string) (watch.Interface, error) {
+	r.lock.Lock()
+	generation := r.generation
+	r.lock.Unlock()
+
+	w, err := r.lw.Watch(resourceVersion)
+	if err != nil {
+		r.invalidate(generation)
+		return nil, err
+	}
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		r.record(generation, event)
+		return event, true
+	}), nil
+}
+
+// record applies event to the items of generation and checkpoints its resource version
+func (r *ResumableListWatch) record(generation int, event watch.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if generation != r.generation || !r.valid {
+		return
+	}
+	if event.Type == watch.Error {
+		r.valid = false
+		return
+	}
+	obj, err := kapi.Scheme.Copy(event.Object)
+	if err != nil {
+		r.valid = false
+		return
+	}
+	eventMeta, err := meta.Accessor(obj)
+	if err != nil {
+		r.valid = false
+		return
+	}
+	switch event.Type {
+	case watch.Added, watch.Modified:
+		err = r.items.Add(obj)
+	case watch.Deleted:
+		err = r.items.Delete(obj)
+	}
+	if err != nil {
+		r.valid = false
+		return
+	}
+	r.resourceVersion = eventMeta.ResourceVersion()
+}
+
+// invalidate makes the next List go to the server if no List happened since generation
+func (r *ResumableListWatch) invalidate(generation int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if generation == r.generation {
+		r.valid = false
+	}
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+type fakeListWatch struct {
+	lists   int
+	watches []string
+	watcher *watch.FakeWatcher
+	err     error
+}
+
+func (f *fakeListWatch) List() (runtime.Object, error) {
+	f.lists++
+	return &kapi.PodList{
+		ListMeta: unversioned.ListMeta{ResourceVersion: "10"},
+		Items:    []kapi.Pod{pod("a", "5"), pod("b", "10")},
+	}, nil
+}
+
+func (f *fakeListWatch) Watch(resourceVersion string) (watch.Interface, error) {
+	f.watches = append(f.watches, resourceVersion)
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.watcher = watch.NewFake()
+	return f.watcher, nil
+}
+
+func pod(name, resourceVersion string) kapi.Pod {
+	return kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: "test", ResourceVersion: resourceVersion}}
+}
+
+// watchEvent sends event through the watch of r and waits until it was recorded
+func watchEvent(t *testing.T, fake *fakeListWatch, w watch.Interface, eventType watch.EventType, obj runtime.Object) {
+	fake.watcher.Action(eventType, obj)
+	select {
+	case <-w.ResultChan():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the %s event", eventType)
+	}
+}
+
+func listedPods(t *testing.T, r *ResumableListWatch) (string, []string) {
+	list, err := r.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pods := list.(*kapi.PodList)
+	names := []string{}
+	for _, pod := range pods.Items {
+		names = append(names, pod.Name)
+	}
+	return pods.ResourceVersion, names
+}
+
+func TestResumableListWatchResumes(t *testing.T) {
+	fake := &fakeListWatch{}
+	r := NewResumableListWatch(fake, time.Hour)
+
+	if resourceVersion, names := listedPods(t, r); resourceVersion != "10" || len(names) != 2 {
+		t.Fatalf("unexpected list at %s: %v", resourceVersion, names)
+	}
+	w, err := r.Watch("10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := pod("c", "11")
+	watchEvent(t, fake, w, watch.Added, &c)
+	a := pod("a", "12")
+	watchEvent(t, fake, w, watch.Deleted, &a)
+	w.Stop()
+
+	resourceVersion, names := listedPods(t, r)
+	if fake.lists != 1 {
+		t.Errorf("expected the list to be served from the watched objects, listed %d times", fake.lists)
+	}
+	if resourceVersion != "12" {
+		t.Errorf("expected the list at the resource version of the last event, got %s", resourceVersion)
+	}
+	if len(names) != 2 {
+		t.Errorf("unexpected pods: %v", names)
+	}
+	for _, name := range names {
+		if name == "a" {
+			t.Errorf("expected the deleted pod to be gone: %v", names)
+		}
+	}
+}
+
+func TestResumableListWatchRelists(t *testing.T) {
+	now := time.Now()
+	fake := &fakeListWatch{}
+	r := NewResumableListWatch(fake, time.Hour)
+	r.now = func() time.Time { return now }
+
+	listedPods(t, r)
+	w, err := r.Watch("10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	watchEvent(t, fake, w, watch.Error, &unversioned.Status{Code: 410})
+	if listedPods(t, r); fake.lists != 2 {
+		t.Errorf("expected a list from the server after a watch error, listed %d times", fake.lists)
+	}
+
+	fake.err = errors.New("too old resource version")
+	if _, err := r.Watch("10"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if listedPods(t, r); fake.lists != 3 {
+		t.Errorf("expected a list from the server after a failed watch, listed %d times", fake.lists)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if listedPods(t, r); fake.lists != 4 {
+		t.Errorf("expected a list from the server once the last list is too old, listed %d times", fake.lists)
+	}
+}
+
+func TestResumableListWatchCopies(t *testing.T) {
+	fake := &fakeListWatch{}
+	r := NewResumableListWatch(fake, time.Hour)
+
+	list, err := r.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list.(*kapi.PodList).Items[0].Labels = map[string]string{"listed": "true"}
+
+	w, err := r.Watch("10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := pod("c", "11")
+	watchEvent(t, fake, w, watch.Added, &c)
+	w.Stop()
+	c.Labels = map[string]string{"watched": "true"}
+
+	resumed, err := r.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, pod := range resumed.(*kapi.PodList).Items {
+		if len(pod.Labels) != 0 {
+			t.Errorf("expected the recorded pods not to share the objects handed out, got %#v", pod)
+		}
+	}
+	resumed.(*kapi.PodList).Items[0].Labels = map[string]string{"resumed": "true"}
+
+	again, err := r.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again == resumed {
+		t.Errorf("expected a new list to be returned")
+	}
+	for _, pod := range again.(*kapi.PodList).Items {
+		if len(pod.Labels) != 0 {
+			t.Errorf("expected the listed pods not to be modified by the previous list, got %#v", pod)
+		}
+	}
+	if fake.lists != 1 {
+		t.Errorf("expected a single list from the server, got %d", fake.lists)
+	}
+}
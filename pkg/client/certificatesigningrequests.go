@@ -0,0 +1,93 @@
+package client
+
+import (
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+)
+
+// CertificateSigningRequestsInterface has methods to work with CertificateSigningRequest resources
+type CertificateSigningRequestsInterface interface {
+	CertificateSigningRequests() CertificateSigningRequestInterface
+}
+
+// CertificateSigningRequestInterface exposes methods on CertificateSigningRequest resources.
+type CertificateSigningRequestInterface interface {
+	List(label labels.Selector, field fields.Selector) (*certapi.CertificateSigningRequestList, error)
+	Get(name string) (*certapi.CertificateSigningRequest, error)
+	Create(csr *certapi.CertificateSigningRequest) (*certapi.CertificateSigningRequest, error)
+	UpdateApproval(csr *certapi.CertificateSigningRequest) (*certapi.CertificateSigningRequest, error)
+	UpdateStatus(csr *certapi.CertificateSigningRequest) (*certapi.CertificateSigningRequest, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// certificateSigningRequests implements CertificateSigningRequestInterface interface
+type certificateSigningRequests struct {
+	r *Client
+}
+
+// newCertificateSigningRequests returns a certificateSigningRequests
+func newCertificateSigningRequests(c *Client) *certificateSigningRequests {
+	return &certificateSigningRequests{
+		r: c,
+	}
+}
+
+// List returns a list of certificate signing requests that match the label and field selectors.
+func (c *certificateSigningRequests) List(label labels.Selector, field fields.Selector) (result *certapi.CertificateSigningRequestList, err error) {
+	result = &certapi.CertificateSigningRequestList{}
+	err = c.r.Get().
+		Resource("certificateSigningRequests").
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get returns information about a particular certificate signing request or an error
+func (c *certificateSigningRequests) Get(name string) (result *certapi.CertificateSigningRequest, err error) {
+	result = &certapi.CertificateSigningRequest{}
+	err = c.r.Get().Resource("certificateSigningRequests").Name(name).Do().Into(result)
+	return
+}
+
+// Create creates a new certificate signing request. Returns the server's representation of the request and error if one occurs.
+func (c *certificateSigningRequests) Create(csr *certapi.CertificateSigningRequest) (result *certapi.CertificateSigningRequest, err error) {
+	result = &certapi.CertificateSigningRequest{}
+	err = c.r.Post().Resource("certificateSigningRequests").Body(csr).Do().Into(result)
+	return
+}
+
+// UpdateApproval updates the approval decision of a certificate signing request
+func (c *certificateSigningRequests) UpdateApproval(csr *certapi.CertificateSigningRequest) (result *certapi.CertificateSigningRequest, err error) {
+	result = &certapi.CertificateSigningRequest{}
+	err = c.r.Put().Resource("certificateSigningRequests").Name(csr.Name).SubResource("approval").Body(csr).Do().Into(result)
+	return
+}
+
+// UpdateStatus updates the issued certificate of a certificate signing request
+func (c *certificateSigningRequests) UpdateStatus(csr *certapi.CertificateSigningRequest) (result *certapi.CertificateSigningRequest, err error) {
+	result = &certapi.CertificateSigningRequest{}
+	err = c.r.Put().Resource("certificateSigningRequests").Name(csr.Name).SubResource("status").Body(csr).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the certificate signing request, and returns an error if one occurs during deletion
+func (c *certificateSigningRequests) Delete(name string) error {
+	return c.r.Delete().Resource("certificateSigningRequests").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested certificate signing requests
+func (c *certificateSigningRequests) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.r.Get().
+		Prefix("watch").
+		Resource("certificateSigningRequests").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Watch()
+}
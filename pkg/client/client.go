@@ -29,6 +29,7 @@ type Interface interface {
 	HostSubnetsInterface
 	NetNamespacesInterface
 	ClusterNetworkingInterface
+	CertificateSigningRequestsInterface
 	IdentitiesInterface
 	UsersInterface
 	GroupsInterface
@@ -44,6 +45,9 @@ type Interface interface {
 	TemplatesNamespacer
 	TemplateConfigsNamespacer
 	OAuthAccessTokensInterface
+	OAuthAuthorizeTokensInterface
+	OAuthClientAuthorizationsInterface
+	UserOAuthClientAuthorizationsInterface
 	PoliciesNamespacer
 	PolicyBindingsNamespacer
 	RolesNamespacer
@@ -124,6 +128,11 @@ func (c *Client) ClusterNetwork() ClusterNetworkInterface {
 	return newClusterNetwork(c)
 }
 
+// CertificateSigningRequests provides a REST client for CertificateSigningRequest
+func (c *Client) CertificateSigningRequests() CertificateSigningRequestInterface {
+	return newCertificateSigningRequests(c)
+}
+
 // Users provides a REST client for User
 func (c *Client) Users() UserInterface {
 	return newUsers(c)
@@ -219,6 +228,21 @@ func (c *Client) OAuthAccessTokens() OAuthAccessTokenInterface {
 	return newOAuthAccessTokens(c)
 }
 
+// OAuthAuthorizeTokens provides a REST client for OAuthAuthorizeTokens
+func (c *Client) OAuthAuthorizeTokens() OAuthAuthorizeTokenInterface {
+	return newOAuthAuthorizeTokens(c)
+}
+
+// OAuthClientAuthorizations provides a REST client for OAuthClientAuthorizations
+func (c *Client) OAuthClientAuthorizations() OAuthClientAuthorizationInterface {
+	return newOAuthClientAuthorizations(c)
+}
+
+// UserOAuthClientAuthorizations provides a REST client for the OAuthClientAuthorizations of the current user
+func (c *Client) UserOAuthClientAuthorizations() UserOAuthClientAuthorizationInterface {
+	return newUserOAuthClientAuthorizations(c)
+}
+
 func (c *Client) ClusterPolicies() ClusterPolicyInterface {
 	return newClusterPolicies(c)
 }
@@ -0,0 +1,90 @@
+package client
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+// DefaultConflictRetry is the backoff used by UpdateWithRetries between attempts
+// rejected because of a resourceVersion conflict.
+var DefaultConflictRetry = kclient.DefaultRetry
+
+// UpdateWithRetries implements the optimistic concurrency get-mutate-update loop
+// shared by controllers. mutateUpdate applies the desired changes to the object in
+// hand and sends the update to the server. If the server rejects the update with a
+// conflict, refresh is invoked to replace the object in hand with its latest version
+// and mutateUpdate is tried again. Any other error is returned immediately; if the
+// retries are exhausted the last conflict error is returned.
+func UpdateWithRetries(refresh, mutateUpdate func() error) error {
+	attempt := 0
+	return kclient.RetryOnConflict(DefaultConflictRetry, func() error {
+		if attempt > 0 {
+			if err := refresh(); err != nil {
+				return err
+			}
+		}
+		attempt++
+		return mutateUpdate()
+	})
+}
+
+// UpdateDeploymentConfigWithRetries applies mutateFn to config and updates it,
+// reapplying mutateFn to the latest version of the config on conflicts. The config
+// returned by the server is returned on success.
+func UpdateDeploymentConfigWithRetries(c DeploymentConfigsNamespacer, config *deployapi.DeploymentConfig, mutateFn func(*deployapi.DeploymentConfig)) (*deployapi.DeploymentConfig, error) {
+	current, updated := config, config
+	err := UpdateWithRetries(
+		func() (err error) {
+			current, err = c.DeploymentConfigs(config.Namespace).Get(config.Name)
+			return
+		},
+		func() (err error) {
+			mutateFn(current)
+			updated, err = c.DeploymentConfigs(current.Namespace).Update(current)
+			return
+		},
+	)
+	return updated, err
+}
+
+// UpdateReplicationControllerWithRetries applies mutateFn to rc and updates it with
+// update, reapplying mutateFn to the latest version of the rc retrieved with get on
+// conflicts. The rc is not updated if mutateFn returns false.
+func UpdateReplicationControllerWithRetries(get func(namespace, name string) (*kapi.ReplicationController, error), update func(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error), rc *kapi.ReplicationController, mutateFn func(*kapi.ReplicationController) bool) error {
+	current := rc
+	return UpdateWithRetries(
+		func() (err error) {
+			current, err = get(rc.Namespace, rc.Name)
+			return
+		},
+		func() error {
+			if !mutateFn(current) {
+				return nil
+			}
+			_, err := update(current.Namespace, current)
+			return err
+		},
+	)
+}
+
+// UpdatePodWithRetries applies mutateFn to pod and updates it with update, reapplying
+// mutateFn to the latest version of the pod retrieved with get on conflicts. The pod
+// is not updated if mutateFn returns false.
+func UpdatePodWithRetries(get func(namespace, name string) (*kapi.Pod, error), update func(namespace string, pod *kapi.Pod) (*kapi.Pod, error), pod *kapi.Pod, mutateFn func(*kapi.Pod) bool) error {
+	current := pod
+	return UpdateWithRetries(
+		func() (err error) {
+			current, err = get(pod.Namespace, pod.Name)
+			return
+		},
+		func() error {
+			if !mutateFn(current) {
+				return nil
+			}
+			_, err := update(current.Namespace, current)
+			return err
+		},
+	)
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+)
+
+func TestUpdateWithRetries(t *testing.T) {
+	conflict := kerrors.NewConflict("build", "test", errors.New("stale"))
+	other := errors.New("other")
+
+	tests := []struct {
+		name            string
+		updateErrs      []error
+		expectedErr     error
+		expectedUpdates int
+		expectedGets    int
+	}{
+		{
+			name:            "no conflict",
+			updateErrs:      []error{nil},
+			expectedUpdates: 1,
+		},
+		{
+			name:            "conflict then success",
+			updateErrs:      []error{conflict, conflict, nil},
+			expectedUpdates: 3,
+			expectedGets:    2,
+		},
+		{
+			name:            "non-conflict error",
+			updateErrs:      []error{other},
+			expectedErr:     other,
+			expectedUpdates: 1,
+		},
+		{
+			name:            "retries exhausted",
+			updateErrs:      []error{conflict, conflict, conflict, conflict, conflict, conflict},
+			expectedErr:     conflict,
+			expectedUpdates: DefaultConflictRetry.Steps,
+			expectedGets:    DefaultConflictRetry.Steps - 1,
+		},
+	}
+
+	for _, test := range tests {
+		gets, updates := 0, 0
+		err := UpdateWithRetries(
+			func() error {
+				gets++
+				return nil
+			},
+			func() error {
+				err := test.updateErrs[updates]
+				updates++
+				return err
+			},
+		)
+		if err != test.expectedErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		}
+		if updates != test.expectedUpdates {
+			t.Errorf("%s: expected %d updates, got %d", test.name, test.expectedUpdates, updates)
+		}
+		if gets != test.expectedGets {
+			t.Errorf("%s: expected %d gets, got %d", test.name, test.expectedGets, gets)
+		}
+	}
+}
+
+func TestUpdateReplicationControllerWithRetries(t *testing.T) {
+	rc := &kapi.ReplicationController{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "rc"}}
+	latest := &kapi.ReplicationController{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "rc", ResourceVersion: "2"}}
+
+	tests := []struct {
+		name            string
+		mutate          bool
+		expectedUpdates []string
+	}{
+		{
+			name:            "updated on conflict",
+			mutate:          true,
+			expectedUpdates: []string{"", "2"},
+		},
+		{
+			name: "left alone",
+		},
+	}
+
+	for _, test := range tests {
+		updates := []string{}
+		err := UpdateReplicationControllerWithRetries(
+			func(namespace, name string) (*kapi.ReplicationController, error) {
+				if namespace != rc.Namespace || name != rc.Name {
+					t.Errorf("%s: unexpected get of %s/%s", test.name, namespace, name)
+				}
+				return latest, nil
+			},
+			func(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+				updates = append(updates, rc.ResourceVersion)
+				if len(updates) == 1 {
+					return nil, kerrors.NewConflict("ReplicationController", rc.Name, errors.New("stale"))
+				}
+				return rc, nil
+			},
+			rc,
+			func(rc *kapi.ReplicationController) bool {
+				rc.Spec.Replicas = 3
+				return test.mutate
+			},
+		)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if len(updates) != len(test.expectedUpdates) || (len(updates) > 0 && !reflect.DeepEqual(updates, test.expectedUpdates)) {
+			t.Errorf("%s: expected updates of versions %v, got %v", test.name, test.expectedUpdates, updates)
+		}
+	}
+}
+
+func TestUpdatePodWithRetries(t *testing.T) {
+	pod := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "pod"}}
+	latest := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "pod", ResourceVersion: "2"}}
+
+	updates := []*kapi.Pod{}
+	err := UpdatePodWithRetries(
+		func(namespace, name string) (*kapi.Pod, error) {
+			return latest, nil
+		},
+		func(namespace string, pod *kapi.Pod) (*kapi.Pod, error) {
+			updates = append(updates, pod)
+			if len(updates) == 1 {
+				return nil, kerrors.NewConflict("Pod", pod.Name, errors.New("stale"))
+			}
+			return pod, nil
+		},
+		pod,
+		func(pod *kapi.Pod) bool {
+			deadline := int64(1)
+			pod.Spec.ActiveDeadlineSeconds = &deadline
+			return true
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updates) != 2 || updates[1] != latest {
+		t.Fatalf("expected the latest version of the pod to be updated after the conflict, got %#v", updates)
+	}
+	if latest.Spec.ActiveDeadlineSeconds == nil || *latest.Spec.ActiveDeadlineSeconds != 1 {
+		t.Errorf("expected the deadline to be set on the latest version of the pod, got %#v", latest.Spec)
+	}
+}
@@ -1,5 +1,12 @@
 package client
 
+import (
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
 // OAuthAccessTokensInterface has methods to work with OAuthAccessTokens resources in a namespace
 type OAuthAccessTokensInterface interface {
 	OAuthAccessTokens() OAuthAccessTokenInterface
@@ -7,6 +14,7 @@ type OAuthAccessTokensInterface interface {
 
 // OAuthAccessTokenInterface exposes methods on OAuthAccessTokens resources.
 type OAuthAccessTokenInterface interface {
+	List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthAccessTokenList, error)
 	Delete(name string) error
 }
 
@@ -20,6 +28,18 @@ func newOAuthAccessTokens(c *Client) *oauthAccessTokenInterface {
 	}
 }
 
+// List returns a list of OAuthAccessTokens that match the label and field selectors.
+func (c *oauthAccessTokenInterface) List(label labels.Selector, field fields.Selector) (result *oauthapi.OAuthAccessTokenList, err error) {
+	result = &oauthapi.OAuthAccessTokenList{}
+	err = c.r.Get().
+		Resource("oAuthAccessTokens").
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete removes the OAuthAccessToken on server
 func (c *oauthAccessTokenInterface) Delete(name string) (err error) {
 	err = c.r.Delete().Resource("oAuthAccessTokens").Name(name).Do().Error()
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// OAuthClientAuthorizationsInterface has methods to work with OAuthClientAuthorizations resources
+type OAuthClientAuthorizationsInterface interface {
+	OAuthClientAuthorizations() OAuthClientAuthorizationInterface
+}
+
+// OAuthClientAuthorizationInterface exposes methods on OAuthClientAuthorizations resources.
+type OAuthClientAuthorizationInterface interface {
+	List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthClientAuthorizationList, error)
+	Delete(name string) error
+}
+
+type oauthClientAuthorizations struct {
+	r *Client
+}
+
+func newOAuthClientAuthorizations(c *Client) *oauthClientAuthorizations {
+	return &oauthClientAuthorizations{
+		r: c,
+	}
+}
+
+// List returns a list of OAuthClientAuthorizations that match the label and field selectors.
+func (c *oauthClientAuthorizations) List(label labels.Selector, field fields.Selector) (result *oauthapi.OAuthClientAuthorizationList, err error) {
+	result = &oauthapi.OAuthClientAuthorizationList{}
+	err = c.r.Get().
+		Resource("oAuthClientAuthorizations").
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete removes the OAuthClientAuthorization on server
+func (c *oauthClientAuthorizations) Delete(name string) (err error) {
+	err = c.r.Delete().Resource("oAuthClientAuthorizations").Name(name).Do().Error()
+	return
+}
@@ -0,0 +1,19 @@
+package testclient
+
+import (
+	"errors"
+
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+)
+
+// NewConflicts returns a function that returns a conflict error for its first n calls and nil afterwards. Fake
+// clients call it on updates to reject them like the server rejects the updates of stale objects.
+func NewConflicts(n int) func(kind, name string) error {
+	return func(kind, name string) error {
+		if n <= 0 {
+			return nil
+		}
+		n--
+		return kerrors.NewConflict(kind, name, errors.New("the object has been modified"))
+	}
+}
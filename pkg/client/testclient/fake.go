@@ -190,6 +190,11 @@ func (c *Fake) ClusterNetwork() client.ClusterNetworkInterface {
 	return &FakeClusterNetwork{Fake: c}
 }
 
+// CertificateSigningRequests provides a fake REST client for CertificateSigningRequests
+func (c *Fake) CertificateSigningRequests() client.CertificateSigningRequestInterface {
+	return &FakeCertificateSigningRequests{Fake: c}
+}
+
 // Templates provides a fake REST client for Templates
 func (c *Fake) Templates(namespace string) client.TemplateInterface {
 	return &FakeTemplates{Fake: c, Namespace: namespace}
@@ -275,6 +280,21 @@ func (c *Fake) OAuthAccessTokens() client.OAuthAccessTokenInterface {
 	return &FakeOAuthAccessTokens{Fake: c}
 }
 
+// OAuthAuthorizeTokens provides a fake REST client for OAuthAuthorizeTokens
+func (c *Fake) OAuthAuthorizeTokens() client.OAuthAuthorizeTokenInterface {
+	return &FakeOAuthAuthorizeTokens{Fake: c}
+}
+
+// OAuthClientAuthorizations provides a fake REST client for OAuthClientAuthorizations
+func (c *Fake) OAuthClientAuthorizations() client.OAuthClientAuthorizationInterface {
+	return &FakeOAuthClientAuthorizations{Fake: c}
+}
+
+// UserOAuthClientAuthorizations provides a fake REST client for the OAuthClientAuthorizations of the current user
+func (c *Fake) UserOAuthClientAuthorizations() client.UserOAuthClientAuthorizationInterface {
+	return &FakeUserOAuthClientAuthorizations{Fake: c}
+}
+
 // LocalSubjectAccessReviews provides a fake REST client for SubjectAccessReviews
 func (c *Fake) LocalSubjectAccessReviews(namespace string) client.LocalSubjectAccessReviewInterface {
 	return &FakeLocalSubjectAccessReviews{Fake: c}
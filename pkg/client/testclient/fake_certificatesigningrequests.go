@@ -0,0 +1,70 @@
+package testclient
+
+import (
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+)
+
+// FakeCertificateSigningRequests implements CertificateSigningRequestInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the methods you want to test easier.
+type FakeCertificateSigningRequests struct {
+	Fake *Fake
+}
+
+func (c *FakeCertificateSigningRequests) Get(name string) (*certapi.CertificateSigningRequest, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootGetAction("certificatesigningrequests", name), &certapi.CertificateSigningRequest{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*certapi.CertificateSigningRequest), err
+}
+
+func (c *FakeCertificateSigningRequests) List(label labels.Selector, field fields.Selector) (*certapi.CertificateSigningRequestList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("certificatesigningrequests", label, field), &certapi.CertificateSigningRequestList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*certapi.CertificateSigningRequestList), err
+}
+
+func (c *FakeCertificateSigningRequests) Create(inObj *certapi.CertificateSigningRequest) (*certapi.CertificateSigningRequest, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootCreateAction("certificatesigningrequests", inObj), inObj)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*certapi.CertificateSigningRequest), err
+}
+
+func (c *FakeCertificateSigningRequests) UpdateApproval(inObj *certapi.CertificateSigningRequest) (*certapi.CertificateSigningRequest, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewUpdateSubresourceAction("certificatesigningrequests", "approval", "", inObj), inObj)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*certapi.CertificateSigningRequest), err
+}
+
+func (c *FakeCertificateSigningRequests) UpdateStatus(inObj *certapi.CertificateSigningRequest) (*certapi.CertificateSigningRequest, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewUpdateSubresourceAction("certificatesigningrequests", "status", "", inObj), inObj)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*certapi.CertificateSigningRequest), err
+}
+
+func (c *FakeCertificateSigningRequests) Delete(name string) error {
+	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("certificatesigningrequests", name), &certapi.CertificateSigningRequest{})
+	return err
+}
+
+func (c *FakeCertificateSigningRequests) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.Fake.InvokesWatch(ktestclient.NewRootWatchAction("certificatesigningrequests", label, field, resourceVersion))
+}
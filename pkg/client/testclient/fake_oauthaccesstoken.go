@@ -2,6 +2,8 @@ package testclient
 
 import (
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
 
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 )
@@ -12,6 +14,15 @@ type FakeOAuthAccessTokens struct {
 	Fake *Fake
 }
 
+func (c *FakeOAuthAccessTokens) List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthAccessTokenList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("oauthaccesstokens", label, field), &oauthapi.OAuthAccessTokenList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.OAuthAccessTokenList), err
+}
+
 func (c *FakeOAuthAccessTokens) Delete(name string) error {
 	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("oauthaccesstokens", name), &oauthapi.OAuthAccessToken{})
 	return err
@@ -0,0 +1,29 @@
+package testclient
+
+import (
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// FakeOAuthAuthorizeTokens implements OAuthAuthorizeTokenInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the methods you want to test easier.
+type FakeOAuthAuthorizeTokens struct {
+	Fake *Fake
+}
+
+func (c *FakeOAuthAuthorizeTokens) List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthAuthorizeTokenList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("oauthauthorizetokens", label, field), &oauthapi.OAuthAuthorizeTokenList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.OAuthAuthorizeTokenList), err
+}
+
+func (c *FakeOAuthAuthorizeTokens) Delete(name string) error {
+	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("oauthauthorizetokens", name), &oauthapi.OAuthAuthorizeToken{})
+	return err
+}
@@ -0,0 +1,29 @@
+package testclient
+
+import (
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// FakeOAuthClientAuthorizations implements OAuthClientAuthorizationInterface. Meant to be embedded into a struct to
+// get a default implementation. This makes faking out just the methods you want to test easier.
+type FakeOAuthClientAuthorizations struct {
+	Fake *Fake
+}
+
+func (c *FakeOAuthClientAuthorizations) List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthClientAuthorizationList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("oauthclientauthorizations", label, field), &oauthapi.OAuthClientAuthorizationList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.OAuthClientAuthorizationList), err
+}
+
+func (c *FakeOAuthClientAuthorizations) Delete(name string) error {
+	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("oauthclientauthorizations", name), &oauthapi.OAuthClientAuthorization{})
+	return err
+}
@@ -0,0 +1,38 @@
+package testclient
+
+import (
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// FakeUserOAuthClientAuthorizations implements UserOAuthClientAuthorizationInterface. Meant to be embedded into a struct to
+// get a default implementation. This makes faking out just the methods you want to test easier.
+type FakeUserOAuthClientAuthorizations struct {
+	Fake *Fake
+}
+
+func (c *FakeUserOAuthClientAuthorizations) List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthClientAuthorizationList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("useroauthclientauthorizations", label, field), &oauthapi.OAuthClientAuthorizationList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.OAuthClientAuthorizationList), err
+}
+
+func (c *FakeUserOAuthClientAuthorizations) Get(clientName string) (*oauthapi.OAuthClientAuthorization, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootGetAction("useroauthclientauthorizations", clientName), &oauthapi.OAuthClientAuthorization{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.OAuthClientAuthorization), err
+}
+
+func (c *FakeUserOAuthClientAuthorizations) Delete(clientName string) error {
+	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("useroauthclientauthorizations", clientName), &oauthapi.OAuthClientAuthorization{})
+	return err
+}
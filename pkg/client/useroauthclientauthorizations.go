@@ -0,0 +1,56 @@
+package client
+
+import (
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// UserOAuthClientAuthorizationsInterface has methods to work with the OAuthClientAuthorizations of the current user
+type UserOAuthClientAuthorizationsInterface interface {
+	UserOAuthClientAuthorizations() UserOAuthClientAuthorizationInterface
+}
+
+// UserOAuthClientAuthorizationInterface exposes methods on the OAuthClientAuthorizations of the current user. They
+// are addressed by the name of their client.
+type UserOAuthClientAuthorizationInterface interface {
+	List(label labels.Selector, field fields.Selector) (*oauthapi.OAuthClientAuthorizationList, error)
+	Get(clientName string) (*oauthapi.OAuthClientAuthorization, error)
+	Delete(clientName string) error
+}
+
+type userOAuthClientAuthorizations struct {
+	r *Client
+}
+
+func newUserOAuthClientAuthorizations(c *Client) *userOAuthClientAuthorizations {
+	return &userOAuthClientAuthorizations{
+		r: c,
+	}
+}
+
+// List returns the OAuthClientAuthorizations of the current user that match the label and field selectors.
+func (c *userOAuthClientAuthorizations) List(label labels.Selector, field fields.Selector) (result *oauthapi.OAuthClientAuthorizationList, err error) {
+	result = &oauthapi.OAuthClientAuthorizationList{}
+	err = c.r.Get().
+		Resource("userOAuthClientAuthorizations").
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get returns the OAuthClientAuthorization of the current user for the named client
+func (c *userOAuthClientAuthorizations) Get(clientName string) (result *oauthapi.OAuthClientAuthorization, err error) {
+	result = &oauthapi.OAuthClientAuthorization{}
+	err = c.r.Get().Resource("userOAuthClientAuthorizations").Name(clientName).Do().Into(result)
+	return
+}
+
+// Delete revokes the OAuthClientAuthorization of the current user for the named client
+func (c *userOAuthClientAuthorizations) Delete(clientName string) (err error) {
+	err = c.r.Delete().Resource("userOAuthClientAuthorizations").Name(clientName).Do().Error()
+	return
+}
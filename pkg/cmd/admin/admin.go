@@ -7,9 +7,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/openshift-sdn/pkg/cmd/admin/network"
+	"github.com/openshift/origin/pkg/cmd/admin/backup"
 	"github.com/openshift/origin/pkg/cmd/admin/cert"
+	"github.com/openshift/origin/pkg/cmd/admin/certificate"
+	"github.com/openshift/origin/pkg/cmd/admin/config"
 	"github.com/openshift/origin/pkg/cmd/admin/groups"
+	"github.com/openshift/origin/pkg/cmd/admin/hostsubnet"
+	"github.com/openshift/origin/pkg/cmd/admin/logging"
+	"github.com/openshift/origin/pkg/cmd/admin/metrics"
 	"github.com/openshift/origin/pkg/cmd/admin/node"
+	"github.com/openshift/origin/pkg/cmd/admin/podnetwork"
 	"github.com/openshift/origin/pkg/cmd/admin/policy"
 	"github.com/openshift/origin/pkg/cmd/admin/project"
 	"github.com/openshift/origin/pkg/cmd/admin/prune"
@@ -57,6 +64,8 @@ func NewCommandAdmin(name, fullName string, out io.Writer) *cobra.Command {
 				router.NewCmdRouter(f, fullName, "router", out),
 				exipfailover.NewCmdIPFailoverConfig(f, fullName, "ipfailover", out),
 				registry.NewCmdRegistry(f, fullName, "registry", out),
+				metrics.NewCmdMetrics(f, fullName, "metrics", out),
+				logging.NewCmdLogging(f, fullName, "logging", out),
 			},
 		},
 		{
@@ -64,13 +73,17 @@ func NewCommandAdmin(name, fullName string, out io.Writer) *cobra.Command {
 			Commands: []*cobra.Command{
 				buildchain.NewCmdBuildChain(name, fullName+" "+buildchain.BuildChainRecommendedCommandName, f, out),
 				node.NewCommandManageNode(f, node.ManageNodeCommandName, fullName+" "+node.ManageNodeCommandName, out),
+				node.NewCommandDrain(f, node.DrainCommandName, fullName+" "+node.DrainCommandName, out),
+				certificate.NewCmdCertificate(certificate.CertificateRecommendedName, fullName+" "+certificate.CertificateRecommendedName, f, out),
 				prune.NewCommandPrune(prune.PruneRecommendedName, fullName+" "+prune.PruneRecommendedName, f, out),
+				backup.NewCmdBackup(backup.BackupRecommendedName, fullName+" "+backup.BackupRecommendedName, fullName+" "+backup.RestoreRecommendedName, f, out),
+				backup.NewCmdRestore(backup.RestoreRecommendedName, fullName+" "+backup.RestoreRecommendedName, fullName+" "+backup.BackupRecommendedName, f, out),
 			},
 		},
 		{
 			Message: "Settings Commands:",
 			Commands: []*cobra.Command{
-				cmd.NewCmdConfig(fullName, "config"),
+				newCmdConfig(fullName, "config", out),
 
 				// TODO: these probably belong in a sub command
 				admin.NewCommandCreateKubeConfig(admin.CreateKubeConfigCommandName, fullName+" "+admin.CreateKubeConfigCommandName, out),
@@ -80,10 +93,13 @@ func NewCommandAdmin(name, fullName string, out io.Writer) *cobra.Command {
 		{
 			Message: "Advanced Commands:",
 			Commands: []*cobra.Command{
-				network.NewCmdPodNetwork(network.PodNetworkCommandName, fullName+" "+network.PodNetworkCommandName, f, out),
+				podnetwork.NewCmdPodNetwork(network.PodNetworkCommandName, fullName+" "+network.PodNetworkCommandName, f, out),
+				hostsubnet.NewCmdHostSubnets(hostsubnet.HostSubnetsRecommendedName, fullName+" "+hostsubnet.HostSubnetsRecommendedName, f, out),
 				admin.NewCommandCreateBootstrapProjectTemplate(f, admin.CreateBootstrapProjectTemplateCommand, fullName+" "+admin.CreateBootstrapProjectTemplateCommand, out),
 				admin.NewCommandCreateBootstrapPolicyFile(admin.CreateBootstrapPolicyFileCommand, fullName+" "+admin.CreateBootstrapPolicyFileCommand, out),
 				admin.NewCommandCreateLoginTemplate(f, admin.CreateLoginTemplateCommand, fullName+" "+admin.CreateLoginTemplateCommand, out),
+				admin.NewCommandCreateGrantTemplate(f, admin.CreateGrantTemplateCommand, fullName+" "+admin.CreateGrantTemplateCommand, out),
+				admin.NewCommandCreateErrorTemplate(f, admin.CreateErrorTemplateCommand, fullName+" "+admin.CreateErrorTemplateCommand, out),
 				admin.NewCommandOverwriteBootstrapPolicy(admin.OverwriteBootstrapPolicyCommandName, fullName+" "+admin.OverwriteBootstrapPolicyCommandName, fullName+" "+admin.CreateBootstrapPolicyFileCommand, out),
 				admin.NewCommandNodeConfig(admin.NodeConfigCommandName, fullName+" "+admin.NodeConfigCommandName, out),
 				cert.NewCmdCert(cert.CertRecommendedName, fullName+" "+cert.CertRecommendedName, out),
@@ -116,3 +132,10 @@ func NewCommandAdmin(name, fullName string, out io.Writer) *cobra.Command {
 
 	return cmds
 }
+
+// newCmdConfig adds the commands for server configuration files to the client config command
+func newCmdConfig(parentName, name string, out io.Writer) *cobra.Command {
+	configCmd := cmd.NewCmdConfig(parentName, name)
+	configCmd.AddCommand(config.NewCmdViewEffective(config.ViewEffectiveRecommendedName, parentName+" "+name+" "+config.ViewEffectiveRecommendedName, out))
+	return configCmd
+}
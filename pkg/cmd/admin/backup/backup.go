@@ -0,0 +1,183 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/kubectl"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const BackupRecommendedName = "backup"
+
+const (
+	backupLong = `
+Back up the resources of the cluster to a directory
+
+The resources are read through the API and saved to one file per resource type in
+DIR, from which '%[1]s' can create them again in another cluster. Objects keep
+their UIDs in the backup so that references between them can be remapped when
+they are restored.
+
+By default the resources needed to recreate the projects, users and applications of
+the cluster are saved: %[2]s. Use --resources to choose
+others, such as builds or endpoints. Pods are recreated by their replication
+controllers and are not backed up.
+
+The backup contains secrets and must be stored securely.`
+
+	backupExample = `  # Back up the cluster
+  $ %[1]s /var/backups/openshift
+
+  # Back up the image streams and build configs of a project
+  $ %[1]s /var/backups/myproject --resources=imagestreams,buildconfigs --all-namespaces=false -n myproject`
+)
+
+// DefaultResources are the resources backed up unless others are chosen, in the order they are restored in.
+// Objects that are referenced by others come first.
+var DefaultResources = []string{
+	"namespaces",
+	"users",
+	"identities",
+	"groups",
+	"serviceaccounts",
+	"secrets",
+	// claims are restored before the volumes bound to them, whose claim references need the new UIDs of claims
+	"persistentvolumeclaims",
+	"persistentvolumes",
+	"limitranges",
+	"resourcequotas",
+	"roles",
+	// policy bindings hold the bindings to the roles of other namespaces, which role bindings do not show
+	"policybindings",
+	"rolebindings",
+	"clusterrolebindings",
+	"imagestreams",
+	"templates",
+	"buildconfigs",
+	"deploymentconfigs",
+	"replicationcontrollers",
+	"services",
+	"routes",
+}
+
+// BackupOptions saves the resources of the cluster to a directory
+type BackupOptions struct {
+	Dir           string
+	Resources     []string
+	Selector      string
+	AllNamespaces bool
+	Namespace     string
+
+	Mapper       meta.RESTMapper
+	Typer        runtime.ObjectTyper
+	ClientMapper resource.ClientMapper
+
+	Out io.Writer
+}
+
+func NewCmdBackup(name, fullName, restoreFullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &BackupOptions{
+		Resources:     DefaultResources,
+		AllNamespaces: true,
+		Out:           out,
+	}
+
+	cmd := &cobra.Command{
+		Use:     name + " DIR",
+		Short:   "Back up the resources of the cluster",
+		Long:    fmt.Sprintf(backupLong, restoreFullName, strings.Join(DefaultResources, ", ")),
+		Example: fmt.Sprintf(backupExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.Resources, "resources", options.Resources, "The resource types to back up, comma separated.")
+	cmd.Flags().StringVarP(&options.Selector, "selector", "l", options.Selector, "Only back up the objects that match this label selector.")
+	cmd.Flags().BoolVar(&options.AllNamespaces, "all-namespaces", options.AllNamespaces, "If false, only the objects of the current namespace are backed up.")
+
+	return cmd
+}
+
+func (o *BackupOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 1 {
+		return errors.New("you must specify the directory to save the backup to: DIR")
+	}
+	o.Dir = args[0]
+	if len(o.Resources) == 0 {
+		return errors.New("--resources must name at least one resource type")
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+	o.Mapper, o.Typer = f.Object()
+	o.ClientMapper = f.ClientMapperForCommand()
+	return nil
+}
+
+// Run saves every resource type to its own file in Dir. A resource type without objects results in an empty list.
+func (o *BackupOptions) Run() error {
+	if err := os.MkdirAll(o.Dir, 0700); err != nil {
+		return err
+	}
+
+	for _, resourceType := range o.Resources {
+		// save the objects under the full resource name, so that they are restored in order when a shortcut is given
+		version, kind, err := o.Mapper.VersionAndKindForResource(resourceType)
+		if err != nil {
+			return err
+		}
+		mapping, err := o.Mapper.RESTMapping(kind, version)
+		if err != nil {
+			return err
+		}
+
+		infos, err := resource.NewBuilder(o.Mapper, o.Typer, o.ClientMapper).
+			NamespaceParam(o.Namespace).DefaultNamespace().AllNamespaces(o.AllNamespaces).
+			SelectorParam(o.Selector).
+			ResourceTypeOrNameArgs(true, resourceType).
+			Flatten().
+			Do().Infos()
+		if err != nil {
+			return fmt.Errorf("unable to back up %s: %v", resourceType, err)
+		}
+		if err := o.save(mapping.Resource, infos); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "Saved %d %s\n", len(infos), mapping.Resource)
+	}
+	return nil
+}
+
+// save writes infos as a list to the file of resourceType
+func (o *BackupOptions) save(resourceType string, infos []*resource.Info) error {
+	list, err := resource.AsVersionedObject(infos, true, latest.Version)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(o.Dir, resourceType+".json"), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return (&kubectl.JSONPrinter{}).PrintObj(list, file)
+}
@@ -0,0 +1,381 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	osautil "github.com/openshift/origin/pkg/serviceaccounts/util"
+)
+
+const RestoreRecommendedName = "restore"
+
+// restoredUIDsFile records the UIDs the objects of a backup were restored with, so that a restore that was
+// interrupted or that is done in steps remaps references to the objects restored before
+const restoredUIDsFile = "restored-uids.json"
+
+const (
+	restoreLong = `
+Restore resources from a backup
+
+Creates the objects saved by '%[1]s' in DIR through the API, one resource type
+at a time so that objects are created before the objects that refer to them.
+Objects that already exist are left unchanged.
+
+Restored objects are given new UIDs by the server. References to other objects by
+UID, such as the user an identity belongs to, are changed to the UID the referenced
+object was restored with. The new UIDs are recorded in DIR, so that references to
+objects restored by an earlier run are remapped too. Persistent volumes are bound
+again to the restored claims they were bound to.
+
+Services are given new cluster IPs, except headless services.
+
+Service account tokens and the image pull secrets generated from them are not
+restored, as the cluster creates new ones for every restored service account.`
+
+	restoreExample = `  # Restore a backup
+  $ %[1]s /var/backups/openshift
+
+  # Restore only the users and identities of a backup
+  $ %[1]s /var/backups/openshift --resources=users,identities`
+)
+
+// errSkipped is returned by prepareForRestore for objects that must not be restored
+var errSkipped = errors.New("skipped, the cluster creates it")
+
+// RestoreOptions creates the objects of a backup
+type RestoreOptions struct {
+	Dir       string
+	Resources []string
+
+	Mapper       meta.RESTMapper
+	Typer        runtime.ObjectTyper
+	ClientMapper resource.ClientMapper
+
+	Out io.Writer
+}
+
+func NewCmdRestore(name, fullName, backupFullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &RestoreOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " DIR",
+		Short:   "Restore resources from a backup",
+		Long:    fmt.Sprintf(restoreLong, backupFullName),
+		Example: fmt.Sprintf(restoreExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.Resources, "resources", options.Resources, "The resource types to restore, comma separated. Defaults to all resource types in the backup.")
+
+	return cmd
+}
+
+func (o *RestoreOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 1 {
+		return errors.New("you must specify the directory of the backup: DIR")
+	}
+	o.Dir = args[0]
+	o.Mapper, o.Typer = f.Object()
+	o.ClientMapper = f.ClientMapperForCommand()
+	return nil
+}
+
+// Run creates the objects of every resource type in the backup. Objects that cannot be created are reported once
+// all others were restored.
+func (o *RestoreOptions) Run() error {
+	resourceTypes, err := o.resourceTypes()
+	if err != nil {
+		return err
+	}
+	uids, err := readUIDMap(filepath.Join(o.Dir, restoredUIDsFile))
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for _, resourceType := range resourceTypes {
+		infos, err := resource.NewBuilder(o.Mapper, o.Typer, o.ClientMapper).
+			FilenameParam(false, filepath.Join(o.Dir, resourceType+".json")).
+			Flatten().
+			Do().Infos()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to read the %s of the backup: %v", resourceType, err))
+			continue
+		}
+		for _, info := range infos {
+			if err := o.restore(info, uids); err != nil {
+				errs = append(errs, fmt.Errorf("unable to restore %s %s: %v", info.Mapping.Resource, describe(info), err))
+			}
+		}
+		if err := uids.write(filepath.Join(o.Dir, restoredUIDsFile)); err != nil {
+			return err
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// resourceTypes returns the resource types of the backup to restore, in the order of DefaultResources followed
+// by other resource types by name
+func (o *RestoreOptions) resourceTypes() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(o.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	saved := sets.NewString()
+	for _, file := range files {
+		if name := filepath.Base(file); name != restoredUIDsFile {
+			saved.Insert(strings.TrimSuffix(name, ".json"))
+		}
+	}
+	if saved.Len() == 0 {
+		return nil, fmt.Errorf("%s does not contain a backup", o.Dir)
+	}
+
+	if len(o.Resources) > 0 {
+		selected := sets.NewString()
+		for _, resourceType := range o.Resources {
+			version, kind, err := o.Mapper.VersionAndKindForResource(resourceType)
+			if err != nil {
+				return nil, err
+			}
+			mapping, err := o.Mapper.RESTMapping(kind, version)
+			if err != nil {
+				return nil, err
+			}
+			if !saved.Has(mapping.Resource) {
+				return nil, fmt.Errorf("the backup does not contain %s", mapping.Resource)
+			}
+			selected.Insert(mapping.Resource)
+		}
+		saved = selected
+	}
+
+	resourceTypes := []string{}
+	for _, resourceType := range DefaultResources {
+		if saved.Has(resourceType) {
+			resourceTypes = append(resourceTypes, resourceType)
+			saved.Delete(resourceType)
+		}
+	}
+	return append(resourceTypes, saved.List()...), nil
+}
+
+// restore creates the object of info, recording the UID it is restored with
+func (o *RestoreOptions) restore(info *resource.Info, uids uidMap) error {
+	objMeta, err := meta.Accessor(info.Object)
+	if err != nil {
+		return err
+	}
+	oldUID := objMeta.UID()
+
+	if err := prepareForRestore(info.Object); err != nil {
+		if err == errSkipped {
+			fmt.Fprintf(o.Out, "%s %s %v\n", info.Mapping.Resource, describe(info), err)
+			return nil
+		}
+		return err
+	}
+	if pv, ok := info.Object.(*kapi.PersistentVolume); ok {
+		uids.remapClaimRef(pv)
+	}
+	uids.remapReferences(info.Object)
+
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	created, err := helper.Create(info.Namespace, true, info.Object)
+	if kerrors.IsAlreadyExists(err) {
+		// references to the object must now refer to the one that exists
+		existing, err := helper.Get(info.Namespace, info.Name)
+		if err != nil {
+			return err
+		}
+		if err := uids.record(oldUID, existing); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "%s %s already exists\n", info.Mapping.Resource, describe(info))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := uids.record(oldUID, created); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "%s %s restored\n", info.Mapping.Resource, describe(info))
+	return nil
+}
+
+// describe returns the namespace and name of the object of info
+func describe(info *resource.Info) string {
+	if len(info.Namespace) == 0 {
+		return info.Name
+	}
+	return info.Namespace + "/" + info.Name
+}
+
+// prepareForRestore clears the fields of obj that the server sets when an object is created, and returns errSkipped
+// if obj is created by the cluster itself.
+func prepareForRestore(obj runtime.Object) error {
+	if objMeta, err := kapi.ObjectMetaFor(obj); err == nil {
+		objMeta.UID = ""
+		objMeta.ResourceVersion = ""
+		objMeta.SelfLink = ""
+		objMeta.CreationTimestamp = unversioned.Time{}
+		objMeta.DeletionTimestamp = nil
+	}
+
+	switch t := obj.(type) {
+	case *kapi.Secret:
+		// tokens are signed by the old cluster, and are created again for the restored service accounts
+		if t.Type == kapi.SecretTypeServiceAccountToken || len(t.Annotations[kapi.ServiceAccountUIDKey]) > 0 {
+			return errSkipped
+		}
+	case *kapi.Service:
+		// the cluster IP may belong to another service or be outside the service network of the cluster, headless
+		// services have none
+		if t.Spec.ClusterIP != kapi.ClusterIPNone {
+			t.Spec.ClusterIP = ""
+		}
+	case *kapi.ServiceAccount:
+		// drop the references to the secrets that are not restored
+		dockercfgSecretPrefix := osautil.GetDockercfgSecretNamePrefix(t)
+		tokenSecretPrefix := osautil.GetTokenSecretNamePrefix(t)
+		imagePullSecrets := []kapi.LocalObjectReference{}
+		for _, secretRef := range t.ImagePullSecrets {
+			if !strings.HasPrefix(secretRef.Name, dockercfgSecretPrefix) {
+				imagePullSecrets = append(imagePullSecrets, secretRef)
+			}
+		}
+		t.ImagePullSecrets = imagePullSecrets
+		secrets := []kapi.ObjectReference{}
+		for _, secretRef := range t.Secrets {
+			if !strings.HasPrefix(secretRef.Name, dockercfgSecretPrefix) && !strings.HasPrefix(secretRef.Name, tokenSecretPrefix) {
+				secrets = append(secrets, secretRef)
+			}
+		}
+		t.Secrets = secrets
+	}
+	return nil
+}
+
+// uidMap maps the UIDs of the objects in a backup to the UIDs they were restored with
+type uidMap map[types.UID]types.UID
+
+var objectReferenceType = reflect.TypeOf(kapi.ObjectReference{})
+
+// readUIDMap reads the UIDs recorded by an earlier restore from file, if it exists
+func readUIDMap(file string) (uidMap, error) {
+	uids := uidMap{}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return uids, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &uids); err != nil {
+		return nil, fmt.Errorf("unable to read the restored UIDs from %s: %v", file, err)
+	}
+	return uids, nil
+}
+
+// write saves the UIDs to file
+func (m uidMap) write(file string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+// record maps oldUID to the UID of restored
+func (m uidMap) record(oldUID types.UID, restored runtime.Object) error {
+	restoredMeta, err := meta.Accessor(restored)
+	if err != nil {
+		return err
+	}
+	if len(oldUID) > 0 && len(restoredMeta.UID()) > 0 {
+		m[oldUID] = restoredMeta.UID()
+	}
+	return nil
+}
+
+// remapClaimRef changes the UID of the claim pv is bound to to the UID the claim was restored with. If the claim
+// was not restored, the UID is cleared so that the volume is bound to the claim of the same name rather than
+// released as bound to a claim that no longer exists.
+func (m uidMap) remapClaimRef(pv *kapi.PersistentVolume) {
+	if pv.Spec.ClaimRef == nil {
+		return
+	}
+	pv.Spec.ClaimRef.ResourceVersion = ""
+	if uid, ok := m[pv.Spec.ClaimRef.UID]; ok {
+		pv.Spec.ClaimRef.UID = uid
+		return
+	}
+	pv.Spec.ClaimRef.UID = ""
+}
+
+// remapReferences changes the UID of every object reference in obj that refers to a restored object to the UID
+// the object was restored with. References to objects that were not restored are left unchanged.
+func (m uidMap) remapReferences(obj runtime.Object) {
+	m.remap(reflect.ValueOf(obj))
+}
+
+func (m uidMap) remap(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			m.remap(v.Elem())
+		}
+	case reflect.Struct:
+		if v.Type() == objectReferenceType {
+			if uid, ok := m[types.UID(v.FieldByName("UID").String())]; ok && v.CanSet() {
+				v.FieldByName("UID").SetString(string(uid))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			m.remap(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			m.remap(v.Index(i))
+		}
+	case reflect.Map:
+		if !v.CanSet() || v.Type().Elem().Kind() == reflect.String {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			// map values cannot be changed in place
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			m.remap(value)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/types"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+func TestPrepareForRestore(t *testing.T) {
+	secret := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Name: "creds", Namespace: "test", UID: "1", ResourceVersion: "10", SelfLink: "/api/v1/namespaces/test/secrets/creds"},
+		Type:       kapi.SecretTypeOpaque,
+	}
+	if err := prepareForRestore(secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret.UID) > 0 || len(secret.ResourceVersion) > 0 || len(secret.SelfLink) > 0 {
+		t.Errorf("expected the fields set by the server to be cleared: %#v", secret.ObjectMeta)
+	}
+	if secret.Name != "creds" || secret.Namespace != "test" {
+		t.Errorf("expected the name and namespace to be kept: %#v", secret.ObjectMeta)
+	}
+
+	token := &kapi.Secret{ObjectMeta: kapi.ObjectMeta{Name: "builder-token-abcde"}, Type: kapi.SecretTypeServiceAccountToken}
+	if err := prepareForRestore(token); err != errSkipped {
+		t.Errorf("expected service account tokens to be skipped, got %v", err)
+	}
+	dockercfg := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Name: "builder-dockercfg-abcde", Annotations: map[string]string{kapi.ServiceAccountUIDKey: "2"}},
+		Type:       kapi.SecretTypeDockercfg,
+	}
+	if err := prepareForRestore(dockercfg); err != errSkipped {
+		t.Errorf("expected secrets generated for service accounts to be skipped, got %v", err)
+	}
+
+	sa := &kapi.ServiceAccount{
+		ObjectMeta:       kapi.ObjectMeta{Name: "builder"},
+		Secrets:          []kapi.ObjectReference{{Name: "builder-token-abcde"}, {Name: "builder-dockercfg-abcde"}, {Name: "creds"}},
+		ImagePullSecrets: []kapi.LocalObjectReference{{Name: "builder-dockercfg-abcde"}, {Name: "pull"}},
+	}
+	if err := prepareForRestore(sa); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sa.Secrets, []kapi.ObjectReference{{Name: "creds"}}) {
+		t.Errorf("expected only the secrets that are restored to be kept, got %v", sa.Secrets)
+	}
+	if !reflect.DeepEqual(sa.ImagePullSecrets, []kapi.LocalObjectReference{{Name: "pull"}}) {
+		t.Errorf("expected only the image pull secrets that are restored to be kept, got %v", sa.ImagePullSecrets)
+	}
+
+	service := &kapi.Service{Spec: kapi.ServiceSpec{ClusterIP: "172.30.0.10"}}
+	if err := prepareForRestore(service); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(service.Spec.ClusterIP) > 0 {
+		t.Errorf("expected the cluster IP to be allocated again, got %s", service.Spec.ClusterIP)
+	}
+	headless := &kapi.Service{Spec: kapi.ServiceSpec{ClusterIP: kapi.ClusterIPNone}}
+	if err := prepareForRestore(headless); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if headless.Spec.ClusterIP != kapi.ClusterIPNone {
+		t.Errorf("expected the service to stay headless, got %s", headless.Spec.ClusterIP)
+	}
+}
+
+func TestRemapClaimRef(t *testing.T) {
+	uids := uidMap{"old-claim": "new-claim"}
+
+	restored := &kapi.PersistentVolume{Spec: kapi.PersistentVolumeSpec{ClaimRef: &kapi.ObjectReference{Namespace: "test", Name: "data", UID: "old-claim", ResourceVersion: "10"}}}
+	uids.remapClaimRef(restored)
+	if ref := restored.Spec.ClaimRef; ref.UID != "new-claim" || len(ref.ResourceVersion) > 0 {
+		t.Errorf("expected the volume to refer to the restored claim, got %#v", ref)
+	}
+
+	notRestored := &kapi.PersistentVolume{Spec: kapi.PersistentVolumeSpec{ClaimRef: &kapi.ObjectReference{Namespace: "test", Name: "other", UID: "other-claim"}}}
+	uids.remapClaimRef(notRestored)
+	if ref := notRestored.Spec.ClaimRef; len(ref.UID) > 0 || ref.Name != "other" {
+		t.Errorf("expected the UID of a claim that was not restored to be cleared, got %#v", ref)
+	}
+
+	unbound := &kapi.PersistentVolume{}
+	uids.remapClaimRef(unbound)
+	if unbound.Spec.ClaimRef != nil {
+		t.Errorf("expected an unbound volume to stay unbound")
+	}
+}
+
+func TestRemapReferences(t *testing.T) {
+	uids := uidMap{"old-user": "new-user", "old-stream": "new-stream"}
+
+	identity := &userapi.Identity{
+		ObjectMeta: kapi.ObjectMeta{Name: "github:alice"},
+		User:       kapi.ObjectReference{Name: "alice", UID: "old-user"},
+	}
+	uids.remapReferences(identity)
+	if identity.User.UID != "new-user" {
+		t.Errorf("expected the user of the identity to be remapped, got %s", identity.User.UID)
+	}
+
+	binding := &authorizationapi.RoleBinding{
+		Subjects: []kapi.ObjectReference{{Kind: "User", Name: "alice", UID: "old-user"}, {Kind: "User", Name: "bob", UID: "not-restored"}},
+	}
+	uids.remapReferences(binding)
+	if binding.Subjects[0].UID != "new-user" || binding.Subjects[1].UID != "not-restored" {
+		t.Errorf("expected only references to restored objects to be remapped, got %v", binding.Subjects)
+	}
+
+	stream := &imageapi.ImageStream{
+		Spec: imageapi.ImageStreamSpec{Tags: map[string]imageapi.TagReference{
+			"latest": {From: &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "base:latest", UID: "old-stream"}},
+		}},
+	}
+	uids.remapReferences(stream)
+	if uid := stream.Spec.Tags["latest"].From.UID; uid != "new-stream" {
+		t.Errorf("expected the reference in the tag to be remapped, got %s", uid)
+	}
+}
+
+func TestUIDMapIsRecorded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, restoredUIDsFile)
+
+	uids, err := readUIDMap(file)
+	if err != nil || len(uids) != 0 {
+		t.Fatalf("expected no UIDs before the first restore, got %v: %v", uids, err)
+	}
+	if err := uids.record("old", &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "alice", UID: "new"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uids.write(file); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read, err := readUIDMap(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(read, uidMap{types.UID("old"): types.UID("new")}) {
+		t.Errorf("unexpected UIDs: %v", read)
+	}
+}
+
+func TestRestoreOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"routes.json", "builds.json", "identities.json", "users.json", "endpoints.json", restoredUIDsFile} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	o := &RestoreOptions{Dir: dir, Mapper: latest.RESTMapper}
+	resourceTypes, err := o.resourceTypes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"users", "identities", "routes", "builds", "endpoints"}; !reflect.DeepEqual(expected, resourceTypes) {
+		t.Errorf("expected %v, got %v", expected, resourceTypes)
+	}
+
+	o.Resources = []string{"identity", "users"}
+	resourceTypes, err = o.resourceTypes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"users", "identities"}; !reflect.DeepEqual(expected, resourceTypes) {
+		t.Errorf("expected %v, got %v", expected, resourceTypes)
+	}
+
+	o.Resources = []string{"templates"}
+	if _, err := o.resourceTypes(); err == nil {
+		t.Errorf("expected an error for a resource type that is not in the backup")
+	}
+}
@@ -0,0 +1,53 @@
+package certificate
+
+import (
+	"fmt"
+	"io"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	ApproveRecommendedName = "approve"
+	approveLong            = `
+Approve the certificate requests of nodes
+
+Once approved, the master signs the requested certificate and the node that
+made the request starts using it. Only approve a request after confirming it
+was made by the node it names.`
+
+	approveExample = `  # Approve a certificate request
+  $ %[1]s node-node1-client-x7k2p`
+)
+
+func NewCmdApproveCertificate(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &DecideCertificateOptions{
+		Out:      out,
+		Decision: certapi.CertificateApproved,
+		Reason:   "AdminApproved",
+		Message:  "This request was approved by a cluster administrator",
+	}
+
+	cmd := &cobra.Command{
+		Use:     name + " NAME [NAME ...]",
+		Short:   "Approve node certificate requests",
+		Long:    approveLong,
+		Example: fmt.Sprintf(approveExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Message, "message", options.Message, "A message recorded with the approval.")
+
+	return cmd
+}
@@ -0,0 +1,122 @@
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/spf13/cobra"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/client"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const CertificateRecommendedName = "certificate"
+
+const certificateLong = `
+Approve or deny the certificate requests of nodes
+
+Nodes started with bootstrap credentials request a client certificate to
+connect to the master and a serving certificate for the kubelet. A cluster
+administrator must approve these first requests before the master signs them.
+Later requests made by a node to renew its own client certificate are approved
+automatically. Requests to renew the serving certificate of a node must always
+be approved, since the names in them are chosen by the node.
+
+List the pending requests with 'oc get certificatesigningrequests'.`
+
+func NewCmdCertificate(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	// Parent command to which all subcommands are added.
+	cmds := &cobra.Command{
+		Use:   name,
+		Short: "Approve or deny node certificate requests",
+		Long:  certificateLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdApproveCertificate(ApproveRecommendedName, fullName+" "+ApproveRecommendedName, f, out))
+	cmds.AddCommand(NewCmdDenyCertificate(DenyRecommendedName, fullName+" "+DenyRecommendedName, f, out))
+
+	return cmds
+}
+
+// DecideCertificateOptions records an approval decision on certificate signing requests
+type DecideCertificateOptions struct {
+	Client client.CertificateSigningRequestInterface
+	Out    io.Writer
+
+	Names    []string
+	Decision certapi.CertificateSigningRequestConditionType
+	Reason   string
+	Message  string
+}
+
+func (o *DecideCertificateOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must specify at least one certificate signing request: NAME [NAME ...]")
+	}
+	o.Names = args
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient.CertificateSigningRequests()
+	return nil
+}
+
+func (o *DecideCertificateOptions) Run() error {
+	errs := []error{}
+	for _, name := range o.Names {
+		if err := o.decide(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// decide records the decision on the named request, unless the same decision was already made
+func (o *DecideCertificateOptions) decide(name string) error {
+	csr, err := o.Client.Get(name)
+	if err != nil {
+		return err
+	}
+
+	decided := describeDecision(o.Decision)
+	if condition := certapi.GetCondition(csr); condition != nil {
+		if condition.Type != o.Decision {
+			return fmt.Errorf("certificate signing request %q was already %s", name, describeDecision(condition.Type))
+		}
+		fmt.Fprintf(o.Out, "certificatesigningrequest %q was already %s\n", name, decided)
+		return nil
+	}
+
+	csr.Status.Conditions = append(csr.Status.Conditions, certapi.CertificateSigningRequestCondition{
+		Type:           o.Decision,
+		Reason:         o.Reason,
+		Message:        o.Message,
+		LastUpdateTime: unversioned.Now(),
+	})
+	if _, err := o.Client.UpdateApproval(csr); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "certificatesigningrequest %q %s\n", name, decided)
+	return nil
+}
+
+// describeDecision returns the past tense verb for decision
+func describeDecision(decision certapi.CertificateSigningRequestConditionType) string {
+	switch decision {
+	case certapi.CertificateApproved:
+		return "approved"
+	case certapi.CertificateDenied:
+		return "denied"
+	default:
+		return string(decision)
+	}
+}
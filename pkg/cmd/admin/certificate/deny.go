@@ -0,0 +1,52 @@
+package certificate
+
+import (
+	"fmt"
+	"io"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	DenyRecommendedName = "deny"
+	denyLong            = `
+Deny the certificate requests of nodes
+
+The master never signs a denied request. A node waiting for the certificate
+stops with an error that includes the message given here.`
+
+	denyExample = `  # Deny a certificate request that was not made by a known node
+  $ %[1]s node-node1-client-x7k2p --message="node1 is not part of this cluster"`
+)
+
+func NewCmdDenyCertificate(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &DecideCertificateOptions{
+		Out:      out,
+		Decision: certapi.CertificateDenied,
+		Reason:   "AdminDenied",
+		Message:  "This request was denied by a cluster administrator",
+	}
+
+	cmd := &cobra.Command{
+		Use:     name + " NAME [NAME ...]",
+		Short:   "Deny node certificate requests",
+		Long:    denyLong,
+		Example: fmt.Sprintf(denyExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Message, "message", options.Message, "A message recorded with the denial.")
+
+	return cmd
+}
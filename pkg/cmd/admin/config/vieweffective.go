@@ -0,0 +1,241 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kyaml "k8s.io/kubernetes/pkg/util/yaml"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	configapilatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
+)
+
+const (
+	ViewEffectiveRecommendedName = "view-effective"
+
+	viewEffectiveLong = `
+Show the configuration a master or node would run with
+
+Reads a master or node configuration file, applies the defaults of every unset field and resolves
+the paths of referenced files relative to the directory of the configuration file, the same way the
+server does when it starts. The result is printed as YAML. Values that are not set in the file are
+marked as defaults, and paths that were resolved show the value in the file.`
+
+	viewEffectiveExample = `  # Show the effective configuration of a master
+  $ %[1]s openshift.local.config/master/master-config.yaml`
+)
+
+// ViewEffectiveOptions prints the effective configuration of a master or node
+type ViewEffectiveOptions struct {
+	// ConfigFile is the master or node configuration file
+	ConfigFile string
+
+	Out io.Writer
+}
+
+// NewCmdViewEffective prints the effective configuration of a master or node
+func NewCmdViewEffective(name, fullName string, out io.Writer) *cobra.Command {
+	options := &ViewEffectiveOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " FILE",
+		Short:   "Show the effective master or node configuration",
+		Long:    viewEffectiveLong,
+		Example: fmt.Sprintf(viewEffectiveExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	return cmd
+}
+
+func (o *ViewEffectiveOptions) Complete(args []string) error {
+	if len(args) != 1 {
+		return errors.New("exactly one master or node configuration file is required")
+	}
+	o.ConfigFile = args[0]
+	return nil
+}
+
+func (o *ViewEffectiveOptions) Run() error {
+	data, err := ioutil.ReadFile(o.ConfigFile)
+	if err != nil {
+		return err
+	}
+	data, err = kyaml.ToJSON(data)
+	if err != nil {
+		return fmt.Errorf("could not load config file %q: %v", o.ConfigFile, err)
+	}
+	fileValues := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fileValues); err != nil {
+		return fmt.Errorf("could not load config file %q: %v", o.ConfigFile, err)
+	}
+
+	// decoding applies the defaults
+	obj, err := configapilatest.Codec.Decode(data)
+	if err != nil {
+		return fmt.Errorf("could not load config file %q: %v", o.ConfigFile, err)
+	}
+	switch config := obj.(type) {
+	case *configapi.MasterConfig:
+		err = configapi.ResolveMasterConfigPaths(config, path.Dir(o.ConfigFile))
+	case *configapi.NodeConfig:
+		err = configapi.ResolveNodeConfigPaths(config, path.Dir(o.ConfigFile))
+	default:
+		return fmt.Errorf("%q is not a master or node configuration file", o.ConfigFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := configapilatest.WriteYAML(obj)
+	if err != nil {
+		return err
+	}
+	content, err = kyaml.ToJSON(content)
+	if err != nil {
+		return err
+	}
+	effectiveValues := map[string]interface{}{}
+	if err := json.Unmarshal(content, &effectiveValues); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "# Effective configuration of %s\n", o.ConfigFile)
+	p := &provenancePrinter{out: o.Out}
+	p.printMap("", "", effectiveValues, fileValues)
+	return p.err
+}
+
+// provenancePrinter prints effective configuration values as YAML, with a comment on the values that differ
+// from the configuration file
+type provenancePrinter struct {
+	out io.Writer
+	err error
+}
+
+// printMap prints the fields of effective, sorted like the YAML the server writes. The first field is prefixed
+// with first, for maps in lists, and the others with indent. file holds the values of the configuration file at
+// the same place, or nil if the whole map is not in the file.
+func (p *provenancePrinter) printMap(first, indent string, effective, file map[string]interface{}) {
+	keys := []string{}
+	for key := range effective {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	prefix := first
+	for _, key := range keys {
+		fileValue, inFile := file[key]
+		if file == nil {
+			// the parent is already marked as a default
+			fileValue, inFile = effective[key], true
+		}
+		p.printValue(prefix+key+":", indent, effective[key], fileValue, inFile)
+		prefix = indent
+	}
+	if len(keys) == 0 {
+		p.printf("%s{}\n", first)
+	}
+}
+
+// printValue prints value after label, followed by its provenance if it differs from fileValue
+func (p *provenancePrinter) printValue(label, indent string, value, fileValue interface{}, inFile bool) {
+	comment := ""
+	switch {
+	case !inFile, fileValue == nil && value != nil:
+		comment = "  # default"
+	case !reflect.DeepEqual(value, fileValue) && isScalar(value) && isScalar(fileValue):
+		comment = "  # resolved from " + formatScalar(fileValue)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			p.printf("%s {}%s\n", label, comment)
+			return
+		}
+		p.printf("%s%s\n", label, comment)
+		fileMap, _ := fileValue.(map[string]interface{})
+		if len(comment) > 0 {
+			fileMap = nil
+		}
+		p.printMap(indent+"  ", indent+"  ", v, fileMap)
+
+	case []interface{}:
+		if len(v) == 0 {
+			p.printf("%s []%s\n", label, comment)
+			return
+		}
+		p.printf("%s%s\n", label, comment)
+		fileList, _ := fileValue.([]interface{})
+		if len(comment) > 0 {
+			// the items are already marked with the list
+			fileList = v
+		}
+		for i, item := range v {
+			var fileItem interface{}
+			itemInFile := inFile && i < len(fileList)
+			if itemInFile {
+				fileItem = fileList[i]
+			}
+			if itemMap, ok := item.(map[string]interface{}); ok {
+				fileMap, _ := fileItem.(map[string]interface{})
+				if !itemInFile {
+					fileMap = nil
+				}
+				p.printMap(indent+"- ", indent+"  ", itemMap, fileMap)
+				continue
+			}
+			p.printValue(indent+"-", indent+"  ", item, fileItem, itemInFile)
+		}
+
+	default:
+		p.printf("%s %s%s\n", label, formatScalar(value), comment)
+	}
+}
+
+func (p *provenancePrinter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.out, format, args...)
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+// formatScalar formats a JSON scalar as YAML
+func formatScalar(value interface{}) string {
+	if s, ok := value.(string); ok && strings.Contains(s, "\n") {
+		// JSON strings are valid YAML, and unlike block scalars do not depend on the indentation
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestViewEffectiveMasterConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "view-effective")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := path.Join(dir, "master-config.yaml")
+	config := `apiVersion: v1
+kind: MasterConfig
+servingInfo:
+  bindAddress: 0.0.0.0:8443
+  certFile: master.server.crt
+corsAllowedOrigins:
+- localhost
+`
+	if err := ioutil.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	options := &ViewEffectiveOptions{ConfigFile: configFile, Out: out}
+	if err := options.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"\n  bindAddress: 0.0.0.0:8443\n",
+		"\n  bindNetwork: tcp4  # default\n",
+		"\n  certFile: " + path.Join(dir, "master.server.crt") + "  # resolved from master.server.crt\n",
+		"\ncorsAllowedOrigins:\n- localhost\n",
+		"\nanonymousConfig:  # default\n  access: Allow\n",
+	}
+	for _, s := range expected {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("expected %q in:\n%s", s, out.String())
+		}
+	}
+}
+
+func TestViewEffectiveInvalidConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "view-effective")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := path.Join(dir, "pod.yaml")
+	if err := ioutil.WriteFile(configFile, []byte("apiVersion: v1\nkind: Pod\n"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	options := &ViewEffectiveOptions{ConfigFile: configFile, Out: &bytes.Buffer{}}
+	if err := options.Run(); err == nil {
+		t.Errorf("expected an error for a configuration that is not a master or node configuration")
+	}
+}
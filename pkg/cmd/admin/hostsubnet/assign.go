@@ -0,0 +1,124 @@
+package hostsubnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+const (
+	AssignRecommendedName = "assign"
+	assignLong            = `
+Assign a pod subnet to a node
+
+Creates the HostSubnet of a node by hand instead of waiting for the master to
+allocate one. The subnet must be part of the cluster network, must have the
+size the cluster network allocates to each node, and must not overlap the
+service network or the subnet of any other node; the master rejects subnets
+that do not. The node IP is read from the
+node's status unless --host-ip is given.`
+
+	assignExample = `  # Assign 10.1.5.0/24 to node1
+  $ %[1]s node1 10.1.5.0/24
+
+  # Assign a subnet to a node that has not registered yet
+  $ %[1]s node2 10.1.6.0/24 --host-ip=192.168.1.12`
+)
+
+type AssignHostSubnetOptions struct {
+	Client     client.Interface
+	KubeClient kclient.Interface
+	Out        io.Writer
+
+	NodeName string
+	Subnet   string
+	HostIP   string
+}
+
+func NewCmdAssignHostSubnet(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &AssignHostSubnetOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " NODE SUBNET",
+		Short:   "Assign a pod subnet to a node",
+		Long:    assignLong,
+		Example: fmt.Sprintf(assignExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&options.HostIP, "host-ip", options.HostIP, "The IP of the node. Defaults to the address reported by the node.")
+
+	return cmd
+}
+
+func (o *AssignHostSubnetOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 2 {
+		return errors.New("you must specify a node name and a subnet: NODE SUBNET")
+	}
+	o.NodeName, o.Subnet = args[0], args[1]
+	if len(o.HostIP) > 0 && net.ParseIP(o.HostIP) == nil {
+		return fmt.Errorf("--host-ip %q is not a valid IP address", o.HostIP)
+	}
+
+	osClient, kubeClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	o.KubeClient = kubeClient
+	return nil
+}
+
+func (o *AssignHostSubnetOptions) Run() error {
+	hostIP := o.HostIP
+	if len(hostIP) == 0 {
+		node, err := o.KubeClient.Nodes().Get(o.NodeName)
+		if err != nil {
+			return fmt.Errorf("unable to determine the IP of node %q, use --host-ip: %v", o.NodeName, err)
+		}
+		if hostIP = nodeAddress(node); len(hostIP) == 0 {
+			return fmt.Errorf("node %q does not report an IP address, use --host-ip", o.NodeName)
+		}
+	}
+
+	subnet := &sdnapi.HostSubnet{
+		ObjectMeta: kapi.ObjectMeta{Name: o.NodeName},
+		Host:       o.NodeName,
+		HostIP:     hostIP,
+		Subnet:     o.Subnet,
+	}
+	if _, err := o.Client.HostSubnets().Create(subnet); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "Assigned subnet %s to node %q\n", subnet.Subnet, subnet.Name)
+	return nil
+}
+
+// nodeAddress returns the internal IP address of the node, falling back to its legacy host IP.
+func nodeAddress(node *kapi.Node) string {
+	for _, addressType := range []kapi.NodeAddressType{kapi.NodeInternalIP, kapi.NodeLegacyHostIP} {
+		for _, address := range node.Status.Addresses {
+			if address.Type == addressType {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
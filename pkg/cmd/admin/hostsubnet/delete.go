@@ -0,0 +1,82 @@
+package hostsubnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	DeleteRecommendedName = "delete"
+	deleteLong            = `
+Release the pod subnets of nodes
+
+Deletes the HostSubnet of each named node, returning the subnet to the cluster
+network. Pods still running on the node keep their IPs until they are deleted,
+so the node should be evacuated first. If the node is still registered, the
+master assigns it a new subnet.`
+
+	deleteExample = `  # Release the subnet of node1
+  $ %[1]s node1`
+)
+
+type DeleteHostSubnetsOptions struct {
+	Client client.HostSubnetInterface
+	Out    io.Writer
+
+	NodeNames []string
+}
+
+func NewCmdDeleteHostSubnets(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &DeleteHostSubnetsOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " NODE [NODE ...]",
+		Short:   "Release the pod subnets of nodes",
+		Long:    deleteLong,
+		Example: fmt.Sprintf(deleteExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	return cmd
+}
+
+func (o *DeleteHostSubnetsOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must specify at least one node: NODE [NODE ...]")
+	}
+	o.NodeNames = args
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient.HostSubnets()
+	return nil
+}
+
+func (o *DeleteHostSubnetsOptions) Run() error {
+	errs := []error{}
+	for _, name := range o.NodeNames {
+		if err := o.Client.Delete(name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(o.Out, "Released the subnet of node %q\n", name)
+	}
+	return kerrors.NewAggregate(errs)
+}
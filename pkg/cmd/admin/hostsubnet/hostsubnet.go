@@ -0,0 +1,40 @@
+package hostsubnet
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const HostSubnetsRecommendedName = "host-subnets"
+
+const hostSubnetsLong = `
+Manage the pod subnets assigned to nodes
+
+When an SDN plugin is in use, the master assigns each node a HostSubnet: a part
+of the cluster network from which the node allocates pod IPs. These commands
+list the assignments and pod IP usage, assign or release subnets by hand, and
+check that no assignment overlaps another or the service network.`
+
+// clusterNetworkName is the name of the ClusterNetwork record created by the SDN master
+const clusterNetworkName = "default"
+
+func NewCmdHostSubnets(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	// Parent command to which all subcommands are added.
+	cmds := &cobra.Command{
+		Use:   name,
+		Short: "Manage node pod subnets",
+		Long:  hostSubnetsLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdListHostSubnets(ListRecommendedName, fullName+" "+ListRecommendedName, f, out))
+	cmds.AddCommand(NewCmdAssignHostSubnet(AssignRecommendedName, fullName+" "+AssignRecommendedName, f, out))
+	cmds.AddCommand(NewCmdDeleteHostSubnets(DeleteRecommendedName, fullName+" "+DeleteRecommendedName, f, out))
+	cmds.AddCommand(NewCmdVerifyHostSubnets(VerifyRecommendedName, fullName+" "+VerifyRecommendedName, f, out))
+
+	return cmds
+}
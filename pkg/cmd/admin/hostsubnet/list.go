@@ -0,0 +1,114 @@
+package hostsubnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"text/tabwriter"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+const (
+	ListRecommendedName = "list"
+	listLong            = `
+List the pod subnets assigned to nodes
+
+For each node, shows the subnet pod IPs are allocated from, how many pods
+currently hold an IP from it, and how many pod IPs the subnet can hold.`
+
+	listExample = `  # List the subnets and pod IP usage of all nodes
+  $ %[1]s`
+)
+
+type ListHostSubnetsOptions struct {
+	Client     client.Interface
+	KubeClient kclient.Interface
+	Out        io.Writer
+}
+
+func NewCmdListHostSubnets(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &ListHostSubnetsOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "List node pod subnets and their usage",
+		Long:    listLong,
+		Example: fmt.Sprintf(listExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	return cmd
+}
+
+func (o *ListHostSubnetsOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed")
+	}
+	osClient, kubeClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	o.KubeClient = kubeClient
+	return nil
+}
+
+func (o *ListHostSubnetsOptions) Run() error {
+	subnets, err := o.Client.HostSubnets().List()
+	if err != nil {
+		return err
+	}
+	pods, err := o.KubeClient.Pods(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.Out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "NAME\tHOST IP\tSUBNET\tPODS\tCAPACITY")
+	for _, subnet := range subnets.Items {
+		used, capacity := subnetUtilization(&subnet, pods.Items)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\n", subnet.Name, subnet.HostIP, subnet.Subnet, used, capacity)
+	}
+	return nil
+}
+
+// subnetUtilization returns the number of pods with an IP in the subnet, and the number of pod IPs
+// the subnet can hold. The network and broadcast addresses and the node's gateway address cannot be
+// given to pods.
+func subnetUtilization(subnet *sdnapi.HostSubnet, pods []kapi.Pod) (int, int) {
+	_, cidr, err := net.ParseCIDR(subnet.Subnet)
+	if err != nil {
+		return 0, 0
+	}
+	used := 0
+	for _, pod := range pods {
+		if ip := net.ParseIP(pod.Status.PodIP); ip != nil && cidr.Contains(ip) {
+			used++
+		}
+	}
+	ones, bits := cidr.Mask.Size()
+	capacity := (1 << uint(bits-ones)) - 3
+	if capacity < 0 {
+		capacity = 0
+	}
+	return used, capacity
+}
@@ -0,0 +1,92 @@
+package hostsubnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	"github.com/openshift/origin/pkg/sdn/api/validation"
+)
+
+const (
+	VerifyRecommendedName = "verify"
+	verifyLong            = `
+Check the pod subnets assigned to nodes
+
+Reports each HostSubnet that is not a valid CIDR, does not have the size the
+cluster network allocates to each node, lies outside of the cluster network,
+overlaps the service network, or overlaps the subnet of another node. The
+master rejects such subnets when they are created, but subnets created before
+the cluster network changed are not checked again.
+Overlapping subnets cause pods on different nodes to be given the same IP.`
+
+	verifyExample = `  # Check all host subnets for overlaps
+  $ %[1]s`
+)
+
+type VerifyHostSubnetsOptions struct {
+	Client client.Interface
+	Out    io.Writer
+}
+
+func NewCmdVerifyHostSubnets(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &VerifyHostSubnetsOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "Check node pod subnets for overlaps",
+		Long:    verifyLong,
+		Example: fmt.Sprintf(verifyExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	return cmd
+}
+
+func (o *VerifyHostSubnetsOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed")
+	}
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	return nil
+}
+
+func (o *VerifyHostSubnetsOptions) Run() error {
+	clusterNetwork, err := o.Client.ClusterNetwork().Get(clusterNetworkName)
+	if err != nil {
+		return err
+	}
+	subnets, err := o.Client.HostSubnets().List()
+	if err != nil {
+		return err
+	}
+
+	problems := 0
+	for i := range subnets.Items {
+		for _, err := range validation.ValidateHostSubnetPlacement(&subnets.Items[i], subnets.Items[:i], clusterNetwork) {
+			fmt.Fprintf(o.Out, "%s: %v\n", subnets.Items[i].Name, err)
+			problems++
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problems with the host subnets", problems)
+	}
+	fmt.Fprintf(o.Out, "All %d host subnets are valid and do not overlap\n", len(subnets.Items))
+	return nil
+}
@@ -0,0 +1,21 @@
+package hostsubnet
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+func TestSubnetUtilization(t *testing.T) {
+	pod := func(ip string) kapi.Pod {
+		return kapi.Pod{Status: kapi.PodStatus{PodIP: ip}}
+	}
+	pods := []kapi.Pod{pod("10.1.2.2"), pod("10.1.2.3"), pod("10.1.3.2"), pod("")}
+
+	used, capacity := subnetUtilization(&sdnapi.HostSubnet{Subnet: "10.1.2.0/24"}, pods)
+	if used != 2 || capacity != 253 {
+		t.Errorf("expected 2 of 253 IPs to be used, got %d of %d", used, capacity)
+	}
+}
@@ -0,0 +1,600 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmd "k8s.io/kubernetes/pkg/kubectl/cmd"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kresource "k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	kutil "k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	"github.com/openshift/origin/pkg/cmd/util/variable"
+	configcmd "github.com/openshift/origin/pkg/config/cmd"
+	dapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/generate/app"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
+)
+
+const (
+	loggingLong = `
+Install or remove the aggregated logging stack
+
+This command sets up the components that collect the logs of the containers of the cluster
+and let users search them: Fluentd runs on the nodes and sends the logs of the containers to
+Elasticsearch, and Kibana serves the search interface through a route, behind a proxy that logs
+users in with the OAuth server of the master. With no arguments, the
+command will check for the existing service called 'logging-es' and create the stack if it does
+not exist. If you want to test whether the stack has been created add the --dry-run flag and
+the command will exit with 1 if it does not exist. Pass --delete to remove the stack; the
+persistent volume claim of Elasticsearch is kept unless --delete-storage is passed too.
+
+Fluentd runs once on every node matching --fluentd-node-selector; label the nodes whose logs
+should be collected before creating the stack, and scale the logging-fluentd deployment config
+when nodes are added. Elasticsearch and Kibana run on the nodes matching --selector.
+
+Elasticsearch serves HTTPS and only accepts Fluentd and Kibana, which authenticate with client
+certificates. The certificates are signed by a certificate authority generated for the logging
+stack, so that they are not trusted by the rest of the cluster. Elasticsearch stores the logs in an emptyDir volume that is lost when its pod is
+deleted unless a --storage-size is given, in which case a persistent volume claim of that size
+is created for it.
+
+NOTE: Fluentd reads the logs of the nodes from host directories. Once the stack is created,
+  allow its service account to do so with:
+
+    $ oadm policy add-scc-to-user privileged system:serviceaccount:<project>:aggregated-logging-fluentd
+    $ oadm policy add-cluster-role-to-user cluster-reader system:serviceaccount:<project>:aggregated-logging-fluentd`
+
+	loggingExample = `  # Check if the logging stack has been created
+  $ %[1]s %[2]s --dry-run
+
+  # See what the logging stack will look like if created
+  $ %[1]s %[2]s -o yaml --hostname=kibana.example.com
+
+  # Create a logging stack that collects the logs of the nodes labeled logging=true and stores them on 100GB
+  $ %[1]s %[2]s --hostname=kibana.example.com --fluentd-node-selector=logging=true --storage-size=100Gi
+
+  # Remove the logging stack, keeping the stored logs
+  $ %[1]s %[2]s --delete
+
+  # Remove the logging stack and the stored logs
+  $ %[1]s %[2]s --delete --delete-storage`
+)
+
+const (
+	elasticsearchName = "logging-es"
+	kibanaName        = "logging-kibana"
+	kibanaProxyName   = "logging-kibana-proxy"
+	fluentdName       = "logging-fluentd"
+
+	// fluentdServiceAccount runs Fluentd, which needs to read host directories
+	fluentdServiceAccount = "aggregated-logging-fluentd"
+	// fluentdPort is the host port of Fluentd, which keeps two Fluentd pods from running on the same node
+	fluentdPort = 24224
+
+	certsMountPath = "/etc/logging/certs"
+	// kibanaProxyPort is the port the proxy in front of Kibana serves HTTPS on
+	kibanaProxyPort = 3000
+	// kibanaProxySecretsPath holds the certificates of the proxy in front of Kibana and its OAuth secrets
+	kibanaProxySecretsPath = "/etc/logging/proxy"
+	// masterInternalURL is the URL the proxy in front of Kibana reaches the master at from its pod
+	masterInternalURL = "https://kubernetes.default.svc.cluster.local"
+	// serviceAccountCAPath is the certificate authority of the master mounted in every pod
+	serviceAccountCAPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+	// componentLabel is set on every object of the logging stack, to the component the object belongs to
+	componentLabel = "logging-infra"
+
+	defaultFluentdNodeSelector = "logging-infra-fluentd=true"
+)
+
+var errExit = fmt.Errorf("exit")
+
+// teardownResources are the resources of the objects of the logging stack removed by --delete. The replication
+// controllers and pods are removed with the deployment configs. The persistent volume claim of Elasticsearch is
+// only removed by --delete-storage.
+var teardownResources = []string{"deploymentconfigs", "routes", "services", "secrets", "serviceaccounts"}
+
+type LoggingConfig struct {
+	ImageTemplate       variable.ImageTemplate
+	Hostname            string
+	MasterURL           string
+	StorageSize         string
+	Selector            string
+	FluentdNodeSelector string
+	DryRun              bool
+	Delete              bool
+	DeleteStorage       bool
+}
+
+// NewCmdLogging implements the OpenShift cli logging command
+func NewCmdLogging(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
+	cfg := &LoggingConfig{
+		ImageTemplate:       variable.NewDefaultImageTemplate(),
+		FluentdNodeSelector: defaultFluentdNodeSelector,
+	}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "Install or remove the aggregated logging stack",
+		Long:    loggingLong,
+		Example: fmt.Sprintf(loggingExample, parentName, name),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "No arguments are allowed to this command"))
+			}
+			err := RunCmdLogging(f, cmd, out, cfg)
+			if err != errExit {
+				cmdutil.CheckErr(err)
+			} else {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.ImageTemplate.Format, "images", cfg.ImageTemplate.Format, "The image to base the components on - ${component} will be replaced with logging-elasticsearch, logging-kibana, logging-auth-proxy and logging-fluentd")
+	cmd.Flags().BoolVar(&cfg.ImageTemplate.Latest, "latest-images", cfg.ImageTemplate.Latest, "If true, attempt to use the latest images for the components instead of the latest release.")
+	cmd.Flags().StringVar(&cfg.Hostname, "hostname", cfg.Hostname, "The host name of the route to Kibana.")
+	cmd.Flags().StringVar(&cfg.MasterURL, "master-url", cfg.MasterURL, "The public URL of the master users log in to Kibana with. Defaults to the server of the current client configuration.")
+	cmd.Flags().StringVar(&cfg.StorageSize, "storage-size", cfg.StorageSize, "The size of the persistent volume claim created for Elasticsearch, for example 100Gi. If empty, the logs are lost when the Elasticsearch pod is deleted.")
+	cmd.Flags().StringVar(&cfg.Selector, "selector", cfg.Selector, "Selector used to filter nodes on deployment. Used to run Elasticsearch and Kibana on a specific set of nodes.")
+	cmd.Flags().StringVar(&cfg.FluentdNodeSelector, "fluentd-node-selector", cfg.FluentdNodeSelector, "Selector of the nodes whose logs are collected. Fluentd runs once on each of them.")
+	cmd.Flags().BoolVar(&cfg.DryRun, "dry-run", cfg.DryRun, "Check if the logging stack exists instead of creating it.")
+	cmd.Flags().BoolVar(&cfg.Delete, "delete", cfg.Delete, "Remove the logging stack instead of creating it.")
+	cmd.Flags().BoolVar(&cfg.DeleteStorage, "delete-storage", cfg.DeleteStorage, "With --delete, also remove the persistent volume claim holding the logs stored by Elasticsearch.")
+
+	cmdutil.AddPrinterFlags(cmd)
+
+	return cmd
+}
+
+// RunCmdLogging contains all the necessary functionality for the OpenShift cli logging command
+func RunCmdLogging(f *clientcmd.Factory, cmd *cobra.Command, out io.Writer, cfg *LoggingConfig) error {
+	namespace, _, err := f.OpenShiftClientConfig.Namespace()
+	if err != nil {
+		return fmt.Errorf("error getting client: %v", err)
+	}
+	_, kClient, err := f.Clients()
+	if err != nil {
+		return fmt.Errorf("error getting client: %v", err)
+	}
+
+	if cfg.Delete {
+		resources := teardownResources
+		if cfg.DeleteStorage {
+			resources = append(resources, "persistentvolumeclaims")
+		}
+		mapper, typer := f.Object()
+		shortOutput := cmdutil.GetFlagString(cmd, "output") == "name"
+		r := kresource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+			ContinueOnError().
+			NamespaceParam(namespace).DefaultNamespace().
+			SelectorParam(componentLabel).
+			ResourceTypeOrNameArgs(false, strings.Join(resources, ",")).
+			Flatten().
+			Do()
+		if r.Err() != nil {
+			return r.Err()
+		}
+		if err := kcmd.ReapResult(r, f.Factory, out, true, true, 5*time.Minute, -1, shortOutput, mapper); err != nil {
+			return err
+		}
+		// the OAuth client of the proxy is not namespaced, it is removed by name rather than by label so that the
+		// clients of the logging stacks of other projects are kept
+		r = kresource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+			ContinueOnError().
+			ResourceNames("oauthclients", kibanaProxyClientName(namespace)).
+			Flatten().
+			Do()
+		if r.Err() != nil {
+			return r.Err()
+		}
+		return kcmd.ReapResult(r, f.Factory, out, true, true, 5*time.Minute, -1, shortOutput, mapper)
+	}
+
+	_, output, err := cmdutil.PrinterForCommand(cmd)
+	if err != nil {
+		return fmt.Errorf("unable to configure printer: %v", err)
+	}
+
+	generate := output
+	if !generate {
+		_, err = kClient.Services(namespace).Get(elasticsearchName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("can't check for an existing logging stack: %v", err)
+			}
+			generate = true
+		}
+	}
+	if !generate {
+		fmt.Fprintf(out, "Logging stack %q service exists\n", elasticsearchName)
+		return nil
+	}
+	if cfg.DryRun && !output {
+		return fmt.Errorf("logging stack %q does not exist (no service).", elasticsearchName)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cmdutil.UsageError(cmd, "%v", err)
+	}
+	fluentdNodeSelector, err := labels.Parse(cfg.FluentdNodeSelector)
+	if err != nil {
+		return err
+	}
+	nodes, err := kClient.Nodes().List(fluentdNodeSelector, fields.Everything())
+	if err != nil {
+		return fmt.Errorf("unable to list the nodes whose logs are collected: %v", err)
+	}
+	if len(nodes.Items) == 0 {
+		fmt.Fprintf(cmd.Out(), "warning: no node matches %q, Fluentd will not run until nodes are labeled and the %s deployment config is scaled\n", cfg.FluentdNodeSelector, fluentdName)
+	}
+	if len(cfg.MasterURL) == 0 {
+		clientConfig, err := f.OpenShiftClientConfig.ClientConfig()
+		if err != nil {
+			return fmt.Errorf("unable to determine the URL of the master, pass --master-url: %v", err)
+		}
+		cfg.MasterURL = clientConfig.Host
+	}
+	ca, err := crypto.MakeCAConfig(fmt.Sprintf("logging-signer@%d", time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("unable to create the certificate authority of the logging stack: %v", err)
+	}
+	objects, err := cfg.Objects(namespace, ca, len(nodes.Items))
+	if err != nil {
+		return err
+	}
+	list := &kapi.List{Items: objects}
+
+	if output {
+		if err := f.PrintObject(cmd, list, out); err != nil {
+			return fmt.Errorf("unable to print object: %v", err)
+		}
+		return nil
+	}
+
+	mapper, typer := f.Factory.Object()
+	bulk := configcmd.Bulk{
+		Mapper:            mapper,
+		Typer:             typer,
+		RESTClientFactory: f.Factory.RESTClient,
+
+		After: configcmd.NewPrintNameOrErrorAfter(mapper, cmdutil.GetFlagString(cmd, "output") == "name", "created", out, cmd.Out()),
+	}
+	if errs := bulk.Create(list, namespace); len(errs) != 0 {
+		return errExit
+	}
+	return nil
+}
+
+// Validate checks the flags needed to generate the logging stack
+func (cfg *LoggingConfig) Validate() error {
+	if len(cfg.Hostname) == 0 {
+		return fmt.Errorf("the logging stack does not exist; you must specify the host name of the route to Kibana with --hostname")
+	}
+	if len(cfg.StorageSize) > 0 {
+		if _, err := resource.ParseQuantity(cfg.StorageSize); err != nil {
+			return fmt.Errorf("--storage-size must be a quantity like 100Gi: %v", err)
+		}
+	}
+	if _, err := nodeSelector(cfg.Selector); err != nil {
+		return fmt.Errorf("--selector: %v", err)
+	}
+	if _, err := nodeSelector(cfg.FluentdNodeSelector); err != nil {
+		return fmt.Errorf("--fluentd-node-selector: %v", err)
+	}
+	if len(cfg.MasterURL) > 0 {
+		if u, err := url.Parse(cfg.MasterURL); err != nil || u.Scheme != "https" || len(u.Host) == 0 {
+			return fmt.Errorf("--master-url must be an https URL")
+		}
+	}
+	return nil
+}
+
+// nodeSelector returns the node selector of pods described by spec
+func nodeSelector(spec string) (map[string]string, error) {
+	if len(spec) == 0 {
+		return nil, nil
+	}
+	valid, remove, err := app.LabelsFromSpec(strings.Split(spec, ","))
+	if err != nil {
+		return nil, err
+	}
+	if len(remove) > 0 {
+		return nil, fmt.Errorf("you may not pass negative labels in %q", spec)
+	}
+	return valid, nil
+}
+
+// Objects returns the objects of the logging stack in namespace with Fluentd scaled to fluentdReplicas, and the
+// certificates of Elasticsearch, Fluentd, Kibana and its proxy signed by ca, the certificate authority of the
+// logging stack
+func (cfg *LoggingConfig) Objects(namespace string, ca *crypto.CA, fluentdReplicas int) ([]runtime.Object, error) {
+	selector, err := nodeSelector(cfg.Selector)
+	if err != nil {
+		return nil, err
+	}
+	fluentdSelector, err := nodeSelector(cfg.FluentdNodeSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := sets.NewString(
+		elasticsearchName,
+		fmt.Sprintf("%s.%s.svc", elasticsearchName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", elasticsearchName, namespace),
+	)
+	serving, err := ca.MakeServerCertConfig(hostnames)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the serving certificate of Elasticsearch: %v", err)
+	}
+	esSecret, err := certsSecret(elasticsearchName, ca, serving)
+	if err != nil {
+		return nil, err
+	}
+	fluentdCert, err := ca.MakeClientCertificateConfig(&user.DefaultInfo{Name: "system.logging.fluentd"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the client certificate of Fluentd: %v", err)
+	}
+	fluentdSecret, err := certsSecret(fluentdName, ca, fluentdCert)
+	if err != nil {
+		return nil, err
+	}
+	kibanaCert, err := ca.MakeClientCertificateConfig(&user.DefaultInfo{Name: "system.logging.kibana"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the client certificate of Kibana: %v", err)
+	}
+	kibanaSecret, err := certsSecret(kibanaName, ca, kibanaCert)
+	if err != nil {
+		return nil, err
+	}
+	proxyServing, err := ca.MakeServerCertConfig(sets.NewString(
+		kibanaName,
+		fmt.Sprintf("%s.%s.svc", kibanaName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", kibanaName, namespace),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the serving certificate of the Kibana proxy: %v", err)
+	}
+	proxySecret, err := certsSecret(kibanaProxyName, ca, proxyServing)
+	if err != nil {
+		return nil, err
+	}
+	oauthSecret := randomSecret()
+	proxySecret.Data["oauth-secret"] = []byte(oauthSecret)
+	proxySecret.Data["session-secret"] = []byte(randomSecret())
+	caBytes, _, err := ca.Config.GetPEMBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	esURL := fmt.Sprintf("https://%s:9200", elasticsearchName)
+	certsEnv := []kapi.EnvVar{
+		{Name: "ES_URL", Value: esURL},
+		{Name: "ES_CA", Value: certsMountPath + "/ca.crt"},
+		{Name: "ES_CLIENT_CERT", Value: certsMountPath + "/tls.crt"},
+		{Name: "ES_CLIENT_KEY", Value: certsMountPath + "/tls.key"},
+	}
+
+	objects := []runtime.Object{
+		&kapi.ServiceAccount{ObjectMeta: kapi.ObjectMeta{Name: fluentdServiceAccount, Labels: componentLabels(fluentdName)}},
+		esSecret,
+		fluentdSecret,
+		kibanaSecret,
+		proxySecret,
+		&oauthapi.OAuthClient{
+			ObjectMeta:   kapi.ObjectMeta{Name: kibanaProxyClientName(namespace), Labels: componentLabels(kibanaProxyName)},
+			Secret:       oauthSecret,
+			RedirectURIs: []string{"https://" + cfg.Hostname},
+		},
+	}
+
+	esVolume := kapi.VolumeSource{EmptyDir: &kapi.EmptyDirVolumeSource{}}
+	if len(cfg.StorageSize) > 0 {
+		objects = append(objects, &kapi.PersistentVolumeClaim{
+			ObjectMeta: kapi.ObjectMeta{Name: elasticsearchName, Labels: componentLabels(elasticsearchName)},
+			Spec: kapi.PersistentVolumeClaimSpec{
+				AccessModes: []kapi.PersistentVolumeAccessMode{kapi.ReadWriteOnce},
+				Resources: kapi.ResourceRequirements{
+					Requests: kapi.ResourceList{kapi.ResourceStorage: resource.MustParse(cfg.StorageSize)},
+				},
+			},
+		})
+		esVolume = kapi.VolumeSource{PersistentVolumeClaim: &kapi.PersistentVolumeClaimVolumeSource{ClaimName: elasticsearchName}}
+	}
+
+	elasticsearch := kapi.Container{
+		Name:  elasticsearchName,
+		Image: cfg.ImageTemplate.ExpandOrDie("logging-elasticsearch"),
+		Ports: []kapi.ContainerPort{{Name: "https", ContainerPort: 9200}},
+		Env: []kapi.EnvVar{
+			{Name: "ES_SERVER_CERT", Value: certsMountPath + "/tls.crt"},
+			{Name: "ES_SERVER_KEY", Value: certsMountPath + "/tls.key"},
+			{Name: "ES_CA", Value: certsMountPath + "/ca.crt"},
+		},
+		VolumeMounts: []kapi.VolumeMount{
+			certsMount(),
+			{Name: "elasticsearch-storage", MountPath: "/elasticsearch/persistent"},
+		},
+	}
+	objects = append(objects,
+		deploymentConfig(elasticsearchName, "", 1, selector, []kapi.Container{elasticsearch},
+			certsVolume(elasticsearchName),
+			kapi.Volume{Name: "elasticsearch-storage", VolumeSource: esVolume},
+		),
+		service(elasticsearchName, 9200, 9200),
+	)
+
+	// Kibana is only reached through the proxy in its pod, which logs users in with the OAuth server of the master
+	// and passes their name and token to Kibana
+	kibana := kapi.Container{
+		Name:         kibanaName,
+		Image:        cfg.ImageTemplate.ExpandOrDie("logging-kibana"),
+		Env:          certsEnv,
+		VolumeMounts: []kapi.VolumeMount{certsMount()},
+	}
+	proxy := kapi.Container{
+		Name:  kibanaProxyName,
+		Image: cfg.ImageTemplate.ExpandOrDie("logging-auth-proxy"),
+		Ports: []kapi.ContainerPort{{Name: "https", ContainerPort: kibanaProxyPort}},
+		Env: []kapi.EnvVar{
+			{Name: "OAP_BACKEND_URL", Value: "http://localhost:5601"},
+			{Name: "OAP_AUTH_MODE", Value: "oauth2"},
+			{Name: "OAP_TRANSFORM", Value: "user_header,token_header"},
+			{Name: "OAP_OAUTH_ID", Value: kibanaProxyClientName(namespace)},
+			{Name: "OAP_MASTER_URL", Value: masterInternalURL},
+			{Name: "OAP_PUBLIC_MASTER_URL", Value: cfg.MasterURL},
+			{Name: "OAP_MASTER_CA_FILE", Value: serviceAccountCAPath},
+			{Name: "OAP_SERVER_CERT_FILE", Value: kibanaProxySecretsPath + "/tls.crt"},
+			{Name: "OAP_SERVER_KEY_FILE", Value: kibanaProxySecretsPath + "/tls.key"},
+			{Name: "OAP_OAUTH_SECRET_FILE", Value: kibanaProxySecretsPath + "/oauth-secret"},
+			{Name: "OAP_SESSION_SECRET_FILE", Value: kibanaProxySecretsPath + "/session-secret"},
+		},
+		VolumeMounts: []kapi.VolumeMount{{Name: "proxy-secrets", MountPath: kibanaProxySecretsPath, ReadOnly: true}},
+	}
+	objects = append(objects,
+		deploymentConfig(kibanaName, "", 1, selector, []kapi.Container{kibana, proxy},
+			certsVolume(kibanaName),
+			kapi.Volume{Name: "proxy-secrets", VolumeSource: kapi.VolumeSource{Secret: &kapi.SecretVolumeSource{SecretName: kibanaProxyName + "-certs"}}},
+		),
+		service(kibanaName, 443, kibanaProxyPort),
+		&routeapi.Route{
+			ObjectMeta: kapi.ObjectMeta{Name: kibanaName, Labels: componentLabels(kibanaName)},
+			Spec: routeapi.RouteSpec{
+				Host: cfg.Hostname,
+				To:   kapi.ObjectReference{Kind: "Service", Name: kibanaName},
+				TLS: &routeapi.TLSConfig{
+					Termination:              routeapi.TLSTerminationReencrypt,
+					DestinationCACertificate: string(caBytes),
+				},
+			},
+		},
+	)
+
+	privileged := true
+	fluentd := kapi.Container{
+		Name:  fluentdName,
+		Image: cfg.ImageTemplate.ExpandOrDie("logging-fluentd"),
+		Ports: []kapi.ContainerPort{{Name: "forward", ContainerPort: fluentdPort, HostPort: fluentdPort}},
+		Env:   certsEnv,
+		VolumeMounts: []kapi.VolumeMount{
+			certsMount(),
+			{Name: "varlog", MountPath: "/var/log"},
+			{Name: "varlibdockercontainers", MountPath: "/var/lib/docker/containers", ReadOnly: true},
+		},
+		SecurityContext: &kapi.SecurityContext{Privileged: &privileged},
+	}
+	objects = append(objects,
+		deploymentConfig(fluentdName, fluentdServiceAccount, fluentdReplicas, fluentdSelector, []kapi.Container{fluentd},
+			certsVolume(fluentdName),
+			kapi.Volume{Name: "varlog", VolumeSource: kapi.VolumeSource{HostPath: &kapi.HostPathVolumeSource{Path: "/var/log"}}},
+			kapi.Volume{Name: "varlibdockercontainers", VolumeSource: kapi.VolumeSource{HostPath: &kapi.HostPathVolumeSource{Path: "/var/lib/docker/containers"}}},
+		),
+	)
+	return objects, nil
+}
+
+// kibanaProxyClientName is the name of the OAuth client of the proxy in front of Kibana in namespace. OAuth clients
+// are not namespaced, so the name includes the namespace of the logging stack.
+func kibanaProxyClientName(namespace string) string {
+	return kibanaProxyName + "-" + namespace
+}
+
+// randomSecret returns a random string for the secrets of the proxy in front of Kibana
+func randomSecret() string {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		// rand.Reader should never fail
+		panic(err.Error())
+	}
+	return strings.TrimRight(base64.URLEncoding.EncodeToString(b), "=")
+}
+
+// certsSecret returns the secret of component holding its certificate, key and the certificate of ca
+func certsSecret(component string, ca *crypto.CA, cert *crypto.TLSCertificateConfig) (*kapi.Secret, error) {
+	certBytes, keyBytes, err := cert.GetPEMBytes()
+	if err != nil {
+		return nil, err
+	}
+	caBytes, _, err := ca.Config.GetPEMBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Name: component + "-certs", Labels: componentLabels(component)},
+		Data: map[string][]byte{
+			"tls.crt": certBytes,
+			"tls.key": keyBytes,
+			"ca.crt":  caBytes,
+		},
+	}, nil
+}
+
+func certsVolume(component string) kapi.Volume {
+	return kapi.Volume{Name: "certs", VolumeSource: kapi.VolumeSource{Secret: &kapi.SecretVolumeSource{SecretName: component + "-certs"}}}
+}
+
+func certsMount() kapi.VolumeMount {
+	return kapi.VolumeMount{Name: "certs", MountPath: certsMountPath, ReadOnly: true}
+}
+
+// componentLabels are the labels of the objects of a component of the logging stack
+func componentLabels(component string) map[string]string {
+	return map[string]string{componentLabel: component}
+}
+
+// deploymentConfig returns the deployment config running replicas of containers with volumes for component
+func deploymentConfig(component, serviceAccount string, replicas int, nodeSelector map[string]string, containers []kapi.Container, volumes ...kapi.Volume) *dapi.DeploymentConfig {
+	labels := componentLabels(component)
+	return &dapi.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: component, Labels: labels},
+		Spec: dapi.DeploymentConfigSpec{
+			Replicas: replicas,
+			Selector: labels,
+			Triggers: []dapi.DeploymentTriggerPolicy{
+				{Type: dapi.DeploymentTriggerOnConfigChange},
+			},
+			Template: &kapi.PodTemplateSpec{
+				ObjectMeta: kapi.ObjectMeta{Labels: labels},
+				Spec: kapi.PodSpec{
+					ServiceAccountName: serviceAccount,
+					NodeSelector:       nodeSelector,
+					Containers:         containers,
+					Volumes:            volumes,
+				},
+			},
+		},
+	}
+}
+
+// service returns the service of component, forwarding port to targetPort of its pods
+func service(component string, port, targetPort int) *kapi.Service {
+	labels := componentLabels(component)
+	return &kapi.Service{
+		ObjectMeta: kapi.ObjectMeta{Name: component, Labels: labels},
+		Spec: kapi.ServiceSpec{
+			Selector: labels,
+			Ports: []kapi.ServicePort{
+				{Port: port, TargetPort: kutil.NewIntOrStringFromInt(targetPort)},
+			},
+		},
+	}
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
+	"github.com/openshift/origin/pkg/cmd/util/variable"
+	dapi "github.com/openshift/origin/pkg/deploy/api"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
+)
+
+func TestObjects(t *testing.T) {
+	ca, err := crypto.MakeCAConfig("test-signer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := &LoggingConfig{
+		ImageTemplate:       variable.ImageTemplate{Format: "openshift/origin-${component}:test"},
+		Hostname:            "kibana.example.com",
+		MasterURL:           "https://master.example.com:8443",
+		StorageSize:         "100Gi",
+		FluentdNodeSelector: "logging=true",
+	}
+	objects, err := cfg.Objects("logging", ca, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var claim *kapi.PersistentVolumeClaim
+	var route *routeapi.Route
+	var client *oauthapi.OAuthClient
+	secrets := map[string]*kapi.Secret{}
+	configs := map[string]*dapi.DeploymentConfig{}
+	for _, obj := range objects {
+		switch t := obj.(type) {
+		case *kapi.Secret:
+			secrets[t.Name] = t
+		case *kapi.PersistentVolumeClaim:
+			claim = t
+		case *routeapi.Route:
+			route = t
+		case *oauthapi.OAuthClient:
+			client = t
+		case *dapi.DeploymentConfig:
+			configs[t.Name] = t
+		}
+		if accessor, err := kapi.ObjectMetaFor(obj); err != nil || len(accessor.Labels[componentLabel]) == 0 {
+			t.Errorf("expected every object to be labeled for --delete, got %#v", obj)
+		}
+	}
+
+	esSecret := secrets["logging-es-certs"]
+	if esSecret == nil {
+		t.Fatalf("expected a secret with the serving certificate of Elasticsearch")
+	}
+	certs, err := crypto.CertsFromPEM(esSecret.Data["tls.crt"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := certs[0].VerifyHostname("logging-es.logging.svc"); err != nil {
+		t.Errorf("expected the serving certificate to be valid for the service: %v", err)
+	}
+	fluentdSecret := secrets["logging-fluentd-certs"]
+	if fluentdSecret == nil {
+		t.Fatalf("expected a secret with the client certificate of Fluentd")
+	}
+	certs, err = crypto.CertsFromPEM(fluentdSecret.Data["tls.crt"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if certs[0].Subject.CommonName != "system.logging.fluentd" {
+		t.Errorf("unexpected client certificate %s", certs[0].Subject.CommonName)
+	}
+	if certs[0].Issuer.CommonName != "test-signer" {
+		t.Errorf("expected the client certificate to be signed by the logging CA, got %s", certs[0].Issuer.CommonName)
+	}
+
+	if claim == nil {
+		t.Fatalf("expected a claim for the storage of Elasticsearch")
+	}
+	if size := claim.Spec.Resources.Requests[kapi.ResourceStorage]; size.String() != "100Gi" {
+		t.Errorf("unexpected storage size %s", size.String())
+	}
+	if route == nil || route.Spec.Host != "kibana.example.com" || route.Spec.TLS == nil || route.Spec.TLS.Termination != routeapi.TLSTerminationReencrypt || route.Spec.TLS.DestinationCACertificate != string(esSecret.Data["ca.crt"]) {
+		t.Errorf("expected a reencrypt route to the Kibana proxy for the host name, got %#v", route)
+	}
+
+	// the proxy is the only container of the Kibana pod that is exposed, and is registered as an OAuth client
+	proxySecret := secrets["logging-kibana-proxy-certs"]
+	if proxySecret == nil || len(proxySecret.Data["oauth-secret"]) == 0 || len(proxySecret.Data["session-secret"]) == 0 {
+		t.Fatalf("expected a secret with the certificate and secrets of the Kibana proxy, got %#v", proxySecret)
+	}
+	if client == nil || client.Name != "logging-kibana-proxy-logging" || client.Secret != string(proxySecret.Data["oauth-secret"]) || len(client.RedirectURIs) != 1 || client.RedirectURIs[0] != "https://kibana.example.com" {
+		t.Errorf("unexpected OAuth client of the Kibana proxy %#v", client)
+	}
+	kibana := configs[kibanaName]
+	if kibana == nil || len(kibana.Spec.Template.Spec.Containers) != 2 {
+		t.Fatalf("expected a deployment config running Kibana and its proxy, got %#v", kibana)
+	}
+	if containers := kibana.Spec.Template.Spec.Containers; len(containers[0].Ports) != 0 || containers[1].Ports[0].ContainerPort != kibanaProxyPort {
+		t.Errorf("expected only the proxy to expose a port, got %#v", containers)
+	}
+
+	fluentd := configs[fluentdName]
+	if fluentd == nil {
+		t.Fatalf("expected a deployment config for fluentd")
+	}
+	if fluentd.Spec.Replicas != 3 {
+		t.Errorf("expected fluentd to run on every selected node, got %d replicas", fluentd.Spec.Replicas)
+	}
+	if selector := fluentd.Spec.Template.Spec.NodeSelector; selector["logging"] != "true" {
+		t.Errorf("unexpected node selector %v", selector)
+	}
+	container := fluentd.Spec.Template.Spec.Containers[0]
+	if container.Image != "openshift/origin-logging-fluentd:test" {
+		t.Errorf("unexpected image %s", container.Image)
+	}
+	if container.Ports[0].HostPort != fluentdPort {
+		t.Errorf("expected fluentd to use a host port, got %#v", container.Ports[0])
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := &LoggingConfig{}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected the host name to be required")
+	}
+	cfg = &LoggingConfig{Hostname: "kibana.example.com", StorageSize: "lots"}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected an invalid storage size to be rejected")
+	}
+	cfg = &LoggingConfig{Hostname: "kibana.example.com", FluentdNodeSelector: "logging-"}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected a negative node selector to be rejected")
+	}
+	cfg = &LoggingConfig{Hostname: "kibana.example.com", MasterURL: "http://master.example.com"}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected a master URL without https to be rejected")
+	}
+}
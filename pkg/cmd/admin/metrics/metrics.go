@@ -0,0 +1,405 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/runtime"
+	kutil "k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	"github.com/openshift/origin/pkg/cmd/util/variable"
+	configcmd "github.com/openshift/origin/pkg/config/cmd"
+	dapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/generate/app"
+	routeapi "github.com/openshift/origin/pkg/route/api"
+)
+
+const (
+	metricsLong = `
+Install the cluster metrics stack
+
+This command sets up the components that collect the CPU and memory usage of the pods of the
+cluster and serve it to the web console: Heapster collects the usage from the nodes, Hawkular
+Metrics stores it in Cassandra and serves it through a route. With no arguments, the command
+will check for the existing service called 'hawkular-metrics' and create the stack if it does
+not exist. If you want to test whether the stack has been created add the --dry-run flag and
+the command will exit with 1 if it does not exist.
+
+Hawkular Metrics only serves the requests with a token of the master, and only the metrics of
+the projects the user of the token can view; Heapster writes the metrics with the token of its
+service account. Hawkular Metrics serves HTTPS with a certificate for its service names, signed
+by a certificate authority generated for the metrics stack, so that it is not trusted by the
+rest of the cluster. The route terminates TLS for --hostname with the certificate of the router
+and reencrypts the traffic to Hawkular Metrics.
+
+The --size flag sets the memory of the components for the number of pods of the cluster:
+small (up to 100 pods), medium (up to 1000 pods) or large (more than 1000 pods). Cassandra
+stores the metrics in an emptyDir volume that is lost when its pod is deleted unless a
+persistent volume claim is passed with --storage-claim.
+
+NOTE: Heapster reads the pods and nodes of every project. Once the stack is created, grant its
+  service account access to them with:
+
+    $ oadm policy add-cluster-role-to-user cluster-reader system:serviceaccount:<project>:heapster
+
+  and set assetConfig.metricsPublicURL in the master configuration to the URL printed by the
+  command for the web console to show the metrics.`
+
+	metricsExample = `  # Check if the metrics stack has been created
+  $ %[1]s %[2]s --dry-run
+
+  # See what the metrics stack will look like if created
+  $ %[1]s %[2]s -o yaml --hostname=metrics.example.com
+
+  # Create a metrics stack for a large cluster that stores the metrics on a persistent volume
+  $ %[1]s %[2]s --hostname=metrics.example.com --size=large --storage-claim=metrics-cassandra`
+)
+
+const (
+	hawkularName  = "hawkular-metrics"
+	cassandraName = "hawkular-cassandra"
+	heapsterName  = "heapster"
+
+	// certsSecretName is the secret holding the serving certificate of Hawkular Metrics and the certificate of the
+	// certificate authority of the metrics stack
+	certsSecretName = "hawkular-metrics-certs"
+	certsMountPath  = "/secrets"
+
+	// serviceAccountCAPath is the certificate authority of the master mounted in every pod
+	serviceAccountCAPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+	defaultMasterURL = "https://kubernetes.default.svc:443"
+)
+
+var errExit = fmt.Errorf("exit")
+
+// metricsSize is the memory of the components of the metrics stack
+type metricsSize struct {
+	cassandra string
+	hawkular  string
+	heapster  string
+}
+
+// metricsSizes are the presets of --size
+var metricsSizes = map[string]metricsSize{
+	"small":  {cassandra: "1Gi", hawkular: "1Gi", heapster: "512Mi"},
+	"medium": {cassandra: "2Gi", hawkular: "2Gi", heapster: "1Gi"},
+	"large":  {cassandra: "4Gi", hawkular: "4Gi", heapster: "2Gi"},
+}
+
+type MetricsConfig struct {
+	ImageTemplate    variable.ImageTemplate
+	Hostname         string
+	Size             string
+	StorageClaim     string
+	MetricResolution string
+	MasterURL        string
+	Selector         map[string]string
+	DryRun           bool
+}
+
+// NewCmdMetrics implements the OpenShift cli metrics command
+func NewCmdMetrics(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
+	cfg := &MetricsConfig{
+		ImageTemplate:    variable.NewDefaultImageTemplate(),
+		Size:             "small",
+		MetricResolution: "15s",
+		MasterURL:        defaultMasterURL,
+	}
+	var selector string
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "Install the cluster metrics stack",
+		Long:    metricsLong,
+		Example: fmt.Sprintf(metricsExample, parentName, name),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "No arguments are allowed to this command"))
+			}
+			if len(selector) > 0 {
+				valid, remove, err := app.LabelsFromSpec(strings.Split(selector, ","))
+				if err != nil {
+					cmdutil.CheckErr(err)
+				}
+				if len(remove) > 0 {
+					cmdutil.CheckErr(cmdutil.UsageError(cmd, "You may not pass negative labels in selector %q", selector))
+				}
+				cfg.Selector = valid
+			}
+			err := RunCmdMetrics(f, cmd, out, cfg)
+			if err != errExit {
+				cmdutil.CheckErr(err)
+			} else {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.ImageTemplate.Format, "images", cfg.ImageTemplate.Format, "The image to base the components on - ${component} will be replaced with metrics-heapster, metrics-hawkular-metrics and metrics-cassandra")
+	cmd.Flags().BoolVar(&cfg.ImageTemplate.Latest, "latest-images", cfg.ImageTemplate.Latest, "If true, attempt to use the latest images for the components instead of the latest release.")
+	cmd.Flags().StringVar(&cfg.Hostname, "hostname", cfg.Hostname, "The host name of the route to Hawkular Metrics the web console reads metrics from.")
+	cmd.Flags().StringVar(&cfg.Size, "size", cfg.Size, "The size of the cluster the components are sized for: small, medium or large.")
+	cmd.Flags().StringVar(&cfg.StorageClaim, "storage-claim", cfg.StorageClaim, "The name of a persistent volume claim to store the metrics on. If empty, the metrics are lost when the Cassandra pod is deleted.")
+	cmd.Flags().StringVar(&cfg.MetricResolution, "metric-resolution", cfg.MetricResolution, "How often Heapster collects the metrics.")
+	cmd.Flags().StringVar(&cfg.MasterURL, "master-url", cfg.MasterURL, "The URL Heapster and Hawkular Metrics reach the master at from their pods.")
+	cmd.Flags().StringVar(&selector, "selector", selector, "Selector used to filter nodes on deployment. Used to run the components on a specific set of nodes.")
+	cmd.Flags().BoolVar(&cfg.DryRun, "dry-run", cfg.DryRun, "Check if the metrics stack exists instead of creating it.")
+
+	cmdutil.AddPrinterFlags(cmd)
+
+	return cmd
+}
+
+// RunCmdMetrics contains all the necessary functionality for the OpenShift cli metrics command
+func RunCmdMetrics(f *clientcmd.Factory, cmd *cobra.Command, out io.Writer, cfg *MetricsConfig) error {
+	namespace, _, err := f.OpenShiftClientConfig.Namespace()
+	if err != nil {
+		return fmt.Errorf("error getting client: %v", err)
+	}
+	_, kClient, err := f.Clients()
+	if err != nil {
+		return fmt.Errorf("error getting client: %v", err)
+	}
+
+	_, output, err := cmdutil.PrinterForCommand(cmd)
+	if err != nil {
+		return fmt.Errorf("unable to configure printer: %v", err)
+	}
+
+	generate := output
+	if !generate {
+		_, err = kClient.Services(namespace).Get(hawkularName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("can't check for an existing metrics stack: %v", err)
+			}
+			generate = true
+		}
+	}
+	if !generate {
+		fmt.Fprintf(out, "Metrics stack %q service exists\n", hawkularName)
+		return nil
+	}
+	if cfg.DryRun && !output {
+		return fmt.Errorf("metrics stack %q does not exist (no service).", hawkularName)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cmdutil.UsageError(cmd, "%v", err)
+	}
+	ca, err := crypto.MakeCAConfig(fmt.Sprintf("metrics-signer@%d", time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("unable to create the certificate authority of the metrics stack: %v", err)
+	}
+	objects, err := cfg.Objects(namespace, ca)
+	if err != nil {
+		return err
+	}
+	list := &kapi.List{Items: objects}
+
+	if output {
+		if err := f.PrintObject(cmd, list, out); err != nil {
+			return fmt.Errorf("unable to print object: %v", err)
+		}
+		return nil
+	}
+
+	mapper, typer := f.Factory.Object()
+	bulk := configcmd.Bulk{
+		Mapper:            mapper,
+		Typer:             typer,
+		RESTClientFactory: f.Factory.RESTClient,
+
+		After: configcmd.NewPrintNameOrErrorAfter(mapper, cmdutil.GetFlagString(cmd, "output") == "name", "created", out, cmd.Out()),
+	}
+	if errs := bulk.Create(list, namespace); len(errs) != 0 {
+		return errExit
+	}
+	fmt.Fprintf(out, "\nSet assetConfig.metricsPublicURL in the master configuration to %s\n", cfg.publicURL())
+	fmt.Fprintf(out, "Grant Heapster access to the pods and nodes of the cluster with:\n  oadm policy add-cluster-role-to-user cluster-reader system:serviceaccount:%s:%s\n", namespace, heapsterName)
+	return nil
+}
+
+// Validate checks the flags needed to generate the metrics stack
+func (cfg *MetricsConfig) Validate() error {
+	if len(cfg.Hostname) == 0 {
+		return fmt.Errorf("the metrics stack does not exist; you must specify the host name of the route to Hawkular Metrics with --hostname")
+	}
+	if _, ok := metricsSizes[cfg.Size]; !ok {
+		sizes := []string{}
+		for size := range metricsSizes {
+			sizes = append(sizes, size)
+		}
+		return fmt.Errorf("--size must be one of %s", strings.Join(sets.NewString(sizes...).List(), ", "))
+	}
+	return nil
+}
+
+// publicURL is the URL the web console reads metrics from
+func (cfg *MetricsConfig) publicURL() string {
+	return fmt.Sprintf("https://%s/hawkular/metrics", cfg.Hostname)
+}
+
+// Objects returns the objects of the metrics stack in namespace, with the serving certificate of Hawkular Metrics
+// signed by ca, the certificate authority of the metrics stack
+func (cfg *MetricsConfig) Objects(namespace string, ca *crypto.CA) ([]runtime.Object, error) {
+	size := metricsSizes[cfg.Size]
+
+	// the route terminates TLS for cfg.Hostname, the certificate is only valid for the service
+	hostnames := sets.NewString(
+		hawkularName,
+		fmt.Sprintf("%s.%s.svc", hawkularName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", hawkularName, namespace),
+	)
+	serving, err := ca.MakeServerCertConfig(hostnames)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the serving certificate of Hawkular Metrics: %v", err)
+	}
+	certBytes, keyBytes, err := serving.GetPEMBytes()
+	if err != nil {
+		return nil, err
+	}
+	caBytes, _, err := ca.Config.GetPEMBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	cassandraVolume := kapi.VolumeSource{EmptyDir: &kapi.EmptyDirVolumeSource{}}
+	if len(cfg.StorageClaim) > 0 {
+		cassandraVolume = kapi.VolumeSource{PersistentVolumeClaim: &kapi.PersistentVolumeClaimVolumeSource{ClaimName: cfg.StorageClaim}}
+	}
+	certsVolume := kapi.Volume{Name: "certs", VolumeSource: kapi.VolumeSource{Secret: &kapi.SecretVolumeSource{SecretName: certsSecretName}}}
+	certsMount := kapi.VolumeMount{Name: "certs", MountPath: certsMountPath, ReadOnly: true}
+
+	cassandra := kapi.Container{
+		Name:      cassandraName,
+		Image:     cfg.ImageTemplate.ExpandOrDie("metrics-cassandra"),
+		Ports:     []kapi.ContainerPort{{Name: "cql", ContainerPort: 9042}},
+		Resources: memoryLimit(size.cassandra),
+		VolumeMounts: []kapi.VolumeMount{
+			{Name: "cassandra-data", MountPath: "/cassandra_data"},
+		},
+	}
+	hawkular := kapi.Container{
+		Name:      hawkularName,
+		Image:     cfg.ImageTemplate.ExpandOrDie("metrics-hawkular-metrics"),
+		Ports:     []kapi.ContainerPort{{Name: "https", ContainerPort: 8443}},
+		Resources: memoryLimit(size.hawkular),
+		Env: []kapi.EnvVar{
+			{Name: "CASSANDRA_NODES", Value: cassandraName},
+			{Name: "HAWKULAR_METRICS_TLS_CERT_FILE", Value: certsMountPath + "/tls.crt"},
+			{Name: "HAWKULAR_METRICS_TLS_KEY_FILE", Value: certsMountPath + "/tls.key"},
+			// requests are authenticated and authorized for their tenant, the project of the metrics, by the master
+			{Name: "HAWKULAR_METRICS_AUTH_METHOD", Value: "openshift-oauth"},
+			{Name: "MASTER_URL", Value: cfg.MasterURL},
+			{Name: "MASTER_CA_FILE", Value: serviceAccountCAPath},
+		},
+		VolumeMounts: []kapi.VolumeMount{certsMount},
+	}
+	heapster := kapi.Container{
+		Name:      heapsterName,
+		Image:     cfg.ImageTemplate.ExpandOrDie("metrics-heapster"),
+		Ports:     []kapi.ContainerPort{{Name: "http", ContainerPort: 8082}},
+		Resources: memoryLimit(size.heapster),
+		Command: []string{
+			"heapster",
+			fmt.Sprintf("--source=kubernetes:%s?useServiceAccount=true&kubeletHttps=true&kubeletPort=10250", cfg.MasterURL),
+			fmt.Sprintf("--sink=hawkular:https://%s:443?tenant=_system&labelToTenant=pod_namespace&useServiceAccount=true&caCert=%s/ca.crt", hawkularName, certsMountPath),
+			"--metric_resolution=" + cfg.MetricResolution,
+		},
+		VolumeMounts: []kapi.VolumeMount{certsMount},
+	}
+
+	objects := []runtime.Object{
+		&kapi.ServiceAccount{ObjectMeta: kapi.ObjectMeta{Name: heapsterName, Labels: componentLabels(heapsterName)}},
+		&kapi.ServiceAccount{ObjectMeta: kapi.ObjectMeta{Name: hawkularName, Labels: componentLabels(hawkularName)}},
+		&kapi.Secret{
+			ObjectMeta: kapi.ObjectMeta{Name: certsSecretName, Labels: componentLabels(hawkularName)},
+			Data: map[string][]byte{
+				"tls.crt": certBytes,
+				"tls.key": keyBytes,
+				"ca.crt":  caBytes,
+			},
+		},
+		cfg.deploymentConfig(cassandraName, "", cassandra, kapi.Volume{Name: "cassandra-data", VolumeSource: cassandraVolume}),
+		service(cassandraName, 9042, 9042),
+		cfg.deploymentConfig(hawkularName, hawkularName, hawkular, certsVolume),
+		service(hawkularName, 443, 8443),
+		cfg.deploymentConfig(heapsterName, heapsterName, heapster, certsVolume),
+		service(heapsterName, 80, 8082),
+		&routeapi.Route{
+			ObjectMeta: kapi.ObjectMeta{Name: hawkularName, Labels: componentLabels(hawkularName)},
+			Spec: routeapi.RouteSpec{
+				Host: cfg.Hostname,
+				To:   kapi.ObjectReference{Kind: "Service", Name: hawkularName},
+				TLS: &routeapi.TLSConfig{
+					Termination:              routeapi.TLSTerminationReencrypt,
+					DestinationCACertificate: string(caBytes),
+				},
+			},
+		},
+	}
+	return objects, nil
+}
+
+// componentLabels are the labels of the objects of a component of the metrics stack
+func componentLabels(component string) map[string]string {
+	return map[string]string{"metrics-infra": component}
+}
+
+// deploymentConfig returns the deployment config running container with volume as the only replica of component
+func (cfg *MetricsConfig) deploymentConfig(component, serviceAccount string, container kapi.Container, volume kapi.Volume) *dapi.DeploymentConfig {
+	labels := componentLabels(component)
+	return &dapi.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: component, Labels: labels},
+		Spec: dapi.DeploymentConfigSpec{
+			Replicas: 1,
+			Selector: labels,
+			Triggers: []dapi.DeploymentTriggerPolicy{
+				{Type: dapi.DeploymentTriggerOnConfigChange},
+			},
+			Template: &kapi.PodTemplateSpec{
+				ObjectMeta: kapi.ObjectMeta{Labels: labels},
+				Spec: kapi.PodSpec{
+					ServiceAccountName: serviceAccount,
+					NodeSelector:       cfg.Selector,
+					Containers:         []kapi.Container{container},
+					Volumes:            []kapi.Volume{volume},
+				},
+			},
+		},
+	}
+}
+
+// service returns the service of component, forwarding port to targetPort of its pods
+func service(component string, port, targetPort int) *kapi.Service {
+	labels := componentLabels(component)
+	return &kapi.Service{
+		ObjectMeta: kapi.ObjectMeta{Name: component, Labels: labels},
+		Spec: kapi.ServiceSpec{
+			Selector: labels,
+			Ports: []kapi.ServicePort{
+				{Port: port, TargetPort: kutil.NewIntOrStringFromInt(targetPort)},
+			},
+		},
+	}
+}
+
+// memoryLimit returns the resources of a container that uses up to memory
+func memoryLimit(memory string) kapi.ResourceRequirements {
+	return kapi.ResourceRequirements{
+		Limits: kapi.ResourceList{kapi.ResourceMemory: resource.MustParse(memory)},
+	}
+}
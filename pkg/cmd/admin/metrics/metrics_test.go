@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
+	"github.com/openshift/origin/pkg/cmd/util/variable"
+	dapi "github.com/openshift/origin/pkg/deploy/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
+)
+
+func TestObjects(t *testing.T) {
+	ca, err := crypto.MakeCAConfig("test-signer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := &MetricsConfig{
+		ImageTemplate:    variable.ImageTemplate{Format: "openshift/origin-${component}:test"},
+		Hostname:         "metrics.example.com",
+		Size:             "large",
+		StorageClaim:     "metrics",
+		MetricResolution: "30s",
+		MasterURL:        defaultMasterURL,
+	}
+	objects, err := cfg.Objects("openshift-infra", ca)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var secret *kapi.Secret
+	var route *routeapi.Route
+	configs := map[string]*dapi.DeploymentConfig{}
+	for _, obj := range objects {
+		switch t := obj.(type) {
+		case *kapi.Secret:
+			secret = t
+		case *routeapi.Route:
+			route = t
+		case *dapi.DeploymentConfig:
+			configs[t.Name] = t
+		}
+	}
+
+	if secret == nil {
+		t.Fatalf("expected a secret with the serving certificate")
+	}
+	certs, err := crypto.CertsFromPEM(secret.Data["tls.crt"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := certs[0].VerifyHostname("metrics.example.com"); err == nil {
+		t.Errorf("expected the serving certificate not to be valid for the public host name")
+	}
+	if certs[0].Issuer.CommonName != "test-signer" {
+		t.Errorf("expected the serving certificate to be signed by the metrics CA, got %s", certs[0].Issuer.CommonName)
+	}
+	if err := certs[0].VerifyHostname("hawkular-metrics.openshift-infra.svc"); err != nil {
+		t.Errorf("expected the serving certificate to be valid for the service: %v", err)
+	}
+
+	if route == nil || route.Spec.Host != "metrics.example.com" || route.Spec.TLS == nil || route.Spec.TLS.Termination != routeapi.TLSTerminationReencrypt || route.Spec.TLS.DestinationCACertificate != string(secret.Data["ca.crt"]) {
+		t.Errorf("expected a reencrypt route for the host name, got %#v", route)
+	}
+
+	cassandra := configs[cassandraName]
+	if cassandra == nil {
+		t.Fatalf("expected a deployment config for cassandra")
+	}
+	if claim := cassandra.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim; claim == nil || claim.ClaimName != "metrics" {
+		t.Errorf("expected cassandra to store the metrics on the claim, got %#v", cassandra.Spec.Template.Spec.Volumes[0])
+	}
+	container := cassandra.Spec.Template.Spec.Containers[0]
+	if container.Image != "openshift/origin-metrics-cassandra:test" {
+		t.Errorf("unexpected image %s", container.Image)
+	}
+	if memory := container.Resources.Limits[kapi.ResourceMemory]; memory.String() != "4Gi" {
+		t.Errorf("expected the memory of the large preset, got %s", memory.String())
+	}
+	if heapster := configs[heapsterName]; heapster == nil || heapster.Spec.Template.Spec.ServiceAccountName != heapsterName {
+		t.Errorf("expected heapster to run as its service account, got %#v", heapster)
+	}
+	hawkular := configs[hawkularName]
+	if hawkular == nil {
+		t.Fatalf("expected a deployment config for hawkular metrics")
+	}
+	authenticated := false
+	for _, env := range hawkular.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "HAWKULAR_METRICS_AUTH_METHOD" && env.Value == "openshift-oauth" {
+			authenticated = true
+		}
+	}
+	if !authenticated {
+		t.Errorf("expected hawkular metrics to authenticate requests with the master")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := &MetricsConfig{Size: "small"}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected the host name to be required")
+	}
+	cfg = &MetricsConfig{Hostname: "metrics.example.com", Size: "huge"}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected an unknown size to be rejected")
+	}
+}
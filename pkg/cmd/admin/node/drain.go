@@ -0,0 +1,483 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kubelettypes "k8s.io/kubernetes/pkg/kubelet/types"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/types"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+const (
+	DrainCommandName = "drain"
+
+	drainLong = `
+Drain nodes in preparation for maintenance
+
+The nodes are marked unschedulable, then the pods running on them are deleted so that their
+replication controllers recreate them on other nodes. The node is left unschedulable; mark it
+schedulable again with 'manage-node --schedulable=true' once the maintenance is done.
+
+The drain refuses to delete any pod of a node unless every pod can be deleted safely:
+
+* Pods that are not managed by a replication controller, a deployment or a daemon set are
+  not recreated elsewhere. Use --force to delete them anyway.
+* Pods managed by a daemon set would be recreated on the same node. Use --ignore-daemonsets
+  to leave them running.
+* Pods that use emptyDir volumes lose their data. Use --delete-local-data to delete them anyway.
+
+Mirror pods, which the node runs from its manifests, are always left running. Before deleting the
+pods of a deployment config, the drain waits for any deployment of that config in progress to
+finish, so the deployment does not have to replace the pods again.
+
+The pods of deployment configs that use the rolling strategy are deleted only while enough of their
+other pods are ready: the drain keeps as many pods available as a deployment of the config would,
+as set by the maxUnavailable rolling parameter. If needed, the drain waits for the pods deleted before,
+or their replacements, to become ready again before deleting the next one. One pod of a config can
+always be deleted, even when maxUnavailable is 0, so its replacement can be created.`
+
+	drainExample = `	# Drain a node
+	$ %[1]s <mynode>
+
+	# Drain the nodes of a zone, leaving the pods of daemon sets running
+	$ %[1]s --selector="<zone=east>" --ignore-daemonsets
+
+	# Drain a node, deleting bare pods and waiting at most 5 minutes for the pods to be gone
+	$ %[1]s <mynode> --force --timeout=5m`
+)
+
+type DrainOptions struct {
+	Options *NodeOptions
+	Oclient osclient.Interface
+
+	// Optional params
+	Force            bool
+	IgnoreDaemonSets bool
+	DeleteLocalData  bool
+	GracePeriod      int64
+	Timeout          time.Duration
+}
+
+// NewDrainOptions creates a new DrainOptions with default values.
+func NewDrainOptions() *DrainOptions {
+	return &DrainOptions{
+		Options:     &NodeOptions{},
+		GracePeriod: 30,
+	}
+}
+
+// NewCommandDrain implements the OpenShift cli drain command
+func NewCommandDrain(f *clientcmd.Factory, commandName, fullName string, out io.Writer) *cobra.Command {
+	options := NewDrainOptions()
+
+	cmd := &cobra.Command{
+		Use:     commandName + " NODE [NODE ...]",
+		Short:   "Drain nodes in preparation for maintenance",
+		Long:    drainLong,
+		Example: fmt.Sprintf(drainExample, fullName),
+		Run: func(c *cobra.Command, args []string) {
+			if err := options.Complete(f, args, out); err != nil {
+				kcmdutil.CheckErr(err)
+			}
+			if err := options.Options.Validate(c.Flag("selector").Changed); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(c, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+	flags := cmd.Flags()
+
+	flags.StringVar(&options.Options.Selector, "selector", "", "Label selector to filter nodes. Either pass one/more nodes as arguments or use this node selector")
+	flags.BoolVar(&options.Force, flagForce, options.Force, "Delete pods that are not managed by a replication controller, a deployment or a daemon set.")
+	flags.BoolVar(&options.IgnoreDaemonSets, "ignore-daemonsets", options.IgnoreDaemonSets, "Leave the pods managed by daemon sets running on the node.")
+	flags.BoolVar(&options.DeleteLocalData, "delete-local-data", options.DeleteLocalData, "Delete pods that use emptyDir volumes, losing their data.")
+	flags.Int64Var(&options.GracePeriod, flagGracePeriod, options.GracePeriod, "Grace period (seconds) for pods being deleted.")
+	flags.DurationVar(&options.Timeout, "timeout", options.Timeout, "How long to wait for deployments in progress to finish and for the pods to be deleted. 0 waits forever.")
+
+	return cmd
+}
+
+func (d *DrainOptions) Complete(f *clientcmd.Factory, args []string, out io.Writer) error {
+	defaultNamespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	oc, kc, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	mapper, typer := f.Object()
+
+	d.Oclient = oc
+	d.Options.DefaultNamespace = defaultNamespace
+	d.Options.Kclient = kc
+	d.Options.Writer = out
+	d.Options.Mapper = mapper
+	d.Options.Typer = typer
+	d.Options.RESTClientFactory = f.Factory.RESTClient
+	d.Options.Printer = f.Printer
+	d.Options.NodeNames = args
+	return nil
+}
+
+func (d *DrainOptions) Run() error {
+	nodes, err := d.Options.GetNodes()
+	if err != nil {
+		return err
+	}
+
+	errList := []error{}
+	for _, node := range nodes {
+		if err := d.RunDrain(node); err != nil {
+			// Don't bail out if one node fails
+			errList = append(errList, err)
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+func (d *DrainOptions) RunDrain(node *kapi.Node) error {
+	// The node is marked unschedulable first so that no pod lands on it while it is drained.
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		if _, err := d.Options.Kclient.Nodes().Update(node); err != nil {
+			return err
+		}
+	}
+
+	fieldSelector := fields.Set{GetPodHostFieldLabel(node.TypeMeta.APIVersion): node.ObjectMeta.Name}.AsSelector()
+	pods, err := d.Options.Kclient.Pods(kapi.NamespaceAll).List(labels.Everything(), fieldSelector)
+	if err != nil {
+		return err
+	}
+	rcs, err := d.Options.Kclient.ReplicationControllers(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	// daemon sets are not served when the extensions API is disabled
+	daemonSets := []extensions.DaemonSet{}
+	if d.Options.Kclient.ExtensionsClient != nil {
+		list, err := d.Options.Kclient.Extensions().DaemonSets(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+		if err != nil && !kapierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			daemonSets = list.Items
+		}
+	}
+
+	deletable, ignored, err := d.podsToDelete(pods.Items, rcs.Items, daemonSets)
+	if err != nil {
+		return fmt.Errorf("Unable to drain node %q, it was left unschedulable:\n%v", node.ObjectMeta.Name, err)
+	}
+	for _, pod := range ignored {
+		fmt.Fprintf(d.Options.Writer, "Leaving pod %s/%s of a daemon set running on node %s\n", pod.Namespace, pod.Name, node.ObjectMeta.Name)
+	}
+	if len(deletable) == 0 {
+		return nil
+	}
+
+	if err := d.waitForDeployments(deletable); err != nil {
+		return err
+	}
+	budgets, err := d.disruptionBudgets(deletable)
+	if err != nil {
+		return err
+	}
+
+	printerWithHeaders, printerNoHeaders, err := d.Options.GetPrintersByResource("pod")
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(d.Options.Writer, "\nDeleting these pods on node: ", node.ObjectMeta.Name, "\n\n")
+
+	errList := []error{}
+	deleted := []kapi.Pod{}
+	deleteOptions := &kapi.DeleteOptions{GracePeriodSeconds: &d.GracePeriod}
+	for i, pod := range deletable {
+		if i == 0 {
+			printerWithHeaders.PrintObj(&pod, d.Options.Writer)
+		} else {
+			printerNoHeaders.PrintObj(&pod, d.Options.Writer)
+		}
+		if err := d.waitForAvailability(&pod, budgets); err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		if err := d.Options.Kclient.Pods(pod.Namespace).Delete(pod.Name, deleteOptions); err != nil && !kapierrors.IsNotFound(err) {
+			glog.Errorf("Unable to delete a pod: %+v, error: %v", pod, err)
+			errList = append(errList, err)
+			continue
+		}
+		deleted = append(deleted, pod)
+	}
+	if err := d.waitForDeletion(deleted); err != nil {
+		errList = append(errList, err)
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// podsToDelete returns the pods that a drain deletes and the pods of daemon sets that it leaves running, or an
+// error listing every pod that can not be deleted without one of the flags allowing it. Mirror pods are never
+// returned.
+func (d *DrainOptions) podsToDelete(pods []kapi.Pod, rcs []kapi.ReplicationController, daemonSets []extensions.DaemonSet) ([]kapi.Pod, []kapi.Pod, error) {
+	deletable, ignored := []kapi.Pod{}, []kapi.Pod{}
+	errList := []error{}
+	for _, pod := range pods {
+		if _, ok := pod.Annotations[kubelettypes.ConfigMirrorAnnotationKey]; ok {
+			continue
+		}
+
+		if isDaemonSetPod(&pod, daemonSets) {
+			if !d.IgnoreDaemonSets {
+				errList = append(errList, fmt.Errorf("pod %s/%s is managed by a daemon set and would be recreated on the node, use --ignore-daemonsets to leave it running", pod.Namespace, pod.Name))
+				continue
+			}
+			ignored = append(ignored, pod)
+			continue
+		}
+		if !d.Force && !isManagedPod(&pod, rcs) {
+			errList = append(errList, fmt.Errorf("pod %s/%s is not managed by a replication controller or a deployment and would not be recreated, use --force to delete it", pod.Namespace, pod.Name))
+			continue
+		}
+		if !d.DeleteLocalData && hasLocalStorage(&pod) {
+			errList = append(errList, fmt.Errorf("pod %s/%s uses emptyDir volumes whose data would be lost, use --delete-local-data to delete it", pod.Namespace, pod.Name))
+			continue
+		}
+		deletable = append(deletable, pod)
+	}
+	if len(errList) != 0 {
+		return nil, nil, kerrors.NewAggregate(errList)
+	}
+	return deletable, ignored, nil
+}
+
+// isManagedPod returns true if pod is recreated by a replication controller, or was created by a deployment,
+// like the deployer and hook pods of the deployment
+func isManagedPod(pod *kapi.Pod, rcs []kapi.ReplicationController) bool {
+	if _, ok := pod.Annotations[deployapi.DeploymentAnnotation]; ok {
+		return true
+	}
+	for _, rc := range rcs {
+		if rc.Namespace == pod.Namespace && len(rc.Spec.Selector) > 0 && labels.SelectorFromSet(rc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDaemonSetPod returns true if pod is managed by one of daemonSets
+func isDaemonSetPod(pod *kapi.Pod, daemonSets []extensions.DaemonSet) bool {
+	for _, ds := range daemonSets {
+		if ds.Namespace == pod.Namespace && len(ds.Spec.Selector) > 0 && labels.SelectorFromSet(ds.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLocalStorage returns true if pod stores data on the node that is lost when it is deleted
+func hasLocalStorage(pod *kapi.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForDeployments waits for the deployments in progress of the deployment configs of pods to finish. Deleting
+// pods while their config is deployed would have the deployment replace them with pods that are deleted in turn,
+// and would fail the deployment if its deployer pod was deleted.
+func (d *DrainOptions) waitForDeployments(pods []kapi.Pod) error {
+	configs := sets.NewString()
+	for _, pod := range pods {
+		if name := deployutil.DeploymentConfigNameFor(&pod); len(name) > 0 {
+			configs.Insert(pod.Namespace + "/" + name)
+			continue
+		}
+		// deployer and hook pods only refer to their deployment
+		name := deployutil.DeploymentNameFor(&pod)
+		if len(name) == 0 {
+			continue
+		}
+		deployment, err := d.Options.Kclient.ReplicationControllers(pod.Namespace).Get(name)
+		if kapierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if config := deployutil.DeploymentConfigNameFor(deployment); len(config) > 0 {
+			configs.Insert(pod.Namespace + "/" + config)
+		}
+	}
+
+	for _, key := range configs.List() {
+		parts := strings.SplitN(key, "/", 2)
+		namespace, name := parts[0], parts[1]
+		waiting := false
+		err := d.poll(func() (bool, error) {
+			deployments, err := d.Options.Kclient.ReplicationControllers(namespace).List(deployutil.ConfigSelector(name), fields.Everything())
+			if err != nil {
+				return false, err
+			}
+			if !deploymentInProgress(deployments.Items) {
+				return true, nil
+			}
+			if !waiting {
+				fmt.Fprintf(d.Options.Writer, "Waiting for the deployment of deployment config %s to finish\n", key)
+				waiting = true
+			}
+			return false, nil
+		})
+		if err == wait.ErrWaitTimeout {
+			return fmt.Errorf("timed out waiting for the deployment of deployment config %s to finish", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deploymentInProgress returns true if any of deployments is neither complete nor failed
+func deploymentInProgress(deployments []kapi.ReplicationController) bool {
+	for i := range deployments {
+		if !deployutil.IsTerminatedDeployment(&deployments[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// disruptionBudgets returns how many pods of the rolling deployment configs of pods must stay available, keyed by
+// the namespace and name of the configs
+func (d *DrainOptions) disruptionBudgets(pods []kapi.Pod) (map[string]int, error) {
+	budgets := map[string]int{}
+	for i := range pods {
+		pod := &pods[i]
+		key, name := budgetKey(pod)
+		if len(name) == 0 {
+			continue
+		}
+		if _, ok := budgets[key]; ok {
+			continue
+		}
+		config, err := d.Oclient.DeploymentConfigs(pod.Namespace).Get(name)
+		if kapierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		min, ok, err := deployutil.MinAvailableReplicas(config)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			budgets[key] = min
+		}
+	}
+	return budgets, nil
+}
+
+// waitForAvailability waits until enough pods of the deployment config of pod are available for pod to be
+// deleted without going below the budget of the config. Deployer and hook pods are deleted right away.
+func (d *DrainOptions) waitForAvailability(pod *kapi.Pod, budgets map[string]int) error {
+	key, name := budgetKey(pod)
+	min, ok := budgets[key]
+	if len(name) == 0 || !ok {
+		return nil
+	}
+
+	selector := labels.Set{deployapi.DeploymentConfigLabel: name}.AsSelector()
+	waiting := false
+	err := d.poll(func() (bool, error) {
+		pods, err := d.Options.Kclient.Pods(pod.Namespace).List(selector, fields.Everything())
+		if err != nil {
+			return false, err
+		}
+		if availablePods(pods.Items, pod.UID) >= min {
+			return true, nil
+		}
+		if !waiting {
+			fmt.Fprintf(d.Options.Writer, "Waiting for %d other pods of deployment config %s/%s to be ready before deleting pod %s\n", min, pod.Namespace, name, pod.Name)
+			waiting = true
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out waiting for %d other pods of deployment config %s/%s to be ready, pod %s was not deleted", min, pod.Namespace, name, pod.Name)
+	}
+	return err
+}
+
+// budgetKey returns the key of the budget of the deployment config of pod and the name of the config. The config is
+// found through the same label that selects the pods counted against its budget.
+func budgetKey(pod *kapi.Pod) (string, string) {
+	name := pod.Labels[deployapi.DeploymentConfigLabel]
+	return pod.Namespace + "/" + name, name
+}
+
+// availablePods returns the number of pods that are ready and not being deleted, other than the pod with the
+// except UID
+func availablePods(pods []kapi.Pod, except types.UID) int {
+	available := 0
+	for i := range pods {
+		pod := &pods[i]
+		if pod.UID == except || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if kapi.IsPodReady(pod) {
+			available++
+		}
+	}
+	return available
+}
+
+// waitForDeletion waits for pods to be gone from the server
+func (d *DrainOptions) waitForDeletion(pods []kapi.Pod) error {
+	err := d.poll(func() (bool, error) {
+		for _, pod := range pods {
+			current, err := d.Options.Kclient.Pods(pod.Namespace).Get(pod.Name)
+			if kapierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+				continue
+			}
+			return false, err
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return errors.New("timed out waiting for the pods to be deleted")
+	}
+	return err
+}
+
+// poll runs condition every second until it is done, for at most the timeout of the drain
+func (d *DrainOptions) poll(condition wait.ConditionFunc) error {
+	if d.Timeout == 0 {
+		return wait.PollInfinite(time.Second, condition)
+	}
+	return wait.PollImmediate(time.Second, d.Timeout, condition)
+}
@@ -0,0 +1,172 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	kubelettypes "k8s.io/kubernetes/pkg/kubelet/types"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+func TestPodsToDelete(t *testing.T) {
+	rcs := []kapi.ReplicationController{
+		{ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: "test"}, Spec: kapi.ReplicationControllerSpec{Selector: map[string]string{"app": "frontend"}}},
+	}
+	daemonSets := []extensions.DaemonSet{
+		{ObjectMeta: kapi.ObjectMeta{Name: "logging", Namespace: "test"}, Spec: extensions.DaemonSetSpec{Selector: map[string]string{"app": "logging"}}},
+	}
+	managed := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1", Namespace: "test", Labels: map[string]string{"app": "frontend"}}}
+	otherNamespace := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1", Namespace: "other", Labels: map[string]string{"app": "frontend"}}}
+	deployer := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "database-1-deploy", Namespace: "test", Annotations: map[string]string{deployapi.DeploymentAnnotation: "database-1"}}}
+	daemon := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "logging-abcde", Namespace: "test", Labels: map[string]string{"app": "logging"}}}
+	mirror := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "static", Namespace: "test", Annotations: map[string]string{kubelettypes.ConfigMirrorAnnotationKey: "hash"}}}
+	local := managed
+	local.Name = "frontend-2"
+	local.Spec.Volumes = []kapi.Volume{{Name: "scratch", VolumeSource: kapi.VolumeSource{EmptyDir: &kapi.EmptyDirVolumeSource{}}}}
+
+	tests := map[string]struct {
+		options   DrainOptions
+		pods      []kapi.Pod
+		deletable []kapi.Pod
+		ignored   []kapi.Pod
+		err       bool
+	}{
+		"managed pods": {
+			pods:      []kapi.Pod{managed, deployer, mirror},
+			deletable: []kapi.Pod{managed, deployer},
+			ignored:   []kapi.Pod{},
+		},
+		"bare pod": {
+			pods: []kapi.Pod{managed, otherNamespace},
+			err:  true,
+		},
+		"bare pod with force": {
+			options:   DrainOptions{Force: true},
+			pods:      []kapi.Pod{managed, otherNamespace},
+			deletable: []kapi.Pod{managed, otherNamespace},
+			ignored:   []kapi.Pod{},
+		},
+		"daemon set pod": {
+			pods: []kapi.Pod{managed, daemon},
+			err:  true,
+		},
+		"daemon set pod ignored": {
+			options:   DrainOptions{IgnoreDaemonSets: true},
+			pods:      []kapi.Pod{managed, daemon},
+			deletable: []kapi.Pod{managed},
+			ignored:   []kapi.Pod{daemon},
+		},
+		"local storage": {
+			pods: []kapi.Pod{local},
+			err:  true,
+		},
+		"local storage deleted": {
+			options:   DrainOptions{DeleteLocalData: true},
+			pods:      []kapi.Pod{local},
+			deletable: []kapi.Pod{local},
+			ignored:   []kapi.Pod{},
+		},
+	}
+	for name, test := range tests {
+		deletable, ignored, err := test.options.podsToDelete(test.pods, rcs, daemonSets)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.deletable, deletable) {
+			t.Errorf("%s: expected %v to be deleted, got %v", name, test.deletable, deletable)
+		}
+		if !reflect.DeepEqual(test.ignored, ignored) {
+			t.Errorf("%s: expected %v to be ignored, got %v", name, test.ignored, ignored)
+		}
+	}
+}
+
+func TestDeploymentInProgress(t *testing.T) {
+	deployment := func(status deployapi.DeploymentStatus) kapi.ReplicationController {
+		return kapi.ReplicationController{ObjectMeta: kapi.ObjectMeta{Annotations: map[string]string{deployapi.DeploymentStatusAnnotation: string(status)}}}
+	}
+	if deploymentInProgress([]kapi.ReplicationController{deployment(deployapi.DeploymentStatusComplete), deployment(deployapi.DeploymentStatusFailed)}) {
+		t.Errorf("expected complete and failed deployments not to be in progress")
+	}
+	for _, status := range []deployapi.DeploymentStatus{deployapi.DeploymentStatusNew, deployapi.DeploymentStatusPending, deployapi.DeploymentStatusRunning} {
+		if !deploymentInProgress([]kapi.ReplicationController{deployment(deployapi.DeploymentStatusComplete), deployment(status)}) {
+			t.Errorf("expected a %s deployment to be in progress", status)
+		}
+	}
+}
+
+func TestAvailablePods(t *testing.T) {
+	ready := kapi.PodStatus{Conditions: []kapi.PodCondition{{Type: kapi.PodReady, Status: kapi.ConditionTrue}}}
+	now := unversioned.Now()
+	pods := []kapi.Pod{
+		{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1-a", UID: "a"}, Status: ready},
+		{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1-b", UID: "b"}, Status: ready},
+		{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1-c", UID: "c"}},
+		{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1-d", UID: "d", DeletionTimestamp: &now}, Status: ready},
+	}
+	if e, a := 1, availablePods(pods, "a"); e != a {
+		t.Errorf("expected %d available pods other than the drained one, got %d", e, a)
+	}
+	if e, a := 2, availablePods(pods, "c"); e != a {
+		t.Errorf("expected %d available pods, got %d", e, a)
+	}
+}
+
+func TestDisruptionBudgets(t *testing.T) {
+	rolling := &deployapi.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: "test"},
+		Spec: deployapi.DeploymentConfigSpec{
+			Replicas: 4,
+			Strategy: deployapi.DeploymentStrategy{
+				Type:          deployapi.DeploymentStrategyTypeRolling,
+				RollingParams: &deployapi.RollingDeploymentStrategyParams{MaxUnavailable: kutil.NewIntOrStringFromInt(1)},
+			},
+		},
+	}
+	d := &DrainOptions{Oclient: testclient.NewSimpleFake(rolling)}
+
+	labeled := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1-abcde", Namespace: "test", Labels: map[string]string{deployapi.DeploymentConfigLabel: "frontend"}}}
+	annotated := kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "frontend-1-deploy", Namespace: "test", Annotations: map[string]string{deployapi.DeploymentConfigAnnotation: "frontend"}}}
+
+	budgets, err := d.disruptionBudgets([]kapi.Pod{labeled})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := map[string]int{"test/frontend": 3}, budgets; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected budgets %v, got %v", e, a)
+	}
+	if key, _ := budgetKey(&labeled); budgets[key] != 3 {
+		t.Errorf("expected the pod to be counted against the budget of its config")
+	}
+
+	rolling.Spec.Strategy.RollingParams = &deployapi.RollingDeploymentStrategyParams{MaxUnavailable: kutil.NewIntOrStringFromInt(0), MaxSurge: kutil.NewIntOrStringFromInt(1)}
+	d = &DrainOptions{Oclient: testclient.NewSimpleFake(rolling)}
+	budgets, err = d.disruptionBudgets([]kapi.Pod{labeled})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := map[string]int{"test/frontend": 3}, budgets; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected a zero max unavailable to leave one pod to delete, got budgets %v", a)
+	}
+
+	budgets, err = d.disruptionBudgets([]kapi.Pod{annotated})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(budgets) != 0 {
+		t.Errorf("expected no budget for a pod without the deployment config label, got %v", budgets)
+	}
+}
@@ -0,0 +1,109 @@
+package podnetwork
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+const (
+	ListRecommendedName = "list"
+	listLong            = `
+List the network ids of projects
+
+When the multitenant SDN plugin is in use, each project is given a network id
+(VNID) and its pods can only reach the pods of projects sharing the same id.
+Projects with the id 0 are global: their pods reach and are reachable from
+every project. For each project, shows its id and the projects sharing it.`
+
+	listExample = `  # List the network ids of all projects
+  $ %[1]s`
+)
+
+type ListNetworksOptions struct {
+	Client client.Interface
+	Out    io.Writer
+}
+
+func NewCmdListNetworks(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &ListNetworksOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "List the network ids of projects",
+		Long:    listLong,
+		Example: fmt.Sprintf(listExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	return cmd
+}
+
+func (o *ListNetworksOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed")
+	}
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	return nil
+}
+
+func (o *ListNetworksOptions) Run() error {
+	netNamespaces, err := o.Client.NetNamespaces().List()
+	if err != nil {
+		return err
+	}
+	items := netNamespaces.Items
+	sort.Sort(byNetName(items))
+
+	w := tabwriter.NewWriter(o.Out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "NAME\tNETID\tNETWORK")
+	for _, netns := range items {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", netns.NetName, netns.NetID, describeNetwork(&netns, items))
+	}
+	return nil
+}
+
+// describeNetwork returns whether netns is global, isolated or shares its network id with other projects
+func describeNetwork(netns *sdnapi.NetNamespace, all []sdnapi.NetNamespace) string {
+	if netns.NetID == globalNetID {
+		return "global"
+	}
+	shared := []string{}
+	for _, other := range all {
+		if other.NetID == netns.NetID && other.NetName != netns.NetName {
+			shared = append(shared, other.NetName)
+		}
+	}
+	if len(shared) == 0 {
+		return "isolated"
+	}
+	return "shared with " + strings.Join(shared, ", ")
+}
+
+type byNetName []sdnapi.NetNamespace
+
+func (s byNetName) Len() int           { return len(s) }
+func (s byNetName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byNetName) Less(i, j int) bool { return s[i].NetName < s[j].NetName }
@@ -0,0 +1,24 @@
+package podnetwork
+
+import (
+	"io"
+
+	"github.com/openshift/openshift-sdn/pkg/cmd/admin/network"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+// globalNetID is the network id of the projects whose pods can reach and be reached by the pods of every project
+const globalNetID = uint(0)
+
+// NewCmdPodNetwork returns the pod network commands of the SDN, completed with the commands inspecting the
+// network ids of projects
+func NewCmdPodNetwork(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	cmds := network.NewCmdPodNetwork(name, fullName, f, out)
+
+	cmds.AddCommand(NewCmdListNetworks(ListRecommendedName, fullName+" "+ListRecommendedName, f, out))
+	cmds.AddCommand(NewCmdVerifyIsolation(VerifyIsolationRecommendedName, fullName+" "+VerifyIsolationRecommendedName, f, out))
+
+	return cmds
+}
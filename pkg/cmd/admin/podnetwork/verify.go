@@ -0,0 +1,258 @@
+package podnetwork
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	VerifyIsolationRecommendedName = "verify-isolation"
+	verifyIsolationLong            = `
+Check that the pod network isolates projects as configured
+
+Starts a probe pod listening on port 8080 in each of the given projects, then
+from each project tries to connect to the probes of the others. Prints a matrix
+of the connections, marking with FAIL the ones which succeeded although the
+network ids of the projects should isolate them, or failed although the
+projects share a network. The probe pods are deleted when the check ends.
+
+The probe image must provide sh, httpd and wget, like busybox does.`
+
+	verifyIsolationExample = `  # Check the isolation between three projects
+  $ %[1]s project1 project2 project3
+
+  # Check the isolation with probe pods from an internal mirror
+  $ %[1]s project1 project2 --image=mirror.example.com:5000/busybox`
+)
+
+// probePort is the port the server probes listen on
+const probePort = 8080
+
+type VerifyIsolationOptions struct {
+	Projects []string
+	Image    string
+	Timeout  time.Duration
+
+	Client     client.Interface
+	KubeClient kclient.Interface
+	Out        io.Writer
+}
+
+func NewCmdVerifyIsolation(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &VerifyIsolationOptions{Image: "busybox", Timeout: 2 * time.Minute, Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " PROJECT PROJECT [PROJECT...]",
+		Short:   "Check the network isolation between projects",
+		Long:    verifyIsolationLong,
+		Example: fmt.Sprintf(verifyIsolationExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Image, "image", options.Image, "The image of the probe pods, which must provide sh, httpd and wget.")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "How long to wait for each probe pod to start or complete.")
+
+	return cmd
+}
+
+func (o *VerifyIsolationOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) < 2 {
+		return errors.New("you must specify at least two projects")
+	}
+	if len(o.Image) == 0 {
+		return errors.New("you must specify the image of the probe pods with --image")
+	}
+	o.Projects = args
+
+	osClient, kubeClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	o.KubeClient = kubeClient
+	return nil
+}
+
+// connection is a probe from the pods of a project to the pods of another one
+type connection struct {
+	from, to string
+}
+
+func (o *VerifyIsolationOptions) Run() error {
+	netIDs := map[string]uint{}
+	for _, project := range o.Projects {
+		netns, err := o.Client.NetNamespaces().Get(project)
+		if err != nil {
+			return fmt.Errorf("unable to read the network id of project %s: %v", project, err)
+		}
+		netIDs[project] = netns.NetID
+	}
+
+	created := []*kapi.Pod{}
+	defer func() {
+		zero := int64(0)
+		for _, pod := range created {
+			if err := o.KubeClient.Pods(pod.Namespace).Delete(pod.Name, &kapi.DeleteOptions{GracePeriodSeconds: &zero}); err != nil {
+				fmt.Fprintf(o.Out, "error: unable to delete the probe pod %s/%s: %v\n", pod.Namespace, pod.Name, err)
+			}
+		}
+	}()
+	create := func(project string, pod *kapi.Pod) (*kapi.Pod, error) {
+		pod, err := o.KubeClient.Pods(project).Create(pod)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create a probe pod in project %s: %v", project, err)
+		}
+		created = append(created, pod)
+		return pod, nil
+	}
+
+	servers := map[string]*kapi.Pod{}
+	for _, project := range o.Projects {
+		pod, err := create(project, o.serverPod())
+		if err != nil {
+			return err
+		}
+		servers[project] = pod
+	}
+	for _, project := range o.Projects {
+		pod, err := o.waitForPod(servers[project], func(pod *kapi.Pod) bool {
+			return pod.Status.Phase == kapi.PodRunning && len(pod.Status.PodIP) > 0
+		})
+		if err != nil {
+			return fmt.Errorf("the probe pod of project %s did not start: %v", project, err)
+		}
+		servers[project] = pod
+	}
+
+	clients := map[connection]*kapi.Pod{}
+	for _, from := range o.Projects {
+		for _, to := range o.Projects {
+			if from == to {
+				continue
+			}
+			pod, err := create(from, o.clientPod(servers[to].Status.PodIP))
+			if err != nil {
+				return err
+			}
+			clients[connection{from, to}] = pod
+		}
+	}
+	reachable := map[connection]bool{}
+	for c, pod := range clients {
+		pod, err := o.waitForPod(pod, func(pod *kapi.Pod) bool {
+			return pod.Status.Phase == kapi.PodSucceeded || pod.Status.Phase == kapi.PodFailed
+		})
+		if err != nil {
+			return fmt.Errorf("the probe pod connecting project %s to project %s did not complete: %v", c.from, c.to, err)
+		}
+		reachable[c] = pod.Status.Phase == kapi.PodSucceeded
+	}
+
+	if failures := printMatrix(o.Out, o.Projects, netIDs, reachable); failures > 0 {
+		return fmt.Errorf("%d connection(s) do not match the network ids of the projects", failures)
+	}
+	fmt.Fprintln(o.Out, "All connections match the network ids of the projects")
+	return nil
+}
+
+// serverPod returns a probe pod answering the connections to probePort. httpd serves the connections of the
+// client probes concurrently, so that none is refused while another is answered.
+func (o *VerifyIsolationOptions) serverPod() *kapi.Pod {
+	return o.probePod("pod-network-probe-", fmt.Sprintf("mkdir -p /tmp/probe && echo ok > /tmp/probe/index.html && exec httpd -f -p %d -h /tmp/probe", probePort))
+}
+
+// clientPod returns a probe pod completing successfully if it can connect to the probe with the given IP. It tries
+// a few times so that a connection which is dropped once does not fail the check.
+func (o *VerifyIsolationOptions) clientPod(ip string) *kapi.Pod {
+	return o.probePod("pod-network-client-", fmt.Sprintf("for i in 1 2 3; do wget -q -T 5 -O - http://%s:%d/ | grep -q ok && exit 0; sleep 1; done; exit 1", ip, probePort))
+}
+
+func (o *VerifyIsolationOptions) probePod(generateName, script string) *kapi.Pod {
+	return &kapi.Pod{
+		ObjectMeta: kapi.ObjectMeta{GenerateName: generateName},
+		Spec: kapi.PodSpec{
+			RestartPolicy: kapi.RestartPolicyNever,
+			Containers: []kapi.Container{
+				{
+					Name:    "probe",
+					Image:   o.Image,
+					Command: []string{"/bin/sh", "-c", script},
+				},
+			},
+		},
+	}
+}
+
+// waitForPod returns the pod once condition is true, or an error if it is not before the timeout
+func (o *VerifyIsolationOptions) waitForPod(pod *kapi.Pod, condition func(*kapi.Pod) bool) (*kapi.Pod, error) {
+	var current *kapi.Pod
+	err := wait.PollImmediate(time.Second, o.Timeout, func() (bool, error) {
+		var err error
+		current, err = o.KubeClient.Pods(pod.Namespace).Get(pod.Name)
+		if err != nil {
+			return false, err
+		}
+		return condition(current), nil
+	})
+	return current, err
+}
+
+// expectReachable returns true if the pods of project from should reach the pods of project to: when the projects
+// share their network id, or when either is global
+func expectReachable(netIDs map[string]uint, from, to string) bool {
+	return netIDs[from] == netIDs[to] || netIDs[from] == globalNetID || netIDs[to] == globalNetID
+}
+
+// printMatrix prints whether the pods of each project reached the pods of the others, and returns the number of
+// connections which do not match the network ids of the projects
+func printMatrix(out io.Writer, projects []string, netIDs map[string]uint, reachable map[connection]bool) int {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprint(w, "FROM \\ TO")
+	for _, to := range projects {
+		fmt.Fprintf(w, "\t%s (%d)", to, netIDs[to])
+	}
+	fmt.Fprintln(w)
+
+	failures := 0
+	for _, from := range projects {
+		fmt.Fprintf(w, "%s (%d)", from, netIDs[from])
+		for _, to := range projects {
+			if from == to {
+				fmt.Fprint(w, "\t-")
+				continue
+			}
+			result := "no"
+			if reachable[connection{from, to}] {
+				result = "yes"
+			}
+			if reachable[connection{from, to}] != expectReachable(netIDs, from, to) {
+				result += " FAIL"
+				failures++
+			}
+			fmt.Fprintf(w, "\t%s", result)
+		}
+		fmt.Fprintln(w)
+	}
+	return failures
+}
@@ -0,0 +1,56 @@
+package podnetwork
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+func TestPrintMatrix(t *testing.T) {
+	projects := []string{"default", "a", "b", "c"}
+	netIDs := map[string]uint{"default": 0, "a": 5, "b": 5, "c": 7}
+	reachable := map[connection]bool{
+		{"default", "a"}: true, {"default", "b"}: true, {"default", "c"}: true,
+		{"a", "default"}: true, {"a", "b"}: true,
+		{"b", "default"}: true, {"b", "a"}: true,
+		// c is isolated from a, but its pods reached them anyway
+		{"c", "default"}: true, {"c", "a"}: true,
+	}
+
+	out := &bytes.Buffer{}
+	if failures := printMatrix(out, projects, netIDs, reachable); failures != 1 {
+		t.Errorf("expected 1 failure, got %d:\n%s", failures, out.String())
+	}
+	lines := strings.Split(out.String(), "\n")
+	if fields := strings.Fields(lines[4]); strings.Join(fields, " ") != "c (7) yes yes FAIL no -" {
+		t.Errorf("unexpected connections from c: %q", lines[4])
+	}
+
+	reachable[connection{"c", "a"}] = false
+	if failures := printMatrix(&bytes.Buffer{}, projects, netIDs, reachable); failures != 0 {
+		t.Errorf("expected no failures, got %d", failures)
+	}
+}
+
+func TestDescribeNetwork(t *testing.T) {
+	netns := func(name string, id uint) sdnapi.NetNamespace {
+		return sdnapi.NetNamespace{ObjectMeta: kapi.ObjectMeta{Name: name}, NetName: name, NetID: id}
+	}
+	all := []sdnapi.NetNamespace{netns("default", 0), netns("a", 5), netns("b", 5), netns("c", 7)}
+
+	expected := map[string]string{
+		"default": "global",
+		"a":       "shared with b",
+		"b":       "shared with a",
+		"c":       "isolated",
+	}
+	for i := range all {
+		if description := describeNetwork(&all[i], all); description != expected[all[i].NetName] {
+			t.Errorf("%s: expected %q, got %q", all[i].NetName, expected[all[i].NetName], description)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	AllowBuildStrategyRecommendedName    = "allow-build-strategy"
+	RestrictBuildStrategyRecommendedName = "restrict-build-strategy"
+)
+
+// buildStrategyRoles are the roles that allow the use of each build strategy
+var buildStrategyRoles = map[string]string{
+	"docker": bootstrappolicy.BuildStrategyDockerRoleName,
+	"source": bootstrappolicy.BuildStrategySourceRoleName,
+	"custom": bootstrappolicy.BuildStrategyCustomRoleName,
+}
+
+// buildStrategyResources are the resources users must be allowed to create to use each build strategy
+var buildStrategyResources = map[string]string{
+	"docker": authorizationapi.DockerBuildResource,
+	"source": authorizationapi.SourceBuildResource,
+	"custom": authorizationapi.CustomBuildResource,
+}
+
+const (
+	allowBuildStrategyLong = `
+Allow users or groups to create builds with a build strategy
+
+Without users or groups, every authenticated user may use the strategy in every project, which is
+the default for all strategies. With users or groups, they may use the strategy in the current
+project only. The strategy is one of docker, source or custom.`
+
+	allowBuildStrategyExample = `  # Allow the group builders to create custom builds in the project ci
+  $ %[1]s custom --groups=builders -n ci
+
+  # Allow every user to create custom builds in every project again
+  $ %[1]s custom`
+
+	restrictBuildStrategyLong = `
+Stop users or groups from creating builds with a build strategy
+
+Without users or groups, the strategy is no longer allowed for every authenticated user. Only
+the users and groups that were allowed to use it in a project, and cluster administrators, may
+still use it. With users or groups, their access to the strategy in the current project is removed.
+The strategy is one of docker, source or custom.
+
+Other roles, like cluster-admin, may still allow the users and groups to use the strategy, which is
+reported as a warning. While every authenticated user may use the strategy in every project, removing
+the access of users or groups in a project has no effect.
+
+Reconciling the cluster role bindings adds the default access back unless the system:authenticated
+group is excluded.`
+
+	restrictBuildStrategyExample = `  # Only allow custom builds where they are explicitly allowed
+  $ %[1]s custom
+
+  # Remove the access of the group builders to custom builds in the project ci
+  $ %[1]s custom --groups=builders -n ci`
+)
+
+// BuildStrategyOptions adds or removes the users and groups bound to the role of a build strategy
+type BuildStrategyOptions struct {
+	RoleModificationOptions
+
+	Strategy string
+	// Namespace is the project the role is bound in, or empty for the whole cluster
+	Namespace string
+	// ReviewAccess returns the users and groups allowed to perform action in Namespace, or in every
+	// project if it is empty
+	ReviewAccess func(action authorizationapi.AuthorizationAttributes) (*authorizationapi.ResourceAccessReviewResponse, error)
+
+	Out io.Writer
+}
+
+// NewCmdAllowBuildStrategy implements the OpenShift cli allow-build-strategy command
+func NewCmdAllowBuildStrategy(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &BuildStrategyOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " STRATEGY",
+		Short:   "Allow users or groups to create builds with a build strategy",
+		Long:    allowBuildStrategyLong,
+		Example: fmt.Sprintf(allowBuildStrategyExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Allow())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.Users, "users", options.Users, "users allowed to use the strategy in the current project")
+	cmd.Flags().StringSliceVar(&options.Groups, "groups", options.Groups, "groups allowed to use the strategy in the current project")
+
+	return cmd
+}
+
+// NewCmdRestrictBuildStrategy implements the OpenShift cli restrict-build-strategy command
+func NewCmdRestrictBuildStrategy(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &BuildStrategyOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " STRATEGY",
+		Short:   "Stop users or groups from creating builds with a build strategy",
+		Long:    restrictBuildStrategyLong,
+		Example: fmt.Sprintf(restrictBuildStrategyExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Restrict())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.Users, "users", options.Users, "users no longer allowed to use the strategy in the current project")
+	cmd.Flags().StringSliceVar(&options.Groups, "groups", options.Groups, "groups no longer allowed to use the strategy in the current project")
+
+	return cmd
+}
+
+func (o *BuildStrategyOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 1 {
+		return errors.New("exactly one build strategy is required: docker, source or custom")
+	}
+	o.Strategy = strings.ToLower(args[0])
+	role, ok := buildStrategyRoles[o.Strategy]
+	if !ok {
+		return fmt.Errorf("unknown build strategy %q: must be docker, source or custom", args[0])
+	}
+	o.RoleName = role
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+
+	if len(o.Users) == 0 && len(o.Groups) == 0 {
+		o.Groups = []string{bootstrappolicy.AuthenticatedGroup}
+		o.RoleBindingAccessor = NewClusterRoleBindingAccessor(osClient)
+		o.ReviewAccess = func(action authorizationapi.AuthorizationAttributes) (*authorizationapi.ResourceAccessReviewResponse, error) {
+			return osClient.ResourceAccessReviews().Create(&authorizationapi.ResourceAccessReview{Action: action})
+		}
+		return nil
+	}
+
+	o.Namespace, _, err = f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	o.RoleBindingAccessor = NewLocalRoleBindingAccessor(o.Namespace, osClient)
+	o.ReviewAccess = func(action authorizationapi.AuthorizationAttributes) (*authorizationapi.ResourceAccessReviewResponse, error) {
+		return osClient.LocalResourceAccessReviews(o.Namespace).Create(&authorizationapi.LocalResourceAccessReview{Action: action})
+	}
+	return nil
+}
+
+// Allow binds the users and groups to the role of the strategy
+func (o *BuildStrategyOptions) Allow() error {
+	if err := o.AddRole(); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "%s builds allowed for %s\n", o.Strategy, o.describeSubjects())
+	if len(o.Namespace) == 0 {
+		return nil
+	}
+	review, err := o.reviewAccess()
+	if err != nil {
+		return err
+	}
+	if review.Groups.Has(bootstrappolicy.AuthenticatedGroup) {
+		fmt.Fprintf(o.Out, "warning: every authenticated user may already use %s builds in all projects\n", o.Strategy)
+	}
+	return nil
+}
+
+// Restrict removes the users and groups from the bindings of the role of the strategy. The users and groups
+// that other roles or bindings still allow to use the strategy are reported as a warning.
+func (o *BuildStrategyOptions) Restrict() error {
+	if err := o.RemoveRole(); err != nil {
+		return err
+	}
+	review, err := o.reviewAccess()
+	if err != nil {
+		return err
+	}
+
+	// every user belongs to the authenticated group, so a binding of that group allows them all
+	everyone := review.Groups.Has(bootstrappolicy.AuthenticatedGroup)
+	allowed := []string{}
+	for _, user := range o.Users {
+		if everyone || review.Users.Has(user) {
+			allowed = append(allowed, "user "+user)
+		}
+	}
+	for _, group := range o.Groups {
+		if everyone || review.Groups.Has(group) {
+			allowed = append(allowed, "group "+group)
+		}
+	}
+	if len(allowed) == 0 {
+		fmt.Fprintf(o.Out, "%s builds no longer allowed for %s\n", o.Strategy, o.describeSubjects())
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "%s removed from role %s\n", o.describeSubjects(), o.RoleName)
+	if everyone && len(o.Namespace) > 0 {
+		fmt.Fprintf(o.Out, "warning: every authenticated user may still use %s builds in all projects, restrict the strategy for all projects first\n", o.Strategy)
+		return nil
+	}
+	fmt.Fprintf(o.Out, "warning: other roles still allow %s to use %s builds\n", strings.Join(allowed, ", "), o.Strategy)
+	return nil
+}
+
+// reviewAccess returns the users and groups allowed to use the strategy
+func (o *BuildStrategyOptions) reviewAccess() (*authorizationapi.ResourceAccessReviewResponse, error) {
+	return o.ReviewAccess(authorizationapi.AuthorizationAttributes{Verb: "create", Resource: buildStrategyResources[o.Strategy]})
+}
+
+func (o *BuildStrategyOptions) describeSubjects() string {
+	if len(o.Namespace) == 0 {
+		return "all authenticated users in all projects"
+	}
+	subjects := []string{}
+	for _, user := range o.Users {
+		subjects = append(subjects, "user "+user)
+	}
+	for _, group := range o.Groups {
+		subjects = append(subjects, "group "+group)
+	}
+	return fmt.Sprintf("%s in project %s", strings.Join(subjects, ", "), o.Namespace)
+}
@@ -0,0 +1,113 @@
+package policy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+// fakeRoleBindingAccessor holds the role bindings of a single role
+type fakeRoleBindingAccessor struct {
+	bindings []*authorizationapi.RoleBinding
+}
+
+func (a *fakeRoleBindingAccessor) GetExistingRoleBindingsForRole(roleNamespace, role string) ([]*authorizationapi.RoleBinding, error) {
+	return a.bindings, nil
+}
+
+func (a *fakeRoleBindingAccessor) GetExistingRoleBindingNames() (*sets.String, error) {
+	names := sets.NewString()
+	for _, binding := range a.bindings {
+		names.Insert(binding.Name)
+	}
+	return &names, nil
+}
+
+func (a *fakeRoleBindingAccessor) UpdateRoleBinding(binding *authorizationapi.RoleBinding) error {
+	return nil
+}
+
+func (a *fakeRoleBindingAccessor) CreateRoleBinding(binding *authorizationapi.RoleBinding) error {
+	a.bindings = append(a.bindings, binding)
+	return nil
+}
+
+func TestRestrictBuildStrategy(t *testing.T) {
+	testCases := map[string]struct {
+		Namespace     string
+		Users         []string
+		Groups        []string
+		AllowedUsers  []string
+		AllowedGroups []string
+		Expected      string
+	}{
+		"all projects": {
+			Groups:        []string{bootstrappolicy.AuthenticatedGroup},
+			AllowedGroups: []string{bootstrappolicy.ClusterAdminGroup},
+			Expected:      "custom builds no longer allowed for all authenticated users in all projects\n",
+		},
+		"all projects with another role": {
+			Groups:        []string{bootstrappolicy.AuthenticatedGroup},
+			AllowedGroups: []string{bootstrappolicy.AuthenticatedGroup},
+			Expected:      "warning: other roles still allow group system:authenticated to use custom builds",
+		},
+		"project": {
+			Namespace: "ci",
+			Groups:    []string{"builders"},
+			Expected:  "custom builds no longer allowed for group builders in project ci\n",
+		},
+		"project with another role": {
+			Namespace:    "ci",
+			Users:        []string{"alice", "bob"},
+			AllowedUsers: []string{"bob"},
+			Expected:     "warning: other roles still allow user bob to use custom builds",
+		},
+		"project while allowed in all projects": {
+			Namespace:     "ci",
+			Groups:        []string{"builders"},
+			AllowedGroups: []string{bootstrappolicy.AuthenticatedGroup},
+			Expected:      "warning: every authenticated user may still use custom builds in all projects",
+		},
+	}
+
+	for k, tc := range testCases {
+		out := &bytes.Buffer{}
+		var action authorizationapi.AuthorizationAttributes
+		o := &BuildStrategyOptions{
+			RoleModificationOptions: RoleModificationOptions{
+				RoleName: bootstrappolicy.BuildStrategyCustomRoleName,
+				RoleBindingAccessor: &fakeRoleBindingAccessor{bindings: []*authorizationapi.RoleBinding{{
+					ObjectMeta: kapi.ObjectMeta{Name: bootstrappolicy.BuildStrategyCustomRoleBindingName},
+				}}},
+				Users:  tc.Users,
+				Groups: tc.Groups,
+			},
+			Strategy:  "custom",
+			Namespace: tc.Namespace,
+			ReviewAccess: func(a authorizationapi.AuthorizationAttributes) (*authorizationapi.ResourceAccessReviewResponse, error) {
+				action = a
+				return &authorizationapi.ResourceAccessReviewResponse{
+					Users:  sets.NewString(tc.AllowedUsers...),
+					Groups: sets.NewString(tc.AllowedGroups...),
+				}, nil
+			},
+			Out: out,
+		}
+		if err := o.Restrict(); err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if action.Verb != "create" || action.Resource != authorizationapi.CustomBuildResource {
+			t.Errorf("%s: unexpected access review of %#v", k, action)
+		}
+		if !strings.Contains(out.String(), tc.Expected) {
+			t.Errorf("%s: expected %q in the output, got %q", k, tc.Expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			from:     "a\nb\n",
+			to:       "a\nb\n",
+			expected: " a\n b\n",
+		},
+		{
+			name:     "added",
+			from:     "",
+			to:       "a\nb\n",
+			expected: "+a\n+b\n",
+		},
+		{
+			name:     "changed line",
+			from:     "users:\n- alice\n- bob\npriority: 10\n",
+			to:       "users:\n- alice\npriority: 10\n- carol\n",
+			expected: " users:\n - alice\n-- bob\n priority: 10\n+- carol\n",
+		},
+	}
+
+	for _, test := range tests {
+		out := &bytes.Buffer{}
+		printLineDiff(out, test.from, test.to)
+		if out.String() != test.expected {
+			t.Errorf("%s: expected\n%q\ngot\n%q", test.name, test.expected, out.String())
+		}
+	}
+}
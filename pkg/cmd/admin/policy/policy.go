@@ -53,6 +53,11 @@ func NewCmdPolicy(name, fullName string, f *clientcmd.Factory, out io.Writer) *c
 	cmds.AddCommand(NewCmdRemoveSCCFromGroup(RemoveSCCFromGroupRecommendedName, fullName+" "+RemoveSCCFromGroupRecommendedName, f, out))
 	cmds.AddCommand(NewCmdReconcileSCC(ReconcileSCCRecommendedName, fullName+" "+ReconcileSCCRecommendedName, f, out))
 
+	cmds.AddCommand(NewCmdAllowBuildStrategy(AllowBuildStrategyRecommendedName, fullName+" "+AllowBuildStrategyRecommendedName, f, out))
+	cmds.AddCommand(NewCmdRestrictBuildStrategy(RestrictBuildStrategyRecommendedName, fullName+" "+RestrictBuildStrategyRecommendedName, f, out))
+
+	cmds.AddCommand(NewCmdRevokeTokens(RevokeTokensRecommendedName, fullName+" "+RevokeTokensRecommendedName, f, out))
+
 	return cmds
 }
 
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	kapierrors "k8s.io/kubernetes/pkg/api/errors"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl"
 	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	"k8s.io/kubernetes/pkg/util/sets"
 
@@ -49,11 +51,15 @@ will not remove additional users and groups that have been granted access to the
 will preserve existing priorities (but will always reconcile unset priorities and the policy
 definition).
 
-You can see which cluster SCCs have recommended changes by choosing an output type.`
+You can see which cluster SCCs have recommended changes by choosing an output type.
+Choosing the diff output type displays the changes that would be made to each SCC.`
 
 	reconcileSCCExample = `  # Display the cluster SCCs that would be modified
   $ %[1]s
 
+  # Display the changes that would be made to the cluster SCCs
+  $ %[1]s -o diff
+
   # Update cluster SCCs that don't match the current defaults preserving additional grants
   # for users and group and keeping any priorities that are already set
   $ %[1]s --confirm
@@ -122,7 +128,7 @@ func (o *ReconcileSCCOptions) Validate() error {
 	if o.SCCClient == nil {
 		return errors.New("a SCC client is required")
 	}
-	if o.Output != "yaml" && o.Output != "json" && o.Output != "" {
+	if o.Output != "yaml" && o.Output != "json" && o.Output != "diff" && o.Output != "" {
 		return fmt.Errorf("unknown output specified: %s", o.Output)
 	}
 	if _, err := o.NSClient.Get(o.InfraNamespace); err != nil {
@@ -144,6 +150,10 @@ func (o *ReconcileSCCOptions) RunReconcileSCCs(cmd *cobra.Command, f *clientcmd.
 		return nil
 	}
 
+	if !o.Confirmed && o.Output == "diff" {
+		return o.PrintSCCDiffs(changedSCCs)
+	}
+
 	if !o.Confirmed {
 		list := &kapi.List{}
 		for _, item := range changedSCCs {
@@ -188,6 +198,36 @@ func (o *ReconcileSCCOptions) ChangedSCCs() ([]*kapi.SecurityContextConstraints,
 	return changedSCCs, nil
 }
 
+// PrintSCCDiffs displays the difference between each existing SCC and the version
+// that would replace it. SCCs that do not exist yet are displayed as additions.
+func (o *ReconcileSCCOptions) PrintSCCDiffs(changedSCCs []*kapi.SecurityContextConstraints) error {
+	printer := kubectl.NewVersionedPrinter(&kubectl.YAMLPrinter{}, kapi.Scheme, "v1")
+	for _, changedSCC := range changedSCCs {
+		actual, proposed := &bytes.Buffer{}, &bytes.Buffer{}
+		displayedSCC := *changedSCC
+
+		actualSCC, err := o.SCCClient.Get(changedSCC.Name)
+		switch {
+		case kapierrors.IsNotFound(err):
+		case err != nil:
+			return err
+		default:
+			// metadata is not reconciled, so don't display it as a change
+			displayedSCC.ObjectMeta = actualSCC.ObjectMeta
+			if err := printer.PrintObj(actualSCC, actual); err != nil {
+				return err
+			}
+		}
+		if err := printer.PrintObj(&displayedSCC, proposed); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(o.Out, "securitycontextconstraints/%s\n", changedSCC.Name)
+		printLineDiff(o.Out, actual.String(), proposed.String())
+	}
+	return nil
+}
+
 // ReplaceChangedSCCs persists the changed SCCs.
 func (o *ReconcileSCCOptions) ReplaceChangedSCCs(changedSCCs []*kapi.SecurityContextConstraints) error {
 	for i := range changedSCCs {
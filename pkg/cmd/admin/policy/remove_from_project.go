@@ -31,6 +31,9 @@ type RemoveFromProjectOptions struct {
 	Groups []string
 	Users  []string
 
+	// DryRun displays the role bindings that would be changed without changing them.
+	DryRun bool
+
 	Out io.Writer
 }
 
@@ -53,6 +56,8 @@ func NewCmdRemoveGroupFromProject(name, fullName string, f *clientcmd.Factory, o
 		},
 	}
 
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", options.DryRun, "Display the role bindings that would be changed without changing them.")
+
 	return cmd
 }
 
@@ -75,6 +80,8 @@ func NewCmdRemoveUserFromProject(name, fullName string, f *clientcmd.Factory, ou
 		},
 	}
 
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", options.DryRun, "Display the role bindings that would be changed without changing them.")
+
 	return cmd
 }
 
@@ -108,6 +115,11 @@ func (o *RemoveFromProjectOptions) Run() error {
 	sasRemoved := sets.String{}
 	othersRemoved := sets.String{}
 
+	action := "Removing"
+	if o.DryRun {
+		action = "Would remove"
+	}
+
 	subjectsToRemove := authorizationapi.BuildSubjects(o.Users, o.Groups, uservalidation.ValidateUserName, uservalidation.ValidateGroupName)
 
 	for _, currPolicyBinding := range bindingList.Items {
@@ -125,9 +137,11 @@ func (o *RemoveFromProjectOptions) Run() error {
 				continue
 			}
 
-			_, err = o.Client.RoleBindings(o.BindingNamespace).Update(currBinding)
-			if err != nil {
-				return err
+			if !o.DryRun {
+				_, err = o.Client.RoleBindings(o.BindingNamespace).Update(currBinding)
+				if err != nil {
+					return err
+				}
 			}
 
 			roleDisplayName := fmt.Sprintf("%s/%s", currBinding.RoleRef.Namespace, currBinding.RoleRef.Name)
@@ -136,19 +150,19 @@ func (o *RemoveFromProjectOptions) Run() error {
 			}
 
 			if diff := oldUsersSet.Difference(newUsersSet); len(diff) != 0 {
-				fmt.Fprintf(o.Out, "Removing %s from users %v in project %s.\n", roleDisplayName, diff.List(), o.BindingNamespace)
+				fmt.Fprintf(o.Out, "%s %s from users %v in project %s.\n", action, roleDisplayName, diff.List(), o.BindingNamespace)
 				usersRemoved.Insert(diff.List()...)
 			}
 			if diff := oldGroupsSet.Difference(newGroupsSet); len(diff) != 0 {
-				fmt.Fprintf(o.Out, "Removing %s from groups %v in project %s.\n", roleDisplayName, diff.List(), o.BindingNamespace)
+				fmt.Fprintf(o.Out, "%s %s from groups %v in project %s.\n", action, roleDisplayName, diff.List(), o.BindingNamespace)
 				groupsRemoved.Insert(diff.List()...)
 			}
 			if diff := oldSAsSet.Difference(newSAsSet); len(diff) != 0 {
-				fmt.Fprintf(o.Out, "Removing %s from serviceaccounts %v in project %s.\n", roleDisplayName, diff.List(), o.BindingNamespace)
+				fmt.Fprintf(o.Out, "%s %s from serviceaccounts %v in project %s.\n", action, roleDisplayName, diff.List(), o.BindingNamespace)
 				sasRemoved.Insert(diff.List()...)
 			}
 			if diff := oldOtherSet.Difference(newOtherSet); len(diff) != 0 {
-				fmt.Fprintf(o.Out, "Removing %s from subjects %v in project %s.\n", roleDisplayName, diff.List(), o.BindingNamespace)
+				fmt.Fprintf(o.Out, "%s %s from subjects %v in project %s.\n", action, roleDisplayName, diff.List(), o.BindingNamespace)
 				othersRemoved.Insert(diff.List()...)
 			}
 		}
@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const RevokeTokensRecommendedName = "revoke-tokens"
+
+const (
+	revokeTokensLong = `Revoke all the OAuth tokens of a user
+
+Deletes every access token and authorize token granted to the user, so that the tokens
+the user holds stop working immediately, for instance after they leaked. Authorize tokens
+are deleted first, so they cannot be exchanged for new access tokens in the meantime.
+
+The user, its identities and its client authorizations are kept: logging in again grants
+new tokens. Use 'prune users' to remove the user entirely.`
+
+	revokeTokensExample = `  # Revoke all the tokens of the user alice
+  $ %[1]s --user=alice`
+)
+
+type RevokeTokensOptions struct {
+	User string
+
+	Client client.Interface
+
+	Out io.Writer
+}
+
+// NewCmdRevokeTokens implements the OpenShift cli revoke-tokens command
+func NewCmdRevokeTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &RevokeTokensOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " --user=USER",
+		Short:   "Revoke all the OAuth tokens of a user",
+		Long:    revokeTokensLong,
+		Example: fmt.Sprintf(revokeTokensExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&options.User, "user", options.User, "The user whose tokens are revoked.")
+
+	return cmd
+}
+
+func (o *RevokeTokensOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are supported, specify the user with --user")
+	}
+	if len(o.User) == 0 {
+		return errors.New("you must specify a user with --user")
+	}
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	return nil
+}
+
+// Run deletes the authorize tokens, then the access tokens of the user. The names of the tokens are the tokens
+// themselves, so only how many were revoked is printed.
+func (o *RevokeTokensOptions) Run() error {
+	byUserName := fields.OneTermEqualSelector("userName", o.User)
+
+	authorizeTokens, err := o.Client.OAuthAuthorizeTokens().List(labels.Everything(), byUserName)
+	if err != nil {
+		return err
+	}
+	for _, token := range authorizeTokens.Items {
+		if err := o.Client.OAuthAuthorizeTokens().Delete(token.Name); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	accessTokens, err := o.Client.OAuthAccessTokens().List(labels.Everything(), byUserName)
+	if err != nil {
+		return err
+	}
+	for _, token := range accessTokens.Items {
+		if err := o.Client.OAuthAccessTokens().Delete(token.Name); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	fmt.Fprintf(o.Out, "Revoked %d access token(s) and %d authorize token(s) of user %s\n", len(accessTokens.Items), len(authorizeTokens.Items), o.User)
+	return nil
+}
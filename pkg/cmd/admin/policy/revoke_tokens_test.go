@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+func TestRevokeTokens(t *testing.T) {
+	client := testclient.NewSimpleFake(
+		&oauthapi.OAuthAuthorizeTokenList{Items: []oauthapi.OAuthAuthorizeToken{
+			{ObjectMeta: kapi.ObjectMeta{Name: "code"}, UserName: "alice"},
+		}},
+		&oauthapi.OAuthAccessTokenList{Items: []oauthapi.OAuthAccessToken{
+			{ObjectMeta: kapi.ObjectMeta{Name: "token1"}, UserName: "alice"},
+			{ObjectMeta: kapi.ObjectMeta{Name: "token2"}, UserName: "alice"},
+		}},
+	)
+	out := &bytes.Buffer{}
+	o := &RevokeTokensOptions{User: "alice", Client: client, Out: out}
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deleted := []string{}
+	for _, action := range client.Actions() {
+		switch a := action.(type) {
+		case ktestclient.ListAction:
+			if selector := a.GetListRestrictions().Fields.String(); selector != "userName=alice" {
+				t.Errorf("expected the tokens of alice to be listed, got %s", selector)
+			}
+		case ktestclient.DeleteAction:
+			deleted = append(deleted, a.GetResource()+"/"+a.GetName())
+		}
+	}
+
+	// authorize tokens are removed first, so they cannot be exchanged for new access tokens
+	expectedDeleted := []string{
+		"oauthauthorizetokens/code",
+		"oauthaccesstokens/token1",
+		"oauthaccesstokens/token2",
+	}
+	if !reflect.DeepEqual(expectedDeleted, deleted) {
+		t.Errorf("expected %v to be deleted, got %v", expectedDeleted, deleted)
+	}
+	if strings.Contains(out.String(), "token1") {
+		t.Errorf("expected the tokens not to be printed:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Revoked 2 access token(s) and 1 authorize token(s)") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
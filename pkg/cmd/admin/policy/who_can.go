@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/controller/serviceaccount"
 	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
@@ -23,18 +24,21 @@ type whoCanOptions struct {
 	bindingNamespace string
 	client           *client.Client
 
-	verb     string
-	resource string
+	verb         string
+	resource     string
+	resourceName string
+
+	out io.Writer
 }
 
 // NewCmdWhoCan implements the OpenShift cli who-can command
 func NewCmdWhoCan(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
-	options := &whoCanOptions{}
+	options := &whoCanOptions{out: out}
 
 	cmd := &cobra.Command{
-		Use:   "who-can VERB RESOURCE",
+		Use:   "who-can VERB RESOURCE|PATH [NAME]",
 		Short: "List who can perform the specified action on a resource",
-		Long:  "List who can perform the specified action on a resource",
+		Long:  "List who can perform the specified action on a resource, or on the resource with the given name, or on a non-resource URL such as /healthz if a path starting with / is given",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := options.complete(args); err != nil {
 				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
@@ -58,24 +62,39 @@ func NewCmdWhoCan(name, fullName string, f *clientcmd.Factory, out io.Writer) *c
 }
 
 func (o *whoCanOptions) complete(args []string) error {
-	if len(args) != 2 {
-		return errors.New("you must specify two arguments: verb and resource")
+	if len(args) != 2 && len(args) != 3 {
+		return errors.New("you must specify two or three arguments: verb, resource, and optional resourceName")
 	}
 
 	o.verb = args[0]
 	o.resource = args[1]
+	if len(args) == 3 {
+		if strings.HasPrefix(o.resource, "/") {
+			return errors.New("a resource name can not be given for a non-resource URL")
+		}
+		o.resourceName = args[2]
+	}
 	return nil
 }
 
 func (o *whoCanOptions) run() error {
 	authorizationAttributes := authorizationapi.AuthorizationAttributes{
-		Resource: o.resource,
-		Verb:     o.verb,
+		Resource:     o.resource,
+		Verb:         o.verb,
+		ResourceName: o.resourceName,
+	}
+	if strings.HasPrefix(o.resource, "/") {
+		authorizationAttributes = authorizationapi.AuthorizationAttributes{
+			Verb:             o.verb,
+			IsNonResourceURL: true,
+			Path:             o.resource,
+		}
 	}
 
 	resourceAccessReviewResponse := &authorizationapi.ResourceAccessReviewResponse{}
 	var err error
-	if o.allNamespaces {
+	// non-resource URLs are only authorized by cluster policy, whatever the namespace
+	if o.allNamespaces || authorizationAttributes.IsNonResourceURL {
 		resourceAccessReviewResponse, err = o.client.ResourceAccessReviews().Create(&authorizationapi.ResourceAccessReview{Action: authorizationAttributes})
 	} else {
 		resourceAccessReviewResponse, err = o.client.LocalResourceAccessReviews(o.bindingNamespace).Create(&authorizationapi.LocalResourceAccessReview{Action: authorizationAttributes})
@@ -86,23 +105,40 @@ func (o *whoCanOptions) run() error {
 	}
 
 	if resourceAccessReviewResponse.Namespace == kapi.NamespaceAll {
-		fmt.Printf("Namespace: <all>\n")
+		fmt.Fprintf(o.out, "Namespace: <all>\n")
 	} else {
-		fmt.Printf("Namespace: %s\n", resourceAccessReviewResponse.Namespace)
+		fmt.Fprintf(o.out, "Namespace: %s\n", resourceAccessReviewResponse.Namespace)
 	}
-	fmt.Printf("Verb:      %s\n", o.verb)
-	fmt.Printf("Resource:  %s\n\n", o.resource)
-	if len(resourceAccessReviewResponse.Users) == 0 {
-		fmt.Printf("Users:  none\n\n")
+	fmt.Fprintf(o.out, "Verb:      %s\n", o.verb)
+	if authorizationAttributes.IsNonResourceURL {
+		fmt.Fprintf(o.out, "Path:      %s\n\n", o.resource)
+	} else if len(o.resourceName) > 0 {
+		fmt.Fprintf(o.out, "Resource:  %s\n", o.resource)
+		fmt.Fprintf(o.out, "Name:      %s\n\n", o.resourceName)
 	} else {
-		fmt.Printf("Users:  %s\n\n", strings.Join(resourceAccessReviewResponse.Users.List(), "\n        "))
+		fmt.Fprintf(o.out, "Resource:  %s\n\n", o.resource)
 	}
 
-	if len(resourceAccessReviewResponse.Groups) == 0 {
-		fmt.Printf("Groups: none\n\n")
-	} else {
-		fmt.Printf("Groups: %s\n\n", strings.Join(resourceAccessReviewResponse.Groups.List(), "\n        "))
+	users, serviceAccounts := []string{}, []string{}
+	for _, user := range resourceAccessReviewResponse.Users.List() {
+		if _, _, err := serviceaccount.SplitUsername(user); err == nil {
+			serviceAccounts = append(serviceAccounts, user)
+			continue
+		}
+		users = append(users, user)
 	}
+	printSubjects(o.out, "Users:           ", users)
+	printSubjects(o.out, "Service accounts:", serviceAccounts)
+	printSubjects(o.out, "Groups:          ", resourceAccessReviewResponse.Groups.List())
 
 	return nil
 }
+
+// printSubjects prints the subjects after the heading, one per line
+func printSubjects(out io.Writer, heading string, subjects []string) {
+	if len(subjects) == 0 {
+		fmt.Fprintf(out, "%s none\n\n", heading)
+		return
+	}
+	fmt.Fprintf(out, "%s %s\n\n", heading, strings.Join(subjects, "\n"+strings.Repeat(" ", len(heading)+1)))
+}
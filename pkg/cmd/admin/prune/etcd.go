@@ -0,0 +1,246 @@
+package prune
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+
+	etcdclient "github.com/coreos/go-etcd/etcd"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/runtime"
+	etcdstorage "k8s.io/kubernetes/pkg/storage/etcd"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	configapilatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
+	"github.com/openshift/origin/pkg/cmd/server/etcd"
+)
+
+const PruneEtcdRecommendedName = "etcd"
+
+const (
+	etcdLongDesc = `Remove orphaned OpenShift keys from etcd
+
+This command connects directly to the etcd servers of the master and scans the keys that
+OpenShift stores resources under. It reports keys whose objects can no longer be decoded
+with the storage version of the master, which may be left behind by an upgrade that did
+not complete, and keys of resources in namespaces that no longer exist.
+
+By default, the prune operation performs a dry run making no changes to etcd.
+A --confirm flag is needed for changes to be effective. Back up etcd before removing keys.
+`
+
+	etcdExample = `  # Dry run listing the orphaned keys
+  $ %[1]s %[2]s --master-config=/etc/openshift/master/master-config.yaml
+
+  # To actually remove the orphaned keys, the confirm flag must be appended
+  $ %[1]s %[2]s --master-config=/etc/openshift/master/master-config.yaml --confirm`
+)
+
+// etcdKeysClient is the part of the etcd client needed to find and remove orphaned keys
+type etcdKeysClient interface {
+	Get(key string, sort, recursive bool) (*etcdclient.Response, error)
+	CompareAndDelete(key string, prevValue string, prevIndex uint64) (*etcdclient.Response, error)
+}
+
+// orphanedKey is a key that does not hold a usable object
+type orphanedKey struct {
+	Key    string
+	Reason string
+	// ModifiedIndex is the index of the value that was scanned, the key is only removed if it still holds it
+	ModifiedIndex uint64
+	// Namespace is the namespace that did not exist when the key was scanned, if that is why it is orphaned
+	Namespace string
+}
+
+type pruneEtcdOptions struct {
+	MasterConfigFile string
+	Confirm          bool
+
+	Client etcdKeysClient
+	Codec  runtime.Codec
+	// Prefix is the etcd directory OpenShift resources are stored in
+	Prefix string
+	// NamespacePrefix is the etcd directory namespaces are stored in
+	NamespacePrefix string
+
+	Out io.Writer
+	Err io.Writer
+}
+
+func NewCmdPruneEtcd(parentName, name string, out io.Writer) *cobra.Command {
+	options := &pruneEtcdOptions{Out: out, Err: os.Stderr}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "Remove orphaned OpenShift keys from etcd",
+		Long:    etcdLongDesc,
+		Example: fmt.Sprintf(etcdExample, parentName, name),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "no arguments are allowed to this command"))
+			}
+			kcmdutil.CheckErr(options.Complete())
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Confirm, "confirm", options.Confirm, "Specify that the orphaned keys should be removed. Defaults to false, displaying what would be removed but not actually removing anything.")
+	cmd.Flags().StringVar(&options.MasterConfigFile, "master-config", "openshift.local.config/master/master-config.yaml", "Location of the master configuration file to run from in order to connect to etcd.")
+	cmd.MarkFlagFilename("master-config", "yaml", "yml")
+
+	return cmd
+}
+
+// Complete connects to the etcd servers of the master
+func (o *pruneEtcdOptions) Complete() error {
+	if len(o.MasterConfigFile) == 0 {
+		return fmt.Errorf("--master-config must be provided")
+	}
+	masterConfig, err := configapilatest.ReadAndResolveMasterConfig(o.MasterConfigFile)
+	if err != nil {
+		return err
+	}
+	interfaces, err := latest.InterfacesFor(masterConfig.EtcdStorageConfig.OpenShiftStorageVersion)
+	if err != nil {
+		return err
+	}
+	client, err := etcd.GetAndTestEtcdClient(masterConfig.EtcdClientInfo)
+	if err != nil {
+		return err
+	}
+	o.Client = client
+	o.Codec = interfaces.Codec
+	o.Prefix = path.Join("/", masterConfig.EtcdStorageConfig.OpenShiftStoragePrefix)
+	o.NamespacePrefix = path.Join("/", masterConfig.EtcdStorageConfig.KubernetesStoragePrefix, "namespaces")
+	return nil
+}
+
+// Run lists the orphaned keys and removes them if confirmed
+func (o *pruneEtcdOptions) Run() error {
+	orphans, err := o.findOrphanedKeys()
+	if err != nil {
+		return err
+	}
+	if !o.Confirm {
+		fmt.Fprintln(o.Err, "Dry run enabled - no modifications will be made. Add --confirm to remove keys")
+	}
+
+	w := tabwriter.NewWriter(o.Out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "KEY\tREASON")
+	for _, orphan := range orphans {
+		if !o.Confirm {
+			fmt.Fprintf(w, "%s\t%s\n", orphan.Key, orphan.Reason)
+			continue
+		}
+		kept, err := o.remove(orphan)
+		if err != nil {
+			return err
+		}
+		if len(kept) > 0 {
+			fmt.Fprintf(w, "%s\tkept, %s\n", orphan.Key, kept)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\n", orphan.Key, orphan.Reason)
+	}
+	return nil
+}
+
+// remove removes the key of orphan unless it changed since it was scanned, in which case it returns why the key
+// was kept. The scan is not atomic, so the namespace of the key may have been created since, and the key may
+// hold a new object.
+func (o *pruneEtcdOptions) remove(orphan orphanedKey) (string, error) {
+	if len(orphan.Namespace) > 0 {
+		_, err := o.Client.Get(path.Join(o.NamespacePrefix, orphan.Namespace), false, false)
+		switch {
+		case err == nil:
+			return fmt.Sprintf("namespace %s was created", orphan.Namespace), nil
+		case !etcdstorage.IsEtcdNotFound(err):
+			return "", fmt.Errorf("unable to check namespace %s: %v", orphan.Namespace, err)
+		}
+	}
+	_, err := o.Client.CompareAndDelete(orphan.Key, "", orphan.ModifiedIndex)
+	switch {
+	case err == nil, etcdstorage.IsEtcdNotFound(err):
+		return "", nil
+	case etcdstorage.IsEtcdTestFailed(err):
+		return "the key was modified", nil
+	default:
+		return "", fmt.Errorf("unable to remove %s: %v", orphan.Key, err)
+	}
+}
+
+// findOrphanedKeys returns the keys under Prefix whose value cannot be decoded or whose object belongs to a
+// namespace that does not exist. The namespaces are listed before the keys are scanned, so a namespace created
+// during the scan makes its keys look orphaned; remove checks the namespace again.
+func (o *pruneEtcdOptions) findOrphanedKeys() ([]orphanedKey, error) {
+	namespaces, err := o.namespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := o.Client.Get(o.Prefix, true, true)
+	if err != nil {
+		if etcdstorage.IsEtcdNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// controller leases are not API objects
+	leases := path.Join(o.Prefix, "leases") + "/"
+
+	orphans := []orphanedKey{}
+	var visit func(*etcdclient.Node)
+	visit = func(node *etcdclient.Node) {
+		if node.Dir {
+			for _, child := range node.Nodes {
+				visit(child)
+			}
+			return
+		}
+		if strings.HasPrefix(node.Key, leases) {
+			return
+		}
+		obj, err := o.Codec.Decode([]byte(node.Value))
+		if err != nil {
+			orphans = append(orphans, orphanedKey{Key: node.Key, Reason: fmt.Sprintf("cannot be decoded: %v", err), ModifiedIndex: node.ModifiedIndex})
+			return
+		}
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			orphans = append(orphans, orphanedKey{Key: node.Key, Reason: fmt.Sprintf("is not an API object: %v", err), ModifiedIndex: node.ModifiedIndex})
+			return
+		}
+		if namespace := objMeta.Namespace(); len(namespace) > 0 && !namespaces.Has(namespace) {
+			orphans = append(orphans, orphanedKey{Key: node.Key, Reason: fmt.Sprintf("namespace %s does not exist", namespace), ModifiedIndex: node.ModifiedIndex, Namespace: namespace})
+		}
+	}
+	visit(response.Node)
+	return orphans, nil
+}
+
+// namespaces returns the names of the namespaces that exist
+func (o *pruneEtcdOptions) namespaces() (sets.String, error) {
+	response, err := o.Client.Get(o.NamespacePrefix, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the namespaces under %s: %v", o.NamespacePrefix, err)
+	}
+	namespaces := sets.NewString()
+	for _, node := range response.Node.Nodes {
+		namespaces.Insert(path.Base(node.Key))
+	}
+	// every cluster has namespaces, so finding none means that the wrong directory was read and removing keys
+	// of resources in "deleted" namespaces would remove them all
+	if namespaces.Len() == 0 {
+		return nil, fmt.Errorf("no namespaces were found under %s", o.NamespacePrefix)
+	}
+	return namespaces, nil
+}
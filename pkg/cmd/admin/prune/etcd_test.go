@@ -0,0 +1,161 @@
+package prune
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	etcdclient "github.com/coreos/go-etcd/etcd"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+type fakeEtcdKeysClient struct {
+	nodes map[string]*etcdclient.Node
+	// modified are the keys modified since they were scanned
+	modified sets.String
+	deleted  []string
+}
+
+func (c *fakeEtcdKeysClient) Get(key string, sort, recursive bool) (*etcdclient.Response, error) {
+	node, ok := c.nodes[key]
+	if !ok {
+		return nil, &etcdclient.EtcdError{ErrorCode: 100}
+	}
+	return &etcdclient.Response{Node: node}, nil
+}
+
+func (c *fakeEtcdKeysClient) CompareAndDelete(key string, prevValue string, prevIndex uint64) (*etcdclient.Response, error) {
+	if prevIndex == 0 {
+		return nil, fmt.Errorf("expected the index of the scanned value")
+	}
+	if c.modified.Has(key) {
+		return nil, &etcdclient.EtcdError{ErrorCode: 101}
+	}
+	c.deleted = append(c.deleted, key)
+	return &etcdclient.Response{}, nil
+}
+
+func encode(t *testing.T, obj runtime.Object) string {
+	data, err := latest.Codec.Encode(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(data)
+}
+
+func newPruneEtcdOptions(t *testing.T) (*pruneEtcdOptions, *fakeEtcdKeysClient) {
+	live := &buildapi.BuildConfig{ObjectMeta: kapi.ObjectMeta{Name: "ruby", Namespace: "live"}}
+	gone := &buildapi.BuildConfig{ObjectMeta: kapi.ObjectMeta{Name: "ruby", Namespace: "gone"}}
+	image := &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc"}}
+
+	client := &fakeEtcdKeysClient{nodes: map[string]*etcdclient.Node{
+		"/kubernetes.io/namespaces": {Key: "/kubernetes.io/namespaces", Dir: true, Nodes: etcdclient.Nodes{
+			{Key: "/kubernetes.io/namespaces/default"},
+			{Key: "/kubernetes.io/namespaces/live"},
+		}},
+		"/openshift.io": {Key: "/openshift.io", Dir: true, Nodes: etcdclient.Nodes{
+			{Key: "/openshift.io/buildconfigs", Dir: true, Nodes: etcdclient.Nodes{
+				{Key: "/openshift.io/buildconfigs/gone", Dir: true, Nodes: etcdclient.Nodes{
+					{Key: "/openshift.io/buildconfigs/gone/ruby", Value: encode(t, gone), ModifiedIndex: 10},
+				}},
+				{Key: "/openshift.io/buildconfigs/live", Dir: true, Nodes: etcdclient.Nodes{
+					{Key: "/openshift.io/buildconfigs/live/ruby", Value: encode(t, live), ModifiedIndex: 11},
+					{Key: "/openshift.io/buildconfigs/live/old", Value: `{"kind":"BuildConfig","apiVersion":"v1beta1"}`, ModifiedIndex: 12},
+				}},
+			}},
+			{Key: "/openshift.io/images", Dir: true, Nodes: etcdclient.Nodes{
+				{Key: "/openshift.io/images/sha256:abc", Value: encode(t, image), ModifiedIndex: 13},
+			}},
+			{Key: "/openshift.io/leases", Dir: true, Nodes: etcdclient.Nodes{
+				{Key: "/openshift.io/leases/controllers", Value: "master-abcdefgh", ModifiedIndex: 14},
+			}},
+		}},
+	}, modified: sets.NewString()}
+
+	return &pruneEtcdOptions{
+		Client:          client,
+		Codec:           latest.Codec,
+		Prefix:          "/openshift.io",
+		NamespacePrefix: "/kubernetes.io/namespaces",
+		Out:             ioutil.Discard,
+		Err:             ioutil.Discard,
+	}, client
+}
+
+func TestPruneEtcdFindsOrphanedKeys(t *testing.T) {
+	o, client := newPruneEtcdOptions(t)
+	out := &bytes.Buffer{}
+	o.Out = out
+
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.deleted) != 0 {
+		t.Errorf("expected a dry run, got deletions: %v", client.deleted)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("namespace gone does not exist")) {
+		t.Errorf("expected the key in the deleted namespace to be reported, got:\n%s", out.String())
+	}
+
+	o.Confirm = true
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"/openshift.io/buildconfigs/gone/ruby", "/openshift.io/buildconfigs/live/old"}
+	if !reflect.DeepEqual(expected, client.deleted) {
+		t.Errorf("expected %v to be deleted, got %v", expected, client.deleted)
+	}
+}
+
+func TestPruneEtcdKeepsChangedKeys(t *testing.T) {
+	o, client := newPruneEtcdOptions(t)
+	orphans, err := o.findOrphanedKeys()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("unexpected orphans: %#v", orphans)
+	}
+
+	// the namespace of the first key and the value of the second one changed after the scan
+	client.nodes["/kubernetes.io/namespaces/gone"] = &etcdclient.Node{Key: "/kubernetes.io/namespaces/gone"}
+	client.modified.Insert("/openshift.io/buildconfigs/live/old")
+	for _, orphan := range orphans {
+		kept, err := o.remove(orphan)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(kept) == 0 {
+			t.Errorf("expected %s to be kept", orphan.Key)
+		}
+	}
+	if len(client.deleted) != 0 {
+		t.Errorf("unexpected deletions: %v", client.deleted)
+	}
+}
+
+func TestPruneEtcdRequiresNamespaces(t *testing.T) {
+	o, client := newPruneEtcdOptions(t)
+	o.Confirm = true
+	o.NamespacePrefix = "/kubernetes/namespaces"
+
+	if err := o.Run(); err == nil {
+		t.Fatalf("expected an error when no namespaces are found")
+	}
+	client.nodes["/kubernetes/namespaces"] = &etcdclient.Node{Key: "/kubernetes/namespaces", Dir: true}
+	if err := o.Run(); err == nil {
+		t.Fatalf("expected an error when no namespaces are found")
+	}
+	if len(client.deleted) != 0 {
+		t.Errorf("unexpected deletions: %v", client.deleted)
+	}
+}
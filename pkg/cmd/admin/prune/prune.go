@@ -31,6 +31,9 @@ func NewCommandPrune(name, fullName string, f *clientcmd.Factory, out io.Writer)
 	cmds.AddCommand(NewCmdPruneBuilds(f, fullName, PruneBuildsRecommendedName, out))
 	cmds.AddCommand(NewCmdPruneDeployments(f, fullName, PruneDeploymentsRecommendedName, out))
 	cmds.AddCommand(NewCmdPruneImages(f, fullName, PruneImagesRecommendedName, out))
+	cmds.AddCommand(NewCmdPruneEtcd(fullName, PruneEtcdRecommendedName, out))
+	cmds.AddCommand(NewCmdPruneUsers(f, fullName, PruneUsersRecommendedName, out))
+	cmds.AddCommand(NewCmdPruneTokens(f, fullName, PruneTokensRecommendedName, out))
 	cmds.AddCommand(groups.NewCmdPrune(PruneGroupsRecommendedName, fullName+" "+PruneGroupsRecommendedName, f, out))
 	return cmds
 }
@@ -0,0 +1,224 @@
+package prune
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const PruneTokensRecommendedName = "tokens"
+
+const (
+	tokensLongDesc = `Remove expired and orphaned OAuth tokens
+
+Access and authorize tokens are normally removed by the server when they
+expire, but tokens granted with very long or no lifetimes can pile up. This
+command removes the tokens which have expired, the tokens older than --max-age
+whatever their lifetime, and with --orphans the tokens of users which were
+deleted or deleted and created again. Tokens are removed --batch-size at a time.
+
+The names of tokens are the tokens themselves, so only their users, clients and
+the reason of their removal are displayed.
+
+By default, the prune operation performs a dry run making no changes to the server.
+A --confirm flag is needed for changes to be effective.`
+
+	tokensExample = `  # Dry run listing the expired tokens
+  $ %[1]s %[2]s
+
+  # Remove the expired tokens, the tokens of deleted users, and any token older than 30 days
+  $ %[1]s %[2]s --orphans --max-age=720h --confirm`
+)
+
+type pruneTokensOptions struct {
+	Confirm   bool
+	Orphans   bool
+	MaxAge    time.Duration
+	BatchSize int
+
+	Client client.Interface
+
+	Out io.Writer
+	Err io.Writer
+}
+
+func NewCmdPruneTokens(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
+	options := &pruneTokensOptions{BatchSize: 50, Out: out, Err: os.Stderr}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "Remove expired and orphaned OAuth tokens",
+		Long:    tokensLongDesc,
+		Example: fmt.Sprintf(tokensExample, parentName, name),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Confirm, "confirm", options.Confirm, "Specify that the tokens should be removed. Defaults to false, displaying what would be removed but not actually removing anything.")
+	cmd.Flags().BoolVar(&options.Orphans, "orphans", options.Orphans, "Also remove the tokens of users which no longer exist or were created again.")
+	cmd.Flags().DurationVar(&options.MaxAge, "max-age", options.MaxAge, "Also remove the tokens older than this, whatever their lifetime. Disabled if 0.")
+	cmd.Flags().IntVar(&options.BatchSize, "batch-size", options.BatchSize, "The number of tokens removed at a time.")
+
+	return cmd
+}
+
+func (o *pruneTokensOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed to this command")
+	}
+	if o.BatchSize <= 0 {
+		return errors.New("--batch-size must be greater than zero")
+	}
+	if o.MaxAge < 0 {
+		return errors.New("--max-age cannot be negative")
+	}
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	return nil
+}
+
+// prunableToken is a token to remove. Its name is never displayed.
+type prunableToken struct {
+	resource string
+	name     string
+	user     string
+	client   string
+	reason   string
+}
+
+// Run lists the tokens to remove, then removes them in batches
+func (o *pruneTokensOptions) Run() error {
+	if !o.Confirm {
+		fmt.Fprintln(o.Err, "Dry run enabled - no modifications will be made. Add --confirm to remove tokens")
+	}
+
+	var userUIDs map[string]string
+	if o.Orphans {
+		users, err := o.Client.Users().List(labels.Everything(), fields.Everything())
+		if err != nil {
+			return err
+		}
+		userUIDs = map[string]string{}
+		for _, user := range users.Items {
+			userUIDs[user.Name] = string(user.UID)
+		}
+	}
+	now := time.Now()
+
+	tokens := []prunableToken{}
+	authorizeTokens, err := o.Client.OAuthAuthorizeTokens().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	for _, token := range authorizeTokens.Items {
+		if reason := o.pruneReason(token.ObjectMeta, token.ExpiresIn, token.UserName, token.UserUID, userUIDs, now); len(reason) > 0 {
+			tokens = append(tokens, prunableToken{"oauthauthorizetokens", token.Name, token.UserName, token.ClientName, reason})
+		}
+	}
+	accessTokens, err := o.Client.OAuthAccessTokens().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	for _, token := range accessTokens.Items {
+		if reason := o.pruneReason(token.ObjectMeta, token.ExpiresIn, token.UserName, token.UserUID, userUIDs, now); len(reason) > 0 {
+			tokens = append(tokens, prunableToken{"oauthaccesstokens", token.Name, token.UserName, token.ClientName, reason})
+		}
+	}
+
+	w := tabwriter.NewWriter(o.Out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tUSER\tCLIENT\tREASON")
+	for _, token := range tokens {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", token.resource, token.user, token.client, token.reason)
+	}
+	w.Flush()
+
+	if !o.Confirm {
+		return nil
+	}
+	for start := 0; start < len(tokens); start += o.BatchSize {
+		end := start + o.BatchSize
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		if err := o.deleteTokens(tokens[start:end]); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(o.Out, "Removed %d token(s)\n", len(tokens))
+	return nil
+}
+
+// pruneReason returns why a token should be removed, or an empty string if it should be kept. userUIDs maps the
+// names of the existing users to their UIDs, and is nil if orphaned tokens are kept.
+func (o *pruneTokensOptions) pruneReason(meta kapi.ObjectMeta, expiresIn int64, userName, userUID string, userUIDs map[string]string, now time.Time) string {
+	created := meta.CreationTimestamp.Time
+	switch {
+	case expiresIn > 0 && !created.Add(time.Duration(expiresIn)*time.Second).After(now):
+		return "expired"
+	case o.MaxAge > 0 && !created.Add(o.MaxAge).After(now):
+		return "older than max age"
+	}
+	if userUIDs == nil {
+		return ""
+	}
+	if uid, exists := userUIDs[userName]; !exists {
+		return "user deleted"
+	} else if len(userUID) > 0 && uid != userUID {
+		return "user created again"
+	}
+	return ""
+}
+
+// deleteTokens removes tokens concurrently and returns once they are all removed
+func (o *pruneTokensOptions) deleteTokens(tokens []prunableToken) error {
+	errs := make(chan error, len(tokens))
+	wg := sync.WaitGroup{}
+	for _, token := range tokens {
+		wg.Add(1)
+		go func(token prunableToken) {
+			defer wg.Done()
+			var err error
+			switch token.resource {
+			case "oauthauthorizetokens":
+				err = o.Client.OAuthAuthorizeTokens().Delete(token.name)
+			case "oauthaccesstokens":
+				err = o.Client.OAuthAccessTokens().Delete(token.name)
+			}
+			if err != nil && !kerrors.IsNotFound(err) {
+				errs <- fmt.Errorf("unable to remove a token of user %s from %s: %v", token.user, token.resource, err)
+			}
+		}(token)
+	}
+	wg.Wait()
+	close(errs)
+
+	aggregate := []error{}
+	for err := range errs {
+		aggregate = append(aggregate, err)
+	}
+	return utilerrors.NewAggregate(aggregate)
+}
@@ -0,0 +1,114 @@
+package prune
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/types"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+func newPruneTokensClient() *testclient.Fake {
+	ago := func(d time.Duration) kapi.ObjectMeta {
+		return kapi.ObjectMeta{CreationTimestamp: unversioned.NewTime(time.Now().Add(-d))}
+	}
+	accessToken := func(name string, age time.Duration, expiresIn int64, user, uid string) oauthapi.OAuthAccessToken {
+		token := oauthapi.OAuthAccessToken{ObjectMeta: ago(age), ExpiresIn: expiresIn, UserName: user, UserUID: uid, ClientName: "openshift-challenging-client"}
+		token.Name = name
+		return token
+	}
+
+	return testclient.NewSimpleFake(
+		&oauthapi.OAuthAuthorizeTokenList{Items: []oauthapi.OAuthAuthorizeToken{
+			{ObjectMeta: kapi.ObjectMeta{Name: "expired-code", CreationTimestamp: unversioned.NewTime(time.Now().Add(-time.Hour))}, ExpiresIn: 300, UserName: "alice", UserUID: "alice-uid"},
+			{ObjectMeta: kapi.ObjectMeta{Name: "code", CreationTimestamp: unversioned.NewTime(time.Now())}, ExpiresIn: 300, UserName: "alice", UserUID: "alice-uid"},
+		}},
+		&oauthapi.OAuthAccessTokenList{Items: []oauthapi.OAuthAccessToken{
+			accessToken("expired", 48*time.Hour, 86400, "alice", "alice-uid"),
+			accessToken("valid", time.Hour, 86400, "alice", "alice-uid"),
+			accessToken("never-expiring", 90*24*time.Hour, 0, "alice", "alice-uid"),
+			accessToken("deleted-user", time.Hour, 86400, "bob", "bob-uid"),
+			accessToken("recreated-user", time.Hour, 86400, "alice", "old-alice-uid"),
+		}},
+		&userapi.UserList{Items: []userapi.User{
+			{ObjectMeta: kapi.ObjectMeta{Name: "alice", UID: types.UID("alice-uid")}},
+		}},
+	)
+}
+
+func deletedTokens(client *testclient.Fake) []string {
+	deleted := []string{}
+	for _, action := range client.Actions() {
+		if a, ok := action.(ktestclient.DeleteAction); ok {
+			deleted = append(deleted, a.GetResource()+"/"+a.GetName())
+		}
+	}
+	sort.Strings(deleted)
+	return deleted
+}
+
+func TestPruneTokens(t *testing.T) {
+	testCases := map[string]struct {
+		orphans         bool
+		maxAge          time.Duration
+		expectedDeleted []string
+	}{
+		"expired": {
+			expectedDeleted: []string{"oauthaccesstokens/expired", "oauthauthorizetokens/expired-code"},
+		},
+		"max age": {
+			maxAge:          30 * 24 * time.Hour,
+			expectedDeleted: []string{"oauthaccesstokens/expired", "oauthaccesstokens/never-expiring", "oauthauthorizetokens/expired-code"},
+		},
+		"orphans": {
+			orphans:         true,
+			expectedDeleted: []string{"oauthaccesstokens/deleted-user", "oauthaccesstokens/expired", "oauthaccesstokens/recreated-user", "oauthauthorizetokens/expired-code"},
+		},
+	}
+
+	for k, tc := range testCases {
+		client := newPruneTokensClient()
+		o := &pruneTokensOptions{Confirm: true, Orphans: tc.orphans, MaxAge: tc.maxAge, BatchSize: 2, Client: client, Out: ioutil.Discard, Err: ioutil.Discard}
+		if err := o.Run(); err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if deleted := deletedTokens(client); strings.Join(deleted, ",") != strings.Join(tc.expectedDeleted, ",") {
+			t.Errorf("%s: expected %v to be deleted, got %v", k, tc.expectedDeleted, deleted)
+		}
+	}
+}
+
+func TestPruneTokensDryRun(t *testing.T) {
+	client := newPruneTokensClient()
+	out := &bytes.Buffer{}
+	o := &pruneTokensOptions{Orphans: true, BatchSize: 50, Client: client, Out: out, Err: ioutil.Discard}
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deleted := deletedTokens(client); len(deleted) > 0 {
+		t.Errorf("expected no modifications in a dry run, got %v", deleted)
+	}
+	for _, expected := range []string{"expired", "user deleted", "user created again"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q to be listed:\n%s", expected, out.String())
+		}
+	}
+	// the names of the tokens are the tokens themselves
+	for _, name := range []string{"expired-code", "deleted-user", "recreated-user"} {
+		if strings.Contains(out.String(), name) {
+			t.Errorf("expected the token %q not to be printed:\n%s", name, out.String())
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package prune
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+const PruneUsersRecommendedName = "users"
+
+const (
+	usersLongDesc = `Remove users and everything that grants them access
+
+Deprovisioning a user removes, in one pass, the OAuth access tokens and client
+authorizations of the user, the user from the subjects of every role binding and
+cluster role binding and from the members of every group, the identities that map
+to the user, and finally the user itself. Tokens are removed first so that the user
+loses access to the server before anything else is changed.
+
+By default, the prune operation performs a dry run making no changes to the server.
+A --confirm flag is needed for changes to be effective.`
+
+	usersExample = `  # Dry run listing what deprovisioning the user alice would remove
+  $ %[1]s %[2]s alice
+
+  # To actually deprovision the users, the confirm flag must be appended
+  $ %[1]s %[2]s alice bob --confirm`
+)
+
+type pruneUsersOptions struct {
+	Users   []string
+	Confirm bool
+
+	Client client.Interface
+
+	Out io.Writer
+	Err io.Writer
+}
+
+func NewCmdPruneUsers(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
+	options := &pruneUsersOptions{Out: out, Err: os.Stderr}
+
+	cmd := &cobra.Command{
+		Use:     name + " USER [USER ...]",
+		Short:   "Remove users with their identities, tokens and bindings",
+		Long:    usersLongDesc,
+		Example: fmt.Sprintf(usersExample, parentName, name),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "%v", err))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Confirm, "confirm", options.Confirm, "Specify that the users should be removed. Defaults to false, displaying what would be removed but not actually removing anything.")
+
+	return cmd
+}
+
+func (o *pruneUsersOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must specify at least one user to remove")
+	}
+	o.Users = args
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient
+	return nil
+}
+
+// Run removes every user in turn, listing what is removed
+func (o *pruneUsersOptions) Run() error {
+	if !o.Confirm {
+		fmt.Fprintln(o.Err, "Dry run enabled - no modifications will be made. Add --confirm to remove users")
+	}
+
+	w := tabwriter.NewWriter(o.Out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "USER\tRESOURCE\tNAME")
+	for _, username := range o.Users {
+		if err := o.pruneUser(w, username); err != nil {
+			return fmt.Errorf("unable to remove user %s: %v", username, err)
+		}
+	}
+	return nil
+}
+
+// pruneUser removes everything that grants username access, then the user. The user is removed last so that
+// running the command again finishes a deprovisioning that failed half way.
+func (o *pruneUsersOptions) pruneUser(w io.Writer, username string) error {
+	byUserName := fields.OneTermEqualSelector("userName", username)
+
+	tokens, err := o.Client.OAuthAccessTokens().List(labels.Everything(), byUserName)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens.Items {
+		fmt.Fprintf(w, "%s\toauthaccesstokens\t%s\n", username, token.Name)
+		if o.Confirm {
+			if err := o.Client.OAuthAccessTokens().Delete(token.Name); err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	authorizations, err := o.Client.OAuthClientAuthorizations().List(labels.Everything(), byUserName)
+	if err != nil {
+		return err
+	}
+	for _, authorization := range authorizations.Items {
+		fmt.Fprintf(w, "%s\toauthclientauthorizations\t%s\n", username, authorization.Name)
+		if o.Confirm {
+			if err := o.Client.OAuthClientAuthorizations().Delete(authorization.Name); err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	roleBindings, err := o.Client.RoleBindings(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	for i := range roleBindings.Items {
+		binding := &roleBindings.Items[i]
+		subjects, removed := removeUserSubject(binding.Subjects, username)
+		if !removed {
+			continue
+		}
+		fmt.Fprintf(w, "%s\trolebindings\t%s/%s\n", username, binding.Namespace, binding.Name)
+		if o.Confirm {
+			binding.Subjects = subjects
+			if _, err := o.Client.RoleBindings(binding.Namespace).Update(binding); err != nil {
+				return err
+			}
+		}
+	}
+
+	clusterRoleBindings, err := o.Client.ClusterRoleBindings().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	for i := range clusterRoleBindings.Items {
+		binding := &clusterRoleBindings.Items[i]
+		subjects, removed := removeUserSubject(binding.Subjects, username)
+		if !removed {
+			continue
+		}
+		fmt.Fprintf(w, "%s\tclusterrolebindings\t%s\n", username, binding.Name)
+		if o.Confirm {
+			binding.Subjects = subjects
+			if _, err := o.Client.ClusterRoleBindings().Update(binding); err != nil {
+				return err
+			}
+		}
+	}
+
+	groups, err := o.Client.Groups().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	for i := range groups.Items {
+		group := &groups.Items[i]
+		members := sets.NewString(group.Users...)
+		if !members.Has(username) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\tgroups\t%s\n", username, group.Name)
+		if o.Confirm {
+			members.Delete(username)
+			group.Users = members.List()
+			if _, err := o.Client.Groups().Update(group); err != nil {
+				return err
+			}
+		}
+	}
+
+	user, err := o.Client.Users().Get(username)
+	if kerrors.IsNotFound(err) {
+		user = nil
+	} else if err != nil {
+		return err
+	}
+	identities, err := o.identitiesOf(username, user)
+	if err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		fmt.Fprintf(w, "%s\tidentities\t%s\n", username, identity)
+		if o.Confirm {
+			if err := o.Client.Identities().Delete(identity); err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	if user == nil {
+		return nil
+	}
+	fmt.Fprintf(w, "%s\tusers\t%s\n", username, username)
+	if o.Confirm {
+		if err := o.Client.Users().Delete(username); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// identitiesOf returns the names of the identities listed by user and of the identities that map to username,
+// which may not be listed if a provisioning failed half way
+func (o *pruneUsersOptions) identitiesOf(username string, user *userapi.User) ([]string, error) {
+	names := sets.NewString()
+	if user != nil {
+		names.Insert(user.Identities...)
+	}
+	identities, err := o.Client.Identities().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities.Items {
+		if identity.User.Name == username {
+			names.Insert(identity.Name)
+		}
+	}
+	return names.List(), nil
+}
+
+// removeUserSubject returns subjects without the user named username, and whether it was a subject
+func removeUserSubject(subjects []kapi.ObjectReference, username string) ([]kapi.ObjectReference, bool) {
+	kept := []kapi.ObjectReference{}
+	removed := false
+	for _, subject := range subjects {
+		if subject.Kind == authorizationapi.UserKind && subject.Name == username {
+			removed = true
+			continue
+		}
+		kept = append(kept, subject)
+	}
+	return kept, removed
+}
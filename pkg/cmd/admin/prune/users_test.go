@@ -0,0 +1,147 @@
+package prune
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+func newPruneUsersClient() *testclient.Fake {
+	return testclient.NewSimpleFake(
+		&oauthapi.OAuthAccessTokenList{Items: []oauthapi.OAuthAccessToken{
+			{ObjectMeta: kapi.ObjectMeta{Name: "token"}, UserName: "alice"},
+		}},
+		&oauthapi.OAuthClientAuthorizationList{Items: []oauthapi.OAuthClientAuthorization{
+			{ObjectMeta: kapi.ObjectMeta{Name: "alice:openshift-web-console"}, UserName: "alice"},
+		}},
+		&authorizationapi.RoleBindingList{Items: []authorizationapi.RoleBinding{
+			{
+				ObjectMeta: kapi.ObjectMeta{Name: "admin", Namespace: "alice-project"},
+				Subjects:   []kapi.ObjectReference{{Kind: authorizationapi.UserKind, Name: "alice"}, {Kind: authorizationapi.UserKind, Name: "bob"}},
+			},
+			{
+				ObjectMeta: kapi.ObjectMeta{Name: "view", Namespace: "bob-project"},
+				Subjects:   []kapi.ObjectReference{{Kind: authorizationapi.UserKind, Name: "bob"}, {Kind: authorizationapi.GroupKind, Name: "alice"}},
+			},
+		}},
+		&authorizationapi.ClusterRoleBindingList{Items: []authorizationapi.ClusterRoleBinding{
+			{
+				ObjectMeta: kapi.ObjectMeta{Name: "cluster-admins"},
+				Subjects:   []kapi.ObjectReference{{Kind: authorizationapi.UserKind, Name: "alice"}},
+			},
+		}},
+		&userapi.GroupList{Items: []userapi.Group{
+			{ObjectMeta: kapi.ObjectMeta{Name: "developers"}, Users: []string{"alice", "bob"}},
+			{ObjectMeta: kapi.ObjectMeta{Name: "testers"}, Users: []string{"bob"}},
+		}},
+		&userapi.IdentityList{Items: []userapi.Identity{
+			{ObjectMeta: kapi.ObjectMeta{Name: "github:alice"}, User: kapi.ObjectReference{Name: "alice"}},
+			{ObjectMeta: kapi.ObjectMeta{Name: "github:bob"}, User: kapi.ObjectReference{Name: "bob"}},
+		}},
+		&userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "alice"}, Identities: []string{"ldap:alice"}},
+	)
+}
+
+func TestPruneUsersDryRun(t *testing.T) {
+	client := newPruneUsersClient()
+	out := &bytes.Buffer{}
+	o := &pruneUsersOptions{Users: []string{"alice"}, Client: client, Out: out, Err: ioutil.Discard}
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, action := range client.Actions() {
+		if verb := action.GetVerb(); verb != "list" && verb != "get" {
+			t.Errorf("expected no modifications in a dry run, got %#v", action)
+		}
+	}
+	for _, expected := range []string{
+		"oauthaccesstokens", "token",
+		"oauthclientauthorizations", "alice:openshift-web-console",
+		"rolebindings", "alice-project/admin",
+		"clusterrolebindings", "cluster-admins",
+		"groups", "developers",
+		"identities", "github:alice", "ldap:alice",
+		"users",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q to be listed:\n%s", expected, out.String())
+		}
+	}
+	for _, unexpected := range []string{"bob-project", "testers", "github:bob"} {
+		if strings.Contains(out.String(), unexpected) {
+			t.Errorf("expected %q not to be listed:\n%s", unexpected, out.String())
+		}
+	}
+}
+
+func TestPruneUsersConfirm(t *testing.T) {
+	client := newPruneUsersClient()
+	o := &pruneUsersOptions{Users: []string{"alice"}, Confirm: true, Client: client, Out: ioutil.Discard, Err: ioutil.Discard}
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deleted := []string{}
+	updated := map[string]interface{}{}
+	for _, action := range client.Actions() {
+		switch a := action.(type) {
+		case ktestclient.DeleteAction:
+			if a.GetVerb() == "delete" {
+				deleted = append(deleted, a.GetResource()+"/"+a.GetName())
+			}
+		case ktestclient.UpdateAction:
+			switch obj := a.GetObject().(type) {
+			case *authorizationapi.RoleBinding:
+				updated["rolebindings/"+obj.Name] = obj.Subjects
+			case *authorizationapi.ClusterRoleBinding:
+				updated["clusterrolebindings/"+obj.Name] = obj.Subjects
+			case *userapi.Group:
+				updated["groups/"+obj.Name] = obj.Users
+			}
+		}
+	}
+
+	// tokens are removed first, the user last
+	expectedDeleted := []string{
+		"oauthaccesstokens/token",
+		"oauthclientauthorizations/alice:openshift-web-console",
+		"identities/github:alice",
+		"identities/ldap:alice",
+		"users/alice",
+	}
+	if !reflect.DeepEqual(expectedDeleted, deleted) {
+		t.Errorf("expected %v to be deleted, got %v", expectedDeleted, deleted)
+	}
+	expectedUpdated := map[string]interface{}{
+		"rolebindings/admin":                 []kapi.ObjectReference{{Kind: authorizationapi.UserKind, Name: "bob"}},
+		"clusterrolebindings/cluster-admins": []kapi.ObjectReference{},
+		"groups/developers":                  []string{"bob"},
+	}
+	if !reflect.DeepEqual(expectedUpdated, updated) {
+		t.Errorf("expected %v to be updated, got %v", expectedUpdated, updated)
+	}
+}
+
+func TestPruneUsersMissingUser(t *testing.T) {
+	client := testclient.NewSimpleFake()
+	o := &pruneUsersOptions{Users: []string{"alice"}, Confirm: true, Client: client, Out: ioutil.Discard, Err: ioutil.Discard}
+	if err := o.Run(); err != nil {
+		t.Fatalf("expected a user that no longer exists to be ignored, got %v", err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "delete" {
+			t.Errorf("expected nothing to be deleted, got %#v", action)
+		}
+	}
+}
@@ -91,9 +91,10 @@ const (
 	healthzRouteTimeoutSeconds = 5
 )
 
-// NewCmdRegistry implements the OpenShift cli registry command
-func NewCmdRegistry(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
-	cfg := &RegistryConfig{
+// NewRegistryConfig returns the default configuration of the registry
+func NewRegistryConfig() *RegistryConfig {
+	return &RegistryConfig{
+		Type:          "docker-registry",
 		ImageTemplate: variable.NewDefaultImageTemplate(),
 
 		Labels:   defaultLabel,
@@ -101,6 +102,11 @@ func NewCmdRegistry(f *clientcmd.Factory, parentName, name string, out io.Writer
 		Volume:   "/registry",
 		Replicas: 1,
 	}
+}
+
+// NewCmdRegistry implements the OpenShift cli registry command
+func NewCmdRegistry(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
+	cfg := NewRegistryConfig()
 
 	cmd := &cobra.Command{
 		Use:     name,
@@ -117,7 +123,7 @@ func NewCmdRegistry(f *clientcmd.Factory, parentName, name string, out io.Writer
 		},
 	}
 
-	cmd.Flags().StringVar(&cfg.Type, "type", "docker-registry", "The registry image to use - if you specify --images this flag may be ignored.")
+	cmd.Flags().StringVar(&cfg.Type, "type", cfg.Type, "The registry image to use - if you specify --images this flag may be ignored.")
 	cmd.Flags().StringVar(&cfg.ImageTemplate.Format, "images", cfg.ImageTemplate.Format, "The image to base this registry on - ${component} will be replaced with --type")
 	cmd.Flags().BoolVar(&cfg.ImageTemplate.Latest, "latest-images", cfg.ImageTemplate.Latest, "If true, attempt to use the latest image for the registry instead of the latest release.")
 	cmd.Flags().StringVar(&cfg.Ports, "ports", cfg.Ports, fmt.Sprintf("A comma delimited list of ports or port pairs to expose on the registry pod. The default is set for %d.", defaultPort))
@@ -180,9 +180,10 @@ const (
 	defaultHealthzPort = defaultStatsPort
 )
 
-// NewCmdRouter implements the OpenShift CLI router command.
-func NewCmdRouter(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
-	cfg := &RouterConfig{
+// NewRouterConfig returns the default configuration of the router
+func NewRouterConfig() *RouterConfig {
+	return &RouterConfig{
+		Type:          "haproxy-router",
 		ImageTemplate: variable.NewDefaultImageTemplate(),
 
 		Labels:   defaultLabel,
@@ -193,6 +194,11 @@ func NewCmdRouter(f *clientcmd.Factory, parentName, name string, out io.Writer)
 		StatsPort:     defaultStatsPort,
 		HostNetwork:   true,
 	}
+}
+
+// NewCmdRouter implements the OpenShift CLI router command.
+func NewCmdRouter(f *clientcmd.Factory, parentName, name string, out io.Writer) *cobra.Command {
+	cfg := NewRouterConfig()
 
 	cmd := &cobra.Command{
 		Use:     fmt.Sprintf("%s [NAME]", name),
@@ -209,7 +215,7 @@ func NewCmdRouter(f *clientcmd.Factory, parentName, name string, out io.Writer)
 		},
 	}
 
-	cmd.Flags().StringVar(&cfg.Type, "type", "haproxy-router", "The type of router to use - if you specify --images this flag may be ignored.")
+	cmd.Flags().StringVar(&cfg.Type, "type", cfg.Type, "The type of router to use - if you specify --images this flag may be ignored.")
 	cmd.Flags().StringVar(&cfg.ImageTemplate.Format, "images", cfg.ImageTemplate.Format, "The image to base this router on - ${component} will be replaced with --type")
 	cmd.Flags().BoolVar(&cfg.ImageTemplate.Latest, "latest-images", cfg.ImageTemplate.Latest, "If true, attempt to use the latest images for the router instead of the latest release.")
 	cmd.Flags().StringVar(&cfg.Ports, "ports", cfg.Ports, "A comma delimited list of ports or port pairs to expose on the router pod. The default is set for HAProxy.")
@@ -0,0 +1,65 @@
+package create
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+// AddCreateFlags adds the flags shared by the commands generating a resource
+func AddCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "If true, only print the object that would be created, without creating it.")
+	kcmdutil.AddPrinterFlags(cmd)
+}
+
+// ObjectPrinter prints the objects generated by a create command
+type ObjectPrinter struct {
+	// DryRun is true if the object is only printed and not created
+	DryRun bool
+	Mapper meta.RESTMapper
+	Out    io.Writer
+
+	// printObject prints the object with the format of --output, nil if the output is the name of the object or
+	// the default message
+	printObject func(obj runtime.Object) error
+	shortOutput bool
+}
+
+// NewObjectPrinter returns a printer for the flags added by AddCreateFlags to cmd
+func NewObjectPrinter(cmd *cobra.Command, f *clientcmd.Factory, out io.Writer) *ObjectPrinter {
+	mapper, _ := f.Object()
+	p := &ObjectPrinter{
+		DryRun: kcmdutil.GetFlagBool(cmd, "dry-run"),
+		Mapper: mapper,
+		Out:    out,
+	}
+	switch output := kcmdutil.GetFlagString(cmd, "output"); output {
+	case "":
+	case "name":
+		p.shortOutput = true
+	default:
+		p.printObject = func(obj runtime.Object) error {
+			return f.PrintObject(cmd, obj, out)
+		}
+	}
+	return p
+}
+
+// Print prints obj with the format of --output, or a message naming the resource created
+func (p *ObjectPrinter) Print(obj runtime.Object, resource, name string) error {
+	if p.printObject != nil {
+		return p.printObject(obj)
+	}
+	operation := "created"
+	if p.DryRun {
+		operation = "created (dry run)"
+	}
+	kcmdutil.PrintSuccess(p.Mapper, p.shortOutput, p.Out, resource, name, operation)
+	return nil
+}
@@ -0,0 +1,184 @@
+package create
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/client"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/generate/app"
+)
+
+// DeploymentConfigRecommendedName is the recommended command name
+const DeploymentConfigRecommendedName = "deploymentconfig"
+
+const (
+	deploymentConfigLong = `
+Create a deployment config that uses a given image
+
+Deployment configs define the template for a pod and manages deploying new images or configuration changes.
+The deployment config created runs a single container with the given image, and is deployed again whenever
+it is changed.`
+
+	deploymentConfigExample = `  # Create an nginx deployment config named my-nginx
+  $ %[1]s my-nginx --image=nginx
+
+  # Create a deployment config running three replicas of a web server listening on port 8080
+  $ %[1]s web --image=openshift/hello-openshift --replicas=3 --port=8080 --env=RESPONSE=hello`
+)
+
+// CreateDeploymentConfigOptions contains the options to create a minimal deployment config
+type CreateDeploymentConfigOptions struct {
+	DC     *deployapi.DeploymentConfig
+	Client client.DeploymentConfigsNamespacer
+
+	Printer *ObjectPrinter
+}
+
+// NewCmdCreateDeploymentConfig is a command to create a new deployment config
+func NewCmdCreateDeploymentConfig(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	o := &CreateDeploymentConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:     name + " NAME --image=IMAGE [--replicas=N] [--port=PORT[/PROTOCOL]] [--env=KEY=VALUE]",
+		Short:   "Create deployment config with default options that uses a given image.",
+		Long:    deploymentConfigLong,
+		Example: fmt.Sprintf(deploymentConfigExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(o.Complete(cmd, f, args, out))
+			kcmdutil.CheckErr(o.Validate())
+			kcmdutil.CheckErr(o.Run())
+		},
+		Aliases: []string{"dc"},
+	}
+
+	cmd.Flags().String("image", "", "The image for the container to run.")
+	cmd.MarkFlagRequired("image")
+	cmd.Flags().Int("replicas", 1, "The number of replicas of the pod to run.")
+	cmd.Flags().StringSlice("port", []string{}, "A port the container exposes, as PORT[/PROTOCOL]. May be repeated.")
+	cmd.Flags().StringSliceP("env", "e", []string{}, "An environment variable to set in the container, as KEY=VALUE. May be repeated.")
+	AddCreateFlags(cmd)
+	return cmd
+}
+
+// Complete builds the deployment config named by args from the flags of cmd
+func (o *CreateDeploymentConfigOptions) Complete(cmd *cobra.Command, f *clientcmd.Factory, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return kcmdutil.UsageError(cmd, "NAME is required: %v", args)
+	}
+	image := kcmdutil.GetFlagString(cmd, "image")
+	if len(image) == 0 {
+		return kcmdutil.UsageError(cmd, "--image is required")
+	}
+	replicas := kcmdutil.GetFlagInt(cmd, "replicas")
+	if replicas < 0 {
+		return kcmdutil.UsageError(cmd, "--replicas must not be negative")
+	}
+	ports, err := ParseContainerPorts(kcmdutil.GetFlagStringSlice(cmd, "port"))
+	if err != nil {
+		return kcmdutil.UsageError(cmd, "%v", err)
+	}
+	env, _, errs := cmdutil.ParseEnvironmentArguments(kcmdutil.GetFlagStringSlice(cmd, "env"))
+	if len(errs) > 0 {
+		return kcmdutil.UsageError(cmd, "%v", kerrors.NewAggregate(errs))
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	o.DC = NewDeploymentConfig(args[0], image, replicas, ports, app.Environment(env).List())
+	o.DC.Namespace = namespace
+
+	if o.Client, _, err = f.Clients(); err != nil {
+		return err
+	}
+	o.Printer = NewObjectPrinter(cmd, f, out)
+	return nil
+}
+
+// Validate checks that the options are complete
+func (o *CreateDeploymentConfigOptions) Validate() error {
+	if o.DC == nil {
+		return fmt.Errorf("DC is required")
+	}
+	if o.Client == nil {
+		return fmt.Errorf("Client is required")
+	}
+	if o.Printer == nil {
+		return fmt.Errorf("Printer is required")
+	}
+	return nil
+}
+
+// Run creates the deployment config, unless it is a dry run, and prints it
+func (o *CreateDeploymentConfigOptions) Run() error {
+	actualObj := o.DC
+	if !o.Printer.DryRun {
+		var err error
+		if actualObj, err = o.Client.DeploymentConfigs(o.DC.Namespace).Create(o.DC); err != nil {
+			return err
+		}
+	}
+	return o.Printer.Print(actualObj, "deploymentconfigs", actualObj.Name)
+}
+
+// NewDeploymentConfig returns a deployment config running replicas of a pod with a single container running image,
+// which is deployed again whenever the deployment config changes
+func NewDeploymentConfig(name, image string, replicas int, ports []kapi.ContainerPort, env []kapi.EnvVar) *deployapi.DeploymentConfig {
+	labels := map[string]string{"deploymentconfig": name}
+	return &deployapi.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: name},
+		Spec: deployapi.DeploymentConfigSpec{
+			Replicas: replicas,
+			Selector: labels,
+			Template: &kapi.PodTemplateSpec{
+				ObjectMeta: kapi.ObjectMeta{Labels: labels},
+				Spec: kapi.PodSpec{
+					Containers: []kapi.Container{
+						{
+							Name:  name,
+							Image: image,
+							Ports: ports,
+							Env:   env,
+						},
+					},
+				},
+			},
+			Triggers: []deployapi.DeploymentTriggerPolicy{
+				{Type: deployapi.DeploymentTriggerOnConfigChange},
+			},
+		},
+	}
+}
+
+// ParseContainerPorts parses ports of the form PORT[/PROTOCOL], where the protocol is TCP or UDP
+func ParseContainerPorts(specs []string) ([]kapi.ContainerPort, error) {
+	ports := []kapi.ContainerPort{}
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "/", 2)
+		port, err := strconv.Atoi(parts[0])
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("%q is not a valid port number", spec)
+		}
+		protocol := kapi.ProtocolTCP
+		if len(parts) == 2 {
+			protocol = kapi.Protocol(strings.ToUpper(parts[1]))
+			if protocol != kapi.ProtocolTCP && protocol != kapi.ProtocolUDP {
+				return nil, fmt.Errorf("%q must use the TCP or UDP protocol", spec)
+			}
+		}
+		ports = append(ports, kapi.ContainerPort{ContainerPort: port, Protocol: protocol})
+	}
+	return ports, nil
+}
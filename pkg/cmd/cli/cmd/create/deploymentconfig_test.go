@@ -0,0 +1,72 @@
+package create
+
+import (
+	"bytes"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/client/testclient"
+)
+
+func TestParseContainerPorts(t *testing.T) {
+	ports, err := ParseContainerPorts([]string{"8080", "53/udp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []kapi.ContainerPort{{ContainerPort: 8080, Protocol: kapi.ProtocolTCP}, {ContainerPort: 53, Protocol: kapi.ProtocolUDP}}
+	if !kapi.Semantic.DeepEqual(ports, expected) {
+		t.Errorf("expected %#v, got %#v", expected, ports)
+	}
+
+	for _, spec := range []string{"", "http", "0", "65536", "80/sctp"} {
+		if _, err := ParseContainerPorts([]string{spec}); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestCreateDeploymentConfig(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		client := testclient.NewSimpleFake()
+		client.PrependReactor("create", "deploymentconfigs", func(action ktestclient.Action) (bool, runtime.Object, error) {
+			return true, action.(ktestclient.CreateAction).GetObject(), nil
+		})
+		out := &bytes.Buffer{}
+		dc := NewDeploymentConfig("web", "nginx", 2, nil, []kapi.EnvVar{{Name: "A", Value: "b"}})
+		dc.Namespace = "project"
+		o := &CreateDeploymentConfigOptions{
+			DC:      dc,
+			Client:  client,
+			Printer: &ObjectPrinter{DryRun: dryRun, Mapper: latest.RESTMapper, Out: out},
+		}
+		if err := o.Validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := o.Run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		actions := client.Actions()
+		if dryRun && len(actions) != 0 {
+			t.Errorf("expected a dry run to leave the server untouched, got %v", actions)
+		}
+		if !dryRun && (len(actions) != 1 || !actions[0].Matches("create", "deploymentconfigs")) {
+			t.Errorf("expected the deployment config to be created, got %v", actions)
+		}
+		if len(out.String()) == 0 {
+			t.Errorf("expected a message about the deployment config")
+		}
+	}
+
+	dc := NewDeploymentConfig("web", "nginx", 2, nil, nil)
+	if dc.Spec.Replicas != 2 || dc.Spec.Template.Spec.Containers[0].Image != "nginx" {
+		t.Errorf("unexpected deployment config %#v", dc)
+	}
+	if dc.Spec.Selector["deploymentconfig"] != "web" || dc.Spec.Template.Labels["deploymentconfig"] != "web" {
+		t.Errorf("expected the pods of the deployment config to be selected by its name, got %#v", dc.Spec)
+	}
+}
@@ -0,0 +1,110 @@
+package create
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// ImageStreamRecommendedName is the recommended command name
+const ImageStreamRecommendedName = "imagestream"
+
+const (
+	imageStreamLong = `
+Create a new image stream
+
+Image streams allow you to track, tag, and import images from other registries. They also define an
+access controlled destination that you can push images to. An image stream can reference images
+from many different registries and control how those images are referenced by pods, deployments,
+and builds.`
+
+	imageStreamExample = `  # Create a new image stream
+  $ %[1]s mysql
+
+  # Show the image stream that would be created, without creating it
+  $ %[1]s mysql --dry-run -o yaml`
+)
+
+// CreateImageStreamOptions contains the options to create an empty image stream
+type CreateImageStreamOptions struct {
+	IS     *imageapi.ImageStream
+	Client client.ImageStreamsNamespacer
+
+	Printer *ObjectPrinter
+}
+
+// NewCmdCreateImageStream is a command to create a new image stream
+func NewCmdCreateImageStream(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	o := &CreateImageStreamOptions{}
+
+	cmd := &cobra.Command{
+		Use:     name + " NAME",
+		Short:   "Create a new empty image stream",
+		Long:    imageStreamLong,
+		Example: fmt.Sprintf(imageStreamExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(o.Complete(cmd, f, args, out))
+			kcmdutil.CheckErr(o.Validate())
+			kcmdutil.CheckErr(o.Run())
+		},
+		Aliases: []string{"is"},
+	}
+
+	AddCreateFlags(cmd)
+	return cmd
+}
+
+// Complete builds the image stream named by args
+func (o *CreateImageStreamOptions) Complete(cmd *cobra.Command, f *clientcmd.Factory, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return kcmdutil.UsageError(cmd, "NAME is required: %v", args)
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	o.IS = &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Name: args[0], Namespace: namespace},
+	}
+
+	if o.Client, _, err = f.Clients(); err != nil {
+		return err
+	}
+	o.Printer = NewObjectPrinter(cmd, f, out)
+	return nil
+}
+
+// Validate checks that the options are complete
+func (o *CreateImageStreamOptions) Validate() error {
+	if o.IS == nil {
+		return fmt.Errorf("IS is required")
+	}
+	if o.Client == nil {
+		return fmt.Errorf("Client is required")
+	}
+	if o.Printer == nil {
+		return fmt.Errorf("Printer is required")
+	}
+	return nil
+}
+
+// Run creates the image stream, unless it is a dry run, and prints it
+func (o *CreateImageStreamOptions) Run() error {
+	actualObj := o.IS
+	if !o.Printer.DryRun {
+		var err error
+		if actualObj, err = o.Client.ImageStreams(o.IS.Namespace).Create(o.IS); err != nil {
+			return err
+		}
+	}
+	return o.Printer.Print(actualObj, "imagestreams", actualObj.Name)
+}
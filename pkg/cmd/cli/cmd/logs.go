@@ -4,17 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/spf13/cobra"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/fields"
 	kcmd "k8s.io/kubernetes/pkg/kubectl/cmd"
 	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 )
@@ -30,11 +36,19 @@ Print the logs for a resource.
 Supported resources are builds, build configs (bc), deployment configs (dc), and pods.
 When a pod is specified and has more than one container, the container name should be
 specified via -c. When a build config or deployment config is specified, you can view
-the logs for a particular version of it via --version.`
+the logs for a particular version of it via --version, or the logs of the version
+prior to the latest one via --previous.
+
+When following the logs of a build config with --wait, the command waits for a build
+to start if the build config has none, and switches to each new build of the build
+config as it starts until it is interrupted.`
 
 	logsExample = `  # Start streaming the logs of the most recent build of the openldap build config.
   $ %[1]s -f bc/openldap
 
+  # Stream the logs of every new build of the openldap build config as it starts.
+  $ %[1]s -f --wait bc/openldap
+
   # Start streaming the logs of the latest deployment of the mysql deployment config.
   $ %[1]s -f dc/mysql
 
@@ -43,6 +57,10 @@ the logs for a particular version of it via --version.`
   # or due to deployment pruning or manual deletion of the deployment.
   $ %[1]s --version=1 dc/mysql
 
+  # Get the logs of the deployment prior to the latest one for the mysql deployment config,
+  # for example to inspect the deployer pod logs of a failed rollout.
+  $ %[1]s --previous dc/mysql
+
   # Return a snapshot of ruby-container logs from pod backend.
   $ %[1]s backend -c ruby-container
 
@@ -57,6 +75,17 @@ type OpenShiftLogsOptions struct {
 	// KubeLogOptions contains all the necessary options for
 	// running the upstream logs command.
 	KubeLogOptions *kcmd.LogsOptions
+
+	// Wait follows the logs of the new builds of BuildConfigName as they start.
+	Wait bool
+	// BuildConfigName is the name of the build config whose builds are followed with Wait.
+	BuildConfigName string
+	// Builds lists and watches the builds in the namespace of BuildConfigName.
+	Builds client.BuildInterface
+	// BuildLogs streams the logs of a build.
+	BuildLogs func(build *buildapi.Build, opts buildapi.BuildLogOptions) (io.ReadCloser, error)
+	// Out is where the logs of the builds are written.
+	Out io.Writer
 }
 
 // NewCmdLogs creates a new logs command that supports OpenShift resources.
@@ -77,6 +106,7 @@ func NewCmdLogs(name, parent string, f *clientcmd.Factory, out io.Writer) *cobra
 		cmdutil.CheckErr(o.RunLog())
 	}
 	cmd.Flags().Int64("version", 0, "View the logs of a particular build or deployment by version if greater than zero")
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "When following a build config, wait for a build to start and switch to each new build as it starts")
 
 	return cmd
 }
@@ -116,6 +146,7 @@ func (o *OpenShiftLogsOptions) Complete(f *clientcmd.Factory, out io.Writer, cmd
 	case "build", "buildconfig":
 		bopts := &buildapi.BuildLogOptions{
 			Follow:       podLogOptions.Follow,
+			Previous:     podLogOptions.Previous,
 			SinceSeconds: podLogOptions.SinceSeconds,
 			SinceTime:    podLogOptions.SinceTime,
 			Timestamps:   podLogOptions.Timestamps,
@@ -126,9 +157,22 @@ func (o *OpenShiftLogsOptions) Complete(f *clientcmd.Factory, out io.Writer, cmd
 			bopts.Version = &version
 		}
 		o.Options = bopts
+		if o.Wait && resource == "buildconfig" {
+			oc, _, err := f.Clients()
+			if err != nil {
+				return err
+			}
+			o.BuildConfigName = infos[0].Name
+			o.Builds = oc.Builds(infos[0].Namespace)
+			o.BuildLogs = func(build *buildapi.Build, opts buildapi.BuildLogOptions) (io.ReadCloser, error) {
+				return oc.BuildLogs(build.Namespace).Get(build.Name, opts).Stream()
+			}
+			o.Out = out
+		}
 	case "deploymentconfig":
 		dopts := &deployapi.DeploymentLogOptions{
 			Follow:       podLogOptions.Follow,
+			Previous:     podLogOptions.Previous,
 			SinceSeconds: podLogOptions.SinceSeconds,
 			SinceTime:    podLogOptions.SinceTime,
 			Timestamps:   podLogOptions.Timestamps,
@@ -152,16 +196,36 @@ func (o OpenShiftLogsOptions) Validate() error {
 	if err := o.KubeLogOptions.Validate(); err != nil {
 		return err
 	}
-	if o.Options == nil {
-		return nil
+	if o.Wait {
+		t, ok := o.Options.(*buildapi.BuildLogOptions)
+		switch {
+		case !ok || len(o.BuildConfigName) == 0:
+			return errors.New("--wait can only be used with a build config")
+		case !t.Follow:
+			return errors.New("--wait can only be used with --follow")
+		case t.Previous || t.Version != nil:
+			return errors.New("cannot use --wait with --previous or --version")
+		}
+	}
+	switch t := o.Options.(type) {
+	case *buildapi.BuildLogOptions:
+		if t.Previous && t.Version != nil {
+			return errors.New("cannot use both --previous and --version")
+		}
+	case *deployapi.DeploymentLogOptions:
+		if t.Previous && t.Version != nil {
+			return errors.New("cannot use both --previous and --version")
+		}
 	}
-	// TODO: Validate our own options.
 	return nil
 }
 
 // RunLog will run the upstream logs command and may use an OpenShift
 // logOptions object.
 func (o OpenShiftLogsOptions) RunLog() error {
+	if o.Wait {
+		return o.followBuildConfig()
+	}
 	if o.Options != nil {
 		// Use our own options object.
 		o.KubeLogOptions.Options = o.Options
@@ -169,3 +233,105 @@ func (o OpenShiftLogsOptions) RunLog() error {
 	_, err := o.KubeLogOptions.RunLog()
 	return err
 }
+
+// followBuildConfig streams the logs of the latest build of a build config, waiting for a build to start if the
+// build config has none, and switches to every new build of the build config as it starts until it is interrupted.
+func (o OpenShiftLogsOptions) followBuildConfig() error {
+	list, err := o.Builds.List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	resourceVersion := list.ResourceVersion
+	var next *buildapi.Build
+	if builds := buildapi.FilterBuilds(list.Items, buildapi.ByBuildConfigLabelPredicate(o.BuildConfigName)); len(builds) > 0 {
+		sort.Sort(sort.Reverse(buildapi.BuildSliceByCreationTimestamp(builds)))
+		next = &builds[0]
+	}
+
+	w, err := o.Builds.Watch(labels.Everything(), fields.Everything(), resourceVersion)
+	if err != nil {
+		return err
+	}
+	defer func() { w.Stop() }()
+
+	var current *buildLogStream
+	defer func() {
+		if current != nil {
+			current.stop()
+		}
+	}()
+	for {
+		if next != nil {
+			if current != nil {
+				current.stop()
+				fmt.Fprintf(o.Out, "--> Switching to the logs of build %s\n", next.Name)
+			}
+			current = o.streamBuildLogs(next)
+			next = nil
+		}
+
+		var done <-chan error
+		if current != nil {
+			done = current.done
+		}
+		select {
+		case err := <-done:
+			if err != nil {
+				fmt.Fprintf(o.Out, "--> Unable to stream the logs of build %s: %v\n", current.build.Name, err)
+			}
+			current = nil
+
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				// the server ends watches after a while, resume from the last build seen
+				if w, err = o.Builds.Watch(labels.Everything(), fields.Everything(), resourceVersion); err != nil {
+					return err
+				}
+				continue
+			}
+			if event.Type == watch.Error {
+				return kerrors.FromObject(event.Object)
+			}
+			build, ok := event.Object.(*buildapi.Build)
+			if !ok {
+				continue
+			}
+			resourceVersion = build.ResourceVersion
+			if event.Type == watch.Added && len(buildapi.FilterBuilds([]buildapi.Build{*build}, buildapi.ByBuildConfigLabelPredicate(o.BuildConfigName))) > 0 {
+				next = build
+			}
+		}
+	}
+}
+
+// buildLogStream copies the logs of a build to the output in the background
+type buildLogStream struct {
+	build  *buildapi.Build
+	reader io.ReadCloser
+	done   chan error
+}
+
+// streamBuildLogs starts copying the logs of build to the output. The done channel of the returned stream receives
+// the result of the copy.
+func (o OpenShiftLogsOptions) streamBuildLogs(build *buildapi.Build) *buildLogStream {
+	s := &buildLogStream{build: build, done: make(chan error, 1)}
+	reader, err := o.BuildLogs(build, *o.Options.(*buildapi.BuildLogOptions))
+	if err != nil {
+		s.done <- err
+		return s
+	}
+	s.reader = reader
+	go func() {
+		_, err := io.Copy(o.Out, reader)
+		s.done <- err
+	}()
+	return s
+}
+
+// stop interrupts the copy of the logs and waits for it to end
+func (s *buildLogStream) stop() {
+	if s.reader != nil {
+		s.reader.Close()
+		<-s.done
+	}
+}
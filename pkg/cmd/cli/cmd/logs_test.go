@@ -1,15 +1,26 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
 	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/spf13/pflag"
 
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	kcmd "k8s.io/kubernetes/pkg/kubectl/cmd"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
 
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
 )
 
 // TestFlagParity makes sure that our copied flags don't slip during rebases
@@ -30,3 +41,87 @@ func TestFlagParity(t *testing.T) {
 		}
 	})
 }
+
+func TestFollowBuildConfig(t *testing.T) {
+	build := func(name, config string, created int64) *buildapi.Build {
+		return &buildapi.Build{ObjectMeta: kapi.ObjectMeta{
+			Name:              name,
+			Namespace:         "test",
+			Labels:            map[string]string{buildapi.BuildConfigLabel: config},
+			CreationTimestamp: unversioned.Unix(created, 0),
+		}}
+	}
+	fakeWatch := watch.NewFake()
+	client := testclient.NewSimpleFake(&buildapi.BuildList{Items: []buildapi.Build{*build("foo-1", "foo", 1), *build("foo-2", "foo", 2), *build("bar-1", "bar", 3)}})
+	client.AddWatchReactor("builds", ktestclient.DefaultWatchReactor(fakeWatch, nil))
+
+	streamed := make(chan string, 10)
+	pending, _ := io.Pipe()
+	out := &bytes.Buffer{}
+	o := OpenShiftLogsOptions{
+		Options:         &buildapi.BuildLogOptions{Follow: true},
+		Wait:            true,
+		BuildConfigName: "foo",
+		Builds:          client.Builds("test"),
+		BuildLogs: func(build *buildapi.Build, opts buildapi.BuildLogOptions) (io.ReadCloser, error) {
+			streamed <- build.Name
+			if build.Name == "foo-2" {
+				// still running until the command switches to the next build
+				return pending, nil
+			}
+			return ioutil.NopCloser(strings.NewReader("logs of " + build.Name + "\n")), nil
+		},
+		Out: out,
+	}
+
+	errCh := make(chan error)
+	go func() { errCh <- o.RunLog() }()
+
+	if name := <-streamed; name != "foo-2" {
+		t.Fatalf("expected the logs of the latest build to be streamed, got %s", name)
+	}
+	fakeWatch.Add(build("bar-2", "bar", 4))
+	fakeWatch.Add(build("foo-3", "foo", 5))
+	if name := <-streamed; name != "foo-3" {
+		t.Fatalf("expected the logs of the new build to be streamed, got %s", name)
+	}
+	fakeWatch.Error(&unversioned.Status{Status: unversioned.StatusFailure, Message: "expired", Code: 410})
+	if err := <-errCh; err == nil {
+		t.Errorf("expected the error of the watch to be returned")
+	}
+	if expected := "--> Switching to the logs of build foo-3\nlogs of foo-3\n"; out.String() != expected {
+		t.Errorf("expected output %q, got %q", expected, out.String())
+	}
+	select {
+	case name := <-streamed:
+		t.Errorf("unexpected logs of %s streamed", name)
+	default:
+	}
+}
+
+func TestValidateWait(t *testing.T) {
+	version := int64(1)
+	tests := []struct {
+		name        string
+		options     runtime.Object
+		buildConfig string
+		valid       bool
+	}{
+		{name: "follow build config", options: &buildapi.BuildLogOptions{Follow: true}, buildConfig: "foo", valid: true},
+		{name: "build", options: &buildapi.BuildLogOptions{Follow: true}},
+		{name: "deployment config", options: &deployapi.DeploymentLogOptions{Follow: true}},
+		{name: "no follow", options: &buildapi.BuildLogOptions{}, buildConfig: "foo"},
+		{name: "version", options: &buildapi.BuildLogOptions{Follow: true, Version: &version}, buildConfig: "foo"},
+	}
+	for _, test := range tests {
+		o := OpenShiftLogsOptions{
+			KubeLogOptions:  &kcmd.LogsOptions{ResourceArg: "bc/foo", Options: &kapi.PodLogOptions{}},
+			Options:         test.options,
+			Wait:            true,
+			BuildConfigName: test.buildConfig,
+		}
+		if err := o.Validate(); (err == nil) != test.valid {
+			t.Errorf("%s: unexpected validation result: %v", test.name, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+const (
+	// PodSelectionOldest picks the ready pod that was created first
+	PodSelectionOldest = "oldest"
+	// PodSelectionNewest picks the ready pod that was created last
+	PodSelectionNewest = "newest"
+)
+
+// resolvePodName returns the name of the pod that a command targeting name should run in. A plain name is a pod,
+// while TYPE/NAME refers to a pod, replication controller, deployment config or service whose ready pods are
+// considered, of which selection picks one.
+func resolvePodName(f *clientcmd.Factory, namespace, name, selection string) (string, error) {
+	if !strings.Contains(name, "/") {
+		return name, nil
+	}
+
+	mapper, typer := f.Object()
+	infos, err := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		NamespaceParam(namespace).DefaultNamespace().
+		ResourceNames("pods", name).
+		SingleResourceType().
+		Do().Infos()
+	if err != nil {
+		return "", err
+	}
+	if len(infos) != 1 {
+		return "", fmt.Errorf("%s does not refer to a single resource", name)
+	}
+
+	_, kc, err := f.Clients()
+	if err != nil {
+		return "", err
+	}
+	return podNameForObject(kc, infos[0].Object, selection)
+}
+
+// podNameForObject returns the name of the ready pod of obj chosen by selection
+func podNameForObject(kc kclient.Interface, obj runtime.Object, selection string) (string, error) {
+	var (
+		namespace, description string
+		selector               map[string]string
+	)
+	switch t := obj.(type) {
+	case *kapi.Pod:
+		return t.Name, nil
+	case *kapi.ReplicationController:
+		namespace, description, selector = t.Namespace, "replication controller "+t.Name, t.Spec.Selector
+	case *kapi.Service:
+		namespace, description, selector = t.Namespace, "service "+t.Name, t.Spec.Selector
+	case *deployapi.DeploymentConfig:
+		namespace, description, selector = t.Namespace, "deployment config "+t.Name, t.Spec.Selector
+		// prefer the pods of the latest deployment over those of older deployments that are scaling down
+		if t.Status.LatestVersion > 0 {
+			deployment, err := kc.ReplicationControllers(t.Namespace).Get(deployutil.LatestDeploymentNameForConfig(t))
+			switch {
+			case err == nil:
+				selector = deployment.Spec.Selector
+			case !kerrors.IsNotFound(err):
+				return "", err
+			}
+		}
+	default:
+		return "", fmt.Errorf("cannot select a pod for %T, only pods, replication controllers, deployment configs and services are supported", obj)
+	}
+	if len(selector) == 0 {
+		return "", fmt.Errorf("%s has no pod selector", description)
+	}
+
+	pods, err := kc.Pods(namespace).List(labels.SelectorFromSet(selector), fields.Everything())
+	if err != nil {
+		return "", err
+	}
+	pod, err := selectPod(pods.Items, selection)
+	if err != nil {
+		return "", err
+	}
+	if pod == nil {
+		return "", fmt.Errorf("%s has no ready pods", description)
+	}
+	return pod.Name, nil
+}
+
+// selectPod returns the ready pod of pods chosen by selection, or nil if none of the pods is ready. Pods created at
+// the same time are ordered by name so that the same pod is always picked.
+func selectPod(pods []kapi.Pod, selection string) (*kapi.Pod, error) {
+	ready := []*kapi.Pod{}
+	for i := range pods {
+		if pods[i].DeletionTimestamp == nil && pods[i].Status.Phase == kapi.PodRunning && kapi.IsPodReady(&pods[i]) {
+			ready = append(ready, &pods[i])
+		}
+	}
+	if len(ready) == 0 {
+		return nil, nil
+	}
+	sort.Sort(podsByCreation(ready))
+
+	switch selection {
+	case PodSelectionOldest, "":
+		return ready[0], nil
+	case PodSelectionNewest:
+		return ready[len(ready)-1], nil
+	default:
+		return nil, fmt.Errorf("unknown pod selection %q, must be %q or %q", selection, PodSelectionOldest, PodSelectionNewest)
+	}
+}
+
+// podsByCreation sorts pods by creation time, and then by name
+type podsByCreation []*kapi.Pod
+
+func (p podsByCreation) Len() int      { return len(p) }
+func (p podsByCreation) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p podsByCreation) Less(i, j int) bool {
+	if !p[i].CreationTimestamp.Equal(p[j].CreationTimestamp) {
+		return p[i].CreationTimestamp.Before(p[j].CreationTimestamp)
+	}
+	return p[i].Name < p[j].Name
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+func readyPod(name string, created time.Time) kapi.Pod {
+	return kapi.Pod{
+		ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: "test", CreationTimestamp: unversioned.NewTime(created)},
+		Status: kapi.PodStatus{
+			Phase:      kapi.PodRunning,
+			Conditions: []kapi.PodCondition{{Type: kapi.PodReady, Status: kapi.ConditionTrue}},
+		},
+	}
+}
+
+func TestSelectPod(t *testing.T) {
+	now := time.Now()
+	pending := readyPod("pending", now.Add(-time.Hour))
+	pending.Status.Phase = kapi.PodPending
+	notReady := readyPod("not-ready", now.Add(-time.Hour))
+	notReady.Status.Conditions[0].Status = kapi.ConditionFalse
+	deleted := readyPod("deleted", now.Add(-time.Hour))
+	deleted.DeletionTimestamp = &unversioned.Time{Time: now}
+
+	pods := []kapi.Pod{
+		readyPod("b", now.Add(-time.Minute)),
+		pending,
+		readyPod("c", now),
+		notReady,
+		readyPod("a", now.Add(-time.Minute)),
+		deleted,
+	}
+
+	testCases := map[string]struct {
+		pods        []kapi.Pod
+		selection   string
+		expected    string
+		expectedErr bool
+	}{
+		"oldest ready pod, ties broken by name": {pods: pods, selection: PodSelectionOldest, expected: "a"},
+		"newest ready pod":                      {pods: pods, selection: PodSelectionNewest, expected: "c"},
+		"defaults to the oldest":                {pods: pods, expected: "a"},
+		"no ready pods":                         {pods: []kapi.Pod{pending, notReady, deleted}, selection: PodSelectionOldest},
+		"unknown selection":                     {pods: pods, selection: "random", expectedErr: true},
+	}
+
+	for name, tc := range testCases {
+		pod, err := selectPod(tc.pods, tc.selection)
+		if tc.expectedErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		actual := ""
+		if pod != nil {
+			actual = pod.Name
+		}
+		if actual != tc.expected {
+			t.Errorf("%s: expected pod %q, got %q", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestPodNameForObject(t *testing.T) {
+	now := time.Now()
+	oldDeploymentPod := readyPod("old-deployment", now.Add(-2*time.Minute))
+	oldDeploymentPod.Labels = map[string]string{"app": "frontend", "deployment": "frontend-1"}
+	olderPod := readyPod("older", now.Add(-time.Minute))
+	olderPod.Labels = map[string]string{"app": "frontend", "deployment": "frontend-2"}
+	newerPod := readyPod("newer", now)
+	newerPod.Labels = olderPod.Labels
+	pods := &kapi.PodList{Items: []kapi.Pod{newerPod, olderPod, oldDeploymentPod}}
+	deployment := &kapi.ReplicationController{
+		ObjectMeta: kapi.ObjectMeta{Name: "frontend-2", Namespace: "test"},
+		Spec:       kapi.ReplicationControllerSpec{Selector: map[string]string{"deployment": "frontend-2"}},
+	}
+
+	testCases := map[string]struct {
+		obj         runtime.Object
+		objects     []runtime.Object
+		expected    string
+		expectedErr bool
+	}{
+		"pod": {
+			obj:      &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "mypod", Namespace: "test"}},
+			expected: "mypod",
+		},
+		"service": {
+			obj:      &kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: "test"}, Spec: kapi.ServiceSpec{Selector: map[string]string{"app": "frontend"}}},
+			objects:  []runtime.Object{pods},
+			expected: "old-deployment",
+		},
+		"service without selector": {
+			obj:         &kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "external", Namespace: "test"}},
+			objects:     []runtime.Object{pods},
+			expectedErr: true,
+		},
+		"replication controller": {
+			obj:      deployment,
+			objects:  []runtime.Object{pods},
+			expected: "older",
+		},
+		"deployment config uses the latest deployment": {
+			obj: &deployapi.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: "test"},
+				Spec:       deployapi.DeploymentConfigSpec{Selector: map[string]string{"app": "frontend"}},
+				Status:     deployapi.DeploymentConfigStatus{LatestVersion: 2},
+			},
+			objects:  []runtime.Object{deployment, pods},
+			expected: "older",
+		},
+		"deployment config without deployments": {
+			obj: &deployapi.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: "test"},
+				Spec:       deployapi.DeploymentConfigSpec{Selector: map[string]string{"app": "frontend"}},
+			},
+			objects:  []runtime.Object{pods},
+			expected: "old-deployment",
+		},
+		"no ready pods": {
+			obj:         deployment,
+			objects:     []runtime.Object{&kapi.PodList{}},
+			expectedErr: true,
+		},
+		"unsupported resource": {
+			obj:         &kapi.Secret{ObjectMeta: kapi.ObjectMeta{Name: "secret", Namespace: "test"}},
+			expectedErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		kc := ktestclient.NewSimpleFake(tc.objects...)
+		actual, err := podNameForObject(kc, tc.obj, PodSelectionOldest)
+		if tc.expectedErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if actual != tc.expected {
+			t.Errorf("%s: expected pod %q, got %q", name, tc.expected, actual)
+		}
+	}
+}
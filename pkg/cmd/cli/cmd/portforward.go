@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/portforward"
+	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	portForwardLong = `Forward 1 or more local ports to a pod
+
+Instead of a pod you may name a deployment config, replication controller or service as TYPE/NAME,
+in which case the ports are forwarded to one of its ready pods - the oldest one, unless
+--pod-selection=newest is given. When the connection to the pod is lost, for instance because
+the pod restarted or was replaced by a new deployment, the pod is looked up again and forwarding
+resumes. Local ports chosen at random may change when that happens.
+
+By default only connections to localhost are forwarded. Use --address to listen on other local
+addresses, such as 0.0.0.0 to accept connections from other hosts.`
+
+	portForwardExample = `  # Listens on ports 5000 and 6000 locally, forwarding data to/from ports 5000 and 6000 in the pod
+  $ %[1]s port-forward mypod 5000 6000
+
+  # Listens on port 8888 locally, forwarding to 5000 in the pod
+  $ %[1]s port-forward mypod 8888:5000
+
+  # Listens on a random port locally, forwarding to 5000 in the pod
+  $ %[1]s port-forward mypod :5000
+
+  # Listens on a random port locally, forwarding to 5000 in the pod
+  $ %[1]s port-forward mypod 0:5000
+
+  # Listens on port 8080 on all addresses, forwarding to 8080 in a pod of deployment config 'frontend'
+  $ %[1]s port-forward --address=0.0.0.0 dc/frontend 8080`
+
+	// portForwardReconnectInterval is how long to wait before reconnecting to a pod that was lost
+	portForwardReconnectInterval = 2 * time.Second
+)
+
+// PortForwarder forwards local ports to the pod behind url until stopChan is closed or the connection is lost
+type PortForwarder interface {
+	ForwardPorts(method string, url *url.URL, config *kclient.Config, addresses, ports []string, stopChan <-chan struct{}) error
+}
+
+// DefaultPortForwarder forwards ports over an upgraded connection to the server
+type DefaultPortForwarder struct{}
+
+// ForwardPorts forwards ports until stopChan is closed or the connection is lost
+func (*DefaultPortForwarder) ForwardPorts(method string, url *url.URL, config *kclient.Config, addresses, ports []string, stopChan <-chan struct{}) error {
+	dialer, err := remotecommand.NewExecutor(config, method, url)
+	if err != nil {
+		return err
+	}
+	fw, err := portforward.NewOnAddresses(dialer, addresses, ports, stopChan)
+	if err != nil {
+		return err
+	}
+	return fw.ForwardPorts()
+}
+
+// PortForwardOptions declare the arguments accepted by the port-forward command
+type PortForwardOptions struct {
+	Namespace string
+	// Name is a pod name or a TYPE/NAME reference to a resource with pods
+	Name         string
+	Ports        []string
+	Addresses    []string
+	PodSelection string
+
+	Err io.Writer
+
+	// ResolvePod returns the name of the pod to forward to
+	ResolvePod  func() (string, error)
+	Forwarder   PortForwarder
+	Client      kclient.Interface
+	RESTClient  *kclient.RESTClient
+	Config      *kclient.Config
+	StopChannel chan struct{}
+}
+
+// NewCmdPortForward returns a command that forwards local ports to a pod
+func NewCmdPortForward(fullName string, f *clientcmd.Factory, errout io.Writer) *cobra.Command {
+	options := &PortForwardOptions{
+		Addresses:    []string{"localhost"},
+		PodSelection: PodSelectionOldest,
+		Err:          errout,
+		Forwarder:    &DefaultPortForwarder{},
+	}
+	cmd := &cobra.Command{
+		Use:     "port-forward [TYPE/]NAME [LOCAL_PORT:]REMOTE_PORT [...[LOCAL_PORT_N:]REMOTE_PORT_N]",
+		Short:   "Forward one or more local ports to a pod.",
+		Long:    portForwardLong,
+		Example: fmt.Sprintf(portForwardExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(options.Complete(f, cmd, args))
+			kcmdutil.CheckErr(options.Validate())
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+	cmd.Flags().StringP("pod", "p", "", "Pod name")
+	cmd.Flags().MarkDeprecated("pod", "use port-forward POD instead")
+	cmd.Flags().StringSliceVar(&options.Addresses, "address", options.Addresses, "Local addresses to listen on, comma separated. 'localhost' listens on the IPv4 and IPv6 loopback addresses")
+	cmd.Flags().StringVar(&options.PodSelection, "pod-selection", options.PodSelection, "Which ready pod of a deployment config, replication controller or service to forward to: oldest or newest")
+	return cmd
+}
+
+// Complete applies the command environment to PortForwardOptions
+func (o *PortForwardOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string) error {
+	o.Name = kcmdutil.GetFlagString(cmd, "pod")
+	if len(o.Name) == 0 {
+		if len(args) == 0 {
+			return kcmdutil.UsageError(cmd, "POD is required for port-forward")
+		}
+		o.Name, args = args[0], args[1:]
+	}
+	if len(args) == 0 {
+		return kcmdutil.UsageError(cmd, "at least 1 PORT is required for port-forward")
+	}
+	o.Ports = args
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+
+	if o.Config, err = f.ClientConfig(); err != nil {
+		return err
+	}
+	_, kc, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client, o.RESTClient = kc, kc.RESTClient
+
+	o.ResolvePod = func() (string, error) {
+		return resolvePodName(f, o.Namespace, o.Name, o.PodSelection)
+	}
+
+	o.StopChannel = make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		close(o.StopChannel)
+	}()
+	return nil
+}
+
+// Validate ensures that PortForwardOptions are valid
+func (o *PortForwardOptions) Validate() error {
+	if len(o.Name) == 0 {
+		return fmt.Errorf("pod name must be specified")
+	}
+	if len(o.Ports) == 0 {
+		return fmt.Errorf("at least 1 port must be specified")
+	}
+	if len(o.Addresses) == 0 {
+		return fmt.Errorf("at least 1 address must be specified")
+	}
+	for _, address := range o.Addresses {
+		if address != "localhost" && net.ParseIP(address) == nil {
+			return fmt.Errorf("address %q must be 'localhost' or an IP address", address)
+		}
+	}
+	if o.PodSelection != PodSelectionOldest && o.PodSelection != PodSelectionNewest {
+		return fmt.Errorf("--pod-selection must be %q or %q", PodSelectionOldest, PodSelectionNewest)
+	}
+	if o.ResolvePod == nil || o.Forwarder == nil || o.Client == nil || o.RESTClient == nil || o.Config == nil || o.StopChannel == nil {
+		return fmt.Errorf("client, client config, pod resolver and forwarder must be provided")
+	}
+	return nil
+}
+
+// Run forwards ports to the pod until interrupted, reconnecting whenever the connection to the pod is lost
+func (o *PortForwardOptions) Run() error {
+	connected := false
+	for {
+		err := o.forward()
+		if !connected && err != nil {
+			return err
+		}
+		connected = true
+
+		select {
+		case <-o.StopChannel:
+			return nil
+		default:
+		}
+		if err != nil {
+			fmt.Fprintf(o.Err, "Unable to forward ports to %s, retrying: %v\n", o.Name, err)
+		} else {
+			fmt.Fprintf(o.Err, "Lost connection to %s, reconnecting\n", o.Name)
+		}
+
+		select {
+		case <-o.StopChannel:
+			return nil
+		case <-time.After(portForwardReconnectInterval):
+		}
+	}
+}
+
+// forward looks up the pod to forward to and forwards ports to it until the connection is lost or the command is
+// stopped
+func (o *PortForwardOptions) forward() error {
+	podName, err := o.ResolvePod()
+	if err != nil {
+		return err
+	}
+	pod, err := o.Client.Pods(o.Namespace).Get(podName)
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase != kapi.PodRunning {
+		return fmt.Errorf("pod %s is not running and cannot forward ports; current phase is %s", pod.Name, pod.Status.Phase)
+	}
+
+	url := o.RESTClient.Post().Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+	postErr := o.Forwarder.ForwardPorts("POST", url, o.Config, o.Addresses, o.Ports, o.StopChannel)
+	// only try a GET, which v3.0.0 servers expect, if the POST was rejected
+	if postErr == nil || (!kerrors.IsForbidden(postErr) && !kerrors.IsMethodNotSupported(postErr)) {
+		return postErr
+	}
+	url = o.RESTClient.Get().Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+	if err := o.Forwarder.ForwardPorts("GET", url, o.Config, o.Addresses, o.Ports, o.StopChannel); err != nil {
+		// the POST error is more likely to be correct
+		return postErr
+	}
+	return nil
+}
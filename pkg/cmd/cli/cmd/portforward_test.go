@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+)
+
+type fakePortForwarder struct {
+	urls      []string
+	addresses [][]string
+	// forward is called for each connection and returns the result of ForwardPorts
+	forward func(call int) error
+}
+
+func (f *fakePortForwarder) ForwardPorts(method string, url *url.URL, config *kclient.Config, addresses, ports []string, stopChan <-chan struct{}) error {
+	f.urls = append(f.urls, url.Path)
+	f.addresses = append(f.addresses, addresses)
+	return f.forward(len(f.urls))
+}
+
+func newPortForwardOptions(t *testing.T, pods ...string) (*PortForwardOptions, *fakePortForwarder) {
+	objects := &kapi.PodList{}
+	for _, name := range pods {
+		objects.Items = append(objects.Items, kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: "test"},
+			Status:     kapi.PodStatus{Phase: kapi.PodRunning},
+		})
+	}
+	kc, err := kclient.New(&kclient.Config{Host: "https://localhost:8443", Version: "v1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resolved := 0
+	forwarder := &fakePortForwarder{}
+	return &PortForwardOptions{
+		Namespace:    "test",
+		Name:         "dc/frontend",
+		Ports:        []string{"8080"},
+		Addresses:    []string{"localhost", "10.0.0.1"},
+		PodSelection: PodSelectionOldest,
+		Err:          ioutil.Discard,
+		ResolvePod: func() (string, error) {
+			resolved++
+			if resolved > len(pods) {
+				return "", errors.New("deployment config frontend has no ready pods")
+			}
+			return pods[resolved-1], nil
+		},
+		Forwarder:   forwarder,
+		Client:      ktestclient.NewSimpleFake(objects),
+		RESTClient:  kc.RESTClient,
+		Config:      &kclient.Config{},
+		StopChannel: make(chan struct{}),
+	}, forwarder
+}
+
+func TestPortForwardReconnects(t *testing.T) {
+	o, forwarder := newPortForwardOptions(t, "frontend-1-abcde", "frontend-2-fghij")
+	forwarder.forward = func(call int) error {
+		if call == 2 {
+			close(o.StopChannel)
+		}
+		// the connection to the first pod is lost, the second lasts until the command is stopped
+		return nil
+	}
+
+	if err := o.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(forwarder.urls) != 2 {
+		t.Fatalf("expected two connections, got %v", forwarder.urls)
+	}
+	for i, pod := range []string{"frontend-1-abcde", "frontend-2-fghij"} {
+		if !strings.HasSuffix(forwarder.urls[i], "/namespaces/test/pods/"+pod+"/portforward") {
+			t.Errorf("expected connection %d to pod %s, got %s", i, pod, forwarder.urls[i])
+		}
+		if len(forwarder.addresses[i]) != 2 || forwarder.addresses[i][1] != "10.0.0.1" {
+			t.Errorf("expected the ports to be forwarded on all addresses, got %v", forwarder.addresses[i])
+		}
+	}
+}
+
+func TestPortForwardFailsWithoutPod(t *testing.T) {
+	o, forwarder := newPortForwardOptions(t)
+	forwarder.forward = func(int) error { return nil }
+
+	if err := o.Run(); err == nil || !strings.Contains(err.Error(), "no ready pods") {
+		t.Fatalf("expected an error for the missing pod, got %v", err)
+	}
+	if len(forwarder.urls) != 0 {
+		t.Errorf("unexpected connections: %v", forwarder.urls)
+	}
+}
+
+func TestPortForwardValidateAddresses(t *testing.T) {
+	o, _ := newPortForwardOptions(t)
+	for _, address := range []string{"localhost", "0.0.0.0", "::"} {
+		o.Addresses = []string{address}
+		if err := o.Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", address, err)
+		}
+	}
+	o.Addresses = []string{"example.com"}
+	if err := o.Validate(); err == nil {
+		t.Errorf("expected an error for a hostname")
+	}
+}
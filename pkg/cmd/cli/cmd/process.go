@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"reflect"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/openshift/origin/pkg/cmd/cli/describe"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	newapp "github.com/openshift/origin/pkg/generate/app"
 	"github.com/openshift/origin/pkg/template"
 	"github.com/openshift/origin/pkg/template/api"
 )
@@ -31,7 +33,11 @@ update. Templates have "parameters", which may either be generated on creation o
 as well as metadata describing the template.
 
 The output of the process command is always a list of one or more resources. You may pipe the
-output to the create command over STDIN (using the '-f -' option) or redirect it to a file.`
+output to the create command over STDIN (using the '-f -' option) or redirect it to a file.
+
+Templates may also be read from an HTTP(S) URL or from a file in a git repository, using the form
+<repository>#[<ref>]:<path>. Pass --checksum to verify the SHA-256 checksum of the template file
+before it is processed.`
 
 	processExample = `  # Convert template.json file into resource list and pass to create
   $ %[1]s process -f template.json | %[1]s create -f -
@@ -48,6 +54,9 @@ output to the create command over STDIN (using the '-f -' option) or redirect it
   # Convert template.json into resource list
   $ cat template.json | %[1]s process -f -
 
+  # Process a template stored in a git repository, verifying its checksum
+  $ %[1]s process -f https://github.com/youruser/yourgitrepo.git#master:template.json --checksum=<sha256>
+
   # Combine multiple templates into single resource list
   $ cat template.json second_template.json | %[1]s process -f -`
 )
@@ -65,6 +74,7 @@ func NewCmdProcess(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.
 		},
 	}
 	cmd.Flags().StringP("filename", "f", "", "Filename or URL to file to read a template")
+	cmd.Flags().String("checksum", "", "SHA-256 checksum that the template file must match")
 	cmd.Flags().StringSliceP("value", "v", nil, "Specify a list of key-value pairs (eg. -v FOO=BAR,BAR=FOO) to set/override parameter values")
 	cmd.Flags().BoolP("parameters", "", false, "Do not process but only print available parameters")
 	cmd.Flags().StringP("labels", "l", "", "Label to set in all resources for this template")
@@ -91,6 +101,11 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 		return kcmdutil.UsageError(cmd, "Must pass a filename or name of stored template")
 	}
 
+	checksum := kcmdutil.GetFlagString(cmd, "checksum")
+	if len(checksum) > 0 && len(filename) == 0 {
+		return kcmdutil.UsageError(cmd, "--checksum may only be used with a template file")
+	}
+
 	if kcmdutil.GetFlagBool(cmd, "parameters") {
 		for _, flag := range []string{"value", "labels", "output", "output-version", "raw", "template"} {
 			if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
@@ -150,11 +165,18 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 		templateObj.CreationTimestamp = unversioned.Now()
 		infos = append(infos, &resource.Info{Object: templateObj})
 	} else {
-		infos, err = resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
-			NamespaceParam(namespace).RequireNamespace().
-			FilenameParam(explicit, filename).
-			Do().
-			Infos()
+		builder := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+			NamespaceParam(namespace).RequireNamespace()
+		if newapp.IsRemoteTemplateFile(filename) || len(checksum) > 0 {
+			data, err := newapp.ReadTemplateFile(filename, checksum)
+			if err != nil {
+				return err
+			}
+			builder = builder.Stream(bytes.NewReader(data), filename)
+		} else {
+			builder = builder.FilenameParam(explicit, filename)
+		}
+		infos, err = builder.Do().Infos()
 		if err != nil {
 			return err
 		}
@@ -21,6 +21,9 @@ Open a remote shell session to a container
 
 This command will attempt to start a shell session in the specified pod. It will default to the
 first container if none is specified, and will attempt to use '/bin/bash' as the default shell.
+Instead of a pod you may name a deployment config, replication controller or service as TYPE/NAME,
+in which case the shell is started in one of its ready pods - the oldest one, unless
+--pod-selection=newest is given.
 You may pass an optional command after the pod name, which will be executed instead of a login
 shell. A TTY will be automatically allocated if standard input is interactive - use -t and -T
 to override.
@@ -33,7 +36,10 @@ directly.`
   $ %[1]s foo
 
   # Run the command 'cat /etc/resolv.conf' inside pod 'foo'
-  $ %[1]s foo cat /etc/resolv.conf`
+  $ %[1]s foo cat /etc/resolv.conf
+
+  # Open a shell session in the most recently created ready pod of deployment config 'frontend'
+  $ %[1]s --pod-selection=newest dc/frontend`
 )
 
 // RshOptions declare the arguments accepted by the Rsh command
@@ -41,14 +47,17 @@ type RshOptions struct {
 	ForceTTY   bool
 	DisableTTY bool
 	Executable string
+	// PodSelection picks the pod when a resource other than a pod is named
+	PodSelection string
 	*kubecmd.ExecOptions
 }
 
 // NewCmdRsh returns a command that attempts to open a shell session to the server.
 func NewCmdRsh(name string, parent string, f *clientcmd.Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 	options := &RshOptions{
-		ForceTTY:   false,
-		DisableTTY: false,
+		ForceTTY:     false,
+		DisableTTY:   false,
+		PodSelection: PodSelectionOldest,
 		ExecOptions: &kubecmd.ExecOptions{
 			In:  in,
 			Out: out,
@@ -62,7 +71,7 @@ func NewCmdRsh(name string, parent string, f *clientcmd.Factory, in io.Reader, o
 	}
 
 	cmd := &cobra.Command{
-		Use:     fmt.Sprintf("%s POD [options] [COMMAND]", name),
+		Use:     fmt.Sprintf("%s [TYPE/]NAME [options] [COMMAND]", name),
 		Short:   "Start a shell session in a pod",
 		Long:    fmt.Sprintf(rshLong, parent),
 		Example: fmt.Sprintf(rshExample, parent+" "+name),
@@ -76,6 +85,7 @@ func NewCmdRsh(name string, parent string, f *clientcmd.Factory, in io.Reader, o
 	cmd.Flags().BoolVarP(&options.DisableTTY, "no-tty", "T", false, "Disable pseudo-terminal allocation")
 	cmd.Flags().StringVar(&options.Executable, "shell", "/bin/bash", "Path to shell command")
 	cmd.Flags().StringVarP(&options.ContainerName, "container", "c", "", "Container name; defaults to first container")
+	cmd.Flags().StringVar(&options.PodSelection, "pod-selection", options.PodSelection, "Which ready pod of a deployment config, replication controller or service to connect to: oldest or newest")
 	cmd.Flags().SetInterspersed(false)
 	return cmd
 }
@@ -96,6 +106,9 @@ func (o *RshOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []s
 	if len(args) < 1 {
 		return kcmdutil.UsageError(cmd, "rsh requires a single Pod to connect to")
 	}
+	if o.PodSelection != PodSelectionOldest && o.PodSelection != PodSelectionNewest {
+		return kcmdutil.UsageError(cmd, "--pod-selection must be %q or %q", PodSelectionOldest, PodSelectionNewest)
+	}
 	o.PodName = args[0]
 	args = args[1:]
 	if len(args) > 0 {
@@ -110,6 +123,10 @@ func (o *RshOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []s
 	}
 	o.Namespace = namespace
 
+	if o.PodName, err = resolvePodName(f, namespace, o.PodName, o.PodSelection); err != nil {
+		return err
+	}
+
 	config, err := f.ClientConfig()
 	if err != nil {
 		return err
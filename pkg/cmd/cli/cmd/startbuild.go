@@ -39,7 +39,9 @@ const (
 Start a build
 
 This command starts a new build for the provided build config or copies an existing build using
---from-build=<name>. Pass the --follow flag to see output from the build.
+--from-build=<name>. Pass the --follow flag to see output from the build, or the --wait flag to
+wait until the build completes. With either flag, the command exits with a non-zero return code if
+the build fails, so it can gate scripts and CI pipelines on the result of the build.
 
 In addition, you can pass a file, directory, or source code repository with the --from-file,
 --from-dir, or --from-repo flags directly to the build. The contents will be streamed to the build
@@ -56,6 +58,9 @@ base image changes will use the source specified on the build config.
   # Starts build from a previous build "hello-world-1"
   $ %[1]s start-build --from-build=hello-world-1
 
+  # Rebuild "hello-world-1" at commit "v2" with debugging enabled
+  $ %[1]s start-build --from-build=hello-world-1 --commit=v2 -e DEBUG=true
+
   # Use the contents of a directory as build input
   $ %[1]s start-build hello-world --from-dir=src/
 
@@ -63,7 +68,7 @@ base image changes will use the source specified on the build config.
   $ %[1]s start-build hello-world --from-repo=../hello-world --commit=v2
 
   # Start a new build for build config "hello-world" and watch the logs until the build
-  # completes or fails.
+  # completes or fails. It exits with a non-zero return code if the build fails.
   $ %[1]s start-build hello-world --follow
 
   # Start a new build for build config "hello-world" and wait until the build completes. It
@@ -92,7 +97,7 @@ func NewCmdStartBuild(fullName string, f *clientcmd.Factory, in io.Reader, out i
 	cmd.Flags().StringSliceVarP(&env, "env", "e", env, "Specify key value pairs of environment variables to set for the build container.")
 	cmd.Flags().String("from-build", "", "Specify the name of a build which should be re-run")
 
-	cmd.Flags().Bool("follow", false, "Start a build and watch its logs until it completes or fails")
+	cmd.Flags().Bool("follow", false, "Start a build and watch its logs until it completes or fails, exiting with a non-zero return code if the build fails")
 	cmd.Flags().Bool("wait", false, "Wait for a build to complete and exit with a non-zero return code if the build fails")
 
 	cmd.Flags().String("from-file", "", "A file use as the binary input for the build; example a pom.xml or Dockerfile. Will be the only file in the build source.")
@@ -287,6 +292,12 @@ func RunStartBuild(f *clientcmd.Factory, in io.Reader, out io.Writer, cmd *cobra
 
 	wg.Wait()
 
+	// The logs end when the build pod exits, wait for the build to record its final phase so that the
+	// exit code reflects the result of the build
+	if follow && !waitForComplete {
+		exitErr = WaitForBuildComplete(client.Builds(namespace), newBuild.Name)
+	}
+
 	return exitErr
 }
 
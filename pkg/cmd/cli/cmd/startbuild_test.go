@@ -12,10 +12,12 @@ import (
 	"strings"
 	"testing"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
 	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 )
 
@@ -119,3 +121,25 @@ func TestStartBuildHookPostReceive(t *testing.T) {
 		t.Fatalf("unexpected ref: %#v", event.Git.Refs[0])
 	}
 }
+
+func TestWaitForBuildComplete(t *testing.T) {
+	testCases := map[string]struct {
+		Phase       buildapi.BuildPhase
+		ExpectError bool
+	}{
+		"complete":  {Phase: buildapi.BuildPhaseComplete},
+		"failed":    {Phase: buildapi.BuildPhaseFailed, ExpectError: true},
+		"cancelled": {Phase: buildapi.BuildPhaseCancelled, ExpectError: true},
+		"error":     {Phase: buildapi.BuildPhaseError, ExpectError: true},
+	}
+
+	for k, tc := range testCases {
+		client := testclient.NewSimpleFake(&buildapi.BuildList{Items: []buildapi.Build{
+			{ObjectMeta: kapi.ObjectMeta{Name: "hello-world-1", Namespace: "test"}, Status: buildapi.BuildStatus{Phase: tc.Phase}},
+		}})
+		err := WaitForBuildComplete(client.Builds("test"), "hello-world-1")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", k, tc.ExpectError, err)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"io"
 
@@ -10,6 +9,7 @@ import (
 
 	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 
+	osgraph "github.com/openshift/origin/pkg/api/graph"
 	"github.com/openshift/origin/pkg/cmd/cli/describe"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 )
@@ -27,7 +27,8 @@ about individual items, use the describe command (e.g. oc describe buildConfig,
 oc describe deploymentConfig, oc describe service).
 
 You can specify an output format of "-o dot" to have this command output the generated status
-graph in DOT format that is suitable for use by the "dot" command.`
+graph in DOT format that is suitable for use by the "dot" command, or "-o json" to output the
+nodes and edges of the graph as JSON for use by other tools.`
 
 	statusExample = `  # See an overview of the current project.
   $ %[1]s
@@ -35,6 +36,9 @@ graph in DOT format that is suitable for use by the "dot" command.`
   # Export the overview of the current project in an svg file.
   $ %[1]s -o dot | dot -T svg -o project.svg
 
+  # Export the graph of the current project as JSON.
+  $ %[1]s -o json
+
   # See an overview of the current project including details for any identified issues.
   $ %[1]s -v`
 )
@@ -53,7 +57,7 @@ func NewCmdStatus(name, fullName string, f *clientcmd.Factory, out io.Writer) *c
 	opts := &StatusOptions{}
 
 	cmd := &cobra.Command{
-		Use:     fmt.Sprintf("%s [-o dot | -v ]", StatusRecommendedName),
+		Use:     fmt.Sprintf("%s [-o dot|json | -v ]", StatusRecommendedName),
 		Short:   "Show an overview of the current project",
 		Long:    statusLong,
 		Example: fmt.Sprintf(statusExample, fullName),
@@ -70,7 +74,7 @@ func NewCmdStatus(name, fullName string, f *clientcmd.Factory, out io.Writer) *c
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", opts.outputFormat, "Output format. One of: dot.")
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", opts.outputFormat, "Output format. One of: dot|json.")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", opts.verbose, "See details for resolving issues.")
 
 	return cmd
@@ -107,11 +111,13 @@ func (o *StatusOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args
 
 // Validate validates the options for the Openshift cli status command.
 func (o StatusOptions) Validate() error {
-	if len(o.outputFormat) != 0 && o.outputFormat != "dot" {
+	switch o.outputFormat {
+	case "", "dot", "json":
+	default:
 		return fmt.Errorf("invalid output format provided: %s", o.outputFormat)
 	}
 	if len(o.outputFormat) > 0 && o.verbose {
-		return errors.New("cannot provide suggestions when output format is dot")
+		return fmt.Errorf("cannot provide suggestions when output format is %s", o.outputFormat)
 	}
 	return nil
 }
@@ -139,6 +145,16 @@ func (o StatusOptions) RunStatus() error {
 			return err
 		}
 		s = string(data)
+	case "json":
+		g, _, err := o.describer.MakeGraph(o.namespace)
+		if err != nil {
+			return err
+		}
+		data, err := osgraph.MarshalJSON(g, o.namespace, "", "  ")
+		if err != nil {
+			return err
+		}
+		s = string(data) + "\n"
 	default:
 		return fmt.Errorf("invalid output format provided: %s", o.outputFormat)
 	}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/spf13/cobra"
 	kcmd "k8s.io/kubernetes/pkg/kubectl/cmd"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 
+	"github.com/openshift/origin/pkg/cmd/cli/cmd/create"
 	"github.com/openshift/origin/pkg/cmd/cli/describe"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 )
@@ -28,7 +30,11 @@ const (
 
 Possible resources include builds, buildConfigs, services, pods, etc.
 Some resources may omit advanced details that you can see with '-o wide'.
-If you want an even more detailed view, use '%[1]s describe'.`
+If you want an even more detailed view, use '%[1]s describe'.
+
+Use '-o custom-columns' to print chosen fields of the resources as columns, and '--sort-by' to
+order them by a field. Fields are given as JSONPath expressions on the resource as it is shown
+by '-o json', for example '.status.startTimestamp' for builds or '.spec.host' for routes.`
 
 	getExample = `  # List all pods in ps output format.
   $ %[1]s get pods
@@ -43,7 +49,16 @@ If you want an even more detailed view, use '%[1]s describe'.`
   $ %[1]s get -o json pod redis-pod
 
   # Return only the status value of the specified pod.
-  $ %[1]s get -o template pod redis-pod --template={{.currentState.status}}`
+  $ %[1]s get -o template pod redis-pod --template={{.currentState.status}}
+
+  # List all builds in the order they started.
+  $ %[1]s get builds --sort-by=.status.startTimestamp
+
+  # List the host and service of all routes, ordered by host.
+  $ %[1]s get routes -o custom-columns=NAME:.metadata.name,HOST:.spec.host,SERVICE:.spec.to.name --sort-by=.spec.host
+
+  # List the image stream tags of all image streams, most recently created last.
+  $ %[1]s get istag --sort-by=.metadata.creationTimestamp`
 )
 
 // NewCmdGet is a wrapper for the Kubernetes cli get command
@@ -141,57 +156,72 @@ JSON and YAML formats are accepted.`
   $ %[1]s create -f pod.json
 
   # Create a pod based on the JSON passed into stdin.
-  $ cat pod.json | %[1]s create -f -`
+  $ cat pod.json | %[1]s create -f -
+
+  # Create an image stream without writing its definition
+  $ %[1]s create imagestream mysql`
 )
 
-// NewCmdCreate is a wrapper for the Kubernetes cli create command
+// NewCmdCreate is a wrapper for the Kubernetes cli create command that also generates simple resources
 func NewCmdCreate(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
 	cmd := kcmd.NewCmdCreate(f.Factory, out)
 	cmd.Long = createLong
 	cmd.Example = fmt.Sprintf(createExample, fullName)
+
+	createFullName := fullName + " create"
+	cmd.AddCommand(create.NewCmdCreateImageStream(create.ImageStreamRecommendedName, createFullName+" "+create.ImageStreamRecommendedName, f, out))
+	cmd.AddCommand(create.NewCmdCreateDeploymentConfig(create.DeploymentConfigRecommendedName, createFullName+" "+create.DeploymentConfigRecommendedName, f, out))
 	return cmd
 }
 
 const (
-	execLong = `Execute a command in a container`
+	execLong = `Execute a command in a container
+
+Instead of a pod you may name a deployment config, replication controller or service as TYPE/NAME,
+in which case the command is executed in one of its ready pods - the oldest one, unless
+--pod-selection=newest is given.`
 
 	execExample = `  # Get output from running 'date' in ruby-container from pod 123456-7890
   $ %[1]s exec -p 123456-7890 -c ruby-container date
 
   # Switch to raw terminal mode, sends stdin to 'bash' in ruby-container from pod 123456-780 and sends stdout/stderr from 'bash' back to the client
-  $ %[1]s exec -p 123456-7890 -c ruby-container -i -t -- bash -il`
+  $ %[1]s exec -p 123456-7890 -c ruby-container -i -t -- bash -il
+
+  # List the files served by the most recently created ready pod behind service 'frontend'
+  $ %[1]s exec --pod-selection=newest svc/frontend -- ls /var/www`
 )
 
-// NewCmdExec is a wrapper for the Kubernetes cli exec command
+// NewCmdExec is a wrapper for the Kubernetes cli exec command that also accepts the resources that manage pods
 func NewCmdExec(fullName string, f *clientcmd.Factory, cmdIn io.Reader, cmdOut, cmdErr io.Writer) *cobra.Command {
 	cmd := kcmd.NewCmdExec(f.Factory, cmdIn, cmdOut, cmdErr)
-	cmd.Use = "exec POD [-c CONTAINER] [options] -- COMMAND [args...]"
+	cmd.Use = "exec [TYPE/]NAME [-c CONTAINER] [options] -- COMMAND [args...]"
 	cmd.Long = execLong
 	cmd.Example = fmt.Sprintf(execExample, fullName)
-	return cmd
-}
-
-const (
-	portForwardLong = `Forward 1 or more local ports to a pod`
-
-	portForwardExample = `  # Listens on ports 5000 and 6000 locally, forwarding data to/from ports 5000 and 6000 in the pod
-  $ %[1]s port-forward -p mypod 5000 6000
-
-  # Listens on port 8888 locally, forwarding to 5000 in the pod
-  $ %[1]s port-forward -p mypod 8888:5000
-
-  # Listens on a random port locally, forwarding to 5000 in the pod
-  $ %[1]s port-forward -p mypod :5000
-
-  # Listens on a random port locally, forwarding to 5000 in the pod
-  $ %[1]s port-forward -p mypod 0:5000`
-)
-
-// NewCmdPortForward is a wrapper for the Kubernetes cli port-forward command
-func NewCmdPortForward(fullName string, f *clientcmd.Factory) *cobra.Command {
-	cmd := kcmd.NewCmdPortForward(f.Factory)
-	cmd.Long = portForwardLong
-	cmd.Example = fmt.Sprintf(portForwardExample, fullName)
+	cmd.Flags().String("pod-selection", PodSelectionOldest, "Which ready pod of a deployment config, replication controller or service to execute in: oldest or newest")
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		options := &kcmd.ExecOptions{
+			PodName:       kcmdutil.GetFlagString(cmd, "pod"),
+			ContainerName: kcmdutil.GetFlagString(cmd, "container"),
+			Stdin:         kcmdutil.GetFlagBool(cmd, "stdin"),
+			TTY:           kcmdutil.GetFlagBool(cmd, "tty"),
+
+			In:  cmdIn,
+			Out: cmdOut,
+			Err: cmdErr,
+
+			Executor: &kcmd.DefaultRemoteExecutor{},
+		}
+		selection := kcmdutil.GetFlagString(cmd, "pod-selection")
+		if selection != PodSelectionOldest && selection != PodSelectionNewest {
+			kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "--pod-selection must be %q or %q", PodSelectionOldest, PodSelectionNewest))
+		}
+		kcmdutil.CheckErr(options.Complete(f.Factory, cmd, args))
+		podName, err := resolvePodName(f, options.Namespace, options.PodName, selection)
+		kcmdutil.CheckErr(err)
+		options.PodName = podName
+		kcmdutil.CheckErr(options.Validate())
+		kcmdutil.CheckErr(options.Run())
+	}
 	return cmd
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/gonum/graph/encoding/dot"
 	"github.com/gonum/graph/path"
 	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
 	utilerrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/sets"
 
@@ -17,6 +18,8 @@ import (
 	buildedges "github.com/openshift/origin/pkg/build/graph"
 	buildgraph "github.com/openshift/origin/pkg/build/graph/nodes"
 	"github.com/openshift/origin/pkg/client"
+	deployedges "github.com/openshift/origin/pkg/deploy/graph"
+	deploygraph "github.com/openshift/origin/pkg/deploy/graph/nodes"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	imagegraph "github.com/openshift/origin/pkg/image/graph/nodes"
 	"github.com/openshift/origin/pkg/util/parallel"
@@ -37,17 +40,20 @@ func (e NotFoundErr) Error() string {
 // dependencies of an image stream
 type ChainDescriber struct {
 	c            client.BuildConfigsNamespacer
+	d            client.DeploymentConfigsNamespacer
 	namespaces   sets.String
 	outputFormat string
 }
 
-// NewChainDescriber returns a new ChainDescriber
-func NewChainDescriber(c client.BuildConfigsNamespacer, namespaces sets.String, out string) *ChainDescriber {
-	return &ChainDescriber{c: c, namespaces: namespaces, outputFormat: out}
+// NewChainDescriber returns a new ChainDescriber. If d is not nil, deployment
+// configurations triggered by the image stream tags in the chain are included.
+func NewChainDescriber(c client.BuildConfigsNamespacer, d client.DeploymentConfigsNamespacer, namespaces sets.String, out string) *ChainDescriber {
+	return &ChainDescriber{c: c, d: d, namespaces: namespaces, outputFormat: out}
 }
 
-// MakeGraph will create the graph of all build configurations and the image streams
-// they point to via image change triggers in the provided namespace(s)
+// MakeGraph will create the graph of all build configurations, deployment configurations
+// and the image streams they point to via image change triggers in the provided namespace(s).
+// Namespaces the user is not allowed to read are skipped.
 func (d *ChainDescriber) MakeGraph() (osgraph.Graph, error) {
 	g := osgraph.New()
 
@@ -55,6 +61,10 @@ func (d *ChainDescriber) MakeGraph() (osgraph.Graph, error) {
 	for namespace := range d.namespaces {
 		glog.V(4).Infof("Loading build configurations from %q", namespace)
 		loaders = append(loaders, &bcLoader{namespace: namespace, lister: d.c})
+		if d.d != nil {
+			glog.V(4).Infof("Loading deployment configurations from %q", namespace)
+			loaders = append(loaders, &dcLoader{namespace: namespace, lister: d.d})
+		}
 	}
 	loadingFuncs := []func() error{}
 	for _, loader := range loaders {
@@ -62,7 +72,17 @@ func (d *ChainDescriber) MakeGraph() (osgraph.Graph, error) {
 	}
 
 	if errs := parallel.Run(loadingFuncs...); len(errs) > 0 {
-		return g, utilerrors.NewAggregate(errs)
+		actualErrors := []error{}
+		for _, err := range errs {
+			if kapierrors.IsForbidden(err) || kapierrors.IsNotFound(err) {
+				glog.V(4).Infof("Skipping inaccessible resources: %v", err)
+				continue
+			}
+			actualErrors = append(actualErrors, err)
+		}
+		if len(actualErrors) > 0 {
+			return g, utilerrors.NewAggregate(actualErrors)
+		}
 	}
 
 	for _, loader := range loaders {
@@ -70,6 +90,7 @@ func (d *ChainDescriber) MakeGraph() (osgraph.Graph, error) {
 	}
 
 	buildedges.AddAllInputOutputEdges(g)
+	deployedges.AddAllTriggerEdges(g)
 
 	return g, nil
 }
@@ -77,8 +98,11 @@ func (d *ChainDescriber) MakeGraph() (osgraph.Graph, error) {
 // Describe returns the output of the graph starting from the provided
 // image stream tag (name:tag) in namespace. Namespace is needed here
 // because image stream tags with the same name can be found across
-// different namespaces.
-func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImages bool) (string, error) {
+// different namespaces. By default the graph contains everything that
+// would be rebuilt or redeployed if the image stream tag changed; if
+// reverse is true it contains the builds and images the image stream
+// tag is built from instead.
+func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImages, reverse bool) (string, error) {
 	g, err := d.MakeGraph()
 	if err != nil {
 		return "", err
@@ -90,6 +114,10 @@ func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImag
 		return "", NotFoundErr(fmt.Sprintf("%q", ist.Name))
 	}
 
+	if reverse {
+		g = g.EdgeSubgraph(osgraph.ReverseGraphEdge)
+	}
+
 	buildInputEdgeKinds := []string{buildedges.BuildTriggerImageEdgeKind}
 	if includeInputImages {
 		buildInputEdgeKinds = append(buildInputEdgeKinds, buildedges.BuildInputImageEdgeKind)
@@ -105,6 +133,12 @@ func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImag
 			return "", err
 		}
 		return string(data), nil
+	case "json":
+		data, err := osgraph.MarshalJSON(partitioned, ist.Name, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
 	case "":
 		return d.humanReadableOutput(partitioned, istNode), nil
 	}
@@ -114,12 +148,12 @@ func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImag
 
 // partition the graph down to a subgraph starting from the given root
 func partition(g osgraph.Graph, root graph.Node, buildInputEdgeKinds []string) osgraph.Graph {
-	// Filter out all but BuildConfig and ImageStreamTag nodes
-	nodeFn := osgraph.NodesOfKind(buildgraph.BuildConfigNodeKind, imagegraph.ImageStreamTagNodeKind)
-	// Filter out all but BuildInputImage and BuildOutput edges
+	// Filter out all but BuildConfig, DeploymentConfig and ImageStreamTag nodes
+	nodeFn := osgraph.NodesOfKind(buildgraph.BuildConfigNodeKind, deploygraph.DeploymentConfigNodeKind, imagegraph.ImageStreamTagNodeKind)
+	// Filter out all but BuildInputImage, BuildOutput and TriggersDeployment edges
 	edgeKinds := []string{}
 	edgeKinds = append(edgeKinds, buildInputEdgeKinds...)
-	edgeKinds = append(edgeKinds, buildedges.BuildOutputEdgeKind)
+	edgeKinds = append(edgeKinds, buildedges.BuildOutputEdgeKind, deployedges.TriggersDeploymentEdgeKind)
 	edgeFn := osgraph.EdgesOfKind(edgeKinds...)
 	sub := g.Subgraph(nodeFn, edgeFn)
 
@@ -146,7 +180,7 @@ func partition(g osgraph.Graph, root graph.Node, buildInputEdgeKinds []string) o
 // humanReadableOutput traverses the provided graph using DFS and outputs it
 // in a human-readable format. It starts from the provided root, assuming it
 // is an imageStreamTag node and continues to the rest of the graph handling
-// only imageStreamTag and buildConfig nodes.
+// only imageStreamTag, buildConfig and deploymentConfig nodes.
 func (d *ChainDescriber) humanReadableOutput(g osgraph.Graph, root graph.Node) string {
 	var singleNamespace bool
 	if len(d.namespaces) == 1 && !d.namespaces.Has(kapi.NamespaceAll) {
@@ -171,8 +205,10 @@ func (d *ChainDescriber) humanReadableOutput(g osgraph.Graph, root graph.Node) s
 			info = outputHelper(t.ResourceString(), t.Namespace, singleNamespace)
 		case *buildgraph.BuildConfigNode:
 			info = outputHelper(t.ResourceString(), t.BuildConfig.Namespace, singleNamespace)
+		case *deploygraph.DeploymentConfigNode:
+			info = outputHelper(t.ResourceString(), t.DeploymentConfig.Namespace, singleNamespace)
 		default:
-			panic("this graph contains node kinds other than imageStreamTags and buildConfigs")
+			panic("this graph contains node kinds other than imageStreamTags, buildConfigs and deploymentConfigs")
 		}
 
 		if depth[node] != 0 {
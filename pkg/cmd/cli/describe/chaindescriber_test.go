@@ -27,6 +27,7 @@ func TestChainDescriber(t *testing.T) {
 		dot              []string
 		expectedErr      error
 		includeInputImg  bool
+		reverse          bool
 	}{
 		{
 			testName:         "human readable test - single namespace",
@@ -71,6 +72,38 @@ func TestChainDescriber(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			testName:         "human readable test - reverse",
+			namespaces:       sets.NewString("test"),
+			output:           "",
+			defaultNamespace: "test",
+			name:             "ruby-hello-world",
+			tag:              "latest",
+			path:             "../../../../pkg/cmd/experimental/buildchain/test/single-namespace-bcs.yaml",
+			reverse:          true,
+			humanReadable: map[string]int{
+				"imagestreamtag/ruby-hello-world:latest":    1,
+				"\tbc/ruby-hello-world":                     1,
+				"\t\timagestreamtag/ruby-22-centos7:latest": 1,
+			},
+			expectedErr: nil,
+		},
+		{
+			testName:         "human readable test - deployment configs",
+			namespaces:       sets.NewString("test"),
+			output:           "",
+			defaultNamespace: "test",
+			name:             "ruby-22-centos7",
+			tag:              "latest",
+			path:             "../../../../pkg/cmd/experimental/buildchain/test/single-namespace-bcs-dcs.yaml",
+			humanReadable: map[string]int{
+				"imagestreamtag/ruby-22-centos7:latest":        1,
+				"\tbc/ruby-sample-build":                       1,
+				"\t\timagestreamtag/origin-ruby-sample:latest": 1,
+				"\t\t\tdc/frontend":                            1,
+			},
+			expectedErr: nil,
+		},
 		{
 			testName:         "human readable test - multiple namespaces",
 			namespaces:       sets.NewString("test", "master", "default"),
@@ -174,7 +207,7 @@ func TestChainDescriber(t *testing.T) {
 		oc, _ := testclient.NewFixtureClients(o)
 		ist := imagegraph.MakeImageStreamTagObjectMeta(test.defaultNamespace, test.name, test.tag)
 
-		desc, err := NewChainDescriber(oc, test.namespaces, test.output).Describe(ist, test.includeInputImg)
+		desc, err := NewChainDescriber(oc, oc, test.namespaces, test.output).Describe(ist, test.includeInputImg, test.reverse)
 		t.Logf("%s: output:\n%s\n\n", test.testName, desc)
 		if err != test.expectedErr {
 			t.Fatalf("%s: error mismatch: expected %v, got %v", test.testName, test.expectedErr, err)
@@ -2,6 +2,8 @@ package describe
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -28,6 +30,7 @@ import (
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildutil "github.com/openshift/origin/pkg/build/util"
+	certapi "github.com/openshift/origin/pkg/certificates/api"
 	"github.com/openshift/origin/pkg/client"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	projectapi "github.com/openshift/origin/pkg/project/api"
@@ -58,6 +61,8 @@ func describerMap(c *client.Client, kclient kclient.Interface, host string) map[
 		"User":                 &UserDescriber{c},
 		"Group":                &GroupDescriber{c.Groups()},
 		"UserIdentityMapping":  &UserIdentityMappingDescriber{c},
+
+		"CertificateSigningRequest": &CertificateSigningRequestDescriber{c.CertificateSigningRequests()},
 	}
 	return m
 }
@@ -245,6 +250,10 @@ func describeBuildSpec(p buildapi.BuildSpec, out *tabwriter.Writer) {
 		formatString(out, "Push Secret", p.Output.PushSecret.Name)
 	}
 
+	for _, label := range p.Output.ImageLabels {
+		formatString(out, "Image Label", fmt.Sprintf("%s=%s", label.Name, label.Value))
+	}
+
 	if p.Revision != nil && p.Revision.Git != nil {
 		buildDescriber := &BuildDescriber{}
 
@@ -294,6 +303,9 @@ func describeDockerStrategy(s *buildapi.DockerBuildStrategy, out *tabwriter.Writ
 	if len(s.DockerfilePath) != 0 {
 		formatString(out, "Dockerfile Path", s.DockerfilePath)
 	}
+	if len(s.Target) != 0 {
+		formatString(out, "Target Stage", s.Target)
+	}
 	if s.PullSecret != nil {
 		formatString(out, "Pull Secret Name", s.PullSecret.Name)
 	}
@@ -361,6 +373,12 @@ func describeBuildTriggers(triggers []buildapi.BuildTriggerPolicy, w *tabwriter.
 			} else {
 				labels = append(labels, string(t.Type))
 			}
+		case buildapi.ScheduleBuildTriggerType:
+			if t.Schedule != nil && len(t.Schedule.Cron) > 0 {
+				labels = append(labels, fmt.Sprintf("Schedule(%s)", t.Schedule.Cron))
+			} else {
+				labels = append(labels, string(t.Type))
+			}
 		case "":
 			labels = append(labels, "<unknown>")
 		default:
@@ -946,6 +964,58 @@ func (d *GroupDescriber) Describe(namespace, name string) (string, error) {
 	})
 }
 
+// CertificateSigningRequestDescriber generates information about a certificate signing request
+type CertificateSigningRequestDescriber struct {
+	c client.CertificateSigningRequestInterface
+}
+
+// Describe returns the description of a certificate signing request
+func (d *CertificateSigningRequestDescriber) Describe(namespace, name string) (string, error) {
+	csr, err := d.c.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		formatMeta(out, csr.ObjectMeta)
+		formatString(out, "Requestor", csr.Spec.Username)
+		formatString(out, "Requestor Groups", strings.Join(csr.Spec.Groups, ", "))
+		formatString(out, "Usage", csr.Spec.Usage)
+
+		if request, err := certapi.ParseCertificateRequest(csr); err != nil {
+			formatString(out, "Request", fmt.Sprintf("<invalid: %v>", err))
+		} else {
+			formatString(out, "Subject", request.Subject.CommonName)
+			if len(request.Subject.Organization) > 0 {
+				formatString(out, "Subject Groups", strings.Join(request.Subject.Organization, ", "))
+			}
+			hostnames := append([]string{}, request.DNSNames...)
+			for _, ip := range request.IPAddresses {
+				hostnames = append(hostnames, ip.String())
+			}
+			if len(hostnames) > 0 {
+				formatString(out, "Hostnames", strings.Join(hostnames, ", "))
+			}
+		}
+
+		if condition := certapi.GetCondition(csr); condition == nil {
+			formatString(out, "Condition", "Pending")
+		} else {
+			formatString(out, "Condition", fmt.Sprintf("%s %s ago (%s)", condition.Type, formatRelativeTime(condition.LastUpdateTime.Time), condition.Reason))
+			if len(condition.Message) > 0 {
+				formatString(out, "Message", condition.Message)
+			}
+		}
+
+		if block, _ := pem.Decode(csr.Status.Certificate); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				formatString(out, "Certificate Expires", cert.NotAfter)
+			}
+		}
+		return nil
+	})
+}
+
 // policy describers
 
 // PolicyDescriber generates information about a Project
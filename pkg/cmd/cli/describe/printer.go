@@ -9,14 +9,20 @@ import (
 	"text/tabwriter"
 	"time"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	kctl "k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/util/sets"
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 	projectapi "github.com/openshift/origin/pkg/project/api"
@@ -59,16 +65,34 @@ var (
 	hostSubnetColumns     = []string{"NAME", "HOST", "HOST IP", "SUBNET"}
 	netNamespaceColumns   = []string{"NAME", "NETID"}
 	clusterNetworkColumns = []string{"NAME", "NETWORK", "HOST SUBNET LENGTH", "SERVICE NETWORK"}
+
+	certificateSigningRequestColumns = []string{"NAME", "REQUESTOR", "USAGE", "CONDITION", "AGE"}
+
+	// the columns added by -o wide
+	buildConfigWideColumns      = []string{"TRIGGERS", "LAST BUILD STATUS"}
+	routeWideColumns            = []string{"PORT"}
+	deploymentConfigWideColumns = []string{"DESIRED", "CURRENT", "STATUS"}
 )
 
+// unknownStatus is printed when the status of the latest build or deployment of a config could not be looked up
+const unknownStatus = "<unknown>"
+
+// withWideColumns returns columns followed by wideColumns if wide output is requested
+func withWideColumns(wide bool, columns, wideColumns []string) []string {
+	if !wide {
+		return columns
+	}
+	return append(append([]string{}, columns...), wideColumns...)
+}
+
 // NewHumanReadablePrinter returns a new HumanReadablePrinter
 func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool, columnLabels []string) *kctl.HumanReadablePrinter {
 	// TODO: support cross namespace listing
 	p := kctl.NewHumanReadablePrinter(noHeaders, withNamespace, wide, showAll, columnLabels)
 	p.Handler(buildColumns, printBuild)
 	p.Handler(buildColumns, printBuildList)
-	p.Handler(buildConfigColumns, printBuildConfig)
-	p.Handler(buildConfigColumns, printBuildConfigList)
+	p.Handler(withWideColumns(wide, buildConfigColumns, buildConfigWideColumns), printBuildConfig)
+	p.Handler(withWideColumns(wide, buildConfigColumns, buildConfigWideColumns), printBuildConfigList)
 	p.Handler(imageColumns, printImage)
 	p.Handler(imageStreamTagColumns, printImageStreamTag)
 	p.Handler(imageStreamTagColumns, printImageStreamTagList)
@@ -78,10 +102,10 @@ func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool,
 	p.Handler(imageStreamColumns, printImageStreamList)
 	p.Handler(projectColumns, printProject)
 	p.Handler(projectColumns, printProjectList)
-	p.Handler(routeColumns, printRoute)
-	p.Handler(routeColumns, printRouteList)
-	p.Handler(deploymentConfigColumns, printDeploymentConfig)
-	p.Handler(deploymentConfigColumns, printDeploymentConfigList)
+	p.Handler(withWideColumns(wide, routeColumns, routeWideColumns), printRoute)
+	p.Handler(withWideColumns(wide, routeColumns, routeWideColumns), printRouteList)
+	p.Handler(withWideColumns(wide, deploymentConfigColumns, deploymentConfigWideColumns), printDeploymentConfig)
+	p.Handler(withWideColumns(wide, deploymentConfigColumns, deploymentConfigWideColumns), printDeploymentConfigList)
 	p.Handler(templateColumns, printTemplate)
 	p.Handler(templateColumns, printTemplateList)
 
@@ -129,9 +153,54 @@ func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool,
 	p.Handler(clusterNetworkColumns, printClusterNetwork)
 	p.Handler(clusterNetworkColumns, printClusterNetworkList)
 
+	p.Handler(certificateSigningRequestColumns, printCertificateSigningRequest)
+	p.Handler(certificateSigningRequestColumns, printCertificateSigningRequestList)
+
 	return p
 }
 
+// AddStatusHandlers makes the wide output of p report the status of the last build of build configs and of the
+// latest deployment of deployment configs, which are looked up through the given clients.
+func AddStatusHandlers(p *kctl.HumanReadablePrinter, builds client.BuildsNamespacer, deployments kclient.ReplicationControllersNamespacer) {
+	lastBuildStatus := func(bc *buildapi.BuildConfig) string {
+		build, err := builds.Builds(bc.Namespace).Get(buildutil.BuildNameForConfigVersion(bc.Name, bc.Status.LastVersion))
+		if err != nil {
+			return unknownStatus
+		}
+		return string(build.Status.Phase)
+	}
+	latestDeployment := func(dc *deployapi.DeploymentConfig) *kapi.ReplicationController {
+		deployment, err := deployments.ReplicationControllers(dc.Namespace).Get(deployutil.LatestDeploymentNameForConfig(dc))
+		if err != nil {
+			return nil
+		}
+		return deployment
+	}
+
+	p.Handler(withWideColumns(true, buildConfigColumns, buildConfigWideColumns), func(bc *buildapi.BuildConfig, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+		return printBuildConfigWithStatus(bc, lastBuildStatus, w, withNamespace, wide)
+	})
+	p.Handler(withWideColumns(true, buildConfigColumns, buildConfigWideColumns), func(list *buildapi.BuildConfigList, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+		for i := range list.Items {
+			if err := printBuildConfigWithStatus(&list.Items[i], lastBuildStatus, w, withNamespace, wide); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	p.Handler(withWideColumns(true, deploymentConfigColumns, deploymentConfigWideColumns), func(dc *deployapi.DeploymentConfig, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+		return printDeploymentConfigWithStatus(dc, latestDeployment, w, withNamespace, wide)
+	})
+	p.Handler(withWideColumns(true, deploymentConfigColumns, deploymentConfigWideColumns), func(list *deployapi.DeploymentConfigList, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+		for i := range list.Items {
+			if err := printDeploymentConfigWithStatus(&list.Items[i], latestDeployment, w, withNamespace, wide); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 const templateDescriptionLen = 80
 
 // PrintTemplateParameters the Template parameters with their default values
@@ -277,19 +346,38 @@ func printBuildList(buildList *buildapi.BuildList, w io.Writer, withNamespace, w
 }
 
 func printBuildConfig(bc *buildapi.BuildConfig, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
-	if bc.Spec.Strategy.CustomStrategy != nil {
-		_, err := fmt.Fprintf(w, "%s\t%v\t%s\t%d\n", bc.Name, buildapi.StrategyType(bc.Spec.Strategy), bc.Spec.Strategy.CustomStrategy.From.Name, bc.Status.LastVersion)
-		return err
-	}
+	return printBuildConfigWithStatus(bc, func(*buildapi.BuildConfig) string { return unknownStatus }, w, withNamespace, wide)
+}
 
+// printBuildConfigWithStatus prints bc, using lastBuildStatus to find the status of its last build for wide output
+func printBuildConfigWithStatus(bc *buildapi.BuildConfig, lastBuildStatus func(*buildapi.BuildConfig) string, w io.Writer, withNamespace, wide bool) error {
 	from := describeSourceShort(bc.Spec.BuildSpec)
+	if bc.Spec.Strategy.CustomStrategy != nil {
+		from = bc.Spec.Strategy.CustomStrategy.From.Name
+	}
 
 	if withNamespace {
 		if _, err := fmt.Fprintf(w, "%s\t", bc.Namespace); err != nil {
 			return err
 		}
 	}
-	_, err := fmt.Fprintf(w, "%s\t%v\t%s\t%d\n", bc.Name, buildapi.StrategyType(bc.Spec.Strategy), from, bc.Status.LastVersion)
+	if _, err := fmt.Fprintf(w, "%s\t%v\t%s\t%d", bc.Name, buildapi.StrategyType(bc.Spec.Strategy), from, bc.Status.LastVersion); err != nil {
+		return err
+	}
+	if wide {
+		triggers := sets.String{}
+		for _, trigger := range bc.Spec.Triggers {
+			triggers.Insert(string(trigger.Type))
+		}
+		status := "<none>"
+		if bc.Status.LastVersion > 0 {
+			status = lastBuildStatus(bc)
+		}
+		if _, err := fmt.Fprintf(w, "\t%s\t%s", strings.Join(triggers.List(), ", "), status); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
 	return err
 }
 
@@ -437,8 +525,20 @@ func printRoute(route *routeapi.Route, w io.Writer, withNamespace, wide, showAll
 			return err
 		}
 	}
-	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-		route.Name, route.Spec.Host, route.Spec.Path, route.Spec.To.Name, labels.Set(route.Labels), insecurePolicy, tlsTerm)
+	if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s",
+		route.Name, route.Spec.Host, route.Spec.Path, route.Spec.To.Name, labels.Set(route.Labels), insecurePolicy, tlsTerm); err != nil {
+		return err
+	}
+	if wide {
+		port := "<none>"
+		if route.Spec.Port != nil {
+			port = route.Spec.Port.TargetPort.String()
+		}
+		if _, err := fmt.Fprintf(w, "\t%s", port); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
 	return err
 }
 
@@ -452,6 +552,11 @@ func printRouteList(routeList *routeapi.RouteList, w io.Writer, withNamespace, w
 }
 
 func printDeploymentConfig(dc *deployapi.DeploymentConfig, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+	return printDeploymentConfigWithStatus(dc, func(*deployapi.DeploymentConfig) *kapi.ReplicationController { return nil }, w, withNamespace, wide)
+}
+
+// printDeploymentConfigWithStatus prints dc, using latestDeployment to find its latest deployment for wide output
+func printDeploymentConfigWithStatus(dc *deployapi.DeploymentConfig, latestDeployment func(*deployapi.DeploymentConfig) *kapi.ReplicationController, w io.Writer, withNamespace, wide bool) error {
 	triggers := sets.String{}
 	for _, trigger := range dc.Spec.Triggers {
 		triggers.Insert(string(trigger.Type))
@@ -463,7 +568,23 @@ func printDeploymentConfig(dc *deployapi.DeploymentConfig, w io.Writer, withName
 			return err
 		}
 	}
-	_, err := fmt.Fprintf(w, "%s\t%s\t%v\n", dc.Name, tStr, dc.Status.LatestVersion)
+	if _, err := fmt.Fprintf(w, "%s\t%s\t%v", dc.Name, tStr, dc.Status.LatestVersion); err != nil {
+		return err
+	}
+	if wide {
+		current, status := "<none>", "<none>"
+		if dc.Status.LatestVersion > 0 {
+			current, status = unknownStatus, unknownStatus
+			if deployment := latestDeployment(dc); deployment != nil {
+				current = fmt.Sprintf("%d", deployment.Status.Replicas)
+				status = string(deployutil.DeploymentStatusFor(deployment))
+			}
+		}
+		if _, err := fmt.Fprintf(w, "\t%d\t%s\t%s", dc.Spec.Replicas, current, status); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
 	return err
 }
 
@@ -757,3 +878,27 @@ func printClusterNetworkList(list *sdnapi.ClusterNetworkList, w io.Writer, withN
 	}
 	return nil
 }
+
+func printCertificateSigningRequest(csr *certapi.CertificateSigningRequest, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+	condition := "Pending"
+	switch {
+	case certapi.IsDenied(csr):
+		condition = "Denied"
+	case len(csr.Status.Certificate) > 0:
+		condition = "Approved,Issued"
+	case certapi.IsApproved(csr):
+		condition = "Approved"
+	}
+	age := formatRelativeTime(csr.CreationTimestamp.Time)
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", csr.Name, csr.Spec.Username, csr.Spec.Usage, condition, age)
+	return err
+}
+
+func printCertificateSigningRequestList(list *certapi.CertificateSigningRequestList, w io.Writer, withNamespace, wide, showAll bool, columnLabels []string) error {
+	for _, item := range list.Items {
+		if err := printCertificateSigningRequest(&item, w, withNamespace, wide, showAll, columnLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
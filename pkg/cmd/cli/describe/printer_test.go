@@ -6,17 +6,23 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"text/tabwriter"
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	kctl "k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	projectapi "github.com/openshift/origin/pkg/project/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
 )
 
 // PrinterCoverageExceptions is the list of API types that do NOT have corresponding printers
@@ -231,3 +237,181 @@ func mockStreams() []*imageapi.ImageStream {
 		},
 	}
 }
+
+func TestSortAndCustomColumnsForOriginTypes(t *testing.T) {
+	now := unversioned.Now()
+	earlier := unversioned.NewTime(now.Add(-time.Hour))
+
+	testCases := map[string]struct {
+		list     runtime.Object
+		field    string
+		expected []string
+	}{
+		"builds by start time": {
+			list: &buildapi.BuildList{Items: []buildapi.Build{
+				{ObjectMeta: kapi.ObjectMeta{Name: "running"}, Status: buildapi.BuildStatus{StartTimestamp: &now}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "new"}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "complete"}, Status: buildapi.BuildStatus{StartTimestamp: &earlier}},
+			}},
+			field:    ".status.startTimestamp",
+			expected: []string{"new", "complete", "running"},
+		},
+		"routes by host": {
+			list: &routeapi.RouteList{Items: []routeapi.Route{
+				{ObjectMeta: kapi.ObjectMeta{Name: "www"}, Spec: routeapi.RouteSpec{Host: "www.example.com"}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "api"}, Spec: routeapi.RouteSpec{Host: "api.example.com"}},
+			}},
+			field:    ".spec.host",
+			expected: []string{"api", "www"},
+		},
+		"image stream tags by creation": {
+			list: &imageapi.ImageStreamTagList{Items: []imageapi.ImageStreamTag{
+				{ObjectMeta: kapi.ObjectMeta{Name: "ruby:latest", CreationTimestamp: now}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "ruby:2.0", CreationTimestamp: earlier}},
+			}},
+			field:    ".metadata.creationTimestamp",
+			expected: []string{"ruby:2.0", "ruby:latest"},
+		},
+	}
+
+	for name, tc := range testCases {
+		items, err := runtime.ExtractList(tc.list)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		// the list printed with -o custom-columns is converted to v1 with raw items
+		generic, err := kapi.Scheme.ConvertToVersion(&kapi.List{Items: items}, "v1")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		columns, err := kctl.NewCustomColumnsPrinterFromSpec("NAME:.metadata.name,FIELD:" + tc.field)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		printers := map[string]struct {
+			list    runtime.Object
+			printer kctl.ResourcePrinter
+		}{
+			"human readable": {tc.list, NewHumanReadablePrinter(false, false, false, true, nil)},
+			"custom columns": {generic, columns},
+		}
+
+		for kind, p := range printers {
+			printer := &kctl.SortingPrinter{SortField: "{" + tc.field + "}", Delegate: p.printer}
+			out := &bytes.Buffer{}
+			if err := printer.PrintObj(p.list, out); err != nil {
+				t.Errorf("%s, %s: unexpected error: %v", name, kind, err)
+				continue
+			}
+			names := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+				names = append(names, strings.Fields(line)[0])
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("%s, %s: expected %v, got:\n%s", name, kind, tc.expected, out.String())
+			}
+		}
+	}
+}
+
+func TestWideOutput(t *testing.T) {
+	bc := &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "ruby", Namespace: "test"},
+		Spec: buildapi.BuildConfigSpec{
+			Triggers: []buildapi.BuildTriggerPolicy{{Type: buildapi.ImageChangeBuildTriggerType}, {Type: buildapi.GitHubWebHookBuildTriggerType}},
+			BuildSpec: buildapi.BuildSpec{
+				Source:   buildapi.BuildSource{Git: &buildapi.GitBuildSource{URI: "https://github.com/openshift/ruby-hello-world"}},
+				Strategy: buildapi.BuildStrategy{SourceStrategy: &buildapi.SourceBuildStrategy{}},
+			},
+		},
+		Status: buildapi.BuildConfigStatus{LastVersion: 2},
+	}
+	dc := &deployapi.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: "test"},
+		Spec: deployapi.DeploymentConfigSpec{
+			Triggers: []deployapi.DeploymentTriggerPolicy{{Type: deployapi.DeploymentTriggerOnConfigChange}},
+			Replicas: 3,
+		},
+		Status: deployapi.DeploymentConfigStatus{LatestVersion: 1},
+	}
+	route := &routeapi.Route{
+		ObjectMeta: kapi.ObjectMeta{Name: "www", Namespace: "test"},
+		Spec: routeapi.RouteSpec{
+			Host: "www.example.com",
+			To:   kapi.ObjectReference{Name: "frontend"},
+			Port: &routeapi.RoutePort{TargetPort: util.NewIntOrStringFromString("http")},
+			TLS:  &routeapi.TLSConfig{Termination: routeapi.TLSTerminationEdge},
+		},
+	}
+	build := &buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{Name: "ruby-2", Namespace: "test"},
+		Status:     buildapi.BuildStatus{Phase: buildapi.BuildPhaseFailed},
+	}
+	deployment := &kapi.ReplicationController{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        "frontend-1",
+			Namespace:   "test",
+			Annotations: map[string]string{deployapi.DeploymentStatusAnnotation: string(deployapi.DeploymentStatusComplete)},
+		},
+		Status: kapi.ReplicationControllerStatus{Replicas: 2},
+	}
+
+	testCases := map[string]struct {
+		obj          runtime.Object
+		withStatus   bool
+		expectedCols []string
+	}{
+		"build config": {
+			obj:          bc,
+			expectedCols: []string{"ruby", "Source", "Git", "2", "GitHub, ImageChange", "<unknown>"},
+		},
+		"build config with status": {
+			obj:          bc,
+			withStatus:   true,
+			expectedCols: []string{"ruby", "Source", "Git", "2", "GitHub, ImageChange", "Failed"},
+		},
+		"deployment config": {
+			obj:          dc,
+			expectedCols: []string{"frontend", "ConfigChange", "1", "3", "<unknown>", "<unknown>"},
+		},
+		"deployment config with status": {
+			obj:          dc,
+			withStatus:   true,
+			expectedCols: []string{"frontend", "ConfigChange", "1", "3", "2", "Complete"},
+		},
+		"route": {
+			obj:          route,
+			expectedCols: []string{"www", "www.example.com", "", "frontend", "", "", "edge", "http"},
+		},
+	}
+
+	for name, tc := range testCases {
+		printer := NewHumanReadablePrinter(false, false, true, false, nil)
+		if tc.withStatus {
+			AddStatusHandlers(printer, testclient.NewSimpleFake(build), ktestclient.NewSimpleFake(deployment))
+		}
+		out := &bytes.Buffer{}
+		w := tabwriter.NewWriter(out, 0, 8, 1, ' ', tabwriter.Debug)
+		if err := printer.PrintObj(tc.obj, w); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		w.Flush()
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 2 {
+			t.Errorf("%s: expected a header and a row, got:\n%s", name, out.String())
+			continue
+		}
+		headers, cols := strings.Split(lines[0], "|"), strings.Split(lines[1], "|")
+		for i := range cols {
+			cols[i] = strings.TrimSpace(cols[i])
+		}
+		if len(headers) != len(cols) {
+			t.Errorf("%s: expected a column for each header, got:\n%s", name, out.String())
+		}
+		if !reflect.DeepEqual(cols, tc.expectedCols) {
+			t.Errorf("%s: expected columns %q, got %q", name, tc.expectedCols, cols)
+		}
+	}
+}
@@ -302,6 +302,7 @@ func getMarkerScanners() []osgraph.MarkerScanner {
 		buildanalysis.FindCircularBuilds,
 		buildanalysis.FindPendingTags,
 		deployanalysis.FindDeploymentConfigTriggerErrors,
+		deployanalysis.FindDeploymentConfigReadinessWarnings,
 		routeanalysis.FindMissingPortMapping,
 		routeanalysis.FindMissingTLSTerminationType,
 
@@ -0,0 +1,159 @@
+package grants
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/cli/describe"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	GrantsRecommendedName = "grants"
+	ListRecommendedName   = "list"
+	RevokeRecommendedName = "revoke"
+)
+
+const (
+	grantsLong = `
+Manage the access you granted to OAuth clients
+
+When you log in to an OAuth client, such as the web console or a build server, you may be asked
+to grant it access to your account. The client then gets access tokens that act on your behalf.
+These commands show the clients you granted access to and let you revoke that access. Revoking
+a grant also deletes the access tokens you granted to the client, so it has to ask for access
+again.`
+
+	listGrantsLong = `
+List the OAuth clients you granted access to your account`
+
+	revokeGrantsLong = `
+Revoke the access you granted to OAuth clients
+
+The access tokens you granted to the clients are deleted, and the clients must ask for your
+approval again the next time you log in to them.`
+
+	revokeGrantsExample = `  # Revoke the access of the client named jenkins
+  $ %[1]s jenkins`
+)
+
+func NewCmdGrants(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	// Parent command to which all subcommands are added.
+	cmds := &cobra.Command{
+		Use:     name,
+		Short:   "Manage the access granted to OAuth clients",
+		Long:    grantsLong,
+		Aliases: []string{"grant"},
+		Run:     cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdListGrants(ListRecommendedName, fullName+" "+ListRecommendedName, f, out))
+	cmds.AddCommand(NewCmdRevokeGrants(RevokeRecommendedName, fullName+" "+RevokeRecommendedName, f, out))
+
+	return cmds
+}
+
+// GrantsOptions lists or revokes the client authorizations of the current user
+type GrantsOptions struct {
+	Client client.UserOAuthClientAuthorizationInterface
+	Out    io.Writer
+
+	ClientNames []string
+}
+
+func NewCmdListGrants(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &GrantsOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: "List the OAuth clients you granted access to",
+		Long:  listGrantsLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "no arguments are allowed"))
+			}
+			kcmdutil.CheckErr(options.Complete(f))
+			kcmdutil.CheckErr(options.List())
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdRevokeGrants(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &GrantsOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " CLIENT [CLIENT ...]",
+		Short:   "Revoke the access granted to OAuth clients",
+		Long:    revokeGrantsLong,
+		Example: fmt.Sprintf(revokeGrantsExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "you must specify at least one client: CLIENT [CLIENT ...]"))
+			}
+			options.ClientNames = args
+			kcmdutil.CheckErr(options.Complete(f))
+			kcmdutil.CheckErr(options.Revoke())
+		},
+	}
+
+	return cmd
+}
+
+func (o *GrantsOptions) Complete(f *clientcmd.Factory) error {
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient.UserOAuthClientAuthorizations()
+	return nil
+}
+
+// List prints the clients the current user granted access to, with the granted scopes
+func (o *GrantsOptions) List() error {
+	authorizations, err := o.Client.List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	if len(authorizations.Items) == 0 {
+		fmt.Fprintln(o.Out, "You have not granted access to any clients.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(o.Out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "CLIENT\tSCOPES\tAGE")
+	for _, authorization := range authorizations.Items {
+		scopes := "<none>"
+		if len(authorization.Scopes) > 0 {
+			scopes = strings.Join(authorization.Scopes, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", authorization.ClientName, scopes, describe.FormatRelativeTime(authorization.CreationTimestamp.Time))
+	}
+	return nil
+}
+
+// Revoke deletes the client authorizations of the current user for the named clients
+func (o *GrantsOptions) Revoke() error {
+	errs := []error{}
+	for _, clientName := range o.ClientNames {
+		if err := o.Client.Delete(clientName); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(o.Out, "access of client %q revoked\n", clientName)
+	}
+	return kerrors.NewAggregate(errs)
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	gorillahandlers "github.com/gorilla/handlers"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Sirupsen/logrus/formatters/logstash"
 	"github.com/docker/distribution/configuration"
@@ -20,6 +23,8 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/docker/distribution/registry/auth"
 	"github.com/docker/distribution/registry/handlers"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/factory"
 	"github.com/docker/distribution/uuid"
 	"github.com/docker/distribution/version"
 
@@ -31,8 +36,12 @@ import (
 	_ "github.com/docker/distribution/registry/storage/driver/s3"
 	_ "github.com/docker/distribution/registry/storage/driver/swift"
 
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
 	"github.com/openshift/origin/pkg/cmd/server/crypto"
 	"github.com/openshift/origin/pkg/dockerregistry/server"
+	"github.com/openshift/origin/pkg/util/proc"
 )
 
 // Execute runs the Docker registry.
@@ -54,6 +63,10 @@ func Execute(configFile io.Reader) {
 
 	app := handlers.NewApp(ctx, config)
 
+	if len(config.HTTP.Debug.Addr) > 0 {
+		go debugServer(config.HTTP.Debug.Addr)
+	}
+
 	// TODO add https scheme
 	adminRouter := app.NewRoute().PathPrefix("/admin/").Subrouter()
 
@@ -93,7 +106,21 @@ func Execute(configFile io.Reader) {
 			context.GetLogger(app).Fatalln(err)
 		}
 	} else {
-		tlsConf := crypto.SecureTLSConfig(&tls.Config{ClientAuth: tls.NoClientCert})
+		// The serving certificate is read again when the registry receives SIGHUP, so that it can be rotated
+		// without dropping connections
+		certificate := &reloadingCertificate{certFile: config.HTTP.TLS.Certificate, keyFile: config.HTTP.TLS.Key}
+		if err := certificate.reload(); err != nil {
+			context.GetLogger(app).Fatalln(err)
+		}
+		proc.StartReloader(func() {
+			if err := certificate.reload(); err != nil {
+				context.GetLogger(app).Errorf("unable to reload the serving certificate: %v", err)
+				return
+			}
+			context.GetLogger(app).Infof("reloaded the serving certificate")
+		})
+
+		tlsConf := crypto.SecureTLSConfig(&tls.Config{ClientAuth: tls.NoClientCert, GetCertificate: certificate.get})
 
 		if len(config.HTTP.TLS.ClientCAs) != 0 {
 			pool := x509.NewCertPool()
@@ -124,12 +151,98 @@ func Execute(configFile io.Reader) {
 			TLSConfig: tlsConf,
 		}
 
-		if err := server.ListenAndServeTLS(config.HTTP.TLS.Certificate, config.HTTP.TLS.Key); err != nil {
+		listener, err := net.Listen("tcp", config.HTTP.Addr)
+		if err != nil {
+			context.GetLogger(app).Fatalln(err)
+		}
+		if err := server.Serve(tls.NewListener(listener, tlsConf)); err != nil {
 			context.GetLogger(app).Fatalln(err)
 		}
 	}
 }
 
+// debugServer serves the expvar variables and the Prometheus metrics of the registry on addr, which should not
+// be reachable by the clients of the registry since the metrics name its repositories.
+func debugServer(addr string) {
+	http.Handle("/metrics", prometheus.Handler())
+	log.Infof("debug server listening on %v", addr)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		log.Fatalf("error serving the debug server: %v", err)
+	}
+}
+
+// ExecutePruner runs a hard prune of the storage of the Docker registry: the manifests, layer links and blobs
+// that no image of the cluster references are removed, or only listed if dryRun is true. The registry should
+// not accept pushes while the prune runs.
+func ExecutePruner(configFile io.Reader, dryRun bool) {
+	config, err := configuration.Parse(configFile)
+	if err != nil {
+		log.Fatalf("Error parsing configuration file: %s", err)
+	}
+
+	ctx := context.Background()
+	ctx, err = configureLogging(ctx, config)
+	if err != nil {
+		log.Fatalf("error configuring logger: %v", err)
+	}
+
+	driver, err := factory.Create(config.Storage.Type(), config.Storage.Parameters())
+	if err != nil {
+		log.Fatalf("error creating the storage driver: %v", err)
+	}
+	registry, err := storage.NewRegistry(ctx, driver, storage.EnableDelete, storage.RemoveParentsOnDelete)
+	if err != nil {
+		log.Fatalf("error creating the registry: %v", err)
+	}
+
+	client, err := server.NewRegistryOpenShiftClient()
+	if err != nil {
+		log.Fatalf("error creating the OpenShift client: %v", err)
+	}
+	images, err := client.Images().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		log.Fatalf("error listing the images: %v", err)
+	}
+
+	stats, err := server.HardPrune(ctx, registry, images.Items, dryRun, os.Stdout)
+	if err != nil {
+		log.Fatalf("error pruning the registry storage: %v", err)
+	}
+	verb := "Deleted"
+	if dryRun {
+		verb = "Would delete"
+	}
+	fmt.Printf("%s %d manifests, %d layer links and %d blobs\n", verb, stats.Manifests, stats.LayerLinks, stats.Blobs)
+}
+
+// reloadingCertificate serves the certificate and key read from files, until they are read again
+type reloadingCertificate struct {
+	certFile string
+	keyFile  string
+
+	lock        sync.RWMutex
+	certificate *tls.Certificate
+}
+
+// reload reads the certificate and key from their files, the previous certificate is kept if they are invalid
+func (c *reloadingCertificate) reload() error {
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.certificate = &certificate
+	return nil
+}
+
+// get returns the certificate to serve, whatever the client asks for
+func (c *reloadingCertificate) get(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.certificate, nil
+}
+
 // configureLogging prepares the context with a logger using the
 // configuration.
 func configureLogging(ctx context.Context, config *configuration.Configuration) (context.Context, error) {
@@ -24,9 +24,15 @@ const (
 	buildChainLong = `
 Output the inputs and dependencies of your builds
 
-Supported formats for the generated graph are dot and a human-readable output.
+By default the build configurations and deployment configurations that would be
+triggered if the image stream tag changed are shown. Use --reverse to show the
+build configurations and image stream tags the image stream tag is built from
+instead.
+
+Supported formats for the generated graph are dot, json and a human-readable output.
 Tag and namespace are optional and if they are not specified, 'latest' and the
-default namespace will be used respectively.`
+default namespace will be used respectively. When --all is specified, every
+namespace you have access to is searched.`
 
 	buildChainExample = `  # Build the dependency tree for the 'latest' tag in <image-stream>
   $ %[1]s <image-stream>
@@ -35,7 +41,10 @@ default namespace will be used respectively.`
   $ %[1]s <image-stream>:v2 -o dot | dot -T svg -o deps.svg
 
   # Build the dependency tree across all namespaces for the specified image stream tag found in 'test' namespace
-  $ %[1]s <image-stream> -n test --all`
+  $ %[1]s <image-stream> -n test --all
+
+  # Show what the 'latest' tag in <image-stream> is built from, in json format
+  $ %[1]s <image-stream> --reverse -o json`
 )
 
 // BuildChainRecommendedCommandName is the recommended command name
@@ -49,10 +58,12 @@ type BuildChainOptions struct {
 	namespaces       sets.String
 	allNamespaces    bool
 	triggerOnly      bool
+	reverse          bool
 
 	output string
 
 	c client.BuildConfigsNamespacer
+	d client.DeploymentConfigsNamespacer
 	t client.ImageStreamTagsNamespacer
 }
 
@@ -77,7 +88,8 @@ func NewCmdBuildChain(name, fullName string, f *clientcmd.Factory, out io.Writer
 
 	cmd.Flags().BoolVar(&options.allNamespaces, "all", false, "Build dependency tree for the specified image stream tag across all namespaces")
 	cmd.Flags().BoolVar(&options.triggerOnly, "trigger-only", true, "If true, only include dependencies based on build triggers. If false, include all dependencies.")
-	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output format of dependency tree")
+	cmd.Flags().BoolVar(&options.reverse, "reverse", false, "If true, show what the image stream tag is built from instead of what depends on it")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output format of dependency tree. One of: dot|json.")
 	return cmd
 }
 
@@ -92,7 +104,7 @@ func (o *BuildChainOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, a
 	if err != nil {
 		return err
 	}
-	o.c, o.t = oc, oc
+	o.c, o.d, o.t = oc, oc, oc
 
 	resource := ""
 	mapper, _ := f.Object()
@@ -143,8 +155,10 @@ func (o *BuildChainOptions) Validate() error {
 	if len(o.defaultNamespace) == 0 {
 		return fmt.Errorf("default namespace cannot be empty")
 	}
-	if o.output != "" && o.output != "dot" {
-		return fmt.Errorf("output must be either empty or 'dot'")
+	switch o.output {
+	case "", "dot", "json":
+	default:
+		return fmt.Errorf("output must be one of '', 'dot' or 'json'")
 	}
 	if o.c == nil {
 		return fmt.Errorf("buildConfig client must not be nil")
@@ -160,7 +174,7 @@ func (o *BuildChainOptions) Validate() error {
 func (o *BuildChainOptions) RunBuildChain() error {
 	ist := imagegraph.MakeImageStreamTagObjectMeta2(o.defaultNamespace, o.name)
 
-	desc, err := describe.NewChainDescriber(o.c, o.namespaces, o.output).Describe(ist, !o.triggerOnly)
+	desc, err := describe.NewChainDescriber(o.c, o.d, o.namespaces, o.output).Describe(ist, !o.triggerOnly, o.reverse)
 	if err != nil {
 		if _, isNotFoundErr := err.(describe.NotFoundErr); isNotFoundErr {
 			name, tag, _ := imageapi.SplitImageStreamTag(o.name)
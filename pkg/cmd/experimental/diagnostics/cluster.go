@@ -20,7 +20,7 @@ import (
 var (
 	// availableClusterDiagnostics contains the names of cluster diagnostics that can be executed
 	// during a single run of diagnostics. Add more diagnostics to the list as they are defined.
-	availableClusterDiagnostics = sets.NewString(clustdiags.NodeDefinitionsName, clustdiags.ClusterRegistryName, clustdiags.ClusterRouterName, clustdiags.ClusterRolesName, clustdiags.ClusterRoleBindingsName, clustdiags.MasterNodeName)
+	availableClusterDiagnostics = sets.NewString(clustdiags.NodeDefinitionsName, clustdiags.ClusterRegistryName, clustdiags.ClusterRouterName, clustdiags.ClusterRolesName, clustdiags.ClusterRoleBindingsName, clustdiags.MasterNodeName, clustdiags.ConfigConsistencyName, clustdiags.ServiceAccountsName)
 )
 
 // buildClusterDiagnostics builds cluster Diagnostic objects if a cluster-admin client can be extracted from the rawConfig passed in.
@@ -57,6 +57,10 @@ func (o DiagnosticsOptions) buildClusterDiagnostics(rawConfig *clientcmdapi.Conf
 			diagnostics = append(diagnostics, &clustdiags.ClusterRoles{ClusterRolesClient: clusterClient, SARClient: clusterClient})
 		case clustdiags.ClusterRoleBindingsName:
 			diagnostics = append(diagnostics, &clustdiags.ClusterRoleBindings{ClusterRoleBindingsClient: clusterClient, SARClient: clusterClient})
+		case clustdiags.ServiceAccountsName:
+			diagnostics = append(diagnostics, &clustdiags.ServiceAccounts{KubeClient: kclusterClient, OsClient: clusterClient})
+		case clustdiags.ConfigConsistencyName:
+			diagnostics = append(diagnostics, &clustdiags.ConfigConsistency{KubeClient: kclusterClient, OsClient: clusterClient, MasterConfigFile: o.MasterConfigLocation, NodeConfigFile: o.NodeConfigLocation})
 
 		default:
 			return nil, false, fmt.Errorf("unknown diagnostic: %v", diagnosticName)
@@ -3,8 +3,12 @@ package router
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	"github.com/openshift/origin/pkg/router/controller"
+	"github.com/openshift/origin/pkg/router/metrics/haproxy"
 	"github.com/openshift/origin/pkg/util/proc"
 	"github.com/openshift/origin/pkg/version"
 	templateplugin "github.com/openshift/origin/plugins/router/template"
@@ -28,6 +33,22 @@ created by users and keeps a local router configuration up to date with those ch
 
 You may customize the router by providing your own --template and --reload scripts.
 
+The default certificate may be read from a file with --default-certificate-path, for instance one mounted
+from a secret. The file is read again when the router receives SIGHUP, and the router configuration is
+reloaded without dropping connections if the certificate changed.
+
+Routes may configure the health checks of their endpoints with the annotations
+router.openshift.io/health-check-interval (e.g. 2s), router.openshift.io/health-check-path
+(e.g. /healthz) and router.openshift.io/health-check-expected-status (e.g. 200). Intervals
+shorter than 1s are raised to 1s. When --metrics-address is set, the health of the backends and endpoints of the HAProxy router is
+exposed for Prometheus at /metrics.
+
+Routes may restrict the clients allowed to reach them with the router.openshift.io/ip-whitelist
+annotation, a space separated list of addresses and CIDRs. When the router is behind load balancers or
+other proxies, list them with --trusted-proxies so that the whitelists and the X-Forwarded-For header
+received by applications use the address of the client reported by the proxies. The header is otherwise
+handled according to --forwarded-header-policy.
+
 You may restrict the set of routes exposed to a single project (with --namespace), projects your client has
 access to with a set of labels (--project-labels), namespaces matching a label (--namespace-labels), or all
 namespaces (no argument). You can limit the routes to those matching a --labels or --fields selector. Note
@@ -43,24 +64,41 @@ type TemplateRouterOptions struct {
 }
 
 type TemplateRouter struct {
-	WorkingDir         string
-	TemplateFile       string
-	ReloadScript       string
-	DefaultCertificate string
-	RouterService      *ktypes.NamespacedName
+	WorkingDir             string
+	TemplateFile           string
+	ReloadScript           string
+	DefaultCertificate     string
+	DefaultCertificatePath string
+	RouterService          *ktypes.NamespacedName
+	ForwardedHeaderPolicy  string
+	TrustedProxies         []string
 }
 
 func (o *TemplateRouter) Bind(flag *pflag.FlagSet) {
 	flag.StringVar(&o.WorkingDir, "working-dir", "/var/lib/containers/router", "The working directory for the router plugin")
 	flag.StringVar(&o.DefaultCertificate, "default-certificate", util.Env("DEFAULT_CERTIFICATE", ""), "A path to default certificate to use for routes that don't expose a TLS server cert; in PEM format")
+	flag.StringVar(&o.DefaultCertificatePath, "default-certificate-path", util.Env("DEFAULT_CERTIFICATE_PATH", ""), "A path to a file holding the default certificate, in PEM format, instead of --default-certificate. The file is read again when the router receives SIGHUP.")
 	flag.StringVar(&o.TemplateFile, "template", util.Env("TEMPLATE_FILE", ""), "The path to the template file to use")
 	flag.StringVar(&o.ReloadScript, "reload", util.Env("RELOAD_SCRIPT", ""), "The path to the reload script to use")
+	flag.StringVar(&o.ForwardedHeaderPolicy, "forwarded-header-policy", util.Env("ROUTER_FORWARDED_HEADER_POLICY", templateplugin.ForwardedHeaderPolicyAppend), "How to set the X-Forwarded-For header of requests: append the address of the client to it, replace it with the address, or set it to the address if requests have none.")
+	flag.StringSliceVar(&o.TrustedProxies, "trusted-proxies", envSlice("ROUTER_TRUSTED_PROXIES"), "The addresses and CIDRs of the proxies in front of the router allowed to report the address of clients in the X-Forwarded-For header.")
+}
+
+// envSlice returns the comma separated values of the environment variable key
+func envSlice(key string) []string {
+	value := util.Env(key, "")
+	if len(value) == 0 {
+		return []string{}
+	}
+	return strings.Split(value, ",")
 }
 
 type RouterStats struct {
 	StatsPortString string
 	StatsPassword   string
 	StatsUsername   string
+	StatsSocket     string
+	MetricsAddress  string
 
 	StatsPort int
 }
@@ -69,6 +107,8 @@ func (o *RouterStats) Bind(flag *pflag.FlagSet) {
 	flag.StringVar(&o.StatsPortString, "stats-port", util.Env("STATS_PORT", ""), "If the underlying router implementation can provide statistics this is a hint to expose it on this port.")
 	flag.StringVar(&o.StatsPassword, "stats-password", util.Env("STATS_PASSWORD", ""), "If the underlying router implementation can provide statistics this is the requested password for auth.")
 	flag.StringVar(&o.StatsUsername, "stats-user", util.Env("STATS_USERNAME", ""), "If the underlying router implementation can provide statistics this is the requested username for auth.")
+	flag.StringVar(&o.StatsSocket, "stats-socket", util.Env("STATS_SOCKET", "/var/lib/haproxy/run/haproxy.sock"), "The path of the statistics socket of HAProxy, read to expose the health of backends as metrics.")
+	flag.StringVar(&o.MetricsAddress, "metrics-address", util.Env("ROUTER_METRICS_ADDRESS", ""), "If set, the address (host:port) to expose the health of the HAProxy backends on for Prometheus.")
 }
 
 // NewCommndTemplateRouter provides CLI handler for the template router backend
@@ -132,21 +172,40 @@ func (o *TemplateRouterOptions) Validate() error {
 	if len(o.ReloadScript) == 0 {
 		return errors.New("reload script must be specified")
 	}
+
+	if len(o.DefaultCertificate) > 0 && len(o.DefaultCertificatePath) > 0 {
+		return errors.New("only one of --default-certificate and --default-certificate-path may be specified")
+	}
+
+	switch o.ForwardedHeaderPolicy {
+	case templateplugin.ForwardedHeaderPolicyAppend, templateplugin.ForwardedHeaderPolicyReplace, templateplugin.ForwardedHeaderPolicySet:
+	default:
+		return fmt.Errorf("forwarded header policy must be %s, %s or %s", templateplugin.ForwardedHeaderPolicyAppend, templateplugin.ForwardedHeaderPolicyReplace, templateplugin.ForwardedHeaderPolicySet)
+	}
+	for _, proxy := range o.TrustedProxies {
+		if !templateplugin.IsIPOrCIDR(proxy) {
+			return fmt.Errorf("trusted proxy %q is not an IP address or a CIDR", proxy)
+		}
+	}
 	return nil
 }
 
 // Run launches a template router using the provided options. It never exits.
 func (o *TemplateRouterOptions) Run() error {
 	pluginCfg := templateplugin.TemplatePluginConfig{
-		WorkingDir:         o.WorkingDir,
-		TemplatePath:       o.TemplateFile,
-		ReloadScriptPath:   o.ReloadScript,
-		DefaultCertificate: o.DefaultCertificate,
-		StatsPort:          o.StatsPort,
-		StatsUsername:      o.StatsUsername,
-		StatsPassword:      o.StatsPassword,
-		PeerService:        o.RouterService,
-		IncludeUDP:         o.RouterSelection.IncludeUDP,
+		WorkingDir:             o.WorkingDir,
+		TemplatePath:           o.TemplateFile,
+		ReloadScriptPath:       o.ReloadScript,
+		DefaultCertificate:     o.DefaultCertificate,
+		DefaultCertificatePath: o.DefaultCertificatePath,
+		StatsPort:              o.StatsPort,
+		StatsUsername:          o.StatsUsername,
+		StatsPassword:          o.StatsPassword,
+		PeerService:            o.RouterService,
+		IncludeUDP:             o.RouterSelection.IncludeUDP,
+
+		ForwardedHeaderPolicy: o.ForwardedHeaderPolicy,
+		TrustedProxies:        o.TrustedProxies,
 	}
 
 	templatePlugin, err := templateplugin.NewTemplatePlugin(pluginCfg)
@@ -165,7 +224,18 @@ func (o *TemplateRouterOptions) Run() error {
 	controller := factory.Create(plugin)
 	controller.Run()
 
+	if len(o.MetricsAddress) > 0 {
+		prometheus.MustRegister(haproxy.NewExporter(o.StatsSocket))
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", prometheus.Handler())
+		go func() {
+			glog.Fatal(http.ListenAndServe(o.MetricsAddress, mux))
+		}()
+		glog.Infof("Router metrics available at http://%s/metrics", o.MetricsAddress)
+	}
+
 	proc.StartReaper()
+	proc.StartReloader(controller.Reload)
 
 	select {}
 }
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"errors"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/auth/server/grant"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	CreateGrantTemplateCommand = "create-grant-template"
+	grantLongDescription       = `
+Create a template for customizing the grant approval page
+
+This command creates a basic template to use as a starting point for
+customizing the page prompting users to approve the grants requested by OAuth
+clients. Save the output to a file and edit the template to change the look and
+feel or add content. Be careful not to remove any parameter values inside curly
+braces.
+
+To use the template, set oauthConfig.templates.grant in the master
+configuration to point to the template file. For example,
+
+    oauthConfig:
+      templates:
+        grant: templates/grant.html
+`
+)
+
+type CreateGrantTemplateOptions struct{}
+
+func NewCommandCreateGrantTemplate(f *clientcmd.Factory, commandName string, fullName string, out io.Writer) *cobra.Command {
+	options := &CreateGrantTemplateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   commandName,
+		Short: "Create a grant approval template",
+		Long:  grantLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Validate(args); err != nil {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "%v", err))
+			}
+
+			_, err := io.WriteString(out, grant.GrantTemplateExample)
+			if err != nil {
+				cmdutil.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func (o CreateGrantTemplateOptions) Validate(args []string) error {
+	if len(args) != 0 {
+		return errors.New("no arguments are supported")
+	}
+
+	return nil
+}
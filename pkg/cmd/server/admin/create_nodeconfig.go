@@ -260,7 +260,7 @@ func (o CreateNodeConfigOptions) MakeClientCert(clientCertFile, clientKeyFile st
 			CertFile: clientCertFile,
 			KeyFile:  clientKeyFile,
 
-			User:   "system:node:" + o.NodeName,
+			User:   bootstrappolicy.NodeUsernamePrefix + o.NodeName,
 			Groups: []string{bootstrappolicy.NodesGroup},
 			Output: o.Output,
 		}
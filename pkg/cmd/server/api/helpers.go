@@ -125,6 +125,12 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 		refs = append(refs, &config.ServingInfo.NamedCertificates[i].CertFile)
 		refs = append(refs, &config.ServingInfo.NamedCertificates[i].KeyFile)
 	}
+	for i := range config.ClientCertificateAuthConfig.DelegatedCAs {
+		refs = append(refs, &config.ClientCertificateAuthConfig.DelegatedCAs[i].CA)
+	}
+	if config.RequestAuthenticationConfig.WebhookTokenConfig != nil {
+		refs = append(refs, &config.RequestAuthenticationConfig.WebhookTokenConfig.ConfigFile)
+	}
 
 	refs = append(refs, &config.EtcdClientInfo.ClientCert.CertFile)
 	refs = append(refs, &config.EtcdClientInfo.ClientCert.KeyFile)
@@ -188,11 +194,16 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 			case (*OpenIDIdentityProvider):
 				refs = append(refs, &provider.CA)
 
+			case (*SAMLIdentityProvider):
+				refs = append(refs, &provider.SigningCertificates)
+
 			}
 		}
 
 		if config.OAuthConfig.Templates != nil {
 			refs = append(refs, &config.OAuthConfig.Templates.Login)
+			refs = append(refs, &config.OAuthConfig.Templates.Grant)
+			refs = append(refs, &config.OAuthConfig.Templates.Error)
 		}
 	}
 
@@ -223,6 +234,12 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 		refs = append(refs, &config.KubernetesMasterConfig.ProxyClientInfo.KeyFile)
 	}
 
+	if config.CertificateSigningConfig != nil {
+		refs = append(refs, &config.CertificateSigningConfig.CertFile)
+		refs = append(refs, &config.CertificateSigningConfig.KeyFile)
+		refs = append(refs, &config.CertificateSigningConfig.SerialFile)
+	}
+
 	refs = append(refs, &config.ServiceAccountConfig.MasterCA)
 	refs = append(refs, &config.ServiceAccountConfig.PrivateKeyFile)
 	for i := range config.ServiceAccountConfig.PublicKeyFiles {
@@ -257,6 +274,7 @@ func GetNodeFileReferences(config *NodeConfig) []*string {
 	}
 
 	refs = append(refs, &config.MasterKubeConfig)
+	refs = append(refs, &config.BootstrapKubeConfig)
 
 	refs = append(refs, &config.VolumeDirectory)
 
@@ -422,7 +440,18 @@ func getAPIClientCertCAs(options MasterConfig) ([]*x509.Certificate, error) {
 		return nil, nil
 	}
 
-	return cmdutil.CertificatesFromFile(options.ServingInfo.ClientCA)
+	allCerts, err := cmdutil.CertificatesFromFile(options.ServingInfo.ClientCA)
+	if err != nil {
+		return nil, err
+	}
+	for _, delegated := range options.ClientCertificateAuthConfig.DelegatedCAs {
+		certs, err := cmdutil.CertificatesFromFile(delegated.CA)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading %s: %s", delegated.CA, err)
+		}
+		allCerts = append(allCerts, certs...)
+	}
+	return allCerts, nil
 }
 
 func GetKubeletClientConfig(options MasterConfig) *kclient.KubeletConfig {
@@ -471,6 +500,7 @@ func IsIdentityProviderType(provider runtime.EmbeddedObject) bool {
 		(*LDAPPasswordIdentityProvider),
 		(*KeystonePasswordIdentityProvider),
 		(*OpenIDIdentityProvider),
+		(*SAMLIdentityProvider),
 		(*GitHubIdentityProvider),
 		(*GoogleIdentityProvider):
 
@@ -23,6 +23,7 @@ func init() {
 		&GitHubIdentityProvider{},
 		&GoogleIdentityProvider{},
 		&OpenIDIdentityProvider{},
+		&SAMLIdentityProvider{},
 		&GrantConfig{},
 		&AdmissionPluginConfig{},
 
@@ -41,6 +42,7 @@ func (*RequestHeaderIdentityProvider) IsAnAPIObject()     {}
 func (*GitHubIdentityProvider) IsAnAPIObject()            {}
 func (*GoogleIdentityProvider) IsAnAPIObject()            {}
 func (*OpenIDIdentityProvider) IsAnAPIObject()            {}
+func (*SAMLIdentityProvider) IsAnAPIObject()              {}
 func (*GrantConfig) IsAnAPIObject()                       {}
 func (*AdmissionPluginConfig) IsAnAPIObject()             {}
 
@@ -58,6 +58,24 @@ var (
 	}
 	KnownOpenShiftFeatures = []string{FeatureBuilder, FeatureS2I, FeatureWebConsole}
 	AtomicDisabledFeatures = []string{FeatureBuilder, FeatureS2I, FeatureWebConsole}
+
+	// KnownOpenShiftAPIResources are the top level OpenShift API resources that can be
+	// listed in DisabledAPIResources. Subresources are disabled along with their parent.
+	KnownOpenShiftAPIResources = []string{
+		"images", "imagestreams", "imagestreamimages", "imagestreammappings", "imagestreamtags",
+		"deploymentconfigs", "generatedeploymentconfigs", "deploymentconfigrollbacks",
+		"builds", "buildconfigs",
+		"processedtemplates", "templates",
+		"routes",
+		"projects", "projectrequests",
+		"hostsubnets", "netnamespaces", "clusternetworks",
+		"certificatesigningrequests",
+		"users", "groups", "identities", "useridentitymappings",
+		"oauthauthorizetokens", "oauthaccesstokens", "oauthclients", "oauthclientauthorizations", "useroauthclientauthorizations",
+		"resourceaccessreviews", "subjectaccessreviews", "localsubjectaccessreviews", "localresourceaccessreviews",
+		"policies", "policybindings", "roles", "rolebindings",
+		"clusterpolicies", "clusterpolicybindings", "clusterrolebindings", "clusterroles",
+	}
 )
 
 type ExtendedArguments map[string][]string
@@ -80,6 +98,11 @@ type NodeConfig struct {
 	// MasterKubeConfig is a filename for the .kubeconfig file that describes how to connect this node to the master
 	MasterKubeConfig string
 
+	// BootstrapKubeConfig is a filename for a .kubeconfig file holding bootstrap credentials. If set, a node without
+	// a MasterKubeConfig or serving certificate requests them from the master with these credentials, and the node
+	// renews its certificates before they expire.
+	BootstrapKubeConfig string
+
 	// DNSDomain holds the domain suffix
 	DNSDomain string
 
@@ -108,6 +131,9 @@ type NodeConfig struct {
 	// DockerConfig holds Docker related configuration options.
 	DockerConfig DockerConfig
 
+	// DiskConfig holds the thresholds of disk usage the node maintains
+	DiskConfig NodeDiskConfig
+
 	// KubeletArguments are key value pairs that will be passed directly to the Kubelet that match the Kubelet's
 	// command line arguments.  These are not migrated or validated, so if you use them they may become invalid.
 	// These values override other settings in NodeConfig which may cause invalid configurations.
@@ -115,13 +141,33 @@ type NodeConfig struct {
 
 	// IPTablesSyncPeriod is how often iptable rules are refreshed
 	IPTablesSyncPeriod string
+
+	// ProxyMode selects the service proxy implementation: iptables or userspace. If the iptables proxy is
+	// selected but the kernel or iptables version does not support it, the userspace proxy is used.
+	ProxyMode ProxyModeType
+
+	// ConntrackMax is the maximum number of connections the kernel tracks (nf_conntrack_max). If 0, the
+	// kernel setting is left unchanged.
+	ConntrackMax int
 }
 
+type ProxyModeType string
+
+const (
+	// ProxyModeIPTables proxies service traffic with iptables rules, without passing it through a userspace process
+	ProxyModeIPTables ProxyModeType = "iptables"
+	// ProxyModeUserspace proxies service traffic through a userspace process listening on a port per service
+	ProxyModeUserspace ProxyModeType = "userspace"
+)
+
+var ValidProxyModes = sets.NewString(string(ProxyModeIPTables), string(ProxyModeUserspace))
+
 // NodeNetworkConfig provides network options for the node
 type NodeNetworkConfig struct {
 	// NetworkPluginName is a string specifying the networking plugin
 	NetworkPluginName string
-	// Maximum transmission unit for the network packets
+	// Maximum transmission unit for the network packets. If zero, it is detected from the interface
+	// holding the node IP, less the overhead of the overlay network.
 	MTU uint
 }
 
@@ -142,11 +188,34 @@ type NodeAuthConfig struct {
 	AuthorizationCacheSize int
 }
 
+// NodeDiskConfig holds the thresholds of disk usage the node maintains. A zero value keeps the default of the
+// Kubelet.
+type NodeDiskConfig struct {
+	// ImageGCHighThresholdPercent is the percent of disk usage of the image filesystem after which unused images
+	// are always garbage collected.
+	ImageGCHighThresholdPercent int
+	// ImageGCLowThresholdPercent is the percent of disk usage of the image filesystem that image garbage
+	// collection frees space down to. Images are never garbage collected below it.
+	ImageGCLowThresholdPercent int
+	// ImageFSMinimumFreeMB is the free space, in MB, of the filesystem holding images below which new pods are
+	// rejected by the node.
+	ImageFSMinimumFreeMB int
+	// NodeFSMinimumFreeMB is the free space, in MB, of the root filesystem of the node, which holds volumes and
+	// logs, below which new pods are rejected by the node.
+	NodeFSMinimumFreeMB int
+}
+
 // DockerConfig holds Docker related configuration options.
 type DockerConfig struct {
 	// ExecHandlerName is the name of the handler to use for executing
 	// commands in Docker containers.
 	ExecHandlerName DockerExecHandlerType
+
+	// Endpoint is the address of the Docker daemon the node runs containers with, for example
+	// unix:///var/run/docker.sock or tcp://10.0.0.1:2376. If empty, DOCKER_HOST is used, or the local Docker socket
+	// if it is not set. Remote daemons secured with TLS use the client certificates in DOCKER_CERT_PATH when
+	// DOCKER_TLS_VERIFY is set.
+	Endpoint string
 }
 
 type DockerExecHandlerType string
@@ -202,6 +271,11 @@ type MasterConfig struct {
 	// Allow to disable OpenShift components
 	DisabledFeatures FeatureList
 
+	// DisabledAPIResources is a list of OpenShift API resources (e.g. builds or templates) that
+	// should not be served by this master. Disabling a resource also disables its subresources
+	// and any controllers that depend on it.
+	DisabledAPIResources []string
+
 	// EtcdStorageConfig contains information about how API resources are
 	// stored in Etcd. These values are only relevant when etcd is the
 	// backing store for the cluster.
@@ -223,10 +297,30 @@ type MasterConfig struct {
 	AssetConfig *AssetConfig
 	// DNSConfig, if present start the DNS server in this process
 	DNSConfig *DNSConfig
+	// CertificateSigningConfig, if present sign the approved certificate requests of nodes in this process
+	CertificateSigningConfig *CertificateSigningConfig
+	// NotificationConfig, if present post notifications of the completion and failure of builds and deployments
+	// from this process
+	NotificationConfig *NotificationConfig
+	// PodLoggingConfig, if present annotate the build and deployer pods created by this process with the metadata
+	// aggregated logging indexes
+	PodLoggingConfig *PodLoggingConfig
 
 	// ServiceAccountConfig holds options related to service accounts
 	ServiceAccountConfig ServiceAccountConfig
 
+	// AnonymousConfig holds options related to requests made without credentials
+	AnonymousConfig AnonymousConfig
+
+	// AuditConfig holds options related to the audit log of the master
+	AuditConfig AuditConfig
+
+	// ClientCertificateAuthConfig holds options related to authenticating users with client certificates
+	ClientCertificateAuthConfig ClientCertificateAuthConfig
+
+	// RequestAuthenticationConfig holds options related to the order the credentials of requests are checked in
+	RequestAuthenticationConfig RequestAuthenticationConfig
+
 	// MasterClients holds all the client connection information for controllers and other system components
 	MasterClients MasterClients
 
@@ -246,6 +340,57 @@ type MasterConfig struct {
 	NetworkConfig MasterNetworkConfig
 }
 
+// CertificateSigningConfig holds the CA used to sign the certificates requested by nodes
+type CertificateSigningConfig struct {
+	// CertFile is a file containing the PEM-encoded CA certificate
+	CertFile string
+	// KeyFile is a file containing the PEM-encoded private key of the CA
+	KeyFile string
+	// SerialFile is a file holding the serial number of the last certificate signed by the CA
+	SerialFile string
+}
+
+// NotificationConfig holds the sinks notified of the completion and failure of builds and deployments
+type NotificationConfig struct {
+	// Webhooks are the URLs notifications are posted to
+	Webhooks []NotificationWebhookConfig
+}
+
+// PodLoggingConfig holds the annotations added to build and deployer pods for aggregated logging
+type PodLoggingConfig struct {
+	// AnnotationPrefix is prepended to the names of the app, buildconfig, deploymentconfig and commit annotations
+	AnnotationPrefix string
+}
+
+// NotificationWebhookConfig describes a URL notifications are posted to
+type NotificationWebhookConfig struct {
+	// URL is the address the notifications are posted to
+	URL string
+	// Events are the events posted to the URL: BuildCompleted, BuildFailed, DeploymentCompleted or
+	// DeploymentFailed. If empty, every event is posted.
+	Events []NotificationEventType
+	// PayloadTemplate is a Go template of the body posted for a notification, executed with its event, kind,
+	// namespace, name, message and time. If empty, the notification is posted as JSON.
+	PayloadTemplate string
+	// ContentType is the content type of the body posted for a notification. Defaults to application/json.
+	ContentType string
+}
+
+type NotificationEventType string
+
+const (
+	// NotificationEventBuildCompleted is posted when a build completes
+	NotificationEventBuildCompleted NotificationEventType = "BuildCompleted"
+	// NotificationEventBuildFailed is posted when a build fails, errors or is cancelled
+	NotificationEventBuildFailed NotificationEventType = "BuildFailed"
+	// NotificationEventDeploymentCompleted is posted when a deployment completes
+	NotificationEventDeploymentCompleted NotificationEventType = "DeploymentCompleted"
+	// NotificationEventDeploymentFailed is posted when a deployment fails
+	NotificationEventDeploymentFailed NotificationEventType = "DeploymentFailed"
+)
+
+var ValidNotificationEventTypes = sets.NewString(string(NotificationEventBuildCompleted), string(NotificationEventBuildFailed), string(NotificationEventDeploymentCompleted), string(NotificationEventDeploymentFailed))
+
 type ProjectConfig struct {
 	// DefaultNodeSelector holds default project node label selector
 	DefaultNodeSelector string
@@ -296,8 +441,16 @@ type PolicyConfig struct {
 	// OpenShiftSharedResourcesNamespace is the namespace where shared OpenShift resources live (like shared templates)
 	OpenShiftSharedResourcesNamespace string
 
+	// BootstrapSharedResources installs the default image streams and templates in the
+	// OpenShiftSharedResourcesNamespace when the master starts, and updates the ones installed by another version
+	BootstrapSharedResources bool
+
 	// OpenShiftInfrastructureNamespace is the namespace where OpenShift infrastructure resources live (like controller service accounts)
 	OpenShiftInfrastructureNamespace string
+
+	// RestrictNodeAccess limits the API access of each node's client credentials to its own Node object,
+	// the pods bound to it, and the secrets those pods reference
+	RestrictNodeAccess bool
 }
 
 // MasterNetworkConfig to be passed to the compiled in network plugin
@@ -313,6 +466,13 @@ type ImageConfig struct {
 	Format string
 	// Latest indicates whether to attempt to use the latest system component images as opposed to latest release
 	Latest bool
+	// DeployerImage overrides the image used by deployer pods for the Recreate and Rolling
+	// deployment strategies. If empty, the image is derived from Format.
+	DeployerImage string
+	// ResolveDigests indicates whether the images of infrastructure components should be resolved
+	// to their digests when they are first used, so that every node runs exactly the same image even
+	// if the tag is later moved. An image whose digest cannot be resolved is used as is until it is.
+	ResolveDigests bool
 }
 
 type RemoteConnectionInfo struct {
@@ -340,6 +500,20 @@ type EtcdConnectionInfo struct {
 	CA string
 	// ClientCert is the TLS client cert information for securing communication to etcd
 	ClientCert CertInfo
+
+	// DialTimeoutSeconds is the number of seconds to wait for a connection to an etcd server before failing over
+	// to the next URL. Defaults to 30.
+	DialTimeoutSeconds int
+	// RequestTimeoutSeconds is the number of seconds to wait for an etcd server to start answering a request
+	// before failing over to the next URL. 0 means no timeout.
+	RequestTimeoutSeconds int
+	// MaxIdleConnectionsPerServer is the number of idle connections kept open to each etcd server, so that
+	// bursts of requests and watches reuse them. Defaults to 500.
+	MaxIdleConnectionsPerServer int
+	// HealthCheckIntervalSeconds is the number of seconds between health checks of the etcd servers when more
+	// than one URL is given. Connections to servers that failed their last check are refused, so that requests
+	// fail over to a healthy server at once. 0 disables health checks.
+	HealthCheckIntervalSeconds int
 }
 
 type EtcdStorageConfig struct {
@@ -471,12 +645,161 @@ type OAuthConfig struct {
 
 	// Templates allow you to customize pages like the login page.
 	Templates *OAuthTemplates
+
+	// LoginThrottle records failed password logins in the audit log and limits repeated failures. If unspecified,
+	// failed logins are neither recorded nor limited.
+	LoginThrottle *LoginThrottleConfig
+}
+
+// LoginThrottleConfig refuses password logins as a user or from a source IP after repeated failures
+type LoginThrottleConfig struct {
+	// MaxFailedAttempts is the number of failed logins as a user after which logins as that user are refused.
+	// 0 does not limit the logins of users.
+	MaxFailedAttempts int
+
+	// MaxFailedAttemptsPerSourceIP is the number of failed logins from a source IP after which logins from that
+	// address are refused. 0 does not limit the logins from source IPs.
+	MaxFailedAttemptsPerSourceIP int
+
+	// LockoutSeconds is how long logins are refused for the first time a limit is reached. Each further lockout
+	// doubles it, up to MaxLockoutSeconds.
+	LockoutSeconds int
+
+	// MaxLockoutSeconds is the longest time logins are refused for. Failures are forgotten after MaxLockoutSeconds
+	// without another failure.
+	MaxLockoutSeconds int
+
+	// RequestAuthentication also limits the API requests rejected for invalid credentials, like an unknown bearer
+	// token or an untrusted client certificate. After MaxFailedAttemptsPerSourceIP failures from a source IP, the
+	// requests with credentials from that address are rejected without checking them. Failures to check the
+	// credentials, like an unavailable token store, are not counted.
+	RequestAuthentication bool
+
+	// TrustedProxies are the IP addresses and CIDRs of the proxies in front of the master allowed to report the
+	// source IP of clients in the X-Forwarded-For header. The source IP of other requests is the address they
+	// were received from.
+	TrustedProxies []string
 }
 
 type OAuthTemplates struct {
 	// Login is a path to a file containing a go template used to render the login page.
 	// If unspecified, the default login page is used.
 	Login string
+
+	// Grant is a path to a file containing a go template used to render the page prompting users to approve the
+	// grants requested by OAuth clients. If unspecified, the default grant page is used.
+	Grant string
+
+	// Error is a path to a file containing a go template used to render the page displayed when authenticating a
+	// user or checking a grant fails. If unspecified, the default error page is used.
+	Error string
+}
+
+// AnonymousConfig holds options related to requests made without credentials
+type AnonymousConfig struct {
+	// Access controls which requests made without credentials are handled as the system:anonymous user.
+	// Allow lets all of them through to authorization, DiscoveryOnly only the requests for the health and
+	// discovery endpoints, and Deny rejects all of them. Defaults to Allow.
+	Access AnonymousAccessType
+}
+
+type AnonymousAccessType string
+
+const (
+	// AnonymousAccessAllow handles every request made without credentials as the system:anonymous user
+	AnonymousAccessAllow AnonymousAccessType = "Allow"
+	// AnonymousAccessDiscoveryOnly handles the requests made without credentials for the health and discovery
+	// endpoints as the system:anonymous user, and rejects the others
+	AnonymousAccessDiscoveryOnly AnonymousAccessType = "DiscoveryOnly"
+	// AnonymousAccessDeny rejects every request made without credentials
+	AnonymousAccessDeny AnonymousAccessType = "Deny"
+)
+
+var ValidAnonymousAccessTypes = sets.NewString(string(AnonymousAccessAllow), string(AnonymousAccessDiscoveryOnly), string(AnonymousAccessDeny))
+
+// AuditConfig holds options related to the audit log of the master
+type AuditConfig struct {
+	// Enabled records in the audit log the exec, attach and port-forward sessions opened on pods (who opened
+	// them, on which pod, from where, and how long they lasted), the impersonated requests and the API requests
+	// rejected for invalid credentials.
+	Enabled bool
+	// AnonymousRequests records the requests handled as the system:anonymous user and the unauthenticated
+	// requests rejected, whether or not Enabled is true.
+	AnonymousRequests bool
+}
+
+// ClientCertificateAuthConfig holds options related to authenticating users with client certificates
+type ClientCertificateAuthConfig struct {
+	// RequireSystemPrefix rejects the client certificates signed by servingInfo.clientCA whose user or groups do not
+	// start with system:, so that the authority only authenticates system components
+	RequireSystemPrefix bool
+	// DelegatedCAs are additional authorities whose client certificates authenticate users, restricted to the users
+	// and groups allowed for each of them
+	DelegatedCAs []DelegatedClientCA
+}
+
+// DelegatedClientCA is an authority whose client certificates authenticate a restricted set of users
+type DelegatedClientCA struct {
+	// CA is the file of the certificate bundle of the authority
+	CA string
+	// UserPrefix is the prefix the common names of the certificates must start with. If empty, the certificates may
+	// authenticate any user.
+	UserPrefix string
+	// AllowedGroups are the groups the organizations of the certificates may name. Certificates naming any other
+	// organization are rejected.
+	AllowedGroups []string
+}
+
+// RequestAuthenticationConfig holds options related to the order the credentials of requests are checked in
+type RequestAuthenticationConfig struct {
+	// Order lists the request authenticators in the order they are tried. Authenticators not listed are tried after
+	// the listed ones, in the default order: ServiceAccountToken, OAuthToken, OAuthTokenParam, WebhookToken,
+	// ClientCertificate and DelegatedClientCertificate.
+	Order []string
+	// FailOnError stops authenticating a request at the first authenticator failing with an error, instead of
+	// trying the next ones
+	FailOnError bool
+
+	// WebhookTokenConfig enables the WebhookToken authenticator, which checks bearer tokens against an external token
+	// review service
+	WebhookTokenConfig *WebhookTokenConfig
+}
+
+// WebhookTokenConfig holds options for checking bearer tokens against an external token review service
+type WebhookTokenConfig struct {
+	// ConfigFile is a kubeconfig file describing the token review service. TokenReview objects holding the tokens
+	// are posted to the server of its current context, with the credentials of its current user.
+	ConfigFile string
+	// CacheTTL is how long the reviews of the service are cached, e.g. 2m. Every token is reviewed on each request if
+	// empty or 0.
+	CacheTTL string
+	// CacheSize is the maximum number of reviews cached
+	CacheSize int
+}
+
+const (
+	// ServiceAccountTokenAuthenticator authenticates the bearer tokens of service accounts
+	ServiceAccountTokenAuthenticator = "ServiceAccountToken"
+	// OAuthTokenAuthenticator authenticates OAuth access tokens passed as bearer tokens
+	OAuthTokenAuthenticator = "OAuthToken"
+	// OAuthTokenParamAuthenticator authenticates OAuth access tokens passed as the access_token query parameter
+	OAuthTokenParamAuthenticator = "OAuthTokenParam"
+	// WebhookTokenAuthenticator authenticates bearer tokens reviewed by the service of requestAuthenticationConfig.webhookTokenConfig
+	WebhookTokenAuthenticator = "WebhookToken"
+	// ClientCertificateAuthenticator authenticates the client certificates signed by servingInfo.clientCA
+	ClientCertificateAuthenticator = "ClientCertificate"
+	// DelegatedClientCertificateAuthenticator authenticates the client certificates signed by delegated client CAs
+	DelegatedClientCertificateAuthenticator = "DelegatedClientCertificate"
+)
+
+// RequestAuthenticators are the request authenticators in their default order
+var RequestAuthenticators = []string{
+	ServiceAccountTokenAuthenticator,
+	OAuthTokenAuthenticator,
+	OAuthTokenParamAuthenticator,
+	WebhookTokenAuthenticator,
+	ClientCertificateAuthenticator,
+	DelegatedClientCertificateAuthenticator,
 }
 
 type ServiceAccountConfig struct {
@@ -509,6 +832,14 @@ type TokenConfig struct {
 	AuthorizeTokenMaxAgeSeconds int32
 	// AccessTokenMaxAgeSeconds defines the maximum age of access tokens
 	AccessTokenMaxAgeSeconds int32
+
+	// AccessTokenCacheTTL indicates how long the result of authenticating a request with an access token is cached.
+	// It takes a valid time duration string (e.g. "10s"). If empty or zero, access tokens are read from etcd for
+	// every request. Deleted and expired tokens are removed from the cache as soon as they are observed in etcd.
+	AccessTokenCacheTTL string
+	// AccessTokenCacheSize indicates how many authentication results are cached. Required when
+	// AccessTokenCacheTTL is set.
+	AccessTokenCacheSize int
 }
 
 // SessionConfig specifies options for cookie-based sessions. Used by AuthRequestHandlerSession
@@ -592,6 +923,28 @@ type LDAPPasswordIdentityProvider struct {
 	CA string
 	// Attributes maps LDAP attributes to identities
 	Attributes LDAPAttributeMapping
+	// Groups optionally looks up the LDAP groups of users when they log in. The names of the groups are recorded
+	// in the groups of the users, so that roles can be granted to them.
+	Groups *LDAPGroupMembershipLookup
+}
+
+// LDAPGroupMembershipLookup describes how to find the LDAP groups a user is a member of
+type LDAPGroupMembershipLookup struct {
+	// BaseDN is the DN of the branch of the directory that groups are searched in
+	BaseDN string
+	// Filter is an optional LDAP filter restricting the entries considered as groups, e.g. (objectClass=groupOfNames)
+	Filter string
+	// MemberAttribute is the attribute of group entries holding the DNs of their members, e.g. member
+	MemberAttribute string
+	// NameAttribute is the attribute of group entries holding the name of the group, e.g. cn
+	NameAttribute string
+	// Nested, if true, also includes the groups that the groups of a user are members of, recursively
+	Nested bool
+	// GroupPrefix is prepended to the names of the LDAP groups to get the names of the groups users are added to,
+	// e.g. ldap:. Groups whose name would start with system: are ignored.
+	GroupPrefix string
+	// AllowedGroups, if not empty, are the only LDAP groups, by name, that users are added to
+	AllowedGroups []string
 }
 
 type LDAPAttributeMapping struct {
@@ -639,8 +992,19 @@ type RequestHeaderIdentityProvider struct {
 
 	// ClientCA is a file with the trusted signer certs.  If empty, no request verification is done, and any direct request to the OAuth server can impersonate any identity from this provider, merely by setting a request header.
 	ClientCA string
+	// ClientCommonNames is an optional list of common names to require a match from. If empty, any client certificate
+	// validated against the clientCA bundle is considered authoritative.
+	ClientCommonNames []string
 	// Headers is the set of headers to check for identity information
 	Headers []string
+	// GroupHeaders is the set of headers to read the groups of users from, e.g. X-Remote-Group. Each value is a comma
+	// separated list of groups. Requires ClientCA.
+	GroupHeaders []string
+	// GroupPrefix is prepended to the names of the groups read from GroupHeaders. Groups whose name would start with
+	// system: are ignored.
+	GroupPrefix string
+	// AllowedGroups, if not empty, are the only groups, by name in GroupHeaders, that users are added to
+	AllowedGroups []string
 }
 
 type GitHubIdentityProvider struct {
@@ -682,6 +1046,10 @@ type OpenIDIdentityProvider struct {
 	// ExtraAuthorizeParameters are any custom parameters to add to the authorize request.
 	ExtraAuthorizeParameters map[string]string
 
+	// Issuer is the optional URL of the OpenID provider. If set, the URLs missing from urls and the keys signing
+	// ID tokens are read from its discovery document, and ID tokens must be issued by it.
+	Issuer string
+
 	// URLs to use to authenticate
 	URLs OpenIDURLs
 
@@ -715,6 +1083,41 @@ type OpenIDClaims struct {
 	Email []string
 }
 
+type SAMLIdentityProvider struct {
+	unversioned.TypeMeta
+
+	// EntityID identifies the OAuth server to the identity provider. Assertions must be restricted to it as an audience.
+	EntityID string
+
+	// Issuer is the entity ID of the identity provider. Assertions must be issued by it.
+	Issuer string
+
+	// SingleSignOnURL is the URL of the identity provider authentication requests are redirected to
+	SingleSignOnURL string
+
+	// SigningCertificates is a file with the certificates the identity provider signs responses or assertions with.
+	// Assertions which are not signed by one of them are rejected.
+	SigningCertificates string
+
+	// Attributes mappings
+	Attributes SAMLAttributes
+}
+
+type SAMLAttributes struct {
+	// ID is the list of attributes whose values should be used as the user ID.
+	// If unspecified, the name ID of the subject of the assertion is used
+	ID []string
+	// PreferredUsername is the list of attributes whose values should be used as the preferred username.
+	// If unspecified, the preferred username is determined from the user ID
+	PreferredUsername []string
+	// Name is the list of attributes whose values should be used as the display name. Optional.
+	// If unspecified, no display name is set for the identity
+	Name []string
+	// Email is the list of attributes whose values should be used as the email address. Optional.
+	// If unspecified, no email is set for the identity
+	Email []string
+}
+
 type GrantConfig struct {
 	// Method: allow, deny, prompt
 	Method GrantHandlerType
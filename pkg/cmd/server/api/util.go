@@ -1,6 +1,44 @@
 package api
 
-// IsBuildDisabled returns true if the builder feature is disabled.
+import (
+	"strings"
+)
+
+// IsBuildEnabled returns true if the builder feature and the build APIs are enabled.
 func IsBuildEnabled(config *MasterConfig) bool {
-	return !config.DisabledFeatures.Has(FeatureBuilder)
+	return !config.DisabledFeatures.Has(FeatureBuilder) &&
+		IsAPIResourceEnabled(config, "builds") &&
+		IsAPIResourceEnabled(config, "buildconfigs")
+}
+
+// IsDeploymentEnabled returns true if the deployment config API is enabled.
+func IsDeploymentEnabled(config *MasterConfig) bool {
+	return IsAPIResourceEnabled(config, "deploymentconfigs")
+}
+
+// IsImageStreamEnabled returns true if the image stream API is enabled.
+func IsImageStreamEnabled(config *MasterConfig) bool {
+	return IsAPIResourceEnabled(config, "imagestreams")
+}
+
+// IsNotificationEnabled returns true if webhooks are configured to be notified of builds or deployments, and the
+// builds or deployments are enabled.
+func IsNotificationEnabled(config *MasterConfig) bool {
+	return config.NotificationConfig != nil && len(config.NotificationConfig.Webhooks) > 0 &&
+		(IsBuildEnabled(config) || IsDeploymentEnabled(config))
+}
+
+// IsAPIResourceEnabled returns true if the given OpenShift API resource or subresource
+// (e.g. "builds/log") has not been disabled. Resource names are matched case-insensitively
+// and a subresource is disabled along with its parent resource.
+func IsAPIResourceEnabled(config *MasterConfig, resource string) bool {
+	resource = strings.ToLower(resource)
+	parent := strings.SplitN(resource, "/", 2)[0]
+	for _, disabled := range config.DisabledAPIResources {
+		disabled = strings.ToLower(disabled)
+		if disabled == resource || disabled == parent {
+			return false
+		}
+	}
+	return true
 }
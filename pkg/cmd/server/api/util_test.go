@@ -0,0 +1,63 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestIsAPIResourceEnabled(t *testing.T) {
+	config := &MasterConfig{DisabledAPIResources: []string{"Builds", "templates/status"}}
+
+	testCases := map[string]bool{
+		"builds":            false,
+		"BUILDS":            false,
+		"builds/log":        false,
+		"buildconfigs":      true,
+		"templates":         true,
+		"templates/status":  false,
+		"deploymentconfigs": true,
+	}
+	for resource, expected := range testCases {
+		if actual := IsAPIResourceEnabled(config, resource); actual != expected {
+			t.Errorf("%s: expected %t, got %t", resource, expected, actual)
+		}
+	}
+
+	if IsBuildEnabled(config) {
+		t.Errorf("expected builds to be disabled when the builds resource is disabled")
+	}
+	if !IsDeploymentEnabled(config) || !IsImageStreamEnabled(config) {
+		t.Errorf("expected deployments and image streams to be enabled")
+	}
+}
+
+func TestIsNotificationEnabled(t *testing.T) {
+	webhooks := &NotificationConfig{Webhooks: []NotificationWebhookConfig{{URL: "https://example.com/hook"}}}
+
+	testCases := map[string]struct {
+		config   *MasterConfig
+		expected bool
+	}{
+		"no notification config": {
+			config: &MasterConfig{},
+		},
+		"no webhooks": {
+			config: &MasterConfig{NotificationConfig: &NotificationConfig{}},
+		},
+		"webhooks": {
+			config:   &MasterConfig{NotificationConfig: webhooks},
+			expected: true,
+		},
+		"builds disabled": {
+			config:   &MasterConfig{NotificationConfig: webhooks, DisabledAPIResources: []string{"builds"}},
+			expected: true,
+		},
+		"builds and deployments disabled": {
+			config: &MasterConfig{NotificationConfig: webhooks, DisabledAPIResources: []string{"builds", "deploymentconfigs"}},
+		},
+	}
+	for k, tc := range testCases {
+		if actual := IsNotificationEnabled(tc.config); actual != tc.expected {
+			t.Errorf("%s: expected %t, got %t", k, tc.expected, actual)
+		}
+	}
+}
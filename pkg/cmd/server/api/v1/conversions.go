@@ -29,6 +29,13 @@ func init() {
 			if len(obj.RoutingConfig.Subdomain) == 0 {
 				obj.RoutingConfig.Subdomain = "router.default.svc.cluster.local"
 			}
+			if len(obj.AnonymousConfig.Access) == 0 {
+				obj.AnonymousConfig.Access = AnonymousAccessAllow
+			}
+			// Migrate the deprecated anonymousConfig.audit
+			if obj.AnonymousConfig.DeprecatedAudit {
+				obj.AuditConfig.AnonymousRequests = true
+			}
 
 			// Populate the new NetworkConfig.ServiceNetworkCIDR field from the KubernetesMasterConfig.ServicesSubnet field if needed
 			if len(obj.NetworkConfig.ServiceNetworkCIDR) == 0 {
@@ -49,6 +56,16 @@ func init() {
 				obj.OAuthConfig.MasterCA = &s
 			}
 		},
+		func(obj *PodLoggingConfig) {
+			if len(obj.AnnotationPrefix) == 0 {
+				obj.AnnotationPrefix = "logging.openshift.io/"
+			}
+		},
+		func(obj *NotificationWebhookConfig) {
+			if len(obj.ContentType) == 0 {
+				obj.ContentType = "application/json"
+			}
+		},
 		func(obj *KubernetesMasterConfig) {
 			if obj.MasterCount == 0 {
 				obj.MasterCount = 1
@@ -68,12 +85,12 @@ func init() {
 			if len(obj.NetworkConfig.NetworkPluginName) == 0 {
 				obj.NetworkConfig.NetworkPluginName = obj.DeprecatedNetworkPluginName
 			}
-			if obj.NetworkConfig.MTU == 0 {
-				obj.NetworkConfig.MTU = 1450
-			}
 			if len(obj.IPTablesSyncPeriod) == 0 {
 				obj.IPTablesSyncPeriod = "5s"
 			}
+			if len(obj.ProxyMode) == 0 {
+				obj.ProxyMode = ProxyModeIPTables
+			}
 
 			// Auth cache defaults
 			if len(obj.AuthConfig.AuthenticationCacheTTL) == 0 {
@@ -149,6 +166,12 @@ func init() {
 		func(in *internal.NodeConfig, out *NodeConfig, s conversion.Scope) error {
 			return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
 		},
+		func(in *AnonymousConfig, out *internal.AnonymousConfig, s conversion.Scope) error {
+			return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+		},
+		func(in *internal.AnonymousConfig, out *AnonymousConfig, s conversion.Scope) error {
+			return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+		},
 		func(in *KubernetesMasterConfig, out *internal.KubernetesMasterConfig, s conversion.Scope) error {
 			if err := s.DefaultConvert(in, out, conversion.IgnoreMissingFields); err != nil {
 				return err
@@ -219,6 +242,10 @@ func init() {
 			out.CA = in.CA
 			out.ClientCert.CertFile = in.CertFile
 			out.ClientCert.KeyFile = in.KeyFile
+			out.DialTimeoutSeconds = in.DialTimeoutSeconds
+			out.RequestTimeoutSeconds = in.RequestTimeoutSeconds
+			out.MaxIdleConnectionsPerServer = in.MaxIdleConnectionsPerServer
+			out.HealthCheckIntervalSeconds = in.HealthCheckIntervalSeconds
 			return nil
 		},
 		func(in *internal.EtcdConnectionInfo, out *EtcdConnectionInfo, s conversion.Scope) error {
@@ -226,6 +253,10 @@ func init() {
 			out.CA = in.CA
 			out.CertFile = in.ClientCert.CertFile
 			out.KeyFile = in.ClientCert.KeyFile
+			out.DialTimeoutSeconds = in.DialTimeoutSeconds
+			out.RequestTimeoutSeconds = in.RequestTimeoutSeconds
+			out.MaxIdleConnectionsPerServer = in.MaxIdleConnectionsPerServer
+			out.HealthCheckIntervalSeconds = in.HealthCheckIntervalSeconds
 			return nil
 		},
 		func(in *KubeletConnectionInfo, out *internal.KubeletConnectionInfo, s conversion.Scope) error {
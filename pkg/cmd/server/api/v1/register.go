@@ -24,6 +24,7 @@ func init() {
 		&GitHubIdentityProvider{},
 		&GoogleIdentityProvider{},
 		&OpenIDIdentityProvider{},
+		&SAMLIdentityProvider{},
 		&GrantConfig{},
 		&AdmissionPluginConfig{},
 
@@ -44,6 +45,7 @@ func (*RequestHeaderIdentityProvider) IsAnAPIObject()     {}
 func (*GitHubIdentityProvider) IsAnAPIObject()            {}
 func (*GoogleIdentityProvider) IsAnAPIObject()            {}
 func (*OpenIDIdentityProvider) IsAnAPIObject()            {}
+func (*SAMLIdentityProvider) IsAnAPIObject()              {}
 func (*GrantConfig) IsAnAPIObject()                       {}
 func (*AdmissionPluginConfig) IsAnAPIObject()             {}
 
@@ -23,6 +23,13 @@ authConfig:
   authenticationCacheTTL: ""
   authorizationCacheSize: 0
   authorizationCacheTTL: ""
+bootstrapKubeConfig: ""
+conntrackMax: 0
+diskConfig:
+  imageFSMinimumFreeMB: 0
+  imageGCHighThresholdPercent: 0
+  imageGCLowThresholdPercent: 0
+  nodeFSMinimumFreeMB: 0
 dnsDomain: ""
 dnsIP: ""
 dockerConfig:
@@ -41,6 +48,7 @@ nodeName: ""
 podManifestConfig:
   fileCheckIntervalSeconds: 0
   path: ""
+proxyMode: ""
 servingInfo:
   bindAddress: ""
   bindNetwork: ""
@@ -66,6 +74,8 @@ volumeDirectory: ""
       location: ""
   pluginOrderOverride:
   - plugin
+anonymousConfig:
+  access: ""
 apiLevels: null
 apiVersion: v1
 assetConfig:
@@ -90,9 +100,20 @@ assetConfig:
     maxRequestsInFlight: 0
     namedCertificates: null
     requestTimeoutSeconds: 0
+auditConfig:
+  anonymousRequests: false
+  enabled: false
+certificateSigningConfig:
+  certFile: ""
+  keyFile: ""
+  serialFile: ""
+clientCertificateAuthConfig:
+  delegatedCAs: null
+  requireSystemPrefix: false
 controllerLeaseTTL: 0
 controllers: ""
 corsAllowedOrigins: null
+disabledAPIResources: null
 disabledFeatures: null
 dnsConfig:
   bindAddress: ""
@@ -100,7 +121,11 @@ dnsConfig:
 etcdClientInfo:
   ca: ""
   certFile: ""
+  dialTimeoutSeconds: 0
+  healthCheckIntervalSeconds: 0
   keyFile: ""
+  maxIdleConnectionsPerServer: 0
+  requestTimeoutSeconds: 0
   urls: null
 etcdConfig:
   address: ""
@@ -168,6 +193,12 @@ networkConfig:
   hostSubnetLength: 0
   networkPluginName: ""
   serviceNetworkCIDR: ""
+notificationConfig:
+  webhooks:
+  - contentType: ""
+    events: null
+    payloadTemplate: ""
+    url: ""
 oauthConfig:
   assetPublicURL: ""
   grantConfig:
@@ -228,9 +259,13 @@ oauthConfig:
     mappingMethod: ""
     name: ""
     provider:
+      allowedGroups: null
       apiVersion: v1
       challengeURL: ""
       clientCA: ""
+      clientCommonNames: null
+      groupHeaders: null
+      groupPrefix: ""
       headers: null
       kind: RequestHeaderIdentityProvider
       loginURL: ""
@@ -286,6 +321,29 @@ oauthConfig:
         authorize: ""
         token: ""
         userInfo: ""
+  - challenge: false
+    login: false
+    mappingMethod: ""
+    name: ""
+    provider:
+      apiVersion: v1
+      attributes:
+        email: null
+        id: null
+        name: null
+        preferredUsername: null
+      entityID: ""
+      issuer: ""
+      kind: SAMLIdentityProvider
+      signingCertificates: ""
+      singleSignOnURL: ""
+  loginThrottle:
+    lockoutSeconds: 0
+    maxFailedAttempts: 0
+    maxFailedAttemptsPerSourceIP: 0
+    maxLockoutSeconds: 0
+    requestAuthentication: false
+    trustedProxies: null
   masterCA: null
   masterPublicURL: ""
   masterURL: ""
@@ -294,20 +352,29 @@ oauthConfig:
     sessionName: ""
     sessionSecretsFile: ""
   templates:
+    error: ""
+    grant: ""
     login: ""
   tokenConfig:
     accessTokenMaxAgeSeconds: 0
     authorizeTokenMaxAgeSeconds: 0
 pauseControllers: false
+podLoggingConfig:
+  annotationPrefix: ""
 policyConfig:
   bootstrapPolicyFile: ""
+  bootstrapSharedResources: false
   openshiftInfrastructureNamespace: ""
   openshiftSharedResourcesNamespace: ""
+  restrictNodeAccess: false
 projectConfig:
   defaultNodeSelector: ""
   projectRequestMessage: ""
   projectRequestTemplate: ""
   securityAllocator: null
+requestAuthenticationConfig:
+  failOnError: false
+  order: null
 routingConfig:
   subdomain: ""
 serviceAccountConfig:
@@ -383,14 +450,21 @@ func TestMasterConfig(t *testing.T) {
 				{Provider: runtime.EmbeddedObject{Object: &internal.GitHubIdentityProvider{}}},
 				{Provider: runtime.EmbeddedObject{Object: &internal.GoogleIdentityProvider{}}},
 				{Provider: runtime.EmbeddedObject{Object: &internal.OpenIDIdentityProvider{}}},
+				{Provider: runtime.EmbeddedObject{Object: &internal.SAMLIdentityProvider{}}},
 			},
 			SessionConfig: &internal.SessionConfig{},
 			Templates:     &internal.OAuthTemplates{},
+			LoginThrottle: &internal.LoginThrottleConfig{},
 		},
 		AssetConfig: &internal.AssetConfig{
 			Extensions: []internal.AssetExtensionsConfig{{}},
 		},
-		DNSConfig: &internal.DNSConfig{},
+		DNSConfig:                &internal.DNSConfig{},
+		CertificateSigningConfig: &internal.CertificateSigningConfig{},
+		NotificationConfig: &internal.NotificationConfig{
+			Webhooks: []internal.NotificationWebhookConfig{{}},
+		},
+		PodLoggingConfig: &internal.PodLoggingConfig{},
 		AdmissionConfig: internal.AdmissionConfig{
 			PluginConfig: map[string]internal.AdmissionPluginConfig{ // test config as an embedded object
 				"plugin": {
@@ -410,6 +484,16 @@ func TestMasterConfig(t *testing.T) {
 
 }
 
+func TestMasterConfigDeprecatedAnonymousAudit(t *testing.T) {
+	obj, err := Codec.Decode([]byte(`{"kind":"MasterConfig","apiVersion":"v1","anonymousConfig":{"audit":true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config := obj.(*internal.MasterConfig); !config.AuditConfig.AnonymousRequests {
+		t.Errorf("expected anonymousConfig.audit to enable auditConfig.anonymousRequests")
+	}
+}
+
 func writeYAML(obj runtime.Object) ([]byte, error) {
 	json, err := Codec.Encode(obj)
 	if err != nil {
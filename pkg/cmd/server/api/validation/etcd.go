@@ -30,6 +30,19 @@ func ValidateEtcdConnectionInfo(config api.EtcdConnectionInfo, server *api.EtcdC
 	}
 	allErrs = append(allErrs, ValidateCertInfo(config.ClientCert, false)...)
 
+	if config.DialTimeoutSeconds < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("dialTimeoutSeconds", config.DialTimeoutSeconds, "must be greater than or equal to 0"))
+	}
+	if config.RequestTimeoutSeconds < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("requestTimeoutSeconds", config.RequestTimeoutSeconds, "must be greater than or equal to 0"))
+	}
+	if config.MaxIdleConnectionsPerServer < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("maxIdleConnectionsPerServer", config.MaxIdleConnectionsPerServer, "must be greater than or equal to 0"))
+	}
+	if config.HealthCheckIntervalSeconds < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("healthCheckIntervalSeconds", config.HealthCheckIntervalSeconds, "must be greater than or equal to 0"))
+	}
+
 	// If we have server config info, make sure the client connection info will work with it
 	if server != nil {
 		var builtInAddress string
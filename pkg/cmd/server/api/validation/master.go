@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
 	kapp "k8s.io/kubernetes/cmd/kube-apiserver/app"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	imageapi "github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/security/mcs"
 	"github.com/openshift/origin/pkg/security/uid"
 	"github.com/openshift/origin/pkg/util/labelselector"
@@ -70,7 +72,10 @@ func ValidateMasterConfig(config *api.MasterConfig) ValidationResults {
 		validationResults.AddErrors(fielderrors.NewFieldInvalid("controllerLeaseTTL", config.ControllerLeaseTTL, "TTL must be -1 (disabled), 0 (default), or between 10 and 300 seconds"))
 	}
 
+	validationResults.Append(ValidateMasterPublicURLs(config))
+
 	validationResults.AddErrors(ValidateDisabledFeatures(config.DisabledFeatures, "disabledFeatures")...)
+	validationResults.Append(ValidateDisabledAPIResources(config.DisabledAPIResources, "disabledAPIResources"))
 
 	if config.AssetConfig != nil {
 		validationResults.Append(ValidateAssetConfig(config.AssetConfig).Prefix("assetConfig"))
@@ -110,6 +115,17 @@ func ValidateMasterConfig(config *api.MasterConfig) ValidationResults {
 		}
 	}
 
+	if config.CertificateSigningConfig != nil {
+		validationResults.AddErrors(ValidateCertificateSigningConfig(*config.CertificateSigningConfig).Prefix("certificateSigningConfig")...)
+	}
+
+	if config.NotificationConfig != nil {
+		validationResults.AddErrors(ValidateNotificationConfig(*config.NotificationConfig).Prefix("notificationConfig")...)
+	}
+	if config.PodLoggingConfig != nil {
+		validationResults.AddErrors(ValidatePodLoggingConfig(*config.PodLoggingConfig).Prefix("podLoggingConfig")...)
+	}
+
 	if config.EtcdConfig != nil {
 		etcdConfigErrs := ValidateEtcdConfig(config.EtcdConfig).Prefix("etcdConfig")
 		validationResults.Append(etcdConfigErrs)
@@ -163,6 +179,12 @@ func ValidateMasterConfig(config *api.MasterConfig) ValidationResults {
 
 	validationResults.Append(ValidateServiceAccountConfig(config.ServiceAccountConfig, builtInKubernetes).Prefix("serviceAccountConfig"))
 
+	validationResults.Append(ValidateAnonymousConfig(config.AnonymousConfig).Prefix("anonymousConfig"))
+
+	validationResults.Append(ValidateClientCertificateAuthConfig(config.ClientCertificateAuthConfig).Prefix("clientCertificateAuthConfig"))
+
+	validationResults.AddErrors(ValidateRequestAuthenticationConfig(config.RequestAuthenticationConfig).Prefix("requestAuthenticationConfig")...)
+
 	validationResults.Append(ValidateHTTPServingInfo(config.ServingInfo).Prefix("servingInfo"))
 
 	validationResults.Append(ValidateProjectConfig(config.ProjectConfig).Prefix("projectConfig"))
@@ -199,6 +221,25 @@ func ValidateAPILevels(apiLevels []string, knownAPILevels, deadAPILevels []strin
 	return validationResults
 }
 
+func ValidateDisabledAPIResources(resources []string, name string) ValidationResults {
+	validationResults := ValidationResults{}
+
+	knownResources := sets.NewString(api.KnownOpenShiftAPIResources...)
+	for i, resource := range resources {
+		field := fmt.Sprintf(name+"[%d]", i)
+		if len(resource) == 0 {
+			validationResults.AddErrors(fielderrors.NewFieldRequired(field))
+			continue
+		}
+		parent := strings.SplitN(strings.ToLower(resource), "/", 2)[0]
+		if !knownResources.Has(parent) {
+			validationResults.AddWarnings(fielderrors.NewFieldValueNotSupported(field, resource, knownResources.List()))
+		}
+	}
+
+	return validationResults
+}
+
 func ValidateEtcdStorageConfig(config api.EtcdStorageConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
@@ -239,6 +280,132 @@ func ValidateStorageVersionLevel(level string, knownAPILevels, deadAPILevels []s
 	return allErrs
 }
 
+func ValidateCertificateSigningConfig(config api.CertificateSigningConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	allErrs = append(allErrs, ValidateFile(config.CertFile, "certFile")...)
+	allErrs = append(allErrs, ValidateFile(config.KeyFile, "keyFile")...)
+	allErrs = append(allErrs, ValidateFile(config.SerialFile, "serialFile")...)
+
+	return allErrs
+}
+
+func ValidateNotificationConfig(config api.NotificationConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	for i, webhook := range config.Webhooks {
+		webhookErrs := fielderrors.ValidationErrorList{}
+		if len(webhook.URL) == 0 {
+			webhookErrs = append(webhookErrs, fielderrors.NewFieldRequired("url"))
+		} else if u, urlErrs := ValidateURL(webhook.URL, "url"); len(urlErrs) > 0 {
+			webhookErrs = append(webhookErrs, urlErrs...)
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			webhookErrs = append(webhookErrs, fielderrors.NewFieldInvalid("url", webhook.URL, "must use the http or https scheme"))
+		}
+		for j, event := range webhook.Events {
+			if !api.ValidNotificationEventTypes.Has(string(event)) {
+				webhookErrs = append(webhookErrs, fielderrors.NewFieldValueNotSupported(fmt.Sprintf("events[%d]", j), event, api.ValidNotificationEventTypes.List()))
+			}
+		}
+		if len(webhook.PayloadTemplate) > 0 {
+			if _, err := template.New("payload").Parse(webhook.PayloadTemplate); err != nil {
+				webhookErrs = append(webhookErrs, fielderrors.NewFieldInvalid("payloadTemplate", webhook.PayloadTemplate, err.Error()))
+			}
+		}
+		allErrs = append(allErrs, webhookErrs.Prefix(fmt.Sprintf("webhooks[%d]", i))...)
+	}
+
+	return allErrs
+}
+
+func ValidatePodLoggingConfig(config api.PodLoggingConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	// the longest annotation name must be a valid annotation key
+	if len(config.AnnotationPrefix) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("annotationPrefix"))
+	} else if !kuval.IsQualifiedName(config.AnnotationPrefix + "deploymentconfig") {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("annotationPrefix", config.AnnotationPrefix, "must be the prefix of a valid annotation name, such as logging.example.com/"))
+	}
+
+	return allErrs
+}
+
+func ValidateAnonymousConfig(config api.AnonymousConfig) ValidationResults {
+	validationResults := ValidationResults{}
+
+	switch config.Access {
+	case "", api.AnonymousAccessAllow:
+	case api.AnonymousAccessDiscoveryOnly, api.AnonymousAccessDeny:
+		validationResults.AddWarnings(fielderrors.NewFieldInvalid("access", config.Access, "webhooks called without credentials will be rejected, which will prevent them from triggering builds"))
+	default:
+		validationResults.AddErrors(fielderrors.NewFieldValueNotSupported("access", config.Access, api.ValidAnonymousAccessTypes.List()))
+	}
+
+	return validationResults
+}
+
+func ValidateClientCertificateAuthConfig(config api.ClientCertificateAuthConfig) ValidationResults {
+	validationResults := ValidationResults{}
+
+	for i, delegated := range config.DelegatedCAs {
+		field := fmt.Sprintf("delegatedCAs[%d]", i)
+		validationResults.AddErrors(ValidateFile(delegated.CA, field+".ca")...)
+		if len(delegated.UserPrefix) == 0 {
+			validationResults.AddWarnings(fielderrors.NewFieldInvalid(field+".userPrefix", "", "the client certificates signed by this authority may authenticate any user, including system users"))
+		}
+		for j, group := range delegated.AllowedGroups {
+			if len(group) == 0 {
+				validationResults.AddErrors(fielderrors.NewFieldRequired(fmt.Sprintf("%s.allowedGroups[%d]", field, j)))
+			}
+		}
+	}
+
+	return validationResults
+}
+
+func ValidateRequestAuthenticationConfig(config api.RequestAuthenticationConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	valid := sets.NewString(api.RequestAuthenticators...)
+	seen := sets.NewString()
+	for i, name := range config.Order {
+		field := fmt.Sprintf("order[%d]", i)
+		switch {
+		case !valid.Has(name):
+			allErrs = append(allErrs, fielderrors.NewFieldValueNotSupported(field, name, api.RequestAuthenticators))
+		case seen.Has(name):
+			allErrs = append(allErrs, fielderrors.NewFieldDuplicate(field, name))
+		}
+		seen.Insert(name)
+	}
+
+	if config.WebhookTokenConfig != nil {
+		allErrs = append(allErrs, ValidateWebhookTokenConfig(*config.WebhookTokenConfig).Prefix("webhookTokenConfig")...)
+	}
+
+	return allErrs
+}
+
+func ValidateWebhookTokenConfig(config api.WebhookTokenConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	allErrs = append(allErrs, ValidateFile(config.ConfigFile, "configFile")...)
+
+	if len(config.CacheTTL) == 0 {
+		return allErrs
+	}
+	if ttl, err := time.ParseDuration(config.CacheTTL); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("cacheTTL", config.CacheTTL, fmt.Sprintf("%v", err)))
+	} else if ttl < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("cacheTTL", config.CacheTTL, "cannot be less than zero"))
+	} else if ttl > 0 && config.CacheSize <= 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("cacheSize", config.CacheSize, "must be greater than zero when cacheTTL is set"))
+	}
+
+	return allErrs
+}
+
 func ValidateServiceAccountConfig(config api.ServiceAccountConfig, builtInKubernetes bool) ValidationResults {
 	validationResults := ValidationResults{}
 
@@ -291,6 +458,19 @@ func ValidateServiceAccountConfig(config api.ServiceAccountConfig, builtInKubern
 	return validationResults
 }
 
+// ValidateMasterPublicURLs warns when the components advertising the public URL of the master disagree on it, since
+// clients are then sent to different addresses by the web console and by OAuth redirects
+func ValidateMasterPublicURLs(config *api.MasterConfig) ValidationResults {
+	validationResults := ValidationResults{}
+	if config.OAuthConfig != nil && len(config.OAuthConfig.MasterPublicURL) > 0 && config.OAuthConfig.MasterPublicURL != config.MasterPublicURL {
+		validationResults.AddWarnings(fielderrors.NewFieldInvalid("oauthConfig.masterPublicURL", config.OAuthConfig.MasterPublicURL, "should match masterPublicURL"))
+	}
+	if config.AssetConfig != nil && len(config.AssetConfig.MasterPublicURL) > 0 && config.AssetConfig.MasterPublicURL != config.MasterPublicURL {
+		validationResults.AddWarnings(fielderrors.NewFieldInvalid("assetConfig.masterPublicURL", config.AssetConfig.MasterPublicURL, "should match masterPublicURL"))
+	}
+	return validationResults
+}
+
 func ValidateAssetConfig(config *api.AssetConfig) ValidationResults {
 	validationResults := ValidationResults{}
 
@@ -379,6 +559,12 @@ func ValidateImageConfig(config api.ImageConfig) fielderrors.ValidationErrorList
 		allErrs = append(allErrs, fielderrors.NewFieldRequired("format"))
 	}
 
+	if len(config.DeployerImage) > 0 {
+		if _, err := imageapi.ParseDockerImageReference(config.DeployerImage); err != nil {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("deployerImage", config.DeployerImage, err.Error()))
+		}
+	}
+
 	return allErrs
 }
 
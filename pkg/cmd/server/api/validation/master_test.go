@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -265,3 +267,272 @@ func TestValidateAdmissionPluginConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDisabledAPIResources(t *testing.T) {
+	testCases := map[string]struct {
+		resources        []string
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		"known resources": {
+			resources: []string{"builds", "BuildConfigs", "templates", "deploymentconfigs/log"},
+		},
+		"unknown resource": {
+			resources:        []string{"builds", "widgets"},
+			expectedWarnings: 1,
+		},
+		"empty resource": {
+			resources:      []string{""},
+			expectedErrors: 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		results := ValidateDisabledAPIResources(tc.resources, "disabledAPIResources")
+		if len(results.Errors) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectedErrors, results.Errors)
+		}
+		if len(results.Warnings) != tc.expectedWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.expectedWarnings, results.Warnings)
+		}
+	}
+}
+
+func TestValidateAnonymousConfig(t *testing.T) {
+	tests := []struct {
+		access         configapi.AnonymousAccessType
+		expectError    bool
+		expectWarnings bool
+	}{
+		{access: ""},
+		{access: configapi.AnonymousAccessAllow},
+		{access: configapi.AnonymousAccessDiscoveryOnly, expectWarnings: true},
+		{access: configapi.AnonymousAccessDeny, expectWarnings: true},
+		{access: "Sometimes", expectError: true},
+	}
+
+	for _, tc := range tests {
+		results := ValidateAnonymousConfig(configapi.AnonymousConfig{Access: tc.access})
+		if (len(results.Errors) > 0) != tc.expectError {
+			t.Errorf("%q: unexpected errors %v", tc.access, results.Errors)
+		}
+		if (len(results.Warnings) > 0) != tc.expectWarnings {
+			t.Errorf("%q: unexpected warnings %v", tc.access, results.Warnings)
+		}
+	}
+}
+
+func TestValidateEtcdConnectionInfoLimits(t *testing.T) {
+	tests := map[string]struct {
+		config      configapi.EtcdConnectionInfo
+		expectError bool
+	}{
+		"defaults": {
+			config: configapi.EtcdConnectionInfo{URLs: []string{"https://etcd.example.com:4001"}},
+		},
+		"tuned": {
+			config: configapi.EtcdConnectionInfo{URLs: []string{"https://etcd.example.com:4001"}, DialTimeoutSeconds: 5, RequestTimeoutSeconds: 10, MaxIdleConnectionsPerServer: 100, HealthCheckIntervalSeconds: 5},
+		},
+		"negative dial timeout": {
+			config:      configapi.EtcdConnectionInfo{URLs: []string{"https://etcd.example.com:4001"}, DialTimeoutSeconds: -1},
+			expectError: true,
+		},
+		"negative request timeout": {
+			config:      configapi.EtcdConnectionInfo{URLs: []string{"https://etcd.example.com:4001"}, RequestTimeoutSeconds: -1},
+			expectError: true,
+		},
+		"negative idle connections": {
+			config:      configapi.EtcdConnectionInfo{URLs: []string{"https://etcd.example.com:4001"}, MaxIdleConnectionsPerServer: -1},
+			expectError: true,
+		},
+		"negative health check interval": {
+			config:      configapi.EtcdConnectionInfo{URLs: []string{"https://etcd.example.com:4001"}, HealthCheckIntervalSeconds: -1},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		errs := ValidateEtcdConnectionInfo(tc.config, nil)
+		if (len(errs) > 0) != tc.expectError {
+			t.Errorf("%s: unexpected errors %v", name, errs)
+		}
+	}
+}
+
+func TestValidateClientCertificateAuthConfig(t *testing.T) {
+	tests := map[string]struct {
+		config         configapi.ClientCertificateAuthConfig
+		expectError    bool
+		expectWarnings bool
+	}{
+		"empty": {},
+		"restricted": {
+			config: configapi.ClientCertificateAuthConfig{DelegatedCAs: []configapi.DelegatedClientCA{{CA: "/dev/null", UserPrefix: "partner:", AllowedGroups: []string{"partners"}}}},
+		},
+		"missing ca": {
+			config:      configapi.ClientCertificateAuthConfig{DelegatedCAs: []configapi.DelegatedClientCA{{UserPrefix: "partner:"}}},
+			expectError: true,
+		},
+		"empty group": {
+			config:      configapi.ClientCertificateAuthConfig{DelegatedCAs: []configapi.DelegatedClientCA{{CA: "/dev/null", UserPrefix: "partner:", AllowedGroups: []string{""}}}},
+			expectError: true,
+		},
+		"any user": {
+			config:         configapi.ClientCertificateAuthConfig{DelegatedCAs: []configapi.DelegatedClientCA{{CA: "/dev/null"}}},
+			expectWarnings: true,
+		},
+	}
+
+	for name, tc := range tests {
+		results := ValidateClientCertificateAuthConfig(tc.config)
+		if (len(results.Errors) > 0) != tc.expectError {
+			t.Errorf("%s: unexpected errors %v", name, results.Errors)
+		}
+		if (len(results.Warnings) > 0) != tc.expectWarnings {
+			t.Errorf("%s: unexpected warnings %v", name, results.Warnings)
+		}
+	}
+}
+
+func TestValidateRequestAuthenticationConfig(t *testing.T) {
+	configFile, err := ioutil.TempFile("", "webhook.kubeconfig")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(configFile.Name())
+	configFile.Close()
+
+	tests := map[string]struct {
+		order       []string
+		webhook     *configapi.WebhookTokenConfig
+		expectError bool
+	}{
+		"default":    {},
+		"reordered":  {order: []string{configapi.ClientCertificateAuthenticator, configapi.OAuthTokenAuthenticator}},
+		"unknown":    {order: []string{"Kerberos"}, expectError: true},
+		"duplicated": {order: []string{configapi.OAuthTokenAuthenticator, configapi.OAuthTokenAuthenticator}, expectError: true},
+		"webhook": {
+			order:   []string{configapi.WebhookTokenAuthenticator},
+			webhook: &configapi.WebhookTokenConfig{ConfigFile: configFile.Name(), CacheTTL: "2m", CacheSize: 1000},
+		},
+		"webhook without config file": {
+			webhook:     &configapi.WebhookTokenConfig{},
+			expectError: true,
+		},
+		"webhook with missing config file": {
+			webhook:     &configapi.WebhookTokenConfig{ConfigFile: configFile.Name() + ".missing"},
+			expectError: true,
+		},
+		"webhook with invalid cache ttl": {
+			webhook:     &configapi.WebhookTokenConfig{ConfigFile: configFile.Name(), CacheTTL: "2", CacheSize: 1000},
+			expectError: true,
+		},
+		"webhook cache without size": {
+			webhook:     &configapi.WebhookTokenConfig{ConfigFile: configFile.Name(), CacheTTL: "2m"},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		errs := ValidateRequestAuthenticationConfig(configapi.RequestAuthenticationConfig{Order: tc.order, WebhookTokenConfig: tc.webhook})
+		if (len(errs) > 0) != tc.expectError {
+			t.Errorf("%s: unexpected errors %v", name, errs)
+		}
+	}
+}
+
+func TestValidateNotificationConfig(t *testing.T) {
+	tests := map[string]struct {
+		webhook     configapi.NotificationWebhookConfig
+		expectError bool
+	}{
+		"all events": {
+			webhook: configapi.NotificationWebhookConfig{URL: "https://hooks.example.com/builds"},
+		},
+		"templated": {
+			webhook: configapi.NotificationWebhookConfig{
+				URL:             "http://hooks.example.com/builds",
+				Events:          []configapi.NotificationEventType{configapi.NotificationEventBuildFailed, configapi.NotificationEventDeploymentFailed},
+				PayloadTemplate: `{"text": "{{.Kind}} {{.Namespace}}/{{.Name}}: {{.Event}}"}`,
+			},
+		},
+		"missing url": {
+			webhook:     configapi.NotificationWebhookConfig{},
+			expectError: true,
+		},
+		"unsupported scheme": {
+			webhook:     configapi.NotificationWebhookConfig{URL: "ftp://hooks.example.com"},
+			expectError: true,
+		},
+		"unknown event": {
+			webhook:     configapi.NotificationWebhookConfig{URL: "https://hooks.example.com", Events: []configapi.NotificationEventType{"BuildStarted"}},
+			expectError: true,
+		},
+		"invalid template": {
+			webhook:     configapi.NotificationWebhookConfig{URL: "https://hooks.example.com", PayloadTemplate: "{{.Name"},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		errs := ValidateNotificationConfig(configapi.NotificationConfig{Webhooks: []configapi.NotificationWebhookConfig{tc.webhook}})
+		if (len(errs) > 0) != tc.expectError {
+			t.Errorf("%s: unexpected errors %v", name, errs)
+		}
+	}
+}
+
+func TestValidatePodLoggingConfig(t *testing.T) {
+	tests := map[string]struct {
+		prefix      string
+		expectError bool
+	}{
+		"domain":            {prefix: "logging.example.com/"},
+		"no domain":         {prefix: "logging-"},
+		"empty":             {prefix: "", expectError: true},
+		"invalid domain":    {prefix: "logging_example/", expectError: true},
+		"invalid character": {prefix: "logging.example.com/ci:", expectError: true},
+	}
+
+	for name, tc := range tests {
+		errs := ValidatePodLoggingConfig(configapi.PodLoggingConfig{AnnotationPrefix: tc.prefix})
+		if (len(errs) > 0) != tc.expectError {
+			t.Errorf("%s: unexpected errors %v", name, errs)
+		}
+	}
+}
+
+func TestValidateMasterPublicURLs(t *testing.T) {
+	testCases := map[string]struct {
+		config           *configapi.MasterConfig
+		expectedWarnings int
+	}{
+		"consistent": {
+			config: &configapi.MasterConfig{
+				MasterPublicURL: "https://master.example.com:8443",
+				OAuthConfig:     &configapi.OAuthConfig{MasterPublicURL: "https://master.example.com:8443"},
+				AssetConfig:     &configapi.AssetConfig{MasterPublicURL: "https://master.example.com:8443"},
+			},
+		},
+		"no oauth or console": {
+			config: &configapi.MasterConfig{MasterPublicURL: "https://master.example.com:8443"},
+		},
+		"internal url advertised": {
+			config: &configapi.MasterConfig{
+				MasterPublicURL: "https://master.example.com:8443",
+				OAuthConfig:     &configapi.OAuthConfig{MasterPublicURL: "https://master.internal:8443"},
+				AssetConfig:     &configapi.AssetConfig{MasterPublicURL: "https://master.internal:8443"},
+			},
+			expectedWarnings: 2,
+		},
+	}
+	for name, tc := range testCases {
+		results := ValidateMasterPublicURLs(tc.config)
+		if len(results.Errors) > 0 {
+			t.Errorf("%s: unexpected errors %v", name, results.Errors)
+		}
+		if len(results.Warnings) != tc.expectedWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.expectedWarnings, results.Warnings)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
 	kapp "k8s.io/kubernetes/cmd/kubelet/app"
 	"k8s.io/kubernetes/pkg/util/fielderrors"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/cmd/server/api"
 )
@@ -26,6 +28,9 @@ func ValidateNodeConfig(config *api.NodeConfig) ValidationResults {
 		validationResults.AddErrors(fielderrors.NewFieldInvalid("servingInfo.bindNetwork", config.ServingInfo.BindNetwork, "tcp6 is not a valid bindNetwork for nodes, must be tcp or tcp4"))
 	}
 	validationResults.AddErrors(ValidateKubeConfig(config.MasterKubeConfig, "masterKubeConfig")...)
+	if len(config.BootstrapKubeConfig) > 0 {
+		validationResults.AddErrors(ValidateKubeConfig(config.BootstrapKubeConfig, "bootstrapKubeConfig")...)
+	}
 
 	if len(config.DNSIP) > 0 {
 		validationResults.AddErrors(ValidateSpecifiedIP(config.DNSIP, "dnsIP")...)
@@ -41,15 +46,32 @@ func ValidateNodeConfig(config *api.NodeConfig) ValidationResults {
 
 	validationResults.AddErrors(ValidateDockerConfig(config.DockerConfig).Prefix("dockerConfig")...)
 
+	validationResults.AddErrors(ValidateNodeDiskConfig(config.DiskConfig).Prefix("diskConfig")...)
+
 	validationResults.AddErrors(ValidateNodeAuthConfig(config.AuthConfig).Prefix("authConfig")...)
 
 	validationResults.AddErrors(ValidateKubeletExtendedArguments(config.KubeletArguments).Prefix("kubeletArguments")...)
 
+	validationResults.AddErrors(ValidateProxyConfig(*config)...)
+
+	return validationResults
+}
+
+// ValidateProxyConfig validates the settings of the service proxy run by the node
+func ValidateProxyConfig(config api.NodeConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
 	if _, err := time.ParseDuration(config.IPTablesSyncPeriod); err != nil {
-		validationResults.AddErrors(fielderrors.NewFieldInvalid("iptablesSyncPeriod", config.IPTablesSyncPeriod, fmt.Sprintf("unable to parse iptablesSyncPeriod: %v. Examples with correct format: '5s', '1m', '2h22m'", err)))
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("iptablesSyncPeriod", config.IPTablesSyncPeriod, fmt.Sprintf("unable to parse iptablesSyncPeriod: %v. Examples with correct format: '5s', '1m', '2h22m'", err)))
+	}
+	if !api.ValidProxyModes.Has(string(config.ProxyMode)) {
+		allErrs = append(allErrs, fielderrors.NewFieldValueNotSupported("proxyMode", config.ProxyMode, api.ValidProxyModes.List()))
+	}
+	if config.ConntrackMax < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("conntrackMax", config.ConntrackMax, "cannot be less than zero"))
 	}
 
-	return validationResults
+	return allErrs
 }
 
 func ValidateNodeAuthConfig(config api.NodeAuthConfig) fielderrors.ValidationErrorList {
@@ -82,17 +104,56 @@ func ValidateNodeAuthConfig(config api.NodeAuthConfig) fielderrors.ValidationErr
 	return allErrs
 }
 
+// minimumMTU is the smallest MTU every IPv4 host is required to accept
+const minimumMTU = 576
+
 func ValidateNetworkConfig(config api.NodeNetworkConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
 	if len(config.NetworkPluginName) > 0 {
-		if config.MTU == 0 {
-			allErrs = append(allErrs, fielderrors.NewFieldInvalid("mtu", config.MTU, fmt.Sprintf("must be greater than zero")))
+		if config.MTU != 0 && config.MTU < minimumMTU {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("mtu", config.MTU, fmt.Sprintf("must be zero to detect the MTU automatically, or at least %d", minimumMTU)))
 		}
 	}
 	return allErrs
 }
 
+// ValidateNodeDiskConfig validates the thresholds of disk usage, taking the defaults of the Kubelet for the
+// thresholds that are not set
+func ValidateNodeDiskConfig(config api.NodeDiskConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	defaults := kapp.NewKubeletServer()
+	high, low := defaults.ImageGCHighThresholdPercent, defaults.ImageGCLowThresholdPercent
+	if config.ImageGCHighThresholdPercent != 0 {
+		high = config.ImageGCHighThresholdPercent
+		if high < 0 || high > 100 {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("imageGCHighThresholdPercent", high, "must be between 0 and 100"))
+		}
+	}
+	if config.ImageGCLowThresholdPercent != 0 {
+		low = config.ImageGCLowThresholdPercent
+		if low < 0 || low > 100 {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("imageGCLowThresholdPercent", low, "must be between 0 and 100"))
+		}
+	}
+	if len(allErrs) == 0 && low > high {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("imageGCLowThresholdPercent", low, fmt.Sprintf("cannot be greater than the high threshold of %d%%", high)))
+	}
+
+	if config.ImageFSMinimumFreeMB < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("imageFSMinimumFreeMB", config.ImageFSMinimumFreeMB, "cannot be less than zero"))
+	}
+	if config.NodeFSMinimumFreeMB < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("nodeFSMinimumFreeMB", config.NodeFSMinimumFreeMB, "cannot be less than zero"))
+	}
+
+	return allErrs
+}
+
+// validDockerEndpointSchemes are the schemes of the addresses Docker daemons can be reached at
+var validDockerEndpointSchemes = sets.NewString("unix", "tcp", "http", "https")
+
 func ValidateDockerConfig(config api.DockerConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
@@ -104,6 +165,14 @@ func ValidateDockerConfig(config api.DockerConfig) fielderrors.ValidationErrorLi
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("execHandlerName", config.ExecHandlerName, fmt.Sprintf("must be one of %s", validValues)))
 	}
 
+	if len(config.Endpoint) > 0 {
+		if u, err := url.Parse(config.Endpoint); err != nil {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("endpoint", config.Endpoint, err.Error()))
+		} else if !validDockerEndpointSchemes.Has(u.Scheme) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("endpoint", config.Endpoint, fmt.Sprintf("must use one of the schemes %s", strings.Join(validDockerEndpointSchemes.List(), ", "))))
+		}
+	}
+
 	return allErrs
 }
 
@@ -67,3 +67,124 @@ func TestFailingKubeletArgs(t *testing.T) {
 		t.Errorf("expected %v, got %v", e, a)
 	}
 }
+
+func TestValidateNetworkConfigMTU(t *testing.T) {
+	tests := map[uint]int{
+		0:    0,
+		500:  1,
+		576:  0,
+		1450: 0,
+	}
+	for mtu, expected := range tests {
+		errs := ValidateNetworkConfig(configapi.NodeNetworkConfig{NetworkPluginName: "redhat/openshift-ovs-subnet", MTU: mtu})
+		if len(errs) != expected {
+			t.Errorf("%d: expected %d errors, got %v", mtu, expected, errs)
+		}
+	}
+}
+
+func TestValidateProxyConfig(t *testing.T) {
+	tests := map[string]struct {
+		config   configapi.NodeConfig
+		expected int
+	}{
+		"iptables": {
+			config:   configapi.NodeConfig{IPTablesSyncPeriod: "5s", ProxyMode: configapi.ProxyModeIPTables},
+			expected: 0,
+		},
+		"userspace with conntrack max": {
+			config:   configapi.NodeConfig{IPTablesSyncPeriod: "30s", ProxyMode: configapi.ProxyModeUserspace, ConntrackMax: 262144},
+			expected: 0,
+		},
+		"unknown mode": {
+			config:   configapi.NodeConfig{IPTablesSyncPeriod: "5s", ProxyMode: "ipvs"},
+			expected: 1,
+		},
+		"negative conntrack max": {
+			config:   configapi.NodeConfig{IPTablesSyncPeriod: "5s", ProxyMode: configapi.ProxyModeIPTables, ConntrackMax: -1},
+			expected: 1,
+		},
+		"missing everything": {
+			config:   configapi.NodeConfig{},
+			expected: 2,
+		},
+	}
+	for name, test := range tests {
+		errs := ValidateProxyConfig(test.config)
+		if len(errs) != test.expected {
+			t.Errorf("%s: expected %d errors, got %v", name, test.expected, errs)
+		}
+	}
+}
+
+func TestValidateNodeDiskConfig(t *testing.T) {
+	tests := map[string]struct {
+		config   configapi.NodeDiskConfig
+		expected int
+	}{
+		"defaults": {
+			config:   configapi.NodeDiskConfig{},
+			expected: 0,
+		},
+		"all set": {
+			config:   configapi.NodeDiskConfig{ImageGCHighThresholdPercent: 85, ImageGCLowThresholdPercent: 70, ImageFSMinimumFreeMB: 1024, NodeFSMinimumFreeMB: 512},
+			expected: 0,
+		},
+		"high threshold over 100": {
+			config:   configapi.NodeDiskConfig{ImageGCHighThresholdPercent: 101},
+			expected: 1,
+		},
+		"low threshold over the high threshold": {
+			config:   configapi.NodeDiskConfig{ImageGCHighThresholdPercent: 60, ImageGCLowThresholdPercent: 70},
+			expected: 1,
+		},
+		"high threshold under the default low threshold": {
+			config:   configapi.NodeDiskConfig{ImageGCHighThresholdPercent: 50},
+			expected: 1,
+		},
+		"negative free space": {
+			config:   configapi.NodeDiskConfig{ImageFSMinimumFreeMB: -1, NodeFSMinimumFreeMB: -1},
+			expected: 2,
+		},
+	}
+	for name, test := range tests {
+		errs := ValidateNodeDiskConfig(test.config)
+		if len(errs) != test.expected {
+			t.Errorf("%s: expected %d errors, got %v", name, test.expected, errs)
+		}
+	}
+}
+
+func TestValidateDockerConfig(t *testing.T) {
+	tests := map[string]struct {
+		config   configapi.DockerConfig
+		expected int
+	}{
+		"default endpoint": {
+			config:   configapi.DockerConfig{ExecHandlerName: configapi.DockerExecHandlerNative},
+			expected: 0,
+		},
+		"socket": {
+			config:   configapi.DockerConfig{ExecHandlerName: configapi.DockerExecHandlerNative, Endpoint: "unix:///var/run/docker.sock"},
+			expected: 0,
+		},
+		"remote daemon": {
+			config:   configapi.DockerConfig{ExecHandlerName: configapi.DockerExecHandlerNsenter, Endpoint: "tcp://10.0.0.1:2376"},
+			expected: 0,
+		},
+		"path": {
+			config:   configapi.DockerConfig{ExecHandlerName: configapi.DockerExecHandlerNative, Endpoint: "/var/run/docker.sock"},
+			expected: 1,
+		},
+		"unknown exec handler": {
+			config:   configapi.DockerConfig{ExecHandlerName: "ssh"},
+			expected: 1,
+		},
+	}
+	for name, test := range tests {
+		errs := ValidateDockerConfig(test.config)
+		if len(errs) != test.expected {
+			t.Errorf("%s: expected %d errors, got %v", name, test.expected, errs)
+		}
+	}
+}
@@ -5,15 +5,22 @@ import (
 	"io/ioutil"
 	"net/url"
 	"strings"
+	"time"
+
+	"gopkg.in/ldap.v2"
 
 	"k8s.io/kubernetes/pkg/util/fielderrors"
 	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/auth/authenticator/redirector"
+	"github.com/openshift/origin/pkg/auth/server/errorpage"
+	"github.com/openshift/origin/pkg/auth/server/grant"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 	"github.com/openshift/origin/pkg/auth/server/login"
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
 	"github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/api/latest"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/user/api/validation"
 )
 
@@ -44,8 +51,14 @@ func ValidateOAuthConfig(config *api.OAuthConfig) ValidationResults {
 		validationResults.AddErrors(ValidateSessionConfig(config.SessionConfig).Prefix("sessionConfig")...)
 	}
 
+	if config.LoginThrottle != nil {
+		validationResults.AddErrors(ValidateLoginThrottleConfig(config.LoginThrottle).Prefix("loginThrottle")...)
+	}
+
 	validationResults.AddErrors(ValidateGrantConfig(config.GrantConfig).Prefix("grantConfig")...)
 
+	validationResults.AddErrors(ValidateTokenConfig(config.TokenConfig).Prefix("tokenConfig")...)
+
 	providerNames := sets.NewString()
 	redirectingIdentityProviders := []string{}
 
@@ -98,20 +111,32 @@ func ValidateOAuthConfig(config *api.OAuthConfig) ValidationResults {
 			)))
 	}
 
-	if config.Templates != nil && len(config.Templates.Login) > 0 {
-		content, err := ioutil.ReadFile(config.Templates.Login)
-		if err != nil {
-			validationResults.AddErrors(fielderrors.NewFieldInvalid("templates.login", config.Templates.Login, "could not read file"))
-		} else {
-			for _, err = range login.ValidateLoginTemplate(content) {
-				validationResults.AddErrors(fielderrors.NewFieldInvalid("templates.login", config.Templates.Login, err.Error()))
-			}
-		}
+	if config.Templates != nil {
+		validationResults.AddErrors(validateOAuthTemplate(config.Templates.Login, "templates.login", login.ValidateLoginTemplate)...)
+		validationResults.AddErrors(validateOAuthTemplate(config.Templates.Grant, "templates.grant", grant.ValidateGrantTemplate)...)
+		validationResults.AddErrors(validateOAuthTemplate(config.Templates.Error, "templates.error", errorpage.ValidateErrorPageTemplate)...)
 	}
 
 	return validationResults
 }
 
+// validateOAuthTemplate checks that the optional template file can be read and is accepted by validate
+func validateOAuthTemplate(file, field string, validate func([]byte) []error) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	if len(file) == 0 {
+		return allErrs
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return append(allErrs, fielderrors.NewFieldInvalid(field, file, "could not read file"))
+	}
+	for _, err := range validate(content) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid(field, file, err.Error()))
+	}
+	return allErrs
+}
+
 var validMappingMethods = sets.NewString(
 	string(identitymapper.MappingMethodLookup),
 	string(identitymapper.MappingMethodClaim),
@@ -163,6 +188,9 @@ func ValidateIdentityProvider(identityProvider api.IdentityProvider) ValidationR
 		case (*api.OpenIDIdentityProvider):
 			validationResults.AddErrors(ValidateOpenIDIdentityProvider(provider, identityProvider)...)
 
+		case (*api.SAMLIdentityProvider):
+			validationResults.AddErrors(ValidateSAMLIdentityProvider(provider, identityProvider)...)
+
 		}
 	}
 
@@ -177,9 +205,39 @@ func ValidateLDAPIdentityProvider(provider *api.LDAPPasswordIdentityProvider) Va
 		validationResults.AddErrors(fielderrors.NewFieldInvalid("provider.attributes.id", "[]", "at least one id attribute is required (LDAP standard identity attribute is 'dn')"))
 	}
 
+	if provider.Groups != nil {
+		validationResults.AddErrors(ValidateLDAPGroupMembershipLookup(provider.Groups).Prefix("provider.groups")...)
+	}
+
 	return validationResults
 }
 
+func ValidateLDAPGroupMembershipLookup(lookup *api.LDAPGroupMembershipLookup) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	if len(lookup.BaseDN) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("baseDN"))
+	} else if _, err := ldap.ParseDN(lookup.BaseDN); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("baseDN", lookup.BaseDN, fmt.Sprintf("invalid base DN for search: %v", err)))
+	}
+	if len(lookup.Filter) > 0 {
+		if _, err := ldap.CompileFilter(lookup.Filter); err != nil {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("filter", lookup.Filter, fmt.Sprintf("invalid query filter: %v", err)))
+		}
+	}
+	if len(lookup.MemberAttribute) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("memberAttribute"))
+	}
+	if len(lookup.NameAttribute) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("nameAttribute"))
+	}
+	if strings.HasPrefix(lookup.GroupPrefix, "system:") {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("groupPrefix", lookup.GroupPrefix, "users cannot be added to system: groups"))
+	}
+
+	return allErrs
+}
+
 // RemoteConnection fields validated separately -- this is for keystone-specific validation
 func ValidateKeystoneIdentityProvider(provider *api.KeystonePasswordIdentityProvider, identityProvider api.IdentityProvider) ValidationResults {
 	validationResults := ValidationResults{}
@@ -203,10 +261,29 @@ func ValidateRequestHeaderIdentityProvider(provider *api.RequestHeaderIdentityPr
 
 	if len(provider.ClientCA) > 0 {
 		validationResults.AddErrors(ValidateFile(provider.ClientCA, "provider.clientCA")...)
+	} else if len(provider.ClientCommonNames) > 0 {
+		err := fielderrors.NewFieldRequired("provider.clientCA")
+		err.Detail = "clientCA is required if clientCommonNames are set"
+		validationResults.AddErrors(err)
+	} else {
+		validationResults.AddWarnings(fielderrors.NewFieldInvalid("provider.clientCA", provider.ClientCA, "requests are not verified, any client that can reach the OAuth server can set the headers and impersonate any identity"))
+	}
+	for i, name := range provider.ClientCommonNames {
+		if len(name) == 0 {
+			validationResults.AddErrors(fielderrors.NewFieldRequired(fmt.Sprintf("provider.clientCommonNames[%d]", i)))
+		}
 	}
 	if len(provider.Headers) == 0 {
 		validationResults.AddErrors(fielderrors.NewFieldRequired("provider.headers"))
 	}
+	if len(provider.GroupHeaders) > 0 && len(provider.ClientCA) == 0 {
+		err := fielderrors.NewFieldRequired("provider.clientCA")
+		err.Detail = "clientCA is required if groupHeaders are set, otherwise any client could add users to groups"
+		validationResults.AddErrors(err)
+	}
+	if strings.HasPrefix(provider.GroupPrefix, "system:") {
+		validationResults.AddErrors(fielderrors.NewFieldInvalid("provider.groupPrefix", provider.GroupPrefix, "users cannot be added to system: groups"))
+	}
 	if identityProvider.UseAsChallenger && len(provider.ChallengeURL) == 0 {
 		err := fielderrors.NewFieldRequired("provider.challengeURL")
 		err.Detail = "challengeURL is required if challenge=true"
@@ -274,20 +351,35 @@ func ValidateOpenIDIdentityProvider(provider *api.OpenIDIdentityProvider, identi
 
 	allErrs = append(allErrs, ValidateOAuthIdentityProvider(provider.ClientID, provider.ClientSecret, identityProvider.UseAsChallenger)...)
 
+	// The issuer value is a case sensitive URL using the https scheme
+	// http://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+	if len(provider.Issuer) != 0 {
+		issuer, urlErrs := ValidateSecureURL(provider.Issuer, "provider.issuer")
+		allErrs = append(allErrs, urlErrs...)
+		if len(urlErrs) == 0 && (len(issuer.RawQuery) != 0 || len(issuer.Fragment) != 0) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("provider.issuer", provider.Issuer, "must not contain a query or a fragment"))
+		}
+	}
+
 	// Communication with the Authorization Endpoint MUST utilize TLS
 	// http://openid.net/specs/openid-connect-core-1_0.html#AuthorizationEndpoint
-	_, urlErrs := ValidateSecureURL(provider.URLs.Authorize, "authorize")
-	allErrs = append(allErrs, urlErrs.Prefix("provider.urls")...)
+	// The endpoints of the discovery document of the issuer are used when they are not set
+	if len(provider.URLs.Authorize) != 0 || len(provider.Issuer) == 0 {
+		_, urlErrs := ValidateSecureURL(provider.URLs.Authorize, "authorize")
+		allErrs = append(allErrs, urlErrs.Prefix("provider.urls")...)
+	}
 
 	// Communication with the Token Endpoint MUST utilize TLS
 	// http://openid.net/specs/openid-connect-core-1_0.html#TokenEndpoint
-	_, urlErrs = ValidateSecureURL(provider.URLs.Token, "token")
-	allErrs = append(allErrs, urlErrs.Prefix("provider.urls")...)
+	if len(provider.URLs.Token) != 0 || len(provider.Issuer) == 0 {
+		_, urlErrs := ValidateSecureURL(provider.URLs.Token, "token")
+		allErrs = append(allErrs, urlErrs.Prefix("provider.urls")...)
+	}
 
 	if len(provider.URLs.UserInfo) != 0 {
 		// Communication with the UserInfo Endpoint MUST utilize TLS
 		// http://openid.net/specs/openid-connect-core-1_0.html#UserInfo
-		_, urlErrs = ValidateSecureURL(provider.URLs.UserInfo, "userInfo")
+		_, urlErrs := ValidateSecureURL(provider.URLs.UserInfo, "userInfo")
 		allErrs = append(allErrs, urlErrs.Prefix("provider.urls")...)
 	}
 
@@ -303,6 +395,32 @@ func ValidateOpenIDIdentityProvider(provider *api.OpenIDIdentityProvider, identi
 	return allErrs
 }
 
+func ValidateSAMLIdentityProvider(provider *api.SAMLIdentityProvider, identityProvider api.IdentityProvider) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	if len(provider.EntityID) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("provider.entityID"))
+	}
+	if len(provider.Issuer) == 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldRequired("provider.issuer"))
+	}
+
+	_, urlErrs := ValidateURL(provider.SingleSignOnURL, "provider.singleSignOnURL")
+	allErrs = append(allErrs, urlErrs...)
+
+	if fileErrs := ValidateFile(provider.SigningCertificates, "provider.signingCertificates"); len(fileErrs) != 0 {
+		allErrs = append(allErrs, fileErrs...)
+	} else if _, err := cmdutil.CertificatesFromFile(provider.SigningCertificates); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("provider.signingCertificates", provider.SigningCertificates, fmt.Sprintf("could not read certificates: %v", err)))
+	}
+
+	if identityProvider.UseAsChallenger {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("challenge", identityProvider.UseAsChallenger, "saml providers cannot be used for challenges"))
+	}
+
+	return allErrs
+}
+
 func ValidateGrantConfig(config api.GrantConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
@@ -313,6 +431,51 @@ func ValidateGrantConfig(config api.GrantConfig) fielderrors.ValidationErrorList
 	return allErrs
 }
 
+func ValidateTokenConfig(config api.TokenConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	if len(config.AccessTokenCacheTTL) == 0 {
+		return allErrs
+	}
+	if ttl, err := time.ParseDuration(config.AccessTokenCacheTTL); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("accessTokenCacheTTL", config.AccessTokenCacheTTL, fmt.Sprintf("%v", err)))
+	} else if ttl < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("accessTokenCacheTTL", config.AccessTokenCacheTTL, "cannot be less than zero"))
+	} else if ttl > 0 && config.AccessTokenCacheSize <= 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("accessTokenCacheSize", config.AccessTokenCacheSize, "must be greater than zero when accessTokenCacheTTL is set"))
+	}
+
+	return allErrs
+}
+
+func ValidateLoginThrottleConfig(config *api.LoginThrottleConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	if config.MaxFailedAttempts < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("maxFailedAttempts", config.MaxFailedAttempts, "must be 0 (unlimited) or greater"))
+	}
+	if config.MaxFailedAttemptsPerSourceIP < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("maxFailedAttemptsPerSourceIP", config.MaxFailedAttemptsPerSourceIP, "must be 0 (unlimited) or greater"))
+	}
+	for i, proxy := range config.TrustedProxies {
+		if _, err := lockout.ParseTrustedProxies([]string{proxy}); err != nil {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid(fmt.Sprintf("trustedProxies[%d]", i), proxy, "must be an IP address or a CIDR"))
+		}
+	}
+	if config.MaxFailedAttempts == 0 && config.MaxFailedAttemptsPerSourceIP == 0 {
+		return allErrs
+	}
+
+	if config.LockoutSeconds <= 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("lockoutSeconds", config.LockoutSeconds, "must be greater than 0"))
+	}
+	if config.MaxLockoutSeconds < config.LockoutSeconds {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("maxLockoutSeconds", config.MaxLockoutSeconds, "must be greater than or equal to lockoutSeconds"))
+	}
+
+	return allErrs
+}
+
 func ValidateSessionConfig(config *api.SessionConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
@@ -0,0 +1,223 @@
+package validation
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+func TestValidateLDAPGroupMembershipLookup(t *testing.T) {
+	testCases := map[string]struct {
+		lookup         configapi.LDAPGroupMembershipLookup
+		expectedErrors int
+	}{
+		"valid": {
+			lookup: configapi.LDAPGroupMembershipLookup{BaseDN: "ou=groups,dc=example,dc=com", Filter: "(objectClass=groupOfNames)", MemberAttribute: "member", NameAttribute: "cn", Nested: true, GroupPrefix: "ldap:", AllowedGroups: []string{"developers"}},
+		},
+		"system group prefix": {
+			lookup:         configapi.LDAPGroupMembershipLookup{BaseDN: "ou=groups,dc=example,dc=com", MemberAttribute: "member", NameAttribute: "cn", GroupPrefix: "system:"},
+			expectedErrors: 1,
+		},
+		"empty": {
+			expectedErrors: 3,
+		},
+		"invalid base DN and filter": {
+			lookup:         configapi.LDAPGroupMembershipLookup{BaseDN: "groups", Filter: "objectClass=groupOfNames", MemberAttribute: "member", NameAttribute: "cn"},
+			expectedErrors: 2,
+		},
+	}
+	for name, tc := range testCases {
+		errs := ValidateLDAPGroupMembershipLookup(&tc.lookup)
+		if len(errs) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectedErrors, errs)
+		}
+	}
+}
+
+func TestValidateOpenIDIdentityProviderIssuer(t *testing.T) {
+	testCases := map[string]struct {
+		issuer         string
+		urls           configapi.OpenIDURLs
+		expectedErrors int
+	}{
+		"urls": {
+			urls: configapi.OpenIDURLs{Authorize: "https://example.com/auth", Token: "https://example.com/token"},
+		},
+		"issuer": {
+			issuer: "https://example.com",
+		},
+		"issuer and urls": {
+			issuer: "https://example.com",
+			urls:   configapi.OpenIDURLs{Token: "http://example.com/token"},
+			// the token URL must use https
+			expectedErrors: 1,
+		},
+		"insecure issuer": {
+			issuer:         "http://example.com",
+			expectedErrors: 1,
+		},
+		"issuer with query": {
+			issuer:         "https://example.com?realm=master",
+			expectedErrors: 1,
+		},
+		"neither": {
+			// the authorize and token URLs lack a scheme and a host
+			expectedErrors: 4,
+		},
+	}
+	for name, tc := range testCases {
+		provider := &configapi.OpenIDIdentityProvider{
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Issuer:       tc.issuer,
+			URLs:         tc.urls,
+			Claims:       configapi.OpenIDClaims{ID: []string{"sub"}},
+		}
+		errs := ValidateOpenIDIdentityProvider(provider, configapi.IdentityProvider{Name: "openid"})
+		if len(errs) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectedErrors, errs)
+		}
+	}
+}
+
+func TestValidateRequestHeaderIdentityProvider(t *testing.T) {
+	testCases := map[string]struct {
+		provider         configapi.RequestHeaderIdentityProvider
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		"client CA and common names": {
+			provider: configapi.RequestHeaderIdentityProvider{ClientCA: "/dev/null", ClientCommonNames: []string{"front-proxy"}, Headers: []string{"X-Remote-User"}},
+		},
+		"no client CA": {
+			provider:         configapi.RequestHeaderIdentityProvider{Headers: []string{"X-Remote-User"}},
+			expectedWarnings: 1,
+		},
+		"common names without client CA": {
+			provider:       configapi.RequestHeaderIdentityProvider{ClientCommonNames: []string{"front-proxy"}, Headers: []string{"X-Remote-User"}},
+			expectedErrors: 1,
+		},
+		"empty common name": {
+			provider:       configapi.RequestHeaderIdentityProvider{ClientCA: "/dev/null", ClientCommonNames: []string{""}, Headers: []string{"X-Remote-User"}},
+			expectedErrors: 1,
+		},
+		"group headers": {
+			provider: configapi.RequestHeaderIdentityProvider{ClientCA: "/dev/null", Headers: []string{"X-Remote-User"}, GroupHeaders: []string{"X-Remote-Group"}, GroupPrefix: "proxy:", AllowedGroups: []string{"developers"}},
+		},
+		"group headers without client CA": {
+			provider:         configapi.RequestHeaderIdentityProvider{Headers: []string{"X-Remote-User"}, GroupHeaders: []string{"X-Remote-Group"}},
+			expectedErrors:   1,
+			expectedWarnings: 1,
+		},
+		"system group prefix": {
+			provider:       configapi.RequestHeaderIdentityProvider{ClientCA: "/dev/null", Headers: []string{"X-Remote-User"}, GroupHeaders: []string{"X-Remote-Group"}, GroupPrefix: "system:"},
+			expectedErrors: 1,
+		},
+	}
+	for name, tc := range testCases {
+		results := ValidateRequestHeaderIdentityProvider(&tc.provider, configapi.IdentityProvider{})
+		if len(results.Errors) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectedErrors, results.Errors)
+		}
+		if len(results.Warnings) != tc.expectedWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.expectedWarnings, results.Warnings)
+		}
+	}
+}
+
+func TestValidateTokenConfig(t *testing.T) {
+	testCases := map[string]struct {
+		config         configapi.TokenConfig
+		expectedErrors int
+	}{
+		"no cache": {},
+		"disabled cache": {
+			config: configapi.TokenConfig{AccessTokenCacheTTL: "0s"},
+		},
+		"cache": {
+			config: configapi.TokenConfig{AccessTokenCacheTTL: "10s", AccessTokenCacheSize: 1000},
+		},
+		"cache without size": {
+			config:         configapi.TokenConfig{AccessTokenCacheTTL: "10s"},
+			expectedErrors: 1,
+		},
+		"invalid ttl": {
+			config:         configapi.TokenConfig{AccessTokenCacheTTL: "10", AccessTokenCacheSize: 1000},
+			expectedErrors: 1,
+		},
+		"negative ttl": {
+			config:         configapi.TokenConfig{AccessTokenCacheTTL: "-10s", AccessTokenCacheSize: 1000},
+			expectedErrors: 1,
+		},
+	}
+	for name, tc := range testCases {
+		errs := ValidateTokenConfig(tc.config)
+		if len(errs) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectedErrors, errs)
+		}
+	}
+}
+
+func TestValidateSAMLIdentityProvider(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "idp"}, NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	certFile, err := ioutil.TempFile("", "saml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(certFile.Name())
+	pem.Encode(certFile, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	certFile.Close()
+
+	notCertFile, err := ioutil.TempFile("", "saml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(notCertFile.Name())
+	notCertFile.Close()
+
+	testCases := map[string]struct {
+		provider       configapi.SAMLIdentityProvider
+		challenge      bool
+		expectedErrors int
+	}{
+		"valid": {
+			provider: configapi.SAMLIdentityProvider{EntityID: "https://master.example.com", Issuer: "https://idp.example.com", SingleSignOnURL: "https://idp.example.com/sso", SigningCertificates: certFile.Name()},
+		},
+		"empty": {
+			// the single sign-on URL lacks a scheme and a host
+			expectedErrors: 5,
+		},
+		"no certificates": {
+			provider:       configapi.SAMLIdentityProvider{EntityID: "https://master.example.com", Issuer: "https://idp.example.com", SingleSignOnURL: "https://idp.example.com/sso", SigningCertificates: notCertFile.Name()},
+			expectedErrors: 1,
+		},
+		"challenge": {
+			provider:       configapi.SAMLIdentityProvider{EntityID: "https://master.example.com", Issuer: "https://idp.example.com", SingleSignOnURL: "https://idp.example.com/sso", SigningCertificates: certFile.Name()},
+			challenge:      true,
+			expectedErrors: 1,
+		},
+	}
+	for name, tc := range testCases {
+		errs := ValidateSAMLIdentityProvider(&tc.provider, configapi.IdentityProvider{Name: "saml", UseAsChallenger: tc.challenge})
+		if len(errs) != tc.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectedErrors, errs)
+		}
+	}
+}
@@ -29,6 +29,9 @@ const (
 	// This should remain in the default role bindings for the NodeAdmin role
 	LegacyMasterKubeletAdminClientUsername = "system:master"
 	MasterKubeletAdminClientUsername       = "system:openshift-node-admin"
+
+	// NodeUsernamePrefix is prepended to the node name to form the username of the node's client certificate
+	NodeUsernamePrefix = "system:node:"
 )
 
 // groups
@@ -79,6 +82,12 @@ const (
 	NodeReaderRoleName = "system:node-reader"
 
 	OpenshiftSharedResourceViewRoleName = "shared-resource-viewer"
+
+	// The build strategy roles allow creating builds and build configs of one strategy. Admission
+	// rejects builds of a strategy the user is not allowed to use.
+	BuildStrategyDockerRoleName = "system:build-strategy-docker"
+	BuildStrategySourceRoleName = "system:build-strategy-source"
+	BuildStrategyCustomRoleName = "system:build-strategy-custom"
 )
 
 // RoleBindings
@@ -103,5 +112,20 @@ const (
 	SDNManagerRoleBindingName        = SDNManagerRoleName + "s"
 	WebHooksRoleBindingName          = WebHooksRoleName + "s"
 
+	BuildStrategyDockerRoleBindingName = BuildStrategyDockerRoleName + "-binding"
+	BuildStrategySourceRoleBindingName = BuildStrategySourceRoleName + "-binding"
+	BuildStrategyCustomRoleBindingName = BuildStrategyCustomRoleName + "-binding"
+
 	OpenshiftSharedResourceViewRoleBindingName = OpenshiftSharedResourceViewRoleName + "s"
 )
+
+// StatusCheckerURLs are the health and discovery endpoints everyone may get. A trailing * matches any path
+// starting with the rest of the URL.
+var StatusCheckerURLs = []string{
+	"/healthz", "/healthz/*",
+	"/version",
+	"/api", "/api/", "/api/v1", "/api/v1/",
+	"/apis", "/apis/", "/apis/extensions", "/apis/extensions/", "/apis/extensions/v1beta1", "/apis/extensions/v1beta1/",
+	"/osapi", "/osapi/", // these cannot be removed until we can drop support for pre 3.1 clients
+	"/oapi/", "/oapi", "/oapi/v1", "/oapi/v1/",
+}
@@ -33,6 +33,9 @@ const (
 
 	InfraPersistentVolumeProvisionerControllerServiceAccountName = "pv-provisioner-controller"
 	PersistentVolumeProvisionerControllerRoleName                = "system:pv-provisioner-controller"
+
+	InfraNodeBootstrapServiceAccountName = "node-bootstrapper"
+	NodeBootstrapRoleName                = "system:node-bootstrapper"
 )
 
 type InfraServiceAccounts struct {
@@ -88,7 +91,9 @@ func init() {
 			},
 			Rules: []authorizationapi.PolicyRule{
 				// BuildControllerFactory.buildLW
-				// BuildControllerFactory.buildDeleteLW
+				// BuildControllerFactory.migrateBuilds
+				// BuildController.BuildGetter (OSClientBuildClient)
+				// BuildDeleteController.BuildGetter (OSClientBuildClient)
 				{
 					Verbs:     sets.NewString("get", "list", "watch"),
 					Resources: sets.NewString("builds"),
@@ -98,6 +103,16 @@ func init() {
 					Verbs:     sets.NewString("update"),
 					Resources: sets.NewString("builds"),
 				},
+				// BuildDeleteController.BuildFinalizer (OSClientBuildClient)
+				{
+					Verbs:     sets.NewString("update"),
+					Resources: sets.NewString("builds/finalize"),
+				},
+				// BuildDeleteController.BuildDeleter (OSClientBuildClient)
+				{
+					Verbs:     sets.NewString("delete"),
+					Resources: sets.NewString("builds"),
+				},
 				// Create permission on virtual build type resources allows builds of those types to be updated
 				{
 					Verbs:     sets.NewString("create"),
@@ -110,7 +125,7 @@ func init() {
 				},
 				// BuildController.PodManager (ControllerClient)
 				// BuildDeleteController.PodManager (ControllerClient)
-				// BuildControllerFactory.buildDeleteLW
+				// BuildControllerFactory.migrateBuilds
 				{
 					Verbs:     sets.NewString("get", "list", "create", "delete"),
 					Resources: sets.NewString("pods"),
@@ -443,4 +458,23 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	err = InfraSAs.addServiceAccount(
+		InfraNodeBootstrapServiceAccountName,
+		authorizationapi.ClusterRole{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: NodeBootstrapRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				// Nodes use the token of this service account to request their first client certificate
+				{
+					Verbs:     sets.NewString("create", "get", "list", "watch"),
+					Resources: sets.NewString("certificatesigningrequests"),
+				},
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
 }
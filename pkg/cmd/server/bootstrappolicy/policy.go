@@ -102,7 +102,7 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 			Rules: []authorizationapi.PolicyRule{
 				{
 					Verbs:     sets.NewString("get", "list", "watch", "create", "update", "patch", "delete"),
-					Resources: sets.NewString(authorizationapi.OpenshiftExposedGroupName, authorizationapi.PermissionGrantingGroupName, authorizationapi.KubeExposedGroupName, "projects", "secrets", "pods/attach", "pods/proxy", "pods/exec", "pods/portforward", authorizationapi.DockerBuildResource, authorizationapi.SourceBuildResource, authorizationapi.CustomBuildResource, "deploymentconfigs/scale"),
+					Resources: sets.NewString(authorizationapi.OpenshiftExposedGroupName, authorizationapi.PermissionGrantingGroupName, authorizationapi.KubeExposedGroupName, "projects", "secrets", "pods/attach", "pods/proxy", "pods/exec", "pods/portforward", "deploymentconfigs/scale"),
 				},
 				{
 					APIGroups: []string{authorizationapi.APIGroupExtensions},
@@ -127,7 +127,7 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 			Rules: []authorizationapi.PolicyRule{
 				{
 					Verbs:     sets.NewString("get", "list", "watch", "create", "update", "patch", "delete"),
-					Resources: sets.NewString(authorizationapi.OpenshiftExposedGroupName, authorizationapi.KubeExposedGroupName, "secrets", "pods/attach", "pods/proxy", "pods/exec", "pods/portforward", authorizationapi.DockerBuildResource, authorizationapi.SourceBuildResource, authorizationapi.CustomBuildResource, "deploymentconfigs/scale"),
+					Resources: sets.NewString(authorizationapi.OpenshiftExposedGroupName, authorizationapi.KubeExposedGroupName, "secrets", "pods/attach", "pods/proxy", "pods/exec", "pods/portforward", "deploymentconfigs/scale"),
 				},
 				{
 					APIGroups: []string{authorizationapi.APIGroupExtensions},
@@ -171,6 +171,7 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 				{Verbs: sets.NewString("list", "get"), Resources: sets.NewString("clusterroles")},
 				{Verbs: sets.NewString("list"), Resources: sets.NewString("projects")},
 				{Verbs: sets.NewString("create"), Resources: sets.NewString("subjectaccessreviews", "localsubjectaccessreviews"), AttributeRestrictions: runtime.EmbeddedObject{Object: &authorizationapi.IsPersonalSubjectAccessReview{}}},
+				{Verbs: sets.NewString("list", "get", "delete"), Resources: sets.NewString("useroauthclientauthorizations")},
 			},
 		},
 		{
@@ -187,15 +188,8 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 			},
 			Rules: []authorizationapi.PolicyRule{
 				{
-					Verbs: sets.NewString("get"),
-					NonResourceURLs: sets.NewString(
-						"/healthz", "/healthz/*",
-						"/version",
-						"/api", "/api/", "/api/v1", "/api/v1/",
-						"/apis", "/apis/", "/apis/extensions", "/apis/extensions/", "/apis/extensions/v1beta1", "/apis/extensions/v1beta1/",
-						"/osapi", "/osapi/", // these cannot be removed until we can drop support for pre 3.1 clients
-						"/oapi/", "/oapi", "/oapi/v1", "/oapi/v1/",
-					),
+					Verbs:           sets.NewString("get"),
+					NonResourceURLs: sets.NewString(StatusCheckerURLs...),
 				},
 			},
 		},
@@ -332,7 +326,7 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 			},
 			Rules: []authorizationapi.PolicyRule{
 				{
-					Verbs:     sets.NewString("get", "delete"),
+					Verbs:     sets.NewString("get", "list", "delete"),
 					Resources: sets.NewString("images"),
 				},
 				{
@@ -432,6 +426,11 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 					Verbs:     sets.NewString("update"),
 					Resources: sets.NewString("nodes/status"),
 				},
+				{
+					// Needed to request the serving certificate and renew the certificates of the node
+					Verbs:     sets.NewString("create", "get", "list", "watch"),
+					Resources: sets.NewString("certificatesigningrequests"),
+				},
 
 				{
 					// TODO: restrict to the bound node as creator once supported
@@ -543,6 +542,40 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 				},
 			},
 		},
+
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategyDockerRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				{
+					Verbs:     sets.NewString("create"),
+					Resources: sets.NewString(authorizationapi.DockerBuildResource),
+				},
+			},
+		},
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategySourceRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				{
+					Verbs:     sets.NewString("create"),
+					Resources: sets.NewString(authorizationapi.SourceBuildResource),
+				},
+			},
+		},
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategyCustomRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				{
+					Verbs:     sets.NewString("create"),
+					Resources: sets.NewString(authorizationapi.CustomBuildResource),
+				},
+			},
+		},
 	}
 
 	saRoles := InfraSAs.AllRoles()
@@ -717,5 +750,33 @@ func GetBootstrapClusterRoleBindings() []authorizationapi.ClusterRoleBinding {
 			},
 			Subjects: []kapi.ObjectReference{{Kind: authorizationapi.SystemGroupKind, Name: AuthenticatedGroup}, {Kind: authorizationapi.SystemGroupKind, Name: UnauthenticatedGroup}},
 		},
+		// everyone may use every build strategy until an administrator restricts one
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategyDockerRoleBindingName,
+			},
+			RoleRef: kapi.ObjectReference{
+				Name: BuildStrategyDockerRoleName,
+			},
+			Subjects: []kapi.ObjectReference{{Kind: authorizationapi.SystemGroupKind, Name: AuthenticatedGroup}},
+		},
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategySourceRoleBindingName,
+			},
+			RoleRef: kapi.ObjectReference{
+				Name: BuildStrategySourceRoleName,
+			},
+			Subjects: []kapi.ObjectReference{{Kind: authorizationapi.SystemGroupKind, Name: AuthenticatedGroup}},
+		},
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategyCustomRoleBindingName,
+			},
+			RoleRef: kapi.ObjectReference{
+				Name: BuildStrategyCustomRoleName,
+			},
+			Subjects: []kapi.ObjectReference{{Kind: authorizationapi.SystemGroupKind, Name: AuthenticatedGroup}},
+		},
 	}
 }
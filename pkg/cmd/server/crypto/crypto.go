@@ -67,6 +67,19 @@ type TLSCARoots struct {
 	Roots []*x509.Certificate
 }
 
+// GetPEMBytes returns the PEM-encoded certificates and private key of c
+func (c *TLSCertificateConfig) GetPEMBytes() ([]byte, []byte, error) {
+	certBytes, err := encodeCertificates(c.Certs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes, err := encodeKey(c.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certBytes, keyBytes, nil
+}
+
 func (c *TLSCertificateConfig) writeCertConfig(certFile, keyFile string) error {
 	if err := writeCertificates(certFile, c.Certs...); err != nil {
 		return err
@@ -185,6 +198,23 @@ func GetCA(certFile, keyFile, serialFile string) (*CA, error) {
 
 func MakeCA(certFile, keyFile, serialFile, name string) (*CA, error) {
 	glog.V(2).Infof("Generating new CA for %s cert, and key in %s, %s", name, certFile, keyFile)
+	ca, err := MakeCAConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.Config.writeCertConfig(certFile, keyFile); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(serialFile, []byte("0"), 0644); err != nil {
+		return nil, err
+	}
+	ca.SerialFile = serialFile
+	return ca, nil
+}
+
+// MakeCAConfig returns a new CA for name without writing it to disk. Its serial numbers are not persisted.
+func MakeCAConfig(name string) (*CA, error) {
 	// Create CA cert
 	rootcaPublicKey, rootcaPrivateKey, err := NewKeyPair()
 	if err != nil {
@@ -198,22 +228,12 @@ func MakeCA(certFile, keyFile, serialFile, name string) (*CA, error) {
 	if err != nil {
 		return nil, err
 	}
-	caConfig := &TLSCertificateConfig{
-		Certs: []*x509.Certificate{rootcaCert},
-		Key:   rootcaPrivateKey,
-	}
-	if err := caConfig.writeCertConfig(certFile, keyFile); err != nil {
-		return nil, err
-	}
-
-	if err := ioutil.WriteFile(serialFile, []byte("0"), 0644); err != nil {
-		return nil, err
-	}
-
 	return &CA{
-		Serial:     0,
-		SerialFile: serialFile,
-		Config:     caConfig,
+		Serial: 0,
+		Config: &TLSCertificateConfig{
+			Certs: []*x509.Certificate{rootcaCert},
+			Key:   rootcaPrivateKey,
+		},
 	}, nil
 }
 
@@ -248,12 +268,9 @@ func GetServerCert(certFile, keyFile string, hostnames sets.String) (*TLSCertifi
 func (ca *CA) MakeServerCert(certFile, keyFile string, hostnames sets.String) (*TLSCertificateConfig, error) {
 	glog.V(4).Infof("Generating server certificate in %s, key in %s", certFile, keyFile)
 
-	serverPublicKey, serverPrivateKey, _ := NewKeyPair()
-	serverTemplate, _ := newServerCertificateTemplate(pkix.Name{CommonName: hostnames.List()[0]}, hostnames.List())
-	serverCrt, _ := ca.signCertificate(serverTemplate, serverPublicKey)
-	server := &TLSCertificateConfig{
-		Certs: append([]*x509.Certificate{serverCrt}, ca.Config.Certs...),
-		Key:   serverPrivateKey,
+	server, err := ca.MakeServerCertConfig(hostnames)
+	if err != nil {
+		return nil, err
 	}
 	if err := server.writeCertConfig(certFile, keyFile); err != nil {
 		return server, err
@@ -261,6 +278,24 @@ func (ca *CA) MakeServerCert(certFile, keyFile string, hostnames sets.String) (*
 	return server, nil
 }
 
+// MakeServerCertConfig returns a server certificate for hostnames, followed by the certificates of the CA, without
+// writing it to disk
+func (ca *CA) MakeServerCertConfig(hostnames sets.String) (*TLSCertificateConfig, error) {
+	serverPublicKey, serverPrivateKey, err := NewKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	serverTemplate, _ := newServerCertificateTemplate(pkix.Name{CommonName: hostnames.List()[0]}, hostnames.List())
+	serverCrt, err := ca.signCertificate(serverTemplate, serverPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &TLSCertificateConfig{
+		Certs: append([]*x509.Certificate{serverCrt}, ca.Config.Certs...),
+		Key:   serverPrivateKey,
+	}, nil
+}
+
 func (ca *CA) EnsureClientCertificate(certFile, keyFile string, u user.Info) (*TLSCertificateConfig, bool, error) {
 	certConfig, err := GetTLSCertificateConfig(certFile, keyFile)
 	if err != nil {
@@ -281,15 +316,11 @@ func (ca *CA) MakeClientCertificate(certFile, keyFile string, u user.Info) (*TLS
 		return nil, err
 	}
 
-	clientPublicKey, clientPrivateKey, _ := NewKeyPair()
-	clientTemplate, _ := newClientCertificateTemplate(x509request.UserToSubject(u))
-	clientCrt, _ := ca.signCertificate(clientTemplate, clientPublicKey)
-
-	certData, err := encodeCertificates(clientCrt)
+	client, err := ca.MakeClientCertificateConfig(u)
 	if err != nil {
 		return nil, err
 	}
-	keyData, err := encodeKey(clientPrivateKey)
+	certData, keyData, err := client.GetPEMBytes()
 	if err != nil {
 		return nil, err
 	}
@@ -304,8 +335,48 @@ func (ca *CA) MakeClientCertificate(certFile, keyFile string, u user.Info) (*TLS
 	return GetTLSCertificateConfig(certFile, keyFile)
 }
 
+// MakeClientCertificateConfig returns a client certificate for u without writing it to disk
+func (ca *CA) MakeClientCertificateConfig(u user.Info) (*TLSCertificateConfig, error) {
+	clientPublicKey, clientPrivateKey, err := NewKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	clientTemplate, _ := newClientCertificateTemplate(x509request.UserToSubject(u))
+	clientCrt, err := ca.signCertificate(clientTemplate, clientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &TLSCertificateConfig{
+		Certs: []*x509.Certificate{clientCrt},
+		Key:   clientPrivateKey,
+	}, nil
+}
+
+// SignClientCertificateRequest returns the PEM-encoded client certificate issued for the subject and public key of request
+func (ca *CA) SignClientCertificateRequest(request *x509.CertificateRequest) ([]byte, error) {
+	clientTemplate, _ := newClientCertificateTemplate(request.Subject)
+	clientCrt, err := ca.signCertificate(clientTemplate, request.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCertificates(clientCrt)
+}
+
+// SignServerCertificateRequest returns the PEM-encoded server certificate issued for the subject, hostnames and public key
+// of request, followed by the certificates of the CA
+func (ca *CA) SignServerCertificateRequest(request *x509.CertificateRequest) ([]byte, error) {
+	serverTemplate, _ := newServerCertificateTemplate(request.Subject, nil)
+	serverTemplate.IPAddresses = request.IPAddresses
+	serverTemplate.DNSNames = request.DNSNames
+	serverCrt, err := ca.signCertificate(serverTemplate, request.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCertificates(append([]*x509.Certificate{serverCrt}, ca.Config.Certs...)...)
+}
+
 // nextSerial returns a unique, monotonically increasing serial number and ensures the CA on
-// disk records that value.
+// disk, if any, records that value.
 func (ca *CA) nextSerial() (int64, error) {
 	ca.lock.Lock()
 	defer ca.lock.Unlock()
@@ -318,6 +389,9 @@ func (ca *CA) nextSerial() (int64, error) {
 		serialText = "0" + serialText
 	}
 
+	if len(ca.SerialFile) == 0 {
+		return next, nil
+	}
 	if err := ioutil.WriteFile(ca.SerialFile, []byte(serialText), os.FileMode(0640)); err != nil {
 		return 0, err
 	}
@@ -342,6 +416,45 @@ func NewKeyPair() (crypto.PublicKey, crypto.PrivateKey, error) {
 	return &privateKey.PublicKey, privateKey, nil
 }
 
+// NewCertificateRequest generates a new private key and returns it along with a PEM-encoded certificate request for the
+// subject and hostnames that is signed by the key
+func NewCertificateRequest(subject pkix.Name, hosts []string) ([]byte, crypto.PrivateKey, error) {
+	_, privateKey, err := NewKeyPair()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:            subject,
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+	template.IPAddresses, template.DNSNames = IPAddressesDNSNames(hosts)
+
+	derBytes, err := x509.CreateCertificateRequest(rand.Reader, template, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	b := bytes.Buffer{}
+	if err := pem.Encode(&b, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: derBytes}); err != nil {
+		return nil, nil, err
+	}
+	return b.Bytes(), privateKey, nil
+}
+
+// WriteCertificateAndKey writes the PEM-encoded certificates to certFile and the private key to keyFile
+func WriteCertificateAndKey(certFile string, certData []byte, keyFile string, key crypto.PrivateKey) error {
+	if _, err := CertsFromPEM(certData); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(certFile), os.FileMode(0755)); err != nil {
+		return err
+	}
+	if err := writeKeyFile(keyFile, key); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(certFile, certData, os.FileMode(0644))
+}
+
 // Can be used for CA or intermediate signing certs
 func newSigningCertificateTemplate(subject pkix.Name) (*x509.Certificate, error) {
 	return &x509.Certificate{
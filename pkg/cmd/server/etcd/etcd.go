@@ -1,10 +1,14 @@
 package etcd
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	etcdclient "github.com/coreos/go-etcd/etcd"
@@ -12,6 +16,8 @@ import (
 
 	client "k8s.io/kubernetes/pkg/client/unversioned"
 	etcdstorage "k8s.io/kubernetes/pkg/storage/etcd"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 )
@@ -43,27 +49,161 @@ func EtcdClient(etcdClientInfo configapi.EtcdConnectionInfo) (*etcdclient.Client
 		return nil, err
 	}
 
+	if tlsConfig != nil {
+		// resume TLS sessions when reconnecting, to avoid a full handshake for every new connection
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+	}
+
+	dialTimeout := 30 * time.Second // default from http.DefaultTransport
+	if etcdClientInfo.DialTimeoutSeconds > 0 {
+		dialTimeout = time.Duration(etcdClientInfo.DialTimeoutSeconds) * time.Second
+	}
+	// Because watches are very bursty, defends against long delays in watch reconnections.
+	maxIdleConns := 500
+	if etcdClientInfo.MaxIdleConnectionsPerServer > 0 {
+		maxIdleConns = etcdClientInfo.MaxIdleConnectionsPerServer
+	}
+
+	dial := (&net.Dialer{
+		Timeout: dialTimeout,
+		// Lower the keep alive for connections.
+		KeepAlive: 1 * time.Second,
+	}).Dial
+
+	// with a single server there is nothing to fail over to, so only the health of several is checked
+	checkHealth := etcdClientInfo.HealthCheckIntervalSeconds > 0 && len(etcdClientInfo.URLs) > 1
+	var health *etcdHealth
+	clientDial := dial
+	if checkHealth {
+		health, err = newEtcdHealth(etcdClientInfo.URLs)
+		if err != nil {
+			return nil, err
+		}
+		clientDial = health.dial(dial)
+	}
+
 	transport := &http.Transport{
-		TLSClientConfig: tlsConfig,
-		Dial: (&net.Dialer{
-			// default from http.DefaultTransport
-			Timeout: 30 * time.Second,
-			// Lower the keep alive for connections.
-			KeepAlive: 1 * time.Second,
-		}).Dial,
-		// Because watches are very bursty, defends against long delays in watch reconnections.
-		MaxIdleConnsPerHost: 500,
+		TLSClientConfig:     tlsConfig,
+		Dial:                clientDial,
+		MaxIdleConnsPerHost: maxIdleConns,
+		// a request that times out is retried on the next etcd server
+		ResponseHeaderTimeout: time.Duration(etcdClientInfo.RequestTimeoutSeconds) * time.Second,
 		// defaults from http.DefaultTransport
 		Proxy:               http.ProxyFromEnvironment,
 		TLSHandshakeTimeout: 10 * time.Second,
 	}
 
+	if checkHealth {
+		interval := time.Duration(etcdClientInfo.HealthCheckIntervalSeconds) * time.Second
+		// health checks dial directly, so that servers are noticed when they recover
+		checkClient := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:     tlsConfig,
+				Dial:                dial,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			Timeout: interval,
+		}
+		go util.Until(func() {
+			if health.check(checkClient) {
+				// drop kept alive connections, which may be to the servers that just became unhealthy
+				transport.CloseIdleConnections()
+			}
+		}, interval, util.NeverStop)
+	}
+
 	etcdClient := etcdclient.NewClient(etcdClientInfo.URLs)
 	etcdClient.SetTransport(transport)
 	etcdClient.CheckRetry = NeverRetryOnFailure
 	return etcdClient, nil
 }
 
+// etcdHealth records the etcd servers that failed their last health check. Connections to them are refused
+// while another server is healthy, so that the client fails over to the next URL without waiting on them.
+type etcdHealth struct {
+	// addresses maps the URL of each server to the address it is dialed at
+	addresses map[string]string
+	// servers is the set of addresses
+	servers sets.String
+
+	lock      sync.RWMutex
+	unhealthy sets.String
+}
+
+func newEtcdHealth(urls []string) (*etcdHealth, error) {
+	addresses := map[string]string{}
+	servers := sets.NewString()
+	for _, s := range urls {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		addr := u.Host
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			if u.Scheme == "https" {
+				addr = net.JoinHostPort(addr, "443")
+			} else {
+				addr = net.JoinHostPort(addr, "80")
+			}
+		}
+		addresses[strings.TrimRight(s, "/")] = addr
+		servers.Insert(addr)
+	}
+	return &etcdHealth{addresses: addresses, servers: servers, unhealthy: sets.NewString()}, nil
+}
+
+// check calls the health endpoint of every server and records the servers that did not answer healthy. It
+// returns true if a server became unhealthy.
+func (h *etcdHealth) check(client *http.Client) bool {
+	unhealthy := sets.NewString()
+	for u, addr := range h.addresses {
+		if err := checkEtcdHealth(client, u); err != nil {
+			if !h.isUnhealthy(addr) {
+				glog.Warningf("etcd server %s failed its health check: %v", u, err)
+			}
+			unhealthy.Insert(addr)
+		}
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, addr := range h.unhealthy.Difference(unhealthy).List() {
+		glog.Infof("etcd server at %s is healthy again", addr)
+	}
+	becameUnhealthy := unhealthy.Difference(h.unhealthy).Len() > 0
+	h.unhealthy = unhealthy
+	return becameUnhealthy
+}
+
+// isUnhealthy returns true if the server at addr failed its last health check and another server passed it.
+func (h *etcdHealth) isUnhealthy(addr string) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.unhealthy.Has(addr) && h.unhealthy.Len() < h.servers.Len()
+}
+
+// dial refuses connections to unhealthy servers and dials the others with dial.
+func (h *etcdHealth) dial(dial func(network, addr string) (net.Conn, error)) func(network, addr string) (net.Conn, error) {
+	return func(network, addr string) (net.Conn, error) {
+		if h.isUnhealthy(addr) {
+			return nil, fmt.Errorf("etcd server at %s failed its last health check", addr)
+		}
+		return dial(network, addr)
+	}
+}
+
+func checkEtcdHealth(client *http.Client, server string) error {
+	resp, err := client.Get(server + "/health")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return nil
+}
+
 // TestEtcdClient verifies a client is functional.  It will attempt to
 // connect to the etcd server and block until the server responds at least once, or return an
 // error if the server never responded.
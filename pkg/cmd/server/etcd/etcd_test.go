@@ -0,0 +1,76 @@
+package etcd
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEtcdHealth(t *testing.T) {
+	healthy := true
+	etcd1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			http.Error(w, `{"health": "false"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"health": "true"}`))
+	}))
+	defer etcd1.Close()
+	etcd2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"health": "false"}`, http.StatusServiceUnavailable)
+	}))
+	defer etcd2.Close()
+
+	health, err := newEtcdHealth([]string{etcd1.URL, etcd2.URL + "/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dialed := []string{}
+	dial := health.dial(func(network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return nil, nil
+	})
+	addr1 := strings.TrimPrefix(etcd1.URL, "http://")
+	addr2 := strings.TrimPrefix(etcd2.URL, "http://")
+
+	if !health.check(http.DefaultClient) {
+		t.Errorf("expected a server to become unhealthy")
+	}
+	if _, err := dial("tcp", addr1); err != nil {
+		t.Errorf("expected the healthy server to be dialed, got %v", err)
+	}
+	if _, err := dial("tcp", addr2); err == nil {
+		t.Errorf("expected the unhealthy server to be refused")
+	}
+	if len(dialed) != 1 || dialed[0] != addr1 {
+		t.Errorf("expected only %s to be dialed, got %v", addr1, dialed)
+	}
+
+	// with no healthy server left, every server is dialed
+	healthy = false
+	health.check(http.DefaultClient)
+	if _, err := dial("tcp", addr2); err != nil {
+		t.Errorf("expected the server to be dialed when none is healthy, got %v", err)
+	}
+
+	healthy = true
+	if health.check(http.DefaultClient) {
+		t.Errorf("expected no server to become unhealthy")
+	}
+	if _, err := dial("tcp", addr2); err == nil {
+		t.Errorf("expected the unhealthy server to be refused once another server recovered")
+	}
+}
+
+func TestNewEtcdHealthDefaultPorts(t *testing.T) {
+	health, err := newEtcdHealth([]string{"https://etcd1.example.com", "http://etcd2.example.com", "https://etcd3.example.com:4001"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"etcd1.example.com:443", "etcd2.example.com:80", "etcd3.example.com:4001"}
+	if actual := health.servers.List(); strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/kubernetes/pkg/util/sets"
 	saadmit "k8s.io/kubernetes/plugin/pkg/admission/serviceaccount"
 
+	"github.com/openshift/origin/pkg/authorization/admission/noderestriction"
 	"github.com/openshift/origin/pkg/cmd/flagtypes"
 	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
@@ -35,11 +36,12 @@ import (
 )
 
 // AdmissionPlugins is the full list of admission control plugins to enable in the order they must run
-var AdmissionPlugins = []string{"NamespaceLifecycle", "OriginPodNodeEnvironment", "LimitRanger", "ServiceAccount", "SecurityContextConstraint", "ResourceQuota", "SCCExecRestrictions"}
+var AdmissionPlugins = []string{"NamespaceLifecycle", "OriginPodNodeEnvironment", "OriginAllowedRegistries", "LimitRanger", "ClusterResourceOverride", "ServiceAccount", "SecurityContextConstraint", "ResourceQuota", "SCCExecRestrictions"}
 
 // MasterConfig defines the required values to start a Kubernetes master
 type MasterConfig struct {
-	Options    configapi.KubernetesMasterConfig
+	Options configapi.KubernetesMasterConfig
+	// KubeClient is the client of the controllers
 	KubeClient *kclient.Client
 
 	Master            *master.Config
@@ -47,7 +49,7 @@ type MasterConfig struct {
 	CloudProvider     cloudprovider.Interface
 }
 
-func BuildKubernetesMasterConfig(options configapi.MasterConfig, requestContextMapper kapi.RequestContextMapper, kubeClient *kclient.Client, projectCache *projectcache.ProjectCache) (*MasterConfig, error) {
+func BuildKubernetesMasterConfig(options configapi.MasterConfig, requestContextMapper kapi.RequestContextMapper, kubeClient, controllerClient *kclient.Client, projectCache *projectcache.ProjectCache) (*MasterConfig, error) {
 	if options.KubernetesMasterConfig == nil {
 		return nil, errors.New("insufficient information to build KubernetesMasterConfig")
 	}
@@ -91,6 +93,10 @@ func BuildKubernetesMasterConfig(options configapi.MasterConfig, requestContextM
 	server.ServiceClusterIPRange = net.IPNet(flagtypes.DefaultIPNet(options.KubernetesMasterConfig.ServicesSubnet))
 	server.ServiceNodePortRange = *portRange
 	admissionPlugins := AdmissionPlugins
+	if options.PolicyConfig.RestrictNodeAccess {
+		// the node authorizer cannot see the objects being created, so nodes are also restricted during admission
+		admissionPlugins = append([]string{noderestriction.PluginName}, AdmissionPlugins...)
+	}
 	server.AdmissionControl = strings.Join(admissionPlugins, ",")
 
 	// resolve extended arguments
@@ -271,7 +277,7 @@ func BuildKubernetesMasterConfig(options configapi.MasterConfig, requestContextM
 
 	kmaster := &MasterConfig{
 		Options:    *options.KubernetesMasterConfig,
-		KubeClient: kubeClient,
+		KubeClient: controllerClient,
 
 		Master:            m,
 		ControllerManager: cmserver,
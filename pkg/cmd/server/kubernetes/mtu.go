@@ -0,0 +1,162 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+const (
+	// overlayOverhead is the number of bytes the VXLAN encapsulation of the overlay adds to each packet
+	overlayOverhead = 50
+	// defaultOverlayMTU is used when the MTU of the node's interface cannot be determined
+	defaultOverlayMTU = 1450
+)
+
+// networkInterface describes the parts of a host interface used to detect the overlay MTU
+type networkInterface struct {
+	Name  string
+	MTU   int
+	Addrs []net.Addr
+}
+
+// hostInterfaces returns the network interfaces of the host
+func hostInterfaces() ([]networkInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	result := []networkInterface{}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, networkInterface{Name: iface.Name, MTU: iface.MTU, Addrs: addrs})
+	}
+	return result, nil
+}
+
+// detectOverlayMTU returns the MTU of the overlay network for a node reachable at nodeIP, or at
+// the addresses nodeName resolves to if nodeIP is empty. The MTU is that of the interface holding
+// the node address, less the overhead of the overlay encapsulation.
+func detectOverlayMTU(nodeName, nodeIP string) (uint, error) {
+	var ips []net.IP
+	if len(nodeIP) > 0 {
+		ips = []net.IP{net.ParseIP(nodeIP)}
+	} else {
+		addrs, err := net.LookupIP(nodeName)
+		if err != nil {
+			return 0, fmt.Errorf("unable to resolve node name %q: %v", nodeName, err)
+		}
+		ips = addrs
+	}
+	ifaces, err := hostInterfaces()
+	if err != nil {
+		return 0, err
+	}
+	return overlayMTUForIPs(ips, ifaces)
+}
+
+// overlayMTUForIPs finds the interface holding one of ips and returns its MTU less the overlay overhead.
+func overlayMTUForIPs(ips []net.IP, ifaces []networkInterface) (uint, error) {
+	for _, iface := range ifaces {
+		for _, addr := range iface.Addrs {
+			var ifaceIP net.IP
+			switch t := addr.(type) {
+			case *net.IPNet:
+				ifaceIP = t.IP
+			case *net.IPAddr:
+				ifaceIP = t.IP
+			default:
+				continue
+			}
+			for _, ip := range ips {
+				if !ip.Equal(ifaceIP) {
+					continue
+				}
+				if iface.MTU <= overlayOverhead {
+					return 0, fmt.Errorf("the MTU %d of interface %s is too small for the overlay network", iface.MTU, iface.Name)
+				}
+				return uint(iface.MTU - overlayOverhead), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no interface holds any of the node addresses %v", ips)
+}
+
+// recordOverlayMTU sets the overlay MTU annotation on the named node, waiting for the node to be
+// registered, and warns about nodes that report a different overlay MTU.
+func recordOverlayMTU(client *kclient.Client, nodeName string, mtu uint) {
+	value := strconv.FormatUint(uint64(mtu), 10)
+
+	var node *kapi.Node
+	err := wait.Poll(5*time.Second, 5*time.Minute, func() (bool, error) {
+		var err error
+		node, err = client.Nodes().Get(nodeName)
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		glog.Warningf("Unable to record the overlay MTU of node %q: %v", nodeName, err)
+		return
+	}
+
+	err = osclient.UpdateWithRetries(
+		func() (err error) {
+			node, err = client.Nodes().Get(nodeName)
+			return
+		},
+		func() error {
+			if node.Annotations[sdnapi.OverlayMTUAnnotation] == value {
+				return nil
+			}
+			if node.Annotations == nil {
+				node.Annotations = map[string]string{}
+			}
+			node.Annotations[sdnapi.OverlayMTUAnnotation] = value
+			_, err := client.Nodes().Update(node)
+			return err
+		},
+	)
+	if err != nil {
+		glog.Warningf("Unable to record the overlay MTU of node %q: %v", nodeName, err)
+		return
+	}
+
+	nodes, err := client.Nodes().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		glog.Warningf("Unable to compare the overlay MTU with other nodes: %v", err)
+		return
+	}
+	for _, other := range mismatchedOverlayMTUNodes(nodes.Items, value) {
+		glog.Warningf("Node %q uses an overlay MTU of %s, which differs from the MTU %s of this node. Packets between pods on these nodes may be dropped; set networkConfig.mtu to the same value in every node config.", other.Name, other.Annotations[sdnapi.OverlayMTUAnnotation], value)
+	}
+}
+
+// mismatchedOverlayMTUNodes returns the nodes whose recorded overlay MTU differs from mtu. Nodes
+// that have not recorded an MTU are ignored.
+func mismatchedOverlayMTUNodes(nodes []kapi.Node, mtu string) []kapi.Node {
+	mismatched := []kapi.Node{}
+	for _, node := range nodes {
+		if value, ok := node.Annotations[sdnapi.OverlayMTUAnnotation]; ok && value != mtu {
+			mismatched = append(mismatched, node)
+		}
+	}
+	return mismatched
+}
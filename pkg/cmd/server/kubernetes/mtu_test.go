@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"net"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+func TestOverlayMTUForIPs(t *testing.T) {
+	ifaces := []networkInterface{
+		{Name: "lo", MTU: 65536, Addrs: []net.Addr{&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)}}},
+		{Name: "eth0", MTU: 9000, Addrs: []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.0.5"), Mask: net.CIDRMask(24, 32)}}},
+		{Name: "eth1", MTU: 40, Addrs: []net.Addr{&net.IPAddr{IP: net.ParseIP("10.1.0.5")}}},
+	}
+
+	tests := []struct {
+		ips         []string
+		expectedMTU uint
+		expectErr   bool
+	}{
+		{ips: []string{"10.0.0.5"}, expectedMTU: 8950},
+		{ips: []string{"192.168.0.1", "10.0.0.5"}, expectedMTU: 8950},
+		{ips: []string{"192.168.0.1"}, expectErr: true},
+		{ips: []string{"10.1.0.5"}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		ips := []net.IP{}
+		for _, ip := range test.ips {
+			ips = append(ips, net.ParseIP(ip))
+		}
+		mtu, err := overlayMTUForIPs(ips, ifaces)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%v: expected an error, got MTU %d", test.ips, mtu)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.ips, err)
+			continue
+		}
+		if mtu != test.expectedMTU {
+			t.Errorf("%v: expected MTU %d, got %d", test.ips, test.expectedMTU, mtu)
+		}
+	}
+}
+
+func TestMismatchedOverlayMTUNodes(t *testing.T) {
+	node := func(name string, annotations map[string]string) kapi.Node {
+		return kapi.Node{ObjectMeta: kapi.ObjectMeta{Name: name, Annotations: annotations}}
+	}
+	nodes := []kapi.Node{
+		node("same", map[string]string{sdnapi.OverlayMTUAnnotation: "1450"}),
+		node("different", map[string]string{sdnapi.OverlayMTUAnnotation: "8950"}),
+		node("unknown", nil),
+	}
+
+	mismatched := mismatchedOverlayMTUNodes(nodes, "1450")
+	if len(mismatched) != 1 || mismatched[0].Name != "different" {
+		t.Errorf("expected only the node with a different MTU, got %v", mismatched)
+	}
+}
@@ -10,16 +10,22 @@ import (
 
 	dockerclient "github.com/fsouza/go-dockerclient"
 	"github.com/golang/glog"
+	cadvisordocker "github.com/google/cadvisor/container/docker"
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/record"
 	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+	kproxy "k8s.io/kubernetes/pkg/proxy"
 	pconfig "k8s.io/kubernetes/pkg/proxy/config"
-	proxy "k8s.io/kubernetes/pkg/proxy/iptables"
+	iptablesproxy "k8s.io/kubernetes/pkg/proxy/iptables"
+	"k8s.io/kubernetes/pkg/proxy/userspace"
+	"k8s.io/kubernetes/pkg/util"
 	utildbus "k8s.io/kubernetes/pkg/util/dbus"
 	kexec "k8s.io/kubernetes/pkg/util/exec"
 	"k8s.io/kubernetes/pkg/util/iptables"
+	"k8s.io/kubernetes/pkg/util/sysctl"
 
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	dockerutil "github.com/openshift/origin/pkg/cmd/util/docker"
 )
@@ -147,6 +153,10 @@ func (c *NodeConfig) RunKubelet() {
 	}
 
 	c.KubeletConfig.DockerClient = c.DockerClient
+	if len(c.DockerEndpoint) > 0 {
+		// cadvisor reads the stats of the containers from the same daemon
+		*cadvisordocker.ArgDockerEndpoint = c.DockerEndpoint
+	}
 	// updated by NodeConfig.EnsureVolumeDir
 	c.KubeletConfig.RootDirectory = c.VolumeDir
 
@@ -172,9 +182,16 @@ func (c *NodeConfig) RunSDN() {
 		if err := c.SDNPlugin.StartNode(c.MTU); err != nil {
 			glog.Fatalf("SDN Node failed: %v", err)
 		}
+		if err := c.keepSDNIPTablesRules(); err != nil {
+			glog.Errorf("Unable to keep the iptables rules of the SDN in place: %v", err)
+		}
+		go recordOverlayMTU(c.Client, c.KubeletServer.HostnameOverride, c.MTU)
 	}
 }
 
+// sysctlConntrackMax is the kernel setting that limits the number of tracked connections
+const sysctlConntrackMax = "net/netfilter/nf_conntrack_max"
+
 // RunProxy starts the proxy
 func (c *NodeConfig) RunProxy() {
 	// initialize kube proxy
@@ -208,16 +225,57 @@ func (c *NodeConfig) RunProxy() {
 		Name: c.KubeletConfig.NodeName,
 	}
 
+	if c.ConntrackMax > 0 {
+		if err := sysctl.SetSysctl(sysctlConntrackMax, c.ConntrackMax); err != nil {
+			glog.Warningf("WARNING: Could not set %s to %d: %v", sysctlConntrackMax, c.ConntrackMax, err)
+		}
+	}
+
 	exec := kexec.New()
 	dbus := utildbus.New()
-	iptables := iptables.New(exec, dbus, protocol)
-	proxier, err := proxy.NewProxier(iptables, exec, syncPeriod, false)
-	if err != nil {
-		// This should be fatal, but that would break the integration tests
-		glog.Warningf("WARNING: Could not initialize Kubernetes Proxy. You must run this process as root to use the service proxy: %v", err)
-		return
+	iptInterface := iptables.New(exec, dbus, protocol)
+
+	var proxier kproxy.ProxyProvider
+	var endpointsHandler pconfig.EndpointsConfigHandler
+
+	useIPTablesProxy := false
+	if c.ProxyMode == configapi.ProxyModeIPTables {
+		useIPTablesProxy, err = iptablesproxy.ShouldUseIptablesProxier()
+		if err != nil {
+			glog.Warningf("Unable to determine whether the iptables proxy can be used, falling back to the userspace proxy: %v", err)
+		} else if !useIPTablesProxy {
+			glog.Warningf("The kernel or iptables version does not support the iptables proxy, falling back to the userspace proxy")
+		}
+	}
+
+	if useIPTablesProxy {
+		proxierIPTables, err := iptablesproxy.NewProxier(iptInterface, exec, syncPeriod, false)
+		if err != nil {
+			// This should be fatal, but that would break the integration tests
+			glog.Warningf("WARNING: Could not initialize Kubernetes Proxy. You must run this process as root to use the service proxy: %v", err)
+			return
+		}
+		proxier = proxierIPTables
+		endpointsHandler = proxierIPTables
+		// Remove the rules of the userspace proxy in case it was used before. Errors here are acceptable.
+		userspace.CleanupLeftovers(iptInterface)
+	} else {
+		loadBalancer := userspace.NewLoadBalancerRR()
+		endpointsHandler = loadBalancer
+		proxierUserspace, err := userspace.NewProxier(loadBalancer, ip, iptInterface, util.PortRange{}, syncPeriod)
+		if err != nil {
+			// This should be fatal, but that would break the integration tests
+			glog.Warningf("WARNING: Could not initialize Kubernetes Proxy. You must run this process as root to use the service proxy: %v", err)
+			return
+		}
+		proxier = proxierUserspace
+		// Remove the rules of the iptables proxy in case it was used before. Errors here are acceptable.
+		iptablesproxy.CleanupLeftovers(iptInterface)
+	}
+	iptInterface.AddReloadFunc(proxier.Sync)
+	if protocol == iptables.ProtocolIpv4 {
+		c.keepProxyIPTablesRules(useIPTablesProxy, proxier.Sync)
 	}
-	iptables.AddReloadFunc(proxier.Sync)
 
 	pconfig.NewSourceAPI(
 		c.Client,
@@ -227,9 +285,9 @@ func (c *NodeConfig) RunProxy() {
 
 	serviceConfig.RegisterHandler(proxier)
 	if c.FilteringEndpointsHandler == nil {
-		endpointsConfig.RegisterHandler(proxier)
+		endpointsConfig.RegisterHandler(endpointsHandler)
 	} else {
-		c.FilteringEndpointsHandler.SetBaseEndpointsHandler(proxier)
+		c.FilteringEndpointsHandler.SetBaseEndpointsHandler(endpointsHandler)
 		endpointsConfig.RegisterHandler(c.FilteringEndpointsHandler)
 	}
 	recorder.Eventf(nodeRef, "Starting", "Starting kube-proxy.")
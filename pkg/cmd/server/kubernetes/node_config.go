@@ -21,12 +21,15 @@ import (
 
 	osdnapi "github.com/openshift/openshift-sdn/plugins/osdn/api"
 	"github.com/openshift/openshift-sdn/plugins/osdn/factory"
+	osclient "github.com/openshift/origin/pkg/client"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/crypto"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	cmdflags "github.com/openshift/origin/pkg/cmd/util/flags"
 	"github.com/openshift/origin/pkg/cmd/util/variable"
+	"github.com/openshift/origin/pkg/dockerregistry"
+	"github.com/openshift/origin/pkg/util/iptables"
 )
 
 // NodeConfig represents the required parameters to start the OpenShift node
@@ -40,6 +43,10 @@ type NodeConfig struct {
 	AllowDisabledDocker bool
 	// Client to connect to the master.
 	Client *client.Client
+	// OriginClient is a client to connect to the OpenShift resources of the master.
+	OriginClient *osclient.Client
+	// DockerEndpoint is the address of the Docker daemon. If empty, DOCKER_HOST or the local Docker socket is used.
+	DockerEndpoint string
 	// DockerClient is a client to connect to Docker
 	DockerClient dockertools.DockerInterface
 	// KubeletServer contains the KubeletServer configuration
@@ -48,6 +55,10 @@ type NodeConfig struct {
 	KubeletConfig *kapp.KubeletConfig
 	// IPTablesSyncPeriod is how often iptable rules are refreshed
 	IPTablesSyncPeriod string
+	// ProxyMode selects the service proxy implementation
+	ProxyMode configapi.ProxyModeType
+	// ConntrackMax is the value nf_conntrack_max is set to. If 0, the kernel setting is left unchanged.
+	ConntrackMax int
 
 	// Maximum transmission unit for the network packets
 	MTU uint
@@ -55,6 +66,9 @@ type NodeConfig struct {
 	SDNPlugin osdnapi.OsdnPlugin
 	// EndpointsFilterer is an optional endpoints filterer
 	FilteringEndpointsHandler osdnapi.FilteringEndpointsConfigHandler
+
+	// iptablesManager keeps the iptables rules of the SDN and the service proxy in place once started
+	iptablesManager *iptables.Manager
 }
 
 func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error) {
@@ -87,6 +101,9 @@ func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error
 	imageTemplate := variable.NewDefaultImageTemplate()
 	imageTemplate.Format = options.ImageConfig.Format
 	imageTemplate.Latest = options.ImageConfig.Latest
+	if options.ImageConfig.ResolveDigests {
+		imageTemplate.Pin([]string{"pod"}, variable.NewRegistryDigestResolver(dockerregistry.NewClient(30*time.Second)))
+	}
 
 	var path string
 	var fileCheckInterval int64
@@ -140,6 +157,7 @@ func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error
 	server.ClusterDNS = dnsIP
 	server.ClusterDomain = options.DNSDomain
 	server.NetworkPluginName = options.NetworkConfig.NetworkPluginName
+	server.DockerEndpoint = options.DockerConfig.Endpoint
 	server.HostNetworkSources = strings.Join([]string{kubelettypes.ApiserverSource, kubelettypes.FileSource}, ",")
 	server.HostPIDSources = strings.Join([]string{kubelettypes.ApiserverSource, kubelettypes.FileSource}, ",")
 	server.HostIPCSources = strings.Join([]string{kubelettypes.ApiserverSource, kubelettypes.FileSource}, ",")
@@ -147,6 +165,12 @@ func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error
 	server.FileCheckFrequency = time.Duration(fileCheckInterval) * time.Second
 	server.PodInfraContainerImage = imageTemplate.ExpandOrDie("pod")
 	server.CPUCFSQuota = true // enable cpu cfs quota enforcement by default
+	if options.DiskConfig.ImageGCHighThresholdPercent != 0 {
+		server.ImageGCHighThresholdPercent = options.DiskConfig.ImageGCHighThresholdPercent
+	}
+	if options.DiskConfig.ImageGCLowThresholdPercent != 0 {
+		server.ImageGCLowThresholdPercent = options.DiskConfig.ImageGCLowThresholdPercent
+	}
 
 	// prevents kube from generating certs
 	server.TLSCertFile = options.ServingInfo.ServerCert.CertFile
@@ -173,6 +197,16 @@ func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error
 	cfg.KubeClient = kubeClient
 	cfg.DockerExecHandler = dockerExecHandler
 
+	// the Kubelet only has one flag for the free space of both filesystems, which overrides the node config
+	if _, ok := options.KubeletArguments["low-diskspace-threshold-mb"]; !ok {
+		if options.DiskConfig.ImageFSMinimumFreeMB != 0 {
+			cfg.DiskSpacePolicy.DockerFreeDiskMB = options.DiskConfig.ImageFSMinimumFreeMB
+		}
+		if options.DiskConfig.NodeFSMinimumFreeMB != 0 {
+			cfg.DiskSpacePolicy.RootFreeDiskMB = options.DiskConfig.NodeFSMinimumFreeMB
+		}
+	}
+
 	// docker-in-docker (dind) deployments are used for testing
 	// networking plugins.  Running openshift under dind won't work
 	// with the real oom adjuster due to the state of the cgroups path
@@ -264,12 +298,24 @@ func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error
 		cfg.NetworkPlugins = append(cfg.NetworkPlugins, sdnPlugin)
 	}
 
+	mtu := options.NetworkConfig.MTU
+	if sdnPlugin != nil && mtu == 0 {
+		if mtu, err = detectOverlayMTU(options.NodeName, options.NodeIP); err != nil {
+			glog.Warningf("Unable to detect the MTU of the overlay network, using %d: %v", defaultOverlayMTU, err)
+			mtu = defaultOverlayMTU
+		} else {
+			glog.V(2).Infof("Detected an MTU of %d for the overlay network", mtu)
+		}
+	}
+
 	config := &NodeConfig{
 		BindAddress: options.ServingInfo.BindAddress,
 
 		AllowDisabledDocker: options.AllowDisabledDocker,
+		DockerEndpoint:      options.DockerConfig.Endpoint,
 
-		Client: kubeClient,
+		Client:       kubeClient,
+		OriginClient: originClient,
 
 		VolumeDir: options.VolumeDirectory,
 
@@ -277,7 +323,9 @@ func BuildKubernetesNodeConfig(options configapi.NodeConfig) (*NodeConfig, error
 		KubeletConfig: cfg,
 
 		IPTablesSyncPeriod: options.IPTablesSyncPeriod,
-		MTU:                options.NetworkConfig.MTU,
+		ProxyMode:          options.ProxyMode,
+		ConntrackMax:       options.ConntrackMax,
+		MTU:                mtu,
 
 		SDNPlugin:                 sdnPlugin,
 		FilteringEndpointsHandler: endpointFilter,
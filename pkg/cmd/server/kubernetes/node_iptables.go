@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/util"
+	utildbus "k8s.io/kubernetes/pkg/util/dbus"
+	kexec "k8s.io/kubernetes/pkg/util/exec"
+	kiptables "k8s.io/kubernetes/pkg/util/iptables"
+
+	"github.com/openshift/openshift-sdn/plugins/osdn"
+	"github.com/openshift/origin/pkg/util/iptables"
+)
+
+const (
+	// sdnIPTablesOwner labels the rules of the SDN in the iptables metrics
+	sdnIPTablesOwner = "sdn"
+	// proxyIPTablesOwner labels the rules of the service proxy in the iptables metrics
+	proxyIPTablesOwner = "kube-proxy"
+	// defaultIPTablesSyncPeriod is used when the iptables sync period of the node cannot be parsed
+	defaultIPTablesSyncPeriod = 30 * time.Second
+)
+
+// sdnIPTablesRules returns the iptables rules the SDN needs for the pods of the cluster network to reach each other
+// and the outside
+func sdnIPTablesRules(clusterNetworkCIDR string) []iptables.Rule {
+	rules := []iptables.Rule{}
+	for _, rule := range osdn.IptablesRules(clusterNetworkCIDR) {
+		rules = append(rules, iptables.Rule{Table: kiptables.Table(rule.Table), Chain: kiptables.Chain(rule.Chain), Args: rule.Args})
+	}
+	return rules
+}
+
+// proxyIPTablesRules returns the rules sending the traffic to services through the chains of the service proxy, as
+// inserted by iptablesproxy.NewProxier or userspace.NewProxier. The rules of the services in those chains are kept
+// in place by the proxy itself.
+func proxyIPTablesRules(useIPTablesProxy bool) []iptables.Rule {
+	if useIPTablesProxy {
+		args := []string{"-m", "comment", "--comment", "kubernetes service portals", "-j", "KUBE-SERVICES"}
+		return []iptables.Rule{
+			{Table: kiptables.TableNAT, Chain: kiptables.ChainOutput, Args: args},
+			{Table: kiptables.TableNAT, Chain: kiptables.ChainPrerouting, Args: args},
+		}
+	}
+
+	portals := []string{"-m", "comment", "--comment", "handle ClusterIPs; NOTE: this must be before the NodePort rules"}
+	nodePorts := []string{"-m", "addrtype", "--dst-type", "LOCAL", "-m", "comment", "--comment", "handle service NodePorts; NOTE: this must be the last rule in the chain"}
+	return []iptables.Rule{
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainPrerouting, Args: append(portals, "-j", "KUBE-PORTALS-CONTAINER")},
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainOutput, Args: append(portals, "-j", "KUBE-PORTALS-HOST")},
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainPrerouting, Args: append(nodePorts, "-j", "KUBE-NODEPORT-CONTAINER"), Position: kiptables.Append},
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainOutput, Args: append(nodePorts, "-j", "KUBE-NODEPORT-HOST"), Position: kiptables.Append},
+	}
+}
+
+// runIPTablesManager returns the manager keeping the iptables rules of the node in place, starting it the first time
+func (c *NodeConfig) runIPTablesManager() *iptables.Manager {
+	if c.iptablesManager != nil {
+		return c.iptablesManager
+	}
+
+	period, err := time.ParseDuration(c.IPTablesSyncPeriod)
+	if err != nil {
+		glog.Warningf("Unable to parse the iptables sync period %q, using %v: %v", c.IPTablesSyncPeriod, defaultIPTablesSyncPeriod, err)
+		period = defaultIPTablesSyncPeriod
+	}
+	c.iptablesManager = iptables.NewManager(kiptables.New(kexec.New(), utildbus.New(), kiptables.ProtocolIpv4), period)
+	go c.iptablesManager.Run(util.NeverStop)
+	return c.iptablesManager
+}
+
+// keepSDNIPTablesRules keeps the iptables rules of the SDN in place, inserting again the rules removed by other
+// firewall tooling
+func (c *NodeConfig) keepSDNIPTablesRules() error {
+	clusterNetwork, err := c.OriginClient.ClusterNetwork().Get("default")
+	if err != nil {
+		return fmt.Errorf("unable to read the cluster network: %v", err)
+	}
+	c.runIPTablesManager().SetRules(sdnIPTablesOwner, sdnIPTablesRules(clusterNetwork.Network))
+	return nil
+}
+
+// keepProxyIPTablesRules keeps the iptables rules of the service proxy in place. When some were removed, the
+// proxy is synced so that it restores the rules of the services too.
+func (c *NodeConfig) keepProxyIPTablesRules(useIPTablesProxy bool, sync func()) {
+	manager := c.runIPTablesManager()
+	manager.SetRepairFunc(proxyIPTablesOwner, sync)
+	manager.SetRules(proxyIPTablesOwner, proxyIPTablesRules(useIPTablesProxy))
+}
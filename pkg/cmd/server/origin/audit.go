@@ -0,0 +1,56 @@
+package origin
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apiserver"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/audit"
+)
+
+// streamingSubresources are the subresources of pods that open a session with their containers
+var streamingSubresources = sets.NewString("exec", "attach", "portforward")
+
+// streamingSessionID numbers the audited streaming sessions so that their start and end can be matched in the log
+var streamingSessionID int64
+
+// streamingAuditFilter records in sink who opened an exec, attach or port-forward session on which pod,
+// from where, and how long the session lasted. Other requests are passed to handler unchanged.
+func streamingAuditFilter(handler http.Handler, contextMapper kapi.RequestContextMapper, sink audit.Sink) http.Handler {
+	infoResolver := &apiserver.RequestInfoResolver{APIPrefixes: sets.NewString("api", "osapi", "oapi", "apis"), GrouplessAPIPrefixes: sets.NewString("api", "osapi", "oapi")}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestInfo, err := infoResolver.GetRequestInfo(req)
+		if err != nil || requestInfo.Resource != "pods" || !streamingSubresources.Has(requestInfo.Subresource) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		userName := "<unknown>"
+		if ctx, ok := contextMapper.Get(req); ok {
+			if user, ok := kapi.UserFrom(ctx); ok {
+				userName = user.GetName()
+			}
+		}
+
+		id := atomic.AddInt64(&streamingSessionID, 1)
+		query := req.URL.Query()
+		switch requestInfo.Subresource {
+		case "portforward":
+			sink.Record("session %d: %s opened %s session on pod %s/%s from %s", id, userName, requestInfo.Subresource, requestInfo.Namespace, requestInfo.Name, req.RemoteAddr)
+		default:
+			sink.Record("session %d: %s opened %s session on pod %s/%s container %q from %s: command %q", id, userName, requestInfo.Subresource, requestInfo.Namespace, requestInfo.Name, query.Get("container"), req.RemoteAddr, strings.Join(query["command"], " "))
+		}
+
+		start := time.Now()
+		defer func() {
+			sink.Record("session %d: %s session of %s on pod %s/%s closed after %v", id, requestInfo.Subresource, userName, requestInfo.Namespace, requestInfo.Name, time.Since(start))
+		}()
+		handler.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,97 @@
+package origin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+)
+
+type fakeAuditSink struct {
+	records []string
+}
+
+func (s *fakeAuditSink) Record(format string, args ...interface{}) {
+	s.records = append(s.records, fmt.Sprintf(format, args...))
+}
+
+func TestStreamingAuditFilter(t *testing.T) {
+	sink := &fakeAuditSink{}
+
+	contextMapper := kapi.NewRequestContextMapper()
+	served := false
+	audited := streamingAuditFilter(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served = true
+	}), contextMapper, sink)
+	// authenticate every request as alice
+	authenticated := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, _ := contextMapper.Get(req)
+		contextMapper.Update(req, kapi.WithUser(ctx, &user.DefaultInfo{Name: "alice"}))
+		audited.ServeHTTP(w, req)
+	})
+	handler, err := kapi.NewRequestContextFilter(contextMapper, authenticated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := map[string]struct {
+		path     string
+		expected []string
+	}{
+		"exec": {
+			path: "/api/v1/namespaces/myproject/pods/mypod/exec?container=ruby&command=ls&command=-l",
+			expected: []string{
+				`alice opened exec session on pod myproject/mypod container "ruby" from 10.0.0.1:1234: command "ls -l"`,
+				`exec session of alice on pod myproject/mypod closed after`,
+			},
+		},
+		"attach": {
+			path: "/api/v1/namespaces/myproject/pods/mypod/attach?container=ruby",
+			expected: []string{
+				`alice opened attach session on pod myproject/mypod container "ruby" from 10.0.0.1:1234: command ""`,
+				`attach session of alice on pod myproject/mypod closed after`,
+			},
+		},
+		"port-forward": {
+			path: "/api/v1/namespaces/myproject/pods/mypod/portforward",
+			expected: []string{
+				`alice opened portforward session on pod myproject/mypod from 10.0.0.1:1234`,
+				`portforward session of alice on pod myproject/mypod closed after`,
+			},
+		},
+		"log": {
+			path: "/api/v1/namespaces/myproject/pods/mypod/log",
+		},
+		"pod": {
+			path: "/api/v1/namespaces/myproject/pods/mypod",
+		},
+		"non-API": {
+			path: "/healthz",
+		},
+	}
+	for name, tc := range testCases {
+		sink.records = []string{}
+		served = false
+		req, _ := http.NewRequest("POST", "https://master.example.com"+tc.path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if !served {
+			t.Errorf("%s: expected the request to be served", name)
+		}
+		records := sink.records
+		if len(records) != len(tc.expected) {
+			t.Errorf("%s: expected %d audit records, got %v", name, len(tc.expected), records)
+			continue
+		}
+		for i := range tc.expected {
+			if !strings.HasPrefix(records[i], "session ") || !strings.Contains(records[i], tc.expected[i]) {
+				t.Errorf("%s: expected audit record %q, got %q", name, tc.expected[i], records[i])
+			}
+		}
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/openshift/origin/pkg/auth/authenticator/request/headerrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/unionrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/x509request"
+	authgroup "github.com/openshift/origin/pkg/auth/group"
 	"github.com/openshift/origin/pkg/auth/ldaputil"
 	"github.com/openshift/origin/pkg/auth/oauth/external"
 	"github.com/openshift/origin/pkg/auth/oauth/external/github"
@@ -42,14 +43,18 @@ import (
 	"github.com/openshift/origin/pkg/auth/oauth/external/openid"
 	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	"github.com/openshift/origin/pkg/auth/oauth/registry"
+	"github.com/openshift/origin/pkg/auth/saml"
 	"github.com/openshift/origin/pkg/auth/server/csrf"
+	"github.com/openshift/origin/pkg/auth/server/errorpage"
 	"github.com/openshift/origin/pkg/auth/server/grant"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 	"github.com/openshift/origin/pkg/auth/server/login"
 	"github.com/openshift/origin/pkg/auth/server/tokenrequest"
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/discovery"
 	accesstokenregistry "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
 	accesstokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken/etcd"
 	authorizetokenregistry "github.com/openshift/origin/pkg/oauth/registry/oauthauthorizetoken"
@@ -109,7 +114,12 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 	clientAuthStorage := clientauthetcd.NewREST(c.EtcdHelper)
 	clientAuthRegistry := clientauthregistry.NewRegistry(clientAuthStorage)
 
-	authRequestHandler, authHandler, authFinalizer, err := c.getAuthorizeAuthenticationHandlers(mux)
+	errorPageHandler, err := c.getErrorHandler()
+	if err != nil {
+		glog.Fatal(err)
+	}
+
+	authRequestHandler, authHandler, authFinalizer, err := c.getAuthorizeAuthenticationHandlers(mux, errorPageHandler)
 	if err != nil {
 		glog.Fatal(err)
 	}
@@ -124,7 +134,10 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 	}
 
 	grantChecker := registry.NewClientAuthorizationGrantChecker(clientAuthRegistry)
-	grantHandler := c.getGrantHandler(mux, authRequestHandler, clientRegistry, clientAuthRegistry)
+	grantHandler, err := c.getGrantHandler(mux, authRequestHandler, clientRegistry, clientAuthRegistry)
+	if err != nil {
+		glog.Fatal(err)
+	}
 
 	server := osinserver.New(
 		config,
@@ -133,12 +146,12 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 			handlers.NewAuthorizeAuthenticator(
 				authRequestHandler,
 				authHandler,
-				handlers.EmptyError{},
+				errorPageHandler,
 			),
 			handlers.NewGrantCheck(
 				grantChecker,
 				grantHandler,
-				handlers.EmptyError{},
+				errorPageHandler,
 			),
 			authFinalizer,
 		},
@@ -149,6 +162,19 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 	)
 	server.Install(mux, OpenShiftOAuthAPIPrefix)
 
+	// advertise the public endpoints so that clients behind a different DNS view than the master find the server
+	metadata := discovery.Get(
+		c.Options.MasterPublicURL,
+		OpenShiftOAuthAuthorizeURL(c.Options.MasterPublicURL),
+		OpenShiftOAuthTokenURL(c.Options.MasterPublicURL),
+		config,
+	)
+	metadataHandler, err := discovery.NewHandler(metadata)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	mux.Handle(discovery.MetadataPath, metadataHandler)
+
 	CreateOrUpdateDefaultOAuthClients(c.Options.MasterPublicURL, c.AssetPublicAddresses, clientRegistry)
 	osOAuthClientConfig := c.NewOpenShiftOAuthClientConfig(&OSBrowserClientBase)
 	osOAuthClientConfig.RedirectUrl = c.Options.MasterPublicURL + path.Join(OpenShiftOAuthAPIPrefix, tokenrequest.DisplayTokenEndpoint)
@@ -165,7 +191,7 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 		})
 	}
 
-	tokenRequestEndpoints := tokenrequest.NewEndpoints(c.Options.MasterPublicURL, osOAuthClient)
+	tokenRequestEndpoints := tokenrequest.NewEndpoints(c.Options.MasterPublicURL, c.getCSRF(), osOAuthClient)
 	tokenRequestEndpoints.Install(mux, OpenShiftOAuthAPIPrefix)
 
 	// glog.Infof("oauth server configured as: %#v", server)
@@ -177,6 +203,7 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 	return []string{
 		fmt.Sprintf("Started OAuth2 API at %%s%s", OpenShiftOAuthAPIPrefix),
 		fmt.Sprintf("Started Login endpoint at %%s%s", OpenShiftLoginPrefix),
+		fmt.Sprintf("Started OAuth2 server metadata at %%s%s", discovery.MetadataPath),
 	}
 }
 
@@ -253,6 +280,10 @@ func CreateOrUpdateDefaultOAuthClients(masterPublicAddr string, assetPublicAddre
 				}
 			}
 
+			// Preserve the token lifetime and grant method an administrator chose for the client
+			currClient.AccessTokenMaxAgeSeconds = existing.AccessTokenMaxAgeSeconds
+			currClient.GrantMethod = existing.GrantMethod
+
 			if _, err := clientRegistry.UpdateClient(ctx, currClient); err != nil {
 				glog.Errorf("Error updating OAuthClient %v: %v", currClient.Name, err)
 			}
@@ -272,12 +303,12 @@ func (c *AuthConfig) getCSRF() csrf.CSRF {
 	return csrf.NewCookieCSRF("csrf", "/", "", secure, true)
 }
 
-func (c *AuthConfig) getAuthorizeAuthenticationHandlers(mux cmdutil.Mux) (authenticator.Request, handlers.AuthenticationHandler, osinserver.AuthorizeHandler, error) {
+func (c *AuthConfig) getAuthorizeAuthenticationHandlers(mux cmdutil.Mux, errorHandler handlers.AuthenticationErrorHandler) (authenticator.Request, handlers.AuthenticationHandler, osinserver.AuthorizeHandler, error) {
 	authRequestHandler, err := c.getAuthenticationRequestHandler()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	authHandler, err := c.getAuthenticationHandler(mux, handlers.EmptyError{})
+	authHandler, err := c.getAuthenticationHandler(mux, errorHandler)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -286,25 +317,47 @@ func (c *AuthConfig) getAuthorizeAuthenticationHandlers(mux cmdutil.Mux) (authen
 	return authRequestHandler, authHandler, authFinalizer, nil
 }
 
-// getGrantHandler returns the object that handles approving or rejecting grant requests
-func (c *AuthConfig) getGrantHandler(mux cmdutil.Mux, auth authenticator.Request, clientregistry clientregistry.Registry, authregistry clientauthregistry.Registry) handlers.GrantHandler {
+// getGrantHandler returns the object that handles approving or rejecting grant requests. Clients may set their own
+// grant method, otherwise the method of the configuration is used.
+func (c *AuthConfig) getGrantHandler(mux cmdutil.Mux, auth authenticator.Request, clientregistry clientregistry.Registry, authregistry clientauthregistry.Registry) (handlers.GrantHandler, error) {
+	defaultMethod := oauthapi.GrantHandlerType(c.Options.GrantConfig.Method)
 	switch c.Options.GrantConfig.Method {
-	case configapi.GrantHandlerDeny:
-		return handlers.NewEmptyGrant()
-
-	case configapi.GrantHandlerAuto:
-		return handlers.NewAutoGrant()
-
-	case configapi.GrantHandlerPrompt:
-		grantServer := grant.NewGrant(c.getCSRF(), auth, grant.DefaultFormRenderer, clientregistry, authregistry)
-		grantServer.Install(mux, OpenShiftApprovePrefix)
-		return handlers.NewRedirectGrant(OpenShiftApprovePrefix)
-
+	case configapi.GrantHandlerDeny, configapi.GrantHandlerAuto, configapi.GrantHandlerPrompt:
 	default:
 		glog.Fatalf("No grant handler found that matches %v.  The oauth server cannot start!", c.Options.GrantConfig.Method)
 	}
 
-	return nil
+	var grantTemplateFile string
+	if c.Options.Templates != nil {
+		grantTemplateFile = c.Options.Templates.Grant
+	}
+	grantFormRenderer, err := grant.NewFormRenderer(grantTemplateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// the approval page is needed for clients that prompt even when the configuration does not
+	grantServer := grant.NewGrant(c.getCSRF(), auth, grantFormRenderer, clientregistry, authregistry, defaultMethod)
+	grantServer.Install(mux, OpenShiftApprovePrefix)
+
+	return handlers.NewPerClientGrant(defaultMethod, map[oauthapi.GrantHandlerType]handlers.GrantHandler{
+		oauthapi.GrantHandlerDeny:   handlers.NewEmptyGrant(),
+		oauthapi.GrantHandlerAuto:   handlers.NewAutoGrant(),
+		oauthapi.GrantHandlerPrompt: handlers.NewRedirectGrant(OpenShiftApprovePrefix),
+	}), nil
+}
+
+// getErrorHandler returns the handler rendering the authentication and grant errors of the authorize flow
+func (c *AuthConfig) getErrorHandler() (*errorpage.ErrorPage, error) {
+	var errorTemplateFile string
+	if c.Options.Templates != nil {
+		errorTemplateFile = c.Options.Templates.Error
+	}
+	errorPageRenderer, err := errorpage.NewErrorPageTemplateRenderer(errorTemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	return errorpage.NewErrorPageHandler(errorPageRenderer), nil
 }
 
 // getAuthenticationFinalizer returns an authentication finalizer which is called just prior to writing a response to an authorization request
@@ -361,7 +414,7 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 					return nil, err
 				}
 
-				login := login.NewLogin(c.getCSRF(), &callbackPasswordAuthenticator{passwordAuth, passwordSuccessHandler}, loginFormRenderer)
+				login := login.NewLogin(c.getCSRF(), &callbackPasswordAuthenticator{passwordAuth, passwordSuccessHandler}, loginFormRenderer, c.getLoginThrottle(identityProvider))
 				login.Install(mux, OpenShiftLoginPrefix)
 			}
 			if identityProvider.UseAsChallenger {
@@ -385,8 +438,8 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 			}
 			oauthSuccessHandler := handlers.AuthenticationSuccessHandlers{c.SessionAuth, state}
 
-			// If the specified errorHandler doesn't handle the login error, let the state error handler attempt to propagate specific errors back to the token requester
-			oauthErrorHandler := handlers.AuthenticationErrorHandlers{errorHandler, state}
+			// Let the state error handler attempt to propagate specific errors back to the token requester before the errorHandler renders the rest
+			oauthErrorHandler := handlers.AuthenticationErrorHandlers{state, errorHandler}
 
 			callbackPath := path.Join(OpenShiftOAuthCallbackPrefix, identityProvider.Name)
 			oauthHandler, err := external.NewExternalOAuthRedirector(oauthProvider, state, c.Options.MasterPublicURL+callbackPath, oauthSuccessHandler, oauthErrorHandler, identityMapper)
@@ -401,6 +454,45 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 			if identityProvider.UseAsChallenger {
 				return nil, errors.New("oauth identity providers cannot issue challenges")
 			}
+		} else if samlProvider, isSAML := identityProvider.Provider.Object.(*configapi.SAMLIdentityProvider); isSAML {
+			certs, err := cmdutil.CertificatesFromFile(samlProvider.SigningCertificates)
+			if err != nil {
+				return nil, fmt.Errorf("Error reading the signing certificates of SAMLIdentityProvider %s: %v", identityProvider.Name, err)
+			}
+			config := saml.Config{
+				EntityID:        samlProvider.EntityID,
+				Issuer:          samlProvider.Issuer,
+				SingleSignOnURL: samlProvider.SingleSignOnURL,
+				Certificates:    certs,
+
+				IDAttributes:                samlProvider.Attributes.ID,
+				PreferredUsernameAttributes: samlProvider.Attributes.PreferredUsername,
+				EmailAttributes:             samlProvider.Attributes.Email,
+				NameAttributes:              samlProvider.Attributes.Name,
+			}
+
+			// The relay state round-tripped through the identity provider combines CSRF and return URL handling
+			state := external.CSRFRedirectingState(c.getCSRF())
+
+			if c.SessionAuth == nil {
+				return nil, errors.New("SessionAuth is required for SAML-based login")
+			}
+			samlSuccessHandler := handlers.AuthenticationSuccessHandlers{c.SessionAuth, state}
+			samlErrorHandler := handlers.AuthenticationErrorHandlers{state, errorHandler}
+
+			acsPath := path.Join(OpenShiftOAuthCallbackPrefix, identityProvider.Name)
+			samlHandler, err := saml.NewSAMLRedirector(identityProvider.Name, config, state, c.Options.MasterPublicURL+acsPath, samlSuccessHandler, samlErrorHandler, identityMapper)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected error: %v", err)
+			}
+
+			mux.Handle(acsPath, samlHandler)
+			if identityProvider.UseAsLogin {
+				redirectors["saml-"+identityProvider.Name+"-redirect"] = samlHandler
+			}
+			if identityProvider.UseAsChallenger {
+				return nil, errors.New("saml identity providers cannot issue challenges")
+			}
 		} else if requestHeaderProvider, isRequestHeader := identityProvider.Provider.Object.(*configapi.RequestHeaderIdentityProvider); isRequestHeader {
 			// We might be redirecting to an external site, we need to fully resolve the request URL to the public master
 			baseRequestURL, err := url.Parse(c.Options.MasterPublicURL + OpenShiftOAuthAPIPrefix + osinserver.AuthorizePath)
@@ -456,6 +548,9 @@ func (c *AuthConfig) getOAuthProvider(identityProvider configapi.IdentityProvide
 			TokenURL:     provider.URLs.Token,
 			UserInfoURL:  provider.URLs.UserInfo,
 
+			// URLs which are not configured are read from the discovery document of the issuer on first use
+			Issuer: provider.Issuer,
+
 			IDClaims:                provider.Claims.ID,
 			PreferredUsernameClaims: provider.Claims.PreferredUsername,
 			EmailClaims:             provider.Claims.Email,
@@ -502,6 +597,8 @@ func (c *AuthConfig) getPasswordAuthenticator(identityProvider configapi.Identit
 			URL:                  url,
 			ClientConfig:         clientConfig,
 			UserAttributeDefiner: ldaputil.NewLDAPUserAttributeDefiner(provider.Attributes),
+			Groups:               provider.Groups,
+			Users:                c.UserRegistry,
 		}
 		return ldappassword.New(identityProvider.Name, opts, identityMapper)
 
@@ -545,6 +642,15 @@ func (c *AuthConfig) getPasswordAuthenticator(identityProvider configapi.Identit
 
 }
 
+// getLoginThrottle returns the throttle of the password logins of identityProvider, or nil if logins are not limited.
+// The login form and basic auth challenges share the throttle, so failures in either count against both.
+func (c *AuthConfig) getLoginThrottle(identityProvider configapi.IdentityProvider) lockout.Throttle {
+	if c.LoginLockout == nil {
+		return nil
+	}
+	return c.LoginLockout.ForProvider(identityProvider.Name)
+}
+
 func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, error) {
 	var authRequestHandlers []authenticator.Request
 
@@ -563,7 +669,7 @@ func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, e
 			if err != nil {
 				return nil, err
 			}
-			authRequestHandlers = append(authRequestHandlers, basicauthrequest.NewBasicAuthAuthentication(passwordAuthenticator, true))
+			authRequestHandlers = append(authRequestHandlers, basicauthrequest.NewBasicAuthAuthentication(passwordAuthenticator, true, c.getLoginThrottle(identityProvider)))
 
 		} else {
 			switch provider := identityProvider.Provider.Object.(type) {
@@ -573,6 +679,11 @@ func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, e
 				authRequestConfig := &headerrequest.Config{
 					UserNameHeaders: provider.Headers,
 				}
+				if len(provider.GroupHeaders) > 0 {
+					filter := authgroup.Filter{Prefix: provider.GroupPrefix, Allowed: sets.NewString(provider.AllowedGroups...)}
+					authRequestConfig.GroupHeaders = provider.GroupHeaders
+					authRequestConfig.Groups = authgroup.NewRecorder(headerrequest.GroupsAnnotationPrefix+identityProvider.Name, filter, c.UserRegistry)
+				}
 				authRequestHandler = headerrequest.NewAuthenticator(identityProvider.Name, authRequestConfig, identityMapper)
 
 				// Wrap with an x509 verifier
@@ -587,7 +698,7 @@ func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, e
 						return nil, fmt.Errorf("Error loading certs from %s: %v", provider.ClientCA, err)
 					}
 
-					authRequestHandler = x509request.NewVerifier(opts, authRequestHandler)
+					authRequestHandler = x509request.NewVerifier(opts, authRequestHandler, sets.NewString(provider.ClientCommonNames...))
 				}
 				authRequestHandlers = append(authRequestHandlers, authRequestHandler)
 
@@ -4,11 +4,14 @@ import (
 	"crypto/md5"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/pborman/uuid"
 
 	"k8s.io/kubernetes/pkg/storage"
 
+	"github.com/openshift/origin/pkg/audit"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 	"github.com/openshift/origin/pkg/auth/server/session"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/api/latest"
@@ -37,9 +40,15 @@ type AuthConfig struct {
 	IdentityRegistry identityregistry.Registry
 
 	SessionAuth *session.Authenticator
+
+	// LoginLockout audits and limits the failed password logins of every identity provider. Nil if failed logins
+	// are neither audited nor limited.
+	LoginLockout *lockout.Lockout
 }
 
-func BuildAuthConfig(options configapi.MasterConfig) (*AuthConfig, error) {
+// BuildAuthConfig returns the AuthConfig of options. loginLockout is the MasterConfig.LoginLockout, so that the
+// failures of password logins and API requests are limited together.
+func BuildAuthConfig(options configapi.MasterConfig, loginLockout *lockout.Lockout) (*AuthConfig, error) {
 	client, err := etcd.EtcdClient(options.EtcdClientInfo)
 	if err != nil {
 		return nil, err
@@ -99,6 +108,8 @@ func BuildAuthConfig(options configapi.MasterConfig) (*AuthConfig, error) {
 		UserRegistry:     userRegistry,
 
 		SessionAuth: sessionAuth,
+
+		LoginLockout: loginLockout,
 	}
 
 	return ret, nil
@@ -145,3 +156,20 @@ func isHTTPS(u string) bool {
 	parsedURL, err := url.Parse(u)
 	return err == nil && parsedURL.Scheme == "https"
 }
+
+// newLoginLockout returns the Lockout that records failed logins in auditSink and limits them according to throttle,
+// or nil if throttle is nil. Without limits, failed logins are only recorded.
+func newLoginLockout(throttle *configapi.LoginThrottleConfig, auditSink audit.Sink) *lockout.Lockout {
+	if throttle == nil {
+		return nil
+	}
+	// validation rejects invalid proxies
+	trustedProxies, _ := lockout.ParseTrustedProxies(throttle.TrustedProxies)
+	return lockout.New(lockout.Config{
+		MaxFailedAttempts:            throttle.MaxFailedAttempts,
+		MaxFailedAttemptsPerSourceIP: throttle.MaxFailedAttemptsPerSourceIP,
+		Lockout:                      time.Duration(throttle.LockoutSeconds) * time.Second,
+		MaxLockout:                   time.Duration(throttle.MaxLockoutSeconds) * time.Second,
+		TrustedProxies:               trustedProxies,
+	}, lockout.SinkAuditor{Sink: auditSink})
+}
@@ -18,8 +18,10 @@ import (
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	clusterpolicyregistry "github.com/openshift/origin/pkg/authorization/registry/clusterpolicy"
 	clusterpolicystorage "github.com/openshift/origin/pkg/authorization/registry/clusterpolicy/etcd"
+	"github.com/openshift/origin/pkg/bootstrap"
 	"github.com/openshift/origin/pkg/cmd/server/admin"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	"github.com/openshift/origin/pkg/version"
 )
 
 // ensureOpenShiftSharedResourcesNamespace is called as part of global policy initialization to ensure shared namespace exists
@@ -35,6 +37,19 @@ func (c *MasterConfig) ensureOpenShiftSharedResourcesNamespace() {
 	}
 }
 
+// ensureOpenShiftSharedResourcesContent installs the default image streams and templates in the shared namespace,
+// and updates the ones installed by another version of the server
+func (c *MasterConfig) ensureOpenShiftSharedResourcesContent() {
+	namespace := c.Options.PolicyConfig.OpenShiftSharedResourcesNamespace
+	result, err := bootstrap.Install(c.PrivilegedLoopbackOpenShiftClient, namespace, version.Get().String())
+	if err != nil {
+		glog.Errorf("Error installing the default image streams and templates in %s: %v", namespace, err)
+	}
+	if result.Created+result.Updated > 0 {
+		glog.Infof("Installed %d and updated %d default image streams and templates in %s", result.Created, result.Updated, namespace)
+	}
+}
+
 // ensureOpenShiftInfraNamespace is called as part of global policy initialization to ensure infra namespace exists
 func (c *MasterConfig) ensureOpenShiftInfraNamespace() {
 	ns := c.Options.PolicyConfig.OpenShiftInfrastructureNamespace
@@ -17,9 +17,13 @@ import (
 	klatest "k8s.io/kubernetes/pkg/api/latest"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/apiserver"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/controller/serviceaccount"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/authorization/authorizer"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 )
 
 // TODO We would like to use the IndexHandler from k8s but we do not yet have a
@@ -91,6 +95,92 @@ func (c *MasterConfig) authorizationFilter(handler http.Handler) http.Handler {
 	})
 }
 
+// impersonationFilter replaces the user of requests that set the Impersonate-User header with the requested user, if
+// the authenticated user is allowed to impersonate it. Impersonating a user requires the impersonate verb on the
+// user, or on the service account for service account users, and on each group set with Impersonate-Group.
+func (c *MasterConfig) impersonationFilter(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestedUser := req.Header.Get(authapi.ImpersonateUserHeader)
+		requestedGroups := req.Header[http.CanonicalHeaderKey(authapi.ImpersonateGroupHeader)]
+		if len(requestedUser) == 0 {
+			if len(requestedGroups) > 0 {
+				forbidden(fmt.Sprintf("%s requires %s", authapi.ImpersonateGroupHeader, authapi.ImpersonateUserHeader), nil, w, req)
+				return
+			}
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		ctx, exists := c.RequestContextMapper.Get(req)
+		if !exists {
+			forbidden("context not found", nil, w, req)
+			return
+		}
+		oldUser, exists := kapi.UserFrom(ctx)
+		if !exists {
+			forbidden("user not found", nil, w, req)
+			return
+		}
+
+		newUser := &user.DefaultInfo{Name: requestedUser}
+		checks := []authorizer.DefaultAuthorizationAttributes{}
+		checkNamespace := ""
+		if namespace, name, err := serviceaccount.SplitUsername(requestedUser); err == nil {
+			checkNamespace = namespace
+			checks = append(checks, authorizer.DefaultAuthorizationAttributes{Verb: "impersonate", Resource: "serviceaccounts", ResourceName: name})
+			if len(requestedGroups) == 0 {
+				newUser.Groups = serviceaccount.MakeGroupNames(namespace, name)
+			}
+		} else {
+			checks = append(checks, authorizer.DefaultAuthorizationAttributes{Verb: "impersonate", Resource: "users", ResourceName: requestedUser})
+			if len(requestedGroups) == 0 {
+				groups, err := c.GroupCache.GroupsFor(requestedUser)
+				if err != nil {
+					forbidden(err.Error(), nil, w, req)
+					return
+				}
+				for _, group := range groups {
+					newUser.Groups = append(newUser.Groups, group.Name)
+				}
+			}
+		}
+		for _, group := range requestedGroups {
+			checks = append(checks, authorizer.DefaultAuthorizationAttributes{Verb: "impersonate", Resource: "groups", ResourceName: group})
+			newUser.Groups = append(newUser.Groups, group)
+		}
+		newUser.Groups = append(newUser.Groups, bootstrappolicy.AuthenticatedGroup)
+
+		for i := range checks {
+			// users and groups are cluster scoped, service accounts are checked in their namespace
+			checkCtx := kapi.WithNamespace(ctx, "")
+			if checks[i].Resource == "serviceaccounts" {
+				checkCtx = kapi.WithNamespace(ctx, checkNamespace)
+			}
+			allowed, reason, err := c.Authorizer.Authorize(checkCtx, checks[i])
+			if err != nil {
+				forbidden(err.Error(), checks[i], w, req)
+				return
+			}
+			if !allowed {
+				forbidden(reason, checks[i], w, req)
+				return
+			}
+		}
+
+		if c.Options.AuditConfig.Enabled {
+			c.AuditSink.Record("%s is impersonating %s with groups %v for %s %s", oldUser.GetName(), newUser.Name, newUser.Groups, req.Method, req.URL.Path)
+		}
+		if err := c.RequestContextMapper.Update(req, kapi.WithUser(ctx, newUser)); err != nil {
+			forbidden(err.Error(), nil, w, req)
+			return
+		}
+		req.Header.Del(authapi.ImpersonateUserHeader)
+		req.Header.Del(authapi.ImpersonateGroupHeader)
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
 // forbidden renders a simple forbidden error
 func forbidden(reason string, attributes authorizer.AuthorizationAttributes, w http.ResponseWriter, req *http.Request) {
 	kind := ""
@@ -0,0 +1,125 @@
+package origin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/authorization/authorizer"
+)
+
+// impersonateAuthorizer allows alice to impersonate the listed users, groups and service accounts
+type impersonateAuthorizer struct {
+	allowed sets.String
+}
+
+func (a *impersonateAuthorizer) Authorize(ctx kapi.Context, attributes authorizer.AuthorizationAttributes) (bool, string, error) {
+	u, _ := kapi.UserFrom(ctx)
+	key := kapi.NamespaceValue(ctx) + "/" + attributes.GetResource() + "/" + attributes.GetResourceName()
+	if u.GetName() == "alice" && attributes.GetVerb() == "impersonate" && a.allowed.Has(key) {
+		return true, "", nil
+	}
+	return false, "denied", nil
+}
+
+func (a *impersonateAuthorizer) GetAllowedSubjects(ctx kapi.Context, attributes authorizer.AuthorizationAttributes) (sets.String, sets.String, error) {
+	return nil, nil, nil
+}
+
+func TestImpersonationFilter(t *testing.T) {
+	contextMapper := kapi.NewRequestContextMapper()
+	config := &MasterConfig{
+		RequestContextMapper: contextMapper,
+		Authorizer:           &impersonateAuthorizer{allowed: sets.NewString("/users/bob", "/groups/devs", "myproject/serviceaccounts/builder")},
+	}
+
+	var served user.Info
+	impersonated := config.impersonationFilter(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, _ := contextMapper.Get(req)
+		served, _ = kapi.UserFrom(ctx)
+	}))
+	// authenticate every request as alice
+	authenticated := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, _ := contextMapper.Get(req)
+		contextMapper.Update(req, kapi.WithUser(ctx, &user.DefaultInfo{Name: "alice", Groups: []string{"system:authenticated"}}))
+		impersonated.ServeHTTP(w, req)
+	})
+	handler, err := kapi.NewRequestContextFilter(contextMapper, authenticated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := map[string]struct {
+		user   string
+		groups []string
+
+		expectedUser   *user.DefaultInfo
+		expectedStatus int
+	}{
+		"no impersonation": {
+			expectedUser:   &user.DefaultInfo{Name: "alice", Groups: []string{"system:authenticated"}},
+			expectedStatus: http.StatusOK,
+		},
+		"allowed user and group": {
+			user:           "bob",
+			groups:         []string{"devs"},
+			expectedUser:   &user.DefaultInfo{Name: "bob", Groups: []string{"devs", "system:authenticated"}},
+			expectedStatus: http.StatusOK,
+		},
+		"allowed service account": {
+			user:           "system:serviceaccount:myproject:builder",
+			expectedUser:   &user.DefaultInfo{Name: "system:serviceaccount:myproject:builder", Groups: []string{"system:serviceaccounts", "system:serviceaccounts:myproject", "system:authenticated"}},
+			expectedStatus: http.StatusOK,
+		},
+		"forbidden user": {
+			user:           "eve",
+			groups:         []string{"devs"},
+			expectedStatus: http.StatusForbidden,
+		},
+		"forbidden group": {
+			user:           "bob",
+			groups:         []string{"devs", "cluster-admins"},
+			expectedStatus: http.StatusForbidden,
+		},
+		"forbidden service account": {
+			user:           "system:serviceaccount:otherproject:builder",
+			expectedStatus: http.StatusForbidden,
+		},
+		"group without user": {
+			groups:         []string{"devs"},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+	for name, tc := range testCases {
+		served = nil
+		req, _ := http.NewRequest("GET", "https://master.example.com/api/v1/namespaces/myproject/pods", nil)
+		if len(tc.user) > 0 {
+			req.Header.Set(authapi.ImpersonateUserHeader, tc.user)
+		}
+		for _, group := range tc.groups {
+			req.Header.Add(authapi.ImpersonateGroupHeader, group)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tc.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d: %s", name, tc.expectedStatus, w.Code, w.Body.String())
+			continue
+		}
+		if tc.expectedUser == nil {
+			if served != nil {
+				t.Errorf("%s: expected the request not to be served, got user %#v", name, served)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(served, tc.expectedUser) {
+			t.Errorf("%s: expected user %#v, got %#v", name, tc.expectedUser, served)
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/apiserver"
+	"k8s.io/kubernetes/pkg/client/record"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	kmaster "k8s.io/kubernetes/pkg/master"
 	"k8s.io/kubernetes/pkg/util"
@@ -35,6 +36,7 @@ import (
 	"github.com/openshift/origin/pkg/build/webhook"
 	"github.com/openshift/origin/pkg/build/webhook/generic"
 	"github.com/openshift/origin/pkg/build/webhook/github"
+	csretcd "github.com/openshift/origin/pkg/certificates/registry/certificatesigningrequest/etcd"
 	"github.com/openshift/origin/pkg/cmd/server/crypto"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	deployconfiggenerator "github.com/openshift/origin/pkg/deploy/generator"
@@ -49,10 +51,13 @@ import (
 	"github.com/openshift/origin/pkg/image/registry/imagestreamimage"
 	"github.com/openshift/origin/pkg/image/registry/imagestreammapping"
 	"github.com/openshift/origin/pkg/image/registry/imagestreamtag"
+	accesstokenregistry "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
 	accesstokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken/etcd"
 	authorizetokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthauthorizetoken/etcd"
 	clientetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclient/etcd"
+	clientauthregistry "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization"
 	clientauthetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization/etcd"
+	"github.com/openshift/origin/pkg/oauth/registry/useroauthclientauthorization"
 	projectproxy "github.com/openshift/origin/pkg/project/registry/project/proxy"
 	projectrequeststorage "github.com/openshift/origin/pkg/project/registry/projectrequest/delegated"
 	routeallocationcontroller "github.com/openshift/origin/pkg/route/controller/allocation"
@@ -141,7 +146,13 @@ func (c *MasterConfig) Run(protected []APIInstaller, unprotected []APIInstaller)
 	for _, i := range protected {
 		extra = append(extra, i.InstallAPI(safe)...)
 	}
-	handler := c.authorizationFilter(safe)
+	var handler http.Handler = safe
+	if c.Options.AuditConfig.Enabled {
+		// only the sessions allowed by authorization are recorded
+		handler = streamingAuditFilter(handler, c.getRequestContextMapper(), c.AuditSink)
+	}
+	handler = c.authorizationFilter(handler)
+	handler = c.impersonationFilter(handler)
 	handler = authenticationHandlerFilter(handler, c.Authenticator, c.getRequestContextMapper())
 	handler = namespacingFilter(handler, c.getRequestContextMapper())
 	handler = cacheControlFilter(handler, "no-store") // protected endpoints should not be cached
@@ -269,6 +280,10 @@ func (c *MasterConfig) InitializeObjects() {
 	c.ensureOpenShiftInfraNamespace()
 	// Create the shared resource namespace
 	c.ensureOpenShiftSharedResourcesNamespace()
+	// Install the default image streams and templates in the shared resource namespace
+	if c.Options.PolicyConfig.BootstrapSharedResources {
+		c.ensureOpenShiftSharedResourcesContent()
+	}
 }
 
 func (c *MasterConfig) InstallProtectedAPI(container *restful.Container) []string {
@@ -314,6 +329,7 @@ func (c *MasterConfig) InstallProtectedAPI(container *restful.Container) []strin
 	initControllerRoutes(root, "/controllers", c.Options.Controllers != configapi.ControllersDisabled, c.ControllerPlug)
 	initHealthCheckRoute(root, "/healthz")
 	initReadinessCheckRoute(root, "/healthz/ready", c.ProjectAuthorizationCache.ReadyForAccess)
+	initInfrastructureImagesRoute(root, "/infrastructure/images", c.ImageFor)
 
 	return messages
 }
@@ -331,7 +347,7 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 		glog.Fatalf("Unable to configure Kubelet client: %v", err)
 	}
 
-	buildStorage, buildDetailsStorage := buildetcd.NewStorage(c.EtcdHelper)
+	buildStorage, buildDetailsStorage, buildFinalizeStorage := buildetcd.NewStorage(c.EtcdHelper)
 	buildRegistry := buildregistry.NewRegistry(buildStorage)
 
 	buildConfigStorage := buildconfigetcd.NewStorage(c.EtcdHelper)
@@ -343,9 +359,10 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 	routeAllocator := c.RouteAllocator()
 
 	routeEtcd := routeetcd.NewREST(c.EtcdHelper, routeAllocator)
-	hostSubnetStorage := hostsubnetetcd.NewREST(c.EtcdHelper)
-	netNamespaceStorage := netnamespaceetcd.NewREST(c.EtcdHelper)
 	clusterNetworkStorage := clusternetworketcd.NewREST(c.EtcdHelper)
+	hostSubnetStorage := hostsubnetetcd.NewREST(c.EtcdHelper, clusterNetworkStorage)
+	netNamespaceStorage := netnamespaceetcd.NewREST(c.EtcdHelper)
+	csrStorage, csrApprovalStorage, csrStatusStorage := csretcd.NewREST(c.EtcdHelper)
 
 	userStorage := useretcd.NewREST(c.EtcdHelper)
 	userRegistry := userregistry.NewRegistry(userStorage)
@@ -353,6 +370,10 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 	identityRegistry := identityregistry.NewRegistry(identityStorage)
 	userIdentityMappingStorage := useridentitymapping.NewREST(userRegistry, identityRegistry)
 
+	accessTokenStorage := accesstokenetcd.NewREST(c.EtcdHelper)
+	clientAuthorizationStorage := clientauthetcd.NewREST(c.EtcdHelper)
+	userClientAuthorizationStorage := useroauthclientauthorization.NewREST(clientauthregistry.NewRegistry(clientAuthorizationStorage), accesstokenregistry.NewRegistry(accessTokenStorage))
+
 	policyStorage := policyetcd.NewStorage(c.EtcdHelper)
 	policyRegistry := policyregistry.NewRegistry(policyStorage)
 	policyBindingStorage := policybindingetcd.NewStorage(c.EtcdHelper)
@@ -379,7 +400,9 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 	imageRegistry := image.NewRegistry(imageStorage)
 	imageStreamStorage, imageStreamStatusStorage, internalImageStreamStorage := imagestreametcd.NewREST(c.EtcdHelper, imagestream.DefaultRegistryFunc(defaultRegistryFunc), subjectAccessReviewRegistry)
 	imageStreamRegistry := imagestream.NewRegistry(imageStreamStorage, imageStreamStatusStorage, internalImageStreamStorage)
-	imageStreamMappingStorage := imagestreammapping.NewREST(imageRegistry, imageStreamRegistry)
+	imageStreamEventBroadcaster := record.NewBroadcaster()
+	imageStreamEventBroadcaster.StartRecordingToSink(c.PrivilegedLoopbackKubernetesClient.Events(""))
+	imageStreamMappingStorage := imagestreammapping.NewREST(imageRegistry, imageStreamRegistry, imageStreamEventBroadcaster.NewRecorder(kapi.EventSource{Component: "imagestreammapping"}))
 	imageStreamTagStorage := imagestreamtag.NewREST(imageRegistry, imageStreamRegistry)
 	imageStreamTagRegistry := imagestreamtag.NewRegistry(imageStreamTagStorage)
 	imageStreamImageStorage := imagestreamimage.NewREST(imageRegistry, imageStreamRegistry)
@@ -461,15 +484,20 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 		"netNamespaces":   netNamespaceStorage,
 		"clusterNetworks": clusterNetworkStorage,
 
+		"certificateSigningRequests":          csrStorage,
+		"certificateSigningRequests/approval": csrApprovalStorage,
+		"certificateSigningRequests/status":   csrStatusStorage,
+
 		"users":                userStorage,
 		"groups":               groupetcd.NewREST(c.EtcdHelper),
 		"identities":           identityStorage,
 		"userIdentityMappings": userIdentityMappingStorage,
 
-		"oAuthAuthorizeTokens":      authorizetokenetcd.NewREST(c.EtcdHelper),
-		"oAuthAccessTokens":         accesstokenetcd.NewREST(c.EtcdHelper),
-		"oAuthClients":              clientetcd.NewREST(c.EtcdHelper),
-		"oAuthClientAuthorizations": clientauthetcd.NewREST(c.EtcdHelper),
+		"oAuthAuthorizeTokens":          authorizetokenetcd.NewREST(c.EtcdHelper),
+		"oAuthAccessTokens":             accessTokenStorage,
+		"oAuthClients":                  clientetcd.NewREST(c.EtcdHelper),
+		"oAuthClientAuthorizations":     clientAuthorizationStorage,
+		"userOAuthClientAuthorizations": userClientAuthorizationStorage,
 
 		"resourceAccessReviews":      resourceAccessReviewStorage,
 		"subjectAccessReviews":       subjectAccessReviewStorage,
@@ -496,6 +524,13 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 		storage["buildConfigs/instantiatebinary"] = buildconfiginstantiate.NewBinaryStorage(buildGenerator, buildStorage, c.BuildLogClient(), kubeletClient)
 		storage["builds/log"] = buildlogregistry.NewREST(buildStorage, buildStorage, c.BuildLogClient(), kubeletClient)
 		storage["builds/details"] = buildDetailsStorage
+		storage["builds/finalize"] = buildFinalizeStorage
+	}
+
+	for resource := range storage {
+		if !configapi.IsAPIResourceEnabled(&c.Options, resource) {
+			delete(storage, resource)
+		}
 	}
 
 	return storage
@@ -541,6 +576,20 @@ func initReadinessCheckRoute(root *restful.WebService, path string, readyFunc fu
 		Produces(restful.MIME_JSON))
 }
 
+// initInfrastructureImagesRoute initializes an HTTP endpoint that reports the image used for each
+// infrastructure component, which allows digests resolved at startup to be inspected.
+func initInfrastructureImagesRoute(root *restful.WebService, path string, imageFor func(string) string) {
+	root.Route(root.GET(path).To(func(req *restful.Request, resp *restful.Response) {
+		images := make(map[string]string)
+		for _, component := range infrastructureImageComponents {
+			images[component] = imageFor(component)
+		}
+		resp.WriteAsJson(images)
+	}).Doc("return the images used for infrastructure components").
+		Returns(http.StatusOK, "the image of each infrastructure component", nil).
+		Produces(restful.MIME_JSON))
+}
+
 // initHealthCheckRoute initalizes an HTTP endpoint for health checking.
 // OpenShift is deemed healthy if the API server can respond with an OK messages
 func initMetricsRoute(root *restful.WebService, path string) {
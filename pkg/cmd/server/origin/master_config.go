@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"time"
 
 	etcdclient "github.com/coreos/go-etcd/etcd"
 	"github.com/golang/glog"
@@ -23,16 +24,23 @@ import (
 	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/audit"
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"github.com/openshift/origin/pkg/auth/authenticator/anonymous"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/bearertoken"
+	"github.com/openshift/origin/pkg/auth/authenticator/request/lockoutrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/paramtoken"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/unionrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/x509request"
+	authncache "github.com/openshift/origin/pkg/auth/authenticator/token/cache"
+	webhooktoken "github.com/openshift/origin/pkg/auth/authenticator/token/webhook"
 	"github.com/openshift/origin/pkg/auth/group"
 	authnregistry "github.com/openshift/origin/pkg/auth/oauth/registry"
+	"github.com/openshift/origin/pkg/auth/server/lockout"
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
 	"github.com/openshift/origin/pkg/authorization/authorizer"
+	authzcache "github.com/openshift/origin/pkg/authorization/authorizer/cache"
+	nodeauthorizer "github.com/openshift/origin/pkg/authorization/authorizer/node"
 	policycache "github.com/openshift/origin/pkg/authorization/cache"
 	policyclient "github.com/openshift/origin/pkg/authorization/client"
 	clusterpolicyregistry "github.com/openshift/origin/pkg/authorization/registry/clusterpolicy"
@@ -49,9 +57,11 @@ import (
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	"github.com/openshift/origin/pkg/cmd/server/etcd"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/plug"
 	"github.com/openshift/origin/pkg/cmd/util/pluginconfig"
 	"github.com/openshift/origin/pkg/cmd/util/variable"
+	"github.com/openshift/origin/pkg/dockerregistry"
 	accesstokenregistry "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
 	accesstokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken/etcd"
 	projectauth "github.com/openshift/origin/pkg/project/auth"
@@ -67,8 +77,18 @@ import (
 
 const (
 	unauthenticatedUsername = "system:anonymous"
+
+	// authorizationCacheTTL bounds how long a cached authorization decision is used for. Decisions are also dropped
+	// as soon as a policy or binding changes, so this only limits the effect of a missed change.
+	authorizationCacheTTL = 10 * time.Second
+	// authorizationCacheSize is the number of authorization decisions that are cached
+	authorizationCacheSize = 10000
 )
 
+// infrastructureImageComponents are the components the master launches pods for. Their images
+// are pinned to a digest when ImageConfig.ResolveDigests is set.
+var infrastructureImageComponents = []string{"deployer", "docker-builder", "sti-builder", "recycler"}
+
 // MasterConfig defines the required parameters for starting the OpenShift master
 type MasterConfig struct {
 	Options configapi.MasterConfig
@@ -82,6 +102,17 @@ type MasterConfig struct {
 	ProjectAuthorizationCache *projectauth.AuthorizationCache
 	ProjectCache              *projectcache.ProjectCache
 
+	// AccessTokenCache caches the results of authenticating access tokens, nil if caching is disabled
+	AccessTokenCache *authncache.CacheAuthenticator
+
+	// LoginLockout limits the failed password logins and API authentications, nil if oauthConfig.loginThrottle is
+	// not set. It is shared with the AuthConfig.
+	LoginLockout *lockout.Lockout
+
+	// AuditSink records every audited event of the master. Whether an event is audited is decided by the
+	// setting of the component that records it, like auditConfig.enabled.
+	AuditSink audit.Sink
+
 	// RequestContextMapper maps requests to contexts
 	RequestContextMapper kapi.RequestContextMapper
 
@@ -122,6 +153,15 @@ type MasterConfig struct {
 	// To apply different access control to a system component, create a separate client/config specifically
 	// for that component.
 	PrivilegedLoopbackOpenShiftClient *osclient.Client
+
+	// ControllerClientConfig is the PrivilegedLoopbackClientConfig of the controllers. While the controllers run
+	// under a lease, its writes are refused unless the lease is still held with the token it was acquired with
+	// and has not expired.
+	ControllerClientConfig kclient.Config
+	// ControllerKubernetesClient and ControllerOpenShiftClient are the clients of the controllers, built from
+	// ControllerClientConfig. They should only be accessed via the *ControllerClient(s)() helper methods.
+	ControllerKubernetesClient *kclient.Client
+	ControllerOpenShiftClient  *osclient.Client
 }
 
 // BuildMasterConfig builds and returns the OpenShift master configuration based on the
@@ -157,6 +197,12 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 	imageTemplate := variable.NewDefaultImageTemplate()
 	imageTemplate.Format = options.ImageConfig.Format
 	imageTemplate.Latest = options.ImageConfig.Latest
+	if len(options.ImageConfig.DeployerImage) > 0 {
+		imageTemplate.Overrides = map[string]string{"deployer": options.ImageConfig.DeployerImage}
+	}
+	if options.ImageConfig.ResolveDigests {
+		imageTemplate.Pin(infrastructureImageComponents, variable.NewRegistryDigestResolver(dockerregistry.NewClient(30*time.Second)))
+	}
 
 	policyCache, policyClient := newReadOnlyCacheAndClient(etcdHelper)
 	requestContextMapper := kapi.NewRequestContextMapper()
@@ -167,7 +213,7 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 	kubeletClientConfig := configapi.GetKubeletClientConfig(options)
 
 	// in-order list of plug-ins that should intercept admission decisions (origin only intercepts)
-	admissionControlPluginNames := []string{"OriginNamespaceLifecycle", "BuildByStrategy"}
+	admissionControlPluginNames := []string{"OriginNamespaceLifecycle", "BuildByStrategy", "OriginAllowedRegistries", "OriginResourceQuota"}
 	if len(options.AdmissionConfig.PluginOrderOverride) > 0 {
 		admissionControlPluginNames = options.AdmissionConfig.PluginOrderOverride
 	}
@@ -199,14 +245,43 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 		return nil, err
 	}
 
-	plug, plugStart := newControllerPlug(options, client)
+	auditSink := audit.LogSink{}
+	var loginLockout *lockout.Lockout
+	if options.OAuthConfig != nil {
+		loginLockout = newLoginLockout(options.OAuthConfig.LoginThrottle, auditSink)
+	}
+
+	accessTokenCache, err := newAccessTokenCache(options, etcdHelper, groupCache)
+	if err != nil {
+		return nil, err
+	}
+
+	controllerPlug, plugStart := newControllerPlug(options, client)
+	controllerClientConfig := *privilegedLoopbackClientConfig
+	if leased, ok := controllerPlug.(*plug.Leased); ok {
+		controllerClientConfig.WrapTransport = leased.WrapTransport
+	}
+	controllerKubeClient, err := kclient.New(&controllerClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	controllerOpenShiftClient, err := osclient.New(&controllerClientConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	authorizer := newAuthorizer(policyClient, options.ProjectConfig.ProjectRequestMessage)
+	authorizer, err := newAuthorizer(policyClient, options.ProjectConfig.ProjectRequestMessage)
+	if err != nil {
+		return nil, err
+	}
+	if options.PolicyConfig.RestrictNodeAccess {
+		authorizer = nodeauthorizer.NewAuthorizer(authorizer, privilegedLoopbackKubeClient)
+	}
 
 	config := &MasterConfig{
 		Options: options,
 
-		Authenticator:                 newAuthenticator(options, etcdHelper, serviceAccountTokenGetter, apiClientCAs, groupCache),
+		Authenticator:                 newAuthenticator(options, etcdHelper, serviceAccountTokenGetter, apiClientCAs, groupCache, accessTokenCache, loginLockout, auditSink),
 		Authorizer:                    authorizer,
 		AuthorizationAttributeBuilder: newAuthorizationAttributeBuilder(requestContextMapper),
 
@@ -214,6 +289,9 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 		GroupCache:                groupCache,
 		ProjectAuthorizationCache: newProjectAuthorizationCache(authorizer, privilegedLoopbackKubeClient, policyClient),
 		ProjectCache:              projectCache,
+		AccessTokenCache:          accessTokenCache,
+		LoginLockout:              loginLockout,
+		AuditSink:                 auditSink,
 
 		RequestContextMapper: requestContextMapper,
 
@@ -221,7 +299,7 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 
 		TLS: configapi.UseTLS(options.ServingInfo.ServingInfo),
 
-		ControllerPlug:      plug,
+		ControllerPlug:      controllerPlug,
 		ControllerPlugStart: plugStart,
 
 		ImageFor:            imageTemplate.ExpandOrDie,
@@ -235,6 +313,10 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 		PrivilegedLoopbackClientConfig:     *privilegedLoopbackClientConfig,
 		PrivilegedLoopbackOpenShiftClient:  privilegedLoopbackOpenShiftClient,
 		PrivilegedLoopbackKubernetesClient: privilegedLoopbackKubeClient,
+
+		ControllerClientConfig:     controllerClientConfig,
+		ControllerKubernetesClient: controllerKubeClient,
+		ControllerOpenShiftClient:  controllerOpenShiftClient,
 	}
 
 	return config, nil
@@ -282,8 +364,11 @@ func newServiceAccountTokenGetter(options configapi.MasterConfig, client *etcdcl
 	return tokenGetter, nil
 }
 
-func newAuthenticator(config configapi.MasterConfig, etcdHelper storage.Interface, tokenGetter serviceaccount.ServiceAccountTokenGetter, apiClientCAs *x509.CertPool, groupMapper identitymapper.UserToGroupMapper) authenticator.Request {
-	authenticators := []authenticator.Request{}
+// requestAuthenticationLoginProvider is the name the failed authentications of API requests are recorded under
+const requestAuthenticationLoginProvider = "api"
+
+func newAuthenticator(config configapi.MasterConfig, etcdHelper storage.Interface, tokenGetter serviceaccount.ServiceAccountTokenGetter, apiClientCAs *x509.CertPool, groupMapper identitymapper.UserToGroupMapper, accessTokenCache *authncache.CacheAuthenticator, loginLockout *lockout.Lockout, auditSink audit.Sink) authenticator.Request {
+	authenticators := map[string]authenticator.Request{}
 
 	// ServiceAccount token
 	if len(config.ServiceAccountConfig.PublicKeyFiles) > 0 {
@@ -296,32 +381,107 @@ func newAuthenticator(config configapi.MasterConfig, etcdHelper storage.Interfac
 			publicKeys = append(publicKeys, publicKey)
 		}
 		tokenAuthenticator := serviceaccount.JWTTokenAuthenticator(publicKeys, true, tokenGetter)
-		authenticators = append(authenticators, bearertoken.New(tokenAuthenticator, true))
+		authenticators[configapi.ServiceAccountTokenAuthenticator] = bearertoken.New(tokenAuthenticator, true)
 	}
 
 	// OAuth token
 	if config.OAuthConfig != nil {
 		tokenAuthenticator := getEtcdTokenAuthenticator(etcdHelper, groupMapper)
-		authenticators = append(authenticators, bearertoken.New(tokenAuthenticator, true))
+		if accessTokenCache != nil {
+			tokenAuthenticator = accessTokenCache
+		}
+		authenticators[configapi.OAuthTokenAuthenticator] = bearertoken.New(tokenAuthenticator, true)
 		// Allow token as access_token param for WebSockets
-		authenticators = append(authenticators, paramtoken.New("access_token", tokenAuthenticator, true))
+		authenticators[configapi.OAuthTokenParamAuthenticator] = paramtoken.New("access_token", tokenAuthenticator, true)
+	}
+
+	// bearer tokens reviewed by an external service
+	if config.RequestAuthenticationConfig.WebhookTokenConfig != nil {
+		tokenAuthenticator, err := newWebhookTokenAuthenticator(*config.RequestAuthenticationConfig.WebhookTokenConfig)
+		if err != nil {
+			glog.Fatalf("Error setting up the webhook token authenticator: %v", err)
+		}
+		authenticators[configapi.WebhookTokenAuthenticator] = bearertoken.New(tokenAuthenticator, true)
 	}
 
 	if configapi.UseTLS(config.ServingInfo.ServingInfo) {
-		// build cert authenticator
-		// TODO: add "system:" prefix in authenticator, limit cert to username
-		// TODO: add "system:" prefix to groups in authenticator, limit cert to group name
+		// build cert authenticators, mapping the common name of a certificate to the user and its organizations to groups
+		var userConversion x509request.UserConversion = x509request.SubjectToUserConversion
+		if config.ClientCertificateAuthConfig.RequireSystemPrefix {
+			userConversion = x509request.NewRestrictedUserConversion(userConversion, x509request.UserRestriction{UserPrefix: "system:", GroupPrefix: "system:"})
+		}
 		opts := x509request.DefaultVerifyOptions()
 		opts.Roots = apiClientCAs
-		certauth := x509request.New(opts, x509request.SubjectToUserConversion)
-		authenticators = append(authenticators, certauth)
+		// certificates signed by another CA are left to the other authenticators, like the delegated ones, since an
+		// error would end the authentication when FailOnError is set
+		authenticators[configapi.ClientCertificateAuthenticator] = x509request.IgnoreUnknownAuthority(x509request.New(opts, userConversion))
+
+		delegatedAuthenticators := []authenticator.Request{}
+		for _, delegated := range config.ClientCertificateAuthConfig.DelegatedCAs {
+			roots, err := cmdutil.CertPoolFromFile(delegated.CA)
+			if err != nil {
+				glog.Fatalf("Error reading delegated client CA %s: %v", delegated.CA, err)
+			}
+			opts := x509request.DefaultVerifyOptions()
+			opts.Roots = roots
+			restriction := x509request.UserRestriction{UserPrefix: delegated.UserPrefix, AllowedGroups: sets.NewString(delegated.AllowedGroups...)}
+			delegatedAuthenticators = append(delegatedAuthenticators, x509request.IgnoreUnknownAuthority(x509request.New(opts, x509request.NewRestrictedUserConversion(x509request.SubjectToUserConversion, restriction))))
+		}
+		if len(delegatedAuthenticators) > 0 {
+			authenticators[configapi.DelegatedClientCertificateAuthenticator] = unionrequest.NewUnionAuthentication(delegatedAuthenticators...)
+		}
+	}
+
+	// the authenticators are tried in the configured order, then in the default order, recording each of them
+	// under its name
+	credentialsAuthenticator := &unionrequest.Authenticator{FailOnError: config.RequestAuthenticationConfig.FailOnError}
+	for _, name := range append(config.RequestAuthenticationConfig.Order, configapi.RequestAuthenticators...) {
+		if a, ok := authenticators[name]; ok {
+			credentialsAuthenticator.Handlers = append(credentialsAuthenticator.Handlers, a)
+			credentialsAuthenticator.Names = append(credentialsAuthenticator.Names, name)
+			delete(authenticators, name)
+		}
+	}
+
+	// requests made without credentials fall back to the anonymous user, unless restricted by the config
+	var anonymousAuthenticator authenticator.Request
+	switch config.AnonymousConfig.Access {
+	case configapi.AnonymousAccessDiscoveryOnly:
+		anonymousAuthenticator = anonymous.NewPathAuthenticator(bootstrappolicy.StatusCheckerURLs)
+	case configapi.AnonymousAccessDeny:
+		// no path is open to the anonymous user
+		anonymousAuthenticator = anonymous.NewPathAuthenticator(nil)
+	default:
+		anonymousAuthenticator = anonymous.NewAuthenticator()
+	}
+	if config.AuditConfig.AnonymousRequests {
+		anonymousAuthenticator = anonymous.NewAuditingAuthenticator(anonymousAuthenticator, auditSink)
+	}
+
+	// requests with invalid credentials are audited, and the source IPs that keep sending them are refused, if
+	// configured
+	var requestAuthenticator authenticator.Request = credentialsAuthenticator
+	var throttle lockout.Throttle
+	sourceIP := lockout.SourceIP
+	if loginLockout != nil {
+		sourceIP = loginLockout.SourceIP
+		if config.OAuthConfig.LoginThrottle.RequestAuthentication {
+			throttle = loginLockout.ForRequests(requestAuthenticationLoginProvider)
+		}
+	}
+	var auditor lockout.Auditor
+	if config.AuditConfig.Enabled {
+		auditor = lockout.SinkAuditor{Sink: auditSink}
+	}
+	if throttle != nil || auditor != nil {
+		requestAuthenticator = lockoutrequest.New(credentialsAuthenticator, requestAuthenticationLoginProvider, throttle, auditor, sourceIP)
 	}
 
 	ret := &unionrequest.Authenticator{
 		FailOnError: true,
 		Handlers: []authenticator.Request{
-			group.NewGroupAdder(unionrequest.NewUnionAuthentication(authenticators...), []string{bootstrappolicy.AuthenticatedGroup}),
-			anonymous.NewAuthenticator(),
+			group.NewGroupAdder(requestAuthenticator, []string{bootstrappolicy.AuthenticatedGroup}),
+			anonymousAuthenticator,
 		},
 	}
 
@@ -348,9 +508,11 @@ func newReadOnlyCacheAndClient(etcdHelper storage.Interface) (cache policycache.
 	return
 }
 
-func newAuthorizer(policyClient policyclient.ReadOnlyPolicyClient, projectRequestDenyMessage string) authorizer.Authorizer {
+// newAuthorizer returns an authorizer that evaluates the policies and bindings of policyClient. Its decisions are cached
+// until policyClient reports a change, since identical requests are authorized many times per second.
+func newAuthorizer(policyClient policyclient.ReadOnlyPolicyClient, projectRequestDenyMessage string) (authorizer.Authorizer, error) {
 	authorizer := authorizer.NewAuthorizer(rulevalidation.NewDefaultRuleResolver(policyClient, policyClient, policyClient, policyClient), authorizer.NewForbiddenMessageResolver(projectRequestDenyMessage))
-	return authorizer
+	return authzcache.NewInvalidatingAuthorizer(authorizer, policyClient, authorizationCacheTTL, authorizationCacheSize)
 }
 
 func newAuthorizationAttributeBuilder(requestContextMapper kapi.RequestContextMapper) authorizer.AuthorizationAttributeBuilder {
@@ -368,6 +530,46 @@ func getEtcdTokenAuthenticator(etcdHelper storage.Interface, groupMapper identit
 	return authnregistry.NewTokenAuthenticator(accessTokenRegistry, userRegistry, groupMapper)
 }
 
+// newAccessTokenCache returns a cache of the results of authenticating access tokens against etcd, or nil if the
+// OAuth server is disabled or caching is not configured
+func newAccessTokenCache(options configapi.MasterConfig, etcdHelper storage.Interface, groupMapper identitymapper.UserToGroupMapper) (*authncache.CacheAuthenticator, error) {
+	if options.OAuthConfig == nil || len(options.OAuthConfig.TokenConfig.AccessTokenCacheTTL) == 0 {
+		return nil, nil
+	}
+	ttl, err := time.ParseDuration(options.OAuthConfig.TokenConfig.AccessTokenCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	if ttl == 0 {
+		return nil, nil
+	}
+	tokenAuthenticator, err := authncache.NewAuthenticator(getEtcdTokenAuthenticator(etcdHelper, groupMapper), ttl, options.OAuthConfig.TokenConfig.AccessTokenCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return tokenAuthenticator.(*authncache.CacheAuthenticator), nil
+}
+
+// newWebhookTokenAuthenticator returns an authenticator reviewing tokens with the service of config, caching the
+// reviews if a cache TTL is configured
+func newWebhookTokenAuthenticator(config configapi.WebhookTokenConfig) (authenticator.Token, error) {
+	tokenAuthenticator, err := webhooktoken.NewFromConfigFile(config.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.CacheTTL) == 0 {
+		return tokenAuthenticator, nil
+	}
+	ttl, err := time.ParseDuration(config.CacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	if ttl == 0 {
+		return tokenAuthenticator, nil
+	}
+	return authncache.NewAuthenticator(tokenAuthenticator, ttl, config.CacheSize)
+}
+
 // KubeClient returns the kubernetes client object
 func (c *MasterConfig) KubeClient() *kclient.Client {
 	return c.PrivilegedLoopbackKubernetesClient
@@ -429,17 +631,22 @@ func (c *MasterConfig) BuildControllerClients() (*osclient.Client, *kclient.Clie
 
 // BuildPodControllerClients returns the build pod controller client objects
 func (c *MasterConfig) BuildPodControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // BuildImageChangeTriggerControllerClients returns the build image change trigger controller client objects
 func (c *MasterConfig) BuildImageChangeTriggerControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // BuildConfigChangeControllerClients returns the build config change controller client objects
 func (c *MasterConfig) BuildConfigChangeControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
+}
+
+// BuildScheduleControllerClients returns the build schedule controller client objects
+func (c *MasterConfig) BuildScheduleControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // ImageChangeControllerClient returns the openshift client object
@@ -447,9 +654,14 @@ func (c *MasterConfig) ImageChangeControllerClient() *osclient.Client {
 	return c.PrivilegedLoopbackOpenShiftClient
 }
 
-// ImageImportControllerClient returns the deployment client object
-func (c *MasterConfig) ImageImportControllerClient() *osclient.Client {
-	return c.PrivilegedLoopbackOpenShiftClient
+// ImageImportControllerClients returns the image import controller client objects
+func (c *MasterConfig) ImageImportControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
+}
+
+// ImageTriggerControllerClients returns the image trigger controller client objects
+func (c *MasterConfig) ImageTriggerControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // DeploymentConfigScaleClient returns the client used by the Scale subresource registry
@@ -468,7 +680,7 @@ func (c *MasterConfig) DeploymentControllerClients() (*osclient.Client, *kclient
 
 // DeployerPodControllerClients returns the deployer pod controller client objects
 func (c *MasterConfig) DeployerPodControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // DeploymentConfigClients returns deploymentConfig and deployment client objects
@@ -478,17 +690,17 @@ func (c *MasterConfig) DeploymentConfigClients() (*osclient.Client, *kclient.Cli
 
 // DeploymentConfigControllerClients returns the deploymentConfig controller client objects
 func (c *MasterConfig) DeploymentConfigControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // DeploymentConfigChangeControllerClients returns the deploymentConfig config change controller client objects
 func (c *MasterConfig) DeploymentConfigChangeControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // DeploymentImageChangeTriggerControllerClient returns the deploymentConfig image change controller client object
 func (c *MasterConfig) DeploymentImageChangeTriggerControllerClient() *osclient.Client {
-	return c.PrivilegedLoopbackOpenShiftClient
+	return c.ControllerOpenShiftClient
 }
 
 // DeploymentLogClient returns the deployment log client object
@@ -498,12 +710,27 @@ func (c *MasterConfig) DeploymentLogClient() *kclient.Client {
 
 // SecurityAllocationControllerClient returns the security allocation controller client object
 func (c *MasterConfig) SecurityAllocationControllerClient() *kclient.Client {
-	return c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerKubernetesClient
+}
+
+// ServiceAccountControllerClient returns the client object of the service account controllers
+func (c *MasterConfig) ServiceAccountControllerClient() *kclient.Client {
+	return c.ControllerKubernetesClient
+}
+
+// KubernetesControllerClient returns the client object of the Kubernetes controllers
+func (c *MasterConfig) KubernetesControllerClient() *kclient.Client {
+	return c.ControllerKubernetesClient
 }
 
 // SDNControllerClients returns the SDN controller client objects
 func (c *MasterConfig) SDNControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
+}
+
+// CertificateSigningControllerClient returns the certificate signing controller client object
+func (c *MasterConfig) CertificateSigningControllerClient() *osclient.Client {
+	return c.ControllerOpenShiftClient
 }
 
 // RouteAllocatorClients returns the route allocator client objects
@@ -520,7 +747,21 @@ func (c *MasterConfig) WebConsoleEnabled() bool {
 // The openshift client object must have authority to delete openshift content in any namespace
 // The kubernetes client object must have authority to execute a finalize request on a namespace
 func (c *MasterConfig) OriginNamespaceControllerClients() (*osclient.Client, *kclient.Client) {
-	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
+}
+
+// ResourceQuotaControllerClients returns a client for openshift and kubernetes.
+// The openshift client object must have authority to list the quota limited content in any namespace
+// The kubernetes client object must have authority to update the status of any resource quota
+func (c *MasterConfig) ResourceQuotaControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
+}
+
+// NotificationControllerClients returns a client for openshift and kubernetes.
+// The openshift client object must have authority to watch builds in any namespace
+// The kubernetes client object must have authority to watch replication controllers in any namespace
+func (c *MasterConfig) NotificationControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.ControllerOpenShiftClient, c.ControllerKubernetesClient
 }
 
 // NewEtcdHelper returns an EtcdHelper for the provided storage version.
@@ -533,13 +774,13 @@ func NewEtcdStorage(client *etcdclient.Client, version, prefix string) (oshelper
 }
 
 // GetServiceAccountClients returns an OpenShift and Kubernetes client with the credentials of the
-// named service account in the infra namespace
+// named service account in the infra namespace. They are controller clients, built from ControllerClientConfig.
 func (c *MasterConfig) GetServiceAccountClients(name string) (*osclient.Client, *kclient.Client, error) {
 	if len(name) == 0 {
 		return nil, nil, errors.New("No service account name specified")
 	}
 	return serviceaccounts.Clients(
-		c.PrivilegedLoopbackClientConfig,
+		c.ControllerClientConfig,
 		&serviceaccounts.ClientLookupTokenRetriever{Client: c.PrivilegedLoopbackKubernetesClient},
 		c.Options.PolicyConfig.OpenShiftInfrastructureNamespace,
 		name,
@@ -12,6 +12,7 @@ import (
 
 	"github.com/openshift/origin/pkg/api/validation"
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 )
 
 // TestValidationRegistration makes sure that any RESTStorage that allows create or update has the correct validation register.
@@ -63,6 +64,39 @@ func TestAllOpenShiftResourceCoverage(t *testing.T) {
 	}
 }
 
+// TestKnownOpenShiftAPIResourceCoverage checks that every served resource can be disabled through the master config
+func TestKnownOpenShiftAPIResourceCoverage(t *testing.T) {
+	known := sets.NewString(configapi.KnownOpenShiftAPIResources...)
+
+	config := fakeMasterConfig()
+
+	storageMap := config.GetRestStorage()
+	for key := range storageMap {
+		resource := strings.SplitN(strings.ToLower(key), "/", 2)[0]
+		if known.Has(resource) {
+			continue
+		}
+
+		t.Errorf("configapi.KnownOpenShiftAPIResources is missing %v.  Check pkg/cmd/server/api/types.go.", resource)
+	}
+}
+
+// TestDisabledAPIResources checks that disabled resources and their subresources are not served
+func TestDisabledAPIResources(t *testing.T) {
+	config := fakeMasterConfig()
+	config.Options.DisabledAPIResources = []string{"templates", "deploymentConfigs"}
+
+	storageMap := config.GetRestStorage()
+	for _, key := range []string{"templates", "deploymentConfigs", "deploymentConfigs/log", "deploymentConfigs/scale"} {
+		if _, ok := storageMap[key]; ok {
+			t.Errorf("expected %s to be disabled", key)
+		}
+	}
+	if _, ok := storageMap["processedTemplates"]; !ok {
+		t.Errorf("expected processedTemplates to be served")
+	}
+}
+
 // fakeMasterConfig creates a new fake master config with an empty kubelet config and dummy storage.
 func fakeMasterConfig() *MasterConfig {
 	return &MasterConfig{
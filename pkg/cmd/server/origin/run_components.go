@@ -17,9 +17,11 @@ import (
 	serviceaccountadmission "k8s.io/kubernetes/plugin/pkg/admission/serviceaccount"
 
 	"github.com/openshift/origin/pkg/api/latest"
+	authnregistry "github.com/openshift/origin/pkg/auth/oauth/registry"
 	buildclient "github.com/openshift/origin/pkg/build/client"
 	buildcontrollerfactory "github.com/openshift/origin/pkg/build/controller/factory"
 	buildstrategy "github.com/openshift/origin/pkg/build/controller/strategy"
+	certificatecontroller "github.com/openshift/origin/pkg/certificates/controller"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	configchangecontroller "github.com/openshift/origin/pkg/deploy/controller/configchange"
@@ -29,7 +31,11 @@ import (
 	imagechangecontroller "github.com/openshift/origin/pkg/deploy/controller/imagechange"
 	"github.com/openshift/origin/pkg/dns"
 	imagecontroller "github.com/openshift/origin/pkg/image/controller"
+	imagetriggercontroller "github.com/openshift/origin/pkg/image/controller/trigger"
+	"github.com/openshift/origin/pkg/notification"
 	projectcontroller "github.com/openshift/origin/pkg/project/controller"
+	quotacontroller "github.com/openshift/origin/pkg/quota/controller"
+	quotaevaluator "github.com/openshift/origin/pkg/quota/evaluator"
 	securitycontroller "github.com/openshift/origin/pkg/security/controller"
 	"github.com/openshift/origin/pkg/security/mcs"
 	"github.com/openshift/origin/pkg/security/uid"
@@ -38,6 +44,7 @@ import (
 	"github.com/openshift/openshift-sdn/plugins/osdn/factory"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	"github.com/openshift/origin/pkg/cmd/server/crypto"
 	serviceaccountcontrollers "github.com/openshift/origin/pkg/serviceaccounts/controllers"
 )
 
@@ -56,7 +63,36 @@ func (c *MasterConfig) RunOriginNamespaceController() {
 		KubeClient: kclient,
 	}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
+}
+
+// RunResourceQuotaController starts the controller that records the usage of the openshift content limited by
+// resource quotas
+func (c *MasterConfig) RunResourceQuotaController() {
+	osclient, kclient := c.ResourceQuotaControllerClients()
+	// TODO: share the sync period of the kubernetes resource quota controller
+	period := 10 * time.Second
+	quotacontroller.NewResourceQuotaController(kclient, quotaevaluator.NewEvaluators(osclient)).Run(period)
+}
+
+// RunNotificationController starts the controller that posts the completion and failure of builds and deployments
+// to the configured webhooks
+func (c *MasterConfig) RunNotificationController() {
+	sinks := []notification.Sink{}
+	for _, webhook := range c.Options.NotificationConfig.Webhooks {
+		sink, err := notification.NewWebhookSink(webhook)
+		if err != nil {
+			glog.Fatalf("Unable to start the notification controller: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	osclient, kclient := c.NotificationControllerClients()
+	options := notification.NotificationControllerOptions{
+		Resync:      5 * time.Minute,
+		Builds:      configapi.IsBuildEnabled(&c.Options),
+		Deployments: configapi.IsDeploymentEnabled(&c.Options),
+	}
+	notification.NewNotificationController(osclient, kclient, sinks, options).Run()
 }
 
 // RunServiceAccountsController starts the service account controller
@@ -75,7 +111,7 @@ func (c *MasterConfig) RunServiceAccountsController() {
 		options.ServiceAccounts = append(options.ServiceAccounts, sa)
 	}
 
-	serviceaccount.NewServiceAccountsController(c.KubeClient(), options).Run()
+	serviceaccount.NewServiceAccountsController(c.ServiceAccountControllerClient(), options).Run()
 }
 
 // RunServiceAccountTokensController starts the service account token controller
@@ -105,15 +141,16 @@ func (c *MasterConfig) RunServiceAccountTokensController() {
 		RootCA:         rootCA,
 	}
 
-	serviceaccount.NewTokensController(c.KubeClient(), options).Run()
+	serviceaccount.NewTokensController(c.ServiceAccountControllerClient(), options).Run()
 }
 
 // RunServiceAccountPullSecretsControllers starts the service account pull secret controllers
 func (c *MasterConfig) RunServiceAccountPullSecretsControllers() {
-	serviceaccountcontrollers.NewDockercfgDeletedController(c.KubeClient(), serviceaccountcontrollers.DockercfgDeletedControllerOptions{}).Run()
-	serviceaccountcontrollers.NewDockercfgTokenDeletedController(c.KubeClient(), serviceaccountcontrollers.DockercfgTokenDeletedControllerOptions{}).Run()
+	serviceaccountcontrollers.NewDockercfgDeletedController(c.ServiceAccountControllerClient(), serviceaccountcontrollers.DockercfgDeletedControllerOptions{}).Run()
+	serviceaccountcontrollers.NewDockercfgTokenDeletedController(c.ServiceAccountControllerClient(), serviceaccountcontrollers.DockercfgTokenDeletedControllerOptions{}).Run()
+	serviceaccountcontrollers.NewDockercfgTokenRotatedController(c.ServiceAccountControllerClient(), serviceaccountcontrollers.DockercfgTokenRotatedControllerOptions{}).Run()
 
-	dockercfgController := serviceaccountcontrollers.NewDockercfgController(c.KubeClient(), serviceaccountcontrollers.DockercfgControllerOptions{DefaultDockerURL: serviceaccountcontrollers.DefaultOpenshiftDockerURL})
+	dockercfgController := serviceaccountcontrollers.NewDockercfgController(c.ServiceAccountControllerClient(), serviceaccountcontrollers.DockercfgControllerOptions{DefaultDockerURL: serviceaccountcontrollers.DefaultOpenshiftDockerURL})
 	dockercfgController.Run()
 
 	dockerRegistryControllerOptions := serviceaccountcontrollers.DockerRegistryServiceControllerOptions{
@@ -122,7 +159,7 @@ func (c *MasterConfig) RunServiceAccountPullSecretsControllers() {
 		DockercfgController: dockercfgController,
 		DefaultDockerURL:    serviceaccountcontrollers.DefaultOpenshiftDockerURL,
 	}
-	serviceaccountcontrollers.NewDockerRegistryServiceController(c.KubeClient(), dockerRegistryControllerOptions).Run()
+	serviceaccountcontrollers.NewDockerRegistryServiceController(c.ServiceAccountControllerClient(), dockerRegistryControllerOptions).Run()
 }
 
 // RunPolicyCache starts the policy cache
@@ -196,10 +233,14 @@ func (c *MasterConfig) RunBuildController() {
 	admissionControl := admission.NewFromPlugins(c.PrivilegedLoopbackKubernetesClient, []string{"SecurityContextConstraint"}, "")
 
 	osclient, kclient := c.BuildControllerClients()
+	buildClient := buildclient.NewOSClientBuildClient(osclient)
 	factory := buildcontrollerfactory.BuildControllerFactory{
-		OSClient:     osclient,
-		KubeClient:   kclient,
-		BuildUpdater: buildclient.NewOSClientBuildClient(osclient),
+		OSClient:       osclient,
+		KubeClient:     kclient,
+		BuildUpdater:   buildClient,
+		BuildGetter:    buildClient,
+		BuildDeleter:   buildClient,
+		BuildFinalizer: buildClient,
 		DockerBuildStrategy: &buildstrategy.DockerBuildStrategy{
 			Image: dockerImage,
 			// TODO: this will be set to --storage-version (the internal schema we use)
@@ -216,42 +257,64 @@ func (c *MasterConfig) RunBuildController() {
 			// TODO: this will be set to --storage-version (the internal schema we use)
 			Codec: interfaces.Codec,
 		},
+		LoggingAnnotationPrefix: c.loggingAnnotationPrefix(),
+		Stop:                    c.ControllerPlug.Stopped(),
 	}
 
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 	deleteController := factory.CreateDeleteController()
-	deleteController.Run()
+	deleteController.RunUntil(c.ControllerPlug.Stopped())
+}
+
+// loggingAnnotationPrefix returns the prefix of the annotations added to build and deployer pods for aggregated
+// logging, or an empty string if they are not annotated
+func (c *MasterConfig) loggingAnnotationPrefix() string {
+	if c.Options.PodLoggingConfig == nil {
+		return ""
+	}
+	return c.Options.PodLoggingConfig.AnnotationPrefix
 }
 
 // RunBuildPodController starts the build/pod status sync loop for build status
 func (c *MasterConfig) RunBuildPodController() {
 	osclient, kclient := c.BuildPodControllerClients()
+	buildClient := buildclient.NewOSClientBuildClient(osclient)
 	factory := buildcontrollerfactory.BuildPodControllerFactory{
 		OSClient:     osclient,
 		KubeClient:   kclient,
-		BuildUpdater: buildclient.NewOSClientBuildClient(osclient),
+		BuildUpdater: buildClient,
+		BuildGetter:  buildClient,
+		Stop:         c.ControllerPlug.Stopped(),
 	}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 	deletecontroller := factory.CreateDeleteController()
-	deletecontroller.Run()
+	deletecontroller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunBuildImageChangeTriggerController starts the build image change trigger controller process.
 func (c *MasterConfig) RunBuildImageChangeTriggerController() {
 	bcClient, _ := c.BuildImageChangeTriggerControllerClients()
 	bcInstantiator := buildclient.NewOSClientBuildConfigInstantiatorClient(bcClient)
-	factory := buildcontrollerfactory.ImageChangeControllerFactory{Client: bcClient, BuildConfigInstantiator: bcInstantiator}
-	factory.Create().Run()
+	factory := buildcontrollerfactory.ImageChangeControllerFactory{Client: bcClient, BuildConfigInstantiator: bcInstantiator, Stop: c.ControllerPlug.Stopped()}
+	factory.Create().RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunBuildConfigChangeController starts the build config change trigger controller process.
 func (c *MasterConfig) RunBuildConfigChangeController() {
 	bcClient, _ := c.BuildConfigChangeControllerClients()
 	bcInstantiator := buildclient.NewOSClientBuildConfigInstantiatorClient(bcClient)
-	factory := buildcontrollerfactory.BuildConfigControllerFactory{Client: bcClient, BuildConfigInstantiator: bcInstantiator}
-	factory.Create().Run()
+	factory := buildcontrollerfactory.BuildConfigControllerFactory{Client: bcClient, BuildConfigInstantiator: bcInstantiator, Stop: c.ControllerPlug.Stopped()}
+	factory.Create().RunUntil(c.ControllerPlug.Stopped())
+}
+
+// RunBuildScheduleController starts the build schedule trigger controller process.
+func (c *MasterConfig) RunBuildScheduleController() {
+	bcClient, _ := c.BuildScheduleControllerClients()
+	bcInstantiator := buildclient.NewOSClientBuildConfigInstantiatorClient(bcClient)
+	factory := buildcontrollerfactory.ScheduleControllerFactory{Client: bcClient, BuildConfigInstantiator: bcInstantiator, Stop: c.ControllerPlug.Stopped()}
+	factory.Create().RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunDeploymentController starts the deployment controller process.
@@ -270,16 +333,20 @@ func (c *MasterConfig) RunDeploymentController() {
 		path.Join(serviceaccountadmission.DefaultAPITokenMountPath, kapi.ServiceAccountTokenKey),
 	)
 
+	deployerImage := c.ImageFor("deployer")
+
 	factory := deploycontroller.DeploymentControllerFactory{
 		KubeClient:     kclient,
 		Codec:          c.EtcdHelper.Codec(),
 		Environment:    env,
-		DeployerImage:  c.ImageFor("deployer"),
+		DeployerImage:  deployerImage,
 		ServiceAccount: bootstrappolicy.DeployerServiceAccountName,
+
+		LoggingAnnotationPrefix: c.loggingAnnotationPrefix(),
 	}
 
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunDeployerPodController starts the deployer pod controller process.
@@ -290,7 +357,7 @@ func (c *MasterConfig) RunDeployerPodController() {
 	}
 
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunDeploymentConfigController starts the deployment config controller process.
@@ -302,7 +369,7 @@ func (c *MasterConfig) RunDeploymentConfigController() {
 		Codec:      c.EtcdHelper.Codec(),
 	}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunDeploymentConfigChangeController starts the deployment config change controller process.
@@ -314,7 +381,7 @@ func (c *MasterConfig) RunDeploymentConfigChangeController() {
 		Codec:      c.EtcdHelper.Codec(),
 	}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunDeploymentImageChangeTriggerController starts the image change trigger controller process.
@@ -322,7 +389,7 @@ func (c *MasterConfig) RunDeploymentImageChangeTriggerController() {
 	osclient := c.DeploymentImageChangeTriggerControllerClient()
 	factory := imagechangecontroller.ImageChangeControllerFactory{Client: osclient}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunSDNController runs openshift-sdn if the said network plugin is provided
@@ -343,12 +410,25 @@ func (c *MasterConfig) RunSDNController() {
 
 // RunImageImportController starts the image import trigger controller process.
 func (c *MasterConfig) RunImageImportController() {
-	osclient := c.ImageImportControllerClient()
+	osclient, kclient := c.ImageImportControllerClients()
 	factory := imagecontroller.ImportControllerFactory{
-		Client: osclient,
+		Client:     osclient,
+		KubeClient: kclient,
+	}
+	controller := factory.Create()
+	controller.RunUntil(c.ControllerPlug.Stopped())
+}
+
+// RunImageTriggerController starts the image trigger controller process, which updates the images of
+// replication controllers with a trigger annotation.
+func (c *MasterConfig) RunImageTriggerController() {
+	osclient, kclient := c.ImageTriggerControllerClients()
+	factory := imagetriggercontroller.ImageTriggerControllerFactory{
+		Client:     osclient,
+		KubeClient: kclient,
 	}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
 }
 
 // RunSecurityAllocationController starts the security allocation controller process.
@@ -399,10 +479,36 @@ func (c *MasterConfig) RunSecurityAllocationController() {
 		// TODO: reuse namespace cache
 	}
 	controller := factory.Create()
-	controller.Run()
+	controller.RunUntil(c.ControllerPlug.Stopped())
+}
+
+// RunCertificateSigningController starts the controller that approves node certificate renewals and signs approved
+// certificate requests.
+func (c *MasterConfig) RunCertificateSigningController() {
+	signing := c.Options.CertificateSigningConfig
+	if signing == nil {
+		glog.V(3).Infof("Certificate signing is disabled - certificate requests from nodes will not be signed")
+		return
+	}
+
+	ca, err := crypto.GetCA(signing.CertFile, signing.KeyFile, signing.SerialFile)
+	if err != nil {
+		glog.Fatalf("Unable to load the certificate signing CA: %v", err)
+	}
+
+	options := certificatecontroller.CertificateSigningControllerOptions{Resync: 5 * time.Minute}
+	certificatecontroller.NewCertificateSigningController(c.CertificateSigningControllerClient(), ca, options).Run()
 }
 
 // RunGroupCache starts the group cache
 func (c *MasterConfig) RunGroupCache() {
 	c.GroupCache.Run()
 }
+
+// RunAccessTokenCacheInvalidation removes deleted and expired access tokens from the access token cache
+func (c *MasterConfig) RunAccessTokenCacheInvalidation() {
+	if c.AccessTokenCache == nil {
+		return
+	}
+	go authnregistry.InvalidateDeletedTokens(c.EtcdHelper, c.AccessTokenCache, util.NeverStop)
+}
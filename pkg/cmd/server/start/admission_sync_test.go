@@ -23,6 +23,8 @@ var admissionPluginsNotUsedByKube = sets.NewString(
 
 	"BuildByStrategy",          // from origin, only needed for managing builds, not kubernetes resources
 	"OriginNamespaceLifecycle", // from origin, only needed for rejecting openshift resources, so not needed by kube
+	"OriginNodeRestriction",    // from origin, only added when policyConfig.restrictNodeAccess is set
+	"OriginResourceQuota",      // from origin, only needed for limiting openshift resources, so not needed by kube
 
 	"NamespaceExists",  // superceded by NamespaceLifecycle
 	"InitialResources", // do we want this? https://github.com/kubernetes/kubernetes/blob/master/docs/proposals/initial-resources.md
@@ -0,0 +1,149 @@
+package start
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclientcmd "k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	"github.com/openshift/origin/pkg/bootstrap"
+	"github.com/openshift/origin/pkg/cmd/admin/registry"
+	"github.com/openshift/origin/pkg/cmd/admin/router"
+	"github.com/openshift/origin/pkg/cmd/server/admin"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	"github.com/openshift/origin/pkg/version"
+)
+
+const (
+	// DevProfile starts an all-in-one server with a registry, a router, and the default image streams and
+	// templates installed
+	DevProfile = "dev"
+
+	// devRouterServiceAccount is the service account running the router of the dev profile
+	devRouterServiceAccount = "router"
+	// devProfileTimeout is how long the components of the dev profile wait for the master to be ready
+	devProfileTimeout = 2 * time.Minute
+)
+
+// installDevProfile installs the registry, the router, and the default image streams and templates of the dev
+// profile once the master is ready, then prints how to log in. Components which already exist are left untouched.
+func (o AllInOneOptions) installDevProfile() error {
+	out := o.MasterOptions.Output
+	masterConfigDir := o.MasterOptions.MasterArgs.ConfigDir.Value()
+	adminKubeConfig := admin.DefaultKubeConfigFilename(masterConfigDir, "admin")
+
+	f := clientcmd.NewFactory(kclientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&kclientcmd.ClientConfigLoadingRules{ExplicitPath: adminKubeConfig},
+		&kclientcmd.ConfigOverrides{},
+	))
+	_, kClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+
+	// the shared namespace is created once the master has bootstrapped its policy
+	err = wait.Poll(time.Second, devProfileTimeout, func() (bool, error) {
+		for _, namespace := range []string{kapi.NamespaceDefault, bootstrappolicy.DefaultOpenShiftSharedResourcesNamespace} {
+			if _, err := kClient.Namespaces().Get(namespace); err != nil {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("the master was not ready after %v", devProfileTimeout)
+	}
+
+	imageTemplate := o.MasterOptions.MasterArgs.ImageFormatArgs.ImageTemplate
+
+	registryConfig := registry.NewRegistryConfig()
+	registryConfig.ImageTemplate = imageTemplate
+	registryConfig.Credentials = admin.DefaultKubeConfigFilename(masterConfigDir, bootstrappolicy.RegistryUnqualifiedUsername)
+	if err := registry.RunCmdRegistry(f, registry.NewCmdRegistry(f, "openshift admin", "registry", out), out, registryConfig, nil); err != nil {
+		return fmt.Errorf("unable to install the registry: %v", err)
+	}
+
+	// the router binds the ports of the host, which requires the privileged security context constraints
+	if err := ensurePrivilegedServiceAccount(f, kapi.NamespaceDefault, devRouterServiceAccount); err != nil {
+		return fmt.Errorf("unable to create the service account of the router: %v", err)
+	}
+	routerConfig := router.NewRouterConfig()
+	routerConfig.ImageTemplate = imageTemplate
+	routerConfig.Credentials = admin.DefaultKubeConfigFilename(masterConfigDir, bootstrappolicy.RouterUnqualifiedUsername)
+	routerConfig.ServiceAccount = devRouterServiceAccount
+	if err := router.RunCmdRouter(f, router.NewCmdRouter(f, "openshift admin", "router", out), out, routerConfig, nil); err != nil {
+		return fmt.Errorf("unable to install the router: %v", err)
+	}
+
+	if err := installBootstrapContent(f, bootstrappolicy.DefaultOpenShiftSharedResourcesNamespace, out); err != nil {
+		return fmt.Errorf("unable to install the default image streams and templates: %v", err)
+	}
+
+	masterPublicAddr, err := o.MasterOptions.MasterArgs.GetMasterPublicAddress()
+	if err != nil {
+		return err
+	}
+	assetPublicAddr, err := o.MasterOptions.MasterArgs.GetAssetPublicAddress()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, `
+The server is ready for development. Log in as any user with any password:
+
+    oc login %s
+
+The web console is available at %s
+
+To administer the server, use the credentials of system:admin in:
+
+    %s
+`, masterPublicAddr.String(), assetPublicAddr.String(), adminKubeConfig)
+	return nil
+}
+
+// ensurePrivilegedServiceAccount creates the service account name in namespace, if needed, and lets it use the
+// privileged security context constraints
+func ensurePrivilegedServiceAccount(f *clientcmd.Factory, namespace, name string) error {
+	_, kClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	if _, err := kClient.ServiceAccounts(namespace).Create(&kapi.ServiceAccount{ObjectMeta: kapi.ObjectMeta{Name: name}}); err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	scc, err := kClient.SecurityContextConstraints().Get(bootstrappolicy.SecurityContextConstraintPrivileged)
+	if err != nil {
+		return err
+	}
+	user := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+	for _, existing := range scc.Users {
+		if existing == user {
+			return nil
+		}
+	}
+	scc.Users = append(scc.Users, user)
+	_, err = kClient.SecurityContextConstraints().Update(scc)
+	return err
+}
+
+// installBootstrapContent installs the image streams and templates embedded in the bootstrap package in namespace.
+func installBootstrapContent(f *clientcmd.Factory, namespace string, out io.Writer) error {
+	oClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	result, err := bootstrap.Install(oClient, namespace, version.Get().String())
+	if err != nil {
+		return err
+	}
+	if changed := result.Created + result.Updated; changed > 0 {
+		fmt.Fprintf(out, "Installed %d image streams and templates in the %s project\n", changed, namespace)
+	}
+	return nil
+}
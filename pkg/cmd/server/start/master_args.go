@@ -225,6 +225,7 @@ func (args MasterArgs) BuildSerializeableMasterConfig() (*configapi.MasterConfig
 		PolicyConfig: configapi.PolicyConfig{
 			BootstrapPolicyFile:               args.GetPolicyFile(),
 			OpenShiftSharedResourcesNamespace: bootstrappolicy.DefaultOpenShiftSharedResourcesNamespace,
+			BootstrapSharedResources:          true,
 		},
 
 		ImageConfig: configapi.ImageConfig{
@@ -265,6 +266,13 @@ func (args MasterArgs) BuildSerializeableMasterConfig() (*configapi.MasterConfig
 			config.KubeletClientInfo.CA = admin.DefaultRootCAFile(args.ConfigDir.Value())
 			config.KubeletClientInfo.ClientCert = kubeletClientInfo.CertLocation
 			config.ServiceAccountConfig.MasterCA = admin.DefaultRootCAFile(args.ConfigDir.Value())
+
+			// Node certificates are signed by the same CA the master uses to verify kubelets and clients
+			config.CertificateSigningConfig = &configapi.CertificateSigningConfig{
+				CertFile:   admin.DefaultCertFilename(args.ConfigDir.Value(), admin.CAFilePrefix),
+				KeyFile:    admin.DefaultKeyFilename(args.ConfigDir.Value(), admin.CAFilePrefix),
+				SerialFile: admin.DefaultSerialFilename(args.ConfigDir.Value(), admin.CAFilePrefix),
+			}
 		}
 
 		// Only set up ca/cert info for etcd connections if we're self-hosting etcd
@@ -0,0 +1,252 @@
+package start
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/auth/authenticator/request/x509request"
+	certapi "github.com/openshift/origin/pkg/certificates/api"
+	"github.com/openshift/origin/pkg/certificates/bootstrap"
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/server/admin"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	cmdcrypto "github.com/openshift/origin/pkg/cmd/server/crypto"
+)
+
+// nodeCertificateRequest is a certificate requested by the node that has not been issued yet
+type nodeCertificateRequest struct {
+	name     string
+	key      crypto.PrivateKey
+	certInfo configapi.CertInfo
+}
+
+// bootstrapNodeCertificates requests the client certificate of a node without a MasterKubeConfig, and the serving
+// certificate of a node without one, using the bootstrap credentials of the node. Both requests must be approved by
+// a cluster administrator before the node can start.
+func bootstrapNodeCertificates(nodeConfig configapi.NodeConfig) error {
+	if len(nodeConfig.BootstrapKubeConfig) == 0 {
+		return nil
+	}
+	_, err := os.Stat(nodeConfig.MasterKubeConfig)
+	needsClient := os.IsNotExist(err)
+	needsServing := false
+	if certFile := nodeConfig.ServingInfo.ServerCert.CertFile; len(certFile) > 0 {
+		_, err := os.Stat(certFile)
+		needsServing = os.IsNotExist(err)
+	}
+	if !needsClient && !needsServing {
+		return nil
+	}
+
+	osClient, clientConfig, err := configapi.GetOpenShiftClient(nodeConfig.BootstrapKubeConfig)
+	if err != nil {
+		return err
+	}
+	glog.Infof("Requesting the certificates of node %s from %s", nodeConfig.NodeName, clientConfig.Host)
+
+	requests := []nodeCertificateRequest{}
+	if needsClient {
+		request, err := submitNodeCertificateRequest(osClient, nodeConfig.NodeName, certapi.CertificateUsageClient, nil, nodeClientCertInfo(nodeConfig))
+		if err != nil {
+			return err
+		}
+		requests = append(requests, request)
+	}
+	if needsServing {
+		hostnames := sets.NewString(nodeConfig.NodeName)
+		if len(nodeConfig.NodeIP) > 0 {
+			hostnames.Insert(nodeConfig.NodeIP)
+		}
+		request, err := submitNodeCertificateRequest(osClient, nodeConfig.NodeName, certapi.CertificateUsageServing, hostnames.List(), nodeConfig.ServingInfo.ServerCert)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, request)
+	}
+
+	if err := waitForNodeCertificates(osClient, requests); err != nil {
+		return err
+	}
+	if needsClient {
+		return writeNodeKubeConfig(nodeConfig.MasterKubeConfig, clientConfig)
+	}
+	return nil
+}
+
+// startNodeCertificateRotation renews the client and serving certificates of a node with bootstrap credentials once
+// most of their lifetime has passed. The node exits after renewing them so that it is restarted with the new
+// certificates.
+func startNodeCertificateRotation(nodeConfig configapi.NodeConfig) {
+	if len(nodeConfig.BootstrapKubeConfig) == 0 {
+		return
+	}
+	go func() {
+		for {
+			err := rotateNodeCertificates(nodeConfig)
+			if err == nil {
+				glog.Infof("Renewed the certificates of node %s, exiting to restart with them", nodeConfig.NodeName)
+				glog.Flush()
+				os.Exit(0)
+			}
+			util.HandleError(fmt.Errorf("unable to renew the certificates of node %s: %v", nodeConfig.NodeName, err))
+			time.Sleep(time.Minute)
+		}
+	}()
+}
+
+// rotateNodeCertificates waits until the client or serving certificate of the node is due for renewal, and renews
+// every certificate that is due using the credentials of the node.
+func rotateNodeCertificates(nodeConfig configapi.NodeConfig) error {
+	osClient, clientConfig, err := configapi.GetOpenShiftClient(nodeConfig.MasterKubeConfig)
+	if err != nil {
+		return err
+	}
+	certData := clientConfig.CertData
+	if len(certData) == 0 {
+		if certData, err = ioutil.ReadFile(clientConfig.CertFile); err != nil {
+			return err
+		}
+	}
+	clientCerts, err := cmdcrypto.CertsFromPEM(certData)
+	if err != nil {
+		return err
+	}
+	renewAt := bootstrap.RenewalTime(clientCerts[0])
+
+	var servingCert *x509.Certificate
+	if certFile := nodeConfig.ServingInfo.ServerCert.CertFile; len(certFile) > 0 {
+		servingData, err := ioutil.ReadFile(certFile)
+		if err != nil {
+			return err
+		}
+		servingCerts, err := cmdcrypto.CertsFromPEM(servingData)
+		if err != nil {
+			return err
+		}
+		servingCert = servingCerts[0]
+		if servingRenewAt := bootstrap.RenewalTime(servingCert); servingRenewAt.Before(renewAt) {
+			renewAt = servingRenewAt
+		}
+	}
+
+	glog.V(2).Infof("The certificates of node %s will be renewed at %s", nodeConfig.NodeName, renewAt)
+	time.Sleep(renewAt.Sub(time.Now()))
+
+	now := time.Now()
+	requests := []nodeCertificateRequest{}
+	renewClient := !bootstrap.RenewalTime(clientCerts[0]).After(now)
+	if renewClient {
+		request, err := submitNodeCertificateRequest(osClient, nodeConfig.NodeName, certapi.CertificateUsageClient, nil, nodeClientCertInfo(nodeConfig))
+		if err != nil {
+			return err
+		}
+		requests = append(requests, request)
+	}
+	if servingCert != nil && !bootstrap.RenewalTime(servingCert).After(now) {
+		hostnames := sets.NewString(servingCert.DNSNames...)
+		for _, ip := range servingCert.IPAddresses {
+			hostnames.Insert(ip.String())
+		}
+		request, err := submitNodeCertificateRequest(osClient, nodeConfig.NodeName, certapi.CertificateUsageServing, hostnames.List(), nodeConfig.ServingInfo.ServerCert)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, request)
+	}
+
+	if err := waitForNodeCertificates(osClient, requests); err != nil {
+		return err
+	}
+	if renewClient {
+		return writeNodeKubeConfig(nodeConfig.MasterKubeConfig, clientConfig)
+	}
+	return nil
+}
+
+// submitNodeCertificateRequest generates a new key for the node and requests a certificate for it that will be
+// written to certInfo
+func submitNodeCertificateRequest(client osclient.CertificateSigningRequestsInterface, nodeName string, usage certapi.CertificateUsage, hostnames []string, certInfo configapi.CertInfo) (nodeCertificateRequest, error) {
+	subject := x509request.UserToSubject(&user.DefaultInfo{
+		Name:   bootstrappolicy.NodeUsernamePrefix + nodeName,
+		Groups: []string{bootstrappolicy.NodesGroup},
+	})
+	if usage == certapi.CertificateUsageServing {
+		subject = pkix.Name{CommonName: subject.CommonName}
+	}
+	requestData, key, err := cmdcrypto.NewCertificateRequest(subject, hostnames)
+	if err != nil {
+		return nodeCertificateRequest{}, err
+	}
+	csr, err := bootstrap.SubmitCertificateRequest(client.CertificateSigningRequests(), nodeName, usage, requestData)
+	if err != nil {
+		return nodeCertificateRequest{}, err
+	}
+	glog.Infof("Requested a %s certificate for node %s with certificate signing request %s", usage, nodeName, csr.Name)
+	return nodeCertificateRequest{name: csr.Name, key: key, certInfo: certInfo}, nil
+}
+
+// waitForNodeCertificates waits for the certificates of requests to be issued and writes them with their keys
+func waitForNodeCertificates(client osclient.CertificateSigningRequestsInterface, requests []nodeCertificateRequest) error {
+	for _, request := range requests {
+		certData, err := bootstrap.WaitForCertificate(client.CertificateSigningRequests(), request.name)
+		if err != nil {
+			return err
+		}
+		if err := cmdcrypto.WriteCertificateAndKey(request.certInfo.CertFile, certData, request.certInfo.KeyFile, request.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeClientCertInfo returns the location of the client certificate of a node with bootstrap credentials
+func nodeClientCertInfo(nodeConfig configapi.NodeConfig) configapi.CertInfo {
+	return admin.DefaultNodeClientCertInfo(filepath.Dir(nodeConfig.MasterKubeConfig))
+}
+
+// writeNodeKubeConfig writes a .kubeconfig to kubeConfigFile that connects to the master of clientConfig using the
+// client certificate stored next to it
+func writeNodeKubeConfig(kubeConfigFile string, clientConfig *kclient.Config) error {
+	caData := clientConfig.CAData
+	if len(caData) == 0 && len(clientConfig.CAFile) > 0 {
+		var err error
+		if caData, err = ioutil.ReadFile(clientConfig.CAFile); err != nil {
+			return err
+		}
+	}
+	certInfo := admin.DefaultNodeClientCertInfo("")
+
+	kubeConfig := clientcmdapi.NewConfig()
+	kubeConfig.Clusters["master"] = &clientcmdapi.Cluster{
+		Server:                   clientConfig.Host,
+		CertificateAuthorityData: caData,
+		InsecureSkipTLSVerify:    clientConfig.Insecure,
+	}
+	// the certificate is referenced relative to the .kubeconfig so that it can be renewed in place
+	kubeConfig.AuthInfos["node"] = &clientcmdapi.AuthInfo{
+		ClientCertificate: certInfo.CertFile,
+		ClientKey:         certInfo.KeyFile,
+	}
+	kubeConfig.Contexts["node"] = &clientcmdapi.Context{Cluster: "master", AuthInfo: "node", Namespace: kapi.NamespaceDefault}
+	kubeConfig.CurrentContext = "node"
+
+	glog.V(3).Infof("Writing the node API client config to %s", kubeConfigFile)
+	return clientcmd.WriteToFile(*kubeConfig, kubeConfigFile)
+}
@@ -3,9 +3,13 @@ package start
 import (
 
 	// Admission control plug-ins used by OpenShift
+	_ "github.com/openshift/origin/pkg/authorization/admission/noderestriction"
 	_ "github.com/openshift/origin/pkg/build/admission"
+	_ "github.com/openshift/origin/pkg/project/admission/allowedregistries"
 	_ "github.com/openshift/origin/pkg/project/admission/lifecycle"
 	_ "github.com/openshift/origin/pkg/project/admission/nodeenv"
+	_ "github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride"
+	_ "github.com/openshift/origin/pkg/quota/admission/resourcequota"
 	_ "github.com/openshift/origin/pkg/security/admission"
 	_ "k8s.io/kubernetes/plugin/pkg/admission/admit"
 	_ "k8s.io/kubernetes/plugin/pkg/admission/exec"
@@ -33,6 +33,7 @@ type AllInOneOptions struct {
 	NodeConfigFile     string
 	PrintIP            bool
 	ServiceNetworkCIDR string
+	Profile            string
 }
 
 const allInOneLong = `
@@ -54,7 +55,10 @@ so if you have problems tell OpenShift what public address it will be via --mast
 
 You may also pass --etcd=<address> to connect to an external etcd server.
 
-You may also pass --kubeconfig=<path> to connect to an external Kubernetes cluster.`
+You may also pass --kubeconfig=<path> to connect to an external Kubernetes cluster.
+
+To get started quickly, pass --profile=dev to also install a Docker registry, a router, and the
+default image streams and templates once the server is running, and print how to log in.`
 
 // NewCommandStartAllInOne provides a CLI handler for 'start' command
 func NewCommandStartAllInOne(basename string, out io.Writer) (*cobra.Command, *AllInOneOptions) {
@@ -100,6 +104,7 @@ func NewCommandStartAllInOne(basename string, out io.Writer) (*cobra.Command, *A
 	flags.StringVar(&options.NodeConfigFile, "node-config", "", "Location of the node configuration file to run from. When running from configuration files, all other command-line arguments are ignored.")
 	flags.BoolVar(&options.MasterOptions.CreateCertificates, "create-certs", true, "Indicates whether missing certs should be created.")
 	flags.BoolVar(&options.PrintIP, "print-ip", false, "Print the IP that would be used if no master IP is specified and exit.")
+	flags.StringVar(&options.Profile, "profile", "", fmt.Sprintf("If set to %q, install a registry, a router, and the default image streams and templates after starting.", DevProfile))
 	flags.StringVar(&options.ServiceNetworkCIDR, "portal-net", NewDefaultNetworkArgs().ServiceNetworkCIDR, "The CIDR string representing the network that portal/service IPs will be assigned from. This must not overlap with any IP ranges assigned to nodes for pods.")
 
 	masterArgs, nodeArgs, listenArg, imageFormatArgs, _ := GetAllInOneArgs()
@@ -169,6 +174,15 @@ func (o AllInOneOptions) Validate(args []string) error {
 		return errors.New("config directory must have a value")
 	}
 
+	if len(o.Profile) > 0 {
+		if o.Profile != DevProfile {
+			return fmt.Errorf("the only supported profile is %q", DevProfile)
+		}
+		if o.IsRunFromConfig() || o.IsWriteConfigOnly() {
+			return errors.New("--profile cannot be specified with --master-config, --node-config or --write-config")
+		}
+	}
+
 	// if we are not starting up using a config file, run the argument validation
 	if !o.IsRunFromConfig() {
 		if err := o.MasterOptions.MasterArgs.Validate(); err != nil {
@@ -225,7 +239,8 @@ func (o *AllInOneOptions) Complete() error {
 // 2.  Calls RunMaster
 // 3.  Calls RunNode
 // 4.  If only writing configs, it exits
-// 5.  Waits forever
+// 5.  Installs the components of the profile, if any
+// 6.  Waits forever
 func (o AllInOneOptions) StartAllInOne() error {
 	if o.PrintIP {
 		host, _, err := net.SplitHostPort(o.NodeArgs.DefaultKubernetesURL.Host)
@@ -249,6 +264,14 @@ func (o AllInOneOptions) StartAllInOne() error {
 		return nil
 	}
 
+	if o.Profile == DevProfile {
+		go func() {
+			if err := o.installDevProfile(); err != nil {
+				glog.Errorf("Unable to set up the %s profile: %v", DevProfile, err)
+			}
+		}()
+	}
+
 	daemon.SdNotify("READY=1")
 	select {}
 }
@@ -332,7 +332,7 @@ func BuildKubernetesMasterConfig(openshiftConfig *origin.MasterConfig) (*kuberne
 	if openshiftConfig.Options.KubernetesMasterConfig == nil {
 		return nil, nil
 	}
-	kubeConfig, err := kubernetes.BuildKubernetesMasterConfig(openshiftConfig.Options, openshiftConfig.RequestContextMapper, openshiftConfig.KubeClient(), openshiftConfig.ProjectCache)
+	kubeConfig, err := kubernetes.BuildKubernetesMasterConfig(openshiftConfig.Options, openshiftConfig.RequestContextMapper, openshiftConfig.KubeClient(), openshiftConfig.KubernetesControllerClient(), openshiftConfig.ProjectCache)
 	return kubeConfig, err
 }
 
@@ -435,13 +435,14 @@ func StartAPI(oc *origin.MasterConfig, kc *kubernetes.MasterConfig) error {
 
 	// Must start policy caching immediately
 	oc.RunGroupCache()
+	oc.RunAccessTokenCacheInvalidation()
 	oc.RunPolicyCache()
 	oc.RunProjectCache()
 
 	unprotectedInstallers := []origin.APIInstaller{}
 
 	if oc.Options.OAuthConfig != nil {
-		authConfig, err := origin.BuildAuthConfig(oc.Options)
+		authConfig, err := origin.BuildAuthConfig(oc.Options, oc.LoginLockout)
 		if err != nil {
 			return err
 		}
@@ -499,7 +500,8 @@ func startControllers(oc *origin.MasterConfig, kc *kubernetes.MasterConfig) erro
 		oc.ControllerPlugStart()
 		// when a manual shutdown (DELETE /controllers) or lease lost occurs, the process should exit
 		// this ensures no code is still running as a controller, and allows a process manager to reset
-		// the controller to come back into a candidate state and compete for the lease
+		// the controller to come back into a candidate state and compete for the lease. The controllers
+		// stop on oc.ControllerPlug.Stopped() before that, and their clients refuse to write once the lease is lost.
 		oc.ControllerPlug.WaitForStop()
 		glog.Fatalf("Controller shutdown requested")
 	}()
@@ -564,20 +566,36 @@ func startControllers(oc *origin.MasterConfig, kc *kubernetes.MasterConfig) erro
 	}
 
 	// no special order
+	imageStreamsEnabled := configapi.IsImageStreamEnabled(&oc.Options)
 	if configapi.IsBuildEnabled(&oc.Options) {
 		oc.RunBuildController()
 		oc.RunBuildPodController()
 		oc.RunBuildConfigChangeController()
-		oc.RunBuildImageChangeTriggerController()
-	}
-	oc.RunDeploymentController()
-	oc.RunDeployerPodController()
-	oc.RunDeploymentConfigController()
-	oc.RunDeploymentConfigChangeController()
-	oc.RunDeploymentImageChangeTriggerController()
-	oc.RunImageImportController()
+		oc.RunBuildScheduleController()
+		if imageStreamsEnabled {
+			oc.RunBuildImageChangeTriggerController()
+		}
+	}
+	if configapi.IsDeploymentEnabled(&oc.Options) {
+		oc.RunDeploymentController()
+		oc.RunDeployerPodController()
+		oc.RunDeploymentConfigController()
+		oc.RunDeploymentConfigChangeController()
+		if imageStreamsEnabled {
+			oc.RunDeploymentImageChangeTriggerController()
+		}
+	}
+	if imageStreamsEnabled {
+		oc.RunImageImportController()
+		oc.RunImageTriggerController()
+	}
 	oc.RunOriginNamespaceController()
+	oc.RunResourceQuotaController()
+	if configapi.IsNotificationEnabled(&oc.Options) {
+		oc.RunNotificationController()
+	}
 	oc.RunSDNController()
+	oc.RunCertificateSigningController()
 
 	glog.Infof("Started Origin Controllers")
 
@@ -163,6 +163,10 @@ func (o NodeOptions) RunNode() error {
 		return err
 	}
 
+	if err := bootstrapNodeCertificates(*nodeConfig); err != nil {
+		return err
+	}
+
 	validationResults := validation.ValidateNodeConfig(nodeConfig)
 	if len(validationResults.Warnings) != 0 {
 		for _, warning := range validationResults.Warnings {
@@ -182,6 +186,7 @@ func (o NodeOptions) RunNode() error {
 	if err := StartNode(*nodeConfig); err != nil {
 		return err
 	}
+	startNodeCertificateRotation(*nodeConfig)
 
 	return nil
 }
@@ -258,7 +263,7 @@ func StartNode(nodeConfig configapi.NodeConfig) error {
 
 	// preconditions
 	config.EnsureVolumeDir()
-	config.EnsureDocker(docker.NewHelper())
+	config.EnsureDocker(docker.NewHelperForEndpoint(config.DockerEndpoint))
 
 	// async starts
 	config.RunKubelet()
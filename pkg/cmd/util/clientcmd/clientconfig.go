@@ -1,11 +1,16 @@
 package clientcmd
 
 import (
+	"net/http"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/cmd/cli/config"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
 )
 
 func DefaultClientConfig(flags *pflag.FlagSet) clientcmd.ClientConfig {
@@ -23,7 +28,60 @@ func DefaultClientConfig(flags *pflag.FlagSet) clientcmd.ClientConfig {
 	cobra.MarkFlagFilename(flags, overrideFlags.AuthOverrideFlags.ClientKey.LongName)
 	cobra.MarkFlagFilename(flags, overrideFlags.ClusterOverrideFlags.CertificateAuthority.LongName)
 
-	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+	clientConfig := &impersonatingClientConfig{nested: clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)}
+	flags.StringVar(&clientConfig.user, "as", "", "Username to impersonate for the operation.")
 
 	return clientConfig
 }
+
+// impersonatingClientConfig makes the requests of the clients it configures as another user, if one is set
+type impersonatingClientConfig struct {
+	nested clientcmd.ClientConfig
+	user   string
+}
+
+// RawConfig calls the nested method
+func (c *impersonatingClientConfig) RawConfig() (clientcmdapi.Config, error) {
+	return c.nested.RawConfig()
+}
+
+// Namespace calls the nested method
+func (c *impersonatingClientConfig) Namespace() (string, bool, error) {
+	return c.nested.Namespace()
+}
+
+// ClientConfig calls the nested method, and sets the user to impersonate on the requests
+func (c *impersonatingClientConfig) ClientConfig() (*kclient.Config, error) {
+	config, err := c.nested.ClientConfig()
+	if err != nil || len(c.user) == 0 {
+		return config, err
+	}
+
+	wrapTransport := config.WrapTransport
+	user := c.user
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrapTransport != nil {
+			rt = wrapTransport(rt)
+		}
+		return &impersonatingRoundTripper{user: user, delegate: rt}
+	}
+	return config, nil
+}
+
+// impersonatingRoundTripper sets the Impersonate-User header on requests
+type impersonatingRoundTripper struct {
+	user     string
+	delegate http.RoundTripper
+}
+
+func (rt *impersonatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// requests must not be modified by round trippers
+	impersonated := &http.Request{}
+	*impersonated = *req
+	impersonated.Header = http.Header{}
+	for k, v := range req.Header {
+		impersonated.Header[k] = v
+	}
+	impersonated.Header.Set(authapi.ImpersonateUserHeader, rt.user)
+	return rt.delegate.RoundTrip(impersonated)
+}
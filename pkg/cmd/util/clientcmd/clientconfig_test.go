@@ -0,0 +1,50 @@
+package clientcmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+)
+
+func TestImpersonatingClientConfig(t *testing.T) {
+	impersonated := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		impersonated = req.Header.Get(authapi.ImpersonateUserHeader)
+	}))
+	defer server.Close()
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = &clientcmdapi.Cluster{Server: server.URL}
+	config.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts["context"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"}
+	config.CurrentContext = "context"
+
+	for _, user := range []string{"", "bob"} {
+		clientConfig := &impersonatingClientConfig{nested: clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}), user: user}
+		restConfig, err := clientConfig.ClientConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		transport, err := kclient.TransportFor(restConfig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if impersonated != user {
+			t.Errorf("expected to impersonate %q, got %q", user, impersonated)
+		}
+		if len(req.Header) != 0 {
+			t.Errorf("expected the request not to be modified, got headers %v", req.Header)
+		}
+	}
+}
@@ -280,6 +280,9 @@ func NewFactory(clientConfig kclientcmd.ClientConfig) *Factory {
 				// should --version work with builds at all?
 				return nil, errors.New("cannot specify a version and a build")
 			}
+			if bopts.Previous {
+				return nil, errors.New("cannot specify previous and a build")
+			}
 			return oc.BuildLogs(t.Namespace).Get(t.Name, *bopts), nil
 		case *buildapi.BuildConfig:
 			bopts, ok := options.(*buildapi.BuildLogOptions)
@@ -299,6 +302,8 @@ func NewFactory(clientConfig kclientcmd.ClientConfig) *Factory {
 				desired := buildutil.BuildNameForConfigVersion(t.Name, int(*bopts.Version))
 				return oc.BuildLogs(t.Namespace).Get(desired, *bopts), nil
 			}
+			// If previous has been specified, the server returns the logs of the build numbered one less than
+			// the most recent one.
 			sort.Sort(sort.Reverse(buildapi.BuildSliceByCreationTimestamp(builds.Items)))
 			return oc.BuildLogs(t.Namespace).Get(builds.Items[0].Name, *bopts), nil
 		default:
@@ -306,7 +311,15 @@ func NewFactory(clientConfig kclientcmd.ClientConfig) *Factory {
 		}
 	}
 	w.Printer = func(mapping *meta.RESTMapping, noHeaders, withNamespace, wide bool, showAll bool, columnLabels []string) (kubectl.ResourcePrinter, error) {
-		return describe.NewHumanReadablePrinter(noHeaders, withNamespace, wide, showAll, columnLabels), nil
+		printer := describe.NewHumanReadablePrinter(noHeaders, withNamespace, wide, showAll, columnLabels)
+		if wide {
+			oc, kc, err := w.Clients()
+			if err != nil {
+				return nil, err
+			}
+			describe.AddStatusHandlers(printer, oc, kc)
+		}
+		return printer, nil
 	}
 	kCanBeExposed := w.Factory.CanBeExposed
 	w.CanBeExposed = func(kind string) error {
@@ -2,6 +2,7 @@ package docker
 
 import (
 	"os"
+	"path/filepath"
 
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/golang/glog"
@@ -11,6 +12,7 @@ import (
 // Helper contains all the valid config options for connecting to Docker from
 // a command line.
 type Helper struct {
+	endpoint string
 }
 
 // NewHelper creates a Flags object with the default values set.  Use this
@@ -19,6 +21,12 @@ func NewHelper() *Helper {
 	return &Helper{}
 }
 
+// NewHelperForEndpoint creates a Helper connecting to the Docker daemon at endpoint. If endpoint is empty, the
+// daemon is located like NewHelper does.
+func NewHelperForEndpoint(endpoint string) *Helper {
+	return &Helper{endpoint: endpoint}
+}
+
 // InstallFlags installs the Docker flag helper into a FlagSet with the default
 // options and default values from the Helper object.
 func (_ *Helper) InstallFlags(flags *pflag.FlagSet) {
@@ -26,7 +34,12 @@ func (_ *Helper) InstallFlags(flags *pflag.FlagSet) {
 
 // GetClient returns a valid Docker client, the address of the client, or an error
 // if the client couldn't be created.
-func (_ *Helper) GetClient() (client *docker.Client, endpoint string, err error) {
+func (h *Helper) GetClient() (client *docker.Client, endpoint string, err error) {
+	if len(h.endpoint) > 0 {
+		client, err = newClientForEndpoint(h.endpoint)
+		return client, h.endpoint, err
+	}
+
 	client, err = docker.NewClientFromEnv()
 	if len(os.Getenv("DOCKER_HOST")) > 0 {
 		endpoint = os.Getenv("DOCKER_HOST")
@@ -36,6 +49,26 @@ func (_ *Helper) GetClient() (client *docker.Client, endpoint string, err error)
 	return
 }
 
+// newClientForEndpoint returns a client for the Docker daemon at endpoint, using the TLS client certificates of the
+// environment if DOCKER_TLS_VERIFY is set, like the Docker client does
+func newClientForEndpoint(endpoint string) (*docker.Client, error) {
+	var (
+		client *docker.Client
+		err    error
+	)
+	if len(os.Getenv("DOCKER_TLS_VERIFY")) > 0 {
+		certPath := os.Getenv("DOCKER_CERT_PATH")
+		client, err = docker.NewTLSClient(endpoint, filepath.Join(certPath, "cert.pem"), filepath.Join(certPath, "key.pem"), filepath.Join(certPath, "ca.pem"))
+	} else {
+		client, err = docker.NewClient(endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	client.SkipServerVersionCheck = true
+	return client, nil
+}
+
 // GetClientOrExit returns a valid Docker client and the address of the client,
 // or prints an error and exits.
 func (h *Helper) GetClientOrExit() (*docker.Client, string) {
@@ -12,3 +12,13 @@ func TestNewHelper(t *testing.T) {
 	helper := NewHelper()
 	helper.InstallFlags(flags)
 }
+
+func TestGetClientForEndpoint(t *testing.T) {
+	_, endpoint, err := NewHelperForEndpoint("tcp://10.0.0.1:2376").GetClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if endpoint != "tcp://10.0.0.1:2376" {
+		t.Errorf("expected the configured endpoint, got %s", endpoint)
+	}
+}
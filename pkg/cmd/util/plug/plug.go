@@ -1,6 +1,8 @@
 package plug
 
 import (
+	"fmt"
+	"net/http"
 	"sync"
 )
 
@@ -19,6 +21,8 @@ type Plug interface {
 	WaitForStart()
 	// Blocks until Stop() is invoked
 	WaitForStop()
+	// Returns a channel that is closed when Stop() is invoked
+	Stopped() <-chan struct{}
 	// Returns true if Start() has been invoked
 	IsStarted() bool
 }
@@ -68,6 +72,10 @@ func (p *plug) WaitForStop() {
 	<-p.stopCh
 }
 
+func (p *plug) Stopped() <-chan struct{} {
+	return p.stopCh
+}
+
 // Leaser controls access to a lease
 type Leaser interface {
 	// AcquireAndHold tries to acquire the lease and hold it until it expires, the lease is deleted,
@@ -75,6 +83,10 @@ type Leaser interface {
 	// when the lease is held, and closed when the lease is lost.
 	AcquireAndHold(chan struct{})
 	Release()
+	// Token returns the fencing token of the lease while it is held, or 0
+	Token() uint64
+	// Check returns an error unless the lease is still held with the current token and has not expired
+	Check() error
 }
 
 // leased uses a Leaser to control Start and Stop on a Plug
@@ -95,10 +107,74 @@ func NewLeased(leaser Leaser) *Leased {
 	}
 }
 
-// Stop releases the acquired lease
+// Stop stops the plug and releases the acquired lease. The plug is stopped first, so that the controllers stop
+// as soon as the lease is lost rather than once the release is attempted.
 func (l *Leased) Stop() {
-	l.leaser.Release()
 	l.Plug.Stop()
+	l.leaser.Release()
+}
+
+// Token returns the fencing token of the held lease, or 0 if the lease is not held. A master that acquires the
+// lease later gets a greater token.
+func (l *Leased) Token() uint64 {
+	return l.leaser.Token()
+}
+
+// Check returns an error unless the lease is still held and has not expired. Controllers can check the lease before
+// a write that should not be made by two masters, for instance while etcd is partitioned.
+func (l *Leased) Check() error {
+	return l.leaser.Check()
+}
+
+// WrapTransport returns a transport that refuses the requests of rt that are not reads unless the lease is still
+// held with the same fencing token it was acquired with. Clients of controllers wrapped with it stop writing once
+// the lease is lost or may have expired. The server does not check the token, so a write sent just before the
+// lease expires can still be applied after another master acquired the lease.
+func (l *Leased) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &fencedRoundTripper{leaser: l.leaser, rt: rt}
+}
+
+// fencedRoundTripper checks a lease before every write
+type fencedRoundTripper struct {
+	leaser Leaser
+	rt     http.RoundTripper
+
+	// lock guards token, and is never held while the lease is checked or a request is sent
+	lock  sync.Mutex
+	token uint64
+}
+
+func (f *fencedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return f.rt.RoundTrip(req)
+	}
+	if err := f.check(); err != nil {
+		return nil, fmt.Errorf("refusing to %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	return f.rt.RoundTrip(req)
+}
+
+// check returns an error unless the lease is held with the token of the first write
+func (f *fencedRoundTripper) check() error {
+	token := f.leaser.Token()
+	if token == 0 {
+		return fmt.Errorf("the lease is not held")
+	}
+	if first := f.firstToken(token); token != first {
+		return fmt.Errorf("the lease was acquired again with token %d since the first write with token %d", token, first)
+	}
+	return f.leaser.Check()
+}
+
+// firstToken records token if no write was made yet, and returns the token of the first write
+func (f *fencedRoundTripper) firstToken(token uint64) uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.token == 0 {
+		f.token = token
+	}
+	return f.token
 }
 
 // Run tries to acquire and hold a lease, invoking Start()
@@ -0,0 +1,130 @@
+package plug
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/util"
+)
+
+type fakeLeaser struct {
+	token uint64
+	err   error
+}
+
+func (l *fakeLeaser) AcquireAndHold(chan struct{}) {}
+func (l *fakeLeaser) Release()                     {}
+func (l *fakeLeaser) Token() uint64                { return l.token }
+func (l *fakeLeaser) Check() error                 { return l.err }
+
+type countingRoundTripper struct {
+	requests int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests++
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+type okRoundTripper struct{}
+
+func (okRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestLeasedWrapTransport(t *testing.T) {
+	leaser := &fakeLeaser{}
+	rt := &countingRoundTripper{}
+	transport := NewLeased(leaser).WrapTransport(rt)
+
+	get, _ := http.NewRequest("GET", "https://master.example.com/api/v1/pods", nil)
+	put, _ := http.NewRequest("PUT", "https://master.example.com/api/v1/namespaces/test/pods/pod", nil)
+
+	testCases := []struct {
+		name      string
+		token     uint64
+		err       error
+		req       *http.Request
+		expectErr bool
+	}{
+		{name: "read without the lease", req: get},
+		{name: "write without the lease", req: put, expectErr: true},
+		{name: "write with the lease", token: 5, req: put},
+		{name: "write once etcd no longer confirms the lease", token: 5, err: errors.New("lease is held by other"), req: put, expectErr: true},
+		{name: "write with a later token", token: 7, req: put, expectErr: true},
+		{name: "read with a later token", token: 7, req: get},
+	}
+	for _, tc := range testCases {
+		leaser.token, leaser.err = tc.token, tc.err
+		before := rt.requests
+		_, err := transport.RoundTrip(tc.req)
+		if tc.expectErr != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", tc.name, tc.expectErr, err)
+		}
+		if sent := rt.requests > before; sent == tc.expectErr {
+			t.Errorf("%s: expected the request to be sent %t, got %t", tc.name, !tc.expectErr, sent)
+		}
+	}
+}
+
+// blockingLeaser blocks every check until release is closed
+type blockingLeaser struct {
+	fakeLeaser
+	checking chan struct{}
+	release  chan struct{}
+}
+
+func (l *blockingLeaser) Check() error {
+	l.checking <- struct{}{}
+	<-l.release
+	return nil
+}
+
+func TestLeasedWrapTransportConcurrentChecks(t *testing.T) {
+	leaser := &blockingLeaser{
+		fakeLeaser: fakeLeaser{token: 5},
+		checking:   make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	transport := NewLeased(leaser).WrapTransport(okRoundTripper{})
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			put, _ := http.NewRequest("PUT", "https://master.example.com/api/v1/namespaces/test/pods/pod", nil)
+			_, err := transport.RoundTrip(put)
+			errs <- err
+		}()
+	}
+	// both writes check the lease at the same time
+	for i := 0; i < 2; i++ {
+		select {
+		case <-leaser.checking:
+		case <-time.After(util.ForeverTestTimeout):
+			t.Fatal("expected the writes not to wait for each other's lease check")
+		}
+	}
+	close(leaser.release)
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestLeasedStopped(t *testing.T) {
+	leased := NewLeased(&fakeLeaser{})
+	select {
+	case <-leased.Stopped():
+		t.Fatal("unexpected stop")
+	default:
+	}
+	leased.Stop()
+	select {
+	case <-leased.Stopped():
+	default:
+		t.Fatal("expected the plug to be stopped")
+	}
+}
@@ -38,7 +38,7 @@ func (c *BasicChallengeHandler) CanHandle(headers http.Header) bool {
 	isBasic, _ := basicRealm(headers)
 	return isBasic
 }
-func (c *BasicChallengeHandler) HandleChallenge(headers http.Header) (http.Header, bool, error) {
+func (c *BasicChallengeHandler) HandleChallenge(requestURL string, headers http.Header) (http.Header, bool, error) {
 	if c.prompted {
 		glog.V(2).Info("already prompted for challenge, won't prompt again")
 		return nil, false, nil
@@ -93,6 +93,12 @@ func (c *BasicChallengeHandler) HandleChallenge(headers http.Header) (http.Heade
 	glog.V(2).Info("no username or password available")
 	return nil, false, nil
 }
+func (c *BasicChallengeHandler) CompleteChallenge(requestURL string, headers http.Header) error {
+	return nil
+}
+func (c *BasicChallengeHandler) Release() error {
+	return nil
+}
 
 // if any of these match a WWW-Authenticate header, it is a basic challenge
 // capturing group 1 (if present) should contain the realm
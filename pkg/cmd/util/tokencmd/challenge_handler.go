@@ -0,0 +1,91 @@
+package tokencmd
+
+import (
+	"net/http"
+
+	"github.com/golang/glog"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+)
+
+// ChallengeHandler handles responses to WWW-Authenticate challenges.
+type ChallengeHandler interface {
+	// CanHandle returns true if the handler recognizes a challenge it thinks it can handle.
+	CanHandle(headers http.Header) bool
+	// HandleChallenge lets the handler attempt to handle a challenge from the given URL.
+	// It returns the headers to retry the request with, and whether the request should be retried.
+	HandleChallenge(requestURL string, headers http.Header) (http.Header, bool, error)
+	// CompleteChallenge is given the headers of the response that ended a handled challenge successfully,
+	// which may carry a last token the handler has to verify.
+	CompleteChallenge(requestURL string, headers http.Header) error
+	// Release gives the handler a chance to release any resources held during a challenge.
+	Release() error
+}
+
+// MultiHandler manages a series of challenge handlers. The first handler that handles a challenge is used for
+// the rest of the challenge/response sequence.
+type MultiHandler struct {
+	// handler is the handler that handled the first challenge, if any
+	handler ChallengeHandler
+	// handlers are tried in order until one handles a challenge
+	handlers []ChallengeHandler
+}
+
+// NewMultiHandler returns a challenge handler trying the given handlers in order
+func NewMultiHandler(handlers ...ChallengeHandler) ChallengeHandler {
+	return &MultiHandler{handlers: handlers}
+}
+
+func (h *MultiHandler) CanHandle(headers http.Header) bool {
+	if h.handler != nil {
+		return h.handler.CanHandle(headers)
+	}
+	for _, handler := range h.handlers {
+		if handler.CanHandle(headers) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *MultiHandler) HandleChallenge(requestURL string, headers http.Header) (http.Header, bool, error) {
+	if h.handler != nil {
+		return h.handler.HandleChallenge(requestURL, headers)
+	}
+
+	// Fall back to the next handler when one fails, a Negotiate challenge cannot be handled without a ticket
+	// but the server may also accept a password
+	errs := []error{}
+	for _, handler := range h.handlers {
+		if !handler.CanHandle(headers) {
+			continue
+		}
+		newHeaders, shouldRetry, err := handler.HandleChallenge(requestURL, headers)
+		if err != nil {
+			glog.V(2).Infof("challenge handler failed: %v", err)
+			errs = append(errs, err)
+			continue
+		}
+		if shouldRetry {
+			h.handler = handler
+			return newHeaders, true, nil
+		}
+	}
+	return nil, false, kerrors.NewAggregate(errs)
+}
+
+func (h *MultiHandler) CompleteChallenge(requestURL string, headers http.Header) error {
+	if h.handler != nil {
+		return h.handler.CompleteChallenge(requestURL, headers)
+	}
+	return nil
+}
+
+func (h *MultiHandler) Release() error {
+	errs := []error{}
+	for _, handler := range h.handlers {
+		if err := handler.Release(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
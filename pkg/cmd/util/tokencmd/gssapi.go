@@ -0,0 +1,179 @@
+// +build gssapi
+
+package tokencmd
+
+/*
+#cgo LDFLAGS: -lgssapi_krb5
+#include <stdlib.h>
+#include <string.h>
+#include <gssapi/gssapi.h>
+
+// spnego_mech is the SPNEGO mechanism (1.3.6.1.5.5.2) used by HTTP Negotiate authentication
+static gss_OID_desc spnego_mech = {6, "\x2b\x06\x01\x05\x05\x02"};
+
+static int is_error(OM_uint32 major) {
+	return GSS_ERROR(major) != 0;
+}
+
+static int is_continue_needed(OM_uint32 major) {
+	return (major & GSS_S_CONTINUE_NEEDED) != 0;
+}
+
+static OM_uint32 import_name(OM_uint32 *minor, char *name, int service, gss_name_t *output) {
+	gss_buffer_desc buffer = {strlen(name), name};
+	return gss_import_name(minor, &buffer, service ? GSS_C_NT_HOSTBASED_SERVICE : GSS_C_NT_USER_NAME, output);
+}
+
+static OM_uint32 acquire_cred(OM_uint32 *minor, gss_name_t name, gss_cred_id_t *cred) {
+	gss_OID_set_desc mechs = {1, &spnego_mech};
+	return gss_acquire_cred(minor, name, GSS_C_INDEFINITE, &mechs, GSS_C_INITIATE, cred, NULL, NULL);
+}
+
+static OM_uint32 init_sec_context(OM_uint32 *minor, gss_cred_id_t cred, gss_ctx_id_t *ctx, gss_name_t target, void *input, size_t input_length, gss_buffer_t output) {
+	gss_buffer_desc input_buffer = {input_length, input};
+	return gss_init_sec_context(minor, cred, ctx, target, &spnego_mech, GSS_C_MUTUAL_FLAG | GSS_C_SEQUENCE_FLAG,
+		GSS_C_INDEFINITE, GSS_C_NO_CHANNEL_BINDINGS, &input_buffer, NULL, output, NULL, NULL);
+}
+
+static OM_uint32 display_status(OM_uint32 *minor, OM_uint32 status, int status_type, OM_uint32 *message_context, gss_buffer_t message) {
+	return gss_display_status(minor, status, status_type, GSS_C_NO_OID, message_context, message);
+}
+
+static void release(gss_ctx_id_t *ctx, gss_name_t *name, gss_cred_id_t *cred) {
+	OM_uint32 minor;
+	if (*ctx != GSS_C_NO_CONTEXT) {
+		gss_delete_sec_context(&minor, ctx, GSS_C_NO_BUFFER);
+	}
+	if (*name != GSS_C_NO_NAME) {
+		gss_release_name(&minor, name);
+	}
+	if (*cred != GSS_C_NO_CREDENTIAL) {
+		gss_release_cred(&minor, cred);
+	}
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"unsafe"
+)
+
+// gssapiNegotiator establishes a SPNEGO security context with the Kerberos credentials of the user
+type gssapiNegotiator struct {
+	// principalName is the Kerberos principal whose credentials are used. If empty, the default credentials are used
+	principalName string
+
+	cred     C.gss_cred_id_t
+	ctx      C.gss_ctx_id_t
+	name     C.gss_name_t
+	complete bool
+}
+
+// NewGSSAPINegotiator returns a negotiater using the credentials of the given Kerberos principal, or the default
+// credentials if principalName is empty
+func NewGSSAPINegotiator(principalName string) Negotiater {
+	return &gssapiNegotiator{principalName: principalName}
+}
+
+func (g *gssapiNegotiator) Load() error {
+	if len(g.principalName) == 0 || g.cred != nil {
+		return nil
+	}
+
+	var minor C.OM_uint32
+	var principal C.gss_name_t
+	principalName := C.CString(g.principalName)
+	defer C.free(unsafe.Pointer(principalName))
+	if major := C.import_name(&minor, principalName, 0, &principal); C.is_error(major) != 0 {
+		return gssapiError(fmt.Sprintf("unable to import the principal name %s", g.principalName), major, minor)
+	}
+	defer C.gss_release_name(&minor, &principal)
+
+	if major := C.acquire_cred(&minor, principal, &g.cred); C.is_error(major) != 0 {
+		return gssapiError(fmt.Sprintf("unable to acquire the credentials of %s", g.principalName), major, minor)
+	}
+	return nil
+}
+
+func (g *gssapiNegotiator) InitSecContext(requestURL string, challengeToken []byte) ([]byte, error) {
+	var minor C.OM_uint32
+
+	if g.name == nil {
+		serviceName, err := serviceName(requestURL)
+		if err != nil {
+			return nil, err
+		}
+		name := C.CString(serviceName)
+		defer C.free(unsafe.Pointer(name))
+		if major := C.import_name(&minor, name, 1, &g.name); C.is_error(major) != 0 {
+			return nil, gssapiError(fmt.Sprintf("unable to import the service name %s", serviceName), major, minor)
+		}
+	}
+
+	var input unsafe.Pointer
+	if len(challengeToken) > 0 {
+		input = unsafe.Pointer(&challengeToken[0])
+	}
+	var output C.gss_buffer_desc
+	major := C.init_sec_context(&minor, g.cred, &g.ctx, g.name, input, C.size_t(len(challengeToken)), &output)
+	token := C.GoBytes(output.value, C.int(output.length))
+	var ignored C.OM_uint32
+	C.gss_release_buffer(&ignored, &output)
+	if C.is_error(major) != 0 {
+		return nil, gssapiError("unable to initialize the security context", major, minor)
+	}
+
+	g.complete = C.is_continue_needed(major) == 0
+	return token, nil
+}
+
+func (g *gssapiNegotiator) IsComplete() bool {
+	return g.complete
+}
+
+func (g *gssapiNegotiator) Release() error {
+	C.release(&g.ctx, &g.name, &g.cred)
+	g.complete = false
+	return nil
+}
+
+// serviceName returns the host based service name of the HTTP service serving requestURL
+func serviceName(requestURL string) (string, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
+	host := u.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return "HTTP@" + host, nil
+}
+
+// gssapiError returns an error describing the given major and minor GSSAPI status codes
+func gssapiError(message string, major, minor C.OM_uint32) error {
+	details := append(statusMessages(major, C.GSS_C_GSS_CODE), statusMessages(minor, C.GSS_C_MECH_CODE)...)
+	return fmt.Errorf("%s: %s", message, strings.Join(details, ", "))
+}
+
+func statusMessages(status C.OM_uint32, statusType C.int) []string {
+	messages := []string{}
+	var messageContext C.OM_uint32
+	for {
+		var minor C.OM_uint32
+		var message C.gss_buffer_desc
+		if C.is_error(C.display_status(&minor, status, statusType, &messageContext, &message)) != 0 {
+			break
+		}
+		messages = append(messages, C.GoStringN((*C.char)(message.value), C.int(message.length)))
+		C.gss_release_buffer(&minor, &message)
+		if messageContext == 0 {
+			break
+		}
+	}
+	return messages
+}
@@ -0,0 +1,26 @@
+// +build !gssapi
+
+package tokencmd
+
+import "errors"
+
+// gssapiUnsupported is the negotiater used when built without GSSAPI support
+type gssapiUnsupported struct{}
+
+// NewGSSAPINegotiator returns a negotiater that cannot load, this binary being built without GSSAPI support
+func NewGSSAPINegotiator(principalName string) Negotiater {
+	return gssapiUnsupported{}
+}
+
+func (gssapiUnsupported) Load() error {
+	return errors.New("GSSAPI support is not enabled, build with the gssapi tag to authenticate with Kerberos")
+}
+func (gssapiUnsupported) InitSecContext(requestURL string, challengeToken []byte) ([]byte, error) {
+	return nil, errors.New("GSSAPI support is not enabled")
+}
+func (gssapiUnsupported) IsComplete() bool {
+	return false
+}
+func (gssapiUnsupported) Release() error {
+	return nil
+}
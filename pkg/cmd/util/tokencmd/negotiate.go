@@ -0,0 +1,112 @@
+package tokencmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// Negotiater defines the minimal interface needed to interact with GSSAPI to perform a Negotiate
+// challenge/response sequence
+type Negotiater interface {
+	// Load gives the negotiater a chance to load the resources needed to handle a challenge/response sequence.
+	// It may be invoked multiple times. If an error is returned, InitSecContext is not called.
+	Load() error
+	// InitSecContext returns the token to send in response to the given challenge token from the given URL, or
+	// an error if no credentials are available or the challenge token is invalid. The challenge token is empty
+	// for the first challenge of a sequence.
+	InitSecContext(requestURL string, challengeToken []byte) ([]byte, error)
+	// IsComplete returns true when the security context is established.
+	IsComplete() bool
+	// Release releases the resources held during a challenge/response sequence.
+	Release() error
+}
+
+// NegotiateChallengeHandler handles "WWW-Authenticate: Negotiate" challenges, as used by SPNEGO to authenticate
+// with Kerberos tickets
+type NegotiateChallengeHandler struct {
+	negotiater Negotiater
+}
+
+// NewNegotiateChallengeHandler returns a challenge handler responding to Negotiate challenges with the given negotiater
+func NewNegotiateChallengeHandler(negotiater Negotiater) ChallengeHandler {
+	return &NegotiateChallengeHandler{negotiater: negotiater}
+}
+
+func (c *NegotiateChallengeHandler) CanHandle(headers http.Header) bool {
+	if _, isNegotiate := negotiateToken(headers); !isNegotiate {
+		return false
+	}
+	if err := c.negotiater.Load(); err != nil {
+		glog.V(5).Infof("unable to handle Negotiate challenges: %v", err)
+		return false
+	}
+	return true
+}
+
+func (c *NegotiateChallengeHandler) HandleChallenge(requestURL string, headers http.Header) (http.Header, bool, error) {
+	if c.negotiater.IsComplete() {
+		glog.V(2).Info("Negotiate challenge received after the security context was established")
+		return nil, false, nil
+	}
+
+	encodedToken, _ := negotiateToken(headers)
+	challengeToken, err := base64.StdEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return nil, false, err
+	}
+	token, err := c.negotiater.InitSecContext(requestURL, challengeToken)
+	if err != nil {
+		return nil, false, err
+	}
+
+	responseHeaders := http.Header{}
+	responseHeaders.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(token))
+	return responseHeaders, true, nil
+}
+
+func (c *NegotiateChallengeHandler) CompleteChallenge(requestURL string, headers http.Header) error {
+	if c.negotiater.IsComplete() {
+		return nil
+	}
+
+	// The server authenticates itself with a last token when mutual authentication is requested
+	encodedToken, _ := negotiateToken(headers)
+	if len(encodedToken) == 0 {
+		return errors.New("the server did not complete the Negotiate authentication")
+	}
+	challengeToken, err := base64.StdEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return err
+	}
+	if _, err := c.negotiater.InitSecContext(requestURL, challengeToken); err != nil {
+		return err
+	}
+	if !c.negotiater.IsComplete() {
+		return errors.New("the server did not complete the Negotiate authentication")
+	}
+	return nil
+}
+
+func (c *NegotiateChallengeHandler) Release() error {
+	return c.negotiater.Release()
+}
+
+// negotiateToken returns the base64 encoded token of the first Negotiate WWW-Authenticate header, and whether
+// there is one. The token is empty for the first challenge of a sequence.
+func negotiateToken(headers http.Header) (string, bool) {
+	for _, challenge := range headers[http.CanonicalHeaderKey("WWW-Authenticate")] {
+		fields := strings.Fields(challenge)
+		if len(fields) == 0 || !strings.EqualFold(fields[0], "Negotiate") {
+			continue
+		}
+		if len(fields) > 1 {
+			return fields[1], true
+		}
+		return "", true
+	}
+	return "", false
+}
@@ -0,0 +1,111 @@
+package tokencmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+// fakeNegotiater establishes a security context after the server answers its first token with "server token"
+type fakeNegotiater struct {
+	loadErr  error
+	complete bool
+	released bool
+}
+
+func (n *fakeNegotiater) Load() error {
+	return n.loadErr
+}
+func (n *fakeNegotiater) InitSecContext(requestURL string, challengeToken []byte) ([]byte, error) {
+	switch string(challengeToken) {
+	case "":
+		return []byte("client token"), nil
+	case "server token":
+		n.complete = true
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected challenge token %q", challengeToken)
+	}
+}
+func (n *fakeNegotiater) IsComplete() bool {
+	return n.complete
+}
+func (n *fakeNegotiater) Release() error {
+	n.released = true
+	return nil
+}
+
+func TestRequestTokenWithNegotiate(t *testing.T) {
+	testCases := map[string]struct {
+		Negotiater    *fakeNegotiater
+		ServerToken   string
+		ExpectedError string
+	}{
+		"negotiate": {
+			Negotiater:  &fakeNegotiater{},
+			ServerToken: "server token",
+		},
+		"negotiate without mutual authentication": {
+			Negotiater:    &fakeNegotiater{},
+			ExpectedError: "the server did not complete the Negotiate authentication",
+		},
+		"negotiate with an invalid server token": {
+			Negotiater:    &fakeNegotiater{},
+			ServerToken:   "forged token",
+			ExpectedError: `unexpected challenge token "forged token"`,
+		},
+		"fall back to basic without GSSAPI": {
+			Negotiater: &fakeNegotiater{loadErr: errors.New("not supported")},
+		},
+	}
+
+	for k, tc := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authorization := req.Header.Get("Authorization")
+			switch {
+			case authorization == "Negotiate "+base64.StdEncoding.EncodeToString([]byte("client token")):
+				if len(tc.ServerToken) > 0 {
+					w.Header().Set("WWW-Authenticate", "Negotiate "+base64.StdEncoding.EncodeToString([]byte(tc.ServerToken)))
+				}
+			case authorization == "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")):
+			default:
+				w.Header().Add("WWW-Authenticate", "Negotiate")
+				w.Header().Add("WWW-Authenticate", `Basic realm="sso"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Location", "/oauth/token/implicit#access_token=token&token_type=Bearer")
+			w.WriteHeader(http.StatusFound)
+		}))
+
+		handler := NewMultiHandler(
+			NewNegotiateChallengeHandler(tc.Negotiater),
+			&BasicChallengeHandler{Username: "user", Password: "pass"},
+		)
+		token, err := requestToken(&kclient.Config{Host: server.URL}, handler)
+		server.Close()
+		handler.Release()
+
+		if len(tc.ExpectedError) > 0 {
+			if err == nil || err.Error() != tc.ExpectedError {
+				t.Errorf("%s: expected error %q, got %v", k, tc.ExpectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if token != "token" {
+			t.Errorf("%s: expected token, got %q", k, token)
+		}
+		if !tc.Negotiater.released {
+			t.Errorf("%s: expected the negotiater to be released", k)
+		}
+	}
+}
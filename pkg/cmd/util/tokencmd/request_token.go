@@ -6,7 +6,9 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
+	"regexp"
 	"strings"
 
 	apierrs "k8s.io/kubernetes/pkg/api/errors"
@@ -19,16 +21,28 @@ import (
 // Corresponds to the header expected by basic-auth challenging authenticators
 const CSRFTokenHeader = "X-CSRF-Token"
 
+// tokenRequestPath is where a token can be requested in a browser when the server issues no challenge the CLI
+// can handle
+const tokenRequestPath = "/oauth/token/request"
+
 // RequestToken uses the cmd arguments to locate an openshift oauth server and attempts to authenticate
 // it returns the access token if it gets one.  An error if it does not
 func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername string, defaultPassword string) (string, error) {
-	challengeHandler := &BasicChallengeHandler{
-		Host:     clientCfg.Host,
-		Reader:   reader,
-		Username: defaultUsername,
-		Password: defaultPassword,
-	}
+	// Negotiate challenges are tried first, so that Kerberos tickets are used without prompting for a password
+	challengeHandler := NewMultiHandler(
+		NewNegotiateChallengeHandler(NewGSSAPINegotiator(defaultUsername)),
+		&BasicChallengeHandler{
+			Host:     clientCfg.Host,
+			Reader:   reader,
+			Username: defaultUsername,
+			Password: defaultPassword,
+		},
+	)
+	defer challengeHandler.Release()
+	return requestToken(clientCfg, challengeHandler)
+}
 
+func requestToken(clientCfg *kclient.Config, challengeHandler ChallengeHandler) (string, error) {
 	rt, err := kclient.TransportFor(clientCfg)
 	if err != nil {
 		return "", err
@@ -41,10 +55,18 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 	// requestedURLSet/requestedURLList hold the URLs we have requested, to prevent redirect loops. Gets reset when a challenge is handled.
 	requestedURLSet := sets.NewString()
 	requestedURLList := []string{}
+	// handledChallenge is true when the last request responded to a challenge, until a response ends the challenge
+	handledChallenge := false
+	// jar holds the cookies set along the way. Challenging proxies in front of SSO systems commonly keep the
+	// session they authenticated in a cookie before redirecting back to the server.
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return "", err
+	}
 
 	for {
 		// Make the request
-		resp, err := request(rt, requestURL, requestHeaders)
+		resp, err := request(rt, jar, requestURL, requestHeaders)
 		if err != nil {
 			return "", err
 		}
@@ -53,12 +75,12 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 		if resp.StatusCode == http.StatusUnauthorized {
 			if resp.Header.Get("WWW-Authenticate") != "" {
 				if !challengeHandler.CanHandle(resp.Header) {
-					return "", apierrs.NewUnauthorized("unhandled challenge")
+					return "", apierrs.NewUnauthorized(fmt.Sprintf("unhandled challenge (%s), you must obtain an API token by visiting %s", challengeSchemes(resp.Header), tokenRequestURL(clientCfg.Host, resp.Header)))
 				}
 				// Handle a challenge
-				newRequestHeaders, shouldRetry, err := challengeHandler.HandleChallenge(resp.Header)
+				newRequestHeaders, shouldRetry, err := challengeHandler.HandleChallenge(requestURL, resp.Header)
 				if err != nil {
-					return "", err
+					return "", apierrs.NewUnauthorized(fmt.Sprintf("unable to handle the challenge (%s): %v, you must obtain an API token by visiting %s", challengeSchemes(resp.Header), err, tokenRequestURL(clientCfg.Host, resp.Header)))
 				}
 				if !shouldRetry {
 					return "", apierrs.NewUnauthorized("challenger chose not to retry the request")
@@ -69,11 +91,16 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 				requestedURLList = []string{}
 				// Use the response to the challenge as the new headers
 				requestHeaders = newRequestHeaders
+				handledChallenge = true
 				continue
 			}
 
-			// Unauthorized with no challenge
-			unauthorizedError := apierrs.NewUnauthorized("")
+			// Unauthorized with no challenge, the server may point to where a token can be requested instead
+			message := ""
+			if related := relatedLink(resp.Header); len(related) > 0 {
+				message = fmt.Sprintf("you must obtain an API token by visiting %s", related)
+			}
+			unauthorizedError := apierrs.NewUnauthorized(message)
 			// Attempt to read body content and include as an error detail
 			if details, err := ioutil.ReadAll(resp.Body); err == nil && len(details) > 0 {
 				unauthorizedError.(*apierrs.StatusError).ErrStatus.Details = &unversioned.StatusDetails{
@@ -86,8 +113,20 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 			return "", unauthorizedError
 		}
 
+		// The challenge succeeded, the response may carry a last token for the challenge handler to verify
+		if handledChallenge {
+			if err := challengeHandler.CompleteChallenge(requestURL, resp.Header); err != nil {
+				return "", err
+			}
+			handledChallenge = false
+		}
+
 		if resp.StatusCode == http.StatusFound {
-			redirectURL := resp.Header.Get("Location")
+			// proxies may redirect relative to the URL that was requested
+			redirectURL, err := resolveLocation(requestURL, resp.Header.Get("Location"))
+			if err != nil {
+				return "", err
+			}
 
 			// OAuth response case (access_token or error parameter)
 			accessToken, err := oauthAuthorizeResult(redirectURL)
@@ -138,7 +177,52 @@ func oauthAuthorizeResult(location string) (string, error) {
 	return "", nil
 }
 
-func request(rt http.RoundTripper, requestURL string, requestHeaders http.Header) (*http.Response, error) {
+// resolveLocation returns the absolute URL of a Location header returned for requestURL
+func resolveLocation(requestURL, location string) (string, error) {
+	base, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(locationURL).String(), nil
+}
+
+// relatedLinkRegex matches a Link header with the "related" relation, capturing the URL
+var relatedLinkRegex = regexp.MustCompile(`^\s*<([^>]*)>\s*;\s*rel\s*=\s*"?related"?\s*$`)
+
+// relatedLink returns the URL of the first related Link header, if any
+func relatedLink(headers http.Header) string {
+	for _, link := range headers[http.CanonicalHeaderKey("Link")] {
+		if matches := relatedLinkRegex.FindStringSubmatch(link); matches != nil {
+			return matches[1]
+		}
+	}
+	return ""
+}
+
+// tokenRequestURL returns where a token can be requested in a browser
+func tokenRequestURL(host string, headers http.Header) string {
+	if related := relatedLink(headers); len(related) > 0 {
+		return related
+	}
+	return strings.TrimRight(host, "/") + tokenRequestPath
+}
+
+// challengeSchemes returns the authentication schemes of the WWW-Authenticate headers
+func challengeSchemes(headers http.Header) string {
+	schemes := []string{}
+	for _, challenge := range headers[http.CanonicalHeaderKey("WWW-Authenticate")] {
+		if fields := strings.Fields(challenge); len(fields) > 0 {
+			schemes = append(schemes, fields[0])
+		}
+	}
+	return strings.Join(schemes, ", ")
+}
+
+func request(rt http.RoundTripper, jar http.CookieJar, requestURL string, requestHeaders http.Header) (*http.Response, error) {
 	// Build the request
 	req, err := http.NewRequest("GET", requestURL, nil)
 	if err != nil {
@@ -148,7 +232,17 @@ func request(rt http.RoundTripper, requestURL string, requestHeaders http.Header
 		req.Header[k] = v
 	}
 	req.Header.Set(CSRFTokenHeader, "1")
+	for _, cookie := range jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
 
 	// Make the request
-	return rt.RoundTrip(req)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		jar.SetCookies(req.URL, cookies)
+	}
+	return resp, nil
 }
@@ -0,0 +1,77 @@
+package tokencmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+func TestRequestTokenThroughChallengingProxy(t *testing.T) {
+	mux := http.NewServeMux()
+	// the server redirects unauthenticated requests to the challenging proxy, relative to its own URL
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, req *http.Request) {
+		if cookie, err := req.Cookie("sso"); err != nil || cookie.Value != "session" {
+			w.Header().Set("Location", "/challenging-proxy/authorize?"+req.URL.RawQuery)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Header().Set("Location", "/oauth/token/implicit#access_token=token&token_type=Bearer")
+		w.WriteHeader(http.StatusFound)
+	})
+	// the proxy challenges, then keeps the authenticated session in a cookie and redirects back
+	mux.HandleFunc("/challenging-proxy/authorize", func(w http.ResponseWriter, req *http.Request) {
+		if username, password, ok := req.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="sso"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "sso", Value: "session", Path: "/"})
+		w.Header().Set("Location", "../oauth/authorize?"+req.URL.RawQuery)
+		w.WriteHeader(http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, err := RequestToken(&kclient.Config{Host: server.URL}, nil, "user", "pass")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "token" {
+		t.Errorf("expected token, got %q", token)
+	}
+}
+
+func TestRequestTokenUnhandledChallenge(t *testing.T) {
+	testCases := map[string]struct {
+		Headers         http.Header
+		ExpectedMessage string
+	}{
+		"unhandled challenge": {
+			Headers:         http.Header{"Www-Authenticate": []string{`Digest realm="sso"`}},
+			ExpectedMessage: "unhandled challenge (Digest), you must obtain an API token by visiting SERVER/oauth/token/request",
+		},
+		"no challenge with a related link": {
+			Headers:         http.Header{"Link": []string{`<https://example.com/oauth/token/request>; rel="related"`}},
+			ExpectedMessage: "you must obtain an API token by visiting https://example.com/oauth/token/request",
+		},
+	}
+
+	for k, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			for key, values := range testCase.Headers {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+
+		_, err := RequestToken(&kclient.Config{Host: server.URL}, nil, "", "")
+		expected := strings.Replace(testCase.ExpectedMessage, "SERVER", server.URL, -1)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", k, expected, err)
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,86 @@
+package variable
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/dockerregistry"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// DigestResolverFunc returns a pull spec that references the image identified by the provided
+// pull spec by its digest.
+type DigestResolverFunc func(image string) (string, error)
+
+// NewRegistryDigestResolver returns a DigestResolverFunc that looks up the digest of an image
+// in the registry that hosts it.
+func NewRegistryDigestResolver(client dockerregistry.Client) DigestResolverFunc {
+	return func(image string) (string, error) {
+		ref, err := imageapi.ParseDockerImageReference(image)
+		if err != nil {
+			return "", err
+		}
+		if len(ref.ID) > 0 {
+			return image, nil
+		}
+		conn, err := client.Connect(ref.Registry, false)
+		if err != nil {
+			return "", err
+		}
+		dockerImage, err := conn.ImageByTag(ref.Namespace, ref.Name, ref.Tag)
+		if err != nil {
+			return "", err
+		}
+		if !dockerImage.PullByID {
+			return "", fmt.Errorf("the registry hosting %q does not support pulling images by digest", image)
+		}
+		ref.Tag = ""
+		ref.ID = dockerImage.ID
+		return ref.Exact(), nil
+	}
+}
+
+// Pin makes the expansions of the provided components return a reference to their image by digest, as returned
+// by resolve. The digest of an image is resolved when its component is expanded. If it cannot be resolved, the image
+// is returned as expanded and resolving it is retried on the next expansion, so that an unreachable registry does
+// not prevent the components from starting.
+func (t *ImageTemplate) Pin(components []string, resolve DigestResolverFunc) {
+	t.digests = &imageDigests{
+		components: sets.NewString(components...),
+		resolve:    resolve,
+		resolved:   make(map[string]string),
+	}
+}
+
+// imageDigests holds the digests of the images of pinned components once they are resolved
+type imageDigests struct {
+	lock       sync.Mutex
+	components sets.String
+	resolve    DigestResolverFunc
+	// resolved maps images to their reference by digest
+	resolved map[string]string
+}
+
+// pin returns the reference by digest of image, the image of component, if component is pinned and its digest can
+// be resolved. Otherwise it returns image.
+func (d *imageDigests) pin(component, image string) string {
+	if !d.components.Has(component) {
+		return image
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if resolved, ok := d.resolved[image]; ok {
+		return resolved
+	}
+	resolved, err := d.resolve(image)
+	if err != nil {
+		glog.Warningf("Unable to resolve the digest of %q for %q, using it as is until it is resolved: %v", image, component, err)
+		return image
+	}
+	glog.V(2).Infof("Resolved image for %q to %s", component, resolved)
+	d.resolved[image] = resolved
+	return resolved
+}
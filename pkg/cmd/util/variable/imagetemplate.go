@@ -18,6 +18,12 @@ type ImageTemplate struct {
 	// var that matches this format. Is a printf format string accepting a single
 	// string parameter.
 	EnvFormat string
+	// Overrides is optional, if set it maps component names to the image that should be used
+	// for them instead of expanding Format.
+	Overrides map[string]string
+
+	// digests are the digests of the images of the components pinned by Pin
+	digests *imageDigests
 }
 
 const defaultImageFormat = "openshift/origin-${component}:${version}"
@@ -43,6 +49,18 @@ func (t *ImageTemplate) ExpandOrDie(component string) string {
 
 // Expand expands a string using a series of common format functions
 func (t *ImageTemplate) Expand(component string) (string, error) {
+	value, err := t.expand(component)
+	if err != nil || t.digests == nil {
+		return value, err
+	}
+	return t.digests.pin(component, value), nil
+}
+
+// expand returns the image of component before it is pinned
+func (t *ImageTemplate) expand(component string) (string, error) {
+	if image, ok := t.Overrides[component]; ok {
+		return image, nil
+	}
 	template := t.Format
 	if len(t.EnvFormat) > 0 {
 		if s, ok := t.imageComponentEnvExpander(component); ok {
@@ -0,0 +1,61 @@
+package variable
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestImageTemplatePin(t *testing.T) {
+	template := ImageTemplate{Format: "registry.example.com/origin-${component}:v1"}
+	resolved := map[string]string{
+		"registry.example.com/origin-deployer:v1": "registry.example.com/origin-deployer@sha256:abc",
+	}
+	resolve := func(image string) (string, error) {
+		if pinned, ok := resolved[image]; ok {
+			return pinned, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	template.Pin([]string{"deployer", "sti-builder"}, resolve)
+	if image := template.ExpandOrDie("deployer"); image != "registry.example.com/origin-deployer@sha256:abc" {
+		t.Errorf("expected the deployer image to be pinned, got %s", image)
+	}
+	if image := template.ExpandOrDie("pod"); image != "registry.example.com/origin-pod:v1" {
+		t.Errorf("expected the pod image not to be pinned, got %s", image)
+	}
+
+	// an image whose digest cannot be resolved is used as is until it is resolved
+	if image := template.ExpandOrDie("sti-builder"); image != "registry.example.com/origin-sti-builder:v1" {
+		t.Errorf("expected the unresolved sti-builder image to be used by tag, got %s", image)
+	}
+	resolved["registry.example.com/origin-sti-builder:v1"] = "registry.example.com/origin-sti-builder@sha256:def"
+	if image := template.ExpandOrDie("sti-builder"); image != "registry.example.com/origin-sti-builder@sha256:def" {
+		t.Errorf("expected the sti-builder image to be pinned once resolved, got %s", image)
+	}
+
+	// the digest is resolved once
+	delete(resolved, "registry.example.com/origin-deployer:v1")
+	if image := template.ExpandOrDie("deployer"); image != "registry.example.com/origin-deployer@sha256:abc" {
+		t.Errorf("expected the deployer image to stay pinned, got %s", image)
+	}
+}
+
+func TestImageTemplateOverrides(t *testing.T) {
+	template := ImageTemplate{
+		Format:    "registry.example.com/origin-${component}:v1",
+		Overrides: map[string]string{"deployer": "registry.example.com/custom-deployer:v2"},
+	}
+	template.Pin([]string{"deployer"}, func(image string) (string, error) {
+		if image != "registry.example.com/custom-deployer:v2" {
+			return "", errors.New("not found")
+		}
+		return "registry.example.com/custom-deployer@sha256:abc", nil
+	})
+	if image := template.ExpandOrDie("deployer"); image != "registry.example.com/custom-deployer@sha256:abc" {
+		t.Errorf("expected the overridden deployer image to be pinned, got %s", image)
+	}
+	if image := template.ExpandOrDie("pod"); image != "registry.example.com/origin-pod:v1" {
+		t.Errorf("expected the pod image to be expanded, got %s", image)
+	}
+}
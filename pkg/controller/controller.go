@@ -1,15 +1,21 @@
 package controller
 
 import (
+	"sync"
+	"time"
+
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	kcache "k8s.io/kubernetes/pkg/client/cache"
 	kutil "k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/wait"
 )
 
 // RunnableController is a controller which implements a Run loop.
 type RunnableController interface {
 	// Run starts the asynchronous controller loop.
 	Run()
+	// RunUntil starts the asynchronous controller loop, which stops when stopCh is closed.
+	RunUntil(stopCh <-chan struct{})
 }
 
 // RetryController is a RunnableController which delegates resource
@@ -98,8 +104,51 @@ type QueueRetryManager struct {
 	retries map[string]Retry
 
 	// limits how fast retries can be enqueued to ensure you can't tight
-	// loop on retries.
+	// loop on retries. Only used when backoff is nil.
 	limiter kutil.RateLimiter
+
+	// backoff delays the retries of each resource independently of the
+	// others, so a resource that keeps failing does not hold up other work.
+	backoff *Backoff
+
+	// timersLock guards timers.
+	timersLock sync.Mutex
+	// timers maps resources to the timer that will re-queue them after their
+	// backoff delay, so that forgetting a resource cancels its pending retry.
+	timers map[string]*time.Timer
+}
+
+// Backoff describes how long a resource waits before it is retried. The delay
+// starts at Initial and doubles with every retry of the resource up to Max. A
+// random delay of up to Jitter times the delay is added so that resources that
+// failed together are not retried together.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Jitter  float64
+}
+
+// DefaultBackoff is the Backoff used by the controllers of the master.
+var DefaultBackoff = Backoff{
+	Initial: 100 * time.Millisecond,
+	Max:     time.Minute,
+	Jitter:  0.1,
+}
+
+// Delay returns how long to wait before retrying a resource that has already
+// been retried count times.
+func (b Backoff) Delay(count int) time.Duration {
+	delay := b.Initial
+	for i := 0; i < count && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if delay > b.Max {
+		delay = b.Max
+	}
+	if b.Jitter > 0 {
+		delay = wait.Jitter(delay, b.Jitter)
+	}
+	return delay
 }
 
 // Retry describes provides additional information regarding retries.
@@ -128,9 +177,23 @@ func NewQueueRetryManager(queue ReQueue, keyFn kcache.KeyFunc, retryFn RetryFunc
 	}
 }
 
+// NewBackoffQueueRetryManager creates a QueueRetryManager that delays the retries
+// of each resource according to backoff instead of sharing a rate limiter.
+func NewBackoffQueueRetryManager(queue ReQueue, keyFn kcache.KeyFunc, retryFn RetryFunc, backoff Backoff) *QueueRetryManager {
+	return &QueueRetryManager{
+		queue:     queue,
+		keyFunc:   keyFn,
+		retryFunc: retryFn,
+		retries:   make(map[string]Retry),
+		backoff:   &backoff,
+		timers:    make(map[string]*time.Timer),
+	}
+}
+
 // Retry will enqueue resource until retryFunc returns false for that resource has been
 // exceeded, at which point resource will be forgotten and no longer retried. The current
-// retry count will be passed to each invocation of retryFunc.
+// retry count will be passed to each invocation of retryFunc. With a backoff, resource is
+// enqueued after the delay of its retry count without blocking the caller.
 func (r *QueueRetryManager) Retry(resource interface{}, err error) {
 	id, _ := r.keyFunc(resource)
 
@@ -140,10 +203,14 @@ func (r *QueueRetryManager) Retry(resource interface{}, err error) {
 	tries := r.retries[id]
 
 	if r.retryFunc(resource, err, tries) {
-		r.limiter.Accept()
 		// It's important to use AddIfNotPresent to prevent overwriting newer
 		// state in the queue which may have arrived asynchronously.
-		r.queue.AddIfNotPresent(resource)
+		if r.backoff != nil {
+			r.requeueAfter(id, resource, r.backoff.Delay(tries.Count))
+		} else {
+			r.limiter.Accept()
+			r.queue.AddIfNotPresent(resource)
+		}
 		tries.Count = tries.Count + 1
 		r.retries[id] = tries
 	} else {
@@ -151,8 +218,43 @@ func (r *QueueRetryManager) Retry(resource interface{}, err error) {
 	}
 }
 
-// Forget resets the retry count for resource.
+// requeueAfter adds resource to the queue after delay, replacing any pending
+// retry of the resource with key id.
+func (r *QueueRetryManager) requeueAfter(id string, resource interface{}, delay time.Duration) {
+	r.timersLock.Lock()
+	defer r.timersLock.Unlock()
+
+	if timer, ok := r.timers[id]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		r.timersLock.Lock()
+		// the retry was cancelled or replaced by a newer one while the timer fired
+		if r.timers[id] != timer {
+			r.timersLock.Unlock()
+			return
+		}
+		delete(r.timers, id)
+		r.timersLock.Unlock()
+		r.queue.AddIfNotPresent(resource)
+	})
+	r.timers[id] = timer
+}
+
+// Forget resets the retry count for resource and cancels its pending retry, so
+// that a stale copy of resource is not re-queued after it was handled.
 func (r *QueueRetryManager) Forget(resource interface{}) {
 	id, _ := r.keyFunc(resource)
 	delete(r.retries, id)
+
+	if r.backoff == nil {
+		return
+	}
+	r.timersLock.Lock()
+	defer r.timersLock.Unlock()
+	if timer, ok := r.timers[id]; ok {
+		timer.Stop()
+		delete(r.timers, id)
+	}
 }
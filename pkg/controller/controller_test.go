@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	kcache "k8s.io/kubernetes/pkg/client/cache"
 	kutil "k8s.io/kubernetes/pkg/util"
@@ -181,6 +182,100 @@ func TestRetryController_ratelimit(t *testing.T) {
 	}
 }
 
+func TestBackoffDelay(t *testing.T) {
+	backoff := Backoff{Initial: time.Second, Max: 10 * time.Second}
+	for count, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		if delay := backoff.Delay(count); delay != expected {
+			t.Errorf("expected a delay of %v after %d retries, got %v", expected, count, delay)
+		}
+	}
+
+	backoff.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if delay := backoff.Delay(10); delay < 10*time.Second || delay > 15*time.Second {
+			t.Fatalf("expected a delay between 10s and 15s, got %v", delay)
+		}
+	}
+}
+
+func TestQueueRetryManager_backoff(t *testing.T) {
+	keyFunc := func(obj interface{}) (string, error) {
+		return obj.(string), nil
+	}
+	fifo := kcache.NewFIFO(keyFunc)
+	retryManager := NewBackoffQueueRetryManager(fifo,
+		keyFunc,
+		func(_ interface{}, _ error, r Retry) bool {
+			return r.Count < 15
+		},
+		Backoff{Initial: 10 * time.Millisecond, Max: time.Hour},
+	)
+
+	// a resource that keeps failing waits longer and longer without delaying
+	// the retry of another resource
+	retryManager.retries["failing"] = Retry{Count: 10}
+	retryManager.Retry("failing", nil)
+	retryManager.Retry("other", nil)
+	if count := retryManager.retries["failing"].Count; count != 11 {
+		t.Fatalf("expected 11 retries, got %d", count)
+	}
+
+	done := make(chan interface{})
+	go func() { done <- fifo.Pop() }()
+	select {
+	case obj := <-done:
+		if obj != "other" {
+			t.Fatalf("expected other to be retried first, got %v", obj)
+		}
+	case <-time.After(kutil.ForeverTestTimeout):
+		t.Fatalf("other was not retried while failing was backing off")
+	}
+	if keys := fifo.ListKeys(); len(keys) != 0 {
+		t.Errorf("expected failing to be backing off, got %v", keys)
+	}
+}
+
+func TestQueueRetryManager_forgetCancelsRetry(t *testing.T) {
+	keyFunc := func(obj interface{}) (string, error) {
+		return obj.(*testObj).id, nil
+	}
+	fifo := kcache.NewFIFO(keyFunc)
+	retryManager := NewBackoffQueueRetryManager(fifo,
+		keyFunc,
+		func(_ interface{}, _ error, r Retry) bool {
+			return true
+		},
+		Backoff{Initial: 10 * time.Millisecond, Max: time.Hour},
+	)
+
+	// a stale copy of a resource that was handled after its retry was
+	// scheduled must not be re-queued
+	retryManager.Retry(&testObj{"a", 1}, nil)
+	retryManager.Forget(&testObj{"a", 2})
+	if len(retryManager.timers) != 0 {
+		t.Fatalf("expected the pending retry to be cancelled, got %v", retryManager.timers)
+	}
+
+	// a newer retry replaces the pending one
+	retryManager.Retry(&testObj{"b", 1}, nil)
+	retryManager.Retry(&testObj{"b", 2}, nil)
+	done := make(chan interface{})
+	go func() { done <- fifo.Pop() }()
+	select {
+	case obj := <-done:
+		if e, a := (&testObj{"b", 2}), obj.(*testObj); *e != *a {
+			t.Fatalf("expected %#v to be retried, got %#v", e, a)
+		}
+	case <-time.After(kutil.ForeverTestTimeout):
+		t.Fatalf("b was not retried")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if keys := fifo.ListKeys(); len(keys) != 0 {
+		t.Errorf("expected no other retries, got %v", keys)
+	}
+}
+
 type mockLimiter struct {
 	count int
 }
@@ -6,8 +6,8 @@ import (
 )
 
 // DeploymentToPodLogOptions builds a PodLogOptions object out of a DeploymentLogOptions.
-// Currently DeploymentLogOptions.Container and DeploymentLogOptions.Previous aren't used
-// so they won't be copied to PodLogOptions.
+// DeploymentLogOptions.Container isn't used and DeploymentLogOptions.Previous selects
+// a deployment rather than a container instance, so neither is copied to PodLogOptions.
 func DeploymentToPodLogOptions(opts *DeploymentLogOptions) *kapi.PodLogOptions {
 	return &kapi.PodLogOptions{
 		Follow:       opts.Follow,
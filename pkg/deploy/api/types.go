@@ -62,6 +62,9 @@ type CustomDeploymentStrategyParams struct {
 	Environment []kapi.EnvVar
 	// Command is optional and overrides CMD in the container Image.
 	Command []string
+	// ServiceAccountName is the name of the service account the deployer pod runs as. If
+	// empty, the deployer service account configured on the master is used.
+	ServiceAccountName string
 }
 
 // RecreateDeploymentStrategyParams are the input to the Recreate deployment
@@ -218,6 +221,12 @@ const (
 	// DeploymentReplicasAnnotation is for internal use only and is for
 	// detecting external modifications to deployment replica counts.
 	DeploymentReplicasAnnotation = "openshift.io/deployment.replicas"
+	// DeploymentConfigPausedAnnotation marks a DeploymentConfig as paused. Image changes are only recorded
+	// on the image change triggers of a paused DeploymentConfig and are deployed once it is resumed.
+	DeploymentConfigPausedAnnotation = "openshift.io/deployment-config.paused"
+	// DeploymentConfigImageChangePendingAnnotation marks a DeploymentConfig whose image change triggers
+	// recorded a new image while it was paused, until that image is deployed.
+	DeploymentConfigImageChangePendingAnnotation = "openshift.io/deployment-config.image-change-pending"
 )
 
 // These constants represent the various reasons for cancelling a deployment
@@ -388,7 +397,8 @@ type DeploymentLogOptions struct {
 	// Follow if true indicates that the deployment log should be streamed until
 	// the deployment terminates.
 	Follow bool
-	// If true, return previous terminated container logs
+	// Previous if true returns the logs of the deployment prior to the latest one.
+	// It cannot be used with Version.
 	Previous bool
 	// A relative time in seconds before the current time from which to show logs. If this value
 	// precedes the time a pod was started, only logs since the pod start will be returned.
@@ -62,6 +62,9 @@ type CustomDeploymentStrategyParams struct {
 	Environment []kapi.EnvVar `json:"environment,omitempty" description:"environment variables provided to the deployment process container"`
 	// Command is optional and overrides CMD in the container Image.
 	Command []string `json:"command,omitempty" description:"optionally overrides the container command (default is specified by the image)"`
+	// ServiceAccountName is the name of the service account the deployer pod runs as. If
+	// empty, the deployer service account configured on the master is used.
+	ServiceAccountName string `json:"serviceAccountName,omitempty" description:"the service account the deployer pod runs as; defaults to the deployer service account"`
 }
 
 // RecreateDeploymentStrategyParams are the input to the Recreate deployment
@@ -379,7 +382,7 @@ type DeploymentLogOptions struct {
 	// the build terminates.
 	Follow bool `json:"follow,omitempty" description:"if true indicates that the log should be streamed; defaults to false"`
 	// Return previous terminated container logs. Defaults to false.
-	Previous bool `json:"previous,omitempty" description:"return previous terminated container logs; defaults to false."`
+	Previous bool `json:"previous,omitempty" description:"return previous deployment logs; defaults to false."`
 	// A relative time in seconds before the current time from which to show logs. If this value
 	// precedes the time a pod was started, only logs since the pod start will be returned.
 	// If this value is in the future, no logs will be returned.
@@ -8,6 +8,7 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/fielderrors"
 	kvalidation "k8s.io/kubernetes/pkg/util/validation"
@@ -18,6 +19,12 @@ import (
 )
 
 func ValidateDeploymentConfig(config *deployapi.DeploymentConfig) fielderrors.ValidationErrorList {
+	allErrs := validateDeploymentConfig(config)
+	allErrs = append(allErrs, validateSelectorMatchesTemplate(config)...)
+	return allErrs
+}
+
+func validateDeploymentConfig(config *deployapi.DeploymentConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 	allErrs = append(allErrs, validation.ValidateObjectMeta(&config.ObjectMeta, true, validation.NameIsDNSSubdomain).Prefix("metadata")...)
 
@@ -43,10 +50,37 @@ func ValidateDeploymentConfig(config *deployapi.DeploymentConfig) fielderrors.Va
 	return allErrs
 }
 
+// validateSelectorMatchesTemplate rejects a selector that does not match the labels of the pod template, since the
+// deployments would never see the pods they create
+func validateSelectorMatchesTemplate(config *deployapi.DeploymentConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	if len(config.Spec.Selector) == 0 || config.Spec.Template == nil {
+		return allErrs
+	}
+	selector := labels.Set(config.Spec.Selector).AsSelector()
+	if !selector.Matches(labels.Set(config.Spec.Template.Labels)) {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("spec.template.metadata.labels", config.Spec.Template.Labels, "selector does not match labels in spec.template"))
+	}
+	return allErrs
+}
+
+// templateLabels returns the labels of the pod template of config
+func templateLabels(config *deployapi.DeploymentConfig) map[string]string {
+	if config.Spec.Template == nil {
+		return nil
+	}
+	return config.Spec.Template.Labels
+}
+
 func ValidateDeploymentConfigUpdate(newConfig *deployapi.DeploymentConfig, oldConfig *deployapi.DeploymentConfig) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&newConfig.ObjectMeta, &oldConfig.ObjectMeta).Prefix("metadata")...)
-	allErrs = append(allErrs, ValidateDeploymentConfig(newConfig)...)
+	allErrs = append(allErrs, validateDeploymentConfig(newConfig)...)
+	// configs created before the selector had to match the template may still be updated, as long as the selector
+	// and the labels are left alone
+	if !kapi.Semantic.DeepEqual(newConfig.Spec.Selector, oldConfig.Spec.Selector) || !kapi.Semantic.DeepEqual(templateLabels(newConfig), templateLabels(oldConfig)) {
+		allErrs = append(allErrs, validateSelectorMatchesTemplate(newConfig)...)
+	}
 	if newConfig.Status.LatestVersion < oldConfig.Status.LatestVersion {
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("status.latestVersion", newConfig.Status.LatestVersion, "latestVersion cannot be decremented"))
 	} else if newConfig.Status.LatestVersion > (oldConfig.Status.LatestVersion + 1) {
@@ -118,6 +152,16 @@ func validateCustomParams(params *deployapi.CustomDeploymentStrategyParams) fiel
 		errs = append(errs, fielderrors.NewFieldRequired("image"))
 	}
 
+	if len(params.Environment) > 0 {
+		errs = append(errs, validateEnv(params.Environment).Prefix("environment")...)
+	}
+
+	if len(params.ServiceAccountName) > 0 {
+		if ok, msg := validation.ValidateServiceAccountName(params.ServiceAccountName, false); !ok {
+			errs = append(errs, fielderrors.NewFieldInvalid("serviceAccountName", params.ServiceAccountName, msg))
+		}
+	}
+
 	return errs
 }
 
@@ -364,6 +408,9 @@ func ValidateDeploymentLogOptions(opts *deployapi.DeploymentLogOptions) fielderr
 	if opts.Version != nil && *opts.Version <= 0 {
 		allErrs = append(allErrs, fielderrors.NewFieldInvalid("version", *opts.Version, "deployment version must be greater than 0"))
 	}
+	if opts.Version != nil && opts.Previous {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("previous", opts.Previous, "cannot use previous when a version is specified"))
+	}
 
 	return allErrs
 }
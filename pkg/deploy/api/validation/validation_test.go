@@ -208,6 +208,20 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			fielderrors.ValidationErrorTypeRequired,
 			"spec.triggers[0].imageChangeParams.containerNames",
 		},
+		"selector not matching template labels": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Triggers: manualTrigger(),
+					Selector: map[string]string{"a": "c"},
+					Strategy: test.OkStrategy(),
+					Template: test.OkPodTemplate(),
+				},
+			},
+			fielderrors.ValidationErrorTypeInvalid,
+			"spec.template.metadata.labels",
+		},
 		"missing strategy.type": {
 			api.DeploymentConfig{
 				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
@@ -257,6 +271,26 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			fielderrors.ValidationErrorTypeRequired,
 			"spec.strategy.customParams.image",
 		},
+		"invalid spec.strategy.customParams.serviceAccountName": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Triggers: manualTrigger(),
+					Selector: test.OkSelector(),
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeCustom,
+						CustomParams: &api.CustomDeploymentStrategyParams{
+							Image:              "registry:8080/repo1:ref1",
+							ServiceAccountName: "Not_Valid",
+						},
+					},
+					Template: test.OkPodTemplate(),
+				},
+			},
+			fielderrors.ValidationErrorTypeInvalid,
+			"spec.strategy.customParams.serviceAccountName",
+		},
 		"missing spec.strategy.recreateParams.pre.failurePolicy": {
 			api.DeploymentConfig{
 				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
@@ -542,6 +576,40 @@ func TestValidateDeploymentConfigUpdate(t *testing.T) {
 	}
 }
 
+func TestValidateDeploymentConfigUpdateSelectorMismatch(t *testing.T) {
+	mismatched := func() *api.DeploymentConfig {
+		return &api.DeploymentConfig{
+			ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar", ResourceVersion: "1"},
+			Spec: api.DeploymentConfigSpec{
+				Replicas: 1,
+				Triggers: manualTrigger(),
+				Selector: map[string]string{"name": "other"},
+				Strategy: test.OkStrategy(),
+				Template: test.OkPodTemplate(),
+			},
+		}
+	}
+
+	// a config whose selector already did not match the template may still be scaled
+	oldConfig, newConfig := mismatched(), mismatched()
+	newConfig.Spec.Replicas = 2
+	if errs := ValidateDeploymentConfigUpdate(newConfig, oldConfig); len(errs) != 0 {
+		t.Errorf("unexpected update failure: %v", errs)
+	}
+
+	// changing the selector or the labels of the template requires them to match
+	newConfig = mismatched()
+	newConfig.Spec.Selector = map[string]string{"name": "another"}
+	if errs := ValidateDeploymentConfigUpdate(newConfig, oldConfig); len(errs) != 1 || errs[0].(*fielderrors.ValidationError).Field != "spec.template.metadata.labels" {
+		t.Errorf("expected a selector mismatch error, got %v", errs)
+	}
+	newConfig = mismatched()
+	newConfig.Spec.Template.Labels = map[string]string{"name": "another"}
+	if errs := ValidateDeploymentConfigUpdate(newConfig, oldConfig); len(errs) != 1 || errs[0].(*fielderrors.ValidationError).Field != "spec.template.metadata.labels" {
+		t.Errorf("expected a selector mismatch error, got %v", errs)
+	}
+}
+
 func TestValidateDeploymentConfigRollbackOK(t *testing.T) {
 	rollback := &api.DeploymentConfigRollback{
 		Spec: api.DeploymentConfigRollbackSpec{
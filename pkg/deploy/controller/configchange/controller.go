@@ -8,6 +8,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 
+	osclient "github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 )
@@ -93,26 +94,41 @@ func (c *DeploymentConfigChangeController) generateDeployment(config *deployapi.
 		return config.Status.LatestVersion, 0, err
 	}
 
-	if newConfig.Status.LatestVersion == config.Status.LatestVersion {
-		newConfig.Status.LatestVersion++
-	}
+	// If the update conflicts, the config is generated again from its latest version. A deployment
+	// started by someone else in the meantime is left alone.
+	superseded := false
+	var updatedConfig *deployapi.DeploymentConfig
+	err = osclient.UpdateWithRetries(
+		func() (err error) {
+			newConfig, err = c.changeStrategy.generateDeploymentConfig(config.Namespace, config.Name)
+			superseded = err == nil && newConfig.Status.LatestVersion != config.Status.LatestVersion
+			return
+		},
+		func() (err error) {
+			if superseded {
+				updatedConfig = newConfig
+				return nil
+			}
+			if newConfig.Status.LatestVersion == config.Status.LatestVersion {
+				newConfig.Status.LatestVersion++
+			}
 
-	// set the trigger details for the new deployment config
-	causes := []*deployapi.DeploymentCause{}
-	causes = append(causes,
-		&deployapi.DeploymentCause{
-			Type: deployapi.DeploymentTriggerOnConfigChange,
-		})
-	newConfig.Status.Details = &deployapi.DeploymentDetails{
-		Causes: causes,
-	}
+			// set the trigger details for the new deployment config
+			causes := []*deployapi.DeploymentCause{}
+			causes = append(causes,
+				&deployapi.DeploymentCause{
+					Type: deployapi.DeploymentTriggerOnConfigChange,
+				})
+			newConfig.Status.Details = &deployapi.DeploymentDetails{
+				Causes: causes,
+			}
 
-	// This update is atomic. If it fails because a newer resource was already persisted, that's
-	// okay - we can just ignore the update for the old resource and any changes to the more
-	// current config will be captured in future events.
-	updatedConfig, err := c.changeStrategy.updateDeploymentConfig(config.Namespace, newConfig)
+			updatedConfig, err = c.changeStrategy.updateDeploymentConfig(config.Namespace, newConfig)
+			return
+		},
+	)
 	if err != nil {
-		return config.Status.LatestVersion, newConfig.Status.LatestVersion, err
+		return config.Status.LatestVersion, 0, err
 	}
 
 	return config.Status.LatestVersion, updatedConfig.Status.LatestVersion, nil
@@ -6,6 +6,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 
 	api "github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployapitest "github.com/openshift/origin/pkg/deploy/api/test"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
@@ -170,3 +171,75 @@ func TestHandle_changeWithTemplateDiff(t *testing.T) {
 		}
 	}
 }
+
+// TestHandle_changeConflict ensures that a config is generated again when the
+// update of a new version conflicts, unless a newer version was deployed in
+// the meantime.
+func TestHandle_changeConflict(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		latestVersion   int
+		expectedUpdates int
+	}{
+		{
+			name:            "config updated meanwhile",
+			latestVersion:   1,
+			expectedUpdates: 2,
+		},
+		{
+			name:            "config deployed meanwhile",
+			latestVersion:   2,
+			expectedUpdates: 1,
+		},
+	}
+
+	for _, s := range scenarios {
+		config := deployapitest.OkDeploymentConfig(1)
+		config.Spec.Triggers = []deployapi.DeploymentTriggerPolicy{deployapitest.OkConfigChangeTrigger()}
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codec)
+		config.Spec.Template.Labels["newkey"] = "value"
+
+		generated := 0
+		updates := []*deployapi.DeploymentConfig{}
+		conflicts := testclient.NewConflicts(1)
+		controller := &DeploymentConfigChangeController{
+			decodeConfig: func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error) {
+				return deployutil.DecodeDeploymentConfig(deployment, api.Codec)
+			},
+			changeStrategy: &changeStrategyImpl{
+				generateDeploymentConfigFunc: func(namespace, name string) (*deployapi.DeploymentConfig, error) {
+					generated++
+					if generated == 1 {
+						return deployapitest.OkDeploymentConfig(1), nil
+					}
+					return deployapitest.OkDeploymentConfig(s.latestVersion), nil
+				},
+				updateDeploymentConfigFunc: func(namespace string, config *deployapi.DeploymentConfig) (*deployapi.DeploymentConfig, error) {
+					updates = append(updates, config)
+					if err := conflicts("DeploymentConfig", config.Name); err != nil {
+						return nil, err
+					}
+					return config, nil
+				},
+				getDeploymentFunc: func(namespace, name string) (*kapi.ReplicationController, error) {
+					return deployment, nil
+				},
+			},
+		}
+
+		if err := controller.Handle(config); err != nil {
+			t.Errorf("%s: unexpected error: %v", s.name, err)
+			continue
+		}
+		if e, a := 2, generated; e != a {
+			t.Errorf("%s: expected the config to be generated %d times, got %d", s.name, e, a)
+		}
+		if e, a := s.expectedUpdates, len(updates); e != a {
+			t.Errorf("%s: expected %d updates, got %d", s.name, e, a)
+			continue
+		}
+		if e, a := 2, updates[len(updates)-1].Status.LatestVersion; e != a {
+			t.Errorf("%s: expected update to latestversion=%d, got %d", s.name, e, a)
+		}
+	}
+}
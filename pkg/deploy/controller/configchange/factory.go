@@ -65,7 +65,7 @@ func (factory *DeploymentConfigChangeControllerFactory) Create() controller.Runn
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
@@ -78,7 +78,7 @@ func (factory *DeploymentConfigChangeControllerFactory) Create() controller.Runn
 				}
 				return true
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			config := obj.(*deployapi.DeploymentConfig)
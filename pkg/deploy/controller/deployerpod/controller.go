@@ -8,6 +8,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 
+	osclient "github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 )
@@ -75,6 +76,7 @@ func (c *DeployerPodController) Handle(pod *kapi.Pod) error {
 
 	currentStatus := deployutil.DeploymentStatusFor(deployment)
 	nextStatus := currentStatus
+	syncReplicas := false
 
 	switch pod.Status.Phase {
 	case kapi.PodRunning:
@@ -90,19 +92,29 @@ func (c *DeployerPodController) Handle(pod *kapi.Pod) error {
 				break
 			}
 		}
-		// Sync the internal replica annotation with the target so that we can
-		// distinguish deployer updates from other scaling events.
-		deployment.Annotations[deployapi.DeploymentReplicasAnnotation] = deployment.Annotations[deployapi.DesiredReplicasAnnotation]
-		if nextStatus == deployapi.DeploymentStatusComplete {
-			delete(deployment.Annotations, deployapi.DesiredReplicasAnnotation)
-		}
+		syncReplicas = true
 	case kapi.PodFailed:
 		nextStatus = deployapi.DeploymentStatusFailed
 	}
 
 	if currentStatus != nextStatus {
-		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(nextStatus)
-		if _, err := c.deploymentClient.updateDeployment(deployment.Namespace, deployment); err != nil {
+		err := osclient.UpdateReplicationControllerWithRetries(c.deploymentClient.getDeployment, c.deploymentClient.updateDeployment, deployment, func(deployment *kapi.ReplicationController) bool {
+			// The status of the deployment may have changed in the meantime.
+			if deployutil.DeploymentStatusFor(deployment) != currentStatus {
+				return false
+			}
+			if syncReplicas {
+				// Sync the internal replica annotation with the target so that we can
+				// distinguish deployer updates from other scaling events.
+				deployment.Annotations[deployapi.DeploymentReplicasAnnotation] = deployment.Annotations[deployapi.DesiredReplicasAnnotation]
+				if nextStatus == deployapi.DeploymentStatusComplete {
+					delete(deployment.Annotations, deployapi.DesiredReplicasAnnotation)
+				}
+			}
+			deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(nextStatus)
+			return true
+		})
+		if err != nil {
 			if kerrors.IsNotFound(err) {
 				return nil
 			}
@@ -7,6 +7,7 @@ import (
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
@@ -248,6 +249,78 @@ func TestHandle_cleanupDesiredReplicasAnnotation(t *testing.T) {
 	}
 }
 
+// TestHandle_updateConflict ensures that the status of a deployment is
+// updated on its latest version if the update conflicts, unless the status
+// changed in the meantime.
+func TestHandle_updateConflict(t *testing.T) {
+	tests := []struct {
+		name            string
+		latestStatus    deployapi.DeploymentStatus
+		expectedUpdates int
+	}{
+		{
+			name:            "deployment updated meanwhile",
+			latestStatus:    deployapi.DeploymentStatusRunning,
+			expectedUpdates: 2,
+		},
+		{
+			name:            "deployment failed meanwhile",
+			latestStatus:    deployapi.DeploymentStatusFailed,
+			expectedUpdates: 1,
+		},
+	}
+
+	for _, test := range tests {
+		deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codec)
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusRunning)
+		deployment.Annotations[deployapi.DesiredReplicasAnnotation] = "1"
+		latest, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codec)
+		latest.Annotations[deployapi.DeploymentStatusAnnotation] = string(test.latestStatus)
+		latest.Annotations[deployapi.DesiredReplicasAnnotation] = "3"
+
+		gets := 0
+		updates := []kapi.ReplicationController{}
+		conflicts := testclient.NewConflicts(1)
+		controller := &DeployerPodController{
+			deploymentClient: &deploymentClientImpl{
+				getDeploymentFunc: func(namespace, name string) (*kapi.ReplicationController, error) {
+					gets++
+					if gets == 1 {
+						return deployment, nil
+					}
+					return latest, nil
+				},
+				updateDeploymentFunc: func(namespace string, deployment *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+					updates = append(updates, *deployment)
+					if err := conflicts("ReplicationController", deployment.Name); err != nil {
+						return nil, err
+					}
+					return deployment, nil
+				},
+			},
+		}
+
+		if err := controller.Handle(succeededPod(deployment)); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if e, a := test.expectedUpdates, len(updates); e != a {
+			t.Errorf("%s: expected %d updates, got %d", test.name, e, a)
+			continue
+		}
+		if test.expectedUpdates == 1 {
+			continue
+		}
+		updated := updates[1]
+		if e, a := deployapi.DeploymentStatusComplete, deployutil.DeploymentStatusFor(&updated); e != a {
+			t.Errorf("%s: expected updated deployment status %s, got %s", test.name, e, a)
+		}
+		if e, a := "3", updated.Annotations[deployapi.DeploymentReplicasAnnotation]; e != a {
+			t.Errorf("%s: expected the replicas annotation %s of the latest deployment, got %s", test.name, e, a)
+		}
+	}
+}
+
 func okPod(deployment *kapi.ReplicationController) *kapi.Pod {
 	return &kapi.Pod{
 		ObjectMeta: kapi.ObjectMeta{
@@ -82,7 +82,7 @@ func (factory *DeployerPodControllerFactory) Create() controller.RunnableControl
 
 	return &controller.RetryController{
 		Queue: podQueue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			podQueue,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
@@ -97,7 +97,7 @@ func (factory *DeployerPodControllerFactory) Create() controller.RunnableControl
 				}
 				return true
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			pod := obj.(*kapi.Pod)
@@ -10,6 +10,7 @@ import (
 	"k8s.io/kubernetes/pkg/client/record"
 	kutil "k8s.io/kubernetes/pkg/util"
 
+	osclient "github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	"github.com/openshift/origin/pkg/util"
@@ -38,6 +39,9 @@ type DeploymentController struct {
 	// decodeConfig knows how to decode the deploymentConfig from a deployment's annotations.
 	decodeConfig func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error)
 	recorder     record.EventRecorder
+	// loggingAnnotationPrefix, if set, is prepended to the names of the annotations added to deployer pods for
+	// aggregated logging
+	loggingAnnotationPrefix string
 }
 
 // fatalError is an error which can't be retried.
@@ -144,8 +148,11 @@ func (c *DeploymentController) Handle(deployment *kapi.ReplicationController) er
 			for _, deployerPod := range deployerPods {
 				// Set the ActiveDeadlineSeconds on the pod so it's terminated very soon.
 				if deployerPod.Spec.ActiveDeadlineSeconds == nil || *deployerPod.Spec.ActiveDeadlineSeconds != zeroDelay {
-					deployerPod.Spec.ActiveDeadlineSeconds = &zeroDelay
-					if _, err := c.podClient.updatePod(deployerPod.Namespace, &deployerPod); err != nil {
+					err := osclient.UpdatePodWithRetries(c.podClient.getPod, c.podClient.updatePod, &deployerPod, func(pod *kapi.Pod) bool {
+						pod.Spec.ActiveDeadlineSeconds = &zeroDelay
+						return true
+					})
+					if err != nil {
 						c.recorder.Eventf(deployment, "failedCancellation", "Error cancelling deployer pod %s for deployment %s: %v", deployerPod.Name, deployutil.LabelForDeployment(deployment), err)
 						return fmt.Errorf("couldn't cancel deployer pod %s for deployment %s: %v", deployerPod.Name, deployutil.LabelForDeployment(deployment), err)
 					}
@@ -187,7 +194,7 @@ func (c *DeploymentController) Handle(deployment *kapi.ReplicationController) er
 
 	if currentStatus != nextStatus {
 		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(nextStatus)
-		if _, err := c.deploymentClient.updateDeployment(deployment.Namespace, deployment); err != nil {
+		if err := c.updateDeploymentStatus(deployment, currentStatus); err != nil {
 			c.recorder.Eventf(deployment, "FailedUpdate", "Error updating deployment %s status to %s", deployutil.LabelForDeployment(deployment), nextStatus)
 			return fmt.Errorf("couldn't update deployment %s to status %s: %v", deployutil.LabelForDeployment(deployment), nextStatus, err)
 		}
@@ -196,6 +203,31 @@ func (c *DeploymentController) Handle(deployment *kapi.ReplicationController) er
 	return nil
 }
 
+// updateDeploymentStatus updates deployment with the annotations set while handling it. If the update conflicts,
+// the annotations are carried over to the latest version of the deployment, unless its status moved on from
+// currentStatus in the meantime.
+func (c *DeploymentController) updateDeploymentStatus(deployment *kapi.ReplicationController, currentStatus deployapi.DeploymentStatus) error {
+	annotations := deployment.Annotations
+	return osclient.UpdateReplicationControllerWithRetries(c.deploymentClient.getDeployment, c.deploymentClient.updateDeployment, deployment, func(latest *kapi.ReplicationController) bool {
+		if latest == deployment {
+			return true
+		}
+		if deployutil.DeploymentStatusFor(latest) != currentStatus {
+			glog.V(4).Infof("Not updating deployment %s whose status changed to %s", deployutil.LabelForDeployment(latest), deployutil.DeploymentStatusFor(latest))
+			return false
+		}
+		if latest.Annotations == nil {
+			latest.Annotations = make(map[string]string)
+		}
+		for _, key := range []string{deployapi.DeploymentPodAnnotation, deployapi.DeploymentStatusReasonAnnotation, deployapi.DeploymentStatusAnnotation} {
+			if value, ok := annotations[key]; ok {
+				latest.Annotations[key] = value
+			}
+		}
+		return true
+	})
+}
+
 // makeDeployerPod creates a pod which implements deployment behavior. The pod is correlated to
 // the deployment with an annotation.
 func (c *DeploymentController) makeDeployerPod(deployment *kapi.ReplicationController) (*kapi.Pod, error) {
@@ -220,6 +252,12 @@ func (c *DeploymentController) makeDeployerPod(deployment *kapi.ReplicationContr
 	// Assigning to a variable since its address is required
 	maxDeploymentDurationSeconds := deployapi.MaxDeploymentDurationSeconds
 
+	// Custom strategies may run the deployer pod as a different service account.
+	serviceAccount := c.serviceAccount
+	if params := deploymentConfig.Spec.Strategy.CustomParams; params != nil && len(params.ServiceAccountName) > 0 {
+		serviceAccount = params.ServiceAccountName
+	}
+
 	pod := &kapi.Pod{
 		ObjectMeta: kapi.ObjectMeta{
 			Name: deployutil.DeployerPodNameForDeployment(deployment.Name),
@@ -246,19 +284,40 @@ func (c *DeploymentController) makeDeployerPod(deployment *kapi.ReplicationContr
 			// on the same set of nodes as the pods.
 			NodeSelector:       deployment.Spec.Template.Spec.NodeSelector,
 			RestartPolicy:      kapi.RestartPolicyNever,
-			ServiceAccountName: c.serviceAccount,
+			ServiceAccountName: serviceAccount,
 		},
 	}
 
 	// MergeInfo will not overwrite values unless the flag OverwriteExistingDstKey is set.
 	util.MergeInto(pod.Labels, deploymentConfig.Spec.Strategy.Labels, 0)
 	util.MergeInto(pod.Annotations, deploymentConfig.Spec.Strategy.Annotations, 0)
+	c.setLoggingAnnotations(deploymentConfig, pod)
 
 	pod.Spec.Containers[0].ImagePullPolicy = kapi.PullIfNotPresent
 
 	return pod, nil
 }
 
+// setLoggingAnnotations annotates the deployer pod of a deployment with the application and deployment config, so
+// that aggregated logging can index the logs of deployments. The annotations set by the strategy are kept.
+func (c *DeploymentController) setLoggingAnnotations(config *deployapi.DeploymentConfig, pod *kapi.Pod) {
+	if len(c.loggingAnnotationPrefix) == 0 {
+		return
+	}
+
+	values := map[string]string{
+		"app":              config.Labels["app"],
+		"deploymentconfig": config.Name,
+	}
+	for name, value := range values {
+		key := c.loggingAnnotationPrefix + name
+		if _, exists := pod.Annotations[key]; exists || len(value) == 0 {
+			continue
+		}
+		pod.Annotations[key] = value
+	}
+}
+
 // deploymentClient abstracts access to deployments.
 type deploymentClient interface {
 	getDeployment(namespace, name string) (*kapi.ReplicationController, error)
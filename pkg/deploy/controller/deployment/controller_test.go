@@ -12,6 +12,7 @@ import (
 	"k8s.io/kubernetes/pkg/client/record"
 
 	api "github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
@@ -651,6 +652,145 @@ func TestHandle_deployerPodDisappeared(t *testing.T) {
 	}
 }
 
+// TestHandle_updateConflict ensures that the status of a deployment is
+// updated on its latest version if the update conflicts, unless the status
+// changed in the meantime.
+func TestHandle_updateConflict(t *testing.T) {
+	tests := []struct {
+		name            string
+		latestStatus    deployapi.DeploymentStatus
+		expectedUpdates int
+	}{
+		{
+			name:            "deployment updated meanwhile",
+			latestStatus:    deployapi.DeploymentStatusRunning,
+			expectedUpdates: 2,
+		},
+		{
+			name:            "deployment completed meanwhile",
+			latestStatus:    deployapi.DeploymentStatusComplete,
+			expectedUpdates: 1,
+		},
+	}
+
+	for _, test := range tests {
+		latest, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codec)
+		latest.Annotations[deployapi.DeploymentStatusAnnotation] = string(test.latestStatus)
+		latest.Annotations["updated"] = "meanwhile"
+
+		updates := []kapi.ReplicationController{}
+		conflicts := testclient.NewConflicts(1)
+		controller := &DeploymentController{
+			decodeConfig: func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error) {
+				return deployutil.DecodeDeploymentConfig(deployment, api.Codec)
+			},
+			deploymentClient: &deploymentClientImpl{
+				getDeploymentFunc: func(namespace, name string) (*kapi.ReplicationController, error) {
+					return latest, nil
+				},
+				updateDeploymentFunc: func(namespace string, deployment *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+					updates = append(updates, *deployment)
+					if err := conflicts("ReplicationController", deployment.Name); err != nil {
+						return nil, err
+					}
+					return deployment, nil
+				},
+			},
+			podClient: &podClientImpl{
+				getPodFunc: func(namespace, name string) (*kapi.Pod, error) {
+					return nil, kerrors.NewNotFound("Pod", name)
+				},
+			},
+			makeContainer: func(strategy *deployapi.DeploymentStrategy) (*kapi.Container, error) {
+				return okContainer(), nil
+			},
+			recorder: &record.FakeRecorder{},
+		}
+
+		deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codec)
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusRunning)
+		if err := controller.Handle(deployment); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if e, a := test.expectedUpdates, len(updates); e != a {
+			t.Errorf("%s: expected %d updates, got %d", test.name, e, a)
+			continue
+		}
+		if test.expectedUpdates == 1 {
+			continue
+		}
+		updated := updates[1]
+		if e, a := deployapi.DeploymentStatusFailed, deployutil.DeploymentStatusFor(&updated); e != a {
+			t.Errorf("%s: expected deployment status %s, got %s", test.name, e, a)
+		}
+		if e, a := deployapi.DeploymentFailedDeployerPodNoLongerExists, updated.Annotations[deployapi.DeploymentStatusReasonAnnotation]; e != a {
+			t.Errorf("%s: expected status reason %s, got %s", test.name, e, a)
+		}
+		if _, ok := updated.Annotations["updated"]; !ok {
+			t.Errorf("%s: expected the latest version of the deployment to be updated, got %#v", test.name, updated.Annotations)
+		}
+	}
+}
+
+// TestHandle_cancelPodConflict ensures that the deadline of a deployer pod is
+// set on its latest version if the update conflicts.
+func TestHandle_cancelPodConflict(t *testing.T) {
+	latest := ttlNonZeroPod()
+	latest.Annotations = map[string]string{"updated": "meanwhile"}
+	updatedPods := []kapi.Pod{}
+	conflicts := testclient.NewConflicts(1)
+
+	controller := &DeploymentController{
+		decodeConfig: func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error) {
+			return deployutil.DecodeDeploymentConfig(deployment, api.Codec)
+		},
+		deploymentClient: &deploymentClientImpl{
+			updateDeploymentFunc: func(namespace string, deployment *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+				t.Errorf("unexpected call to updateDeployment")
+				return nil, nil
+			},
+		},
+		podClient: &podClientImpl{
+			getPodFunc: func(namespace, name string) (*kapi.Pod, error) {
+				return latest, nil
+			},
+			updatePodFunc: func(namespace string, pod *kapi.Pod) (*kapi.Pod, error) {
+				updatedPods = append(updatedPods, *pod)
+				if err := conflicts("Pod", pod.Name); err != nil {
+					return nil, err
+				}
+				return pod, nil
+			},
+			getDeployerPodsForFunc: func(namespace, name string) ([]kapi.Pod, error) {
+				return []kapi.Pod{*ttlNonZeroPod()}, nil
+			},
+		},
+		makeContainer: func(strategy *deployapi.DeploymentStrategy) (*kapi.Container, error) {
+			return okContainer(), nil
+		},
+		recorder: &record.FakeRecorder{},
+	}
+
+	deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codec)
+	deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusRunning)
+	deployment.Annotations[deployapi.DeploymentCancelledAnnotation] = deployapi.DeploymentCancelledAnnotationValue
+	if err := controller.Handle(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := 2, len(updatedPods); e != a {
+		t.Fatalf("expected %d pod updates, got %d", e, a)
+	}
+	updated := updatedPods[1]
+	if updated.Annotations["updated"] != "meanwhile" {
+		t.Errorf("expected the latest version of the pod to be updated, got %#v", updated)
+	}
+	if e, a := int64(1), *updated.Spec.ActiveDeadlineSeconds; e != a {
+		t.Errorf("expected ActiveDeadlineSeconds %d, got %d", e, a)
+	}
+}
+
 func expectMapContains(t *testing.T, exists, expected map[string]string, what string) {
 	if expected == nil {
 		return
@@ -719,6 +859,45 @@ func TestDeployerCustomLabelsAndAnnotations(t *testing.T) {
 	}
 }
 
+func TestDeployerServiceAccount(t *testing.T) {
+	controller := &DeploymentController{
+		serviceAccount: "deployer",
+		decodeConfig: func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error) {
+			return deployutil.DecodeDeploymentConfig(deployment, api.Codec)
+		},
+		makeContainer: func(strategy *deployapi.DeploymentStrategy) (*kapi.Container, error) {
+			return okContainer(), nil
+		},
+	}
+
+	customWithServiceAccount := deploytest.OkCustomStrategy()
+	customWithServiceAccount.CustomParams.ServiceAccountName = "custom-deployer"
+
+	testCases := []struct {
+		name     string
+		strategy deployapi.DeploymentStrategy
+		expected string
+	}{
+		{name: "default strategy", strategy: deploytest.OkStrategy(), expected: "deployer"},
+		{name: "custom strategy without service account", strategy: deploytest.OkCustomStrategy(), expected: "deployer"},
+		{name: "custom strategy with service account", strategy: customWithServiceAccount, expected: "custom-deployer"},
+	}
+
+	for _, test := range testCases {
+		config := deploytest.OkDeploymentConfig(1)
+		config.Spec.Strategy = test.strategy
+
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codec)
+		pod, err := controller.makeDeployerPod(deployment)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if e, a := test.expected, pod.Spec.ServiceAccountName; e != a {
+			t.Errorf("%s: expected service account %q, got %q", test.name, e, a)
+		}
+	}
+}
+
 func okContainer() *kapi.Container {
 	return &kapi.Container{
 		Image:   "test/image",
@@ -777,3 +956,29 @@ func ttlZeroPod() *kapi.Pod {
 		},
 	}
 }
+
+func TestDeployerLoggingAnnotations(t *testing.T) {
+	controller := &DeploymentController{
+		loggingAnnotationPrefix: "logging.example.com/",
+		decodeConfig: func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error) {
+			return deployutil.DecodeDeploymentConfig(deployment, api.Codec)
+		},
+		makeContainer: func(strategy *deployapi.DeploymentStrategy) (*kapi.Container, error) {
+			return okContainer(), nil
+		},
+	}
+
+	config := deploytest.OkDeploymentConfig(1)
+	config.Labels = map[string]string{"app": "frontend"}
+	config.Spec.Strategy.Annotations = map[string]string{"logging.example.com/app": "strategy"}
+
+	deployment, _ := deployutil.MakeDeployment(config, kapi.Codec)
+	pod, err := controller.makeDeployerPod(deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectMapContains(t, pod.Annotations, map[string]string{
+		"logging.example.com/app":              "strategy",
+		"logging.example.com/deploymentconfig": config.Name,
+	}, "annotations")
+}
@@ -32,6 +32,9 @@ type DeploymentControllerFactory struct {
 	Environment []kapi.EnvVar
 	// DeployerImage specifies which Docker image can support the default strategies.
 	DeployerImage string
+	// LoggingAnnotationPrefix, if set, is prepended to the names of the annotations added to deployer pods for
+	// aggregated logging
+	LoggingAnnotationPrefix string
 }
 
 // Create creates a DeploymentController.
@@ -95,12 +98,13 @@ func (factory *DeploymentControllerFactory) Create() controller.RunnableControll
 		decodeConfig: func(deployment *kapi.ReplicationController) (*deployapi.DeploymentConfig, error) {
 			return deployutil.DecodeDeploymentConfig(deployment, factory.Codec)
 		},
-		recorder: eventBroadcaster.NewRecorder(kapi.EventSource{Component: "deployer"}),
+		recorder:                eventBroadcaster.NewRecorder(kapi.EventSource{Component: "deployer"}),
+		loggingAnnotationPrefix: factory.LoggingAnnotationPrefix,
 	}
 
 	return &controller.RetryController{
 		Queue: deploymentQueue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			deploymentQueue,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
@@ -113,7 +117,7 @@ func (factory *DeploymentControllerFactory) Create() controller.RunnableControll
 				}
 				return true
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			deployment := obj.(*kapi.ReplicationController)
@@ -127,8 +131,8 @@ func (factory *DeploymentControllerFactory) Create() controller.RunnableControll
 //   1. For the Recreate and Rolling strategies, strategy, use the factory's
 //      DeployerImage as the container image, and the factory's Environment
 //      as the container environment.
-//   2. For all Custom strategy, use the strategy's image for the container
-//      image, and use the combination of the factory's Environment and the
+//   2. For all Custom strategy, use the strategy's image and command for the
+//      container, and use the combination of the factory's Environment and the
 //      strategy's environment as the container environment.
 //
 // An error is returned if the deployment strategy type is not supported.
@@ -153,8 +157,9 @@ func (factory *DeploymentControllerFactory) makeContainer(strategy *deployapi.De
 			environment = append(environment, env)
 		}
 		return &kapi.Container{
-			Image: strategy.CustomParams.Image,
-			Env:   environment,
+			Image:   strategy.CustomParams.Image,
+			Command: strategy.CustomParams.Command,
+			Env:     environment,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unsupported deployment strategy type: %s", strategy.Type)
@@ -92,9 +92,11 @@ func (c *DeploymentConfigController) Handle(config *deployapi.DeploymentConfig)
 			// Cancel running deployments.
 			awaitingCancellations = true
 			if !deployutil.IsDeploymentCancelled(&deployment) {
-				deployment.Annotations[deployapi.DeploymentCancelledAnnotation] = deployapi.DeploymentCancelledAnnotationValue
-				deployment.Annotations[deployapi.DeploymentStatusReasonAnnotation] = deployapi.DeploymentCancelledNewerDeploymentExists
-				_, err := c.kubeClient.ReplicationControllers(deployment.Namespace).Update(&deployment)
+				err := osclient.UpdateReplicationControllerWithRetries(c.getDeployment, c.updateDeployment, &deployment, func(deployment *kapi.ReplicationController) bool {
+					deployment.Annotations[deployapi.DeploymentCancelledAnnotation] = deployapi.DeploymentCancelledAnnotationValue
+					deployment.Annotations[deployapi.DeploymentStatusReasonAnnotation] = deployapi.DeploymentCancelledNewerDeploymentExists
+					return true
+				})
 				if err != nil {
 					c.recorder.Eventf(config, "DeploymentCancellationFailed", "Failed to cancel deployment %q superceded by version %d: %s", deployment.Name, config.Status.LatestVersion, err)
 				} else {
@@ -211,8 +213,9 @@ func (c *DeploymentConfigController) reconcileDeployments(existingDeployments *k
 	// we can safely reconcile deployments.
 	if config.Spec.Replicas != activeReplicas {
 		oldReplicas := config.Spec.Replicas
-		config.Spec.Replicas = activeReplicas
-		_, err := c.osClient.DeploymentConfigs(config.Namespace).Update(config)
+		_, err := osclient.UpdateDeploymentConfigWithRetries(c.osClient, config, func(config *deployapi.DeploymentConfig) {
+			config.Spec.Replicas = activeReplicas
+		})
 		if err != nil {
 			return err
 		}
@@ -231,9 +234,11 @@ func (c *DeploymentConfigController) reconcileDeployments(existingDeployments *k
 		lastReplicas, hasLastReplicas := deployutil.DeploymentReplicas(&deployment)
 		// Only update if necessary.
 		if !hasLastReplicas || newReplicaCount != oldReplicaCount || lastReplicas != newReplicaCount {
-			deployment.Spec.Replicas = newReplicaCount
-			deployment.Annotations[deployapi.DeploymentReplicasAnnotation] = strconv.Itoa(newReplicaCount)
-			_, err := c.kubeClient.ReplicationControllers(deployment.Namespace).Update(&deployment)
+			err := osclient.UpdateReplicationControllerWithRetries(c.getDeployment, c.updateDeployment, &deployment, func(deployment *kapi.ReplicationController) bool {
+				deployment.Spec.Replicas = newReplicaCount
+				deployment.Annotations[deployapi.DeploymentReplicasAnnotation] = strconv.Itoa(newReplicaCount)
+				return true
+			})
 			if err != nil {
 				c.recorder.Eventf(config, "DeploymentScaleFailed",
 					"Failed to scale deployment %q from %d to %d: %s", deployment.Name, oldReplicaCount, newReplicaCount, err)
@@ -250,3 +255,13 @@ func (c *DeploymentConfigController) reconcileDeployments(existingDeployments *k
 	}
 	return nil
 }
+
+// getDeployment returns the deployment name of namespace
+func (c *DeploymentConfigController) getDeployment(namespace, name string) (*kapi.ReplicationController, error) {
+	return c.kubeClient.ReplicationControllers(namespace).Get(name)
+}
+
+// updateDeployment updates deployment in namespace
+func (c *DeploymentConfigController) updateDeployment(namespace string, deployment *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+	return c.kubeClient.ReplicationControllers(namespace).Update(deployment)
+}
@@ -628,6 +628,82 @@ func TestHandleScenarios(t *testing.T) {
 	}
 }
 
+// TestHandleUpdateConflicts ensures that the changes to a config and its
+// deployments are made on their latest versions if the updates conflict.
+func TestHandleUpdateConflicts(t *testing.T) {
+	deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codec)
+	deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusComplete)
+	deployment.Annotations[deployapi.DeploymentReplicasAnnotation] = "1"
+	deployment.Spec.Replicas = 2
+	latestDeployment := *deployment
+	latestDeployment.Annotations = map[string]string{"updated": "meanwhile"}
+	for k, v := range deployment.Annotations {
+		latestDeployment.Annotations[k] = v
+	}
+
+	config := deploytest.OkDeploymentConfig(1)
+	config.Spec.Replicas = 1
+	latestConfig := deploytest.OkDeploymentConfig(1)
+	latestConfig.Spec.Replicas = 1
+	latestConfig.Annotations = map[string]string{"updated": "meanwhile"}
+
+	var updatedDeployments []*kapi.ReplicationController
+	deploymentConflicts := testclient.NewConflicts(1)
+	kc := &ktestclient.Fake{}
+	kc.AddReactor("list", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &kapi.ReplicationControllerList{Items: []kapi.ReplicationController{*deployment}}, nil
+	})
+	kc.AddReactor("get", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &latestDeployment, nil
+	})
+	kc.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		rc := action.(ktestclient.UpdateAction).GetObject().(*kapi.ReplicationController)
+		updatedDeployments = append(updatedDeployments, rc)
+		if err := deploymentConflicts("ReplicationController", rc.Name); err != nil {
+			return true, nil, err
+		}
+		return true, rc, nil
+	})
+
+	var updatedConfigs []*deployapi.DeploymentConfig
+	configConflicts := testclient.NewConflicts(1)
+	oc := &testclient.Fake{}
+	oc.AddReactor("get", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		return true, latestConfig, nil
+	})
+	oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		dc := action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+		updatedConfigs = append(updatedConfigs, dc)
+		if err := configConflicts("DeploymentConfig", dc.Name); err != nil {
+			return true, nil, err
+		}
+		return true, dc, nil
+	})
+
+	controller := &DeploymentConfigController{
+		kubeClient: kc,
+		osClient:   oc,
+		codec:      kapi.Codec,
+		recorder:   &record.FakeRecorder{},
+	}
+	if err := controller.Handle(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := 2, len(updatedConfigs); e != a {
+		t.Fatalf("expected %d config updates, got %d", e, a)
+	}
+	if updated := updatedConfigs[1]; updated.Annotations["updated"] != "meanwhile" || updated.Spec.Replicas != 2 {
+		t.Errorf("expected the latest config to be scaled to 2 replicas, got %#v", updated)
+	}
+	if e, a := 2, len(updatedDeployments); e != a {
+		t.Fatalf("expected %d deployment updates, got %d", e, a)
+	}
+	if updated := updatedDeployments[1]; updated.Annotations["updated"] != "meanwhile" || updated.Annotations[deployapi.DeploymentReplicasAnnotation] != "2" {
+		t.Errorf("expected the replicas annotation of the latest deployment to be 2, got %#v", updated.Annotations)
+	}
+}
+
 func newint(i int) *int {
 	return &i
 }
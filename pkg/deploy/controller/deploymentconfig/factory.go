@@ -52,7 +52,7 @@ func (factory *DeploymentConfigControllerFactory) Create() controller.RunnableCo
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
@@ -75,7 +75,7 @@ func (factory *DeploymentConfigControllerFactory) Create() controller.RunnableCo
 				}
 				return true
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			config := obj.(*deployapi.DeploymentConfig)
@@ -5,13 +5,18 @@ import (
 
 	"github.com/golang/glog"
 
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	osclient "github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
 // ImageChangeController increments the version of a DeploymentConfig which has an image
-// change trigger when a tag update to a triggered ImageStream is detected.
+// change trigger when a tag update to a triggered ImageStream is detected. The new image
+// is only recorded on the triggers of paused DeploymentConfigs, and deployed once they are
+// resumed.
 //
 // Use the ImageChangeControllerFactory to create this controller.
 type ImageChangeController struct {
@@ -34,10 +39,13 @@ func (c *ImageChangeController) Handle(imageRepo *imageapi.ImageStream) error {
 
 	// Find any configs which should be updated based on the new image state
 	configsToUpdate := map[string]*deployapi.DeploymentConfig{}
+	pausedConfigsToUpdate := map[string]*deployapi.DeploymentConfig{}
 	for _, config := range configs {
 		glog.V(4).Infof("Detecting changed images for DeploymentConfig %s", deployutil.LabelForDeploymentConfig(config))
+		// the images recorded while the config was paused are deployed once it is resumed
+		pending := deployutil.IsImageChangePending(config) && !deployutil.IsPaused(config)
 
-		for _, trigger := range config.Spec.Triggers {
+		for i, trigger := range config.Spec.Triggers {
 			params := trigger.ImageChangeParams
 
 			// Only automatic image change triggers should fire
@@ -62,11 +70,34 @@ func (c *ImageChangeController) Handle(imageRepo *imageapi.ImageStream) error {
 				continue
 			}
 
+			if pending {
+				configsToUpdate[config.Name] = config
+				continue
+			}
+
 			// Ensure a change occurred
 			if len(latestEvent.DockerImageReference) > 0 &&
 				latestEvent.DockerImageReference != params.LastTriggeredImage {
-				// Mark the config for regeneration
-				configsToUpdate[config.Name] = config
+				if !deployutil.IsPaused(config) {
+					// Mark the config for regeneration
+					configsToUpdate[config.Name] = config
+					continue
+				}
+				// Only record the image on a copy of a paused config
+				pausedConfig, ok := pausedConfigsToUpdate[config.Name]
+				if !ok {
+					obj, err := kapi.Scheme.Copy(config)
+					if err != nil {
+						return err
+					}
+					pausedConfig = obj.(*deployapi.DeploymentConfig)
+					pausedConfigsToUpdate[config.Name] = pausedConfig
+				}
+				pausedConfig.Spec.Triggers[i].ImageChangeParams.LastTriggeredImage = latestEvent.DockerImageReference
+				if pausedConfig.Annotations == nil {
+					pausedConfig.Annotations = map[string]string{}
+				}
+				pausedConfig.Annotations[deployapi.DeploymentConfigImageChangePendingAnnotation] = "true"
 			}
 		}
 	}
@@ -82,6 +113,15 @@ func (c *ImageChangeController) Handle(imageRepo *imageapi.ImageStream) error {
 		}
 	}
 
+	for _, config := range pausedConfigsToUpdate {
+		if _, err := c.deploymentConfigClient.updateDeploymentConfig(config.Namespace, config); err != nil {
+			anyFailed = true
+			glog.V(2).Infof("Couldn't record the images triggering paused DeploymentConfig %s: %s", deployutil.LabelForDeploymentConfig(config), err)
+			continue
+		}
+		glog.V(4).Infof("Recorded the images triggering paused DeploymentConfig %s", deployutil.LabelForDeploymentConfig(config))
+	}
+
 	if anyFailed {
 		return fatalError(fmt.Sprintf("couldn't update some DeploymentConfig for trigger on ImageStream %s", labelForRepo(imageRepo)))
 	}
@@ -110,24 +150,48 @@ func triggerMatchesImage(config *deployapi.DeploymentConfig, params *deployapi.D
 // config's version is newer, update the old config to be the new config.
 // Otherwise do nothing.
 func (c *ImageChangeController) regenerate(config *deployapi.DeploymentConfig) error {
-	// Get a regenerated config which includes the new image repo references
-	newConfig, err := c.deploymentConfigClient.generateDeploymentConfig(config.Namespace, config.Name)
-	if err != nil {
-		return fmt.Errorf("error generating new version of DeploymentConfig %s: %v", deployutil.LabelForDeploymentConfig(config), err)
-	}
-
-	// No update occurred
-	if config.Status.LatestVersion == newConfig.Status.LatestVersion {
-		glog.V(5).Infof("No version difference for generated DeploymentConfig %s", deployutil.LabelForDeploymentConfig(config))
+	// Get a regenerated config which includes the new image repo references. If the update
+	// conflicts, the config is generated again from its latest version.
+	var newConfig *deployapi.DeploymentConfig
+	generate := func() (err error) {
+		newConfig, err = c.deploymentConfigClient.generateDeploymentConfig(config.Namespace, config.Name)
+		if err != nil {
+			return fmt.Errorf("error generating new version of DeploymentConfig %s: %v", deployutil.LabelForDeploymentConfig(config), err)
+		}
 		return nil
 	}
+	if err := generate(); err != nil {
+		return err
+	}
+
+	updated := false
+	err := osclient.UpdateWithRetries(generate, func() error {
+		// The images recorded while the config was paused are deployed by the new version, or were already
+		pending := deployutil.IsImageChangePending(newConfig)
+		delete(newConfig.Annotations, deployapi.DeploymentConfigImageChangePendingAnnotation)
 
-	// Persist the new config
-	_, err = c.deploymentConfigClient.updateDeploymentConfig(newConfig.Namespace, newConfig)
+		// No update occurred
+		if config.Status.LatestVersion == newConfig.Status.LatestVersion {
+			if !pending {
+				return nil
+			}
+			_, err := c.deploymentConfigClient.updateDeploymentConfig(newConfig.Namespace, newConfig)
+			return err
+		}
+
+		// Persist the new config
+		_, err := c.deploymentConfigClient.updateDeploymentConfig(newConfig.Namespace, newConfig)
+		updated = err == nil
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	if !updated {
+		glog.V(5).Infof("No version difference for generated DeploymentConfig %s", deployutil.LabelForDeploymentConfig(config))
+		return nil
+	}
 	glog.V(4).Infof("Regenerated DeploymentConfig %s for image updates", deployutil.LabelForDeploymentConfig(config))
 	return nil
 }
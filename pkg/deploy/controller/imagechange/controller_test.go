@@ -2,12 +2,15 @@ package imagechange
 
 import (
 	"flag"
+	"strconv"
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 
+	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployapitest "github.com/openshift/origin/pkg/deploy/api/test"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
@@ -85,6 +88,100 @@ func TestHandle_changeForUnregisteredTag(t *testing.T) {
 	}
 }
 
+// TestHandle_pausedConfig ensures that an image update for a paused config is
+// recorded on its trigger without generating a new version of the config.
+func TestHandle_pausedConfig(t *testing.T) {
+	config := deployapitest.OkDeploymentConfig(1)
+	config.Namespace = kapi.NamespaceDefault
+	config.Annotations = map[string]string{deployapi.DeploymentConfigPausedAnnotation: "true"}
+	config.Spec.Triggers[0].ImageChangeParams.From = kapi.ObjectReference{Name: imageapi.JoinImageStreamTag("test-image-repo", imageapi.DefaultImageTag)}
+
+	var updated *deployapi.DeploymentConfig
+	controller := &ImageChangeController{
+		deploymentConfigClient: &deploymentConfigClientImpl{
+			updateDeploymentConfigFunc: func(namespace string, config *deployapi.DeploymentConfig) (*deployapi.DeploymentConfig, error) {
+				updated = config
+				return config, nil
+			},
+			generateDeploymentConfigFunc: func(namespace, name string) (*deployapi.DeploymentConfig, error) {
+				t.Fatalf("unexpected generator call")
+				return nil, nil
+			},
+			listDeploymentConfigsFunc: func() ([]*deployapi.DeploymentConfig, error) {
+				return []*deployapi.DeploymentConfig{config}, nil
+			},
+		},
+	}
+
+	image := "registry:8080/openshift/test-image@sha256:00000000000000000000000000000002"
+	tagUpdate := makeRepo("test-image-repo", imageapi.DefaultImageTag, image, "00000000000000000000000000000002")
+	tagUpdate.Namespace = kapi.NamespaceDefault
+	if err := controller.Handle(tagUpdate); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if updated == nil {
+		t.Fatalf("expected the image to be recorded on the paused config")
+	}
+	if e, a := image, updated.Spec.Triggers[0].ImageChangeParams.LastTriggeredImage; e != a {
+		t.Errorf("expected last triggered image %s, got %s", e, a)
+	}
+	if updated.Status.LatestVersion != 1 || updated.Spec.Template.Spec.Containers[0].Image == image {
+		t.Errorf("expected the paused config not to be deployed, got %#v", updated)
+	}
+	if !deployutil.IsImageChangePending(updated) {
+		t.Errorf("expected the recorded image to be pending, got annotations %v", updated.Annotations)
+	}
+	if config.Spec.Triggers[0].ImageChangeParams.LastTriggeredImage == image {
+		t.Errorf("expected the listed config to be left untouched")
+	}
+}
+
+// TestHandle_resumedConfig ensures that the image recorded while a config was
+// paused is deployed once it is resumed, and is no longer pending.
+func TestHandle_resumedConfig(t *testing.T) {
+	image := "registry:8080/openshift/test-image@sha256:00000000000000000000000000000002"
+	config := deployapitest.OkDeploymentConfig(1)
+	config.Namespace = kapi.NamespaceDefault
+	config.Annotations = map[string]string{deployapi.DeploymentConfigImageChangePendingAnnotation: "true"}
+	config.Spec.Triggers[0].ImageChangeParams.From = kapi.ObjectReference{Name: imageapi.JoinImageStreamTag("test-image-repo", imageapi.DefaultImageTag)}
+	config.Spec.Triggers[0].ImageChangeParams.LastTriggeredImage = image
+
+	var updated *deployapi.DeploymentConfig
+	controller := &ImageChangeController{
+		deploymentConfigClient: &deploymentConfigClientImpl{
+			updateDeploymentConfigFunc: func(namespace string, config *deployapi.DeploymentConfig) (*deployapi.DeploymentConfig, error) {
+				updated = config
+				return config, nil
+			},
+			generateDeploymentConfigFunc: func(namespace, name string) (*deployapi.DeploymentConfig, error) {
+				generated := deployapitest.OkDeploymentConfig(2)
+				generated.Annotations = map[string]string{deployapi.DeploymentConfigImageChangePendingAnnotation: "true"}
+				return generated, nil
+			},
+			listDeploymentConfigsFunc: func() ([]*deployapi.DeploymentConfig, error) {
+				return []*deployapi.DeploymentConfig{config}, nil
+			},
+		},
+	}
+
+	tagUpdate := makeRepo("test-image-repo", imageapi.DefaultImageTag, image, "00000000000000000000000000000002")
+	tagUpdate.Namespace = kapi.NamespaceDefault
+	if err := controller.Handle(tagUpdate); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if updated == nil {
+		t.Fatalf("expected the resumed config to be regenerated")
+	}
+	if updated.Status.LatestVersion != 2 {
+		t.Errorf("expected a new version of the resumed config, got %d", updated.Status.LatestVersion)
+	}
+	if deployutil.IsImageChangePending(updated) {
+		t.Errorf("expected the deployed image not to be pending anymore")
+	}
+}
+
 // TestHandle_matchScenarios comprehensively tests trigger definitions against
 // image repo updates to ensure that the image change triggers match (or don't
 // match) properly.
@@ -222,6 +319,56 @@ func TestHandle_matchScenarios(t *testing.T) {
 	}
 }
 
+// TestHandle_updateConflict ensures that a config is generated again when the
+// update of its new version conflicts.
+func TestHandle_updateConflict(t *testing.T) {
+	config := deployapitest.OkDeploymentConfig(1)
+	config.Namespace = kapi.NamespaceDefault
+	config.Spec.Triggers[0].ImageChangeParams.From = kapi.ObjectReference{Name: imageapi.JoinImageStreamTag("test-image-repo", imageapi.DefaultImageTag)}
+
+	generated := 0
+	updates := []*deployapi.DeploymentConfig{}
+	conflicts := testclient.NewConflicts(1)
+	controller := &ImageChangeController{
+		deploymentConfigClient: &deploymentConfigClientImpl{
+			updateDeploymentConfigFunc: func(namespace string, config *deployapi.DeploymentConfig) (*deployapi.DeploymentConfig, error) {
+				updates = append(updates, config)
+				if err := conflicts("DeploymentConfig", config.Name); err != nil {
+					return nil, err
+				}
+				return config, nil
+			},
+			generateDeploymentConfigFunc: func(namespace, name string) (*deployapi.DeploymentConfig, error) {
+				generated++
+				newConfig := deployapitest.OkDeploymentConfig(2)
+				newConfig.Annotations = map[string]string{"generation": strconv.Itoa(generated)}
+				return newConfig, nil
+			},
+			listDeploymentConfigsFunc: func() ([]*deployapi.DeploymentConfig, error) {
+				return []*deployapi.DeploymentConfig{config}, nil
+			},
+		},
+	}
+
+	repo := makeRepo(
+		"test-image-repo",
+		imageapi.DefaultImageTag,
+		"registry:8080/openshift/test-image@sha256:00000000000000000000000000000002",
+		"00000000000000000000000000000002",
+	)
+	repo.Namespace = kapi.NamespaceDefault
+	if err := controller.Handle(repo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := 2, len(updates); e != a {
+		t.Fatalf("expected %d updates, got %d", e, a)
+	}
+	if e, a := "2", updates[1].Annotations["generation"]; e != a {
+		t.Errorf("expected the config generated after the conflict to be updated, got generation %s", a)
+	}
+}
+
 func makeRepo(name, tag, dir, image string) *imageapi.ImageStream {
 	return &imageapi.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{Name: name},
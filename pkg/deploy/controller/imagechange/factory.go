@@ -70,7 +70,7 @@ func (factory *ImageChangeControllerFactory) Create() controller.RunnableControl
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
@@ -83,7 +83,7 @@ func (factory *ImageChangeControllerFactory) Create() controller.RunnableControl
 				}
 				return true
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			repo := obj.(*imageapi.ImageStream)
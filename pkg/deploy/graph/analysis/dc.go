@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gonum/graph"
 
@@ -15,6 +16,7 @@ import (
 const (
 	MissingImageStreamErr        = "MissingImageStream"
 	MissingImageStreamTagWarning = "MissingImageStreamTag"
+	MissingReadinessProbeWarning = "MissingReadinessProbe"
 )
 
 // FindDeploymentConfigTriggerErrors checks for possible failures in deployment config
@@ -65,6 +67,42 @@ dc:
 	return markers
 }
 
+// FindDeploymentConfigReadinessWarnings checks for deployment configs whose containers have no readiness
+// probe. Their pods are considered ready as soon as they start, so rollouts continue and services send
+// traffic to them before they can serve it.
+func FindDeploymentConfigReadinessWarnings(g osgraph.Graph) []osgraph.Marker {
+	markers := []osgraph.Marker{}
+
+	for _, uncastDcNode := range g.NodesByKind(deploygraph.DeploymentConfigNodeKind) {
+		dcNode := uncastDcNode.(*deploygraph.DeploymentConfigNode)
+		if dcNode.DeploymentConfig.Spec.Template == nil {
+			continue
+		}
+
+		missing := []string{}
+		for _, container := range dcNode.DeploymentConfig.Spec.Template.Spec.Containers {
+			if container.ReadinessProbe == nil {
+				missing = append(missing, container.Name)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		markers = append(markers, osgraph.Marker{
+			Node: uncastDcNode,
+
+			Severity: osgraph.WarningSeverity,
+			Key:      MissingReadinessProbeWarning,
+			Message: fmt.Sprintf("%s has no readiness probe for container(s) %s, its pods receive traffic and count as deployed as soon as they start.",
+				dcNode.ResourceString(), strings.Join(missing, ", ")),
+			Suggestion: osgraph.Suggestion(fmt.Sprintf("Add a readinessProbe to the containers with 'oc edit %s'", dcNode.ResourceString())),
+		})
+	}
+
+	return markers
+}
+
 func doesImageStreamExist(g osgraph.Graph, istag graph.Node) (graph.Node, bool) {
 	for _, imagestream := range g.SuccessorNodesByEdgeKind(istag, imageedges.ReferencedImageStreamGraphEdgeKind) {
 		return imagestream, imagestream.(*imagegraph.ImageStreamNode).Found()
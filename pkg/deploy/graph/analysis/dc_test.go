@@ -46,3 +46,19 @@ func TestMissingImageStream(t *testing.T) {
 		t.Fatalf("expected marker key %q, got %q", expected, got)
 	}
 }
+
+func TestMissingReadinessProbe(t *testing.T) {
+	g, _, err := osgraphtest.BuildGraph("../../../api/graph/test/bare-dc.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	markers := FindDeploymentConfigReadinessWarnings(g)
+	if e, a := 1, len(markers); e != a {
+		t.Fatalf("expected %v, got %v", e, a)
+	}
+
+	if got, expected := markers[0].Key, MissingReadinessProbeWarning; got != expected {
+		t.Fatalf("expected marker key %q, got %q", expected, got)
+	}
+}
@@ -110,6 +110,7 @@ func (r *ScaleREST) Get(ctx kapi.Context, name string) (runtime.Object, error) {
 			Name:              name,
 			Namespace:         deploymentConfig.Namespace,
 			CreationTimestamp: deploymentConfig.CreationTimestamp,
+			ResourceVersion:   deploymentConfig.ResourceVersion,
 		},
 		Spec: extensions.ScaleSpec{
 			Replicas: deploymentConfig.Spec.Replicas,
@@ -122,6 +123,8 @@ func (r *ScaleREST) Get(ctx kapi.Context, name string) (runtime.Object, error) {
 }
 
 // Update scales the DeploymentConfig for the given Scale subresource, returning the updated Scale.
+// If the Scale has a resource version, the update fails with a conflict unless it matches the
+// resource version of the DeploymentConfig.
 func (r *ScaleREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
 	if obj == nil {
 		return nil, false, errors.NewBadRequest(fmt.Sprintf("nil update passed to Scale"))
@@ -130,6 +133,8 @@ func (r *ScaleREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object
 	if !ok {
 		return nil, false, errors.NewBadRequest(fmt.Sprintf("wrong object passed to Scale update: %v", obj))
 	}
+	// validation replaces the resource version of the scale
+	resourceVersion := scale.ResourceVersion
 
 	// fake an existing object to validate
 	existing := &extensions.Scale{
@@ -175,6 +180,9 @@ func (r *ScaleREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object
 
 	oldReplicas := deploymentConfig.Spec.Replicas
 	deploymentConfig.Spec.Replicas = scale.Spec.Replicas
+	if len(resourceVersion) > 0 {
+		deploymentConfig.ResourceVersion = resourceVersion
+	}
 	if err := r.registry.UpdateDeploymentConfig(ctx, deploymentConfig); err != nil {
 		return nil, false, err
 	}
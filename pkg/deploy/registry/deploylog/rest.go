@@ -89,6 +89,12 @@ func (r *REST) Get(ctx kapi.Context, name string, opts runtime.Object) (runtime.
 
 	// Support retrieving logs for older deployments
 	switch {
+	case deployLogOpts.Previous:
+		// The deployment prior to the latest one
+		desiredVersion--
+		if desiredVersion < 1 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("no previous deployment exists for deploymentConfig %q", config.Name))
+		}
 	case deployLogOpts.Version == nil:
 		// Latest
 	case *deployLogOpts.Version <= 0 || int(*deployLogOpts.Version) > config.Status.LatestVersion:
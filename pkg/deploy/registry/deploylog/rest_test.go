@@ -141,6 +141,24 @@ func TestRESTGet(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			testName: "previous deployment",
+			rest:     mockREST(3, 2, api.DeploymentStatusFailed),
+			name:     "config",
+			opts:     &api.DeploymentLogOptions{Follow: false, Previous: true},
+			expected: &genericrest.LocationStreamer{
+				Location: &url.URL{
+					Scheme: "https",
+					Host:   "config-2-deploy-host:12345",
+					Path:   "/containerLogs/default/config-2-deploy/config-2-deploy-container",
+				},
+				Transport:       nil,
+				ContentType:     "text/plain",
+				Flush:           false,
+				ResponseChecker: genericrest.NewGenericHttpResponseChecker("Pod", "config-2-deploy"),
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, test := range tests {
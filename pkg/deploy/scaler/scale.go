@@ -1,15 +1,17 @@
 package scaler
 
 import (
+	"strconv"
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/apis/extensions"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/util/wait"
 
 	"github.com/openshift/origin/pkg/client"
-	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	"github.com/openshift/origin/pkg/deploy/util"
 )
 
@@ -51,22 +53,44 @@ func (scaler *DeploymentConfigScaler) Scale(namespace, name string, newSize uint
 		if err != nil {
 			return err
 		}
-		return wait.Poll(waitForReplicas.Interval, waitForReplicas.Timeout, controllerHasSpecifiedReplicas(scaler.clientInterface, rc, dc.Spec.Replicas))
+		return wait.Poll(waitForReplicas.Interval, waitForReplicas.Timeout, controllerHasSpecifiedReplicas(scaler.clientInterface, rc, int(newSize)))
 	}
 	return nil
 }
 
 // ScaleSimple does a simple one-shot attempt at scaling - not useful on it's
-// own, but a necessary building block for Scale
+// own, but a necessary building block for Scale. The scale subresource is
+// updated with the resource version it was read with, so a concurrent change
+// to the deploymentConfig fails the update and the preconditions are checked
+// again on retry.
 func (scaler *DeploymentConfigScaler) ScaleSimple(namespace, name string, preconditions *kubectl.ScalePrecondition, newSize uint) error {
-	dc, err := scaler.dcClient.DeploymentConfigs(namespace).Get(name)
+	scale, err := scaler.dcClient.DeploymentConfigs(namespace).GetScale(name)
 	if err != nil {
-		return err
+		return kubectl.ControllerScaleError{FailureType: kubectl.ControllerScaleGetFailure, ResourceVersion: "Unknown", ActualError: err}
+	}
+	if preconditions != nil {
+		if err := validateScale(preconditions, scale); err != nil {
+			return err
+		}
 	}
-	scale := deployapi.ScaleFromConfig(dc)
 	scale.Spec.Replicas = int(newSize)
 	if _, err := scaler.dcClient.DeploymentConfigs(namespace).UpdateScale(scale); err != nil {
-		return kubectl.ControllerScaleError{FailureType: kubectl.ControllerScaleUpdateFailure, ResourceVersion: dc.ResourceVersion, ActualError: err}
+		if kerrors.IsInvalid(err) {
+			return kubectl.ControllerScaleError{FailureType: kubectl.ControllerScaleUpdateInvalidFailure, ResourceVersion: scale.ResourceVersion, ActualError: err}
+		}
+		return kubectl.ControllerScaleError{FailureType: kubectl.ControllerScaleUpdateFailure, ResourceVersion: scale.ResourceVersion, ActualError: err}
+	}
+	return nil
+}
+
+// validateScale checks the preconditions against the scale subresource of a
+// deploymentConfig.
+func validateScale(preconditions *kubectl.ScalePrecondition, scale *extensions.Scale) error {
+	if preconditions.Size != -1 && scale.Spec.Replicas != preconditions.Size {
+		return kubectl.PreconditionError{Precondition: "replicas", ExpectedValue: strconv.Itoa(preconditions.Size), ActualValue: strconv.Itoa(scale.Spec.Replicas)}
+	}
+	if len(preconditions.ResourceVersion) != 0 && scale.ResourceVersion != preconditions.ResourceVersion {
+		return kubectl.PreconditionError{Precondition: "resource version", ExpectedValue: preconditions.ResourceVersion, ActualValue: scale.ResourceVersion}
 	}
 	return nil
 }
@@ -76,14 +100,12 @@ func (scaler *DeploymentConfigScaler) ScaleSimple(namespace, name string, precon
 // equals the Replicas count.
 //
 // This is a slightly modified version of
-// unversioned.ControllerHasDesiredReplicas. This  is necessary because when
+// unversioned.ControllerHasDesiredReplicas. This is necessary because when
 // scaling an RC via a DC, the RC spec replica count is not immediately
-// updated to match the owning DC.
+// updated to match the owning DC, so the condition also waits for the
+// deploymentConfig controller to copy the count to the RC and for the
+// replication manager to observe that change.
 func controllerHasSpecifiedReplicas(c kclient.Interface, controller *kapi.ReplicationController, specifiedReplicas int) wait.ConditionFunc {
-	// If we're given a controller where the status lags the spec, it either means that the controller is stale,
-	// or that the rc manager hasn't noticed the update yet. Polling status.Replicas is not safe in the latter case.
-	desiredGeneration := controller.Generation
-
 	return func() (bool, error) {
 		ctrl, err := c.ReplicationControllers(controller.Namespace).Get(controller.Name)
 		if err != nil {
@@ -93,6 +115,8 @@ func controllerHasSpecifiedReplicas(c kclient.Interface, controller *kapi.Replic
 		// or, after this check has passed, a modification causes the rc manager to create more pods.
 		// This will not be an issue once we've implemented graceful delete for rcs, but till then
 		// concurrent stop operations on the same rc might have unintended side effects.
-		return ctrl.Status.ObservedGeneration >= desiredGeneration && ctrl.Status.Replicas == specifiedReplicas, nil
+		return ctrl.Spec.Replicas == specifiedReplicas &&
+			ctrl.Status.ObservedGeneration >= ctrl.Generation &&
+			ctrl.Status.Replicas == specifiedReplicas, nil
 	}
 }
@@ -11,16 +11,18 @@ import (
 	"k8s.io/kubernetes/pkg/runtime"
 
 	"github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 )
 
 func TestScale(t *testing.T) {
 	tests := []struct {
-		name        string
-		size        uint
-		wait        bool
-		errExpected bool
+		name          string
+		size          uint
+		wait          bool
+		preconditions *kubectl.ScalePrecondition
+		errExpected   bool
 	}{
 		{
 			name:        "simple scale",
@@ -34,6 +36,24 @@ func TestScale(t *testing.T) {
 			wait:        true,
 			errExpected: false,
 		},
+		{
+			name:          "matching preconditions",
+			size:          2,
+			preconditions: &kubectl.ScalePrecondition{Size: 1, ResourceVersion: "10"},
+			errExpected:   false,
+		},
+		{
+			name:          "replicas precondition mismatch",
+			size:          2,
+			preconditions: &kubectl.ScalePrecondition{Size: 3, ResourceVersion: ""},
+			errExpected:   true,
+		},
+		{
+			name:          "resource version precondition mismatch",
+			size:          2,
+			preconditions: &kubectl.ScalePrecondition{Size: -1, ResourceVersion: "9"},
+			errExpected:   true,
+		},
 	}
 
 	for _, test := range tests {
@@ -44,6 +64,7 @@ func TestScale(t *testing.T) {
 
 		config := deploytest.OkDeploymentConfig(1)
 		config.Spec.Replicas = 1
+		config.ResourceVersion = "10"
 		deployment, _ := deployutil.MakeDeployment(config, kapi.Codec)
 
 		var wait *kubectl.RetryParams
@@ -54,6 +75,12 @@ func TestScale(t *testing.T) {
 		oc.AddReactor("get", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
 			return true, config, nil
 		})
+		oc.AddReactor("get", "deploymentconfigs/scale", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			scale := deployapi.ScaleFromConfig(config)
+			scale.ResourceVersion = config.ResourceVersion
+			scale.Spec.Replicas = config.Spec.Replicas
+			return true, scale, nil
+		})
 		oc.AddReactor("update", "deploymentconfigs/scale", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
 			// Simulate the asynchronous update of the RC replicas based on the
 			// scale replica count.
@@ -68,13 +95,22 @@ func TestScale(t *testing.T) {
 			return true, deployment, nil
 		})
 
-		err := scaler.Scale("default", config.Name, test.size, nil, nil, wait)
+		err := scaler.Scale("default", config.Name, test.size, test.preconditions, nil, wait)
 		if err != nil {
 			if !test.errExpected {
 				t.Errorf("unexpected error: %s", err)
 				continue
 			}
 		}
+		if test.errExpected {
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+			if config.Spec.Replicas != 1 {
+				t.Errorf("expected the config not to be scaled, got %d replicas", config.Spec.Replicas)
+			}
+			continue
+		}
 
 		if e, a := config.Spec.Replicas, deployment.Spec.Replicas; e != a {
 			t.Errorf("expected rc/%s replicas %d, got %d", deployment.Name, e, a)
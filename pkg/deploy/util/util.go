@@ -9,6 +9,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	kutil "k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/watch"
 
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
@@ -105,6 +106,44 @@ func HasChangeTrigger(config *deployapi.DeploymentConfig) bool {
 	return false
 }
 
+// IsPaused returns true if the image change triggers of the provided deployment configuration are paused
+func IsPaused(config *deployapi.DeploymentConfig) bool {
+	return strings.ToLower(config.Annotations[deployapi.DeploymentConfigPausedAnnotation]) == "true"
+}
+
+// IsImageChangePending returns true if an image recorded while the provided deployment configuration was paused
+// was not deployed yet
+func IsImageChangePending(config *deployapi.DeploymentConfig) bool {
+	return strings.ToLower(config.Annotations[deployapi.DeploymentConfigImageChangePendingAnnotation]) == "true"
+}
+
+// MinAvailableReplicas returns how many pods of the provided deployment configuration must stay available
+// when its pods are disrupted, like when a node is drained. This is the floor that the max unavailable of its
+// rolling parameters keeps during a deployment, so a disruption never takes away more than a deployment
+// would. A deployment with a max unavailable of 0 surges new pods before removing old ones, which a disruption
+// cannot do, so the floor is at most one less than the replicas and a pod can always be disrupted. It returns
+// false if the configuration does not use the rolling strategy, whose deployments do not keep pods available.
+func MinAvailableReplicas(config *deployapi.DeploymentConfig) (int, bool, error) {
+	params := config.Spec.Strategy.RollingParams
+	if config.Spec.Strategy.Type != deployapi.DeploymentStrategyTypeRolling || params == nil {
+		return 0, false, nil
+	}
+	maxUnavailable, isPercent, err := kutil.GetIntOrPercentValue(&params.MaxUnavailable)
+	if err != nil {
+		return 0, false, err
+	}
+	if isPercent {
+		maxUnavailable = kutil.GetValueFromPercent(maxUnavailable, config.Spec.Replicas)
+	}
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+	if maxUnavailable >= config.Spec.Replicas {
+		return 0, true, nil
+	}
+	return config.Spec.Replicas - maxUnavailable, true, nil
+}
+
 // DecodeDeploymentConfig decodes a DeploymentConfig from controller using codec. An error is returned
 // if the controller doesn't contain an encoded config.
 func DecodeDeploymentConfig(controller *api.ReplicationController, codec runtime.Codec) (*deployapi.DeploymentConfig, error) {
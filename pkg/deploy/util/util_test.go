@@ -8,6 +8,7 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	kutil "k8s.io/kubernetes/pkg/util"
 
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
@@ -182,3 +183,78 @@ func TestSort(t *testing.T) {
 		t.Errorf("Unexpected sort order")
 	}
 }
+
+func TestMinAvailableReplicas(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       deployapi.DeploymentStrategy
+		maxUnavailable kutil.IntOrString
+		replicas       int
+		expected       int
+		expectedOk     bool
+	}{
+		{
+			name:     "recreate",
+			strategy: deploytest.OkStrategy(),
+			replicas: 4,
+		},
+		{
+			name:           "absolute max unavailable",
+			strategy:       deploytest.OkRollingStrategy(),
+			maxUnavailable: kutil.NewIntOrStringFromInt(1),
+			replicas:       4,
+			expected:       3,
+			expectedOk:     true,
+		},
+		{
+			name:           "percent max unavailable rounds up",
+			strategy:       deploytest.OkRollingStrategy(),
+			maxUnavailable: kutil.NewIntOrStringFromString("25%"),
+			replicas:       5,
+			expected:       3,
+			expectedOk:     true,
+		},
+		{
+			name:           "zero max unavailable leaves one pod to disrupt",
+			strategy:       deploytest.OkRollingStrategy(),
+			maxUnavailable: kutil.NewIntOrStringFromInt(0),
+			replicas:       3,
+			expected:       2,
+			expectedOk:     true,
+		},
+		{
+			name:           "zero max unavailable of a single replica",
+			strategy:       deploytest.OkRollingStrategy(),
+			maxUnavailable: kutil.NewIntOrStringFromString("0%"),
+			replicas:       1,
+			expected:       0,
+			expectedOk:     true,
+		},
+		{
+			name:           "max unavailable above replicas",
+			strategy:       deploytest.OkRollingStrategy(),
+			maxUnavailable: kutil.NewIntOrStringFromInt(3),
+			replicas:       2,
+			expected:       0,
+			expectedOk:     true,
+		},
+	}
+
+	for _, test := range tests {
+		config := deploytest.OkDeploymentConfig(1)
+		config.Spec.Replicas = test.replicas
+		config.Spec.Strategy = test.strategy
+		if config.Spec.Strategy.RollingParams != nil {
+			config.Spec.Strategy.RollingParams.MaxUnavailable = test.maxUnavailable
+		}
+
+		min, ok, err := MinAvailableReplicas(config)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if ok != test.expectedOk || min != test.expected {
+			t.Errorf("%s: expected %d (%t), got %d (%t)", test.name, test.expected, test.expectedOk, min, ok)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	configapilatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
+	"github.com/openshift/origin/pkg/diagnostics/types"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+// ConfigConsistency is a Diagnostic to check that the master and node config files found on
+// this host agree with the state of the running cluster.
+type ConfigConsistency struct {
+	KubeClient       *kclient.Client
+	OsClient         *osclient.Client
+	MasterConfigFile string
+	NodeConfigFile   string
+}
+
+const (
+	ConfigConsistencyName = "ConfigConsistency"
+
+	// clusterNetworkName is the name of the ClusterNetwork record created by the SDN master
+	clusterNetworkName = "default"
+
+	clServiceOutsideNetwork = `
+The "%s" service in project "%s" has the IP %s, which is not part of the
+service network %s configured in the master config file '%s'.
+This usually means the service network was changed after the service was
+created. Services keep their IP when the service network changes, so they
+will not be reachable through the new network. Either restore the original
+service network in the master config file, or delete and recreate the
+service so that it is assigned an IP from the new network.`
+
+	clClusterNetworkMismatch = `
+The %s recorded by the SDN in the cluster is %q, but the master config
+file '%s' specifies %q for %s.
+The SDN does not support changing its network configuration once it has
+been initialized, so the value in the config file is ignored. Restore the
+original value in the master config file to avoid confusion.`
+
+	clNodeNotRegistered = `
+The node config file '%s' specifies the node name %q, but no node with
+that name is registered with the master.
+Check that the node is running and can reach the master, and that the
+nodeName in the node config file matches a name listed by 'oc get nodes'.`
+)
+
+// infrastructureServices are services whose IP is checked against the configured service network
+var infrastructureServices = []string{"kubernetes", registryName}
+
+func (d *ConfigConsistency) Name() string {
+	return ConfigConsistencyName
+}
+
+func (d *ConfigConsistency) Description() string {
+	return "Check that the master and node config files are consistent with the cluster"
+}
+
+func (d *ConfigConsistency) CanRun() (bool, error) {
+	if d.KubeClient == nil || d.OsClient == nil {
+		return false, errors.New("must have kube and os client")
+	}
+	if len(d.MasterConfigFile) == 0 && len(d.NodeConfigFile) == 0 {
+		return false, errors.New("must have a master or node config file")
+	}
+	can, err := userCan(d.OsClient, authorizationapi.AuthorizationAttributes{
+		Namespace: kapi.NamespaceDefault,
+		Verb:      "get",
+		Resource:  "services",
+	})
+	if err != nil {
+		return false, types.DiagnosticError{ID: "DClu4000", LogMessage: fmt.Sprintf("Unable to determine access to services:\n%v", err), Cause: err}
+	} else if !can {
+		return false, types.DiagnosticError{ID: "DClu4001", LogMessage: "Client does not have access to see services in the default project", Cause: err}
+	}
+	return true, nil
+}
+
+func (d *ConfigConsistency) Check() types.DiagnosticResult {
+	r := types.NewDiagnosticResult(ConfigConsistencyName)
+	if len(d.MasterConfigFile) > 0 {
+		d.checkMasterConfig(r)
+	}
+	if len(d.NodeConfigFile) > 0 {
+		d.checkNodeConfig(r)
+	}
+	return r
+}
+
+func (d *ConfigConsistency) checkMasterConfig(r types.DiagnosticResult) {
+	masterConfig, err := configapilatest.ReadAndResolveMasterConfig(d.MasterConfigFile)
+	if err != nil {
+		r.Error("DClu4002", err, fmt.Sprintf("Could not read master config file '%s':\n(%T) %[2]v", d.MasterConfigFile, err))
+		return
+	}
+
+	serviceNetwork := masterConfig.NetworkConfig.ServiceNetworkCIDR
+	if len(serviceNetwork) == 0 && masterConfig.KubernetesMasterConfig != nil {
+		serviceNetwork = masterConfig.KubernetesMasterConfig.ServicesSubnet
+	}
+	if len(serviceNetwork) > 0 {
+		services := []kapi.Service{}
+		for _, name := range infrastructureServices {
+			service, err := d.KubeClient.Services(kapi.NamespaceDefault).Get(name)
+			switch {
+			case kerrs.IsNotFound(err):
+				r.Debug("DClu4003", fmt.Sprintf("There is no %q service in project %q to compare with the service network", name, kapi.NamespaceDefault))
+			case err != nil:
+				r.Error("DClu4004", err, fmt.Sprintf("Unable to retrieve the %q service in project %q:\n%v", name, kapi.NamespaceDefault, err))
+			default:
+				services = append(services, *service)
+			}
+		}
+		checkServiceNetwork(r, d.MasterConfigFile, serviceNetwork, services)
+	}
+
+	if len(masterConfig.NetworkConfig.NetworkPluginName) > 0 {
+		clusterNetwork, err := d.OsClient.ClusterNetwork().Get(clusterNetworkName)
+		switch {
+		case kerrs.IsNotFound(err):
+			r.Debug("DClu4005", "The SDN has not recorded a cluster network yet")
+		case err != nil:
+			r.Error("DClu4006", err, fmt.Sprintf("Unable to retrieve the cluster network:\n%v", err))
+		default:
+			checkClusterNetwork(r, d.MasterConfigFile, masterConfig.NetworkConfig, clusterNetwork)
+		}
+	}
+}
+
+func (d *ConfigConsistency) checkNodeConfig(r types.DiagnosticResult) {
+	nodeConfig, err := configapilatest.ReadAndResolveNodeConfig(d.NodeConfigFile)
+	if err != nil {
+		r.Error("DClu4007", err, fmt.Sprintf("Could not read node config file '%s':\n(%T) %[2]v", d.NodeConfigFile, err))
+		return
+	}
+
+	_, err = d.KubeClient.Nodes().Get(nodeConfig.NodeName)
+	switch {
+	case kerrs.IsNotFound(err):
+		r.Warn("DClu4008", err, fmt.Sprintf(clNodeNotRegistered, d.NodeConfigFile, nodeConfig.NodeName))
+	case err != nil:
+		r.Error("DClu4009", err, fmt.Sprintf("Unable to retrieve node %q:\n%v", nodeConfig.NodeName, err))
+	default:
+		r.Debug("DClu4010", fmt.Sprintf("Node %q from the node config file is registered with the master", nodeConfig.NodeName))
+	}
+}
+
+// checkServiceNetwork reports an error for each service whose IP is outside of serviceNetwork.
+func checkServiceNetwork(r types.DiagnosticResult, file, serviceNetwork string, services []kapi.Service) {
+	_, cidr, err := net.ParseCIDR(strings.TrimSpace(serviceNetwork))
+	if err != nil {
+		// reported by the master config validation
+		return
+	}
+	for _, service := range services {
+		ip := net.ParseIP(service.Spec.ClusterIP)
+		if ip == nil {
+			continue
+		}
+		if !cidr.Contains(ip) {
+			r.Error("DClu4011", nil, fmt.Sprintf(clServiceOutsideNetwork, service.Name, service.Namespace, service.Spec.ClusterIP, serviceNetwork, file))
+			continue
+		}
+		r.Debug("DClu4012", fmt.Sprintf("The %q service IP %s is part of the service network %s", service.Name, service.Spec.ClusterIP, serviceNetwork))
+	}
+}
+
+// checkClusterNetwork reports an error for each value of the network config that differs from the
+// values recorded by the SDN.
+func checkClusterNetwork(r types.DiagnosticResult, file string, config configapi.MasterNetworkConfig, clusterNetwork *sdnapi.ClusterNetwork) {
+	if len(config.ClusterNetworkCIDR) > 0 && config.ClusterNetworkCIDR != clusterNetwork.Network {
+		r.Error("DClu4013", nil, fmt.Sprintf(clClusterNetworkMismatch, "cluster network", clusterNetwork.Network, file, config.ClusterNetworkCIDR, "networkConfig.clusterNetworkCIDR"))
+	}
+	if len(config.ServiceNetworkCIDR) > 0 && len(clusterNetwork.ServiceNetwork) > 0 && config.ServiceNetworkCIDR != clusterNetwork.ServiceNetwork {
+		r.Error("DClu4014", nil, fmt.Sprintf(clClusterNetworkMismatch, "service network", clusterNetwork.ServiceNetwork, file, config.ServiceNetworkCIDR, "networkConfig.serviceNetworkCIDR"))
+	}
+	if config.HostSubnetLength > 0 && int(config.HostSubnetLength) != clusterNetwork.HostSubnetLength {
+		r.Error("DClu4015", nil, fmt.Sprintf(clClusterNetworkMismatch, "host subnet length", fmt.Sprintf("%d", clusterNetwork.HostSubnetLength), file, fmt.Sprintf("%d", config.HostSubnetLength), "networkConfig.hostSubnetLength"))
+	}
+}
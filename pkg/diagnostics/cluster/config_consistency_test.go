@@ -0,0 +1,90 @@
+package cluster
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	"github.com/openshift/origin/pkg/diagnostics/types"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+func TestCheckServiceNetwork(t *testing.T) {
+	service := func(name, ip string) kapi.Service {
+		return kapi.Service{
+			ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: kapi.NamespaceDefault},
+			Spec:       kapi.ServiceSpec{ClusterIP: ip},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		serviceNetwork string
+		services       []kapi.Service
+		expectedErrors int
+	}{
+		{
+			name:           "services in network",
+			serviceNetwork: "172.30.0.0/16",
+			services:       []kapi.Service{service("kubernetes", "172.30.0.1"), service("docker-registry", "172.30.10.20")},
+		},
+		{
+			name:           "service outside network",
+			serviceNetwork: "172.30.0.0/16",
+			services:       []kapi.Service{service("kubernetes", "172.31.0.1"), service("docker-registry", "172.30.10.20")},
+			expectedErrors: 1,
+		},
+		{
+			name:           "headless service",
+			serviceNetwork: "172.30.0.0/16",
+			services:       []kapi.Service{service("kubernetes", kapi.ClusterIPNone)},
+		},
+		{
+			name:           "invalid network",
+			serviceNetwork: "invalid",
+			services:       []kapi.Service{service("kubernetes", "172.30.0.1")},
+		},
+	}
+
+	for _, test := range tests {
+		r := types.NewDiagnosticResult(ConfigConsistencyName)
+		checkServiceNetwork(r, "master-config.yaml", test.serviceNetwork, test.services)
+		if len(r.Errors()) != test.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", test.name, test.expectedErrors, r.Errors())
+		}
+	}
+}
+
+func TestCheckClusterNetwork(t *testing.T) {
+	clusterNetwork := &sdnapi.ClusterNetwork{Network: "10.1.0.0/16", HostSubnetLength: 8, ServiceNetwork: "172.30.0.0/16"}
+
+	tests := []struct {
+		name           string
+		config         configapi.MasterNetworkConfig
+		expectedErrors int
+	}{
+		{
+			name:   "matching",
+			config: configapi.MasterNetworkConfig{ClusterNetworkCIDR: "10.1.0.0/16", HostSubnetLength: 8, ServiceNetworkCIDR: "172.30.0.0/16"},
+		},
+		{
+			name:           "changed cluster network",
+			config:         configapi.MasterNetworkConfig{ClusterNetworkCIDR: "10.2.0.0/16", HostSubnetLength: 8, ServiceNetworkCIDR: "172.30.0.0/16"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "changed everything",
+			config:         configapi.MasterNetworkConfig{ClusterNetworkCIDR: "10.2.0.0/16", HostSubnetLength: 9, ServiceNetworkCIDR: "172.31.0.0/16"},
+			expectedErrors: 3,
+		},
+	}
+
+	for _, test := range tests {
+		r := types.NewDiagnosticResult(ConfigConsistencyName)
+		checkClusterNetwork(r, "master-config.yaml", test.config, clusterNetwork)
+		if len(r.Errors()) != test.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", test.name, test.expectedErrors, r.Errors())
+		}
+	}
+}
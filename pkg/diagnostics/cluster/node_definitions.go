@@ -6,16 +6,19 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	osclient "github.com/openshift/origin/pkg/client"
 	"github.com/openshift/origin/pkg/diagnostics/log"
 	"github.com/openshift/origin/pkg/diagnostics/types"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
 )
 
 const (
@@ -44,6 +47,15 @@ An administrator can mark the node schedulable with:
 While in this state, pods should not be scheduled to deploy on the node.
 Existing pods will continue to run until completed or evacuated (see
 other options for 'oadm manage-node').
+`
+
+	nodeMTUMismatch = `The nodes do not agree on the MTU of the overlay network:
+{{.mtus}}
+Packets larger than the smallest MTU are silently dropped between pods on
+nodes with different MTUs, which usually shows up as connections that hang
+once a larger response is sent. Set networkConfig.mtu to the same value in
+every node config file (or remove it from all of them to use the detected
+value on identical hosts) and restart the nodes.
 `
 )
 
@@ -118,6 +130,30 @@ func (d *NodeDefinitions) Check() types.DiagnosticResult {
 	if !anyNodesAvail {
 		r.Error("DClu0004", nil, "There were no nodes available to use. No new pods can be scheduled.")
 	}
+	checkOverlayMTU(r, nodes.Items)
 
 	return r
 }
+
+// checkOverlayMTU reports an error if the nodes have recorded different overlay network MTUs.
+func checkOverlayMTU(r types.DiagnosticResult, nodes []kapi.Node) {
+	nodesByMTU := map[string][]string{}
+	for _, node := range nodes {
+		if mtu, ok := node.Annotations[sdnapi.OverlayMTUAnnotation]; ok {
+			nodesByMTU[mtu] = append(nodesByMTU[mtu], node.Name)
+		}
+	}
+	if len(nodesByMTU) <= 1 {
+		return
+	}
+
+	mtus := sets.NewString()
+	for mtu := range nodesByMTU {
+		mtus.Insert(mtu)
+	}
+	lines := []string{}
+	for _, mtu := range mtus.List() {
+		lines = append(lines, fmt.Sprintf("  %s: %s", mtu, strings.Join(nodesByMTU[mtu], ", ")))
+	}
+	r.Error("DClu0007", nil, log.EvalTemplate("DClu0007", nodeMTUMismatch, log.Hash{"mtus": strings.Join(lines, "\n")}))
+}
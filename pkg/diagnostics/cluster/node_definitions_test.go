@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/openshift/origin/pkg/diagnostics/types"
+	sdnapi "github.com/openshift/origin/pkg/sdn/api"
+)
+
+func TestCheckOverlayMTU(t *testing.T) {
+	node := func(name, mtu string) kapi.Node {
+		node := kapi.Node{ObjectMeta: kapi.ObjectMeta{Name: name}}
+		if len(mtu) > 0 {
+			node.Annotations = map[string]string{sdnapi.OverlayMTUAnnotation: mtu}
+		}
+		return node
+	}
+
+	tests := []struct {
+		name           string
+		nodes          []kapi.Node
+		expectedErrors int
+	}{
+		{
+			name:  "consistent",
+			nodes: []kapi.Node{node("a", "1450"), node("b", "1450"), node("c", "")},
+		},
+		{
+			name:           "mismatched",
+			nodes:          []kapi.Node{node("a", "1450"), node("b", "8950")},
+			expectedErrors: 1,
+		},
+	}
+
+	for _, test := range tests {
+		r := types.NewDiagnosticResult(NodeDefinitionsName)
+		checkOverlayMTU(r, test.nodes)
+		if len(r.Errors()) != test.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", test.name, test.expectedErrors, r.Errors())
+		}
+	}
+}
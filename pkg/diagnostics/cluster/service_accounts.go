@@ -0,0 +1,410 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller/serviceaccount"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	"github.com/openshift/origin/pkg/diagnostics/types"
+	"github.com/openshift/origin/pkg/serviceaccounts/controllers"
+)
+
+// ServiceAccounts is a Diagnostic to check that the service accounts used by infrastructure
+// components exist, may use the security context constraints they need, are bound to the roles
+// the bootstrap policy grants them, and have valid dockercfg secrets.
+type ServiceAccounts struct {
+	KubeClient *kclient.Client
+	OsClient   *osclient.Client
+	// InfraNamespace is the namespace holding the infrastructure controller service accounts.
+	// Defaults to bootstrappolicy.DefaultOpenShiftInfraNamespace.
+	InfraNamespace string
+}
+
+const (
+	ServiceAccountsName = "ServiceAccounts"
+
+	clSAMissing = `
+The "%s" service account in project "%s" does not exist.
+%s
+Service accounts in this project are normally created automatically by the
+master. If it is not created again shortly, check the master logs for errors
+from the service account controllers.`
+
+	clSAMissingDC = `
+The "%[1]s" deployment config in project "%[2]s" runs as the "%[3]s" service
+account, which does not exist. Pods for "%[1]s" cannot be created until the
+service account is created again, e.g. with:
+
+  oc create serviceaccount %[3]s -n %[2]s`
+
+	clSANoSCC = `
+The "%[1]s" deployment config in project "%[2]s" runs as the "%[3]s" service
+account, and its pods require %[4]s. No security context constraint allowing
+this is granted to the service account, so its pods will be rejected.
+Add the service account to a suitable SCC, e.g. with:
+
+  oadm policy add-scc-to-user privileged system:serviceaccount:%[2]s:%[3]s`
+
+	clSABootstrapSCC = `
+The bootstrap policy grants the user "%[1]s" the "%[2]s" security context
+constraint, but the user is not listed in it. Infrastructure pods run by this
+user may be rejected. Add the user back to the SCC with:
+
+  oadm policy add-scc-to-user %[2]s %[1]s`
+
+	clSARoleBinding = `
+The bootstrap policy binds the "%[1]s" role to %[2]s in project "%[3]s"
+through the "%[4]s" role binding, but no such binding was found. Builds or
+deployments in the project may fail with authorization errors.
+Restore the binding with:
+
+  oc policy add-role-to-user %[1]s -z %[5]s -n %[3]s`
+
+	clSANoDockercfg = `
+The "%s" service account in project "%s" has no dockercfg secret to pull images
+from the integrated registry. The master normally creates one automatically;
+if it does not appear shortly, check the master logs for errors from the
+dockercfg controllers.`
+
+	clSADockercfgMissing = `
+The "%s" service account in project "%s" refers to the dockercfg secret "%s",
+which does not exist. Remove the reference from the service account so that
+the master creates a new secret.`
+
+	clSADockercfgStale = `
+The dockercfg secret "%s" of the "%s" service account in project "%s" uses the
+token secret "%s", which no longer exists or belongs to a different service
+account. The credentials in the dockercfg secret are no longer valid. Delete
+the dockercfg secret so that the master creates a new one.`
+)
+
+// infraDeploymentConfigs are the deployment configs in the default project whose service accounts are checked
+var infraDeploymentConfigs = []string{routerName, registryName}
+
+func (d *ServiceAccounts) Name() string {
+	return ServiceAccountsName
+}
+
+func (d *ServiceAccounts) Description() string {
+	return "Check that infrastructure service accounts exist and have the expected access and secrets"
+}
+
+func (d *ServiceAccounts) CanRun() (bool, error) {
+	if d.KubeClient == nil || d.OsClient == nil {
+		return false, errors.New("must have kube and os client")
+	}
+	can, err := userCan(d.OsClient, authorizationapi.AuthorizationAttributes{
+		Verb:     "list",
+		Resource: "securitycontextconstraints",
+	})
+	if err != nil {
+		return false, types.DiagnosticError{ID: "DClu5000", LogMessage: fmt.Sprintf("Unable to determine access to security context constraints:\n%v", err), Cause: err}
+	} else if !can {
+		return false, types.DiagnosticError{ID: "DClu5001", LogMessage: "Client does not have access to see security context constraints", Cause: err}
+	}
+	return true, nil
+}
+
+func (d *ServiceAccounts) Check() types.DiagnosticResult {
+	r := types.NewDiagnosticResult(ServiceAccountsName)
+
+	infraNamespace := d.InfraNamespace
+	if len(infraNamespace) == 0 {
+		infraNamespace = bootstrappolicy.DefaultOpenShiftInfraNamespace
+	}
+
+	sccList, err := d.KubeClient.SecurityContextConstraints().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		r.Error("DClu5002", err, fmt.Sprintf("Unable to list security context constraints:\n%v", err))
+		return r
+	}
+	checkBootstrapSCCUsers(r, infraNamespace, sccList.Items)
+
+	for _, name := range infraDeploymentConfigs {
+		d.checkDeploymentConfig(r, kapi.NamespaceDefault, name, sccList.Items)
+	}
+
+	for _, name := range []string{bootstrappolicy.BuilderServiceAccountName, bootstrappolicy.DeployerServiceAccountName} {
+		if sa, ok := d.getServiceAccount(r, kapi.NamespaceDefault, name, ""); ok {
+			d.checkDockercfgSecrets(r, sa)
+		}
+	}
+	for _, name := range bootstrappolicy.InfraSAs.GetServiceAccounts() {
+		d.getServiceAccount(r, infraNamespace, name, "It is used by the master to run its controllers.")
+	}
+
+	d.checkRoleBindings(r, kapi.NamespaceDefault)
+	return r
+}
+
+// getServiceAccount retrieves a service account and reports an error if it does not exist.
+func (d *ServiceAccounts) getServiceAccount(r types.DiagnosticResult, namespace, name, purpose string) (*kapi.ServiceAccount, bool) {
+	sa, err := d.KubeClient.ServiceAccounts(namespace).Get(name)
+	switch {
+	case kerrs.IsNotFound(err):
+		r.Error("DClu5003", err, fmt.Sprintf(clSAMissing, name, namespace, purpose))
+		return nil, false
+	case err != nil:
+		r.Error("DClu5004", err, fmt.Sprintf("Unable to retrieve the %q service account in project %q:\n%v", name, namespace, err))
+		return nil, false
+	}
+	r.Debug("DClu5005", fmt.Sprintf("Found the %q service account in project %q", name, namespace))
+	return sa, true
+}
+
+// checkDeploymentConfig verifies that the service account of the named deployment config exists,
+// is allowed to run its pods, and has a usable dockercfg secret.
+func (d *ServiceAccounts) checkDeploymentConfig(r types.DiagnosticResult, namespace, name string, sccs []kapi.SecurityContextConstraints) {
+	dc, err := d.OsClient.DeploymentConfigs(namespace).Get(name)
+	switch {
+	case kerrs.IsNotFound(err):
+		r.Debug("DClu5006", fmt.Sprintf("There is no %q deployment config in project %q", name, namespace))
+		return
+	case err != nil:
+		r.Error("DClu5007", err, fmt.Sprintf("Unable to retrieve the %q deployment config in project %q:\n%v", name, namespace, err))
+		return
+	}
+	if dc.Spec.Template == nil {
+		return
+	}
+	podSpec := dc.Spec.Template.Spec
+	saName := podSpec.ServiceAccountName
+	if len(saName) == 0 {
+		saName = bootstrappolicy.DefaultServiceAccountName
+	}
+
+	sa, err := d.KubeClient.ServiceAccounts(namespace).Get(saName)
+	switch {
+	case kerrs.IsNotFound(err):
+		r.Error("DClu5008", err, fmt.Sprintf(clSAMissingDC, name, namespace, saName))
+		return
+	case err != nil:
+		r.Error("DClu5004", err, fmt.Sprintf("Unable to retrieve the %q service account in project %q:\n%v", saName, namespace, err))
+		return
+	}
+
+	checkServiceAccountSCC(r, name, sa, &podSpec, sccs)
+	d.checkDockercfgSecrets(r, sa)
+}
+
+// checkDockercfgSecrets retrieves the dockercfg secrets of the service account and checks them.
+func (d *ServiceAccounts) checkDockercfgSecrets(r types.DiagnosticResult, sa *kapi.ServiceAccount) {
+	secrets := map[string]*kapi.Secret{}
+	for _, ref := range sa.ImagePullSecrets {
+		secret, err := d.KubeClient.Secrets(sa.Namespace).Get(ref.Name)
+		if err != nil {
+			if !kerrs.IsNotFound(err) {
+				r.Error("DClu5009", err, fmt.Sprintf("Unable to retrieve secret %q in project %q:\n%v", ref.Name, sa.Namespace, err))
+			}
+			continue
+		}
+		secrets[secret.Name] = secret
+		if tokenName := secret.Annotations[controllers.ServiceAccountTokenSecretNameKey]; len(tokenName) > 0 {
+			token, err := d.KubeClient.Secrets(sa.Namespace).Get(tokenName)
+			if err != nil {
+				if !kerrs.IsNotFound(err) {
+					r.Error("DClu5009", err, fmt.Sprintf("Unable to retrieve secret %q in project %q:\n%v", tokenName, sa.Namespace, err))
+				}
+				continue
+			}
+			secrets[token.Name] = token
+		}
+	}
+	checkDockercfgSecrets(r, sa, secrets)
+}
+
+// checkRoleBindings verifies that the service account role bindings of the bootstrap policy exist.
+func (d *ServiceAccounts) checkRoleBindings(r types.DiagnosticResult, namespace string) {
+	bindings, err := d.OsClient.RoleBindings(namespace).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		r.Error("DClu5010", err, fmt.Sprintf("Unable to list role bindings in project %q:\n%v", namespace, err))
+		return
+	}
+	checkRoleBindings(r, namespace, bootstrappolicy.GetBootstrapServiceAccountProjectRoleBindings(namespace), bindings.Items)
+}
+
+// checkBootstrapSCCUsers reports an error for each user the bootstrap policy grants an SCC that is
+// no longer listed in it.
+func checkBootstrapSCCUsers(r types.DiagnosticResult, infraNamespace string, sccs []kapi.SecurityContextConstraints) {
+	_, users := bootstrappolicy.GetBoostrapSCCAccess(infraNamespace)
+	for _, scc := range sccs {
+		actual := sets.NewString(scc.Users...)
+		for _, user := range users[scc.Name] {
+			if !actual.Has(user) {
+				r.Error("DClu5011", nil, fmt.Sprintf(clSABootstrapSCC, user, scc.Name))
+			}
+		}
+	}
+}
+
+// checkServiceAccountSCC reports an error if none of the SCCs granted to the service account
+// allows the host access that podSpec requires.
+func checkServiceAccountSCC(r types.DiagnosticResult, dcName string, sa *kapi.ServiceAccount, podSpec *kapi.PodSpec, sccs []kapi.SecurityContextConstraints) {
+	requirements := hostAccessRequirements(podSpec)
+	if len(requirements) == 0 {
+		return
+	}
+
+	user := serviceaccount.MakeUsername(sa.Namespace, sa.Name)
+	groups := sets.NewString(serviceaccount.MakeGroupNames(sa.Namespace, sa.Name)...)
+	groups.Insert(bootstrappolicy.AuthenticatedGroup)
+	for i := range sccs {
+		scc := &sccs[i]
+		if !sets.NewString(scc.Users...).Has(user) && !groups.HasAny(scc.Groups...) {
+			continue
+		}
+		if sccAllows(scc, podSpec) {
+			r.Debug("DClu5012", fmt.Sprintf("The %q service account in project %q may use the %q security context constraint", sa.Name, sa.Namespace, scc.Name))
+			return
+		}
+	}
+	r.Error("DClu5013", nil, fmt.Sprintf(clSANoSCC, dcName, sa.Namespace, sa.Name, joinRequirements(requirements)))
+}
+
+// hostAccessRequirements describes the host access podSpec requires from an SCC.
+func hostAccessRequirements(podSpec *kapi.PodSpec) []string {
+	requirements := []string{}
+	if podSpec.SecurityContext != nil && podSpec.SecurityContext.HostNetwork {
+		requirements = append(requirements, "host networking")
+	}
+	if usesHostPorts(podSpec) {
+		requirements = append(requirements, "host ports")
+	}
+	if usesPrivilegedContainers(podSpec) {
+		requirements = append(requirements, "privileged containers")
+	}
+	if usesHostPathVolumes(podSpec) {
+		requirements = append(requirements, "host path volumes")
+	}
+	return requirements
+}
+
+// sccAllows returns true if scc allows the host access podSpec requires.
+func sccAllows(scc *kapi.SecurityContextConstraints, podSpec *kapi.PodSpec) bool {
+	if podSpec.SecurityContext != nil && podSpec.SecurityContext.HostNetwork && !scc.AllowHostNetwork {
+		return false
+	}
+	if usesHostPorts(podSpec) && !scc.AllowHostPorts {
+		return false
+	}
+	if usesPrivilegedContainers(podSpec) && !scc.AllowPrivilegedContainer {
+		return false
+	}
+	if usesHostPathVolumes(podSpec) && !scc.AllowHostDirVolumePlugin {
+		return false
+	}
+	return true
+}
+
+func usesHostPorts(podSpec *kapi.PodSpec) bool {
+	for _, container := range podSpec.Containers {
+		for _, port := range container.Ports {
+			if port.HostPort != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func usesPrivilegedContainers(podSpec *kapi.PodSpec) bool {
+	for _, container := range podSpec.Containers {
+		if container.SecurityContext != nil && container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
+			return true
+		}
+	}
+	return false
+}
+
+func usesHostPathVolumes(podSpec *kapi.PodSpec) bool {
+	for _, volume := range podSpec.Volumes {
+		if volume.HostPath != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func joinRequirements(requirements []string) string {
+	last := len(requirements) - 1
+	if last == 0 {
+		return requirements[0]
+	}
+	return strings.Join(requirements[:last], ", ") + " and " + requirements[last]
+}
+
+// checkRoleBindings reports an error for each expected binding that does not exist or no longer
+// includes the expected service account subjects.
+func checkRoleBindings(r types.DiagnosticResult, namespace string, expected []authorizationapi.RoleBinding, actual []authorizationapi.RoleBinding) {
+	byName := map[string]*authorizationapi.RoleBinding{}
+	for i := range actual {
+		byName[actual[i].Name] = &actual[i]
+	}
+
+	for _, binding := range expected {
+		for _, subject := range binding.Subjects {
+			if subject.Kind != authorizationapi.ServiceAccountKind {
+				continue
+			}
+			if existing, ok := byName[binding.Name]; ok && existing.RoleRef.Name == binding.RoleRef.Name && hasServiceAccountSubject(existing, namespace, subject.Name) {
+				r.Debug("DClu5014", fmt.Sprintf("The %q role binding in project %q includes the %q service account", binding.Name, namespace, subject.Name))
+				continue
+			}
+			description := fmt.Sprintf("the %q service account", subject.Name)
+			r.Error("DClu5015", nil, fmt.Sprintf(clSARoleBinding, binding.RoleRef.Name, description, namespace, binding.Name, subject.Name))
+		}
+	}
+}
+
+func hasServiceAccountSubject(binding *authorizationapi.RoleBinding, namespace, name string) bool {
+	for _, subject := range binding.Subjects {
+		if subject.Kind != authorizationapi.ServiceAccountKind || subject.Name != name {
+			continue
+		}
+		if len(subject.Namespace) == 0 || subject.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDockercfgSecrets reports problems with the dockercfg secrets of sa. secrets holds the
+// secrets of the service account's namespace that were found, by name.
+func checkDockercfgSecrets(r types.DiagnosticResult, sa *kapi.ServiceAccount, secrets map[string]*kapi.Secret) {
+	found := false
+	for _, ref := range sa.ImagePullSecrets {
+		secret, ok := secrets[ref.Name]
+		if !ok {
+			r.Warn("DClu5016", nil, fmt.Sprintf(clSADockercfgMissing, sa.Name, sa.Namespace, ref.Name))
+			continue
+		}
+		if secret.Type != kapi.SecretTypeDockercfg {
+			continue
+		}
+		found = true
+
+		tokenName, ok := secret.Annotations[controllers.ServiceAccountTokenSecretNameKey]
+		if !ok {
+			continue
+		}
+		if token, ok := secrets[tokenName]; !ok || !serviceaccount.IsServiceAccountToken(token, sa) {
+			r.Error("DClu5017", nil, fmt.Sprintf(clSADockercfgStale, secret.Name, sa.Name, sa.Namespace, tokenName))
+			continue
+		}
+		r.Debug("DClu5018", fmt.Sprintf("The dockercfg secret %q of the %q service account in project %q is valid", secret.Name, sa.Name, sa.Namespace))
+	}
+	if !found {
+		r.Warn("DClu5019", nil, fmt.Sprintf(clSANoDockercfg, sa.Name, sa.Namespace))
+	}
+}
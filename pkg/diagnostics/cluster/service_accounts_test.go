@@ -0,0 +1,150 @@
+package cluster
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	"github.com/openshift/origin/pkg/diagnostics/types"
+	"github.com/openshift/origin/pkg/serviceaccounts/controllers"
+)
+
+func TestCheckServiceAccountSCC(t *testing.T) {
+	sa := &kapi.ServiceAccount{ObjectMeta: kapi.ObjectMeta{Name: "router", Namespace: "default"}}
+	hostNetwork := &kapi.PodSpec{SecurityContext: &kapi.PodSecurityContext{HostNetwork: true}}
+	restricted := kapi.SecurityContextConstraints{ObjectMeta: kapi.ObjectMeta{Name: "restricted"}, Groups: []string{bootstrappolicy.AuthenticatedGroup}}
+	privileged := kapi.SecurityContextConstraints{ObjectMeta: kapi.ObjectMeta{Name: "privileged"}, AllowHostNetwork: true, AllowHostPorts: true}
+	privilegedForRouter := privileged
+	privilegedForRouter.Users = []string{"system:serviceaccount:default:router"}
+
+	tests := []struct {
+		name           string
+		podSpec        *kapi.PodSpec
+		sccs           []kapi.SecurityContextConstraints
+		expectedErrors int
+	}{
+		{
+			name:    "no host access required",
+			podSpec: &kapi.PodSpec{},
+			sccs:    []kapi.SecurityContextConstraints{restricted},
+		},
+		{
+			name:           "host network not granted",
+			podSpec:        hostNetwork,
+			sccs:           []kapi.SecurityContextConstraints{restricted, privileged},
+			expectedErrors: 1,
+		},
+		{
+			name:    "host network granted",
+			podSpec: hostNetwork,
+			sccs:    []kapi.SecurityContextConstraints{restricted, privilegedForRouter},
+		},
+		{
+			name:           "host path volume not allowed",
+			podSpec:        &kapi.PodSpec{Volumes: []kapi.Volume{{Name: "storage", VolumeSource: kapi.VolumeSource{HostPath: &kapi.HostPathVolumeSource{Path: "/registry"}}}}},
+			sccs:           []kapi.SecurityContextConstraints{restricted, privilegedForRouter},
+			expectedErrors: 1,
+		},
+	}
+
+	for _, test := range tests {
+		r := types.NewDiagnosticResult(ServiceAccountsName)
+		checkServiceAccountSCC(r, "router", sa, test.podSpec, test.sccs)
+		if len(r.Errors()) != test.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", test.name, test.expectedErrors, r.Errors())
+		}
+	}
+}
+
+func TestCheckBootstrapSCCUsers(t *testing.T) {
+	sccs := []kapi.SecurityContextConstraints{{ObjectMeta: kapi.ObjectMeta{Name: bootstrappolicy.SecurityContextConstraintPrivileged}}}
+	r := types.NewDiagnosticResult(ServiceAccountsName)
+	checkBootstrapSCCUsers(r, "openshift-infra", sccs)
+	if len(r.Errors()) != 1 {
+		t.Errorf("expected the missing build controller user to be reported, got %v", r.Errors())
+	}
+
+	sccs[0].Users = []string{"system:serviceaccount:openshift-infra:build-controller"}
+	r = types.NewDiagnosticResult(ServiceAccountsName)
+	checkBootstrapSCCUsers(r, "openshift-infra", sccs)
+	if len(r.Errors()) != 0 {
+		t.Errorf("unexpected errors: %v", r.Errors())
+	}
+}
+
+func TestCheckRoleBindings(t *testing.T) {
+	expected := bootstrappolicy.GetBootstrapServiceAccountProjectRoleBindings("default")
+	actual := append([]authorizationapi.RoleBinding{}, expected...)
+
+	r := types.NewDiagnosticResult(ServiceAccountsName)
+	checkRoleBindings(r, "default", expected, actual)
+	if len(r.Errors()) != 0 {
+		t.Errorf("unexpected errors: %v", r.Errors())
+	}
+
+	// drop the deployer binding and remove the builder from its binding
+	actual = actual[:len(actual)-1]
+	actual[1].Subjects = []kapi.ObjectReference{{Kind: authorizationapi.ServiceAccountKind, Name: "other"}}
+	r = types.NewDiagnosticResult(ServiceAccountsName)
+	checkRoleBindings(r, "default", expected, actual)
+	if len(r.Errors()) != 2 {
+		t.Errorf("expected 2 errors, got %v", r.Errors())
+	}
+}
+
+func TestCheckDockercfgSecrets(t *testing.T) {
+	sa := &kapi.ServiceAccount{
+		ObjectMeta:       kapi.ObjectMeta{Name: "builder", Namespace: "default", UID: "1"},
+		ImagePullSecrets: []kapi.LocalObjectReference{{Name: "builder-dockercfg"}},
+	}
+	dockercfg := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Name: "builder-dockercfg", Annotations: map[string]string{controllers.ServiceAccountTokenSecretNameKey: "builder-token"}},
+		Type:       kapi.SecretTypeDockercfg,
+	}
+	token := func(uid string) *kapi.Secret {
+		return &kapi.Secret{
+			ObjectMeta: kapi.ObjectMeta{Name: "builder-token", Annotations: map[string]string{kapi.ServiceAccountNameKey: "builder", kapi.ServiceAccountUIDKey: uid}},
+			Type:       kapi.SecretTypeServiceAccountToken,
+		}
+	}
+
+	tests := []struct {
+		name             string
+		secrets          map[string]*kapi.Secret
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{
+			name:    "valid",
+			secrets: map[string]*kapi.Secret{"builder-dockercfg": dockercfg, "builder-token": token("1")},
+		},
+		{
+			name:           "token of a deleted service account",
+			secrets:        map[string]*kapi.Secret{"builder-dockercfg": dockercfg, "builder-token": token("0")},
+			expectedErrors: 1,
+		},
+		{
+			name:           "token missing",
+			secrets:        map[string]*kapi.Secret{"builder-dockercfg": dockercfg},
+			expectedErrors: 1,
+		},
+		{
+			name:             "dockercfg missing",
+			secrets:          map[string]*kapi.Secret{},
+			expectedWarnings: 2,
+		},
+	}
+
+	for _, test := range tests {
+		r := types.NewDiagnosticResult(ServiceAccountsName)
+		checkDockercfgSecrets(r, sa, test.secrets)
+		if len(r.Errors()) != test.expectedErrors {
+			t.Errorf("%s: expected %d errors, got %v", test.name, test.expectedErrors, r.Errors())
+		}
+		if len(r.Warnings()) != test.expectedWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", test.name, test.expectedWarnings, r.Warnings())
+		}
+	}
+}
@@ -43,19 +43,6 @@ func (d MasterConfigCheck) Check() types.DiagnosticResult {
 	r.Info("DH0003", fmt.Sprintf("Found a master config file: %[1]s", d.MasterConfigFile))
 
 	results := configvalidation.ValidateMasterConfig(masterConfig)
-	if len(results.Errors) > 0 {
-		errText := fmt.Sprintf("Validation of master config file '%s' failed:\n", d.MasterConfigFile)
-		for _, err := range results.Errors {
-			errText += fmt.Sprintf("%v\n", err)
-		}
-		r.Error("DH0004", nil, errText)
-	}
-	if len(results.Warnings) > 0 {
-		warnText := fmt.Sprintf("Validation of master config file '%s' warned:\n", d.MasterConfigFile)
-		for _, warn := range results.Warnings {
-			warnText += fmt.Sprintf("%v\n", warn)
-		}
-		r.Warn("DH0005", nil, warnText)
-	}
+	reportValidationResults(r, "DH0004", "DH0005", "master", d.MasterConfigFile, results)
 	return r
 }
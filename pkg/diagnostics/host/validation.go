@@ -0,0 +1,34 @@
+package host
+
+import (
+	"fmt"
+
+	configvalidation "github.com/openshift/origin/pkg/cmd/server/api/validation"
+	"github.com/openshift/origin/pkg/diagnostics/types"
+)
+
+const (
+	configValidationError = `
+Validation of the %s config file '%s' failed:
+  %v
+The server will refuse to start with this configuration. Correct the value
+in the config file and restart the server.`
+
+	configValidationWarning = `
+Validation of the %s config file '%s' warned:
+  %v
+The server will start with this configuration, but the value may not behave
+as intended. Review the value in the config file and restart the server if
+it is changed.`
+)
+
+// reportValidationResults records a separate error or warning on r for each problem found
+// while validating a config file, so that each one can be addressed on its own.
+func reportValidationResults(r types.DiagnosticResult, errorID, warningID, kind, file string, results configvalidation.ValidationResults) {
+	for _, err := range results.Errors {
+		r.Error(errorID, err, fmt.Sprintf(configValidationError, kind, file, err))
+	}
+	for _, warn := range results.Warnings {
+		r.Warn(warningID, warn, fmt.Sprintf(configValidationWarning, kind, file, warn))
+	}
+}
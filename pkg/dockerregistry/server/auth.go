@@ -22,7 +22,10 @@ func init() {
 
 type contextKey int
 
-var userClientKey contextKey = 0
+var (
+	userClientKey contextKey = 0
+	auditKey      contextKey = 1
+)
 
 func WithUserClient(parent context.Context, userClient *client.Client) context.Context {
 	return context.WithValue(parent, userClientKey, userClient)
@@ -33,8 +36,21 @@ func UserClientFrom(ctx context.Context) (*client.Client, bool) {
 	return userClient, ok
 }
 
+// WithAudit returns a context recording that pulls and pushes in parent are audited.
+func WithAudit(parent context.Context) context.Context {
+	return context.WithValue(parent, auditKey, true)
+}
+
+// AuditFrom returns true if pulls and pushes in ctx are audited.
+func AuditFrom(ctx context.Context) bool {
+	audit, _ := ctx.Value(auditKey).(bool)
+	return audit
+}
+
 type AccessController struct {
 	realm string
+	// audit is true if the pulls and pushes of authorized requests are audited
+	audit bool
 }
 
 var _ registryauth.AccessController = &AccessController{}
@@ -67,7 +83,18 @@ func newAccessController(options map[string]interface{}) (registryauth.AccessCon
 		// Default to openshift if not present
 		realm = "origin"
 	}
-	return &AccessController{realm: realm}, nil
+	// the option may be set in the configuration file or as a string by REGISTRY_AUTH_OPENSHIFT_AUDIT
+	audit := false
+	switch value := options["audit"].(type) {
+	case bool:
+		audit = value
+	case string:
+		audit = value == "true"
+	}
+	if audit {
+		log.Info("Auditing image pulls and pushes")
+	}
+	return &AccessController{realm: realm, audit: audit}, nil
 }
 
 // Error returns the internal error string for this authChallenge.
@@ -86,8 +113,25 @@ func (ac *authChallenge) SetHeaders(w http.ResponseWriter) {
 	w.Header().Set("WWW-Authenticate", str)
 }
 
+// authFailureReasons are the reasons counted by the auth failure metric, other errors are counted as "error"
+var authFailureReasons = map[error]string{
+	ErrTokenRequired:          "token_required",
+	ErrTokenInvalid:           "token_invalid",
+	ErrOpenShiftTokenRequired: "openshift_token_required",
+	ErrOpenShiftAccessDenied:  "access_denied",
+	ErrNamespaceRequired:      "namespace_required",
+	ErrUnsupportedAction:      "unsupported_action",
+	ErrUnsupportedResource:    "unsupported_resource",
+}
+
 // wrapErr wraps errors related to authorization in an authChallenge error that will present a WWW-Authenticate challenge response
 func (ac *AccessController) wrapErr(err error) error {
+	reason, ok := authFailureReasons[err]
+	if !ok {
+		reason = "error"
+	}
+	authFailureCounter.WithLabelValues(reason).Inc()
+
 	switch err {
 	case ErrTokenRequired, ErrTokenInvalid, ErrOpenShiftTokenRequired, ErrOpenShiftAccessDenied:
 		// Challenge for errors that involve tokens or access denied
@@ -188,9 +232,30 @@ func (ac *AccessController) Authorized(ctx context.Context, accessRecords ...reg
 		}
 	}
 
+	if ac.audit {
+		ctx = WithAudit(ctx)
+	}
+
 	return WithUserClient(ctx, client), nil
 }
 
+// auditUser returns the name of the user of the client in ctx, or the reason it is unknown, since a failure to
+// resolve the user should not fail requests that were authorized. It is only called for audited pulls and pushes
+// so that other requests, like blob downloads, do not look up the user.
+func auditUser(ctx context.Context) string {
+	client, ok := UserClientFrom(ctx)
+	if !ok {
+		context.GetLogger(ctx).Errorf("Unable to get the user to audit: no user client in the request context")
+		return "<unknown>"
+	}
+	user, err := client.Users().Get("~")
+	if err != nil {
+		context.GetLogger(ctx).Errorf("Unable to get the user to audit: %v", err)
+		return "<unknown>"
+	}
+	return user.Name
+}
+
 func getNamespaceName(resourceName string) (string, string, error) {
 	repoParts := strings.SplitN(resourceName, "/", 2)
 	if len(repoParts) != 2 {
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pullCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_registry_image_pull_count",
+			Help: "Counter of the manifests pulled from each repository.",
+		},
+		[]string{"repository"},
+	)
+	pushCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_registry_image_push_count",
+			Help: "Counter of the manifests pushed to each repository.",
+		},
+		[]string{"repository"},
+	)
+	authFailureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_registry_auth_failure_count",
+			Help: "Counter of the requests the registry did not authorize, broken out by reason.",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(pullCounter)
+	prometheus.MustRegister(pushCounter)
+	prometheus.MustRegister(authFailureCounter)
+}
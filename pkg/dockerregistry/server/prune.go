@@ -0,0 +1,206 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/storage"
+
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// PruneStats counts what a hard prune removed, or would remove in a dry run.
+type PruneStats struct {
+	Manifests  int
+	LayerLinks int
+	Blobs      int
+}
+
+// HardPrune removes the manifest revisions, repository layer links and blobs of the registry storage that are
+// not referenced by any of images. They are left behind by pushes that failed before their image was created,
+// and by pruning images, which only removes the references to their content. The registry must not accept
+// pushes while it runs, because the content of a push in progress is not referenced by an image yet. If dryRun
+// is true, what would be removed is only reported. The registry should be created with the
+// storage.RemoveParentsOnDelete option, so that removed links are not found again by the next prune.
+func HardPrune(ctx context.Context, registry distribution.Namespace, images []imageapi.Image, dryRun bool, out io.Writer) (*PruneStats, error) {
+	manifests, blobs, err := referencedContent(images)
+	if err != nil {
+		return nil, err
+	}
+	p := &hardPruner{
+		ctx:       ctx,
+		registry:  registry,
+		manifests: manifests,
+		blobs:     blobs,
+		dryRun:    dryRun,
+		out:       out,
+		stats:     &PruneStats{},
+	}
+
+	repos := make([]string, 100)
+	last := ""
+	for {
+		n, err := registry.Repositories(ctx, repos, last)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		for _, name := range repos[:n] {
+			if err := p.pruneRepository(name); err != nil {
+				return nil, err
+			}
+		}
+		if err == io.EOF || n == 0 {
+			break
+		}
+		last = repos[n-1]
+	}
+
+	if err := p.pruneBlobs(); err != nil {
+		return nil, err
+	}
+	return p.stats, nil
+}
+
+// referencedContent returns the digests of the manifests of images and the digests of the blobs they refer to.
+// Images whose layers are unknown are an error, since their layers would be removed.
+func referencedContent(images []imageapi.Image) (sets.String, sets.String, error) {
+	manifests, blobs := sets.NewString(), sets.NewString()
+	for _, image := range images {
+		if value, ok := image.Annotations[imageapi.ManagedByOpenShiftAnnotation]; !ok || value != "true" {
+			continue
+		}
+		manifest := imageapi.DockerImageManifest{}
+		if err := json.Unmarshal([]byte(image.DockerImageManifest), &manifest); err != nil {
+			return nil, nil, fmt.Errorf("unable to read the manifest of image %s, its layers would be removed: %v", image.Name, err)
+		}
+		manifests.Insert(image.Name)
+		blobs.Insert(image.Name)
+		for _, layer := range manifest.FSLayers {
+			blobs.Insert(layer.DockerBlobSum)
+		}
+	}
+	return manifests, blobs, nil
+}
+
+type hardPruner struct {
+	ctx      context.Context
+	registry distribution.Namespace
+	// manifests are the digests of the manifests of images
+	manifests sets.String
+	// blobs are the digests of the blobs to keep, which grow with the signatures of the kept manifests
+	blobs  sets.String
+	dryRun bool
+	out    io.Writer
+	stats  *PruneStats
+}
+
+// pruneRepository removes the manifest revisions of the repository that are not the manifest of an image, and
+// its links to layers that no image refers to
+func (p *hardPruner) pruneRepository(name string) error {
+	repo, err := p.registry.Repository(p.ctx, name)
+	if err != nil {
+		return err
+	}
+	manifestService, err := repo.Manifests(p.ctx)
+	if err != nil {
+		return err
+	}
+	revisions, err := manifestService.Enumerate()
+	if err != nil {
+		return err
+	}
+	for _, dgst := range revisions {
+		if p.manifests.Has(dgst.String()) {
+			signatures, err := repo.Signatures().Enumerate(dgst)
+			if err != nil && err != io.EOF {
+				return err
+			}
+			for _, signature := range signatures {
+				p.blobs.Insert(signature.String())
+			}
+			continue
+		}
+		p.report("manifest %s of repository %s", dgst, name)
+		p.stats.Manifests++
+		if p.dryRun {
+			continue
+		}
+		if err := manifestService.Delete(dgst); err != nil {
+			return fmt.Errorf("unable to delete manifest %s of repository %s: %v", dgst, name, err)
+		}
+	}
+
+	layers := repo.Blobs(p.ctx)
+	unreferenced, err := p.unreferenced(layers)
+	if err != nil {
+		return err
+	}
+	for _, dgst := range unreferenced {
+		p.report("layer link %s of repository %s", dgst, name)
+		p.stats.LayerLinks++
+		if p.dryRun {
+			continue
+		}
+		if err := layers.Delete(p.ctx, dgst); err != nil {
+			return fmt.Errorf("unable to delete layer link %s of repository %s: %v", dgst, name, err)
+		}
+	}
+	return nil
+}
+
+// pruneBlobs removes the blobs that are neither kept manifests, their signatures, nor layers of images
+func (p *hardPruner) pruneBlobs() error {
+	enumerator, err := storage.RegistryBlobEnumerator(p.registry)
+	if err != nil {
+		return err
+	}
+	deleter, err := storage.RegistryBlobDeleter(p.registry)
+	if err != nil {
+		return err
+	}
+	unreferenced, err := p.unreferenced(enumerator)
+	if err != nil {
+		return err
+	}
+	for _, dgst := range unreferenced {
+		p.report("blob %s", dgst)
+		p.stats.Blobs++
+		if p.dryRun {
+			continue
+		}
+		if err := deleter.Delete(p.ctx, dgst); err != nil {
+			return fmt.Errorf("unable to delete blob %s: %v", dgst, err)
+		}
+	}
+	return nil
+}
+
+// unreferenced returns the digests of enumerator that are not kept. They are collected before anything is
+// deleted, since deleting while enumerating would change the storage being walked.
+func (p *hardPruner) unreferenced(enumerator distribution.BlobEnumerator) ([]digest.Digest, error) {
+	unreferenced := []digest.Digest{}
+	err := enumerator.Enumerate(p.ctx, func(dgst digest.Digest) error {
+		if !p.blobs.Has(dgst.String()) {
+			unreferenced = append(unreferenced, dgst)
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return unreferenced, nil
+}
+
+func (p *hardPruner) report(format string, args ...interface{}) {
+	if p.dryRun {
+		fmt.Fprintf(p.out, "Would delete "+format+"\n", args...)
+		return
+	}
+	fmt.Fprintf(p.out, "Deleting "+format+"\n", args...)
+}
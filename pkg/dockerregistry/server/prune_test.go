@@ -0,0 +1,150 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+	"github.com/docker/libtrust"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func putManifest(t *testing.T, repo distribution.Repository, tag string, layer digest.Digest) (digest.Digest, string) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sm, err := schema1.Sign(&schema1.Manifest{
+		Versioned: manifest.Versioned{SchemaVersion: 1},
+		Name:      repo.Name(),
+		Tag:       tag,
+		FSLayers:  []schema1.FSLayer{{BlobSum: layer}},
+		History:   []schema1.History{{V1Compatibility: `{"id":"` + tag + `"}`}},
+	}, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manifests, err := repo.Manifests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manifests.Put(sm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload, err := sm.Payload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dgst, err := digest.FromBytes(payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return dgst, string(sm.Raw)
+}
+
+func TestHardPrune(t *testing.T) {
+	ctx := context.Background()
+	registry, err := storage.NewRegistry(ctx, inmemory.New(), storage.EnableDelete, storage.RemoveParentsOnDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repo, err := registry.Repository(ctx, "test/app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	layer, err := repo.Blobs(ctx).Put(ctx, "application/octet-stream", []byte("layer"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	orphanedLayer, err := repo.Blobs(ctx).Put(ctx, "application/octet-stream", []byte("orphaned layer"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kept, keptManifest := putManifest(t, repo, "latest", layer.Digest)
+	orphaned, _ := putManifest(t, repo, "old", layer.Digest)
+
+	images := []imageapi.Image{
+		{
+			ObjectMeta:          kapi.ObjectMeta{Name: kept.String(), Annotations: map[string]string{imageapi.ManagedByOpenShiftAnnotation: "true"}},
+			DockerImageManifest: keptManifest,
+		},
+		// images of other registries are ignored
+		{
+			ObjectMeta: kapi.ObjectMeta{Name: "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	stats, err := HardPrune(ctx, registry, images, true, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the orphaned manifest, its signature and the orphaned layer are removed
+	expected := PruneStats{Manifests: 1, LayerLinks: 1, Blobs: 3}
+	if *stats != expected {
+		t.Errorf("expected %#v, got %#v", expected, *stats)
+	}
+	if _, err := repo.Blobs(ctx).Stat(ctx, orphanedLayer.Digest); err != nil {
+		t.Errorf("expected a dry run not to delete anything, got %v", err)
+	}
+
+	out.Reset()
+	stats, err = HardPrune(ctx, registry, images, false, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *stats != expected {
+		t.Errorf("expected %#v, got %#v", expected, *stats)
+	}
+
+	manifests, err := repo.Manifests(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exists, err := manifests.Exists(kept); err != nil || !exists {
+		t.Errorf("expected manifest %s to be kept, got %v", kept, err)
+	}
+	if _, err := manifests.Get(orphaned); err == nil {
+		t.Errorf("expected manifest %s to be deleted", orphaned)
+	}
+	if _, err := repo.Blobs(ctx).Stat(ctx, layer.Digest); err != nil {
+		t.Errorf("expected layer %s to be kept, got %v", layer.Digest, err)
+	}
+	if _, err := registry.Blobs().Stat(ctx, orphanedLayer.Digest); err != distribution.ErrBlobUnknown {
+		t.Errorf("expected layer %s to be deleted, got %v", orphanedLayer.Digest, err)
+	}
+
+	// nothing is left to prune
+	out.Reset()
+	stats, err = HardPrune(ctx, registry, images, false, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *stats != (PruneStats{}) {
+		t.Errorf("expected nothing to be pruned, got %#v:\n%s", *stats, out.String())
+	}
+}
+
+func TestHardPruneUnreadableManifest(t *testing.T) {
+	ctx := context.Background()
+	registry, err := storage.NewRegistry(ctx, inmemory.New(), storage.EnableDelete, storage.RemoveParentsOnDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	images := []imageapi.Image{
+		{ObjectMeta: kapi.ObjectMeta{Name: "sha256:0000000000000000000000000000000000000000000000000000000000000000", Annotations: map[string]string{imageapi.ManagedByOpenShiftAnnotation: "true"}}},
+	}
+	if _, err := HardPrune(ctx, registry, images, true, &bytes.Buffer{}); err == nil {
+		t.Errorf("expected an error for an image without a manifest")
+	}
+}
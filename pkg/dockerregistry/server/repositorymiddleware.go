@@ -14,6 +14,7 @@ import (
 	"github.com/docker/distribution/manifest/schema1"
 	repomw "github.com/docker/distribution/registry/middleware/repository"
 	"github.com/docker/libtrust"
+	"github.com/prometheus/client_golang/prometheus"
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 
@@ -121,7 +122,12 @@ func (r *repository) Get(dgst digest.Digest) (*schema1.SignedManifest, error) {
 		return nil, err
 	}
 
-	return r.manifestFromImage(image)
+	manifest, err := r.manifestFromImage(image)
+	if err != nil {
+		return nil, err
+	}
+	r.recordAccess(pullCounter, "pull", dgst)
+	return manifest, nil
 }
 
 // Enumerate retrieves digests of manifest revisions in particular repository
@@ -155,7 +161,12 @@ func (r *repository) GetByTag(tag string, options ...distribution.ManifestServic
 		return nil, err
 	}
 
-	return r.manifestFromImage(image)
+	manifest, err := r.manifestFromImage(image)
+	if err != nil {
+		return nil, err
+	}
+	r.recordAccess(pullCounter, "pull", dgst)
+	return manifest, nil
 }
 
 // Put creates or updates the named manifest.
@@ -242,6 +253,7 @@ func (r *repository) Put(manifest *schema1.SignedManifest) error {
 		}
 	}
 
+	r.recordAccess(pushCounter, "push", dgst)
 	return nil
 }
 
@@ -256,6 +268,24 @@ func (r *repository) Delete(dgst digest.Digest) error {
 	return ms.Delete(dgst)
 }
 
+// recordAccess counts a pull or push of the manifest dgst with counter and, if pulls and pushes are audited,
+// logs the user who did it.
+func (r *repository) recordAccess(counter *prometheus.CounterVec, action string, dgst digest.Digest) {
+	name := r.namespace + "/" + r.name
+	counter.WithLabelValues(name).Inc()
+
+	if !AuditFrom(r.ctx) {
+		return
+	}
+	user := auditUser(r.ctx)
+	context.GetLoggerWithFields(r.ctx, map[interface{}]interface{}{
+		"audit.user":       user,
+		"audit.action":     action,
+		"audit.repository": name,
+		"audit.digest":     dgst.String(),
+	}).Infof("audit: %s %s %s@%s", user, action, name, dgst)
+}
+
 // getImageStream retrieves the ImageStream for r.
 func (r *repository) getImageStream() (*imageapi.ImageStream, error) {
 	return r.registryClient.ImageStreams(r.namespace).Get(r.name)
@@ -53,6 +53,8 @@ type AppConfig struct {
 	DockerImages  []string
 	Templates     []string
 	TemplateFiles []string
+	// TemplateChecksums are the SHA-256 checksums of TemplateFiles, in the same order.
+	TemplateChecksums []string
 
 	TemplateParameters []string
 	Groups             []string
@@ -381,6 +383,17 @@ func (c *AppConfig) validate() (app.ComponentReferences, app.SourceRepositories,
 		errs = append(errs, fmt.Errorf("when --strategy is specified you must provide at least one source code location"))
 	}
 
+	if len(c.TemplateChecksums) > 0 {
+		if len(c.TemplateChecksums) != len(c.TemplateFiles) {
+			errs = append(errs, fmt.Errorf("--template-checksum must be specified once for each --file"))
+		} else if searcher, ok := c.templateFileSearcher.(*app.TemplateFileSearcher); ok {
+			searcher.Checksums = map[string]string{}
+			for i, file := range c.TemplateFiles {
+				searcher.Checksums[file] = c.TemplateChecksums[i]
+			}
+		}
+	}
+
 	if c.BinaryBuild && (len(repos) > 0 || refs.HasSource()) {
 		errs = append(errs, fmt.Errorf("specifying binary builds and source repositories at the same time is not allowed"))
 	}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/golang/glog"
@@ -70,12 +71,15 @@ func IsPossibleTemplateFile(value string) bool {
 	return isFile(value)
 }
 
-// TemplateFileSearcher resolves template files into template objects
+// TemplateFileSearcher resolves template files into template objects. Template files may
+// be local paths, HTTP(S) URLs or paths inside a git repository.
 type TemplateFileSearcher struct {
 	Mapper       meta.RESTMapper
 	Typer        runtime.ObjectTyper
 	ClientMapper resource.ClientMapper
 	Namespace    string
+	// Checksums maps template locations to the SHA-256 checksum their contents must match.
+	Checksums map[string]string
 }
 
 // Search attemps to read template files and transform it into template objects
@@ -84,9 +88,18 @@ func (r *TemplateFileSearcher) Search(terms ...string) (ComponentMatches, error)
 
 	for _, term := range terms {
 		var isSingular bool
-		obj, err := resource.NewBuilder(r.Mapper, r.Typer, r.ClientMapper).
-			NamespaceParam(r.Namespace).RequireNamespace().
-			FilenameParam(false, term).
+		builder := resource.NewBuilder(r.Mapper, r.Typer, r.ClientMapper).
+			NamespaceParam(r.Namespace).RequireNamespace()
+		if checksum := r.Checksums[term]; IsRemoteTemplateFile(term) || len(checksum) > 0 {
+			data, err := ReadTemplateFile(term, checksum)
+			if err != nil {
+				return nil, err
+			}
+			builder = builder.Stream(bytes.NewReader(data), term)
+		} else {
+			builder = builder.FilenameParam(false, term)
+		}
+		obj, err := builder.
 			Do().
 			IntoSingular(&isSingular).
 			Object()
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/generate/git"
+)
+
+// checksumPrefix is an optional prefix identifying the algorithm of a template checksum.
+const checksumPrefix = "sha256:"
+
+// templateHTTPClient downloads the templates read from HTTP(S) URLs, giving up on servers that stop responding.
+var templateHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// IsRemoteTemplateFile returns true if the argument refers to a template stored at an
+// HTTP(S) URL or inside a git repository (<repository>#[<ref>]:<path>).
+func IsRemoteTemplateFile(value string) bool {
+	if _, _, _, ok := parseGitTemplateLocation(value); ok {
+		return true
+	}
+	return isHTTPURL(value)
+}
+
+// isHTTPURL returns true if value is an http or https URL.
+func isHTTPURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
+
+// parseGitTemplateLocation splits a location of the form <repository>#[<ref>]:<path> into the
+// repository URL, the ref to check out (which may be empty), and the path of the template file
+// within the repository.
+func parseGitTemplateLocation(value string) (repo, ref, path string, ok bool) {
+	i := strings.LastIndex(value, "#")
+	if i <= 0 {
+		return "", "", "", false
+	}
+	fragment := value[i+1:]
+	j := strings.Index(fragment, ":")
+	if j < 0 || j == len(fragment)-1 {
+		return "", "", "", false
+	}
+	uri, err := git.ParseRepository(value[:i])
+	if err != nil {
+		return "", "", "", false
+	}
+	return uri.String(), fragment[:j], fragment[j+1:], true
+}
+
+// ReadTemplateFile retrieves the contents of a template stored in a local file, on standard input
+// if location is "-", at an HTTP(S) URL or inside a git repository. If checksum is not empty, the
+// SHA-256 sum of the contents must match it.
+func ReadTemplateFile(location, checksum string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if repo, ref, path, ok := parseGitTemplateLocation(location); ok {
+		data, err = readGitTemplate(git.NewRepository(), repo, ref, path)
+	} else if isHTTPURL(location) {
+		data, err = readHTTPTemplate(location)
+	} else if location == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyTemplateChecksum(data, checksum); err != nil {
+		return nil, fmt.Errorf("template %q could not be verified: %v", location, err)
+	}
+	return data, nil
+}
+
+// readHTTPTemplate downloads the template at url.
+func readHTTPTemplate(url string) ([]byte, error) {
+	glog.V(4).Infof("Downloading template from %s", url)
+	resp, err := templateHTTPClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read template %q, server reported: %s", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// readGitTemplate clones repo, checks out ref if provided, and reads the file at path. Symlinks are
+// rejected, as they could point outside of the repository.
+func readGitTemplate(repository git.Repository, repo, ref, path string) ([]byte, error) {
+	path = filepath.Clean(path)
+	if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("template path %q must be relative to the root of the repository", path)
+	}
+
+	dir, err := ioutil.TempDir("", "template")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	glog.V(4).Infof("Cloning %s to read template %s", repo, path)
+	if err := repository.CloneWithOptions(dir, repo, git.CloneOptions{Recursive: false, Quiet: true}); err != nil {
+		return nil, fmt.Errorf("unable to clone %q: %v", repo, err)
+	}
+	if len(ref) > 0 {
+		if err := repository.Checkout(dir, ref); err != nil {
+			return nil, fmt.Errorf("unable to check out %q in %q: %v", ref, repo, err)
+		}
+	}
+	if err := rejectSymlinks(dir, path); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(filepath.Join(dir, path))
+}
+
+// rejectSymlinks returns an error if path, relative to dir, is not a regular file or any of its
+// parent directories is a symlink.
+func rejectSymlinks(dir, path string) error {
+	current := dir
+	for _, element := range strings.Split(path, string(filepath.Separator)) {
+		current = filepath.Join(current, element)
+		info, err := os.Lstat(current)
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("template path %q must not contain symlinks", path)
+		}
+	}
+	if info, err := os.Lstat(current); err != nil || !info.Mode().IsRegular() {
+		return fmt.Errorf("template path %q must be a regular file", path)
+	}
+	return nil
+}
+
+// verifyTemplateChecksum returns an error if checksum is set and does not match the SHA-256
+// sum of data.
+func verifyTemplateChecksum(data []byte, checksum string) error {
+	if len(checksum) == 0 {
+		return nil
+	}
+	expected := strings.ToLower(strings.TrimPrefix(checksum, checksumPrefix))
+	if actual := fmt.Sprintf("%x", sha256.Sum256(data)); actual != expected {
+		return fmt.Errorf("expected checksum %s, got %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/origin/pkg/generate/git"
+)
+
+func TestParseGitTemplateLocation(t *testing.T) {
+	tests := map[string]struct {
+		repo, ref, path string
+		ok              bool
+	}{
+		"https://github.com/user/repo.git#master:template.json": {
+			repo: "https://github.com/user/repo.git", ref: "master", path: "template.json", ok: true,
+		},
+		"https://github.com/user/repo.git#:dir/template.json": {
+			repo: "https://github.com/user/repo.git", path: "dir/template.json", ok: true,
+		},
+		"git@github.com:user/repo.git#v1:template.yaml": {
+			repo: "ssh://git@github.com/user/repo.git", ref: "v1", path: "template.yaml", ok: true,
+		},
+		"https://github.com/user/repo.git#master":  {},
+		"https://github.com/user/repo.git#master:": {},
+		"https://example.com/template.json":        {},
+		"#master:template.json":                    {},
+	}
+
+	for value, expected := range tests {
+		repo, ref, path, ok := parseGitTemplateLocation(value)
+		if ok != expected.ok || repo != expected.repo || ref != expected.ref || path != expected.path {
+			t.Errorf("%s: expected %q %q %q %t, got %q %q %q %t", value, expected.repo, expected.ref, expected.path, expected.ok, repo, ref, path, ok)
+		}
+	}
+}
+
+func TestReadRemoteTemplateFromURL(t *testing.T) {
+	contents := []byte(`{"kind":"Template","apiVersion":"v1","metadata":{"name":"test"}}`)
+	checksum := fmt.Sprintf("%x", sha256.Sum256(contents))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/template.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(contents)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		location string
+		checksum string
+		err      bool
+	}{
+		{name: "no checksum", location: server.URL + "/template.json"},
+		{name: "matching checksum", location: server.URL + "/template.json", checksum: checksum},
+		{name: "prefixed checksum", location: server.URL + "/template.json", checksum: checksumPrefix + checksum},
+		{name: "mismatched checksum", location: server.URL + "/template.json", checksum: "abc", err: true},
+		{name: "not found", location: server.URL + "/missing.json", err: true},
+	}
+
+	for _, test := range tests {
+		if !IsRemoteTemplateFile(test.location) {
+			t.Errorf("%s: expected %s to be a remote template", test.name, test.location)
+		}
+		data, err := ReadTemplateFile(test.location, test.checksum)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if string(data) != string(contents) {
+			t.Errorf("%s: unexpected contents: %s", test.name, string(data))
+		}
+	}
+}
+
+type fakeTemplateRepository struct {
+	git.Repository
+	files      map[string]string
+	symlinks   map[string]string
+	checkedOut string
+}
+
+func (r *fakeTemplateRepository) CloneWithOptions(dir string, url string, opts git.CloneOptions) error {
+	for name, contents := range r.files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			return err
+		}
+	}
+	for name, target := range r.symlinks {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			return err
+		}
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeTemplateRepository) Checkout(dir string, ref string) error {
+	r.checkedOut = ref
+	return nil
+}
+
+func TestReadGitTemplate(t *testing.T) {
+	repository := &fakeTemplateRepository{files: map[string]string{"templates/app.json": "template"}}
+
+	data, err := readGitTemplate(repository, "https://github.com/user/repo.git", "v1", "templates/app.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "template" {
+		t.Errorf("unexpected contents: %s", string(data))
+	}
+	if repository.checkedOut != "v1" {
+		t.Errorf("expected ref v1 to be checked out, got %q", repository.checkedOut)
+	}
+
+	for _, path := range []string{"../app.json", "/etc/passwd", "templates/../../app.json"} {
+		if _, err := readGitTemplate(repository, "https://github.com/user/repo.git", "", path); err == nil {
+			t.Errorf("%s: expected an error for a path outside the repository", path)
+		}
+	}
+}
+
+func TestReadGitTemplateRejectsSymlinks(t *testing.T) {
+	repository := &fakeTemplateRepository{
+		files: map[string]string{"templates/app.json": "template"},
+		symlinks: map[string]string{
+			"link.json":          "/etc/passwd",
+			"relative.json":      "templates/app.json",
+			"linked/app.json":    "../templates/app.json",
+			"linkeddir":          "templates",
+			"templates/dir.json": ".",
+		},
+	}
+
+	for _, path := range []string{"link.json", "relative.json", "linkeddir/app.json", "templates"} {
+		if _, err := readGitTemplate(repository, "https://github.com/user/repo.git", "", path); err == nil {
+			t.Errorf("%s: expected an error for a symlink or a directory", path)
+		}
+	}
+}
+
+func TestReadTemplateFileVerifiesLocalFiles(t *testing.T) {
+	contents := []byte(`{"kind":"Template","apiVersion":"v1","metadata":{"name":"test"}}`)
+	f, err := ioutil.TempFile("", "template")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(contents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.Close()
+
+	if data, err := ReadTemplateFile(f.Name(), fmt.Sprintf("%x", sha256.Sum256(contents))); err != nil || string(data) != string(contents) {
+		t.Errorf("expected the local template to be read, got %q, %v", string(data), err)
+	}
+	if _, err := ReadTemplateFile(f.Name(), "abc"); err == nil {
+		t.Errorf("expected a mismatched checksum of a local template to be rejected")
+	}
+}
@@ -25,6 +25,11 @@ const (
 	// InsecureRepositoryAnnotation may be set true on an image stream to allow insecure access to pull content.
 	InsecureRepositoryAnnotation = "openshift.io/image.insecureRepository"
 
+	// TriggerAnnotationKey is set on objects with a pod template, such as replication controllers, to keep the
+	// images of their containers up to date with image stream tags. Its value is a JSON list of triggers, each
+	// naming the ImageStreamTag to follow in "from" and the container to update in "container".
+	TriggerAnnotationKey = "image.openshift.io/triggers"
+
 	// DefaultImageTag is used when an image tag is needed and the configuration does not specify a tag to use.
 	DefaultImageTag = "latest"
 )
@@ -128,6 +133,9 @@ type ImageStreamTag struct {
 	unversioned.TypeMeta
 	kapi.ObjectMeta
 
+	// Tag is the spec tag of the ImageStream for this tag, or nil if images were only pushed to the tag.
+	Tag *TagReference
+
 	// The Image associated with the ImageStream and tag.
 	Image Image
 }
@@ -113,6 +113,40 @@ func convert_v1_ImageStreamMapping_To_api_ImageStreamMapping(in *ImageStreamMapp
 	return s.DefaultConvert(in, out, conversion.SourceToDest)
 }
 
+func convert_api_ImageStreamTag_To_v1_ImageStreamTag(in *newer.ImageStreamTag, out *ImageStreamTag, s conversion.Scope) error {
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	if in.Tag != nil {
+		_, tag, _ := newer.SplitImageStreamTag(in.Name)
+		out.Tag = &NamedTagReference{
+			Name:        tag,
+			Annotations: in.Tag.Annotations,
+			Reference:   in.Tag.Reference,
+		}
+		if err := s.Convert(&in.Tag.From, &out.Tag.From, 0); err != nil {
+			return err
+		}
+	}
+	return s.Convert(&in.Image, &out.Image, 0)
+}
+
+func convert_v1_ImageStreamTag_To_api_ImageStreamTag(in *ImageStreamTag, out *newer.ImageStreamTag, s conversion.Scope) error {
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	if in.Tag != nil {
+		out.Tag = &newer.TagReference{
+			Annotations: in.Tag.Annotations,
+			Reference:   in.Tag.Reference,
+		}
+		if err := s.Convert(&in.Tag.From, &out.Tag.From, 0); err != nil {
+			return err
+		}
+	}
+	return s.Convert(&in.Image, &out.Image, 0)
+}
+
 func init() {
 	err := kapi.Scheme.AddConversionFuncs(
 		func(in *[]NamedTagEventList, out *map[string]newer.TagEventList, s conversion.Scope) error {
@@ -188,6 +222,8 @@ func init() {
 		convert_api_ImageStreamStatus_To_v1_ImageStreamStatus,
 		convert_api_ImageStreamMapping_To_v1_ImageStreamMapping,
 		convert_v1_ImageStreamMapping_To_api_ImageStreamMapping,
+		convert_api_ImageStreamTag_To_v1_ImageStreamTag,
+		convert_v1_ImageStreamTag_To_api_ImageStreamTag,
 	)
 	if err != nil {
 		// If one of the conversion functions is malformed, detect it immediately.
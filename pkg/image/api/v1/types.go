@@ -115,6 +115,9 @@ type ImageStreamTag struct {
 	unversioned.TypeMeta `json:",inline"`
 	kapi.ObjectMeta      `json:"metadata,omitempty"`
 
+	// Tag is the spec tag of the ImageStream for this tag, or nil if images were only pushed to the tag.
+	Tag *NamedTagReference `json:"tag,omitempty" description:"the spec tag of the ImageStream for this tag, if any; its name is the tag"`
+
 	// Image associated with the ImageStream and tag.
 	Image Image `json:"image" description:"the image associated with the ImageStream and tag"`
 }
@@ -93,13 +93,7 @@ func ValidateImageStream(stream *api.ImageStream) fielderrors.ValidationErrorLis
 		}
 	}
 	for tag, tagRef := range stream.Spec.Tags {
-		if tagRef.From != nil {
-			switch tagRef.From.Kind {
-			case "DockerImage", "ImageStreamImage", "ImageStreamTag":
-			default:
-				result = append(result, fielderrors.NewFieldInvalid(fmt.Sprintf("spec.tags[%s].from.kind", tag), tagRef.From.Kind, "valid values are 'DockerImage', 'ImageStreamImage', 'ImageStreamTag'"))
-			}
-		}
+		result = append(result, validateTagReference(&tagRef).Prefix(fmt.Sprintf("spec.tags[%s]", tag))...)
 	}
 	for tag, history := range stream.Status.Tags {
 		for i, tagEvent := range history.Items {
@@ -160,27 +154,54 @@ func ValidateImageStreamMapping(mapping *api.ImageStreamMapping) fielderrors.Val
 	return result
 }
 
-// ValidateImageStreamTag is essentially a no-op.  We don't allow direct creation of istags
+// validateTagReference ensures that a tag reference points to a supported kind of image
+func validateTagReference(tagRef *api.TagReference) fielderrors.ValidationErrorList {
+	result := fielderrors.ValidationErrorList{}
+	if tagRef.From != nil {
+		switch tagRef.From.Kind {
+		case "DockerImage", "ImageStreamImage", "ImageStreamTag":
+		default:
+			result = append(result, fielderrors.NewFieldInvalid("from.kind", tagRef.From.Kind, "valid values are 'DockerImage', 'ImageStreamImage', 'ImageStreamTag'"))
+		}
+	}
+	return result
+}
+
+// ValidateImageStreamTag tests required fields for an ImageStreamTag. The annotations of its tag, if set,
+// must match its own, since both are stored as the annotations of the spec tag of the image stream.
 func ValidateImageStreamTag(ist *api.ImageStreamTag) fielderrors.ValidationErrorList {
 	result := fielderrors.ValidationErrorList{}
 	result = append(result, validation.ValidateObjectMeta(&ist.ObjectMeta, true, oapi.MinimalNameRequirements).Prefix("metadata")...)
+	if _, _, ok := api.SplitImageStreamTag(ist.Name); !ok {
+		result = append(result, fielderrors.NewFieldInvalid("metadata.name", ist.Name, "must be of the form <stream_name>:<tag>"))
+	}
+
+	if ist.Tag != nil {
+		result = append(result, validateTagReference(ist.Tag).Prefix("tag")...)
+		if ist.Tag.Annotations != nil && !kapi.Semantic.DeepEqual(ist.Tag.Annotations, ist.Annotations) {
+			result = append(result, fielderrors.NewFieldInvalid("tag.annotations", "", "must match metadata.annotations when set"))
+		}
+	}
 
 	return result
 }
 
-// ValidateImageStreamTagUpdate ensures that only the annotations of the IST have changed
+// ValidateImageStreamTagUpdate ensures that only the annotations and the tag of the IST have changed
 func ValidateImageStreamTagUpdate(newIST, oldIST *api.ImageStreamTag) fielderrors.ValidationErrorList {
 	result := fielderrors.ValidationErrorList{}
 
 	result = append(result, validation.ValidateObjectMetaUpdate(&newIST.ObjectMeta, &oldIST.ObjectMeta).Prefix("metadata")...)
+	result = append(result, ValidateImageStreamTag(newIST)...)
 
-	// ensure that only annotations have changed
+	// ensure that only annotations and the tag have changed; a stale resource version is a conflict
+	// reported when the image stream is updated
 	newISTCopy := *newIST
 	oldISTCopy := *oldIST
-	newISTCopy.Annotations = nil
-	oldISTCopy.Annotations = nil
+	newISTCopy.Annotations, oldISTCopy.Annotations = nil, nil
+	newISTCopy.ResourceVersion, oldISTCopy.ResourceVersion = "", ""
+	newISTCopy.Tag, oldISTCopy.Tag = nil, nil
 	if !kapi.Semantic.Equalities.DeepEqual(&newISTCopy, &oldISTCopy) {
-		result = append(result, fielderrors.NewFieldInvalid("metadata", "", "may not update fields other than metadata.annotations"))
+		result = append(result, fielderrors.NewFieldInvalid("metadata", "", "may not update fields other than metadata.annotations and tag"))
 	}
 
 	return result
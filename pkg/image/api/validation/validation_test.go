@@ -363,6 +363,17 @@ func TestValidateISTUpdate(t *testing.T) {
 		t.Errorf("expected success: %v", errs)
 	}
 
+	errs = ValidateImageStreamTagUpdate(
+		&api.ImageStreamTag{
+			ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "foo:bar", ResourceVersion: "1", Annotations: map[string]string{"one": "two"}},
+			Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"}},
+		},
+		old,
+	)
+	if len(errs) != 0 {
+		t.Errorf("expected the tag to be updatable: %v", errs)
+	}
+
 	errorCases := map[string]struct {
 		A api.ImageStreamTag
 		T fielderrors.ValidationErrorType
@@ -375,6 +386,30 @@ func TestValidateISTUpdate(t *testing.T) {
 			T: fielderrors.ValidationErrorTypeInvalid,
 			F: "metadata",
 		},
+		"changedImage": {
+			A: api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "foo:bar", ResourceVersion: "1", Annotations: map[string]string{"one": "two"}},
+				Image:      api.Image{ObjectMeta: kapi.ObjectMeta{Name: "10"}},
+			},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "metadata",
+		},
+		"invalidTagFromKind": {
+			A: api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "foo:bar", ResourceVersion: "1", Annotations: map[string]string{"one": "two"}},
+				Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "Pod", Name: "foo"}},
+			},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "tag.from.kind",
+		},
+		"mismatchedTagAnnotations": {
+			A: api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault, Name: "foo:bar", ResourceVersion: "1", Annotations: map[string]string{"one": "two"}},
+				Tag:        &api.TagReference{Annotations: map[string]string{"three": "four"}},
+			},
+			T: fielderrors.ValidationErrorTypeInvalid,
+			F: "tag.annotations",
+		},
 	}
 	for k, v := range errorCases {
 		errs := ValidateImageStreamTagUpdate(&v.A, old)
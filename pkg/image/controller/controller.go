@@ -9,6 +9,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
 	kerrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/sets"
 
@@ -20,6 +21,8 @@ import (
 type ImportController struct {
 	streams  client.ImageStreamsNamespacer
 	mappings client.ImageStreamMappingsNamespacer
+	// recorder records the import failures as events of the image streams
+	recorder record.EventRecorder
 	// injected for testing
 	client dockerregistry.Client
 }
@@ -68,6 +71,9 @@ func (c *ImportController) Next(stream *api.ImageStream) error {
 
 	var errlist []error
 	toImport, retry, err := getTags(stream, client, insecure)
+	if err != nil {
+		c.recorder.Eventf(stream, "ImportFailed", "Failed to read the tags of %s: %v", stream.Spec.DockerImageRepository, err)
+	}
 	// return here, only if there is an error and nothing to import
 	if err != nil && len(toImport) == 0 {
 		if retry {
@@ -182,6 +188,7 @@ func (c *ImportController) importTags(stream *api.ImageStream, imports map[strin
 	for tag, ref := range imports {
 		image, retry, err := c.importTag(stream, tag, ref, retrieved[ref.ID], client, insecure)
 		if err != nil {
+			c.recorder.Eventf(stream, "ImportFailed", "Failed to import tag %q from %s: %v", tag, ref.String(), err)
 			if retry {
 				shouldRetry = retry
 			}
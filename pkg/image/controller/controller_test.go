@@ -10,6 +10,7 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/runtime"
 
@@ -73,7 +74,7 @@ func (f *fakeDockerRegistryClient) ImageByID(namespace, name, id string) (*docke
 
 func TestControllerNoOp(t *testing.T) {
 	cli, fake := &fakeDockerRegistryClient{}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -96,7 +97,7 @@ func TestControllerNoOp(t *testing.T) {
 
 func TestControllerNoDockerRepo(t *testing.T) {
 	cli, fake := &fakeDockerRegistryClient{}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -133,7 +134,7 @@ func TestControllerExternalRepo(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -183,7 +184,7 @@ func TestControllerExternalReferenceRepo(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -227,7 +228,7 @@ func TestControllerExternalRepoFails(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -258,7 +259,7 @@ func TestControllerExternalRepoFails(t *testing.T) {
 
 func TestControllerRepoHandled(t *testing.T) {
 	cli, fake := &fakeDockerRegistryClient{}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -286,7 +287,7 @@ func TestControllerRepoHandled(t *testing.T) {
 
 func TestControllerTagRetrievalFails(t *testing.T) {
 	cli, fake := &fakeDockerRegistryClient{Err: fmt.Errorf("test error")}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "other"},
@@ -307,7 +308,7 @@ func TestControllerTagRetrievalFails(t *testing.T) {
 
 func TestControllerRetrievesInsecure(t *testing.T) {
 	cli, fake := &fakeDockerRegistryClient{Err: fmt.Errorf("test error")}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -337,7 +338,7 @@ func TestControllerRetrievesInsecure(t *testing.T) {
 
 func TestControllerImageNotFoundError(t *testing.T) {
 	cli, fake := &fakeDockerRegistryClient{Tags: map[string]string{api.DefaultImageTag: "not_found"}}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "other"},
 		Spec: api.ImageStreamSpec{
@@ -369,7 +370,7 @@ func TestControllerImageWithGenericError(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "other"},
 		Spec: api.ImageStreamSpec{
@@ -402,7 +403,7 @@ func TestControllerWithImage(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "other"},
 		Spec: api.ImageStreamSpec{
@@ -460,7 +461,7 @@ func TestControllerWithExternalAndDefaultRegistry(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -528,7 +529,7 @@ func TestControllerWithExternalAndDefaultRegistryErrorOnOneTag(t *testing.T) {
 			},
 		},
 	}, &client.Fake{}
-	c := ImportController{client: cli, streams: fake, mappings: fake}
+	c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 
 	stream := api.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
@@ -620,7 +621,7 @@ func TestControllerWithSpecTags(t *testing.T) {
 				},
 			},
 		}, &client.Fake{}
-		c := ImportController{client: cli, streams: fake, mappings: fake}
+		c := ImportController{client: cli, streams: fake, mappings: fake, recorder: &record.FakeRecorder{}}
 		stream := api.ImageStream{
 			ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "other"},
 			Spec: api.ImageStreamSpec{
@@ -789,7 +790,8 @@ func TestControllerReturnsErrForRetries(t *testing.T) {
 	}
 
 	for name, test := range tests {
-		c := ImportController{client: test.fakeDocker, streams: test.fakeClient, mappings: test.fakeClient}
+		recorder := &record.FakeRecorder{}
+		c := ImportController{client: test.fakeDocker, streams: test.fakeClient, mappings: test.fakeClient, recorder: recorder}
 
 		err := c.Next(test.stream)
 		if err == nil {
@@ -807,6 +809,9 @@ func TestControllerReturnsErrForRetries(t *testing.T) {
 		if len(test.fakeClient.Actions()) != test.expActions {
 			t.Errorf("%s: expected no actions: %#v", name, test.fakeClient.Actions())
 		}
+		if len(recorder.Events) != 1 || !strings.HasPrefix(recorder.Events[0], "ImportFailed ") || !strings.Contains(recorder.Events[0], expErr.Error()) {
+			t.Errorf("%s: expected an import failure event, got %v", name, recorder.Events)
+		}
 	}
 }
 
@@ -5,11 +5,12 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/record"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
-	kutil "k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/openshift/origin/pkg/client"
@@ -19,7 +20,8 @@ import (
 
 // ImportControllerFactory can create an ImportController.
 type ImportControllerFactory struct {
-	Client client.Interface
+	Client     client.Interface
+	KubeClient kclient.Interface
 }
 
 // Create creates an ImportController.
@@ -35,21 +37,25 @@ func (f *ImportControllerFactory) Create() controller.RunnableController {
 	q := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
 	cache.NewReflector(lw, &api.ImageStream{}, q, 2*time.Minute).Run()
 
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(f.KubeClient.Events(""))
+
 	c := &ImportController{
 		streams:  f.Client,
 		mappings: f.Client,
+		recorder: eventBroadcaster.NewRecorder(kapi.EventSource{Component: "imagestream-import-controller"}),
 	}
 
 	return &controller.RetryController{
 		Queue: q,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			q,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
 				util.HandleError(err)
 				return retries.Count < 5
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			r := obj.(*api.ImageStream)
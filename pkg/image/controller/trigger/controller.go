@@ -0,0 +1,154 @@
+package trigger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// ObjectFieldTrigger is an entry of the imageapi.TriggerAnnotationKey annotation. It keeps the image of a
+// container of the pod template of an object up to date with an ImageStreamTag.
+type ObjectFieldTrigger struct {
+	// From is the ImageStreamTag to follow. Its namespace defaults to the namespace of the object.
+	From kapi.ObjectReference `json:"from"`
+	// Container is the name of the container whose image is updated.
+	Container string `json:"container"`
+	// Paused is true if the image of the container should not be updated for now.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ParseTriggers returns the triggers of the imageapi.TriggerAnnotationKey annotation, or nil if it is not set.
+func ParseTriggers(annotations map[string]string) ([]ObjectFieldTrigger, error) {
+	value, ok := annotations[imageapi.TriggerAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	triggers := []ObjectFieldTrigger{}
+	if err := json.Unmarshal([]byte(value), &triggers); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", imageapi.TriggerAnnotationKey, err)
+	}
+	for _, trigger := range triggers {
+		if trigger.From.Kind != "ImageStreamTag" {
+			return nil, fmt.Errorf("invalid %s annotation: only triggers from an ImageStreamTag are supported, got %q", imageapi.TriggerAnnotationKey, trigger.From.Kind)
+		}
+		if _, _, ok := imageapi.SplitImageStreamTag(trigger.From.Name); !ok {
+			return nil, fmt.Errorf("invalid %s annotation: %q is not of the form <stream_name>:<tag>", imageapi.TriggerAnnotationKey, trigger.From.Name)
+		}
+		if len(trigger.Container) == 0 {
+			return nil, fmt.Errorf("invalid %s annotation: the container of the trigger from %s is required", imageapi.TriggerAnnotationKey, trigger.From.Name)
+		}
+	}
+	return triggers, nil
+}
+
+// ImageTriggerController sets the images of the containers of replication controllers to the latest images
+// of the ImageStreamTags listed in their imageapi.TriggerAnnotationKey annotation when an ImageStream changes.
+// Only the pod template is changed, so only the pods created afterwards run the new images.
+//
+// Use the ImageTriggerControllerFactory to create this controller.
+type ImageTriggerController struct {
+	replicationControllerClient replicationControllerClient
+}
+
+// Handle updates the replication controllers with a trigger on a tag of stream whose image changed.
+func (c *ImageTriggerController) Handle(stream *imageapi.ImageStream) error {
+	rcs, err := c.replicationControllerClient.listReplicationControllers()
+	if err != nil {
+		return fmt.Errorf("couldn't get list of ReplicationControllers while handling ImageStream %s/%s: %v", stream.Namespace, stream.Name, err)
+	}
+
+	anyFailed := false
+	for _, rc := range rcs {
+		if rc.Spec.Template == nil {
+			continue
+		}
+		triggers, err := ParseTriggers(rc.Annotations)
+		if err != nil {
+			glog.V(2).Infof("Ignoring the triggers of ReplicationController %s/%s: %v", rc.Namespace, rc.Name, err)
+			continue
+		}
+		if len(triggers) == 0 {
+			continue
+		}
+
+		obj, err := kapi.Scheme.Copy(rc)
+		if err != nil {
+			return err
+		}
+		updated := obj.(*kapi.ReplicationController)
+		if !UpdateContainerImages(triggers, updated.Namespace, &updated.Spec.Template.Spec, stream) {
+			continue
+		}
+		if _, err := c.replicationControllerClient.updateReplicationController(updated.Namespace, updated); err != nil {
+			anyFailed = true
+			glog.V(2).Infof("Couldn't update the images of ReplicationController %s/%s: %v", rc.Namespace, rc.Name, err)
+			continue
+		}
+		glog.V(4).Infof("Updated the images of ReplicationController %s/%s for ImageStream %s/%s", rc.Namespace, rc.Name, stream.Namespace, stream.Name)
+	}
+
+	if anyFailed {
+		return fmt.Errorf("couldn't update some ReplicationControllers for triggers on ImageStream %s/%s", stream.Namespace, stream.Name)
+	}
+	return nil
+}
+
+// UpdateContainerImages sets the images of the containers of spec to the latest images of the tags of stream
+// that triggers of an object in namespace follow, and returns true if any image changed. It applies to any
+// object with a pod template.
+func UpdateContainerImages(triggers []ObjectFieldTrigger, namespace string, spec *kapi.PodSpec, stream *imageapi.ImageStream) bool {
+	changed := false
+	for _, trigger := range triggers {
+		if trigger.Paused {
+			continue
+		}
+		fromNamespace := trigger.From.Namespace
+		if len(fromNamespace) == 0 {
+			fromNamespace = namespace
+		}
+		name, tag, ok := imageapi.SplitImageStreamTag(trigger.From.Name)
+		if !ok || fromNamespace != stream.Namespace || name != stream.Name {
+			continue
+		}
+
+		latestEvent := imageapi.LatestTaggedImage(stream, tag)
+		if latestEvent == nil || len(latestEvent.DockerImageReference) == 0 {
+			glog.V(5).Infof("Couldn't find latest tag event for tag %s in ImageStream %s/%s", tag, stream.Namespace, stream.Name)
+			continue
+		}
+		for i := range spec.Containers {
+			container := &spec.Containers[i]
+			if container.Name != trigger.Container || container.Image == latestEvent.DockerImageReference {
+				continue
+			}
+			container.Image = latestEvent.DockerImageReference
+			changed = true
+		}
+	}
+	return changed
+}
+
+// replicationControllerClient abstracts access to ReplicationControllers.
+type replicationControllerClient interface {
+	listReplicationControllers() ([]*kapi.ReplicationController, error)
+	updateReplicationController(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error)
+}
+
+// replicationControllerClientImpl is a pluggable replicationControllerClient.
+type replicationControllerClientImpl struct {
+	listReplicationControllersFunc  func() ([]*kapi.ReplicationController, error)
+	updateReplicationControllerFunc func(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error)
+}
+
+func (i *replicationControllerClientImpl) listReplicationControllers() ([]*kapi.ReplicationController, error) {
+	return i.listReplicationControllersFunc()
+}
+
+func (i *replicationControllerClientImpl) updateReplicationController(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+	return i.updateReplicationControllerFunc(namespace, rc)
+}
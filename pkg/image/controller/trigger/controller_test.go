@@ -0,0 +1,180 @@
+package trigger
+
+import (
+	"fmt"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func makeStream(namespace, name, tag, dockerImageReference string) *imageapi.ImageStream {
+	return &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: namespace, Name: name},
+		Status: imageapi.ImageStreamStatus{
+			Tags: map[string]imageapi.TagEventList{
+				tag: {Items: []imageapi.TagEvent{{DockerImageReference: dockerImageReference}}},
+			},
+		},
+	}
+}
+
+func makeRC(name, triggers string, containers ...string) *kapi.ReplicationController {
+	rc := &kapi.ReplicationController{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "default", Name: name},
+		Spec: kapi.ReplicationControllerSpec{
+			Template: &kapi.PodTemplateSpec{},
+		},
+	}
+	if len(triggers) > 0 {
+		rc.Annotations = map[string]string{imageapi.TriggerAnnotationKey: triggers}
+	}
+	for _, container := range containers {
+		rc.Spec.Template.Spec.Containers = append(rc.Spec.Template.Spec.Containers, kapi.Container{Name: container, Image: "old"})
+	}
+	return rc
+}
+
+func TestParseTriggers(t *testing.T) {
+	testCases := map[string]struct {
+		annotation  string
+		expected    int
+		expectError bool
+	}{
+		"valid": {
+			annotation: `[{"from":{"kind":"ImageStreamTag","name":"app:latest"},"container":"web"},{"from":{"kind":"ImageStreamTag","name":"proxy:v1","namespace":"shared"},"container":"proxy","paused":true}]`,
+			expected:   2,
+		},
+		"invalid json": {
+			annotation:  `{"from"`,
+			expectError: true,
+		},
+		"unsupported kind": {
+			annotation:  `[{"from":{"kind":"DockerImage","name":"app:latest"},"container":"web"}]`,
+			expectError: true,
+		},
+		"missing tag": {
+			annotation:  `[{"from":{"kind":"ImageStreamTag","name":"app"},"container":"web"}]`,
+			expectError: true,
+		},
+		"missing container": {
+			annotation:  `[{"from":{"kind":"ImageStreamTag","name":"app:latest"}}]`,
+			expectError: true,
+		},
+	}
+	for name, tc := range testCases {
+		triggers, err := ParseTriggers(map[string]string{imageapi.TriggerAnnotationKey: tc.annotation})
+		if tc.expectError != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", name, tc.expectError, err)
+			continue
+		}
+		if len(triggers) != tc.expected {
+			t.Errorf("%s: expected %d triggers, got %#v", name, tc.expected, triggers)
+		}
+	}
+
+	if triggers, err := ParseTriggers(nil); err != nil || triggers != nil {
+		t.Errorf("expected no triggers without the annotation, got %#v, %v", triggers, err)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	const image = "registry:5000/default/app@sha256:00000000000000000000000000000001"
+	appTrigger := `[{"from":{"kind":"ImageStreamTag","name":"app:latest"},"container":"web"}]`
+
+	testCases := map[string]struct {
+		stream   *imageapi.ImageStream
+		rc       *kapi.ReplicationController
+		expected map[string]string
+	}{
+		"updates the triggered container": {
+			stream:   makeStream("default", "app", "latest", image),
+			rc:       makeRC("rc", appTrigger, "web", "sidecar"),
+			expected: map[string]string{"web": image, "sidecar": "old"},
+		},
+		"other namespace": {
+			stream:   makeStream("shared", "app", "latest", image),
+			rc:       makeRC("rc", `[{"from":{"kind":"ImageStreamTag","name":"app:latest","namespace":"shared"},"container":"web"}]`, "web"),
+			expected: map[string]string{"web": image},
+		},
+		"up to date": {
+			stream: makeStream("default", "app", "latest", "old"),
+			rc:     makeRC("rc", appTrigger, "web"),
+		},
+		"other tag": {
+			stream: makeStream("default", "app", "v1", image),
+			rc:     makeRC("rc", appTrigger, "web"),
+		},
+		"other stream": {
+			stream: makeStream("default", "other", "latest", image),
+			rc:     makeRC("rc", appTrigger, "web"),
+		},
+		"paused": {
+			stream: makeStream("default", "app", "latest", image),
+			rc:     makeRC("rc", `[{"from":{"kind":"ImageStreamTag","name":"app:latest"},"container":"web","paused":true}]`, "web"),
+		},
+		"no annotation": {
+			stream: makeStream("default", "app", "latest", image),
+			rc:     makeRC("rc", "", "web"),
+		},
+		"invalid annotation": {
+			stream: makeStream("default", "app", "latest", image),
+			rc:     makeRC("rc", "[", "web"),
+		},
+	}
+
+	for name, tc := range testCases {
+		var updated *kapi.ReplicationController
+		controller := &ImageTriggerController{
+			replicationControllerClient: &replicationControllerClientImpl{
+				listReplicationControllersFunc: func() ([]*kapi.ReplicationController, error) {
+					return []*kapi.ReplicationController{tc.rc}, nil
+				},
+				updateReplicationControllerFunc: func(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+					updated = rc
+					return rc, nil
+				},
+			},
+		}
+		if err := controller.Handle(tc.stream); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+
+		if tc.expected == nil {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", name, updated)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected an update", name)
+			continue
+		}
+		for _, container := range updated.Spec.Template.Spec.Containers {
+			if e, a := tc.expected[container.Name], container.Image; e != a {
+				t.Errorf("%s: container %s: expected image %q, got %q", name, container.Name, e, a)
+			}
+		}
+		if tc.rc.Spec.Template.Spec.Containers[0].Image != "old" {
+			t.Errorf("%s: expected the cached ReplicationController not to be modified", name)
+		}
+	}
+}
+
+func TestHandleUpdateError(t *testing.T) {
+	controller := &ImageTriggerController{
+		replicationControllerClient: &replicationControllerClientImpl{
+			listReplicationControllersFunc: func() ([]*kapi.ReplicationController, error) {
+				return []*kapi.ReplicationController{makeRC("rc", `[{"from":{"kind":"ImageStreamTag","name":"app:latest"},"container":"web"}]`, "web")}, nil
+			},
+			updateReplicationControllerFunc: func(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+				return nil, fmt.Errorf("conflict")
+			},
+		},
+	}
+	if err := controller.Handle(makeStream("default", "app", "latest", "new")); err == nil {
+		t.Errorf("expected an error to retry the ImageStream")
+	}
+}
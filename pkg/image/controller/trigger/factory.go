@@ -0,0 +1,84 @@
+package trigger
+
+import (
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	kutil "k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/controller"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// ImageTriggerControllerFactory can create an ImageTriggerController which watches all ImageStream
+// changes. The periodic resync of the ImageStreams also applies the triggers of new ReplicationControllers.
+type ImageTriggerControllerFactory struct {
+	// Client is an OpenShift client.
+	Client osclient.Interface
+	// KubeClient is a Kubernetes client.
+	KubeClient kclient.Interface
+}
+
+// Create creates an ImageTriggerController.
+func (factory *ImageTriggerControllerFactory) Create() controller.RunnableController {
+	imageStreamLW := &cache.ListWatch{
+		ListFunc: func() (runtime.Object, error) {
+			return factory.Client.ImageStreams(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+		},
+		WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+			return factory.Client.ImageStreams(kapi.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
+		},
+	}
+	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(imageStreamLW, &imageapi.ImageStream{}, queue, 2*time.Minute).Run()
+
+	rcLW := &cache.ListWatch{
+		ListFunc: func() (runtime.Object, error) {
+			return factory.KubeClient.ReplicationControllers(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+		},
+		WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+			return factory.KubeClient.ReplicationControllers(kapi.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
+		},
+	}
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(rcLW, &kapi.ReplicationController{}, store, 2*time.Minute).Run()
+
+	triggerController := &ImageTriggerController{
+		replicationControllerClient: &replicationControllerClientImpl{
+			listReplicationControllersFunc: func() ([]*kapi.ReplicationController, error) {
+				rcs := []*kapi.ReplicationController{}
+				for _, obj := range store.List() {
+					rcs = append(rcs, obj.(*kapi.ReplicationController))
+				}
+				return rcs, nil
+			},
+			updateReplicationControllerFunc: func(namespace string, rc *kapi.ReplicationController) (*kapi.ReplicationController, error) {
+				return factory.KubeClient.ReplicationControllers(namespace).Update(rc)
+			},
+		},
+	}
+
+	return &controller.RetryController{
+		Queue: queue,
+		RetryManager: controller.NewBackoffQueueRetryManager(
+			queue,
+			cache.MetaNamespaceKeyFunc,
+			func(obj interface{}, err error, retries controller.Retry) bool {
+				kutil.HandleError(err)
+				return retries.Count < 5
+			},
+			controller.DefaultBackoff,
+		),
+		Handle: func(obj interface{}) error {
+			stream := obj.(*imageapi.ImageStream)
+			return triggerController.Handle(stream)
+		},
+	}
+}
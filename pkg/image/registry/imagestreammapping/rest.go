@@ -5,11 +5,13 @@ import (
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/fielderrors"
 	"k8s.io/kubernetes/pkg/util/wait"
 
+	"github.com/openshift/origin/pkg/api/latest"
 	"github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/image/api/validation"
 	"github.com/openshift/origin/pkg/image/registry/image"
@@ -26,13 +28,16 @@ const maxRetriesOnConflict = 10
 type REST struct {
 	imageRegistry       image.Registry
 	imageStreamRegistry imagestream.Registry
+	// recorder records the tag updates as events of the image streams
+	recorder record.EventRecorder
 }
 
 // NewREST returns a new REST.
-func NewREST(imageRegistry image.Registry, imageStreamRegistry imagestream.Registry) *REST {
+func NewREST(imageRegistry image.Registry, imageStreamRegistry imagestream.Registry, recorder record.EventRecorder) *REST {
 	return &REST{
 		imageRegistry:       imageRegistry,
 		imageStreamRegistry: imageStreamRegistry,
+		recorder:            recorder,
 	}
 }
 
@@ -99,6 +104,7 @@ func (s *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 		Image:                image.Name,
 	}
 
+	updated := false
 	err = wait.ExponentialBackoff(wait.Backoff{Steps: maxRetriesOnConflict}, func() (bool, error) {
 		lastEvent := api.LatestTaggedImage(stream, tag)
 		if !api.AddTagEventToImageStream(stream, tag, next) {
@@ -108,6 +114,7 @@ func (s *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 		api.UpdateTrackingTags(stream, tag, next)
 		_, err := s.imageStreamRegistry.UpdateImageStreamStatus(ctx, stream)
 		if err == nil {
+			updated = true
 			return true, nil
 		}
 		if !errors.IsConflict(err) {
@@ -131,9 +138,25 @@ func (s *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 	if err != nil {
 		return nil, err
 	}
+	if updated {
+		s.recorder.Eventf(streamReference(stream), "TagUpdated", "Tag %s updated to %s", tag, image.Name)
+	}
 	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
 }
 
+// streamReference returns a reference to stream. Unlike kapi.GetReference, it does not require the self link
+// of the stream, which is only set on the streams returned by the API.
+func streamReference(stream *api.ImageStream) *kapi.ObjectReference {
+	return &kapi.ObjectReference{
+		Kind:            "ImageStream",
+		APIVersion:      latest.Version,
+		Namespace:       stream.Namespace,
+		Name:            stream.Name,
+		UID:             stream.UID,
+		ResourceVersion: stream.ResourceVersion,
+	}
+}
+
 // findStreamForMapping retrieves an ImageStream whose DockerImageRepository matches dockerRepo.
 func (s *REST) findStreamForMapping(ctx kapi.Context, mapping *api.ImageStreamMapping) (*api.ImageStream, error) {
 	if len(mapping.Name) > 0 {
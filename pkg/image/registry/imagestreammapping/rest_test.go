@@ -11,6 +11,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -49,7 +50,7 @@ func setup(t *testing.T) (*tools.FakeEtcdClient, kstorage.Interface, *REST) {
 	imageRegistry := image.NewRegistry(imageStorage)
 	imageStreamStorage, imageStreamStatus, internalStorage := imagestreametcd.NewREST(helper, testDefaultRegistry, &fakeSubjectAccessReviewRegistry{})
 	imageStreamRegistry := imagestream.NewRegistry(imageStreamStorage, imageStreamStatus, internalStorage)
-	storage := NewREST(imageRegistry, imageStreamRegistry)
+	storage := NewREST(imageRegistry, imageStreamRegistry, &record.FakeRecorder{})
 	return fakeEtcdClient, helper, storage
 }
 
@@ -172,6 +173,9 @@ func TestCreateSuccessWithName(t *testing.T) {
 	if e, a := "imageID1", repo.Status.Tags["latest"].Items[0].Image; e != a {
 		t.Errorf("Expected %s, got %s", e, a)
 	}
+	if e, a := []string{"TagUpdated Tag latest updated to imageID1"}, storage.recorder.(*record.FakeRecorder).Events; !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected events %v, got %v", e, a)
+	}
 }
 
 func TestAddExistingImageWithNewTag(t *testing.T) {
@@ -452,6 +456,7 @@ func TestTrackingTags(t *testing.T) {
 // using failing registry update calls will return an error.
 func TestCreateRetryUnrecoverable(t *testing.T) {
 	rest := &REST{
+		recorder: &record.FakeRecorder{},
 		imageRegistry: &fakeImageRegistry{
 			createImage: func(ctx kapi.Context, image *api.Image) error {
 				return nil
@@ -485,6 +490,7 @@ func TestCreateRetryUnrecoverable(t *testing.T) {
 func TestCreateRetryConflictNoTagDiff(t *testing.T) {
 	firstUpdate := true
 	rest := &REST{
+		recorder: &record.FakeRecorder{},
 		imageRegistry: &fakeImageRegistry{
 			createImage: func(ctx kapi.Context, image *api.Image) error {
 				return nil
@@ -527,6 +533,7 @@ func TestCreateRetryConflictTagDiff(t *testing.T) {
 	firstGet := true
 	firstUpdate := true
 	rest := &REST{
+		recorder: &record.FakeRecorder{},
 		imageRegistry: &fakeImageRegistry{
 			createImage: func(ctx kapi.Context, image *api.Image) error {
 				return nil
@@ -11,14 +11,15 @@ import (
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/image/registry/image"
 	"github.com/openshift/origin/pkg/image/registry/imagestream"
 )
 
-// REST implements the RESTStorage interface for ImageStreamTag
-// It only supports the Get method and is used to simplify retrieving an Image by tag from an ImageStream
+// REST implements the RESTStorage interface for ImageStreamTag. It simplifies retrieving an Image by tag
+// from an ImageStream, and tagging, retagging and untagging images without editing the whole ImageStream.
 type REST struct {
 	imageRegistry       image.Registry
 	imageStreamRegistry imagestream.Registry
@@ -66,7 +67,14 @@ func (r *REST) List(ctx kapi.Context, label labels.Selector, field fields.Select
 
 	list := &api.ImageStreamTagList{}
 	for _, currIS := range imageStreams.Items {
+		tags := sets.NewString()
+		for currTag := range currIS.Spec.Tags {
+			tags.Insert(currTag)
+		}
 		for currTag := range currIS.Status.Tags {
+			tags.Insert(currTag)
+		}
+		for _, currTag := range tags.List() {
 			istag, err := newISTag(currTag, &currIS, nil)
 			if err != nil {
 				return nil, err
@@ -105,6 +113,72 @@ func (r *REST) Get(ctx kapi.Context, id string) (runtime.Object, error) {
 	return newISTag(tag, imageStream, image)
 }
 
+// Create tags an image by adding the tag of the ImageStreamTag to the spec of its ImageStream, which is
+// created if it does not exist yet. The image of the returned ImageStreamTag is empty until the tag has
+// been resolved.
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	istag, ok := obj.(*api.ImageStreamTag)
+	if !ok {
+		return nil, kapierrors.NewBadRequest(fmt.Sprintf("obj is not an ImageStreamTag: %#v", obj))
+	}
+
+	if err := rest.BeforeCreate(Strategy, ctx, obj); err != nil {
+		return nil, err
+	}
+
+	name, tag, err := nameAndTag(istag.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	imageStream, err := r.imageStreamRegistry.GetImageStream(ctx, name)
+	exists := err == nil
+	switch {
+	case kapierrors.IsNotFound(err):
+		imageStream = &api.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{
+				Namespace: kapi.NamespaceValue(ctx),
+				Name:      name,
+			},
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	_, hasSpecTag := imageStream.Spec.Tags[tag]
+	_, hasStatusTag := imageStream.Status.Tags[tag]
+	if hasSpecTag || hasStatusTag {
+		return nil, kapierrors.NewAlreadyExists("imageStreamTag", istag.Name)
+	}
+
+	if imageStream.Spec.Tags == nil {
+		imageStream.Spec.Tags = map[string]api.TagReference{}
+	}
+	imageStream.Spec.Tags[tag] = api.TagReference{
+		Annotations: istag.Annotations,
+		From:        istag.Tag.From,
+		Reference:   istag.Tag.Reference,
+	}
+
+	var newImageStream *api.ImageStream
+	if exists {
+		newImageStream, err = r.imageStreamRegistry.UpdateImageStream(ctx, imageStream)
+	} else {
+		newImageStream, err = r.imageStreamRegistry.CreateImageStream(ctx, imageStream)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := r.imageFor(ctx, tag, newImageStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return newISTag(tag, newImageStream, image)
+}
+
+// Update changes the annotations of a tag and, if the ImageStreamTag has a tag, the image it references.
 func (r *REST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
 	istag, ok := obj.(*api.ImageStreamTag)
 	if !ok {
@@ -120,18 +194,30 @@ func (r *REST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, boo
 		return nil, false, err
 	}
 
-	// we only allow updates of annotations, so lets find the correct image stream and update it.
+	// we only allow updates of annotations and the tag, so lets find the correct image stream and update it.
 	name, tag, err := nameAndTag(istag.Name)
 	if err != nil {
 		return nil, false, err
 	}
 
 	imageStream, err := r.imageStreamRegistry.GetImageStream(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	// the resource version of an ImageStreamTag is the one of its ImageStream, so a stale update conflicts
+	if len(istag.ResourceVersion) > 0 {
+		imageStream.ResourceVersion = istag.ResourceVersion
+	}
 	if imageStream.Spec.Tags == nil {
 		imageStream.Spec.Tags = map[string]api.TagReference{}
 	}
 	tagRef := imageStream.Spec.Tags[tag]
 	tagRef.Annotations = istag.Annotations
+	// clients that do not know about the tag leave it out, which keeps the image the tag references
+	if istag.Tag != nil {
+		tagRef.From = istag.Tag.From
+		tagRef.Reference = istag.Tag.Reference
+	}
 	imageStream.Spec.Tags[tag] = tagRef
 
 	newImageStream, err := r.imageStreamRegistry.UpdateImageStream(ctx, imageStream)
@@ -187,21 +273,29 @@ func (r *REST) Delete(ctx kapi.Context, id string) (runtime.Object, error) {
 	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
 }
 
-// imageFor retrieves the most recent image for a tag in a given imageStreem.
+// imageFor retrieves the most recent image for a tag in a given imageStreem. It returns nil if the tag is
+// in the spec of the imageStream but has not been resolved to an image yet.
 func (r *REST) imageFor(ctx kapi.Context, tag string, imageStream *api.ImageStream) (*api.Image, error) {
 	event := api.LatestTaggedImage(imageStream, tag)
 	if event == nil || len(event.Image) == 0 {
+		if _, ok := imageStream.Spec.Tags[tag]; ok {
+			return nil, nil
+		}
 		return nil, kapierrors.NewNotFound("imageStreamTag", api.JoinImageStreamTag(imageStream.Name, tag))
 	}
 
 	return r.imageRegistry.GetImage(ctx, event.Image)
 }
 
+// newISTag returns the ImageStreamTag for tag in imageStream. A tag that is only in the spec of imageStream,
+// because it has not been resolved yet, has an empty image.
 func newISTag(tag string, imageStream *api.ImageStream, image *api.Image) (*api.ImageStreamTag, error) {
 	istagName := api.JoinImageStreamTag(imageStream.Name, tag)
 
+	tagRef, hasSpecTag := imageStream.Spec.Tags[tag]
 	event := api.LatestTaggedImage(imageStream, tag)
-	if event == nil || len(event.Image) == 0 {
+	resolved := event != nil && len(event.Image) > 0
+	if !resolved && !hasSpecTag {
 		return nil, kapierrors.NewNotFound("imageStreamTag", istagName)
 	}
 
@@ -209,28 +303,39 @@ func newISTag(tag string, imageStream *api.ImageStream, image *api.Image) (*api.
 		ObjectMeta: kapi.ObjectMeta{
 			Namespace:         imageStream.Namespace,
 			Name:              istagName,
-			CreationTimestamp: event.Created,
+			CreationTimestamp: imageStream.CreationTimestamp,
 			Annotations:       map[string]string{},
 			ResourceVersion:   imageStream.ResourceVersion,
 		},
 	}
+	if resolved {
+		ist.CreationTimestamp = event.Created
+	}
 
-	// if the imageStream has Spec.Tags[tag].Annotations[k] = v, copy it to the image's annotations
-	// and add them to the istag's annotations
-	if imageStream.Spec.Tags != nil {
-		if tagRef, ok := imageStream.Spec.Tags[tag]; ok {
-			if image != nil && image.Annotations == nil {
-				image.Annotations = make(map[string]string)
-			}
-			for k, v := range tagRef.Annotations {
-				ist.Annotations[k] = v
-				if image != nil {
-					image.Annotations[k] = v
-				}
+	if hasSpecTag {
+		// the annotations of the tag are returned as the istag's annotations
+		ist.Tag = &api.TagReference{
+			From:      tagRef.From,
+			Reference: tagRef.Reference,
+		}
+
+		// if the imageStream has Spec.Tags[tag].Annotations[k] = v, copy it to the image's annotations
+		// and add them to the istag's annotations
+		if image != nil && image.Annotations == nil {
+			image.Annotations = make(map[string]string)
+		}
+		for k, v := range tagRef.Annotations {
+			ist.Annotations[k] = v
+			if image != nil {
+				image.Annotations[k] = v
 			}
 		}
 	}
 
+	if !resolved {
+		return ist, nil
+	}
+
 	if image != nil {
 		imageWithMetadata, err := api.ImageWithMetadata(*image)
 		if err != nil {
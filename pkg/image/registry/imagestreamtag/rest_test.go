@@ -509,3 +509,225 @@ func TestDeleteImageStreamTag(t *testing.T) {
 
 	}
 }
+
+func TestCreateImageStreamTag(t *testing.T) {
+	tests := map[string]struct {
+		repo        *api.ImageStream
+		istag       *api.ImageStreamTag
+		expectError func(error) bool
+	}{
+		"missing repo": {
+			istag: &api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "test:latest", Annotations: map[string]string{"color": "blue"}},
+				Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"}},
+			},
+		},
+		"new tag": {
+			repo: &api.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Namespace: "default", Name: "test"},
+				Spec: api.ImageStreamSpec{
+					Tags: map[string]api.TagReference{
+						"other": {From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:other"}},
+					},
+				},
+			},
+			istag: &api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "test:latest", Annotations: map[string]string{"color": "blue"}},
+				Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"}},
+			},
+		},
+		"existing spec tag": {
+			repo: &api.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Namespace: "default", Name: "test"},
+				Spec: api.ImageStreamSpec{
+					Tags: map[string]api.TagReference{
+						"latest": {From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:other"}},
+					},
+				},
+			},
+			istag: &api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "test:latest"},
+				Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"}},
+			},
+			expectError: errors.IsAlreadyExists,
+		},
+		"existing pushed tag": {
+			repo: &api.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Namespace: "default", Name: "test"},
+				Status: api.ImageStreamStatus{
+					Tags: map[string]api.TagEventList{
+						"latest": {Items: []api.TagEvent{{DockerImageReference: "test", Image: "10"}}},
+					},
+				},
+			},
+			istag: &api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "test:latest"},
+				Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"}},
+			},
+			expectError: errors.IsAlreadyExists,
+		},
+		"missing from": {
+			istag: &api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "test:latest"},
+			},
+			expectError: errors.IsInvalid,
+		},
+		"invalid from kind": {
+			istag: &api.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "test:latest"},
+				Tag:        &api.TagReference{From: &kapi.ObjectReference{Kind: "Pod", Name: "foo"}},
+			},
+			expectError: errors.IsInvalid,
+		},
+	}
+
+	for name, testCase := range tests {
+		fakeEtcdClient, helper, storage := setup(t)
+		if testCase.repo != nil {
+			fakeEtcdClient.Data[etcdtest.AddPrefix("/imagestreams/default/test")] = tools.EtcdResponseWithError{
+				R: &etcd.Response{
+					Node: &etcd.Node{
+						Value:         runtime.EncodeOrDie(latest.Codec, testCase.repo),
+						ModifiedIndex: 1,
+					},
+				},
+			}
+		} else {
+			fakeEtcdClient.Data[etcdtest.AddPrefix("/imagestreams/default/test")] = tools.EtcdResponseWithError{
+				R: &etcd.Response{
+					Node: nil,
+				},
+				E: tools.EtcdErrorNotFound,
+			}
+		}
+
+		ctx := kapi.WithUser(kapi.NewDefaultContext(), &fakeUser{})
+		obj, err := storage.Create(ctx, testCase.istag)
+		if testCase.expectError != nil {
+			if !testCase.expectError(err) {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		actual := obj.(*api.ImageStreamTag)
+		if e, a := testCase.istag.Tag.From, actual.Tag.From; !reflect.DeepEqual(e, a) {
+			t.Errorf("%s: tag: expected %#v, got %#v", name, e, a)
+		}
+		if e, a := testCase.istag.Annotations, actual.Annotations; !reflect.DeepEqual(e, a) {
+			t.Errorf("%s: annotations: expected %v, got %v", name, e, a)
+		}
+		if len(actual.Image.Name) != 0 {
+			t.Errorf("%s: expected the image not to be resolved yet, got %#v", name, actual.Image)
+		}
+
+		updatedRepo := &api.ImageStream{}
+		if err := helper.Get(kapi.NewDefaultContext(), "/imagestreams/default/test", updatedRepo, false); err != nil {
+			t.Fatalf("%s: error retrieving updated repo: %s", name, err)
+		}
+		expectedTagRef := api.TagReference{Annotations: testCase.istag.Annotations, From: testCase.istag.Tag.From}
+		if e, a := expectedTagRef, updatedRepo.Spec.Tags["latest"]; !reflect.DeepEqual(e, a) {
+			t.Errorf("%s: stream spec: expected %#v, got %#v", name, e, a)
+		}
+		if testCase.repo != nil && len(updatedRepo.Spec.Tags) != len(testCase.repo.Spec.Tags)+1 {
+			t.Errorf("%s: expected the other tags to be kept, got %#v", name, updatedRepo.Spec.Tags)
+		}
+	}
+}
+
+func TestUpdateImageStreamTag(t *testing.T) {
+	repo := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.ImageStreamSpec{
+			Tags: map[string]api.TagReference{
+				"latest": {
+					Annotations: map[string]string{"color": "blue"},
+					From:        &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		update      func(*api.ImageStreamTag)
+		expected    api.TagReference
+		expectError func(error) bool
+	}{
+		"retag": {
+			update: func(istag *api.ImageStreamTag) {
+				istag.Annotations = map[string]string{"color": "red"}
+				istag.Tag.From = &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:other"}
+				istag.Tag.Reference = true
+			},
+			expected: api.TagReference{
+				Annotations: map[string]string{"color": "red"},
+				From:        &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:other"},
+				Reference:   true,
+			},
+		},
+		"annotations only": {
+			update: func(istag *api.ImageStreamTag) {
+				istag.Annotations = map[string]string{"color": "red"}
+				istag.Tag = nil
+			},
+			expected: api.TagReference{
+				Annotations: map[string]string{"color": "red"},
+				From:        &kapi.ObjectReference{Kind: "DockerImage", Name: "foo/bar:latest"},
+			},
+		},
+		"stale resource version": {
+			update: func(istag *api.ImageStreamTag) {
+				istag.ResourceVersion = "0"
+			},
+			expectError: errors.IsConflict,
+		},
+		"invalid from kind": {
+			update: func(istag *api.ImageStreamTag) {
+				istag.Tag.From = &kapi.ObjectReference{Kind: "Pod", Name: "foo"}
+			},
+			expectError: errors.IsInvalid,
+		},
+	}
+
+	for name, testCase := range tests {
+		fakeEtcdClient, helper, storage := setup(t)
+		fakeEtcdClient.Data[etcdtest.AddPrefix("/imagestreams/default/test")] = tools.EtcdResponseWithError{
+			R: &etcd.Response{
+				Node: &etcd.Node{
+					Value:         runtime.EncodeOrDie(latest.Codec, repo),
+					ModifiedIndex: 1,
+				},
+			},
+		}
+
+		ctx := kapi.WithUser(kapi.NewDefaultContext(), &fakeUser{})
+		obj, err := storage.Get(ctx, "test:latest")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		istag := obj.(*api.ImageStreamTag)
+		testCase.update(istag)
+
+		_, _, err = storage.Update(ctx, istag)
+		if testCase.expectError != nil {
+			if !testCase.expectError(err) {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		updatedRepo := &api.ImageStream{}
+		if err := helper.Get(kapi.NewDefaultContext(), "/imagestreams/default/test", updatedRepo, false); err != nil {
+			t.Fatalf("%s: error retrieving updated repo: %s", name, err)
+		}
+		if e, a := testCase.expected, updatedRepo.Spec.Tags["latest"]; !reflect.DeepEqual(e, a) {
+			t.Errorf("%s: stream spec: expected %#v, got %#v", name, e, a)
+		}
+	}
+}
@@ -17,23 +17,34 @@ import (
 // strategy implements behavior for ImageStreamTags.
 type strategy struct {
 	runtime.ObjectTyper
+	kapi.NameGenerator
 }
 
 var Strategy = &strategy{
-	ObjectTyper: kapi.Scheme,
+	ObjectTyper:   kapi.Scheme,
+	NameGenerator: kapi.SimpleNameGenerator,
 }
 
 func (s *strategy) NamespaceScoped() bool {
 	return true
 }
 
+// PrepareForCreate clears the image, which is resolved from the tag once it has been imported.
 func (s *strategy) PrepareForCreate(obj runtime.Object) {
+	istag := obj.(*api.ImageStreamTag)
+
+	istag.Image = api.Image{}
 }
 
+// Validate validates a new ImageStreamTag, which must reference the image to tag.
 func (s *strategy) Validate(ctx kapi.Context, obj runtime.Object) fielderrors.ValidationErrorList {
 	istag := obj.(*api.ImageStreamTag)
 
-	return validation.ValidateImageStreamTag(istag)
+	result := validation.ValidateImageStreamTag(istag)
+	if istag.Tag == nil || istag.Tag.From == nil {
+		result = append(result, fielderrors.NewFieldRequired("tag.from"))
+	}
+	return result
 }
 
 func (s *strategy) AllowCreateOnUpdate() bool {
@@ -0,0 +1,180 @@
+package notification
+
+import (
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+// NotificationControllerOptions contains options for the NotificationController
+type NotificationControllerOptions struct {
+	// Resync is the time.Duration at which to fully re-list builds and deployments.
+	// If zero, re-list will be delayed as long as possible
+	Resync time.Duration
+	// Builds enables the notifications of builds
+	Builds bool
+	// Deployments enables the notifications of deployments
+	Deployments bool
+}
+
+// NewNotificationController returns a new *NotificationController delivering the notifications of the builds of
+// osClient and of the deployments of kClient to sinks, as enabled by options.
+func NewNotificationController(osClient osclient.BuildsNamespacer, kClient kclient.ReplicationControllersNamespacer, sinks []Sink, options NotificationControllerOptions) *NotificationController {
+	e := &NotificationController{
+		sinks: sinks,
+		now:   time.Now,
+	}
+
+	if options.Builds {
+		_, e.buildController = framework.NewInformer(
+			&cache.ListWatch{
+				ListFunc: func() (runtime.Object, error) {
+					return osClient.Builds(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+				},
+				WatchFunc: func(rv string) (watch.Interface, error) {
+					return osClient.Builds(kapi.NamespaceAll).Watch(labels.Everything(), fields.Everything(), rv)
+				},
+			},
+			&buildapi.Build{},
+			options.Resync,
+			framework.ResourceEventHandlerFuncs{
+				UpdateFunc: e.buildUpdated,
+			},
+		)
+	}
+
+	if options.Deployments {
+		_, e.deploymentController = framework.NewInformer(
+			&cache.ListWatch{
+				ListFunc: func() (runtime.Object, error) {
+					return kClient.ReplicationControllers(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+				},
+				WatchFunc: func(rv string) (watch.Interface, error) {
+					return kClient.ReplicationControllers(kapi.NamespaceAll).Watch(labels.Everything(), fields.Everything(), rv)
+				},
+			},
+			&kapi.ReplicationController{},
+			options.Resync,
+			framework.ResourceEventHandlerFuncs{
+				UpdateFunc: e.deploymentUpdated,
+			},
+		)
+	}
+
+	return e
+}
+
+// NotificationController notifies sinks when builds and deployments complete or fail. Only the transitions it
+// observes are notified, so builds and deployments finished while the master was down are not.
+type NotificationController struct {
+	stopChan chan struct{}
+
+	sinks []Sink
+	now   func() time.Time
+
+	buildController      *framework.Controller
+	deploymentController *framework.Controller
+}
+
+// Runs controller loops and returns immediately
+func (e *NotificationController) Run() {
+	if e.stopChan == nil {
+		e.stopChan = make(chan struct{})
+		if e.buildController != nil {
+			go e.buildController.Run(e.stopChan)
+		}
+		if e.deploymentController != nil {
+			go e.deploymentController.Run(e.stopChan)
+		}
+	}
+}
+
+// Stop gracefully shuts down this controller
+func (e *NotificationController) Stop() {
+	if e.stopChan != nil {
+		close(e.stopChan)
+		e.stopChan = nil
+	}
+}
+
+// buildUpdated notifies the completion or failure of a build
+func (e *NotificationController) buildUpdated(oldObj interface{}, newObj interface{}) {
+	old, build := oldObj.(*buildapi.Build), newObj.(*buildapi.Build)
+	if old.Status.Phase == build.Status.Phase {
+		return
+	}
+	var event configapi.NotificationEventType
+	switch build.Status.Phase {
+	case buildapi.BuildPhaseComplete:
+		event = configapi.NotificationEventBuildCompleted
+	case buildapi.BuildPhaseFailed, buildapi.BuildPhaseError, buildapi.BuildPhaseCancelled:
+		event = configapi.NotificationEventBuildFailed
+	default:
+		return
+	}
+	message := build.Status.Message
+	if len(message) == 0 {
+		message = string(build.Status.Reason)
+	}
+	e.notify(&Notification{
+		Event:     event,
+		Kind:      "Build",
+		Namespace: build.Namespace,
+		Name:      build.Name,
+		Message:   message,
+	})
+}
+
+// deploymentUpdated notifies the completion or failure of a deployment
+func (e *NotificationController) deploymentUpdated(oldObj interface{}, newObj interface{}) {
+	old, deployment := oldObj.(*kapi.ReplicationController), newObj.(*kapi.ReplicationController)
+	if len(deployutil.DeploymentConfigNameFor(deployment)) == 0 {
+		return
+	}
+	status := deployutil.DeploymentStatusFor(deployment)
+	if deployutil.DeploymentStatusFor(old) == status {
+		return
+	}
+	var event configapi.NotificationEventType
+	switch status {
+	case deployapi.DeploymentStatusComplete:
+		event = configapi.NotificationEventDeploymentCompleted
+	case deployapi.DeploymentStatusFailed:
+		event = configapi.NotificationEventDeploymentFailed
+	default:
+		return
+	}
+	e.notify(&Notification{
+		Event:     event,
+		Kind:      "Deployment",
+		Namespace: deployment.Namespace,
+		Name:      deployment.Name,
+		Message:   deployment.Annotations[deployapi.DeploymentStatusReasonAnnotation],
+	})
+}
+
+// notify delivers n to every sink without blocking the watch of builds and deployments
+func (e *NotificationController) notify(n *Notification) {
+	n.Time = e.now()
+	for _, sink := range e.sinks {
+		go func(sink Sink) {
+			if err := sink.Notify(n); err != nil {
+				util.HandleError(err)
+			}
+		}(sink)
+	}
+}
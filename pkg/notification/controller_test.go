@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+type fakeSink chan *Notification
+
+func (s fakeSink) Notify(n *Notification) error {
+	s <- n
+	return nil
+}
+
+func newController(sink fakeSink) *NotificationController {
+	return &NotificationController{sinks: []Sink{sink}, now: time.Now}
+}
+
+func expectNotification(t *testing.T, sink fakeSink, name string, expected configapi.NotificationEventType) *Notification {
+	select {
+	case n := <-sink:
+		if n.Event != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, n.Event)
+		}
+		return n
+	case <-time.After(5 * time.Second):
+		t.Errorf("%s: expected a %s notification", name, expected)
+		return nil
+	}
+}
+
+func expectNoNotification(t *testing.T, sink fakeSink, name string) {
+	select {
+	case n := <-sink:
+		t.Errorf("%s: unexpected notification %#v", name, n)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBuildUpdated(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new buildapi.BuildPhase
+		expected configapi.NotificationEventType
+	}{
+		{name: "running", old: buildapi.BuildPhasePending, new: buildapi.BuildPhaseRunning},
+		{name: "complete", old: buildapi.BuildPhaseRunning, new: buildapi.BuildPhaseComplete, expected: configapi.NotificationEventBuildCompleted},
+		{name: "failed", old: buildapi.BuildPhaseRunning, new: buildapi.BuildPhaseFailed, expected: configapi.NotificationEventBuildFailed},
+		{name: "error", old: buildapi.BuildPhasePending, new: buildapi.BuildPhaseError, expected: configapi.NotificationEventBuildFailed},
+		{name: "cancelled", old: buildapi.BuildPhaseRunning, new: buildapi.BuildPhaseCancelled, expected: configapi.NotificationEventBuildFailed},
+		{name: "resync", old: buildapi.BuildPhaseComplete, new: buildapi.BuildPhaseComplete},
+	}
+
+	for _, test := range tests {
+		sink := make(fakeSink, 1)
+		old := &buildapi.Build{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "build-1"}, Status: buildapi.BuildStatus{Phase: test.old}}
+		build := &buildapi.Build{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "build-1"}, Status: buildapi.BuildStatus{Phase: test.new, Message: "message"}}
+		newController(sink).buildUpdated(old, build)
+		if len(test.expected) == 0 {
+			expectNoNotification(t, sink, test.name)
+			continue
+		}
+		if n := expectNotification(t, sink, test.name, test.expected); n != nil && (n.Kind != "Build" || n.Name != "build-1" || n.Message != "message") {
+			t.Errorf("%s: unexpected notification %#v", test.name, n)
+		}
+	}
+}
+
+func TestDeploymentUpdated(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		old, new deployapi.DeploymentStatus
+		expected configapi.NotificationEventType
+	}{
+		{name: "running", config: "config", old: deployapi.DeploymentStatusPending, new: deployapi.DeploymentStatusRunning},
+		{name: "complete", config: "config", old: deployapi.DeploymentStatusRunning, new: deployapi.DeploymentStatusComplete, expected: configapi.NotificationEventDeploymentCompleted},
+		{name: "failed", config: "config", old: deployapi.DeploymentStatusRunning, new: deployapi.DeploymentStatusFailed, expected: configapi.NotificationEventDeploymentFailed},
+		{name: "resync", config: "config", old: deployapi.DeploymentStatusFailed, new: deployapi.DeploymentStatusFailed},
+		{name: "not a deployment", old: deployapi.DeploymentStatusRunning, new: deployapi.DeploymentStatusComplete},
+	}
+
+	for _, test := range tests {
+		sink := make(fakeSink, 1)
+		deployment := func(status deployapi.DeploymentStatus) *kapi.ReplicationController {
+			annotations := map[string]string{
+				deployapi.DeploymentStatusAnnotation:       string(status),
+				deployapi.DeploymentStatusReasonAnnotation: "reason",
+			}
+			if len(test.config) > 0 {
+				annotations[deployapi.DeploymentConfigAnnotation] = test.config
+			}
+			return &kapi.ReplicationController{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "config-1", Annotations: annotations}}
+		}
+		newController(sink).deploymentUpdated(deployment(test.old), deployment(test.new))
+		if len(test.expected) == 0 {
+			expectNoNotification(t, sink, test.name)
+			continue
+		}
+		if n := expectNotification(t, sink, test.name, test.expected); n != nil && (n.Kind != "Deployment" || n.Name != "config-1" || n.Message != "reason") {
+			t.Errorf("%s: unexpected notification %#v", test.name, n)
+		}
+	}
+}
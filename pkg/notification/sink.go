@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+// Notification describes the completion or failure of a build or deployment. It is the data of the payload
+// templates of webhooks.
+type Notification struct {
+	// Event is what happened to the object
+	Event configapi.NotificationEventType `json:"event"`
+	// Kind is the kind of the object, Build or Deployment
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the object
+	Namespace string `json:"namespace"`
+	// Name is the name of the object
+	Name string `json:"name"`
+	// Message describes why the event happened, if known
+	Message string `json:"message,omitempty"`
+	// Time is when the event was observed
+	Time time.Time `json:"time"`
+}
+
+// Sink delivers notifications
+type Sink interface {
+	// Notify delivers n, unless the sink ignores its event
+	Notify(n *Notification) error
+}
+
+// webhookSink posts notifications to a URL
+type webhookSink struct {
+	config  configapi.NotificationWebhookConfig
+	payload *template.Template
+	client  *http.Client
+}
+
+// NewWebhookSink returns a sink posting the notifications of the events of config to its URL. The body is the
+// payload template of config executed with the notification, or the notification as JSON without a template.
+func NewWebhookSink(config configapi.NotificationWebhookConfig) (Sink, error) {
+	sink := &webhookSink{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	if len(config.PayloadTemplate) > 0 {
+		payload, err := template.New(config.URL).Parse(config.PayloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload template for %s: %v", config.URL, err)
+		}
+		sink.payload = payload
+	}
+	return sink, nil
+}
+
+func (s *webhookSink) Notify(n *Notification) error {
+	if !s.wants(n.Event) {
+		return nil
+	}
+
+	body := &bytes.Buffer{}
+	if s.payload != nil {
+		if err := s.payload.Execute(body, n); err != nil {
+			return fmt.Errorf("unable to render the %s notification for %s: %v", n.Event, s.config.URL, err)
+		}
+	} else if err := json.NewEncoder(body).Encode(n); err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.config.URL, s.config.ContentType, body)
+	if err != nil {
+		return fmt.Errorf("unable to post the %s notification to %s: %v", n.Event, s.config.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unable to post the %s notification to %s: %s", n.Event, s.config.URL, resp.Status)
+	}
+	return nil
+}
+
+// wants returns true if the webhook is configured for event, or for every event
+func (s *webhookSink) wants(event configapi.NotificationEventType) bool {
+	if len(s.config.Events) == 0 {
+		return true
+	}
+	for _, e := range s.config.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+type request struct {
+	contentType string
+	body        string
+}
+
+func newServer(t *testing.T, status int) (*httptest.Server, chan request) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		requests <- request{contentType: r.Header.Get("Content-Type"), body: string(body)}
+		w.WriteHeader(status)
+	}))
+	return server, requests
+}
+
+func TestWebhookSink(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK)
+	defer server.Close()
+
+	n := &Notification{Event: configapi.NotificationEventBuildFailed, Kind: "Build", Namespace: "ns", Name: "build-1", Message: "timed out"}
+
+	sink, err := NewWebhookSink(configapi.NotificationWebhookConfig{URL: server.URL, ContentType: "application/json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := <-requests
+	if r.contentType != "application/json" {
+		t.Errorf("unexpected content type %s", r.contentType)
+	}
+	posted := &Notification{}
+	if err := json.Unmarshal([]byte(r.body), posted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if posted.Event != n.Event || posted.Name != n.Name || posted.Message != n.Message {
+		t.Errorf("unexpected notification %#v", posted)
+	}
+
+	sink, err = NewWebhookSink(configapi.NotificationWebhookConfig{
+		URL:             server.URL,
+		Events:          []configapi.NotificationEventType{configapi.NotificationEventBuildFailed},
+		PayloadTemplate: `{"text": "{{.Kind}} {{.Namespace}}/{{.Name}}: {{.Event}}"}`,
+		ContentType:     "text/plain",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r = <-requests
+	if r.contentType != "text/plain" || r.body != `{"text": "Build ns/build-1: BuildFailed"}` {
+		t.Errorf("unexpected request %#v", r)
+	}
+
+	if err := sink.Notify(&Notification{Event: configapi.NotificationEventBuildCompleted}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case r := <-requests:
+		t.Errorf("expected events the webhook is not configured for to be ignored, got %#v", r)
+	default:
+	}
+}
+
+func TestWebhookSinkErrors(t *testing.T) {
+	if _, err := NewWebhookSink(configapi.NotificationWebhookConfig{URL: "http://example.com", PayloadTemplate: "{{.Name"}); err == nil {
+		t.Errorf("expected an invalid template to be rejected")
+	}
+
+	server, _ := newServer(t, http.StatusInternalServerError)
+	defer server.Close()
+	sink, err := NewWebhookSink(configapi.NotificationWebhookConfig{URL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.Notify(&Notification{Event: configapi.NotificationEventDeploymentFailed}); err == nil {
+		t.Errorf("expected an error for a failed post")
+	}
+}
@@ -73,8 +73,28 @@ type OAuthClient struct {
 
 	// RedirectURIs is the valid redirection URIs associated with a client
 	RedirectURIs []string `json:"redirectURIs,omitempty" description:"valid redirection URIs associated with a client"`
+
+	// AccessTokenMaxAgeSeconds is the lifetime of the access tokens granted to this client. 0 uses the
+	// accessTokenMaxAgeSeconds of the OAuth server configuration.
+	AccessTokenMaxAgeSeconds int32 `json:"accessTokenMaxAgeSeconds,omitempty" description:"lifetime of the access tokens granted to this client in seconds, 0 uses the server default"`
+
+	// GrantMethod determines how grant requests of this client are handled: auto approves them, prompt asks the
+	// user to approve them, and deny denies them. Empty uses the grant method of the OAuth server configuration.
+	GrantMethod GrantHandlerType `json:"grantMethod,omitempty" description:"how grant requests of this client are handled: auto, prompt or deny, empty uses the server default"`
 }
 
+// GrantHandlerType is how the grant requests of a client are handled
+type GrantHandlerType string
+
+const (
+	// GrantHandlerAuto auto-approves client authorization grant requests
+	GrantHandlerAuto GrantHandlerType = "auto"
+	// GrantHandlerPrompt prompts the user to approve new client authorization grant requests
+	GrantHandlerPrompt GrantHandlerType = "prompt"
+	// GrantHandlerDeny auto-denies client authorization grant requests
+	GrantHandlerDeny GrantHandlerType = "deny"
+)
+
 type OAuthClientAuthorization struct {
 	unversioned.TypeMeta `json:",inline"`
 	kapi.ObjectMeta      `json:"metadata,omitempty"`
@@ -7,6 +7,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/util/fielderrors"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	oapi "github.com/openshift/origin/pkg/api"
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -92,10 +93,18 @@ func ValidateClient(client *api.OAuthClient) fielderrors.ValidationErrorList {
 			allErrs = append(allErrs, fielderrors.NewFieldInvalid(fmt.Sprintf("redirectURIs[%d]", i), redirect, msg))
 		}
 	}
+	if client.AccessTokenMaxAgeSeconds < 0 {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("accessTokenMaxAgeSeconds", client.AccessTokenMaxAgeSeconds, "must be 0 (server default) or greater"))
+	}
+	if len(client.GrantMethod) > 0 && !validGrantHandlerTypes.Has(string(client.GrantMethod)) {
+		allErrs = append(allErrs, fielderrors.NewFieldValueNotSupported("grantMethod", client.GrantMethod, validGrantHandlerTypes.List()))
+	}
 
 	return allErrs
 }
 
+var validGrantHandlerTypes = sets.NewString(string(api.GrantHandlerAuto), string(api.GrantHandlerPrompt), string(api.GrantHandlerDeny))
+
 func ValidateClientUpdate(client *api.OAuthClient, oldClient *api.OAuthClient) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 
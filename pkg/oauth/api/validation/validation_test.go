@@ -150,7 +150,9 @@ func TestValidateClientAuthorization(t *testing.T) {
 
 func TestValidateClient(t *testing.T) {
 	errs := ValidateClient(&oapi.OAuthClient{
-		ObjectMeta: api.ObjectMeta{Name: "client-name"},
+		ObjectMeta:               api.ObjectMeta{Name: "client-name"},
+		AccessTokenMaxAgeSeconds: 300,
+		GrantMethod:              oapi.GrantHandlerPrompt,
 	})
 	if len(errs) != 0 {
 		t.Errorf("expected success: %v", errs)
@@ -171,6 +173,16 @@ func TestValidateClient(t *testing.T) {
 			T:      fielderrors.ValidationErrorTypeInvalid,
 			F:      "metadata.namespace",
 		},
+		"negative access token max age": {
+			Client: oapi.OAuthClient{ObjectMeta: api.ObjectMeta{Name: "name"}, AccessTokenMaxAgeSeconds: -1},
+			T:      fielderrors.ValidationErrorTypeInvalid,
+			F:      "accessTokenMaxAgeSeconds",
+		},
+		"unknown grant method": {
+			Client: oapi.OAuthClient{ObjectMeta: api.ObjectMeta{Name: "name"}, GrantMethod: "sometimes"},
+			T:      fielderrors.ValidationErrorTypeNotSupported,
+			F:      "grantMethod",
+		},
 	}
 	for k, v := range errorCases {
 		errs := ValidateClient(&v.Client)
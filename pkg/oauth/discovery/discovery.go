@@ -0,0 +1,66 @@
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/RangelReale/osin"
+)
+
+// MetadataPath is where the OAuth server metadata is served, relative to the issuer
+const MetadataPath = "/.well-known/oauth-authorization-server"
+
+// OAuthAuthorizationServerMetadata describes an OAuth server to its clients, as defined by
+// https://tools.ietf.org/html/draft-ietf-oauth-discovery-00
+type OAuthAuthorizationServerMetadata struct {
+	// Issuer is the URL of the server, which the metadata is served under
+	Issuer string `json:"issuer"`
+	// AuthorizationEndpoint is the URL of the authorization endpoint
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	// TokenEndpoint is the URL of the token endpoint
+	TokenEndpoint string `json:"token_endpoint"`
+	// ResponseTypesSupported are the response types accepted by the authorization endpoint
+	ResponseTypesSupported []string `json:"response_types_supported"`
+	// GrantTypesSupported are the grant types accepted by the token endpoint
+	GrantTypesSupported []string `json:"grant_types_supported"`
+}
+
+// Get returns the metadata of an OAuth server configured by config, with the public URL issuer and the given public
+// endpoint URLs
+func Get(issuer, authorizeURL, tokenURL string, config *osin.ServerConfig) OAuthAuthorizationServerMetadata {
+	metadata := OAuthAuthorizationServerMetadata{
+		Issuer:                 issuer,
+		AuthorizationEndpoint:  authorizeURL,
+		TokenEndpoint:          tokenURL,
+		ResponseTypesSupported: []string{},
+		GrantTypesSupported:    []string{},
+	}
+	for _, t := range config.AllowedAuthorizeTypes {
+		metadata.ResponseTypesSupported = append(metadata.ResponseTypesSupported, string(t))
+		if t == osin.TOKEN {
+			// tokens issued by the authorization endpoint are implicit grants
+			metadata.GrantTypesSupported = append(metadata.GrantTypesSupported, "implicit")
+		}
+	}
+	for _, t := range config.AllowedAccessTypes {
+		metadata.GrantTypesSupported = append(metadata.GrantTypesSupported, string(t))
+	}
+	return metadata
+}
+
+// NewHandler returns a handler serving metadata as JSON
+func NewHandler(metadata OAuthAuthorizationServerMetadata) (http.Handler, error) {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "GET" && req.Method != "HEAD" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}), nil
+}
@@ -0,0 +1,56 @@
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/RangelReale/osin"
+)
+
+func TestGet(t *testing.T) {
+	config := osin.NewServerConfig()
+	config.AllowedAuthorizeTypes = osin.AllowedAuthorizeType{osin.CODE, osin.TOKEN}
+	config.AllowedAccessTypes = osin.AllowedAccessType{osin.AUTHORIZATION_CODE, osin.REFRESH_TOKEN}
+
+	metadata := Get("https://master.example.com", "https://master.example.com/oauth/authorize", "https://master.example.com/oauth/token", config)
+	expected := OAuthAuthorizationServerMetadata{
+		Issuer:                 "https://master.example.com",
+		AuthorizationEndpoint:  "https://master.example.com/oauth/authorize",
+		TokenEndpoint:          "https://master.example.com/oauth/token",
+		ResponseTypesSupported: []string{"code", "token"},
+		GrantTypesSupported:    []string{"implicit", "authorization_code", "refresh_token"},
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Errorf("expected %#v, got %#v", expected, metadata)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	metadata := OAuthAuthorizationServerMetadata{Issuer: "https://master.example.com", ResponseTypesSupported: []string{"code"}}
+	handler, err := NewHandler(metadata)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, &http.Request{Method: "GET"})
+	if resp.Code != http.StatusOK || resp.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected response %#v", resp)
+	}
+	served := OAuthAuthorizationServerMetadata{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &served); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if served.Issuer != metadata.Issuer || !reflect.DeepEqual(served.ResponseTypesSupported, metadata.ResponseTypesSupported) {
+		t.Errorf("unexpected metadata %#v", served)
+	}
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, &http.Request{Method: "POST"})
+	if resp.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected posts to be rejected, got %d", resp.Code)
+	}
+}
@@ -11,8 +11,8 @@ import (
 
 // Registry is an interface for things that know how to store AccessToken objects.
 type Registry interface {
-	// ListAccessTokens obtains a list of access tokens that match a selector.
-	ListAccessTokens(ctx kapi.Context, selector labels.Selector) (*api.OAuthAccessTokenList, error)
+	// ListAccessTokens obtains a list of access tokens that match the selectors.
+	ListAccessTokens(ctx kapi.Context, label labels.Selector, field fields.Selector) (*api.OAuthAccessTokenList, error)
 	// GetAccessToken retrieves a specific access token.
 	GetAccessToken(ctx kapi.Context, name string) (*api.OAuthAccessToken, error)
 	// CreateAccessToken creates a new access token.
@@ -40,8 +40,8 @@ func NewRegistry(s Storage) Registry {
 	return &storage{s}
 }
 
-func (s *storage) ListAccessTokens(ctx kapi.Context, label labels.Selector) (*api.OAuthAccessTokenList, error) {
-	obj, err := s.List(ctx, label, fields.Everything())
+func (s *storage) ListAccessTokens(ctx kapi.Context, label labels.Selector, field fields.Selector) (*api.OAuthAccessTokenList, error) {
+	obj, err := s.List(ctx, label, field)
 	if err != nil {
 		return nil, err
 	}
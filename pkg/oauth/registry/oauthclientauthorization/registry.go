@@ -13,8 +13,8 @@ import (
 type Registry interface {
 	// ClientAuthorizationName returns the name of the OAuthClientAuthorization for the given user name and client name
 	ClientAuthorizationName(userName, clientName string) string
-	// ListClientAuthorizations obtains a list of client auths that match a selector.
-	ListClientAuthorizations(ctx kapi.Context, selector labels.Selector) (*api.OAuthClientAuthorizationList, error)
+	// ListClientAuthorizations obtains a list of client auths that match the selectors.
+	ListClientAuthorizations(ctx kapi.Context, label labels.Selector, field fields.Selector) (*api.OAuthClientAuthorizationList, error)
 	// GetClientAuthorization retrieves a specific client auth.
 	GetClientAuthorization(ctx kapi.Context, name string) (*api.OAuthClientAuthorization, error)
 	// CreateClientAuthorization creates a new client auth.
@@ -40,8 +40,8 @@ func (s *storage) ClientAuthorizationName(userName, clientName string) string {
 	return userName + ":" + clientName
 }
 
-func (s *storage) ListClientAuthorizations(ctx kapi.Context, label labels.Selector) (*api.OAuthClientAuthorizationList, error) {
-	obj, err := s.List(ctx, label, fields.Everything())
+func (s *storage) ListClientAuthorizations(ctx kapi.Context, label labels.Selector, field fields.Selector) (*api.OAuthClientAuthorizationList, error) {
+	obj, err := s.List(ctx, label, field)
 	if err != nil {
 		return nil, err
 	}
@@ -2,20 +2,31 @@ package test
 
 import (
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
 )
 
 type AccessTokenRegistry struct {
-	Err                    error
-	AccessTokens           *api.OAuthAccessTokenList
-	AccessToken            *api.OAuthAccessToken
-	DeletedAccessTokenName string
+	Err                     error
+	AccessTokens            *api.OAuthAccessTokenList
+	AccessToken             *api.OAuthAccessToken
+	DeletedAccessTokenName  string
+	DeletedAccessTokenNames []string
 }
 
-func (r *AccessTokenRegistry) ListAccessTokens(ctx kapi.Context, labels labels.Selector) (*api.OAuthAccessTokenList, error) {
-	return r.AccessTokens, r.Err
+func (r *AccessTokenRegistry) ListAccessTokens(ctx kapi.Context, label labels.Selector, field fields.Selector) (*api.OAuthAccessTokenList, error) {
+	if r.AccessTokens == nil || r.Err != nil {
+		return r.AccessTokens, r.Err
+	}
+	list := &api.OAuthAccessTokenList{}
+	for _, token := range r.AccessTokens.Items {
+		if label.Matches(labels.Set(token.Labels)) && field.Matches(api.OAuthAccessTokenToSelectableFields(&token)) {
+			list.Items = append(list.Items, token)
+		}
+	}
+	return list, nil
 }
 
 func (r *AccessTokenRegistry) GetAccessToken(ctx kapi.Context, name string) (*api.OAuthAccessToken, error) {
@@ -28,5 +39,6 @@ func (r *AccessTokenRegistry) CreateAccessToken(ctx kapi.Context, token *api.OAu
 
 func (r *AccessTokenRegistry) DeleteAccessToken(ctx kapi.Context, name string) error {
 	r.DeletedAccessTokenName = name
+	r.DeletedAccessTokenNames = append(r.DeletedAccessTokenNames, name)
 	return r.Err
 }
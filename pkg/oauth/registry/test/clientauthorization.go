@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -22,8 +23,17 @@ func (r *ClientAuthorizationRegistry) ClientAuthorizationName(userName, clientNa
 	return fmt.Sprintf("%s:%s", userName, clientName)
 }
 
-func (r *ClientAuthorizationRegistry) ListClientAuthorizations(ctx kapi.Context, label labels.Selector) (*api.OAuthClientAuthorizationList, error) {
-	return r.ClientAuthorizations, r.Err
+func (r *ClientAuthorizationRegistry) ListClientAuthorizations(ctx kapi.Context, label labels.Selector, field fields.Selector) (*api.OAuthClientAuthorizationList, error) {
+	if r.ClientAuthorizations == nil || r.Err != nil {
+		return r.ClientAuthorizations, r.Err
+	}
+	list := &api.OAuthClientAuthorizationList{}
+	for _, authorization := range r.ClientAuthorizations.Items {
+		if label.Matches(labels.Set(authorization.Labels)) && field.Matches(api.OAuthClientAuthorizationToSelectableFields(&authorization)) {
+			list.Items = append(list.Items, authorization)
+		}
+	}
+	return list, nil
 }
 
 func (r *ClientAuthorizationRegistry) GetClientAuthorization(ctx kapi.Context, name string) (*api.OAuthClientAuthorization, error) {
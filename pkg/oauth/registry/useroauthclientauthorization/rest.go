@@ -0,0 +1,177 @@
+package useroauthclientauthorization
+
+import (
+	"errors"
+	"sort"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization"
+)
+
+// REST implements a RESTStorage for the client authorizations of the current user. Client authorizations are
+// addressed by the name of their client, and deleting one also deletes the access tokens the user granted to
+// that client. The clients the user has access tokens for but no authorization, like the clients granted
+// automatically, are listed and revoked like the authorized ones.
+type REST struct {
+	clientAuthorizations oauthclientauthorization.Registry
+	accessTokens         oauthaccesstoken.Registry
+}
+
+// NewREST returns a RESTStorage object that will work against the client authorizations of the current user
+func NewREST(clientAuthorizations oauthclientauthorization.Registry, accessTokens oauthaccesstoken.Registry) *REST {
+	return &REST{clientAuthorizations: clientAuthorizations, accessTokens: accessTokens}
+}
+
+// New returns a new OAuthClientAuthorization
+func (r *REST) New() runtime.Object {
+	return &api.OAuthClientAuthorization{}
+}
+
+// NewList returns a new OAuthClientAuthorizationList
+func (r *REST) NewList() runtime.Object {
+	return &api.OAuthClientAuthorizationList{}
+}
+
+// List returns the client authorizations of the current user matching the selectors, including the clients the user
+// only has access tokens for, like the clients granted automatically
+func (r *REST) List(ctx kapi.Context, label labels.Selector, field fields.Selector) (runtime.Object, error) {
+	userName, err := userNameFrom(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	userField := fields.OneTermEqualSelector("userName", userName)
+	authorizations, err := r.clientAuthorizations.ListClientAuthorizations(ctx, labels.Everything(), userField)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := r.accessTokens.ListAccessTokens(ctx, labels.Everything(), userField)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &api.OAuthClientAuthorizationList{ListMeta: authorizations.ListMeta}
+	authorized := sets.NewString()
+	for _, authorization := range authorizations.Items {
+		authorized.Insert(authorization.ClientName)
+		if label.Matches(labels.Set(authorization.Labels)) && field.Matches(api.OAuthClientAuthorizationToSelectableFields(&authorization)) {
+			list.Items = append(list.Items, authorization)
+		}
+	}
+	for _, authorization := range r.authorizationsFromTokens(userName, tokens.Items) {
+		if authorized.Has(authorization.ClientName) {
+			continue
+		}
+		if label.Matches(labels.Set(authorization.Labels)) && field.Matches(api.OAuthClientAuthorizationToSelectableFields(authorization)) {
+			list.Items = append(list.Items, *authorization)
+		}
+	}
+	return list, nil
+}
+
+// Get returns the client authorization of the current user for the named client, or one made up from the access
+// tokens of the user for the client if the user has no authorization for it
+func (r *REST) Get(ctx kapi.Context, clientName string) (runtime.Object, error) {
+	userName, err := userNameFrom(ctx, clientName)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := r.clientAuthorizations.GetClientAuthorization(ctx, r.clientAuthorizations.ClientAuthorizationName(userName, clientName))
+	if err == nil || !kerrors.IsNotFound(err) {
+		return authorization, err
+	}
+	tokens, tokensErr := r.accessTokens.ListAccessTokens(ctx, labels.Everything(), userClientSelector(userName, clientName))
+	if tokensErr != nil {
+		return nil, tokensErr
+	}
+	if authorizations := r.authorizationsFromTokens(userName, tokens.Items); len(authorizations) > 0 {
+		return authorizations[0], nil
+	}
+	return nil, err
+}
+
+// Delete revokes the client authorization of the current user for the named client, and deletes the access tokens
+// of the user for that client. Clients the user only has access tokens for can be revoked too.
+func (r *REST) Delete(ctx kapi.Context, clientName string) (runtime.Object, error) {
+	userName, err := userNameFrom(ctx, clientName)
+	if err != nil {
+		return nil, err
+	}
+	found := true
+	if err := r.clientAuthorizations.DeleteClientAuthorization(ctx, r.clientAuthorizations.ClientAuthorizationName(userName, clientName)); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+		found = false
+	}
+
+	tokens, err := r.accessTokens.ListAccessTokens(ctx, labels.Everything(), userClientSelector(userName, clientName))
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens.Items {
+		if err := r.accessTokens.DeleteAccessToken(ctx, token.Name); err != nil && !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+		found = true
+	}
+	if !found {
+		return nil, kerrors.NewNotFound("OAuthClientAuthorization", clientName)
+	}
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
+
+// authorizationsFromTokens returns a client authorization for each client of tokens, holding the scopes of the
+// tokens of that client, sorted by the name of the client
+func (r *REST) authorizationsFromTokens(userName string, tokens []api.OAuthAccessToken) []*api.OAuthClientAuthorization {
+	byClient := map[string]*api.OAuthClientAuthorization{}
+	clientNames := []string{}
+	for _, token := range tokens {
+		authorization, ok := byClient[token.ClientName]
+		if !ok {
+			authorization = &api.OAuthClientAuthorization{
+				ObjectMeta: kapi.ObjectMeta{Name: r.clientAuthorizations.ClientAuthorizationName(userName, token.ClientName)},
+				ClientName: token.ClientName,
+				UserName:   userName,
+				UserUID:    token.UserUID,
+			}
+			byClient[token.ClientName] = authorization
+			clientNames = append(clientNames, token.ClientName)
+		}
+		scopes := sets.NewString(authorization.Scopes...)
+		for _, scope := range token.Scopes {
+			if !scopes.Has(scope) {
+				scopes.Insert(scope)
+				authorization.Scopes = append(authorization.Scopes, scope)
+			}
+		}
+	}
+	sort.Strings(clientNames)
+	authorizations := make([]*api.OAuthClientAuthorization, 0, len(clientNames))
+	for _, clientName := range clientNames {
+		authorizations = append(authorizations, byClient[clientName])
+	}
+	return authorizations
+}
+
+// userClientSelector selects the objects of the user for the client
+func userClientSelector(userName, clientName string) fields.Selector {
+	return fields.Set{"userName": userName, "clientName": clientName}.AsSelector()
+}
+
+// userNameFrom returns the name of the user on ctx, or a forbidden error if there is none
+func userNameFrom(ctx kapi.Context, name string) (string, error) {
+	user, ok := kapi.UserFrom(ctx)
+	if !ok || len(user.GetName()) == 0 {
+		return "", kerrors.NewForbidden("OAuthClientAuthorization", name, errors.New("requests for client authorizations must be authenticated"))
+	}
+	return user.GetName(), nil
+}
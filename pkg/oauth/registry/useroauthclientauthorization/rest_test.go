@@ -0,0 +1,142 @@
+package useroauthclientauthorization
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/test"
+)
+
+func userContext(name string) kapi.Context {
+	return kapi.WithUser(kapi.NewContext(), &user.DefaultInfo{Name: name})
+}
+
+func TestList(t *testing.T) {
+	clientAuthorizations := &test.ClientAuthorizationRegistry{
+		ClientAuthorizations: &api.OAuthClientAuthorizationList{
+			Items: []api.OAuthClientAuthorization{
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob:console"}, UserName: "bob", ClientName: "console"},
+				{ObjectMeta: kapi.ObjectMeta{Name: "alice:console"}, UserName: "alice", ClientName: "console"},
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob:jenkins"}, UserName: "bob", ClientName: "jenkins"},
+			},
+		},
+	}
+	accessTokens := &test.AccessTokenRegistry{
+		AccessTokens: &api.OAuthAccessTokenList{
+			Items: []api.OAuthAccessToken{
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-console-token"}, UserName: "bob", ClientName: "console", Scopes: []string{"user:full"}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-cli-token"}, UserName: "bob", ClientName: "openshift-challenging-client", Scopes: []string{"user:info"}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-cli-token-2"}, UserName: "bob", ClientName: "openshift-challenging-client", Scopes: []string{"user:info", "user:check-access"}},
+				{ObjectMeta: kapi.ObjectMeta{Name: "alice-cli-token"}, UserName: "alice", ClientName: "openshift-browser-client"},
+			},
+		},
+	}
+	rest := NewREST(clientAuthorizations, accessTokens)
+
+	obj, err := rest.List(userContext("bob"), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := obj.(*api.OAuthClientAuthorizationList)
+	if len(list.Items) != 3 || list.Items[0].Name != "bob:console" || list.Items[1].Name != "bob:jenkins" || list.Items[2].Name != "bob:openshift-challenging-client" {
+		t.Fatalf("expected the authorizations of bob and the client bob has tokens for, got %#v", list.Items)
+	}
+	if e, a := []string{"user:info", "user:check-access"}, list.Items[2].Scopes; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the scopes %v of the tokens, got %v", e, a)
+	}
+
+	obj, err = rest.List(userContext("bob"), labels.Everything(), fields.OneTermEqualSelector("clientName", "jenkins"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list := obj.(*api.OAuthClientAuthorizationList); len(list.Items) != 1 || list.Items[0].Name != "bob:jenkins" {
+		t.Errorf("expected the jenkins authorization of bob, got %#v", list.Items)
+	}
+
+	if _, err := rest.List(kapi.NewContext(), labels.Everything(), fields.Everything()); !kerrors.IsForbidden(err) {
+		t.Errorf("expected a forbidden error without a user, got %v", err)
+	}
+}
+
+func TestGetWithoutAuthorization(t *testing.T) {
+	clientAuthorizations := &test.ClientAuthorizationRegistry{Err: kerrors.NewNotFound("OAuthClientAuthorization", "bob:openshift-challenging-client")}
+	accessTokens := &test.AccessTokenRegistry{
+		AccessTokens: &api.OAuthAccessTokenList{
+			Items: []api.OAuthAccessToken{
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-cli-token"}, UserName: "bob", ClientName: "openshift-challenging-client", Scopes: []string{"user:info"}},
+			},
+		},
+	}
+	rest := NewREST(clientAuthorizations, accessTokens)
+
+	obj, err := rest.Get(userContext("bob"), "openshift-challenging-client")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authorization := obj.(*api.OAuthClientAuthorization); authorization.Name != "bob:openshift-challenging-client" || authorization.UserName != "bob" {
+		t.Errorf("expected an authorization made up from the tokens of bob, got %#v", authorization)
+	}
+
+	if _, err := rest.Get(userContext("bob"), "jenkins"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error without an authorization or tokens, got %v", err)
+	}
+}
+
+func TestDeleteWithoutAuthorization(t *testing.T) {
+	clientAuthorizations := &test.ClientAuthorizationRegistry{Err: kerrors.NewNotFound("OAuthClientAuthorization", "bob:openshift-challenging-client")}
+	accessTokens := &test.AccessTokenRegistry{
+		AccessTokens: &api.OAuthAccessTokenList{
+			Items: []api.OAuthAccessToken{
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-cli-token"}, UserName: "bob", ClientName: "openshift-challenging-client"},
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-cli-token-2"}, UserName: "bob", ClientName: "openshift-challenging-client"},
+			},
+		},
+	}
+	rest := NewREST(clientAuthorizations, accessTokens)
+
+	if _, err := rest.Delete(userContext("bob"), "openshift-challenging-client"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []string{"bob-cli-token", "bob-cli-token-2"}, accessTokens.DeletedAccessTokenNames; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the access tokens %v to be deleted, got %v", e, a)
+	}
+
+	if _, err := rest.Delete(userContext("bob"), "jenkins"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error without an authorization or tokens, got %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	clientAuthorizations := &test.ClientAuthorizationRegistry{}
+	accessTokens := &test.AccessTokenRegistry{
+		AccessTokens: &api.OAuthAccessTokenList{
+			Items: []api.OAuthAccessToken{
+				{ObjectMeta: kapi.ObjectMeta{Name: "alice-token"}, UserName: "alice", ClientName: "jenkins"},
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-jenkins-token"}, UserName: "bob", ClientName: "jenkins"},
+				{ObjectMeta: kapi.ObjectMeta{Name: "bob-console-token"}, UserName: "bob", ClientName: "console"},
+			},
+		},
+	}
+	rest := NewREST(clientAuthorizations, accessTokens)
+
+	if _, err := rest.Delete(userContext("bob"), "jenkins"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clientAuthorizations.DeletedClientAuthorizationName != "bob:jenkins" {
+		t.Errorf("expected the authorization bob:jenkins to be deleted, got %q", clientAuthorizations.DeletedClientAuthorizationName)
+	}
+	if accessTokens.DeletedAccessTokenName != "bob-jenkins-token" {
+		t.Errorf("expected the access token of bob for jenkins to be deleted, got %q", accessTokens.DeletedAccessTokenName)
+	}
+
+	if _, err := rest.Delete(kapi.NewContext(), "jenkins"); !kerrors.IsForbidden(err) {
+		t.Errorf("expected a forbidden error without a user, got %v", err)
+	}
+}
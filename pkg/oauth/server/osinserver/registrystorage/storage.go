@@ -116,7 +116,12 @@ func (s *storage) RemoveAuthorize(code string) error {
 
 // SaveAccess writes AccessData.
 // If RefreshToken is not blank, it must save in a way that can be loaded using LoadRefresh.
+// Clients with an access token max age override the expiration of the server. The response is written from data
+// after it is saved, so the client is told the expiration the token is saved with.
 func (s *storage) SaveAccess(data *osin.AccessData) error {
+	if client, ok := data.Client.GetUserData().(*api.OAuthClient); ok && client.AccessTokenMaxAgeSeconds > 0 {
+		data.ExpiresIn = client.AccessTokenMaxAgeSeconds
+	}
 	token, err := s.convertToAccessToken(data)
 	if err != nil {
 		return err
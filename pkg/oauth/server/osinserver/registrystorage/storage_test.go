@@ -2,8 +2,73 @@ package registrystorage
 
 import (
 	"testing"
+
+	"github.com/RangelReale/osin"
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/test"
 )
 
 func TestRegistry(t *testing.T) {
 	_ = storage{}
 }
+
+type recordingAccessTokenRegistry struct {
+	test.AccessTokenRegistry
+	Created *api.OAuthAccessToken
+}
+
+func (r *recordingAccessTokenRegistry) CreateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error) {
+	r.Created = token
+	return token, nil
+}
+
+type fakeUserConversion struct{}
+
+func (fakeUserConversion) ConvertToAuthorizeToken(interface{}, *api.OAuthAuthorizeToken) error {
+	return nil
+}
+func (fakeUserConversion) ConvertToAccessToken(interface{}, *api.OAuthAccessToken) error { return nil }
+func (fakeUserConversion) ConvertFromAuthorizeToken(*api.OAuthAuthorizeToken) (interface{}, error) {
+	return nil, nil
+}
+func (fakeUserConversion) ConvertFromAccessToken(*api.OAuthAccessToken) (interface{}, error) {
+	return nil, nil
+}
+
+func TestSaveAccessClientMaxAge(t *testing.T) {
+	testCases := map[string]struct {
+		Client        *api.OAuthClient
+		ExpectExpires int32
+	}{
+		"server default": {
+			Client:        &api.OAuthClient{ObjectMeta: kapi.ObjectMeta{Name: "cli"}},
+			ExpectExpires: 3600,
+		},
+		"client max age": {
+			Client:        &api.OAuthClient{ObjectMeta: kapi.ObjectMeta{Name: "console"}, AccessTokenMaxAgeSeconds: 300},
+			ExpectExpires: 300,
+		},
+	}
+
+	for k, testCase := range testCases {
+		registry := &recordingAccessTokenRegistry{}
+		s := New(registry, &test.AuthorizeTokenRegistry{}, &test.ClientRegistry{}, fakeUserConversion{})
+		data := &osin.AccessData{
+			AccessToken: "token",
+			Client:      &clientWrapper{testCase.Client.Name, testCase.Client},
+			ExpiresIn:   3600,
+		}
+		if err := s.SaveAccess(data); err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if data.ExpiresIn != testCase.ExpectExpires {
+			t.Errorf("%s: expected the response to expire in %d, got %d", k, testCase.ExpectExpires, data.ExpiresIn)
+		}
+		if registry.Created == nil || registry.Created.ExpiresIn != int64(testCase.ExpectExpires) {
+			t.Errorf("%s: expected the saved token to expire in %d, got %#v", k, testCase.ExpectExpires, registry.Created)
+		}
+	}
+}
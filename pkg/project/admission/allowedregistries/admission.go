@@ -0,0 +1,201 @@
+package allowedregistries
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
+	"github.com/openshift/origin/pkg/project/cache"
+)
+
+// PluginName is the name of the allowed registries admission plugin
+const PluginName = "OriginAllowedRegistries"
+
+func init() {
+	admission.RegisterPlugin(PluginName, func(client client.Interface, config io.Reader) (admission.Interface, error) {
+		return NewAllowedRegistries(), nil
+	})
+}
+
+// allowedRegistries is an implementation of admission.Interface.
+type allowedRegistries struct {
+	*admission.Handler
+	cache *cache.ProjectCache
+}
+
+var _ = oadmission.WantsProjectCache(&allowedRegistries{})
+var _ = oadmission.Validator(&allowedRegistries{})
+
+// NewAllowedRegistries returns an admission plugin rejecting the objects referencing images from registries their
+// project does not allow
+func NewAllowedRegistries() admission.Interface {
+	return &allowedRegistries{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}
+}
+
+// Admit checks the images of pods and pod templates, the images the strategies of builds, build configs and
+// deployment configs start from, the images imported or tagged into image streams, and the images mapped into image
+// streams against the registries allowed by the openshift.io/allowed-registries annotation of their project.
+func (p *allowedRegistries) Admit(a admission.Attributes) error {
+	if a.GetSubresource() != "" {
+		return nil
+	}
+
+	var images []string
+	switch obj := a.GetObject().(type) {
+	case *kapi.Pod:
+		images = podSpecImages(&obj.Spec)
+	case *kapi.PodTemplate:
+		images = podSpecImages(&obj.Template.Spec)
+	case *kapi.ReplicationController:
+		images = podTemplateImages(obj.Spec.Template)
+	case *extensions.Deployment:
+		images = podTemplateImages(obj.Spec.Template)
+	case *extensions.DaemonSet:
+		images = podTemplateImages(obj.Spec.Template)
+	case *extensions.Job:
+		images = podSpecImages(&obj.Spec.Template.Spec)
+	case *deployapi.DeploymentConfig:
+		images = podTemplateImages(obj.Spec.Template)
+		if params := obj.Spec.Strategy.CustomParams; params != nil && len(params.Image) > 0 {
+			images = append(images, params.Image)
+		}
+	case *buildapi.Build:
+		images = strategyImages(obj.Spec.Strategy)
+	case *buildapi.BuildConfig:
+		images = strategyImages(obj.Spec.Strategy)
+	case *imageapi.ImageStream:
+		images = imageStreamImages(obj)
+	case *imageapi.ImageStreamTag:
+		if obj.Tag != nil {
+			images = tagReferenceImages(*obj.Tag)
+		}
+	case *imageapi.ImageStreamMapping:
+		images = []string{obj.Image.DockerImageReference}
+	default:
+		return nil
+	}
+	if len(images) == 0 {
+		return nil
+	}
+
+	namespace, err := p.getNamespace(a.GetNamespace())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	allowed, restricted := AllowedRegistries(namespace)
+	if !restricted {
+		return nil
+	}
+
+	for _, image := range images {
+		ref, err := imageapi.ParseDockerImageReference(image)
+		if err != nil {
+			// invalid references are reported by validation
+			continue
+		}
+		if registry := ref.DockerClientDefaults().Registry; !allowed.Has(registry) {
+			return admission.NewForbidden(a, fmt.Errorf("image %s comes from registry %s, the project only allows images from %s", image, registry, strings.Join(allowed.List(), ", ")))
+		}
+	}
+	return nil
+}
+
+// getNamespace returns the namespace from the project cache, or from the server until the cache runs, so that no
+// object is admitted without checking its images
+func (p *allowedRegistries) getNamespace(name string) (*kapi.Namespace, error) {
+	if p.cache.Running() {
+		return p.cache.GetNamespace(name)
+	}
+	namespace, err := p.cache.Client.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check the allowed registries of project %s: %v", name, err)
+	}
+	return namespace, nil
+}
+
+func (p *allowedRegistries) SetProjectCache(c *cache.ProjectCache) {
+	p.cache = c
+}
+
+func (p *allowedRegistries) Validate() error {
+	if p.cache == nil {
+		return fmt.Errorf("%s needs a project cache", PluginName)
+	}
+	return nil
+}
+
+// AllowedRegistries returns the registries allowed by the openshift.io/allowed-registries annotation of namespace,
+// and whether namespace is restricted at all
+func AllowedRegistries(namespace *kapi.Namespace) (sets.String, bool) {
+	value, ok := namespace.Annotations[projectapi.ProjectAllowedRegistries]
+	if !ok {
+		return nil, false
+	}
+	allowed := sets.NewString()
+	for _, registry := range strings.Split(value, ",") {
+		if registry = strings.TrimSpace(registry); len(registry) > 0 {
+			allowed.Insert(registry)
+		}
+	}
+	return allowed, true
+}
+
+func podSpecImages(spec *kapi.PodSpec) []string {
+	images := []string{}
+	for _, container := range spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+func podTemplateImages(template *kapi.PodTemplateSpec) []string {
+	if template == nil {
+		return nil
+	}
+	return podSpecImages(&template.Spec)
+}
+
+// strategyImages returns the image a build strategy starts from, when it is a Docker image. Image stream tags and
+// images are checked when the image stream imports them.
+func strategyImages(strategy buildapi.BuildStrategy) []string {
+	from := buildutil.GetImageStreamForStrategy(strategy)
+	if from == nil || from.Kind != "DockerImage" {
+		return nil
+	}
+	return []string{from.Name}
+}
+
+// imageStreamImages returns the images an image stream imports: its Docker image repository and the Docker images
+// of its tags which are not mere references.
+func imageStreamImages(stream *imageapi.ImageStream) []string {
+	images := []string{}
+	if len(stream.Spec.DockerImageRepository) > 0 {
+		images = append(images, stream.Spec.DockerImageRepository)
+	}
+	for _, tag := range stream.Spec.Tags {
+		images = append(images, tagReferenceImages(tag)...)
+	}
+	return images
+}
+
+// tagReferenceImages returns the Docker image a tag imports, unless it is a mere reference.
+func tagReferenceImages(tag imageapi.TagReference) []string {
+	if tag.Reference || tag.From == nil || tag.From.Kind != "DockerImage" {
+		return nil
+	}
+	return []string{tag.From.Name}
+}
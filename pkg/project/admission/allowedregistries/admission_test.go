@@ -0,0 +1,231 @@
+package allowedregistries
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+)
+
+func pod(images ...string) *kapi.Pod {
+	pod := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "pod"}}
+	for _, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, kapi.Container{Image: image})
+	}
+	return pod
+}
+
+func podTemplate(images ...string) *kapi.PodTemplateSpec {
+	return &kapi.PodTemplateSpec{Spec: pod(images...).Spec}
+}
+
+func build(from kapi.ObjectReference) *buildapi.Build {
+	return &buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{Name: "build"},
+		Spec: buildapi.BuildSpec{
+			Strategy: buildapi.BuildStrategy{SourceStrategy: &buildapi.SourceBuildStrategy{From: from}},
+		},
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	testCases := map[string]struct {
+		allowed  *string
+		resource string
+		object   runtime.Object
+		admit    bool
+	}{
+		"unrestricted project": {
+			resource: "pods",
+			object:   pod("registry.example.com/app"),
+			admit:    true,
+		},
+		"pod from allowed registries": {
+			allowed:  strptr("mirror.example.com:5000, docker.io"),
+			resource: "pods",
+			object:   pod("mirror.example.com:5000/ns/app:latest", "centos"),
+			admit:    true,
+		},
+		"pod from another registry": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "pods",
+			object:   pod("mirror.example.com:5000/ns/app", "registry.example.com/app"),
+		},
+		"pod from the default registry": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "pods",
+			object:   pod("centos"),
+		},
+		"nothing allowed": {
+			allowed:  strptr(""),
+			resource: "pods",
+			object:   pod("mirror.example.com:5000/ns/app"),
+		},
+		"build from an allowed image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "builds",
+			object:   build(kapi.ObjectReference{Kind: "DockerImage", Name: "mirror.example.com:5000/ns/builder"}),
+			admit:    true,
+		},
+		"build from another image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "builds",
+			object:   build(kapi.ObjectReference{Kind: "DockerImage", Name: "registry.example.com/builder"}),
+		},
+		"build from an image stream tag": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "builds",
+			object:   build(kapi.ObjectReference{Kind: "ImageStreamTag", Name: "builder:latest"}),
+			admit:    true,
+		},
+		"image stream importing another repository": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreams",
+			object: &imageapi.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream"},
+				Spec:       imageapi.ImageStreamSpec{DockerImageRepository: "registry.example.com/app"},
+			},
+		},
+		"image stream importing another image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreams",
+			object: &imageapi.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream"},
+				Spec: imageapi.ImageStreamSpec{Tags: map[string]imageapi.TagReference{
+					"latest": {From: &kapi.ObjectReference{Kind: "DockerImage", Name: "registry.example.com/app:1"}},
+				}},
+			},
+		},
+		"image stream referencing another image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreams",
+			object: &imageapi.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream"},
+				Spec: imageapi.ImageStreamSpec{Tags: map[string]imageapi.TagReference{
+					"latest": {From: &kapi.ObjectReference{Kind: "DockerImage", Name: "registry.example.com/app:1"}, Reference: true},
+				}},
+			},
+			admit: true,
+		},
+		"replication controller from another registry": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "replicationcontrollers",
+			object: &kapi.ReplicationController{
+				ObjectMeta: kapi.ObjectMeta{Name: "rc"},
+				Spec:       kapi.ReplicationControllerSpec{Template: podTemplate("registry.example.com/app")},
+			},
+		},
+		"deployment config from an allowed registry": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "deploymentconfigs",
+			object: &deployapi.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "dc"},
+				Spec:       deployapi.DeploymentConfigSpec{Template: podTemplate("mirror.example.com:5000/ns/app")},
+			},
+			admit: true,
+		},
+		"deployment config with a custom strategy from another registry": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "deploymentconfigs",
+			object: &deployapi.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "dc"},
+				Spec: deployapi.DeploymentConfigSpec{
+					Strategy: deployapi.DeploymentStrategy{
+						Type:         deployapi.DeploymentStrategyTypeCustom,
+						CustomParams: &deployapi.CustomDeploymentStrategyParams{Image: "registry.example.com/deployer"},
+					},
+					Template: podTemplate("mirror.example.com:5000/ns/app"),
+				},
+			},
+		},
+		"image stream tag importing another image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreamtags",
+			object: &imageapi.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream:latest"},
+				Tag:        &imageapi.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "registry.example.com/app:1"}},
+			},
+		},
+		"image stream tag importing an allowed image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreamtags",
+			object: &imageapi.ImageStreamTag{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream:latest"},
+				Tag:        &imageapi.TagReference{From: &kapi.ObjectReference{Kind: "DockerImage", Name: "mirror.example.com:5000/ns/app:1"}},
+			},
+			admit: true,
+		},
+		"image stream mapping of another image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreammappings",
+			object: &imageapi.ImageStreamMapping{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream"},
+				Image:      imageapi.Image{DockerImageReference: "registry.example.com/app@sha256:00000000000000000000000000000001"},
+				Tag:        "latest",
+			},
+		},
+		"image stream mapping of an allowed image": {
+			allowed:  strptr("mirror.example.com:5000"),
+			resource: "imagestreammappings",
+			object: &imageapi.ImageStreamMapping{
+				ObjectMeta: kapi.ObjectMeta{Name: "stream"},
+				Image:      imageapi.Image{DockerImageReference: "mirror.example.com:5000/ns/app@sha256:00000000000000000000000000000001"},
+				Tag:        "latest",
+			},
+			admit: true,
+		},
+	}
+
+	mockClient := &testclient.Fake{}
+	for k, tc := range testCases {
+		project := &kapi.Namespace{ObjectMeta: kapi.ObjectMeta{Name: "project"}}
+		if tc.allowed != nil {
+			project.Annotations = map[string]string{projectapi.ProjectAllowedRegistries: *tc.allowed}
+		}
+		projectStore := cache.NewStore(cache.IndexFuncToKeyFuncAdapter(cache.MetaNamespaceIndexFunc))
+		projectStore.Add(project)
+
+		handler := NewAllowedRegistries().(*allowedRegistries)
+		handler.SetProjectCache(projectcache.NewFake(mockClient.Namespaces(), projectStore, ""))
+
+		err := handler.Admit(admission.NewAttributesRecord(tc.object, "", "project", "name", tc.resource, "", admission.Create, nil))
+		if tc.admit && err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+		} else if !tc.admit && err == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+	}
+}
+
+func TestAdmitBeforeCacheRuns(t *testing.T) {
+	project := &kapi.Namespace{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        "project",
+			Annotations: map[string]string{projectapi.ProjectAllowedRegistries: "mirror.example.com:5000"},
+		},
+	}
+	handler := NewAllowedRegistries().(*allowedRegistries)
+	handler.SetProjectCache(projectcache.NewProjectCache(testclient.NewSimpleFake(project).Namespaces(), ""))
+	if err := handler.Admit(admission.NewAttributesRecord(pod("registry.example.com/app"), "", "project", "name", "pods", "", admission.Create, nil)); err == nil {
+		t.Errorf("expected the image to be checked against the project read from the server")
+	}
+	if err := handler.Admit(admission.NewAttributesRecord(pod("registry.example.com/app"), "", "missing", "name", "pods", "", admission.Create, nil)); err == nil {
+		t.Errorf("expected an error when the project cannot be read")
+	}
+	if err := handler.Admit(admission.NewAttributesRecord(pod("mirror.example.com:5000/app"), "", "project", "name", "pods", "", admission.Create, nil)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func strptr(s string) *string {
+	return &s
+}
@@ -56,4 +56,7 @@ const (
 	// ProjectRequester is the username that requested a given project.  Its not guaranteed to be present,
 	// but it is set by the default project template.
 	ProjectRequester = "openshift.io/requester"
+	// ProjectAllowedRegistries is an annotation that holds the comma separated list of the registries the images of
+	// the pods, builds and image streams of a project may come from. The images of projects without it are not restricted.
+	ProjectAllowedRegistries = "openshift.io/allowed-registries"
 )
@@ -41,6 +41,7 @@ func ValidateProject(project *api.Project) fielderrors.ValidationErrorList {
 			project.Annotations[projectapi.ProjectDisplayName], "may not contain a new line or tab"))
 	}
 	result = append(result, validateNodeSelector(project)...)
+	result = append(result, validateAllowedRegistries(project)...)
 	return result
 }
 
@@ -116,3 +117,21 @@ func validateNodeSelector(p *api.Project) fielderrors.ValidationErrorList {
 	}
 	return allErrs
 }
+
+func validateAllowedRegistries(p *api.Project) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+
+	registries, ok := p.Annotations[projectapi.ProjectAllowedRegistries]
+	if !ok {
+		return allErrs
+	}
+	for _, registry := range strings.Split(registries, ",") {
+		registry = strings.TrimSpace(registry)
+		if len(registry) == 0 || strings.ContainsAny(registry, "/ \t\n") {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("metadata.annotations["+projectapi.ProjectAllowedRegistries+"]",
+				registries, "must be a comma separated list of registry hosts"))
+			break
+		}
+	}
+	return allErrs
+}
@@ -144,6 +144,31 @@ func TestValidateProject(t *testing.T) {
 			// Should fail because infra and $test doesn't satisfy the format
 			numErrs: 1,
 		},
+		{
+			name: "valid allowed registries",
+			project: api.Project{
+				ObjectMeta: kapi.ObjectMeta{
+					Name: "foo",
+					Annotations: map[string]string{
+						api.ProjectAllowedRegistries: "mirror.example.com:5000, docker.io",
+					},
+				},
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid allowed registries",
+			project: api.Project{
+				ObjectMeta: kapi.ObjectMeta{
+					Name: "foo",
+					Annotations: map[string]string{
+						api.ProjectAllowedRegistries: "mirror.example.com/library,",
+					},
+				},
+			},
+			// Should fail because registries are hosts, not repositories
+			numErrs: 1,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -43,7 +43,7 @@ func (factory *NamespaceControllerFactory) Create() controller.RunnableControlle
 
 	return &controller.RetryController{
 		Queue: queue,
-		RetryManager: controller.NewQueueRetryManager(
+		RetryManager: controller.NewBackoffQueueRetryManager(
 			queue,
 			cache.MetaNamespaceKeyFunc,
 			func(obj interface{}, err error, retries controller.Retry) bool {
@@ -56,7 +56,7 @@ func (factory *NamespaceControllerFactory) Create() controller.RunnableControlle
 				}
 				return true
 			},
-			kutil.NewTokenBucketRateLimiter(1, 10),
+			controller.DefaultBackoff,
 		),
 		Handle: func(obj interface{}) error {
 			namespace := obj.(*kapi.Namespace)
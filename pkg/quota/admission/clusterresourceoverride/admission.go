@@ -0,0 +1,197 @@
+package clusterresourceoverride
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	kyaml "k8s.io/kubernetes/pkg/util/yaml"
+
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
+	configlatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
+	"github.com/openshift/origin/pkg/project/cache"
+	"github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride/api"
+	_ "github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride/api/v1"
+	"github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride/api/validation"
+)
+
+func init() {
+	admission.RegisterPlugin(api.PluginName, func(client client.Interface, config io.Reader) (admission.Interface, error) {
+		overrideConfig, err := ReadConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewClusterResourceOverride(client, overrideConfig), nil
+	})
+}
+
+// clusterResourceOverride is an implementation of admission.Interface which sets the resource requests of the
+// containers of new pods to a ratio of their limits
+type clusterResourceOverride struct {
+	*admission.Handler
+	client client.Interface
+	config *api.ClusterResourceOverrideConfig
+	cache  *cache.ProjectCache
+}
+
+var _ = oadmission.WantsProjectCache(&clusterResourceOverride{})
+var _ = oadmission.Validator(&clusterResourceOverride{})
+
+// ReadConfig reads the configuration of the plugin from config, which may be empty
+func ReadConfig(config io.Reader) (*api.ClusterResourceOverrideConfig, error) {
+	overrideConfig := &api.ClusterResourceOverrideConfig{}
+	if config == nil {
+		return overrideConfig, nil
+	}
+	// the plugin is given a nil *os.File when it has no configuration file
+	if v := reflect.ValueOf(config); v.Kind() == reflect.Ptr && v.IsNil() {
+		return overrideConfig, nil
+	}
+	data, err := ioutil.ReadAll(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return overrideConfig, nil
+	}
+	data, err = kyaml.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := configlatest.Codec.DecodeInto(data, overrideConfig); err != nil {
+		return nil, err
+	}
+	if errs := validation.ValidateClusterResourceOverrideConfig(overrideConfig); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s configuration: %v", api.PluginName, errs)
+	}
+	return overrideConfig, nil
+}
+
+// NewClusterResourceOverride returns a plugin overriding the resource requests of containers as described by config
+func NewClusterResourceOverride(client client.Interface, config *api.ClusterResourceOverrideConfig) admission.Interface {
+	return &clusterResourceOverride{
+		Handler: admission.NewHandler(admission.Create),
+		client:  client,
+		config:  config,
+	}
+}
+
+// Admit sets the CPU and memory requests of the containers of a new pod to the ratios of their limits configured for
+// the cluster, or by the labels of the project of the pod. Requests are left untouched for resources without a limit.
+// The plugin runs after LimitRanger, so that the limits it defaults are overridden too, and it never lowers a request
+// below the minimum a limit range of the project sets for containers, which LimitRanger has already enforced.
+func (p *clusterResourceOverride) Admit(a admission.Attributes) error {
+	if a.GetResource() != "pods" || a.GetSubresource() != "" {
+		return nil
+	}
+	pod, ok := a.GetObject().(*kapi.Pod)
+	if !ok {
+		return nil
+	}
+
+	cpuPercent, memoryPercent := p.config.CPURequestToLimitPercent, p.config.MemoryRequestToLimitPercent
+	if p.cache.Running() {
+		namespace, err := p.cache.GetNamespace(a.GetNamespace())
+		if err != nil {
+			return apierrors.NewForbidden(a.GetResource(), pod.Name, err)
+		}
+		if cpuPercent, err = projectPercent(namespace, api.CPURequestToLimitPercentLabel, cpuPercent); err != nil {
+			return apierrors.NewForbidden(a.GetResource(), pod.Name, err)
+		}
+		if memoryPercent, err = projectPercent(namespace, api.MemoryRequestToLimitPercentLabel, memoryPercent); err != nil {
+			return apierrors.NewForbidden(a.GetResource(), pod.Name, err)
+		}
+	}
+
+	if cpuPercent == 0 && memoryPercent == 0 {
+		return nil
+	}
+	minimums, err := p.containerMinimums(a.GetNamespace())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+
+	for i := range pod.Spec.Containers {
+		resources := &pod.Spec.Containers[i].Resources
+		if limit, ok := resources.Limits[kapi.ResourceCPU]; ok && cpuPercent > 0 {
+			request := resource.NewMilliQuantity(limit.MilliValue()*int64(cpuPercent)/100, limit.Format)
+			if minimum, ok := minimums[kapi.ResourceCPU]; ok && request.Cmp(minimum) < 0 {
+				request = minimum.Copy()
+			}
+			setRequest(resources, kapi.ResourceCPU, request)
+		}
+		if limit, ok := resources.Limits[kapi.ResourceMemory]; ok && memoryPercent > 0 {
+			request := resource.NewQuantity(limit.Value()*int64(memoryPercent)/100, limit.Format)
+			if minimum, ok := minimums[kapi.ResourceMemory]; ok && request.Cmp(minimum) < 0 {
+				request = minimum.Copy()
+			}
+			setRequest(resources, kapi.ResourceMemory, request)
+		}
+	}
+	return nil
+}
+
+// containerMinimums returns the highest minimum of each resource set for containers by the limit ranges of namespace
+func (p *clusterResourceOverride) containerMinimums(namespace string) (kapi.ResourceList, error) {
+	limitRanges, err := p.client.LimitRanges(namespace).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the limit ranges of project %s: %v", namespace, err)
+	}
+	minimums := kapi.ResourceList{}
+	for _, limitRange := range limitRanges.Items {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != kapi.LimitTypeContainer {
+				continue
+			}
+			for name, minimum := range item.Min {
+				if current, ok := minimums[name]; !ok || minimum.Cmp(current) > 0 {
+					minimums[name] = minimum
+				}
+			}
+		}
+	}
+	return minimums, nil
+}
+
+func (p *clusterResourceOverride) SetProjectCache(c *cache.ProjectCache) {
+	p.cache = c
+}
+
+func (p *clusterResourceOverride) Validate() error {
+	if p.cache == nil {
+		return fmt.Errorf("%s plugin needs a project cache", api.PluginName)
+	}
+	return nil
+}
+
+// projectPercent returns the percentage set by the label of namespace, or percent if the label is not set
+func projectPercent(namespace *kapi.Namespace, label string, percent int) (int, error) {
+	value, ok := namespace.Labels[label]
+	if !ok {
+		return percent, nil
+	}
+	projectPercent, err := strconv.Atoi(value)
+	if err == nil {
+		err = validation.ValidatePercent(projectPercent)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s label of project %s: %v", label, namespace.Name, err)
+	}
+	return projectPercent, nil
+}
+
+func setRequest(resources *kapi.ResourceRequirements, name kapi.ResourceName, request *resource.Quantity) {
+	if resources.Requests == nil {
+		resources.Requests = kapi.ResourceList{}
+	}
+	resources.Requests[name] = *request
+}
@@ -0,0 +1,168 @@
+package clusterresourceoverride
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+	"github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride/api"
+)
+
+func TestReadConfig(t *testing.T) {
+	config, err := ReadConfig(bytes.NewBufferString(`
+apiVersion: v1
+kind: ClusterResourceOverrideConfig
+cpuRequestToLimitPercent: 25
+memoryRequestToLimitPercent: 50
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.CPURequestToLimitPercent != 25 || config.MemoryRequestToLimitPercent != 50 {
+		t.Errorf("unexpected config %#v", config)
+	}
+
+	var noFile *os.File
+	if config, err := ReadConfig(noFile); err != nil || *config != (api.ClusterResourceOverrideConfig{}) {
+		t.Errorf("expected an empty config without a configuration file, got %#v, %v", config, err)
+	}
+
+	if _, err := ReadConfig(bytes.NewBufferString(`{"apiVersion": "v1", "kind": "ClusterResourceOverrideConfig", "cpuRequestToLimitPercent": 150}`)); err == nil {
+		t.Errorf("expected a ratio over 100 percent to be rejected")
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        api.ClusterResourceOverrideConfig
+		projectLabels map[string]string
+		limitRanges   []kapi.LimitRange
+		resources     kapi.ResourceRequirements
+		expected      kapi.ResourceList
+		admit         bool
+	}{
+		{
+			name:      "no override",
+			resources: resources("1", "1Gi", "100m", "128Mi"),
+			expected:  kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("100m"), kapi.ResourceMemory: resource.MustParse("128Mi")},
+			admit:     true,
+		},
+		{
+			name:      "cluster override",
+			config:    api.ClusterResourceOverrideConfig{CPURequestToLimitPercent: 25, MemoryRequestToLimitPercent: 50},
+			resources: resources("1", "1Gi", "1", "1Gi"),
+			expected:  kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("250m"), kapi.ResourceMemory: resource.MustParse("512Mi")},
+			admit:     true,
+		},
+		{
+			name:      "cluster override without requests",
+			config:    api.ClusterResourceOverrideConfig{CPURequestToLimitPercent: 25},
+			resources: kapi.ResourceRequirements{Limits: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("2")}},
+			expected:  kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("500m")},
+			admit:     true,
+		},
+		{
+			name:      "no limits",
+			config:    api.ClusterResourceOverrideConfig{CPURequestToLimitPercent: 25, MemoryRequestToLimitPercent: 50},
+			resources: kapi.ResourceRequirements{Requests: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("1")}},
+			expected:  kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("1")},
+			admit:     true,
+		},
+		{
+			name:          "project override",
+			config:        api.ClusterResourceOverrideConfig{CPURequestToLimitPercent: 25, MemoryRequestToLimitPercent: 50},
+			projectLabels: map[string]string{api.CPURequestToLimitPercentLabel: "100", api.MemoryRequestToLimitPercentLabel: "0"},
+			resources:     resources("1", "1Gi", "100m", "128Mi"),
+			expected:      kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("1"), kapi.ResourceMemory: resource.MustParse("128Mi")},
+			admit:         true,
+		},
+		{
+			name:   "limit range minimum",
+			config: api.ClusterResourceOverrideConfig{CPURequestToLimitPercent: 25, MemoryRequestToLimitPercent: 50},
+			limitRanges: []kapi.LimitRange{
+				limitRange(kapi.LimitTypeContainer, "200m", "128Mi"),
+				limitRange(kapi.LimitTypeContainer, "400m", "64Mi"),
+				limitRange(kapi.LimitTypePod, "1", "1Gi"),
+			},
+			resources: resources("1", "1Gi", "1", "1Gi"),
+			expected:  kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("400m"), kapi.ResourceMemory: resource.MustParse("512Mi")},
+			admit:     true,
+		},
+		{
+			name:          "invalid project override",
+			projectLabels: map[string]string{api.CPURequestToLimitPercentLabel: "most"},
+			resources:     resources("1", "1Gi", "100m", "128Mi"),
+			expected:      kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("100m"), kapi.ResourceMemory: resource.MustParse("128Mi")},
+		},
+	}
+
+	for _, test := range tests {
+		project := &kapi.Namespace{ObjectMeta: kapi.ObjectMeta{Name: "project", Labels: test.projectLabels}}
+		projectStore := cache.NewStore(cache.IndexFuncToKeyFuncAdapter(cache.MetaNamespaceIndexFunc))
+		projectStore.Add(project)
+
+		config := test.config
+		client := testclient.NewSimpleFake(&kapi.LimitRangeList{Items: test.limitRanges})
+		handler := NewClusterResourceOverride(client, &config)
+		handler.(*clusterResourceOverride).SetProjectCache(projectcache.NewFake((&testclient.Fake{}).Namespaces(), projectStore, ""))
+
+		pod := &kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Name: "pod", Namespace: "project"},
+			Spec:       kapi.PodSpec{Containers: []kapi.Container{{Name: "container", Resources: test.resources}}},
+		}
+		err := handler.Admit(admission.NewAttributesRecord(pod, "Pod", "project", "pod", "pods", "", admission.Create, nil))
+		if test.admit && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !test.admit && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+
+		requests := pod.Spec.Containers[0].Resources.Requests
+		if len(requests) != len(test.expected) {
+			t.Errorf("%s: expected requests %v, got %v", test.name, test.expected, requests)
+			continue
+		}
+		for name, expected := range test.expected {
+			if actual := requests[name]; actual.Cmp(expected) != 0 {
+				t.Errorf("%s: expected a %s request of %s, got %s", test.name, name, expected.String(), actual.String())
+			}
+		}
+	}
+}
+
+func resources(cpuLimit, memoryLimit, cpuRequest, memoryRequest string) kapi.ResourceRequirements {
+	return kapi.ResourceRequirements{
+		Limits: kapi.ResourceList{
+			kapi.ResourceCPU:    resource.MustParse(cpuLimit),
+			kapi.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+		Requests: kapi.ResourceList{
+			kapi.ResourceCPU:    resource.MustParse(cpuRequest),
+			kapi.ResourceMemory: resource.MustParse(memoryRequest),
+		},
+	}
+}
+
+func limitRange(limitType kapi.LimitType, cpuMin, memoryMin string) kapi.LimitRange {
+	return kapi.LimitRange{
+		Spec: kapi.LimitRangeSpec{
+			Limits: []kapi.LimitRangeItem{{
+				Type: limitType,
+				Min: kapi.ResourceList{
+					kapi.ResourceCPU:    resource.MustParse(cpuMin),
+					kapi.ResourceMemory: resource.MustParse(memoryMin),
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+func init() {
+	configapi.Scheme.AddKnownTypes("",
+		&ClusterResourceOverrideConfig{},
+	)
+}
+
+func (*ClusterResourceOverrideConfig) IsAnAPIObject() {}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+const (
+	// PluginName is the name of the admission plugin overriding the resource requests of containers
+	PluginName = "ClusterResourceOverride"
+
+	// CPURequestToLimitPercentLabel is the label of a project overriding the CPURequestToLimitPercent of the cluster
+	CPURequestToLimitPercentLabel = "quota.openshift.io/cpu-request-to-limit-percent"
+	// MemoryRequestToLimitPercentLabel is the label of a project overriding the MemoryRequestToLimitPercent of the cluster
+	MemoryRequestToLimitPercentLabel = "quota.openshift.io/memory-request-to-limit-percent"
+)
+
+// ClusterResourceOverrideConfig is the configuration of the ClusterResourceOverride admission plugin, which
+// sets the resource requests of the containers of new pods to a ratio of their limits so that nodes are
+// overcommitted consistently, whatever the requests of the pods were
+type ClusterResourceOverrideConfig struct {
+	unversioned.TypeMeta
+
+	// CPURequestToLimitPercent is the percentage of its CPU limit a container requests. 0 leaves CPU requests untouched.
+	CPURequestToLimitPercent int
+	// MemoryRequestToLimitPercent is the percentage of its memory limit a container requests. 0 leaves memory
+	// requests untouched.
+	MemoryRequestToLimitPercent int
+}
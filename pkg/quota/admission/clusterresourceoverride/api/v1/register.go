@@ -0,0 +1,13 @@
+package v1
+
+import (
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+func init() {
+	configapi.Scheme.AddKnownTypes("v1",
+		&ClusterResourceOverrideConfig{},
+	)
+}
+
+func (*ClusterResourceOverrideConfig) IsAnAPIObject() {}
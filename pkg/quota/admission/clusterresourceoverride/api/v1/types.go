@@ -0,0 +1,17 @@
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// ClusterResourceOverrideConfig is the configuration of the ClusterResourceOverride admission plugin, which
+// sets the resource requests of the containers of new pods to a ratio of their limits
+type ClusterResourceOverrideConfig struct {
+	unversioned.TypeMeta `json:",inline"`
+
+	// CPURequestToLimitPercent is the percentage of its CPU limit a container requests. 0 leaves CPU requests untouched.
+	CPURequestToLimitPercent int `json:"cpuRequestToLimitPercent"`
+	// MemoryRequestToLimitPercent is the percentage of its memory limit a container requests. 0 leaves memory
+	// requests untouched.
+	MemoryRequestToLimitPercent int `json:"memoryRequestToLimitPercent"`
+}
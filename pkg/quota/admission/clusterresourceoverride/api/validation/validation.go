@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"errors"
+
+	"k8s.io/kubernetes/pkg/util/fielderrors"
+
+	"github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride/api"
+)
+
+var errPercent = errors.New("must be between 0 and 100")
+
+// ValidateClusterResourceOverrideConfig checks that the ratios of config are percentages
+func ValidateClusterResourceOverrideConfig(config *api.ClusterResourceOverrideConfig) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	if err := ValidatePercent(config.CPURequestToLimitPercent); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("cpuRequestToLimitPercent", config.CPURequestToLimitPercent, err.Error()))
+	}
+	if err := ValidatePercent(config.MemoryRequestToLimitPercent); err != nil {
+		allErrs = append(allErrs, fielderrors.NewFieldInvalid("memoryRequestToLimitPercent", config.MemoryRequestToLimitPercent, err.Error()))
+	}
+	return allErrs
+}
+
+// ValidatePercent returns an error if percent is not between 0 and 100
+func ValidatePercent(percent int) error {
+	if percent < 0 || percent > 100 {
+		return errPercent
+	}
+	return nil
+}
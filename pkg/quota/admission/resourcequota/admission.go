@@ -0,0 +1,125 @@
+package resourcequota
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	quotaapi "github.com/openshift/origin/pkg/quota/api"
+)
+
+// PluginName is the name of the plugin enforcing the quotas of origin resources
+const PluginName = "OriginResourceQuota"
+
+// numRetries is the number of attempts to increment the usage of a quota modified by concurrent requests
+const numRetries = 10
+
+func init() {
+	admission.RegisterPlugin(PluginName, func(client kclient.Interface, config io.Reader) (admission.Interface, error) {
+		return NewOriginResourceQuota(client), nil
+	})
+}
+
+// originResourceQuota is an implementation of admission.Interface which rejects the creation of origin objects over
+// the limits of the resource quotas of their namespace
+type originResourceQuota struct {
+	*admission.Handler
+	client kclient.ResourceQuotasNamespacer
+}
+
+// NewOriginResourceQuota returns a plugin counting the origin objects created against the quotas read with client
+func NewOriginResourceQuota(client kclient.ResourceQuotasNamespacer) admission.Interface {
+	return &originResourceQuota{
+		Handler: admission.NewHandler(admission.Create),
+		client:  client,
+	}
+}
+
+// Admit increments the usage recorded by every quota of the namespace limiting the resource of a new object, and
+// rejects the object if it would go over one of the limits
+func (q *originResourceQuota) Admit(a admission.Attributes) error {
+	if a.GetSubresource() != "" {
+		return nil
+	}
+	name, ok := quotaapi.ResourceNames[a.GetResource()]
+	if !ok {
+		return nil
+	}
+
+	quotas, err := q.client.ResourceQuotas(a.GetNamespace()).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to %s %s at this time because there was an error enforcing quota", a.GetOperation(), a.GetResource()))
+	}
+
+	// concurrent requests can conflict when incrementing the usage, so each retry waits a random interval
+	interval := time.Duration(rand.Int63n(90)+int64(10)) * time.Millisecond
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		for retry := 1; ; retry++ {
+			usage, err := incrementUsage(quota, name)
+			if err != nil {
+				return admission.NewForbidden(a, err)
+			}
+			if usage == nil {
+				break
+			}
+			if _, err = q.client.ResourceQuotas(quota.Namespace).UpdateStatus(usage); err == nil {
+				break
+			}
+			if retry == numRetries {
+				return admission.NewForbidden(a, fmt.Errorf("unable to %s %s at this time because there are too many concurrent requests to increment quota", a.GetOperation(), a.GetResource()))
+			}
+			time.Sleep(interval)
+			if quota, err = q.client.ResourceQuotas(quota.Namespace).Get(quota.Name); err != nil {
+				return admission.NewForbidden(a, err)
+			}
+		}
+	}
+	return nil
+}
+
+// incrementUsage returns a copy of quota counting one more object of the named resource, nil if quota does not
+// limit the resource, or an error if the usage is unknown or the limit is reached
+func incrementUsage(quota *kapi.ResourceQuota, name kapi.ResourceName) (*kapi.ResourceQuota, error) {
+	hard, ok := quota.Status.Hard[name]
+	if !ok {
+		return nil, nil
+	}
+	used, ok := quota.Status.Used[name]
+	if !ok {
+		return nil, fmt.Errorf("quota usage stats are not yet known, unable to admit resource until an accurate count is completed.")
+	}
+	if used.Value() >= hard.Value() {
+		return nil, fmt.Errorf("limited to %s %s", hard.String(), name)
+	}
+
+	usage := &kapi.ResourceQuota{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:            quota.Name,
+			Namespace:       quota.Namespace,
+			ResourceVersion: quota.ResourceVersion,
+			Labels:          quota.Labels,
+			Annotations:     quota.Annotations,
+		},
+		Status: kapi.ResourceQuotaStatus{
+			Hard: kapi.ResourceList{},
+			Used: kapi.ResourceList{},
+		},
+	}
+	for k, v := range quota.Status.Hard {
+		usage.Status.Hard[k] = *v.Copy()
+	}
+	for k, v := range quota.Status.Used {
+		usage.Status.Used[k] = *v.Copy()
+	}
+	usage.Status.Used[name] = *resource.NewQuantity(used.Value()+1, resource.DecimalSI)
+	return usage, nil
+}
@@ -0,0 +1,98 @@
+package resourcequota
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	quotaapi "github.com/openshift/origin/pkg/quota/api"
+)
+
+func TestAdmit(t *testing.T) {
+	tests := []struct {
+		name         string
+		resource     string
+		hard         kapi.ResourceList
+		used         kapi.ResourceList
+		admit        bool
+		expectedUsed string
+	}{
+		{
+			name:         "under the limit",
+			resource:     "buildconfigs",
+			hard:         kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+			used:         kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("1")},
+			admit:        true,
+			expectedUsed: "2",
+		},
+		{
+			name:     "at the limit",
+			resource: "buildconfigs",
+			hard:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+			used:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+		},
+		{
+			name:     "unknown usage",
+			resource: "buildconfigs",
+			hard:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+		},
+		{
+			name:     "resource not limited",
+			resource: "routes",
+			hard:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+			used:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+			admit:    true,
+		},
+		{
+			name:     "resource not tracked",
+			resource: "builds",
+			hard:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+			used:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+			admit:    true,
+		},
+	}
+
+	for _, test := range tests {
+		quota := &kapi.ResourceQuota{
+			ObjectMeta: kapi.ObjectMeta{Name: "quota", Namespace: "project"},
+			Spec:       kapi.ResourceQuotaSpec{Hard: test.hard},
+			Status:     kapi.ResourceQuotaStatus{Hard: test.hard, Used: test.used},
+		}
+		client := ktestclient.NewSimpleFake(&kapi.ResourceQuotaList{Items: []kapi.ResourceQuota{*quota}})
+
+		obj := &buildapi.BuildConfig{ObjectMeta: kapi.ObjectMeta{Name: "config", Namespace: "project"}}
+		err := NewOriginResourceQuota(client).Admit(admission.NewAttributesRecord(obj, "BuildConfig", "project", "config", test.resource, "", admission.Create, nil))
+		if test.admit && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !test.admit && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+
+		var updated *kapi.ResourceQuota
+		for _, action := range client.Actions() {
+			if action.Matches("update", "resourcequotas") && action.GetSubresource() == "status" {
+				updated = action.(ktestclient.UpdateAction).GetObject().(*kapi.ResourceQuota)
+			}
+		}
+		if len(test.expectedUsed) == 0 {
+			if updated != nil {
+				t.Errorf("%s: unexpected update of the usage %v", test.name, updated.Status.Used)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected the usage to be incremented", test.name)
+			continue
+		}
+		name := quotaapi.ResourceNames[test.resource]
+		if used := updated.Status.Used[name]; used.String() != test.expectedUsed {
+			t.Errorf("%s: expected a usage of %s, got %s", test.name, test.expectedUsed, used.String())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+)
+
+// The names of the quota resources counting the objects of origin resources in a namespace
+const (
+	ResourceBuildConfigs      kapi.ResourceName = "openshift.io/buildconfigs"
+	ResourceDeploymentConfigs kapi.ResourceName = "openshift.io/deploymentconfigs"
+	ResourceRoutes            kapi.ResourceName = "openshift.io/routes"
+	ResourceImageStreams      kapi.ResourceName = "openshift.io/imagestreams"
+)
+
+// ResourceNames maps the origin resources whose objects can be limited by a ResourceQuota to the names of the quota
+// resources counting them
+var ResourceNames = map[string]kapi.ResourceName{
+	"buildconfigs":      ResourceBuildConfigs,
+	"deploymentconfigs": ResourceDeploymentConfigs,
+	"routes":            ResourceRoutes,
+	"imagestreams":      ResourceImageStreams,
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/quota/evaluator"
+)
+
+// ResourceQuotaController records the usage of the origin resources limited by resource quotas. The usage of the
+// other resources is left to the Kubernetes resource quota controller.
+type ResourceQuotaController struct {
+	client     kclient.ResourceQuotasNamespacer
+	evaluators map[kapi.ResourceName]evaluator.Evaluator
+}
+
+// NewResourceQuotaController returns a controller computing the usage of quotas with evaluators
+func NewResourceQuotaController(client kclient.ResourceQuotasNamespacer, evaluators map[kapi.ResourceName]evaluator.Evaluator) *ResourceQuotaController {
+	return &ResourceQuotaController{
+		client:     client,
+		evaluators: evaluators,
+	}
+}
+
+// Run synchronizes the usage of every quota each period and returns immediately
+func (c *ResourceQuotaController) Run(period time.Duration) {
+	go util.Until(c.synchronize, period, util.NeverStop)
+}
+
+func (c *ResourceQuotaController) synchronize() {
+	list, err := c.client.ResourceQuotas(kapi.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		glog.Errorf("Unable to list resource quotas: %v", err)
+		return
+	}
+	for i := range list.Items {
+		if err := c.syncResourceQuota(&list.Items[i]); err != nil {
+			glog.Errorf("Unable to synchronize the usage of resource quota %s/%s: %v", list.Items[i].Namespace, list.Items[i].Name, err)
+		}
+	}
+}
+
+// syncResourceQuota updates the usage of the origin resources limited by quota if it changed
+func (c *ResourceQuotaController) syncResourceQuota(quota *kapi.ResourceQuota) error {
+	usage := kapi.ResourceQuota{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:            quota.Name,
+			Namespace:       quota.Namespace,
+			ResourceVersion: quota.ResourceVersion,
+			Labels:          quota.Labels,
+			Annotations:     quota.Annotations,
+		},
+		Status: kapi.ResourceQuotaStatus{
+			Hard: kapi.ResourceList{},
+			Used: kapi.ResourceList{},
+		},
+	}
+	for k, v := range quota.Spec.Hard {
+		usage.Status.Hard[k] = *v.Copy()
+	}
+	for k, v := range quota.Status.Used {
+		usage.Status.Used[k] = *v.Copy()
+	}
+
+	dirty := false
+	for k := range usage.Status.Hard {
+		e, ok := c.evaluators[k]
+		if !ok {
+			continue
+		}
+		count, err := e.Usage(quota.Namespace)
+		if err != nil {
+			return err
+		}
+		if previous, found := usage.Status.Used[k]; !found || previous.Value() != count {
+			dirty = true
+		}
+		usage.Status.Used[k] = *resource.NewQuantity(count, resource.DecimalSI)
+	}
+	if !dirty {
+		return nil
+	}
+
+	glog.V(4).Infof("Updating the usage of resource quota %s/%s", quota.Namespace, quota.Name)
+	_, err := c.client.ResourceQuotas(quota.Namespace).UpdateStatus(&usage)
+	return err
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	quotaapi "github.com/openshift/origin/pkg/quota/api"
+	"github.com/openshift/origin/pkg/quota/evaluator"
+)
+
+func TestSyncResourceQuota(t *testing.T) {
+	osClient := testclient.NewSimpleFake(&buildapi.BuildConfigList{Items: []buildapi.BuildConfig{
+		{ObjectMeta: kapi.ObjectMeta{Name: "one", Namespace: "project"}},
+		{ObjectMeta: kapi.ObjectMeta{Name: "two", Namespace: "project"}},
+	}})
+
+	tests := []struct {
+		name     string
+		hard     kapi.ResourceList
+		used     kapi.ResourceList
+		expected kapi.ResourceList
+	}{
+		{
+			name:     "first sync",
+			hard:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("5"), kapi.ResourcePods: resource.MustParse("5")},
+			expected: kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+		},
+		{
+			name:     "usage changed",
+			hard:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("5")},
+			used:     kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("3"), kapi.ResourcePods: resource.MustParse("1")},
+			expected: kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2"), kapi.ResourcePods: resource.MustParse("1")},
+		},
+		{
+			name: "usage unchanged",
+			hard: kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("5")},
+			used: kapi.ResourceList{quotaapi.ResourceBuildConfigs: resource.MustParse("2")},
+		},
+		{
+			name: "no origin resource",
+			hard: kapi.ResourceList{kapi.ResourcePods: resource.MustParse("5")},
+		},
+	}
+
+	for _, test := range tests {
+		kubeClient := &ktestclient.Fake{}
+		controller := NewResourceQuotaController(kubeClient, evaluator.NewEvaluators(osClient))
+		quota := &kapi.ResourceQuota{
+			ObjectMeta: kapi.ObjectMeta{Name: "quota", Namespace: "project"},
+			Spec:       kapi.ResourceQuotaSpec{Hard: test.hard},
+			Status:     kapi.ResourceQuotaStatus{Used: test.used},
+		}
+		if err := controller.syncResourceQuota(quota); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		actions := kubeClient.Actions()
+		if test.expected == nil {
+			if len(actions) != 0 {
+				t.Errorf("%s: unexpected actions %v", test.name, actions)
+			}
+			continue
+		}
+		if len(actions) != 1 || !actions[0].Matches("update", "resourcequotas") || actions[0].GetSubresource() != "status" {
+			t.Errorf("%s: expected the status to be updated, got %v", test.name, actions)
+			continue
+		}
+		usage := actions[0].(ktestclient.UpdateAction).GetObject().(*kapi.ResourceQuota)
+		if len(usage.Status.Hard) != len(test.hard) {
+			t.Errorf("%s: expected the hard limits %v, got %v", test.name, test.hard, usage.Status.Hard)
+		}
+		if len(usage.Status.Used) != len(test.expected) {
+			t.Errorf("%s: expected the usage %v, got %v", test.name, test.expected, usage.Status.Used)
+			continue
+		}
+		for name, expected := range test.expected {
+			if actual := usage.Status.Used[name]; actual.Cmp(expected) != 0 {
+				t.Errorf("%s: expected a %s usage of %s, got %s", test.name, name, expected.String(), actual.String())
+			}
+		}
+	}
+}
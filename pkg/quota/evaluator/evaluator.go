@@ -0,0 +1,58 @@
+package evaluator
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	quotaapi "github.com/openshift/origin/pkg/quota/api"
+)
+
+// Evaluator computes the usage of a quota resource in a namespace
+type Evaluator interface {
+	// Usage returns the usage of the quota resource in namespace
+	Usage(namespace string) (int64, error)
+}
+
+// EvaluatorFunc is a function that implements Evaluator
+type EvaluatorFunc func(namespace string) (int64, error)
+
+func (f EvaluatorFunc) Usage(namespace string) (int64, error) {
+	return f(namespace)
+}
+
+// NewEvaluators returns the evaluators counting the objects of origin resources, keyed by the name of the quota
+// resource they compute
+func NewEvaluators(client osclient.Interface) map[kapi.ResourceName]Evaluator {
+	return map[kapi.ResourceName]Evaluator{
+		quotaapi.ResourceBuildConfigs: EvaluatorFunc(func(namespace string) (int64, error) {
+			list, err := client.BuildConfigs(namespace).List(labels.Everything(), fields.Everything())
+			if err != nil {
+				return 0, err
+			}
+			return int64(len(list.Items)), nil
+		}),
+		quotaapi.ResourceDeploymentConfigs: EvaluatorFunc(func(namespace string) (int64, error) {
+			list, err := client.DeploymentConfigs(namespace).List(labels.Everything(), fields.Everything())
+			if err != nil {
+				return 0, err
+			}
+			return int64(len(list.Items)), nil
+		}),
+		quotaapi.ResourceRoutes: EvaluatorFunc(func(namespace string) (int64, error) {
+			list, err := client.Routes(namespace).List(labels.Everything(), fields.Everything())
+			if err != nil {
+				return 0, err
+			}
+			return int64(len(list.Items)), nil
+		}),
+		quotaapi.ResourceImageStreams: EvaluatorFunc(func(namespace string) (int64, error) {
+			list, err := client.ImageStreams(namespace).List(labels.Everything(), fields.Everything())
+			if err != nil {
+				return 0, err
+			}
+			return int64(len(list.Items)), nil
+		}),
+	}
+}
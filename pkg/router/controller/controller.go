@@ -65,6 +65,23 @@ func (c *RouterController) HandleNamespaces() {
 	glog.V(4).Infof("Unable to update list of namespaces")
 }
 
+// Reload reloads the configuration of the plugin, if it supports it, between the handling of two events.
+func (c *RouterController) Reload() {
+	reloader, ok := c.Plugin.(router.Reloader)
+	if !ok {
+		glog.V(4).Infof("The router plugin does not support reloading its configuration")
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	glog.V(2).Infof("Reloading the router configuration")
+	if err := reloader.Reload(); err != nil {
+		util.HandleError(fmt.Errorf("unable to reload the router configuration: %v", err))
+	}
+}
+
 // HandleRoute handles a single Route event and synchronizes the router backend.
 func (c *RouterController) HandleRoute() {
 	eventType, route, err := c.NextRoute()
@@ -183,6 +183,14 @@ func (p *UniqueHost) HandleNamespaces(namespaces sets.String) error {
 	return p.plugin.HandleNamespaces(namespaces)
 }
 
+// Reload reloads the configuration of the underlying plugin, if it supports it.
+func (p *UniqueHost) Reload() error {
+	if reloader, ok := p.plugin.(router.Reloader); ok {
+		return reloader.Reload()
+	}
+	return nil
+}
+
 // routeKey returns the internal router key to use for the given Route.
 func routeKey(route *routeapi.Route) string {
 	return fmt.Sprintf("%s/%s", route.Namespace, route.Spec.To.Name)
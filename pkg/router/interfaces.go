@@ -16,3 +16,9 @@ type Plugin interface {
 	// If sent, filter the list of accepted routes and endpoints to this set
 	HandleNamespaces(namespaces sets.String) error
 }
+
+// Reloader is implemented by the plugins that can reload their configuration, e.g. certificates read from files,
+// without restarting.
+type Reloader interface {
+	Reload() error
+}
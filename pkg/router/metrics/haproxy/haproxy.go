@@ -0,0 +1,142 @@
+package haproxy
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "haproxy"
+
+var (
+	upDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "up"),
+		"Whether the statistics of HAProxy could be read: 1 if they could, 0 if they could not.",
+		nil, nil,
+	)
+	backendUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "backend", "up"),
+		"Whether a backend has a healthy server: 1 if it has, 0 if it has not.",
+		[]string{"backend"}, nil,
+	)
+	serverUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "server", "up"),
+		"Whether a server of a backend passes its health checks: 1 if it does, 0 if it does not.",
+		[]string{"backend", "server"}, nil,
+	)
+	serverCheckFailuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "server", "check_failures_total"),
+		"Number of failed health checks of a server of a backend.",
+		[]string{"backend", "server"}, nil,
+	)
+)
+
+// Exporter collects the health of the backends and servers of HAProxy from its statistics socket
+type Exporter struct {
+	socket  string
+	timeout time.Duration
+}
+
+var _ prometheus.Collector = &Exporter{}
+
+// NewExporter returns an exporter reading the statistics of the HAProxy listening on the unix socket at path
+func NewExporter(socket string) *Exporter {
+	return &Exporter{socket: socket, timeout: 5 * time.Second}
+}
+
+// Describe implements prometheus.Collector
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- upDesc
+	ch <- backendUpDesc
+	ch <- serverUpDesc
+	ch <- serverCheckFailuresDesc
+}
+
+// Collect implements prometheus.Collector
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	if err := e.collect(ch); err != nil {
+		glog.V(2).Infof("Unable to read the statistics of HAProxy: %v", err)
+		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1)
+}
+
+func (e *Exporter) collect(ch chan<- prometheus.Metric) error {
+	conn, err := net.DialTimeout("unix", e.socket, e.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(e.timeout))
+	if _, err := io.WriteString(conn, "show stat\n"); err != nil {
+		return err
+	}
+	return parseStats(conn, ch)
+}
+
+// parseStats emits the health of the backends and servers listed in the CSV output of the show stat command
+func parseStats(r io.Reader, ch chan<- prometheus.Metric) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "# ")] = i
+	}
+	for _, name := range []string{"pxname", "svname", "status", "chkfail"} {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("the statistics have no %s column", name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+
+		proxy, server, status := field("pxname"), field("svname"), field("status")
+		switch server {
+		case "FRONTEND":
+		case "BACKEND":
+			ch <- prometheus.MustNewConstMetric(backendUpDesc, prometheus.GaugeValue, up(status), proxy)
+		default:
+			ch <- prometheus.MustNewConstMetric(serverUpDesc, prometheus.GaugeValue, up(status), proxy, server)
+			// servers without health checks report no failures
+			if failures, err := strconv.ParseFloat(field("chkfail"), 64); err == nil {
+				ch <- prometheus.MustNewConstMetric(serverCheckFailuresDesc, prometheus.CounterValue, failures, proxy, server)
+			}
+		}
+	}
+}
+
+// up returns 1 for the statuses of healthy backends and servers, including those going down and servers without
+// health checks, and 0 for the others
+func up(status string) float64 {
+	if strings.HasPrefix(status, "UP") || status == "no check" {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+package haproxy
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const stats = `# pxname,svname,qcur,qmax,scur,smax,slim,stot,bin,bout,dreq,dresp,ereq,econ,eresp,wretr,wredis,status,weight,act,bck,chkfail,chkdown,lastchg,downtime,
+public,FRONTEND,,,0,0,2000,0,0,0,0,0,0,,,,,OPEN,,,,,,,,
+be_http_ns_app,ep1,0,0,0,0,,0,0,0,,0,,0,0,0,0,UP,1,1,0,0,0,10,0,
+be_http_ns_app,ep2,0,0,0,0,,0,0,0,,0,,0,0,0,0,DOWN,1,1,0,3,1,10,5,
+be_http_ns_app,BACKEND,0,0,0,0,200,0,0,0,0,0,,0,0,0,0,UP,1,1,0,,0,10,0,
+be_tcp_ns_db,ep3,0,0,0,0,,0,0,0,,0,,0,0,0,0,no check,1,1,0,,,,,
+be_tcp_ns_db,BACKEND,0,0,0,0,200,0,0,0,0,0,,0,0,0,0,UP,1,1,0,,0,10,0,
+be_tcp_ns_down,ep4,0,0,0,0,,0,0,0,,0,,0,0,0,0,DOWN 1/2,1,1,0,7,1,10,5,
+be_tcp_ns_down,BACKEND,0,0,0,0,200,0,0,0,0,0,,0,0,0,0,DOWN,1,1,0,,1,10,5,
+`
+
+var names = map[*prometheus.Desc]string{
+	upDesc:                  "haproxy_up",
+	backendUpDesc:           "haproxy_backend_up",
+	serverUpDesc:            "haproxy_server_up",
+	serverCheckFailuresDesc: "haproxy_server_check_failures_total",
+}
+
+// collect returns the values of the metrics emitted by fn, keyed by their name and label values
+func collect(t *testing.T, fn func(ch chan<- prometheus.Metric)) map[string]float64 {
+	ch := make(chan prometheus.Metric, 100)
+	fn(ch)
+	close(ch)
+	values := map[string]float64{}
+	for metric := range ch {
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		labels := []string{}
+		for _, label := range m.Label {
+			labels = append(labels, label.GetValue())
+		}
+		key := strings.Join(append([]string{names[metric.Desc()]}, labels...), " ")
+		switch {
+		case m.Gauge != nil:
+			values[key] = m.Gauge.GetValue()
+		case m.Counter != nil:
+			values[key] = m.Counter.GetValue()
+		}
+	}
+	return values
+}
+
+func TestParseStats(t *testing.T) {
+	values := collect(t, func(ch chan<- prometheus.Metric) {
+		if err := parseStats(strings.NewReader(stats), ch); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	expected := map[string]float64{
+		"haproxy_backend_up be_http_ns_app":                      1,
+		"haproxy_server_up be_http_ns_app ep1":                   1,
+		"haproxy_server_up be_http_ns_app ep2":                   0,
+		"haproxy_server_check_failures_total be_http_ns_app ep1": 0,
+		"haproxy_server_check_failures_total be_http_ns_app ep2": 3,
+		"haproxy_backend_up be_tcp_ns_db":                        1,
+		"haproxy_server_up be_tcp_ns_db ep3":                     1,
+		"haproxy_backend_up be_tcp_ns_down":                      0,
+		"haproxy_server_up be_tcp_ns_down ep4":                   0,
+		"haproxy_server_check_failures_total be_tcp_ns_down ep4": 7,
+	}
+	if len(values) != len(expected) {
+		t.Errorf("expected %d metrics, got %v", len(expected), values)
+	}
+	for key, value := range expected {
+		if actual, ok := values[key]; !ok || actual != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, values)
+		}
+	}
+
+	if err := parseStats(strings.NewReader("# pxname,svname\n"), make(chan prometheus.Metric, 10)); err == nil {
+		t.Errorf("expected statistics without a status to be rejected")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "haproxy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "haproxy.sock")
+
+	exporter := NewExporter(socket)
+	values := collect(t, exporter.Collect)
+	if values["haproxy_up"] != 0 || len(values) != 1 {
+		t.Errorf("expected HAProxy to be reported down without a socket, got %v", values)
+	}
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		command := make([]byte, len("show stat\n"))
+		if _, err := conn.Read(command); err != nil || string(command) != "show stat\n" {
+			t.Errorf("unexpected command %q: %v", command, err)
+			return
+		}
+		conn.Write([]byte(stats))
+	}()
+
+	values = collect(t, exporter.Collect)
+	if values["haproxy_up"] != 1 || values["haproxy_server_up be_http_ns_app ep2"] != 0 || values["haproxy_server_up be_http_ns_app ep1"] != 1 {
+		t.Errorf("unexpected metrics %v", values)
+	}
+}
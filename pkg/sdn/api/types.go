@@ -5,6 +5,10 @@ import (
 	"k8s.io/kubernetes/pkg/api/unversioned"
 )
 
+// OverlayMTUAnnotation is set on each node by the SDN to the MTU of its overlay network. The MTU
+// must be the same on every node, or packets between pods on different nodes may be dropped.
+const OverlayMTUAnnotation = "openshift.io/overlay-mtu"
+
 type ClusterNetwork struct {
 	unversioned.TypeMeta
 	kapi.ObjectMeta
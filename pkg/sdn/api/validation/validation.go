@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"fmt"
 	"net"
 
 	"k8s.io/kubernetes/pkg/api/validation"
@@ -97,6 +98,50 @@ func ValidateHostSubnet(hs *sdnapi.HostSubnet) fielderrors.ValidationErrorList {
 	return allErrs
 }
 
+// ValidateHostSubnetPlacement tests that the subnet of a new host subnet has the size the cluster network allocates
+// to each node, lies within the cluster network, and does not overlap the service network or the subnet of another
+// node. clusterNetwork may be nil if the cluster network is not set up yet.
+func ValidateHostSubnetPlacement(hs *sdnapi.HostSubnet, existing []sdnapi.HostSubnet, clusterNetwork *sdnapi.ClusterNetwork) fielderrors.ValidationErrorList {
+	allErrs := fielderrors.ValidationErrorList{}
+	_, cidr, err := net.ParseCIDR(hs.Subnet)
+	if err != nil {
+		return append(allErrs, fielderrors.NewFieldInvalid("subnet", hs.Subnet, err.Error()))
+	}
+
+	if clusterNetwork != nil {
+		if ones, bits := cidr.Mask.Size(); bits-ones != clusterNetwork.HostSubnetLength {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("subnet", hs.Subnet, fmt.Sprintf("must have a prefix length of %d to match the host subnet length of the cluster network", bits-clusterNetwork.HostSubnetLength)))
+		}
+		if _, serviceNetwork, err := net.ParseCIDR(clusterNetwork.ServiceNetwork); err == nil && cidrsOverlap(cidr, serviceNetwork) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("subnet", hs.Subnet, fmt.Sprintf("overlaps the service network %s", clusterNetwork.ServiceNetwork)))
+		}
+		if _, network, err := net.ParseCIDR(clusterNetwork.Network); err == nil && !cidrContains(network, cidr) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("subnet", hs.Subnet, fmt.Sprintf("is not part of the cluster network %s", clusterNetwork.Network)))
+		}
+	}
+	for _, other := range existing {
+		if other.Name == hs.Name {
+			continue
+		}
+		if _, otherCIDR, err := net.ParseCIDR(other.Subnet); err == nil && cidrsOverlap(cidr, otherCIDR) {
+			allErrs = append(allErrs, fielderrors.NewFieldInvalid("subnet", hs.Subnet, fmt.Sprintf("overlaps the subnet %s of %q", other.Subnet, other.Name)))
+		}
+	}
+	return allErrs
+}
+
+// cidrsOverlap returns true if a and b share any address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// cidrContains returns true if every address of inner is part of outer.
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerSize, _ := outer.Mask.Size()
+	innerSize, _ := inner.Mask.Size()
+	return outerSize <= innerSize && outer.Contains(inner.IP)
+}
+
 func ValidateHostSubnetUpdate(obj *sdnapi.HostSubnet, old *sdnapi.HostSubnet) fielderrors.ValidationErrorList {
 	allErrs := fielderrors.ValidationErrorList{}
 	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&obj.ObjectMeta, &old.ObjectMeta).Prefix("metadata")...)
@@ -139,3 +139,36 @@ func TestValidateHostSubnet(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateHostSubnetPlacement(t *testing.T) {
+	clusterNetwork := &api.ClusterNetwork{Network: "10.1.0.0/16", HostSubnetLength: 8, ServiceNetwork: "172.30.0.0/16"}
+	subnet := func(name, cidr string) api.HostSubnet {
+		return api.HostSubnet{ObjectMeta: kapi.ObjectMeta{Name: name}, Subnet: cidr}
+	}
+	existing := []api.HostSubnet{subnet("node1", "10.1.0.0/24"), subnet("node2", "10.1.1.0/24")}
+
+	tests := []struct {
+		name           string
+		subnet         api.HostSubnet
+		clusterNetwork *api.ClusterNetwork
+		expectedErrors int
+	}{
+		{name: "free subnet", subnet: subnet("node3", "10.1.2.0/24"), clusterNetwork: clusterNetwork},
+		{name: "same node", subnet: subnet("node1", "10.1.0.0/24"), clusterNetwork: clusterNetwork},
+		{name: "overlaps another node", subnet: subnet("node3", "10.1.1.0/24"), clusterNetwork: clusterNetwork, expectedErrors: 1},
+		{name: "contains other nodes", subnet: subnet("node3", "10.1.0.0/23"), clusterNetwork: clusterNetwork, expectedErrors: 3},
+		{name: "wrong size", subnet: subnet("node3", "10.1.2.0/25"), clusterNetwork: clusterNetwork, expectedErrors: 1},
+		{name: "overlaps service network", subnet: subnet("node3", "172.30.5.0/24"), clusterNetwork: clusterNetwork, expectedErrors: 2},
+		{name: "outside cluster network", subnet: subnet("node3", "10.2.0.0/24"), clusterNetwork: clusterNetwork, expectedErrors: 1},
+		{name: "invalid", subnet: subnet("node3", "10.1.2.0"), clusterNetwork: clusterNetwork, expectedErrors: 1},
+		{name: "no cluster network", subnet: subnet("node3", "172.30.5.0/25")},
+		{name: "no cluster network, overlaps another node", subnet: subnet("node3", "10.1.1.0/25"), expectedErrors: 1},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateHostSubnetPlacement(&tc.subnet, existing, tc.clusterNetwork)
+		if len(errs) != tc.expectedErrors {
+			t.Errorf("Test case %s expected %d error(s), got %d. %v", tc.name, tc.expectedErrors, len(errs), errs)
+		}
+	}
+}
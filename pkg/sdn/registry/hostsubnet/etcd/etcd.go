@@ -2,6 +2,8 @@ package etcd
 
 import (
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -10,18 +12,25 @@ import (
 	"k8s.io/kubernetes/pkg/storage"
 
 	"github.com/openshift/origin/pkg/sdn/api"
+	"github.com/openshift/origin/pkg/sdn/api/validation"
 	"github.com/openshift/origin/pkg/sdn/registry/hostsubnet"
 )
 
 // rest implements a RESTStorage for sdn against etcd
 type REST struct {
 	etcdgeneric.Etcd
+	clusterNetworks rest.Getter
 }
 
-const etcdPrefix = "/registry/sdnsubnets"
+const (
+	etcdPrefix = "/registry/sdnsubnets"
+	// clusterNetworkName is the name of the cluster network the master sets up
+	clusterNetworkName = "default"
+)
 
-// NewREST returns a RESTStorage object that will work against subnets
-func NewREST(s storage.Interface) *REST {
+// NewREST returns a RESTStorage object that will work against subnets. New subnets are checked against the
+// cluster network read from clusterNetworks and against the subnets already assigned.
+func NewREST(s storage.Interface, clusterNetworks rest.Getter) *REST {
 	store := &etcdgeneric.Etcd{
 		NewFunc:     func() runtime.Object { return &api.HostSubnet{} },
 		NewListFunc: func() runtime.Object { return &api.HostSubnetList{} },
@@ -45,5 +54,32 @@ func NewREST(s storage.Interface) *REST {
 	store.CreateStrategy = hostsubnet.Strategy
 	store.UpdateStrategy = hostsubnet.Strategy
 
-	return &REST{*store}
+	return &REST{*store, clusterNetworks}
+}
+
+// Create rejects a subnet that does not fit in the cluster network or overlaps the subnet of another node before
+// storing it
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	hs, ok := obj.(*api.HostSubnet)
+	if !ok {
+		return nil, kerrors.NewBadRequest("not a HostSubnet")
+	}
+
+	var clusterNetwork *api.ClusterNetwork
+	network, err := r.clusterNetworks.Get(kapi.NewContext(), clusterNetworkName)
+	switch {
+	case err == nil:
+		clusterNetwork = network.(*api.ClusterNetwork)
+	case !kerrors.IsNotFound(err):
+		return nil, err
+	}
+	list, err := r.Etcd.List(ctx, labels.Everything(), fields.Everything())
+	if err != nil {
+		return nil, err
+	}
+	if errs := validation.ValidateHostSubnetPlacement(hs, list.(*api.HostSubnetList).Items, clusterNetwork); len(errs) > 0 {
+		return nil, kerrors.NewInvalid("HostSubnet", hs.Name, errs)
+	}
+
+	return r.Etcd.Create(ctx, hs)
 }
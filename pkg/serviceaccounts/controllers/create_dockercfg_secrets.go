@@ -304,6 +304,9 @@ func (e *DockercfgController) createDockerPullSecret(serviceAccount *api.Service
 		return nil, err
 	}
 	dockercfgSecret.Data[api.DockerConfigKey] = dockercfgContent
+	if ca := tokenSecret.Data[api.ServiceAccountRootCAKey]; len(ca) > 0 {
+		dockercfgSecret.Data[api.ServiceAccountRootCAKey] = ca
+	}
 
 	// Save the secret
 	createdSecret, err := e.client.Secrets(tokenSecret.Namespace).Create(dockercfgSecret)
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// tokenSecretIndex indexes dockercfg secrets by the namespace and name of the token secret they were generated from
+const tokenSecretIndex = "tokenSecret"
+
+// DockercfgTokenRotatedControllerOptions contains options for the DockercfgTokenRotatedController
+type DockercfgTokenRotatedControllerOptions struct {
+	// Resync is the time.Duration at which to fully re-list secrets.
+	// If zero, re-list will be delayed as long as possible
+	Resync time.Duration
+}
+
+// NewDockercfgTokenRotatedController returns a new *DockercfgTokenRotatedController.
+func NewDockercfgTokenRotatedController(cl client.Interface, options DockercfgTokenRotatedControllerOptions) *DockercfgTokenRotatedController {
+	e := &DockercfgTokenRotatedController{
+		client: cl,
+	}
+
+	tokenSelector := fields.OneTermEqualSelector(client.SecretType, string(api.SecretTypeServiceAccountToken))
+	e.tokenSecrets, e.tokenSecretController = framework.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return e.client.Secrets(api.NamespaceAll).List(labels.Everything(), tokenSelector)
+			},
+			WatchFunc: func(rv string) (watch.Interface, error) {
+				return e.client.Secrets(api.NamespaceAll).Watch(labels.Everything(), tokenSelector, rv)
+			},
+		},
+		&api.Secret{},
+		options.Resync,
+		framework.ResourceEventHandlerFuncs{
+			AddFunc:    e.tokenSecretUpdated,
+			UpdateFunc: func(oldObj, newObj interface{}) { e.tokenSecretUpdated(newObj) },
+		},
+	)
+
+	dockercfgSelector := fields.OneTermEqualSelector(client.SecretType, string(api.SecretTypeDockercfg))
+	e.dockercfgSecrets, e.dockercfgSecretController = framework.NewIndexerInformer(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return e.client.Secrets(api.NamespaceAll).List(labels.Everything(), dockercfgSelector)
+			},
+			WatchFunc: func(rv string) (watch.Interface, error) {
+				return e.client.Secrets(api.NamespaceAll).Watch(labels.Everything(), dockercfgSelector, rv)
+			},
+		},
+		&api.Secret{},
+		options.Resync,
+		framework.ResourceEventHandlerFuncs{
+			AddFunc:    e.dockercfgSecretUpdated,
+			UpdateFunc: func(oldObj, newObj interface{}) { e.dockercfgSecretUpdated(newObj) },
+		},
+		cache.Indexers{tokenSecretIndex: indexByTokenSecret},
+	)
+
+	return e
+}
+
+// The DockercfgTokenRotatedController keeps the generated dockercfg secrets up to date with the token secrets they
+// were generated from. When a token is regenerated, for instance after the key signing service account tokens was
+// rotated, or the CA bundle of a token secret changes, the dockercfg secrets are updated in place. Service accounts
+// and pods keep referencing the same secrets, so image pulls never see them disappear, and a secret is only
+// updated once the new token has been populated.
+type DockercfgTokenRotatedController struct {
+	stopChan chan struct{}
+
+	client client.Interface
+
+	tokenSecrets              cache.Store
+	tokenSecretController     *framework.Controller
+	dockercfgSecrets          cache.Indexer
+	dockercfgSecretController *framework.Controller
+}
+
+// Runs controller loops and returns immediately
+func (e *DockercfgTokenRotatedController) Run() {
+	if e.stopChan == nil {
+		e.stopChan = make(chan struct{})
+		go e.tokenSecretController.Run(e.stopChan)
+		go e.dockercfgSecretController.Run(e.stopChan)
+	}
+}
+
+// Stop gracefully shuts down this controller
+func (e *DockercfgTokenRotatedController) Stop() {
+	if e.stopChan != nil {
+		close(e.stopChan)
+		e.stopChan = nil
+	}
+}
+
+// tokenSecretUpdated reacts to a token secret being created or updated by updating the dockercfg secrets generated from it
+func (e *DockercfgTokenRotatedController) tokenSecretUpdated(obj interface{}) {
+	tokenSecret := obj.(*api.Secret)
+
+	dockercfgSecrets, err := e.dockercfgSecrets.ByIndex(tokenSecretIndex, tokenSecret.Namespace+"/"+tokenSecret.Name)
+	if err != nil {
+		util.HandleError(err)
+		return
+	}
+	for _, obj := range dockercfgSecrets {
+		if err := e.syncDockercfgSecret(obj.(*api.Secret), tokenSecret); err != nil {
+			util.HandleError(err)
+		}
+	}
+}
+
+// dockercfgSecretUpdated reacts to a dockercfg secret being created or updated by making sure it matches its token secret
+func (e *DockercfgTokenRotatedController) dockercfgSecretUpdated(obj interface{}) {
+	dockercfgSecret := obj.(*api.Secret)
+	tokenSecretName := dockercfgSecret.Annotations[ServiceAccountTokenSecretNameKey]
+	if len(tokenSecretName) == 0 {
+		return
+	}
+
+	tokenSecret, exists, err := e.tokenSecrets.GetByKey(dockercfgSecret.Namespace + "/" + tokenSecretName)
+	if err != nil {
+		util.HandleError(err)
+		return
+	}
+	if !exists {
+		// the dockercfg secret is removed by the DockercfgTokenDeletedController
+		return
+	}
+	if err := e.syncDockercfgSecret(dockercfgSecret, tokenSecret.(*api.Secret)); err != nil {
+		util.HandleError(err)
+	}
+}
+
+// syncDockercfgSecret updates the credentials and CA bundle of dockercfgSecret to the ones of tokenSecret, if they differ
+func (e *DockercfgTokenRotatedController) syncDockercfgSecret(dockercfgSecret, tokenSecret *api.Secret) error {
+	token := string(tokenSecret.Data[api.ServiceAccountTokenKey])
+	if len(token) == 0 {
+		// the token is being regenerated, keep the previous credentials until it is populated
+		return nil
+	}
+
+	dockercfg := credentialprovider.DockerConfig{}
+	if err := json.Unmarshal(dockercfgSecret.Data[api.DockerConfigKey], &dockercfg); err != nil {
+		return fmt.Errorf("unable to read dockercfg secret %s/%s: %v", dockercfgSecret.Namespace, dockercfgSecret.Name, err)
+	}
+	changed := false
+	for url, entry := range dockercfg {
+		if entry.Password != token {
+			entry.Password = token
+			dockercfg[url] = entry
+			changed = true
+		}
+	}
+	ca := tokenSecret.Data[api.ServiceAccountRootCAKey]
+	if !bytes.Equal(dockercfgSecret.Data[api.ServiceAccountRootCAKey], ca) {
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	// the secret belongs to the cache, update a copy of it
+	obj, err := api.Scheme.DeepCopy(dockercfgSecret)
+	if err != nil {
+		return err
+	}
+	updatedSecret := obj.(*api.Secret)
+	dockercfgContent, err := json.Marshal(dockercfg)
+	if err != nil {
+		return err
+	}
+	updatedSecret.Data[api.DockerConfigKey] = dockercfgContent
+	if len(ca) > 0 {
+		updatedSecret.Data[api.ServiceAccountRootCAKey] = ca
+	} else {
+		delete(updatedSecret.Data, api.ServiceAccountRootCAKey)
+	}
+
+	glog.V(4).Infof("Updating dockercfg secret %s/%s from token secret %s", dockercfgSecret.Namespace, dockercfgSecret.Name, tokenSecret.Name)
+	if _, err := e.client.Secrets(updatedSecret.Namespace).Update(updatedSecret); err != nil && !kapierrors.IsConflict(err) && !kapierrors.IsNotFound(err) {
+		// a conflict means the secret was updated since it was cached, we'll be notified of it and try again
+		return err
+	}
+	return nil
+}
+
+// indexByTokenSecret indexes dockercfg secrets by the namespace and name of the token secret they were generated from
+func indexByTokenSecret(obj interface{}) ([]string, error) {
+	secret, ok := obj.(*api.Secret)
+	if !ok {
+		return nil, fmt.Errorf("expected a secret, got %T", obj)
+	}
+	tokenSecretName := secret.Annotations[ServiceAccountTokenSecretNameKey]
+	if len(tokenSecretName) == 0 {
+		return []string{}, nil
+	}
+	return []string{secret.Namespace + "/" + tokenSecretName}, nil
+}
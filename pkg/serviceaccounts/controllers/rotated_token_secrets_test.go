@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// rotatedServiceAccountTokenSecret returns the "token-secret-1" token secret after its token was regenerated
+func rotatedServiceAccountTokenSecret() *api.Secret {
+	secret := serviceAccountTokenSecret()
+	secret.Data[api.ServiceAccountTokenKey] = []byte("DEF")
+	secret.Data[api.ServiceAccountRootCAKey] = []byte("CA")
+	return secret
+}
+
+// rotatedDockercfgSecret returns the dockercfg secret updated with the credentials of rotatedServiceAccountTokenSecret
+func rotatedDockercfgSecret() *api.Secret {
+	secret := createdDockercfgSecret()
+	secret.Data[api.DockerConfigKey] = []byte(`{"docker-registry.default.svc.cluster.local":{"username":"serviceaccount","password":"DEF","email":"serviceaccount@example.org","auth":"c2VydmljZWFjY291bnQ6REVG"}}`)
+	secret.Data[api.ServiceAccountRootCAKey] = []byte("CA")
+	return secret
+}
+
+func TestTokenRotation(t *testing.T) {
+	testcases := map[string]struct {
+		DockercfgSecrets []*api.Secret
+		TokenSecrets     []*api.Secret
+
+		UpdatedTokenSecret     *api.Secret
+		UpdatedDockercfgSecret *api.Secret
+
+		ExpectedActions []testclient.Action
+	}{
+		"unchanged token secret": {
+			DockercfgSecrets:   []*api.Secret{createdDockercfgSecret()},
+			UpdatedTokenSecret: serviceAccountTokenSecret(),
+		},
+		"token secret being regenerated": {
+			DockercfgSecrets:   []*api.Secret{createdDockercfgSecret()},
+			UpdatedTokenSecret: serviceAccountTokenSecretWithoutTokenData(),
+		},
+		"rotated token secret": {
+			DockercfgSecrets:   []*api.Secret{createdDockercfgSecret()},
+			UpdatedTokenSecret: rotatedServiceAccountTokenSecret(),
+
+			ExpectedActions: []testclient.Action{
+				testclient.NewUpdateAction("secrets", "default", rotatedDockercfgSecret()),
+			},
+		},
+		"rotated token secret without dockercfg secret": {
+			UpdatedTokenSecret: rotatedServiceAccountTokenSecret(),
+		},
+		"dockercfg secret created before its token secret rotated": {
+			TokenSecrets:           []*api.Secret{rotatedServiceAccountTokenSecret()},
+			UpdatedDockercfgSecret: createdDockercfgSecret(),
+
+			ExpectedActions: []testclient.Action{
+				testclient.NewUpdateAction("secrets", "default", rotatedDockercfgSecret()),
+			},
+		},
+		"up to date dockercfg secret": {
+			TokenSecrets:           []*api.Secret{rotatedServiceAccountTokenSecret()},
+			UpdatedDockercfgSecret: rotatedDockercfgSecret(),
+		},
+		"dockercfg secret without token secret": {
+			UpdatedDockercfgSecret: createdDockercfgSecret(),
+		},
+	}
+
+	for k, tc := range testcases {
+		objects := []runtime.Object{}
+		for _, secret := range tc.DockercfgSecrets {
+			objects = append(objects, secret)
+		}
+		client := testclient.NewSimpleFake(objects...)
+
+		controller := NewDockercfgTokenRotatedController(client, DockercfgTokenRotatedControllerOptions{})
+		for _, secret := range tc.DockercfgSecrets {
+			controller.dockercfgSecrets.Add(secret)
+		}
+		for _, secret := range tc.TokenSecrets {
+			controller.tokenSecrets.Add(secret)
+		}
+
+		if tc.UpdatedTokenSecret != nil {
+			controller.tokenSecretUpdated(tc.UpdatedTokenSecret)
+		}
+		if tc.UpdatedDockercfgSecret != nil {
+			controller.dockercfgSecretUpdated(tc.UpdatedDockercfgSecret)
+		}
+
+		for i, action := range client.Actions() {
+			if len(tc.ExpectedActions) < i+1 {
+				t.Errorf("%s: %d unexpected actions: %+v", k, len(client.Actions())-len(tc.ExpectedActions), client.Actions()[i:])
+				break
+			}
+
+			expectedAction := tc.ExpectedActions[i]
+			if !reflect.DeepEqual(expectedAction, action) {
+				t.Errorf("%s: Expected %v, got %v", k, expectedAction, action)
+				continue
+			}
+		}
+
+		if len(tc.ExpectedActions) > len(client.Actions()) {
+			t.Errorf("%s: %d additional expected actions:%+v", k, len(tc.ExpectedActions)-len(client.Actions()), tc.ExpectedActions[len(client.Actions()):])
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/15 2 * * *",
+		"0 0-6/2,12 1,15 * 1-5",
+		"30 4 * 1 7",
+		" @daily ",
+		"@weekly",
+	}
+	for _, spec := range valid {
+		if _, err := Parse(spec); err != nil {
+			t.Errorf("%q: unexpected error: %v", spec, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+		"@reboot",
+	}
+	for _, spec := range invalid {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// a Thursday
+	from := time.Date(2015, time.December, 31, 22, 47, 12, 0, time.UTC)
+
+	testCases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2015, time.December, 31, 22, 48, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2015, time.December, 31, 23, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2016, time.January, 1, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2015, time.December, 31, 23, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// Sunday, as 0 and as 7
+		{"0 0 * * 0", time.Date(2016, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2016, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		// either day field matches when both are restricted
+		{"0 0 15 * 6", time.Date(2016, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2016, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.spec, err)
+			continue
+		}
+		if next := s.Next(from); !next.Equal(tc.expected) {
+			t.Errorf("%q: expected %v, got %v", tc.spec, tc.expected, next)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	// a Friday
+	from := time.Date(2016, time.January, 1, 1, 47, 12, 0, time.UTC)
+
+	testCases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2016, time.January, 1, 1, 47, 0, 0, time.UTC)},
+		{"47 1 * * *", time.Date(2016, time.January, 1, 1, 47, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2016, time.January, 1, 1, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2015, time.December, 31, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2016, time.January, 1, 1, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 23 * 11 *", time.Date(2015, time.November, 30, 23, 30, 0, 0, time.UTC)},
+		// Sunday, as 0 and as 7
+		{"0 0 * * 0", time.Date(2015, time.December, 27, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2015, time.December, 27, 0, 0, 0, 0, time.UTC)},
+		// either day field matches when both are restricted
+		{"0 0 15 * 2", time.Date(2015, time.December, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2012, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.spec, err)
+			continue
+		}
+		if prev := s.Prev(from); !prev.Equal(tc.expected) {
+			t.Errorf("%q: expected %v, got %v", tc.spec, tc.expected, prev)
+		}
+	}
+}
+
+func TestLast(t *testing.T) {
+	s, err := Parse("0 */6 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := time.Date(2016, time.January, 1, 5, 0, 0, 0, time.UTC)
+
+	if last := s.Last(since, time.Date(2016, time.January, 1, 5, 59, 0, 0, time.UTC)); !last.IsZero() {
+		t.Errorf("expected no time, got %v", last)
+	}
+	expected := time.Date(2016, time.January, 2, 0, 0, 0, 0, time.UTC)
+	if last := s.Last(since, time.Date(2016, time.January, 2, 3, 0, 0, 0, time.UTC)); !last.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, last)
+	}
+	if last := s.Last(since, expected); !last.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, last)
+	}
+}
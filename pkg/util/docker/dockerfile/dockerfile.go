@@ -76,15 +76,32 @@ func LastBaseImage(node *parser.Node) string {
 
 // baseImages takes a Dockerfile root node and returns a list of all base images
 // declared in the Dockerfile. Each base image is the argument of a FROM
-// instruction.
+// instruction, without the name of the build stage.
 func baseImages(node *parser.Node) []string {
 	var images []string
 	for _, pos := range FindAll(node, command.From) {
-		images = append(images, nextValues(node.Children[pos])...)
+		if image, _ := BaseImageAndStage(node.Children[pos]); len(image) > 0 {
+			images = append(images, image)
+		}
 	}
 	return images
 }
 
+// BaseImageAndStage takes a FROM instruction node and returns the base image
+// and the name of the build stage it declares, as in "FROM image AS stage".
+// The name is empty for stages that are not named.
+func BaseImageAndStage(node *parser.Node) (image, stage string) {
+	fields := strings.Fields(strings.Join(nextValues(node), " "))
+	switch {
+	case len(fields) == 0:
+		return "", ""
+	case len(fields) == 3 && strings.EqualFold(fields[1], "AS"):
+		return fields[0], fields[2]
+	default:
+		return fields[0], ""
+	}
+}
+
 // LastExposedPorts takes a Dockerfile root node and returns a list of ports
 // exposed in the last image built by the Dockerfile, i.e., only the EXPOSE
 // instructions after the last FROM instruction are considered.
@@ -346,6 +346,12 @@ COPY . /boot
 FROM centos:7`,
 			want: []string{"scratch", "centos:7"},
 		},
+		"multiple stages": {
+			in: `FROM golang:1.5 AS builder
+RUN go build
+FROM centos:7 as runtime`,
+			want: []string{"golang:1.5", "centos:7"},
+		},
 	}
 	for name, tc := range testCases {
 		node, err := parser.Parse(strings.NewReader(tc.in))
@@ -360,6 +366,45 @@ FROM centos:7`,
 	}
 }
 
+// TestBaseImageAndStage tests calling BaseImageAndStage with named and
+// unnamed build stages.
+func TestBaseImageAndStage(t *testing.T) {
+	testCases := map[string]struct {
+		in        string
+		wantImage string
+		wantStage string
+	}{
+		"FROM missing argument": {
+			in: `FROM`,
+		},
+		"unnamed stage": {
+			in:        `FROM centos:7`,
+			wantImage: "centos:7",
+		},
+		"named stage": {
+			in:        `FROM golang:1.5 AS builder`,
+			wantImage: "golang:1.5",
+			wantStage: "builder",
+		},
+		"lower case AS": {
+			in:        `FROM golang:1.5 as builder`,
+			wantImage: "golang:1.5",
+			wantStage: "builder",
+		},
+	}
+	for name, tc := range testCases {
+		node, err := parser.Parse(strings.NewReader(tc.in))
+		if err != nil {
+			t.Errorf("%s: parse error: %v", name, err)
+			continue
+		}
+		image, stage := BaseImageAndStage(node.Children[0])
+		if image != tc.wantImage || stage != tc.wantStage {
+			t.Errorf("BaseImageAndStage: %s: got %q, %q; want %q, %q", name, image, stage, tc.wantImage, tc.wantStage)
+		}
+	}
+}
+
 // TestBaseImagesNilNode tests calling baseImages with a nil *parser.Node.
 func TestBaseImagesNilNode(t *testing.T) {
 	if got := baseImages(nil); got != nil {
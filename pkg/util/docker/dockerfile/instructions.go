@@ -26,6 +26,12 @@ func From(image string) (string, error) {
 	return unquotedArgsInstruction(command.From, image)
 }
 
+// FromStage builds a FROM Dockerfile instruction referring the base image image
+// that names the build stage stage.
+func FromStage(image, stage string) (string, error) {
+	return unquotedArgsInstruction(command.From, image, "AS", stage)
+}
+
 // Label builds a LABEL Dockerfile instruction from the mapping m. Keys and
 // values are serialized as JSON strings to ensure compatibility with the
 // Dockerfile parser.
@@ -115,3 +115,14 @@ func TestFrom(t *testing.T) {
 		}
 	}
 }
+
+// TestFromStage tests calling FromStage.
+func TestFromStage(t *testing.T) {
+	got, err := FromStage("golang:1.5", "builder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `FROM golang:1.5 AS builder`; got != want {
+		t.Errorf("FromStage() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package iptables
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	kutil "k8s.io/kubernetes/pkg/util"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+	kiptables "k8s.io/kubernetes/pkg/util/iptables"
+)
+
+var (
+	rulesRepaired = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_node_iptables_rules_repaired",
+			Help: "Counter of the iptables rules found missing after they were inserted, and inserted again, broken out by the component owning them.",
+		},
+		[]string{"owner"},
+	)
+	syncErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "openshift_node_iptables_sync_errors",
+			Help: "Counter of the checks of the iptables rules of the node which failed.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(rulesRepaired)
+	prometheus.MustRegister(syncErrors)
+}
+
+// The tables and chains which are not defined by the iptables package of Kubernetes
+const (
+	TableFilter  kiptables.Table = "filter"
+	ChainInput   kiptables.Chain = "INPUT"
+	ChainForward kiptables.Chain = "FORWARD"
+)
+
+// Rule is an iptables rule kept in place by a Manager
+type Rule struct {
+	Table kiptables.Table
+	Chain kiptables.Chain
+	Args  []string
+	// Position is where the rule is inserted in its chain, at the start if empty
+	Position kiptables.RulePosition
+}
+
+func (r Rule) position() kiptables.RulePosition {
+	if len(r.Position) == 0 {
+		return kiptables.Prepend
+	}
+	return r.Position
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("-t %s %s %s %s", r.Table, r.position(), r.Chain, strings.Join(r.Args, " "))
+}
+
+// Manager keeps the iptables rules of the components of the node in place. The rules are checked periodically and
+// whenever firewalld reloads, and the rules removed by other firewall tooling are inserted again at their position
+// in their chain.
+type Manager struct {
+	iptables   kiptables.Interface
+	syncPeriod time.Duration
+
+	lock  sync.Mutex
+	rules map[string][]Rule
+	// synced records the owners whose rules were all inserted once, so that missing rules are drift
+	synced map[string]bool
+	// repairFuncs are called after the rules of their owner were repaired or could not be inserted
+	repairFuncs map[string]func()
+}
+
+// NewManager returns a manager checking its rules every syncPeriod
+func NewManager(iptables kiptables.Interface, syncPeriod time.Duration) *Manager {
+	return &Manager{
+		iptables:    iptables,
+		syncPeriod:  syncPeriod,
+		rules:       map[string][]Rule{},
+		synced:      map[string]bool{},
+		repairFuncs: map[string]func(){},
+	}
+}
+
+// SetRules replaces the rules kept in place for owner. The rules which are no longer listed are left in place.
+func (m *Manager) SetRules(owner string, rules []Rule) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.rules[owner] = rules
+	delete(m.synced, owner)
+}
+
+// SetRepairFunc sets a function called after the rules of owner were repaired or could not be inserted, so that
+// owner can restore the rules of the chains its rules jump to.
+func (m *Manager) SetRepairFunc(owner string, repair func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.repairFuncs[owner] = repair
+}
+
+// Sync inserts the missing rules and returns how many rules were missing after they had been inserted
+func (m *Manager) Sync() (int, error) {
+	repaired, repairFuncs, err := m.syncRules()
+	for _, repair := range repairFuncs {
+		repair()
+	}
+	return repaired, err
+}
+
+// syncRules inserts the missing rules and returns how many rules were missing after they had been inserted, along
+// with the repair functions of their owners
+func (m *Manager) syncRules() (int, []func(), error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	owners := []string{}
+	for owner := range m.rules {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	repaired := 0
+	repairFuncs := []func(){}
+	errs := []error{}
+	for _, owner := range owners {
+		ownerRepaired := 0
+		ownerErrs := []error{}
+		chains := map[string]bool{}
+		for _, rule := range m.rules[owner] {
+			if chain := string(rule.Table) + "/" + string(rule.Chain); !chains[chain] {
+				if _, err := m.iptables.EnsureChain(rule.Table, rule.Chain); err != nil {
+					ownerErrs = append(ownerErrs, fmt.Errorf("unable to create the chain %s of the %s table: %v", rule.Chain, rule.Table, err))
+					continue
+				}
+				chains[chain] = true
+			}
+
+			exists, err := m.iptables.EnsureRule(rule.position(), rule.Table, rule.Chain, rule.Args...)
+			if err != nil {
+				ownerErrs = append(ownerErrs, fmt.Errorf("unable to insert the iptables rule %q: %v", rule, err))
+				continue
+			}
+			if !exists && m.synced[owner] {
+				glog.Warningf("The iptables rule %q of %s was removed, inserted it again", rule, owner)
+				rulesRepaired.WithLabelValues(owner).Inc()
+				ownerRepaired++
+			}
+		}
+		if len(ownerErrs) == 0 {
+			m.synced[owner] = true
+		}
+		if repair, ok := m.repairFuncs[owner]; ok && (ownerRepaired > 0 || len(ownerErrs) > 0) {
+			repairFuncs = append(repairFuncs, repair)
+		}
+		repaired += ownerRepaired
+		errs = append(errs, ownerErrs...)
+	}
+	return repaired, repairFuncs, kerrors.NewAggregate(errs)
+}
+
+// Run checks the rules every sync period and whenever firewalld reloads, until stopCh is closed
+func (m *Manager) Run(stopCh <-chan struct{}) {
+	m.iptables.AddReloadFunc(m.sync)
+	kutil.Until(m.sync, m.syncPeriod, stopCh)
+}
+
+func (m *Manager) sync() {
+	if _, err := m.Sync(); err != nil {
+		syncErrors.Inc()
+		glog.Errorf("Unable to check the iptables rules of the node: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package iptables
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kiptables "k8s.io/kubernetes/pkg/util/iptables"
+)
+
+// fakeIPTables holds the rules of each chain, keyed by table/chain
+type fakeIPTables struct {
+	kiptables.Interface
+	rules map[string][]string
+	// missingChains fail the rules inserted in them
+	missingChains map[string]bool
+}
+
+func (f *fakeIPTables) EnsureChain(table kiptables.Table, chain kiptables.Chain) (bool, error) {
+	key := string(table) + "/" + string(chain)
+	_, exists := f.rules[key]
+	if !exists {
+		f.rules[key] = []string{}
+	}
+	return exists, nil
+}
+
+func (f *fakeIPTables) EnsureRule(position kiptables.RulePosition, table kiptables.Table, chain kiptables.Chain, args ...string) (bool, error) {
+	key := string(table) + "/" + string(chain)
+	if f.missingChains[key] {
+		return false, fmt.Errorf("no chain %s", key)
+	}
+	rule := strings.Join(args, " ")
+	for _, existing := range f.rules[key] {
+		if existing == rule {
+			return true, nil
+		}
+	}
+	if position == kiptables.Append {
+		f.rules[key] = append(f.rules[key], rule)
+	} else {
+		f.rules[key] = append([]string{rule}, f.rules[key]...)
+	}
+	return false, nil
+}
+
+func TestSync(t *testing.T) {
+	ipt := &fakeIPTables{rules: map[string][]string{"filter/INPUT": {"-j REJECT"}}}
+	m := NewManager(ipt, time.Minute)
+	m.SetRules("sdn", []Rule{
+		{Table: TableFilter, Chain: ChainInput, Args: []string{"-i", "tun0", "-j", "ACCEPT"}},
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainPostrouting, Args: []string{"-s", "10.1.0.0/16", "-j", "MASQUERADE"}},
+	})
+
+	// inserting the rules the first time is not drift
+	if repaired, err := m.Sync(); err != nil || repaired != 0 {
+		t.Fatalf("expected the rules to be inserted, got %d repaired, %v", repaired, err)
+	}
+	if rules := ipt.rules["filter/INPUT"]; len(rules) != 2 || rules[0] != "-i tun0 -j ACCEPT" {
+		t.Errorf("expected the rule to be inserted first, got %v", rules)
+	}
+	if repaired, err := m.Sync(); err != nil || repaired != 0 {
+		t.Errorf("expected the rules to be in place, got %d repaired, %v", repaired, err)
+	}
+
+	// a firewall reload removing the rules
+	ipt.rules["filter/INPUT"] = []string{"-j REJECT"}
+	delete(ipt.rules, "nat/POSTROUTING")
+	if repaired, err := m.Sync(); err != nil || repaired != 2 {
+		t.Errorf("expected the removed rules to be repaired, got %d repaired, %v", repaired, err)
+	}
+	if rules := ipt.rules["nat/POSTROUTING"]; len(rules) != 1 {
+		t.Errorf("expected the rule to be inserted again, got %v", rules)
+	}
+
+	// new rules are not drift either
+	m.SetRules("sdn", []Rule{
+		{Table: TableFilter, Chain: ChainForward, Args: []string{"-d", "10.1.0.0/16", "-j", "ACCEPT"}},
+	})
+	if repaired, err := m.Sync(); err != nil || repaired != 0 {
+		t.Errorf("expected the new rules to be inserted, got %d repaired, %v", repaired, err)
+	}
+}
+
+func TestSyncRepairFunc(t *testing.T) {
+	ipt := &fakeIPTables{rules: map[string][]string{"nat/PREROUTING": {"-j DOCKER"}}, missingChains: map[string]bool{}}
+	m := NewManager(ipt, time.Minute)
+	repairs := 0
+	m.SetRepairFunc("kube-proxy", func() { repairs++ })
+	m.SetRules("kube-proxy", []Rule{
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainPrerouting, Args: []string{"-j", "KUBE-PORTALS-CONTAINER"}},
+		{Table: kiptables.TableNAT, Chain: kiptables.ChainPrerouting, Args: []string{"-j", "KUBE-NODEPORT-CONTAINER"}, Position: kiptables.Append},
+	})
+
+	if _, err := m.Sync(); err != nil || repairs != 0 {
+		t.Fatalf("expected the rules to be inserted without repair, got %d repairs, %v", repairs, err)
+	}
+	if rules := ipt.rules["nat/PREROUTING"]; len(rules) != 3 || rules[0] != "-j KUBE-PORTALS-CONTAINER" || rules[2] != "-j KUBE-NODEPORT-CONTAINER" {
+		t.Errorf("expected the rules to be inserted at their position, got %v", rules)
+	}
+
+	ipt.rules["nat/PREROUTING"] = []string{"-j DOCKER"}
+	if repaired, err := m.Sync(); err != nil || repaired != 2 || repairs != 1 {
+		t.Errorf("expected the owner to repair its rules, got %d repaired, %d repairs, %v", repaired, repairs, err)
+	}
+
+	ipt.missingChains["nat/PREROUTING"] = true
+	if _, err := m.Sync(); err == nil || repairs != 2 {
+		t.Errorf("expected the owner to repair the rules which could not be inserted, got %d repairs, %v", repairs, err)
+	}
+}
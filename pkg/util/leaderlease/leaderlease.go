@@ -2,6 +2,7 @@ package leaderlease
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	etcdclient "github.com/coreos/go-etcd/etcd"
@@ -21,6 +22,14 @@ type Leaser interface {
 	AcquireAndHold(chan struct{})
 	// Release returns any active leases
 	Release()
+	// Token returns the fencing token of the lease while it is held, or 0. Every new holder of the lease gets a
+	// greater token than the previous ones.
+	Token() uint64
+	// Check returns an error unless the lease is still held with the current token and cannot have expired
+	// since it was last renewed. It does not contact the server, so callers can check the lease before every
+	// write. The check and the write are not atomic, so a write can still be made shortly after the lease was
+	// taken by another holder if the clocks of the holders drift.
+	Check() error
 }
 
 // Etcd takes and holds a leader lease until it can no longer confirm it owns
@@ -41,6 +50,12 @@ type Etcd struct {
 	maxRetries int
 	// the shortest time between attempts to renew the lease
 	minimumRetryInterval time.Duration
+
+	lock sync.Mutex
+	// token is the index the lease key was created at while the lease is held, 0 otherwise
+	token uint64
+	// expires is the earliest time the lease can expire in etcd, counted from the last acquire or renewal request
+	expires time.Time
 }
 
 // NewEtcd creates a Lease in etcd, storing value at key with expiration ttl
@@ -77,6 +92,8 @@ func (e *Etcd) AcquireAndHold(notify chan struct{}) {
 		// notify
 		notify <- struct{}{}
 		defer close(notify)
+		// forget the token before telling the caller the lease is lost
+		defer e.setHeld(0, time.Time{})
 
 		// hold the lease
 		if err := e.tryHold(ttl, index); err != nil {
@@ -93,11 +110,13 @@ func (e *Etcd) AcquireAndHold(notify chan struct{}) {
 func (e *Etcd) tryAcquire() (ok bool, ttl uint64, nextIndex uint64, err error) {
 	ttl = e.ttl
 
+	start := time.Now()
 	resp, err := e.client.Create(e.key, e.value, ttl)
 	if err == nil {
 		// we hold the lease
 		index := resp.EtcdIndex
 		glog.V(4).Infof("Lease %s acquired at %d, ttl %d seconds", e.key, index, e.ttl)
+		e.setHeld(resp.Node.CreatedIndex, start.Add(time.Duration(ttl)*time.Second))
 		return true, ttl, index + 1, nil
 	}
 
@@ -127,11 +146,13 @@ func (e *Etcd) tryAcquire() (ok bool, ttl uint64, nextIndex uint64, err error) {
 	}
 
 	glog.V(4).Infof("Lease %s already held, expires in %d seconds", e.key, ttl)
+	e.setHeld(latest.Node.CreatedIndex, start.Add(time.Duration(ttl)*time.Second))
 	return true, ttl, nextIndex, nil
 }
 
 // Release tries to delete the leader lock.
 func (e *Etcd) Release() {
+	e.setHeld(0, time.Time{})
 	for i := 0; i < e.maxRetries; i++ {
 		_, err := e.client.CompareAndDelete(e.key, e.value, 0)
 		if err == nil {
@@ -180,12 +201,20 @@ func (e *Etcd) tryHold(ttl, index uint64) error {
 	for {
 		select {
 		case <-time.After(after):
-			err := wait.Poll(interval, last, func() (bool, error) {
+			// give up one retry interval before the lease can expire in etcd, so that this process stops acting as
+			// the holder before another one can acquire the lease
+			remaining := e.expiration().Sub(time.Now()) - interval
+			if remaining <= 0 {
+				return fmt.Errorf("unable to renew lease %s at %d before it expires", e.key, index)
+			}
+			err := wait.Poll(interval, remaining, func() (bool, error) {
 				glog.V(4).Infof("Renewing lease %s at %d", e.key, index-1)
+				start := time.Now()
 				resp, err := e.client.CompareAndSwap(e.key, e.value, e.ttl, e.value, index-1)
 				switch {
 				case err == nil:
 					index = eventIndexFor(resp)
+					e.setHeld(e.Token(), start.Add(time.Duration(e.ttl)*time.Second))
 					return true, nil
 				case storage.IsEtcdTestFailed(err):
 					return false, fmt.Errorf("another client has taken the lease %s: %v", e.key, err)
@@ -215,6 +244,42 @@ func (e *Etcd) tryHold(ttl, index uint64) error {
 	}
 }
 
+// Token implements Leaser
+func (e *Etcd) Token() uint64 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.token
+}
+
+// Check implements Leaser. The token is cleared as soon as the watch of the lease observes another holder, and
+// the expiration is moved forward by every renewal, so the lease is not read from etcd.
+func (e *Etcd) Check() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.token == 0 {
+		return fmt.Errorf("lease %s is not held", e.key)
+	}
+	if time.Now().After(e.expires) {
+		return fmt.Errorf("lease %s may have expired", e.key)
+	}
+	return nil
+}
+
+// setHeld records the token of the held lease and when it can expire
+func (e *Etcd) setHeld(token uint64, expires time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.token = token
+	e.expires = expires
+}
+
+// expiration returns the earliest time the held lease can expire in etcd
+func (e *Etcd) expiration() time.Time {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.expires
+}
+
 // waitForExpiration waits until the lease value changes in etcd through deletion, expiration,
 // or explicit change. Held indicates whether the current process owns the lease. The appropriate
 // next watch index is returned.
@@ -0,0 +1,22 @@
+package proc
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/golang/glog"
+)
+
+// StartReloader starts a goroutine calling reload every time the process receives SIGHUP.
+func StartReloader(reload func()) {
+	glog.V(4).Infof("Launching reloader")
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGHUP)
+		for sig := range sigs {
+			glog.V(2).Infof("Signal received: %v, reloading", sig)
+			reload()
+		}
+	}()
+}
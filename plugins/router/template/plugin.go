@@ -34,11 +34,17 @@ type TemplatePluginConfig struct {
 	TemplatePath       string
 	ReloadScriptPath   string
 	DefaultCertificate string
-	StatsPort          int
-	StatsUsername      string
-	StatsPassword      string
-	IncludeUDP         bool
-	PeerService        *ktypes.NamespacedName
+	// DefaultCertificatePath is the file the default certificate is read from instead, re-read by Reload
+	DefaultCertificatePath string
+	StatsPort              int
+	StatsUsername          string
+	StatsPassword          string
+	IncludeUDP             bool
+	PeerService            *ktypes.NamespacedName
+	// ForwardedHeaderPolicy is how the router sets the X-Forwarded-For header of requests: append, replace or set
+	ForwardedHeaderPolicy string
+	// TrustedProxies are the addresses and CIDRs of the proxies whose X-Forwarded-For header identifies clients
+	TrustedProxies []string
 }
 
 // routerInterface controls the interaction of the plugin with the underlying router implementation
@@ -68,6 +74,9 @@ type routerInterface interface {
 	FilterNamespaces(namespaces sets.String)
 	// Commit refreshes the backend and persists the router state.
 	Commit() error
+	// ReloadDefaultCertificate re-reads the default certificate from its file and refreshes the backend
+	// if it changed.
+	ReloadDefaultCertificate() error
 }
 
 // NewTemplatePlugin creates a new TemplatePlugin.
@@ -96,19 +105,27 @@ func NewTemplatePlugin(cfg TemplatePluginConfig) (*TemplatePlugin, error) {
 	}
 
 	templateRouterCfg := templateRouterCfg{
-		dir:                cfg.WorkingDir,
-		templates:          templates,
-		reloadScriptPath:   cfg.ReloadScriptPath,
-		defaultCertificate: cfg.DefaultCertificate,
-		statsUser:          cfg.StatsUsername,
-		statsPassword:      cfg.StatsPassword,
-		statsPort:          cfg.StatsPort,
-		peerEndpointsKey:   peerKey,
+		dir:                    cfg.WorkingDir,
+		templates:              templates,
+		reloadScriptPath:       cfg.ReloadScriptPath,
+		defaultCertificate:     cfg.DefaultCertificate,
+		defaultCertificateFile: cfg.DefaultCertificatePath,
+		statsUser:              cfg.StatsUsername,
+		statsPassword:          cfg.StatsPassword,
+		statsPort:              cfg.StatsPort,
+		peerEndpointsKey:       peerKey,
+		forwardedHeaderPolicy:  cfg.ForwardedHeaderPolicy,
+		trustedProxies:         cfg.TrustedProxies,
 	}
 	router, err := newTemplateRouter(templateRouterCfg)
 	return newDefaultTemplatePlugin(router, cfg.IncludeUDP), err
 }
 
+// Reload reloads the certificates the router reads from files.
+func (p *TemplatePlugin) Reload() error {
+	return p.Router.ReloadDefaultCertificate()
+}
+
 // HandleEndpoints processes watch events on the Endpoints resource.
 func (p *TemplatePlugin) HandleEndpoints(eventType watch.EventType, endpoints *kapi.Endpoints) error {
 	key := endpointsKey(endpoints)
@@ -126,6 +126,11 @@ func (r *TestRouter) Commit() error {
 	return r.ErrorOnCommit
 }
 
+// ReloadDefaultCertificate is a no-op
+func (r *TestRouter) ReloadDefaultCertificate() error {
+	return nil
+}
+
 // TestHandleEndpoints test endpoint watch events
 func TestHandleEndpoints(t *testing.T) {
 	testCases := []struct {
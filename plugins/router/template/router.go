@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -24,6 +27,29 @@ const (
 	ProtocolTLS   = "tls"
 )
 
+// The annotations of a route configuring the health checks of its endpoints
+const (
+	// HealthCheckIntervalAnnotation is the time between two checks of an endpoint, e.g. 2s. Shorter intervals than
+	// MinHealthCheckInterval are raised to it.
+	HealthCheckIntervalAnnotation = "router.openshift.io/health-check-interval"
+	// HealthCheckPathAnnotation is the path requested to check the endpoints of an HTTP route, e.g. /healthz
+	HealthCheckPathAnnotation = "router.openshift.io/health-check-path"
+	// HealthCheckExpectedStatusAnnotation is the HTTP status code returned by healthy endpoints, e.g. 200
+	HealthCheckExpectedStatusAnnotation = "router.openshift.io/health-check-expected-status"
+	// IPWhitelistAnnotation is the space separated list of addresses and CIDRs of the clients allowed to reach a
+	// route, e.g. 192.168.1.10 10.0.0.0/8
+	IPWhitelistAnnotation = "router.openshift.io/ip-whitelist"
+)
+
+const (
+	// ForwardedHeaderPolicyAppend appends the address of the client to the X-Forwarded-For header of requests
+	ForwardedHeaderPolicyAppend = "append"
+	// ForwardedHeaderPolicyReplace replaces the X-Forwarded-For header of requests with the address of the client
+	ForwardedHeaderPolicyReplace = "replace"
+	// ForwardedHeaderPolicySet sets the X-Forwarded-For header of requests without one to the address of the client
+	ForwardedHeaderPolicySet = "set"
+)
+
 const (
 	routeFile       = "routes.json"
 	certDir         = "certs"
@@ -49,6 +75,8 @@ type templateRouter struct {
 	// usually a wildcard certificate for a cloud domain such as *.mypaas.com to allow applications to create app.mypaas.com
 	// as secure routes without having to provide their own certificates
 	defaultCertificate string
+	// defaultCertificateFile is the file the default certificate is read from, re-read when the router reloads
+	defaultCertificateFile string
 	// if the default certificate is populated then this will be filled in so it can be passed to the templates
 	defaultCertificatePath string
 	// peerService provides a namespace/name to check against when receiving endpoint events in order
@@ -64,19 +92,26 @@ type templateRouter struct {
 	statsPassword string
 	// if the router can expose statistics it should expose them with this port
 	statsPort int
+	// forwardedHeaderPolicy is how the router sets the X-Forwarded-For header of requests
+	forwardedHeaderPolicy string
+	// trustedProxies are the addresses and CIDRs of the proxies whose X-Forwarded-For header is trusted
+	trustedProxies []string
 }
 
 // templateRouterCfg holds all configuration items required to initialize the template router
 type templateRouterCfg struct {
-	dir                string
-	templates          map[string]*template.Template
-	reloadScriptPath   string
-	defaultCertificate string
-	statsUser          string
-	statsPassword      string
-	statsPort          int
-	peerEndpointsKey   string
-	includeUDP         bool
+	dir                    string
+	templates              map[string]*template.Template
+	reloadScriptPath       string
+	defaultCertificate     string
+	defaultCertificateFile string
+	statsUser              string
+	statsPassword          string
+	statsPort              int
+	peerEndpointsKey       string
+	includeUDP             bool
+	forwardedHeaderPolicy  string
+	trustedProxies         []string
 }
 
 // templateConfig is a subset of the templateRouter information that should be passed to the template for generating
@@ -96,6 +131,10 @@ type templateData struct {
 	StatsPassword string
 	//port to expose stats with (if the template supports it)
 	StatsPort int
+	// how to set the X-Forwarded-For header of requests: append, replace or set
+	ForwardedHeaderPolicy string
+	// addresses and CIDRs of the proxies whose X-Forwarded-For header identifies clients
+	TrustedProxies []string
 }
 
 func newTemplateRouter(cfg templateRouterCfg) (*templateRouter, error) {
@@ -124,13 +163,19 @@ func newTemplateRouter(cfg templateRouterCfg) (*templateRouter, error) {
 		state:                  make(map[string]ServiceUnit),
 		certManager:            certManager,
 		defaultCertificate:     cfg.defaultCertificate,
+		defaultCertificateFile: cfg.defaultCertificateFile,
 		defaultCertificatePath: "",
 		statsUser:              cfg.statsUser,
 		statsPassword:          cfg.statsPassword,
 		statsPort:              cfg.statsPort,
+		forwardedHeaderPolicy:  cfg.forwardedHeaderPolicy,
+		trustedProxies:         cfg.trustedProxies,
 		peerEndpointsKey:       cfg.peerEndpointsKey,
 		peerEndpoints:          []Endpoint{},
 	}
+	if err := router.readDefaultCert(); err != nil {
+		return nil, err
+	}
 	if err := router.writeDefaultCert(); err != nil {
 		return nil, err
 	}
@@ -159,7 +204,42 @@ func endpointsForAlias(alias ServiceAliasConfig, svc ServiceUnit) []Endpoint {
 	return endpoints
 }
 
-// writeDefaultCert is called a single time during init to write out the default certificate
+// readDefaultCert reads the default certificate from its file, if any
+func (r *templateRouter) readDefaultCert() error {
+	if len(r.defaultCertificateFile) == 0 {
+		return nil
+	}
+	data, err := ioutil.ReadFile(r.defaultCertificateFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the default certificate: %v", err)
+	}
+	r.defaultCertificate = string(data)
+	return nil
+}
+
+// ReloadDefaultCertificate re-reads the default certificate from its file, and refreshes the backend if it changed.
+func (r *templateRouter) ReloadDefaultCertificate() error {
+	previous := r.defaultCertificate
+	if err := r.readDefaultCert(); err != nil {
+		return err
+	}
+	if len(r.defaultCertificate) == 0 {
+		// the file may be caught in the middle of an update, keep serving the previous certificate
+		r.defaultCertificate = previous
+		return fmt.Errorf("the default certificate file %s is empty", r.defaultCertificateFile)
+	}
+	if r.defaultCertificate == previous {
+		glog.V(4).Infof("The default certificate is unchanged")
+		return nil
+	}
+	if err := r.writeDefaultCert(); err != nil {
+		return err
+	}
+	return r.Commit()
+}
+
+// writeDefaultCert is called during init, and when the default certificate is reloaded, to write out the default
+// certificate
 func (r *templateRouter) writeDefaultCert() error {
 	if len(r.defaultCertificate) == 0 {
 		return nil
@@ -234,13 +314,15 @@ func (r *templateRouter) writeConfig() error {
 		}
 
 		data := templateData{
-			WorkingDir:         r.dir,
-			State:              r.state,
-			DefaultCertificate: r.defaultCertificatePath,
-			PeerEndpoints:      r.peerEndpoints,
-			StatsUser:          r.statsUser,
-			StatsPassword:      r.statsPassword,
-			StatsPort:          r.statsPort,
+			WorkingDir:            r.dir,
+			State:                 r.state,
+			DefaultCertificate:    r.defaultCertificatePath,
+			PeerEndpoints:         r.peerEndpoints,
+			StatsUser:             r.statsUser,
+			StatsPassword:         r.statsPassword,
+			StatsPort:             r.statsPort,
+			ForwardedHeaderPolicy: r.forwardedHeaderPolicy,
+			TrustedProxies:        r.trustedProxies,
 		}
 		if err := template.Execute(file, data); err != nil {
 			file.Close()
@@ -364,6 +446,9 @@ func (r *templateRouter) AddRoute(id string, route *routeapi.Route, host string)
 		config.PreferPort = route.Spec.Port.TargetPort.String()
 	}
 
+	config.HealthCheck = healthCheckForRoute(route)
+	config.IPWhitelist, config.IPWhitelistInvalid = ipWhitelistForRoute(route)
+
 	tls := route.Spec.TLS
 	if tls != nil && len(tls.Termination) > 0 {
 		config.TLSTermination = tls.Termination
@@ -550,3 +635,74 @@ func generateCACertKey(config *ServiceAliasConfig) string {
 func generateDestCertKey(config *ServiceAliasConfig) string {
 	return config.Host + destCertPostfix
 }
+
+// MinHealthCheckInterval is the shortest time between two checks of an endpoint a route can ask for, so that a route
+// cannot make the router flood endpoints with health checks.
+const MinHealthCheckInterval = time.Second
+
+// healthCheckForRoute returns the health check configured by the annotations of route. Invalid annotations are
+// ignored so that a typo does not take the route down.
+func healthCheckForRoute(route *routeapi.Route) HealthCheck {
+	check := HealthCheck{}
+	if value, ok := route.Annotations[HealthCheckIntervalAnnotation]; ok {
+		if interval, err := time.ParseDuration(value); err != nil || interval <= 0 {
+			glog.Warningf("Ignoring the invalid %s annotation %q of route %s/%s", HealthCheckIntervalAnnotation, value, route.Namespace, route.Name)
+		} else {
+			if interval < MinHealthCheckInterval {
+				glog.Warningf("Raising the %s annotation %q of route %s/%s to the minimum of %v", HealthCheckIntervalAnnotation, value, route.Namespace, route.Name, MinHealthCheckInterval)
+				interval = MinHealthCheckInterval
+			}
+			check.Interval = int64(interval / time.Millisecond)
+		}
+	}
+
+	if tls := route.Spec.TLS; tls != nil && tls.Termination == routeapi.TLSTerminationPassthrough {
+		// the router cannot make HTTP requests to endpoints terminating TLS
+		return check
+	}
+	if value, ok := route.Annotations[HealthCheckPathAnnotation]; ok {
+		if !strings.HasPrefix(value, "/") || strings.ContainsAny(value, " #\t\r\n") {
+			glog.Warningf("Ignoring the invalid %s annotation %q of route %s/%s", HealthCheckPathAnnotation, value, route.Namespace, route.Name)
+		} else {
+			check.Path = value
+		}
+	}
+	if value, ok := route.Annotations[HealthCheckExpectedStatusAnnotation]; ok && len(check.Path) > 0 {
+		if status, err := strconv.Atoi(value); err != nil || status < 100 || status > 599 {
+			glog.Warningf("Ignoring the invalid %s annotation %q of route %s/%s", HealthCheckExpectedStatusAnnotation, value, route.Namespace, route.Name)
+		} else {
+			check.ExpectedStatus = status
+		}
+	}
+	return check
+}
+
+// ipWhitelistForRoute returns the addresses and CIDRs of the IP whitelist annotation of route. Invalid entries are
+// ignored. If the annotation is set without any valid entry, invalid is true and no client may reach the route.
+func ipWhitelistForRoute(route *routeapi.Route) (whitelist []string, invalid bool) {
+	value, ok := route.Annotations[IPWhitelistAnnotation]
+	if !ok {
+		return nil, false
+	}
+	for _, entry := range strings.Fields(value) {
+		if !IsIPOrCIDR(entry) {
+			glog.Warningf("Ignoring the invalid entry %q of the %s annotation of route %s/%s", entry, IPWhitelistAnnotation, route.Namespace, route.Name)
+			continue
+		}
+		whitelist = append(whitelist, entry)
+	}
+	if len(whitelist) == 0 {
+		glog.Errorf("The %s annotation of route %s/%s has no valid entry, refusing every client", IPWhitelistAnnotation, route.Namespace, route.Name)
+		return nil, true
+	}
+	return whitelist, false
+}
+
+// IsIPOrCIDR returns true if value is an IP address or a CIDR
+func IsIPOrCIDR(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
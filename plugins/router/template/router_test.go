@@ -2,6 +2,10 @@ package templaterouter
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	routeapi "github.com/openshift/origin/pkg/route/api"
@@ -709,3 +713,183 @@ func TestAddRouteEdgeTerminationInsecurePolicy(t *testing.T) {
 		}
 	}
 }
+
+// TestHealthCheckForRoute ensures the health check annotations of routes are parsed and invalid ones are ignored
+func TestHealthCheckForRoute(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Annotations map[string]string
+		Termination routeapi.TLSTerminationType
+		Expected    HealthCheck
+	}{
+		{
+			Name:     "no annotations",
+			Expected: HealthCheck{},
+		},
+		{
+			Name: "http check",
+			Annotations: map[string]string{
+				HealthCheckIntervalAnnotation:       "2s",
+				HealthCheckPathAnnotation:           "/healthz",
+				HealthCheckExpectedStatusAnnotation: "204",
+			},
+			Termination: routeapi.TLSTerminationEdge,
+			Expected:    HealthCheck{Interval: 2000, Path: "/healthz", ExpectedStatus: 204},
+		},
+		{
+			Name: "passthrough",
+			Annotations: map[string]string{
+				HealthCheckIntervalAnnotation: "5s",
+				HealthCheckPathAnnotation:     "/healthz",
+			},
+			Termination: routeapi.TLSTerminationPassthrough,
+			Expected:    HealthCheck{Interval: 5000},
+		},
+		{
+			Name: "interval below the minimum",
+			Annotations: map[string]string{
+				HealthCheckIntervalAnnotation: "10ms",
+			},
+			Expected: HealthCheck{Interval: 1000},
+		},
+		{
+			Name: "negative interval",
+			Annotations: map[string]string{
+				HealthCheckIntervalAnnotation: "-1s",
+			},
+			Expected: HealthCheck{},
+		},
+		{
+			Name: "status without path",
+			Annotations: map[string]string{
+				HealthCheckExpectedStatusAnnotation: "200",
+			},
+			Expected: HealthCheck{},
+		},
+		{
+			Name: "invalid annotations",
+			Annotations: map[string]string{
+				HealthCheckIntervalAnnotation:       "often",
+				HealthCheckPathAnnotation:           "/health check",
+				HealthCheckExpectedStatusAnnotation: "200",
+			},
+			Expected: HealthCheck{},
+		},
+		{
+			Name: "invalid status",
+			Annotations: map[string]string{
+				HealthCheckPathAnnotation:           "/healthz",
+				HealthCheckExpectedStatusAnnotation: "OK",
+			},
+			Expected: HealthCheck{Path: "/healthz"},
+		},
+	}
+
+	for _, tc := range testCases {
+		route := &routeapi.Route{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "foo", Name: "bar", Annotations: tc.Annotations},
+			Spec:       routeapi.RouteSpec{Host: "host"},
+		}
+		if len(tc.Termination) > 0 {
+			route.Spec.TLS = &routeapi.TLSConfig{Termination: tc.Termination}
+		}
+		if check := healthCheckForRoute(route); check != tc.Expected {
+			t.Errorf("%s: expected %#v, got %#v", tc.Name, tc.Expected, check)
+		}
+	}
+}
+
+func TestIPWhitelistForRoute(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Annotations     map[string]string
+		Expected        []string
+		ExpectedInvalid bool
+	}{
+		{
+			Name:     "no annotation",
+			Expected: nil,
+		},
+		{
+			Name:        "addresses and CIDRs",
+			Annotations: map[string]string{IPWhitelistAnnotation: "192.168.1.10  10.0.0.0/8 2001:db8::/32"},
+			Expected:    []string{"192.168.1.10", "10.0.0.0/8", "2001:db8::/32"},
+		},
+		{
+			Name:        "invalid entries",
+			Annotations: map[string]string{IPWhitelistAnnotation: "10.0.0.0/33 example.com 10.1.2.3"},
+			Expected:    []string{"10.1.2.3"},
+		},
+		{
+			Name:            "no valid entry",
+			Annotations:     map[string]string{IPWhitelistAnnotation: "10.0.0.0/33 example.com"},
+			ExpectedInvalid: true,
+		},
+		{
+			Name:            "empty annotation",
+			Annotations:     map[string]string{IPWhitelistAnnotation: " "},
+			ExpectedInvalid: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		route := &routeapi.Route{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "foo", Name: "bar", Annotations: tc.Annotations},
+		}
+		whitelist, invalid := ipWhitelistForRoute(route)
+		if !reflect.DeepEqual(whitelist, tc.Expected) {
+			t.Errorf("%s: expected whitelist %v, got %v", tc.Name, tc.Expected, whitelist)
+		}
+		if invalid != tc.ExpectedInvalid {
+			t.Errorf("%s: expected invalid %t, got %t", tc.Name, tc.ExpectedInvalid, invalid)
+		}
+	}
+}
+
+// TestReloadDefaultCertificate tests that the default certificate is written again only when its file changes
+func TestReloadDefaultCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "router")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "default.pem")
+
+	router := newFakeTemplateRouter()
+	certWriter := &fakeCertWriter{}
+	router.certManager, _ = newSimpleCertificateManager(newFakeCertificateManagerConfig(), certWriter)
+	router.dir = dir
+	router.reloadScriptPath = "true"
+	router.defaultCertificate = "cert"
+	router.defaultCertificateFile = certFile
+
+	testCases := []struct {
+		name          string
+		contents      string
+		expectedCert  string
+		expectedWrite bool
+		expectedError bool
+	}{
+		{name: "unchanged", contents: "cert", expectedCert: "cert"},
+		{name: "rotated", contents: "rotated cert", expectedCert: "rotated cert", expectedWrite: true},
+		{name: "emptied", contents: "", expectedCert: "rotated cert", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		certWriter.clear()
+		if err := ioutil.WriteFile(certFile, []byte(tc.contents), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err := router.ReloadDefaultCertificate()
+		if tc.expectedError != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", tc.name, tc.expectedError, err)
+		}
+		if router.defaultCertificate != tc.expectedCert {
+			t.Errorf("%s: expected the default certificate %q, got %q", tc.name, tc.expectedCert, router.defaultCertificate)
+		}
+		if written := len(certWriter.addedCerts) > 0; written != tc.expectedWrite {
+			t.Errorf("%s: expected the default certificate to be written %t, got %v", tc.name, tc.expectedWrite, certWriter.addedCerts)
+		}
+	}
+}
@@ -37,6 +37,25 @@ type ServiceAliasConfig struct {
 	// insecure connections to an edge-terminated route:
 	//   none (or disable), allow or redirect
 	InsecureEdgeTerminationPolicy routeapi.InsecureEdgeTerminationPolicyType
+	// HealthCheck configures the checks of the endpoints of the route
+	HealthCheck HealthCheck
+	// IPWhitelist is the list of addresses and CIDRs of the clients allowed to reach the route, or empty to allow
+	// every client
+	IPWhitelist []string
+	// IPWhitelistInvalid is true if the route has an IP whitelist annotation without any valid entry. Every client
+	// is refused rather than allowed.
+	IPWhitelistInvalid bool
+}
+
+// HealthCheck configures how the router checks the endpoints of a route before sending them traffic. The zero
+// value checks that endpoints accept connections at the default interval.
+type HealthCheck struct {
+	// Interval is the time between two checks of an endpoint in milliseconds, or zero for the default interval
+	Interval int64
+	// Path is the path requested to check an HTTP endpoint. If empty, endpoints are only checked for connections.
+	Path string
+	// ExpectedStatus is the HTTP status code of a healthy endpoint, or zero to accept any 2xx or 3xx status
+	ExpectedStatus int
 }
 
 type ServiceAliasConfigStatus string
@@ -101,8 +120,8 @@ type certificateWriter interface {
 	DeleteCertificate(directory, id string) error
 }
 
-//TemplateSafeName provides a name that can be used in the template that does not contain restricted
-//characters like / which is used to concat namespace and name in the service unit key
+// TemplateSafeName provides a name that can be used in the template that does not contain restricted
+// characters like / which is used to concat namespace and name in the service unit key
 func (s ServiceUnit) TemplateSafeName() string {
 	return strings.Replace(s.Name, "/", "-", -1)
 }
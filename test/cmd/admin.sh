@@ -77,6 +77,9 @@ echo "groups: ok"
 os::cmd::expect_success 'oadm policy who-can get pods'
 os::cmd::expect_success 'oadm policy who-can get pods -n default'
 os::cmd::expect_success 'oadm policy who-can get pods --all-namespaces'
+os::cmd::expect_success_and_text 'oadm policy who-can get pods frontend -n default' 'Name:      frontend'
+os::cmd::expect_success_and_text 'oadm policy who-can get pods -n default' 'Service accounts:'
+os::cmd::expect_failure 'oadm policy who-can get /healthz frontend'
 
 os::cmd::expect_success 'oadm policy add-role-to-group cluster-admin system:unauthenticated'
 os::cmd::expect_success 'oadm policy add-role-to-user cluster-admin system:no-user'
@@ -111,6 +114,17 @@ os::cmd::expect_success 'oadm policy remove-scc-from-group privileged fake-group
 os::cmd::expect_success_and_not_text 'oc get scc/privileged -o yaml' 'fake-group'
 echo "admin-scc: ok"
 
+os::cmd::expect_success 'oadm policy restrict-build-strategy custom'
+os::cmd::expect_success_and_not_text 'oc get clusterrolebinding/system:build-strategy-custom-binding -o yaml' 'system:authenticated'
+os::cmd::expect_success 'oadm policy allow-build-strategy custom --groups=fake-group'
+os::cmd::expect_success_and_text 'oc get rolebinding/system:build-strategy-custom -o yaml' 'fake-group'
+os::cmd::expect_success 'oadm policy restrict-build-strategy custom --groups=fake-group'
+os::cmd::expect_success_and_not_text 'oc get rolebinding/system:build-strategy-custom -o yaml' 'fake-group'
+os::cmd::expect_success 'oadm policy allow-build-strategy custom'
+os::cmd::expect_success_and_text 'oc get clusterrolebinding/system:build-strategy-custom-binding -o yaml' 'system:authenticated'
+os::cmd::expect_failure_and_text 'oadm policy allow-build-strategy jenkins' 'unknown build strategy'
+echo "admin-build-strategy: ok"
+
 os::cmd::expect_success 'oc delete clusterrole/cluster-status --cascade=false'
 os::cmd::expect_failure 'oc get clusterrole/cluster-status'
 os::cmd::expect_success 'oadm policy reconcile-cluster-roles'
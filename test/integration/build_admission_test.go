@@ -3,6 +3,7 @@
 package integration
 
 import (
+	"io/ioutil"
 	"strings"
 	"testing"
 
@@ -128,6 +129,39 @@ func TestPolicyBasedRestrictionOfBuildConfigCreateAndInstantiateByStrategy(t *te
 	}
 }
 
+func TestPolicyBasedRestrictionOfBuildStrategyByProject(t *testing.T) {
+	clusterAdminClient, projectAdminClient, projectEditorClient := setupBuildStrategyTest(t)
+
+	// only allow custom builds to joe in the project
+	restrictBuildStrategy(t, clusterAdminClient, "custom")
+	allowJoe := &policy.BuildStrategyOptions{
+		RoleModificationOptions: policy.RoleModificationOptions{
+			RoleName:            bootstrappolicy.BuildStrategyCustomRoleName,
+			RoleBindingAccessor: policy.NewLocalRoleBindingAccessor(testutil.Namespace(), clusterAdminClient),
+			Users:               []string{"joe"},
+		},
+		Strategy:  "custom",
+		Namespace: testutil.Namespace(),
+		Out:       ioutil.Discard,
+	}
+	if err := allowJoe.Allow(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := testutil.WaitForPolicyUpdate(projectAdminClient, testutil.Namespace(), "create", authorizationapi.CustomBuildResource, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := createBuild(t, projectAdminClient.Builds(testutil.Namespace()), "custom"); !kapierror.IsForbidden(err) {
+		t.Errorf("expected forbidden for custom builds of the project admin: got %v", err)
+	}
+	if _, err := createBuild(t, projectAdminClient.Builds(testutil.Namespace()), "docker"); err != nil {
+		t.Errorf("unexpected error for docker builds of the project admin: %v", err)
+	}
+	if _, err := createBuild(t, projectEditorClient.Builds(testutil.Namespace()), "custom"); err != nil {
+		t.Errorf("unexpected error for custom builds of joe: %v", err)
+	}
+}
+
 func buildStrategyTypes() []string {
 	return []string{"source", "docker", "custom"}
 }
@@ -193,31 +227,31 @@ func setupBuildStrategyTest(t *testing.T) (clusterAdminClient, projectAdminClien
 }
 
 func removeBuildStrategyRoleResources(t *testing.T, clusterAdminClient, projectAdminClient, projectEditorClient *client.Client) {
-	// remove resources from role so that certain build strategies are forbidden
-	removeBuildStrategyPrivileges(t, clusterAdminClient.ClusterRoles(), bootstrappolicy.EditRoleName)
+	// remove the cluster wide access to every build strategy so that all of them are forbidden
+	for _, strategy := range buildStrategyTypes() {
+		restrictBuildStrategy(t, clusterAdminClient, strategy)
+	}
 	if err := testutil.WaitForPolicyUpdate(projectEditorClient, testutil.Namespace(), "create", authorizationapi.DockerBuildResource, false); err != nil {
 		t.Error(err)
 	}
-
-	removeBuildStrategyPrivileges(t, clusterAdminClient.ClusterRoles(), bootstrappolicy.AdminRoleName)
 	if err := testutil.WaitForPolicyUpdate(projectAdminClient, testutil.Namespace(), "create", authorizationapi.DockerBuildResource, false); err != nil {
 		t.Error(err)
 	}
 }
 
-func removeBuildStrategyPrivileges(t *testing.T, clusterRoleInterface client.ClusterRoleInterface, roleName string) {
-	role, err := clusterRoleInterface.Get(roleName)
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+func restrictBuildStrategy(t *testing.T, clusterAdminClient *client.Client, strategy string) {
+	options := &policy.BuildStrategyOptions{
+		RoleModificationOptions: policy.RoleModificationOptions{
+			RoleName:            "system:build-strategy-" + strategy,
+			RoleBindingAccessor: policy.NewClusterRoleBindingAccessor(clusterAdminClient),
+			Groups:              []string{bootstrappolicy.AuthenticatedGroup},
+		},
+		Strategy: strategy,
+		Out:      ioutil.Discard,
 	}
-
-	for i := range role.Rules {
-		role.Rules[i].Resources.Delete(authorizationapi.DockerBuildResource, authorizationapi.SourceBuildResource, authorizationapi.CustomBuildResource)
-	}
-	if _, err := clusterRoleInterface.Update(role); err != nil {
+	if err := options.Restrict(); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
-
 }
 
 func strategyForType(strategy string) buildapi.BuildStrategy {
@@ -603,6 +603,11 @@ func runBuildDeleteTest(t *testing.T, clusterAdminClient *client.Client, cluster
 		t.Fatalf("Expected pod %s to be deleted, but pod %s was deleted", expected, pod.Name)
 	}
 
+	// the build is removed once its pod is
+	event = waitForWatchType(t, "build deleted after its pod", buildWatch, watchapi.Deleted)
+	if e, a := watchapi.Deleted, event.Type; e != a {
+		t.Fatalf("expected watch event type %s, got %s", e, a)
+	}
 }
 
 // waitForWatchType tolerates receiving 3 events before failing while watching for a particular event
@@ -150,3 +150,46 @@ func TestLeaderLeaseReacquire(t *testing.T) {
 		t.Error("lease is still open")
 	}
 }
+
+func TestLeaderLeaseToken(t *testing.T) {
+	util.DeleteAllEtcdKeys()
+	client := util.NewEtcdClient()
+	key := "/random/key"
+
+	lease := leaderlease.NewEtcd(client, key, "holder", 10)
+	if err := lease.Check(); err == nil {
+		t.Fatal("expected the check of a lease that is not held to fail")
+	}
+	ch := make(chan struct{})
+	go lease.AcquireAndHold(ch)
+
+	<-ch
+	glog.Infof("Lease acquired")
+	token := lease.Token()
+	if token == 0 {
+		t.Fatal("expected a fencing token while the lease is held")
+	}
+	if err := lease.Check(); err != nil {
+		t.Fatalf("unexpected error checking the held lease: %v", err)
+	}
+
+	// another holder takes the lease and gets a greater token
+	if _, err := client.Delete(key, false); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Create(key, "other", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Node.CreatedIndex <= token {
+		t.Errorf("expected the token of the new holder to be greater than %d, got %d", token, resp.Node.CreatedIndex)
+	}
+	<-ch
+	glog.Infof("Lease lost")
+	if lease.Token() != 0 {
+		t.Errorf("expected no fencing token once the lease is lost, got %d", lease.Token())
+	}
+	if err := lease.Check(); err == nil {
+		t.Error("expected the check of a lease taken by another holder to fail")
+	}
+}